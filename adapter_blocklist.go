@@ -0,0 +1,28 @@
+package wgpu
+
+// IgnoreAdapterBlocklistEnv names the environment variable that disables the
+// built-in adapter blocklist when set to "1". It has the same effect as
+// InstanceDescriptor.IgnoreAdapterBlocklist.
+const IgnoreAdapterBlocklistEnv = "GOGPU_IGNORE_ADAPTER_BLOCKLIST"
+
+// AdapterBlocklistEntry describes a known-broken adapter/driver combination.
+// Adapters matching an entry are still enumerated but become fallback-only:
+// RequestAdapter picks them only when no other GPU or software adapter is
+// available, and Adapter.BlocklistReason reports why.
+//
+// Zero-valued fields match anything. String fields are case-insensitive
+// substring matches.
+type AdapterBlocklistEntry struct {
+	// Backend restricts the entry to one backend. BackendEmpty matches all.
+	Backend Backend
+	// VendorID is the PCI vendor ID. Zero matches all vendors.
+	VendorID uint32
+	// DeviceIDs lists PCI device IDs. Empty matches all devices.
+	DeviceIDs []uint32
+	// NameContains matches AdapterInfo.Name.
+	NameContains string
+	// DriverContains matches AdapterInfo.Driver or AdapterInfo.DriverInfo.
+	DriverContains string
+	// Reason explains why the adapter is blocked.
+	Reason string
+}
@@ -3,6 +3,7 @@
 package wgpu
 
 import (
+	"io"
 	"syscall/js"
 
 	"github.com/gogpu/gputypes"
@@ -11,9 +12,21 @@ import (
 
 // DeviceDescriptor configures device creation.
 type DeviceDescriptor struct {
-	Label            string
+	Label string
+	// RequiredFeatures must all be supported by the adapter; RequestDevice
+	// fails and names the missing features otherwise.
 	RequiredFeatures Features
-	RequiredLimits   Limits
+	// OptionalFeatures are enabled when the adapter supports them and
+	// silently dropped otherwise. Check Device.Features to see which were
+	// granted.
+	OptionalFeatures Features
+	// RequiredLimits are the minimum limits the device must provide. Zero
+	// fields inherit the adapter's limit, so the zero value requests the full
+	// adapter limits. A limit better than the adapter's fails RequestDevice.
+	RequiredLimits Limits
+	// Capture is accepted for API compatibility but ignored: only the
+	// native backends can capture their HAL command stream.
+	Capture io.Writer
 }
 
 // Adapter represents a physical GPU.
@@ -29,12 +42,62 @@ type Adapter struct {
 // Info returns adapter metadata.
 func (a *Adapter) Info() AdapterInfo { return a.info }
 
+// Details returns the adapter's driver version and identifiers. This
+// backend does not report them, so the result is zero.
+func (a *Adapter) Details() AdapterDetails { return AdapterDetails{} }
+
 // Features returns supported features.
 func (a *Adapter) Features() Features { return a.features }
 
 // Limits returns the adapter's resource limits.
 func (a *Adapter) Limits() Limits { return a.limits }
 
+// BlocklistReason always returns ""; the browser applies its own GPU blocklist.
+func (a *Adapter) BlocklistReason() string { return "" }
+
+// SupportedSampleCounts returns []uint32{1, 4}: WebGPU only allows 1 and 4 samples, so this
+// is the WebGPU guarantee for multisample-capable formats.
+func (a *Adapter) SupportedSampleCounts(_ TextureFormat) []uint32 {
+	return []uint32{1, 4}
+}
+
+// GetPreferredDepthStencilFormat returns the WebGPU-guaranteed depth format
+// for req: Depth24Plus or Depth24PlusStencil8 for up to 24 bits of depth,
+// Depth32Float or, with FeatureDepth32FloatStencil8, Depth32FloatStencil8
+// otherwise. Sample counts other than 1 and 4 return TextureFormatUndefined.
+func (a *Adapter) GetPreferredDepthStencilFormat(req DepthStencilRequirements) TextureFormat {
+	return webgpuDepthStencilFormat(req, a.features)
+}
+
+// formatCapabilities returns nil: WebGPU exposes no per-format query.
+func (a *Adapter) formatCapabilities() []FormatCapabilities { return nil }
+
+// HasUnifiedMemory always returns false: the browser does not expose the
+// memory architecture.
+func (a *Adapter) HasUnifiedMemory() bool { return false }
+
+// SupportsTextureCopyReinterpret always returns false: WebGPU only copies
+// between formats that differ at most in their sRGB encoding.
+func (a *Adapter) SupportsTextureCopyReinterpret() bool { return false }
+
+// SubgroupSizes returns GPUAdapterInfo.subgroupMinSize and subgroupMaxSize,
+// or (0, 0) when the browser does not report them.
+func (a *Adapter) SubgroupSizes() (minSize, maxSize uint32) {
+	if a.browser == nil {
+		return 0, 0
+	}
+	return a.browser.SubgroupSizes()
+}
+
+// MaxMultiviewViewCount always returns 0: WebGPU has no multiview.
+func (a *Adapter) MaxMultiviewViewCount() uint32 { return 0 }
+
+// AdoptDevice is not supported by the browser: WebGPU exposes no native devices.
+// It always returns ErrExternalNotSupported.
+func (a *Adapter) AdoptDevice(_ *NativeDevice, _ *DeviceDescriptor) (*Device, error) {
+	return nil, ErrExternalNotSupported
+}
+
 // RequestDevice creates a logical device from this adapter.
 // If desc is nil, default features and limits are used.
 func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
@@ -42,10 +105,14 @@ func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
 		return nil, ErrReleased
 	}
 
+	if err := checkRequiredFeatures(a.features, desc); err != nil {
+		return nil, err
+	}
+
 	// Build JS descriptor from Go types.
 	var jsDesc js.Value
 	if desc != nil {
-		jsDesc = browser.BuildDeviceDescriptor(desc.Label, desc.RequiredFeatures, desc.RequiredLimits)
+		jsDesc = browser.BuildDeviceDescriptor(desc.Label, deviceFeatures(a.features, desc), desc.RequiredLimits)
 	} else {
 		jsDesc = js.Undefined()
 	}
@@ -59,16 +126,14 @@ func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
 	deviceFeatures := browser.ExtractFeatures(bd.Features())
 	deviceLimits := browser.ExtractLimits(bd.Limits())
 
-	queue := &Queue{
-		browser: bd.Queue(),
-	}
-
-	return &Device{
+	device := &Device{
 		browser:  bd,
-		queue:    queue,
+		queue:    &Queue{browser: bd.Queue()},
 		features: deviceFeatures,
 		limits:   deviceLimits,
-	}, nil
+	}
+	device.queue.device = device
+	return device, nil
 }
 
 // SurfaceCapabilities describes what a surface supports on this adapter.
@@ -76,6 +141,13 @@ type SurfaceCapabilities struct {
 	Formats      []TextureFormat
 	PresentModes []PresentMode
 	AlphaModes   []CompositeAlphaMode
+	// CurrentTransform and SupportedTransforms are always identity here.
+	CurrentTransform    SurfaceTransform
+	SupportedTransforms SurfaceTransform
+	// Protected is always false here.
+	Protected bool
+	// Usages is always zero (not reported) here.
+	Usages TextureUsage
 }
 
 // GetSurfaceCapabilities returns the capabilities of a surface for this adapter.
@@ -111,9 +183,11 @@ func (a *Adapter) GetSurfaceCapabilities(surface *Surface) *SurfaceCapabilities
 	}
 
 	return &SurfaceCapabilities{
-		Formats:      formats,
-		PresentModes: []PresentMode{PresentModeFifo},
-		AlphaModes:   []CompositeAlphaMode{gputypes.CompositeAlphaModeOpaque},
+		Formats:             formats,
+		PresentModes:        []PresentMode{PresentModeFifo},
+		AlphaModes:          []CompositeAlphaMode{gputypes.CompositeAlphaModeOpaque},
+		CurrentTransform:    SurfaceTransformIdentity,
+		SupportedTransforms: SurfaceTransformIdentity,
 	}
 }
 
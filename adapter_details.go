@@ -0,0 +1,82 @@
+package wgpu
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// AdapterDetails identifies an adapter and its driver beyond AdapterInfo,
+// which already carries the PCI VendorID and DeviceID. Applications use it to
+// apply driver workarounds by version and to find the same GPU through other
+// APIs (DXGI, CUDA, OpenCL, another Vulkan instance). Fields the backend
+// cannot query are zero.
+type AdapterDetails struct {
+	// DriverVersion is the driver version, decoded from the backend's
+	// vendor-specific encoding.
+	DriverVersion DriverVersion
+
+	// LUID is the locally unique identifier Windows assigns the adapter. The
+	// DX12 backend and Vulkan drivers on Windows report it.
+	LUID [8]byte
+
+	// DeviceUUID is the Vulkan device UUID, also reported for the device by
+	// CUDA and OpenCL. Only the Vulkan backend reports it.
+	DeviceUUID [16]byte
+
+	// MemoryHeaps lists the memory heaps the adapter allocates resources
+	// from. Vulkan reports every heap, DX12 the dedicated video memory and
+	// the shared system memory, and Metal the recommended working set size.
+	MemoryHeaps []MemoryHeap
+}
+
+// AdapterDescription identifies an adapter listed by DescribeAdapters.
+type AdapterDescription struct {
+	Info    AdapterInfo
+	Details AdapterDetails
+}
+
+// MemoryHeap is a pool of memory an adapter allocates resources from.
+type MemoryHeap struct {
+	// Size is the heap size in bytes.
+	Size uint64 `json:"size"`
+	// DeviceLocal reports whether the heap is GPU memory. On unified memory
+	// adapters it is also host memory.
+	DeviceLocal bool `json:"deviceLocal"`
+}
+
+// HasLUID reports whether LUID is known.
+func (d AdapterDetails) HasLUID() bool { return d.LUID != [8]byte{} }
+
+// HasDeviceUUID reports whether DeviceUUID is known.
+func (d AdapterDetails) HasDeviceUUID() bool { return d.DeviceUUID != [16]byte{} }
+
+// DriverVersion is a driver version. Vendors use the fields differently:
+// NVIDIA's 535.104.05 is major 535, minor 104, patch 5, and Windows drivers
+// such as 31.0.15.3623 fill all four. The zero value means unknown.
+type DriverVersion struct {
+	Major, Minor, Patch, Build uint32
+}
+
+// IsZero reports whether the version is unknown.
+func (v DriverVersion) IsZero() bool { return v == DriverVersion{} }
+
+// Compare returns -1, 0 or +1 as v is older than, equal to or newer than o,
+// comparing fields from Major to Build. Only versions of the same vendor's
+// driver on the same platform are meaningful to compare.
+func (v DriverVersion) Compare(o DriverVersion) int {
+	return cmp.Or(
+		cmp.Compare(v.Major, o.Major),
+		cmp.Compare(v.Minor, o.Minor),
+		cmp.Compare(v.Patch, o.Patch),
+		cmp.Compare(v.Build, o.Build),
+	)
+}
+
+// String formats the version as "major.minor.patch", followed by ".build"
+// when Build is set.
+func (v DriverVersion) String() string {
+	if v.Build != 0 {
+		return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Patch, v.Build)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
@@ -0,0 +1,69 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+func TestDriverVersion(t *testing.T) {
+	older := wgpu.DriverVersion{Major: 535, Minor: 104, Patch: 5}
+	newer := wgpu.DriverVersion{Major: 535, Minor: 104, Patch: 5, Build: 1}
+	if older.Compare(newer) != -1 || newer.Compare(older) != 1 || older.Compare(older) != 0 {
+		t.Errorf("Compare is not ordered by field")
+	}
+	if got := older.String(); got != "535.104.5" {
+		t.Errorf("String = %q", got)
+	}
+	if got := newer.String(); got != "535.104.5.1" {
+		t.Errorf("String = %q", got)
+	}
+	if !(wgpu.DriverVersion{}).IsZero() || older.IsZero() {
+		t.Error("IsZero is wrong")
+	}
+}
+
+func TestAdapterCapabilityReport(t *testing.T) {
+	inst, adapter := newAdapter(t)
+	defer inst.Release()
+	defer adapter.Release()
+
+	report := adapter.CapabilityReport(nil)
+	if report.Adapter.Name != adapter.Info().Name || report.Adapter.Backend != adapter.Info().Backend.String() {
+		t.Errorf("Adapter = %+v, want %+v", report.Adapter, adapter.Info())
+	}
+	if report.Limits != adapter.Limits() {
+		t.Error("Limits differ from Adapter.Limits")
+	}
+	if len(report.Features) != adapter.Features().Count() {
+		t.Errorf("Features = %v, want %d names", report.Features, adapter.Features().Count())
+	}
+	if len(report.Formats) != int(gputypes.TextureFormatASTC12x12UnormSrgb) {
+		t.Fatalf("Formats has %d entries, want every format", len(report.Formats))
+	}
+	for _, f := range report.Formats {
+		if len(f.SampleCounts) == 0 || f.SampleCounts[0] != 1 {
+			t.Errorf("%s: SampleCounts = %v, want 1 first", f.Format, f.SampleCounts)
+		}
+	}
+	if report.Surface != nil {
+		t.Error("Surface reported without a surface")
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded wgpu.CapabilityReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, report) {
+		t.Error("report does not survive a JSON round trip")
+	}
+}
@@ -4,16 +4,33 @@ package wgpu
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/core"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/capture"
 )
 
 // DeviceDescriptor configures device creation.
 type DeviceDescriptor struct {
-	Label            string
+	Label string
+	// RequiredFeatures must all be supported by the adapter; RequestDevice
+	// fails and names the missing features otherwise.
 	RequiredFeatures Features
-	RequiredLimits   Limits
+	// OptionalFeatures are enabled when the adapter supports them and
+	// silently dropped otherwise. Check Device.Features to see which were
+	// granted.
+	OptionalFeatures Features
+	// RequiredLimits are the minimum limits the device must provide. Zero
+	// fields inherit the adapter's limit, so the zero value requests the full
+	// adapter limits. A limit better than the adapter's fails RequestDevice.
+	RequiredLimits Limits
+	// Capture, when set, receives a capture of every HAL call the device
+	// makes, for replaying on another backend or machine with
+	// cmd/wgpu-replay. See package hal/capture. Capturing slows the device
+	// down and records all uploaded data; enable it only to reproduce bugs.
+	Capture io.Writer
 }
 
 // Adapter represents a physical GPU.
@@ -30,15 +47,157 @@ type Adapter struct {
 // Info returns adapter metadata.
 func (a *Adapter) Info() AdapterInfo { return a.info }
 
+// Details returns the adapter's driver version, identifiers and memory heaps.
+func (a *Adapter) Details() AdapterDetails {
+	if a.core == nil {
+		return AdapterDetails{}
+	}
+	return adapterDetailsFromHAL(&a.core.Details)
+}
+
+// adapterDetailsFromHAL converts HAL adapter details.
+func adapterDetailsFromHAL(d *hal.AdapterDetails) AdapterDetails {
+	v := d.DriverVersion
+	details := AdapterDetails{
+		DriverVersion: DriverVersion{Major: v[0], Minor: v[1], Patch: v[2], Build: v[3]},
+		LUID:          d.LUID,
+		DeviceUUID:    d.DeviceUUID,
+	}
+	for _, heap := range d.MemoryHeaps {
+		details.MemoryHeaps = append(details.MemoryHeaps, MemoryHeap(heap))
+	}
+	return details
+}
+
 // Features returns supported features.
 func (a *Adapter) Features() Features { return a.features }
 
 // Limits returns the adapter's resource limits.
 func (a *Adapter) Limits() Limits { return a.limits }
 
+// BlocklistReason returns why the adapter matched the adapter blocklist, or
+// "" if it did not. A blocked adapter is only selected when nothing else is
+// available; see InstanceDescriptor.IgnoreAdapterBlocklist.
+func (a *Adapter) BlocklistReason() string {
+	if a.core == nil {
+		return ""
+	}
+	return a.core.BlocklistReason
+}
+
+// SupportedSampleCounts returns the sample counts, in ascending order, that
+// textures and render pipelines using format may use on this adapter. The
+// result always includes 1; formats that cannot be multisampled return only
+// 1. Counts other than 1 and 4 are native extensions beyond WebGPU.
+func (a *Adapter) SupportedSampleCounts(format TextureFormat) []uint32 {
+	mask := a.core.TextureFormatCapabilities(format).SampleCounts | hal.SampleCount1
+	var counts []uint32
+	for n := hal.SampleCount1; n <= hal.SampleCount16; n <<= 1 {
+		if mask&n != 0 {
+			counts = append(counts, n)
+		}
+	}
+	return counts
+}
+
+// GetPreferredDepthStencilFormat returns the depth/stencil format this
+// adapter supports best for req, or TextureFormatUndefined if none meets
+// it. Depth24Plus and Depth24PlusStencil8 are preferred; Depth32Float and
+// Depth32FloatStencil8 stand in where the hardware lacks a 24-bit format.
+// A Depth32FloatStencil8 result needs FeatureDepth32FloatStencil8 in
+// DeviceDescriptor.RequiredFeatures.
+func (a *Adapter) GetPreferredDepthStencilFormat(req DepthStencilRequirements) TextureFormat {
+	if a.core == nil {
+		return TextureFormatUndefined
+	}
+	return a.core.PreferredDepthStencilFormat(core.DepthStencilRequirements{
+		Stencil:      req.Stencil,
+		MinDepthBits: req.MinDepthBits,
+		Usage:        req.Usage,
+		SampleCount:  req.SampleCount,
+	})
+}
+
+// formatCapabilities queries every texture format for CapabilityReport.
+func (a *Adapter) formatCapabilities() []FormatCapabilities {
+	formats := reportedFormats()
+	out := make([]FormatCapabilities, len(formats))
+	for i, format := range formats {
+		flags := a.core.TextureFormatCapabilities(format).Flags
+		out[i] = FormatCapabilities{
+			Format:             format.String(),
+			Sampled:            flags&hal.TextureFormatCapabilitySampled != 0,
+			Storage:            flags&hal.TextureFormatCapabilityStorage != 0,
+			StorageReadWrite:   flags&hal.TextureFormatCapabilityStorageReadWrite != 0,
+			RenderAttachment:   flags&hal.TextureFormatCapabilityRenderAttachment != 0,
+			Blendable:          flags&hal.TextureFormatCapabilityBlendable != 0,
+			Multisample:        flags&hal.TextureFormatCapabilityMultisample != 0,
+			MultisampleResolve: flags&hal.TextureFormatCapabilityMultisampleResolve != 0,
+			SampleCounts:       a.SupportedSampleCounts(format),
+		}
+	}
+	return out
+}
+
+// HasUnifiedMemory reports whether the GPU shares physical memory with the
+// CPU, as integrated, mobile and Apple Silicon GPUs do. On such adapters
+// Queue.WriteBuffer copies straight into host-visible buffers the GPU is not
+// using instead of going through a staging buffer.
+func (a *Adapter) HasUnifiedMemory() bool {
+	if a.core == nil {
+		return false
+	}
+	caps := a.core.Capabilities()
+	return caps != nil && caps.DownlevelCapabilities.Flags&hal.DownlevelFlagsUnifiedMemory != 0
+}
+
+// SupportsTextureCopyReinterpret reports whether CopyTextureToTexture can
+// copy between a block-compressed format and an uncompressed color format
+// whose texel is the size of a compressed block, such as BC7RGBAUnorm and
+// RGBA32Uint. Vulkan and DX12 adapters support it; on others such copies
+// fail validation.
+func (a *Adapter) SupportsTextureCopyReinterpret() bool {
+	if a.core == nil {
+		return false
+	}
+	caps := a.core.Capabilities()
+	return caps != nil && caps.DownlevelCapabilities.Flags&hal.DownlevelFlagsTextureCopyReinterpret != 0
+}
+
+// SubgroupSizes returns the smallest and largest number of invocations a
+// subgroup (Vulkan subgroup, Metal SIMD-group, D3D12 wave) may have on this
+// adapter. Both are 0 unless the adapter supports
+// gputypes.FeatureSubgroupOperations. Shaders read the size they actually
+// run with from the subgroup_size built-in.
+func (a *Adapter) SubgroupSizes() (minSize, maxSize uint32) {
+	if a.core == nil {
+		return 0, 0
+	}
+	caps := a.core.Capabilities()
+	if caps == nil {
+		return 0, 0
+	}
+	return caps.SubgroupMinSize, caps.SubgroupMaxSize
+}
+
+// MaxMultiviewViewCount returns the largest ViewCount a render pass may use
+// on this adapter, or 0 unless it supports FeatureMultiview.
+func (a *Adapter) MaxMultiviewViewCount() uint32 {
+	if a.core == nil {
+		return 0
+	}
+	caps := a.core.Capabilities()
+	if caps == nil {
+		return 0
+	}
+	return caps.MaxMultiviewViewCount
+}
+
 // RequestDevice creates a logical device from this adapter.
 // If desc is nil, default features and limits are used.
 func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
+	defer startSpan("wgpu.Adapter.RequestDevice").End()
+
 	if a == nil || a.released || a.instance == nil || a.instance.isReleased() {
 		return nil, ErrReleased
 	}
@@ -63,33 +222,116 @@ func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
 }
 
 func (a *Adapter) requestDeviceHAL(desc *DeviceDescriptor) (*Device, error) {
-	var features gputypes.Features
-	var limits gputypes.Limits
-	var label string
+	features, limits, err := a.deviceRequirements(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	openDevice, err := a.core.HALAdapter().Open(features, limits)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to open device: %w", err)
+	}
+	return a.newDeviceHAL(openDevice, features, limits, desc), nil
+}
 
+// deviceRequirements resolves the features and limits desc asks of this
+// adapter.
+func (a *Adapter) deviceRequirements(desc *DeviceDescriptor) (Features, Limits, error) {
+	var requiredLimits gputypes.Limits
+	features := deviceFeatures(a.features, desc)
 	if desc != nil {
-		features = desc.RequiredFeatures
-		limits = desc.RequiredLimits
-		label = desc.Label
+		requiredLimits = desc.RequiredLimits
+	}
+
+	if err := checkRequiredFeatures(a.features, desc); err != nil {
+		return 0, Limits{}, err
 	}
 
-	// If no limits specified (nil descriptor or zero-value RequiredLimits),
-	// use the adapter's actual hardware limits. This matches the WebGPU spec:
-	// "Each limit in the returned device will be no worse than the corresponding
-	// limit in adapter.limits." When user doesn't specify limits, device gets
-	// full hardware capabilities (e.g., Intel Iris Xe reports 200 storage buffers,
-	// not the WebGPU minimum of 8).
+	// Unset limits inherit the adapter's actual hardware limits. This matches
+	// the WebGPU spec: "Each limit in the returned device will be no worse
+	// than the corresponding limit in adapter.limits." When the user doesn't
+	// specify limits, the device gets full hardware capabilities (e.g., Intel
+	// Iris Xe reports 200 storage buffers, not the WebGPU minimum of 8).
 	// Matches Rust wgpu which returns adapter limits by default.
-	if limits == (gputypes.Limits{}) {
-		limits = a.limits
+	limits, err := core.ResolveRequiredLimits(a.limits, requiredLimits)
+	if err != nil {
+		return 0, Limits{}, fmt.Errorf("wgpu: %w", err)
 	}
+	return features, limits, nil
+}
 
-	openDevice, err := a.core.HALAdapter().Open(features, limits)
+// AdoptDevice wraps a native device another library created on this
+// adapter's GPU, so gogpu renders with the engine or toolkit that owns the
+// GPU instead of competing with it for a second device. desc is read as by
+// RequestDevice; its features and limits must be ones the native device was
+// created with, as nothing is enabled on it.
+//
+// The returned device does not own the native device or queue. Releasing it
+// frees only what gogpu created, and the owner must keep both alive until
+// then. gogpu submits to the same queue as the owner, so the two must not
+// submit concurrently. Use Device.ImportTexture to render into the owner's
+// textures.
+//
+// Vulkan, Metal and DX12 support adoption. Other backends return an error
+// wrapping ErrExternalNotSupported.
+func (a *Adapter) AdoptDevice(native *NativeDevice, desc *DeviceDescriptor) (*Device, error) {
+	defer startSpan("wgpu.Adapter.AdoptDevice").End()
+
+	if a == nil || a.released || a.instance == nil || a.instance.isReleased() {
+		return nil, ErrReleased
+	}
+	if native == nil {
+		return nil, fmt.Errorf("wgpu: native device is nil")
+	}
+	adopter, ok := a.core.HALAdapter().(hal.DeviceAdopter)
+	if !ok {
+		return nil, fmt.Errorf("wgpu: %s: %w", a.info.Backend, ErrExternalNotSupported)
+	}
+	features, limits, err := a.deviceRequirements(desc)
 	if err != nil {
-		return nil, fmt.Errorf("wgpu: failed to open device: %w", err)
+		return nil, err
+	}
+
+	openDevice, err := adopter.Adopt(&hal.NativeDevice{
+		Device:           native.Device,
+		Queue:            native.Queue,
+		QueueFamilyIndex: native.QueueFamilyIndex,
+		Extensions:       native.Extensions,
+	}, features, limits)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to adopt device: %w", err)
+	}
+	device := a.newDeviceHAL(openDevice, features, limits, desc)
+	if err := a.instance.adoptDevice(device); err != nil {
+		device.Release()
+		return nil, err
+	}
+	return device, nil
+}
+
+// newDeviceHAL builds the public device around an opened or adopted HAL
+// device.
+func (a *Adapter) newDeviceHAL(openDevice hal.OpenDevice, features Features, limits Limits, desc *DeviceDescriptor) *Device {
+	var label string
+	if desc != nil {
+		label = desc.Label
+	}
+
+	if desc != nil && desc.Capture != nil {
+		openDevice = capture.Wrap(openDevice, desc.Capture, capture.Header{
+			Backend:  a.info.Backend,
+			Adapter:  a.info.Name,
+			Features: features,
+			Limits:   limits,
+		})
 	}
 
 	coreDevice := core.NewDevice(openDevice.Device, a.core, features, limits, label)
+	coreDevice.BufferUsageValidation = a.instance != nil && a.instance.core != nil &&
+		a.instance.core.Flags()&gputypes.InstanceFlagsValidation != 0
+	if a.instance != nil && a.instance.core != nil && a.instance.core.SkipValidation() {
+		coreDevice.Validator = core.NewValidator(false)
+	}
 
 	// Single shared encoder pool for both user command encoders (CreateCommandEncoder)
 	// and internal staging encoders (PendingWrites). Matches Rust wgpu-core which uses
@@ -97,9 +339,10 @@ func (a *Adapter) requestDeviceHAL(desc *DeviceDescriptor) (*Device, error) {
 	pool := newEncoderPool(openDevice.Device)
 
 	queue := &Queue{
-		hal:       openDevice.Queue,
-		halDevice: openDevice.Device,
-		pending:   newPendingWrites(openDevice.Device, openDevice.Queue, pool),
+		hal:          openDevice.Queue,
+		halDevice:    openDevice.Device,
+		pending:      newPendingWrites(openDevice.Device, openDevice.Queue, pool),
+		directWrites: a.HasUnifiedMemory(),
 	}
 
 	coreDevice.SetAssociatedQueue(&core.Queue{Label: label + " Queue"})
@@ -110,8 +353,11 @@ func (a *Adapter) requestDeviceHAL(desc *DeviceDescriptor) (*Device, error) {
 		cmdEncoderPool: pool,
 	}
 	queue.device = device
+	if queue.pending != nil {
+		queue.pending.counters = &device.frame
+	}
 
-	return device, nil
+	return device
 }
 
 func (a *Adapter) requestDeviceCore(desc *DeviceDescriptor) (*Device, error) {
@@ -121,20 +367,22 @@ func (a *Adapter) requestDeviceCore(desc *DeviceDescriptor) (*Device, error) {
 			Label:          desc.Label,
 			RequiredLimits: desc.RequiredLimits,
 		}
+		features := deviceFeatures(a.features, desc)
+		for bit := 0; bit < 64; bit++ {
+			if feature := gputypes.Feature(uint64(1) << bit); features.Contains(feature) {
+				gpuDesc.RequiredFeatures = append(gpuDesc.RequiredFeatures, feature)
+			}
+		}
 	}
 
-	_, err := core.RequestDevice(a.id, gpuDesc)
+	deviceID, err := core.RequestDevice(a.id, gpuDesc)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to create device: %w", err)
 	}
 
-	coreDevice := &core.Device{
-		Label:    "",
-		Features: 0,
-		Limits:   gputypes.DefaultLimits(),
-	}
-	if desc != nil {
-		coreDevice.Label = desc.Label
+	coreDevice, err := core.GetDevice(deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create device: %w", err)
 	}
 
 	return &Device{core: coreDevice}, nil
@@ -150,6 +398,24 @@ type SurfaceCapabilities struct {
 
 	// AlphaModes lists the supported composite alpha modes.
 	AlphaModes []gputypes.CompositeAlphaMode
+
+	// CurrentTransform is the transform that maps rendering to the display's
+	// current orientation; pass it to SurfaceConfiguration.PreTransform to
+	// pre-rotate. SurfaceTransformIdentity when no rotation is needed or the
+	// backend does not report one.
+	CurrentTransform SurfaceTransform
+
+	// SupportedTransforms lists the transforms PreTransform accepts.
+	SupportedTransforms SurfaceTransform
+
+	// Protected reports whether SurfaceConfiguration.Protected is supported.
+	Protected bool
+
+	// Usages lists the texture usages surface textures support natively,
+	// or zero when the backend does not report them. When it lacks
+	// TextureUsageStorageBinding, a surface configured for storage writes
+	// goes through an intermediate texture; see Surface.Configure.
+	Usages TextureUsage
 }
 
 // GetSurfaceCapabilities returns the capabilities of a surface for this adapter.
@@ -163,7 +429,9 @@ func (a *Adapter) GetSurfaceCapabilities(surface *Surface) *SurfaceCapabilities
 	if !a.core.HasHAL() {
 		// Core-only path: return safe defaults (Fifo is guaranteed by Vulkan spec).
 		return &SurfaceCapabilities{
-			PresentModes: []gputypes.PresentMode{gputypes.PresentModeFifo},
+			PresentModes:        []gputypes.PresentMode{gputypes.PresentModeFifo},
+			CurrentTransform:    SurfaceTransformIdentity,
+			SupportedTransforms: SurfaceTransformIdentity,
 		}
 	}
 
@@ -176,11 +444,22 @@ func (a *Adapter) GetSurfaceCapabilities(surface *Surface) *SurfaceCapabilities
 		return nil
 	}
 
-	return &SurfaceCapabilities{
-		Formats:      halCaps.Formats,
-		PresentModes: halCaps.PresentModes,
-		AlphaModes:   halCaps.AlphaModes,
+	caps := &SurfaceCapabilities{
+		Formats:             halCaps.Formats,
+		PresentModes:        halCaps.PresentModes,
+		AlphaModes:          halCaps.AlphaModes,
+		CurrentTransform:    SurfaceTransform(halCaps.CurrentTransform),
+		SupportedTransforms: SurfaceTransform(halCaps.SupportedTransforms),
+		Protected:           halCaps.Protected,
+		Usages:              halCaps.Usages,
+	}
+	if caps.CurrentTransform == 0 {
+		caps.CurrentTransform = SurfaceTransformIdentity
+	}
+	if caps.SupportedTransforms == 0 {
+		caps.SupportedTransforms = SurfaceTransformIdentity
 	}
+	return caps
 }
 
 // Release releases the adapter.
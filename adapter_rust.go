@@ -4,6 +4,7 @@ package wgpu
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/gogpu/gputypes"
 
@@ -12,9 +13,21 @@ import (
 
 // DeviceDescriptor configures device creation.
 type DeviceDescriptor struct {
-	Label            string
+	Label string
+	// RequiredFeatures must all be supported by the adapter; RequestDevice
+	// fails and names the missing features otherwise.
 	RequiredFeatures Features
-	RequiredLimits   Limits
+	// OptionalFeatures are enabled when the adapter supports them and
+	// silently dropped otherwise. Check Device.Features to see which were
+	// granted.
+	OptionalFeatures Features
+	// RequiredLimits are the minimum limits the device must provide. Zero
+	// fields inherit the adapter's limit, so the zero value requests the full
+	// adapter limits. A limit better than the adapter's fails RequestDevice.
+	RequiredLimits Limits
+	// Capture is accepted for API compatibility but ignored: only the
+	// native backends can capture their HAL command stream.
+	Capture io.Writer
 }
 
 // Adapter represents a physical GPU.
@@ -31,12 +44,60 @@ type Adapter struct {
 // Info returns adapter metadata.
 func (a *Adapter) Info() AdapterInfo { return a.info }
 
+// Details returns the adapter's driver version and identifiers. This
+// backend does not report them, so the result is zero.
+func (a *Adapter) Details() AdapterDetails { return AdapterDetails{} }
+
 // Features returns supported features.
 func (a *Adapter) Features() Features { return a.features }
 
 // Limits returns the adapter's resource limits.
 func (a *Adapter) Limits() Limits { return a.limits }
 
+// BlocklistReason always returns ""; wgpu-native applies its own driver workarounds.
+func (a *Adapter) BlocklistReason() string { return "" }
+
+// SupportedSampleCounts returns []uint32{1, 4}, the WebGPU guarantee for
+// multisample-capable formats, because wgpu-native does not expose
+// per-format sample counts through this binding.
+func (a *Adapter) SupportedSampleCounts(_ TextureFormat) []uint32 {
+	return []uint32{1, 4}
+}
+
+// GetPreferredDepthStencilFormat returns the WebGPU-guaranteed depth format
+// for req: Depth24Plus or Depth24PlusStencil8 for up to 24 bits of depth,
+// Depth32Float or, with FeatureDepth32FloatStencil8, Depth32FloatStencil8
+// otherwise. Sample counts other than 1 and 4 return TextureFormatUndefined.
+func (a *Adapter) GetPreferredDepthStencilFormat(req DepthStencilRequirements) TextureFormat {
+	return webgpuDepthStencilFormat(req, a.features)
+}
+
+// formatCapabilities returns nil: wgpu-native's adapter has no per-format
+// query here.
+func (a *Adapter) formatCapabilities() []FormatCapabilities { return nil }
+
+// HasUnifiedMemory always returns false: wgpu-native does not report
+// unified memory through this binding.
+func (a *Adapter) HasUnifiedMemory() bool { return false }
+
+// SupportsTextureCopyReinterpret always returns false: wgpu-native only
+// copies between formats that differ at most in their sRGB encoding.
+func (a *Adapter) SupportsTextureCopyReinterpret() bool { return false }
+
+// SubgroupSizes always returns (0, 0): the binding's adapter info does not
+// carry wgpu-native's subgroup size range.
+func (a *Adapter) SubgroupSizes() (minSize, maxSize uint32) { return 0, 0 }
+
+// MaxMultiviewViewCount always returns 0: the binding does not expose
+// wgpu-native's multiview support.
+func (a *Adapter) MaxMultiviewViewCount() uint32 { return 0 }
+
+// AdoptDevice is not supported by the Rust backend: wgpu-native owns its devices.
+// It always returns ErrExternalNotSupported.
+func (a *Adapter) AdoptDevice(_ *NativeDevice, _ *DeviceDescriptor) (*Device, error) {
+	return nil, ErrExternalNotSupported
+}
+
 // RequestDevice creates a logical device from this adapter.
 // If desc is nil, default features and limits are used.
 func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
@@ -44,10 +105,15 @@ func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
 		return nil, ErrReleased
 	}
 
+	if err := checkRequiredFeatures(a.features, desc); err != nil {
+		return nil, err
+	}
+
 	var rDesc *rwgpu.DeviceDescriptor
 	if desc != nil {
 		rDesc = &rwgpu.DeviceDescriptor{
-			Label: desc.Label,
+			Label:            desc.Label,
+			RequiredFeatures: convertToRustFeatures(deviceFeatures(a.features, desc)),
 		}
 		// Limits conversion: if user specified limits, convert them.
 		if desc.RequiredLimits != (gputypes.Limits{}) {
@@ -64,15 +130,15 @@ func (a *Adapter) RequestDevice(desc *DeviceDescriptor) (*Device, error) {
 	deviceFeatures := convertRustFeatures(rd.Features())
 	deviceLimits := convertRustLimits(rd.Limits())
 
-	rq := rd.Queue()
-
-	return &Device{
+	device := &Device{
 		r:        rd,
 		instance: a.instance,
-		queue:    &Queue{r: rq},
+		queue:    &Queue{r: rd.Queue()},
 		features: deviceFeatures,
 		limits:   deviceLimits,
-	}, nil
+	}
+	device.queue.device = device
+	return device, nil
 }
 
 // SurfaceCapabilities describes what a surface supports on this adapter.
@@ -80,6 +146,13 @@ type SurfaceCapabilities struct {
 	Formats      []TextureFormat
 	PresentModes []PresentMode
 	AlphaModes   []CompositeAlphaMode
+	// CurrentTransform and SupportedTransforms are always identity here.
+	CurrentTransform    SurfaceTransform
+	SupportedTransforms SurfaceTransform
+	// Protected is always false here.
+	Protected bool
+	// Usages is always zero (not reported) here.
+	Usages TextureUsage
 }
 
 // GetSurfaceCapabilities returns the capabilities of a surface for this adapter.
@@ -94,9 +167,11 @@ func (a *Adapter) GetSurfaceCapabilities(surface *Surface) *SurfaceCapabilities
 	}
 
 	return &SurfaceCapabilities{
-		Formats:      caps.Formats,
-		PresentModes: caps.PresentModes,
-		AlphaModes:   caps.AlphaModes,
+		Formats:             caps.Formats,
+		PresentModes:        caps.PresentModes,
+		AlphaModes:          caps.AlphaModes,
+		CurrentTransform:    SurfaceTransformIdentity,
+		SupportedTransforms: SurfaceTransformIdentity,
 	}
 }
 
@@ -121,6 +196,7 @@ func convertToRustLimits(gl gputypes.Limits) rwgpu.Limits {
 		MaxTextureDimension3D:                     gl.MaxTextureDimension3D,
 		MaxTextureArrayLayers:                     gl.MaxTextureArrayLayers,
 		MaxBindGroups:                             gl.MaxBindGroups,
+		MaxBindGroupsPlusVertexBuffers:            gl.MaxBindGroupsPlusVertexBuffers,
 		MaxBindingsPerBindGroup:                   gl.MaxBindingsPerBindGroup,
 		MaxDynamicUniformBuffersPerPipelineLayout: gl.MaxDynamicUniformBuffersPerPipelineLayout,
 		MaxDynamicStorageBuffersPerPipelineLayout: gl.MaxDynamicStorageBuffersPerPipelineLayout,
@@ -137,6 +213,10 @@ func convertToRustLimits(gl gputypes.Limits) rwgpu.Limits {
 		MaxBufferSize:                             gl.MaxBufferSize,
 		MaxVertexAttributes:                       gl.MaxVertexAttributes,
 		MaxVertexBufferArrayStride:                gl.MaxVertexBufferArrayStride,
+		MaxInterStageShaderVariables:              gl.MaxInterStageShaderVariables,
+		MaxColorAttachments:                       gl.MaxColorAttachments,
+		MaxColorAttachmentBytesPerSample:          gl.MaxColorAttachmentBytesPerSample,
+		MaxComputeWorkgroupStorageSize:            gl.MaxComputeWorkgroupStorageSize,
 		MaxComputeWorkgroupSizeX:                  gl.MaxComputeWorkgroupSizeX,
 		MaxComputeWorkgroupSizeY:                  gl.MaxComputeWorkgroupSizeY,
 		MaxComputeWorkgroupSizeZ:                  gl.MaxComputeWorkgroupSizeZ,
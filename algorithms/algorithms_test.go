@@ -0,0 +1,210 @@
+//go:build !rust && !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package algorithms
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+// newKernels compiles the kernels on the software backend, which executes
+// compute shaders on the CPU.
+func newKernels(t *testing.T) (*wgpu.Device, *Kernels) {
+	t.Helper()
+	hal.RegisterBackend(software.API{})
+	inst, err := wgpu.CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Release)
+	adapter, err := inst.RequestAdapter(&wgpu.RequestAdapterOptions{ForceFallbackAdapter: true})
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	t.Cleanup(adapter.Release)
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	t.Cleanup(device.Release)
+	k, err := New(device)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(k.Release)
+	return device, k
+}
+
+// newBuffer creates a storage buffer holding values, or n zeros when values
+// is nil.
+func newBuffer(t *testing.T, device *wgpu.Device, values []uint32, n int) *wgpu.Buffer {
+	t.Helper()
+	if values == nil {
+		values = make([]uint32, n)
+	}
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Size:  uint64(max(len(values), 1)) * 4,
+		Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	t.Cleanup(buf.Release)
+	if len(values) > 0 {
+		data := make([]byte, len(values)*4)
+		for i, v := range values {
+			binary.LittleEndian.PutUint32(data[i*4:], v)
+		}
+		if err := device.Queue().WriteBuffer(buf, 0, data); err != nil {
+			t.Fatalf("WriteBuffer: %v", err)
+		}
+	}
+	return buf
+}
+
+func readBuffer(t *testing.T, device *wgpu.Device, buf *wgpu.Buffer, n int) []uint32 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	data := make([]byte, n*4)
+	done, err := device.Queue().ReadBufferAsync(ctx, buf, 0, data)
+	if err == nil {
+		err = <-done
+	}
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	out := make([]uint32, n)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint32(data[i*4:])
+	}
+	return out
+}
+
+// testSizes cover one partial tile, exact tiles, and inputs that need a
+// second and a third level of tile sums.
+var testSizes = []int{1, 31, 32, 100, 1024, 1500, 40000}
+
+func randomValues(n int, limit uint32) []uint32 {
+	r := rand.New(rand.NewPCG(uint64(n), 7))
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = r.Uint32N(limit)
+	}
+	return values
+}
+
+func TestExclusiveScan(t *testing.T) {
+	device, k := newKernels(t)
+	for _, n := range testSizes {
+		values := randomValues(n, 1000)
+		src := newBuffer(t, device, values, n)
+		dst := newBuffer(t, device, nil, n)
+		if err := k.ExclusiveScan(src, dst, uint32(n)); err != nil {
+			t.Fatalf("n=%d: ExclusiveScan: %v", n, err)
+		}
+		got := readBuffer(t, device, dst, n)
+		var sum uint32
+		for i, v := range values {
+			if got[i] != sum {
+				t.Fatalf("n=%d: dst[%d] = %d, want %d", n, i, got[i], sum)
+			}
+			sum += v
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	device, k := newKernels(t)
+	for _, n := range append([]int{0}, testSizes...) {
+		values := randomValues(n, 1<<31)
+		var want uint32
+		for _, v := range values {
+			want += v
+		}
+		src := newBuffer(t, device, values, n)
+		dst := newBuffer(t, device, []uint32{0xDEADBEEF}, 1)
+		if err := k.Reduce(src, dst, uint32(n)); err != nil {
+			t.Fatalf("n=%d: Reduce: %v", n, err)
+		}
+		if got := readBuffer(t, device, dst, 1)[0]; got != want {
+			t.Errorf("n=%d: sum = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	device, k := newKernels(t)
+	const bins = 10
+	values := randomValues(1500, bins+3) // some values fall outside the bins
+	want := make([]uint32, bins)
+	for _, v := range values {
+		if v < bins {
+			want[v]++
+		}
+	}
+	src := newBuffer(t, device, values, 0)
+	dst := newBuffer(t, device, slices.Repeat([]uint32{7}, bins), 0)
+	for range 2 { // the second run must not accumulate onto the first
+		if err := k.Histogram(src, dst, uint32(len(values)), bins); err != nil {
+			t.Fatalf("Histogram: %v", err)
+		}
+	}
+	if got := readBuffer(t, device, dst, bins); !slices.Equal(got, want) {
+		t.Errorf("histogram = %v, want %v", got, want)
+	}
+}
+
+func TestSort(t *testing.T) {
+	device, k := newKernels(t)
+	for _, n := range []int{1, 2, 33, 1000} {
+		values := randomValues(n, ^uint32(0))
+		keys := newBuffer(t, device, values, n)
+		if err := k.Sort(keys, uint32(n)); err != nil {
+			t.Fatalf("n=%d: Sort: %v", n, err)
+		}
+		want := slices.Clone(values)
+		slices.Sort(want)
+		if got := readBuffer(t, device, keys, n); !slices.Equal(got, want) {
+			t.Errorf("n=%d: keys not sorted: got %v..., want %v...", n, got[:min(n, 8)], want[:min(n, 8)])
+		}
+	}
+}
+
+func TestValidation(t *testing.T) {
+	device, k := newKernels(t)
+	small := newBuffer(t, device, nil, 4)
+	big := newBuffer(t, device, nil, 64)
+	uniform, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: 256, Usage: wgpu.BufferUsageUniform})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer uniform.Release()
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"nil src", k.ExclusiveScan(nil, big, 4)},
+		{"short dst", k.ExclusiveScan(big, small, 64)},
+		{"same buffer", k.Reduce(big, big, 4)},
+		{"missing storage usage", k.Sort(uniform, 8)},
+		{"zero bins", k.Histogram(big, small, 64, 0)},
+	}
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+// Package algorithms provides GPU compute building blocks over arrays of
+// uint32: exclusive prefix sum, sum reduction, histogram and radix sort.
+//
+// Every GPGPU program ends up needing one of these, and they are easy to get
+// subtly wrong: a scan that only works within one workgroup, a reduction that
+// drops the tail of an array whose length is not a multiple of the workgroup
+// size, a sort that is not stable. [Kernels] compiles the shaders once per
+// device and runs them on buffers the application owns:
+//
+//	k, err := algorithms.New(device)
+//	defer k.Release()
+//	err = k.ExclusiveScan(values, offsets, n) // offsets[i] = values[0] + ... + values[i-1]
+//	err = k.Sort(keys, n)                     // ascending, in place
+//
+// Each operation records its dispatches into one command buffer and submits
+// it before returning, so its results are visible to commands submitted
+// afterwards and to Buffer.Map. Buffers need [wgpu.BufferUsageStorage].
+//
+// The kernels split arrays into tiles of 32 elements, one tile per
+// invocation, and carry partial results from one dispatch to the next
+// instead of synchronizing within a workgroup. They therefore produce the
+// same results on every backend, including the software backend, which runs
+// the invocations of a workgroup one after another. Arithmetic wraps modulo
+// 2^32.
+package algorithms
@@ -0,0 +1,328 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package algorithms
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+const (
+	// tileSize is the number of elements one invocation processes. It must
+	// match TILE in paramsWGSL.
+	tileSize = 32
+	// workgroupSize must match @workgroup_size in the shaders.
+	workgroupSize = 64
+	// paramsSize is the size of the WGSL Params struct.
+	paramsSize = 16
+)
+
+// Scratch buffer slots. Scan depth d uses scanSlot+2d and scanSlot+2d+1.
+const (
+	sortKeysSlot = iota
+	sortCountsSlot
+	sortOffsetsSlot
+	reduceSlot // and reduceSlot+1
+	scanSlot   = reduceSlot + 2
+)
+
+// Kernels holds the compiled compute pipelines of the algorithms for one
+// device, together with the uniform and scratch buffers they reuse between
+// calls. A Kernels is not safe for concurrent use.
+type Kernels struct {
+	device    *wgpu.Device
+	align     uint64 // minUniformBufferOffsetAlignment
+	maxGroups uint32 // maxComputeWorkgroupsPerDimension
+
+	reduce, scanTiles, scanAdd      *wgpu.ComputePipeline
+	histClear, histogram            *wgpu.ComputePipeline
+	radixCount, radixScatter        *wgpu.ComputePipeline
+	layoutRW, layoutRWRW, layoutRWR *wgpu.BindGroupLayout
+
+	params  *wgpu.Buffer
+	scratch []*wgpu.Buffer
+	// owned lists the shader modules, layouts and pipelines in creation
+	// order.
+	owned []interface{ Release() }
+}
+
+// New compiles the kernels for device.
+func New(device *wgpu.Device) (*Kernels, error) {
+	if device == nil {
+		return nil, fmt.Errorf("algorithms: device is nil")
+	}
+	limits := device.Limits()
+	k := &Kernels{
+		device:    device,
+		align:     uint64(max(limits.MinUniformBufferOffsetAlignment, paramsSize)),
+		maxGroups: max(limits.MaxComputeWorkgroupsPerDimension, 1),
+	}
+	if err := k.init(); err != nil {
+		k.Release()
+		return nil, err
+	}
+	return k, nil
+}
+
+// bufferEntry describes a storage binding of the kernel layouts.
+func bufferEntry(binding uint32, typ gputypes.BufferBindingType) wgpu.BindGroupLayoutEntry {
+	return wgpu.BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: wgpu.ShaderStageCompute,
+		Buffer:     &gputypes.BufferBindingLayout{Type: typ},
+	}
+}
+
+func (k *Kernels) init() error {
+	params := wgpu.BindGroupLayoutEntry{
+		Binding:    0,
+		Visibility: wgpu.ShaderStageCompute,
+		Buffer: &gputypes.BufferBindingLayout{
+			Type:             gputypes.BufferBindingTypeUniform,
+			HasDynamicOffset: true,
+			MinBindingSize:   paramsSize,
+		},
+	}
+	src := bufferEntry(1, gputypes.BufferBindingTypeReadOnlyStorage)
+	dst := bufferEntry(2, gputypes.BufferBindingTypeStorage)
+	var err error
+	if k.layoutRW, err = k.bindGroupLayout("rw", params, src, dst); err != nil {
+		return err
+	}
+	if k.layoutRWRW, err = k.bindGroupLayout("rwrw", params, src, dst,
+		bufferEntry(3, gputypes.BufferBindingTypeStorage)); err != nil {
+		return err
+	}
+	if k.layoutRWR, err = k.bindGroupLayout("rwr", params, src, dst,
+		bufferEntry(3, gputypes.BufferBindingTypeReadOnlyStorage)); err != nil {
+		return err
+	}
+
+	pipelines := []struct {
+		out    **wgpu.ComputePipeline
+		wgsl   string
+		entry  string
+		layout *wgpu.BindGroupLayout
+	}{
+		{&k.reduce, reduceWGSL, "reduce", k.layoutRW},
+		{&k.scanTiles, scanWGSL, "scan_tiles", k.layoutRWRW},
+		{&k.scanAdd, scanWGSL, "scan_add", k.layoutRW},
+		{&k.histClear, histogramWGSL, "clear", k.layoutRW},
+		{&k.histogram, histogramWGSL, "histogram", k.layoutRW},
+		{&k.radixCount, radixWGSL, "radix_count", k.layoutRWR},
+		{&k.radixScatter, radixWGSL, "radix_scatter", k.layoutRWR},
+	}
+	modules := map[string]*wgpu.ShaderModule{}
+	for _, p := range pipelines {
+		module, ok := modules[p.wgsl]
+		if !ok {
+			module, err = k.device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "algorithms", WGSL: p.wgsl})
+			if err != nil {
+				return fmt.Errorf("algorithms: create %s shader: %w", p.entry, err)
+			}
+			k.owned = append(k.owned, module)
+			modules[p.wgsl] = module
+		}
+		layout, err := k.device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+			Label:            "algorithms " + p.entry,
+			BindGroupLayouts: []*wgpu.BindGroupLayout{p.layout},
+		})
+		if err != nil {
+			return fmt.Errorf("algorithms: create %s pipeline layout: %w", p.entry, err)
+		}
+		k.owned = append(k.owned, layout)
+		*p.out, err = k.device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+			Label:      "algorithms " + p.entry,
+			Layout:     layout,
+			Module:     module,
+			EntryPoint: p.entry,
+		})
+		if err != nil {
+			return fmt.Errorf("algorithms: create %s pipeline: %w", p.entry, err)
+		}
+		k.owned = append(k.owned, *p.out)
+	}
+	return nil
+}
+
+func (k *Kernels) bindGroupLayout(label string, entries ...wgpu.BindGroupLayoutEntry) (*wgpu.BindGroupLayout, error) {
+	layout, err := k.device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label:   "algorithms " + label,
+		Entries: entries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("algorithms: create bind group layout: %w", err)
+	}
+	k.owned = append(k.owned, layout)
+	return layout, nil
+}
+
+// Release releases the pipelines and buffers. Operations already submitted
+// complete normally.
+func (k *Kernels) Release() {
+	if k == nil {
+		return
+	}
+	for _, b := range k.scratch {
+		if b != nil {
+			b.Release()
+		}
+	}
+	k.scratch = nil
+	if k.params != nil {
+		k.params.Release()
+		k.params = nil
+	}
+	for i := len(k.owned) - 1; i >= 0; i-- {
+		k.owned[i].Release()
+	}
+	k.owned = nil
+}
+
+// dispatch is one kernel invocation of an operation: tiles invocations of
+// pipeline with buffers bound from binding 1 on.
+type dispatch struct {
+	pipeline *wgpu.ComputePipeline
+	layout   *wgpu.BindGroupLayout
+	buffers  []*wgpu.Buffer
+	count    uint32
+	tiles    uint32
+	arg      uint32
+}
+
+// tiles returns the number of tiles covering count elements, at least one
+// so that empty inputs still produce their result.
+func tiles(count uint32) uint32 {
+	return max((count+tileSize-1)/tileSize, 1)
+}
+
+// run writes the parameters of every dispatch, then records and submits the
+// dispatches in order in one compute pass. The parameters are written before
+// recording because the software backend executes commands as they are
+// recorded.
+func (k *Kernels) run(label string, dispatches []dispatch) error {
+	if len(k.owned) == 0 {
+		return fmt.Errorf("algorithms: %s: kernels released", label)
+	}
+	paramsData := make([]byte, uint64(len(dispatches))*k.align)
+	groups := make([][2]uint32, len(dispatches))
+	for i, d := range dispatches {
+		n := (d.tiles + workgroupSize - 1) / workgroupSize
+		x := min(n, k.maxGroups)
+		groups[i] = [2]uint32{x, (n + x - 1) / x}
+		p := paramsData[uint64(i)*k.align:]
+		binary.LittleEndian.PutUint32(p[0:], d.count)
+		binary.LittleEndian.PutUint32(p[4:], d.tiles)
+		binary.LittleEndian.PutUint32(p[8:], d.arg)
+		binary.LittleEndian.PutUint32(p[12:], x*workgroupSize)
+	}
+	if k.params == nil || k.params.Size() < uint64(len(paramsData)) {
+		if k.params != nil {
+			k.params.Release()
+		}
+		var err error
+		k.params, err = k.device.CreateBuffer(&wgpu.BufferDescriptor{
+			Label: "algorithms params",
+			Size:  uint64(len(paramsData)),
+			Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+		})
+		if err != nil {
+			k.params = nil
+			return fmt.Errorf("algorithms: %s: create params buffer: %w", label, err)
+		}
+	}
+	if err := k.device.Queue().WriteBuffer(k.params, 0, paramsData); err != nil {
+		return fmt.Errorf("algorithms: %s: write params: %w", label, err)
+	}
+
+	bindGroups := make([]*wgpu.BindGroup, 0, len(dispatches))
+	defer func() {
+		for _, bg := range bindGroups {
+			bg.Release()
+		}
+	}()
+	for _, d := range dispatches {
+		entries := []wgpu.BindGroupEntry{{Binding: 0, Buffer: k.params, Size: paramsSize}}
+		for i, b := range d.buffers {
+			entries = append(entries, wgpu.BindGroupEntry{Binding: uint32(i + 1), Buffer: b})
+		}
+		bg, err := k.device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+			Label:   "algorithms " + label,
+			Layout:  d.layout,
+			Entries: entries,
+		})
+		if err != nil {
+			return fmt.Errorf("algorithms: %s: create bind group: %w", label, err)
+		}
+		bindGroups = append(bindGroups, bg)
+	}
+
+	encoder, err := k.device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "algorithms " + label})
+	if err != nil {
+		return fmt.Errorf("algorithms: %s: create encoder: %w", label, err)
+	}
+	pass, err := encoder.BeginComputePass(&wgpu.ComputePassDescriptor{Label: "algorithms " + label})
+	if err != nil {
+		return fmt.Errorf("algorithms: %s: begin compute pass: %w", label, err)
+	}
+	for i, d := range dispatches {
+		pass.SetPipeline(d.pipeline)
+		pass.SetBindGroup(0, bindGroups[i], []uint32{uint32(uint64(i) * k.align)})
+		pass.Dispatch(groups[i][0], groups[i][1], 1)
+	}
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("algorithms: %s: end compute pass: %w", label, err)
+	}
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("algorithms: %s: %w", label, err)
+	}
+	if _, err := k.device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("algorithms: %s: submit: %w", label, err)
+	}
+	return nil
+}
+
+// scratchBuffer returns the scratch buffer of slot, replacing it with a
+// larger one when it holds fewer than n elements. An operation must request
+// each slot at its largest size first: replacing a buffer releases the one
+// earlier dispatches of the operation bind.
+func (k *Kernels) scratchBuffer(slot int, n uint32) (*wgpu.Buffer, error) {
+	for len(k.scratch) <= slot {
+		k.scratch = append(k.scratch, nil)
+	}
+	size := max(uint64(n)*4, 256)
+	if b := k.scratch[slot]; b != nil && b.Size() >= size {
+		return b, nil
+	}
+	b, err := k.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: fmt.Sprintf("algorithms scratch %d", slot),
+		Size:  size,
+		Usage: wgpu.BufferUsageStorage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("algorithms: create scratch buffer: %w", err)
+	}
+	if k.scratch[slot] != nil {
+		k.scratch[slot].Release()
+	}
+	k.scratch[slot] = b
+	return b, nil
+}
+
+// checkBuffer validates a buffer argument that holds n uint32 elements.
+func checkBuffer(op, name string, b *wgpu.Buffer, n uint32) error {
+	switch {
+	case b == nil:
+		return fmt.Errorf("algorithms: %s: %s is nil", op, name)
+	case b.Usage()&wgpu.BufferUsageStorage == 0:
+		return fmt.Errorf("algorithms: %s: %s lacks BufferUsageStorage", op, name)
+	case b.Size() < uint64(n)*4:
+		return fmt.Errorf("algorithms: %s: %s holds %d bytes, need %d", op, name, b.Size(), uint64(n)*4)
+	}
+	return nil
+}
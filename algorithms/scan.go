@@ -0,0 +1,105 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu"
+)
+
+// ExclusiveScan writes the exclusive prefix sums of the first count
+// elements of src to dst: dst[0] = 0 and dst[i] = src[0] + ... + src[i-1].
+// src and dst must be different buffers.
+func (k *Kernels) ExclusiveScan(src, dst *wgpu.Buffer, count uint32) error {
+	if err := checkBuffer("ExclusiveScan", "src", src, count); err != nil {
+		return err
+	}
+	if err := checkBuffer("ExclusiveScan", "dst", dst, count); err != nil {
+		return err
+	}
+	if src == dst {
+		return fmt.Errorf("algorithms: ExclusiveScan: src and dst are the same buffer")
+	}
+	if count == 0 {
+		return nil
+	}
+	plan, err := k.planScan(nil, src, dst, count, 0)
+	if err != nil {
+		return err
+	}
+	return k.run("ExclusiveScan", plan)
+}
+
+// planScan appends the dispatches of an exclusive scan of count elements.
+// Each tile is scanned on its own and its total written to a scratch array;
+// for more than one tile the totals are scanned recursively, at depth+1, and
+// added back to their tiles.
+func (k *Kernels) planScan(plan []dispatch, src, dst *wgpu.Buffer, count uint32, depth int) ([]dispatch, error) {
+	n := tiles(count)
+	sums, err := k.scratchBuffer(scanSlot+2*depth, n)
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, dispatch{
+		pipeline: k.scanTiles, layout: k.layoutRWRW,
+		buffers: []*wgpu.Buffer{src, dst, sums},
+		count:   count, tiles: n,
+	})
+	if n == 1 {
+		return plan, nil
+	}
+	offsets, err := k.scratchBuffer(scanSlot+2*depth+1, n)
+	if err != nil {
+		return nil, err
+	}
+	if plan, err = k.planScan(plan, sums, offsets, n, depth+1); err != nil {
+		return nil, err
+	}
+	return append(plan, dispatch{
+		pipeline: k.scanAdd, layout: k.layoutRW,
+		buffers: []*wgpu.Buffer{offsets, dst},
+		count:   count, tiles: n,
+	}), nil
+}
+
+// Reduce writes the sum of the first count elements of src to dst[0]; an
+// empty input sums to 0. src and dst must be different buffers.
+func (k *Kernels) Reduce(src, dst *wgpu.Buffer, count uint32) error {
+	if err := checkBuffer("Reduce", "src", src, count); err != nil {
+		return err
+	}
+	if err := checkBuffer("Reduce", "dst", dst, 1); err != nil {
+		return err
+	}
+	if src == dst {
+		return fmt.Errorf("algorithms: Reduce: src and dst are the same buffer")
+	}
+
+	// Each level sums tiles into a scratch array a tile size shorter,
+	// alternating between two slots, until one tile is left; its sum goes
+	// to dst.
+	var plan []dispatch
+	in := src
+	for level := 0; ; level++ {
+		n := tiles(count)
+		out := dst
+		if n > 1 {
+			var err error
+			if out, err = k.scratchBuffer(reduceSlot+level%2, n); err != nil {
+				return err
+			}
+		}
+		plan = append(plan, dispatch{
+			pipeline: k.reduce, layout: k.layoutRW,
+			buffers: []*wgpu.Buffer{in, out},
+			count:   count, tiles: n,
+		})
+		if n == 1 {
+			break
+		}
+		in, count = out, n
+	}
+	return k.run("Reduce", plan)
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package algorithms
+
+// Every kernel runs one invocation per tile of tileSize elements. Params is
+// bound at a dynamic offset so each dispatch of an operation reads its own
+// copy; row is the number of invocations in one row of a two-dimensional
+// dispatch, used when a one-dimensional dispatch would exceed
+// maxComputeWorkgroupsPerDimension.
+const paramsWGSL = `
+struct Params {
+    count: u32,
+    tiles: u32,
+    arg: u32,
+    row: u32,
+}
+
+const TILE: u32 = 32u;
+
+@group(0) @binding(0) var<uniform> params: Params;
+
+fn tile_index(id: vec3<u32>) -> u32 {
+    return id.x + id.y * params.row;
+}
+`
+
+// reduceWGSL sums each tile of src into dst[tile].
+const reduceWGSL = paramsWGSL + `
+@group(0) @binding(1) var<storage, read> src: array<u32>;
+@group(0) @binding(2) var<storage, read_write> dst: array<u32>;
+
+@compute @workgroup_size(64)
+fn reduce(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    var sum = 0u;
+    for (var i = begin; i < end; i = i + 1u) {
+        sum = sum + src[i];
+    }
+    dst[t] = sum;
+}
+`
+
+// scanWGSL: scan_tiles writes the exclusive scan of each tile of src to dst
+// and the tile's total to sums[tile]; scan_add adds the scanned totals,
+// passed as src, back to every element of their tile.
+const scanWGSL = paramsWGSL + `
+@group(0) @binding(1) var<storage, read> src: array<u32>;
+@group(0) @binding(2) var<storage, read_write> dst: array<u32>;
+@group(0) @binding(3) var<storage, read_write> sums: array<u32>;
+
+@compute @workgroup_size(64)
+fn scan_tiles(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    var running = 0u;
+    for (var i = begin; i < end; i = i + 1u) {
+        let v = src[i];
+        dst[i] = running;
+        running = running + v;
+    }
+    sums[t] = running;
+}
+
+@compute @workgroup_size(64)
+fn scan_add(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    let offset = src[t];
+    for (var i = begin; i < end; i = i + 1u) {
+        dst[i] = dst[i] + offset;
+    }
+}
+`
+
+// histogramWGSL: clear zeroes the params.arg bins; histogram counts every
+// value of src below params.arg into its bin.
+const histogramWGSL = paramsWGSL + `
+@group(0) @binding(1) var<storage, read> src: array<u32>;
+@group(0) @binding(2) var<storage, read_write> bins: array<atomic<u32>>;
+
+@compute @workgroup_size(64)
+fn clear(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t < params.arg) {
+        atomicStore(&bins[t], 0u);
+    }
+}
+
+@compute @workgroup_size(64)
+fn histogram(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    for (var i = begin; i < end; i = i + 1u) {
+        let v = src[i];
+        if (v < params.arg) {
+            atomicAdd(&bins[v], 1u);
+        }
+    }
+}
+`
+
+// radixWGSL sorts by the 4-bit digit at bit params.arg. radix_count writes
+// the digit counts of each tile to dst[digit*tiles + tile], digit-major, so
+// that their exclusive scan is where each tile's keys of each digit go;
+// radix_scatter then moves the keys of src to dst in tile order, which keeps
+// the sort stable.
+const radixWGSL = paramsWGSL + `
+@group(0) @binding(1) var<storage, read> src: array<u32>;
+@group(0) @binding(2) var<storage, read_write> dst: array<u32>;
+@group(0) @binding(3) var<storage, read> offsets: array<u32>;
+
+@compute @workgroup_size(64)
+fn radix_count(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    var counts: array<u32, 16>;
+    for (var i = begin; i < end; i = i + 1u) {
+        let d = (src[i] >> params.arg) & 15u;
+        counts[d] = counts[d] + 1u;
+    }
+    for (var d = 0u; d < 16u; d = d + 1u) {
+        dst[d * params.tiles + t] = counts[d];
+    }
+}
+
+@compute @workgroup_size(64)
+fn radix_scatter(@builtin(global_invocation_id) id: vec3<u32>) {
+    let t = tile_index(id);
+    if (t >= params.tiles) {
+        return;
+    }
+    let begin = t * TILE;
+    let end = min(begin + TILE, params.count);
+    var next: array<u32, 16>;
+    for (var d = 0u; d < 16u; d = d + 1u) {
+        next[d] = offsets[d * params.tiles + t];
+    }
+    for (var i = begin; i < end; i = i + 1u) {
+        let key = src[i];
+        let d = (key >> params.arg) & 15u;
+        dst[next[d]] = key;
+        next[d] = next[d] + 1u;
+    }
+}
+`
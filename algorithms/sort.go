@@ -0,0 +1,90 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package algorithms
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu"
+)
+
+// radixBits is the width of the digit each radix sort pass sorts by.
+const radixBits = 4
+
+// Sort sorts the first count elements of keys in ascending order, in place.
+// It is a least-significant-digit radix sort: eight stable passes over 4-bit
+// digits, each counting the digits of every tile, scanning the counts and
+// scattering the keys to a scratch buffer and back.
+func (k *Kernels) Sort(keys *wgpu.Buffer, count uint32) error {
+	if err := checkBuffer("Sort", "keys", keys, count); err != nil {
+		return err
+	}
+	if count < 2 {
+		return nil
+	}
+
+	n := tiles(count)
+	other, err := k.scratchBuffer(sortKeysSlot, count)
+	if err != nil {
+		return err
+	}
+	counts, err := k.scratchBuffer(sortCountsSlot, n<<radixBits)
+	if err != nil {
+		return err
+	}
+	offsets, err := k.scratchBuffer(sortOffsetsSlot, n<<radixBits)
+	if err != nil {
+		return err
+	}
+
+	var plan []dispatch
+	src, dst := keys, other
+	for shift := uint32(0); shift < 32; shift += radixBits {
+		plan = append(plan, dispatch{
+			pipeline: k.radixCount, layout: k.layoutRWR,
+			buffers: []*wgpu.Buffer{src, counts, offsets},
+			count:   count, tiles: n, arg: shift,
+		})
+		if plan, err = k.planScan(plan, counts, offsets, n<<radixBits, 0); err != nil {
+			return err
+		}
+		plan = append(plan, dispatch{
+			pipeline: k.radixScatter, layout: k.layoutRWR,
+			buffers: []*wgpu.Buffer{src, dst, offsets},
+			count:   count, tiles: n, arg: shift,
+		})
+		src, dst = dst, src
+	}
+	return k.run("Sort", plan)
+}
+
+// Histogram counts the first count elements of src by value into the first
+// bins elements of dst: dst[v] is the number of elements equal to v. Values
+// of bins or more are not counted. src and dst must be different buffers.
+func (k *Kernels) Histogram(src, dst *wgpu.Buffer, count, bins uint32) error {
+	if err := checkBuffer("Histogram", "src", src, count); err != nil {
+		return err
+	}
+	if bins == 0 {
+		return fmt.Errorf("algorithms: Histogram: bins is 0")
+	}
+	if err := checkBuffer("Histogram", "dst", dst, bins); err != nil {
+		return err
+	}
+	if src == dst {
+		return fmt.Errorf("algorithms: Histogram: src and dst are the same buffer")
+	}
+	return k.run("Histogram", []dispatch{
+		{
+			pipeline: k.histClear, layout: k.layoutRW,
+			buffers: []*wgpu.Buffer{src, dst},
+			count:   bins, tiles: bins, arg: bins,
+		},
+		{
+			pipeline: k.histogram, layout: k.layoutRW,
+			buffers: []*wgpu.Buffer{src, dst},
+			count:   count, tiles: tiles(count), arg: bins,
+		},
+	})
+}
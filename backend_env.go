@@ -0,0 +1,14 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import "github.com/gogpu/wgpu/core"
+
+// BackendEnv names the environment variable that forces the backends
+// CreateInstance and DescribeAdapters use: one backend name, such as
+// WGPU_BACKEND=dx12, or a comma-separated fallback chain such as
+// WGPU_BACKEND=vulkan,gl. It overrides InstanceDescriptor.Backends,
+// BackendOrder and Software, so users and CI can pick a backend to triage
+// backend-specific bugs without code changes. Invalid values are logged and
+// ignored. See ParseBackendType for the names.
+const BackendEnv = core.BackendEnv
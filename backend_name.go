@@ -0,0 +1,19 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/core"
+)
+
+// ParseBackendType parses a backend name, case-insensitively: "vulkan"
+// ("vk"), "dx12" ("d3d12"), "metal" ("mtl"), "gl" ("gles", "opengl"),
+// "webgpu" ("browser") or "software" ("cpu"). The software backend is
+// reported as BackendEmpty, as in AdapterInfo.Backend.
+func ParseBackendType(s string) (Backend, error) {
+	backend, err := core.ParseBackendType(s)
+	if err != nil {
+		return backend, fmt.Errorf("wgpu: %w", err)
+	}
+	return backend, nil
+}
@@ -0,0 +1,93 @@
+package wgpu
+
+// BackendOptions is an escape hatch for advanced users: backend-specific
+// instance settings, each merged with what the backend enables itself.
+// Only the native backends read them; the zero value changes nothing.
+type BackendOptions struct {
+	Vulkan   VulkanOptions
+	DX12     DX12Options
+	Metal    MetalOptions
+	GL       GLOptions
+	Software SoftwareOptions
+}
+
+// VulkanOptions requests Vulkan extensions and layers beyond those the
+// backend enables. Names have no NUL terminator. Names the backend already
+// enables are ignored; names the loader or device does not expose fail
+// CreateInstance or RequestDevice.
+type VulkanOptions struct {
+	// InstanceExtensions are enabled on the VkInstance.
+	InstanceExtensions []string
+	// InstanceLayers are enabled on the VkInstance.
+	InstanceLayers []string
+	// DeviceExtensions are enabled on every VkDevice. Their features are
+	// not enabled.
+	DeviceExtensions []string
+	// SynchronizationValidation enables the Khronos validation layer with
+	// synchronization validation, which reports barrier hazards. They are
+	// logged like other validation layer messages, with type
+	// "Synchronization".
+	SynchronizationValidation bool
+	// GPUAssistedValidation enables the Khronos validation layer with
+	// GPU-assisted validation, which catches out-of-bounds descriptor and
+	// buffer accesses in shaders. It slows rendering considerably.
+	GPUAssistedValidation bool
+}
+
+// DXGICreateFactoryDebug is DXGI_CREATE_FACTORY_DEBUG, for
+// DX12Options.FactoryFlags.
+const DXGICreateFactoryDebug uint32 = 0x1
+
+// DX12Options tunes DXGI factory creation and the D3D12 debug layer.
+type DX12Options struct {
+	// FactoryFlags are extra CreateDXGIFactory2 flags. An explicit
+	// DXGICreateFactoryDebug fails CreateInstance when the Windows Graphics
+	// Tools are missing instead of falling back like InstanceFlagsDebug.
+	FactoryFlags uint32
+	// DebugLayer enables the D3D12 debug layer and DRED without enabling
+	// validation on the other backends.
+	DebugLayer bool
+	// GPUBasedValidation enables D3D12 GPU-based validation. It needs the
+	// debug layer, from DebugLayer or InstanceFlagsDebug.
+	GPUBasedValidation bool
+}
+
+// MetalOptions toggles Metal backend behavior.
+type MetalOptions struct {
+	// MaxFramesInFlight is how many submissions the CPU may queue ahead of
+	// the GPU, from 1 to 16. 0 keeps the default of 2.
+	MaxFramesInFlight int
+	// DisableIndirectCommandBuffers keeps render pipelines off the indirect
+	// command buffer path used on Apple7+ GPUs, to work around driver bugs.
+	DisableIndirectCommandBuffers bool
+}
+
+// ImplicitLodRewrite selects which implicit-LOD texture samples the GL
+// backend rewrites to sample an explicit level, for GLES drivers whose
+// derivatives break in non-uniform control flow.
+type ImplicitLodRewrite uint8
+
+const (
+	// ImplicitLodKeep translates samples as written.
+	ImplicitLodKeep ImplicitLodRewrite = iota
+	// ImplicitLodInControlFlow rewrites samples inside if, switch and loop
+	// bodies, directly or through a called function.
+	ImplicitLodInControlFlow
+	// ImplicitLodAlways rewrites every implicit-LOD sample.
+	ImplicitLodAlways
+)
+
+// GLOptions tunes shader translation on the GL backend.
+type GLOptions struct {
+	// ImplicitLod makes the selected textureSample calls sample mip level 0
+	// and textureSampleBias calls sample the level given by their bias.
+	ImplicitLod ImplicitLodRewrite
+}
+
+// SoftwareOptions tunes the software backend.
+type SoftwareOptions struct {
+	// Deterministic makes rasterization fixed-point, so the software
+	// backend renders the same pixels on every platform, for fuzzers and
+	// tests that diff against it.
+	Deterministic bool
+}
@@ -0,0 +1,89 @@
+package wgpu
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/core"
+)
+
+// BackendEmpty identifies the software (and noop) backend, which has no
+// dedicated gputypes variant.
+const BackendEmpty = gputypes.BackendEmpty
+
+// DefaultBackendOrder is the fallback chain used when
+// InstanceDescriptor.BackendOrder is nil: Vulkan, Metal, DX12, GL, then the
+// software backend. Backends that are not registered or not enabled by
+// InstanceDescriptor.Backends are skipped.
+var DefaultBackendOrder = []Backend{BackendVulkan, BackendMetal, BackendDX12, BackendGL, BackendEmpty}
+
+// BackendStatus is the outcome of one backend during CreateInstance.
+type BackendStatus = core.BackendStatus
+
+// Backend statuses reported in a BackendReport.
+const (
+	// BackendStatusReady means the backend produced at least one adapter.
+	BackendStatusReady = core.BackendStatusReady
+	// BackendStatusDeferred means adapter enumeration waits for a surface
+	// (GLES needs a GL context; see RequestAdapterOptions.CompatibleSurface).
+	BackendStatusDeferred = core.BackendStatusDeferred
+	// BackendStatusNotRegistered means the backend was requested but is not
+	// compiled in (missing blank import or unsupported platform).
+	BackendStatusNotRegistered = core.BackendStatusNotRegistered
+	// BackendStatusDisabled means the backend is registered but excluded by
+	// InstanceDescriptor.Backends or absent from BackendOrder.
+	BackendStatusDisabled = core.BackendStatusDisabled
+	// BackendStatusInstanceFailed means the backend could not create its
+	// instance, typically because the driver library failed to load.
+	BackendStatusInstanceFailed = core.BackendStatusInstanceFailed
+	// BackendStatusNoAdapters means the backend loaded but found no adapters.
+	BackendStatusNoAdapters = core.BackendStatusNoAdapters
+	// BackendStatusTestOnly means the backend is the noop test backend.
+	BackendStatusTestOnly = core.BackendStatusTestOnly
+)
+
+// BackendAttempt records what happened to one backend during CreateInstance.
+type BackendAttempt struct {
+	Backend Backend
+	Status  BackendStatus
+	// Adapters is the number of adapters the backend contributed.
+	Adapters int
+	// Err is the underlying failure reported by the backend, if any.
+	Err error
+}
+
+// BackendReport lists backends in the order they were tried, followed by
+// backends that were not tried. It is returned by Instance.BackendReport.
+type BackendReport struct {
+	Attempts []BackendAttempt
+}
+
+// Ready returns the backends that produced adapters, in fallback order.
+func (r BackendReport) Ready() []Backend {
+	var ready []Backend
+	for _, a := range r.Attempts {
+		if a.Status == BackendStatusReady {
+			ready = append(ready, a.Backend)
+		}
+	}
+	return ready
+}
+
+// String renders the report on one line, for logs and error messages.
+func (r BackendReport) String() string {
+	if len(r.Attempts) == 0 {
+		return "no backends attempted"
+	}
+	var b strings.Builder
+	for i, a := range r.Attempts {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "%s: %s", a.Backend, a.Status)
+		if a.Err != nil {
+			fmt.Fprintf(&b, " (%v)", a.Err)
+		}
+	}
+	return b.String()
+}
@@ -33,64 +33,20 @@ type BindGroupLayout struct {
 	// This matches Rust wgpu-core's pattern where binder.check_compatibility()
 	// compares layouts by their entries, not by pointer identity.
 	entries []gputypes.BindGroupLayoutEntry
+	// pooled is the device-wide layout shared with every other
+	// BindGroupLayout created from equal entries.
+	pooled *core.PooledBindGroupLayout
 }
 
-// isCompatibleWith returns true if two layouts have identical entries.
-// This matches Rust wgpu-core's entry-by-entry compatibility check in
-// binder.check_compatibility(), allowing equivalent layouts created via
+// isCompatibleWith returns true if two layouts have the same entries, in
+// any order. This matches Rust wgpu-core's entry-by-entry compatibility check
+// in binder.check_compatibility(), allowing equivalent layouts created via
 // separate CreateBindGroupLayout calls to be considered compatible.
 func (l *BindGroupLayout) isCompatibleWith(other *BindGroupLayout) bool {
-	if l == other {
-		return true // pointer equality fast path
+	if l == other || (l.pooled != nil && l.pooled == other.pooled) {
+		return true // same object or same pooled layout fast path
 	}
-	if len(l.entries) != len(other.entries) {
-		return false
-	}
-	for i := range l.entries {
-		if !bindGroupLayoutEntriesEqual(&l.entries[i], &other.entries[i]) {
-			return false
-		}
-	}
-	return true
-}
-
-// bindGroupLayoutEntriesEqual compares two BindGroupLayoutEntry values,
-// dereferencing pointer fields (Buffer, Sampler, Texture, StorageTexture)
-// to compare by value rather than by pointer identity.
-func bindGroupLayoutEntriesEqual(a, b *gputypes.BindGroupLayoutEntry) bool {
-	if a.Binding != b.Binding || a.Visibility != b.Visibility {
-		return false
-	}
-
-	// Compare Buffer pointer fields by value.
-	if !optionalEqual(a.Buffer, b.Buffer) {
-		return false
-	}
-	// Compare Sampler pointer fields by value.
-	if !optionalEqual(a.Sampler, b.Sampler) {
-		return false
-	}
-	// Compare Texture pointer fields by value.
-	if !optionalEqual(a.Texture, b.Texture) {
-		return false
-	}
-	// Compare StorageTexture pointer fields by value.
-	if !optionalEqual(a.StorageTexture, b.StorageTexture) {
-		return false
-	}
-	return true
-}
-
-// optionalEqual compares two optional (pointer) values by dereferenced content.
-// Both nil → equal; one nil → not equal; both non-nil → compare dereferenced values.
-func optionalEqual[T comparable](a, b *T) bool {
-	if a == nil && b == nil {
-		return true
-	}
-	if a == nil || b == nil {
-		return false
-	}
-	return *a == *b
+	return core.BindGroupLayoutMismatch(l.entries, other.entries) == ""
 }
 
 // Release destroys the bind group layout. Destruction is deferred until the
@@ -101,6 +57,11 @@ func (l *BindGroupLayout) Release() {
 	}
 	l.released = true
 
+	// Other layouts created from the same entries still use the HAL layout.
+	if l.pooled != nil && !l.device.core.BindGroupLayouts().Release(l.pooled) {
+		return
+	}
+
 	halDevice := l.device.halDevice()
 	if halDevice == nil {
 		return
@@ -5,6 +5,8 @@ package wgpu
 import (
 	"errors"
 	"fmt"
+
+	"github.com/gogpu/wgpu/core"
 )
 
 // lateBufferBinding tracks a single buffer binding that requires late validation.
@@ -152,10 +154,15 @@ func (b *binder) assign(index uint32, layout *BindGroupLayout) {
 
 // validateSetBindGroup performs common validation for SetBindGroup on both
 // render and compute passes. Returns a non-nil error message if validation fails.
-func validateSetBindGroup(passName string, index uint32, group *BindGroup, offsets []uint32, pipelineBGCount uint32) error {
+// Layout compatibility with the pipeline is checked at draw/dispatch time by
+// checkCompatibility, since either may be set first.
+func validateSetBindGroup(passName string, device *Device, index uint32, group *BindGroup, offsets []uint32, pipelineBGCount uint32) error {
 	if group == nil {
 		return fmt.Errorf("wgpu: %s.SetBindGroup: bind group is nil", passName)
 	}
+	if group.device != nil && device != nil && group.device != device {
+		return fmt.Errorf("wgpu: %s.SetBindGroup: bind group at index %d was created on a different device", passName, index)
+	}
 	if index >= MaxBindGroups {
 		return fmt.Errorf("wgpu: %s.SetBindGroup: index %d >= MaxBindGroups (%d)", passName, index, MaxBindGroups)
 	}
@@ -216,7 +223,9 @@ var (
 //
 // Compatibility is checked entry-by-entry, matching Rust wgpu-core's
 // binder.check_compatibility() behavior. Two layouts are compatible if they
-// have the same bindings with matching types, visibility, and counts.
+// have the same bindings with matching types, visibility, and counts. The
+// error for an incompatible slot names the first differing binding and its
+// type in both layouts.
 // This allows equivalent layouts created via separate CreateBindGroupLayout
 // calls to be considered compatible.
 //
@@ -238,8 +247,8 @@ func (b *binder) checkCompatibility() error {
 		}
 		if !asg.isCompatibleWith(exp) {
 			return fmt.Errorf(
-				"wgpu: bind group at index %d has incompatible layout (assigned layout %p != expected layout %p): %w",
-				i, asg, exp, errBindGroupIncompatible,
+				"wgpu: bind group at index %d has a layout incompatible with the pipeline's: %s: %w",
+				i, core.BindGroupLayoutMismatch(exp.entries, asg.entries), errBindGroupIncompatible,
 			)
 		}
 	}
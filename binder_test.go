@@ -156,6 +156,45 @@ func TestBinderCheckCompatibilityIncompatibleLayout(t *testing.T) {
 	if !strings.Contains(err.Error(), "incompatible") {
 		t.Errorf("error should mention 'incompatible': %v", err)
 	}
+	if !strings.Contains(err.Error(), "binding 0 is no resource, visible to Vertex in the pipeline's layout") ||
+		!strings.Contains(err.Error(), "visible to Fragment in the bind group's layout") {
+		t.Errorf("error should name the binding and both of its types: %v", err)
+	}
+}
+
+func TestBinderCheckCompatibilityReorderedEntries(t *testing.T) {
+	var b binder
+
+	uniform := gputypes.BindGroupLayoutEntry{
+		Binding:    0,
+		Visibility: gputypes.ShaderStageVertex,
+		Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform},
+	}
+	sampler := gputypes.BindGroupLayoutEntry{
+		Binding:    1,
+		Visibility: gputypes.ShaderStageFragment,
+		Sampler:    &gputypes.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+	}
+	b.updateExpectations([]*BindGroupLayout{{entries: []gputypes.BindGroupLayoutEntry{uniform, sampler}}})
+	b.assign(0, &BindGroupLayout{entries: []gputypes.BindGroupLayoutEntry{sampler, uniform}})
+
+	if err := b.checkCompatibility(); err != nil {
+		t.Errorf("checkCompatibility() = %v, want nil for the same entries in another order", err)
+	}
+}
+
+func TestValidateSetBindGroupOtherDevice(t *testing.T) {
+	device, other := &Device{}, &Device{}
+	group := &BindGroup{device: other}
+
+	err := validateSetBindGroup("RenderPass", device, 0, group, nil, 1)
+	if err == nil || !strings.Contains(err.Error(), "different device") {
+		t.Errorf("validateSetBindGroup() = %v, want error for a bind group of another device", err)
+	}
+	group.device = device
+	if err := validateSetBindGroup("RenderPass", device, 0, group, nil, 1); err != nil {
+		t.Errorf("validateSetBindGroup() = %v, want nil for a bind group of the same device", err)
+	}
 }
 
 func TestBinderCheckCompatibilityNoPipeline(t *testing.T) {
@@ -34,6 +34,10 @@ type Buffer struct {
 	// pointer would make the Buffer reachable from the cleanup arg, preventing
 	// GC collection and causing the cleanup to never fire.
 	released *atomic.Bool
+	// gpuUse is the index of the last submission that may access the buffer,
+	// or pendingGPUUse while a staged write to it awaits Submit. Maintained
+	// only on unified-memory devices, where it gates direct writes.
+	gpuUse atomic.Uint64
 }
 
 // Size returns the buffer size in bytes.
@@ -108,6 +112,8 @@ func (b *Buffer) MapState() MapState {
 // Map drives Device.Poll internally; callers do not need to schedule
 // polling themselves. If you need non-blocking behavior use MapAsync.
 func (b *Buffer) Map(ctx context.Context, mode MapMode, offset, size uint64) error {
+	defer startSpan("wgpu.Buffer.Map").End()
+
 	if b == nil || b.core == nil {
 		return ErrReleased
 	}
@@ -127,7 +133,7 @@ func (b *Buffer) Map(ctx context.Context, mode MapMode, offset, size uint64) err
 	// buffer has no in-flight submission this returns immediately with
 	// the map already resolved.
 	if b.device != nil {
-		b.device.Poll(PollPoll)
+		b.device.pollMaps(PollPoll)
 	}
 	if done, werr := pending.Status(); done {
 		pending.Release()
@@ -141,7 +147,7 @@ func (b *Buffer) Map(ctx context.Context, mode MapMode, offset, size uint64) err
 		// as soon as the fence advances. Allocation is a single channel
 		// inside MapPending.Wait; the zero-alloc path is Status-driven.
 		go func() {
-			b.device.Poll(PollWait)
+			b.device.pollMaps(PollWait)
 		}()
 	}
 	werr := pending.Wait(ctx)
@@ -225,6 +231,11 @@ func (b *Buffer) MappedRange(offset, size uint64) (*MappedRange, error) {
 // MappedRange handles for this buffer. Safe to call multiple times;
 // a second call returns ErrMapNotMapped.
 //
+// Buffers created with MappedAtCreation but without BufferUsageMapWrite
+// are mapped onto host memory, since their own memory may be device-local;
+// Unmap uploads what was written through the queue, so the contents are
+// visible to every command submitted afterwards.
+//
 // Unmap also cancels a Pending map (the associated MapPending resolves
 // with ErrMapCancelled).
 func (b *Buffer) Unmap() error {
@@ -242,10 +253,17 @@ func (b *Buffer) Unmap() error {
 		return ErrReleased
 	}
 	guard := sl.Read()
-	defer guard.Release()
-	if cerr := b.core.UnmapBuffer(guard, halDev); cerr != nil {
+	staged, cerr := b.core.UnmapBuffer(guard, halDev)
+	guard.Release()
+	if cerr != nil {
 		return coreErrToTyped(cerr)
 	}
+	if staged != nil && b.device.queue != nil {
+		// A MappedAtCreation buffer that cannot be mapped itself was
+		// written in host memory; upload it before any submission uses
+		// the buffer.
+		return b.device.queue.writeMappedAtCreation(b, staged)
+	}
 	return nil
 }
 
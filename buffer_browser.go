@@ -12,6 +12,7 @@ import (
 // Buffer represents a GPU buffer.
 type Buffer struct {
 	browser  *browser.Buffer
+	device   *Device
 	size     uint64
 	usage    BufferUsage
 	released bool
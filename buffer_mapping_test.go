@@ -388,3 +388,46 @@ func TestBufferMapAsyncStatus(t *testing.T) {
 	}
 	_ = buf.Unmap()
 }
+
+// TestBufferMappedAtCreationWithoutMapWrite writes a storage buffer that has
+// neither MAP_WRITE nor COPY_DST through its creation mapping and checks
+// that Unmap uploads the contents.
+func TestBufferMappedAtCreationWithoutMapWrite(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label:            "mapped-at-creation-storage",
+		Size:             10,
+		Usage:            wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc,
+		MappedAtCreation: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+	if s := buf.MapState(); s != wgpu.MapStateMapped {
+		t.Fatalf("MapState after creation: got %v want Mapped", s)
+	}
+
+	rng, err := buf.MappedRange(0, 8)
+	if err != nil {
+		t.Fatalf("MappedRange: %v", err)
+	}
+	binary.LittleEndian.PutUint32(rng.Bytes(), 0xDEADBEEF)
+	binary.LittleEndian.PutUint32(rng.Bytes()[4:], 42)
+	rng.Release()
+	if err := buf.Unmap(); err != nil {
+		t.Fatalf("Unmap: %v", err)
+	}
+
+	got := readBack(t, device, buf, 0, 8)
+	if v := binary.LittleEndian.Uint32(got); v != 0xDEADBEEF {
+		t.Errorf("word 0: got %#x want 0xdeadbeef", v)
+	}
+	if v := binary.LittleEndian.Uint32(got[4:]); v != 42 {
+		t.Errorf("word 1: got %d want 42", v)
+	}
+	if err := buf.Map(context.Background(), wgpu.MapModeWrite, 0, 8); err == nil {
+		t.Error("Map of a buffer without map usage should fail after Unmap")
+	}
+}
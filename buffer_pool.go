@@ -0,0 +1,362 @@
+package wgpu
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultBufferPoolBlockSize is the backing buffer size a BufferPool uses
+// when BufferPoolDescriptor.BlockSize is zero.
+const DefaultBufferPoolBlockSize = 1 << 20
+
+// copyBufferAlignment is WebGPU's COPY_BUFFER_ALIGNMENT: Queue.WriteBuffer
+// offsets and sizes must be multiples of it.
+const copyBufferAlignment = 4
+
+// BufferPoolDescriptor describes a BufferPool.
+type BufferPoolDescriptor struct {
+	Label string
+	// Usage of every backing buffer. Allocations can be bound with any of
+	// these usages.
+	Usage BufferUsage
+	// BlockSize is the size of each backing buffer. Zero selects
+	// DefaultBufferPoolBlockSize. Requests larger than BlockSize get a
+	// dedicated buffer.
+	BlockSize uint64
+	// Alignment of every allocation offset. Zero derives it from the
+	// device limits: MinUniformBufferOffsetAlignment for uniform usage and
+	// MinStorageBufferOffsetAlignment for storage usage. A nonzero value
+	// must be a power of two. Offsets are always at least 4-byte aligned.
+	Alignment uint64
+}
+
+// BufferAllocation is a range of a BufferPool backing buffer. Bind it with
+// Buffer, Offset and Size, e.g. in a BindGroupEntry or SetVertexBuffer, and
+// fill it with Queue.WriteBuffer(a.Buffer, a.Offset, data).
+type BufferAllocation struct {
+	Buffer *Buffer
+	Offset uint64
+	// Size is the requested size. The pool may reserve more to keep the
+	// next offset aligned.
+	Size uint64
+
+	block    *bufferPoolBlock
+	reserved uint64
+}
+
+// BufferPoolStats reports the state of a BufferPool.
+type BufferPoolStats struct {
+	// Blocks is the number of backing buffers, including dedicated ones.
+	Blocks int
+	// Allocations is the number of live allocations.
+	Allocations int
+	// AllocatedBytes is the sum of the requested sizes of live allocations.
+	AllocatedBytes uint64
+	// ReservedBytes is the total size of all backing buffers.
+	ReservedBytes uint64
+}
+
+// BufferPool suballocates small buffers, such as per-object uniform or
+// vertex buffers, from a few large device buffers. Creating thousands of
+// tiny buffers exhausts driver allocations (Vulkan's maxMemoryAllocationCount,
+// D3D12 heaps); a pool needs one per block.
+//
+// Freed ranges are reused by later allocations. Bind groups that reference
+// a freed range must not be used again. A BufferPool is safe for concurrent
+// use.
+type BufferPool struct {
+	device    *Device
+	label     string
+	usage     BufferUsage
+	blockSize uint64
+	alignment uint64
+
+	mu          sync.Mutex
+	blocks      []*bufferPoolBlock
+	allocations int
+	allocated   uint64
+	created     int
+	released    bool
+}
+
+// bufferPoolBlock is one backing buffer and its free ranges.
+type bufferPoolBlock struct {
+	buffer    *Buffer
+	free      bufferSpans
+	used      uint64
+	dedicated bool
+}
+
+// NewBufferPool creates an empty pool. Backing buffers are created on
+// demand by Allocate.
+func NewBufferPool(device *Device, desc *BufferPoolDescriptor) (*BufferPool, error) {
+	if device == nil {
+		return nil, fmt.Errorf("wgpu: NewBufferPool: device is nil")
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("wgpu: NewBufferPool: descriptor is nil")
+	}
+	if desc.Usage == 0 {
+		return nil, fmt.Errorf("wgpu: NewBufferPool: usage must not be empty")
+	}
+	if desc.Alignment&(desc.Alignment-1) != 0 {
+		return nil, fmt.Errorf("wgpu: NewBufferPool: alignment %d is not a power of two", desc.Alignment)
+	}
+
+	limits := device.Limits()
+	alignment := desc.Alignment
+	if alignment == 0 {
+		if desc.Usage&BufferUsageUniform != 0 {
+			alignment = max(alignment, uint64(limits.MinUniformBufferOffsetAlignment))
+		}
+		if desc.Usage&BufferUsageStorage != 0 {
+			alignment = max(alignment, uint64(limits.MinStorageBufferOffsetAlignment))
+		}
+	}
+	alignment = max(alignment, copyBufferAlignment)
+
+	blockSize := desc.BlockSize
+	if blockSize == 0 {
+		blockSize = DefaultBufferPoolBlockSize
+		if limits.MaxBufferSize != 0 {
+			blockSize = min(blockSize, limits.MaxBufferSize)
+		}
+	}
+	if blockSize < alignment {
+		return nil, fmt.Errorf("wgpu: NewBufferPool: block size %d is smaller than alignment %d", blockSize, alignment)
+	}
+
+	return &BufferPool{
+		device:    device,
+		label:     desc.Label,
+		usage:     desc.Usage,
+		blockSize: blockSize,
+		alignment: alignment,
+	}, nil
+}
+
+// Alignment returns the offset alignment of every allocation.
+func (p *BufferPool) Alignment() uint64 { return p.alignment }
+
+// Allocate returns a range of at least size bytes whose offset is a
+// multiple of Alignment.
+func (p *BufferPool) Allocate(size uint64) (*BufferAllocation, error) {
+	if size == 0 {
+		return nil, fmt.Errorf("wgpu: BufferPool.Allocate: size must be greater than zero")
+	}
+	reserved := (size + p.alignment - 1) &^ (p.alignment - 1)
+	if reserved < size {
+		return nil, fmt.Errorf("wgpu: BufferPool.Allocate: size %d overflows", size)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		return nil, fmt.Errorf("wgpu: BufferPool.Allocate: %w", ErrReleased)
+	}
+
+	if reserved > p.blockSize {
+		block, err := p.newBlock(reserved, true)
+		if err != nil {
+			return nil, err
+		}
+		block.used = reserved
+		return p.track(block, 0, size, reserved), nil
+	}
+
+	for _, block := range p.blocks {
+		if block.dedicated {
+			continue
+		}
+		if offset, ok := block.free.alloc(reserved); ok {
+			block.used += reserved
+			return p.track(block, offset, size, reserved), nil
+		}
+	}
+
+	block, err := p.newBlock(p.blockSize, false)
+	if err != nil {
+		return nil, err
+	}
+	offset, _ := block.free.alloc(reserved)
+	block.used += reserved
+	return p.track(block, offset, size, reserved), nil
+}
+
+// Free returns an allocation to the pool. Freeing an allocation twice or
+// one from another pool returns an error.
+func (p *BufferPool) Free(a *BufferAllocation) error {
+	if a == nil {
+		return fmt.Errorf("wgpu: BufferPool.Free: allocation is nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		return fmt.Errorf("wgpu: BufferPool.Free: %w", ErrReleased)
+	}
+	block := a.block
+	if block == nil {
+		return fmt.Errorf("wgpu: BufferPool.Free: allocation already freed")
+	}
+	idx := p.blockIndex(block)
+	if idx < 0 {
+		return fmt.Errorf("wgpu: BufferPool.Free: allocation does not belong to this pool")
+	}
+
+	a.block = nil
+	p.allocations--
+	p.allocated -= a.Size
+	block.used -= a.reserved
+	if !block.dedicated {
+		block.free.release(a.Offset, a.reserved)
+	}
+
+	// Keep one empty block around so a free/allocate cycle at the
+	// boundary does not recreate buffers every frame.
+	if block.used == 0 && (block.dedicated || p.emptyBlocks() > 1) {
+		block.buffer.Release()
+		p.blocks = append(p.blocks[:idx], p.blocks[idx+1:]...)
+	}
+	return nil
+}
+
+// Stats returns the current pool usage.
+func (p *BufferPool) Stats() BufferPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := BufferPoolStats{
+		Blocks:         len(p.blocks),
+		Allocations:    p.allocations,
+		AllocatedBytes: p.allocated,
+	}
+	for _, block := range p.blocks {
+		stats.ReservedBytes += block.buffer.Size()
+	}
+	return stats
+}
+
+// Release destroys every backing buffer. Outstanding allocations become
+// invalid.
+func (p *BufferPool) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		return
+	}
+	p.released = true
+	for _, block := range p.blocks {
+		block.buffer.Release()
+	}
+	p.blocks = nil
+}
+
+func (p *BufferPool) newBlock(size uint64, dedicated bool) (*bufferPoolBlock, error) {
+	label := p.label
+	if label == "" {
+		label = "BufferPool"
+	}
+	buffer, err := p.device.CreateBuffer(&BufferDescriptor{
+		Label: fmt.Sprintf("%s block %d", label, p.created),
+		Size:  size,
+		Usage: p.usage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: BufferPool: create block: %w", err)
+	}
+	p.created++
+
+	block := &bufferPoolBlock{buffer: buffer, dedicated: dedicated}
+	if !dedicated {
+		block.free = bufferSpans{{offset: 0, size: size}}
+	}
+	p.blocks = append(p.blocks, block)
+	return block, nil
+}
+
+func (p *BufferPool) track(block *bufferPoolBlock, offset, size, reserved uint64) *BufferAllocation {
+	p.allocations++
+	p.allocated += size
+	return &BufferAllocation{
+		Buffer:   block.buffer,
+		Offset:   offset,
+		Size:     size,
+		block:    block,
+		reserved: reserved,
+	}
+}
+
+func (p *BufferPool) blockIndex(block *bufferPoolBlock) int {
+	for i, b := range p.blocks {
+		if b == block {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *BufferPool) emptyBlocks() int {
+	n := 0
+	for _, block := range p.blocks {
+		if !block.dedicated && block.used == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// bufferSpan is a free byte range of a block.
+type bufferSpan struct {
+	offset uint64
+	size   uint64
+}
+
+// bufferSpans is a free list sorted by offset with no adjacent spans.
+type bufferSpans []bufferSpan
+
+// alloc takes size bytes from the first span large enough. Spans start at
+// aligned offsets and have aligned sizes, so the result stays aligned.
+func (s *bufferSpans) alloc(size uint64) (uint64, bool) {
+	for i := range *s {
+		span := &(*s)[i]
+		if span.size < size {
+			continue
+		}
+		offset := span.offset
+		span.offset += size
+		span.size -= size
+		if span.size == 0 {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+		}
+		return offset, true
+	}
+	return 0, false
+}
+
+// release returns [offset, offset+size) and merges it with its neighbors.
+func (s *bufferSpans) release(offset, size uint64) {
+	spans := *s
+	i := 0
+	for i < len(spans) && spans[i].offset < offset {
+		i++
+	}
+
+	mergePrev := i > 0 && spans[i-1].offset+spans[i-1].size == offset
+	mergeNext := i < len(spans) && offset+size == spans[i].offset
+	switch {
+	case mergePrev && mergeNext:
+		spans[i-1].size += size + spans[i].size
+		spans = append(spans[:i], spans[i+1:]...)
+	case mergePrev:
+		spans[i-1].size += size
+	case mergeNext:
+		spans[i].offset = offset
+		spans[i].size += size
+	default:
+		spans = append(spans, bufferSpan{})
+		copy(spans[i+1:], spans[i:])
+		spans[i] = bufferSpan{offset: offset, size: size}
+	}
+	*s = spans
+}
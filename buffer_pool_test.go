@@ -0,0 +1,181 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func newUniformPool(t *testing.T, device *wgpu.Device, blockSize uint64) *wgpu.BufferPool {
+	t.Helper()
+	pool, err := wgpu.NewBufferPool(device, &wgpu.BufferPoolDescriptor{
+		Label:     "uniforms",
+		Usage:     wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+		BlockSize: blockSize,
+	})
+	if err != nil {
+		t.Fatalf("NewBufferPool: %v", err)
+	}
+	return pool
+}
+
+func TestNewBufferPoolValidation(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+
+	if _, err := wgpu.NewBufferPool(nil, &wgpu.BufferPoolDescriptor{Usage: wgpu.BufferUsageUniform}); err == nil {
+		t.Error("NewBufferPool(nil device) should return error")
+	}
+	if _, err := wgpu.NewBufferPool(device, nil); err == nil {
+		t.Error("NewBufferPool(nil descriptor) should return error")
+	}
+	if _, err := wgpu.NewBufferPool(device, &wgpu.BufferPoolDescriptor{}); err == nil {
+		t.Error("NewBufferPool with empty usage should return error")
+	}
+	if _, err := wgpu.NewBufferPool(device, &wgpu.BufferPoolDescriptor{Usage: wgpu.BufferUsageVertex, Alignment: 12}); err == nil {
+		t.Error("NewBufferPool with non power of two alignment should return error")
+	}
+}
+
+func TestBufferPoolAlignmentFollowsLimits(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+
+	pool := newUniformPool(t, device, 0)
+	defer pool.Release()
+	want := uint64(max(device.Limits().MinUniformBufferOffsetAlignment, 4))
+	if pool.Alignment() != want {
+		t.Fatalf("Alignment() = %d, want %d", pool.Alignment(), want)
+	}
+
+	vertex, err := wgpu.NewBufferPool(device, &wgpu.BufferPoolDescriptor{Usage: wgpu.BufferUsageVertex})
+	if err != nil {
+		t.Fatalf("NewBufferPool: %v", err)
+	}
+	defer vertex.Release()
+	if vertex.Alignment() != 4 {
+		t.Fatalf("vertex pool Alignment() = %d, want 4", vertex.Alignment())
+	}
+}
+
+func TestBufferPoolSuballocates(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pool := newUniformPool(t, device, 64*1024)
+	defer pool.Release()
+
+	const count = 1000
+	allocs := make([]*wgpu.BufferAllocation, count)
+	seen := make(map[*wgpu.Buffer]map[uint64]bool)
+	for i := range allocs {
+		a, err := pool.Allocate(64)
+		if err != nil {
+			t.Fatalf("Allocate #%d: %v", i, err)
+		}
+		if a.Offset%pool.Alignment() != 0 {
+			t.Fatalf("offset %d is not %d-byte aligned", a.Offset, pool.Alignment())
+		}
+		if seen[a.Buffer] == nil {
+			seen[a.Buffer] = make(map[uint64]bool)
+		}
+		if seen[a.Buffer][a.Offset] {
+			t.Fatalf("offset %d handed out twice", a.Offset)
+		}
+		seen[a.Buffer][a.Offset] = true
+		allocs[i] = a
+	}
+
+	stats := pool.Stats()
+	perBlock := 64 * 1024 / pool.Alignment()
+	wantBlocks := int((count + perBlock - 1) / perBlock)
+	if stats.Blocks != wantBlocks || stats.Allocations != count || stats.AllocatedBytes != 64*count {
+		t.Fatalf("Stats() = %+v, want %d blocks and %d allocations", stats, wantBlocks, count)
+	}
+
+	data := make([]byte, 64)
+	if err := device.Queue().WriteBuffer(allocs[7].Buffer, allocs[7].Offset, data); err != nil {
+		t.Fatalf("WriteBuffer into allocation: %v", err)
+	}
+
+	for _, a := range allocs {
+		if err := pool.Free(a); err != nil {
+			t.Fatalf("Free: %v", err)
+		}
+	}
+	stats = pool.Stats()
+	if stats.Blocks != 1 || stats.Allocations != 0 || stats.AllocatedBytes != 0 {
+		t.Fatalf("Stats() after Free = %+v, want one spare block and no allocations", stats)
+	}
+}
+
+func TestBufferPoolReusesFreedRanges(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pool := newUniformPool(t, device, 0)
+	defer pool.Release()
+
+	a, _ := pool.Allocate(256)
+	b, _ := pool.Allocate(256)
+	c, _ := pool.Allocate(256)
+	if err := pool.Free(a); err != nil {
+		t.Fatalf("Free(a): %v", err)
+	}
+	if err := pool.Free(b); err != nil {
+		t.Fatalf("Free(b): %v", err)
+	}
+
+	// a and b coalesce, so a request spanning both fits in front of c.
+	d, err := pool.Allocate(2 * pool.Alignment())
+	if err != nil {
+		t.Fatalf("Allocate: %v", err)
+	}
+	if d.Buffer != c.Buffer || d.Offset != a.Offset {
+		t.Fatalf("coalesced allocation at offset %d, want %d", d.Offset, a.Offset)
+	}
+}
+
+func TestBufferPoolDedicatedAndFreeErrors(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pool := newUniformPool(t, device, 4096)
+	large, err := pool.Allocate(10000)
+	if err != nil {
+		t.Fatalf("Allocate(large): %v", err)
+	}
+	if large.Offset != 0 || large.Buffer.Size() < 10000 {
+		t.Fatalf("dedicated allocation = offset %d in %d-byte buffer", large.Offset, large.Buffer.Size())
+	}
+	if _, err := pool.Allocate(0); err == nil {
+		t.Error("Allocate(0) should return error")
+	}
+	if err := pool.Free(large); err != nil {
+		t.Fatalf("Free(large): %v", err)
+	}
+	if got := pool.Stats().Blocks; got != 0 {
+		t.Fatalf("dedicated block kept after Free: %d blocks", got)
+	}
+	if err := pool.Free(large); err == nil {
+		t.Error("double Free should return error")
+	}
+
+	other := newUniformPool(t, device, 4096)
+	defer other.Release()
+	foreign, _ := other.Allocate(16)
+	if err := pool.Free(foreign); err == nil {
+		t.Error("Free of another pool's allocation should return error")
+	}
+
+	pool.Release()
+	if _, err := pool.Allocate(16); !errors.Is(err, wgpu.ErrReleased) {
+		t.Fatalf("Allocate after Release err = %v, want ErrReleased", err)
+	}
+}
@@ -0,0 +1,141 @@
+package wgpu
+
+import (
+	"encoding/hex"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// CapabilityReport is a machine-readable description of what an adapter
+// supports, meant to be attached to bug reports. It marshals to JSON with
+// encoding/json; cmd/gpuinfo prints one per backend. Enumerations are
+// reported by name so reports stay readable across versions.
+type CapabilityReport struct {
+	Adapter AdapterReport `json:"adapter"`
+	// Features lists the adapter's features by name.
+	Features []string `json:"features"`
+	Limits   Limits   `json:"limits"`
+	// Formats lists every texture format with what the adapter can do with
+	// it. It is empty where the implementation cannot query formats (the
+	// browser and Rust backends).
+	Formats []FormatCapabilities `json:"formats,omitempty"`
+	// Surface holds the capabilities of the surface passed to
+	// Adapter.CapabilityReport, if any.
+	Surface *SurfaceReport `json:"surface,omitempty"`
+}
+
+// AdapterReport identifies the adapter of a CapabilityReport.
+type AdapterReport struct {
+	Name          string `json:"name"`
+	Vendor        string `json:"vendor"`
+	VendorID      uint32 `json:"vendorID"`
+	DeviceID      uint32 `json:"deviceID"`
+	DeviceType    string `json:"deviceType"`
+	Backend       string `json:"backend"`
+	Driver        string `json:"driver,omitempty"`
+	DriverInfo    string `json:"driverInfo,omitempty"`
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// LUID and DeviceUUID are hex encoded; see AdapterDetails.
+	LUID       string `json:"luid,omitempty"`
+	DeviceUUID string `json:"deviceUUID,omitempty"`
+	// MemoryHeaps are the adapter's memory heaps; see AdapterDetails.
+	MemoryHeaps []MemoryHeap `json:"memoryHeaps,omitempty"`
+	// Blocklisted is the reason the adapter matched the adapter blocklist.
+	Blocklisted string `json:"blocklisted,omitempty"`
+}
+
+// FormatCapabilities describes what an adapter supports for one texture
+// format.
+type FormatCapabilities struct {
+	Format             string `json:"format"`
+	Sampled            bool   `json:"sampled"`
+	Storage            bool   `json:"storage"`
+	StorageReadWrite   bool   `json:"storageReadWrite"`
+	RenderAttachment   bool   `json:"renderAttachment"`
+	Blendable          bool   `json:"blendable"`
+	Multisample        bool   `json:"multisample"`
+	MultisampleResolve bool   `json:"multisampleResolve"`
+	// SampleCounts are the sample counts textures of the format may use,
+	// as returned by Adapter.SupportedSampleCounts.
+	SampleCounts []uint32 `json:"sampleCounts"`
+}
+
+// SurfaceReport lists the formats, present modes and alpha modes of a
+// surface by name.
+type SurfaceReport struct {
+	Formats      []string `json:"formats"`
+	PresentModes []string `json:"presentModes"`
+	AlphaModes   []string `json:"alphaModes"`
+}
+
+// CapabilityReport describes the adapter's features, limits and texture
+// format capabilities, plus the capabilities of surface when it is not nil.
+func (a *Adapter) CapabilityReport(surface *Surface) *CapabilityReport {
+	info := a.Info()
+	details := a.Details()
+	report := &CapabilityReport{
+		Adapter: AdapterReport{
+			Name:        info.Name,
+			Vendor:      info.Vendor,
+			VendorID:    info.VendorID,
+			DeviceID:    info.DeviceID,
+			DeviceType:  info.DeviceType.String(),
+			Backend:     info.Backend.String(),
+			Driver:      info.Driver,
+			DriverInfo:  info.DriverInfo,
+			MemoryHeaps: details.MemoryHeaps,
+			Blocklisted: a.BlocklistReason(),
+		},
+		Features: featureNames(a.Features()),
+		Limits:   a.Limits(),
+		Formats:  a.formatCapabilities(),
+	}
+	if !details.DriverVersion.IsZero() {
+		report.Adapter.DriverVersion = details.DriverVersion.String()
+	}
+	if details.HasLUID() {
+		report.Adapter.LUID = hex.EncodeToString(details.LUID[:])
+	}
+	if details.HasDeviceUUID() {
+		report.Adapter.DeviceUUID = hex.EncodeToString(details.DeviceUUID[:])
+	}
+	if surface != nil {
+		if caps := a.GetSurfaceCapabilities(surface); caps != nil {
+			report.Surface = &SurfaceReport{
+				Formats:      stringNames(caps.Formats),
+				PresentModes: stringNames(caps.PresentModes),
+				AlphaModes:   stringNames(caps.AlphaModes),
+			}
+		}
+	}
+	return report
+}
+
+// featureNames lists the features in bit order by name.
+func featureNames(features Features) []string {
+	out := []string{}
+	for bit := 0; bit < 64; bit++ {
+		if feature := gputypes.Feature(uint64(1) << bit); features.Contains(feature) {
+			out = append(out, hal.FeatureName(feature))
+		}
+	}
+	return out
+}
+
+// reportedFormats is every texture format a CapabilityReport covers.
+func reportedFormats() []TextureFormat {
+	formats := make([]TextureFormat, 0, gputypes.TextureFormatASTC12x12UnormSrgb)
+	for f := gputypes.TextureFormatR8Unorm; f <= gputypes.TextureFormatASTC12x12UnormSrgb; f++ {
+		formats = append(formats, f)
+	}
+	return formats
+}
+
+func stringNames[T interface{ String() string }](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = v.String()
+	}
+	return out
+}
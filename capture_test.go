@@ -0,0 +1,97 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/capture"
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+func TestDeviceCapture(t *testing.T) {
+	inst, adapter := newAdapter(t)
+	defer inst.Release()
+
+	var out bytes.Buffer
+	device, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{Capture: &out})
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	requireHAL(t, device)
+
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "captured",
+		Size:  16,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	if err := device.Queue().WriteBuffer(buf, 0, make([]byte, 16)); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	cmd, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(cmd); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	buf.Release()
+	device.Release()
+
+	trace := out.Bytes()
+	r, err := capture.NewReader(bytes.NewReader(trace))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if r.Header().Adapter != adapter.Info().Name {
+		t.Errorf("Header().Adapter = %q, want %q", r.Header().Adapter, adapter.Info().Name)
+	}
+	ops := map[capture.Op]bool{}
+	for {
+		cmd, err := r.Next()
+		if err != nil {
+			break
+		}
+		ops[cmd.Op] = true
+	}
+	for _, op := range []capture.Op{capture.OpCreateBuffer, capture.OpCreateCommandEncoder, capture.OpSubmit} {
+		if !ops[op] {
+			t.Errorf("capture has no %s command", op)
+		}
+	}
+	if !ops[capture.OpWriteBuffer] && !ops[capture.OpCopyBufferToBuffer] {
+		t.Error("capture does not record the WriteBuffer upload")
+	}
+
+	// The capture replays on the software backend.
+	r, err = capture.NewReader(bytes.NewReader(trace))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	halInst, err := software.API{}.CreateInstance(&hal.InstanceDescriptor{})
+	if err != nil {
+		t.Fatalf("software CreateInstance: %v", err)
+	}
+	defer halInst.Destroy()
+	open, err := halInst.EnumerateAdapters(nil)[0].Adapter.Open(0, gputypes.DefaultLimits())
+	if err != nil {
+		t.Fatalf("software Open: %v", err)
+	}
+	defer open.Device.Destroy()
+	p := capture.NewPlayer(open.Device, open.Queue)
+	defer p.Close()
+	if err := p.Play(r); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+//go:build !(js && wasm)
+
+// Command gpuinfo enumerates every registered backend and adapter and prints,
+// as JSON, why each backend loaded or not and the capability report of each
+// adapter: driver version, limits, features, memory heaps and the texture
+// format table. With -smoke it also renders one frame offscreen on every
+// adapter and reports whether the result read back correctly. Attach its
+// output to bug reports. With -describe it only lists the adapters, from
+// driver queries that open no device, which is fast and works under Remote
+// Desktop.
+//
+// Usage:
+//
+//	go run ./cmd/gpuinfo                        # every backend and adapter
+//	go run ./cmd/gpuinfo -backend vulkan -smoke # Vulkan adapters, with a smoke render
+//	go run ./cmd/gpuinfo -describe              # adapter names and identifiers only
+//
+// -backend accepts vulkan, metal, dx12, gl and software. GL adapters need a
+// surface to be created, so gpuinfo reports the GL backend as deferred and
+// lists no GL adapter. Surface capabilities are not reported for the same
+// reason: the tool opens no window.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+// report is the document gpuinfo prints.
+type report struct {
+	Backends []backendResult `json:"backends"`
+	Adapters []adapterResult `json:"adapters"`
+}
+
+// descriptionReport is the document gpuinfo -describe prints.
+type descriptionReport struct {
+	Backends []backendResult      `json:"backends"`
+	Adapters []adapterDescription `json:"adapters"`
+}
+
+// adapterDescription is an adapter listed by wgpu.DescribeAdapters.
+type adapterDescription struct {
+	wgpu.AdapterInfo
+	Details wgpu.AdapterDetails `json:"details"`
+}
+
+// backendResult is one entry of Instance.BackendReport.
+type backendResult struct {
+	Backend  string `json:"backend"`
+	Status   string `json:"status"`
+	Adapters int    `json:"adapters"`
+	Error    string `json:"error,omitempty"`
+}
+
+// adapterResult is an adapter's capability report and, with -smoke, the
+// outcome of its smoke render.
+type adapterResult struct {
+	*wgpu.CapabilityReport
+	Smoke *smokeResult `json:"smoke,omitempty"`
+}
+
+type smokeResult struct {
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// backendNames are the -backend spellings, in DefaultBackendOrder.
+var backendNames = map[wgpu.Backend]string{
+	wgpu.BackendVulkan: "vulkan",
+	wgpu.BackendMetal:  "metal",
+	wgpu.BackendDX12:   "dx12",
+	wgpu.BackendGL:     "gl",
+	wgpu.BackendEmpty:  "software",
+}
+
+func main() {
+	only := flag.String("backend", "", "report only this backend (vulkan, metal, dx12, gl, software)")
+	smoke := flag.Bool("smoke", false, "render one frame offscreen on every adapter")
+	compact := flag.Bool("compact", false, "print compact instead of indented JSON")
+	describe := flag.Bool("describe", false, "list adapters without opening them (no capabilities or smoke render)")
+	flag.Parse()
+
+	if *only != "" && !knownBackend(*only) {
+		fmt.Fprintf(os.Stderr, "gpuinfo: unknown backend %q\n", *only)
+		os.Exit(2)
+	}
+	var out any
+	var err error
+	if *describe {
+		out, err = collectDescriptions(*only)
+	} else {
+		out, err = collect(*only, *smoke)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gpuinfo: %v\n", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	if !*compact {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintf(os.Stderr, "gpuinfo: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func knownBackend(name string) bool {
+	for _, n := range backendNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// collect reports the backends and adapters of an instance with every
+// backend enabled, keeping only the backend named only when it is set.
+func collect(only string, smoke bool) (*report, error) {
+	instance, err := wgpu.CreateInstance(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer instance.Release()
+
+	out := &report{Backends: backendResults(instance.BackendReport(), only), Adapters: []adapterResult{}}
+
+	adapters, err := instance.EnumerateAdapters()
+	if err != nil {
+		return nil, err
+	}
+	for _, adapter := range adapters {
+		if only != "" && backendNames[adapter.Info().Backend] != only {
+			adapter.Release()
+			continue
+		}
+		r := adapterResult{CapabilityReport: adapter.CapabilityReport(nil)}
+		if smoke {
+			r.Smoke = &smokeResult{Passed: true}
+			if err := smokeRender(adapter); err != nil {
+				r.Smoke = &smokeResult{Error: err.Error()}
+			}
+		}
+		out.Adapters = append(out.Adapters, r)
+		adapter.Release()
+	}
+	return out, nil
+}
+
+// collectDescriptions lists the adapters with wgpu.DescribeAdapters,
+// keeping only the backend named only when it is set.
+func collectDescriptions(only string) (*descriptionReport, error) {
+	descriptions, backendReport, err := wgpu.DescribeAdapters(nil)
+	if err != nil {
+		return nil, err
+	}
+	out := &descriptionReport{Backends: backendResults(backendReport, only), Adapters: []adapterDescription{}}
+	for _, d := range descriptions {
+		if only != "" && backendNames[d.Info.Backend] != only {
+			continue
+		}
+		out.Adapters = append(out.Adapters, adapterDescription{AdapterInfo: d.Info, Details: d.Details})
+	}
+	return out, nil
+}
+
+// backendResults converts a backend report, keeping only the backend named
+// only when it is set.
+func backendResults(backendReport wgpu.BackendReport, only string) []backendResult {
+	results := []backendResult{}
+	for _, a := range backendReport.Attempts {
+		if only != "" && backendNames[a.Backend] != only {
+			continue
+		}
+		r := backendResult{Backend: backendNames[a.Backend], Status: a.Status.String(), Adapters: a.Adapters}
+		if r.Backend == "" {
+			r.Backend = a.Backend.String()
+		}
+		if a.Err != nil {
+			r.Error = a.Err.Error()
+		}
+		results = append(results, r)
+	}
+	return results
+}
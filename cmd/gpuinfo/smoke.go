@@ -0,0 +1,156 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+const (
+	smokeSize        = 64
+	smokeBytesPerRow = smokeSize * 4 // 256, already copy-aligned
+)
+
+// smokeWGSL covers the target with a green triangle.
+const smokeWGSL = `
+@vertex
+fn vs_main(@builtin(vertex_index) vid: u32) -> @builtin(position) vec4<f32> {
+    var positions = array<vec2<f32>, 3>(
+        vec2<f32>(-1.0, -1.0), vec2<f32>(3.0, -1.0), vec2<f32>(-1.0, 3.0),
+    );
+    return vec4<f32>(positions[vid], 0.0, 1.0);
+}
+
+@fragment
+fn fs_main() -> @location(0) vec4<f32> {
+    return vec4<f32>(0.0, 1.0, 0.0, 1.0);
+}
+`
+
+// smokeRender renders one frame offscreen: it clears an RGBA8 target to red,
+// draws smokeWGSL over it and checks that the center pixel reads back green.
+// It exercises device creation, shader compilation, a render pass, a copy
+// and a buffer map.
+func smokeRender(adapter *wgpu.Adapter) error {
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		return fmt.Errorf("request device: %w", err)
+	}
+	defer device.Release()
+
+	var owned []interface{ Release() }
+	defer func() {
+		for i := len(owned) - 1; i >= 0; i-- {
+			owned[i].Release()
+		}
+	}()
+
+	texture, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "gpuinfo-smoke",
+		Size:          wgpu.Extent3D{Width: smokeSize, Height: smokeSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	})
+	if err != nil {
+		return fmt.Errorf("create texture: %w", err)
+	}
+	owned = append(owned, texture)
+	view, err := device.CreateTextureView(texture, nil)
+	if err != nil {
+		return fmt.Errorf("create view: %w", err)
+	}
+	owned = append(owned, view)
+	readback, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "gpuinfo-smoke-readback",
+		Size:  smokeBytesPerRow * smokeSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	})
+	if err != nil {
+		return fmt.Errorf("create readback buffer: %w", err)
+	}
+	owned = append(owned, readback)
+
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "gpuinfo-smoke", WGSL: smokeWGSL})
+	if err != nil {
+		return fmt.Errorf("create shader: %w", err)
+	}
+	owned = append(owned, shader)
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{Label: "gpuinfo-smoke"})
+	if err != nil {
+		return fmt.Errorf("create pipeline layout: %w", err)
+	}
+	owned = append(owned, layout)
+	pipeline, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "gpuinfo-smoke",
+		Layout: layout,
+		Vertex: wgpu.VertexState{Module: shader, EntryPoint: "vs_main"},
+		Fragment: &wgpu.FragmentState{
+			Module:     shader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create render pipeline: %w", err)
+	}
+	owned = append(owned, pipeline)
+
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "gpuinfo-smoke"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{R: 1, A: 1},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("begin render pass: %w", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.Draw(3, 1, 0, 0)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end render pass: %w", err)
+	}
+	encoder.CopyTextureToBuffer(texture, readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: smokeBytesPerRow, RowsPerImage: smokeSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: texture},
+		Size:         wgpu.Extent3D{Width: smokeSize, Height: smokeSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, readback.Size()); err != nil {
+		return fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, readback.Size())
+	if err != nil {
+		return fmt.Errorf("mapped range: %w", err)
+	}
+	off := smokeSize/2*smokeBytesPerRow + smokeSize/2*4
+	if got := rng.Bytes()[off : off+4]; got[0] > 2 || got[1] < 253 || got[2] > 2 {
+		return fmt.Errorf("center pixel is %v, want green", got)
+	}
+	return nil
+}
@@ -1,8 +1,9 @@
-//go:build windows
+//go:build !(js && wasm)
 
 // Command sw-triangle renders a red triangle on a blue background using
 // ONLY the software rasterizer backend. The rendered framebuffer is displayed
-// in a Win32 window via GDI SetDIBitsToDevice — no GPU required.
+// in a native window — via GDI on Windows, XPutImage or wl_shm on Linux and
+// Core Graphics on macOS — no GPU required.
 package main
 
 import (
@@ -17,6 +18,7 @@ import (
 	"github.com/gogpu/wgpu"
 
 	_ "github.com/gogpu/wgpu/hal/allbackends" // register all backends
+	"github.com/gogpu/wgpu/internal/window"
 )
 
 func init() {
@@ -30,8 +32,9 @@ func main() {
 	}
 }
 
-// surfaceConfig returns the standard surface configuration for the given dimensions.
-func surfaceConfig(w, h uint32) *wgpu.SurfaceConfiguration {
+// surfaceConfig returns the standard surface configuration for the given
+// dimensions in pixels and window scale factor.
+func surfaceConfig(w, h uint32, scale float64) *wgpu.SurfaceConfiguration {
 	return &wgpu.SurfaceConfiguration{
 		Format:      gputypes.TextureFormatRGBA8Unorm,
 		Usage:       gputypes.TextureUsageRenderAttachment,
@@ -39,6 +42,7 @@ func surfaceConfig(w, h uint32) *wgpu.SurfaceConfiguration {
 		Height:      h,
 		PresentMode: gputypes.PresentModeFifo,
 		AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+		ScaleFactor: scale,
 	}
 }
 
@@ -77,12 +81,12 @@ func renderFrame(
 		return false
 	}
 	renderPass.SetPipeline(pipeline)
-	renderPass.SetVertexBuffer(0, vertexBuffer, 0)
+	renderPass.SetVertexBuffer(0, vertexBuffer, 0, wgpu.WholeSize)
 	renderPass.Draw(3, 1, 0, 0)
 	_ = renderPass.End()
 	commands, _ := encoder.Finish()
 	_, _ = device.Queue().Submit(commands)
-	// Present() auto-blits framebuffer to window via GDI (hal/software/queue.go).
+	// Present() auto-blits the framebuffer to the window (hal/software/blit_*.go).
 	// Same API as GPU backends — no manual framebuffer access needed.
 	if presentErr := surface.Present(surfaceTex); presentErr != nil {
 		log.Printf("Present: %v", presentErr)
@@ -93,13 +97,13 @@ func renderFrame(
 
 //nolint:funlen // example code — sequential setup is intentionally verbose
 func run() error {
-	log.Println("=== Software Triangle (GDI Blit) ===")
+	log.Println("=== Software Triangle ===")
 
-	window, err := NewWindow("Software Triangle", 800, 600)
+	win, err := window.New("Software Triangle", 800, 600)
 	if err != nil {
 		return fmt.Errorf("window: %w", err)
 	}
-	defer window.Destroy()
+	defer win.Destroy()
 
 	// Use software-only backend mask. Creating Vulkan/DX12 instances (even without
 	// surfaces) loads GPU drivers that interfere with GDI StretchDIBits on some
@@ -112,7 +116,7 @@ func run() error {
 	}
 	defer instance.Release()
 
-	surface, err := instance.CreateSurface(0, window.Handle())
+	surface, err := instance.CreateSurfaceFromTarget(win)
 	if err != nil {
 		return fmt.Errorf("surface: %w", err)
 	}
@@ -138,8 +142,8 @@ func run() error {
 	}
 	defer device.Release()
 
-	w, h := window.Size()
-	if err = surface.Configure(device, surfaceConfig(safeUint32(w), safeUint32(h))); err != nil {
+	w, h := win.Size()
+	if err = surface.Configure(device, surfaceConfig(safeUint32(w), safeUint32(h), win.Scale())); err != nil {
 		return fmt.Errorf("configure: %w", err)
 	}
 
@@ -219,12 +223,12 @@ func run() error {
 	frameCount := 0
 	startTime := time.Now()
 
-	for window.PollEvents() {
+	for win.PollEvents() {
 		// Handle window resize.
-		if window.NeedsResize() {
-			rw, rh := window.Size()
+		if win.NeedsResize() {
+			rw, rh := win.Size()
 			if rw > 0 && rh > 0 {
-				if err = surface.Configure(device, surfaceConfig(safeUint32(rw), safeUint32(rh))); err != nil {
+				if err = surface.Configure(device, surfaceConfig(safeUint32(rw), safeUint32(rh), win.Scale())); err != nil {
 					log.Printf("reconfigure: %v", err)
 					continue
 				}
@@ -152,6 +152,7 @@ type Type struct {
 	Parent    string   `xml:"parent,attr"`
 	Members   []Member `xml:"member"`
 	InnerName string   `xml:"name"` // For types where name is element content
+	InnerType string   `xml:"type"` // Base type of typedefs, e.g. VkFlags64
 	Requires  string   `xml:"requires,attr"`
 }
 
@@ -480,7 +481,7 @@ func generateTypes(registry *Registry, outDir string) error {
 			seenBitmasks[goName] = true
 			// Determine base type (VkFlags or VkFlags64)
 			baseType := "Flags"
-			if strings.Contains(name, "64") || strings.Contains(t.Requires, "64") {
+			if t.InnerType == "VkFlags64" || strings.Contains(name, "64") || strings.Contains(t.Requires, "64") {
 				baseType = "Flags64"
 			}
 			bitmasks = append(bitmasks, struct {
@@ -25,6 +25,7 @@ import (
 	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/vulkan"
 	"github.com/gogpu/wgpu/internal/thread"
+	"github.com/gogpu/wgpu/internal/window"
 )
 
 const (
@@ -67,11 +68,11 @@ func run() error {
 
 	// Step 1: Create window (main thread)
 	fmt.Print("1. Creating window... ")
-	window, err := NewWindow(windowTitle, windowWidth, windowHeight)
+	win, err := window.New(windowTitle, windowWidth, windowHeight)
 	if err != nil {
 		return fmt.Errorf("creating window: %w", err)
 	}
-	defer window.Destroy()
+	defer win.Destroy()
 	fmt.Println("OK")
 
 	// Step 2: Create render loop with dedicated render thread
@@ -85,7 +86,7 @@ func run() error {
 	var initErr error
 
 	renderLoop.RunOnRenderThreadVoid(func() {
-		gpu, initErr = initGPU(window)
+		gpu, initErr = initGPU(win)
 	})
 
 	if initErr != nil {
@@ -108,10 +109,10 @@ func run() error {
 	startTime := time.Now()
 
 	// Main render loop
-	for window.PollEvents() {
+	for win.PollEvents() {
 		// Check for pending resize from UI thread
-		if window.NeedsResize() && !window.InSizeMove() {
-			width, height := window.Size()
+		if win.NeedsResize() && !win.InSizeMove() {
+			width, height := win.Size()
 			newW, newH := safeUint32(width), safeUint32(height)
 			if newW > 0 && newH > 0 {
 				// Queue resize for render thread (non-blocking)
@@ -135,7 +136,7 @@ func run() error {
 		if frameErr != nil {
 			// Handle surface outdated
 			if errors.Is(frameErr, hal.ErrSurfaceOutdated) {
-				width, height := window.Size()
+				width, height := win.Size()
 				renderLoop.RequestResize(safeUint32(width), safeUint32(height))
 				continue
 			}
@@ -202,7 +203,7 @@ func resetCommandPoolIfNeeded(gpu *gpuResources, frameCount int) {
 // initGPU initializes all GPU resources. Called on render thread.
 //
 //nolint:funlen // Sequential initialization steps
-func initGPU(window *Window) (*gpuResources, error) {
+func initGPU(win *window.Window) (*gpuResources, error) {
 	gpu := &gpuResources{}
 
 	// Create Vulkan backend
@@ -226,7 +227,7 @@ func initGPU(window *Window) (*gpuResources, error) {
 	fmt.Print("5. Creating surface... ")
 	surface, err := instance.CreateSurface(hal.SurfaceTarget{
 		Kind:         hal.SurfaceTargetWindowsHWND,
-		WindowHandle: window.Handle(),
+		WindowHandle: win.Handle().Window,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("creating surface: %w", err)
@@ -260,7 +261,7 @@ func initGPU(window *Window) (*gpuResources, error) {
 
 	// Configure surface
 	fmt.Print("8. Configuring surface... ")
-	width, height := window.Size()
+	width, height := win.Size()
 	gpu.currentWidth = safeUint32(width)
 	gpu.currentHeight = safeUint32(height)
 	gpu.surfaceConfig = &hal.SurfaceConfiguration{
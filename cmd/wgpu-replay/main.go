@@ -0,0 +1,222 @@
+//go:build !(js && wasm)
+
+// Command wgpu-replay replays a HAL capture recorded with
+// DeviceDescriptor.Capture on any backend, to reproduce backend-specific
+// rendering bugs without the application that hit them.
+//
+// Usage:
+//
+//	go run ./cmd/wgpu-replay app.gpucapture                    # replay on the recording backend
+//	go run ./cmd/wgpu-replay -backend software app.gpucapture  # replay on the CPU rasterizer
+//	go run ./cmd/wgpu-replay -frames out app.gpucapture        # save presented frames as PNG
+//
+// -backend accepts vulkan, metal, dx12 and software. The GL backend needs a
+// surface to create its context and is not supported. The replay device
+// gets the features of the capture the adapter supports and the adapter's
+// limits; missing features are reported, and commands that need them fail.
+//
+// With -frames, every presented surface texture in an RGBA8 or BGRA8
+// format is written to the directory as frame-NNNN.png.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/capture"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+// backends are the -backend spellings.
+var backends = map[string]gputypes.Backend{
+	"vulkan":   gputypes.BackendVulkan,
+	"metal":    gputypes.BackendMetal,
+	"dx12":     gputypes.BackendDX12,
+	"software": gputypes.BackendEmpty,
+}
+
+func main() {
+	backendName := flag.String("backend", "", "replay on this backend (vulkan, metal, dx12, software); default is the recording backend")
+	frames := flag.String("frames", "", "write presented frames as PNG files to this directory")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: wgpu-replay [flags] capture-file\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *backendName, *frames); err != nil {
+		fmt.Fprintf(os.Stderr, "wgpu-replay: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(path, backendName, frames string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := capture.NewReader(f)
+	if err != nil {
+		return err
+	}
+	header := r.Header()
+
+	variant := header.Backend
+	if backendName != "" {
+		v, ok := backends[backendName]
+		if !ok {
+			return fmt.Errorf("unknown backend %q", backendName)
+		}
+		variant = v
+	}
+	open, adapter, err := openDevice(variant, header.Features)
+	if err != nil {
+		return err
+	}
+	defer open.Device.Destroy()
+	fmt.Printf("recorded on %s (%s), replaying on %s (%s)\n", header.Adapter, header.Backend, adapter.Info.Name, variant)
+	if missing := header.Features &^ adapter.Features; missing != 0 {
+		fmt.Printf("warning: adapter lacks capture features %#x\n", uint64(missing))
+	}
+
+	p := capture.NewPlayer(open.Device, open.Queue)
+	defer p.Close()
+	if frames != "" {
+		if err := os.MkdirAll(frames, 0o755); err != nil {
+			return err
+		}
+		n := 0
+		p.Present = func(texture hal.Texture, desc *hal.TextureDescriptor) {
+			name := filepath.Join(frames, fmt.Sprintf("frame-%04d.png", n))
+			n++
+			if err := saveFrame(open, texture, desc, name); err != nil {
+				fmt.Fprintf(os.Stderr, "wgpu-replay: %s: %v\n", name, err)
+			}
+		}
+	}
+	if err := p.Play(r); err != nil {
+		return fmt.Errorf("after %d commands: %w", p.Commands(), err)
+	}
+	fmt.Printf("replayed %d commands\n", p.Commands())
+	return nil
+}
+
+// openDevice opens the first adapter of backend with the features it
+// supports of features.
+func openDevice(variant gputypes.Backend, features gputypes.Features) (hal.OpenDevice, hal.ExposedAdapter, error) {
+	backend, ok := hal.GetBackend(variant)
+	if !ok {
+		return hal.OpenDevice{}, hal.ExposedAdapter{}, fmt.Errorf("backend %s is not available on this platform", variant)
+	}
+	inst, err := backend.CreateInstance(&hal.InstanceDescriptor{Backends: gputypes.BackendsAll})
+	if err != nil {
+		return hal.OpenDevice{}, hal.ExposedAdapter{}, err
+	}
+	adapters := inst.EnumerateAdapters(nil)
+	if len(adapters) == 0 {
+		return hal.OpenDevice{}, hal.ExposedAdapter{}, fmt.Errorf("backend %s has no adapter", variant)
+	}
+	adapter := adapters[0]
+	open, err := adapter.Adapter.Open(features&adapter.Features, adapter.Capabilities.Limits)
+	return open, adapter, err
+}
+
+// saveFrame copies texture to a buffer and writes it to name as PNG.
+func saveFrame(open hal.OpenDevice, texture hal.Texture, desc *hal.TextureDescriptor, name string) error {
+	bgra := false
+	switch desc.Format {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb:
+	case gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb:
+		bgra = true
+	default:
+		return fmt.Errorf("cannot save format %v", desc.Format)
+	}
+	width, height := desc.Size.Width, desc.Size.Height
+	bytesPerRow := (width*4 + 255) &^ 255
+	size := uint64(bytesPerRow) * uint64(height)
+
+	device := open.Device
+	buffer, err := device.CreateBuffer(&hal.BufferDescriptor{
+		Label: "wgpu-replay frame",
+		Size:  size,
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return err
+	}
+	defer device.DestroyBuffer(buffer)
+	enc, err := device.CreateCommandEncoder(&hal.CommandEncoderDescriptor{Label: "wgpu-replay frame"})
+	if err != nil {
+		return err
+	}
+	defer enc.Destroy()
+	if err := enc.BeginEncoding("wgpu-replay frame"); err != nil {
+		return err
+	}
+	enc.TransitionTextures([]hal.TextureBarrier{{
+		Texture: texture,
+		Range:   hal.TextureRange{Aspect: gputypes.TextureAspectAll},
+		Usage: hal.TextureUsageTransition{
+			OldUsage: gputypes.TextureUsageRenderAttachment,
+			NewUsage: gputypes.TextureUsageCopySrc,
+		},
+	}})
+	enc.CopyTextureToBuffer(texture, buffer, []hal.BufferTextureCopy{{
+		BufferLayout: hal.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: height},
+		TextureBase:  hal.ImageCopyTexture{Texture: texture, Aspect: gputypes.TextureAspectAll},
+		Size:         hal.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+	}})
+	cmd, err := enc.EndEncoding()
+	if err != nil {
+		return err
+	}
+	defer device.FreeCommandBuffer(cmd)
+	if _, err := open.Queue.Submit([]hal.CommandBuffer{cmd}); err != nil {
+		return err
+	}
+	if err := device.WaitIdle(); err != nil {
+		return err
+	}
+
+	m, err := device.MapBuffer(buffer, 0, size)
+	if err != nil {
+		return err
+	}
+	data := unsafe.Slice((*byte)(m.Ptr), size)
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	for y := 0; y < int(height); y++ {
+		row := data[y*int(bytesPerRow) : y*int(bytesPerRow)+int(width)*4]
+		dst := img.Pix[y*img.Stride : y*img.Stride+int(width)*4]
+		copy(dst, row)
+		if bgra {
+			for x := 0; x < len(dst); x += 4 {
+				dst[x], dst[x+2] = dst[x+2], dst[x]
+			}
+		}
+	}
+	if err := device.UnmapBuffer(buffer); err != nil {
+		return err
+	}
+
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(out, img); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
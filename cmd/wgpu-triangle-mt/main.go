@@ -1,4 +1,4 @@
-//go:build windows
+//go:build !(js && wasm)
 
 // Command wgpu-triangle tests the wgpu public API rendering pipeline.
 // Multi-threaded: main thread = window events, render thread = GPU ops.
@@ -16,6 +16,7 @@ import (
 	"github.com/gogpu/wgpu"
 	_ "github.com/gogpu/wgpu/hal/vulkan"
 	"github.com/gogpu/wgpu/internal/thread"
+	"github.com/gogpu/wgpu/internal/window"
 )
 
 const (
@@ -40,11 +41,11 @@ func run() error {
 	log.Println("=== wgpu Multi-Thread Triangle Test ===")
 
 	// 1. Window (main thread)
-	window, err := NewWindow(windowTitle, windowWidth, windowHeight)
+	win, err := window.New(windowTitle, windowWidth, windowHeight)
 	if err != nil {
 		return fmt.Errorf("window: %w", err)
 	}
-	defer window.Destroy()
+	defer win.Destroy()
 	log.Println("1. Window created")
 
 	// 2. Render thread
@@ -70,7 +71,7 @@ func run() error {
 			return
 		}
 
-		surface, err = instance.CreateSurface(0, window.Handle())
+		surface, err = instance.CreateSurfaceFromTarget(win)
 		if err != nil {
 			initErr = fmt.Errorf("surface: %w", err)
 			return
@@ -89,7 +90,7 @@ func run() error {
 			return
 		}
 
-		w, h := window.Size()
+		w, h := win.Size()
 		err = surface.Configure(device, &wgpu.SurfaceConfiguration{
 			Format:      gputypes.TextureFormatBGRA8Unorm,
 			Usage:       gputypes.TextureUsageRenderAttachment,
@@ -97,6 +98,7 @@ func run() error {
 			Height:      safeUint32(h),
 			PresentMode: gputypes.PresentModeFifo,
 			AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+			ScaleFactor: win.Scale(),
 		})
 		if err != nil {
 			initErr = fmt.Errorf("configure: %w", err)
@@ -153,7 +155,7 @@ func run() error {
 	frameCount := 0
 	startTime := time.Now()
 
-	for window.PollEvents() {
+	for win.PollEvents() {
 		var frameErr error
 
 		renderLoop.RunOnRenderThreadVoid(func() {
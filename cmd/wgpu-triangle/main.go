@@ -1,4 +1,4 @@
-//go:build windows
+//go:build !(js && wasm)
 
 // Command wgpu-triangle tests the wgpu public API rendering pipeline.
 // Single-threaded — validates wgpu API works correctly.
@@ -14,6 +14,7 @@ import (
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu"
 	_ "github.com/gogpu/wgpu/hal/vulkan"
+	"github.com/gogpu/wgpu/internal/window"
 )
 
 func init() {
@@ -31,18 +32,18 @@ func main() {
 func run() error {
 	log.Println("=== wgpu Public API Triangle Test ===")
 
-	window, err := NewWindow("wgpu API Triangle Test", 800, 600)
+	win, err := window.New("wgpu API Triangle Test", 800, 600)
 	if err != nil {
 		return fmt.Errorf("window: %w", err)
 	}
-	defer window.Destroy()
+	defer win.Destroy()
 
 	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: gputypes.BackendsVulkan})
 	if err != nil {
 		return fmt.Errorf("instance: %w", err)
 	}
 
-	surface, err := instance.CreateSurface(0, window.Handle())
+	surface, err := instance.CreateSurfaceFromTarget(win)
 	if err != nil {
 		return fmt.Errorf("surface: %w", err)
 	}
@@ -58,7 +59,7 @@ func run() error {
 		return fmt.Errorf("device: %w", err)
 	}
 
-	w, h := window.Size()
+	w, h := win.Size()
 	err = surface.Configure(device, &wgpu.SurfaceConfiguration{
 		Format:      gputypes.TextureFormatBGRA8Unorm,
 		Usage:       gputypes.TextureUsageRenderAttachment,
@@ -66,6 +67,7 @@ func run() error {
 		Height:      safeUint32(h),
 		PresentMode: gputypes.PresentModeFifo,
 		AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+		ScaleFactor: win.Scale(),
 	})
 	if err != nil {
 		return fmt.Errorf("configure: %w", err)
@@ -103,19 +105,19 @@ func run() error {
 	frameCount := 0
 	startTime := time.Now()
 
-	for window.PollEvents() {
+	for win.PollEvents() {
 		surfaceTex, _, err := surface.GetCurrentTexture()
 		if err != nil {
 			continue
 		}
-		view, err := surfaceTex.CreateView(nil)
+		view, err := surfaceTex.View()
 		if err != nil {
 			surface.DiscardTexture()
 			continue
 		}
 		encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "Frame"})
 		if err != nil {
-			view.Release()
+			surface.DiscardTexture()
 			continue
 		}
 		renderPass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
@@ -125,7 +127,7 @@ func run() error {
 			}},
 		})
 		if err != nil {
-			view.Release()
+			surface.DiscardTexture()
 			continue
 		}
 		renderPass.SetPipeline(pipeline)
@@ -134,7 +136,6 @@ func run() error {
 		commands, _ := encoder.Finish()
 		_, _ = device.Queue().Submit(commands)
 		_ = surface.Present(surfaceTex)
-		view.Release()
 
 		frameCount++
 		if frameCount%60 == 0 {
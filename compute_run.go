@@ -0,0 +1,225 @@
+package wgpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/ir"
+)
+
+// ComputeBuffer is a buffer bound for RunCompute.
+type ComputeBuffer struct {
+	// Binding is the @binding number of the buffer in @group(0).
+	Binding uint32
+	// Data is the initial contents of the buffer. It may be nil for an
+	// output buffer, which then starts zeroed.
+	Data []byte
+	// Size is the size of the buffer in bytes. Zero means len(Data).
+	Size uint64
+}
+
+// RunCompute compiles wgsl, dispatches its entry point once with the given
+// number of workgroups and returns the contents of the buffers the shader
+// can write. It is meant for prototypes and tests; code that dispatches
+// repeatedly should create the pipeline and buffers once instead.
+//
+// Every @group(0) binding of the shader must be a uniform or storage buffer
+// with a matching entry in buffers, and the shader uses no other groups.
+// RunCompute derives the bind group layout from the shader, creates the
+// buffers, submits the dispatch and waits for it, or for ctx to end.
+//
+// The result has one element per entry of buffers: the final contents of
+// each read_write storage buffer, and nil for the others.
+func (d *Device) RunCompute(ctx context.Context, wgsl, entryPoint string, buffers []ComputeBuffer, workgroups [3]uint32) ([][]byte, error) {
+	module, err := d.CreateShaderModule(&ShaderModuleDescriptor{Label: "RunCompute", WGSL: wgsl})
+	if err != nil {
+		return nil, err
+	}
+	defer module.Release()
+
+	reflection, err := module.wgslIR(wgsl)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	types, err := computeBufferTypes(reflection, buffers)
+	if err != nil {
+		return nil, err
+	}
+
+	layoutEntries := make([]BindGroupLayoutEntry, len(buffers))
+	groupEntries := make([]BindGroupEntry, len(buffers))
+	gpuBuffers := make([]*Buffer, len(buffers))
+	defer func() {
+		for _, b := range gpuBuffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+	}()
+	for i, cb := range buffers {
+		size := max(cb.Size, uint64(len(cb.Data)))
+		if size == 0 {
+			return nil, fmt.Errorf("wgpu: RunCompute: binding %d has no size", cb.Binding)
+		}
+		size = (size + 3) &^ 3
+		usage := BufferUsageStorage
+		switch types[i] {
+		case gputypes.BufferBindingTypeUniform:
+			usage = BufferUsageUniform
+		case gputypes.BufferBindingTypeStorage:
+			usage |= BufferUsageCopySrc
+		}
+		contents := make([]byte, size)
+		copy(contents, cb.Data)
+		buf, err := d.CreateBufferWithInit(&BufferInitDescriptor{
+			Label:    fmt.Sprintf("RunCompute binding %d", cb.Binding),
+			Contents: contents,
+			Usage:    usage,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+		}
+		gpuBuffers[i] = buf
+		layoutEntries[i] = BindGroupLayoutEntry{
+			Binding:    cb.Binding,
+			Visibility: ShaderStageCompute,
+			Buffer:     &gputypes.BufferBindingLayout{Type: types[i]},
+		}
+		groupEntries[i] = BindGroupEntry{Binding: cb.Binding, Buffer: buf}
+	}
+
+	bgl, err := d.CreateBindGroupLayout(&BindGroupLayoutDescriptor{Label: "RunCompute", Entries: layoutEntries})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	defer bgl.Release()
+	pipelineLayout, err := d.CreatePipelineLayout(&PipelineLayoutDescriptor{
+		Label:            "RunCompute",
+		BindGroupLayouts: []*BindGroupLayout{bgl},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	defer pipelineLayout.Release()
+	pipeline, err := d.CreateComputePipeline(&ComputePipelineDescriptor{
+		Label:      "RunCompute",
+		Layout:     pipelineLayout,
+		Module:     module,
+		EntryPoint: entryPoint,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	defer pipeline.Release()
+	group, err := d.CreateBindGroup(&BindGroupDescriptor{Label: "RunCompute", Layout: bgl, Entries: groupEntries})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	defer group.Release()
+
+	commands, err := d.submitCompute(pipeline, group, workgroups)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+	}
+	// Released once the readbacks below have waited for the dispatch.
+	defer commands.Release()
+
+	results := make([][]byte, len(buffers))
+	for i, buf := range gpuBuffers {
+		if types[i] != gputypes.BufferBindingTypeStorage {
+			continue
+		}
+		dst := make([]byte, buf.Size())
+		done, err := d.Queue().ReadBufferAsync(ctx, buf, 0, dst)
+		if err != nil {
+			return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+		}
+		if err := <-done; err != nil {
+			return nil, fmt.Errorf("wgpu: RunCompute: %w", err)
+		}
+		results[i] = dst[:max(buffers[i].Size, uint64(len(buffers[i].Data)))]
+	}
+	return results, nil
+}
+
+// submitCompute records and submits a single dispatch and returns the
+// submitted command buffer, which the caller releases once the dispatch's
+// results have been read.
+func (d *Device) submitCompute(pipeline *ComputePipeline, group *BindGroup, workgroups [3]uint32) (*CommandBuffer, error) {
+	enc, err := d.CreateCommandEncoder(&CommandEncoderDescriptor{Label: "RunCompute"})
+	if err != nil {
+		return nil, err
+	}
+	// Discards the encoder on every error path; a no-op after Finish.
+	defer enc.DiscardEncoding()
+	pass, err := enc.BeginComputePass(nil)
+	if err != nil {
+		return nil, err
+	}
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, group, nil)
+	pass.Dispatch(workgroups[0], workgroups[1], workgroups[2])
+	if err := pass.End(); err != nil {
+		return nil, err
+	}
+	commands, err := enc.Finish()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.Queue().Submit(commands); err != nil {
+		commands.Release()
+		return nil, err
+	}
+	return commands, nil
+}
+
+// computeBufferTypes returns the binding type of each of buffers, as
+// declared by the module's @group(0) globals, and checks that buffers and
+// the module's resources match one to one.
+func computeBufferTypes(module *ir.Module, buffers []ComputeBuffer) ([]gputypes.BufferBindingType, error) {
+	if module == nil {
+		return nil, errors.New("wgpu: RunCompute: shader has no reflection information")
+	}
+	declared := make(map[uint32]gputypes.BufferBindingType)
+	for _, g := range module.GlobalVariables {
+		if g.Binding == nil {
+			continue
+		}
+		if g.Binding.Group != 0 {
+			return nil, fmt.Errorf("wgpu: RunCompute: global %q is in group %d; only group 0 is supported", g.Name, g.Binding.Group)
+		}
+		switch {
+		case g.Space == ir.SpaceUniform:
+			declared[g.Binding.Binding] = gputypes.BufferBindingTypeUniform
+		case g.Space == ir.SpaceStorage && g.Access == ir.StorageRead:
+			declared[g.Binding.Binding] = gputypes.BufferBindingTypeReadOnlyStorage
+		case g.Space == ir.SpaceStorage:
+			declared[g.Binding.Binding] = gputypes.BufferBindingTypeStorage
+		default:
+			return nil, fmt.Errorf("wgpu: RunCompute: global %q is not a buffer", g.Name)
+		}
+	}
+
+	types := make([]gputypes.BufferBindingType, len(buffers))
+	for i, cb := range buffers {
+		t, ok := declared[cb.Binding]
+		if !ok {
+			return nil, fmt.Errorf("wgpu: RunCompute: shader declares no buffer at binding %d", cb.Binding)
+		}
+		if slices.ContainsFunc(buffers[:i], func(b ComputeBuffer) bool { return b.Binding == cb.Binding }) {
+			return nil, fmt.Errorf("wgpu: RunCompute: binding %d given twice", cb.Binding)
+		}
+		types[i] = t
+	}
+	if len(declared) != len(buffers) {
+		for binding := range declared {
+			if !slices.ContainsFunc(buffers, func(b ComputeBuffer) bool { return b.Binding == binding }) {
+				return nil, fmt.Errorf("wgpu: RunCompute: no buffer given for binding %d", binding)
+			}
+		}
+	}
+	return types, nil
+}
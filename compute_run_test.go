@@ -0,0 +1,81 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+const runComputeDouble = `
+struct Params { scale: u32 }
+
+@group(0) @binding(0) var<storage, read> input: array<u32>;
+@group(0) @binding(1) var<storage, read_write> output: array<u32>;
+@group(0) @binding(2) var<uniform> params: Params;
+
+@compute @workgroup_size(4)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    output[id.x] = input[id.x] * params.scale;
+}
+`
+
+func TestRunCompute(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	input := make([]byte, 8*4)
+	for i := range 8 {
+		binary.LittleEndian.PutUint32(input[i*4:], uint32(i+1))
+	}
+	params := binary.LittleEndian.AppendUint32(nil, 3)
+
+	results, err := device.RunCompute(context.Background(), runComputeDouble, "main", []wgpu.ComputeBuffer{
+		{Binding: 0, Data: input},
+		{Binding: 1, Size: uint64(len(input))},
+		{Binding: 2, Data: params},
+	}, [3]uint32{2, 1, 1})
+	if err != nil {
+		t.Fatalf("RunCompute: %v", err)
+	}
+	if results[0] != nil || results[2] != nil {
+		t.Errorf("read-only buffers returned contents: %v, %v", results[0], results[2])
+	}
+	if len(results[1]) != len(input) {
+		t.Fatalf("output has %d bytes, want %d", len(results[1]), len(input))
+	}
+	for i := range 8 {
+		if got := binary.LittleEndian.Uint32(results[1][i*4:]); got != uint32(3*(i+1)) {
+			t.Errorf("output[%d] = %d, want %d", i, got, 3*(i+1))
+		}
+	}
+}
+
+func TestRunComputeValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+	ctx := context.Background()
+	one := [3]uint32{1, 1, 1}
+
+	for _, tc := range []struct {
+		name    string
+		buffers []wgpu.ComputeBuffer
+		want    string
+	}{
+		{"missing binding", []wgpu.ComputeBuffer{{Binding: 0, Size: 4}, {Binding: 1, Size: 4}}, "no buffer given for binding 2"},
+		{"unknown binding", []wgpu.ComputeBuffer{{Binding: 0, Size: 4}, {Binding: 1, Size: 4}, {Binding: 5, Size: 4}}, "no buffer at binding 5"},
+		{"duplicate binding", []wgpu.ComputeBuffer{{Binding: 0, Size: 4}, {Binding: 0, Size: 4}}, "given twice"},
+		{"no size", []wgpu.ComputeBuffer{{Binding: 0, Size: 4}, {Binding: 1}, {Binding: 2, Size: 4}}, "has no size"},
+	} {
+		_, err := device.RunCompute(ctx, runComputeDouble, "main", tc.buffers, one)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: RunCompute = %v, want an error containing %q", tc.name, err, tc.want)
+		}
+	}
+
+	if _, err := device.RunCompute(ctx, "fn main( {", "main", nil, one); err == nil {
+		t.Error("RunCompute with invalid WGSL should fail")
+	}
+}
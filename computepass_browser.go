@@ -9,6 +9,8 @@ import "github.com/gogpu/wgpu/internal/browser"
 type ComputePassEncoder struct {
 	browser  *browser.ComputePassEncoder
 	released bool
+	// counters receives FrameStatistics counts; nil discards them.
+	counters *frameCounters
 }
 
 // SetPipeline sets the active compute pipeline.
@@ -16,6 +18,7 @@ func (p *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 	if pipeline == nil || pipeline.browser == nil {
 		return
 	}
+	p.counters.addPipelineBind()
 	p.browser.SetPipeline(pipeline.browser.Ref())
 }
 
@@ -29,6 +32,7 @@ func (p *ComputePassEncoder) SetBindGroup(index uint32, group *BindGroup, offset
 
 // Dispatch dispatches compute work.
 func (p *ComputePassEncoder) Dispatch(x, y, z uint32) {
+	p.counters.addDispatch()
 	p.browser.DispatchWorkgroups(x, y, z)
 }
 
@@ -37,9 +41,19 @@ func (p *ComputePassEncoder) DispatchIndirect(buffer *Buffer, offset uint64) {
 	if buffer == nil || buffer.browser == nil {
 		return
 	}
+	p.counters.addDispatch()
 	p.browser.DispatchIndirect(buffer.browser.Ref(), offset)
 }
 
+// BeginPipelineStatisticsQuery starts a pipeline statistics query. This
+// backend cannot create pipeline statistics query sets, so there is never a
+// query to begin.
+func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(_ *QuerySet, _ uint32) {}
+
+// EndPipelineStatisticsQuery ends the query started by
+// BeginPipelineStatisticsQuery.
+func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {}
+
 // End ends the compute pass.
 func (p *ComputePassEncoder) End() error {
 	if p.released {
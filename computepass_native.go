@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -41,6 +40,9 @@ type ComputePassEncoder struct {
 	// binder tracks bind group assignments and validates compatibility
 	// at dispatch time, matching Rust wgpu-core's Binder pattern.
 	binder binder
+	// statisticsActive is true between BeginPipelineStatisticsQuery and
+	// EndPipelineStatisticsQuery.
+	statisticsActive bool
 }
 
 // trackRef Clone()'s a ResourceRef and appends directly to the parent
@@ -66,6 +68,7 @@ func (p *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 	p.binder.updateExpectations(pipeline.bindGroupLayouts)
 	p.binder.updateLateBufferBindingsFromPipeline(pipeline.lateSizedBufferGroups)
 	p.trackRef(pipeline.ref)
+	p.encoder.counters().addPipelineBind()
 	raw := p.core.RawPass()
 	if raw != nil && pipeline.hal != nil {
 		raw.SetPipeline(pipeline.hal)
@@ -74,7 +77,7 @@ func (p *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 
 // SetBindGroup sets a bind group for the given index.
 func (p *ComputePassEncoder) SetBindGroup(index uint32, group *BindGroup, offsets []uint32) {
-	if err := validateSetBindGroup("ComputePass", index, group, offsets, p.currentPipelineBindGroupCount); err != nil {
+	if err := validateSetBindGroup("ComputePass", p.encoder.device, index, group, offsets, p.currentPipelineBindGroupCount); err != nil {
 		p.encoder.setError(err)
 		return
 	}
@@ -153,6 +156,7 @@ func (p *ComputePassEncoder) Dispatch(x, y, z uint32) {
 		return
 	}
 
+	p.encoder.counters().addDispatch()
 	p.core.Dispatch(x, y, z)
 }
 
@@ -172,32 +176,16 @@ func (p *ComputePassEncoder) DispatchIndirect(buffer *Buffer, offset uint64) {
 		p.encoder.setError(fmt.Errorf("wgpu: ComputePass.DispatchIndirect: buffer is nil"))
 		return
 	}
-	// VAL-B3: Validate indirect buffer has INDIRECT usage.
-	// Matches Rust wgpu-core compute.rs:896 (check_usage(BufferUsages::INDIRECT)).
-	if buffer.Usage()&BufferUsageIndirect == 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: ComputePass.DispatchIndirect: buffer %q missing BufferUsageIndirect usage: %w",
-			buffer.Label(), ErrDispatchIndirectBufferUsage))
-		return
-	}
-	// VAL-B3: Validate indirect buffer offset is 4-byte aligned.
-	// Matches Rust wgpu-core compute.rs:899 (offset % 4 != 0).
-	if offset%4 != 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: ComputePass.DispatchIndirect: offset %d is not 4-byte aligned: %w",
-			offset, ErrDispatchIndirectOffsetAlignment))
-		return
-	}
-	// VAL-B3: Validate indirect args fit within buffer.
-	// DispatchIndirect args: 3 x uint32 = 12 bytes. Matches Rust compute.rs:903-909.
-	if offset+12 > buffer.Size() {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: ComputePass.DispatchIndirect: offset %d + 12 bytes exceeds buffer size %d: %w",
-			offset, buffer.Size(), ErrDispatchIndirectBufferOverrun))
+	// VAL-B3: Validate INDIRECT usage, 4-byte offset alignment, and that the
+	// 12-byte record fits. Matches Rust compute.rs:896-909.
+	if err := validateIndirectArgs("ComputePass.DispatchIndirect", buffer, offset,
+		core.DispatchIndirectArgsSize, 1, dispatchIndirectErrors); err != nil {
+		p.encoder.setError(err)
 		return
 	}
 	p.trackRef(buffer.core.Ref)
 	p.encoder.trackBuffer(buffer)
+	p.encoder.counters().addDispatch()
 
 	// FEAT-COMPUTE-004: GPU-side indirect dispatch validation.
 	// If the device has an IndirectValidation pipeline, run a pre-dispatch
@@ -295,15 +283,15 @@ func (p *ComputePassEncoder) dispatchIndirectValidated(
 		// End the current compute pass temporarily to insert barriers.
 		raw.End()
 
-		parentEncoder.TransitionBuffers([]hal.BufferBarrier{
+		p.encoder.counters().addBarriers(p.encoder.core.TransitionBuffers([]hal.BufferBarrier{
 			{
 				Buffer: iv.DstBuffer(),
 				Usage: hal.BufferUsageTransition{
-					OldUsage: gputypes.BufferUsageIndirect,
-					NewUsage: gputypes.BufferUsageStorage,
+					OldUsage: hal.BufferUseIndirect,
+					NewUsage: hal.BufferUseStorageReadWrite,
 				},
 			},
-		})
+		}))
 
 		// Re-begin compute pass for the validation dispatch.
 		validationPass := parentEncoder.BeginComputePass(&hal.ComputePassDescriptor{
@@ -320,15 +308,15 @@ func (p *ComputePassEncoder) dispatchIndirectValidated(
 		validationPass.End()
 
 		// Step 6: Transition dst buffer back from STORAGE to INDIRECT.
-		parentEncoder.TransitionBuffers([]hal.BufferBarrier{
+		p.encoder.counters().addBarriers(p.encoder.core.TransitionBuffers([]hal.BufferBarrier{
 			{
 				Buffer: iv.DstBuffer(),
 				Usage: hal.BufferUsageTransition{
-					OldUsage: gputypes.BufferUsageStorage,
-					NewUsage: gputypes.BufferUsageIndirect,
+					OldUsage: hal.BufferUseStorageReadWrite,
+					NewUsage: hal.BufferUseIndirect,
 				},
 			},
-		})
+		}))
 
 		// Step 7: Re-begin compute pass for the user's actual dispatch.
 		userPass := parentEncoder.BeginComputePass(&hal.ComputePassDescriptor{
@@ -369,7 +357,53 @@ func (p *ComputePassEncoder) restoreComputeState(raw hal.ComputePassEncoder) {
 	}
 }
 
+// BeginPipelineStatisticsQuery starts counting the pipeline statistics the
+// query set selects into query queryIndex; EndPipelineStatisticsQuery stops.
+// One query may be active at a time and must end before the pass does.
+// Requires a QueryTypePipelineStatistics set. The single-workgroup
+// validation dispatch that DispatchIndirect inserts is counted too.
+func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	const method = "ComputePass.BeginPipelineStatisticsQuery"
+	if p.statisticsActive {
+		p.encoder.setError(fmt.Errorf("wgpu: %s: a query is already active", method))
+		return
+	}
+	if err := validatePipelineStatisticsQuery(method, querySet, queryIndex); err != nil {
+		p.encoder.setError(err)
+		return
+	}
+	p.statisticsActive = true
+	p.trackRef(querySet.ref)
+	if raw, ok := p.core.RawPass().(hal.PipelineStatisticsPassEncoder); ok {
+		raw.BeginPipelineStatisticsQuery(querySet.hal, queryIndex)
+	}
+}
+
+// EndPipelineStatisticsQuery ends the query started by
+// BeginPipelineStatisticsQuery.
+func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	if !p.statisticsActive {
+		p.encoder.setError(fmt.Errorf("wgpu: ComputePass.EndPipelineStatisticsQuery: no query is active"))
+		return
+	}
+	p.endPipelineStatisticsQuery()
+}
+
+// endPipelineStatisticsQuery ends the query on the current HAL pass, which
+// DispatchIndirect may have replaced since the query began; both record
+// into the same command buffer.
+func (p *ComputePassEncoder) endPipelineStatisticsQuery() {
+	if raw, ok := p.core.RawPass().(hal.PipelineStatisticsPassEncoder); ok {
+		raw.EndPipelineStatisticsQuery()
+	}
+	p.statisticsActive = false
+}
+
 // End ends the compute pass.
 func (p *ComputePassEncoder) End() error {
+	if p.statisticsActive {
+		p.endPipelineStatisticsQuery()
+		p.encoder.setError(fmt.Errorf("wgpu: ComputePass.End: pipeline statistics query still active"))
+	}
 	return p.core.End()
 }
@@ -9,6 +9,8 @@ import rwgpu "github.com/go-webgpu/webgpu/wgpu"
 type ComputePassEncoder struct {
 	r        *rwgpu.ComputePassEncoder
 	released bool
+	// counters receives FrameStatistics counts; nil discards them.
+	counters *frameCounters
 }
 
 // SetPipeline sets the active compute pipeline.
@@ -16,6 +18,7 @@ func (p *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 	if pipeline == nil || pipeline.r == nil {
 		return
 	}
+	p.counters.addPipelineBind()
 	p.r.SetPipeline(pipeline.r)
 }
 
@@ -30,6 +33,7 @@ func (p *ComputePassEncoder) SetBindGroup(index uint32, group *BindGroup, offset
 // Dispatch dispatches compute work.
 func (p *ComputePassEncoder) Dispatch(x, y, z uint32) {
 	// go-webgpu uses DispatchWorkgroups instead of Dispatch.
+	p.counters.addDispatch()
 	p.r.DispatchWorkgroups(x, y, z)
 }
 
@@ -38,9 +42,19 @@ func (p *ComputePassEncoder) DispatchIndirect(buffer *Buffer, offset uint64) {
 	if buffer == nil || buffer.r == nil {
 		return
 	}
+	p.counters.addDispatch()
 	p.r.DispatchWorkgroupsIndirect(buffer.r, offset)
 }
 
+// BeginPipelineStatisticsQuery starts a pipeline statistics query. This
+// backend cannot create pipeline statistics query sets, so there is never a
+// query to begin.
+func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(_ *QuerySet, _ uint32) {}
+
+// EndPipelineStatisticsQuery ends the query started by
+// BeginPipelineStatisticsQuery.
+func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {}
+
 // End ends the compute pass.
 func (p *ComputePassEncoder) End() error {
 	if p.released {
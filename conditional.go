@@ -0,0 +1,27 @@
+package wgpu
+
+import "fmt"
+
+// conditionalRenderingPredicateSize is the size of the value a conditional
+// rendering predicate reads: a resolved occlusion query result.
+const conditionalRenderingPredicateSize = 8
+
+// validateConditionalRenderingPredicate checks that offset addresses an
+// 8-byte aligned predicate inside a buffer with BufferUsageIndirect. DX12
+// requires the alignment and both backends read predicates in the
+// indirect-argument state.
+func validateConditionalRenderingPredicate(label string, usage BufferUsage, size, offset uint64) error {
+	if usage&BufferUsageIndirect == 0 {
+		return fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: buffer %q missing BufferUsageIndirect usage: %w",
+			label, ErrConditionalRenderingBuffer)
+	}
+	if offset%conditionalRenderingPredicateSize != 0 {
+		return fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: offset %d is not 8-byte aligned: %w",
+			offset, ErrConditionalRenderingBuffer)
+	}
+	if offset > size || size-offset < conditionalRenderingPredicateSize {
+		return fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: offset %d + 8 exceeds buffer size %d: %w",
+			offset, size, ErrConditionalRenderingBuffer)
+	}
+	return nil
+}
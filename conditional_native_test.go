@@ -0,0 +1,173 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/core"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/noop"
+)
+
+// conditionalDevice hands out render passes that record conditional
+// rendering calls.
+type conditionalDevice struct {
+	noop.Device
+	calls []string
+}
+
+func (d *conditionalDevice) CreateCommandEncoder(_ *hal.CommandEncoderDescriptor) (hal.CommandEncoder, error) {
+	return &conditionalCommandEncoder{device: d}, nil
+}
+
+type conditionalCommandEncoder struct {
+	noop.CommandEncoder
+	device *conditionalDevice
+}
+
+func (e *conditionalCommandEncoder) BeginRenderPass(_ *hal.RenderPassDescriptor) hal.RenderPassEncoder {
+	return &conditionalRenderPass{device: e.device}
+}
+
+type conditionalRenderPass struct {
+	noop.RenderPassEncoder
+	device *conditionalDevice
+}
+
+func (p *conditionalRenderPass) BeginConditionalRendering(_ hal.Buffer, _ uint64, inverted bool) {
+	if inverted {
+		p.device.calls = append(p.device.calls, "begin-inverted")
+		return
+	}
+	p.device.calls = append(p.device.calls, "begin")
+}
+
+func (p *conditionalRenderPass) EndConditionalRendering() {
+	p.device.calls = append(p.device.calls, "end")
+}
+
+func newConditionalTestDevice(t *testing.T, features Features) (*Device, *conditionalDevice) {
+	t.Helper()
+	raw := &conditionalDevice{}
+	device := &Device{core: core.NewDevice(raw, nil, features, gputypes.DefaultLimits(), "conditional-test")}
+	t.Cleanup(device.Release)
+	return device, raw
+}
+
+// beginConditionalTestPass starts a render pass on a fresh encoder and
+// returns it with an 8-byte predicate buffer.
+func beginConditionalTestPass(t *testing.T, device *Device, usage BufferUsage) (*CommandEncoder, *RenderPassEncoder, *Buffer) {
+	t.Helper()
+	predicate, err := device.CreateBuffer(&BufferDescriptor{Label: "predicate", Size: 16, Usage: usage})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	t.Cleanup(predicate.Release)
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := encoder.BeginRenderPass(&RenderPassDescriptor{})
+	if err != nil {
+		t.Fatalf("BeginRenderPass: %v", err)
+	}
+	return encoder, pass, predicate
+}
+
+func TestConditionalRenderingUsesHAL(t *testing.T) {
+	device, raw := newConditionalTestDevice(t, Features(FeatureConditionalRendering))
+	encoder, pass, predicate := beginConditionalTestPass(t, device, BufferUsageIndirect|BufferUsageCopyDst)
+
+	pass.BeginConditionalRendering(predicate, 8, false)
+	pass.EndConditionalRendering()
+	pass.BeginConditionalRendering(predicate, 0, true)
+	pass.EndConditionalRendering()
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	if _, err := encoder.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	want := []string{"begin", "end", "begin-inverted", "end"}
+	if len(raw.calls) != len(want) {
+		t.Fatalf("HAL calls = %v, want %v", raw.calls, want)
+	}
+	for i := range want {
+		if raw.calls[i] != want[i] {
+			t.Errorf("HAL call %d = %q, want %q", i, raw.calls[i], want[i])
+		}
+	}
+}
+
+func TestConditionalRenderingFallsBackWithoutFeature(t *testing.T) {
+	device, raw := newConditionalTestDevice(t, 0)
+	encoder, pass, predicate := beginConditionalTestPass(t, device, BufferUsageIndirect)
+
+	pass.BeginConditionalRendering(predicate, 0, false)
+	pass.EndConditionalRendering()
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	if _, err := encoder.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if len(raw.calls) != 0 {
+		t.Errorf("HAL calls without feature = %v, want none", raw.calls)
+	}
+}
+
+func TestConditionalRenderingValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		usage  BufferUsage
+		record func(pass *RenderPassEncoder, predicate *Buffer)
+		want   error
+	}{
+		{"missing indirect usage", BufferUsageCopyDst, func(pass *RenderPassEncoder, predicate *Buffer) {
+			pass.BeginConditionalRendering(predicate, 0, false)
+		}, ErrConditionalRenderingBuffer},
+		{"unaligned offset", BufferUsageIndirect, func(pass *RenderPassEncoder, predicate *Buffer) {
+			pass.BeginConditionalRendering(predicate, 4, false)
+		}, ErrConditionalRenderingBuffer},
+		{"past end", BufferUsageIndirect, func(pass *RenderPassEncoder, predicate *Buffer) {
+			pass.BeginConditionalRendering(predicate, 16, false)
+		}, ErrConditionalRenderingBuffer},
+		{"nested", BufferUsageIndirect, func(pass *RenderPassEncoder, predicate *Buffer) {
+			pass.BeginConditionalRendering(predicate, 0, false)
+			pass.BeginConditionalRendering(predicate, 8, false)
+			pass.EndConditionalRendering()
+		}, ErrConditionalRenderingState},
+		{"end without begin", BufferUsageIndirect, func(pass *RenderPassEncoder, _ *Buffer) {
+			pass.EndConditionalRendering()
+		}, ErrConditionalRenderingState},
+		{"left open", BufferUsageIndirect, func(pass *RenderPassEncoder, predicate *Buffer) {
+			pass.BeginConditionalRendering(predicate, 0, false)
+		}, ErrConditionalRenderingState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, raw := newConditionalTestDevice(t, Features(FeatureConditionalRendering))
+			encoder, pass, predicate := beginConditionalTestPass(t, device, tt.usage)
+			tt.record(pass, predicate)
+			_ = pass.End()
+			if _, err := encoder.Finish(); !errors.Is(err, tt.want) {
+				t.Errorf("Finish = %v, want %v", err, tt.want)
+			}
+			// Every block that reached the backend must be closed.
+			begins, ends := 0, 0
+			for _, call := range raw.calls {
+				if call == "end" {
+					ends++
+				} else {
+					begins++
+				}
+			}
+			if begins != ends {
+				t.Errorf("HAL calls %v are unbalanced", raw.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// IgnoreAdapterBlocklistEnv names the environment variable that disables the
+// adapter blocklist when set to "1". It mirrors Chromium's
+// --ignore-gpu-blocklist switch for users who know their driver works.
+const IgnoreAdapterBlocklistEnv = "GOGPU_IGNORE_ADAPTER_BLOCKLIST"
+
+// AdapterBlocklistEntry describes a known-broken adapter/driver combination.
+// Zero-valued fields match anything; an entry with every match field zero
+// matches every adapter.
+type AdapterBlocklistEntry struct {
+	// Backend restricts the entry to one backend. BackendEmpty matches all.
+	Backend gputypes.Backend
+	// VendorID is the PCI vendor ID. Zero matches all vendors.
+	VendorID uint32
+	// DeviceIDs lists PCI device IDs. Empty matches all devices.
+	DeviceIDs []uint32
+	// NameContains matches a case-insensitive substring of AdapterInfo.Name.
+	NameContains string
+	// DriverContains matches a case-insensitive substring of
+	// AdapterInfo.Driver or AdapterInfo.DriverInfo.
+	DriverContains string
+	// Reason explains why the adapter is blocked. It is logged and reported
+	// through Adapter.BlocklistReason.
+	Reason string
+}
+
+// Matches reports whether info is covered by the entry.
+func (e *AdapterBlocklistEntry) Matches(info *gputypes.AdapterInfo) bool {
+	if e.Backend != gputypes.BackendEmpty && e.Backend != info.Backend {
+		return false
+	}
+	if e.VendorID != 0 && e.VendorID != info.VendorID {
+		return false
+	}
+	if len(e.DeviceIDs) > 0 && !containsDeviceID(e.DeviceIDs, info.DeviceID) {
+		return false
+	}
+	if e.NameContains != "" && !containsFold(info.Name, e.NameContains) {
+		return false
+	}
+	if e.DriverContains != "" &&
+		!containsFold(info.Driver, e.DriverContains) &&
+		!containsFold(info.DriverInfo, e.DriverContains) {
+		return false
+	}
+	return true
+}
+
+// DefaultAdapterBlocklist returns the built-in blocklist. Matching adapters
+// are still enumerated but are only selected when no other GPU or software
+// adapter is available. It is empty: an entry is only added for a driver
+// version range with a reproduced, referenced bug, since blocking on a broad
+// API version string would demote working drivers too.
+func DefaultAdapterBlocklist() []AdapterBlocklistEntry {
+	return nil
+}
+
+// adapterBlocklist resolves the effective blocklist for an instance. Extra
+// entries are appended to the defaults. The environment override wins over
+// everything and disables the blocklist entirely.
+func adapterBlocklist(extra []AdapterBlocklistEntry, ignore bool) []AdapterBlocklistEntry {
+	if ignore || os.Getenv(IgnoreAdapterBlocklistEnv) == "1" {
+		return nil
+	}
+	return append(DefaultAdapterBlocklist(), extra...)
+}
+
+// matchAdapterBlocklist returns the reason of the first entry matching info,
+// or "" when the adapter is not blocked.
+func matchAdapterBlocklist(entries []AdapterBlocklistEntry, info *gputypes.AdapterInfo) string {
+	for idx := range entries {
+		if entries[idx].Matches(info) {
+			if entries[idx].Reason == "" {
+				return "adapter is on the blocklist"
+			}
+			return entries[idx].Reason
+		}
+	}
+	return ""
+}
+
+func containsDeviceID(ids []uint32, id uint32) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
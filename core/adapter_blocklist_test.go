@@ -0,0 +1,111 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestAdapterBlocklistEntryMatches(t *testing.T) {
+	info := gputypes.AdapterInfo{
+		Name:       "Intel(R) HD Graphics 4600",
+		VendorID:   0x8086,
+		DeviceID:   0x0412,
+		Driver:     "Vulkan",
+		DriverInfo: "Vulkan 1.0.31",
+		Backend:    gputypes.BackendVulkan,
+	}
+
+	tests := []struct {
+		name  string
+		entry AdapterBlocklistEntry
+		want  bool
+	}{
+		{"empty entry matches all", AdapterBlocklistEntry{}, true},
+		{"backend", AdapterBlocklistEntry{Backend: gputypes.BackendVulkan}, true},
+		{"other backend", AdapterBlocklistEntry{Backend: gputypes.BackendGL}, false},
+		{"vendor", AdapterBlocklistEntry{VendorID: 0x8086}, true},
+		{"other vendor", AdapterBlocklistEntry{VendorID: 0x1002}, false},
+		{"device list", AdapterBlocklistEntry{DeviceIDs: []uint32{0x0166, 0x0412}}, true},
+		{"device not listed", AdapterBlocklistEntry{DeviceIDs: []uint32{0x0166}}, false},
+		{"name case-insensitive", AdapterBlocklistEntry{NameContains: "hd graphics"}, true},
+		{"driver info", AdapterBlocklistEntry{DriverContains: "vulkan 1.0."}, true},
+		{"driver mismatch", AdapterBlocklistEntry{DriverContains: "Vulkan 1.3."}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.Matches(&info); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdapterBlocklistOverride(t *testing.T) {
+	extra := []AdapterBlocklistEntry{{VendorID: 0x10de, Reason: "test"}}
+	info := gputypes.AdapterInfo{VendorID: 0x10de}
+
+	t.Setenv(IgnoreAdapterBlocklistEnv, "")
+	list := adapterBlocklist(extra, false)
+	if len(list) != len(DefaultAdapterBlocklist())+1 {
+		t.Fatalf("len = %d, want defaults plus one extra entry", len(list))
+	}
+	if got := matchAdapterBlocklist(list, &info); got != "test" {
+		t.Errorf("reason = %q, want %q", got, "test")
+	}
+	if adapterBlocklist(extra, true) != nil {
+		t.Error("IgnoreAdapterBlocklist should disable the blocklist")
+	}
+
+	t.Setenv(IgnoreAdapterBlocklistEnv, "1")
+	if adapterBlocklist(extra, false) != nil {
+		t.Errorf("%s=1 should disable the blocklist", IgnoreAdapterBlocklistEnv)
+	}
+}
+
+func TestSelectAdapterIDsBlocklistedIsFallbackOnly(t *testing.T) {
+	hub := GetGlobal().Hub()
+	register := func(deviceType gputypes.DeviceType, reason string) AdapterID {
+		return hub.RegisterAdapter(&Adapter{
+			Info:            gputypes.AdapterInfo{DeviceType: deviceType},
+			BlocklistReason: reason,
+		})
+	}
+	blockedGPU := register(gputypes.DeviceTypeDiscreteGPU, "broken driver")
+	cpu := register(gputypes.DeviceTypeCPU, "")
+	gpu := register(gputypes.DeviceTypeIntegratedGPU, "")
+	t.Cleanup(func() {
+		for _, id := range []AdapterID{blockedGPU, cpu, gpu} {
+			_, _ = hub.UnregisterAdapter(id)
+		}
+	})
+
+	highPerf := &gputypes.RequestAdapterOptions{PowerPreference: gputypes.PowerPreferenceHighPerformance}
+	fallback := &gputypes.RequestAdapterOptions{ForceFallbackAdapter: true}
+
+	tests := []struct {
+		name       string
+		options    *gputypes.RequestAdapterOptions
+		candidates []AdapterID
+		want       AdapterID
+	}{
+		{"usable GPU wins over blocked preferred GPU", highPerf, []AdapterID{blockedGPU, cpu, gpu}, gpu},
+		{"software wins over blocked GPU", nil, []AdapterID{blockedGPU, cpu}, cpu},
+		{"blocked GPU when nothing else", highPerf, []AdapterID{blockedGPU}, blockedGPU},
+		{"forced fallback prefers software", fallback, []AdapterID{blockedGPU, cpu}, cpu},
+		{"forced fallback accepts blocked", fallback, []AdapterID{gpu, blockedGPU}, blockedGPU},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectAdapterIDs(tt.options, tt.candidates)
+			if err != nil {
+				t.Fatalf("selectAdapterIDs: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// DescribeAdapters lists the adapters of the backends desc and opts enable,
+// in backend order, without creating an Instance or opening any adapter.
+// Backends whose HAL instance implements hal.AdapterDescriber are asked for
+// descriptions only; the others are enumerated as usual and their adapters
+// destroyed again. GL adapters need a surface and are not listed.
+//
+// The report records every backend as NewInstanceWithOptions would. The
+// blocklist and validation settings of opts do not apply.
+func DescribeAdapters(desc *gputypes.InstanceDescriptor, opts *InstanceOptions) ([]hal.AdapterDescription, BackendReport) {
+	if desc == nil {
+		defaultDesc := gputypes.DefaultInstanceDescriptor()
+		desc = &defaultDesc
+	}
+	var order []gputypes.Backend
+	software := SoftwareAuto
+	halDesc := &hal.InstanceDescriptor{
+		Flags: desc.Flags,
+	}
+	if opts != nil {
+		order = opts.BackendOrder
+		software = opts.Software
+		halDesc.Log = hal.NewLog(opts.Logger, opts.LogLevels)
+		halDesc.BackendOptions = opts.BackendOptions
+	}
+	desc, order, software = resolveBackendEnv(desc, order, software, halDesc.Log)
+	halDesc.Backends = desc.Backends
+
+	RegisterHALBackends()
+	registered := GetOrderedBackendProviders()
+	enabled := FilterBackendsByMask(desc.Backends)
+	slots, disabled := planBackends(registered, enabled, softwareBackendOrder(software, order, enabled))
+
+	var descriptions []hal.AdapterDescription
+	var report BackendReport
+	record := func(attempt BackendAttempt) {
+		report.Attempts = append(report.Attempts, attempt)
+	}
+	for _, slot := range slots {
+		if slot.provider == nil {
+			record(BackendAttempt{Backend: slot.backend, Status: BackendStatusNotRegistered})
+			continue
+		}
+		backend := slot.provider.Variant()
+		if backend == gputypes.BackendGL {
+			record(BackendAttempt{Backend: backend, Status: BackendStatusDeferred})
+			continue
+		}
+		halInstance, err := slot.provider.CreateInstance(halDesc)
+		if err != nil {
+			record(BackendAttempt{Backend: backend, Status: BackendStatusInstanceFailed, Err: err})
+			continue
+		}
+		found, testOnly := describeHALAdapters(halInstance, backend)
+		halInstance.Destroy()
+
+		switch {
+		case testOnly:
+			record(BackendAttempt{Backend: backend, Status: BackendStatusTestOnly})
+		case len(found) == 0:
+			record(BackendAttempt{Backend: backend, Status: BackendStatusNoAdapters})
+		default:
+			record(BackendAttempt{Backend: backend, Status: BackendStatusReady, Adapters: len(found)})
+			descriptions = append(descriptions, found...)
+		}
+	}
+
+	for _, provider := range disabled {
+		record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusDisabled})
+	}
+	return descriptions, report
+}
+
+// describeHALAdapters describes the adapters of halInstance, correcting
+// their device type with hal.RefineDeviceType. testOnly reports the noop
+// backend, whose adapter NewInstanceWithOptions skips as well.
+func describeHALAdapters(halInstance hal.Instance, backend gputypes.Backend) (descriptions []hal.AdapterDescription, testOnly bool) {
+	if describer, ok := halInstance.(hal.AdapterDescriber); ok {
+		descriptions = describer.DescribeAdapters()
+	} else {
+		exposed := halInstance.EnumerateAdapters(nil)
+		for idx := range exposed {
+			descriptions = append(descriptions, hal.AdapterDescription{
+				Info:    exposed[idx].Info,
+				Details: exposed[idx].Details,
+			})
+			if exposed[idx].Adapter != nil {
+				exposed[idx].Adapter.Destroy()
+			}
+		}
+	}
+	if backend == gputypes.BackendEmpty && len(descriptions) > 0 &&
+		descriptions[0].Info.DeviceType == gputypes.DeviceTypeOther {
+		return nil, true
+	}
+	for idx := range descriptions {
+		descriptions[idx].Info.DeviceType = hal.RefineDeviceType(&descriptions[idx].Info)
+	}
+	return descriptions, false
+}
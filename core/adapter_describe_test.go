@@ -0,0 +1,84 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// describingTestInstance describes one adapter and fails the test if it is
+// enumerated instead.
+type describingTestInstance struct {
+	providerBackedTestInstance
+	t         *testing.T
+	destroyed bool
+}
+
+func (i *describingTestInstance) EnumerateAdapters(hal.Surface) []hal.ExposedAdapter {
+	i.t.Error("EnumerateAdapters called on an instance implementing hal.AdapterDescriber")
+	return nil
+}
+
+func (i *describingTestInstance) DescribeAdapters() []hal.AdapterDescription {
+	return []hal.AdapterDescription{{
+		Info: gputypes.AdapterInfo{
+			Name:       "llvmpipe (LLVM 17.0.6, 256 bits)",
+			DeviceType: gputypes.DeviceTypeOther,
+			Backend:    gputypes.BackendVulkan,
+		},
+		Details: hal.AdapterDetails{MemoryHeaps: []hal.MemoryHeap{{Size: 1 << 30}}},
+	}}
+}
+
+func (i *describingTestInstance) Destroy() { i.destroyed = true }
+
+func TestDescribeAdapters(t *testing.T) {
+	describer := &describingTestInstance{t: t}
+	providersMu.Lock()
+	savedProviders := providers
+	providers = map[gputypes.Backend]BackendProvider{
+		gputypes.BackendVulkan: &testProvider{variant: gputypes.BackendVulkan, available: true, instance: describer},
+		gputypes.BackendDX12:   &testProvider{variant: gputypes.BackendDX12, available: true, instance: &providerBackedTestInstance{}},
+		gputypes.BackendGL:     &testProvider{variant: gputypes.BackendGL, available: true, instance: &providerBackedTestInstance{}},
+	}
+	providersMu.Unlock()
+	t.Cleanup(func() {
+		providersMu.Lock()
+		providers = savedProviders
+		providersMu.Unlock()
+	})
+
+	descriptions, report := DescribeAdapters(
+		&gputypes.InstanceDescriptor{Backends: gputypes.BackendsVulkan | gputypes.BackendsDX12 | gputypes.BackendsGL},
+		&InstanceOptions{Software: SoftwareDisabled},
+	)
+	if len(descriptions) != 2 {
+		t.Fatalf("DescribeAdapters returned %d adapters, want 2", len(descriptions))
+	}
+	if got := descriptions[0].Info.DeviceType; got != gputypes.DeviceTypeCPU {
+		t.Errorf("described llvmpipe device type = %v, want CPU", got)
+	}
+	if len(descriptions[0].Details.MemoryHeaps) != 1 {
+		t.Errorf("described details = %+v, want one memory heap", descriptions[0].Details)
+	}
+	if got := descriptions[1].Info.Name; got != "provider-backed test adapter" {
+		t.Errorf("enumerated adapter name = %q", got)
+	}
+	if !describer.destroyed {
+		t.Error("DescribeAdapters did not destroy the HAL instance")
+	}
+
+	want := map[gputypes.Backend]BackendStatus{
+		gputypes.BackendVulkan: BackendStatusReady,
+		gputypes.BackendDX12:   BackendStatusReady,
+		gputypes.BackendGL:     BackendStatusDeferred,
+	}
+	for _, a := range report.Attempts {
+		if status, ok := want[a.Backend]; ok && a.Status != status {
+			t.Errorf("%v status = %v, want %v", a.Backend, a.Status, status)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// BackendEnv names the environment variable that forces the backend
+// fallback chain, such as "vulkan" or "dx12,gl". It overrides the
+// descriptor's backend mask, BackendOrder and Software mode, so a backend
+// can be chosen for triage without code changes.
+const BackendEnv = "WGPU_BACKEND"
+
+// parseBackendList parses a comma-separated BackendEnv value into a
+// backend order, dropping repeats. An empty value yields nil.
+func parseBackendList(s string) ([]gputypes.Backend, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var order []gputypes.Backend
+	for _, name := range strings.Split(s, ",") {
+		backend, err := ParseBackendType(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(order, backend) {
+			order = append(order, backend)
+		}
+	}
+	return order, nil
+}
+
+// resolveBackendEnv applies the BackendEnv override. When it is set, the
+// returned descriptor enables exactly the listed backends, the order is
+// the list and the software backend takes part only when listed. Otherwise
+// desc and order are returned as they are, with software resolved against
+// SoftwareModeEnv. Invalid values are logged and ignored.
+func resolveBackendEnv(desc *gputypes.InstanceDescriptor, order []gputypes.Backend, software SoftwareMode, log *hal.Log) (*gputypes.InstanceDescriptor, []gputypes.Backend, SoftwareMode) {
+	value := os.Getenv(BackendEnv)
+	forced, err := parseBackendList(value)
+	if err != nil {
+		log.For(hal.LogAdapter).Warn("core: ignoring "+BackendEnv, "value", value, "error", err)
+	}
+	if len(forced) == 0 {
+		return desc, order, resolveSoftwareMode(software)
+	}
+
+	overridden := *desc
+	overridden.Backends = gputypes.BackendsNone
+	for _, backend := range forced {
+		if backend != gputypes.BackendEmpty {
+			overridden.Backends |= 1 << backend
+		}
+	}
+	log.For(hal.LogAdapter).Info("core: backend chain set by "+BackendEnv, "backends", forced)
+	return &overridden, forced, SoftwareAuto
+}
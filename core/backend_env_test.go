@@ -0,0 +1,78 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestParseBackendType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want gputypes.Backend
+	}{
+		{"vulkan", gputypes.BackendVulkan},
+		{" VK ", gputypes.BackendVulkan},
+		{"dx12", gputypes.BackendDX12},
+		{"D3D12", gputypes.BackendDX12},
+		{"metal", gputypes.BackendMetal},
+		{"gles", gputypes.BackendGL},
+		{"software", gputypes.BackendEmpty},
+		{"webgpu", gputypes.BackendBrowserWebGPU},
+	}
+	for _, tt := range tests {
+		got, err := ParseBackendType(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBackendType(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "vulkan2", "d3d11"} {
+		if _, err := ParseBackendType(in); err == nil {
+			t.Errorf("ParseBackendType(%q) succeeded", in)
+		}
+	}
+}
+
+func TestResolveBackendEnv(t *testing.T) {
+	desc := gputypes.DefaultInstanceDescriptor()
+	order := []gputypes.Backend{gputypes.BackendDX12}
+
+	t.Setenv(BackendEnv, "")
+	t.Setenv(SoftwareModeEnv, "only")
+	gotDesc, gotOrder, software := resolveBackendEnv(&desc, order, SoftwareAuto, nil)
+	if gotDesc != &desc || !slices.Equal(gotOrder, order) || software != SoftwareOnly {
+		t.Errorf("unset: got %v, %v, %v; want the inputs and SoftwareOnly", gotDesc.Backends, gotOrder, software)
+	}
+
+	// The override beats the descriptor, BackendOrder and GOGPU_SOFTWARE.
+	t.Setenv(BackendEnv, "gl, vulkan,gl")
+	gotDesc, gotOrder, software = resolveBackendEnv(&desc, order, SoftwareDisabled, nil)
+	if want := gputypes.BackendsGL | gputypes.BackendsVulkan; gotDesc.Backends != want {
+		t.Errorf("backends = %v, want %v", gotDesc.Backends, want)
+	}
+	if want := []gputypes.Backend{gputypes.BackendGL, gputypes.BackendVulkan}; !slices.Equal(gotOrder, want) {
+		t.Errorf("order = %v, want %v", gotOrder, want)
+	}
+	if software != SoftwareAuto {
+		t.Errorf("software = %v, want auto", software)
+	}
+	if desc.Backends != gputypes.DefaultInstanceDescriptor().Backends {
+		t.Error("resolveBackendEnv modified the caller's descriptor")
+	}
+
+	t.Setenv(BackendEnv, "software")
+	gotDesc, gotOrder, _ = resolveBackendEnv(&desc, nil, SoftwareAuto, nil)
+	if gotDesc.Backends != gputypes.BackendsNone || !slices.Equal(gotOrder, []gputypes.Backend{gputypes.BackendEmpty}) {
+		t.Errorf("software: got %v, %v", gotDesc.Backends, gotOrder)
+	}
+
+	t.Setenv(BackendEnv, "vulkan,bogus")
+	t.Setenv(SoftwareModeEnv, "")
+	gotDesc, gotOrder, _ = resolveBackendEnv(&desc, order, SoftwareAuto, nil)
+	if gotDesc != &desc || !slices.Equal(gotOrder, order) {
+		t.Errorf("invalid value: got %v, %v; want the inputs", gotDesc.Backends, gotOrder)
+	}
+}
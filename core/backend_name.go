@@ -0,0 +1,33 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ParseBackendType parses a backend name, case-insensitively: "vulkan"
+// ("vk"), "dx12" ("d3d12"), "metal" ("mtl"), "gl" ("gles", "opengl"),
+// "webgpu" ("browser") or "software" ("cpu"). The software backend is
+// registered as gputypes.BackendEmpty.
+func ParseBackendType(s string) (gputypes.Backend, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "vulkan", "vk":
+		return gputypes.BackendVulkan, nil
+	case "dx12", "d3d12":
+		return gputypes.BackendDX12, nil
+	case "metal", "mtl":
+		return gputypes.BackendMetal, nil
+	case "gl", "gles", "opengl":
+		return gputypes.BackendGL, nil
+	case "webgpu", "browser":
+		return gputypes.BackendBrowserWebGPU, nil
+	case "software", "cpu":
+		return gputypes.BackendEmpty, nil
+	}
+	return gputypes.BackendEmpty, fmt.Errorf("unknown backend %q (want vulkan, dx12, metal, gl or software)", s)
+}
@@ -0,0 +1,102 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// BackendAttempt records what happened to one backend during instance
+// creation.
+type BackendAttempt struct {
+	Backend gputypes.Backend
+	Status  BackendStatus
+	// Adapters is the number of adapters registered from this backend.
+	Adapters int
+	// Err is the underlying failure, if any.
+	Err error
+}
+
+// BackendReport lists backend attempts in the order they were tried,
+// followed by backends that were not tried at all.
+type BackendReport struct {
+	Attempts []BackendAttempt
+}
+
+// String renders the report on one line, e.g.
+// "Vulkan: instance failed (libvulkan.so.1: not found); Empty: ready (1 adapter)".
+func (r BackendReport) String() string {
+	if len(r.Attempts) == 0 {
+		return "no backends registered"
+	}
+	parts := make([]string, 0, len(r.Attempts))
+	for _, a := range r.Attempts {
+		part := fmt.Sprintf("%s: %s", a.Backend, a.Status)
+		switch {
+		case a.Err != nil:
+			part += fmt.Sprintf(" (%v)", a.Err)
+		case a.Status == BackendStatusReady && a.Adapters == 1:
+			part += " (1 adapter)"
+		case a.Status == BackendStatusReady:
+			part += fmt.Sprintf(" (%d adapters)", a.Adapters)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// backendSlot is one step of the fallback chain. provider is nil when the
+// backend was requested but is not registered.
+type backendSlot struct {
+	backend  gputypes.Backend
+	provider BackendProvider
+}
+
+// planBackends arranges the enabled providers into the fallback chain
+// described by order. Registered providers that are not enabled, or whose
+// backend order does not list, are returned as disabled; a backend order
+// lists but that is registered only as disabled gets no slot, so every
+// backend is reported once. A nil order keeps the default priority.
+func planBackends(registered, enabled []BackendProvider, order []gputypes.Backend) (slots []backendSlot, disabled []BackendProvider) {
+	masked := make(map[gputypes.Backend]bool)
+	for _, p := range registered {
+		if !containsProvider(enabled, p) {
+			disabled = append(disabled, p)
+			masked[p.Variant()] = true
+		}
+	}
+	if order == nil {
+		slots = make([]backendSlot, len(enabled))
+		for i, p := range enabled {
+			slots[i] = backendSlot{backend: p.Variant(), provider: p}
+		}
+		return slots, disabled
+	}
+	byBackend := make(map[gputypes.Backend]BackendProvider, len(enabled))
+	for _, p := range enabled {
+		byBackend[p.Variant()] = p
+	}
+	seen := make(map[gputypes.Backend]bool, len(order))
+	for _, backend := range order {
+		if seen[backend] {
+			continue
+		}
+		seen[backend] = true
+		if byBackend[backend] == nil && masked[backend] {
+			continue
+		}
+		slots = append(slots, backendSlot{backend: backend, provider: byBackend[backend]})
+	}
+	for _, p := range enabled {
+		if !seen[p.Variant()] {
+			disabled = append(disabled, p)
+		}
+	}
+	return slots, disabled
+}
@@ -0,0 +1,94 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestPlanBackends(t *testing.T) {
+	vulkan := &testProvider{variant: gputypes.BackendVulkan, available: true}
+	gl := &testProvider{variant: gputypes.BackendGL, available: true}
+	empty := &testProvider{variant: gputypes.BackendEmpty, available: true}
+	providers := []BackendProvider{vulkan, gl, empty}
+
+	slots, disabled := planBackends(providers, providers, nil)
+	if len(slots) != 3 || len(disabled) != 0 {
+		t.Fatalf("nil order: slots=%d disabled=%d, want 3/0", len(slots), len(disabled))
+	}
+
+	order := []gputypes.Backend{gputypes.BackendEmpty, gputypes.BackendDX12, gputypes.BackendVulkan, gputypes.BackendEmpty}
+	slots, disabled = planBackends(providers, providers, order)
+	want := []backendSlot{
+		{backend: gputypes.BackendEmpty, provider: empty},
+		{backend: gputypes.BackendDX12},
+		{backend: gputypes.BackendVulkan, provider: vulkan},
+	}
+	if len(slots) != len(want) {
+		t.Fatalf("slots = %v, want %v", slots, want)
+	}
+	for i := range want {
+		if slots[i] != want[i] {
+			t.Fatalf("slot %d = %+v, want %+v", i, slots[i], want[i])
+		}
+	}
+	if len(disabled) != 1 || disabled[0] != gl {
+		t.Fatalf("disabled = %v, want [GL]", disabled)
+	}
+
+	// A backend masked out by the instance flags is only disabled, even when
+	// the order lists it, and is not also reported as not registered.
+	enabled := []BackendProvider{gl, empty}
+	slots, disabled = planBackends(providers, enabled, []gputypes.Backend{gputypes.BackendVulkan, gputypes.BackendGL})
+	if len(slots) != 1 || slots[0].provider != gl {
+		t.Fatalf("masked Vulkan: slots = %+v, want only GL", slots)
+	}
+	if len(disabled) != 2 || disabled[0] != vulkan || disabled[1] != empty {
+		t.Fatalf("masked Vulkan: disabled = %v, want [Vulkan Empty]", disabled)
+	}
+	slots, disabled = planBackends(providers, enabled, nil)
+	if len(slots) != 2 || len(disabled) != 1 || disabled[0] != vulkan {
+		t.Fatalf("masked Vulkan, nil order: slots = %+v, disabled = %v", slots, disabled)
+	}
+}
+
+func TestBackendReportString(t *testing.T) {
+	report := BackendReport{Attempts: []BackendAttempt{
+		{Backend: gputypes.BackendVulkan, Status: BackendStatusInstanceFailed, Err: errors.New("libvulkan.so.1 not found")},
+		{Backend: gputypes.BackendEmpty, Status: BackendStatusReady, Adapters: 1},
+	}}
+	got := report.String()
+	for _, want := range []string{"Vulkan: instance failed (libvulkan.so.1 not found)", "Empty: ready (1 adapter)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, missing %q", got, want)
+		}
+	}
+	if got := (BackendReport{}).String(); got != "no backends registered" {
+		t.Errorf("empty String() = %q", got)
+	}
+}
+
+func TestNewInstanceWithOptionsReportsSkippedBackends(t *testing.T) {
+	inst := NewInstanceWithOptions(nil, &InstanceOptions{BackendOrder: []gputypes.Backend{gputypes.BackendDX12}})
+	defer inst.Destroy()
+
+	report := inst.BackendReport()
+	if len(report.Attempts) == 0 || report.Attempts[0].Backend != gputypes.BackendDX12 {
+		t.Fatalf("first attempt = %+v, want DX12", report.Attempts)
+	}
+	for _, a := range report.Attempts[1:] {
+		if a.Status != BackendStatusDisabled {
+			t.Errorf("%v status = %v, want disabled (not in BackendOrder)", a.Backend, a.Status)
+		}
+	}
+	if len(inst.EnumerateAdapters()) != 0 {
+		return // a DX12 provider is registered on this platform
+	}
+	if _, err := inst.RequestAdapter(nil); err == nil || !strings.Contains(err.Error(), "DX12") {
+		t.Fatalf("RequestAdapter error = %v, want report mentioning DX12", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import "fmt"
+
+// BackendStatus is the outcome of one backend during instance creation.
+// Unlike the rest of core it has no build constraints, so the root package
+// can alias it in every build, including js/wasm.
+type BackendStatus uint8
+
+const (
+	// BackendStatusReady means the backend produced at least one adapter.
+	BackendStatusReady BackendStatus = iota
+	// BackendStatusDeferred means the backend's HAL instance was created but
+	// adapter enumeration waits for a surface (GLES needs a GL context).
+	BackendStatusDeferred
+	// BackendStatusNotRegistered means the backend was requested in
+	// BackendOrder but no provider is registered (missing blank import or
+	// unsupported platform).
+	BackendStatusNotRegistered
+	// BackendStatusDisabled means the backend is registered but excluded by
+	// the Backends mask or absent from BackendOrder.
+	BackendStatusDisabled
+	// BackendStatusInstanceFailed means creating the HAL instance failed,
+	// typically because the driver library could not be loaded.
+	BackendStatusInstanceFailed
+	// BackendStatusNoAdapters means the HAL instance exposed no adapters.
+	BackendStatusNoAdapters
+	// BackendStatusTestOnly means the backend is the noop test backend, which
+	// is never used for real adapter enumeration.
+	BackendStatusTestOnly
+)
+
+// String returns a human-readable status name.
+func (s BackendStatus) String() string {
+	switch s {
+	case BackendStatusReady:
+		return "ready"
+	case BackendStatusDeferred:
+		return "deferred"
+	case BackendStatusNotRegistered:
+		return "not registered"
+	case BackendStatusDisabled:
+		return "disabled"
+	case BackendStatusInstanceFailed:
+		return "instance failed"
+	case BackendStatusNoAdapters:
+		return "no adapters"
+	case BackendStatusTestOnly:
+		return "test only"
+	default:
+		return fmt.Sprintf("BackendStatus(%d)", uint8(s))
+	}
+}
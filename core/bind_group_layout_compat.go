@@ -0,0 +1,119 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// BindGroupLayoutMismatch compares the entries of the layout a pipeline
+// expects at a bind group slot with those of the layout the bound bind group
+// was created with. It returns "" when they are compatible: the same
+// bindings, in any order, with the same visibility and resource type.
+// Otherwise it describes the first difference by binding number, naming the
+// binding and both of its types, for draw and dispatch validation errors.
+// Matches Rust wgpu-core's bgl compatibility check (command/bind.rs), which
+// compares layouts by their sorted EntryMap.
+func BindGroupLayoutMismatch(expected, assigned []gputypes.BindGroupLayoutEntry) string {
+	exp := sortedByBinding(expected)
+	asg := sortedByBinding(assigned)
+	for i, j := 0, 0; i < len(exp) || j < len(asg); {
+		switch {
+		case j == len(asg) || (i < len(exp) && exp[i].Binding < asg[j].Binding):
+			return fmt.Sprintf("binding %d (%s) is missing from the bind group's layout",
+				exp[i].Binding, DescribeBindGroupLayoutEntry(&exp[i]))
+		case i == len(exp) || asg[j].Binding < exp[i].Binding:
+			return fmt.Sprintf("binding %d (%s) of the bind group's layout is not in the pipeline's layout",
+				asg[j].Binding, DescribeBindGroupLayoutEntry(&asg[j]))
+		}
+		if !bindGroupLayoutEntriesEqual(&exp[i], &asg[j]) {
+			return fmt.Sprintf("binding %d is %s in the pipeline's layout but %s in the bind group's layout",
+				exp[i].Binding, DescribeBindGroupLayoutEntry(&exp[i]), DescribeBindGroupLayoutEntry(&asg[j]))
+		}
+		i++
+		j++
+	}
+	return ""
+}
+
+// DescribeBindGroupLayoutEntry returns a short description of the resource
+// type and visibility of entry, such as "Uniform buffer (min size 64),
+// visible to Vertex|Fragment".
+func DescribeBindGroupLayoutEntry(entry *gputypes.BindGroupLayoutEntry) string {
+	var sb strings.Builder
+	kinds := 0
+	if b := entry.Buffer; b != nil {
+		kinds++
+		fmt.Fprintf(&sb, "%s buffer", b.Type)
+		if b.HasDynamicOffset {
+			sb.WriteString(" with dynamic offset")
+		}
+		if b.MinBindingSize != 0 {
+			fmt.Fprintf(&sb, " (min size %d)", b.MinBindingSize)
+		}
+	}
+	if s := entry.Sampler; s != nil {
+		if kinds++; kinds > 1 {
+			sb.WriteString(" + ")
+		}
+		fmt.Fprintf(&sb, "%s sampler", s.Type)
+	}
+	if t := entry.Texture; t != nil {
+		if kinds++; kinds > 1 {
+			sb.WriteString(" + ")
+		}
+		fmt.Fprintf(&sb, "%s texture (%s", t.SampleType, t.ViewDimension)
+		if t.Multisampled {
+			sb.WriteString(", multisampled")
+		}
+		sb.WriteByte(')')
+	}
+	if st := entry.StorageTexture; st != nil {
+		if kinds++; kinds > 1 {
+			sb.WriteString(" + ")
+		}
+		fmt.Fprintf(&sb, "%s storage texture (%s, %s)", st.Access, st.Format, st.ViewDimension)
+	}
+	if kinds == 0 {
+		sb.WriteString("no resource")
+	}
+	fmt.Fprintf(&sb, ", visible to %s", entry.Visibility)
+	return sb.String()
+}
+
+// bindGroupLayoutEntriesEqual compares two entries with the same binding
+// number, dereferencing their optional binding layouts to compare them by
+// value rather than by pointer identity.
+func bindGroupLayoutEntriesEqual(a, b *gputypes.BindGroupLayoutEntry) bool {
+	return a.Visibility == b.Visibility &&
+		optionalEqual(a.Buffer, b.Buffer) &&
+		optionalEqual(a.Sampler, b.Sampler) &&
+		optionalEqual(a.Texture, b.Texture) &&
+		optionalEqual(a.StorageTexture, b.StorageTexture)
+}
+
+// optionalEqual compares two optional (pointer) values by dereferenced content.
+// Both nil → equal; one nil → not equal; both non-nil → compare dereferenced values.
+func optionalEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sortedByBinding returns entries sorted by binding number, copying them
+// only when they are not sorted already.
+func sortedByBinding(entries []gputypes.BindGroupLayoutEntry) []gputypes.BindGroupLayoutEntry {
+	byBinding := func(a, b gputypes.BindGroupLayoutEntry) int { return cmp.Compare(a.Binding, b.Binding) }
+	if slices.IsSortedFunc(entries, byBinding) {
+		return entries
+	}
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, byBinding)
+	return sorted
+}
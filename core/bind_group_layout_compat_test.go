@@ -0,0 +1,107 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestBindGroupLayoutMismatch(t *testing.T) {
+	uniform := gputypes.BindGroupLayoutEntry{
+		Binding:    0,
+		Visibility: gputypes.ShaderStageVertex,
+		Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform, MinBindingSize: 64},
+	}
+	sampler := gputypes.BindGroupLayoutEntry{
+		Binding:    1,
+		Visibility: gputypes.ShaderStageFragment,
+		Sampler:    &gputypes.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+	}
+	storage := uniform
+	storage.Buffer = &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage}
+	vertexAndFragment := uniform
+	vertexAndFragment.Visibility |= gputypes.ShaderStageFragment
+
+	tests := []struct {
+		name     string
+		expected []gputypes.BindGroupLayoutEntry
+		assigned []gputypes.BindGroupLayoutEntry
+		want     []string // substrings of the mismatch; none means compatible
+	}{
+		{"equal", poolEntries(0, 1), poolEntries(0, 1), nil},
+		{"reordered", []gputypes.BindGroupLayoutEntry{uniform, sampler}, []gputypes.BindGroupLayoutEntry{sampler, uniform}, nil},
+		{"empty", nil, []gputypes.BindGroupLayoutEntry{}, nil},
+		{
+			"missing binding",
+			[]gputypes.BindGroupLayoutEntry{uniform, sampler}, []gputypes.BindGroupLayoutEntry{uniform},
+			[]string{"binding 1 (Filtering sampler, visible to Fragment) is missing"},
+		},
+		{
+			"extra binding",
+			[]gputypes.BindGroupLayoutEntry{sampler}, []gputypes.BindGroupLayoutEntry{uniform, sampler},
+			[]string{"binding 0 (Uniform buffer (min size 64), visible to Vertex)", "not in the pipeline's layout"},
+		},
+		{
+			"buffer type",
+			[]gputypes.BindGroupLayoutEntry{uniform}, []gputypes.BindGroupLayoutEntry{storage},
+			[]string{"binding 0 is Uniform buffer (min size 64), visible to Vertex in the pipeline's layout",
+				"but Storage buffer, visible to Vertex in the bind group's layout"},
+		},
+		{
+			"visibility",
+			[]gputypes.BindGroupLayoutEntry{uniform}, []gputypes.BindGroupLayoutEntry{vertexAndFragment},
+			[]string{"binding 0", "visible to Vertex|Fragment in the bind group's layout"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BindGroupLayoutMismatch(tt.expected, tt.assigned)
+			if (got == "") != (tt.want == nil) {
+				t.Fatalf("BindGroupLayoutMismatch = %q, want compatible = %v", got, tt.want == nil)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("BindGroupLayoutMismatch = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeBindGroupLayoutEntry(t *testing.T) {
+	tests := []struct {
+		entry gputypes.BindGroupLayoutEntry
+		want  string
+	}{
+		{
+			gputypes.BindGroupLayoutEntry{
+				Visibility: gputypes.ShaderStageCompute,
+				Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeReadOnlyStorage, HasDynamicOffset: true},
+			},
+			"ReadOnlyStorage buffer with dynamic offset, visible to Compute",
+		},
+		{
+			gputypes.BindGroupLayoutEntry{
+				Visibility: gputypes.ShaderStageFragment,
+				Texture: &gputypes.TextureBindingLayout{
+					SampleType:    gputypes.TextureSampleTypeDepth,
+					ViewDimension: gputypes.TextureViewDimension2D,
+					Multisampled:  true,
+				},
+			},
+			"Depth texture (" + gputypes.TextureViewDimension2D.String() + ", multisampled), visible to Fragment",
+		},
+		{
+			gputypes.BindGroupLayoutEntry{Visibility: gputypes.ShaderStageVertex},
+			"no resource, visible to Vertex",
+		},
+	}
+	for _, tt := range tests {
+		if got := DescribeBindGroupLayoutEntry(&tt.entry); got != tt.want {
+			t.Errorf("DescribeBindGroupLayoutEntry = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// BindGroupLayoutPool deduplicates a device's bind group layouts by content.
+// Layouts created with equal entries, in any order, share one HAL layout, so
+// they are the same object to pipeline layout compatibility checks and a
+// bind group made for one pipeline can be bound for any other pipeline whose
+// layout was created from the same entries. Matches Rust wgpu-core's
+// bgl::Pool (device/bgl.rs), which keys layouts by their sorted EntryMap.
+//
+// Entries are reference counted: each Acquire needs a Release, and the last
+// Release hands the HAL layout back for destruction.
+//
+// Methods are safe for concurrent use. A nil pool deduplicates nothing;
+// Acquire then creates a new layout on every call.
+type BindGroupLayoutPool struct {
+	mu      sync.Mutex
+	layouts map[string]*PooledBindGroupLayout
+}
+
+// PooledBindGroupLayout is a HAL bind group layout shared by every bind group
+// layout created with the same entries.
+type PooledBindGroupLayout struct {
+	raw     hal.BindGroupLayout
+	entries []gputypes.BindGroupLayoutEntry
+	key     string
+	refs    int
+}
+
+// Raw returns the shared HAL layout.
+func (l *PooledBindGroupLayout) Raw() hal.BindGroupLayout { return l.raw }
+
+// Entries returns the entries of the first layout created with this content.
+// The returned slice must not be modified.
+func (l *PooledBindGroupLayout) Entries() []gputypes.BindGroupLayoutEntry { return l.entries }
+
+// NewBindGroupLayoutPool returns an empty pool.
+func NewBindGroupLayoutPool() *BindGroupLayoutPool {
+	return &BindGroupLayoutPool{layouts: make(map[string]*PooledBindGroupLayout)}
+}
+
+// Acquire returns the pooled layout for entries and takes a reference to it.
+// When no layout with equal entries exists, create makes the HAL layout and
+// the pool keeps a copy of entries; an error from create is returned as is.
+func (p *BindGroupLayoutPool) Acquire(
+	entries []gputypes.BindGroupLayoutEntry,
+	create func() (hal.BindGroupLayout, error),
+) (*PooledBindGroupLayout, error) {
+	if p == nil {
+		raw, err := create()
+		if err != nil {
+			return nil, err
+		}
+		return &PooledBindGroupLayout{raw: raw, entries: slices.Clone(entries), refs: 1}, nil
+	}
+
+	key := bindGroupLayoutKey(entries)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if l, ok := p.layouts[key]; ok {
+		l.refs++
+		return l, nil
+	}
+	raw, err := create()
+	if err != nil {
+		return nil, err
+	}
+	l := &PooledBindGroupLayout{raw: raw, entries: slices.Clone(entries), key: key, refs: 1}
+	p.layouts[key] = l
+	return l, nil
+}
+
+// Release drops a reference to l. It reports whether that was the last one,
+// in which case the pool has forgotten l and the caller destroys l.Raw().
+func (p *BindGroupLayoutPool) Release(l *PooledBindGroupLayout) bool {
+	if p == nil || l.key == "" {
+		l.refs--
+		return l.refs == 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l.refs--
+	if l.refs > 0 {
+		return false
+	}
+	if p.layouts[l.key] == l {
+		delete(p.layouts, l.key)
+	}
+	return true
+}
+
+// Len returns the number of distinct layouts in the pool.
+func (p *BindGroupLayoutPool) Len() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.layouts)
+}
+
+// bindGroupLayoutKey encodes entries, sorted by binding, with their optional
+// binding layouts compared by value.
+func bindGroupLayoutKey(entries []gputypes.BindGroupLayoutEntry) string {
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b gputypes.BindGroupLayoutEntry) int {
+		return cmp.Compare(a.Binding, b.Binding)
+	})
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d;", len(sorted)) // never empty, unlike the key of an unpooled layout
+	for i := range sorted {
+		e := &sorted[i]
+		fmt.Fprintf(&sb, "%d:%d", e.Binding, e.Visibility)
+		if e.Buffer != nil {
+			fmt.Fprintf(&sb, ":b%+v", *e.Buffer)
+		}
+		if e.Sampler != nil {
+			fmt.Fprintf(&sb, ":s%+v", *e.Sampler)
+		}
+		if e.Texture != nil {
+			fmt.Fprintf(&sb, ":t%+v", *e.Texture)
+		}
+		if e.StorageTexture != nil {
+			fmt.Fprintf(&sb, ":st%+v", *e.StorageTexture)
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
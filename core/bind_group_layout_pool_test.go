@@ -0,0 +1,87 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/noop"
+)
+
+func poolEntries(bindings ...uint32) []gputypes.BindGroupLayoutEntry {
+	entries := make([]gputypes.BindGroupLayoutEntry, len(bindings))
+	for i, b := range bindings {
+		entries[i] = gputypes.BindGroupLayoutEntry{
+			Binding:    b,
+			Visibility: gputypes.ShaderStageCompute,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage},
+		}
+	}
+	return entries
+}
+
+func TestBindGroupLayoutPool_Deduplicates(t *testing.T) {
+	pool := NewBindGroupLayoutPool()
+	created := 0
+	create := func() (hal.BindGroupLayout, error) {
+		created++
+		return &noop.Resource{}, nil
+	}
+
+	a, err := pool.Acquire(poolEntries(0, 1), create)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	// Equal entries in another order, with distinct pointers, share the layout.
+	b, _ := pool.Acquire(poolEntries(1, 0), create)
+	if a != b || created != 1 || pool.Len() != 1 {
+		t.Fatalf("equal entries: shared = %v, created %d, pool holds %d", a == b, created, pool.Len())
+	}
+
+	readOnly := poolEntries(0, 1)
+	readOnly[1].Buffer.Type = gputypes.BufferBindingTypeReadOnlyStorage
+	c, _ := pool.Acquire(readOnly, create)
+	if c == a || created != 2 {
+		t.Fatal("different entries share a layout")
+	}
+
+	if pool.Release(a) {
+		t.Error("Release with a reference left reported the last release")
+	}
+	if !pool.Release(b) {
+		t.Error("last Release not reported")
+	}
+	if pool.Len() != 1 {
+		t.Errorf("pool holds %d layouts after releasing one, want 1", pool.Len())
+	}
+	if d, _ := pool.Acquire(poolEntries(0, 1), create); d == a || created != 3 {
+		t.Error("a released layout was reused")
+	}
+}
+
+func TestBindGroupLayoutPool_CreateError(t *testing.T) {
+	pool := NewBindGroupLayoutPool()
+	failure := errors.New("out of memory")
+	if _, err := pool.Acquire(poolEntries(0), func() (hal.BindGroupLayout, error) { return nil, failure }); !errors.Is(err, failure) {
+		t.Fatalf("Acquire = %v, want the create error", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("failed creation left %d layouts in the pool", pool.Len())
+	}
+}
+
+func TestBindGroupLayoutPool_Nil(t *testing.T) {
+	var pool *BindGroupLayoutPool
+	create := func() (hal.BindGroupLayout, error) { return &noop.Resource{}, nil }
+	a, _ := pool.Acquire(poolEntries(0), create)
+	b, _ := pool.Acquire(poolEntries(0), create)
+	if a == b {
+		t.Error("nil pool shared a layout")
+	}
+	if !pool.Release(a) {
+		t.Error("nil pool Release should always be the last")
+	}
+}
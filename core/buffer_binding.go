@@ -0,0 +1,87 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WholeSize as a binding size selects the rest of the buffer after the
+// offset, like WebGPU's omitted size argument.
+const WholeSize = ^uint64(0)
+
+// VertexBufferOffsetAlignment is the alignment WebGPU requires of
+// SetVertexBuffer offsets.
+const VertexBufferOffsetAlignment = 4
+
+// ErrBufferRange is the Unwrap target of every BufferRangeError.
+var ErrBufferRange = errors.New("buffer range out of bounds")
+
+// BufferRangeError reports a vertex or index buffer binding whose offset is
+// misaligned or whose range does not fit inside the buffer.
+type BufferRangeError struct {
+	// Command names the offending command, e.g. "RenderPass.SetIndexBuffer".
+	Command string
+	// Label is the buffer's debug label.
+	Label string
+	// Offset and Size are the requested range. Size is WholeSize when the
+	// caller asked for the rest of the buffer.
+	Offset, Size uint64
+	// BufferSize is the size the buffer was created with.
+	BufferSize uint64
+	// Alignment is the required offset alignment.
+	Alignment uint64
+}
+
+// Error implements the error interface.
+func (e *BufferRangeError) Error() string {
+	label := e.Label
+	if label == "" {
+		label = unnamedLabel
+	}
+	if e.Offset%e.Alignment != 0 {
+		return fmt.Sprintf("%s: offset %d into buffer %q is not a multiple of %d",
+			e.Command, e.Offset, label, e.Alignment)
+	}
+	if e.Size == WholeSize {
+		return fmt.Sprintf("%s: offset %d is past the end of buffer %q (size %d)",
+			e.Command, e.Offset, label, e.BufferSize)
+	}
+	return fmt.Sprintf("%s: range [%d, %d+%d) exceeds buffer %q (size %d)",
+		e.Command, e.Offset, e.Offset, e.Size, label, e.BufferSize)
+}
+
+// Unwrap returns ErrBufferRange.
+func (e *BufferRangeError) Unwrap() error {
+	return ErrBufferRange
+}
+
+// ResolveBufferRange checks that offset is a multiple of alignment and that
+// size bytes from offset fit in buffer, and returns the size with WholeSize
+// replaced by the bytes remaining after offset. The HAL receives only
+// resolved sizes, so backends that take a size (DX12 views, the software
+// rasterizer) never read past the buffer.
+func ResolveBufferRange(buffer *Buffer, offset, size, alignment uint64, command string) (uint64, error) {
+	bufferSize := buffer.Size()
+	fits := offset <= bufferSize
+	if fits && size == WholeSize {
+		size = bufferSize - offset
+	} else if fits {
+		fits = size <= bufferSize-offset
+	}
+	if offset%alignment == 0 && fits {
+		return size, nil
+	}
+	return 0, &BufferRangeError{
+		Command:    command,
+		Label:      buffer.Label(),
+		Offset:     offset,
+		Size:       size,
+		BufferSize: bufferSize,
+		Alignment:  alignment,
+	}
+}
@@ -0,0 +1,68 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestResolveBufferRange(t *testing.T) {
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, gputypes.Features(0), gputypes.DefaultLimits(), "TestDevice")
+	buf := NewBuffer(mockBuffer{}, device, gputypes.BufferUsageVertex, 256, "vertices")
+
+	tests := []struct {
+		name         string
+		offset, size uint64
+		alignment    uint64
+		want         uint64
+		wantErr      bool
+	}{
+		{"whole buffer", 0, WholeSize, 4, 256, false},
+		{"rest after offset", 64, WholeSize, 4, 192, false},
+		{"explicit range", 16, 32, 4, 32, false},
+		{"range to end", 128, 128, 4, 128, false},
+		{"empty at end", 256, WholeSize, 4, 0, false},
+		{"past end", 200, 64, 4, 0, true},
+		{"offset past end", 260, WholeSize, 4, 0, true},
+		{"misaligned", 2, 16, 4, 0, true},
+		{"uint16 aligned", 2, 16, 2, 16, false},
+		{"overflowing size", 8, ^uint64(0) - 4, 4, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveBufferRange(buf, tt.offset, tt.size, tt.alignment, "RenderPass.SetVertexBuffer")
+			if tt.wantErr {
+				if !errors.Is(err, ErrBufferRange) {
+					t.Fatalf("got %v, want ErrBufferRange", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("size = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBufferRangeErrorMessage(t *testing.T) {
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, gputypes.Features(0), gputypes.DefaultLimits(), "TestDevice")
+	buf := NewBuffer(mockBuffer{}, device, gputypes.BufferUsageIndex, 64, "indices")
+
+	_, err := ResolveBufferRange(buf, 32, 64, 4, "RenderPass.SetIndexBuffer")
+	want := `RenderPass.SetIndexBuffer: range [32, 32+64) exceeds buffer "indices" (size 64)`
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+
+	_, err = ResolveBufferRange(buf, 6, WholeSize, 4, "RenderPass.SetIndexBuffer")
+	want = `RenderPass.SetIndexBuffer: offset 6 into buffer "indices" is not a multiple of 4`
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
@@ -227,6 +227,11 @@ type BufferMapData struct {
 	// mapState == BufferMapStateMapped. Cleared on Unmap/Destroy.
 	mapping hal.BufferMapping
 
+	// staged is the host memory behind a MappedAtCreation mapping of a
+	// buffer that cannot be mapped itself. UnmapBuffer hands it to the
+	// caller for upload. Nil otherwise.
+	staged []byte
+
 	// mappedRanges records every currently-live MappedRange for overlap
 	// detection (WebGPU spec §5.3.4). Typical case is 1 or 2 entries; a
 	// slice keeps this zero-alloc for the common case after the initial
@@ -328,11 +333,13 @@ func (b *Buffer) BeginMap(mode MapModeInternal, offset, size uint64) *BufferMapE
 	switch mode {
 	case MapModeInternalRead:
 		if !b.usage.Contains(gputypes.BufferUsageMapRead) {
-			return &BufferMapError{Kind: BufferMapErrKindInvalidMode}
+			return &BufferMapError{Kind: BufferMapErrKindInvalidMode,
+				Wrapped: b.device.CheckBufferUsage(b, gputypes.BufferUsageMapRead, "Buffer.Map")}
 		}
 	case MapModeInternalWrite:
 		if !b.usage.Contains(gputypes.BufferUsageMapWrite) {
-			return &BufferMapError{Kind: BufferMapErrKindInvalidMode}
+			return &BufferMapError{Kind: BufferMapErrKindInvalidMode,
+				Wrapped: b.device.CheckBufferUsage(b, gputypes.BufferUsageMapWrite, "Buffer.Map")}
 		}
 	default:
 		return &BufferMapError{Kind: BufferMapErrKindInvalidMode}
@@ -402,22 +409,25 @@ func (b *Buffer) ResolveMap(guard SnatchGuard, halDevice hal.Device) {
 // UnmapBuffer transitions the buffer from Mapped or Pending back to Idle.
 //
 //   - Mapped  → Idle : hal.Device.UnmapBuffer is called, generation bumped,
-//     outstanding MappedRange handles are detached.
+//     outstanding MappedRange handles are detached. For a staged
+//     MappedAtCreation mapping (see InstallStagedMappedAtCreation) the HAL
+//     buffer was never mapped; the host memory is returned instead and the
+//     caller must upload it to the buffer.
 //   - Pending → Idle : the pending request is canceled; the waiter
 //     receives ErrMapCancelled.
 //   - Idle    : returns ErrMapNotMapped.
 //   - Destroyed : returns ErrBufferDestroyed.
-func (b *Buffer) UnmapBuffer(guard SnatchGuard, halDevice hal.Device) *BufferMapError {
+func (b *Buffer) UnmapBuffer(guard SnatchGuard, halDevice hal.Device) (staged []byte, err *BufferMapError) {
 	md := b.ensureMapData()
 	md.mu.Lock()
 
 	switch b.mapState {
 	case BufferMapStateDestroyed:
 		md.mu.Unlock()
-		return &BufferMapError{Kind: BufferMapErrKindDestroyed}
+		return nil, &BufferMapError{Kind: BufferMapErrKindDestroyed}
 	case BufferMapStateIdle:
 		md.mu.Unlock()
-		return &BufferMapError{Kind: BufferMapErrKindNotMapped}
+		return nil, &BufferMapError{Kind: BufferMapErrKindNotMapped}
 	case BufferMapStatePending:
 		b.mapState = BufferMapStateIdle
 		md.pendingMode = 0
@@ -427,7 +437,7 @@ func (b *Buffer) UnmapBuffer(guard SnatchGuard, halDevice hal.Device) *BufferMap
 		waiter := md.waiter
 		md.mu.Unlock()
 		waiter.Signal(&BufferMapError{Kind: BufferMapErrKindCancelled})
-		return nil
+		return nil, nil
 	case BufferMapStateMapped:
 		// Detach all outstanding ranges and unmap the HAL buffer.
 		md.mappedRanges = md.mappedRanges[:0]
@@ -440,16 +450,20 @@ func (b *Buffer) UnmapBuffer(guard SnatchGuard, halDevice hal.Device) *BufferMap
 		md.pendingOffset = 0
 		md.pendingSize = 0
 		md.pendingMode = 0
+		staged, md.staged = md.staged, nil
 		b.mapState = BufferMapStateIdle
 		md.mu.Unlock()
 
+		if staged != nil {
+			return staged, nil
+		}
 		if halDevice != nil && hbuf != nil {
 			_ = halDevice.UnmapBuffer(*hbuf)
 		}
-		return nil
+		return nil, nil
 	}
 	md.mu.Unlock()
-	return &BufferMapError{Kind: BufferMapErrKindNotMapped}
+	return nil, &BufferMapError{Kind: BufferMapErrKindNotMapped}
 }
 
 // MarkDestroyed transitions the buffer to the terminal Destroyed state,
@@ -468,6 +482,7 @@ func (b *Buffer) MarkDestroyed() {
 	wasPending := b.mapState == BufferMapStatePending
 	b.mapState = BufferMapStateDestroyed
 	md.mappedRanges = md.mappedRanges[:0]
+	md.staged = nil
 	md.generation.Add(1)
 	waiter := md.waiter
 	md.mu.Unlock()
@@ -557,3 +572,23 @@ func (b *Buffer) InstallMappedAtCreation(guard SnatchGuard, halDevice hal.Device
 	b.mapState = BufferMapStateMapped
 	return nil
 }
+
+// InstallStagedMappedAtCreation maps a new buffer created with
+// MappedAtCreation but without MapWrite onto host memory. Such buffers
+// live in whatever memory their usage calls for, often device-local memory
+// the CPU cannot reach, so the caller writes the host copy and UnmapBuffer
+// returns it for upload through the queue. Matches Rust wgpu-core, which
+// maps a staging buffer in this case and copies it into the buffer on
+// unmap (device/resource.rs create_buffer).
+func (b *Buffer) InstallStagedMappedAtCreation() {
+	md := b.ensureMapData()
+	md.mu.Lock()
+	defer md.mu.Unlock()
+	// The HAL buffer is padded to COPY_BUFFER_ALIGNMENT; so is the upload.
+	md.staged = make([]byte, max((b.size+3)&^3, 4))
+	md.mapping = hal.BufferMapping{Ptr: unsafe.Pointer(&md.staged[0]), IsCoherent: true}
+	md.pendingOffset = 0
+	md.pendingSize = b.size
+	md.pendingMode = MapModeInternalWrite
+	b.mapState = BufferMapStateMapped
+}
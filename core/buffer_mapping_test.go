@@ -402,7 +402,7 @@ func TestUnmapBuffer_FromMapped(t *testing.T) {
 	md.mu.Unlock()
 
 	guard := device.SnatchLock().Read()
-	mapErr := buf.UnmapBuffer(guard, *device.raw.Get(guard))
+	_, mapErr := buf.UnmapBuffer(guard, *device.raw.Get(guard))
 	guard.Release()
 
 	if mapErr != nil {
@@ -426,7 +426,7 @@ func TestUnmapBuffer_FromPending(t *testing.T) {
 
 	waiter := buf.Waiter()
 	guard := device.SnatchLock().Read()
-	mapErr := buf.UnmapBuffer(guard, *device.raw.Get(guard))
+	_, mapErr := buf.UnmapBuffer(guard, *device.raw.Get(guard))
 	guard.Release()
 
 	if mapErr != nil {
@@ -446,7 +446,7 @@ func TestUnmapBuffer_FromPending(t *testing.T) {
 func TestUnmapBuffer_FromIdle(t *testing.T) {
 	buf := newTestBuffer(gputypes.BufferUsageMapRead, 1024)
 	// nil guard/device are ok here since the function checks state first.
-	mapErr := buf.UnmapBuffer(SnatchGuard{}, nil)
+	_, mapErr := buf.UnmapBuffer(SnatchGuard{}, nil)
 	if mapErr == nil || mapErr.Kind != BufferMapErrKindNotMapped {
 		t.Errorf("UnmapBuffer() from Idle = %v, want NotMapped", mapErr)
 	}
@@ -455,7 +455,7 @@ func TestUnmapBuffer_FromIdle(t *testing.T) {
 func TestUnmapBuffer_FromDestroyed(t *testing.T) {
 	buf := newTestBuffer(gputypes.BufferUsageMapRead, 1024)
 	buf.mapState = BufferMapStateDestroyed
-	mapErr := buf.UnmapBuffer(SnatchGuard{}, nil)
+	_, mapErr := buf.UnmapBuffer(SnatchGuard{}, nil)
 	if mapErr == nil || mapErr.Kind != BufferMapErrKindDestroyed {
 		t.Errorf("UnmapBuffer() from Destroyed = %v, want Destroyed", mapErr)
 	}
@@ -0,0 +1,97 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ErrBufferUsage is the Unwrap target of every BufferUsageError.
+var ErrBufferUsage = errors.New("buffer used without required usage")
+
+// BufferUsageError reports a command that used a buffer without the usage
+// flag WebGPU requires for it, e.g. a copy source lacking CopySrc.
+type BufferUsageError struct {
+	// Command names the offending command and argument,
+	// e.g. "CommandEncoder.CopyBufferToBuffer source".
+	Command string
+	// Label is the buffer's debug label.
+	Label string
+	// Required is the usage the command needs.
+	Required gputypes.BufferUsage
+	// Actual is the usage the buffer was created with.
+	Actual gputypes.BufferUsage
+}
+
+// Error implements the error interface.
+func (e *BufferUsageError) Error() string {
+	label := e.Label
+	if label == "" {
+		label = unnamedLabel
+	}
+	return fmt.Sprintf("%s: buffer %q missing usage %s (has %s)",
+		e.Command, label, bufferUsageNames(e.Required), bufferUsageNames(e.Actual))
+}
+
+// Unwrap returns ErrBufferUsage.
+func (e *BufferUsageError) Unwrap() error {
+	return ErrBufferUsage
+}
+
+// CheckBufferUsage returns a *BufferUsageError when buffer lacks any flag in
+// required. It returns nil when BufferUsageValidation is disabled on the
+// device or buffer is nil (nil buffers are reported by the caller).
+func (d *Device) CheckBufferUsage(buffer *Buffer, required gputypes.BufferUsage, command string) error {
+	if d == nil || !d.BufferUsageValidation || buffer == nil {
+		return nil
+	}
+	if buffer.Usage().Contains(required) {
+		return nil
+	}
+	return &BufferUsageError{
+		Command:  command,
+		Label:    buffer.Label(),
+		Required: required,
+		Actual:   buffer.Usage(),
+	}
+}
+
+// bufferUsageNames renders usage flags as "CopySrc|Vertex".
+func bufferUsageNames(usage gputypes.BufferUsage) string {
+	if usage == 0 {
+		return "None"
+	}
+	names := []struct {
+		flag gputypes.BufferUsage
+		name string
+	}{
+		{gputypes.BufferUsageMapRead, "MapRead"},
+		{gputypes.BufferUsageMapWrite, "MapWrite"},
+		{gputypes.BufferUsageCopySrc, "CopySrc"},
+		{gputypes.BufferUsageCopyDst, "CopyDst"},
+		{gputypes.BufferUsageIndex, "Index"},
+		{gputypes.BufferUsageVertex, "Vertex"},
+		{gputypes.BufferUsageUniform, "Uniform"},
+		{gputypes.BufferUsageStorage, "Storage"},
+		{gputypes.BufferUsageIndirect, "Indirect"},
+		{gputypes.BufferUsageQueryResolve, "QueryResolve"},
+	}
+	var parts []string
+	for _, n := range names {
+		if usage.Contains(n.flag) {
+			parts = append(parts, n.name)
+			usage &^= n.flag
+		}
+	}
+	if usage != 0 {
+		parts = append(parts, fmt.Sprintf("%#x", uint64(usage)))
+	}
+	return strings.Join(parts, "|")
+}
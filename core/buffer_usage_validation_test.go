@@ -0,0 +1,58 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestDeviceCheckBufferUsage(t *testing.T) {
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, gputypes.Features(0), gputypes.DefaultLimits(), "TestDevice")
+	buf := NewBuffer(mockBuffer{}, device, gputypes.BufferUsageVertex|gputypes.BufferUsageCopyDst, 256, "vertices")
+
+	if err := device.CheckBufferUsage(buf, gputypes.BufferUsageIndex, "RenderPass.SetIndexBuffer"); err != nil {
+		t.Fatalf("validation disabled: got %v, want nil", err)
+	}
+
+	device.BufferUsageValidation = true
+	if err := device.CheckBufferUsage(buf, gputypes.BufferUsageVertex, "RenderPass.SetVertexBuffer"); err != nil {
+		t.Errorf("matching usage: got %v, want nil", err)
+	}
+	if err := device.CheckBufferUsage(nil, gputypes.BufferUsageVertex, "RenderPass.SetVertexBuffer"); err != nil {
+		t.Errorf("nil buffer: got %v, want nil", err)
+	}
+
+	err := device.CheckBufferUsage(buf, gputypes.BufferUsageCopySrc, "CommandEncoder.CopyBufferToBuffer source")
+	if !errors.Is(err, ErrBufferUsage) {
+		t.Fatalf("missing usage: got %v, want ErrBufferUsage", err)
+	}
+	var usageErr *BufferUsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("errors.As(*BufferUsageError) failed for %v", err)
+	}
+	if usageErr.Label != "vertices" || usageErr.Required != gputypes.BufferUsageCopySrc {
+		t.Errorf("got %+v", usageErr)
+	}
+	want := `CommandEncoder.CopyBufferToBuffer source: buffer "vertices" missing usage CopySrc (has CopyDst|Vertex)`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestMapBufferUsageErrorNamesBuffer(t *testing.T) {
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, gputypes.Features(0), gputypes.DefaultLimits(), "TestDevice")
+	device.BufferUsageValidation = true
+	buf := NewBuffer(mockBuffer{}, device, gputypes.BufferUsageCopyDst, 256, "uniforms")
+
+	err := buf.BeginMap(MapModeInternalRead, 0, 256)
+	if err == nil || err.Kind != BufferMapErrKindInvalidMode {
+		t.Fatalf("got %v, want InvalidMode", err)
+	}
+	if !errors.Is(err.Wrapped, ErrBufferUsage) || !strings.Contains(err.Error(), `"uniforms"`) {
+		t.Errorf("error %q does not name the buffer", err.Error())
+	}
+}
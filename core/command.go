@@ -97,9 +97,14 @@ func (s CommandEncoderStatus) String() string {
 // This tracks resources used within a command buffer for validation
 // and synchronization purposes.
 type CommandBufferMutable struct {
-	// pendingBufferBarriers are buffer barriers to emit.
-	// Used in CORE-007 for barrier tracking.
-	pendingBufferBarriers []hal.BufferBarrier //nolint:unused // Will be used in CORE-007
+	// pendingBufferBarriers is the scratch batch TransitionBuffers merges
+	// into before emitting it.
+	pendingBufferBarriers []hal.BufferBarrier
+
+	// bufferStates holds the state each buffer was last transitioned to in
+	// the current pass scope. It is cleared when a pass begins or ends,
+	// because commands recorded outside core change states untracked.
+	bufferStates map[hal.Buffer]hal.BufferUses
 
 	// pendingTextureBarriers are texture barriers to emit.
 	// Used in CORE-007 for barrier tracking.
@@ -368,11 +373,14 @@ func (e *CoreCommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*CoreR
 
 	// Transition to locked state
 	e.status.Store(int32(CommandEncoderStatusLocked))
+	clear(e.mutable.bufferStates)
 
 	pass := &CoreRenderPassEncoder{
 		raw:     halPass,
 		encoder: e,
 		device:  e.device,
+		label:   desc.Label,
+		context: renderPassContext(desc),
 	}
 	e.mutable.activePass = pass
 
@@ -401,6 +409,7 @@ func (e *CoreCommandEncoder) EndRenderPass(pass *CoreRenderPassEncoder) error {
 	// Return to recording state
 	e.status.Store(int32(CommandEncoderStatusRecording))
 	e.mutable.activePass = nil
+	clear(e.mutable.bufferStates)
 
 	return nil
 }
@@ -424,7 +433,7 @@ func (e *CoreCommandEncoder) BeginComputePass(desc *CoreComputePassDescriptor) (
 	halDesc := &hal.ComputePassDescriptor{}
 	if desc != nil {
 		halDesc.Label = desc.Label
-		// TimestampWrites conversion would go here
+		halDesc.TimestampWrites = desc.TimestampWrites
 	}
 
 	// Get HAL encoder
@@ -443,6 +452,7 @@ func (e *CoreCommandEncoder) BeginComputePass(desc *CoreComputePassDescriptor) (
 
 	// Transition to locked state
 	e.status.Store(int32(CommandEncoderStatusLocked))
+	clear(e.mutable.bufferStates)
 
 	pass := &CoreComputePassEncoder{
 		raw:     halPass,
@@ -476,10 +486,93 @@ func (e *CoreCommandEncoder) EndComputePass(pass *CoreComputePassEncoder) error
 	// Return to recording state
 	e.status.Store(int32(CommandEncoderStatusRecording))
 	e.mutable.activePass = nil
+	clear(e.mutable.bufferStates)
 
 	return nil
 }
 
+// TransitionBuffers records buffer state transitions on the HAL encoder and
+// returns the number of barriers emitted.
+//
+// Barriers are merged before they reach the backend: transitions of the
+// same buffer within one call collapse into a single old-to-new barrier,
+// OldUsage is replaced by the state core last transitioned the buffer to
+// in the current pass, and a transition that leaves a buffer in the same
+// read-only state is dropped. Write states are always kept, since a
+// write-to-write barrier orders the two writes.
+//
+// Transitions are allowed in the Recording and Locked states; inside a pass
+// the caller must have ended the HAL pass first.
+func (e *CoreCommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := e.Status()
+	if len(barriers) == 0 || (status != CommandEncoderStatusRecording && status != CommandEncoderStatusLocked) {
+		return 0
+	}
+
+	if e.mutable.bufferStates == nil {
+		e.mutable.bufferStates = make(map[hal.Buffer]hal.BufferUses)
+	}
+	merged := mergeBufferBarriers(e.mutable.pendingBufferBarriers[:0], barriers, e.mutable.bufferStates)
+	e.mutable.pendingBufferBarriers = merged[:0]
+	if len(merged) == 0 {
+		return 0
+	}
+
+	guard := e.device.snatchLock.Read()
+	defer guard.Release()
+
+	halEncoder := e.raw.Get(guard)
+	if halEncoder == nil {
+		return 0
+	}
+	(*halEncoder).TransitionBuffers(merged)
+	clear(merged)
+	return len(merged)
+}
+
+// mergeBufferBarriers appends the merged form of barriers to dst and
+// records each buffer's new state in states.
+func mergeBufferBarriers(dst, barriers []hal.BufferBarrier, states map[hal.Buffer]hal.BufferUses) []hal.BufferBarrier {
+	first := len(dst)
+	for _, b := range barriers {
+		if b.Buffer == nil {
+			continue
+		}
+		current, known := states[b.Buffer]
+		states[b.Buffer] = b.Usage.NewUsage
+
+		merged := false
+		for i := first; i < len(dst); i++ {
+			if dst[i].Buffer == b.Buffer {
+				dst[i].Usage.NewUsage = b.Usage.NewUsage
+				merged = true
+				break
+			}
+		}
+		if merged {
+			continue
+		}
+
+		transition := b.Usage
+		if known {
+			transition.OldUsage = current
+		}
+		dst = append(dst, hal.BufferBarrier{Buffer: b.Buffer, Usage: transition})
+	}
+
+	kept := dst[:first]
+	for _, b := range dst[first:] {
+		if b.Usage.OldUsage != 0 && b.Usage.OldUsage == b.Usage.NewUsage && b.Usage.NewUsage.IsReadOnly() {
+			continue
+		}
+		kept = append(kept, b)
+	}
+	return kept
+}
+
 // Finish completes encoding and returns a command buffer.
 //
 // The encoder must be in the Recording state (not in a pass).
@@ -565,7 +658,9 @@ func (e *CoreCommandEncoder) statusError(operation string) error {
 // convertRenderPassDescriptor converts a core descriptor to HAL descriptor.
 func (e *CoreCommandEncoder) convertRenderPassDescriptor(desc *RenderPassDescriptor) *hal.RenderPassDescriptor {
 	halDesc := &hal.RenderPassDescriptor{
-		Label: desc.Label,
+		Label:           desc.Label,
+		TimestampWrites: desc.TimestampWrites,
+		ViewCount:       desc.ViewCount,
 	}
 
 	// Convert color attachments
@@ -619,6 +714,13 @@ type RenderPassDescriptor struct {
 
 	// DepthStencilAttachment is the depth/stencil target (optional).
 	DepthStencilAttachment *RenderPassDepthStencilAttachment
+
+	// TimestampWrites are timestamp queries written at pass boundaries
+	// (optional).
+	TimestampWrites *hal.RenderPassTimestampWrites
+
+	// ViewCount is the number of multiview views; 0 and 1 mean one view.
+	ViewCount uint32
 }
 
 // RenderPassColorAttachment describes a color attachment.
@@ -686,6 +788,13 @@ type CoreRenderPassEncoder struct {
 	// pipeline is the currently bound render pipeline.
 	pipeline *RenderPipeline
 
+	// label is the pass's debug label.
+	label string
+
+	// context holds the attachment formats and sample count, or nil when
+	// an attachment view did not record them.
+	context *RenderPassContext
+
 	// ended indicates whether End() has been called.
 	ended bool
 }
@@ -695,6 +804,21 @@ func (p *CoreRenderPassEncoder) RawPass() hal.RenderPassEncoder {
 	return p.raw
 }
 
+// CheckPipeline returns a *RenderPassCompatibilityError when a pipeline
+// labeled label, created for the targets in pipeline, cannot be used in this
+// pass. It returns nil when the pass attachments are unknown.
+func (p *CoreRenderPassEncoder) CheckPipeline(label string, pipeline *RenderPassContext) error {
+	if p.context == nil || pipeline == nil {
+		return nil
+	}
+	if err := p.context.CheckPipeline(pipeline); err != nil {
+		err.Pipeline = label
+		err.Pass = p.label
+		return err
+	}
+	return nil
+}
+
 // SetPipeline sets the render pipeline.
 func (p *CoreRenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 	if p.ended {
@@ -707,32 +831,60 @@ func (p *CoreRenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 	// }
 }
 
-// SetVertexBuffer sets a vertex buffer.
-func (p *CoreRenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset uint64) {
+// SetVertexBuffer binds size bytes of buffer from offset to slot. Size may
+// be WholeSize for the rest of the buffer; out-of-range bindings are
+// recorded on the encoder.
+func (p *CoreRenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset, size uint64) {
 	if p.ended {
 		return
 	}
-	if p.raw != nil && buffer != nil {
+	if err := p.device.CheckBufferUsage(buffer, gputypes.BufferUsageVertex, "RenderPass.SetVertexBuffer"); err != nil {
+		p.encoder.SetError(err)
+		return
+	}
+	if buffer == nil {
+		return
+	}
+	size, err := ResolveBufferRange(buffer, offset, size, VertexBufferOffsetAlignment, "RenderPass.SetVertexBuffer")
+	if err != nil {
+		p.encoder.SetError(err)
+		return
+	}
+	if p.raw != nil {
 		guard := p.device.snatchLock.Read()
 		defer guard.Release()
 		halBuffer := buffer.Raw(guard)
 		if halBuffer != nil {
-			p.raw.SetVertexBuffer(slot, halBuffer, offset)
+			p.raw.SetVertexBuffer(slot, halBuffer, offset, size)
 		}
 	}
 }
 
-// SetIndexBuffer sets the index buffer.
-func (p *CoreRenderPassEncoder) SetIndexBuffer(buffer *Buffer, format gputypes.IndexFormat, offset uint64) {
+// SetIndexBuffer binds size bytes of buffer from offset as the index buffer.
+// The offset must be a multiple of the index format size.
+func (p *CoreRenderPassEncoder) SetIndexBuffer(buffer *Buffer, format gputypes.IndexFormat, offset, size uint64) {
 	if p.ended {
 		return
 	}
-	if p.raw != nil && buffer != nil {
+	if err := p.device.CheckBufferUsage(buffer, gputypes.BufferUsageIndex, "RenderPass.SetIndexBuffer"); err != nil {
+		p.encoder.SetError(err)
+		return
+	}
+	if buffer == nil {
+		return
+	}
+	alignment := uint64(max(format.Size(), 1))
+	size, err := ResolveBufferRange(buffer, offset, size, alignment, "RenderPass.SetIndexBuffer")
+	if err != nil {
+		p.encoder.SetError(err)
+		return
+	}
+	if p.raw != nil {
 		guard := p.device.snatchLock.Read()
 		defer guard.Release()
 		halBuffer := buffer.Raw(guard)
 		if halBuffer != nil {
-			p.raw.SetIndexBuffer(halBuffer, format, offset)
+			p.raw.SetIndexBuffer(halBuffer, format, offset, size)
 		}
 	}
 }
@@ -802,10 +954,16 @@ func (p *CoreRenderPassEncoder) DrawIndirect(buffer *Buffer, offset uint64) {
 	p.MultiDrawIndirect(buffer, offset, 1)
 }
 
+// MultiDrawIndirect draws consecutive primitives with GPU-generated
+// parameters read from DrawIndirectArgsSize-byte records.
 func (p *CoreRenderPassEncoder) MultiDrawIndirect(buffer *Buffer, offset uint64, drawCount uint32) {
 	if p.ended || drawCount == 0 {
 		return
 	}
+	if err := checkIndirectBuffer("RenderPass.DrawIndirect", buffer, offset, DrawIndirectArgsSize, drawCount); err != nil {
+		p.encoder.SetError(err)
+		return
+	}
 	if p.raw != nil && buffer != nil {
 		guard := p.device.snatchLock.Read()
 		defer guard.Release()
@@ -827,6 +985,10 @@ func (p *CoreRenderPassEncoder) MultiDrawIndexedIndirect(buffer *Buffer, offset
 	if p.ended || drawCount == 0 {
 		return
 	}
+	if err := checkIndirectBuffer("RenderPass.DrawIndexedIndirect", buffer, offset, DrawIndexedIndirectArgsSize, drawCount); err != nil {
+		p.encoder.SetError(err)
+		return
+	}
 	if p.raw != nil && buffer != nil {
 		guard := p.device.snatchLock.Read()
 		defer guard.Release()
@@ -859,6 +1021,10 @@ func (p *CoreRenderPassEncoder) End() error {
 type CoreComputePassDescriptor struct {
 	// Label is an optional debug name.
 	Label string
+
+	// TimestampWrites are timestamp queries written at pass boundaries
+	// (optional).
+	TimestampWrites *hal.ComputePassTimestampWrites
 }
 
 // CoreComputePassEncoder records compute commands within a pass.
@@ -919,6 +1085,10 @@ func (p *CoreComputePassEncoder) DispatchIndirect(buffer *Buffer, offset uint64)
 	if p.ended {
 		return
 	}
+	if err := checkIndirectBuffer("ComputePass.DispatchIndirect", buffer, offset, DispatchIndirectArgsSize, 1); err != nil {
+		p.encoder.SetError(err)
+		return
+	}
 	if p.raw != nil && buffer != nil {
 		guard := p.device.snatchLock.Read()
 		defer guard.Release()
@@ -1170,10 +1340,16 @@ func (e *CommandEncoderImpl) BeginComputePass(desc *ComputePassDescriptor) (*Com
 	if desc != nil {
 		halDesc.Label = desc.Label
 
-		if desc.TimestampWrites != nil {
-			// Note: QuerySet HAL integration pending.
-			// Skipping timestamp writes until core.QuerySet has HAL.
-			halDesc.TimestampWrites = nil
+		if tw := desc.TimestampWrites; tw != nil {
+			halQuerySet, err := e.resolveQuerySet(tw.QuerySet)
+			if err != nil {
+				return nil, fmt.Errorf("compute pass timestamp writes: %w", err)
+			}
+			halDesc.TimestampWrites = &hal.ComputePassTimestampWrites{
+				QuerySet:                  halQuerySet,
+				BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+				EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+			}
 		}
 	}
 
@@ -1190,6 +1366,27 @@ func (e *CommandEncoderImpl) BeginComputePass(desc *ComputePassDescriptor) (*Com
 	}, nil
 }
 
+// resolveQuerySet looks up the HAL query set behind a QuerySetID.
+func (e *CommandEncoderImpl) resolveQuerySet(id QuerySetID) (hal.QuerySet, error) {
+	querySet, err := GetGlobal().Hub().GetQuerySet(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.device == nil || e.device.SnatchLock() == nil {
+		return nil, ErrResourceDestroyed
+	}
+	guard := e.device.SnatchLock().Read()
+	defer guard.Release()
+	raw := querySet.Raw(guard)
+	if raw == nil {
+		return nil, ErrResourceDestroyed
+	}
+	if querySet.QueryType() != hal.QueryTypeTimestamp {
+		return nil, fmt.Errorf("query set %q is not a timestamp query set", querySet.Label())
+	}
+	return raw, nil
+}
+
 // DeviceCreateCommandEncoder creates a new command encoder for recording GPU commands.
 // This is the entry point for recording command buffers.
 //
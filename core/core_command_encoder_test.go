@@ -588,6 +588,124 @@ func TestIsEncoderStateError(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// TransitionBuffers Tests
+// =============================================================================
+
+// barrierBuffer is a distinguishable HAL buffer for barrier tests.
+type barrierBuffer struct {
+	mockBuffer
+	id int
+}
+
+// barrierRecorder records the batches passed to TransitionBuffers.
+type barrierRecorder struct {
+	mockCommandEncoder
+	batches [][]hal.BufferBarrier
+}
+
+func (r *barrierRecorder) TransitionBuffers(barriers []hal.BufferBarrier) {
+	r.batches = append(r.batches, append([]hal.BufferBarrier(nil), barriers...))
+}
+
+func newBarrierEncoder(t *testing.T) (*CoreCommandEncoder, *barrierRecorder) {
+	t.Helper()
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, gputypes.Features(0), gputypes.DefaultLimits(), "TestDevice")
+	recorder := &barrierRecorder{}
+	encoder, err := device.CreateCommandEncoderWithHAL(recorder, "barriers")
+	if err != nil {
+		t.Fatalf("CreateCommandEncoderWithHAL failed: %v", err)
+	}
+	return encoder, recorder
+}
+
+func transition(buffer hal.Buffer, from, to hal.BufferUses) hal.BufferBarrier {
+	return hal.BufferBarrier{Buffer: buffer, Usage: hal.BufferUsageTransition{OldUsage: from, NewUsage: to}}
+}
+
+func TestCoreCommandEncoder_TransitionBuffers_MergesSameBuffer(t *testing.T) {
+	encoder, recorder := newBarrierEncoder(t)
+	a, b := &barrierBuffer{id: 1}, &barrierBuffer{id: 2}
+
+	n := encoder.TransitionBuffers([]hal.BufferBarrier{
+		transition(a, hal.BufferUseStorageReadWrite, hal.BufferUseCopySrc),
+		transition(b, hal.BufferUseCopyDst, hal.BufferUseVertex),
+		transition(a, hal.BufferUseCopySrc, hal.BufferUseIndirect),
+	})
+	if n != 2 || len(recorder.batches) != 1 {
+		t.Fatalf("emitted %d barriers in %d batches, want 2 in 1", n, len(recorder.batches))
+	}
+	got := recorder.batches[0][0]
+	if got.Buffer != a || got.Usage.OldUsage != hal.BufferUseStorageReadWrite || got.Usage.NewUsage != hal.BufferUseIndirect {
+		t.Errorf("merged barrier = %+v, want StorageReadWrite -> Indirect on a", got)
+	}
+}
+
+func TestCoreCommandEncoder_TransitionBuffers_DropsRedundant(t *testing.T) {
+	encoder, recorder := newBarrierEncoder(t)
+	a := &barrierBuffer{id: 1}
+
+	// A round trip through a read-only state within one batch is a no-op.
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{
+		transition(a, hal.BufferUseIndirect, hal.BufferUseStorageRead),
+		transition(a, hal.BufferUseStorageRead, hal.BufferUseIndirect),
+	}); n != 0 {
+		t.Errorf("read-only round trip emitted %d barriers, want 0", n)
+	}
+
+	// The tracked state (Indirect) overrides the caller's stale OldUsage.
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{
+		transition(a, hal.BufferUseCopyDst, hal.BufferUseIndirect),
+	}); n != 0 {
+		t.Errorf("transition to the current read-only state emitted %d barriers, want 0", n)
+	}
+
+	// Write-to-write transitions order the two writes and are kept.
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{
+		transition(a, hal.BufferUseIndirect, hal.BufferUseStorageReadWrite),
+		transition(a, hal.BufferUseStorageReadWrite, hal.BufferUseStorageReadWrite),
+	}); n != 1 {
+		t.Errorf("write transition emitted %d barriers, want 1", n)
+	}
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{
+		transition(a, hal.BufferUseStorageReadWrite, hal.BufferUseStorageReadWrite),
+	}); n != 1 {
+		t.Errorf("write-to-write transition emitted %d barriers, want 1", n)
+	}
+	if len(recorder.batches) != 2 {
+		t.Errorf("HAL received %d batches, want 2", len(recorder.batches))
+	}
+}
+
+func TestCoreCommandEncoder_TransitionBuffers_PassScope(t *testing.T) {
+	encoder, recorder := newBarrierEncoder(t)
+	a := &barrierBuffer{id: 1}
+
+	encoder.TransitionBuffers([]hal.BufferBarrier{transition(a, hal.BufferUseCopyDst, hal.BufferUseVertex)})
+
+	// Beginning a pass forgets tracked states, so the caller's OldUsage is used.
+	pass, err := encoder.BeginComputePass(nil)
+	if err != nil {
+		t.Fatalf("BeginComputePass failed: %v", err)
+	}
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{transition(a, hal.BufferUseCopyDst, hal.BufferUseVertex)}); n != 1 {
+		t.Errorf("transition after BeginComputePass emitted %d barriers, want 1", n)
+	}
+	if got := recorder.batches[len(recorder.batches)-1][0].Usage.OldUsage; got != hal.BufferUseCopyDst {
+		t.Errorf("OldUsage = %d, want CopyDst", got)
+	}
+	if err := pass.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	if _, err := encoder.Finish(); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+	if n := encoder.TransitionBuffers([]hal.BufferBarrier{transition(a, hal.BufferUseVertex, hal.BufferUseCopyDst)}); n != 0 {
+		t.Errorf("transition after Finish emitted %d barriers, want 0", n)
+	}
+}
+
 // =============================================================================
 // BufferUses and TextureUses Tests
 // =============================================================================
@@ -0,0 +1,117 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// DepthStencilRequirements describes the depth/stencil format a caller needs
+// for Adapter.PreferredDepthStencilFormat.
+type DepthStencilRequirements struct {
+	// Stencil requires a stencil aspect.
+	Stencil bool
+	// MinDepthBits is the minimum depth precision in bits (16, 24 or 32).
+	// Zero accepts any precision.
+	MinDepthBits uint32
+	// Usage is the texture usage the format must support besides
+	// RenderAttachment, which is always required. Only TextureBinding
+	// changes the result.
+	Usage gputypes.TextureUsage
+	// SampleCount is the sample count the format must support. Zero and
+	// one mean single-sampled.
+	SampleCount uint32
+}
+
+// depthStencilCandidates lists the formats PreferredDepthStencilFormat tries,
+// most preferred first. Depth24Plus is the usual default: 24-bit or wider
+// depth that every WebGPU implementation supports. Formats with a stencil
+// aspect come last so depth-only requests get one only as a fallback.
+var depthStencilCandidates = []gputypes.TextureFormat{
+	gputypes.TextureFormatDepth24Plus,
+	gputypes.TextureFormatDepth32Float,
+	gputypes.TextureFormatDepth16Unorm,
+	gputypes.TextureFormatDepth24PlusStencil8,
+	gputypes.TextureFormatDepth32FloatStencil8,
+}
+
+// depthBits returns the depth precision of a depth format, counting
+// Depth24Plus as 24 bits, or 0 for formats without a depth aspect.
+func depthBits(format gputypes.TextureFormat) uint32 {
+	switch format {
+	case gputypes.TextureFormatDepth16Unorm:
+		return 16
+	case gputypes.TextureFormatDepth24Plus, gputypes.TextureFormatDepth24PlusStencil8:
+		return 24
+	case gputypes.TextureFormatDepth32Float, gputypes.TextureFormatDepth32FloatStencil8:
+		return 32
+	}
+	return 0
+}
+
+// PreferredDepthStencilFormat returns the first depth format, in order of
+// preference, that meets req on this adapter, or TextureFormatUndefined if
+// none does. Depth32FloatStencil8 is only returned when the adapter
+// supports FeatureDepth32FloatStencil8.
+func (a *Adapter) PreferredDepthStencilFormat(req DepthStencilRequirements) gputypes.TextureFormat {
+	for _, format := range depthStencilCandidates {
+		if a.meetsDepthStencil(format, req) {
+			return format
+		}
+	}
+	return gputypes.TextureFormatUndefined
+}
+
+// DepthStencilFallback returns the format to create a texture of format,
+// usage and sampleCount with. When format is a depth format the adapter
+// cannot use that way, it returns the preferred format with the same aspects
+// and at least the same depth precision, and true. Otherwise, including when
+// no replacement exists, it returns format and false, leaving the backend to
+// report the failure.
+func (a *Adapter) DepthStencilFallback(format gputypes.TextureFormat, usage gputypes.TextureUsage, sampleCount uint32) (gputypes.TextureFormat, bool) {
+	bits := depthBits(format)
+	if bits == 0 || usage&gputypes.TextureUsageRenderAttachment == 0 {
+		return format, false
+	}
+	req := DepthStencilRequirements{
+		Stencil:      hasStencilAspect(format),
+		MinDepthBits: bits,
+		Usage:        usage,
+		SampleCount:  sampleCount,
+	}
+	if a.meetsDepthStencil(format, req) {
+		return format, false
+	}
+	for _, candidate := range depthStencilCandidates {
+		if hasStencilAspect(candidate) == req.Stencil && a.meetsDepthStencil(candidate, req) {
+			return candidate, true
+		}
+	}
+	return format, false
+}
+
+// meetsDepthStencil reports whether format satisfies req on this adapter.
+func (a *Adapter) meetsDepthStencil(format gputypes.TextureFormat, req DepthStencilRequirements) bool {
+	if depthBits(format) < req.MinDepthBits || (req.Stencil && !hasStencilAspect(format)) {
+		return false
+	}
+	if format == gputypes.TextureFormatDepth32FloatStencil8 && a != nil &&
+		a.halAdapter != nil && !a.Features.Contains(gputypes.FeatureDepth32FloatStencil8) {
+		return false
+	}
+	caps := a.TextureFormatCapabilities(format)
+	if caps.Flags&hal.TextureFormatCapabilityRenderAttachment == 0 {
+		return false
+	}
+	if req.Usage&gputypes.TextureUsageTextureBinding != 0 && caps.Flags&hal.TextureFormatCapabilitySampled == 0 {
+		return false
+	}
+	if req.SampleCount > 1 && caps.SampleCounts&req.SampleCount == 0 {
+		return false
+	}
+	return true
+}
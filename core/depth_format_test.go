@@ -0,0 +1,110 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// depthCapsAdapter reports the render-attachment formats in renderable,
+// sampled, with the given sample counts; every other format is unusable.
+type depthCapsAdapter struct {
+	stubHALAdapter
+	renderable   []gputypes.TextureFormat
+	sampleCounts uint32
+}
+
+func (a *depthCapsAdapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
+	for _, f := range a.renderable {
+		if f == format {
+			return hal.TextureFormatCapabilities{
+				Flags:        hal.TextureFormatCapabilitySampled | hal.TextureFormatCapabilityRenderAttachment,
+				SampleCounts: a.sampleCounts,
+			}
+		}
+	}
+	return hal.TextureFormatCapabilities{}
+}
+
+func newDepthCapsAdapter(features gputypes.Features, formats ...gputypes.TextureFormat) *Adapter {
+	return &Adapter{
+		Features:   features,
+		halAdapter: &depthCapsAdapter{renderable: formats, sampleCounts: hal.SampleCount1},
+	}
+}
+
+func TestPreferredDepthStencilFormat(t *testing.T) {
+	d32s8 := gputypes.Features(gputypes.FeatureDepth32FloatStencil8)
+	all := []gputypes.TextureFormat{
+		gputypes.TextureFormatDepth16Unorm,
+		gputypes.TextureFormatDepth24Plus,
+		gputypes.TextureFormatDepth24PlusStencil8,
+		gputypes.TextureFormatDepth32Float,
+		gputypes.TextureFormatDepth32FloatStencil8,
+	}
+	noD24S8 := []gputypes.TextureFormat{
+		gputypes.TextureFormatDepth32Float,
+		gputypes.TextureFormatDepth32FloatStencil8,
+	}
+
+	tests := []struct {
+		name     string
+		adapter  *Adapter
+		req      DepthStencilRequirements
+		expected gputypes.TextureFormat
+	}{
+		{"depth default", newDepthCapsAdapter(d32s8, all...), DepthStencilRequirements{}, gputypes.TextureFormatDepth24Plus},
+		{"depth 32 bits", newDepthCapsAdapter(d32s8, all...), DepthStencilRequirements{MinDepthBits: 32}, gputypes.TextureFormatDepth32Float},
+		{"stencil", newDepthCapsAdapter(d32s8, all...), DepthStencilRequirements{Stencil: true}, gputypes.TextureFormatDepth24PlusStencil8},
+		{"stencil without D24S8", newDepthCapsAdapter(d32s8, noD24S8...), DepthStencilRequirements{Stencil: true}, gputypes.TextureFormatDepth32FloatStencil8},
+		{"D32S8 needs feature", newDepthCapsAdapter(0, noD24S8...), DepthStencilRequirements{Stencil: true}, gputypes.TextureFormatUndefined},
+		{"depth only falls back to stencil format", newDepthCapsAdapter(0, gputypes.TextureFormatDepth24PlusStencil8), DepthStencilRequirements{}, gputypes.TextureFormatDepth24PlusStencil8},
+		{"sample count", newDepthCapsAdapter(0, all...), DepthStencilRequirements{SampleCount: 4}, gputypes.TextureFormatUndefined},
+		{"mock adapter", &Adapter{}, DepthStencilRequirements{Stencil: true}, gputypes.TextureFormatDepth24PlusStencil8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.adapter.PreferredDepthStencilFormat(tt.req); got != tt.expected {
+				t.Errorf("PreferredDepthStencilFormat() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDepthStencilFallback(t *testing.T) {
+	adapter := newDepthCapsAdapter(gputypes.Features(gputypes.FeatureDepth32FloatStencil8),
+		gputypes.TextureFormatDepth32Float, gputypes.TextureFormatDepth32FloatStencil8)
+	attachment := gputypes.TextureUsageRenderAttachment
+
+	tests := []struct {
+		name     string
+		format   gputypes.TextureFormat
+		usage    gputypes.TextureUsage
+		expected gputypes.TextureFormat
+		fallback bool
+	}{
+		{"D24S8 to D32S8", gputypes.TextureFormatDepth24PlusStencil8, attachment, gputypes.TextureFormatDepth32FloatStencil8, true},
+		{"D24 to D32", gputypes.TextureFormatDepth24Plus, attachment, gputypes.TextureFormatDepth32Float, true},
+		{"D16 to D32", gputypes.TextureFormatDepth16Unorm, attachment, gputypes.TextureFormatDepth32Float, true},
+		{"supported kept", gputypes.TextureFormatDepth32Float, attachment, gputypes.TextureFormatDepth32Float, false},
+		{"not an attachment", gputypes.TextureFormatDepth24Plus, gputypes.TextureUsageCopyDst, gputypes.TextureFormatDepth24Plus, false},
+		{"color format", gputypes.TextureFormatRGBA8Unorm, attachment, gputypes.TextureFormatRGBA8Unorm, false},
+		{"stencil only", gputypes.TextureFormatStencil8, attachment, gputypes.TextureFormatStencil8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fallback := adapter.DepthStencilFallback(tt.format, tt.usage, 1)
+			if got != tt.expected || fallback != tt.fallback {
+				t.Errorf("DepthStencilFallback() = %v, %v, want %v, %v", got, fallback, tt.expected, tt.fallback)
+			}
+		})
+	}
+
+	noStencil := newDepthCapsAdapter(0, gputypes.TextureFormatDepth32Float)
+	if got, fallback := noStencil.DepthStencilFallback(gputypes.TextureFormatDepth24PlusStencil8, attachment, 1); fallback {
+		t.Errorf("fell back to %v with no stencil format available", got)
+	}
+}
@@ -30,25 +30,21 @@ func CreateDevice(adapterID AdapterID, desc *gputypes.DeviceDescriptor) (DeviceI
 		desc = &defaultDesc
 	}
 
-	// Validate requested features are supported
-	for _, feature := range desc.RequiredFeatures {
-		if !adapter.Features.Contains(feature) {
-			return DeviceID{}, fmt.Errorf("adapter does not support required feature: %v", feature)
-		}
-	}
-
-	// Note: Limits validation against adapter limits is deferred.
-	// The HAL-based API (NewDevice) handles this at the HAL layer.
-
-	// Determine which features to enable
-	// Start with required features
+	// Enable exactly the required features, all of which the adapter must
+	// support.
 	enabledFeatures := gputypes.Features(0)
 	for _, feature := range desc.RequiredFeatures {
 		enabledFeatures.Insert(feature)
 	}
+	if err := ValidateRequiredFeatures(adapter.Features, enabledFeatures); err != nil {
+		return DeviceID{}, err
+	}
 
-	// Use the descriptor's limits or default limits
-	deviceLimits := desc.RequiredLimits
+	// Validate limits against the adapter; unset limits inherit its values.
+	deviceLimits, err := ResolveRequiredLimits(adapter.Limits, desc.RequiredLimits)
+	if err != nil {
+		return DeviceID{}, err
+	}
 
 	// Create the queue first
 	queue := Queue{
@@ -59,11 +55,12 @@ func CreateDevice(adapterID AdapterID, desc *gputypes.DeviceDescriptor) (DeviceI
 
 	// Create the device
 	device := Device{
-		Adapter:  adapterID,
-		Label:    desc.Label,
-		Features: enabledFeatures,
-		Limits:   deviceLimits,
-		Queue:    queueID,
+		Adapter:   adapterID,
+		Label:     desc.Label,
+		Features:  enabledFeatures,
+		Limits:    deviceLimits,
+		Queue:     queueID,
+		Validator: NewValidator(true),
 	}
 
 	// Register the device
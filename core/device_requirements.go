@@ -0,0 +1,63 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ResolveRequiredLimits validates required against the adapter limits and
+// returns the limits the device is created with. Zero fields in required
+// inherit the adapter's value, so a zero Limits yields the full adapter
+// limits.
+//
+// Following WebGPU, "better" means larger for Max* limits and smaller for the
+// Min*Alignment limits, which must also be powers of two.
+func ResolveRequiredLimits(adapter, required gputypes.Limits) (gputypes.Limits, error) {
+	resolved := required
+	rv := reflect.ValueOf(&resolved).Elem()
+	av := reflect.ValueOf(adapter)
+	rt := rv.Type()
+
+	for idx := 0; idx < rv.NumField(); idx++ {
+		field := rv.Field(idx)
+		name := rt.Field(idx).Name
+		want := field.Uint()
+		have := av.Field(idx).Uint()
+		if want == 0 {
+			field.SetUint(have)
+			continue
+		}
+
+		if strings.HasPrefix(name, "Min") {
+			if want&(want-1) != 0 {
+				return gputypes.Limits{}, &ValidationError{
+					Resource: "Device",
+					Field:    "RequiredLimits." + name,
+					Message:  fmt.Sprintf("alignment %d is not a power of two", want),
+				}
+			}
+			if want < have {
+				return gputypes.Limits{}, limitError(name, want, have)
+			}
+			continue
+		}
+		if want > have {
+			return gputypes.Limits{}, limitError(name, want, have)
+		}
+	}
+	return resolved, nil
+}
+
+func limitError(name string, requested, supported uint64) error {
+	return &ValidationError{
+		Resource: "Device",
+		Field:    "RequiredLimits." + name,
+		Message:  fmt.Sprintf("requested %d, adapter supports %d", requested, supported),
+		Cause:    ErrLimitNotSupported,
+	}
+}
@@ -0,0 +1,83 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateRequiredFeatures(t *testing.T) {
+	adapter := gputypes.Features(gputypes.FeatureTimestampQuery)
+	if err := ValidateRequiredFeatures(adapter, adapter); err != nil {
+		t.Fatalf("supported features rejected: %v", err)
+	}
+
+	required := gputypes.Features(gputypes.FeatureTimestampQuery | gputypes.FeatureShaderF16 | gputypes.FeatureTextureCompressionBC)
+	err := ValidateRequiredFeatures(adapter, required)
+	if !errors.Is(err, ErrFeatureNotSupported) {
+		t.Fatalf("err = %v, want ErrFeatureNotSupported", err)
+	}
+	for _, want := range []string{"ShaderF16", "TextureCompressionBC"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not name %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "TimestampQuery") {
+		t.Errorf("error %q names a supported feature", err)
+	}
+}
+
+func TestResolveRequiredLimits(t *testing.T) {
+	adapter := gputypes.DefaultLimits()
+	adapter.MaxStorageBufferBindingSize = 1 << 30
+	adapter.MinUniformBufferOffsetAlignment = 64
+
+	got, err := ResolveRequiredLimits(adapter, gputypes.Limits{})
+	if err != nil || got != adapter {
+		t.Fatalf("zero limits: got %+v, %v; want adapter limits", got, err)
+	}
+
+	got, err = ResolveRequiredLimits(adapter, gputypes.Limits{
+		MaxStorageBufferBindingSize:     512 << 20,
+		MinUniformBufferOffsetAlignment: 256,
+	})
+	if err != nil {
+		t.Fatalf("supported limits rejected: %v", err)
+	}
+	if got.MaxStorageBufferBindingSize != 512<<20 || got.MinUniformBufferOffsetAlignment != 256 {
+		t.Errorf("requested limits not applied: %+v", got)
+	}
+	if got.MaxTextureDimension2D != adapter.MaxTextureDimension2D {
+		t.Errorf("unset limit = %d, want adapter value %d", got.MaxTextureDimension2D, adapter.MaxTextureDimension2D)
+	}
+
+	tests := []struct {
+		name     string
+		required gputypes.Limits
+		field    string
+		sentinel error
+	}{
+		{"max too high", gputypes.Limits{MaxStorageBufferBindingSize: 2 << 30}, "MaxStorageBufferBindingSize", ErrLimitNotSupported},
+		{"alignment too small", gputypes.Limits{MinUniformBufferOffsetAlignment: 32}, "MinUniformBufferOffsetAlignment", ErrLimitNotSupported},
+		{"alignment not power of two", gputypes.Limits{MinStorageBufferOffsetAlignment: 384}, "MinStorageBufferOffsetAlignment", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveRequiredLimits(adapter, tt.required)
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want *ValidationError", err)
+			}
+			if verr.Field != "RequiredLimits."+tt.field {
+				t.Errorf("Field = %q, want RequiredLimits.%s", verr.Field, tt.field)
+			}
+			if tt.sentinel != nil && !errors.Is(err, tt.sentinel) {
+				t.Errorf("err = %v, want %v", err, tt.sentinel)
+			}
+		})
+	}
+}
@@ -5,6 +5,9 @@ package core
 import (
 	"errors"
 	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
 )
 
 // unnamedLabel is the default label for resources without a name.
@@ -40,45 +43,6 @@ var (
 	ErrResourceDestroyed = errors.New("resource destroyed")
 )
 
-// ValidationError represents a validation failure with context.
-type ValidationError struct {
-	Resource string // Resource type (e.g., "Buffer", "Texture")
-	Field    string // Field that failed validation
-	Message  string // Detailed error message
-	Cause    error  // Underlying cause, if any
-}
-
-// Error implements the error interface.
-func (e *ValidationError) Error() string {
-	if e.Field != "" {
-		return fmt.Sprintf("%s.%s: %s", e.Resource, e.Field, e.Message)
-	}
-	return fmt.Sprintf("%s: %s", e.Resource, e.Message)
-}
-
-// Unwrap returns the underlying cause.
-func (e *ValidationError) Unwrap() error {
-	return e.Cause
-}
-
-// NewValidationError creates a new validation error.
-func NewValidationError(resource, field, message string) *ValidationError {
-	return &ValidationError{
-		Resource: resource,
-		Field:    field,
-		Message:  message,
-	}
-}
-
-// NewValidationErrorf creates a new validation error with formatted message.
-func NewValidationErrorf(resource, field, format string, args ...any) *ValidationError {
-	return &ValidationError{
-		Resource: resource,
-		Field:    field,
-		Message:  fmt.Sprintf(format, args...),
-	}
-}
-
 // IDError represents an error related to resource IDs.
 type IDError struct {
 	ID      RawID  // The problematic ID
@@ -299,7 +263,7 @@ const (
 	CreateTextureErrorMaxArrayLayers
 	// CreateTextureErrorInvalidMipLevelCount indicates an invalid mip level count.
 	CreateTextureErrorInvalidMipLevelCount
-	// CreateTextureErrorInvalidSampleCount indicates an invalid sample count (must be 1 or 4).
+	// CreateTextureErrorInvalidSampleCount indicates an invalid sample count (must be 1, 2, 4, 8 or 16).
 	CreateTextureErrorInvalidSampleCount
 	// CreateTextureErrorMultisampleMipLevel indicates multisampled texture must have mip level count of 1.
 	CreateTextureErrorMultisampleMipLevel
@@ -319,6 +283,23 @@ const (
 	CreateTextureErrorInvalidDimension
 	// CreateTextureErrorHAL indicates the HAL backend failed to create the texture.
 	CreateTextureErrorHAL
+	// CreateTextureErrorTransient indicates a transient texture is not a
+	// single-mip, single-layer 2D render attachment.
+	CreateTextureErrorTransient
+	// CreateTextureErrorUnsupportedSampleCount indicates the adapter cannot
+	// multisample the texture's format at the requested count.
+	CreateTextureErrorUnsupportedSampleCount
+	// CreateTextureErrorInvalidBindingViewDimension indicates the
+	// TextureBindingViewDimension hint does not fit the texture's dimension
+	// or layer count.
+	CreateTextureErrorInvalidBindingViewDimension
+	// CreateTextureErrorInvalidViewFormat indicates a ViewFormats entry that
+	// differs from the texture format in more than its sRGB encoding.
+	CreateTextureErrorInvalidViewFormat
+	// CreateTextureErrorMultiPlanar indicates a multi-planar texture that is
+	// not an even-sized, single-mip, single-layer, single-sample 2D texture
+	// used only as a texture binding, or that lists view formats.
+	CreateTextureErrorMultiPlanar
 )
 
 // CreateTextureError represents an error during texture creation.
@@ -332,7 +313,15 @@ type CreateTextureError struct {
 	RequestedMips    uint32
 	MaxMips          uint32
 	RequestedSamples uint32
-	HALError         error
+	// SupportedSamples is the adapter's sample count bitmask for Format
+	// (hal.TextureFormatCapabilities.SampleCounts).
+	SupportedSamples uint32
+	Format           gputypes.TextureFormat
+	// ViewFormat is the rejected ViewFormats entry.
+	ViewFormat    gputypes.TextureFormat
+	Dimension     gputypes.TextureDimension
+	ViewDimension gputypes.TextureViewDimension
+	HALError      error
 }
 
 // Error implements the error interface.
@@ -356,7 +345,7 @@ func (e *CreateTextureError) Error() string {
 		return fmt.Sprintf("texture %q: mip level count %d exceeds maximum %d",
 			label, e.RequestedMips, e.MaxMips)
 	case CreateTextureErrorInvalidSampleCount:
-		return fmt.Sprintf("texture %q: invalid sample count %d (must be 1 or 4)",
+		return fmt.Sprintf("texture %q: invalid sample count %d (must be 1, 2, 4, 8 or 16)",
 			label, e.RequestedSamples)
 	case CreateTextureErrorMultisampleMipLevel:
 		return fmt.Sprintf("texture %q: multisampled texture must have mip level count of 1 (got %d)",
@@ -378,6 +367,20 @@ func (e *CreateTextureError) Error() string {
 		return fmt.Sprintf("texture %q: dimension must not be undefined", label)
 	case CreateTextureErrorHAL:
 		return fmt.Sprintf("texture %q: HAL error: %v", label, e.HALError)
+	case CreateTextureErrorTransient:
+		return fmt.Sprintf("texture %q: transient texture must be a 2D render attachment with 1 mip level and 1 layer, and no other usage", label)
+	case CreateTextureErrorUnsupportedSampleCount:
+		return fmt.Sprintf("texture %q: sample count %d not supported for format %s (supported: %s)",
+			label, e.RequestedSamples, e.Format, sampleCountNames(e.SupportedSamples))
+	case CreateTextureErrorInvalidBindingViewDimension:
+		return fmt.Sprintf("texture %q: binding view dimension %s does not fit a %s texture with %d layers",
+			label, e.ViewDimension, e.Dimension, e.RequestedDepth)
+	case CreateTextureErrorInvalidViewFormat:
+		return fmt.Sprintf("texture %q: view format %s is not %s or its sRGB counterpart",
+			label, e.ViewFormat, e.Format)
+	case CreateTextureErrorMultiPlanar:
+		return fmt.Sprintf("texture %q: multi-planar texture must be an even-sized 2D texture with 1 mip level, 1 layer and 1 sample, used only as a texture binding and without view formats (got %dx%dx%d)",
+			label, e.RequestedWidth, e.RequestedHeight, e.RequestedDepth)
 	default:
 		return fmt.Sprintf("texture %q: unknown error", label)
 	}
@@ -394,6 +397,103 @@ func IsCreateTextureError(err error) bool {
 	return errors.As(err, &cte)
 }
 
+// =============================================================================
+// Texture View Creation Errors
+// =============================================================================
+
+// CreateTextureViewErrorKind represents the type of texture view creation error.
+type CreateTextureViewErrorKind int
+
+const (
+	// CreateTextureViewErrorInvalidDimension indicates the view dimension
+	// cannot be created from the texture's dimension (e.g. a cube view of a
+	// 3D texture).
+	CreateTextureViewErrorInvalidDimension CreateTextureViewErrorKind = iota
+	// CreateTextureViewErrorInvalidLayerCount indicates the layer count does
+	// not fit the view dimension: 1 for 1D/2D/3D, 6 for cube, a multiple of
+	// 6 for cube array.
+	CreateTextureViewErrorInvalidLayerCount
+	// CreateTextureViewErrorLayerRange indicates the layer range exceeds the
+	// texture's array layers.
+	CreateTextureViewErrorLayerRange
+	// CreateTextureViewErrorMipRange indicates the mip range exceeds the
+	// texture's mip levels.
+	CreateTextureViewErrorMipRange
+	// CreateTextureViewErrorNonSquareCube indicates a cube view of a texture
+	// whose width and height differ.
+	CreateTextureViewErrorNonSquareCube
+	// CreateTextureViewErrorMultisampledCube indicates a cube view of a
+	// multisampled texture.
+	CreateTextureViewErrorMultisampledCube
+	// CreateTextureViewErrorCubeArrayUnsupported indicates a cube array view
+	// on an adapter without hal.DownlevelFlagsCubeArrayTextures.
+	CreateTextureViewErrorCubeArrayUnsupported
+	// CreateTextureViewErrorFormatNotAllowed indicates the view format is
+	// neither the texture's format nor listed in its ViewFormats.
+	CreateTextureViewErrorFormatNotAllowed
+	// CreateTextureViewErrorInvalidPlane indicates a view of a multi-planar
+	// texture that does not select a plane, or a plane aspect on a texture
+	// without planes.
+	CreateTextureViewErrorInvalidPlane
+)
+
+// CreateTextureViewError represents an error during texture view creation.
+type CreateTextureViewError struct {
+	Kind             CreateTextureViewErrorKind
+	Label            string
+	ViewDimension    gputypes.TextureViewDimension
+	TextureDimension gputypes.TextureDimension
+	Base             uint32 // first mip level or array layer
+	Count            uint32 // mip level or array layer count
+	Available        uint32 // mip levels or array layers in the texture
+	// Format and TextureFormat are the view's and the texture's formats.
+	Format        gputypes.TextureFormat
+	TextureFormat gputypes.TextureFormat
+}
+
+// Error implements the error interface.
+func (e *CreateTextureViewError) Error() string {
+	label := e.Label
+	if label == "" {
+		label = unnamedLabel
+	}
+
+	switch e.Kind {
+	case CreateTextureViewErrorInvalidDimension:
+		return fmt.Sprintf("texture view %q: %s view of a %s texture", label, e.ViewDimension, e.TextureDimension)
+	case CreateTextureViewErrorInvalidLayerCount:
+		return fmt.Sprintf("texture view %q: %s view cannot have %d array layers", label, e.ViewDimension, e.Count)
+	case CreateTextureViewErrorLayerRange:
+		return fmt.Sprintf("texture view %q: array layers %d..%d exceed the texture's %d layers",
+			label, e.Base, e.Base+e.Count, e.Available)
+	case CreateTextureViewErrorMipRange:
+		return fmt.Sprintf("texture view %q: mip levels %d..%d exceed the texture's %d levels",
+			label, e.Base, e.Base+e.Count, e.Available)
+	case CreateTextureViewErrorNonSquareCube:
+		return fmt.Sprintf("texture view %q: %s view requires a square texture", label, e.ViewDimension)
+	case CreateTextureViewErrorMultisampledCube:
+		return fmt.Sprintf("texture view %q: %s view of a multisampled texture", label, e.ViewDimension)
+	case CreateTextureViewErrorCubeArrayUnsupported:
+		return fmt.Sprintf("texture view %q: cube array views are not supported by this adapter", label)
+	case CreateTextureViewErrorFormatNotAllowed:
+		return fmt.Sprintf("texture view %q: format %s is neither the texture format %s nor one of its view formats",
+			label, e.Format, e.TextureFormat)
+	case CreateTextureViewErrorInvalidPlane:
+		if hal.IsMultiPlanarFormat(e.TextureFormat) {
+			return fmt.Sprintf("texture view %q: view of a multi-planar texture must select Plane0 or Plane1", label)
+		}
+		return fmt.Sprintf("texture view %q: plane aspect on a texture without planes", label)
+	default:
+		return fmt.Sprintf("texture view %q: unknown error", label)
+	}
+}
+
+// IsCreateTextureViewError returns true if the error is a CreateTextureViewError.
+func IsCreateTextureViewError(err error) bool {
+	var ctve *CreateTextureViewError
+	return errors.As(err, &ctve)
+}
+
 // =============================================================================
 // Sampler Creation Errors
 // =============================================================================
@@ -474,6 +574,12 @@ const (
 	CreateShaderModuleErrorDualSource
 	// CreateShaderModuleErrorHAL indicates the HAL backend failed to create the shader module.
 	CreateShaderModuleErrorHAL
+	// CreateShaderModuleErrorInvalidSPIRV indicates the SPIR-V words do not
+	// start with a SPIR-V module header.
+	CreateShaderModuleErrorInvalidSPIRV
+	// CreateShaderModuleErrorUnsupportedSource indicates the backend cannot
+	// ingest the source language and naga cannot translate it.
+	CreateShaderModuleErrorUnsupportedSource
 )
 
 // CreateShaderModuleError represents an error during shader module creation.
@@ -481,6 +587,13 @@ type CreateShaderModuleError struct {
 	Kind     CreateShaderModuleErrorKind
 	Label    string
 	HALError error
+
+	// Source names the source language for DualSource and UnsupportedSource
+	// errors about a language other than WGSL and SPIR-V, such as "GLSL".
+	// Empty means SPIR-V.
+	Source string
+	// Backend is the backend that rejected the source, for UnsupportedSource.
+	Backend gputypes.Backend
 }
 
 // Error implements the error interface.
@@ -494,9 +607,20 @@ func (e *CreateShaderModuleError) Error() string {
 	case CreateShaderModuleErrorNoSource:
 		return fmt.Sprintf("shader module %q: must provide either WGSL or SPIRV source", label)
 	case CreateShaderModuleErrorDualSource:
+		if e.Source != "" {
+			return fmt.Sprintf("shader module %q: must not provide both %s and WGSL or SPIRV source", label, e.Source)
+		}
 		return fmt.Sprintf("shader module %q: must not provide both WGSL and SPIRV source", label)
 	case CreateShaderModuleErrorHAL:
 		return fmt.Sprintf("shader module %q: HAL error: %v", label, e.HALError)
+	case CreateShaderModuleErrorInvalidSPIRV:
+		return fmt.Sprintf("shader module %q: SPIRV source does not start with the SPIR-V magic number", label)
+	case CreateShaderModuleErrorUnsupportedSource:
+		source := e.Source
+		if source == "" {
+			source = "SPIRV"
+		}
+		return fmt.Sprintf("shader module %q: %s source is not supported on the %s backend; provide WGSL", label, source, e.Backend)
 	default:
 		return fmt.Sprintf("shader module %q: unknown error", label)
 	}
@@ -529,7 +653,8 @@ const (
 	CreateRenderPipelineErrorMissingFragmentModule
 	// CreateRenderPipelineErrorMissingFragmentEntryPoint indicates the fragment entry point was empty.
 	CreateRenderPipelineErrorMissingFragmentEntryPoint
-	// CreateRenderPipelineErrorNoFragmentTargets indicates the fragment stage had no color targets.
+	// CreateRenderPipelineErrorNoFragmentTargets indicates the fragment stage had no color targets
+	// and the pipeline no depth/stencil state.
 	CreateRenderPipelineErrorNoFragmentTargets
 	// CreateRenderPipelineErrorTooManyColorTargets indicates too many color targets.
 	CreateRenderPipelineErrorTooManyColorTargets
@@ -553,6 +678,33 @@ const (
 	CreateRenderPipelineErrorDepthFormatNoStencilAspect
 	// CreateRenderPipelineErrorHAL indicates the HAL backend failed to create the pipeline.
 	CreateRenderPipelineErrorHAL
+	// CreateRenderPipelineErrorUnsupportedSampleCount indicates a color target
+	// or depth/stencil format cannot be multisampled at the pipeline's count.
+	CreateRenderPipelineErrorUnsupportedSampleCount
+	// CreateRenderPipelineErrorTooManyVertexBuffers indicates more vertex buffer
+	// layouts than maxVertexBuffers.
+	// Rust: pipeline::CreateRenderPipelineError::TooManyVertexBuffers
+	CreateRenderPipelineErrorTooManyVertexBuffers
+	// CreateRenderPipelineErrorTooManyVertexAttributes indicates more vertex
+	// attributes, summed over all buffers, than maxVertexAttributes.
+	// Rust: pipeline::CreateRenderPipelineError::TooManyVertexAttributes
+	CreateRenderPipelineErrorTooManyVertexAttributes
+	// CreateRenderPipelineErrorVertexStrideTooLarge indicates a vertex buffer
+	// array stride above maxVertexBufferArrayStride.
+	// Rust: pipeline::CreateRenderPipelineError::VertexStrideTooLarge
+	CreateRenderPipelineErrorVertexStrideTooLarge
+	// CreateRenderPipelineErrorTooManyBindGroupsPlusVertexBuffers indicates the
+	// layout's bind groups plus the vertex buffers exceed
+	// maxBindGroupsPlusVertexBuffers.
+	CreateRenderPipelineErrorTooManyBindGroupsPlusVertexBuffers
+	// CreateRenderPipelineErrorColorAttachmentBytesPerSample indicates the color
+	// targets need more bytes per sample than maxColorAttachmentBytesPerSample.
+	// Rust: pipeline::ColorStateError::TooManyBytesPerSample
+	CreateRenderPipelineErrorColorAttachmentBytesPerSample
+	// CreateRenderPipelineErrorTooManyInterStageVariables indicates the vertex
+	// outputs or fragment inputs need more locations than
+	// MaxInterStageShaderVariables.
+	CreateRenderPipelineErrorTooManyInterStageVariables
 )
 
 // CreateRenderPipelineError represents an error during render pipeline creation.
@@ -565,7 +717,13 @@ type CreateRenderPipelineError struct {
 	// TargetIndex is the color target index for format errors.
 	TargetIndex uint32
 	// Format is the texture format that caused the error.
-	Format   string
+	Format string
+	// VertexBuffer is the vertex buffer index for stride errors.
+	VertexBuffer uint32
+	// Count and Limit are the offending total and the device limit for the
+	// vertex, bind group, bytes-per-sample and inter-stage limit errors.
+	Count    uint64
+	Limit    uint64
 	HALError error
 }
 
@@ -586,12 +744,12 @@ func (e *CreateRenderPipelineError) Error() string {
 	case CreateRenderPipelineErrorMissingFragmentEntryPoint:
 		return fmt.Sprintf("render pipeline %q: fragment entry point must not be empty", label)
 	case CreateRenderPipelineErrorNoFragmentTargets:
-		return fmt.Sprintf("render pipeline %q: fragment stage must have at least one color target", label)
+		return fmt.Sprintf("render pipeline %q: fragment stage must have at least one color target or a depth/stencil state", label)
 	case CreateRenderPipelineErrorTooManyColorTargets:
 		return fmt.Sprintf("render pipeline %q: color target count %d exceeds maximum %d",
 			label, e.TargetCount, e.MaxTargets)
 	case CreateRenderPipelineErrorInvalidSampleCount:
-		return fmt.Sprintf("render pipeline %q: invalid sample count %d (must be 1 or 4)",
+		return fmt.Sprintf("render pipeline %q: invalid sample count %d (must be 1, 2, 4, 8 or 16)",
 			label, e.SampleCount)
 	case CreateRenderPipelineErrorColorTargetDepthFormat:
 		return fmt.Sprintf("render pipeline %q: color target [%d] format %s does not have a color aspect",
@@ -607,6 +765,27 @@ func (e *CreateRenderPipelineError) Error() string {
 			label, e.Format)
 	case CreateRenderPipelineErrorHAL:
 		return fmt.Sprintf("render pipeline %q: HAL error: %v", label, e.HALError)
+	case CreateRenderPipelineErrorUnsupportedSampleCount:
+		return fmt.Sprintf("render pipeline %q: sample count %d not supported for format %s",
+			label, e.SampleCount, e.Format)
+	case CreateRenderPipelineErrorTooManyVertexBuffers:
+		return fmt.Sprintf("render pipeline %q: vertex buffer count %d exceeds maximum %d",
+			label, e.Count, e.Limit)
+	case CreateRenderPipelineErrorTooManyVertexAttributes:
+		return fmt.Sprintf("render pipeline %q: vertex attribute count %d exceeds maximum %d",
+			label, e.Count, e.Limit)
+	case CreateRenderPipelineErrorVertexStrideTooLarge:
+		return fmt.Sprintf("render pipeline %q: vertex buffer [%d] array stride %d exceeds maximum %d",
+			label, e.VertexBuffer, e.Count, e.Limit)
+	case CreateRenderPipelineErrorTooManyBindGroupsPlusVertexBuffers:
+		return fmt.Sprintf("render pipeline %q: %d bind groups plus vertex buffers exceed maximum %d",
+			label, e.Count, e.Limit)
+	case CreateRenderPipelineErrorColorAttachmentBytesPerSample:
+		return fmt.Sprintf("render pipeline %q: color targets use %d bytes per sample, maximum is %d",
+			label, e.Count, e.Limit)
+	case CreateRenderPipelineErrorTooManyInterStageVariables:
+		return fmt.Sprintf("render pipeline %q: shader stages need %d inter-stage variables, maximum is %d",
+			label, e.Count, e.Limit)
 	default:
 		return fmt.Sprintf("render pipeline %q: unknown error", label)
 	}
@@ -643,6 +822,8 @@ const (
 	CreateComputePipelineErrorWorkgroupSizeZero
 	// CreateComputePipelineErrorTooManyInvocations indicates total invocations exceed device limit.
 	CreateComputePipelineErrorTooManyInvocations
+	// CreateComputePipelineErrorWorkgroupStorageTooLarge indicates the workgroup variables exceed device limit.
+	CreateComputePipelineErrorWorkgroupStorageTooLarge
 )
 
 // CreateComputePipelineError represents an error during compute pipeline creation.
@@ -658,6 +839,8 @@ type CreateComputePipelineError struct {
 	Limit uint32
 	// TotalInvocations is the product x*y*z for TooManyInvocations errors.
 	TotalInvocations uint64
+	// StorageBytes is the workgroup variable size for WorkgroupStorageTooLarge errors.
+	StorageBytes uint64
 }
 
 // Error implements the error interface.
@@ -682,6 +865,9 @@ func (e *CreateComputePipelineError) Error() string {
 	case CreateComputePipelineErrorTooManyInvocations:
 		return fmt.Sprintf("compute pipeline %q: total workgroup invocations %d exceeds device limit %d",
 			label, e.TotalInvocations, e.Limit)
+	case CreateComputePipelineErrorWorkgroupStorageTooLarge:
+		return fmt.Sprintf("compute pipeline %q: workgroup variables use %d bytes, exceeding device limit %d",
+			label, e.StorageBytes, e.Limit)
 	default:
 		return fmt.Sprintf("compute pipeline %q: unknown error", label)
 	}
@@ -812,6 +998,26 @@ const (
 	CreateBindGroupErrorMinBindingSizeMismatch
 	// CreateBindGroupErrorHAL indicates the HAL backend failed.
 	CreateBindGroupErrorHAL
+	// CreateBindGroupErrorTextureViewDimensionMismatch indicates a texture
+	// view's dimension differs from the layout entry's ViewDimension.
+	// Rust: wgpu-core binding_model.rs CreateBindGroupError::InvalidTextureDimension
+	CreateBindGroupErrorTextureViewDimensionMismatch
+	// CreateBindGroupErrorTextureMultisampleMismatch indicates a multisampled
+	// view bound where the layout expects a single-sampled one, or vice versa.
+	// Rust: wgpu-core binding_model.rs CreateBindGroupError::InvalidTextureMultisample
+	CreateBindGroupErrorTextureMultisampleMismatch
+	// CreateBindGroupErrorTextureUsageMismatch indicates the view's texture lacks
+	// the usage its binding requires (TextureBinding or StorageBinding).
+	// Rust: wgpu-core binding_model.rs CreateBindGroupError::MissingTextureUsage
+	CreateBindGroupErrorTextureUsageMismatch
+	// CreateBindGroupErrorStorageTextureFormatMismatch indicates a storage
+	// texture view's format differs from the layout entry's format.
+	// Rust: wgpu-core binding_model.rs CreateBindGroupError::InvalidStorageTextureFormat
+	CreateBindGroupErrorStorageTextureFormatMismatch
+	// CreateBindGroupErrorStorageTextureMipLevelCount indicates a storage
+	// texture view spans more than one mip level.
+	// Rust: wgpu-core binding_model.rs CreateBindGroupError::InvalidStorageTextureMipLevelCount
+	CreateBindGroupErrorStorageTextureMipLevelCount
 )
 
 // CreateBindGroupError represents an error during bind group creation.
@@ -829,6 +1035,16 @@ type CreateBindGroupError struct {
 	MaxSize        uint64 // maximum allowed binding size (for BindingSizeTooLarge)
 	Alignment      uint64 // required alignment (for alignment errors)
 	MinBindingSize uint64 // layout-declared minimum binding size (for MinBindingSizeMismatch)
+	// ExpectedViewDimension and ActualViewDimension are the layout's and the
+	// bound view's dimensions (for TextureViewDimensionMismatch).
+	ExpectedViewDimension gputypes.TextureViewDimension
+	ActualViewDimension   gputypes.TextureViewDimension
+	SampleCount           uint32 // bound view's sample count (for TextureMultisampleMismatch)
+	// ExpectedFormat and ActualFormat are the layout's and the bound view's
+	// formats (for StorageTextureFormatMismatch).
+	ExpectedFormat gputypes.TextureFormat
+	ActualFormat   gputypes.TextureFormat
+	MipLevelCount  uint32 // bound view's mip level count (for StorageTextureMipLevelCount)
 	HALError       error
 }
 
@@ -874,6 +1090,21 @@ func (e *CreateBindGroupError) Error() string {
 			label, e.Binding, e.Size, e.MinBindingSize)
 	case CreateBindGroupErrorHAL:
 		return fmt.Sprintf("bind group %q: HAL error: %v", label, e.HALError)
+	case CreateBindGroupErrorTextureViewDimensionMismatch:
+		return fmt.Sprintf("bind group %q: binding %d texture view dimension %s does not match layout dimension %s",
+			label, e.Binding, e.ActualViewDimension, e.ExpectedViewDimension)
+	case CreateBindGroupErrorTextureMultisampleMismatch:
+		return fmt.Sprintf("bind group %q: binding %d texture view sample count %d does not match layout multisampling",
+			label, e.Binding, e.SampleCount)
+	case CreateBindGroupErrorTextureUsageMismatch:
+		return fmt.Sprintf("bind group %q: binding %d texture usage mismatch: expected 0x%x, actual 0x%x",
+			label, e.Binding, e.ExpectedUsage, e.ActualUsage)
+	case CreateBindGroupErrorStorageTextureFormatMismatch:
+		return fmt.Sprintf("bind group %q: binding %d storage texture view format %s does not match layout format %s",
+			label, e.Binding, e.ActualFormat, e.ExpectedFormat)
+	case CreateBindGroupErrorStorageTextureMipLevelCount:
+		return fmt.Sprintf("bind group %q: binding %d storage texture view has %d mip levels, must have exactly 1",
+			label, e.Binding, e.MipLevelCount)
 	default:
 		return fmt.Sprintf("bind group %q: unknown error", label)
 	}
@@ -904,6 +1135,11 @@ const (
 	CreatePipelineLayoutErrorTooManyGroups CreatePipelineLayoutErrorKind = iota
 	// CreatePipelineLayoutErrorHAL indicates the HAL backend failed to create the pipeline layout.
 	CreatePipelineLayoutErrorHAL
+	// CreatePipelineLayoutErrorTooManyBindings indicates the bind group layouts
+	// together declare more bindings of one kind than a per-layout or
+	// per-stage device limit allows.
+	// Rust: binding_model::CreatePipelineLayoutError::TooManyBindings
+	CreatePipelineLayoutErrorTooManyBindings
 )
 
 // CreatePipelineLayoutError represents an error during pipeline layout creation.
@@ -912,7 +1148,12 @@ type CreatePipelineLayoutError struct {
 	Label     string
 	Count     int
 	MaxGroups uint32
-	HALError  error
+	// Binding names the kind of binding over the limit for
+	// CreatePipelineLayoutErrorTooManyBindings, e.g. "dynamic uniform buffers".
+	Binding string
+	// Limit is the device limit Count exceeds.
+	Limit    uint32
+	HALError error
 }
 
 // Error implements the error interface.
@@ -928,6 +1169,9 @@ func (e *CreatePipelineLayoutError) Error() string {
 			label, e.Count, e.MaxGroups)
 	case CreatePipelineLayoutErrorHAL:
 		return fmt.Sprintf("pipeline layout %q: HAL error: %v", label, e.HALError)
+	case CreatePipelineLayoutErrorTooManyBindings:
+		return fmt.Sprintf("pipeline layout %q: %d %s exceed device limit %d",
+			label, e.Count, e.Binding, e.Limit)
 	default:
 		return fmt.Sprintf("pipeline layout %q: unknown error", label)
 	}
@@ -944,6 +1188,90 @@ func IsCreatePipelineLayoutError(err error) bool {
 	return errors.As(err, &cple)
 }
 
+// =============================================================================
+// Texture Copy Errors
+// =============================================================================
+
+// CopyTextureErrorKind represents the type of texture-to-texture copy error.
+type CopyTextureErrorKind int
+
+const (
+	// CopyTextureErrorFormatsIncompatible indicates the source and
+	// destination formats are neither equal, nor differ only in their sRGB
+	// encoding, nor a compressed and an uncompressed format of the same
+	// block size.
+	// Rust: command::transfer::TransferError::TextureFormatsNotCopyCompatible
+	CopyTextureErrorFormatsIncompatible CopyTextureErrorKind = iota
+	// CopyTextureErrorReinterpretUnsupported indicates a copy between a
+	// compressed and an uncompressed format on an adapter without
+	// hal.DownlevelFlagsTextureCopyReinterpret.
+	CopyTextureErrorReinterpretUnsupported
+	// CopyTextureErrorSampleCountMismatch indicates the textures have
+	// different sample counts.
+	CopyTextureErrorSampleCountMismatch
+	// CopyTextureErrorInvalidMipLevel indicates a mip level the texture
+	// does not have.
+	CopyTextureErrorInvalidMipLevel
+	// CopyTextureErrorUnaligned indicates an origin or size that is not a
+	// whole number of the format's texel blocks.
+	CopyTextureErrorUnaligned
+	// CopyTextureErrorOutOfBounds indicates the copied region extends past
+	// the mip level.
+	CopyTextureErrorOutOfBounds
+)
+
+// CopyTextureError represents an invalid CopyTextureToTexture region.
+type CopyTextureError struct {
+	Kind CopyTextureErrorKind
+	// Side is "source" or "destination" for errors about one texture.
+	Side      string
+	SrcFormat gputypes.TextureFormat
+	DstFormat gputypes.TextureFormat
+	// SrcSampleCount and DstSampleCount are the textures' sample counts.
+	SrcSampleCount uint32
+	DstSampleCount uint32
+	MipLevel       uint32
+	// Origin and Size are the region on Side, in that texture's texels.
+	Origin hal.Origin3D
+	Size   hal.Extent3D
+	// Extent is the size of the mip level on Side.
+	Extent hal.Extent3D
+	// BlockWidth and BlockHeight are the texel block size of the format on Side.
+	BlockWidth  uint32
+	BlockHeight uint32
+}
+
+// Error implements the error interface.
+func (e *CopyTextureError) Error() string {
+	switch e.Kind {
+	case CopyTextureErrorFormatsIncompatible:
+		return fmt.Sprintf("copy texture: formats %s and %s are not copy-compatible", e.SrcFormat, e.DstFormat)
+	case CopyTextureErrorReinterpretUnsupported:
+		return fmt.Sprintf("copy texture: copying %s to %s reinterprets compressed blocks, which this adapter does not support",
+			e.SrcFormat, e.DstFormat)
+	case CopyTextureErrorSampleCountMismatch:
+		return fmt.Sprintf("copy texture: source sample count %d does not match destination sample count %d",
+			e.SrcSampleCount, e.DstSampleCount)
+	case CopyTextureErrorInvalidMipLevel:
+		return fmt.Sprintf("copy texture: %s mip level %d does not exist", e.Side, e.MipLevel)
+	case CopyTextureErrorUnaligned:
+		return fmt.Sprintf("copy texture: %s origin (%d, %d) and size %dx%d must be multiples of the %dx%d texel block",
+			e.Side, e.Origin.X, e.Origin.Y, e.Size.Width, e.Size.Height, e.BlockWidth, e.BlockHeight)
+	case CopyTextureErrorOutOfBounds:
+		return fmt.Sprintf("copy texture: %s region at (%d, %d, %d) of %dx%dx%d exceeds mip level %d of %dx%dx%d",
+			e.Side, e.Origin.X, e.Origin.Y, e.Origin.Z, e.Size.Width, e.Size.Height, e.Size.DepthOrArrayLayers,
+			e.MipLevel, e.Extent.Width, e.Extent.Height, e.Extent.DepthOrArrayLayers)
+	default:
+		return "copy texture: unknown error"
+	}
+}
+
+// IsCopyTextureError returns true if the error is a CopyTextureError.
+func IsCopyTextureError(err error) bool {
+	var cte *CopyTextureError
+	return errors.As(err, &cte)
+}
+
 // EncoderStateError represents an invalid state transition error.
 // When the encoder is in the Error state (due to a deferred validation error),
 // the Cause field carries the original error so that errors.Is/errors.As
@@ -210,14 +210,15 @@ func (d *Device) PopErrorScope() *GPUError {
 	return gpuErr
 }
 
-// reportError reports a GPU error to the device's error scope stack.
+// ReportError reports a GPU error to the device's error scope stack.
 //
-// This is called internally when a GPU error occurs during validation
-// or GPU operations. The error is delivered to the topmost matching
-// error scope. If no scope matches, the error is considered uncaptured.
+// This is called when a GPU error occurs during validation or GPU
+// operations. The error is delivered to the topmost matching error scope.
+// If no scope matches, the error is considered uncaptured and the caller
+// hands it to the uncaptured error callback.
 //
 // Returns true if the error was captured by a scope, false otherwise.
-func (d *Device) reportError(filter ErrorFilter, message string) bool {
+func (d *Device) ReportError(filter ErrorFilter, message string) bool {
 	return d.errorScopes().ReportError(filter, message)
 }
 
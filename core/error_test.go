@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/gogpu/gputypes"
 )
 
 // =============================================================================
@@ -341,6 +343,17 @@ func TestCreateTextureError_AllKinds(t *testing.T) {
 			err:     &CreateTextureError{Kind: CreateTextureErrorHAL, Label: "tex14", HALError: halErr},
 			wantSub: "HAL error",
 		},
+		{
+			name:    "Transient",
+			err:     &CreateTextureError{Kind: CreateTextureErrorTransient, Label: "tex16"},
+			wantSub: "transient texture",
+		},
+		{
+			name: "UnsupportedSampleCount",
+			err: &CreateTextureError{Kind: CreateTextureErrorUnsupportedSampleCount, Label: "tex17",
+				RequestedSamples: 8, SupportedSamples: 5, Format: gputypes.TextureFormatRGBA8Unorm},
+			wantSub: "not supported for format RGBA8Unorm (supported: 1, 4)",
+		},
 		{
 			name:    "Unknown",
 			err:     &CreateTextureError{Kind: CreateTextureErrorKind(99), Label: "tex15"},
@@ -491,6 +504,21 @@ func TestCreateShaderModuleError_AllKinds(t *testing.T) {
 			err:     &CreateShaderModuleError{Kind: CreateShaderModuleErrorHAL, Label: "shader3", HALError: halErr},
 			wantSub: "HAL error",
 		},
+		{
+			name:    "DualSourceGLSL",
+			err:     &CreateShaderModuleError{Kind: CreateShaderModuleErrorDualSource, Label: "shader2", Source: "GLSL"},
+			wantSub: "both GLSL and WGSL or SPIRV",
+		},
+		{
+			name:    "InvalidSPIRV",
+			err:     &CreateShaderModuleError{Kind: CreateShaderModuleErrorInvalidSPIRV, Label: "shader5"},
+			wantSub: "SPIR-V magic number",
+		},
+		{
+			name:    "UnsupportedSource",
+			err:     &CreateShaderModuleError{Kind: CreateShaderModuleErrorUnsupportedSource, Label: "shader6", Backend: gputypes.BackendMetal},
+			wantSub: "SPIRV source is not supported on the Metal backend",
+		},
 		{
 			name:    "Unknown",
 			err:     &CreateShaderModuleError{Kind: CreateShaderModuleErrorKind(99), Label: "shader4"},
@@ -604,6 +632,11 @@ func TestCreateRenderPipelineError_AllKinds(t *testing.T) {
 			err:     &CreateRenderPipelineError{Kind: CreateRenderPipelineErrorHAL, Label: "rp12", HALError: halErr},
 			wantSub: "HAL error",
 		},
+		{
+			name:    "UnsupportedSampleCount",
+			err:     &CreateRenderPipelineError{Kind: CreateRenderPipelineErrorUnsupportedSampleCount, Label: "rp13", SampleCount: 8, Format: "Depth32Float"},
+			wantSub: "sample count 8 not supported for format Depth32Float",
+		},
 		{
 			name:    "Unknown",
 			err:     &CreateRenderPipelineError{Kind: CreateRenderPipelineErrorKind(99), Label: "rp13"},
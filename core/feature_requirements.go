@@ -0,0 +1,49 @@
+package core
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Device requirement errors. They are returned as the Cause of a
+// ValidationError, so errors.Is matches them through the wrapper.
+var (
+	// ErrFeatureNotSupported is returned when a device requires a feature the
+	// adapter does not expose.
+	ErrFeatureNotSupported = errors.New("feature not supported by adapter")
+
+	// ErrLimitNotSupported is returned when a device requires a limit that is
+	// better than the adapter's.
+	ErrLimitNotSupported = errors.New("limit not supported by adapter")
+)
+
+// ValidateRequiredFeatures checks that every feature in required is exposed
+// by the adapter. The error names all missing features.
+func ValidateRequiredFeatures(adapter, required gputypes.Features) error {
+	missing := required &^ adapter
+	if missing == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Resource: "Device",
+		Field:    "RequiredFeatures",
+		Message:  "adapter does not support " + featureNames(missing),
+		Cause:    ErrFeatureNotSupported,
+	}
+}
+
+// featureNames renders a feature set as a comma-separated list of names.
+func featureNames(features gputypes.Features) string {
+	var names []string
+	for bit := 0; bit < 64; bit++ {
+		feature := gputypes.Feature(uint64(1) << bit)
+		if !features.Contains(feature) {
+			continue
+		}
+		names = append(names, hal.FeatureName(feature))
+	}
+	return strings.Join(names, ", ")
+}
@@ -4,6 +4,7 @@ package core
 
 import (
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
 )
@@ -50,7 +51,7 @@ func TestHubAdapter(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetAdapter failed: %v", err)
 	}
-	if got != *adapter {
+	if !reflect.DeepEqual(got, *adapter) {
 		t.Error("GetAdapter returned different adapter")
 	}
 
@@ -59,7 +60,7 @@ func TestHubAdapter(t *testing.T) {
 	if err != nil {
 		t.Fatalf("UnregisterAdapter failed: %v", err)
 	}
-	if removed != *adapter {
+	if !reflect.DeepEqual(removed, *adapter) {
 		t.Error("UnregisterAdapter returned different adapter")
 	}
 
@@ -0,0 +1,126 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/internal/indirect"
+)
+
+// Sizes of the argument records read by indirect commands.
+const (
+	// DrawIndirectArgsSize is the size of a DrawIndirect record:
+	// vertexCount, instanceCount, firstVertex, firstInstance.
+	DrawIndirectArgsSize = 16
+	// DrawIndexedIndirectArgsSize is the size of a DrawIndexedIndirect
+	// record: indexCount, instanceCount, firstIndex, baseVertex,
+	// firstInstance.
+	DrawIndexedIndirectArgsSize = 20
+	// DispatchIndirectArgsSize is the size of a DispatchIndirect record:
+	// the x, y and z workgroup counts.
+	DispatchIndirectArgsSize = 12
+
+	// indirectOffsetAlignment is the required alignment of indirect offsets.
+	indirectOffsetAlignment = 4
+)
+
+// ErrIndirectArgs is the Unwrap target of every IndirectArgsError.
+var ErrIndirectArgs = errors.New("invalid indirect argument buffer")
+
+// IndirectArgsErrorKind represents the type of indirect argument buffer error.
+type IndirectArgsErrorKind int
+
+const (
+	// IndirectArgsErrorMissingUsage indicates the buffer lacks BufferUsageIndirect.
+	IndirectArgsErrorMissingUsage IndirectArgsErrorKind = iota
+	// IndirectArgsErrorUnalignedOffset indicates the offset is not 4-byte aligned.
+	IndirectArgsErrorUnalignedOffset
+	// IndirectArgsErrorOverrun indicates the records extend past the buffer.
+	IndirectArgsErrorOverrun
+)
+
+// IndirectArgsError reports an indirect draw or dispatch whose argument
+// buffer cannot supply the records the command reads.
+type IndirectArgsError struct {
+	Kind IndirectArgsErrorKind
+	// Command names the offending command, e.g. "RenderPass.DrawIndirect".
+	Command string
+	// Label is the buffer's debug label.
+	Label      string
+	Usage      gputypes.BufferUsage
+	BufferSize uint64
+	Offset     uint64
+	RecordSize uint64
+	Count      uint32
+}
+
+// Error implements the error interface.
+func (e *IndirectArgsError) Error() string {
+	label := e.Label
+	if label == "" {
+		label = unnamedLabel
+	}
+	switch e.Kind {
+	case IndirectArgsErrorMissingUsage:
+		return fmt.Sprintf("%s: buffer %q missing usage Indirect (has %s)",
+			e.Command, label, bufferUsageNames(e.Usage))
+	case IndirectArgsErrorUnalignedOffset:
+		return fmt.Sprintf("%s: offset %d is not %d-byte aligned",
+			e.Command, e.Offset, indirectOffsetAlignment)
+	case IndirectArgsErrorOverrun:
+		return fmt.Sprintf("%s: %d record(s) of %d bytes at offset %d exceed buffer %q of size %d",
+			e.Command, e.Count, e.RecordSize, e.Offset, label, e.BufferSize)
+	default:
+		return fmt.Sprintf("%s: invalid indirect buffer %q", e.Command, label)
+	}
+}
+
+// Unwrap returns ErrIndirectArgs.
+func (e *IndirectArgsError) Unwrap() error {
+	return ErrIndirectArgs
+}
+
+// ValidateIndirectArgs checks that a buffer of the given usage and size can
+// supply count consecutive records of recordSize bytes starting at offset:
+// the buffer needs BufferUsageIndirect, offset must be 4-byte aligned, and the
+// records must fit without overflowing. It returns an *IndirectArgsError.
+//
+// Matches Rust wgpu-core render.rs (draw_indirect) and compute.rs
+// (dispatch_indirect).
+func ValidateIndirectArgs(command, label string, usage gputypes.BufferUsage, bufferSize, offset, recordSize uint64, count uint32) error {
+	e := &IndirectArgsError{
+		Command:    command,
+		Label:      label,
+		Usage:      usage,
+		BufferSize: bufferSize,
+		Offset:     offset,
+		RecordSize: recordSize,
+		Count:      count,
+	}
+	switch {
+	case !usage.Contains(gputypes.BufferUsageIndirect):
+		e.Kind = IndirectArgsErrorMissingUsage
+	case offset%indirectOffsetAlignment != 0:
+		e.Kind = IndirectArgsErrorUnalignedOffset
+	case !indirect.RangeFits(bufferSize, offset, recordSize, count):
+		e.Kind = IndirectArgsErrorOverrun
+	default:
+		return nil
+	}
+	return e
+}
+
+// checkIndirectBuffer validates an indirect command's argument buffer.
+// A nil buffer is left to the caller.
+func checkIndirectBuffer(command string, buffer *Buffer, offset, recordSize uint64, count uint32) error {
+	if buffer == nil {
+		return nil
+	}
+	return ValidateIndirectArgs(command, buffer.Label(), buffer.Usage(), buffer.Size(), offset, recordSize, count)
+}
@@ -0,0 +1,54 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateIndirectArgs(t *testing.T) {
+	const indirect = gputypes.BufferUsageIndirect | gputypes.BufferUsageStorage
+	tests := []struct {
+		name       string
+		usage      gputypes.BufferUsage
+		size       uint64
+		offset     uint64
+		recordSize uint64
+		count      uint32
+		wantKind   IndirectArgsErrorKind
+		wantOK     bool
+	}{
+		{"single draw", indirect, 16, 0, DrawIndirectArgsSize, 1, 0, true},
+		{"multi draw at offset", indirect, 84, 4, DrawIndexedIndirectArgsSize, 4, 0, true},
+		{"dispatch", indirect, 16, 4, DispatchIndirectArgsSize, 1, 0, true},
+		{"missing usage", gputypes.BufferUsageStorage, 16, 0, DrawIndirectArgsSize, 1, IndirectArgsErrorMissingUsage, false},
+		{"unaligned offset", indirect, 32, 2, DrawIndirectArgsSize, 1, IndirectArgsErrorUnalignedOffset, false},
+		{"one record too many", indirect, 64, 0, DrawIndirectArgsSize, 5, IndirectArgsErrorOverrun, false},
+		{"offset past end", indirect, 16, 20, DispatchIndirectArgsSize, 1, IndirectArgsErrorOverrun, false},
+		{"count overflow", indirect, 64, 4, DrawIndexedIndirectArgsSize, ^uint32(0), IndirectArgsErrorOverrun, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIndirectArgs("Test", "args", tt.usage, tt.size, tt.offset, tt.recordSize, tt.count)
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				return
+			}
+			var iae *IndirectArgsError
+			if !errors.As(err, &iae) {
+				t.Fatalf("expected IndirectArgsError, got %T (%v)", err, err)
+			}
+			if iae.Kind != tt.wantKind {
+				t.Errorf("expected kind %v, got %v", tt.wantKind, iae.Kind)
+			}
+			if !errors.Is(err, ErrIndirectArgs) {
+				t.Errorf("error does not wrap ErrIndirectArgs: %v", err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ package core
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 	"unsafe"
 
@@ -63,6 +64,58 @@ type Instance struct {
 	// useMock indicates whether this instance was explicitly created with mock
 	// adapters through NewInstanceWithMock.
 	useMock bool
+
+	// report records the outcome of every backend considered during
+	// enumeration. Immutable after construction.
+	report BackendReport
+
+	// blocklist marks known-broken adapters as fallback-only. Nil when the
+	// blocklist is disabled. Immutable after construction.
+	blocklist []AdapterBlocklistEntry
+
+	// log routes this instance's diagnostics, including those of its HAL
+	// instances. Nil follows hal.SetLogger.
+	log *hal.Log
+
+	// backendOptions is passed to every HAL instance. Immutable after
+	// construction.
+	backendOptions hal.BackendOptions
+
+	// skipValidation disables descriptor validation on this instance's
+	// devices. Immutable after construction.
+	skipValidation bool
+}
+
+// InstanceOptions carries instance configuration that has no gputypes
+// descriptor equivalent.
+type InstanceOptions struct {
+	// BackendOrder is the backend fallback chain. Backends are tried in the
+	// given order and adapters are registered in that order, so adapter
+	// selection prefers earlier backends. Backends enabled by the descriptor
+	// but absent from the order are not tried. Nil uses the default provider
+	// priority (Vulkan, Metal, DX12, GL, then the software provider).
+	BackendOrder []gputypes.Backend
+	// AdapterBlocklist lists entries consulted in addition to
+	// DefaultAdapterBlocklist.
+	AdapterBlocklist []AdapterBlocklistEntry
+	// IgnoreAdapterBlocklist disables the blocklist, including the defaults.
+	// Setting GOGPU_IGNORE_ADAPTER_BLOCKLIST=1 has the same effect.
+	IgnoreAdapterBlocklist bool
+	// Software selects whether the software backend joins the fallback
+	// chain. SoftwareAuto defers to SoftwareModeEnv.
+	Software SoftwareMode
+	// Logger receives this instance's diagnostics instead of the package
+	// logger set with hal.SetLogger. Nil keeps the package logger.
+	Logger *slog.Logger
+	// LogLevels sets the minimum level per log category, for example
+	// hal.LogBarrier at slog.LevelDebug while everything else stays at Info.
+	LogLevels map[hal.LogCategory]slog.Level
+	// BackendOptions holds backend-specific settings for the HAL instances.
+	// The caller checks them with hal.BackendOptions.Validate first.
+	BackendOptions hal.BackendOptions
+	// SkipValidation installs NoValidator on this instance's devices. It has
+	// no effect in builds with the wgpu_novalidate tag, which never validate.
+	SkipValidation bool
 }
 
 // HALInstanceEntry associates an enabled backend with its HAL instance.
@@ -88,13 +141,19 @@ type surfaceAdapterQualifier interface {
 // instance remains empty and RequestAdapter reports the failure. Tests that
 // need a deterministic adapter must opt in through NewInstanceWithMock.
 func NewInstance(desc *gputypes.InstanceDescriptor) *Instance {
+	return NewInstanceWithOptions(desc, nil)
+}
+
+// NewInstanceWithOptions is NewInstance with additional options controlling
+// the backend fallback chain and the adapter blocklist. A nil opts behaves
+// like NewInstance.
+func NewInstanceWithOptions(desc *gputypes.InstanceDescriptor, opts *InstanceOptions) *Instance {
 	if desc == nil {
 		defaultDesc := gputypes.DefaultInstanceDescriptor()
 		desc = &defaultDesc
 	}
 
 	i := &Instance{
-		backends:       desc.Backends,
 		flags:          desc.Flags,
 		adapters:       []AdapterID{},
 		halInstances:   []hal.Instance{},
@@ -102,8 +161,24 @@ func NewInstance(desc *gputypes.InstanceDescriptor) *Instance {
 		useMock:        false,
 	}
 
+	var order []gputypes.Backend
+	software := SoftwareAuto
+	if opts != nil {
+		order = opts.BackendOrder
+		software = opts.Software
+		i.log = hal.NewLog(opts.Logger, opts.LogLevels)
+		i.blocklist = adapterBlocklist(opts.AdapterBlocklist, opts.IgnoreAdapterBlocklist)
+		i.backendOptions = opts.BackendOptions
+		i.skipValidation = opts.SkipValidation
+	} else {
+		i.blocklist = adapterBlocklist(nil, false)
+	}
+
+	desc, order, software = resolveBackendEnv(desc, order, software, i.log)
+	i.backends = desc.Backends
+
 	// Try to enumerate real adapters via HAL backends
-	i.enumerateRealAdapters(desc)
+	i.enumerateRealAdapters(desc, order, software)
 
 	trackResource(uintptr(unsafe.Pointer(i)), "Instance") //nolint:gosec // debug tracking uses pointer as unique ID
 	return i
@@ -132,36 +207,61 @@ func NewInstanceWithMock(desc *gputypes.InstanceDescriptor) *Instance {
 }
 
 // enumerateRealAdapters attempts to enumerate real GPU adapters via HAL
-// backends. If none are available, the instance remains empty.
-func (i *Instance) enumerateRealAdapters(desc *gputypes.InstanceDescriptor) {
+// backends. If none are available, the instance remains empty. Every backend
+// considered is recorded in the instance's BackendReport. software is the
+// resolved software backend mode.
+func (i *Instance) enumerateRealAdapters(desc *gputypes.InstanceDescriptor, order []gputypes.Backend, software SoftwareMode) {
 	// First, ensure HAL backends are registered
 	RegisterHALBackends()
 
-	// Get backend providers filtered by the enabled backends mask
-	providers := FilterBackendsByMask(desc.Backends)
+	// Get backend providers filtered by the enabled backends mask, then
+	// arrange them into the requested fallback chain.
+	registered := GetOrderedBackendProviders()
+	enabled := FilterBackendsByMask(desc.Backends)
+	slots, disabled := planBackends(registered, enabled, softwareBackendOrder(software, order, enabled))
 
 	hub := GetGlobal().Hub()
 
 	// Create HAL descriptor
 	halDesc := &hal.InstanceDescriptor{
-		Backends: desc.Backends,
-		Flags:    desc.Flags,
+		Backends:       desc.Backends,
+		Flags:          desc.Flags,
+		Log:            i.log,
+		BackendOptions: i.backendOptions,
+	}
+
+	record := func(attempt BackendAttempt) {
+		i.report.Attempts = append(i.report.Attempts, attempt)
+		if attempt.Status != BackendStatusReady && attempt.Status != BackendStatusDeferred {
+			i.log.For(hal.LogAdapter).Info("core: backend unavailable",
+				"backend", attempt.Backend,
+				"status", attempt.Status,
+				"error", attempt.Err,
+			)
+		}
 	}
 
 	// Try each backend provider
-	for _, provider := range providers {
+	for _, slot := range slots {
+		provider := slot.provider
+		if provider == nil {
+			record(BackendAttempt{Backend: slot.backend, Status: BackendStatusNotRegistered})
+			continue
+		}
 		// Skip noop backend — it's for testing only, not real rendering.
 		// Software backend (also BackendEmpty variant) is allowed through
 		// because it provides real CPU-based rendering.
 		if provider.Variant() == gputypes.BackendEmpty {
 			halInst, err := provider.CreateInstance(halDesc)
 			if err != nil {
+				record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusInstanceFailed, Err: err})
 				continue
 			}
 			adapters := halInst.EnumerateAdapters(nil)
 			isNoop := len(adapters) > 0 && adapters[0].Info.DeviceType == gputypes.DeviceTypeOther
 			if isNoop {
 				halInst.Destroy()
+				record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusTestOnly})
 				continue
 			}
 			// Not noop (software backend) — destroy temp instance and fall through
@@ -172,6 +272,7 @@ func (i *Instance) enumerateRealAdapters(desc *gputypes.InstanceDescriptor) {
 		halInstance, err := provider.CreateInstance(halDesc)
 		if err != nil {
 			// Backend not available, try next
+			record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusInstanceFailed, Err: err})
 			continue
 		}
 
@@ -186,6 +287,7 @@ func (i *Instance) enumerateRealAdapters(desc *gputypes.InstanceDescriptor) {
 			})
 			i.halInstanceMap[provider.Variant()] = halInstance
 			i.deferredGLES = append(i.deferredGLES, halInstance)
+			record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusDeferred})
 			continue
 		}
 
@@ -201,21 +303,67 @@ func (i *Instance) enumerateRealAdapters(desc *gputypes.InstanceDescriptor) {
 		exposedAdapters := halInstance.EnumerateAdapters(nil)
 		for idx := range exposedAdapters {
 			exposed := &exposedAdapters[idx] // Use pointer to avoid copy
-			// Create core.Adapter wrapping the HAL adapter
-			adapter := &Adapter{
-				Info:            exposed.Info,
-				Features:        exposed.Features,
-				Limits:          exposed.Capabilities.Limits,
-				Backend:         exposed.Info.Backend,
-				halAdapter:      exposed.Adapter,
-				halCapabilities: &exposed.Capabilities,
-			}
-
-			// Register in the hub
-			adapterID := hub.RegisterAdapter(adapter)
+			// Register a core.Adapter wrapping the HAL adapter in the hub
+			adapterID := hub.RegisterAdapter(i.newHALAdapter(exposed))
 			i.adapters = append(i.adapters, adapterID)
 		}
+		if len(exposedAdapters) == 0 {
+			record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusNoAdapters})
+		} else {
+			record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusReady, Adapters: len(exposedAdapters)})
+		}
+	}
+
+	for _, provider := range disabled {
+		record(BackendAttempt{Backend: provider.Variant(), Status: BackendStatusDisabled})
+	}
+}
+
+// newHALAdapter wraps an exposed HAL adapter in a core.Adapter, corrects its
+// device type with hal.RefineDeviceType and marks it fallback-only when it
+// matches the instance's adapter blocklist.
+func (i *Instance) newHALAdapter(exposed *hal.ExposedAdapter) *Adapter {
+	adapter := &Adapter{
+		Info:            exposed.Info,
+		Details:         exposed.Details,
+		Features:        exposed.Features,
+		Limits:          exposed.Capabilities.Limits,
+		Backend:         exposed.Info.Backend,
+		halAdapter:      exposed.Adapter,
+		halCapabilities: &exposed.Capabilities,
+	}
+	adapter.Info.DeviceType = hal.RefineDeviceType(&exposed.Info)
+	if reason := matchAdapterBlocklist(i.blocklist, &exposed.Info); reason != "" {
+		adapter.BlocklistReason = reason
+		i.log.For(hal.LogAdapter).Warn("core: adapter blocklisted, using as fallback only",
+			"name", exposed.Info.Name,
+			"backend", exposed.Info.Backend,
+			"reason", reason,
+			"override", IgnoreAdapterBlocklistEnv+"=1",
+		)
+	}
+	return adapter
+}
+
+func containsProvider(providers []BackendProvider, target BackendProvider) bool {
+	for _, p := range providers {
+		if p == target {
+			return true
+		}
 	}
+	return false
+}
+
+// Logger returns the instance's logger for category c: the logger from
+// InstanceOptions filtered by its LogLevels, or the package logger.
+func (i *Instance) Logger(c hal.LogCategory) *slog.Logger {
+	return i.log.For(c)
+}
+
+// BackendReport returns the per-backend outcome of instance creation.
+// Instances created with NewInstanceWithMock have an empty report.
+func (i *Instance) BackendReport() BackendReport {
+	return BackendReport{Attempts: append([]BackendAttempt(nil), i.report.Attempts...)}
 }
 
 // createMockAdapter creates a mock adapter for testing purposes.
@@ -285,6 +433,9 @@ func (i *Instance) RequestAdapter(options *gputypes.RequestAdapterOptions) (Adap
 	adapterIDs := append([]AdapterID(nil), i.adapters...)
 	i.mu.RUnlock()
 
+	if len(adapterIDs) == 0 && len(i.report.Attempts) > 0 {
+		return AdapterID{}, fmt.Errorf("no adapters available (%s)", i.report)
+	}
 	return selectAdapterIDs(options, adapterIDs)
 }
 
@@ -292,11 +443,47 @@ func (i *Instance) RequestAdapter(options *gputypes.RequestAdapterOptions) (Adap
 // candidate list. Keeping the policy independent from Instance state lets a
 // surface request select request-local adapters without exposing unqualified
 // cached adapters to the selection pass.
-func selectAdapterIDs(options *gputypes.RequestAdapterOptions, adapterIDs []AdapterID) (AdapterID, error) { //nolint:gocognit // adapter selection with GPU preference logic
+//
+// Blocklisted adapters are fallback-only: they are considered after every
+// usable GPU and software adapter, and ForceFallbackAdapter accepts them when
+// no software adapter exists.
+func selectAdapterIDs(options *gputypes.RequestAdapterOptions, adapterIDs []AdapterID) (AdapterID, error) {
 	if len(adapterIDs) == 0 {
 		return AdapterID{}, fmt.Errorf("no adapters available")
 	}
 
+	usable, blocked := partitionBlockedAdapters(adapterIDs)
+	if len(usable) > 0 {
+		id, err := selectUsableAdapterIDs(options, usable)
+		if err == nil || len(blocked) == 0 {
+			return id, err
+		}
+	}
+	if options != nil && options.ForceFallbackAdapter {
+		return blocked[0], nil
+	}
+	return selectUsableAdapterIDs(options, blocked)
+}
+
+// partitionBlockedAdapters splits candidates into usable and blocklisted
+// adapters, preserving order. IDs that fail to resolve stay in usable so the
+// selection pass skips them as before.
+func partitionBlockedAdapters(adapterIDs []AdapterID) (usable, blocked []AdapterID) {
+	hub := GetGlobal().Hub()
+	for _, adapterID := range adapterIDs {
+		adapter, err := hub.GetAdapter(adapterID)
+		if err == nil && adapter.BlocklistReason != "" {
+			blocked = append(blocked, adapterID)
+			continue
+		}
+		usable = append(usable, adapterID)
+	}
+	return usable, blocked
+}
+
+// selectUsableAdapterIDs is the preference policy applied to one tier of
+// candidates.
+func selectUsableAdapterIDs(options *gputypes.RequestAdapterOptions, adapterIDs []AdapterID) (AdapterID, error) { //nolint:gocognit // adapter selection with GPU preference logic
 	hub := GetGlobal().Hub()
 
 	// If no options specified, prefer non-CPU adapters (GPU > Software fallback).
@@ -568,16 +755,7 @@ func (i *Instance) enumerateDeferredGLES(surfaceHint hal.Surface) {
 		exposedAdapters := halInstance.EnumerateAdapters(surfaceHint)
 		for idx := range exposedAdapters {
 			exposed := &exposedAdapters[idx]
-			adapter := &Adapter{
-				Info:            exposed.Info,
-				Features:        exposed.Features,
-				Limits:          exposed.Capabilities.Limits,
-				Backend:         exposed.Info.Backend,
-				halAdapter:      exposed.Adapter,
-				halCapabilities: &exposed.Capabilities,
-			}
-
-			adapterID := hub.RegisterAdapter(adapter)
+			adapterID := hub.RegisterAdapter(i.newHALAdapter(exposed))
 			i.adapters = append(i.adapters, adapterID)
 		}
 	}
@@ -614,6 +792,12 @@ func (i *Instance) Flags() gputypes.InstanceFlags {
 	return i.flags
 }
 
+// SkipValidation reports whether the instance was created with
+// InstanceOptions.SkipValidation.
+func (i *Instance) SkipValidation() bool {
+	return i.skipValidation
+}
+
 // IsMock returns true if the instance is using mock adapters.
 // Mock adapters are used only when the instance was explicitly created with
 // NewInstanceWithMock.
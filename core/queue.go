@@ -136,7 +136,7 @@ func QueueWriteTexture(id QueueID, dst *gputypes.ImageCopyTexture, data []byte,
 // QueueOnSubmittedWorkDone returns when all submitted work completes.
 //
 // Deprecated: This is the legacy ID-based API. For new code, use the
-// HAL-based API via Queue.OnSubmittedWorkDone() (when implemented).
+// HAL-based API via Queue.OnSubmittedWorkDone().
 //
 // This function is currently a no-op as the ID-based API does not
 // perform actual GPU operations. It exists for backward compatibility.
@@ -0,0 +1,225 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// ErrIncompatiblePipeline is the Unwrap target of every
+// RenderPassCompatibilityError.
+var ErrIncompatiblePipeline = errors.New("render pipeline incompatible with render pass")
+
+// RenderPassContext describes the attachment formats and sample count of a
+// render pass, or the targets a render pipeline was created for. A pipeline
+// may only be used in passes with an equal context.
+//
+// Matches Rust wgpu-core's RenderPassContext (command/render.rs).
+type RenderPassContext struct {
+	// ColorFormats holds one format per color attachment slot.
+	// TextureFormatUndefined marks an empty slot.
+	ColorFormats []gputypes.TextureFormat
+
+	// DepthStencilFormat is TextureFormatUndefined when there is no
+	// depth/stencil attachment.
+	DepthStencilFormat gputypes.TextureFormat
+
+	// SampleCount is the sample count of every attachment.
+	SampleCount uint32
+
+	// ViewCount is the number of multiview views; 0 and 1 both mean a
+	// single view.
+	ViewCount uint32
+}
+
+// RenderPipelineContext returns the context a render pipeline created from
+// desc must be used in.
+func RenderPipelineContext(desc *hal.RenderPipelineDescriptor) RenderPassContext {
+	ctx := RenderPassContext{
+		SampleCount: max(desc.Multisample.Count, 1),
+		ViewCount:   max(desc.ViewCount, 1),
+	}
+	if desc.Fragment != nil {
+		ctx.ColorFormats = make([]gputypes.TextureFormat, len(desc.Fragment.Targets))
+		for i, target := range desc.Fragment.Targets {
+			ctx.ColorFormats[i] = target.Format
+		}
+	}
+	if desc.DepthStencil != nil {
+		ctx.DepthStencilFormat = desc.DepthStencil.Format
+	}
+	return ctx
+}
+
+// renderPassContext returns the context of a pass begun with desc, or nil
+// when an attachment view does not record its format and sample count.
+func renderPassContext(desc *RenderPassDescriptor) *RenderPassContext {
+	ctx := &RenderPassContext{ViewCount: max(desc.ViewCount, 1)}
+	noteView := func(view *TextureView) bool {
+		if view.Format == gputypes.TextureFormatUndefined || view.SampleCount == 0 {
+			return false
+		}
+		ctx.SampleCount = view.SampleCount
+		return true
+	}
+	ctx.ColorFormats = make([]gputypes.TextureFormat, len(desc.ColorAttachments))
+	for i, ca := range desc.ColorAttachments {
+		if ca.View == nil {
+			continue
+		}
+		if !noteView(ca.View) {
+			return nil
+		}
+		ctx.ColorFormats[i] = ca.View.Format
+	}
+	if ds := desc.DepthStencilAttachment; ds != nil && ds.View != nil {
+		if !noteView(ds.View) {
+			return nil
+		}
+		ctx.DepthStencilFormat = ds.View.Format
+	}
+	return ctx
+}
+
+// CheckPipeline returns a *RenderPassCompatibilityError when a pipeline
+// created for pipeline cannot be used in a pass with context c.
+// Trailing empty color slots are ignored, as in WebGPU.
+func (c *RenderPassContext) CheckPipeline(pipeline *RenderPassContext) *RenderPassCompatibilityError {
+	passColors := trimEmptyColorSlots(c.ColorFormats)
+	pipelineColors := trimEmptyColorSlots(pipeline.ColorFormats)
+	for i := range max(len(passColors), len(pipelineColors)) {
+		var passFormat, pipelineFormat gputypes.TextureFormat
+		if i < len(passColors) {
+			passFormat = passColors[i]
+		}
+		if i < len(pipelineColors) {
+			pipelineFormat = pipelineColors[i]
+		}
+		if passFormat != pipelineFormat {
+			return &RenderPassCompatibilityError{
+				Kind:           RenderPassCompatibilityErrorColorAttachment,
+				Index:          uint32(i), //nolint:gosec // attachment count fits uint32
+				PassFormat:     passFormat,
+				PipelineFormat: pipelineFormat,
+			}
+		}
+	}
+	if c.DepthStencilFormat != pipeline.DepthStencilFormat {
+		return &RenderPassCompatibilityError{
+			Kind:           RenderPassCompatibilityErrorDepthStencilAttachment,
+			PassFormat:     c.DepthStencilFormat,
+			PipelineFormat: pipeline.DepthStencilFormat,
+		}
+	}
+	if c.SampleCount != pipeline.SampleCount {
+		return &RenderPassCompatibilityError{
+			Kind:            RenderPassCompatibilityErrorSampleCount,
+			PassSamples:     c.SampleCount,
+			PipelineSamples: pipeline.SampleCount,
+		}
+	}
+	if passViews, pipelineViews := max(c.ViewCount, 1), max(pipeline.ViewCount, 1); passViews != pipelineViews {
+		return &RenderPassCompatibilityError{
+			Kind:          RenderPassCompatibilityErrorViewCount,
+			PassViews:     passViews,
+			PipelineViews: pipelineViews,
+		}
+	}
+	return nil
+}
+
+// trimEmptyColorSlots drops trailing TextureFormatUndefined entries.
+func trimEmptyColorSlots(formats []gputypes.TextureFormat) []gputypes.TextureFormat {
+	for len(formats) > 0 && formats[len(formats)-1] == gputypes.TextureFormatUndefined {
+		formats = formats[:len(formats)-1]
+	}
+	return formats
+}
+
+// RenderPassCompatibilityErrorKind identifies which part of a render pass
+// context did not match the pipeline.
+type RenderPassCompatibilityErrorKind int
+
+const (
+	// RenderPassCompatibilityErrorColorAttachment indicates a color
+	// attachment format differs from the pipeline's color target.
+	RenderPassCompatibilityErrorColorAttachment RenderPassCompatibilityErrorKind = iota
+	// RenderPassCompatibilityErrorDepthStencilAttachment indicates the
+	// depth/stencil attachment format differs from the pipeline's.
+	RenderPassCompatibilityErrorDepthStencilAttachment
+	// RenderPassCompatibilityErrorSampleCount indicates the pass and the
+	// pipeline use different sample counts.
+	RenderPassCompatibilityErrorSampleCount
+	// RenderPassCompatibilityErrorViewCount indicates the pass and the
+	// pipeline use different multiview view counts.
+	RenderPassCompatibilityErrorViewCount
+)
+
+// RenderPassCompatibilityError reports a SetPipeline call whose pipeline was
+// created for different attachments than the render pass has.
+type RenderPassCompatibilityError struct {
+	Kind RenderPassCompatibilityErrorKind
+	// Pipeline and Pass are the debug labels of the pipeline and the pass.
+	Pipeline string
+	Pass     string
+	// Index is the color attachment slot for color mismatches.
+	Index uint32
+	// PassFormat and PipelineFormat are the mismatched formats;
+	// TextureFormatUndefined means the attachment or target is absent.
+	PassFormat     gputypes.TextureFormat
+	PipelineFormat gputypes.TextureFormat
+	// PassSamples and PipelineSamples are the mismatched sample counts.
+	PassSamples     uint32
+	PipelineSamples uint32
+	// PassViews and PipelineViews are the mismatched view counts.
+	PassViews     uint32
+	PipelineViews uint32
+}
+
+// Error implements the error interface.
+func (e *RenderPassCompatibilityError) Error() string {
+	pipeline, pass := e.Pipeline, e.Pass
+	if pipeline == "" {
+		pipeline = unnamedLabel
+	}
+	if pass == "" {
+		pass = unnamedLabel
+	}
+	prefix := fmt.Sprintf("render pipeline %q incompatible with render pass %q", pipeline, pass)
+	switch e.Kind {
+	case RenderPassCompatibilityErrorColorAttachment:
+		return fmt.Sprintf("%s: color target [%d] is %s but the attachment is %s",
+			prefix, e.Index, attachmentFormatName(e.PipelineFormat), attachmentFormatName(e.PassFormat))
+	case RenderPassCompatibilityErrorDepthStencilAttachment:
+		return fmt.Sprintf("%s: depth/stencil format is %s but the attachment is %s",
+			prefix, attachmentFormatName(e.PipelineFormat), attachmentFormatName(e.PassFormat))
+	case RenderPassCompatibilityErrorSampleCount:
+		return fmt.Sprintf("%s: pipeline sample count %d but attachments have %d",
+			prefix, e.PipelineSamples, e.PassSamples)
+	case RenderPassCompatibilityErrorViewCount:
+		return fmt.Sprintf("%s: pipeline view count %d but the pass has %d",
+			prefix, e.PipelineViews, e.PassViews)
+	default:
+		return prefix
+	}
+}
+
+// Unwrap returns ErrIncompatiblePipeline.
+func (e *RenderPassCompatibilityError) Unwrap() error {
+	return ErrIncompatiblePipeline
+}
+
+// attachmentFormatName renders a format, or "none" for an absent one.
+func attachmentFormatName(format gputypes.TextureFormat) string {
+	if format == gputypes.TextureFormatUndefined {
+		return "none"
+	}
+	return format.String()
+}
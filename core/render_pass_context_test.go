@@ -0,0 +1,111 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestRenderPipelineContext(t *testing.T) {
+	ctx := RenderPipelineContext(&hal.RenderPipelineDescriptor{
+		Fragment: &hal.FragmentState{Targets: []gputypes.ColorTargetState{
+			{Format: gputypes.TextureFormatBGRA8Unorm},
+			{Format: gputypes.TextureFormatUndefined},
+		}},
+		DepthStencil: &hal.DepthStencilState{Format: gputypes.TextureFormatDepth24Plus},
+	})
+	if len(ctx.ColorFormats) != 2 || ctx.ColorFormats[0] != gputypes.TextureFormatBGRA8Unorm {
+		t.Errorf("ColorFormats = %v", ctx.ColorFormats)
+	}
+	if ctx.DepthStencilFormat != gputypes.TextureFormatDepth24Plus {
+		t.Errorf("DepthStencilFormat = %v", ctx.DepthStencilFormat)
+	}
+	if ctx.SampleCount != 1 {
+		t.Errorf("SampleCount = %d, want 1 for a zero multisample count", ctx.SampleCount)
+	}
+	if ctx.ViewCount != 1 {
+		t.Errorf("ViewCount = %d, want 1 for a zero view count", ctx.ViewCount)
+	}
+}
+
+func TestRenderPassContextCheckPipeline(t *testing.T) {
+	pass := RenderPassContext{
+		ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float},
+		DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+		SampleCount:        4,
+	}
+	tests := []struct {
+		name     string
+		pipeline RenderPassContext
+		wantKind RenderPassCompatibilityErrorKind
+		wantOK   bool
+	}{
+		{"equal", pass, 0, true},
+		{"trailing empty slot", RenderPassContext{
+			ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float, gputypes.TextureFormatUndefined},
+			DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+			SampleCount:        4,
+		}, 0, true},
+		{"color format", RenderPassContext{
+			ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatBGRA8Unorm},
+			DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+			SampleCount:        4,
+		}, RenderPassCompatibilityErrorColorAttachment, false},
+		{"extra color target", RenderPassContext{
+			ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float, gputypes.TextureFormatRGBA8Unorm},
+			DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+			SampleCount:        4,
+		}, RenderPassCompatibilityErrorColorAttachment, false},
+		{"missing depth", RenderPassContext{
+			ColorFormats: []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float},
+			SampleCount:  4,
+		}, RenderPassCompatibilityErrorDepthStencilAttachment, false},
+		{"sample count", RenderPassContext{
+			ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float},
+			DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+			SampleCount:        1,
+		}, RenderPassCompatibilityErrorSampleCount, false},
+		{"view count", RenderPassContext{
+			ColorFormats:       []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float},
+			DepthStencilFormat: gputypes.TextureFormatDepth32Float,
+			SampleCount:        4,
+			ViewCount:          2,
+		}, RenderPassCompatibilityErrorViewCount, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pass.CheckPipeline(&tt.pipeline)
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("got %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Kind != tt.wantKind {
+				t.Fatalf("got %v, want kind %d", err, tt.wantKind)
+			}
+			if !errors.Is(err, ErrIncompatiblePipeline) {
+				t.Errorf("errors.Is(ErrIncompatiblePipeline) = false for %v", err)
+			}
+		})
+	}
+}
+
+func TestRenderPassContextUnknownView(t *testing.T) {
+	desc := &RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{
+		{View: &TextureView{Format: gputypes.TextureFormatRGBA8Unorm, SampleCount: 1}},
+		{View: &TextureView{}},
+	}}
+	if ctx := renderPassContext(desc); ctx != nil {
+		t.Errorf("context = %+v, want nil when a view format is unknown", ctx)
+	}
+	desc.ColorAttachments = desc.ColorAttachments[:1]
+	ctx := renderPassContext(desc)
+	if ctx == nil || ctx.SampleCount != 1 || ctx.ColorFormats[0] != gputypes.TextureFormatRGBA8Unorm {
+		t.Errorf("context = %+v", ctx)
+	}
+}
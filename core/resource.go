@@ -25,6 +25,12 @@ type Adapter struct {
 	Limits gputypes.Limits
 	// Backend identifies which graphics backend this adapter uses.
 	Backend gputypes.Backend
+	// BlocklistReason is non-empty when the adapter matched the instance's
+	// adapter blocklist. Blocked adapters are fallback-only: adapter
+	// selection picks them only when nothing else is available.
+	BlocklistReason string
+	// Details identifies the adapter and its driver beyond Info.
+	Details hal.AdapterDetails
 
 	// === HAL integration fields ===
 
@@ -54,6 +60,26 @@ func (a *Adapter) Capabilities() *hal.Capabilities {
 	return a.halCapabilities
 }
 
+// TextureFormatCapabilities returns the adapter's capabilities for format.
+// Mock adapters, and HAL adapters that report multisample support without
+// sample counts, get the WebGPU baseline of 1x and 4x.
+func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
+	if a == nil || a.halAdapter == nil {
+		return hal.TextureFormatCapabilities{
+			Flags: hal.TextureFormatCapabilitySampled |
+				hal.TextureFormatCapabilityRenderAttachment |
+				hal.TextureFormatCapabilityMultisample |
+				hal.TextureFormatCapabilityMultisampleResolve,
+			SampleCounts: hal.SampleCountsWebGPU,
+		}
+	}
+	caps := a.halAdapter.TextureFormatCapabilities(format)
+	if caps.SampleCounts == 0 && caps.Flags&hal.TextureFormatCapabilityMultisample != 0 {
+		caps.SampleCounts = hal.SampleCountsWebGPU
+	}
+	return caps
+}
+
 // Device represents a logical GPU device.
 //
 // Device wraps a HAL device handle and provides safe access to GPU resources.
@@ -112,6 +138,14 @@ type Device struct {
 	Features gputypes.Features
 	// Limits contains the resource limits of this device.
 	Limits gputypes.Limits
+	// BufferUsageValidation enables the pre-submit buffer usage checks in
+	// CheckBufferUsage. The public API turns it on for instances created with
+	// gputypes.InstanceFlagsValidation.
+	BufferUsageValidation bool
+	// Validator runs the descriptor checks before HAL resource creation.
+	// NewDevice installs FullValidator; the public API replaces it with
+	// NoValidator for instances created with SkipValidation.
+	Validator DeviceValidator
 
 	// valid indicates whether the device is still valid for use.
 	// Once a device is destroyed, this becomes false.
@@ -130,6 +164,11 @@ type Device struct {
 	// or if validation resource creation failed (validation is optional).
 	// Matches Rust wgpu-core Device.indirect_validation (device/resource.rs:264).
 	indirectValidation *IndirectValidation
+
+	// bindGroupLayouts deduplicates bind group layouts by their entries.
+	// This is nil for devices created via the ID-based API without HAL.
+	// Matches Rust wgpu-core Device.bgl_pool (device/resource.rs).
+	bindGroupLayouts *BindGroupLayoutPool
 }
 
 // Backend returns the backend type of the device's adapter.
@@ -162,14 +201,16 @@ func NewDevice(
 	label string,
 ) *Device {
 	d := &Device{
-		raw:            NewSnatchable(halDevice),
-		adapter:        adapter,
-		snatchLock:     NewSnatchLock(),
-		trackerIndices: NewTrackerIndexAllocators(),
-		destroyQueue:   NewDestroyQueue(),
-		Label:          label,
-		Features:       features,
-		Limits:         limits,
+		raw:              NewSnatchable(halDevice),
+		adapter:          adapter,
+		snatchLock:       NewSnatchLock(),
+		trackerIndices:   NewTrackerIndexAllocators(),
+		destroyQueue:     NewDestroyQueue(),
+		bindGroupLayouts: NewBindGroupLayoutPool(),
+		Label:            label,
+		Features:         features,
+		Limits:           limits,
+		Validator:        NewValidator(true),
 	}
 	valid := &atomic.Bool{}
 	valid.Store(true)
@@ -307,6 +348,12 @@ func (d *Device) DestroyQueueRef() *DestroyQueue {
 	return d.destroyQueue
 }
 
+// BindGroupLayouts returns the device's bind group layout pool. Returns nil
+// for devices without HAL integration.
+func (d *Device) BindGroupLayouts() *BindGroupLayoutPool {
+	return d.bindGroupLayouts
+}
+
 // IndirectValidation returns the device's indirect dispatch validation
 // resources. Returns nil if validation is not available (no compute support,
 // or resource creation failed during device init).
@@ -407,12 +454,20 @@ func (d *Device) CreateBuffer(desc *gputypes.BufferDescriptor) (*Buffer, error)
 	const copyBufferAlignment uint64 = 4
 	alignedSize := (desc.Size + copyBufferAlignment - 1) &^ (copyBufferAlignment - 1)
 
-	// 7. Build HAL descriptor
+	// 7. Build HAL descriptor. MappedAtCreation without MapWrite is staged
+	// through host memory (see InstallStagedMappedAtCreation): the HAL
+	// buffer is created unmapped, so backends place it by usage rather than
+	// in host-visible memory, and gains CopyDst for the upload on Unmap.
+	stagedInit := desc.MappedAtCreation && !hasMapWrite
+	halUsage := desc.Usage
+	if stagedInit {
+		halUsage |= gputypes.BufferUsageCopyDst
+	}
 	halDesc := &hal.BufferDescriptor{
 		Label:            desc.Label,
 		Size:             alignedSize,
-		Usage:            desc.Usage,
-		MappedAtCreation: desc.MappedAtCreation,
+		Usage:            halUsage,
+		MappedAtCreation: desc.MappedAtCreation && !stagedInit,
 	}
 
 	// 8. Acquire snatch guard for HAL access
@@ -440,7 +495,10 @@ func (d *Device) CreateBuffer(desc *gputypes.BufferDescriptor) (*Buffer, error)
 	// 11. Handle MappedAtCreation — install the HAL mapping eagerly so
 	// the caller can write via Buffer.MappedRange without going through
 	// the Pending state machine.
-	if desc.MappedAtCreation {
+	if stagedInit {
+		buffer.InstallStagedMappedAtCreation()
+		buffer.MarkInitialized(0, desc.Size)
+	} else if desc.MappedAtCreation {
 		if err := buffer.InstallMappedAtCreation(guard, *halDevice); err != nil {
 			// HAL can't map the buffer despite MappedAtCreation — roll
 			// back and report as a CreateBuffer failure.
@@ -894,6 +952,11 @@ type TextureView struct {
 	// HAL is the underlying HAL texture view handle.
 	// Set by the public API layer when creating texture views with real HAL backends.
 	HAL hal.TextureView
+
+	// Format and SampleCount describe the view for render pass
+	// compatibility checks. Zero values mean unknown and disable them.
+	Format      gputypes.TextureFormat
+	SampleCount uint32
 }
 
 // Sampler represents a texture sampler with HAL integration.
@@ -1573,6 +1636,9 @@ type Surface struct {
 	// prepareFrame is an optional platform hook called before acquiring a texture.
 	prepareFrame PrepareFrameFunc
 
+	// stats counts acquire and present outcomes for diagnostics.
+	stats SurfaceStats
+
 	// mu protects state transitions.
 	mu sync.Mutex
 }
@@ -0,0 +1,91 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package core
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// SoftwareModeEnv names the environment variable that selects the software
+// backend mode when InstanceOptions.Software is SoftwareAuto: "only" uses
+// the software backend alone, "off" never uses it, and "auto" (or unset)
+// keeps it as the last fallback.
+const SoftwareModeEnv = "GOGPU_SOFTWARE"
+
+// SoftwareMode controls when the Pure-Go software backend is used. The
+// backend is a fallback like any other registered provider; the mode only
+// decides whether it joins the backend fallback chain.
+type SoftwareMode uint8
+
+const (
+	// SoftwareAuto tries the software backend after every GPU backend, so
+	// machines without a usable GPU driver still get an adapter.
+	// SoftwareModeEnv can override it.
+	SoftwareAuto SoftwareMode = iota
+	// SoftwareOnly skips GPU backends and uses the software backend alone.
+	SoftwareOnly
+	// SoftwareDisabled never uses the software backend.
+	SoftwareDisabled
+)
+
+// String returns the SoftwareModeEnv spelling of the mode.
+func (m SoftwareMode) String() string {
+	switch m {
+	case SoftwareAuto:
+		return "auto"
+	case SoftwareOnly:
+		return "only"
+	case SoftwareDisabled:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
+
+// resolveSoftwareMode applies the SoftwareModeEnv override to an automatic
+// mode. Explicit modes win over the environment; unknown values are ignored.
+func resolveSoftwareMode(mode SoftwareMode) SoftwareMode {
+	if mode != SoftwareAuto {
+		return mode
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(SoftwareModeEnv))) {
+	case "only", "1":
+		return SoftwareOnly
+	case "off", "0":
+		return SoftwareDisabled
+	default:
+		return SoftwareAuto
+	}
+}
+
+// softwareBackendOrder applies mode to the backend fallback chain. A nil
+// order stands for every enabled provider in priority order, so disabling
+// the software backend expands it before filtering. Backends dropped from
+// the chain show up as disabled in the BackendReport.
+func softwareBackendOrder(mode SoftwareMode, order []gputypes.Backend, providers []BackendProvider) []gputypes.Backend {
+	switch mode {
+	case SoftwareOnly:
+		return []gputypes.Backend{gputypes.BackendEmpty}
+	case SoftwareDisabled:
+		if order == nil {
+			for _, p := range providers {
+				order = append(order, p.Variant())
+			}
+		}
+		filtered := make([]gputypes.Backend, 0, len(order))
+		for _, backend := range order {
+			if backend != gputypes.BackendEmpty {
+				filtered = append(filtered, backend)
+			}
+		}
+		return filtered
+	default:
+		return order
+	}
+}
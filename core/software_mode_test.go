@@ -0,0 +1,65 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestResolveSoftwareMode(t *testing.T) {
+	tests := []struct {
+		env  string
+		mode SoftwareMode
+		want SoftwareMode
+	}{
+		{"", SoftwareAuto, SoftwareAuto},
+		{"only", SoftwareAuto, SoftwareOnly},
+		{" ONLY ", SoftwareAuto, SoftwareOnly},
+		{"1", SoftwareAuto, SoftwareOnly},
+		{"off", SoftwareAuto, SoftwareDisabled},
+		{"0", SoftwareAuto, SoftwareDisabled},
+		{"bogus", SoftwareAuto, SoftwareAuto},
+		{"only", SoftwareDisabled, SoftwareDisabled},
+		{"off", SoftwareOnly, SoftwareOnly},
+	}
+	for _, tt := range tests {
+		t.Setenv(SoftwareModeEnv, tt.env)
+		if got := resolveSoftwareMode(tt.mode); got != tt.want {
+			t.Errorf("env %q, mode %v: got %v, want %v", tt.env, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestSoftwareBackendOrder(t *testing.T) {
+	providers := []BackendProvider{
+		&testProvider{variant: gputypes.BackendVulkan, available: true},
+		&testProvider{variant: gputypes.BackendGL, available: true},
+		&testProvider{variant: gputypes.BackendEmpty, available: true},
+	}
+	custom := []gputypes.Backend{gputypes.BackendEmpty, gputypes.BackendGL}
+
+	if got := softwareBackendOrder(SoftwareAuto, nil, providers); got != nil {
+		t.Errorf("auto, nil order = %v, want nil", got)
+	}
+	if got := softwareBackendOrder(SoftwareAuto, custom, providers); !slices.Equal(got, custom) {
+		t.Errorf("auto, custom order = %v, want %v", got, custom)
+	}
+	only := []gputypes.Backend{gputypes.BackendEmpty}
+	if got := softwareBackendOrder(SoftwareOnly, custom, providers); !slices.Equal(got, only) {
+		t.Errorf("only = %v, want %v", got, only)
+	}
+	want := []gputypes.Backend{gputypes.BackendVulkan, gputypes.BackendGL}
+	if got := softwareBackendOrder(SoftwareDisabled, nil, providers); !slices.Equal(got, want) {
+		t.Errorf("disabled, nil order = %v, want %v", got, want)
+	}
+	want = []gputypes.Backend{gputypes.BackendGL}
+	if got := softwareBackendOrder(SoftwareDisabled, custom, providers); !slices.Equal(got, want) {
+		t.Errorf("disabled, custom order = %v, want %v", got, want)
+	}
+	if got := softwareBackendOrder(SoftwareDisabled, nil, providers[2:]); got == nil || len(got) != 0 {
+		t.Errorf("disabled, software only = %#v, want empty non-nil order", got)
+	}
+}
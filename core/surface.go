@@ -9,6 +9,7 @@ import (
 	"unsafe"
 
 	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/capture"
 )
 
 // Surface lifecycle errors.
@@ -25,6 +26,10 @@ var (
 	// without an acquired texture.
 	ErrSurfaceNoTextureAcquired = errors.New("core: no surface texture acquired")
 
+	// ErrSurfaceTextureMismatch is the Unwrap target of a SurfacePresentError
+	// for a texture that was not acquired from the presenting surface.
+	ErrSurfaceTextureMismatch = errors.New("core: surface texture was not acquired from this surface")
+
 	// ErrSurfaceConfigureWhileAcquired is returned when attempting to configure
 	// a surface while a texture is still acquired.
 	ErrSurfaceConfigureWhileAcquired = errors.New("core: cannot configure surface while texture is acquired")
@@ -36,6 +41,95 @@ var (
 	ErrSurfaceNilConfig = errors.New("core: surface configuration must not be nil")
 )
 
+// SurfacePresentErrorKind identifies why a present was rejected.
+type SurfacePresentErrorKind int
+
+const (
+	// SurfacePresentErrorNotAcquired indicates the surface has no acquired
+	// texture.
+	SurfacePresentErrorNotAcquired SurfacePresentErrorKind = iota
+	// SurfacePresentErrorExpired indicates the texture's acquisition already
+	// ended: it was presented, discarded, or the surface was reconfigured.
+	SurfacePresentErrorExpired
+	// SurfacePresentErrorMismatch indicates the texture is not the one the
+	// surface handed out for the current acquisition.
+	SurfacePresentErrorMismatch
+)
+
+// SurfacePresentError reports a present that was rejected before reaching
+// the backend. Backends assume the texture passed to hal.Queue.Present is the
+// surface's current acquisition; presenting anything else is undefined.
+type SurfacePresentError struct {
+	Kind SurfacePresentErrorKind
+	// Surface is the debug label of the presenting surface.
+	Surface string
+}
+
+// Error implements the error interface.
+func (e *SurfacePresentError) Error() string {
+	label := e.Surface
+	if label == "" {
+		label = unnamedLabel
+	}
+	prefix := fmt.Sprintf("core: cannot present surface %q", label)
+	switch e.Kind {
+	case SurfacePresentErrorNotAcquired:
+		return prefix + ": no texture acquired"
+	case SurfacePresentErrorExpired:
+		return prefix + ": texture was already presented or discarded"
+	case SurfacePresentErrorMismatch:
+		return prefix + ": texture was not acquired from this surface"
+	default:
+		return prefix
+	}
+}
+
+// Unwrap returns ErrSurfaceTextureMismatch for SurfacePresentErrorMismatch
+// and ErrSurfaceNoTextureAcquired otherwise.
+func (e *SurfacePresentError) Unwrap() error {
+	if e.Kind == SurfacePresentErrorMismatch {
+		return ErrSurfaceTextureMismatch
+	}
+	return ErrSurfaceNoTextureAcquired
+}
+
+// SurfaceStats counts a surface's acquire and present outcomes since it was
+// created. Suboptimal frames are still rendered and presented; outdated ones
+// fail until the surface is reconfigured.
+type SurfaceStats struct {
+	// Acquired is the number of successfully acquired textures.
+	Acquired uint64
+	// Suboptimal is the number of acquired textures reported as suboptimal.
+	Suboptimal uint64
+	// AcquireFailures is the number of acquires that returned an error,
+	// including timeouts.
+	AcquireFailures uint64
+	// PresentFailures is the number of presents that returned an error.
+	PresentFailures uint64
+	// Outdated is the number of acquires and presents that failed with
+	// hal.ErrSurfaceOutdated.
+	Outdated uint64
+	// Reconfigurations is the number of times the swapchain was recreated:
+	// Configure on a configured surface and PrepareFrame resizes.
+	Reconfigurations uint64
+}
+
+// recordFailure counts err against failures and, when the surface is
+// outdated, against Outdated. Must be called with s.mu held.
+func (s *Surface) recordFailure(failures *uint64, err error) {
+	*failures++
+	if errors.Is(err, hal.ErrSurfaceOutdated) {
+		s.stats.Outdated++
+	}
+}
+
+// Stats returns the surface's acquire and present counters.
+func (s *Surface) Stats() SurfaceStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
 // SetPrepareFrame registers a platform hook that is called before acquiring a texture.
 //
 // The hook returns the current surface dimensions and whether they changed.
@@ -74,9 +168,13 @@ func (s *Surface) Configure(device *Device, config *hal.SurfaceConfiguration) er
 		return ErrDeviceDestroyed
 	}
 
-	if err := s.raw.Configure(halDevice, config); err != nil {
+	if err := s.raw.Configure(capture.Unwrap(halDevice), config); err != nil {
 		return err
 	}
+	capture.SurfaceConfigured(halDevice, config)
+	if s.config != nil {
+		s.stats.Reconfigurations++
+	}
 
 	s.invalidateAcquisitionLocked()
 	s.device = device
@@ -106,7 +204,7 @@ func (s *Surface) Unconfigure() {
 
 	halDevice := s.getHALDevice(s.device)
 	if halDevice != nil {
-		s.raw.Unconfigure(halDevice)
+		s.raw.Unconfigure(capture.Unwrap(halDevice))
 	}
 
 	s.device = nil
@@ -143,13 +241,19 @@ func (s *Surface) AcquireTextureWithLease(fence hal.Fence) (*hal.AcquiredSurface
 
 	// Call PrepareFrame hook if registered
 	if err := s.applyPrepareFrame(); err != nil {
+		s.recordFailure(&s.stats.AcquireFailures, err)
 		return nil, 0, err
 	}
 
 	result, err := s.raw.AcquireTexture(fence)
 	if err != nil {
+		s.recordFailure(&s.stats.AcquireFailures, err)
 		return nil, 0, err
 	}
+	s.stats.Acquired++
+	if result.Suboptimal {
+		s.stats.Suboptimal++
+	}
 
 	s.acquiredTex = result.Texture
 	s.nextAcquisition++
@@ -195,13 +299,53 @@ func (s *Surface) PresentWithDamage(queue hal.Queue, damageRects []image.Rectang
 	defer s.mu.Unlock()
 
 	if s.state != SurfaceStateAcquired {
-		return ErrSurfaceNoTextureAcquired
+		return &SurfacePresentError{Kind: SurfacePresentErrorNotAcquired, Surface: s.label}
+	}
+	return s.presentLocked(queue, damageRects)
+}
+
+// PresentTexture presents texture, which must be the texture returned with
+// lease by AcquireTextureWithLease. Presenting a texture whose acquisition
+// ended, or one acquired from another surface, returns a *SurfacePresentError
+// and leaves the current acquisition untouched.
+func (s *Surface) PresentTexture(queue hal.Queue, lease uint64, texture hal.SurfaceTexture, damageRects []image.Rectangle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkPresentLocked(lease, texture); err != nil {
+		return err
 	}
+	return s.presentLocked(queue, damageRects)
+}
+
+// checkPresentLocked validates texture against the current acquisition.
+// Must be called with s.mu held.
+func (s *Surface) checkPresentLocked(lease uint64, texture hal.SurfaceTexture) error {
+	var kind SurfacePresentErrorKind
+	switch {
+	case s.state != SurfaceStateAcquired && lease == 0:
+		kind = SurfacePresentErrorNotAcquired
+	case lease != 0 && lease != s.acquisition:
+		// Covers presenting twice: the first present ended the acquisition.
+		kind = SurfacePresentErrorExpired
+	case lease == 0 || texture == nil || texture != s.acquiredTex:
+		kind = SurfacePresentErrorMismatch
+	default:
+		return nil
+	}
+	return &SurfacePresentError{Kind: kind, Surface: s.label}
+}
 
+// presentLocked presents the acquired texture and ends the acquisition.
+// Must be called with s.mu held and the surface in the Acquired state.
+func (s *Surface) presentLocked(queue hal.Queue, damageRects []image.Rectangle) error {
 	err := queue.Present(s.raw, s.acquiredTex, damageRects)
 	s.acquiredTex = nil
 	s.invalidateAcquisitionLocked()
 	s.state = SurfaceStateConfigured
+	if err != nil {
+		s.recordFailure(&s.stats.PresentFailures, err)
+	}
 	return err
 }
 
@@ -324,12 +468,17 @@ func (s *Surface) Destroy() {
 		raw.DiscardTexture(acquiredTex)
 	}
 	if halDevice != nil {
-		raw.Unconfigure(halDevice)
+		raw.Unconfigure(capture.Unwrap(halDevice))
 	}
 	raw.Destroy()
 	untrackResource(uintptr(unsafe.Pointer(s))) //nolint:gosec // debug tracking uses pointer as unique ID
 }
 
+// Label returns the surface's debug label.
+func (s *Surface) Label() string {
+	return s.label
+}
+
 // State returns the current lifecycle state of the surface.
 func (s *Surface) State() SurfaceState {
 	s.mu.Lock()
@@ -366,15 +515,19 @@ func (s *Surface) applyPrepareFrame() error {
 		return ErrDeviceDestroyed
 	}
 
-	if err := s.raw.Configure(halDevice, &newConfig); err != nil {
+	if err := s.raw.Configure(capture.Unwrap(halDevice), &newConfig); err != nil {
 		return err
 	}
+	capture.SurfaceConfigured(halDevice, &newConfig)
+	s.stats.Reconfigurations++
 	s.config = &newConfig
 	return nil
 }
 
 // getHALDevice extracts the hal.Device from a core.Device using the snatch lock.
 // Returns nil if the device has been destroyed or has no HAL integration.
+// Backends receive capture.Unwrap of the result: a capturing device is not
+// theirs.
 // Must NOT be called with s.mu held if the device's snatch lock could deadlock;
 // in practice the snatch lock is independent so this is safe.
 func (s *Surface) getHALDevice(device *Device) hal.Device {
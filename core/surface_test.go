@@ -351,6 +351,61 @@ func TestSurfacePresentWithoutAcquire(t *testing.T) {
 	}
 }
 
+// foreignSurfaceTexture is a surface texture no test surface handed out.
+type foreignSurfaceTexture struct {
+	noop.SurfaceTexture
+}
+
+func TestSurfacePresentTextureValidation(t *testing.T) {
+	surface, device, queue := newTestSurface(t)
+	if err := surface.Configure(device, testSurfaceConfig()); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+
+	wantKind := func(err error, kind SurfacePresentErrorKind) {
+		t.Helper()
+		var pe *SurfacePresentError
+		if !errors.As(err, &pe) || pe.Kind != kind {
+			t.Fatalf("err = %v, want SurfacePresentError kind %d", err, kind)
+		}
+	}
+
+	wantKind(surface.PresentTexture(queue, 0, nil, nil), SurfacePresentErrorNotAcquired)
+
+	acquired, lease, err := surface.AcquireTextureWithLease(nil)
+	if err != nil {
+		t.Fatalf("AcquireTextureWithLease: %v", err)
+	}
+	err = surface.PresentTexture(queue, lease, &foreignSurfaceTexture{}, nil)
+	wantKind(err, SurfacePresentErrorMismatch)
+	if !errors.Is(err, ErrSurfaceTextureMismatch) {
+		t.Errorf("mismatch error %v does not wrap ErrSurfaceTextureMismatch", err)
+	}
+	wantKind(surface.PresentTexture(queue, lease+1, acquired.Texture, nil), SurfacePresentErrorExpired)
+	if !surface.AcquisitionValid(lease) {
+		t.Fatal("rejected present ended the acquisition")
+	}
+
+	if err := surface.PresentTexture(queue, lease, acquired.Texture, nil); err != nil {
+		t.Fatalf("PresentTexture: %v", err)
+	}
+	err = surface.PresentTexture(queue, lease, acquired.Texture, nil)
+	wantKind(err, SurfacePresentErrorExpired)
+	if !errors.Is(err, ErrSurfaceNoTextureAcquired) {
+		t.Errorf("double present error %v does not wrap ErrSurfaceNoTextureAcquired", err)
+	}
+
+	// A texture from an earlier frame stays rejected after the next acquire.
+	next, _, err := surface.AcquireTextureWithLease(nil)
+	if err != nil {
+		t.Fatalf("second AcquireTextureWithLease: %v", err)
+	}
+	wantKind(surface.PresentTexture(queue, lease, next.Texture, nil), SurfacePresentErrorExpired)
+	if surface.State() != SurfaceStateAcquired {
+		t.Errorf("state = %d, want SurfaceStateAcquired", surface.State())
+	}
+}
+
 func TestSurfaceAcquireWithoutConfigure(t *testing.T) {
 	surface, _, _ := newTestSurface(t)
 
@@ -937,3 +992,66 @@ func TestPresentPixelsExpiresActiveAcquisition(t *testing.T) {
 		t.Fatalf("backend calls = %d discards, %d presents; want one each", raw.discards, raw.presents)
 	}
 }
+
+// staleTestSurface reports queued acquire outcomes before falling back to the
+// noop surface.
+type staleTestSurface struct {
+	noop.Surface
+	suboptimal []bool
+	errs       []error
+}
+
+func (s *staleTestSurface) AcquireTexture(fence hal.Fence) (*hal.AcquiredSurfaceTexture, error) {
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	acquired, err := s.Surface.AcquireTexture(fence)
+	if err == nil && len(s.suboptimal) > 0 {
+		acquired.Suboptimal = s.suboptimal[0]
+		s.suboptimal = s.suboptimal[1:]
+	}
+	return acquired, err
+}
+
+func TestSurfaceStats(t *testing.T) {
+	_, device, queue := newTestSurface(t)
+	raw := &staleTestSurface{
+		suboptimal: []bool{true, false},
+		errs:       []error{nil, hal.ErrSurfaceOutdated, nil},
+	}
+	surface := NewSurface(raw, "stats-test")
+	if err := surface.Configure(device, testSurfaceConfig()); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+
+	// A suboptimal frame is still handed out and presented.
+	result, err := surface.AcquireTexture(nil)
+	if err != nil {
+		t.Fatalf("AcquireTexture (suboptimal): %v", err)
+	}
+	if !result.Suboptimal {
+		t.Error("AcquireTexture did not report the suboptimal frame")
+	}
+	if err := surface.Present(queue); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+
+	if _, err := surface.AcquireTexture(nil); !errors.Is(err, hal.ErrSurfaceOutdated) {
+		t.Fatalf("AcquireTexture (outdated) = %v, want hal.ErrSurfaceOutdated", err)
+	}
+	if err := surface.Configure(device, testSurfaceConfig()); err != nil {
+		t.Fatalf("reconfigure: %v", err)
+	}
+	if _, err := surface.AcquireTexture(nil); err != nil {
+		t.Fatalf("AcquireTexture after reconfigure: %v", err)
+	}
+
+	want := SurfaceStats{Acquired: 2, Suboptimal: 1, AcquireFailures: 1, Outdated: 1, Reconfigurations: 1}
+	if got := surface.Stats(); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// astcBlockSizes lists ASTC block footprints in gputypes format order; each
+// footprint has an Unorm and an UnormSrgb format.
+var astcBlockSizes = [...][2]uint32{
+	{4, 4}, {5, 4}, {5, 5}, {6, 5}, {6, 6}, {8, 5}, {8, 6}, {8, 8},
+	{10, 5}, {10, 6}, {10, 8}, {10, 10}, {12, 10}, {12, 12},
+}
+
+// textureFormatBlockSize returns the texel block footprint of format: 4x4
+// for BC, ETC2 and EAC, the footprint for ASTC, 1x1 otherwise.
+func textureFormatBlockSize(format gputypes.TextureFormat) (width, height uint32) {
+	switch {
+	case format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb:
+		dims := astcBlockSizes[(format-gputypes.TextureFormatASTC4x4Unorm)/2]
+		return dims[0], dims[1]
+	case format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatEACRG11Snorm:
+		return 4, 4
+	}
+	return 1, 1
+}
+
+// isCompressedFormat reports whether format stores texels in blocks larger
+// than one texel.
+func isCompressedFormat(format gputypes.TextureFormat) bool {
+	width, _ := textureFormatBlockSize(format)
+	return width > 1
+}
+
+// TextureCopyReinterprets reports whether a copy from src to dst
+// reinterprets block data: one format is block-compressed and the other an
+// uncompressed color format whose texel is the size of a compressed block,
+// such as BC7RGBAUnorm (16-byte 4x4 blocks) and RGBA32Uint (16-byte texels).
+// Compute shaders use it to encode into an RGBA32Uint texture and copy the
+// result into a BC7 one.
+func TextureCopyReinterprets(src, dst gputypes.TextureFormat) bool {
+	if isCompressedFormat(src) == isCompressedFormat(dst) || src.IsDepthStencil() || dst.IsDepthStencil() {
+		return false
+	}
+	size := src.BlockCopySize()
+	return size != 0 && size == dst.BlockCopySize()
+}
+
+// TextureCopyFormatsCompatible reports whether CopyTextureToTexture can copy
+// from src to dst: the formats are equal, differ only in their sRGB
+// encoding, or TextureCopyReinterprets holds for them.
+func TextureCopyFormatsCompatible(src, dst gputypes.TextureFormat) bool {
+	return ViewFormatCompatible(src, dst) || TextureCopyReinterprets(src, dst)
+}
+
+// TextureCopyDestinationSize returns the region of a dst texture that a copy
+// of size texels from a src texture writes: size converted from src blocks
+// to dst blocks. It is size itself unless the copy reinterprets compressed
+// blocks.
+func TextureCopyDestinationSize(src, dst gputypes.TextureFormat, size hal.Extent3D) hal.Extent3D {
+	srcWidth, srcHeight := textureFormatBlockSize(src)
+	dstWidth, dstHeight := textureFormatBlockSize(dst)
+	return hal.Extent3D{
+		Width:              size.Width / srcWidth * dstWidth,
+		Height:             size.Height / srcHeight * dstHeight,
+		DepthOrArrayLayers: size.DepthOrArrayLayers,
+	}
+}
+
+// textureCopyMipExtent returns the size of mip level mip of tex, rounded up
+// to whole texel blocks, with array layers as the depth of 1D and 2D
+// textures.
+func textureCopyMipExtent(tex *hal.TextureDescriptor, mip uint32) hal.Extent3D {
+	blockWidth, blockHeight := textureFormatBlockSize(tex.Format)
+	extent := hal.Extent3D{
+		Width:              max(tex.Size.Width>>mip, 1),
+		Height:             max(tex.Size.Height>>mip, 1),
+		DepthOrArrayLayers: tex.Size.DepthOrArrayLayers,
+	}
+	switch tex.Dimension {
+	case gputypes.TextureDimension1D:
+		extent.Height = 1
+	case gputypes.TextureDimension3D:
+		extent.DepthOrArrayLayers = max(tex.Size.DepthOrArrayLayers>>mip, 1)
+	}
+	extent.Width = (extent.Width + blockWidth - 1) / blockWidth * blockWidth
+	extent.Height = (extent.Height + blockHeight - 1) / blockHeight * blockHeight
+	return extent
+}
+
+// validateTextureCopySide checks one side of a copy: the mip level exists,
+// origin and size are whole texel blocks, and the region fits the mip level.
+func validateTextureCopySide(side string, tex *hal.TextureDescriptor, base *hal.ImageCopyTexture, size hal.Extent3D) error {
+	if base.MipLevel >= max(tex.MipLevelCount, 1) {
+		return &CopyTextureError{Kind: CopyTextureErrorInvalidMipLevel, Side: side, MipLevel: base.MipLevel}
+	}
+	blockWidth, blockHeight := textureFormatBlockSize(tex.Format)
+	if base.Origin.X%blockWidth != 0 || base.Origin.Y%blockHeight != 0 ||
+		size.Width%blockWidth != 0 || size.Height%blockHeight != 0 {
+		return &CopyTextureError{
+			Kind:        CopyTextureErrorUnaligned,
+			Side:        side,
+			Origin:      base.Origin,
+			Size:        size,
+			BlockWidth:  blockWidth,
+			BlockHeight: blockHeight,
+		}
+	}
+	extent := textureCopyMipExtent(tex, base.MipLevel)
+	if uint64(base.Origin.X)+uint64(size.Width) > uint64(extent.Width) ||
+		uint64(base.Origin.Y)+uint64(size.Height) > uint64(extent.Height) ||
+		uint64(base.Origin.Z)+uint64(size.DepthOrArrayLayers) > uint64(extent.DepthOrArrayLayers) {
+		return &CopyTextureError{
+			Kind:     CopyTextureErrorOutOfBounds,
+			Side:     side,
+			MipLevel: base.MipLevel,
+			Origin:   base.Origin,
+			Size:     size,
+			Extent:   extent,
+		}
+	}
+	return nil
+}
+
+// ValidateTextureCopy validates a CopyTextureToTexture region between
+// textures created with src and dst. downlevel are the adapter's downlevel
+// flags, consulted for copies that reinterpret compressed blocks.
+// Returns nil if valid, or a *CopyTextureError describing the first validation failure.
+func ValidateTextureCopy(region *hal.TextureCopy, src, dst *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error {
+	// TC1: Formats must be copy-compatible.
+	if !TextureCopyFormatsCompatible(src.Format, dst.Format) {
+		return &CopyTextureError{Kind: CopyTextureErrorFormatsIncompatible, SrcFormat: src.Format, DstFormat: dst.Format}
+	}
+
+	// TC2: Reinterpreting compressed blocks needs backend support.
+	if TextureCopyReinterprets(src.Format, dst.Format) && downlevel&hal.DownlevelFlagsTextureCopyReinterpret == 0 {
+		return &CopyTextureError{Kind: CopyTextureErrorReinterpretUnsupported, SrcFormat: src.Format, DstFormat: dst.Format}
+	}
+
+	// TC3: Sample counts must match.
+	if max(src.SampleCount, 1) != max(dst.SampleCount, 1) {
+		return &CopyTextureError{
+			Kind:           CopyTextureErrorSampleCountMismatch,
+			SrcSampleCount: src.SampleCount,
+			DstSampleCount: dst.SampleCount,
+		}
+	}
+
+	// TC4: Each side must be block aligned and in bounds; the destination
+	// region is the source size converted to destination blocks.
+	if err := validateTextureCopySide("source", src, &region.SrcBase, region.Size); err != nil {
+		return err
+	}
+	return validateTextureCopySide("destination", dst, &region.DstBase,
+		TextureCopyDestinationSize(src.Format, dst.Format, region.Size))
+}
@@ -0,0 +1,120 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestTextureCopyFormatsCompatible(t *testing.T) {
+	tests := []struct {
+		src, dst    gputypes.TextureFormat
+		compatible  bool
+		reinterpret bool
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8Unorm, true, false},
+		{gputypes.TextureFormatBGRA8UnormSrgb, gputypes.TextureFormatBGRA8Unorm, true, false},
+		{gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatRGBA32Uint, true, true},
+		{gputypes.TextureFormatRGBA32Float, gputypes.TextureFormatBC3RGBAUnormSrgb, true, true},
+		{gputypes.TextureFormatRG32Uint, gputypes.TextureFormatBC1RGBAUnorm, true, true},
+		{gputypes.TextureFormatRGBA16Uint, gputypes.TextureFormatETC2RGB8Unorm, true, true},
+		{gputypes.TextureFormatASTC8x8Unorm, gputypes.TextureFormatRGBA32Uint, true, true},
+		{gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatRG32Uint, false, false},
+		{gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatBC3RGBAUnorm, false, false},
+		{gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatR32Uint, false, false},
+		{gputypes.TextureFormatDepth32Float, gputypes.TextureFormatR32Float, false, false},
+	}
+	for _, tt := range tests {
+		if got := TextureCopyFormatsCompatible(tt.src, tt.dst); got != tt.compatible {
+			t.Errorf("TextureCopyFormatsCompatible(%v, %v) = %v, want %v", tt.src, tt.dst, got, tt.compatible)
+		}
+		if got := TextureCopyReinterprets(tt.src, tt.dst); got != tt.reinterpret {
+			t.Errorf("TextureCopyReinterprets(%v, %v) = %v, want %v", tt.src, tt.dst, got, tt.reinterpret)
+		}
+	}
+}
+
+func TestTextureCopyDestinationSize(t *testing.T) {
+	size := hal.Extent3D{Width: 8, Height: 12, DepthOrArrayLayers: 2}
+	got := TextureCopyDestinationSize(gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatRGBA32Uint, size)
+	if want := (hal.Extent3D{Width: 2, Height: 3, DepthOrArrayLayers: 2}); got != want {
+		t.Errorf("BC7 to RGBA32Uint = %+v, want %+v", got, want)
+	}
+	got = TextureCopyDestinationSize(gputypes.TextureFormatRGBA32Uint, gputypes.TextureFormatASTC6x5Unorm, hal.Extent3D{Width: 2, Height: 3, DepthOrArrayLayers: 1})
+	if want := (hal.Extent3D{Width: 12, Height: 15, DepthOrArrayLayers: 1}); got != want {
+		t.Errorf("RGBA32Uint to ASTC6x5 = %+v, want %+v", got, want)
+	}
+	if got = TextureCopyDestinationSize(gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb, size); got != size {
+		t.Errorf("same block size = %+v, want %+v", got, size)
+	}
+}
+
+func TestValidateTextureCopy(t *testing.T) {
+	texture := func(format gputypes.TextureFormat, width, height uint32) *hal.TextureDescriptor {
+		return &hal.TextureDescriptor{
+			Size:          hal.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+			MipLevelCount: 2,
+			SampleCount:   1,
+			Dimension:     gputypes.TextureDimension2D,
+			Format:        format,
+		}
+	}
+	bc7 := texture(gputypes.TextureFormatBC7RGBAUnorm, 16, 16)
+	rgba32 := texture(gputypes.TextureFormatRGBA32Uint, 4, 4)
+	rgba8 := texture(gputypes.TextureFormatRGBA8Unorm, 16, 16)
+	msaa := texture(gputypes.TextureFormatRGBA8Unorm, 16, 16)
+	msaa.SampleCount = 4
+	region := func(srcX, dstX, mip, width, height uint32) *hal.TextureCopy {
+		return &hal.TextureCopy{
+			SrcBase: hal.ImageCopyTexture{Origin: hal.Origin3D{X: srcX}, MipLevel: mip},
+			DstBase: hal.ImageCopyTexture{Origin: hal.Origin3D{X: dstX}, MipLevel: mip},
+			Size:    hal.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		}
+	}
+	reinterpret := hal.DownlevelFlagsTextureCopyReinterpret
+
+	tests := []struct {
+		name      string
+		region    *hal.TextureCopy
+		src, dst  *hal.TextureDescriptor
+		downlevel hal.DownlevelFlags
+		kind      CopyTextureErrorKind
+		side      string
+		valid     bool
+	}{
+		{"bc7 to rgba32", region(0, 0, 0, 16, 16), bc7, rgba32, reinterpret, 0, "", true},
+		{"rgba32 to bc7", region(2, 8, 0, 2, 4), rgba32, bc7, reinterpret, 0, "", true},
+		{"mip 1", region(0, 0, 1, 8, 8), bc7, rgba32, reinterpret, 0, "", true},
+		{"same format", region(0, 0, 0, 16, 16), rgba8, rgba8, 0, 0, "", true},
+		{"incompatible", region(0, 0, 0, 4, 4), rgba8, rgba32, reinterpret, CopyTextureErrorFormatsIncompatible, "", false},
+		{"reinterpret unsupported", region(0, 0, 0, 16, 16), bc7, rgba32, 0, CopyTextureErrorReinterpretUnsupported, "", false},
+		{"sample count", region(0, 0, 0, 16, 16), rgba8, msaa, 0, CopyTextureErrorSampleCountMismatch, "", false},
+		{"mip level", region(0, 0, 2, 4, 4), bc7, rgba32, reinterpret, CopyTextureErrorInvalidMipLevel, "source", false},
+		{"unaligned size", region(0, 0, 0, 6, 4), bc7, rgba32, reinterpret, CopyTextureErrorUnaligned, "source", false},
+		{"unaligned destination", region(0, 2, 0, 1, 1), rgba32, bc7, reinterpret, CopyTextureErrorUnaligned, "destination", false},
+		{"destination out of bounds", region(0, 4, 0, 16, 16), bc7, rgba32, reinterpret, CopyTextureErrorOutOfBounds, "destination", false},
+		{"source out of bounds", region(4, 0, 1, 8, 8), bc7, rgba32, reinterpret, CopyTextureErrorOutOfBounds, "source", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTextureCopy(tt.region, tt.src, tt.dst, tt.downlevel)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var cte *CopyTextureError
+			if !errors.As(err, &cte) || cte.Kind != tt.kind || cte.Side != tt.side {
+				t.Fatalf("got %v, want kind %d on %q", err, tt.kind, tt.side)
+			}
+			if cte.Error() == "" {
+				t.Error("empty error message")
+			}
+		})
+	}
+}
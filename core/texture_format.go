@@ -0,0 +1,64 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// SrgbCounterpart returns the format that differs from format only in its
+// sRGB encoding: RGBA8UnormSrgb for RGBA8Unorm and vice versa. ok is false
+// for formats without an sRGB variant.
+func SrgbCounterpart(format gputypes.TextureFormat) (counterpart gputypes.TextureFormat, ok bool) {
+	switch format {
+	case gputypes.TextureFormatRGBA8Unorm:
+		return gputypes.TextureFormatRGBA8UnormSrgb, true
+	case gputypes.TextureFormatRGBA8UnormSrgb:
+		return gputypes.TextureFormatRGBA8Unorm, true
+	case gputypes.TextureFormatBGRA8Unorm:
+		return gputypes.TextureFormatBGRA8UnormSrgb, true
+	case gputypes.TextureFormatBGRA8UnormSrgb:
+		return gputypes.TextureFormatBGRA8Unorm, true
+	}
+	// Compressed formats list each Unorm format at an even value directly
+	// before its sRGB variant.
+	if format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatBC3RGBAUnormSrgb ||
+		format >= gputypes.TextureFormatBC7RGBAUnorm && format <= gputypes.TextureFormatBC7RGBAUnormSrgb ||
+		format >= gputypes.TextureFormatETC2RGB8Unorm && format <= gputypes.TextureFormatETC2RGBA8UnormSrgb ||
+		format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb {
+		return format ^ 1, true
+	}
+	return gputypes.TextureFormatUndefined, false
+}
+
+// ViewFormatCompatible reports whether a texture created with format may list
+// view in its ViewFormats: the formats must be equal or differ only in their
+// sRGB encoding.
+func ViewFormatCompatible(format, view gputypes.TextureFormat) bool {
+	if format == view {
+		return true
+	}
+	counterpart, ok := SrgbCounterpart(format)
+	return ok && counterpart == view
+}
+
+// textureViewFormatAllowed reports whether a view of tex may use format: the
+// texture's own format, one of its ViewFormats, or, for views of a single
+// depth or stencil aspect, the aspect's format. A view of one plane of a
+// multi-planar texture may only use the plane's format.
+func textureViewFormatAllowed(format gputypes.TextureFormat, aspect gputypes.TextureAspect, tex *hal.TextureDescriptor) bool {
+	if plane, ok := hal.PlaneFormat(tex.Format, aspect); ok {
+		return format == gputypes.TextureFormatUndefined || format == plane
+	}
+	if format == gputypes.TextureFormatUndefined || format == tex.Format {
+		return true
+	}
+	for _, viewFormat := range tex.ViewFormats {
+		if viewFormat == format {
+			return true
+		}
+	}
+	return tex.Format.IsDepthStencil() && aspect != gputypes.TextureAspectAll &&
+		aspect != gputypes.TextureAspectUndefined
+}
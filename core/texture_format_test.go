@@ -0,0 +1,88 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestSrgbCounterpart(t *testing.T) {
+	tests := []struct {
+		format gputypes.TextureFormat
+		want   gputypes.TextureFormat
+		ok     bool
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb, true},
+		{gputypes.TextureFormatBGRA8UnormSrgb, gputypes.TextureFormatBGRA8Unorm, true},
+		{gputypes.TextureFormatBC1RGBAUnorm, gputypes.TextureFormatBC1RGBAUnormSrgb, true},
+		{gputypes.TextureFormatBC3RGBAUnormSrgb, gputypes.TextureFormatBC3RGBAUnorm, true},
+		{gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatBC7RGBAUnormSrgb, true},
+		{gputypes.TextureFormatETC2RGBA8Unorm, gputypes.TextureFormatETC2RGBA8UnormSrgb, true},
+		{gputypes.TextureFormatASTC12x12UnormSrgb, gputypes.TextureFormatASTC12x12Unorm, true},
+		{gputypes.TextureFormatR8Unorm, gputypes.TextureFormatUndefined, false},
+		{gputypes.TextureFormatBC4RUnorm, gputypes.TextureFormatUndefined, false},
+	}
+	for _, tt := range tests {
+		got, ok := SrgbCounterpart(tt.format)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("SrgbCounterpart(%v) = %v, %v; want %v, %v", tt.format, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidateTextureDescriptor_ViewFormats(t *testing.T) {
+	desc := validTextureDesc()
+	desc.ViewFormats = []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb}
+	if err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits()); err != nil {
+		t.Fatalf("expected nil error for sRGB view format, got: %v", err)
+	}
+
+	desc.ViewFormats = []gputypes.TextureFormat{gputypes.TextureFormatBGRA8UnormSrgb}
+	err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits())
+	var cte *CreateTextureError
+	if !errors.As(err, &cte) || cte.Kind != CreateTextureErrorInvalidViewFormat {
+		t.Fatalf("expected InvalidViewFormat, got %v", err)
+	}
+	if cte.ViewFormat != gputypes.TextureFormatBGRA8UnormSrgb {
+		t.Errorf("ViewFormat = %v, want BGRA8UnormSrgb", cte.ViewFormat)
+	}
+}
+
+func TestValidateTextureDescriptor_MultiPlanar(t *testing.T) {
+	planar := func() *hal.TextureDescriptor {
+		desc := validTextureDesc()
+		desc.Format = hal.TextureFormatP010
+		return desc
+	}
+	if err := ValidateTextureDescriptor(planar(), gputypes.DefaultLimits()); err != nil {
+		t.Fatalf("expected nil error for sampled P010 texture, got: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*hal.TextureDescriptor)
+	}{
+		{"odd width", func(d *hal.TextureDescriptor) { d.Size.Width = 255 }},
+		{"mipmapped", func(d *hal.TextureDescriptor) { d.MipLevelCount = 2 }},
+		{"layered", func(d *hal.TextureDescriptor) { d.Size.DepthOrArrayLayers = 2 }},
+		{"copy destination", func(d *hal.TextureDescriptor) { d.Usage |= gputypes.TextureUsageCopyDst }},
+		{"view formats", func(d *hal.TextureDescriptor) {
+			d.ViewFormats = []gputypes.TextureFormat{hal.TextureFormatP010}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := planar()
+			tt.modify(desc)
+			err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits())
+			var cte *CreateTextureError
+			if !errors.As(err, &cte) || cte.Kind != CreateTextureErrorMultiPlanar {
+				t.Fatalf("expected MultiPlanar, got %v", err)
+			}
+		})
+	}
+}
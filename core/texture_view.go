@@ -0,0 +1,360 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// ResolveTextureViewDimension returns the dimension a view of tex gets when
+// the view descriptor leaves it undefined: the texture's own dimension, with
+// layered 2D textures viewed as 2D arrays. When the view selects a single
+// layer (arrayLayerCount == 1) a 2D texture is viewed as 2D.
+func ResolveTextureViewDimension(viewDim gputypes.TextureViewDimension, tex *hal.TextureDescriptor, arrayLayerCount uint32) gputypes.TextureViewDimension {
+	if viewDim != gputypes.TextureViewDimensionUndefined {
+		return viewDim
+	}
+	switch tex.Dimension {
+	case gputypes.TextureDimension1D:
+		return gputypes.TextureViewDimension1D
+	case gputypes.TextureDimension3D:
+		return gputypes.TextureViewDimension3D
+	default:
+		if tex.Size.DepthOrArrayLayers > 1 && arrayLayerCount != 1 {
+			return gputypes.TextureViewDimension2DArray
+		}
+		return gputypes.TextureViewDimension2D
+	}
+}
+
+// textureViewFitsTexture reports whether a view of viewDim can be created
+// from a texture of texDim.
+func textureViewFitsTexture(viewDim gputypes.TextureViewDimension, texDim gputypes.TextureDimension) bool {
+	switch viewDim {
+	case gputypes.TextureViewDimension1D:
+		return texDim == gputypes.TextureDimension1D
+	case gputypes.TextureViewDimension2D, gputypes.TextureViewDimension2DArray,
+		gputypes.TextureViewDimensionCube, gputypes.TextureViewDimensionCubeArray:
+		return texDim == gputypes.TextureDimension2D
+	case gputypes.TextureViewDimension3D:
+		return texDim == gputypes.TextureDimension3D
+	default:
+		return false
+	}
+}
+
+// viewLayerCountValid reports whether layers array layers fit viewDim.
+func viewLayerCountValid(viewDim gputypes.TextureViewDimension, layers uint32) bool {
+	switch viewDim {
+	case gputypes.TextureViewDimension2DArray:
+		return layers >= 1
+	case gputypes.TextureViewDimensionCube:
+		return layers == 6
+	case gputypes.TextureViewDimensionCubeArray:
+		return layers >= 6 && layers%6 == 0
+	default:
+		return layers == 1
+	}
+}
+
+// isCubeViewDimension reports whether viewDim is a cube or cube array.
+func isCubeViewDimension(viewDim gputypes.TextureViewDimension) bool {
+	return viewDim == gputypes.TextureViewDimensionCube || viewDim == gputypes.TextureViewDimensionCubeArray
+}
+
+// ValidateTextureViewDescriptor validates a view descriptor against the
+// descriptor tex was created with. downlevel are the adapter's downlevel
+// flags, consulted for cube array support.
+// Returns nil if valid, or a *CreateTextureViewError describing the first validation failure.
+func ValidateTextureViewDescriptor(desc *hal.TextureViewDescriptor, tex *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error {
+	label := desc.Label
+
+	// TV1: Mip range must lie within the texture.
+	mipCount := desc.MipLevelCount
+	if mipCount == 0 && desc.BaseMipLevel < tex.MipLevelCount {
+		mipCount = tex.MipLevelCount - desc.BaseMipLevel
+	}
+	if mipCount == 0 || uint64(desc.BaseMipLevel)+uint64(mipCount) > uint64(tex.MipLevelCount) {
+		return &CreateTextureViewError{
+			Kind:      CreateTextureViewErrorMipRange,
+			Label:     label,
+			Base:      desc.BaseMipLevel,
+			Count:     mipCount,
+			Available: tex.MipLevelCount,
+		}
+	}
+
+	viewDim := ResolveTextureViewDimension(desc.Dimension, tex, desc.ArrayLayerCount)
+
+	// TV9: Views of a multi-planar texture select one plane and view it as
+	// 2D; plane aspects select nothing in other textures.
+	_, isPlane := hal.PlaneIndex(desc.Aspect)
+	if isPlane != hal.IsMultiPlanarFormat(tex.Format) {
+		return &CreateTextureViewError{
+			Kind:          CreateTextureViewErrorInvalidPlane,
+			Label:         label,
+			TextureFormat: tex.Format,
+		}
+	}
+	if isPlane && viewDim != gputypes.TextureViewDimension2D {
+		return &CreateTextureViewError{
+			Kind:             CreateTextureViewErrorInvalidDimension,
+			Label:            label,
+			ViewDimension:    viewDim,
+			TextureDimension: tex.Dimension,
+		}
+	}
+
+	// TV8: A view reinterprets the texture only as one of its ViewFormats.
+	if !textureViewFormatAllowed(desc.Format, desc.Aspect, tex) {
+		return &CreateTextureViewError{
+			Kind:          CreateTextureViewErrorFormatNotAllowed,
+			Label:         label,
+			Format:        desc.Format,
+			TextureFormat: tex.Format,
+		}
+	}
+
+	// TV2: View dimension must be compatible with the texture dimension.
+	if !textureViewFitsTexture(viewDim, tex.Dimension) {
+		return &CreateTextureViewError{
+			Kind:             CreateTextureViewErrorInvalidDimension,
+			Label:            label,
+			ViewDimension:    viewDim,
+			TextureDimension: tex.Dimension,
+		}
+	}
+
+	// TV3: Layer range must lie within the texture. 3D textures have one layer.
+	texLayers := tex.Size.DepthOrArrayLayers
+	if tex.Dimension == gputypes.TextureDimension3D {
+		texLayers = 1
+	}
+	layerCount := desc.ArrayLayerCount
+	if layerCount == 0 && desc.BaseArrayLayer < texLayers {
+		layerCount = texLayers - desc.BaseArrayLayer
+		if viewDim == gputypes.TextureViewDimensionCube {
+			layerCount = min(layerCount, 6)
+		}
+	}
+	if layerCount == 0 || uint64(desc.BaseArrayLayer)+uint64(layerCount) > uint64(texLayers) {
+		return &CreateTextureViewError{
+			Kind:      CreateTextureViewErrorLayerRange,
+			Label:     label,
+			Base:      desc.BaseArrayLayer,
+			Count:     layerCount,
+			Available: texLayers,
+		}
+	}
+
+	// TV4: Layer count must fit the view dimension.
+	if !viewLayerCountValid(viewDim, layerCount) {
+		return &CreateTextureViewError{
+			Kind:          CreateTextureViewErrorInvalidLayerCount,
+			Label:         label,
+			ViewDimension: viewDim,
+			Count:         layerCount,
+		}
+	}
+
+	if !isCubeViewDimension(viewDim) {
+		return nil
+	}
+
+	// TV5-TV7: Cube views need square, single-sampled faces, and cube
+	// arrays need adapter support.
+	if tex.Size.Width != tex.Size.Height {
+		return &CreateTextureViewError{Kind: CreateTextureViewErrorNonSquareCube, Label: label, ViewDimension: viewDim}
+	}
+	if tex.SampleCount > 1 {
+		return &CreateTextureViewError{Kind: CreateTextureViewErrorMultisampledCube, Label: label, ViewDimension: viewDim}
+	}
+	if viewDim == gputypes.TextureViewDimensionCubeArray && downlevel&hal.DownlevelFlagsCubeArrayTextures == 0 {
+		return &CreateTextureViewError{Kind: CreateTextureViewErrorCubeArrayUnsupported, Label: label, ViewDimension: viewDim}
+	}
+	return nil
+}
+
+// validateTextureBindingViewDimension checks the TextureBindingViewDimension
+// hint against the texture's dimension and layer count.
+func validateTextureBindingViewDimension(desc *hal.TextureDescriptor) error {
+	viewDim := desc.TextureBindingViewDimension
+	if viewDim == gputypes.TextureViewDimensionUndefined {
+		return nil
+	}
+	layers := desc.Size.DepthOrArrayLayers
+	if desc.Dimension == gputypes.TextureDimension3D {
+		layers = 1
+	}
+	if textureViewFitsTexture(viewDim, desc.Dimension) && viewLayerCountValid(viewDim, layers) {
+		return nil
+	}
+	return &CreateTextureError{
+		Kind:           CreateTextureErrorInvalidBindingViewDimension,
+		Label:          desc.Label,
+		RequestedDepth: desc.Size.DepthOrArrayLayers,
+		Dimension:      desc.Dimension,
+		ViewDimension:  viewDim,
+	}
+}
+
+// BindGroupTextureInfo carries texture view metadata needed for bind group
+// validation. Like BindGroupBufferInfo, it is extracted by the caller from
+// the public API's typed *TextureView objects.
+type BindGroupTextureInfo struct {
+	// Binding is the binding number this info corresponds to.
+	Binding uint32
+	// ViewDimension is the view's resolved dimension. Undefined skips the
+	// dimension check (views of wrapped or surface textures).
+	ViewDimension gputypes.TextureViewDimension
+	// SampleCount is the sample count of the view's texture.
+	SampleCount uint32
+	// Format is the view's format. Undefined skips the storage format check.
+	Format gputypes.TextureFormat
+	// Usage is the usage of the view's texture. Zero skips the usage check.
+	Usage gputypes.TextureUsage
+	// MipLevelCount is the number of mip levels the view spans. Zero skips
+	// the storage mip count check.
+	MipLevelCount uint32
+}
+
+// ValidateBindGroupTextureViews checks each bound texture view against the
+// layout entry it is bound to: the view dimension must equal the layout's
+// ViewDimension (2D when undefined), the texture must carry the binding's
+// usage, sampled textures must match the layout's multisampling, and storage
+// textures must match the layout's format with a single mip level.
+// Returns nil if valid, or a *CreateBindGroupError describing the first validation failure.
+//
+// Rust reference: wgpu-core device/resource.rs create_texture_binding
+func ValidateBindGroupTextureViews(label string, layoutEntries []gputypes.BindGroupLayoutEntry, textureInfos []BindGroupTextureInfo) error {
+	for _, info := range textureInfos {
+		var expected gputypes.TextureViewDimension
+		var storage *gputypes.StorageTextureBindingLayout
+		multisampled := false
+		found := false
+		for i := range layoutEntries {
+			entry := &layoutEntries[i]
+			if entry.Binding != info.Binding {
+				continue
+			}
+			switch {
+			case entry.Texture != nil:
+				expected = entry.Texture.ViewDimension
+				multisampled = entry.Texture.Multisampled
+				found = true
+			case entry.StorageTexture != nil:
+				expected = entry.StorageTexture.ViewDimension
+				storage = entry.StorageTexture
+				found = true
+			}
+			break
+		}
+		if !found {
+			continue
+		}
+
+		requiredUsage := gputypes.TextureUsageTextureBinding
+		if storage != nil {
+			requiredUsage = gputypes.TextureUsageStorageBinding
+		}
+		if info.Usage != 0 && !info.Usage.Contains(requiredUsage) {
+			return &CreateBindGroupError{
+				Kind:          CreateBindGroupErrorTextureUsageMismatch,
+				Label:         label,
+				Binding:       info.Binding,
+				ExpectedUsage: uint64(requiredUsage),
+				ActualUsage:   uint64(info.Usage),
+			}
+		}
+		if expected == gputypes.TextureViewDimensionUndefined {
+			expected = gputypes.TextureViewDimension2D
+		}
+
+		if info.ViewDimension != gputypes.TextureViewDimensionUndefined && info.ViewDimension != expected {
+			return &CreateBindGroupError{
+				Kind:                  CreateBindGroupErrorTextureViewDimensionMismatch,
+				Label:                 label,
+				Binding:               info.Binding,
+				ExpectedViewDimension: expected,
+				ActualViewDimension:   info.ViewDimension,
+			}
+		}
+		if storage == nil {
+			if info.SampleCount != 0 && (info.SampleCount > 1) != multisampled {
+				return &CreateBindGroupError{
+					Kind:        CreateBindGroupErrorTextureMultisampleMismatch,
+					Label:       label,
+					Binding:     info.Binding,
+					SampleCount: info.SampleCount,
+				}
+			}
+			continue
+		}
+
+		if info.Format != gputypes.TextureFormatUndefined && info.Format != storage.Format {
+			return &CreateBindGroupError{
+				Kind:           CreateBindGroupErrorStorageTextureFormatMismatch,
+				Label:          label,
+				Binding:        info.Binding,
+				ExpectedFormat: storage.Format,
+				ActualFormat:   info.Format,
+			}
+		}
+		if info.MipLevelCount > 1 {
+			return &CreateBindGroupError{
+				Kind:          CreateBindGroupErrorStorageTextureMipLevelCount,
+				Label:         label,
+				Binding:       info.Binding,
+				MipLevelCount: info.MipLevelCount,
+			}
+		}
+	}
+	return nil
+}
+
+// isStorageTextureFormat reports whether f can be bound as a storage texture.
+// The list is WebGPU's storage-capable formats (the "STORAGE_BINDING" column
+// of the texture format capabilities table).
+func isStorageTextureFormat(f gputypes.TextureFormat) bool {
+	switch f {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8Snorm,
+		gputypes.TextureFormatRGBA8Uint, gputypes.TextureFormatRGBA8Sint,
+		gputypes.TextureFormatRGBA16Uint, gputypes.TextureFormatRGBA16Sint, gputypes.TextureFormatRGBA16Float,
+		gputypes.TextureFormatR32Uint, gputypes.TextureFormatR32Sint, gputypes.TextureFormatR32Float,
+		gputypes.TextureFormatRG32Uint, gputypes.TextureFormatRG32Sint, gputypes.TextureFormatRG32Float,
+		gputypes.TextureFormatRGBA32Uint, gputypes.TextureFormatRGBA32Sint, gputypes.TextureFormatRGBA32Float:
+		return true
+	default:
+		return false
+	}
+}
+
+// isReadWriteStorageTextureFormat reports whether f supports read-write
+// storage access without extra features.
+func isReadWriteStorageTextureFormat(f gputypes.TextureFormat) bool {
+	return f == gputypes.TextureFormatR32Uint || f == gputypes.TextureFormatR32Sint || f == gputypes.TextureFormatR32Float
+}
+
+// validateStorageTextureLayout checks a storage texture layout entry's access
+// mode, format and view dimension.
+func validateStorageTextureLayout(label string, binding uint32, layout *gputypes.StorageTextureBindingLayout) error {
+	if layout.Access == gputypes.StorageTextureAccessUndefined {
+		return fmt.Errorf("bind group layout %q: binding %d storage texture access must be defined", label, binding)
+	}
+	if !isStorageTextureFormat(layout.Format) {
+		return fmt.Errorf("bind group layout %q: binding %d format %s cannot be used as a storage texture",
+			label, binding, layout.Format)
+	}
+	if layout.Access == gputypes.StorageTextureAccessReadWrite && !isReadWriteStorageTextureFormat(layout.Format) {
+		return fmt.Errorf("bind group layout %q: binding %d format %s does not support read-write storage access",
+			label, binding, layout.Format)
+	}
+	if isCubeViewDimension(layout.ViewDimension) {
+		return fmt.Errorf("bind group layout %q: binding %d storage texture cannot have view dimension %s",
+			label, binding, layout.ViewDimension)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// layeredTextureDesc returns a square 2D texture descriptor with layers array layers.
+func layeredTextureDesc(layers uint32) *hal.TextureDescriptor {
+	desc := validTextureDesc()
+	desc.Size.DepthOrArrayLayers = layers
+	return desc
+}
+
+func TestResolveTextureViewDimension(t *testing.T) {
+	tests := []struct {
+		name   string
+		view   gputypes.TextureViewDimension
+		tex    *hal.TextureDescriptor
+		layers uint32
+		want   gputypes.TextureViewDimension
+	}{
+		{"explicit", gputypes.TextureViewDimensionCube, layeredTextureDesc(6), 0, gputypes.TextureViewDimensionCube},
+		{"single layer 2D", gputypes.TextureViewDimensionUndefined, layeredTextureDesc(1), 0, gputypes.TextureViewDimension2D},
+		{"layered 2D", gputypes.TextureViewDimensionUndefined, layeredTextureDesc(4), 0, gputypes.TextureViewDimension2DArray},
+		{"one layer of array", gputypes.TextureViewDimensionUndefined, layeredTextureDesc(4), 1, gputypes.TextureViewDimension2D},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveTextureViewDimension(tt.view, tt.tex, tt.layers)
+			if got != tt.want {
+				t.Errorf("ResolveTextureViewDimension() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTextureViewDescriptor(t *testing.T) {
+	nonSquare := layeredTextureDesc(6)
+	nonSquare.Size.Width = 128
+	volume := validTextureDesc()
+	volume.Dimension = gputypes.TextureDimension3D
+	volume.Size.DepthOrArrayLayers = 8
+	srgbViewable := validTextureDesc()
+	srgbViewable.ViewFormats = []gputypes.TextureFormat{gputypes.TextureFormatRGBA8UnormSrgb}
+	depthStencil := validTextureDesc()
+	depthStencil.Format = gputypes.TextureFormatDepth24PlusStencil8
+	nv12 := validTextureDesc()
+	nv12.Format = hal.TextureFormatNV12
+
+	tests := []struct {
+		name      string
+		view      hal.TextureViewDescriptor
+		tex       *hal.TextureDescriptor
+		downlevel hal.DownlevelFlags
+		wantKind  CreateTextureViewErrorKind
+		wantOK    bool
+	}{
+		{"default 2D", hal.TextureViewDescriptor{}, validTextureDesc(), 0, 0, true},
+		{"cube", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCube}, layeredTextureDesc(6), 0, 0, true},
+		{"cube from larger array", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCube, BaseArrayLayer: 6}, layeredTextureDesc(12), 0, 0, true},
+		{"cube array", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCubeArray}, layeredTextureDesc(12), hal.DownlevelFlagsCubeArrayTextures, 0, true},
+		{"2D array subrange", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimension2DArray, BaseArrayLayer: 1, ArrayLayerCount: 2}, layeredTextureDesc(4), 0, 0, true},
+		{"3D", hal.TextureViewDescriptor{}, volume, 0, 0, true},
+		{"cube of 3D", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCube}, volume, 0, CreateTextureViewErrorInvalidDimension, false},
+		{"cube with 4 layers", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCube}, layeredTextureDesc(4), 0, CreateTextureViewErrorInvalidLayerCount, false},
+		{"cube array of 8", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCubeArray}, layeredTextureDesc(8), hal.DownlevelFlagsCubeArrayTextures, CreateTextureViewErrorInvalidLayerCount, false},
+		{"2D with 2 layers", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimension2D, ArrayLayerCount: 2}, layeredTextureDesc(4), 0, CreateTextureViewErrorInvalidLayerCount, false},
+		{"layer range", hal.TextureViewDescriptor{BaseArrayLayer: 3, ArrayLayerCount: 2}, layeredTextureDesc(4), 0, CreateTextureViewErrorLayerRange, false},
+		{"mip range", hal.TextureViewDescriptor{BaseMipLevel: 1}, validTextureDesc(), 0, CreateTextureViewErrorMipRange, false},
+		{"non-square cube", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCube}, nonSquare, 0, CreateTextureViewErrorNonSquareCube, false},
+		{"cube array unsupported", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimensionCubeArray}, layeredTextureDesc(12), 0, CreateTextureViewErrorCubeArrayUnsupported, false},
+		{"listed view format", hal.TextureViewDescriptor{Format: gputypes.TextureFormatRGBA8UnormSrgb}, srgbViewable, 0, 0, true},
+		{"unlisted view format", hal.TextureViewDescriptor{Format: gputypes.TextureFormatRGBA8UnormSrgb}, validTextureDesc(), 0, CreateTextureViewErrorFormatNotAllowed, false},
+		{"stencil aspect format", hal.TextureViewDescriptor{Format: gputypes.TextureFormatStencil8, Aspect: gputypes.TextureAspectStencilOnly}, depthStencil, 0, 0, true},
+		{"luma plane", hal.TextureViewDescriptor{Aspect: hal.TextureAspectPlane0}, nv12, 0, 0, true},
+		{"chroma plane format", hal.TextureViewDescriptor{Format: gputypes.TextureFormatRG8Unorm, Aspect: hal.TextureAspectPlane1}, nv12, 0, 0, true},
+		{"wrong plane format", hal.TextureViewDescriptor{Format: gputypes.TextureFormatR8Unorm, Aspect: hal.TextureAspectPlane1}, nv12, 0, CreateTextureViewErrorFormatNotAllowed, false},
+		{"planar without plane", hal.TextureViewDescriptor{}, nv12, 0, CreateTextureViewErrorInvalidPlane, false},
+		{"plane of RGBA", hal.TextureViewDescriptor{Aspect: hal.TextureAspectPlane0}, validTextureDesc(), 0, CreateTextureViewErrorInvalidPlane, false},
+		{"plane as array", hal.TextureViewDescriptor{Dimension: gputypes.TextureViewDimension2DArray, Aspect: hal.TextureAspectPlane0}, nv12, 0, CreateTextureViewErrorInvalidDimension, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTextureViewDescriptor(&tt.view, tt.tex, tt.downlevel)
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				return
+			}
+			var tve *CreateTextureViewError
+			if !errors.As(err, &tve) {
+				t.Fatalf("expected CreateTextureViewError, got %T (%v)", err, err)
+			}
+			if tve.Kind != tt.wantKind {
+				t.Errorf("expected kind %v, got %v", tt.wantKind, tve.Kind)
+			}
+		})
+	}
+}
+
+func TestValidateTextureDescriptor_BindingViewDimension(t *testing.T) {
+	desc := layeredTextureDesc(6)
+	desc.TextureBindingViewDimension = gputypes.TextureViewDimensionCube
+	if err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits()); err != nil {
+		t.Fatalf("expected nil error for cube hint on 6 layers, got: %v", err)
+	}
+
+	desc = layeredTextureDesc(4)
+	desc.TextureBindingViewDimension = gputypes.TextureViewDimensionCube
+	err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits())
+	var cte *CreateTextureError
+	if !errors.As(err, &cte) || cte.Kind != CreateTextureErrorInvalidBindingViewDimension {
+		t.Fatalf("expected InvalidBindingViewDimension, got %v", err)
+	}
+}
+
+func TestValidateBindGroupTextureViews(t *testing.T) {
+	entries := []gputypes.BindGroupLayoutEntry{
+		{Binding: 0, Texture: &gputypes.TextureBindingLayout{ViewDimension: gputypes.TextureViewDimensionCube}},
+		{Binding: 1, Texture: &gputypes.TextureBindingLayout{}},
+		{Binding: 2, Texture: &gputypes.TextureBindingLayout{Multisampled: true}},
+	}
+
+	tests := []struct {
+		name     string
+		info     BindGroupTextureInfo
+		wantKind CreateBindGroupErrorKind
+		wantOK   bool
+	}{
+		{"cube matches", BindGroupTextureInfo{Binding: 0, ViewDimension: gputypes.TextureViewDimensionCube, SampleCount: 1}, 0, true},
+		{"undefined layout means 2D", BindGroupTextureInfo{Binding: 1, ViewDimension: gputypes.TextureViewDimension2D, SampleCount: 1}, 0, true},
+		{"unknown view skipped", BindGroupTextureInfo{Binding: 0}, 0, true},
+		{"2D array for cube", BindGroupTextureInfo{Binding: 0, ViewDimension: gputypes.TextureViewDimension2DArray, SampleCount: 1}, CreateBindGroupErrorTextureViewDimensionMismatch, false},
+		{"single-sampled for multisampled", BindGroupTextureInfo{Binding: 2, ViewDimension: gputypes.TextureViewDimension2D, SampleCount: 1}, CreateBindGroupErrorTextureMultisampleMismatch, false},
+		{"multisampled for single-sampled", BindGroupTextureInfo{Binding: 1, ViewDimension: gputypes.TextureViewDimension2D, SampleCount: 4}, CreateBindGroupErrorTextureMultisampleMismatch, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBindGroupTextureViews("test", entries, []BindGroupTextureInfo{tt.info})
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				return
+			}
+			var bge *CreateBindGroupError
+			if !errors.As(err, &bge) {
+				t.Fatalf("expected CreateBindGroupError, got %T (%v)", err, err)
+			}
+			if bge.Kind != tt.wantKind {
+				t.Errorf("expected kind %v, got %v", tt.wantKind, bge.Kind)
+			}
+		})
+	}
+}
+
+func TestValidateBindGroupTextureViews_Storage(t *testing.T) {
+	entries := []gputypes.BindGroupLayoutEntry{
+		{Binding: 0, StorageTexture: &gputypes.StorageTextureBindingLayout{
+			Access:        gputypes.StorageTextureAccessWriteOnly,
+			Format:        gputypes.TextureFormatRGBA8Unorm,
+			ViewDimension: gputypes.TextureViewDimension3D,
+		}},
+		{Binding: 1, Texture: &gputypes.TextureBindingLayout{ViewDimension: gputypes.TextureViewDimension3D}},
+	}
+	storageView := BindGroupTextureInfo{
+		Binding:       0,
+		ViewDimension: gputypes.TextureViewDimension3D,
+		SampleCount:   1,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageStorageBinding,
+		MipLevelCount: 1,
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*BindGroupTextureInfo)
+		wantKind CreateBindGroupErrorKind
+		wantOK   bool
+	}{
+		{"valid", func(*BindGroupTextureInfo) {}, 0, true},
+		{"missing storage usage", func(i *BindGroupTextureInfo) { i.Usage = gputypes.TextureUsageTextureBinding }, CreateBindGroupErrorTextureUsageMismatch, false},
+		{"format mismatch", func(i *BindGroupTextureInfo) { i.Format = gputypes.TextureFormatR32Float }, CreateBindGroupErrorStorageTextureFormatMismatch, false},
+		{"several mips", func(i *BindGroupTextureInfo) { i.MipLevelCount = 2 }, CreateBindGroupErrorStorageTextureMipLevelCount, false},
+		{"wrong dimension", func(i *BindGroupTextureInfo) { i.ViewDimension = gputypes.TextureViewDimension2D }, CreateBindGroupErrorTextureViewDimensionMismatch, false},
+		{"sampled without texture usage", func(i *BindGroupTextureInfo) { i.Binding = 1 }, CreateBindGroupErrorTextureUsageMismatch, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := storageView
+			tt.modify(&info)
+			err := ValidateBindGroupTextureViews("test", entries, []BindGroupTextureInfo{info})
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("expected nil error, got: %v", err)
+				}
+				return
+			}
+			var bge *CreateBindGroupError
+			if !errors.As(err, &bge) {
+				t.Fatalf("expected CreateBindGroupError, got %T (%v)", err, err)
+			}
+			if bge.Kind != tt.wantKind {
+				t.Errorf("expected kind %v, got %v", tt.wantKind, bge.Kind)
+			}
+		})
+	}
+}
+
+func TestValidateStorageTextureLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout gputypes.StorageTextureBindingLayout
+		wantOK bool
+	}{
+		{"write-only rgba8unorm 3D", gputypes.StorageTextureBindingLayout{
+			Access: gputypes.StorageTextureAccessWriteOnly, Format: gputypes.TextureFormatRGBA8Unorm,
+			ViewDimension: gputypes.TextureViewDimension3D,
+		}, true},
+		{"read-write r32float", gputypes.StorageTextureBindingLayout{
+			Access: gputypes.StorageTextureAccessReadWrite, Format: gputypes.TextureFormatR32Float,
+		}, true},
+		{"undefined access", gputypes.StorageTextureBindingLayout{
+			Format: gputypes.TextureFormatRGBA8Unorm,
+		}, false},
+		{"non-storage format", gputypes.StorageTextureBindingLayout{
+			Access: gputypes.StorageTextureAccessWriteOnly, Format: gputypes.TextureFormatBGRA8UnormSrgb,
+		}, false},
+		{"read-write rgba8unorm", gputypes.StorageTextureBindingLayout{
+			Access: gputypes.StorageTextureAccessReadWrite, Format: gputypes.TextureFormatRGBA8Unorm,
+		}, false},
+		{"cube view", gputypes.StorageTextureBindingLayout{
+			Access: gputypes.StorageTextureAccessWriteOnly, Format: gputypes.TextureFormatRGBA8Unorm,
+			ViewDimension: gputypes.TextureViewDimensionCube,
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorageTextureLayout("test", 0, &tt.layout)
+			if (err == nil) != tt.wantOK {
+				t.Errorf("validateStorageTextureLayout() error = %v, wantOK %v", err, tt.wantOK)
+			}
+		})
+	}
+}
@@ -97,6 +97,46 @@ func (u BufferUses) ToBufferUsage() gputypes.BufferUsage {
 	return result
 }
 
+// ToHAL converts internal uses to the HAL barrier state.
+func (u BufferUses) ToHAL() hal.BufferUses {
+	var result hal.BufferUses
+
+	if u&BufferUsesCopySrc != 0 {
+		result |= hal.BufferUseCopySrc
+	}
+	if u&BufferUsesCopyDst != 0 {
+		result |= hal.BufferUseCopyDst
+	}
+	if u&BufferUsesIndex != 0 {
+		result |= hal.BufferUseIndex
+	}
+	if u&BufferUsesVertex != 0 {
+		result |= hal.BufferUseVertex
+	}
+	if u&BufferUsesUniform != 0 {
+		result |= hal.BufferUseUniform
+	}
+	if u&BufferUsesStorageWrite != 0 {
+		result |= hal.BufferUseStorageReadWrite
+	} else if u&BufferUsesStorageRead != 0 {
+		result |= hal.BufferUseStorageRead
+	}
+	if u&BufferUsesIndirect != 0 {
+		result |= hal.BufferUseIndirect
+	}
+	if u&BufferUsesMapRead != 0 {
+		result |= hal.BufferUseMapRead
+	}
+	if u&BufferUsesMapWrite != 0 {
+		result |= hal.BufferUseMapWrite
+	}
+	if u&BufferUsesQueryResolve != 0 {
+		result |= hal.BufferUseQueryResolve
+	}
+
+	return result
+}
+
 // BufferState holds the tracked state for a single buffer.
 type BufferState struct {
 	usage BufferUses
@@ -306,8 +346,8 @@ func (p PendingTransition) IntoHAL(buffer hal.Buffer) hal.BufferBarrier {
 	return hal.BufferBarrier{
 		Buffer: buffer,
 		Usage: hal.BufferUsageTransition{
-			OldUsage: p.Usage.From.ToBufferUsage(),
-			NewUsage: p.Usage.To.ToBufferUsage(),
+			OldUsage: p.Usage.From.ToHAL(),
+			NewUsage: p.Usage.To.ToHAL(),
 		},
 	}
 }
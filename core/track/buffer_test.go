@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
 )
 
 func TestBufferUses_IsReadOnly(t *testing.T) {
@@ -356,11 +357,34 @@ func TestPendingTransition_IntoHAL(t *testing.T) {
 	// Create a nil buffer (HAL conversion doesn't need actual buffer for this test)
 	barrier := trans.IntoHAL(nil)
 
-	if barrier.Usage.OldUsage != gputypes.BufferUsageVertex {
-		t.Errorf("OldUsage = %d, want %d", barrier.Usage.OldUsage, gputypes.BufferUsageVertex)
+	if barrier.Usage.OldUsage != hal.BufferUseVertex {
+		t.Errorf("OldUsage = %d, want %d", barrier.Usage.OldUsage, hal.BufferUseVertex)
 	}
-	if barrier.Usage.NewUsage != gputypes.BufferUsageCopyDst {
-		t.Errorf("NewUsage = %d, want %d", barrier.Usage.NewUsage, gputypes.BufferUsageCopyDst)
+	if barrier.Usage.NewUsage != hal.BufferUseCopyDst {
+		t.Errorf("NewUsage = %d, want %d", barrier.Usage.NewUsage, hal.BufferUseCopyDst)
+	}
+}
+
+func TestBufferUses_ToHAL(t *testing.T) {
+	tests := []struct {
+		name string
+		uses BufferUses
+		want hal.BufferUses
+	}{
+		{"none", BufferUsesNone, 0},
+		{"storage read", BufferUsesStorageRead, hal.BufferUseStorageRead},
+		{"storage write", BufferUsesStorageWrite, hal.BufferUseStorageReadWrite},
+		{"storage read and write", BufferUsesStorageRead | BufferUsesStorageWrite, hal.BufferUseStorageReadWrite},
+		{"vertex and index", BufferUsesVertex | BufferUsesIndex, hal.BufferUseVertex | hal.BufferUseIndex},
+		{"query resolve", BufferUsesQueryResolve, hal.BufferUseQueryResolve},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.uses.ToHAL(); got != tt.want {
+				t.Errorf("BufferUses(%d).ToHAL() = %d, want %d", tt.uses, got, tt.want)
+			}
+		})
 	}
 }
 
@@ -5,8 +5,11 @@ package core
 import (
 	"fmt"
 	"math/bits"
+	"strconv"
+	"strings"
 
 	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/ir"
 	"github.com/gogpu/wgpu/hal"
 )
 
@@ -85,8 +88,9 @@ func ValidateTextureDescriptor(desc *hal.TextureDescriptor, limits gputypes.Limi
 		}
 	}
 
-	// T10: Sample count must be 1 or 4.
-	if desc.SampleCount != 1 && desc.SampleCount != 4 {
+	// T10: Sample count must be a power of two up to 16. Whether the format
+	// supports it on this adapter is checked by ValidateTextureSampleCount.
+	if !isValidSampleCount(desc.SampleCount) {
 		return &CreateTextureError{
 			Kind:             CreateTextureErrorInvalidSampleCount,
 			Label:            label,
@@ -101,7 +105,45 @@ func ValidateTextureDescriptor(desc *hal.TextureDescriptor, limits gputypes.Limi
 		}
 	}
 
-	return nil
+	// Transient attachments exist only inside a render pass, so they cannot
+	// be sampled, copied or span several mips/layers.
+	if desc.Transient && (desc.Usage != gputypes.TextureUsageRenderAttachment ||
+		desc.Dimension != gputypes.TextureDimension2D ||
+		desc.MipLevelCount != 1 || d != 1) {
+		return &CreateTextureError{
+			Kind:  CreateTextureErrorTransient,
+			Label: label,
+		}
+	}
+
+	// T18: View formats may only change the sRGB encoding.
+	for _, viewFormat := range desc.ViewFormats {
+		if !ViewFormatCompatible(desc.Format, viewFormat) {
+			return &CreateTextureError{
+				Kind:       CreateTextureErrorInvalidViewFormat,
+				Label:      label,
+				Format:     desc.Format,
+				ViewFormat: viewFormat,
+			}
+		}
+	}
+
+	// Multi-planar video formats are single 2D images that are only sampled,
+	// one plane at a time. Chroma planes are half-sized, so the luma plane
+	// must have even dimensions.
+	if hal.IsMultiPlanarFormat(desc.Format) && (desc.Dimension != gputypes.TextureDimension2D ||
+		desc.MipLevelCount != 1 || desc.SampleCount > 1 || d != 1 || w%2 != 0 || h%2 != 0 ||
+		desc.Usage != gputypes.TextureUsageTextureBinding || len(desc.ViewFormats) > 0) {
+		return &CreateTextureError{
+			Kind:            CreateTextureErrorMultiPlanar,
+			Label:           label,
+			RequestedWidth:  w,
+			RequestedHeight: h,
+			RequestedDepth:  d,
+		}
+	}
+
+	return validateTextureBindingViewDimension(desc)
 }
 
 // validateTextureDimLimits checks T4-T7 dimension limit constraints.
@@ -167,6 +209,74 @@ func validateTextureDimLimits(desc *hal.TextureDescriptor, label string, limits
 	return nil
 }
 
+// ValidateTextureSampleCount checks that the adapter can multisample the
+// texture's format at desc.SampleCount. caps are the adapter's capabilities
+// for desc.Format. Returns nil for single-sampled textures.
+func ValidateTextureSampleCount(desc *hal.TextureDescriptor, caps hal.TextureFormatCapabilities) error {
+	if desc.SampleCount <= 1 || caps.SupportsSampleCount(desc.SampleCount) {
+		return nil
+	}
+	return &CreateTextureError{
+		Kind:             CreateTextureErrorUnsupportedSampleCount,
+		Label:            desc.Label,
+		RequestedSamples: desc.SampleCount,
+		SupportedSamples: caps.SampleCounts | hal.SampleCount1,
+		Format:           desc.Format,
+	}
+}
+
+// ValidateRenderPipelineSampleCount checks that every color target and the
+// depth/stencil format can be multisampled at desc.Multisample.Count.
+// formatCaps returns the adapter's capabilities for a format.
+func ValidateRenderPipelineSampleCount(desc *hal.RenderPipelineDescriptor, formatCaps func(gputypes.TextureFormat) hal.TextureFormatCapabilities) error {
+	count := desc.Multisample.Count
+	if count <= 1 {
+		return nil
+	}
+	unsupported := func(format gputypes.TextureFormat, index uint32) error {
+		if formatCaps(format).SupportsSampleCount(count) {
+			return nil
+		}
+		return &CreateRenderPipelineError{
+			Kind:        CreateRenderPipelineErrorUnsupportedSampleCount,
+			Label:       desc.Label,
+			SampleCount: count,
+			TargetIndex: index,
+			Format:      format.String(),
+		}
+	}
+	if desc.Fragment != nil {
+		for i, target := range desc.Fragment.Targets {
+			if target.Format == gputypes.TextureFormatUndefined {
+				continue
+			}
+			if err := unsupported(target.Format, uint32(i)); err != nil {
+				return err
+			}
+		}
+	}
+	if desc.DepthStencil != nil {
+		return unsupported(desc.DepthStencil.Format, 0)
+	}
+	return nil
+}
+
+// isValidSampleCount reports whether count is 1, 2, 4, 8 or 16.
+func isValidSampleCount(count uint32) bool {
+	return count != 0 && count <= hal.SampleCount16 && count&(count-1) == 0
+}
+
+// sampleCountNames renders a SampleCounts bitmask as "1, 4".
+func sampleCountNames(counts uint32) string {
+	var names []string
+	for n := hal.SampleCount1; n <= hal.SampleCount16; n <<= 1 {
+		if counts&n != 0 {
+			names = append(names, strconv.FormatUint(uint64(n), 10))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
 // validateTextureMultisample checks T11-T14 multisample constraints.
 func validateTextureMultisample(desc *hal.TextureDescriptor, label string) error {
 	// T11: MipLevelCount must be 1.
@@ -244,6 +354,9 @@ func ValidateSamplerDescriptor(desc *hal.SamplerDescriptor) error {
 	return nil
 }
 
+// spirvMagic is the first word of every SPIR-V module.
+const spirvMagic = 0x07230203
+
 // ValidateShaderModuleDescriptor validates a shader module descriptor.
 // Returns nil if valid, or a *CreateShaderModuleError describing the first validation failure.
 func ValidateShaderModuleDescriptor(desc *hal.ShaderModuleDescriptor) error {
@@ -267,6 +380,15 @@ func ValidateShaderModuleDescriptor(desc *hal.ShaderModuleDescriptor) error {
 		}
 	}
 
+	// SM3: SPIR-V must start with the magic number. Words in the wrong byte
+	// order fail here too.
+	if hasSPIRV && desc.Source.SPIRV[0] != spirvMagic {
+		return &CreateShaderModuleError{
+			Kind:  CreateShaderModuleErrorInvalidSPIRV,
+			Label: label,
+		}
+	}
+
 	return nil
 }
 
@@ -291,6 +413,103 @@ func ValidatePipelineLayoutDescriptor(desc *hal.PipelineLayoutDescriptor, limits
 	return nil
 }
 
+// pipelineLayoutStages are the shader stages whose per-stage binding limits
+// ValidatePipelineLayoutBindings checks.
+var pipelineLayoutStages = [...]struct {
+	stage gputypes.ShaderStages
+	name  string
+}{
+	{gputypes.ShaderStageVertex, "vertex"},
+	{gputypes.ShaderStageFragment, "fragment"},
+	{gputypes.ShaderStageCompute, "compute"},
+}
+
+// ValidatePipelineLayoutBindings validates the bindings of a pipeline
+// layout's bind group layouts against the limits that span the whole
+// layout. groups holds each bind group layout's entries, which the
+// hal.BindGroupLayout interface does not expose.
+// Returns nil if valid, or a *CreatePipelineLayoutError describing the first validation failure.
+//
+// Checks: dynamic uniform and storage buffers per layout, and storage
+// buffers, uniform buffers, samplers, sampled textures and storage textures
+// per shader stage, summed over every group visible to that stage. As in
+// ValidateBindGroupLayoutDescriptor, a zero per-stage limit is not enforced.
+// Rust: wgpu-core binding_model.rs BindingTypeMaxCountValidator.
+func ValidatePipelineLayoutBindings(label string, groups [][]gputypes.BindGroupLayoutEntry, limits gputypes.Limits) error {
+	var dynamicUniform, dynamicStorage int
+	var perStage [len(pipelineLayoutStages)][5]int
+	for _, entries := range groups {
+		for _, entry := range entries {
+			kind := -1
+			switch {
+			case entry.Buffer != nil && entry.Buffer.Type == gputypes.BufferBindingTypeUniform:
+				kind = 0
+				if entry.Buffer.HasDynamicOffset {
+					dynamicUniform++
+				}
+			case entry.Buffer != nil:
+				kind = 1
+				if entry.Buffer.HasDynamicOffset {
+					dynamicStorage++
+				}
+			case entry.Sampler != nil:
+				kind = 2
+			case entry.Texture != nil:
+				kind = 3
+			case entry.StorageTexture != nil:
+				kind = 4
+			}
+			if kind < 0 {
+				continue
+			}
+			for s, stage := range pipelineLayoutStages {
+				if entry.Visibility&stage.stage != 0 {
+					perStage[s][kind]++
+				}
+			}
+		}
+	}
+
+	tooMany := func(count int, binding string, limit uint32) error {
+		return &CreatePipelineLayoutError{
+			Kind:    CreatePipelineLayoutErrorTooManyBindings,
+			Label:   label,
+			Count:   count,
+			Binding: binding,
+			Limit:   limit,
+		}
+	}
+
+	// PL2: Dynamic buffers per pipeline layout.
+	if dynamicUniform > int(limits.MaxDynamicUniformBuffersPerPipelineLayout) {
+		return tooMany(dynamicUniform, "dynamic uniform buffers", limits.MaxDynamicUniformBuffersPerPipelineLayout)
+	}
+	if dynamicStorage > int(limits.MaxDynamicStorageBuffersPerPipelineLayout) {
+		return tooMany(dynamicStorage, "dynamic storage buffers", limits.MaxDynamicStorageBuffersPerPipelineLayout)
+	}
+
+	// PL3: Per-stage resource limits across all groups.
+	stageLimits := [5]struct {
+		name  string
+		limit uint32
+	}{
+		{"uniform buffers", limits.MaxUniformBuffersPerShaderStage},
+		{"storage buffers", limits.MaxStorageBuffersPerShaderStage},
+		{"samplers", limits.MaxSamplersPerShaderStage},
+		{"sampled textures", limits.MaxSampledTexturesPerShaderStage},
+		{"storage textures", limits.MaxStorageTexturesPerShaderStage},
+	}
+	for s, stage := range pipelineLayoutStages {
+		for kind, l := range stageLimits {
+			if l.limit > 0 && perStage[s][kind] > int(l.limit) {
+				return tooMany(perStage[s][kind], l.name+" in the "+stage.name+" stage", l.limit)
+			}
+		}
+	}
+
+	return nil
+}
+
 // isDepthStencilFormat returns true if the format is a depth and/or stencil format.
 // These formats do not have a color aspect and cannot be used as color targets.
 //
@@ -390,9 +609,14 @@ func ValidateRenderPipelineDescriptor(desc *hal.RenderPipelineDescriptor, limits
 		}
 	}
 
+	// RP10-RP12: Vertex buffer layouts within the vertex limits.
+	if err := validateVertexBufferLimits(desc.Vertex.Buffers, label, limits); err != nil {
+		return err
+	}
+
 	// RP3-RP6: Fragment stage validation (if present).
 	if desc.Fragment != nil {
-		if err := validateFragmentStage(desc.Fragment, label, limits); err != nil {
+		if err := validateFragmentStage(desc.Fragment, desc.DepthStencil != nil, label, limits); err != nil {
 			return err
 		}
 	}
@@ -445,8 +669,8 @@ func ValidateRenderPipelineDescriptor(desc *hal.RenderPipelineDescriptor, limits
 		}
 	}
 
-	// RP7: SampleCount must be 1 or 4.
-	if desc.Multisample.Count != 0 && desc.Multisample.Count != 1 && desc.Multisample.Count != 4 {
+	// RP7: SampleCount must be a power of two up to 16 (0 means 1).
+	if desc.Multisample.Count != 0 && !isValidSampleCount(desc.Multisample.Count) {
 		return &CreateRenderPipelineError{
 			Kind:        CreateRenderPipelineErrorInvalidSampleCount,
 			Label:       label,
@@ -458,7 +682,8 @@ func ValidateRenderPipelineDescriptor(desc *hal.RenderPipelineDescriptor, limits
 }
 
 // validateFragmentStage checks RP3-RP6 fragment stage constraints.
-func validateFragmentStage(frag *hal.FragmentState, label string, limits gputypes.Limits) error {
+// hasDepthStencil reports whether the pipeline has a depth/stencil state.
+func validateFragmentStage(frag *hal.FragmentState, hasDepthStencil bool, label string, limits gputypes.Limits) error {
 	// RP3: Fragment module must not be nil.
 	if frag.Module == nil {
 		return &CreateRenderPipelineError{
@@ -473,8 +698,10 @@ func validateFragmentStage(frag *hal.FragmentState, label string, limits gputype
 			Label: label,
 		}
 	}
-	// RP5: Must have at least 1 target.
-	if len(frag.Targets) == 0 {
+	// RP5: Must have at least 1 target, unless the fragment stage only
+	// affects depth and stencil (frag_depth, discard).
+	// Rust: pipeline.rs NoTargetSpecified.
+	if len(frag.Targets) == 0 && !hasDepthStencil {
 		return &CreateRenderPipelineError{
 			Kind:  CreateRenderPipelineErrorNoFragmentTargets,
 			Label: label,
@@ -490,9 +717,135 @@ func validateFragmentStage(frag *hal.FragmentState, label string, limits gputype
 			MaxTargets:  limits.MaxColorAttachments,
 		}
 	}
+	// RP13: Color targets fit in maxColorAttachmentBytesPerSample.
+	// Rust: pipeline.rs validate_color_attachment_bytes_per_sample.
+	formats := make([]gputypes.TextureFormat, len(frag.Targets))
+	for i, ct := range frag.Targets {
+		formats[i] = ct.Format
+	}
+	if bytes := ColorAttachmentBytesPerSample(formats); bytes > limits.MaxColorAttachmentBytesPerSample {
+		return &CreateRenderPipelineError{
+			Kind:  CreateRenderPipelineErrorColorAttachmentBytesPerSample,
+			Label: label,
+			Count: uint64(bytes),
+			Limit: uint64(limits.MaxColorAttachmentBytesPerSample),
+		}
+	}
+	return nil
+}
+
+// validateVertexBufferLimits checks RP10-RP12: the number of vertex buffers,
+// their array strides and the total attribute count against device limits.
+func validateVertexBufferLimits(buffers []gputypes.VertexBufferLayout, label string, limits gputypes.Limits) error {
+	// RP10: Vertex buffer count <= maxVertexBuffers.
+	if uint64(len(buffers)) > uint64(limits.MaxVertexBuffers) {
+		return &CreateRenderPipelineError{
+			Kind:  CreateRenderPipelineErrorTooManyVertexBuffers,
+			Label: label,
+			Count: uint64(len(buffers)),
+			Limit: uint64(limits.MaxVertexBuffers),
+		}
+	}
+	var attributes uint64
+	for i, buffer := range buffers {
+		// RP11: Array stride <= maxVertexBufferArrayStride.
+		if buffer.ArrayStride > uint64(limits.MaxVertexBufferArrayStride) {
+			return &CreateRenderPipelineError{
+				Kind:         CreateRenderPipelineErrorVertexStrideTooLarge,
+				Label:        label,
+				VertexBuffer: uint32(i), //nolint:gosec // i bounded by MaxVertexBuffers check
+				Count:        buffer.ArrayStride,
+				Limit:        uint64(limits.MaxVertexBufferArrayStride),
+			}
+		}
+		attributes += uint64(len(buffer.Attributes))
+	}
+	// RP12: Total attribute count <= maxVertexAttributes.
+	if attributes > uint64(limits.MaxVertexAttributes) {
+		return &CreateRenderPipelineError{
+			Kind:  CreateRenderPipelineErrorTooManyVertexAttributes,
+			Label: label,
+			Count: attributes,
+			Limit: uint64(limits.MaxVertexAttributes),
+		}
+	}
+	return nil
+}
+
+// ValidateRenderPipelineBindGroups checks RP14: the pipeline layout's bind
+// group count plus the render pipeline's vertex buffers fit in
+// maxBindGroupsPlusVertexBuffers. It is separate from
+// ValidateRenderPipelineDescriptor because hal.PipelineLayout does not
+// expose its group count.
+func ValidateRenderPipelineBindGroups(desc *hal.RenderPipelineDescriptor, bindGroupCount uint32, limits gputypes.Limits) error {
+	total := uint64(bindGroupCount) + uint64(len(desc.Vertex.Buffers))
+	if total > uint64(limits.MaxBindGroupsPlusVertexBuffers) {
+		return &CreateRenderPipelineError{
+			Kind:  CreateRenderPipelineErrorTooManyBindGroupsPlusVertexBuffers,
+			Label: desc.Label,
+			Count: total,
+			Limit: uint64(limits.MaxBindGroupsPlusVertexBuffers),
+		}
+	}
 	return nil
 }
 
+// ColorAttachmentBytesPerSample returns the bytes per sample a set of color
+// attachments in formats occupies, following the WebGPU algorithm: each
+// format's render target pixel byte cost is added after aligning the running
+// total to the format's component alignment. Undefined formats (sparse
+// attachment slots) and formats that are not color renderable count as zero.
+// See: https://gpuweb.github.io/gpuweb/#abstract-opdef-calculating-color-attachment-bytes-per-sample
+func ColorAttachmentBytesPerSample(formats []gputypes.TextureFormat) uint32 {
+	var total uint32
+	for _, format := range formats {
+		cost, alignment := renderTargetByteCost(format)
+		if cost == 0 {
+			continue
+		}
+		total = (total + alignment - 1) / alignment * alignment
+		total += cost
+	}
+	return total
+}
+
+// renderTargetByteCost returns the render target pixel byte cost and
+// component alignment of a color renderable format, or zeros.
+// See: https://gpuweb.github.io/gpuweb/#plain-color-formats
+//
+//nolint:exhaustive // only color renderable formats have a cost
+func renderTargetByteCost(format gputypes.TextureFormat) (cost, alignment uint32) {
+	switch format {
+	case gputypes.TextureFormatR8Unorm, gputypes.TextureFormatR8Uint, gputypes.TextureFormatR8Sint:
+		return 1, 1
+	case gputypes.TextureFormatRG8Unorm, gputypes.TextureFormatRG8Uint, gputypes.TextureFormatRG8Sint:
+		return 2, 1
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb,
+		gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb:
+		return 8, 1
+	case gputypes.TextureFormatRGBA8Uint, gputypes.TextureFormatRGBA8Sint:
+		return 4, 1
+	case gputypes.TextureFormatR16Unorm, gputypes.TextureFormatR16Uint,
+		gputypes.TextureFormatR16Sint, gputypes.TextureFormatR16Float:
+		return 2, 2
+	case gputypes.TextureFormatRG16Unorm, gputypes.TextureFormatRG16Uint,
+		gputypes.TextureFormatRG16Sint, gputypes.TextureFormatRG16Float:
+		return 4, 2
+	case gputypes.TextureFormatRGBA16Unorm, gputypes.TextureFormatRGBA16Uint,
+		gputypes.TextureFormatRGBA16Sint, gputypes.TextureFormatRGBA16Float:
+		return 8, 2
+	case gputypes.TextureFormatR32Uint, gputypes.TextureFormatR32Sint, gputypes.TextureFormatR32Float:
+		return 4, 4
+	case gputypes.TextureFormatRG32Uint, gputypes.TextureFormatRG32Sint, gputypes.TextureFormatRG32Float:
+		return 8, 4
+	case gputypes.TextureFormatRGBA32Uint, gputypes.TextureFormatRGBA32Sint, gputypes.TextureFormatRGBA32Float:
+		return 16, 4
+	case gputypes.TextureFormatRGB10A2Unorm, gputypes.TextureFormatRGB10A2Uint, gputypes.TextureFormatRG11B10Ufloat:
+		return 8, 4
+	}
+	return 0, 0
+}
+
 // ValidateComputePipelineDescriptor validates a compute pipeline descriptor.
 // Returns nil if valid, or a *CreateComputePipelineError describing the first validation failure.
 func ValidateComputePipelineDescriptor(desc *hal.ComputePipelineDescriptor) error {
@@ -517,6 +870,150 @@ func ValidateComputePipelineDescriptor(desc *hal.ComputePipelineDescriptor) erro
 	return nil
 }
 
+// ValidateInterStageVariables checks the user-defined locations passed from
+// the vertex stage to the fragment stage against MaxInterStageShaderVariables.
+// The vertex entry point's outputs and the fragment entry point's inputs are
+// counted from the naga IR; a nil module skips its stage.
+// Returns nil if valid, or a *CreateRenderPipelineError.
+func ValidateInterStageVariables(label string, vertex *ir.Module, vertexEntry string, fragment *ir.Module, fragmentEntry string, limits gputypes.Limits) error {
+	check := func(module *ir.Module, entry string, stage ir.ShaderStage) error {
+		ep := findEntryPoint(module, entry, stage)
+		if ep == nil {
+			return nil
+		}
+		var locations []uint32
+		if stage == ir.StageVertex {
+			if ep.Function.Result != nil {
+				locations = appendLocations(locations, module, ep.Function.Result.Type, ep.Function.Result.Binding)
+			}
+		} else {
+			for _, arg := range ep.Function.Arguments {
+				locations = appendLocations(locations, module, arg.Type, arg.Binding)
+			}
+		}
+		// A location must be below the limit, so the highest one sets the
+		// number of variables needed as well as the count does.
+		need := uint64(len(locations))
+		for _, loc := range locations {
+			need = max(need, uint64(loc)+1)
+		}
+		if need > uint64(limits.MaxInterStageShaderVariables) {
+			return &CreateRenderPipelineError{
+				Kind:  CreateRenderPipelineErrorTooManyInterStageVariables,
+				Label: label,
+				Count: need,
+				Limit: uint64(limits.MaxInterStageShaderVariables),
+			}
+		}
+		return nil
+	}
+	if err := check(vertex, vertexEntry, ir.StageVertex); err != nil {
+		return err
+	}
+	return check(fragment, fragmentEntry, ir.StageFragment)
+}
+
+// ValidateWorkgroupStorageSize checks the workgroup-space variables used by
+// a compute entry point against MaxComputeWorkgroupStorageSize. Variables
+// referenced by the functions the entry point calls are counted too.
+// Returns nil if valid, or a *CreateComputePipelineError.
+func ValidateWorkgroupStorageSize(label string, module *ir.Module, entryPoint string, limits gputypes.Limits) error {
+	ep := findEntryPoint(module, entryPoint, ir.StageCompute)
+	if ep == nil {
+		return nil
+	}
+	used := make([]bool, len(module.GlobalVariables))
+	visited := make([]bool, len(module.Functions))
+	markUsedGlobals(module, &ep.Function, used, visited)
+
+	var size uint64
+	for i, gv := range module.GlobalVariables {
+		if used[i] && gv.Space == ir.SpaceWorkGroup {
+			size += uint64(ir.TypeSize(module, gv.Type))
+		}
+	}
+	if size > uint64(limits.MaxComputeWorkgroupStorageSize) {
+		return &CreateComputePipelineError{
+			Kind:         CreateComputePipelineErrorWorkgroupStorageTooLarge,
+			Label:        label,
+			Limit:        limits.MaxComputeWorkgroupStorageSize,
+			StorageBytes: size,
+		}
+	}
+	return nil
+}
+
+// findEntryPoint returns the entry point of module with the given name and
+// stage, or nil if module is nil or has none.
+func findEntryPoint(module *ir.Module, name string, stage ir.ShaderStage) *ir.EntryPoint {
+	if module == nil {
+		return nil
+	}
+	for i := range module.EntryPoints {
+		if ep := &module.EntryPoints[i]; ep.Name == name && ep.Stage == stage {
+			return ep
+		}
+	}
+	return nil
+}
+
+// appendLocations appends the @location numbers of an entry point argument
+// or result, looking inside struct members when the value has no binding.
+func appendLocations(locations []uint32, module *ir.Module, ty ir.TypeHandle, binding *ir.Binding) []uint32 {
+	if binding != nil {
+		if loc, ok := (*binding).(ir.LocationBinding); ok {
+			locations = append(locations, loc.Location)
+		}
+		return locations
+	}
+	if int(ty) >= len(module.Types) {
+		return locations
+	}
+	if st, ok := module.Types[ty].Inner.(ir.StructType); ok {
+		for _, m := range st.Members {
+			if m.Binding != nil {
+				locations = appendLocations(locations, module, m.Type, m.Binding)
+			}
+		}
+	}
+	return locations
+}
+
+// markUsedGlobals marks the global variables f references, following calls
+// into module functions not yet visited.
+func markUsedGlobals(module *ir.Module, f *ir.Function, used, visited []bool) {
+	for _, expr := range f.Expressions {
+		if gv, ok := expr.Kind.(ir.ExprGlobalVariable); ok && int(gv.Variable) < len(used) {
+			used[gv.Variable] = true
+		}
+	}
+	var walk func(stmts []ir.Statement)
+	walk = func(stmts []ir.Statement) {
+		for _, stmt := range stmts {
+			switch s := stmt.Kind.(type) {
+			case ir.StmtCall:
+				if int(s.Function) < len(visited) && !visited[s.Function] {
+					visited[s.Function] = true
+					markUsedGlobals(module, &module.Functions[s.Function], used, visited)
+				}
+			case ir.StmtBlock:
+				walk(s.Block)
+			case ir.StmtIf:
+				walk(s.Accept)
+				walk(s.Reject)
+			case ir.StmtSwitch:
+				for _, c := range s.Cases {
+					walk(c.Body)
+				}
+			case ir.StmtLoop:
+				walk(s.Body)
+				walk(s.Continuing)
+			}
+		}
+	}
+	walk(f.Body)
+}
+
 // ValidateBindGroupLayoutDescriptor validates a bind group layout descriptor against device limits.
 // Returns nil if valid, or a *CreateBindGroupLayoutError describing the first validation failure.
 func ValidateBindGroupLayoutDescriptor(desc *hal.BindGroupLayoutDescriptor, limits gputypes.Limits) error {
@@ -561,9 +1058,21 @@ func ValidateBindGroupLayoutDescriptor(desc *hal.BindGroupLayoutDescriptor, limi
 		}
 		if entry.Texture != nil {
 			sampledTextures++
+			// BGL4: Multisampled textures are only bound as 2D views.
+			if entry.Texture.Multisampled && entry.Texture.ViewDimension != gputypes.TextureViewDimensionUndefined &&
+				entry.Texture.ViewDimension != gputypes.TextureViewDimension2D {
+				return fmt.Errorf("bind group layout %q: binding %d multisampled texture must have a 2D view dimension, got %s",
+					label, entry.Binding, entry.Texture.ViewDimension)
+			}
 		}
 		if entry.StorageTexture != nil {
 			storageTextures++
+			// BGL5: Storage textures need a defined access mode and a
+			// storage-capable format, read-write access only on r32 formats,
+			// and cannot be cube or cube array views.
+			if err := validateStorageTextureLayout(label, entry.Binding, entry.StorageTexture); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -0,0 +1,292 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga"
+	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func limitsPipeline(buffers []gputypes.VertexBufferLayout, targets []gputypes.TextureFormat) *hal.RenderPipelineDescriptor {
+	desc := &hal.RenderPipelineDescriptor{
+		Label:  "limits",
+		Vertex: hal.VertexState{Module: mockShaderModule{}, EntryPoint: "vs_main", Buffers: buffers},
+		Fragment: &hal.FragmentState{
+			Module:     mockShaderModule{},
+			EntryPoint: "fs_main",
+		},
+	}
+	for _, format := range targets {
+		desc.Fragment.Targets = append(desc.Fragment.Targets, gputypes.ColorTargetState{Format: format})
+	}
+	return desc
+}
+
+func TestValidateRenderPipelineDescriptor_VertexLimits(t *testing.T) {
+	limits := gputypes.DefaultLimits()
+	attrs := func(n int) []gputypes.VertexAttribute { return make([]gputypes.VertexAttribute, n) }
+
+	tests := []struct {
+		name    string
+		buffers []gputypes.VertexBufferLayout
+		kind    CreateRenderPipelineErrorKind
+		valid   bool
+	}{
+		{"at limits", []gputypes.VertexBufferLayout{
+			{ArrayStride: 2048, Attributes: attrs(8)},
+			{ArrayStride: 16, Attributes: attrs(8)},
+		}, 0, true},
+		{"too many buffers", make([]gputypes.VertexBufferLayout, limits.MaxVertexBuffers+1),
+			CreateRenderPipelineErrorTooManyVertexBuffers, false},
+		{"stride too large", []gputypes.VertexBufferLayout{{ArrayStride: 16}, {ArrayStride: 2052}},
+			CreateRenderPipelineErrorVertexStrideTooLarge, false},
+		{"too many attributes", []gputypes.VertexBufferLayout{{Attributes: attrs(9)}, {Attributes: attrs(8)}},
+			CreateRenderPipelineErrorTooManyVertexAttributes, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRenderPipelineDescriptor(limitsPipeline(tt.buffers, []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm}), limits)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var crpe *CreateRenderPipelineError
+			if !errors.As(err, &crpe) || crpe.Kind != tt.kind {
+				t.Fatalf("got %v, want kind %d", err, tt.kind)
+			}
+		})
+	}
+
+	err := ValidateRenderPipelineDescriptor(limitsPipeline([]gputypes.VertexBufferLayout{{ArrayStride: 16}, {ArrayStride: 2052}}, nil), limits)
+	if want := "vertex buffer [1] array stride 2052 exceeds maximum 2048"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Error() = %v, want it to contain %q", err, want)
+	}
+}
+
+func TestColorAttachmentBytesPerSample(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []gputypes.TextureFormat
+		want    uint32
+	}{
+		{"none", nil, 0},
+		{"rgba8", []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm}, 8},
+		{"sparse slot", []gputypes.TextureFormat{gputypes.TextureFormatUndefined, gputypes.TextureFormatR8Unorm}, 1},
+		// r8 (1) is padded to 4 before r32float (4), then rg16float (4, align 2).
+		{"alignment", []gputypes.TextureFormat{
+			gputypes.TextureFormatR8Unorm, gputypes.TextureFormatR32Float, gputypes.TextureFormatRG16Float,
+		}, 12},
+		{"gbuffer", []gputypes.TextureFormat{
+			gputypes.TextureFormatRGBA16Float, gputypes.TextureFormatRGBA16Float,
+			gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA32Float,
+		}, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ColorAttachmentBytesPerSample(tt.formats); got != tt.want {
+				t.Errorf("ColorAttachmentBytesPerSample() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRenderPipelineDescriptor_ColorAttachmentBytesPerSample(t *testing.T) {
+	limits := gputypes.DefaultLimits()
+	fits := []gputypes.TextureFormat{gputypes.TextureFormatRGBA32Float, gputypes.TextureFormatRGBA32Float}
+	if err := ValidateRenderPipelineDescriptor(limitsPipeline(nil, fits), limits); err != nil {
+		t.Fatalf("32 bytes per sample: unexpected error %v", err)
+	}
+
+	over := append(fits, gputypes.TextureFormatR8Unorm)
+	err := ValidateRenderPipelineDescriptor(limitsPipeline(nil, over), limits)
+	var crpe *CreateRenderPipelineError
+	if !errors.As(err, &crpe) || crpe.Kind != CreateRenderPipelineErrorColorAttachmentBytesPerSample {
+		t.Fatalf("got %v, want ColorAttachmentBytesPerSample", err)
+	}
+	if crpe.Count != 33 || crpe.Limit != 32 {
+		t.Errorf("Count, Limit = %d, %d, want 33, 32", crpe.Count, crpe.Limit)
+	}
+}
+
+func TestValidateRenderPipelineBindGroups(t *testing.T) {
+	limits := gputypes.DefaultLimits()
+	limits.MaxBindGroupsPlusVertexBuffers = 6
+	desc := limitsPipeline(make([]gputypes.VertexBufferLayout, 2), nil)
+
+	if err := ValidateRenderPipelineBindGroups(desc, 4, limits); err != nil {
+		t.Fatalf("4 groups + 2 buffers: unexpected error %v", err)
+	}
+	err := ValidateRenderPipelineBindGroups(desc, 5, limits)
+	var crpe *CreateRenderPipelineError
+	if !errors.As(err, &crpe) || crpe.Kind != CreateRenderPipelineErrorTooManyBindGroupsPlusVertexBuffers {
+		t.Fatalf("got %v, want TooManyBindGroupsPlusVertexBuffers", err)
+	}
+}
+
+func TestValidatePipelineLayoutBindings(t *testing.T) {
+	limits := gputypes.DefaultLimits()
+	dynamicUniform := func(binding uint32) gputypes.BindGroupLayoutEntry {
+		return gputypes.BindGroupLayoutEntry{
+			Binding:    binding,
+			Visibility: gputypes.ShaderStageVertex,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform, HasDynamicOffset: true},
+		}
+	}
+	storage := func(binding uint32, stages gputypes.ShaderStages) gputypes.BindGroupLayoutEntry {
+		return gputypes.BindGroupLayoutEntry{
+			Binding:    binding,
+			Visibility: stages,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage},
+		}
+	}
+	group := func(n uint32, entry func(uint32) gputypes.BindGroupLayoutEntry) []gputypes.BindGroupLayoutEntry {
+		entries := make([]gputypes.BindGroupLayoutEntry, n)
+		for i := range entries {
+			entries[i] = entry(uint32(i))
+		}
+		return entries
+	}
+	fragmentStorage := func(b uint32) gputypes.BindGroupLayoutEntry { return storage(b, gputypes.ShaderStageFragment) }
+	computeStorage := func(b uint32) gputypes.BindGroupLayoutEntry { return storage(b, gputypes.ShaderStageCompute) }
+
+	tests := []struct {
+		name    string
+		groups  [][]gputypes.BindGroupLayoutEntry
+		binding string
+	}{
+		{"dynamic uniform at limit", [][]gputypes.BindGroupLayoutEntry{group(4, dynamicUniform), group(4, dynamicUniform)}, ""},
+		{"dynamic uniform over limit", [][]gputypes.BindGroupLayoutEntry{group(4, dynamicUniform), group(5, dynamicUniform)}, "dynamic uniform buffers"},
+		{"storage split across stages", [][]gputypes.BindGroupLayoutEntry{group(8, fragmentStorage), group(8, computeStorage)}, ""},
+		{"storage over limit in one stage", [][]gputypes.BindGroupLayoutEntry{group(5, fragmentStorage), group(4, fragmentStorage)}, "storage buffers in the fragment stage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePipelineLayoutBindings("layout", tt.groups, limits)
+			if tt.binding == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var cple *CreatePipelineLayoutError
+			if !errors.As(err, &cple) || cple.Kind != CreatePipelineLayoutErrorTooManyBindings {
+				t.Fatalf("got %v, want TooManyBindings", err)
+			}
+			if cple.Binding != tt.binding {
+				t.Errorf("Binding = %q, want %q", cple.Binding, tt.binding)
+			}
+		})
+	}
+}
+
+func lowerWGSL(t *testing.T, source string) *ir.Module {
+	t.Helper()
+	ast, err := naga.Parse(source)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	module, err := naga.Lower(ast)
+	if err != nil {
+		t.Fatalf("lower: %v", err)
+	}
+	return module
+}
+
+const interStageWGSL = `
+struct VertexOutput {
+	@builtin(position) position: vec4<f32>,
+	@location(0) a: vec4<f32>,
+	@location(1) b: vec4<f32>,
+	@location(2) c: vec4<f32>,
+}
+
+@vertex
+fn vs_main() -> VertexOutput {
+	var out: VertexOutput;
+	return out;
+}
+
+@fragment
+fn fs_main(@location(0) a: vec4<f32>, @location(5) f: vec4<f32>) -> @location(0) vec4<f32> {
+	return a + f;
+}
+`
+
+func TestValidateInterStageVariables(t *testing.T) {
+	module := lowerWGSL(t, interStageWGSL)
+	limits := gputypes.DefaultLimits()
+	if err := ValidateInterStageVariables("inter", module, "vs_main", module, "fs_main", limits); err != nil {
+		t.Fatalf("default limits: unexpected error %v", err)
+	}
+
+	limits.MaxInterStageShaderVariables = 3
+	if err := ValidateInterStageVariables("inter", module, "vs_main", nil, "", limits); err != nil {
+		t.Fatalf("3 vertex outputs, limit 3: unexpected error %v", err)
+	}
+	limits.MaxInterStageShaderVariables = 2
+	err := ValidateInterStageVariables("inter", module, "vs_main", nil, "", limits)
+	var crpe *CreateRenderPipelineError
+	if !errors.As(err, &crpe) || crpe.Kind != CreateRenderPipelineErrorTooManyInterStageVariables {
+		t.Fatalf("vertex outputs: got %v, want TooManyInterStageVariables", err)
+	}
+	if crpe.Count != 3 || crpe.Limit != 2 {
+		t.Errorf("Count, Limit = %d, %d, want 3, 2", crpe.Count, crpe.Limit)
+	}
+
+	// Two fragment inputs, but location 5 needs six variables.
+	limits.MaxInterStageShaderVariables = 4
+	err = ValidateInterStageVariables("inter", nil, "", module, "fs_main", limits)
+	if !errors.As(err, &crpe) || crpe.Count != 6 {
+		t.Fatalf("fragment inputs: got %v, want TooManyInterStageVariables with Count 6", err)
+	}
+}
+
+const workgroupWGSL = `
+var<workgroup> tile: array<f32, 2048>;
+var<workgroup> other: array<f32, 4096>;
+
+fn touch() {
+	tile[0] = 1.0;
+}
+
+@compute @workgroup_size(64)
+fn main() {
+	touch();
+}
+
+@compute @workgroup_size(64)
+fn both() {
+	touch();
+	other[0] = 1.0;
+}
+`
+
+func TestValidateWorkgroupStorageSize(t *testing.T) {
+	module := lowerWGSL(t, workgroupWGSL)
+	limits := gputypes.DefaultLimits()
+	limits.MaxComputeWorkgroupStorageSize = 8192
+
+	// main reaches tile (8 KiB) through touch and leaves other unused.
+	if err := ValidateWorkgroupStorageSize("wg", module, "main", limits); err != nil {
+		t.Fatalf("main: unexpected error %v", err)
+	}
+	err := ValidateWorkgroupStorageSize("wg", module, "both", limits)
+	var ccpe *CreateComputePipelineError
+	if !errors.As(err, &ccpe) || ccpe.Kind != CreateComputePipelineErrorWorkgroupStorageTooLarge {
+		t.Fatalf("both: got %v, want WorkgroupStorageTooLarge", err)
+	}
+	if ccpe.StorageBytes != 24576 || ccpe.Limit != 8192 {
+		t.Errorf("StorageBytes, Limit = %d, %d, want 24576, 8192", ccpe.StorageBytes, ccpe.Limit)
+	}
+	if !strings.Contains(err.Error(), "24576") {
+		t.Errorf("error %q does not name the size", err)
+	}
+}
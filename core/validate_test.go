@@ -215,6 +215,36 @@ func TestValidateTextureDescriptor_InvalidUsage(t *testing.T) {
 	}
 }
 
+func TestValidateTextureDescriptor_Transient(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*hal.TextureDescriptor)
+		wantErr bool
+	}{
+		{"MSAA color", func(d *hal.TextureDescriptor) { d.SampleCount = 4 }, false},
+		{"depth", func(d *hal.TextureDescriptor) { d.Format = gputypes.TextureFormatDepth32Float }, false},
+		{"sampled", func(d *hal.TextureDescriptor) { d.Usage |= gputypes.TextureUsageTextureBinding }, true},
+		{"copy source", func(d *hal.TextureDescriptor) { d.Usage |= gputypes.TextureUsageCopySrc }, true},
+		{"mipmapped", func(d *hal.TextureDescriptor) { d.MipLevelCount = 2 }, true},
+		{"array", func(d *hal.TextureDescriptor) { d.Size.DepthOrArrayLayers = 2 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := validTextureDesc()
+			desc.Usage = gputypes.TextureUsageRenderAttachment
+			desc.Transient = true
+			tt.mutate(desc)
+
+			err := ValidateTextureDescriptor(desc, gputypes.DefaultLimits())
+			var cte *CreateTextureError
+			gotTransientErr := errors.As(err, &cte) && cte.Kind == CreateTextureErrorTransient
+			if gotTransientErr != tt.wantErr {
+				t.Errorf("ValidateTextureDescriptor() = %v, want transient error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateTextureDescriptor_InvalidMipLevelCount_Zero(t *testing.T) {
 	desc := validTextureDesc()
 	desc.MipLevelCount = 0
@@ -260,7 +290,7 @@ func TestValidateTextureDescriptor_InvalidMipLevelCount_TooMany(t *testing.T) {
 }
 
 func TestValidateTextureDescriptor_InvalidSampleCount(t *testing.T) {
-	for _, sc := range []uint32{0, 2, 3, 5, 8, 16} {
+	for _, sc := range []uint32{0, 3, 5, 12, 32} {
 		desc := validTextureDesc()
 		desc.SampleCount = sc
 
@@ -539,6 +569,17 @@ func TestValidateShaderModuleDescriptor_DualSource(t *testing.T) {
 	}
 }
 
+func TestValidateShaderModuleDescriptor_InvalidSPIRV(t *testing.T) {
+	desc := &hal.ShaderModuleDescriptor{
+		Label:  "test",
+		Source: hal.ShaderSource{SPIRV: []uint32{0x03022307, 0x00000100}},
+	}
+	var csme *CreateShaderModuleError
+	if err := ValidateShaderModuleDescriptor(desc); !errors.As(err, &csme) || csme.Kind != CreateShaderModuleErrorInvalidSPIRV {
+		t.Fatalf("expected InvalidSPIRV, got: %v", err)
+	}
+}
+
 // --- ValidateRenderPipelineDescriptor tests ---
 
 func TestValidateRenderPipelineDescriptor_Valid(t *testing.T) {
@@ -681,6 +722,28 @@ func TestValidateRenderPipelineDescriptor_NoFragmentTargets(t *testing.T) {
 	}
 }
 
+func TestValidateRenderPipelineDescriptor_DepthOnly(t *testing.T) {
+	depthStencil := &hal.DepthStencilState{
+		Format:            gputypes.TextureFormatDepth32Float,
+		DepthWriteEnabled: true,
+		DepthCompare:      gputypes.CompareFunctionLess,
+	}
+	for _, frag := range []*hal.FragmentState{
+		nil,
+		{Module: mockShaderModule{}, EntryPoint: "fs_main"},
+	} {
+		desc := &hal.RenderPipelineDescriptor{
+			Label:        "shadow",
+			Vertex:       hal.VertexState{Module: mockShaderModule{}, EntryPoint: "vs_main"},
+			Fragment:     frag,
+			DepthStencil: depthStencil,
+		}
+		if err := ValidateRenderPipelineDescriptor(desc, gputypes.DefaultLimits()); err != nil {
+			t.Errorf("depth-only pipeline (fragment %v): %v", frag != nil, err)
+		}
+	}
+}
+
 func TestValidateRenderPipelineDescriptor_TooManyColorTargets(t *testing.T) {
 	limits := gputypes.DefaultLimits()
 	targets := make([]gputypes.ColorTargetState, limits.MaxColorAttachments+1)
@@ -734,6 +797,62 @@ func TestValidateRenderPipelineDescriptor_InvalidSampleCount(t *testing.T) {
 	}
 }
 
+func TestValidateTextureSampleCount(t *testing.T) {
+	caps := hal.TextureFormatCapabilities{SampleCounts: hal.SampleCount1 | hal.SampleCount2 | hal.SampleCount4}
+	tests := []struct {
+		count   uint32
+		wantErr bool
+	}{
+		{1, false},
+		{2, false},
+		{4, false},
+		{8, true},
+		{16, true},
+	}
+	for _, tt := range tests {
+		desc := validTextureDesc()
+		desc.SampleCount = tt.count
+		err := ValidateTextureSampleCount(desc, caps)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("count %d: err = %v, wantErr %v", tt.count, err, tt.wantErr)
+			continue
+		}
+		var cte *CreateTextureError
+		if tt.wantErr && (!errors.As(err, &cte) || cte.Kind != CreateTextureErrorUnsupportedSampleCount) {
+			t.Errorf("count %d: expected UnsupportedSampleCount, got %v", tt.count, err)
+		}
+	}
+}
+
+func TestValidateRenderPipelineSampleCount(t *testing.T) {
+	formatCaps := func(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
+		if format == gputypes.TextureFormatDepth32Float {
+			return hal.TextureFormatCapabilities{SampleCounts: hal.SampleCountsWebGPU}
+		}
+		return hal.TextureFormatCapabilities{SampleCounts: hal.SampleCountsUpTo(8)}
+	}
+	desc := &hal.RenderPipelineDescriptor{
+		Label: "msaa",
+		Fragment: &hal.FragmentState{
+			Targets: []gputypes.ColorTargetState{{Format: gputypes.TextureFormatRGBA8Unorm}},
+		},
+		Multisample: gputypes.MultisampleState{Count: 8},
+	}
+	if err := ValidateRenderPipelineSampleCount(desc, formatCaps); err != nil {
+		t.Fatalf("8x color target: unexpected error %v", err)
+	}
+
+	desc.DepthStencil = &hal.DepthStencilState{Format: gputypes.TextureFormatDepth32Float}
+	err := ValidateRenderPipelineSampleCount(desc, formatCaps)
+	var crpe *CreateRenderPipelineError
+	if !errors.As(err, &crpe) || crpe.Kind != CreateRenderPipelineErrorUnsupportedSampleCount {
+		t.Fatalf("8x Depth32Float: expected UnsupportedSampleCount, got %v", err)
+	}
+	if crpe.Format != "Depth32Float" {
+		t.Errorf("Format = %q, want Depth32Float", crpe.Format)
+	}
+}
+
 func TestValidateRenderPipelineDescriptor_NoFragment(t *testing.T) {
 	desc := &hal.RenderPipelineDescriptor{
 		Label: "test",
@@ -1316,7 +1435,7 @@ func TestCreateTextureError_Error(t *testing.T) {
 		{
 			name:     "invalid sample count",
 			err:      &CreateTextureError{Kind: CreateTextureErrorInvalidSampleCount, Label: "test", RequestedSamples: 3},
-			contains: "must be 1 or 4",
+			contains: "must be 1, 2, 4, 8 or 16",
 		},
 		{
 			name:     "multisampled mip level",
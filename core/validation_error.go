@@ -0,0 +1,42 @@
+package core
+
+import "fmt"
+
+// ValidationError represents a validation failure with context.
+type ValidationError struct {
+	Resource string // Resource type (e.g., "Buffer", "Texture")
+	Field    string // Field that failed validation
+	Message  string // Detailed error message
+	Cause    error  // Underlying cause, if any
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%s.%s: %s", e.Resource, e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Resource, e.Message)
+}
+
+// Unwrap returns the underlying cause.
+func (e *ValidationError) Unwrap() error {
+	return e.Cause
+}
+
+// NewValidationError creates a new validation error.
+func NewValidationError(resource, field, message string) *ValidationError {
+	return &ValidationError{
+		Resource: resource,
+		Field:    field,
+		Message:  message,
+	}
+}
+
+// NewValidationErrorf creates a new validation error with formatted message.
+func NewValidationErrorf(resource, field, format string, args ...any) *ValidationError {
+	return &ValidationError{
+		Resource: resource,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,215 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Validator runs the descriptor checks that precede HAL resource creation.
+// Each method delegates to the Validate* function of the same name.
+//
+// FullValidator runs every check and NoValidator none. NewValidator picks
+// one from the instance setting; building with the wgpu_novalidate tag
+// makes DeviceValidator the concrete NoValidator type, so the empty checks
+// inline away and release builds pay nothing for them.
+type Validator interface {
+	// Enabled reports whether checks run. Callers use it to skip building
+	// inputs that only validation needs.
+	Enabled() bool
+
+	TextureDescriptor(desc *hal.TextureDescriptor, limits gputypes.Limits) error
+	TextureSampleCount(desc *hal.TextureDescriptor, caps hal.TextureFormatCapabilities) error
+	TextureViewDescriptor(desc *hal.TextureViewDescriptor, tex *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error
+	SamplerDescriptor(desc *hal.SamplerDescriptor) error
+	ShaderModuleDescriptor(desc *hal.ShaderModuleDescriptor) error
+	BindGroupLayoutDescriptor(desc *hal.BindGroupLayoutDescriptor, limits gputypes.Limits) error
+	PipelineLayoutDescriptor(desc *hal.PipelineLayoutDescriptor, limits gputypes.Limits) error
+	PipelineLayoutBindings(label string, groups [][]gputypes.BindGroupLayoutEntry, limits gputypes.Limits) error
+	BindGroupDescriptor(desc *hal.BindGroupDescriptor, layoutEntries []gputypes.BindGroupLayoutEntry, bufferInfos []BindGroupBufferInfo, limits gputypes.Limits) error
+	BindGroupTextureViews(label string, layoutEntries []gputypes.BindGroupLayoutEntry, textureInfos []BindGroupTextureInfo) error
+	RenderPipelineDescriptor(desc *hal.RenderPipelineDescriptor, limits gputypes.Limits) error
+	RenderPipelineSampleCount(desc *hal.RenderPipelineDescriptor, formatCaps func(gputypes.TextureFormat) hal.TextureFormatCapabilities) error
+	RenderPipelineBindGroups(desc *hal.RenderPipelineDescriptor, bindGroupCount uint32, limits gputypes.Limits) error
+	RenderPipelineInterStage(label string, vertex *ir.Module, vertexEntry string, fragment *ir.Module, fragmentEntry string, limits gputypes.Limits) error
+	ComputePipelineDescriptor(desc *hal.ComputePipelineDescriptor) error
+	ComputePipelineWorkgroupStorage(label string, module *ir.Module, entryPoint string, limits gputypes.Limits) error
+	TextureCopy(region *hal.TextureCopy, src, dst *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error
+}
+
+// FullValidator runs every descriptor check.
+type FullValidator struct{}
+
+// Enabled returns true.
+func (FullValidator) Enabled() bool { return true }
+
+// TextureDescriptor calls ValidateTextureDescriptor.
+func (FullValidator) TextureDescriptor(desc *hal.TextureDescriptor, limits gputypes.Limits) error {
+	return ValidateTextureDescriptor(desc, limits)
+}
+
+// TextureSampleCount calls ValidateTextureSampleCount.
+func (FullValidator) TextureSampleCount(desc *hal.TextureDescriptor, caps hal.TextureFormatCapabilities) error {
+	return ValidateTextureSampleCount(desc, caps)
+}
+
+// TextureViewDescriptor calls ValidateTextureViewDescriptor.
+func (FullValidator) TextureViewDescriptor(desc *hal.TextureViewDescriptor, tex *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error {
+	return ValidateTextureViewDescriptor(desc, tex, downlevel)
+}
+
+// SamplerDescriptor calls ValidateSamplerDescriptor.
+func (FullValidator) SamplerDescriptor(desc *hal.SamplerDescriptor) error {
+	return ValidateSamplerDescriptor(desc)
+}
+
+// ShaderModuleDescriptor calls ValidateShaderModuleDescriptor.
+func (FullValidator) ShaderModuleDescriptor(desc *hal.ShaderModuleDescriptor) error {
+	return ValidateShaderModuleDescriptor(desc)
+}
+
+// BindGroupLayoutDescriptor calls ValidateBindGroupLayoutDescriptor.
+func (FullValidator) BindGroupLayoutDescriptor(desc *hal.BindGroupLayoutDescriptor, limits gputypes.Limits) error {
+	return ValidateBindGroupLayoutDescriptor(desc, limits)
+}
+
+// PipelineLayoutDescriptor calls ValidatePipelineLayoutDescriptor.
+func (FullValidator) PipelineLayoutDescriptor(desc *hal.PipelineLayoutDescriptor, limits gputypes.Limits) error {
+	return ValidatePipelineLayoutDescriptor(desc, limits)
+}
+
+// PipelineLayoutBindings calls ValidatePipelineLayoutBindings.
+func (FullValidator) PipelineLayoutBindings(label string, groups [][]gputypes.BindGroupLayoutEntry, limits gputypes.Limits) error {
+	return ValidatePipelineLayoutBindings(label, groups, limits)
+}
+
+// BindGroupDescriptor calls ValidateBindGroupDescriptor.
+func (FullValidator) BindGroupDescriptor(desc *hal.BindGroupDescriptor, layoutEntries []gputypes.BindGroupLayoutEntry, bufferInfos []BindGroupBufferInfo, limits gputypes.Limits) error {
+	return ValidateBindGroupDescriptor(desc, layoutEntries, bufferInfos, limits)
+}
+
+// BindGroupTextureViews calls ValidateBindGroupTextureViews.
+func (FullValidator) BindGroupTextureViews(label string, layoutEntries []gputypes.BindGroupLayoutEntry, textureInfos []BindGroupTextureInfo) error {
+	return ValidateBindGroupTextureViews(label, layoutEntries, textureInfos)
+}
+
+// RenderPipelineDescriptor calls ValidateRenderPipelineDescriptor.
+func (FullValidator) RenderPipelineDescriptor(desc *hal.RenderPipelineDescriptor, limits gputypes.Limits) error {
+	return ValidateRenderPipelineDescriptor(desc, limits)
+}
+
+// RenderPipelineSampleCount calls ValidateRenderPipelineSampleCount.
+func (FullValidator) RenderPipelineSampleCount(desc *hal.RenderPipelineDescriptor, formatCaps func(gputypes.TextureFormat) hal.TextureFormatCapabilities) error {
+	return ValidateRenderPipelineSampleCount(desc, formatCaps)
+}
+
+// RenderPipelineBindGroups calls ValidateRenderPipelineBindGroups.
+func (FullValidator) RenderPipelineBindGroups(desc *hal.RenderPipelineDescriptor, bindGroupCount uint32, limits gputypes.Limits) error {
+	return ValidateRenderPipelineBindGroups(desc, bindGroupCount, limits)
+}
+
+// RenderPipelineInterStage calls ValidateInterStageVariables.
+func (FullValidator) RenderPipelineInterStage(label string, vertex *ir.Module, vertexEntry string, fragment *ir.Module, fragmentEntry string, limits gputypes.Limits) error {
+	return ValidateInterStageVariables(label, vertex, vertexEntry, fragment, fragmentEntry, limits)
+}
+
+// ComputePipelineDescriptor calls ValidateComputePipelineDescriptor.
+func (FullValidator) ComputePipelineDescriptor(desc *hal.ComputePipelineDescriptor) error {
+	return ValidateComputePipelineDescriptor(desc)
+}
+
+// ComputePipelineWorkgroupStorage calls ValidateWorkgroupStorageSize.
+func (FullValidator) ComputePipelineWorkgroupStorage(label string, module *ir.Module, entryPoint string, limits gputypes.Limits) error {
+	return ValidateWorkgroupStorageSize(label, module, entryPoint, limits)
+}
+
+// TextureCopy calls ValidateTextureCopy.
+func (FullValidator) TextureCopy(region *hal.TextureCopy, src, dst *hal.TextureDescriptor, downlevel hal.DownlevelFlags) error {
+	return ValidateTextureCopy(region, src, dst, downlevel)
+}
+
+// NoValidator skips every descriptor check. Invalid descriptors reach the
+// backend unchecked, where they may fail late or crash the driver.
+type NoValidator struct{}
+
+// Enabled returns false.
+func (NoValidator) Enabled() bool { return false }
+
+// TextureDescriptor returns nil.
+func (NoValidator) TextureDescriptor(*hal.TextureDescriptor, gputypes.Limits) error { return nil }
+
+// TextureSampleCount returns nil.
+func (NoValidator) TextureSampleCount(*hal.TextureDescriptor, hal.TextureFormatCapabilities) error {
+	return nil
+}
+
+// TextureViewDescriptor returns nil.
+func (NoValidator) TextureViewDescriptor(*hal.TextureViewDescriptor, *hal.TextureDescriptor, hal.DownlevelFlags) error {
+	return nil
+}
+
+// SamplerDescriptor returns nil.
+func (NoValidator) SamplerDescriptor(*hal.SamplerDescriptor) error { return nil }
+
+// ShaderModuleDescriptor returns nil.
+func (NoValidator) ShaderModuleDescriptor(*hal.ShaderModuleDescriptor) error { return nil }
+
+// BindGroupLayoutDescriptor returns nil.
+func (NoValidator) BindGroupLayoutDescriptor(*hal.BindGroupLayoutDescriptor, gputypes.Limits) error {
+	return nil
+}
+
+// PipelineLayoutDescriptor returns nil.
+func (NoValidator) PipelineLayoutDescriptor(*hal.PipelineLayoutDescriptor, gputypes.Limits) error {
+	return nil
+}
+
+// PipelineLayoutBindings returns nil.
+func (NoValidator) PipelineLayoutBindings(string, [][]gputypes.BindGroupLayoutEntry, gputypes.Limits) error {
+	return nil
+}
+
+// BindGroupDescriptor returns nil.
+func (NoValidator) BindGroupDescriptor(*hal.BindGroupDescriptor, []gputypes.BindGroupLayoutEntry, []BindGroupBufferInfo, gputypes.Limits) error {
+	return nil
+}
+
+// BindGroupTextureViews returns nil.
+func (NoValidator) BindGroupTextureViews(string, []gputypes.BindGroupLayoutEntry, []BindGroupTextureInfo) error {
+	return nil
+}
+
+// RenderPipelineDescriptor returns nil.
+func (NoValidator) RenderPipelineDescriptor(*hal.RenderPipelineDescriptor, gputypes.Limits) error {
+	return nil
+}
+
+// RenderPipelineSampleCount returns nil.
+func (NoValidator) RenderPipelineSampleCount(*hal.RenderPipelineDescriptor, func(gputypes.TextureFormat) hal.TextureFormatCapabilities) error {
+	return nil
+}
+
+// RenderPipelineBindGroups returns nil.
+func (NoValidator) RenderPipelineBindGroups(*hal.RenderPipelineDescriptor, uint32, gputypes.Limits) error {
+	return nil
+}
+
+// RenderPipelineInterStage returns nil.
+func (NoValidator) RenderPipelineInterStage(string, *ir.Module, string, *ir.Module, string, gputypes.Limits) error {
+	return nil
+}
+
+// ComputePipelineDescriptor returns nil.
+func (NoValidator) ComputePipelineDescriptor(*hal.ComputePipelineDescriptor) error { return nil }
+
+// ComputePipelineWorkgroupStorage returns nil.
+func (NoValidator) ComputePipelineWorkgroupStorage(string, *ir.Module, string, gputypes.Limits) error {
+	return nil
+}
+
+// TextureCopy returns nil.
+func (NoValidator) TextureCopy(*hal.TextureCopy, *hal.TextureDescriptor, *hal.TextureDescriptor, hal.DownlevelFlags) error {
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build !(js && wasm) && wgpu_novalidate
+
+package core
+
+// DeviceValidator is the validator type a Device holds. The wgpu_novalidate
+// build tag makes it the concrete NoValidator, so validation calls compile
+// to nothing.
+type DeviceValidator = NoValidator
+
+// ValidationCompiled reports whether descriptor validation is built in.
+const ValidationCompiled = false
+
+// NewValidator returns NoValidator; enabled is ignored because validation
+// is compiled out.
+func NewValidator(bool) DeviceValidator {
+	return NoValidator{}
+}
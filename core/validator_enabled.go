@@ -0,0 +1,20 @@
+//go:build !(js && wasm) && !wgpu_novalidate
+
+package core
+
+// DeviceValidator is the validator type a Device holds. Without the
+// wgpu_novalidate build tag it is the Validator interface, chosen per
+// instance by NewValidator.
+type DeviceValidator = Validator
+
+// ValidationCompiled reports whether descriptor validation is built in.
+const ValidationCompiled = true
+
+// NewValidator returns FullValidator when enabled is true and NoValidator
+// otherwise.
+func NewValidator(enabled bool) DeviceValidator {
+	if enabled {
+		return FullValidator{}
+	}
+	return NoValidator{}
+}
@@ -0,0 +1,39 @@
+//go:build !(js && wasm)
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestValidatorSampler(t *testing.T) {
+	invalid := &hal.SamplerDescriptor{Label: "bad", LodMinClamp: 4, LodMaxClamp: 1}
+
+	var samplerErr *CreateSamplerError
+	if err := (FullValidator{}).SamplerDescriptor(invalid); !errors.As(err, &samplerErr) {
+		t.Errorf("FullValidator.SamplerDescriptor = %v, want *CreateSamplerError", err)
+	}
+	if err := (NoValidator{}).SamplerDescriptor(invalid); err != nil {
+		t.Errorf("NoValidator.SamplerDescriptor = %v, want nil", err)
+	}
+}
+
+func TestNewValidator(t *testing.T) {
+	if NewValidator(false).Enabled() {
+		t.Error("NewValidator(false) is enabled")
+	}
+	if got := NewValidator(true).Enabled(); got != ValidationCompiled {
+		t.Errorf("NewValidator(true).Enabled() = %v, want %v", got, ValidationCompiled)
+	}
+}
+
+func TestNewDeviceValidates(t *testing.T) {
+	device := NewDevice(&mockHALDevice{}, &Adapter{}, 0, gputypes.DefaultLimits(), "validator")
+	if device.Validator.Enabled() != ValidationCompiled {
+		t.Errorf("NewDevice validator enabled = %v, want %v", device.Validator.Enabled(), ValidationCompiled)
+	}
+}
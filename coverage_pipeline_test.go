@@ -165,6 +165,44 @@ func TestComputePipelineWithLayout(t *testing.T) {
 	}
 }
 
+func TestComputePipelineFromCompilationHint(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pl, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label: "cp-hint-layout",
+	})
+	if err != nil {
+		t.Fatalf("CreatePipelineLayout: %v", err)
+	}
+	defer pl.Release()
+
+	mod, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
+		Label: "cp-hint-shader",
+		WGSL:  "@compute @workgroup_size(1) fn main() {}",
+		CompilationHints: []wgpu.ShaderModuleCompilationHint{
+			{EntryPoint: "main", Layout: pl},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateShaderModule with hints: %v", err)
+	}
+	defer mod.Release()
+
+	pipeline, err := device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+		Label:      "cp-from-hint",
+		Layout:     pl,
+		Module:     mod,
+		EntryPoint: "main",
+	})
+	if err != nil {
+		// Software backend may not support compute.
+		t.Skipf("CreateComputePipeline not supported: %v", err)
+	}
+	defer pipeline.Release()
+}
+
 // =============================================================================
 // Pipeline ref counting — TestRef() on render/compute pipelines
 // Covers pipeline_native.go ref field initialization
@@ -229,7 +267,7 @@ func TestComputePipelineHasRef(t *testing.T) {
 
 // =============================================================================
 // BindGroupLayout compatibility — isCompatibleWith
-// Covers bind_native.go lines 42-55, 60-82
+// Covers bind_native.go lines 42-55, 60-82 and layout deduplication
 // =============================================================================
 
 func TestBindGroupLayoutCompatibility(t *testing.T) {
@@ -272,6 +310,38 @@ func TestBindGroupLayoutCompatibility(t *testing.T) {
 	if bgl1 == nil || bgl2 == nil {
 		t.Fatal("both layouts should be non-nil")
 	}
+
+	// Equal entries are deduplicated into one device-wide layout.
+	if bgl1.TestPooled() == nil || bgl1.TestPooled() != bgl2.TestPooled() {
+		t.Error("layouts with equal entries should share the pooled layout")
+	}
+	other, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label:   "compat-c",
+		Entries: []wgpu.BindGroupLayoutEntry{{Binding: 1, Visibility: wgpu.ShaderStageVertex, Buffer: entries[0].Buffer}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroupLayout c: %v", err)
+	}
+	defer other.Release()
+	if other.TestPooled() == bgl1.TestPooled() {
+		t.Error("layouts with different entries should not share the pooled layout")
+	}
+
+	// Releasing one handle leaves the shared layout usable through the other.
+	bgl1.Release()
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: 16, Usage: wgpu.BufferUsageUniform})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+	bg, err := device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Layout:  bgl2,
+		Entries: []wgpu.BindGroupEntry{{Binding: 0, Buffer: buf, Size: 16}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroup after releasing the twin layout: %v", err)
+	}
+	bg.Release()
 }
 
 // =============================================================================
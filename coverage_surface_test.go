@@ -4,6 +4,7 @@ package wgpu_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gogpu/wgpu"
 	"github.com/gogpu/wgpu/hal/noop"
@@ -122,3 +123,57 @@ func TestDiscardTextureUnconfigured(t *testing.T) {
 	// Must not panic when no texture has been acquired.
 	surface.DiscardTexture()
 }
+
+// =============================================================================
+// Surface.SetMaximumDrawableCount / SetDisplayLink — Metal-only extensions
+// =============================================================================
+
+type mockPacedSurface struct {
+	noop.Surface
+	drawables uint32
+	onFrame   func(time.Duration)
+}
+
+func (m *mockPacedSurface) SetMaximumDrawableCount(count uint32) { m.drawables = count }
+
+func (m *mockPacedSurface) SetDisplayLink(onFrame func(time.Duration)) error {
+	m.onFrame = onFrame
+	return nil
+}
+
+func TestSetDisplayLinkDelegates(t *testing.T) {
+	mock := &mockPacedSurface{}
+	surface := wgpu.NewSurfaceFromHAL(mock, "paced")
+	defer surface.Release()
+
+	surface.SetMaximumDrawableCount(2)
+	if mock.drawables != 2 {
+		t.Errorf("drawables = %d, want 2", mock.drawables)
+	}
+	var ticks int
+	if err := surface.SetDisplayLink(func(time.Duration) { ticks++ }); err != nil {
+		t.Fatalf("SetDisplayLink: %v", err)
+	}
+	mock.onFrame(16 * time.Millisecond)
+	if ticks != 1 {
+		t.Errorf("ticks = %d, want 1", ticks)
+	}
+	if err := surface.SetDisplayLink(nil); err != nil || mock.onFrame != nil {
+		t.Errorf("SetDisplayLink(nil) = %v, callback cleared %v", err, mock.onFrame == nil)
+	}
+}
+
+func TestSetDisplayLinkUnsupported(t *testing.T) {
+	surface := wgpu.NewSurfaceFromHAL(&noop.Surface{}, "noop")
+	surface.SetMaximumDrawableCount(2) // no-op
+	if err := surface.SetDisplayLink(func(time.Duration) {}); err == nil {
+		t.Error("SetDisplayLink on a noop surface succeeded")
+	}
+	if err := surface.SetDisplayLink(nil); err != nil {
+		t.Errorf("SetDisplayLink(nil) on a noop surface: %v", err)
+	}
+	surface.Release()
+	if err := surface.SetDisplayLink(func(time.Duration) {}); err == nil {
+		t.Error("SetDisplayLink on a released surface succeeded")
+	}
+}
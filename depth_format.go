@@ -0,0 +1,21 @@
+package wgpu
+
+// DepthStencilRequirements describes the depth/stencil format a caller needs
+// for Adapter.GetPreferredDepthStencilFormat.
+type DepthStencilRequirements struct {
+	// Stencil requires a stencil aspect.
+	Stencil bool
+
+	// MinDepthBits is the minimum depth precision in bits (16, 24 or 32).
+	// Zero accepts any precision.
+	MinDepthBits uint32
+
+	// Usage is the texture usage the format must support besides
+	// RenderAttachment, which is always required. Set TextureBinding for
+	// depth textures that shaders sample, such as shadow maps.
+	Usage TextureUsage
+
+	// SampleCount is the sample count the format must support. Zero and
+	// one mean single-sampled.
+	SampleCount uint32
+}
@@ -0,0 +1,27 @@
+//go:build rust || (js && wasm)
+
+package wgpu
+
+import "github.com/gogpu/gputypes"
+
+// webgpuDepthStencilFormat picks a depth format for backends that cannot
+// query per-format support. Every WebGPU implementation supports
+// Depth16Unorm, Depth24Plus, Depth24PlusStencil8 and Depth32Float as
+// single- and 4x-sampled, sampleable render attachments;
+// Depth32FloatStencil8 needs FeatureDepth32FloatStencil8.
+func webgpuDepthStencilFormat(req DepthStencilRequirements, features Features) TextureFormat {
+	if req.SampleCount > 1 && req.SampleCount != 4 {
+		return TextureFormatUndefined
+	}
+	switch {
+	case req.MinDepthBits <= 24 && req.Stencil:
+		return TextureFormatDepth24PlusStencil8
+	case req.MinDepthBits <= 24:
+		return TextureFormatDepth24Plus
+	case !req.Stencil:
+		return TextureFormatDepth32Float
+	case features.Contains(gputypes.FeatureDepth32FloatStencil8):
+		return TextureFormatDepth32FloatStencil8
+	}
+	return TextureFormatUndefined
+}
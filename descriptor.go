@@ -42,9 +42,13 @@ func (l ImageDataLayout) toHAL() hal.ImageDataLayout {
 
 // BufferDescriptor describes buffer creation parameters.
 type BufferDescriptor struct {
-	Label            string
-	Size             uint64
-	Usage            BufferUsage
+	Label string
+	Size  uint64
+	Usage BufferUsage
+	// MappedAtCreation creates the buffer in the mapped state, so its
+	// contents can be written through MappedRange before Unmap. Any usage
+	// may be combined with it; buffers without BufferUsageMapWrite keep
+	// the memory their usage calls for and are filled on Unmap.
 	MappedAtCreation bool
 }
 
@@ -67,7 +71,29 @@ type TextureDescriptor struct {
 	Dimension     TextureDimension
 	Format        TextureFormat
 	Usage         TextureUsage
-	ViewFormats   []TextureFormat
+	// ViewFormats lists the other formats views of the texture may use.
+	// Each must be Format's sRGB counterpart (RGBA8UnormSrgb for
+	// RGBA8Unorm and vice versa), so a linear texture can be rendered
+	// through a gamma-encoding view and an sRGB one read back raw.
+	// CreateTextureView rejects any other view format. The GLES and
+	// software backends view the texture in Format regardless.
+	ViewFormats []TextureFormat
+	// Transient marks a render attachment that is cleared at the start of
+	// each pass and discarded at the end, such as an MSAA color target or a
+	// depth buffer. Usage must be exactly TextureUsageRenderAttachment. On
+	// tile-based GPUs (Metal on iOS, tvOS and Apple Silicon; Vulkan on Android
+	// and MoltenVK) it uses no device memory.
+	Transient bool
+	// Protected places the texture in protected memory for DRM content,
+	// such as the target of a protected video decoder. Only protected
+	// command encoders may access it, and the host cannot write or read it
+	// back. Requires FeatureProtectedContent.
+	Protected bool
+	// TextureBindingViewDimension is the view dimension the texture will be
+	// bound with in shaders. The GL backend needs it to create cube and cube
+	// array textures; other backends accept any compatible view. Leave it
+	// undefined for 2D and 2D array textures.
+	TextureBindingViewDimension TextureViewDimension
 }
 
 // toHAL converts a TextureDescriptor to a hal.TextureDescriptor.
@@ -81,6 +107,10 @@ func (d *TextureDescriptor) toHAL() *hal.TextureDescriptor {
 		Format:        d.Format,
 		Usage:         d.Usage,
 		ViewFormats:   d.ViewFormats,
+		Transient:     d.Transient,
+		Protected:     d.Protected,
+
+		TextureBindingViewDimension: d.TextureBindingViewDimension,
 	}
 }
 
@@ -121,8 +151,11 @@ type SamplerDescriptor struct {
 	MipmapFilter FilterMode
 	LodMinClamp  float32
 	LodMaxClamp  float32
-	Compare      CompareFunction
-	Anisotropy   uint16
+	// LodBias offsets the mip level each sample selects. Metal and
+	// OpenGL ES ignore it.
+	LodBias    float32
+	Compare    CompareFunction
+	Anisotropy uint16
 }
 
 // toHAL converts a SamplerDescriptor to a hal.SamplerDescriptor.
@@ -137,38 +170,85 @@ func (d *SamplerDescriptor) toHAL() *hal.SamplerDescriptor {
 		MipmapFilter: d.MipmapFilter,
 		LodMinClamp:  d.LodMinClamp,
 		LodMaxClamp:  d.LodMaxClamp,
+		LodBias:      d.LodBias,
 		Compare:      d.Compare,
 		Anisotropy:   d.Anisotropy,
 	}
 }
 
 // ShaderModuleDescriptor describes shader module creation parameters.
+// Exactly one of WGSL, SPIRV and GLSL must be set.
+//
+// The Vulkan and software backends consume SPIR-V directly. naga has no
+// SPIR-V or GLSL frontend to translate them for the other backends, which
+// reject them with a *core.CreateShaderModuleError of kind
+// UnsupportedSource; GLSL is rejected on every backend. The Rust backend
+// (-tags rust) accepts both, translated by wgpu-native.
 type ShaderModuleDescriptor struct {
 	Label string
 	WGSL  string   // WGSL source code
 	SPIRV []uint32 // SPIR-V bytecode (alternative to WGSL)
+
+	// GLSL is GLSL 4.50 source for a single stage, GLSLStage, with its
+	// entry point named main (alternative to WGSL).
+	GLSL      string
+	GLSLStage ShaderStages
+
+	// CompilationHints name entry points to compile ahead of pipeline
+	// creation, with the layouts they will be used with.
+	CompilationHints []ShaderModuleCompilationHint
+}
+
+// ShaderModuleCompilationHint tells the device that pipelines will use an
+// entry point with a layout, so it can compile the entry point when the
+// module is created instead of stalling the first pipeline creation. The
+// DX12 backend compiles the module with the first hint's layout, and the
+// Vulkan and Metal backends build hinted compute pipelines up front.
+// Matches WebGPU GPUShaderModuleCompilationHint.
+type ShaderModuleCompilationHint struct {
+	EntryPoint string
+	// Layout is the layout pipelines will use, or nil for an automatic
+	// layout.
+	Layout *PipelineLayout
 }
 
 // toHAL converts a ShaderModuleDescriptor to a hal.ShaderModuleDescriptor.
 func (d *ShaderModuleDescriptor) toHAL() *hal.ShaderModuleDescriptor {
-	return &hal.ShaderModuleDescriptor{
+	halDesc := &hal.ShaderModuleDescriptor{
 		Label: d.Label,
 		Source: hal.ShaderSource{
 			WGSL:  d.WGSL,
 			SPIRV: d.SPIRV,
 		},
 	}
+	if len(d.CompilationHints) > 0 {
+		halDesc.CompilationHints = make([]hal.ShaderCompilationHint, len(d.CompilationHints))
+		for i, hint := range d.CompilationHints {
+			halDesc.CompilationHints[i].EntryPoint = hint.EntryPoint
+			if hint.Layout != nil {
+				halDesc.CompilationHints[i].Layout = hint.Layout.hal
+			}
+		}
+	}
+	return halDesc
 }
 
 // CommandEncoderDescriptor describes command encoder creation.
 type CommandEncoderDescriptor struct {
 	Label string
+
+	// Protected records a protected command buffer, which may read and
+	// render to protected textures and surfaces but must not write to
+	// unprotected resources. Protected and unprotected command buffers
+	// cannot be submitted together. Requires FeatureProtectedContent.
+	Protected bool
 }
 
 // toHAL converts a CommandEncoderDescriptor to a hal.CommandEncoderDescriptor.
 func (d *CommandEncoderDescriptor) toHAL() *hal.CommandEncoderDescriptor {
 	return &hal.CommandEncoderDescriptor{
-		Label: d.Label,
+		Label:     d.Label,
+		Protected: d.Protected,
 	}
 }
 
@@ -309,9 +389,14 @@ type RenderPipelineDescriptor struct {
 	Layout       *PipelineLayout
 	Vertex       VertexState
 	Primitive    PrimitiveState
+	PolygonMode  PolygonMode // non-fill modes need FeaturePolygonModeLine/Point
 	DepthStencil *DepthStencilState
 	Multisample  MultisampleState
 	Fragment     *FragmentState
+	// ViewCount is the number of multiview views the pipeline renders,
+	// matching the RenderPassDescriptor it is used with. Above 1 it needs
+	// FeatureMultiview; 0 means 1.
+	ViewCount uint32
 }
 
 // VertexState describes the vertex shader stage.
@@ -333,8 +418,10 @@ func (d *RenderPipelineDescriptor) toHAL() *hal.RenderPipelineDescriptor {
 	halDesc := &hal.RenderPipelineDescriptor{
 		Label:        d.Label,
 		Primitive:    d.Primitive,
+		PolygonMode:  hal.PolygonMode(d.PolygonMode),
 		Multisample:  d.Multisample,
 		DepthStencil: d.DepthStencil.toHAL(),
+		ViewCount:    d.ViewCount,
 	}
 
 	if d.Layout != nil {
@@ -420,6 +507,14 @@ type RenderPassDescriptor struct {
 	Label                  string
 	ColorAttachments       []RenderPassColorAttachment
 	DepthStencilAttachment *RenderPassDepthStencilAttachment
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
+	// ViewCount renders every draw once per view into consecutive array
+	// layers, for stereo and cubemap rendering in one pass. Above 1 it needs
+	// FeatureMultiview, and every attachment must be a 2D array view with
+	// exactly ViewCount layers. Shaders read the view from the view_index
+	// built-in. 0 means 1.
+	ViewCount uint32
 }
 
 // RenderPassColorAttachment describes a color attachment.
@@ -447,7 +542,8 @@ type RenderPassDepthStencilAttachment struct {
 // toHAL converts a RenderPassDescriptor to a hal.RenderPassDescriptor.
 func (d *RenderPassDescriptor) toHAL() *hal.RenderPassDescriptor {
 	halDesc := &hal.RenderPassDescriptor{
-		Label: d.Label,
+		Label:     d.Label,
+		ViewCount: d.ViewCount,
 	}
 
 	for _, ca := range d.ColorAttachments {
@@ -483,22 +579,46 @@ func (d *RenderPassDescriptor) toHAL() *hal.RenderPassDescriptor {
 		halDesc.DepthStencilAttachment = halDS
 	}
 
+	if tw := d.TimestampWrites; tw != nil && tw.QuerySet != nil {
+		halDesc.TimestampWrites = &hal.RenderPassTimestampWrites{
+			QuerySet:                  tw.QuerySet.hal,
+			BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+			EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+		}
+	}
+
 	return halDesc
 }
 
 // ComputePassDescriptor describes a compute pass.
 type ComputePassDescriptor struct {
 	Label string
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
 }
 
 // toHAL converts a ComputePassDescriptor to a hal.ComputePassDescriptor.
 func (d *ComputePassDescriptor) toHAL() *hal.ComputePassDescriptor {
-	return &hal.ComputePassDescriptor{
+	halDesc := &hal.ComputePassDescriptor{
 		Label: d.Label,
 	}
+	if tw := d.TimestampWrites; tw != nil && tw.QuerySet != nil {
+		halDesc.TimestampWrites = &hal.ComputePassTimestampWrites{
+			QuerySet:                  tw.QuerySet.hal,
+			BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+			EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+		}
+	}
+	return halDesc
 }
 
 // SurfaceConfiguration describes surface settings.
+//
+// For gamma-correct output pick the sRGB variant of the preferred format,
+// such as BGRA8UnormSrgb: shaders write linear color and the hardware
+// encodes it on store. A Unorm format presents the shader output unchanged.
+// DX12 flip-model swapchains cannot hold sRGB buffers, so there an sRGB
+// surface renders through sRGB views of linear buffers with the same result.
 type SurfaceConfiguration struct {
 	Width       uint32
 	Height      uint32
@@ -506,17 +626,42 @@ type SurfaceConfiguration struct {
 	Usage       TextureUsage
 	PresentMode PresentMode
 	AlphaMode   CompositeAlphaMode
+
+	// PreTransform declares that rendering is already transformed for the
+	// display (pre-rotation), normally with the CurrentTransform from
+	// SurfaceCapabilities and PreRotationMatrix. Width and Height stay in the
+	// window's orientation; size attachments from the surface texture, which
+	// is in the display's native orientation. Zero lets the compositor
+	// rotate. Non-identity transforms require Vulkan.
+	PreTransform SurfaceTransform
+
+	// Protected presents from protected memory so DRM video can be
+	// composited. Requires FeatureProtectedContent and
+	// SurfaceCapabilities.Protected; only protected command encoders may
+	// render to the surface textures.
+	Protected bool
+
+	// ScaleFactor is the window's scale factor, the ratio of pixels to
+	// logical units. Width and Height are in pixels; convert a logical
+	// window size with PhysicalSize. Metal and the software backend on macOS
+	// set the layer's contents scale from it, so the surface fills the
+	// window on HiDPI displays. Zero means unknown and keeps the platform's
+	// scale.
+	ScaleFactor float64
 }
 
 // toHAL converts a SurfaceConfiguration to a hal.SurfaceConfiguration.
 func (c *SurfaceConfiguration) toHAL() *hal.SurfaceConfiguration {
 	return &hal.SurfaceConfiguration{
-		Width:       c.Width,
-		Height:      c.Height,
-		Format:      c.Format,
-		Usage:       c.Usage,
-		PresentMode: c.PresentMode,
-		AlphaMode:   c.AlphaMode,
+		Width:        c.Width,
+		Height:       c.Height,
+		Format:       c.Format,
+		Usage:        c.Usage,
+		PresentMode:  c.PresentMode,
+		AlphaMode:    c.AlphaMode,
+		PreTransform: hal.SurfaceTransform(c.PreTransform),
+		Protected:    c.Protected,
+		ScaleFactor:  c.ScaleFactor,
 	}
 }
 
@@ -43,6 +43,22 @@ type TextureDescriptor struct {
 	Format        TextureFormat
 	Usage         TextureUsage
 	ViewFormats   []TextureFormat
+	// Transient marks a render attachment that is cleared at the start of
+	// each pass and discarded at the end, such as an MSAA color target or a
+	// depth buffer. Usage must be exactly TextureUsageRenderAttachment. On
+	// tile-based GPUs (Metal on iOS, tvOS and Apple Silicon) it uses no device
+	// memory.
+	// Browsers ignore it and allocate the texture normally.
+	Transient bool
+	// Protected requires FeatureProtectedContent, which this backend never
+	// reports; setting it is an error.
+	Protected bool
+	// TextureBindingViewDimension is the view dimension the texture will be
+	// bound with in shaders. The GL backend needs it to create cube and cube
+	// array textures; other backends accept any compatible view. Leave it
+	// undefined for 2D and 2D array textures.
+	// Browsers ignore it.
+	TextureBindingViewDimension TextureViewDimension
 }
 
 // TextureViewDescriptor describes texture view creation parameters.
@@ -73,15 +89,38 @@ type SamplerDescriptor struct {
 }
 
 // ShaderModuleDescriptor describes shader module creation parameters.
+// Browsers accept only WGSL; CreateShaderModule rejects SPIRV and GLSL.
 type ShaderModuleDescriptor struct {
 	Label string
 	WGSL  string   // WGSL source code
 	SPIRV []uint32 // SPIR-V bytecode (alternative to WGSL)
+
+	// GLSL is GLSL 4.50 source for a single stage, GLSLStage, with its
+	// entry point named main (alternative to WGSL).
+	GLSL      string
+	GLSLStage ShaderStages
+
+	// CompilationHints name entry points to compile ahead of pipeline
+	// creation, with the layouts they will be used with.
+	CompilationHints []ShaderModuleCompilationHint
+}
+
+// ShaderModuleCompilationHint tells the browser that pipelines will use an
+// entry point with a layout, so it can compile the entry point when the
+// module is created. It is passed on as GPUShaderModuleCompilationHint.
+type ShaderModuleCompilationHint struct {
+	EntryPoint string
+	// Layout is the layout pipelines will use, or nil for an automatic
+	// layout.
+	Layout *PipelineLayout
 }
 
 // CommandEncoderDescriptor describes command encoder creation.
 type CommandEncoderDescriptor struct {
 	Label string
+	// Protected requires FeatureProtectedContent, which this backend never
+	// reports; setting it is an error.
+	Protected bool
 }
 
 // BindGroupLayoutDescriptor describes a bind group layout.
@@ -157,9 +196,14 @@ type RenderPipelineDescriptor struct {
 	Layout       *PipelineLayout
 	Vertex       VertexState
 	Primitive    PrimitiveState
+	PolygonMode  PolygonMode // non-fill modes need FeaturePolygonModeLine/Point
 	DepthStencil *DepthStencilState
 	Multisample  MultisampleState
 	Fragment     *FragmentState
+	// ViewCount is the number of multiview views the pipeline renders,
+	// matching the RenderPassDescriptor it is used with. Above 1 it needs
+	// FeatureMultiview; 0 means 1.
+	ViewCount uint32
 }
 
 // VertexState describes the vertex shader stage.
@@ -197,6 +241,14 @@ type RenderPassDescriptor struct {
 	Label                  string
 	ColorAttachments       []RenderPassColorAttachment
 	DepthStencilAttachment *RenderPassDepthStencilAttachment
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
+	// ViewCount renders every draw once per view into consecutive array
+	// layers, for stereo and cubemap rendering in one pass. Above 1 it needs
+	// FeatureMultiview, and every attachment must be a 2D array view with
+	// exactly ViewCount layers. Shaders read the view from the view_index
+	// built-in. 0 means 1.
+	ViewCount uint32
 }
 
 // RenderPassColorAttachment describes a color attachment.
@@ -224,6 +276,8 @@ type RenderPassDepthStencilAttachment struct {
 // ComputePassDescriptor describes a compute pass.
 type ComputePassDescriptor struct {
 	Label string
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
 }
 
 // SurfaceConfiguration describes surface settings.
@@ -234,6 +288,14 @@ type SurfaceConfiguration struct {
 	Usage       TextureUsage
 	PresentMode PresentMode
 	AlphaMode   CompositeAlphaMode
+	// PreTransform must be zero or identity; the browser orients the canvas.
+	PreTransform SurfaceTransform
+	// Protected must be false; the browser has no protected surfaces.
+	Protected bool
+	// ScaleFactor is the canvas's devicePixelRatio. Width and Height are the
+	// canvas size in device pixels; the browser scales the canvas, so it is
+	// only validated.
+	ScaleFactor float64
 }
 
 // ImageCopyTexture describes a texture subresource and origin for write operations.
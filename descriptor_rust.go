@@ -41,6 +41,22 @@ type TextureDescriptor struct {
 	Format        TextureFormat
 	Usage         TextureUsage
 	ViewFormats   []TextureFormat
+	// Transient marks a render attachment that is cleared at the start of
+	// each pass and discarded at the end, such as an MSAA color target or a
+	// depth buffer. Usage must be exactly TextureUsageRenderAttachment. On
+	// tile-based GPUs (Metal on iOS, tvOS and Apple Silicon) it uses no device
+	// memory.
+	// The rust backend ignores it and allocates the texture normally.
+	Transient bool
+	// Protected requires FeatureProtectedContent, which this backend never
+	// reports; setting it is an error.
+	Protected bool
+	// TextureBindingViewDimension is the view dimension the texture will be
+	// bound with in shaders. The GL backend needs it to create cube and cube
+	// array textures; other backends accept any compatible view. Leave it
+	// undefined for 2D and 2D array textures.
+	// The rust backend ignores it.
+	TextureBindingViewDimension TextureViewDimension
 }
 
 // TextureViewDescriptor describes texture view creation parameters.
@@ -71,20 +87,46 @@ type SamplerDescriptor struct {
 }
 
 // ShaderModuleDescriptor describes shader module creation parameters.
+// Exactly one of WGSL, SPIRV and GLSL must be set. wgpu-native translates
+// SPIR-V and GLSL with naga for backends that cannot consume them.
 type ShaderModuleDescriptor struct {
 	Label string
 	WGSL  string   // WGSL source code
 	SPIRV []uint32 // SPIR-V bytecode (alternative to WGSL)
+
+	// GLSL is GLSL 4.50 source for a single stage, GLSLStage, with its
+	// entry point named main (alternative to WGSL).
+	GLSL      string
+	GLSLStage ShaderStages
+
+	// CompilationHints name entry points to compile ahead of pipeline
+	// creation, with the layouts they will be used with.
+	CompilationHints []ShaderModuleCompilationHint
+}
+
+// ShaderModuleCompilationHint names an entry point and the layout pipelines
+// will use it with. wgpu-native has no compilation hints, so the Rust
+// backend ignores them.
+type ShaderModuleCompilationHint struct {
+	EntryPoint string
+	// Layout is the layout pipelines will use, or nil for an automatic
+	// layout.
+	Layout *PipelineLayout
 }
 
 // CommandEncoderDescriptor describes command encoder creation.
 type CommandEncoderDescriptor struct {
 	Label string
+	// Protected requires FeatureProtectedContent, which this backend never
+	// reports; setting it is an error.
+	Protected bool
 }
 
 // ComputePassDescriptor describes compute pass creation.
 type ComputePassDescriptor struct {
 	Label string
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
 }
 
 // SurfaceConfiguration configures surface presentation.
@@ -95,6 +137,15 @@ type SurfaceConfiguration struct {
 	Usage       TextureUsage
 	PresentMode PresentMode
 	AlphaMode   CompositeAlphaMode
+	// PreTransform must be zero or identity; wgpu-native leaves rotation to
+	// the compositor.
+	PreTransform SurfaceTransform
+	// Protected must be false; wgpu-native has no protected surfaces.
+	Protected bool
+	// ScaleFactor is the window's scale factor. Width and Height are in
+	// pixels; wgpu-native reads the layer scale itself, so it is only
+	// validated.
+	ScaleFactor float64
 }
 
 // StencilOperation describes a stencil operation.
@@ -139,6 +190,14 @@ type RenderPassDescriptor struct {
 	Label                  string
 	ColorAttachments       []RenderPassColorAttachment
 	DepthStencilAttachment *RenderPassDepthStencilAttachment
+	// TimestampWrites records GPU timestamps at pass start and end (optional).
+	TimestampWrites *PassTimestampWrites
+	// ViewCount renders every draw once per view into consecutive array
+	// layers, for stereo and cubemap rendering in one pass. Above 1 it needs
+	// FeatureMultiview, and every attachment must be a 2D array view with
+	// exactly ViewCount layers. Shaders read the view from the view_index
+	// built-in. 0 means 1.
+	ViewCount uint32
 }
 
 // RenderPassColorAttachment describes a color attachment for a render pass.
@@ -198,9 +257,14 @@ type RenderPipelineDescriptor struct {
 	Layout       *PipelineLayout
 	Vertex       VertexState
 	Primitive    PrimitiveState
+	PolygonMode  PolygonMode // non-fill modes need FeaturePolygonModeLine/Point
 	DepthStencil *DepthStencilState
 	Multisample  MultisampleState
 	Fragment     *FragmentState
+	// ViewCount is the number of multiview views the pipeline renders,
+	// matching the RenderPassDescriptor it is used with. Above 1 it needs
+	// FeatureMultiview; 0 means 1.
+	ViewCount uint32
 }
 
 // VertexState describes the vertex shader stage.
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/noop"
 )
 
 func TestBufferDescriptorToHAL(t *testing.T) {
@@ -166,6 +169,44 @@ func TestShaderModuleDescriptorToHAL(t *testing.T) {
 			t.Errorf("Source.SPIRV length = %d, want %d", len(halDesc.Source.SPIRV), len(spirv))
 		}
 	})
+
+	t.Run("compilation hints", func(t *testing.T) {
+		layout := &PipelineLayout{hal: &noop.Resource{}}
+		desc := ShaderModuleDescriptor{
+			WGSL: "@compute @workgroup_size(1) fn cs() {}",
+			CompilationHints: []ShaderModuleCompilationHint{
+				{EntryPoint: "cs", Layout: layout},
+				{EntryPoint: "auto"},
+			},
+		}
+		halDesc := desc.toHAL()
+		if len(halDesc.CompilationHints) != 2 {
+			t.Fatalf("CompilationHints length = %d, want 2", len(halDesc.CompilationHints))
+		}
+		if got := halDesc.CompilationHints[0]; got.EntryPoint != "cs" || got.Layout != layout.hal || got.Stage != 0 {
+			t.Errorf("hint 0 = %+v, want entry point cs with the layout and no stage", got)
+		}
+		if got := halDesc.CompilationHints[1]; got.EntryPoint != "auto" || got.Layout != nil {
+			t.Errorf("hint 1 = %+v, want entry point auto without layout", got)
+		}
+	})
+}
+
+func TestSetHintStages(t *testing.T) {
+	module := &ir.Module{EntryPoints: []ir.EntryPoint{
+		{Name: "vs", Stage: ir.StageVertex},
+		{Name: "fs", Stage: ir.StageFragment},
+		{Name: "cs", Stage: ir.StageCompute},
+	}}
+	hints := []hal.ShaderCompilationHint{{EntryPoint: "cs"}, {EntryPoint: "fs"}, {EntryPoint: "vs"}, {EntryPoint: "missing"}}
+	setHintStages(hints, module)
+
+	want := []ShaderStages{ShaderStageCompute, ShaderStageFragment, ShaderStageVertex, 0}
+	for i, hint := range hints {
+		if hint.Stage != want[i] {
+			t.Errorf("hint %q stage = %v, want %v", hint.EntryPoint, hint.Stage, want[i])
+		}
+	}
 }
 
 func TestCommandEncoderDescriptorToHAL(t *testing.T) {
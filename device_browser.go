@@ -3,6 +3,8 @@
 package wgpu
 
 import (
+	"context"
+	"fmt"
 	"syscall/js"
 	"time"
 
@@ -17,6 +19,15 @@ type Device struct {
 	features Features
 	limits   Limits
 	released bool
+
+	// frame accumulates the counters FrameStatistics reports.
+	frame frameCounters
+
+	// readbacks recycles the staging buffers of Queue.ReadBufferAsync.
+	readbacks readbackPool
+
+	// callbacks holds async completion callbacks until Poll runs them.
+	callbacks callbackQueue
 }
 
 // Queue returns the device's command queue.
@@ -24,6 +35,30 @@ func (d *Device) Queue() *Queue {
 	return d.queue
 }
 
+// FrameStatistics returns the draws, dispatches, pipeline binds, and buffer
+// upload bytes recorded since the previous call, and resets the counters.
+// Call it once per frame. Barriers is always 0: the browser inserts its own barriers.
+func (d *Device) FrameStatistics() FrameStatistics {
+	return d.frame.take()
+}
+
+// Trim returns an empty TrimReport: the browser manages GPU memory itself.
+func (d *Device) Trim() TrimReport {
+	return TrimReport{}
+}
+
+// MemoryBudget returns false: the browser does not expose memory budgets.
+func (d *Device) MemoryBudget() (MemoryBudget, bool) {
+	return MemoryBudget{}, false
+}
+
+// SetMemoryPressureCallback does nothing: without a memory budget the
+// callback would never be called.
+func (d *Device) SetMemoryPressureCallback(callback func(MemoryPressure)) {}
+
+// SetTrimOnMemoryPressure does nothing: Trim releases nothing here.
+func (d *Device) SetTrimOnMemoryPressure(enabled bool) {}
+
 // Features returns the device's enabled features.
 func (d *Device) Features() Features {
 	return d.features
@@ -58,6 +93,7 @@ func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
 
 	return &Buffer{
 		browser:  bb,
+		device:   d,
 		size:     desc.Size,
 		usage:    desc.Usage,
 		released: false,
@@ -65,11 +101,48 @@ func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
 	}, nil
 }
 
+// ReloadShaderModule is not supported by the browser: GPURenderPipeline and
+// GPUComputePipeline objects cannot be rebuilt behind their handles.
+// It always returns ErrShaderReloadNotSupported.
+func (d *Device) ReloadShaderModule(_ *ShaderModule, _ string) error {
+	return ErrShaderReloadNotSupported
+}
+
+// ImportTexture is not supported by the browser.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ImportTexture(_ *NativeTexture, _ *TextureDescriptor) (*Texture, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// CreateSharedFence is not supported by the browser.
+// It always returns ErrExternalNotSupported.
+func (d *Device) CreateSharedFence() (*Fence, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// ExportFence is not supported by the browser.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ExportFence(_ *Fence) (*NativeFence, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// ImportFence is not supported by the browser.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ImportFence(_ *NativeFence) (*Fence, error) {
+	return nil, ErrExternalNotSupported
+}
+
 // CreateTexture creates a GPU texture from the given descriptor.
 func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	if d.released {
 		return nil, ErrReleased
 	}
+	if err := validateProtected("texture", desc.Protected, d.features); err != nil {
+		return nil, err
+	}
+	if err := validatePlanarFormat(desc.Format, d.features); err != nil {
+		return nil, err
+	}
 	jsDesc := browser.BuildTextureDescriptor(
 		desc.Label,
 		desc.Size.Width, desc.Size.Height, desc.Size.DepthOrArrayLayers,
@@ -80,11 +153,29 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	bt := d.browser.CreateTextureFromDesc(jsDesc)
 	return &Texture{
 		browser:  bt,
+		device:   d,
 		format:   desc.Format,
 		released: false,
 	}, nil
 }
 
+// CreateTextures creates a texture for each descriptor, in order, with one
+// CreateTexture call each. On error no texture is left behind.
+func (d *Device) CreateTextures(descs []*TextureDescriptor) ([]*Texture, error) {
+	textures := make([]*Texture, 0, len(descs))
+	for i, desc := range descs {
+		texture, err := d.CreateTexture(desc)
+		if err != nil {
+			for _, t := range textures {
+				t.Release()
+			}
+			return nil, fmt.Errorf("wgpu: texture %d: %w", i, err)
+		}
+		textures = append(textures, texture)
+	}
+	return textures, nil
+}
+
 // CreateTextureView creates a view into a texture.
 func (d *Device) CreateTextureView(texture *Texture, desc *TextureViewDescriptor) (*TextureView, error) {
 	if d.released {
@@ -126,6 +217,28 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
 	}, nil
 }
 
+// CreateQuerySet creates a set of occlusion or timestamp queries.
+// Timestamp query sets require gputypes.FeatureTimestampQuery on the device.
+func (d *Device) CreateQuerySet(desc *QuerySetDescriptor) (*QuerySet, error) {
+	if d.released {
+		return nil, ErrReleased
+	}
+	if err := validateQuerySetDescriptor(desc, d.features); err != nil {
+		return nil, err
+	}
+	if desc.Type == QueryTypePipelineStatistics {
+		return nil, fmt.Errorf("wgpu: pipeline statistics queries are not supported on the browser backend: %w", ErrFeatureNotSupported)
+	}
+	jsDesc := browser.BuildQuerySetDescriptor(desc.Label, desc.Type.String(), desc.Count)
+	bq := d.browser.CreateQuerySetFromDesc(jsDesc)
+	return &QuerySet{
+		browser:   bq,
+		label:     desc.Label,
+		queryType: desc.Type,
+		count:     desc.Count,
+	}, nil
+}
+
 // CreateShaderModule creates a shader module from the given descriptor.
 // On browser, WGSL code goes directly to the browser's createShaderModule.
 // SPIR-V bytecode is not supported in the browser (browser only accepts WGSL).
@@ -133,7 +246,21 @@ func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule
 	if d.released {
 		return nil, ErrReleased
 	}
-	jsDesc := browser.BuildShaderModuleDescriptor(desc.Label, desc.WGSL)
+	if desc == nil {
+		return nil, fmt.Errorf("wgpu: shader module descriptor is nil")
+	}
+	if desc.WGSL == "" {
+		return nil, fmt.Errorf("wgpu: shader module %q: browser WebGPU accepts only WGSL source", desc.Label)
+	}
+	var hints []browser.ShaderCompilationHintJS
+	for _, hint := range desc.CompilationHints {
+		ref := js.Undefined()
+		if hint.Layout != nil {
+			ref = hint.Layout.browser.Ref()
+		}
+		hints = append(hints, browser.ShaderCompilationHintJS{EntryPoint: hint.EntryPoint, LayoutRef: ref})
+	}
+	jsDesc := browser.BuildShaderModuleDescriptor(desc.Label, desc.WGSL, hints)
 	bm := d.browser.CreateShaderModuleFromDesc(jsDesc)
 	return &ShaderModule{
 		browser:  bm,
@@ -201,6 +328,15 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 	if d.released {
 		return nil, ErrReleased
 	}
+	if desc == nil {
+		return nil, fmt.Errorf("wgpu: render pipeline descriptor is nil")
+	}
+	if err := validatePolygonMode(desc.PolygonMode, d.features); err != nil {
+		return nil, err
+	}
+	if err := validateViewCount(desc.ViewCount, d.features, 0); err != nil {
+		return nil, err
+	}
 	jsDesc := convertRenderPipelineDescriptor(desc)
 	bp := d.browser.CreateRenderPipelineFromDesc(jsDesc)
 	return &RenderPipeline{
@@ -244,6 +380,9 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 	label := ""
 	if desc != nil {
 		label = desc.Label
+		if err := validateProtected("command encoder", desc.Protected, d.features); err != nil {
+			return nil, err
+		}
 	}
 	jsDesc := browser.BuildCommandEncoderDescriptor(label)
 	jsEncoder := d.browser.CreateCommandEncoder().Invoke(jsDesc)
@@ -251,6 +390,7 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 	return &CommandEncoder{
 		browser:  be,
 		released: false,
+		counters: &d.frame,
 	}, nil
 }
 
@@ -282,6 +422,22 @@ func (d *Device) WaitForFence(_ *Fence, _ uint64, _ time.Duration) (bool, error)
 	return true, nil
 }
 
+// WaitForFenceContext returns immediately on browser like WaitForFence,
+// unless ctx has already ended: then the error wraps ErrWaitCanceled and
+// ctx.Err().
+func (d *Device) WaitForFenceContext(ctx context.Context, _ *Fence, _ uint64) error {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("wgpu: WaitForFenceContext: %w: %w", ErrWaitCanceled, err)
+		}
+	}
+	return nil
+}
+
+// SetUncapturedErrorCallback is accepted for API compatibility but has no
+// effect: the browser reports uncaptured errors on its own console.
+func (d *Device) SetUncapturedErrorCallback(_ func(*GPUError)) {}
+
 // PushErrorScope pushes a new error scope onto the device's error scope stack.
 // Phase 2 — not yet implemented for browser.
 func (d *Device) PushErrorScope(filter ErrorFilter) {
@@ -301,12 +457,22 @@ func (d *Device) WaitIdle() error {
 }
 
 // Poll drives the per-device pending-map triage loop.
-// On browser, the GPU is polled automatically by the browser event loop.
+// On browser, the GPU is polled automatically by the browser event loop;
+// Poll only runs the callbacks of finished MapAsyncCallback and
+// OnSubmittedWorkDoneCallback operations on the calling goroutine.
 // Returns true (devices are always considered polled in browser).
 func (d *Device) Poll(pollType PollType) bool {
+	d.callbacks.run()
 	return true
 }
 
+// Tick runs the callbacks of finished MapAsyncCallback and
+// OnSubmittedWorkDoneCallback operations; the browser runs its own WebGPU
+// callbacks on its event loop.
+func (d *Device) Tick() {
+	d.Poll(PollPoll)
+}
+
 // FreeCommandBuffer is a no-op on browser — JS GC handles GPU resource cleanup.
 // This exists for API compatibility with the native backend where command buffers
 // must be explicitly freed after GPU submission completes.
@@ -317,12 +483,17 @@ func (d *Device) FreeCommandBuffer(_ *CommandBuffer) {}
 // This exists for API compatibility with the native backend.
 func (d *Device) HalDevice() any { return nil }
 
+// isReleased reports whether Release has been called.
+func (d *Device) isReleased() bool { return d.released }
+
 // Release releases the device and all associated resources.
 func (d *Device) Release() {
 	if d.released {
 		return
 	}
 	d.released = true
+	d.readbacks.destroy()
+	d.callbacks.cancel(ErrReleased)
 	if d.browser != nil {
 		d.browser.Destroy()
 	}
@@ -0,0 +1,112 @@
+package wgpu
+
+import (
+	"fmt"
+	"sync"
+)
+
+// deviceCallback is a completion callback waiting in a callbackQueue. ready
+// reports whether the operation it belongs to has finished, and with what
+// error.
+type deviceCallback struct {
+	ready    func() (done bool, err error)
+	callback func(error)
+}
+
+// callbackQueue holds the callbacks of a device's async operations until
+// Device.Poll runs them on its caller's goroutine.
+type callbackQueue struct {
+	mu      sync.Mutex
+	pending []deviceCallback
+
+	// lost is the error of a device loss. Once set, run calls every
+	// waiting callback with it instead of checking its operation.
+	lost error
+}
+
+func (q *callbackQueue) add(ready func() (bool, error), callback func(error)) {
+	q.mu.Lock()
+	q.pending = append(q.pending, deviceCallback{ready: ready, callback: callback})
+	q.mu.Unlock()
+}
+
+// run calls, in registration order, the callback of every operation that has
+// finished and forgets it. Callbacks run without the lock held, so they may
+// register new callbacks; those wait for the next run. It returns how many
+// callbacks ran.
+func (q *callbackQueue) run() int {
+	type finished struct {
+		callback func(error)
+		err      error
+	}
+	q.mu.Lock()
+	var ready []finished
+	kept := q.pending[:0]
+	for _, cb := range q.pending {
+		if q.lost != nil {
+			ready = append(ready, finished{callback: cb.callback, err: q.lost})
+		} else if done, err := cb.ready(); done {
+			ready = append(ready, finished{callback: cb.callback, err: err})
+		} else {
+			kept = append(kept, cb)
+		}
+	}
+	clear(q.pending[len(kept):])
+	q.pending = kept
+	q.mu.Unlock()
+
+	for _, f := range ready {
+		f.callback(f.err)
+	}
+	return len(ready)
+}
+
+// cancelLater makes the next run call every waiting callback, and every
+// callback added later, with err. It does not run callbacks, so it is safe
+// on any goroutine.
+func (q *callbackQueue) cancelLater(err error) {
+	q.mu.Lock()
+	q.lost = err
+	q.mu.Unlock()
+}
+
+// cancel calls every waiting callback with err and forgets it.
+func (q *callbackQueue) cancel(err error) {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	for _, cb := range pending {
+		cb.callback(err)
+	}
+}
+
+// MapAsyncCallback starts mapping the buffer like MapAsync and calls
+// callback once the map resolves: with nil when Buffer.MappedRange may be
+// called, or the error that failed or canceled the map. The callback runs
+// on the goroutine that calls Device.Poll or Device.Tick, never on its own,
+// so it needs no synchronization with the code driving the device.
+//
+// Validation errors are returned synchronously and callback is not called.
+func (b *Buffer) MapAsyncCallback(mode MapMode, offset, size uint64, callback func(error)) error {
+	if callback == nil {
+		return fmt.Errorf("wgpu: Buffer.MapAsyncCallback: callback is nil")
+	}
+	pending, err := b.MapAsync(mode, offset, size)
+	if err != nil {
+		return err
+	}
+	if b.device == nil {
+		pending.Release()
+		return ErrReleased
+	}
+	b.device.callbacks.add(func() (bool, error) {
+		done, err := pending.Status()
+		if done {
+			pending.Release()
+		}
+		return done, err
+	}, callback)
+	return nil
+}
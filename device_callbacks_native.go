@@ -0,0 +1,35 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import "fmt"
+
+// Tick runs the callbacks of async operations that have finished, on the
+// calling goroutine, without blocking. It is Poll(PollPoll) for render loops
+// that drive callbacks once per frame.
+func (d *Device) Tick() {
+	d.Poll(PollPoll)
+}
+
+// OnSubmittedWorkDoneCallback calls callback once the GPU has finished the
+// work of submission, as OnSubmittedWorkDone does, but from Device.Poll or
+// Device.Tick on their caller's goroutine instead of through a channel. A
+// submission of 0 stands for everything submitted so far; an index not yet
+// submitted is reported to callback as an error.
+func (q *Queue) OnSubmittedWorkDoneCallback(submission uint64, callback func(error)) {
+	if callback == nil || q.device == nil {
+		return
+	}
+	if submission == 0 {
+		submission = q.LastSubmissionIndex()
+	}
+	q.device.callbacks.add(func() (bool, error) {
+		if q.hal == nil {
+			return true, ErrReleased
+		}
+		if last := q.LastSubmissionIndex(); submission > last {
+			return true, fmt.Errorf("wgpu: submission %d has not been submitted (last is %d)", submission, last)
+		}
+		return q.hal.PollCompleted() >= submission, nil
+	}, callback)
+}
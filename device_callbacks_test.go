@@ -0,0 +1,132 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func newMapReadBuffer(t *testing.T, device *wgpu.Device) *wgpu.Buffer {
+	t.Helper()
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "callback-map",
+		Size:  16,
+		Usage: wgpu.BufferUsageMapRead | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	t.Cleanup(buf.Release)
+	return buf
+}
+
+func TestMapAsyncCallbackRunsInTick(t *testing.T) {
+	device := newSoftwareDevice(t)
+	buf := newMapReadBuffer(t, device)
+
+	calls := 0
+	var got error
+	if err := buf.MapAsyncCallback(wgpu.MapModeRead, 0, 16, func(err error) {
+		calls++
+		got = err
+	}); err != nil {
+		t.Fatalf("MapAsyncCallback: %v", err)
+	}
+	if calls != 0 {
+		t.Fatal("callback ran before Tick")
+	}
+
+	device.Tick()
+	if calls != 1 || got != nil {
+		t.Fatalf("after Tick: calls = %d, err = %v; want 1, nil", calls, got)
+	}
+	rng, err := buf.MappedRange(0, 16)
+	if err != nil {
+		t.Fatalf("MappedRange: %v", err)
+	}
+	rng.Release()
+	if err := buf.Unmap(); err != nil {
+		t.Fatalf("Unmap: %v", err)
+	}
+
+	device.Tick()
+	if calls != 1 {
+		t.Errorf("callback ran %d times, want 1", calls)
+	}
+}
+
+func TestMapAsyncCallbackValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+	buf := newMapReadBuffer(t, device)
+
+	if err := buf.MapAsyncCallback(wgpu.MapModeRead, 0, 16, nil); err == nil {
+		t.Error("nil callback should fail")
+	}
+	called := false
+	if err := buf.MapAsyncCallback(wgpu.MapModeRead, 4, 16, func(error) { called = true }); err == nil {
+		t.Error("misaligned offset should fail synchronously")
+	}
+	device.Poll(wgpu.PollWait)
+	if called {
+		t.Error("callback ran for a map that failed validation")
+	}
+}
+
+func TestOnSubmittedWorkDoneCallback(t *testing.T) {
+	device := newSoftwareDevice(t)
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	cmd, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	idx, err := device.Queue().Submit(cmd)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	var order []string
+	device.Queue().OnSubmittedWorkDoneCallback(idx, func(err error) {
+		if err != nil {
+			t.Errorf("submission %d: %v", idx, err)
+		}
+		order = append(order, "done")
+		// Registered from a callback: runs on the next Poll.
+		device.Queue().OnSubmittedWorkDoneCallback(0, func(error) { order = append(order, "nested") })
+	})
+	var future error
+	device.Queue().OnSubmittedWorkDoneCallback(idx+1, func(err error) { future = err })
+
+	if !device.Poll(wgpu.PollWait) {
+		t.Error("Poll ran callbacks but reported no work")
+	}
+	if len(order) != 1 || order[0] != "done" {
+		t.Fatalf("after first Poll: %v, want [done]", order)
+	}
+	if future == nil {
+		t.Error("callback for an unsubmitted index should get an error")
+	}
+	device.Tick()
+	if len(order) != 2 || order[1] != "nested" {
+		t.Errorf("after Tick: %v, want [done nested]", order)
+	}
+}
+
+func TestDeviceReleaseCancelsCallbacks(t *testing.T) {
+	device := newSoftwareDevice(t)
+	buf := newMapReadBuffer(t, device)
+
+	var got error
+	if err := buf.MapAsyncCallback(wgpu.MapModeRead, 0, 16, func(err error) { got = err }); err != nil {
+		t.Fatalf("MapAsyncCallback: %v", err)
+	}
+	device.Release()
+	if !errors.Is(got, wgpu.ErrReleased) {
+		t.Errorf("callback err = %v, want ErrReleased", got)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build rust || (js && wasm)
+
+package wgpu
+
+import "context"
+
+// OnSubmittedWorkDoneCallback calls callback once the GPU has finished the
+// work submitted so far, as OnSubmittedWorkDone does, but from Device.Poll or
+// Device.Tick on their caller's goroutine instead of through a channel.
+// submission is ignored, as it is by OnSubmittedWorkDone.
+func (q *Queue) OnSubmittedWorkDoneCallback(submission uint64, callback func(error)) {
+	if callback == nil || q.device == nil {
+		return
+	}
+	done := q.OnSubmittedWorkDone(context.Background(), submission)
+	q.device.callbacks.add(func() (bool, error) {
+		select {
+		case err := <-done:
+			return true, err
+		default:
+			return false, nil
+		}
+	}, callback)
+}
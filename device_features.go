@@ -0,0 +1,29 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/core"
+)
+
+// deviceFeatures returns the features a device requested through desc is
+// created with: every required feature plus the optional features the
+// adapter supports.
+func deviceFeatures(adapter Features, desc *DeviceDescriptor) Features {
+	if desc == nil {
+		return 0
+	}
+	return desc.RequiredFeatures | desc.OptionalFeatures&adapter
+}
+
+// checkRequiredFeatures fails with ErrFeatureNotSupported when desc requires
+// features the adapter does not expose, naming each missing feature.
+func checkRequiredFeatures(adapter Features, desc *DeviceDescriptor) error {
+	if desc == nil {
+		return nil
+	}
+	if err := core.ValidateRequiredFeatures(adapter, desc.RequiredFeatures); err != nil {
+		return fmt.Errorf("wgpu: %w", err)
+	}
+	return nil
+}
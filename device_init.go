@@ -0,0 +1,196 @@
+package wgpu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// BufferInitDescriptor describes a buffer created with initial contents by
+// Device.CreateBufferWithInit. The buffer size is len(Contents) rounded up
+// to the 4-byte copy alignment; the padding is zero.
+type BufferInitDescriptor struct {
+	Label    string
+	Contents []byte
+	Usage    BufferUsage
+}
+
+// TextureDataOrder selects how CreateTextureWithData expects the mip levels
+// and array layers of its data to be ordered.
+type TextureDataOrder uint8
+
+const (
+	// TextureDataOrderLayerMajor stores every mip level of layer 0, then
+	// every mip level of layer 1, and so on. This is the layout of DDS files.
+	TextureDataOrderLayerMajor TextureDataOrder = iota
+	// TextureDataOrderMipMajor stores mip level 0 of every layer, then mip
+	// level 1 of every layer, and so on. This is the layout of KTX2 files.
+	TextureDataOrderMipMajor
+)
+
+// CreateBufferWithInit creates a buffer and fills it with desc.Contents.
+//
+// Buffers that can take CopyDst are written through the queue, so any usage
+// works, including vertex, index and uniform buffers in device-local memory;
+// CopyDst is added to the usage for the upload. MapWrite buffers, which may
+// not combine with CopyDst, are created mapped and written directly instead.
+// The contents are visible to every command submitted afterwards.
+func (d *Device) CreateBufferWithInit(desc *BufferInitDescriptor) (*Buffer, error) {
+	if desc == nil {
+		return nil, errors.New("wgpu: buffer init descriptor is nil")
+	}
+
+	const copyBufferAlignment = 4
+	size := (uint64(len(desc.Contents)) + copyBufferAlignment - 1) &^ (copyBufferAlignment - 1)
+	if size == 0 {
+		return d.CreateBuffer(&BufferDescriptor{Label: desc.Label, Usage: desc.Usage})
+	}
+
+	if desc.Usage&BufferUsageMapWrite != 0 {
+		buf, err := d.CreateBuffer(&BufferDescriptor{
+			Label:            desc.Label,
+			Size:             size,
+			Usage:            desc.Usage,
+			MappedAtCreation: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		mapped, err := buf.MappedRange(0, size)
+		if err != nil {
+			buf.Release()
+			return nil, fmt.Errorf("wgpu: CreateBufferWithInit: %w", err)
+		}
+		copy(mapped.Bytes(), desc.Contents)
+		mapped.Release()
+		if err := buf.Unmap(); err != nil {
+			buf.Release()
+			return nil, fmt.Errorf("wgpu: CreateBufferWithInit: %w", err)
+		}
+		return buf, nil
+	}
+
+	buf, err := d.CreateBuffer(&BufferDescriptor{
+		Label: desc.Label,
+		Size:  size,
+		Usage: desc.Usage | BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, err
+	}
+	contents := desc.Contents
+	if uint64(len(contents)) != size {
+		contents = make([]byte, size)
+		copy(contents, desc.Contents)
+	}
+	if err := d.Queue().WriteBuffer(buf, 0, contents); err != nil {
+		buf.Release()
+		return nil, fmt.Errorf("wgpu: CreateBufferWithInit: %w", err)
+	}
+	return buf, nil
+}
+
+// CreateTextureWithData creates a texture and uploads every mip level and
+// array layer from data, adding CopyDst to the usage for the upload.
+//
+// data holds the subresources back to back in the given order, each tightly
+// packed: rows of blocks without padding, and for 3D textures every depth
+// slice of a mip level in sequence. Compressed mip levels smaller than one
+// block still occupy a whole block. Depth and stencil formats cannot be
+// uploaded and are rejected, as is data shorter than the texture.
+func (d *Device) CreateTextureWithData(desc *TextureDescriptor, order TextureDataOrder, data []byte) (*Texture, error) {
+	if desc == nil {
+		return nil, errors.New("wgpu: texture descriptor is nil")
+	}
+	blockWidth, blockHeight, blockBytes, ok := textureFormatBlock(desc.Format)
+	if !ok {
+		return nil, fmt.Errorf("wgpu: CreateTextureWithData: format %v cannot be written from the CPU", desc.Format)
+	}
+
+	withCopyDst := *desc
+	withCopyDst.Usage |= TextureUsageCopyDst
+	tex, err := d.CreateTexture(&withCopyDst)
+	if err != nil {
+		return nil, err
+	}
+
+	mips := max(desc.MipLevelCount, 1)
+	layers := desc.Size.DepthOrArrayLayers
+	if desc.Dimension == TextureDimension3D || layers == 0 {
+		layers = 1
+	}
+	outer, inner := layers, mips
+	if order == TextureDataOrderMipMajor {
+		outer, inner = mips, layers
+	}
+
+	queue := d.Queue()
+	var offset uint64
+	for o := range outer {
+		for i := range inner {
+			layer, mip := o, i
+			if order == TextureDataOrderMipMajor {
+				layer, mip = i, o
+			}
+
+			// Copy extents of compressed formats cover whole blocks, even
+			// where the mip level itself is smaller than one block.
+			width := ceilDivU32(max(desc.Size.Width>>mip, 1), blockWidth) * blockWidth
+			height := ceilDivU32(max(desc.Size.Height>>mip, 1), blockHeight) * blockHeight
+			depth := uint32(1)
+			if desc.Dimension == TextureDimension3D {
+				depth = max(desc.Size.DepthOrArrayLayers>>mip, 1)
+			}
+			bytesPerRow := width / blockWidth * blockBytes
+			rowsPerImage := height / blockHeight
+			end := offset + uint64(bytesPerRow)*uint64(rowsPerImage)*uint64(depth)
+			if end > uint64(len(data)) {
+				tex.Release()
+				return nil, fmt.Errorf("wgpu: CreateTextureWithData: data is %d bytes, mip %d layer %d needs %d", len(data), mip, layer, end)
+			}
+
+			err := queue.WriteTexture(
+				&ImageCopyTexture{Texture: tex, MipLevel: mip, Origin: Origin3D{Z: layer}},
+				data[offset:end],
+				&ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: rowsPerImage},
+				&Extent3D{Width: width, Height: height, DepthOrArrayLayers: depth},
+			)
+			if err != nil {
+				tex.Release()
+				return nil, fmt.Errorf("wgpu: CreateTextureWithData: mip %d layer %d: %w", mip, layer, err)
+			}
+			offset = end
+		}
+	}
+	return tex, nil
+}
+
+// astcBlockSizes lists ASTC block footprints in gputypes format order; each
+// footprint has an Unorm and an UnormSrgb format.
+var astcBlockSizes = [...][2]uint32{
+	{4, 4}, {5, 4}, {5, 5}, {6, 5}, {6, 6}, {8, 5}, {8, 6}, {8, 8},
+	{10, 5}, {10, 6}, {10, 8}, {10, 10}, {12, 10}, {12, 12},
+}
+
+// textureFormatBlock returns the block footprint and byte size of format,
+// with 1x1 blocks for uncompressed formats. ok is false for formats that
+// have no CPU copy layout, such as Depth24Plus.
+func textureFormatBlock(format TextureFormat) (width, height, bytes uint32, ok bool) {
+	if format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb {
+		dims := astcBlockSizes[(format-gputypes.TextureFormatASTC4x4Unorm)/2]
+		return dims[0], dims[1], 16, true
+	}
+	bytes = format.BlockCopySize()
+	if bytes == 0 {
+		return 0, 0, 0, false
+	}
+	if format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatEACRG11Snorm {
+		return 4, 4, bytes, true
+	}
+	return 1, 1, bytes, true
+}
+
+func ceilDivU32(a, b uint32) uint32 {
+	return (a + b - 1) / b
+}
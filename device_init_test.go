@@ -0,0 +1,147 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+)
+
+func readBack(t *testing.T, device *wgpu.Device, buf *wgpu.Buffer, offset uint64, n int) []byte {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dst := make([]byte, n)
+	done, err := device.Queue().ReadBufferAsync(ctx, buf, offset, dst)
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("ReadBufferAsync completion: %v", err)
+	}
+	return dst
+}
+
+func TestCreateBufferWithInit(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	contents := []byte{1, 2, 3, 4, 5, 6}
+	for _, usage := range []wgpu.BufferUsage{
+		wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc,
+		wgpu.BufferUsageMapWrite | wgpu.BufferUsageCopySrc,
+	} {
+		buf, err := device.CreateBufferWithInit(&wgpu.BufferInitDescriptor{
+			Label:    "init",
+			Contents: contents,
+			Usage:    usage,
+		})
+		if err != nil {
+			t.Fatalf("CreateBufferWithInit(%v): %v", usage, err)
+		}
+		if buf.Size() != 8 {
+			t.Errorf("usage %v: Size() = %d, want 8 (aligned to 4)", usage, buf.Size())
+		}
+		if got := readBack(t, device, buf, 0, 8); !bytes.Equal(got, []byte{1, 2, 3, 4, 5, 6, 0, 0}) {
+			t.Errorf("usage %v: contents = %v", usage, got)
+		}
+		buf.Release()
+	}
+
+	if _, err := device.CreateBufferWithInit(nil); err == nil {
+		t.Error("CreateBufferWithInit(nil) should fail")
+	}
+}
+
+func TestCreateTextureWithData(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	// The software backend stores a single mip level, so the readback
+	// covers a one-layer texture; the layout of larger ones is checked by
+	// the size validation below.
+	data := make([]byte, 4*2*4)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	desc := &wgpu.TextureDescriptor{
+		Label:         "with-data",
+		Size:          wgpu.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopySrc,
+	}
+	tex, err := device.CreateTextureWithData(desc, wgpu.TextureDataOrderLayerMajor, data)
+	if err != nil {
+		t.Fatalf("CreateTextureWithData: %v", err)
+	}
+	defer tex.Release()
+
+	dst, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Size:  256 * 2,
+		Usage: wgpu.BufferUsageCopySrc | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer dst.Release()
+
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	enc.CopyTextureToBuffer(tex, dst, []wgpu.BufferTextureCopy{{
+		TextureBase:  wgpu.ImageCopyTexture{Texture: tex},
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: 256, RowsPerImage: 2},
+		Size:         wgpu.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 1},
+	}})
+	cmd, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(cmd); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	got := append(readBack(t, device, dst, 0, 16), readBack(t, device, dst, 256, 16)...)
+	if !bytes.Equal(got, data) {
+		t.Errorf("texture contents = %v, want %v", got, data)
+	}
+}
+
+func TestCreateTextureWithDataValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	// 4x4 RGBA8 with two layers and three mips needs
+	// 2 * (64 + 16 + 4) = 168 bytes in either order.
+	desc := &wgpu.TextureDescriptor{
+		Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 2},
+		MipLevelCount: 3,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageTextureBinding,
+	}
+	for _, order := range []wgpu.TextureDataOrder{wgpu.TextureDataOrderLayerMajor, wgpu.TextureDataOrderMipMajor} {
+		tex, err := device.CreateTextureWithData(desc, order, make([]byte, 168))
+		if err != nil {
+			t.Errorf("order %d: CreateTextureWithData: %v", order, err)
+		} else {
+			tex.Release()
+		}
+		if _, err := device.CreateTextureWithData(desc, order, make([]byte, 167)); err == nil {
+			t.Errorf("order %d: short data should fail", order)
+		}
+	}
+
+	depth := *desc
+	depth.Format = wgpu.TextureFormatDepth24Plus
+	depth.Usage = wgpu.TextureUsageRenderAttachment
+	if _, err := device.CreateTextureWithData(&depth, wgpu.TextureDataOrderLayerMajor, make([]byte, 1024)); err == nil {
+		t.Error("Depth24Plus should be rejected")
+	}
+}
@@ -0,0 +1,193 @@
+package wgpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// copyBytesPerRowAlignment is the BytesPerRow alignment of texture-to-buffer
+// copies.
+const copyBytesPerRowAlignment = 256
+
+// DeviceLink copies buffers and textures from one device to another, such as
+// from a discrete GPU that renders to an integrated one that reads back or
+// presents. Resources of different devices cannot appear in the same command
+// buffer, so each transfer reads the source into host memory and uploads it
+// through the destination queue.
+//
+// A transfer observes all work submitted to the source device before it
+// starts, and its result is visible to all work submitted to the destination
+// device after it returns. When both ends are the same device the copy is
+// recorded and submitted directly, without the host round trip.
+//
+// DeviceLink is safe for concurrent use; transfers are serialized.
+type DeviceLink struct {
+	src, dst *Device
+
+	mu   sync.Mutex
+	host []byte // staging memory reused across transfers
+}
+
+// NewDeviceLink returns a link that copies from src to dst. The devices may
+// come from different adapters and different instances.
+func NewDeviceLink(src, dst *Device) (*DeviceLink, error) {
+	if src == nil || dst == nil {
+		return nil, errors.New("wgpu: NewDeviceLink: device is nil")
+	}
+	if src.isReleased() || dst.isReleased() {
+		return nil, ErrReleased
+	}
+	return &DeviceLink{src: src, dst: dst}, nil
+}
+
+// Source returns the device transfers read from.
+func (l *DeviceLink) Source() *Device { return l.src }
+
+// Destination returns the device transfers write to.
+func (l *DeviceLink) Destination() *Device { return l.dst }
+
+// CopyBuffer copies size bytes from src, a buffer of the source device, at
+// srcOffset to dst, a buffer of the destination device, at dstOffset. It
+// blocks until the source data has been read, or ctx ends.
+//
+// src needs BufferUsageCopySrc and dst BufferUsageCopyDst; offsets and size
+// must be multiples of 4.
+func (l *DeviceLink) CopyBuffer(ctx context.Context, src *Buffer, srcOffset uint64, dst *Buffer, dstOffset, size uint64) error {
+	if src == nil || dst == nil {
+		return errors.New("wgpu: DeviceLink.CopyBuffer: buffer is nil")
+	}
+	if src.device != l.src || dst.device != l.dst {
+		return errors.New("wgpu: DeviceLink.CopyBuffer: buffers do not belong to the linked devices")
+	}
+	if dst.Usage()&BufferUsageCopyDst == 0 {
+		return fmt.Errorf("wgpu: DeviceLink.CopyBuffer: buffer %q missing BufferUsageCopyDst: %w", dst.Label(), ErrBufferUsage)
+	}
+	if dstOffset%4 != 0 || dstOffset > dst.Size() || size > dst.Size()-dstOffset {
+		return fmt.Errorf("wgpu: DeviceLink.CopyBuffer: offset %d + %d bytes in buffer of size %d: %w",
+			dstOffset, size, dst.Size(), ErrBufferReadRange)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.src == l.dst {
+		return l.submitDirect("DeviceLink.CopyBuffer", func(enc *CommandEncoder) {
+			enc.CopyBufferToBuffer(src, srcOffset, dst, dstOffset, size)
+		})
+	}
+
+	host := l.hostBuffer(size)
+	done, err := l.src.Queue().ReadBufferAsync(ctx, src, srcOffset, host)
+	if err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyBuffer: %w", err)
+	}
+	if err := <-done; err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyBuffer: %w", err)
+	}
+	if err := l.dst.Queue().WriteBuffer(dst, dstOffset, host); err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyBuffer: %w", err)
+	}
+	return nil
+}
+
+// CopyTexture copies a size region of src, a texture of the source device,
+// into dst, a texture of the destination device. Both textures must have the
+// same format, one that can be copied to and from buffers; src needs
+// TextureUsageCopySrc and dst TextureUsageCopyDst. Compressed regions cover
+// whole blocks. It blocks until the source data has been read, or ctx ends.
+func (l *DeviceLink) CopyTexture(ctx context.Context, src, dst *ImageCopyTexture, size Extent3D) error {
+	if src == nil || dst == nil || src.Texture == nil || dst.Texture == nil {
+		return errors.New("wgpu: DeviceLink.CopyTexture: texture is nil")
+	}
+	if src.Texture.device != l.src || dst.Texture.device != l.dst {
+		return errors.New("wgpu: DeviceLink.CopyTexture: textures do not belong to the linked devices")
+	}
+	format := src.Texture.Format()
+	if dst.Texture.Format() != format {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: formats %v and %v differ", format, dst.Texture.Format())
+	}
+	blockWidth, blockHeight, blockBytes, ok := textureFormatBlock(format)
+	if !ok {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: format %v cannot be copied through a buffer", format)
+	}
+	if size.Width%blockWidth != 0 || size.Height%blockHeight != 0 {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: %dx%d is not a multiple of the %dx%d block size",
+			size.Width, size.Height, blockWidth, blockHeight)
+	}
+	depth := max(size.DepthOrArrayLayers, 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.src == l.dst {
+		return l.submitDirect("DeviceLink.CopyTexture", func(enc *CommandEncoder) {
+			enc.CopyTextureToTexture(src.Texture, dst.Texture, []TextureCopy{{Source: *src, Destination: *dst, Size: size}})
+		})
+	}
+
+	rows := size.Height / blockHeight
+	bytesPerRow := size.Width / blockWidth * blockBytes
+	bytesPerRow = (bytesPerRow + copyBytesPerRowAlignment - 1) &^ (copyBytesPerRowAlignment - 1)
+	total := uint64(bytesPerRow) * uint64(rows) * uint64(depth)
+	if total == 0 {
+		return nil
+	}
+	layout := ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: rows}
+
+	staging, err := l.src.CreateBuffer(&BufferDescriptor{
+		Label: "DeviceLink staging",
+		Size:  total,
+		Usage: BufferUsageMapRead | BufferUsageCopyDst,
+	})
+	if err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: %w", err)
+	}
+	err = l.submitDirect("DeviceLink.CopyTexture", func(enc *CommandEncoder) {
+		enc.CopyTextureToBuffer(src.Texture, staging, []BufferTextureCopy{{
+			BufferLayout: layout,
+			TextureBase:  *src,
+			Size:         size,
+		}})
+	})
+	if err != nil {
+		staging.Release()
+		return err
+	}
+	host := l.hostBuffer(total)
+	if err := readStagingBuffer(ctx, staging, host); err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: %w", err)
+	}
+	if err := l.dst.Queue().WriteTexture(dst, host, &layout, &size); err != nil {
+		return fmt.Errorf("wgpu: DeviceLink.CopyTexture: %w", err)
+	}
+	return nil
+}
+
+// submitDirect records the commands of record on the source device and
+// submits them.
+func (l *DeviceLink) submitDirect(op string, record func(*CommandEncoder)) error {
+	enc, err := l.src.CreateCommandEncoder(&CommandEncoderDescriptor{Label: op})
+	if err != nil {
+		return fmt.Errorf("wgpu: %s: %w", op, err)
+	}
+	record(enc)
+	commands, err := enc.Finish()
+	if err != nil {
+		return fmt.Errorf("wgpu: %s: %w", op, err)
+	}
+	if _, err := l.src.Queue().Submit(commands); err != nil {
+		commands.Release()
+		return fmt.Errorf("wgpu: %s: %w", op, err)
+	}
+	return nil
+}
+
+// hostBuffer returns the staging memory resized to n bytes.
+func (l *DeviceLink) hostBuffer(n uint64) []byte {
+	if uint64(cap(l.host)) < n {
+		l.host = make([]byte, n)
+	}
+	return l.host[:n]
+}
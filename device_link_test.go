@@ -0,0 +1,167 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestDeviceLinkCopyBuffer(t *testing.T) {
+	src, dst := newSoftwareDevice(t), newSoftwareDevice(t)
+	link, err := wgpu.NewDeviceLink(src, dst)
+	if err != nil {
+		t.Fatalf("NewDeviceLink: %v", err)
+	}
+
+	from, err := src.CreateBufferWithInit(&wgpu.BufferInitDescriptor{
+		Contents: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Usage:    wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBufferWithInit: %v", err)
+	}
+	defer from.Release()
+	to, err := dst.CreateBuffer(&wgpu.BufferDescriptor{Size: 12, Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageCopySrc})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer to.Release()
+
+	if err := link.CopyBuffer(context.Background(), from, 4, to, 8, 4); err != nil {
+		t.Fatalf("CopyBuffer: %v", err)
+	}
+	if got := readBack(t, dst, to, 0, 12); !bytes.Equal(got, []byte{0, 0, 0, 0, 0, 0, 0, 0, 5, 6, 7, 8}) {
+		t.Errorf("destination = %v", got)
+	}
+
+	if err := link.CopyBuffer(context.Background(), to, 0, from, 0, 4); err == nil {
+		t.Error("copy against the link direction should fail")
+	}
+	if err := link.CopyBuffer(context.Background(), from, 0, to, 8, 8); err == nil {
+		t.Error("copy past the end of the destination should fail")
+	}
+}
+
+func TestDeviceLinkCopyTexture(t *testing.T) {
+	src, dst := newSoftwareDevice(t), newSoftwareDevice(t)
+	link, err := wgpu.NewDeviceLink(src, dst)
+	if err != nil {
+		t.Fatalf("NewDeviceLink: %v", err)
+	}
+
+	data := make([]byte, 4*2*4)
+	for i := range data {
+		data[i] = byte(i + 1)
+	}
+	desc := &wgpu.TextureDescriptor{
+		Size:          wgpu.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageCopySrc,
+	}
+	from, err := src.CreateTextureWithData(desc, wgpu.TextureDataOrderLayerMajor, data)
+	if err != nil {
+		t.Fatalf("CreateTextureWithData: %v", err)
+	}
+	defer from.Release()
+	to, err := dst.CreateTexture(&wgpu.TextureDescriptor{
+		Size:          desc.Size,
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageCopyDst | wgpu.TextureUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	defer to.Release()
+
+	err = link.CopyTexture(context.Background(),
+		&wgpu.ImageCopyTexture{Texture: from}, &wgpu.ImageCopyTexture{Texture: to}, desc.Size)
+	if err != nil {
+		t.Fatalf("CopyTexture: %v", err)
+	}
+
+	readback, err := dst.CreateBuffer(&wgpu.BufferDescriptor{Size: 256 * 2, Usage: wgpu.BufferUsageCopySrc | wgpu.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer readback.Release()
+	enc, err := dst.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	enc.CopyTextureToBuffer(to, readback, []wgpu.BufferTextureCopy{{
+		TextureBase:  wgpu.ImageCopyTexture{Texture: to},
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: 256, RowsPerImage: 2},
+		Size:         desc.Size,
+	}})
+	cmd, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := dst.Queue().Submit(cmd); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	got := append(readBack(t, dst, readback, 0, 16), readBack(t, dst, readback, 256, 16)...)
+	if !bytes.Equal(got, data) {
+		t.Errorf("destination texture = %v, want %v", got, data)
+	}
+
+	other, err := dst.CreateTexture(&wgpu.TextureDescriptor{
+		Size:          desc.Size,
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatBGRA8Unorm,
+		Usage:         wgpu.TextureUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	defer other.Release()
+	err = link.CopyTexture(context.Background(),
+		&wgpu.ImageCopyTexture{Texture: from}, &wgpu.ImageCopyTexture{Texture: other}, desc.Size)
+	if err == nil {
+		t.Error("copy between different formats should fail")
+	}
+}
+
+func TestDeviceLinkSameDevice(t *testing.T) {
+	device := newSoftwareDevice(t)
+	link, err := wgpu.NewDeviceLink(device, device)
+	if err != nil {
+		t.Fatalf("NewDeviceLink: %v", err)
+	}
+	from, err := device.CreateBufferWithInit(&wgpu.BufferInitDescriptor{
+		Contents: []byte{9, 8, 7, 6},
+		Usage:    wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBufferWithInit: %v", err)
+	}
+	defer from.Release()
+	to, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: 4, Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageCopySrc})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer to.Release()
+
+	if err := link.CopyBuffer(context.Background(), from, 0, to, 0, 4); err != nil {
+		t.Fatalf("CopyBuffer: %v", err)
+	}
+	if got := readBack(t, device, to, 0, 4); !bytes.Equal(got, []byte{9, 8, 7, 6}) {
+		t.Errorf("destination = %v", got)
+	}
+
+	if _, err := wgpu.NewDeviceLink(device, nil); err == nil {
+		t.Error("NewDeviceLink with a nil device should fail")
+	}
+}
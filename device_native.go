@@ -3,13 +3,16 @@
 package wgpu
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"github.com/gogpu/gputypes"
 	naga "github.com/gogpu/naga"
 	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/naga/wgsl"
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -41,6 +44,27 @@ type Device struct {
 	//
 	// nil when no HAL device (e.g., core-only path).
 	cmdEncoderPool *encoderPool
+
+	// frame accumulates the counters FrameStatistics reports.
+	frame frameCounters
+
+	// readbacks recycles the staging buffers of Queue.ReadBufferAsync.
+	readbacks readbackPool
+
+	// callbacks holds async completion callbacks until Poll runs them.
+	callbacks callbackQueue
+
+	// memory tracks the memory pressure level Poll reports changes of.
+	memory memoryMonitor
+
+	// uncaptured delivers errors no error scope captured.
+	uncaptured uncapturedErrors
+
+	// lost is set when the instance watchdog detected a GPU hang. Later
+	// submissions fail with lostErr, which wraps ErrDeviceLost; Poll
+	// reports it as an uncaptured error.
+	lost    atomic.Bool
+	lostErr atomic.Pointer[error]
 }
 
 // Queue returns the device's command queue.
@@ -48,6 +72,13 @@ func (d *Device) Queue() *Queue {
 	return d.queue
 }
 
+// FrameStatistics returns the draws, dispatches, pipeline binds, buffer
+// upload bytes, and barriers recorded since the previous call, and resets
+// the counters. Call it once per frame.
+func (d *Device) FrameStatistics() FrameStatistics {
+	return d.frame.take()
+}
+
 // Features returns the device's enabled features.
 func (d *Device) Features() Features {
 	return d.core.Features
@@ -59,7 +90,10 @@ func (d *Device) Limits() Limits {
 }
 
 // CreateBuffer creates a GPU buffer.
-func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
+func (d *Device) CreateBuffer(desc *BufferDescriptor) (_ *Buffer, err error) {
+	defer startSpan("wgpu.Device.CreateBuffer").End()
+	defer d.reportUncaptured("Device.CreateBuffer", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -102,7 +136,10 @@ func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
 }
 
 // CreateTexture creates a GPU texture.
-func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
+func (d *Device) CreateTexture(desc *TextureDescriptor) (_ *Texture, err error) {
+	defer startSpan("wgpu.Device.CreateTexture").End()
+	defer d.reportUncaptured("Device.CreateTexture", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -115,9 +152,8 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 		return nil, ErrReleased
 	}
 
-	halDesc := desc.toHAL()
-
-	if err := core.ValidateTextureDescriptor(halDesc, d.core.Limits); err != nil {
+	halDesc, err := d.textureHALDescriptor(desc)
+	if err != nil {
 		return nil, err
 	}
 
@@ -126,11 +162,165 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 		return nil, fmt.Errorf("wgpu: failed to create texture: %w", err)
 	}
 
-	return &Texture{hal: halTexture, device: d, format: desc.Format}, nil
+	return d.newTexture(desc, halDesc, halTexture), nil
+}
+
+// CreateTextures creates a texture for each descriptor, in order. On
+// backends that batch resource creation (Vulkan) this is faster than
+// calling CreateTexture for each, which matters when loading hundreds of
+// textures at once; elsewhere it is equivalent. Every descriptor is
+// validated before anything is created, and on error no texture is left
+// behind.
+func (d *Device) CreateTextures(descs []*TextureDescriptor) (_ []*Texture, err error) {
+	defer startSpan("wgpu.Device.CreateTextures").End()
+	defer d.reportUncaptured("Device.CreateTextures", &err)
+
+	if d.released.Load() {
+		return nil, ErrReleased
+	}
+
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return nil, ErrReleased
+	}
+
+	halDescs := make([]*hal.TextureDescriptor, len(descs))
+	for i, desc := range descs {
+		if desc == nil {
+			return nil, fmt.Errorf("wgpu: texture descriptor %d is nil", i)
+		}
+		halDesc, err := d.textureHALDescriptor(desc)
+		if err != nil {
+			return nil, fmt.Errorf("wgpu: texture %d: %w", i, err)
+		}
+		halDescs[i] = halDesc
+	}
+
+	halTextures, err := hal.CreateTextures(halDevice, halDescs)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create textures: %w", err)
+	}
+
+	textures := make([]*Texture, len(descs))
+	for i, halTexture := range halTextures {
+		textures[i] = d.newTexture(descs[i], halDescs[i], halTexture)
+	}
+	return textures, nil
+}
+
+// textureHALDescriptor validates desc and converts it to the descriptor
+// CreateTexture passes to the HAL.
+func (d *Device) textureHALDescriptor(desc *TextureDescriptor) (*hal.TextureDescriptor, error) {
+	halDesc := desc.toHAL()
+
+	// Depth formats vary by hardware (no Depth24PlusStencil8 on many AMD
+	// Vulkan drivers, for one). Substitute the closest supported format
+	// rather than fail in the backend; Texture.Format reports the result.
+	if format, ok := d.core.ParentAdapter().DepthStencilFallback(halDesc.Format, halDesc.Usage, halDesc.SampleCount); ok {
+		hal.Logger().Warn("wgpu: depth format not supported by adapter, using fallback",
+			"label", desc.Label, "requested", halDesc.Format, "format", format)
+		halDesc.Format = format
+	}
+
+	if err := d.core.Validator.TextureDescriptor(halDesc, d.core.Limits); err != nil {
+		return nil, err
+	}
+	if err := validateProtected("texture", desc.Protected, d.core.Features); err != nil {
+		return nil, err
+	}
+	if err := validatePlanarFormat(halDesc.Format, d.core.Features); err != nil {
+		return nil, err
+	}
+	formatCaps := d.core.ParentAdapter().TextureFormatCapabilities(halDesc.Format)
+	if err := d.core.Validator.TextureSampleCount(halDesc, formatCaps); err != nil {
+		return nil, err
+	}
+	return halDesc, nil
+}
+
+// newTexture wraps a HAL texture created from desc, converted to halDesc.
+func (d *Device) newTexture(desc *TextureDescriptor, halDesc *hal.TextureDescriptor, halTexture hal.Texture) *Texture {
+	return &Texture{
+		hal:           halTexture,
+		device:        d,
+		format:        halDesc.Format,
+		dimension:     desc.Dimension,
+		size:          desc.Size,
+		mipLevelCount: desc.MipLevelCount,
+		sampleCount:   max(desc.SampleCount, 1),
+		viewFormats:   slices.Clone(desc.ViewFormats),
+		usage:         desc.Usage,
+		transient:     desc.Transient,
+		protected:     desc.Protected,
+	}
+}
+
+// ImportTexture wraps a texture another library created on this device,
+// typically one adopted with Adapter.AdoptDevice, so gogpu can render into
+// or sample from it. desc describes the native texture as it was created;
+// it is validated like a CreateTexture descriptor but nothing is allocated.
+//
+// Releasing the returned texture does not destroy the native texture. The
+// owner must keep it alive until the GPU work using it is done. Backends
+// that cannot import textures return an error wrapping
+// ErrExternalNotSupported.
+func (d *Device) ImportTexture(native *NativeTexture, desc *TextureDescriptor) (_ *Texture, err error) {
+	defer startSpan("wgpu.Device.ImportTexture").End()
+	defer d.reportUncaptured("Device.ImportTexture", &err)
+
+	if d.released.Load() {
+		return nil, ErrReleased
+	}
+	if native == nil || native.Handle == 0 {
+		return nil, fmt.Errorf("wgpu: native texture is nil")
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("wgpu: texture descriptor is nil")
+	}
+
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return nil, ErrReleased
+	}
+	importer, ok := halDevice.(hal.TextureImporter)
+	if !ok {
+		return nil, fmt.Errorf("wgpu: %w", ErrExternalNotSupported)
+	}
+
+	halDesc := desc.toHAL()
+	if err := d.core.Validator.TextureDescriptor(halDesc, d.core.Limits); err != nil {
+		return nil, err
+	}
+	if err := validateProtected("texture", desc.Protected, d.core.Features); err != nil {
+		return nil, err
+	}
+	if err := validatePlanarFormat(desc.Format, d.core.Features); err != nil {
+		return nil, err
+	}
+
+	halTexture, err := importer.ImportTexture(native.Handle, native.CurrentUsage, halDesc)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to import texture: %w", err)
+	}
+
+	return &Texture{
+		hal:           halTexture,
+		device:        d,
+		format:        desc.Format,
+		dimension:     desc.Dimension,
+		size:          desc.Size,
+		mipLevelCount: desc.MipLevelCount,
+		sampleCount:   max(desc.SampleCount, 1),
+		viewFormats:   slices.Clone(desc.ViewFormats),
+		usage:         desc.Usage,
+		protected:     desc.Protected,
+	}, nil
 }
 
 // CreateTextureView creates a view into a texture.
-func (d *Device) CreateTextureView(texture *Texture, desc *TextureViewDescriptor) (*TextureView, error) {
+func (d *Device) CreateTextureView(texture *Texture, desc *TextureViewDescriptor) (_ *TextureView, err error) {
+	defer d.reportUncaptured("Device.CreateTextureView", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -159,22 +349,63 @@ func (d *Device) CreateTextureView(texture *Texture, desc *TextureViewDescriptor
 		halDesc.ArrayLayerCount = desc.ArrayLayerCount
 	}
 
+	// Textures created through CreateTexture know their shape; wrapped and
+	// surface textures do not and keep the backend's default view.
+	viewDim := halDesc.Dimension
+	if texDesc := texture.viewSource(); texDesc != nil {
+		var downlevel hal.DownlevelFlags
+		if caps := d.core.ParentAdapter().Capabilities(); caps != nil {
+			downlevel = caps.DownlevelCapabilities.Flags
+		}
+		if err := d.core.Validator.TextureViewDescriptor(halDesc, texDesc, downlevel); err != nil {
+			return nil, err
+		}
+		viewDim = core.ResolveTextureViewDimension(halDesc.Dimension, texDesc, halDesc.ArrayLayerCount)
+	}
+
+	// A plane view of a multi-planar texture reads the plane's format.
+	if halDesc.Format == gputypes.TextureFormatUndefined {
+		if format, ok := hal.PlaneFormat(texture.format, halDesc.Aspect); ok {
+			halDesc.Format = format
+		}
+	}
+
 	halView, err := halDevice.CreateTextureView(halTexture, halDesc)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to create texture view: %w", err)
 	}
 
+	format := texture.format
+	if halDesc.Format != gputypes.TextureFormatUndefined {
+		format = halDesc.Format
+	}
+	mipLevelCount := halDesc.MipLevelCount
+	if mipLevelCount == 0 && texture.mipLevelCount > halDesc.BaseMipLevel {
+		mipLevelCount = texture.mipLevelCount - halDesc.BaseMipLevel
+	}
+	arrayLayerCount := halDesc.ArrayLayerCount
+	if layers := texture.size.DepthOrArrayLayers; arrayLayerCount == 0 && texture.dimension == gputypes.TextureDimension2D && layers > halDesc.BaseArrayLayer {
+		arrayLayerCount = layers - halDesc.BaseArrayLayer
+	}
+
 	return &TextureView{
-		hal:          halView,
-		device:       d,
-		texture:      texture,
-		surface:      texture.surface,
-		surfaceLease: texture.surfaceLease,
+		hal:             halView,
+		device:          d,
+		texture:         texture,
+		format:          format,
+		dimension:       viewDim,
+		mipLevelCount:   mipLevelCount,
+		arrayLayerCount: arrayLayerCount,
+		sampleCount:     texture.sampleCount,
+		surface:         texture.surface,
+		surfaceLease:    texture.surfaceLease,
 	}, nil
 }
 
 // CreateSampler creates a texture sampler.
-func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
+func (d *Device) CreateSampler(desc *SamplerDescriptor) (_ *Sampler, err error) {
+	defer d.reportUncaptured("Device.CreateSampler", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -195,11 +426,12 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
 		halDesc.MipmapFilter = desc.MipmapFilter
 		halDesc.LodMinClamp = desc.LodMinClamp
 		halDesc.LodMaxClamp = desc.LodMaxClamp
+		halDesc.LodBias = desc.LodBias
 		halDesc.Compare = desc.Compare
 		halDesc.Anisotropy = desc.Anisotropy
 	}
 
-	if err := core.ValidateSamplerDescriptor(halDesc); err != nil {
+	if err := d.core.Validator.SamplerDescriptor(halDesc); err != nil {
 		return nil, err
 	}
 
@@ -211,8 +443,54 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
 	return &Sampler{hal: halSampler, device: d}, nil
 }
 
+// CreateQuerySet creates a set of occlusion, timestamp or pipeline
+// statistics queries. Timestamp query sets require
+// gputypes.FeatureTimestampQuery on the device and pipeline statistics sets
+// gputypes.FeaturePipelineStatisticsQuery.
+func (d *Device) CreateQuerySet(desc *QuerySetDescriptor) (_ *QuerySet, err error) {
+	defer d.reportUncaptured("Device.CreateQuerySet", &err)
+
+	if d.released.Load() {
+		return nil, ErrReleased
+	}
+	if err := validateQuerySetDescriptor(desc, d.core.Features); err != nil {
+		return nil, err
+	}
+
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return nil, ErrReleased
+	}
+
+	halDesc := &hal.QuerySetDescriptor{
+		Label: desc.Label,
+		Type:  hal.QueryType(desc.Type),
+		Count: desc.Count,
+	}
+	if desc.Type == QueryTypePipelineStatistics {
+		halDesc.PipelineStatistics = hal.PipelineStatisticsTypes(desc.PipelineStatistics)
+	}
+	halQuerySet, err := halDevice.CreateQuerySet(halDesc)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create query set: %w", err)
+	}
+
+	return &QuerySet{
+		hal:        halQuerySet,
+		device:     d,
+		label:      desc.Label,
+		queryType:  desc.Type,
+		count:      desc.Count,
+		statistics: PipelineStatisticsTypes(halDesc.PipelineStatistics),
+		ref:        core.NewResourceRef("QuerySet:"+desc.Label, nil),
+	}, nil
+}
+
 // CreateShaderModule creates a shader module.
-func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule, error) {
+func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (_ *ShaderModule, err error) {
+	defer startSpan("wgpu.Device.CreateShaderModule").End()
+	defer d.reportUncaptured("Device.CreateShaderModule", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -225,46 +503,91 @@ func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule
 		return nil, ErrReleased
 	}
 
-	halDesc := &hal.ShaderModuleDescriptor{
-		Label: desc.Label,
-		Source: hal.ShaderSource{
-			WGSL:  desc.WGSL,
-			SPIRV: desc.SPIRV,
-		},
+	// naga has no GLSL frontend, so no native backend can use GLSL.
+	if desc.GLSL != "" {
+		kind := core.CreateShaderModuleErrorUnsupportedSource
+		if desc.WGSL != "" || len(desc.SPIRV) > 0 {
+			kind = core.CreateShaderModuleErrorDualSource
+		}
+		return nil, &core.CreateShaderModuleError{Kind: kind, Label: desc.Label, Source: "GLSL", Backend: d.backend()}
 	}
 
-	if err := core.ValidateShaderModuleDescriptor(halDesc); err != nil {
+	halDesc := desc.toHAL()
+
+	if err := d.core.Validator.ShaderModuleDescriptor(halDesc); err != nil {
 		return nil, err
 	}
 
+	// Only Vulkan and the software backend consume SPIR-V; naga has no
+	// SPIR-V frontend to translate it to HLSL, MSL or GLSL. Reject it here
+	// rather than at pipeline creation.
+	if len(desc.SPIRV) > 0 {
+		switch backend := d.backend(); backend {
+		case gputypes.BackendVulkan, gputypes.BackendEmpty:
+		default:
+			return nil, &core.CreateShaderModuleError{
+				Kind:    core.CreateShaderModuleErrorUnsupportedSource,
+				Label:   desc.Label,
+				Backend: backend,
+			}
+		}
+	}
+
+	// Parse WGSL source to naga IR before handing it to the HAL, so source
+	// errors come back as a ShaderCompileError rather than as the
+	// backend's flattened message. The IR is kept for shader introspection
+	// (late binding validation), matching Rust wgpu-core which stores the
+	// naga Module on ShaderModule for Interface::check_stage during pipeline
+	// creation. SPIR-V shaders skip this — they go directly to HAL without
+	// IR-level introspection.
+	var irModule *ir.Module
+	var warnings []ShaderDiagnostic
+	if desc.WGSL != "" {
+		ast, err := naga.Parse(desc.WGSL)
+		if err != nil {
+			return nil, compileWGSLDiagnostics(desc.Label, desc.WGSL, err)
+		}
+		lowered, err := wgsl.LowerWithWarnings(ast, desc.WGSL)
+		if err != nil {
+			return nil, compileWGSLDiagnostics(desc.Label, desc.WGSL, err)
+		}
+		irModule, warnings = lowered.Module, wgslWarnings(lowered.Warnings)
+		setHintStages(halDesc.CompilationHints, irModule)
+	}
+
 	halModule, err := halDevice.CreateShaderModule(halDesc)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to create shader module: %w", err)
 	}
 
-	sm := &ShaderModule{hal: halModule, device: d}
+	return &ShaderModule{hal: halModule, device: d, label: desc.Label, irModule: irModule, warnings: warnings}, nil
+}
 
-	// Parse WGSL source to naga IR for shader introspection (late binding validation).
-	// Matches Rust wgpu-core which stores the naga Module on ShaderModule for use
-	// by Interface::check_stage during pipeline creation.
-	// SPIR-V shaders skip this — they go directly to HAL without IR-level introspection.
-	if desc.WGSL != "" {
-		ast, parseErr := naga.Parse(desc.WGSL)
-		if parseErr == nil {
-			irModule, lowerErr := naga.Lower(ast)
-			if lowerErr == nil {
-				sm.irModule = irModule
+// setHintStages fills in the stages of compilation hints from the entry
+// points of module. Hints naming no entry point keep stage 0, and backends
+// skip them.
+func setHintStages(hints []hal.ShaderCompilationHint, module *ir.Module) {
+	for i := range hints {
+		for _, ep := range module.EntryPoints {
+			if ep.Name != hints[i].EntryPoint {
+				continue
+			}
+			switch ep.Stage {
+			case ir.StageVertex:
+				hints[i].Stage = ShaderStageVertex
+			case ir.StageFragment:
+				hints[i].Stage = ShaderStageFragment
+			case ir.StageCompute:
+				hints[i].Stage = ShaderStageCompute
 			}
 		}
-		// Parse/lower failures are non-fatal here — the HAL already compiled the shader
-		// successfully. Late binding validation will be skipped if IR is unavailable.
 	}
-
-	return sm, nil
 }
 
 // CreateBindGroupLayout creates a bind group layout.
-func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (_ *BindGroupLayout, err error) {
+	defer d.reportUncaptured("Device.CreateBindGroupLayout", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -282,25 +605,27 @@ func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGr
 		Entries: desc.Entries,
 	}
 
-	if err := core.ValidateBindGroupLayoutDescriptor(halDesc, d.core.Limits); err != nil {
+	if err := d.core.Validator.BindGroupLayoutDescriptor(halDesc, d.core.Limits); err != nil {
 		return nil, err
 	}
 
-	halLayout, err := halDevice.CreateBindGroupLayout(halDesc)
+	// Layouts with equal entries share one HAL layout, as in Rust wgpu-core's
+	// bgl::Pool. The pool keeps a defensive copy of the entries for
+	// entry-by-entry compatibility checks.
+	pooled, err := d.core.BindGroupLayouts().Acquire(desc.Entries, func() (hal.BindGroupLayout, error) {
+		return halDevice.CreateBindGroupLayout(halDesc)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to create bind group layout: %w", err)
 	}
 
-	// Store a defensive copy of entries for entry-by-entry compatibility checks.
-	// This matches Rust wgpu-core's pattern where binder compares layouts by entries.
-	entriesCopy := make([]gputypes.BindGroupLayoutEntry, len(desc.Entries))
-	copy(entriesCopy, desc.Entries)
-
-	return &BindGroupLayout{hal: halLayout, device: d, entries: entriesCopy}, nil
+	return &BindGroupLayout{hal: pooled.Raw(), device: d, entries: pooled.Entries(), pooled: pooled}, nil
 }
 
 // CreatePipelineLayout creates a pipeline layout.
-func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*PipelineLayout, error) {
+func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (_ *PipelineLayout, err error) {
+	defer d.reportUncaptured("Device.CreatePipelineLayout", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -326,9 +651,18 @@ func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*Pipeline
 		BindGroupLayouts: halLayouts,
 	}
 
-	if err := core.ValidatePipelineLayoutDescriptor(halDesc, d.core.Limits); err != nil {
+	if err := d.core.Validator.PipelineLayoutDescriptor(halDesc, d.core.Limits); err != nil {
 		return nil, err
 	}
+	if d.core.Validator.Enabled() {
+		groups := make([][]gputypes.BindGroupLayoutEntry, len(desc.BindGroupLayouts))
+		for i, layout := range desc.BindGroupLayouts {
+			groups[i] = layout.entries
+		}
+		if err := d.core.Validator.PipelineLayoutBindings(desc.Label, groups, d.core.Limits); err != nil {
+			return nil, err
+		}
+	}
 
 	halLayout, err := halDevice.CreatePipelineLayout(halDesc)
 	if err != nil {
@@ -348,7 +682,10 @@ func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*Pipeline
 }
 
 // CreateBindGroup creates a bind group.
-func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (*BindGroup, error) {
+func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (_ *BindGroup, err error) {
+	defer startSpan("wgpu.Device.CreateBindGroup").End()
+	defer d.reportUncaptured("Device.CreateBindGroup", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -382,22 +719,41 @@ func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (*BindGroup, error)
 		Entries: halEntries,
 	}
 
-	// Build buffer metadata for core validation.
-	var bufferInfos []core.BindGroupBufferInfo
-	for _, entry := range desc.Entries {
-		if entry.Buffer != nil {
-			bufferInfos = append(bufferInfos, core.BindGroupBufferInfo{
-				Binding:    entry.Binding,
-				Usage:      entry.Buffer.Usage(),
-				BufferSize: entry.Buffer.Size(),
-				Offset:     entry.Offset,
-				Size:       entry.Size,
-			})
+	if d.core.Validator.Enabled() {
+		// Build buffer metadata for core validation.
+		var bufferInfos []core.BindGroupBufferInfo
+		for _, entry := range desc.Entries {
+			if entry.Buffer != nil {
+				bufferInfos = append(bufferInfos, core.BindGroupBufferInfo{
+					Binding:    entry.Binding,
+					Usage:      entry.Buffer.Usage(),
+					BufferSize: entry.Buffer.Size(),
+					Offset:     entry.Offset,
+					Size:       entry.Size,
+				})
+			}
 		}
-	}
 
-	if err := core.ValidateBindGroupDescriptor(halDesc, desc.Layout.entries, bufferInfos, d.core.Limits); err != nil {
-		return nil, err
+		if err := d.core.Validator.BindGroupDescriptor(halDesc, desc.Layout.entries, bufferInfos, d.core.Limits); err != nil {
+			return nil, err
+		}
+
+		var textureInfos []core.BindGroupTextureInfo
+		for _, entry := range desc.Entries {
+			if entry.TextureView != nil {
+				textureInfos = append(textureInfos, core.BindGroupTextureInfo{
+					Binding:       entry.Binding,
+					ViewDimension: entry.TextureView.dimension,
+					SampleCount:   entry.TextureView.sampleCount,
+					Format:        entry.TextureView.format,
+					Usage:         entry.TextureView.texture.usage,
+					MipLevelCount: entry.TextureView.mipLevelCount,
+				})
+			}
+		}
+		if err := d.core.Validator.BindGroupTextureViews(desc.Label, desc.Layout.entries, textureInfos); err != nil {
+			return nil, err
+		}
 	}
 
 	halGroup, err := halDevice.CreateBindGroup(halDesc)
@@ -484,7 +840,10 @@ func buildBindGroupEntryMap(entries []BindGroupEntry) map[uint32]*BindGroupEntry
 }
 
 // CreateRenderPipeline creates a render pipeline.
-func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPipeline, error) {
+func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (_ *RenderPipeline, err error) {
+	defer startSpan("wgpu.Device.CreateRenderPipeline").End()
+	defer d.reportUncaptured("Device.CreateRenderPipeline", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -497,9 +856,34 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		return nil, ErrReleased
 	}
 
+	if err := validatePolygonMode(desc.PolygonMode, d.core.Features); err != nil {
+		return nil, err
+	}
+	if err := validateViewCount(desc.ViewCount, d.core.Features, d.maxMultiviewViewCount()); err != nil {
+		return nil, err
+	}
 	halDesc := desc.toHAL()
 
-	if err := core.ValidateRenderPipelineDescriptor(halDesc, d.core.Limits); err != nil {
+	if err := d.core.Validator.RenderPipelineDescriptor(halDesc, d.core.Limits); err != nil {
+		return nil, err
+	}
+	if err := d.core.Validator.RenderPipelineSampleCount(halDesc, d.core.ParentAdapter().TextureFormatCapabilities); err != nil {
+		return nil, err
+	}
+	var layoutGroups uint32
+	if desc.Layout != nil {
+		layoutGroups = desc.Layout.bindGroupCount
+	}
+	if err := d.core.Validator.RenderPipelineBindGroups(halDesc, layoutGroups, d.core.Limits); err != nil {
+		return nil, err
+	}
+	var fragmentEntry string
+	if desc.Fragment != nil {
+		fragmentEntry = desc.Fragment.EntryPoint
+	}
+	if err := d.core.Validator.RenderPipelineInterStage(desc.Label,
+		shaderIR(desc.Vertex.Module), desc.Vertex.EntryPoint,
+		shaderIR(fragmentShaderModule(desc.Fragment)), fragmentEntry, d.core.Limits); err != nil {
 		return nil, err
 	}
 
@@ -538,9 +922,16 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 
 	lateGroups := makeLateSizedBufferGroups(shaderBindingSizes, bgLayouts)
 
-	return &RenderPipeline{
+	kept := *desc
+	if desc.Fragment != nil {
+		fragment := *desc.Fragment
+		kept.Fragment = &fragment
+	}
+	pipeline := &RenderPipeline{
 		hal:                   halPipeline,
 		device:                d,
+		label:                 desc.Label,
+		passContext:           core.RenderPipelineContext(halDesc),
 		bindGroupCount:        bgCount,
 		bindGroupLayouts:      bgLayouts,
 		requiredVertexBuffers: uint32(len(desc.Vertex.Buffers)), //nolint:gosec // buffer count fits uint32
@@ -548,7 +939,13 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		stripIndexFormat:      desc.Primitive.StripIndexFormat,
 		lateSizedBufferGroups: lateGroups,
 		ref:                   core.NewResourceRef("RenderPipeline:"+desc.Label, nil),
-	}, nil
+		desc:                  kept,
+	}
+	desc.Vertex.Module.trackRender(pipeline)
+	if frag := fragmentShaderModule(desc.Fragment); frag != desc.Vertex.Module {
+		frag.trackRender(pipeline)
+	}
+	return pipeline, nil
 }
 
 // fragmentShaderModule extracts the ShaderModule from a FragmentState, or nil if absent.
@@ -559,6 +956,15 @@ func fragmentShaderModule(fs *FragmentState) *ShaderModule {
 	return fs.Module
 }
 
+// shaderIR returns the naga IR of a shader module, or nil if the module is
+// nil or was created from SPIR-V.
+func shaderIR(m *ShaderModule) *ir.Module {
+	if m == nil {
+		return nil
+	}
+	return m.irModule
+}
+
 // mergeShaderBindingSizes merges binding sizes from vertex and fragment shader stages,
 // taking the max size when both stages reference the same binding. Matches Rust
 // wgpu-core's pattern of calling check_stage for each stage with the same
@@ -586,7 +992,10 @@ func mergeShaderBindingSizes(
 }
 
 // CreateComputePipeline creates a compute pipeline.
-func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*ComputePipeline, error) {
+func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (_ *ComputePipeline, err error) {
+	defer startSpan("wgpu.Device.CreateComputePipeline").End()
+	defer d.reportUncaptured("Device.CreateComputePipeline", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -601,13 +1010,16 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 
 	halDesc := desc.toHAL()
 
-	if err := core.ValidateComputePipelineDescriptor(halDesc); err != nil {
+	if err := d.core.Validator.ComputePipelineDescriptor(halDesc); err != nil {
+		return nil, err
+	}
+	if err := d.core.Validator.ComputePipelineWorkgroupStorage(desc.Label, shaderIR(desc.Module), desc.EntryPoint, d.core.Limits); err != nil {
 		return nil, err
 	}
 
 	// VAL-010: Validate workgroup_size against device limits.
 	// Matches Rust wgpu-core validation.rs:1243-1264.
-	if desc.Module != nil && desc.Module.irModule != nil {
+	if d.core.Validator.Enabled() && desc.Module != nil && desc.Module.irModule != nil {
 		if err := d.validateComputeWorkgroupSize(desc.Label, desc.EntryPoint, desc.Module); err != nil {
 			return nil, err
 		}
@@ -633,14 +1045,17 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 
 	lateGroups := makeLateSizedBufferGroups(shaderBindingSizes, bgLayouts)
 
-	return &ComputePipeline{
+	pipeline := &ComputePipeline{
 		hal:                   halPipeline,
 		device:                d,
 		bindGroupCount:        bgCount,
 		bindGroupLayouts:      bgLayouts,
 		lateSizedBufferGroups: lateGroups,
 		ref:                   core.NewResourceRef("ComputePipeline:"+desc.Label, nil),
-	}, nil
+		desc:                  *desc,
+	}
+	desc.Module.trackCompute(pipeline)
+	return pipeline, nil
 }
 
 // validateComputeWorkgroupSize checks shader workgroup_size against device limits.
@@ -715,7 +1130,10 @@ func (d *Device) validateComputeWorkgroupSize(label, entryPoint string, module *
 // expensive GPU resources (DX12 ID3D12CommandAllocator ~64KB, Vulkan VkCommandPool)
 // on every frame. After GPU completion, the encoder is reset and returned to the
 // pool for reuse. Matches Rust wgpu-core's CommandAllocator pattern (allocator.rs).
-func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandEncoder, error) {
+func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (_ *CommandEncoder, err error) {
+	defer startSpan("wgpu.Device.CreateCommandEncoder").End()
+	defer d.reportUncaptured("Device.CreateCommandEncoder", &err)
+
 	if d.released.Load() {
 		return nil, ErrReleased
 	}
@@ -725,6 +1143,12 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 		label = desc.Label
 	}
 
+	// Protected encoders record into their own protected command pools, so
+	// they never come from the shared encoder pool.
+	if desc != nil && desc.Protected {
+		return d.createProtectedCommandEncoder(desc)
+	}
+
 	// When pool is available, acquire a recycled HAL encoder and pass it to core.
 	// This bypasses core's internal CreateCommandEncoder which would create a new
 	// HAL encoder, and instead uses CreateCommandEncoderWithHAL that accepts
@@ -766,6 +1190,37 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 	return &CommandEncoder{core: coreEncoder, device: d}, nil
 }
 
+// createProtectedCommandEncoder creates a standalone protected HAL encoder.
+// Its command buffer is freed once the submission that uses it completes.
+func (d *Device) createProtectedCommandEncoder(desc *CommandEncoderDescriptor) (*CommandEncoder, error) {
+	if err := validateProtected("command encoder", true, d.core.Features); err != nil {
+		return nil, err
+	}
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return nil, ErrReleased
+	}
+	halEnc, err := halDevice.CreateCommandEncoder(desc.toHAL())
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create protected command encoder: %w", err)
+	}
+	if err := halEnc.BeginEncoding(desc.Label); err != nil {
+		halEnc.DiscardEncoding()
+		return nil, fmt.Errorf("wgpu: begin encoding: %w", err)
+	}
+	coreEncoder, err := d.core.CreateCommandEncoderWithHAL(halEnc, desc.Label)
+	if err != nil {
+		halEnc.DiscardEncoding()
+		return nil, err
+	}
+	return &CommandEncoder{
+		core:        coreEncoder,
+		device:      d,
+		protected:   true,
+		trackedRefs: make([]*core.ResourceRef, 0, 64),
+	}, nil
+}
+
 // CreateFence creates a GPU synchronization fence.
 // Fences are primarily used by the HAL internally for synchronization.
 // Most callers should use Queue.Submit + Queue.Poll instead.
@@ -844,6 +1299,106 @@ func (d *Device) WaitForFence(f *Fence, value uint64, timeout time.Duration) (bo
 	return halDevice.Wait(f.hal, value, timeout)
 }
 
+// WaitForFenceContext waits for a fence to reach the specified value until
+// ctx ends. It returns nil once the fence reaches the value. When ctx ends
+// first it returns an error wrapping ErrWaitCanceled and ctx.Err(), so
+// server shutdown paths can stop waiting on a stuck GPU.
+//
+// Driver waits cannot be interrupted; cancellation is noticed within about
+// 10ms.
+func (d *Device) WaitForFenceContext(ctx context.Context, f *Fence, value uint64) error {
+	if d.released.Load() {
+		return ErrReleased
+	}
+	if f == nil || f.released {
+		return ErrReleased
+	}
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return ErrReleased
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := hal.WaitContext(ctx, halDevice, f.hal, value); err != nil {
+		return fmt.Errorf("wgpu: WaitForFenceContext: %w", err)
+	}
+	return nil
+}
+
+// CreateSharedFence creates a fence that ExportFence can hand to another
+// API or process. Shared fences are timelines: their value starts at 0, is
+// set by SubmitDescriptor.SignalFences and waited for with WaitForFence or
+// SubmitDescriptor.WaitFences. Backends that cannot share fences return an
+// error wrapping ErrExternalNotSupported.
+func (d *Device) CreateSharedFence() (*Fence, error) {
+	sharer, err := d.fenceSharer()
+	if err != nil {
+		return nil, err
+	}
+	halFence, err := sharer.CreateSharedFence()
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create shared fence: %w", err)
+	}
+	return &Fence{hal: halFence, device: d}, nil
+}
+
+// ExportFence returns a native handle to f, which must come from
+// CreateSharedFence or ImportFence: an opaque file descriptor or NT handle
+// of a Vulkan timeline semaphore, an NT handle of a D3D12 fence, or an
+// id<MTLSharedEvent>. File descriptors and NT handles are new and must be
+// closed by the caller; an id<MTLSharedEvent> belongs to f.
+func (d *Device) ExportFence(f *Fence) (*NativeFence, error) {
+	if f == nil || f.released {
+		return nil, ErrReleased
+	}
+	sharer, err := d.fenceSharer()
+	if err != nil {
+		return nil, err
+	}
+	native, err := sharer.ExportFence(f.hal)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to export fence: %w", err)
+	}
+	return &NativeFence{Type: NativeFenceType(native.Type), Handle: native.Handle}, nil
+}
+
+// ImportFence wraps a fence another API or process exported, so
+// submissions can wait for and signal it. A file descriptor belongs to the
+// fence once the import succeeds; other handles stay owned by the caller.
+// Backends that cannot import the handle type return an error wrapping
+// ErrExternalNotSupported.
+func (d *Device) ImportFence(native *NativeFence) (*Fence, error) {
+	if native == nil {
+		return nil, fmt.Errorf("wgpu: native fence is nil")
+	}
+	sharer, err := d.fenceSharer()
+	if err != nil {
+		return nil, err
+	}
+	halFence, err := sharer.ImportFence(hal.NativeFence{Type: hal.NativeFenceType(native.Type), Handle: native.Handle})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to import fence: %w", err)
+	}
+	return &Fence{hal: halFence, device: d}, nil
+}
+
+// fenceSharer returns the HAL device's hal.FenceSharer.
+func (d *Device) fenceSharer() (hal.FenceSharer, error) {
+	if d.released.Load() {
+		return nil, ErrReleased
+	}
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return nil, ErrReleased
+	}
+	sharer, ok := halDevice.(hal.FenceSharer)
+	if !ok {
+		return nil, fmt.Errorf("wgpu: %w", ErrExternalNotSupported)
+	}
+	return sharer, nil
+}
+
 // FreeCommandBuffer returns a command buffer to the command pool.
 // This must be called after the GPU has finished using the command buffer.
 // The command buffer handle becomes invalid after this call.
@@ -873,7 +1428,10 @@ func (d *Device) PopErrorScope() *GPUError {
 }
 
 // WaitIdle waits for all GPU work to complete.
-func (d *Device) WaitIdle() error {
+func (d *Device) WaitIdle() (err error) {
+	defer startSpan("wgpu.Device.WaitIdle").End()
+	defer d.reportUncaptured("Device.WaitIdle", &err)
+
 	if d.released.Load() {
 		return ErrReleased
 	}
@@ -915,6 +1473,45 @@ func (d *Device) maintainAfterIdle() {
 	}
 }
 
+// Trim releases memory the device keeps pooled for reuse but is not using:
+// idle readback staging buffers, idle command encoders, and, on Vulkan,
+// empty device memory blocks and idle command pools. Long-running
+// applications whose footprint peaked, such as editors after closing a
+// large document, call it to return that memory to the driver. Pools
+// refill on demand, so the next frames may allocate again.
+//
+// Trim does not wait for the GPU. Memory of resources released while the
+// GPU may still use them is freed by a later Trim, after their submission
+// completes; call WaitIdle first to reclaim everything.
+func (d *Device) Trim() TrimReport {
+	defer startSpan("wgpu.Device.Trim").End()
+
+	var report TrimReport
+	if d == nil || d.released.Load() {
+		return report
+	}
+
+	// Destroy resources whose submissions completed first, so their memory
+	// and encoders are back in the pools before they are trimmed.
+	d.maintainAfterIdle()
+
+	report.StagingMemory = d.readbacks.trim()
+	if d.cmdEncoderPool != nil {
+		report.CommandEncoders = d.cmdEncoderPool.trim()
+	}
+
+	if trimmer, ok := d.halDevice().(hal.Trimmer); ok {
+		halReport := trimmer.Trim()
+		report.DeviceMemory = halReport.MemoryBytes
+		report.DeviceMemoryBlocks = halReport.MemoryBlocks
+		report.CommandPools = halReport.CommandPools
+	}
+	return report
+}
+
+// isReleased reports whether Release has been called.
+func (d *Device) isReleased() bool { return d.released.Load() }
+
 // Release releases the device and all associated resources.
 // Deferred resource destructions are flushed before the device is destroyed.
 // Shutdown order:
@@ -924,6 +1521,7 @@ func (d *Device) maintainAfterIdle() {
 //  3. Triage + FlushAll — deferred callbacks fire (encoders return to pool)
 //  4. Destroy encoder pool (HAL device still alive)
 //  5. Destroy core + HAL device
+//  6. Call async callbacks Poll has not run with ErrReleased
 //
 // WaitIdle is required because FlushAll calls Triage(PollCompleted()),
 // but PollCompleted may return a stale index if GPU hasn't finished.
@@ -937,6 +1535,9 @@ func (d *Device) Release() {
 	if d == nil || d.released.Swap(true) {
 		return
 	}
+	if w := d.watchdog(); w != nil {
+		w.sync()
+	}
 	var configuredSurfaces []*Surface
 	if d.instance != nil {
 		configuredSurfaces = d.instance.surfacesForDevice(d)
@@ -960,6 +1561,7 @@ func (d *Device) Release() {
 	// entry points. This ensures PollCompleted() returns the final submission
 	// index before pending encoders or resources are destroyed.
 	_ = d.waitIdle()
+	d.readbacks.destroy()
 
 	// Step 2: Pending writes that were never submitted can now be discarded;
 	// completed inflight batches were recycled by maintainAfterIdle above.
@@ -993,6 +1595,10 @@ func (d *Device) Release() {
 	for _, surface := range configuredSurfaces {
 		surface.retireDevice(d)
 	}
+
+	// Step 6: Callbacks run on the goroutine driving the device, which is
+	// the one releasing it; nothing will Poll for the remaining ones.
+	d.callbacks.cancel(ErrReleased)
 }
 
 // destroyQueue returns the device's DestroyQueue for deferred resource destruction.
@@ -1016,10 +1622,30 @@ func (d *Device) destroyQueue() *core.DestroyQueue {
 // primary path used by Buffer.Map and is appropriate for shutdown
 // drains and short scripts that do not run a render loop.
 //
-// The return value reports whether Poll observed any in-flight maps —
-// tests use it to assert that Submit-driven auto-polling drained
-// everything without needing an explicit Poll call.
+// Both then run, on the calling goroutine, the callbacks of async
+// operations that have finished (Buffer.MapAsyncCallback,
+// Queue.OnSubmittedWorkDoneCallback) and the memory pressure callback.
+// Callbacks only ever run inside Poll and Tick, so the caller decides which
+// goroutine they run on.
+//
+// The return value reports whether Poll observed any in-flight maps, a
+// change of memory pressure, or ran any callbacks — tests use it to assert that Submit-driven
+// auto-polling drained everything without needing an explicit Poll call.
 func (d *Device) Poll(pollType PollType) bool {
+	defer startSpan("wgpu.Device.Poll").End()
+
+	if d == nil || d.core == nil {
+		return false
+	}
+	didWork := d.pollMaps(pollType)
+	pressure := d.pollMemoryPressure(time.Now())
+	d.reportLoss()
+	return d.callbacks.run() > 0 || didWork || pressure
+}
+
+// pollMaps drives the pending-map triage loop for Poll without running
+// callbacks, for internal polling from other goroutines.
+func (d *Device) pollMaps(pollType PollType) bool {
 	if d == nil || d.core == nil {
 		return false
 	}
@@ -1040,6 +1666,15 @@ func (d *Device) Poll(pollType PollType) bool {
 	}
 }
 
+// backend returns the backend of the adapter the device was created from.
+// The software and noop backends report BackendEmpty.
+func (d *Device) backend() gputypes.Backend {
+	if d.core == nil || d.core.ParentAdapter() == nil {
+		return gputypes.BackendEmpty
+	}
+	return d.core.ParentAdapter().Info.Backend
+}
+
 // lastSubmissionIndex returns the latest submission index from the queue.
 // Used by Release() methods to schedule deferred destruction.
 func (d *Device) lastSubmissionIndex() uint64 {
@@ -1058,3 +1693,15 @@ func (d *Device) halDevice() hal.Device {
 	defer guard.Release()
 	return d.core.Raw(guard)
 }
+
+// maxMultiviewViewCount returns the adapter's MaxMultiviewViewCount, or 0
+// when the capabilities are unknown.
+func (d *Device) maxMultiviewViewCount() uint32 {
+	if d.core == nil || d.core.ParentAdapter() == nil {
+		return 0
+	}
+	if caps := d.core.ParentAdapter().Capabilities(); caps != nil {
+		return caps.MaxMultiviewViewCount
+	}
+	return 0
+}
@@ -3,6 +3,7 @@
 package wgpu
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"time"
@@ -20,6 +21,15 @@ type Device struct {
 	features Features
 	limits   Limits
 	released bool
+
+	// frame accumulates the counters FrameStatistics reports.
+	frame frameCounters
+
+	// readbacks recycles the staging buffers of Queue.ReadBufferAsync.
+	readbacks readbackPool
+
+	// callbacks holds async completion callbacks until Poll runs them.
+	callbacks callbackQueue
 }
 
 // Queue returns the device's command queue.
@@ -27,6 +37,30 @@ func (d *Device) Queue() *Queue {
 	return d.queue
 }
 
+// FrameStatistics returns the draws, dispatches, pipeline binds, and buffer
+// upload bytes recorded since the previous call, and resets the counters.
+// Call it once per frame. Barriers is always 0: wgpu-native inserts its own barriers.
+func (d *Device) FrameStatistics() FrameStatistics {
+	return d.frame.take()
+}
+
+// Trim returns an empty TrimReport: wgpu-native manages its pools itself.
+func (d *Device) Trim() TrimReport {
+	return TrimReport{}
+}
+
+// MemoryBudget returns false: wgpu-native does not expose memory budgets.
+func (d *Device) MemoryBudget() (MemoryBudget, bool) {
+	return MemoryBudget{}, false
+}
+
+// SetMemoryPressureCallback does nothing: without a memory budget the
+// callback would never be called.
+func (d *Device) SetMemoryPressureCallback(callback func(MemoryPressure)) {}
+
+// SetTrimOnMemoryPressure does nothing: Trim releases nothing here.
+func (d *Device) SetTrimOnMemoryPressure(enabled bool) {}
+
 // Features returns the device's enabled features.
 func (d *Device) Features() Features {
 	return d.features
@@ -59,6 +93,37 @@ func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
 	return &Buffer{r: rb, device: d}, nil
 }
 
+// ReloadShaderModule is not supported by the Rust backend: wgpu-native
+// pipelines cannot be rebuilt behind their handles.
+// It always returns ErrShaderReloadNotSupported.
+func (d *Device) ReloadShaderModule(_ *ShaderModule, _ string) error {
+	return ErrShaderReloadNotSupported
+}
+
+// ImportTexture is not supported by the Rust backend.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ImportTexture(_ *NativeTexture, _ *TextureDescriptor) (*Texture, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// CreateSharedFence is not supported by the Rust backend.
+// It always returns ErrExternalNotSupported.
+func (d *Device) CreateSharedFence() (*Fence, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// ExportFence is not supported by the Rust backend.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ExportFence(_ *Fence) (*NativeFence, error) {
+	return nil, ErrExternalNotSupported
+}
+
+// ImportFence is not supported by the Rust backend.
+// It always returns ErrExternalNotSupported.
+func (d *Device) ImportFence(_ *NativeFence) (*Fence, error) {
+	return nil, ErrExternalNotSupported
+}
+
 // CreateTexture creates a GPU texture.
 func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	if d.released {
@@ -67,6 +132,12 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	if desc == nil {
 		return nil, fmt.Errorf("wgpu: texture descriptor is nil")
 	}
+	if err := validateProtected("texture", desc.Protected, d.features); err != nil {
+		return nil, err
+	}
+	if err := validatePlanarFormat(desc.Format, d.features); err != nil {
+		return nil, err
+	}
 
 	rt, err := d.r.CreateTexture(&rwgpu.TextureDescriptor{
 		Label:         desc.Label,
@@ -85,6 +156,23 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	return &Texture{r: rt, device: d, format: desc.Format}, nil
 }
 
+// CreateTextures creates a texture for each descriptor, in order, with one
+// CreateTexture call each. On error no texture is left behind.
+func (d *Device) CreateTextures(descs []*TextureDescriptor) ([]*Texture, error) {
+	textures := make([]*Texture, 0, len(descs))
+	for i, desc := range descs {
+		texture, err := d.CreateTexture(desc)
+		if err != nil {
+			for _, t := range textures {
+				t.Release()
+			}
+			return nil, fmt.Errorf("wgpu: texture %d: %w", i, err)
+		}
+		textures = append(textures, texture)
+	}
+	return textures, nil
+}
+
 // CreateTextureView creates a view into a texture.
 // In go-webgpu, CreateView is a method on Texture, not Device.
 func (d *Device) CreateTextureView(texture *Texture, desc *TextureViewDescriptor) (*TextureView, error) {
@@ -165,6 +253,8 @@ func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule
 		rm, err = d.r.CreateShaderModuleWGSL(desc.WGSL)
 	case len(desc.SPIRV) > 0:
 		rm, err = d.r.CreateShaderModuleSPIRV(desc.Label, desc.SPIRV)
+	case desc.GLSL != "":
+		rm, err = d.createShaderModuleGLSL(desc)
 	default:
 		return nil, fmt.Errorf("wgpu: shader module descriptor has no source")
 	}
@@ -176,6 +266,51 @@ func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule
 	return &ShaderModule{r: rm, device: d}, nil
 }
 
+// shaderSourceGLSL mirrors wgpu-native's WGPUShaderSourceGLSL chained struct.
+type shaderSourceGLSL struct {
+	Chain       rwgpu.ChainedStruct
+	Stage       uint64 // WGPUShaderStage flags
+	Code        rwgpu.StringView
+	DefineCount uint32
+	_           [4]byte
+	Defines     uintptr // *WGPUShaderDefine
+}
+
+// createShaderModuleGLSL creates a shader module from GLSL through
+// wgpu-native's GLSL extension, which translates it with naga.
+func (d *Device) createShaderModuleGLSL(desc *ShaderModuleDescriptor) (*rwgpu.ShaderModule, error) {
+	switch desc.GLSLStage {
+	case ShaderStageVertex, ShaderStageFragment, ShaderStageCompute:
+	default:
+		return nil, fmt.Errorf("wgpu: shader module %q: GLSLStage must be exactly one stage", desc.Label)
+	}
+	code := []byte(desc.GLSL)
+	label := []byte(desc.Label)
+	source := shaderSourceGLSL{
+		Chain: rwgpu.ChainedStruct{SType: uint32(rwgpu.STypeShaderSourceGLSL)},
+		Stage: uint64(desc.GLSLStage),
+		Code: rwgpu.StringView{
+			Data:   uintptr(unsafe.Pointer(&code[0])), //nolint:gosec // G103: FFI interop requires pointer to source bytes
+			Length: uintptr(len(code)),
+		},
+	}
+	rdesc := rwgpu.ShaderModuleDescriptor{
+		NextInChain: uintptr(unsafe.Pointer(&source)), //nolint:gosec // G103: FFI interop requires pointer to chained struct
+	}
+	if len(label) > 0 {
+		rdesc.Label = rwgpu.StringView{
+			Data:   uintptr(unsafe.Pointer(&label[0])), //nolint:gosec // G103: FFI interop requires pointer to label bytes
+			Length: uintptr(len(label)),
+		}
+	}
+	rm, err := d.r.CreateShaderModule(&rdesc)
+	// The descriptor holds the buffers as uintptrs, invisible to the GC.
+	runtime.KeepAlive(code)
+	runtime.KeepAlive(label)
+	runtime.KeepAlive(&source)
+	return rm, err
+}
+
 // CreateBindGroupLayout creates a bind group layout.
 func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
 	if d.released {
@@ -267,6 +402,12 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 	if desc == nil {
 		return nil, fmt.Errorf("wgpu: render pipeline descriptor is nil")
 	}
+	if err := validatePolygonMode(desc.PolygonMode, d.features); err != nil {
+		return nil, err
+	}
+	if err := validateViewCount(desc.ViewCount, d.features, 0); err != nil {
+		return nil, err
+	}
 
 	rDesc := convertRenderPipelineDesc(desc)
 
@@ -309,6 +450,31 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 	return &ComputePipeline{r: rp, device: d}, nil
 }
 
+// CreateQuerySet creates a set of occlusion or timestamp queries.
+// Timestamp query sets require gputypes.FeatureTimestampQuery on the device.
+func (d *Device) CreateQuerySet(desc *QuerySetDescriptor) (*QuerySet, error) {
+	if d.released {
+		return nil, ErrReleased
+	}
+	if err := validateQuerySetDescriptor(desc, d.features); err != nil {
+		return nil, err
+	}
+	if desc.Type == QueryTypePipelineStatistics {
+		return nil, fmt.Errorf("wgpu: pipeline statistics queries are not supported on the Rust backend: %w", ErrFeatureNotSupported)
+	}
+
+	rq, err := d.r.CreateQuerySet(&rwgpu.QuerySetDescriptor{
+		Label: desc.Label,
+		Type:  convertQueryTypeRust(desc.Type),
+		Count: desc.Count,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: failed to create query set: %w", err)
+	}
+
+	return &QuerySet{r: rq, label: desc.Label, queryType: desc.Type, count: desc.Count}, nil
+}
+
 // CreateCommandEncoder creates a command encoder for recording GPU commands.
 func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandEncoder, error) {
 	if d.released {
@@ -317,6 +483,9 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 
 	var rDesc *rwgpu.CommandEncoderDescriptor
 	if desc != nil {
+		if err := validateProtected("command encoder", desc.Protected, d.features); err != nil {
+			return nil, err
+		}
 		rDesc = &rwgpu.CommandEncoderDescriptor{
 			Label: desc.Label,
 		}
@@ -388,6 +557,25 @@ func (d *Device) WaitForFence(f *Fence, _ uint64, _ time.Duration) (bool, error)
 	return true, nil
 }
 
+// WaitForFenceContext waits for a fence to reach the specified value until
+// ctx ends. On Rust backend it polls the device like WaitForFence; a ctx
+// that has already ended returns an error wrapping ErrWaitCanceled and
+// ctx.Err().
+func (d *Device) WaitForFenceContext(ctx context.Context, f *Fence, value uint64) error {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("wgpu: WaitForFenceContext: %w: %w", ErrWaitCanceled, err)
+		}
+	}
+	_, err := d.WaitForFence(f, value, 0)
+	return err
+}
+
+// SetUncapturedErrorCallback is accepted for API compatibility but has no
+// effect: wgpu-native takes its uncaptured error callback only at device
+// creation and reports uncaptured errors itself.
+func (d *Device) SetUncapturedErrorCallback(_ func(*GPUError)) {}
+
 // PushErrorScope pushes a new error scope onto the device's error scope stack.
 func (d *Device) PushErrorScope(filter ErrorFilter) {
 	if d.r != nil {
@@ -424,12 +612,22 @@ func (d *Device) WaitIdle() error {
 	return nil
 }
 
-// Poll drives the per-device pending-map triage loop.
+// Poll drives the per-device pending-map triage loop, then runs the
+// callbacks of finished MapAsyncCallback and OnSubmittedWorkDoneCallback
+// operations on the calling goroutine.
 func (d *Device) Poll(pollType PollType) bool {
 	if d == nil || d.r == nil {
 		return false
 	}
-	return d.r.Poll(pollType == PollWait)
+	didWork := d.r.Poll(pollType == PollWait)
+	return d.callbacks.run() > 0 || didWork
+}
+
+// Tick polls the device without blocking, running the callbacks of finished
+// operations, wgpu-native's and those registered with MapAsyncCallback or
+// OnSubmittedWorkDoneCallback, on the calling goroutine.
+func (d *Device) Tick() {
+	d.Poll(PollPoll)
 }
 
 // FreeCommandBuffer is a no-op on Rust backend.
@@ -438,12 +636,17 @@ func (d *Device) FreeCommandBuffer(_ *CommandBuffer) {}
 // HalDevice returns nil on Rust backend. There is no HAL layer.
 func (d *Device) HalDevice() any { return nil }
 
+// isReleased reports whether Release has been called.
+func (d *Device) isReleased() bool { return d.released }
+
 // Release releases the device and all associated resources.
 func (d *Device) Release() {
 	if d.released {
 		return
 	}
 	d.released = true
+	d.readbacks.destroy()
+	d.callbacks.cancel(ErrReleased)
 	if d.r != nil {
 		d.r.Release()
 	}
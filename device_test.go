@@ -3,6 +3,7 @@
 package wgpu_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -228,6 +229,30 @@ func TestDeviceWaitForFence(t *testing.T) {
 	}
 }
 
+func TestDeviceWaitForFenceContext(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	fence, err := device.CreateFence()
+	if err != nil {
+		t.Fatalf("CreateFence: %v", err)
+	}
+	defer fence.Release()
+
+	if err := device.WaitForFenceContext(context.Background(), fence, 0); err != nil {
+		t.Fatalf("WaitForFenceContext on a reached value: %v", err)
+	}
+
+	// Nothing signals value 1, so only the context ends the wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = device.WaitForFenceContext(ctx, fence, 1)
+	if !errors.Is(err, wgpu.ErrWaitCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForFenceContext past the deadline = %v, want ErrWaitCanceled wrapping DeadlineExceeded", err)
+	}
+}
+
 func TestDeviceWaitForFenceReleasedDevice(t *testing.T) {
 	_, _, device := newDevice(t)
 	requireHAL(t, device)
@@ -570,6 +595,49 @@ func TestDeviceCreateTextureViewWithDescriptor(t *testing.T) {
 	view.Release()
 }
 
+func TestDeviceCreateTextureViewSrgbViewFormat(t *testing.T) {
+	requireValidation(t)
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	desc := &wgpu.TextureDescriptor{
+		Size:          wgpu.Extent3D{Width: 32, Height: 32, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageRenderAttachment,
+	}
+	plain, err := device.CreateTexture(desc)
+	if err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	defer plain.Release()
+	srgbView := &wgpu.TextureViewDescriptor{Format: wgpu.TextureFormatRGBA8UnormSrgb}
+	if _, err := device.CreateTextureView(plain, srgbView); err == nil {
+		t.Error("CreateTextureView accepted an sRGB view of a texture without ViewFormats")
+	}
+
+	desc.ViewFormats = []wgpu.TextureFormat{wgpu.TextureFormatRGBA8UnormSrgb}
+	tex, err := device.CreateTexture(desc)
+	if err != nil {
+		t.Fatalf("CreateTexture with ViewFormats: %v", err)
+	}
+	defer tex.Release()
+	view, err := device.CreateTextureView(tex, srgbView)
+	if err != nil {
+		t.Fatalf("CreateTextureView(sRGB): %v", err)
+	}
+	view.Release()
+
+	desc.ViewFormats = []wgpu.TextureFormat{wgpu.TextureFormatBGRA8Unorm}
+	if bad, err := device.CreateTexture(desc); err == nil {
+		bad.Release()
+		t.Error("CreateTexture accepted an incompatible view format")
+	}
+}
+
 // =============================================================================
 // Wrap tests — NewDeviceFromHAL, NewSurfaceFromHAL, etc.
 // Covers wrap.go lines 19-109
@@ -30,6 +30,20 @@
 //
 // # Thread Safety
 //
-// Instance, Adapter, and Device are safe for concurrent use.
-// Encoders (CommandEncoder, RenderPassEncoder, ComputePassEncoder) are NOT thread-safe.
+// Instance, Adapter, Device and Queue are safe for concurrent use.
+// Encoders (CommandEncoder, RenderPassEncoder, ComputePassEncoder) are NOT
+// thread-safe: each must be used by one goroutine at a time, together with
+// the passes it began.
+//
+// Separate encoders of the same device may record concurrently, so large
+// scenes can split pass recording across a pool of goroutines, each creating
+// its own CommandEncoder, and submit the finished command buffers together in
+// one Queue.Submit, in the order they must execute. Every encoder owns its
+// backend command allocator (a VkCommandPool on Vulkan, an
+// ID3D12CommandAllocator on DX12), so recording needs no locking; pooled
+// allocators are recycled once the GPU has finished with them.
+//
+// Resources used by concurrently recorded encoders must not be released or
+// destroyed until those encoders are finished or discarded, and buffers must
+// stay unmapped while any command buffer using them is pending.
 package wgpu
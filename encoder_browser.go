@@ -13,6 +13,8 @@ import (
 type CommandEncoder struct {
 	browser  *browser.CommandEncoder
 	released bool
+	// counters is the device's frame counters, passed on to passes.
+	counters *frameCounters
 }
 
 // BeginRenderPass begins a render pass.
@@ -20,11 +22,23 @@ func (e *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderPas
 	if e.released {
 		return nil, ErrReleased
 	}
+	// WebGPU has no multiview, so FeatureMultiview is never enabled.
+	if err := validateViewCount(desc.ViewCount, 0, 0); err != nil {
+		return nil, err
+	}
 	jsDesc := buildRenderPassDescriptorJS(desc)
+	if desc.TimestampWrites != nil {
+		tw, err := buildTimestampWritesJS(desc.TimestampWrites)
+		if err != nil {
+			return nil, err
+		}
+		jsDesc.Set("timestampWrites", tw)
+	}
 	bp := e.browser.BeginRenderPass(jsDesc)
 	return &RenderPassEncoder{
 		browser:  bp,
 		released: false,
+		counters: e.counters,
 	}, nil
 }
 
@@ -38,10 +52,18 @@ func (e *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compute
 		label = desc.Label
 	}
 	jsDesc := browser.BuildComputePassDescriptor(label)
+	if desc != nil && desc.TimestampWrites != nil {
+		tw, err := buildTimestampWritesJS(desc.TimestampWrites)
+		if err != nil {
+			return nil, err
+		}
+		jsDesc.Set("timestampWrites", tw)
+	}
 	bp := e.browser.BeginComputePass(jsDesc)
 	return &ComputePassEncoder{
 		browser:  bp,
 		released: false,
+		counters: e.counters,
 	}, nil
 }
 
@@ -57,6 +79,19 @@ func (e *CommandEncoder) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst *
 	)
 }
 
+// ResolveQuerySet copies queryCount results starting at firstQuery into
+// destination at destinationOffset, as one uint64 per query. The destination
+// needs BufferUsageQueryResolve and the offset must be a multiple of 256.
+func (e *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery, queryCount uint32, destination *Buffer, destinationOffset uint64) {
+	if e.released {
+		return
+	}
+	if validateResolveQuerySet(querySet, firstQuery, queryCount, destination, destinationOffset) != nil || querySet.released {
+		return
+	}
+	e.browser.ResolveQuerySet(querySet.browser.Ref(), firstQuery, queryCount, destination.browser.Ref(), destinationOffset)
+}
+
 // CopyBufferToTexture copies data from a buffer to a texture.
 func (e *CommandEncoder) CopyBufferToTexture(src *Buffer, dst *Texture, regions []BufferTextureCopy) {
 	if e.released || src == nil || dst == nil {
@@ -0,0 +1,74 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+// TestConcurrentCommandEncoders records on several goroutines at once, each
+// with its own encoder, then submits the results together.
+func TestConcurrentCommandEncoders(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	const workers = 8
+	src, err := device.CreateBufferWithInit(&wgpu.BufferInitDescriptor{
+		Contents: bytes.Repeat([]byte{1, 2, 3, 4}, workers),
+		Usage:    wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBufferWithInit: %v", err)
+	}
+	defer src.Release()
+	dst, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Size:  4 * workers,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer dst.Release()
+
+	commands := make([]*wgpu.CommandBuffer, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			enc, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "worker"})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			pass, err := enc.BeginComputePass(nil)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if err := pass.End(); err != nil {
+				errs[i] = err
+				return
+			}
+			enc.CopyBufferToBuffer(src, uint64(4*i), dst, uint64(4*i), 4)
+			commands[i], errs[i] = enc.Finish()
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("worker %d: %v", i, err)
+		}
+	}
+
+	if _, err := device.Queue().Submit(commands...); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got := readBack(t, device, dst, 0, 4*workers); !bytes.Equal(got, bytes.Repeat([]byte{1, 2, 3, 4}, workers)) {
+		t.Errorf("destination = %v", got)
+	}
+}
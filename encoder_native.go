@@ -5,6 +5,7 @@ package wgpu
 import (
 	"fmt"
 
+	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -29,6 +30,11 @@ type CommandEncoder struct {
 	// On DiscardEncoding(), the encoder is reset and returned to the pool immediately.
 	halEncoder hal.CommandEncoder
 
+	// protected is set for encoders created with
+	// CommandEncoderDescriptor.Protected. They own their HAL encoder
+	// outright instead of borrowing one from the Device's pool.
+	protected bool
+
 	// usedBuffers tracks root-level buffers referenced during encoding for
 	// submit-time validation (VAL-A6). At Submit, each buffer is checked for
 	// destroyed/mapped state. Using a map for O(1) deduplication — the same
@@ -45,6 +51,10 @@ type CommandEncoder struct {
 	// for destroyed state. Matches Rust wgpu-core's cmd_buf_data.trackers.bind_groups
 	// (device/queue.rs:1815-1817).
 	usedBindGroups map[*BindGroup]struct{}
+
+	// passLabels are the labels of the last passes begun on the encoder,
+	// kept for the watchdog's hang reports. Nil without a watchdog.
+	passLabels []string
 }
 
 // setError records a deferred error on the underlying command encoder.
@@ -56,6 +66,29 @@ func (e *CommandEncoder) setError(err error) {
 	}
 }
 
+// checkBufferUsage records a usage error on the encoder when buffer lacks
+// required and the device has buffer usage validation enabled. It reports
+// whether encoding may continue.
+func (e *CommandEncoder) checkBufferUsage(buffer *Buffer, required gputypes.BufferUsage, command string) bool {
+	if e.device == nil || buffer == nil {
+		return true
+	}
+	if err := e.device.core.CheckBufferUsage(buffer.core, required, command); err != nil {
+		e.setError(fmt.Errorf("wgpu: %w", err))
+		return false
+	}
+	return true
+}
+
+// counters returns the device's frame counters, or nil when the encoder has
+// no device.
+func (e *CommandEncoder) counters() *frameCounters {
+	if e.device == nil {
+		return nil
+	}
+	return &e.device.frame
+}
+
 // trackRef Clone()'s a ResourceRef and accumulates it for transfer to the
 // CommandBuffer on Finish(). This keeps the resource alive until the GPU
 // completes the submission. Used for encoder-level operations (copy commands).
@@ -113,14 +146,32 @@ func (e *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderPas
 	if err := validateRenderPassTextureViews(desc); err != nil {
 		return nil, err
 	}
+	if desc != nil {
+		if err := validateViewCount(desc.ViewCount, e.device.core.Features, e.device.maxMultiviewViewCount()); err != nil {
+			return nil, fmt.Errorf("wgpu: BeginRenderPass: %w", err)
+		}
+	}
 	trackRenderPassTextureViews(e, desc)
 
 	coreDesc := convertRenderPassDesc(desc)
+	if desc != nil && desc.TimestampWrites != nil {
+		halQuerySet, err := timestampWritesHAL(desc.TimestampWrites)
+		if err != nil {
+			return nil, err
+		}
+		e.trackRef(desc.TimestampWrites.QuerySet.ref)
+		coreDesc.TimestampWrites = &hal.RenderPassTimestampWrites{
+			QuerySet:                  halQuerySet,
+			BeginningOfPassWriteIndex: desc.TimestampWrites.BeginningOfPassWriteIndex,
+			EndOfPassWriteIndex:       desc.TimestampWrites.EndOfPassWriteIndex,
+		}
+	}
 
 	corePass, err := e.core.BeginRenderPass(coreDesc)
 	if err != nil {
 		return nil, err
 	}
+	e.recordPass(coreDesc.Label)
 
 	return &RenderPassEncoder{core: corePass, encoder: e}, nil
 }
@@ -136,12 +187,29 @@ func (e *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compute
 	var coreDesc *core.CoreComputePassDescriptor
 	if desc != nil {
 		coreDesc = &core.CoreComputePassDescriptor{Label: desc.Label}
+		if desc.TimestampWrites != nil {
+			halQuerySet, err := timestampWritesHAL(desc.TimestampWrites)
+			if err != nil {
+				return nil, err
+			}
+			e.trackRef(desc.TimestampWrites.QuerySet.ref)
+			coreDesc.TimestampWrites = &hal.ComputePassTimestampWrites{
+				QuerySet:                  halQuerySet,
+				BeginningOfPassWriteIndex: desc.TimestampWrites.BeginningOfPassWriteIndex,
+				EndOfPassWriteIndex:       desc.TimestampWrites.EndOfPassWriteIndex,
+			}
+		}
 	}
 
 	corePass, err := e.core.BeginComputePass(coreDesc)
 	if err != nil {
 		return nil, err
 	}
+	if desc != nil {
+		e.recordPass(desc.Label)
+	} else {
+		e.recordPass("")
+	}
 
 	return &ComputePassEncoder{core: corePass, encoder: e}, nil
 }
@@ -159,6 +227,10 @@ func (e *CommandEncoder) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst *
 		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyBufferToBuffer: destination buffer is nil"))
 		return
 	}
+	if !e.checkBufferUsage(src, gputypes.BufferUsageCopySrc, "CommandEncoder.CopyBufferToBuffer source") ||
+		!e.checkBufferUsage(dst, gputypes.BufferUsageCopyDst, "CommandEncoder.CopyBufferToBuffer destination") {
+		return
+	}
 	e.trackRef(src.core.Ref)
 	e.trackRef(dst.core.Ref)
 	e.trackBuffer(src)
@@ -177,6 +249,35 @@ func (e *CommandEncoder) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst *
 	})
 }
 
+// ResolveQuerySet copies queryCount results starting at firstQuery into
+// destination at destinationOffset, as one uint64 per query. The destination
+// needs BufferUsageQueryResolve and the offset must be a multiple of 256.
+func (e *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery, queryCount uint32, destination *Buffer, destinationOffset uint64) {
+	if e.released {
+		return
+	}
+	if err := validateResolveQuerySet(querySet, firstQuery, queryCount, destination, destinationOffset); err != nil {
+		e.setError(err)
+		return
+	}
+	if querySet.released {
+		e.setError(fmt.Errorf("wgpu: CommandEncoder.ResolveQuerySet: %w", ErrReleased))
+		return
+	}
+	e.trackRef(querySet.ref)
+	e.trackRef(destination.core.Ref)
+	e.trackBuffer(destination)
+	raw := e.core.RawEncoder()
+	if raw == nil {
+		return
+	}
+	halDst := destination.halBuffer()
+	if halDst == nil {
+		return
+	}
+	raw.ResolveQuerySet(querySet.hal, firstQuery, queryCount, halDst, destinationOffset)
+}
+
 // CopyTextureToBuffer copies data from a texture to a buffer.
 // This is used for GPU-to-CPU readback of rendered content.
 func (e *CommandEncoder) CopyTextureToBuffer(src *Texture, dst *Buffer, regions []BufferTextureCopy) {
@@ -191,6 +292,9 @@ func (e *CommandEncoder) CopyTextureToBuffer(src *Texture, dst *Buffer, regions
 		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyTextureToBuffer: destination buffer is nil"))
 		return
 	}
+	if !e.checkBufferUsage(dst, gputypes.BufferUsageCopyDst, "CommandEncoder.CopyTextureToBuffer destination") {
+		return
+	}
 	halSrc := src.resolveHAL()
 	if halSrc == nil {
 		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyTextureToBuffer: source texture is released: %w", ErrReleased))
@@ -222,6 +326,12 @@ func (e *CommandEncoder) CopyTextureToBuffer(src *Texture, dst *Buffer, regions
 
 // CopyTextureToTexture copies data between textures using DMA hardware copy.
 // WebGPU spec: GPUCommandEncoder.copyTextureToTexture()
+//
+// The formats must be equal or differ only in their sRGB encoding. On
+// adapters where Adapter.SupportsTextureCopyReinterpret is true they may
+// also be a block-compressed format and an uncompressed one of the block's
+// size; Size is then in source texels and the destination region is scaled
+// to destination blocks, so 8x8 texels of BC7 fill 2x2 texels of RGBA32Uint.
 func (e *CommandEncoder) CopyTextureToTexture(src, dst *Texture, regions []TextureCopy) {
 	if e.released {
 		return
@@ -249,6 +359,10 @@ func (e *CommandEncoder) CopyTextureToTexture(src, dst *Texture, regions []Textu
 		e.trackTexture(region.Source.Texture)
 		e.trackTexture(region.Destination.Texture)
 	}
+	if err := e.validateTextureCopies(src, dst, regions); err != nil {
+		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyTextureToTexture: %w", err))
+		return
+	}
 	e.trackTexture(src)
 	e.trackTexture(dst)
 	raw := e.core.RawEncoder()
@@ -262,6 +376,30 @@ func (e *CommandEncoder) CopyTextureToTexture(src, dst *Texture, regions []Textu
 	raw.CopyTextureToTexture(halSrc, halDst, halRegions)
 }
 
+// validateTextureCopies checks regions against the formats and shapes of src
+// and dst. Copies involving wrapped or surface textures, whose shape is
+// unknown, are left to the backend.
+func (e *CommandEncoder) validateTextureCopies(src, dst *Texture, regions []TextureCopy) error {
+	if e.device == nil || !e.device.core.Validator.Enabled() {
+		return nil
+	}
+	srcDesc, dstDesc := src.viewSource(), dst.viewSource()
+	if srcDesc == nil || dstDesc == nil {
+		return nil
+	}
+	var downlevel hal.DownlevelFlags
+	if caps := e.device.core.ParentAdapter().Capabilities(); caps != nil {
+		downlevel = caps.DownlevelCapabilities.Flags
+	}
+	for i := range regions {
+		region := regions[i].toHAL()
+		if err := e.device.core.Validator.TextureCopy(&region, srcDesc, dstDesc, downlevel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // TransitionTextures transitions texture states for synchronization.
 // This is needed on Vulkan for layout transitions between render pass
 // and copy operations (e.g., after MSAA resolve before CopyTextureToBuffer).
@@ -287,6 +425,7 @@ func (e *CommandEncoder) TransitionTextures(barriers []TextureBarrier) {
 		halBarriers = append(halBarriers, b.toHAL())
 	}
 	if len(halBarriers) > 0 {
+		e.counters().addBarriers(len(halBarriers))
 		raw.TransitionTextures(halBarriers)
 	}
 }
@@ -305,6 +444,9 @@ func (e *CommandEncoder) CopyBufferToTexture(src *Buffer, dst *Texture, regions
 		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyBufferToTexture: destination texture is nil"))
 		return
 	}
+	if !e.checkBufferUsage(src, gputypes.BufferUsageCopySrc, "CommandEncoder.CopyBufferToTexture source") {
+		return
+	}
 	halDst := dst.resolveHAL()
 	if halDst == nil {
 		e.setError(fmt.Errorf("wgpu: CommandEncoder.CopyBufferToTexture: destination texture is released: %w", ErrReleased))
@@ -341,18 +483,43 @@ func validateRenderPassTextureViews(desc *RenderPassDescriptor) error {
 	}
 	for _, attachment := range desc.ColorAttachments {
 		if attachment.View != nil && attachment.View.resolveHAL() == nil {
-			return fmt.Errorf("wgpu: BeginRenderPass: color attachment view is released: %w", ErrReleased)
+			return fmt.Errorf("wgpu: BeginRenderPass: color attachment view is released: %w", attachment.View.releasedError())
 		}
 		if attachment.ResolveTarget != nil && attachment.ResolveTarget.resolveHAL() == nil {
-			return fmt.Errorf("wgpu: BeginRenderPass: resolve target view is released: %w", ErrReleased)
+			return fmt.Errorf("wgpu: BeginRenderPass: resolve target view is released: %w", attachment.ResolveTarget.releasedError())
+		}
+		if attachment.View.isTransient() && !transientOps(attachment.LoadOp, attachment.StoreOp) {
+			return fmt.Errorf("wgpu: BeginRenderPass: transient color attachment must not load or store")
+		}
+		if attachment.ResolveTarget.isTransient() {
+			return fmt.Errorf("wgpu: BeginRenderPass: resolve target must not be transient")
+		}
+		if err := attachment.View.checkViewCount(desc.ViewCount); err != nil {
+			return fmt.Errorf("wgpu: BeginRenderPass: color attachment %w", err)
 		}
 	}
-	if attachment := desc.DepthStencilAttachment; attachment != nil && attachment.View != nil && attachment.View.resolveHAL() == nil {
-		return fmt.Errorf("wgpu: BeginRenderPass: depth/stencil attachment view is released: %w", ErrReleased)
+	if attachment := desc.DepthStencilAttachment; attachment != nil && attachment.View != nil {
+		if attachment.View.resolveHAL() == nil {
+			return fmt.Errorf("wgpu: BeginRenderPass: depth/stencil attachment view is released: %w", attachment.View.releasedError())
+		}
+		if attachment.View.isTransient() &&
+			(!transientOps(attachment.DepthLoadOp, attachment.DepthStoreOp) ||
+				!transientOps(attachment.StencilLoadOp, attachment.StencilStoreOp)) {
+			return fmt.Errorf("wgpu: BeginRenderPass: transient depth/stencil attachment must not load or store")
+		}
+		if err := attachment.View.checkViewCount(desc.ViewCount); err != nil {
+			return fmt.Errorf("wgpu: BeginRenderPass: depth/stencil attachment %w", err)
+		}
 	}
 	return nil
 }
 
+// transientOps reports whether load/store ops are valid for a transient
+// attachment: its contents never exist outside the pass.
+func transientOps(load LoadOp, store StoreOp) bool {
+	return load != gputypes.LoadOpLoad && store != gputypes.StoreOpStore
+}
+
 func trackRenderPassTextureViews(e *CommandEncoder, desc *RenderPassDescriptor) {
 	if e == nil || desc == nil {
 		return
@@ -376,6 +543,9 @@ func (e *CommandEncoder) ClearBuffer(buffer *Buffer, offset, size uint64) {
 	if e.released || buffer == nil {
 		return
 	}
+	if !e.checkBufferUsage(buffer, gputypes.BufferUsageCopyDst, "CommandEncoder.ClearBuffer") {
+		return
+	}
 	raw := e.core.RawEncoder()
 	if raw == nil {
 		return
@@ -426,7 +596,10 @@ func (e *CommandEncoder) returnEncoderToPool() {
 // The HAL encoder ownership transfers from the CommandEncoder to the
 // CommandBuffer. After GPU completion, Submit() schedules the encoder
 // to be reset via ResetAll and returned to the Device's encoder pool.
-func (e *CommandEncoder) Finish() (*CommandBuffer, error) {
+func (e *CommandEncoder) Finish() (_ *CommandBuffer, err error) {
+	defer startSpan("wgpu.CommandEncoder.Finish").End()
+	defer e.device.reportUncaptured("CommandEncoder.Finish", &err)
+
 	if e.released {
 		return nil, ErrReleased
 	}
@@ -457,9 +630,11 @@ func (e *CommandEncoder) Finish() (*CommandBuffer, error) {
 		device:         e.device,
 		trackedRefs:    e.trackedRefs,
 		halEncoder:     e.halEncoder,
+		protected:      e.protected,
 		usedBuffers:    e.usedBuffers,
 		usedTextures:   e.usedTextures,
 		usedBindGroups: e.usedBindGroups,
+		passLabels:     e.passLabels,
 	}
 	e.trackedRefs = nil
 	e.halEncoder = nil     // ownership transferred
@@ -476,7 +651,8 @@ func convertRenderPassDesc(desc *RenderPassDescriptor) *core.RenderPassDescripto
 	}
 
 	coreDesc := &core.RenderPassDescriptor{
-		Label: desc.Label,
+		Label:     desc.Label,
+		ViewCount: desc.ViewCount,
 	}
 
 	for _, ca := range desc.ColorAttachments {
@@ -486,10 +662,10 @@ func convertRenderPassDesc(desc *RenderPassDescriptor) *core.RenderPassDescripto
 			ClearValue: ca.ClearValue,
 		}
 		if ca.View != nil {
-			coreCA.View = &core.TextureView{HAL: ca.View.resolveHAL()}
+			coreCA.View = ca.View.toCore()
 		}
 		if ca.ResolveTarget != nil {
-			coreCA.ResolveTarget = &core.TextureView{HAL: ca.ResolveTarget.resolveHAL()}
+			coreCA.ResolveTarget = ca.ResolveTarget.toCore()
 		}
 		coreDesc.ColorAttachments = append(coreDesc.ColorAttachments, coreCA)
 	}
@@ -507,7 +683,7 @@ func convertRenderPassDesc(desc *RenderPassDescriptor) *core.RenderPassDescripto
 			StencilReadOnly:   ds.StencilReadOnly,
 		}
 		if ds.View != nil {
-			coreDSA.View = &core.TextureView{HAL: ds.View.resolveHAL()}
+			coreDSA.View = ds.View.toCore()
 		}
 		coreDesc.DepthStencilAttachment = coreDSA
 	}
@@ -536,6 +712,10 @@ type CommandBuffer struct {
 	// CommandEncoder -> CommandBuffer -> EncoderInFlight -> GPU done -> pool
 	halEncoder hal.CommandEncoder
 
+	// protected marks a command buffer recorded by a protected encoder.
+	// Submit frees it once the GPU has finished with it.
+	protected bool
+
 	// usedBuffers tracks all buffers referenced during encoding (VAL-A6).
 	// Validated at Submit time: destroyed or mapped buffers cause an error.
 	// Matches Rust wgpu-core's cmd_buf_data.trackers.buffers.used_resources()
@@ -559,6 +739,10 @@ type CommandBuffer struct {
 	// Matches Rust wgpu-core's CommandBuffer::take_finished() which consumes
 	// the buffer, preventing reuse.
 	submitted bool
+
+	// passLabels are the labels of the last passes recorded, from
+	// CommandEncoder.passLabels.
+	passLabels []string
 }
 
 // Release releases a CommandBuffer that will NOT be submitted to the GPU.
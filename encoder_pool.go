@@ -76,6 +76,21 @@ func (p *encoderPool) release(enc hal.CommandEncoder) {
 	p.mu.Unlock()
 }
 
+// trim destroys every idle encoder and returns how many it destroyed.
+// Encoders in the pool have completed their GPU work, so the pool refills
+// on demand afterwards.
+func (p *encoderPool) trim() int {
+	p.mu.Lock()
+	free := p.free
+	p.free = nil
+	p.mu.Unlock()
+
+	for _, enc := range free {
+		enc.Destroy()
+	}
+	return len(free)
+}
+
 // destroy releases all pooled encoders. Called during device shutdown.
 func (p *encoderPool) destroy() {
 	p.mu.Lock()
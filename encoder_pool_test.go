@@ -121,6 +121,38 @@ func TestEncoderPool_DestroyReleasesAll(t *testing.T) {
 	}
 }
 
+func TestEncoderPool_TrimKeepsPoolUsable(t *testing.T) {
+	dev, _, cleanup := createNoopDeviceForTest(t)
+	defer cleanup()
+
+	pool := newEncoderPool(dev)
+	defer pool.destroy()
+
+	enc1, err := pool.acquire()
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	enc2, err := pool.acquire()
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	pool.release(enc1)
+	pool.release(enc2)
+
+	if n := pool.trim(); n != 2 {
+		t.Errorf("trim() = %d, want 2", n)
+	}
+	if n := pool.trim(); n != 0 {
+		t.Errorf("second trim() = %d, want 0", n)
+	}
+
+	enc3, err := pool.acquire()
+	if err != nil {
+		t.Fatalf("acquire after trim failed: %v", err)
+	}
+	pool.release(enc3)
+}
+
 // createNoopDeviceForTest creates a noop HAL device for testing.
 func createNoopDeviceForTest(t *testing.T) (hal.Device, hal.Queue, func()) {
 	t.Helper()
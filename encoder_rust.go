@@ -16,6 +16,15 @@ type CommandEncoder struct {
 	released bool
 }
 
+// counters returns the device's frame counters, or nil when the encoder has
+// no device.
+func (e *CommandEncoder) counters() *frameCounters {
+	if e.device == nil {
+		return nil
+	}
+	return &e.device.frame
+}
+
 // BeginRenderPass begins a render pass.
 func (e *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderPassEncoder, error) {
 	if e.released {
@@ -23,12 +32,23 @@ func (e *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderPas
 	}
 
 	rDesc := convertRenderPassDescriptorRust(desc)
+	if desc != nil {
+		// The binding never reports FeatureMultiview.
+		if err := validateViewCount(desc.ViewCount, 0, 0); err != nil {
+			return nil, err
+		}
+		tw, err := convertTimestampWritesRust(desc.TimestampWrites)
+		if err != nil {
+			return nil, err
+		}
+		rDesc.TimestampWrites = tw
+	}
 	rp, err := e.r.BeginRenderPass(rDesc)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to begin render pass: %w", err)
 	}
 
-	return &RenderPassEncoder{r: rp}, nil
+	return &RenderPassEncoder{r: rp, counters: e.counters()}, nil
 }
 
 // BeginComputePass begins a compute pass.
@@ -39,8 +59,13 @@ func (e *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compute
 
 	var rDesc *rwgpu.ComputePassDescriptor
 	if desc != nil {
+		tw, err := convertTimestampWritesRust(desc.TimestampWrites)
+		if err != nil {
+			return nil, err
+		}
 		rDesc = &rwgpu.ComputePassDescriptor{
-			Label: desc.Label,
+			Label:           desc.Label,
+			TimestampWrites: tw,
 		}
 	}
 
@@ -49,7 +74,7 @@ func (e *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compute
 		return nil, fmt.Errorf("wgpu: failed to begin compute pass: %w", err)
 	}
 
-	return &ComputePassEncoder{r: rp}, nil
+	return &ComputePassEncoder{r: rp, counters: e.counters()}, nil
 }
 
 // CopyBufferToBuffer copies data between buffers.
@@ -60,6 +85,19 @@ func (e *CommandEncoder) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst *
 	e.r.CopyBufferToBuffer(src.r, srcOffset, dst.r, dstOffset, size)
 }
 
+// ResolveQuerySet copies queryCount results starting at firstQuery into
+// destination at destinationOffset, as one uint64 per query. The destination
+// needs BufferUsageQueryResolve and the offset must be a multiple of 256.
+func (e *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery, queryCount uint32, destination *Buffer, destinationOffset uint64) {
+	if e.released {
+		return
+	}
+	if validateResolveQuerySet(querySet, firstQuery, queryCount, destination, destinationOffset) != nil || querySet.released {
+		return
+	}
+	e.r.ResolveQuerySet(querySet.r, firstQuery, queryCount, destination.r, destinationOffset)
+}
+
 // CopyBufferToTexture copies data from a buffer to a texture.
 func (e *CommandEncoder) CopyBufferToTexture(src *Buffer, dst *Texture, regions []BufferTextureCopy) {
 	if e.released || src == nil || dst == nil {
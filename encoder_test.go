@@ -3,6 +3,8 @@
 package wgpu_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gogpu/gputypes"
@@ -715,3 +717,169 @@ func BenchmarkComputePassTrackedRefs(b *testing.B) {
 		}
 	})
 }
+
+// =============================================================================
+// Buffer usage validation (InstanceFlagsValidation)
+// =============================================================================
+
+// newValidatingDevice requests a device from an instance created with
+// gputypes.InstanceFlagsValidation.
+func newValidatingDevice(t *testing.T) *wgpu.Device {
+	t.Helper()
+	inst, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Flags: gputypes.InstanceFlagsValidation})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Release)
+	adapter, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	t.Cleanup(device.Release)
+	requireHAL(t, device)
+	return device
+}
+
+func TestCopyBufferToBufferMissingCopySrc(t *testing.T) {
+	device := newValidatingDevice(t)
+
+	src, err := device.CreateBuffer(&wgpu.BufferDescriptor{Label: "staging", Size: 64, Usage: wgpu.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer src.Release()
+	dst, err := device.CreateBuffer(&wgpu.BufferDescriptor{Label: "dst", Size: 64, Usage: wgpu.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer dst.Release()
+
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	enc.CopyBufferToBuffer(src, 0, dst, 0, 64)
+
+	_, err = enc.Finish()
+	if !errors.Is(err, wgpu.ErrBufferUsage) {
+		t.Fatalf("Finish err = %v, want ErrBufferUsage", err)
+	}
+	if !strings.Contains(err.Error(), `"staging"`) {
+		t.Errorf("error %q does not name the source buffer", err)
+	}
+}
+
+func TestBufferUsageValidationDisabledByDefault(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{Label: "storage", Size: 64, Usage: wgpu.BufferUsageStorage})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	enc.ClearBuffer(buf, 0, 64)
+	if _, err := enc.Finish(); errors.Is(err, wgpu.ErrBufferUsage) {
+		t.Fatalf("Finish err = %v, want no usage error without InstanceFlagsValidation", err)
+	}
+}
+
+// newTargetPipelineAndView creates a pipeline rendering to pipelineFormat and
+// a render target view with viewFormat.
+func newTargetPipelineAndView(t *testing.T, device *wgpu.Device, pipelineFormat, viewFormat gputypes.TextureFormat) (*wgpu.RenderPipeline, *wgpu.TextureView) {
+	t.Helper()
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
+		Label: "compat",
+		WGSL: `@vertex fn vs_main() -> @builtin(position) vec4f { return vec4f(0.0); }
+@fragment fn fs_main() -> @location(0) vec4f { return vec4f(1.0); }`,
+	})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	t.Cleanup(shader.Release)
+	pipeline, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "sprites",
+		Vertex: wgpu.VertexState{Module: shader, EntryPoint: "vs_main"},
+		Fragment: &wgpu.FragmentState{
+			Module: shader, EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{{Format: pipelineFormat, WriteMask: gputypes.ColorWriteMaskAll}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateRenderPipeline: %v", err)
+	}
+	t.Cleanup(pipeline.Release)
+	texture, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+		MipLevelCount: 1, SampleCount: 1, Dimension: gputypes.TextureDimension2D,
+		Format: viewFormat,
+		Usage:  gputypes.TextureUsageRenderAttachment,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	t.Cleanup(texture.Release)
+	view, err := device.CreateTextureView(texture, nil)
+	if err != nil {
+		t.Fatalf("CreateTextureView: %v", err)
+	}
+	t.Cleanup(view.Release)
+	return pipeline, view
+}
+
+func setPipelineInPass(t *testing.T, device *wgpu.Device, pipeline *wgpu.RenderPipeline, view *wgpu.TextureView) error {
+	t.Helper()
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := enc.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label: "hdr",
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View: view, LoadOp: gputypes.LoadOpClear, StoreOp: gputypes.StoreOpStore,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("BeginRenderPass: %v", err)
+	}
+	pass.SetPipeline(pipeline)
+	_ = pass.End()
+	_, err = enc.Finish()
+	return err
+}
+
+func TestSetPipelineIncompatibleColorFormat(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pipeline, view := newTargetPipelineAndView(t, device, gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatRGBA16Float)
+	err := setPipelineInPass(t, device, pipeline, view)
+	if !errors.Is(err, wgpu.ErrIncompatiblePipeline) {
+		t.Fatalf("Finish err = %v, want ErrIncompatiblePipeline", err)
+	}
+	if !strings.Contains(err.Error(), `"sprites"`) || !strings.Contains(err.Error(), `"hdr"`) {
+		t.Errorf("error %q does not name the pipeline and pass", err)
+	}
+}
+
+func TestSetPipelineCompatibleColorFormat(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	pipeline, view := newTargetPipelineAndView(t, device, gputypes.TextureFormatRGBA16Float, gputypes.TextureFormatRGBA16Float)
+	if err := setPipelineInPass(t, device, pipeline, view); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ package wgpu
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
@@ -16,6 +17,16 @@ var (
 	ErrSurfaceLost     = hal.ErrSurfaceLost
 	ErrSurfaceOutdated = hal.ErrSurfaceOutdated
 	ErrTimeout         = hal.ErrTimeout
+
+	// ErrWaitCanceled is returned by Device.WaitForFenceContext when its
+	// context ends before the fence is reached. The error also wraps the
+	// context's error.
+	ErrWaitCanceled = hal.ErrWaitCanceled
+
+	// ErrExternalNotSupported is returned by Adapter.AdoptDevice,
+	// Device.ImportTexture and the fence sharing methods on backends that
+	// cannot wrap or export native objects.
+	ErrExternalNotSupported = hal.ErrExternalNotSupported
 )
 
 // Public API sentinel errors.
@@ -23,11 +34,48 @@ var (
 	// ErrReleased is returned when operating on a released resource.
 	ErrReleased = errors.New("wgpu: resource already released")
 
+	// ErrSurfaceTextureExpired is returned when a view or texture derived
+	// from a SurfaceTexture is used after its frame ended: after Present,
+	// DiscardTexture, Unconfigure or Surface.Release. Using the
+	// SurfaceTexture itself then panics. It wraps ErrReleased.
+	ErrSurfaceTextureExpired = fmt.Errorf("%w: surface texture used after its frame was presented or discarded", ErrReleased)
+
 	// ErrNoAdapters is returned when no GPU adapters are found.
 	ErrNoAdapters = errors.New("wgpu: no GPU adapters available")
 
 	// ErrNoBackends is returned when no backends are registered.
 	ErrNoBackends = errors.New("wgpu: no backends registered (import a backend package)")
+
+	// ErrFeatureNotSupported is returned by RequestDevice when
+	// DeviceDescriptor.RequiredFeatures names a feature the adapter lacks.
+	ErrFeatureNotSupported = core.ErrFeatureNotSupported
+
+	// ErrLimitNotSupported is returned by RequestDevice when
+	// DeviceDescriptor.RequiredLimits exceeds the adapter's limits.
+	ErrLimitNotSupported = core.ErrLimitNotSupported
+
+	// ErrBufferUsage is reported when an instance created with
+	// gputypes.InstanceFlagsValidation sees a command use a buffer without
+	// the usage it requires. The error message names the buffer's label.
+	ErrBufferUsage = core.ErrBufferUsage
+
+	// ErrBufferRange is reported when SetVertexBuffer or SetIndexBuffer is
+	// given a misaligned offset or a range that extends past the buffer.
+	ErrBufferRange = core.ErrBufferRange
+
+	// ErrShaderReloadNotSupported is returned by Device.ReloadShaderModule on
+	// backends that cannot rebuild pipelines in place. The pure-Go backends
+	// always can.
+	ErrShaderReloadNotSupported = errors.New("wgpu: backend cannot reload shader modules")
+
+	// ErrBufferReadRange is returned by Queue.ReadBufferAsync when the range
+	// is not 4-byte aligned or extends past the end of the buffer.
+	ErrBufferReadRange = errors.New("wgpu: buffer read range not 4-byte aligned or out of bounds")
+
+	// ErrIncompatiblePipeline is reported when RenderPass.SetPipeline binds a
+	// pipeline whose color target formats, depth/stencil format or sample
+	// count differ from the pass attachments.
+	ErrIncompatiblePipeline = core.ErrIncompatiblePipeline
 )
 
 // Draw-time validation sentinel errors.
@@ -120,6 +168,16 @@ var (
 	// args extend past the end of the buffer.
 	// Matches Rust wgpu-core IndirectBufferOverrun (compute.rs:903-909).
 	ErrDispatchIndirectBufferOverrun = errors.New("wgpu: indirect dispatch args exceed buffer size")
+
+	// ErrConditionalRenderingBuffer is returned when BeginConditionalRendering
+	// is given a predicate that is not an 8-byte aligned value inside a buffer
+	// with BufferUsageIndirect.
+	ErrConditionalRenderingBuffer = errors.New("wgpu: conditional rendering predicate must be an 8-byte aligned value in an INDIRECT buffer")
+
+	// ErrConditionalRenderingState is returned when conditional rendering is
+	// begun twice, ended without being begun, or left open at the end of the
+	// render pass.
+	ErrConditionalRenderingState = errors.New("wgpu: unbalanced BeginConditionalRendering/EndConditionalRendering")
 )
 
 // Queue.Submit validation sentinel errors (VAL-A6).
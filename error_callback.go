@@ -0,0 +1,118 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// maxLoggedErrors bounds the distinct uncaptured errors the default policy
+// remembers, so a stream of unique messages cannot grow memory without
+// limit. Errors past it are logged every time.
+const maxLoggedErrors = 256
+
+// uncapturedErrors delivers the errors of a device that no error scope
+// captured: to the callback set with SetUncapturedErrorCallback or, by
+// default, to the log, once per distinct message.
+type uncapturedErrors struct {
+	mu       sync.Mutex
+	callback func(*GPUError)
+	logged   map[string]struct{}
+	lost     bool
+}
+
+// SetUncapturedErrorCallback sets the function that receives every GPU
+// error of the device that no error scope captures, as the WebGPU
+// onuncapturederror event does. Errors are reported when a device or queue
+// method returns them, so the callback runs on that method's goroutine,
+// before the method returns. Device loss is reported once, as an
+// ErrorFilterInternal error.
+//
+// By default, each distinct uncaptured error is logged once, with the
+// operation that raised it, to the instance logger or the one set with
+// SetLogger: validation and out-of-memory errors at warning level, device
+// loss at error level. Pass nil to restore the default.
+func (d *Device) SetUncapturedErrorCallback(callback func(*GPUError)) {
+	d.uncaptured.mu.Lock()
+	d.uncaptured.callback = callback
+	d.uncaptured.mu.Unlock()
+}
+
+// reportUncaptured reports *errp, the error operation is returning, to the
+// device's error scopes and then, if none captured it, to the uncaptured
+// error callback. It is deferred by the methods whose errors are GPU errors.
+// Errors that are not, such as using a released object or a canceled
+// context, are not reported.
+func (d *Device) reportUncaptured(operation string, errp *error) {
+	err := *errp
+	if err == nil || d == nil || d.core == nil || d.released.Load() ||
+		errors.Is(err, ErrReleased) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	filter := ErrorFilterValidation
+	lost := errors.Is(err, ErrDeviceLost)
+	switch {
+	case lost:
+		filter = ErrorFilterInternal
+	case errors.Is(err, hal.ErrDeviceOutOfMemory):
+		filter = ErrorFilterOutOfMemory
+	}
+
+	u := &d.uncaptured
+	u.mu.Lock()
+	if lost && u.lost {
+		u.mu.Unlock()
+		return
+	}
+	u.lost = u.lost || lost
+	callback := u.callback
+	u.mu.Unlock()
+
+	if d.core.ReportError(filter, err.Error()) {
+		return
+	}
+	gpuErr := &GPUError{Type: filter, Message: err.Error()}
+	if callback != nil {
+		callback(gpuErr)
+		return
+	}
+	d.logUncaptured(operation, gpuErr)
+}
+
+// logUncaptured is the default uncaptured error policy: it logs gpuErr,
+// raised by operation, unless the same error was logged before.
+func (d *Device) logUncaptured(operation string, gpuErr *GPUError) {
+	key := operation + "\x00" + gpuErr.Message
+	u := &d.uncaptured
+	u.mu.Lock()
+	if _, seen := u.logged[key]; seen {
+		u.mu.Unlock()
+		return
+	}
+	if len(u.logged) < maxLoggedErrors {
+		if u.logged == nil {
+			u.logged = make(map[string]struct{})
+		}
+		u.logged[key] = struct{}{}
+	}
+	u.mu.Unlock()
+
+	level := slog.LevelWarn
+	if gpuErr.Type == ErrorFilterInternal {
+		level = slog.LevelError
+	}
+	logger := hal.Logger()
+	if d.instance != nil && d.instance.core != nil {
+		logger = d.instance.core.Logger(hal.LogGeneral)
+	}
+	logger.Log(context.Background(), level, "wgpu: uncaptured "+gpuErr.Type.String()+" error",
+		"operation", operation,
+		"error", gpuErr.Message,
+	)
+}
@@ -0,0 +1,128 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestUncapturedErrorCallback(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	var got []*wgpu.GPUError
+	device.SetUncapturedErrorCallback(func(err *wgpu.GPUError) {
+		got = append(got, err)
+	})
+
+	if _, err := device.CreateBuffer(nil); err == nil {
+		t.Fatal("CreateBuffer(nil) succeeded")
+	}
+	if len(got) != 1 || got[0].Type != wgpu.ErrorFilterValidation || !strings.Contains(got[0].Message, "nil") {
+		t.Fatalf("uncaptured errors = %v, want one validation error", got)
+	}
+
+	// An error scope takes the error instead of the callback.
+	device.PushErrorScope(wgpu.ErrorFilterValidation)
+	_, err := device.CreateBuffer(nil)
+	if scoped := device.PopErrorScope(); scoped == nil || scoped.Message != err.Error() {
+		t.Errorf("PopErrorScope = %v, want %v", scoped, err)
+	}
+	if len(got) != 1 {
+		t.Errorf("callback ran for a captured error: %v", got[1:])
+	}
+
+	// Successful calls report nothing.
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: 16, Usage: wgpu.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+	if err := device.Queue().WriteBuffer(buf, 2, make([]byte, 4)); err == nil {
+		t.Fatal("unaligned WriteBuffer succeeded")
+	}
+	if len(got) != 2 || got[1].Type != wgpu.ErrorFilterValidation {
+		t.Errorf("uncaptured errors = %v, want the WriteBuffer error", got)
+	}
+}
+
+// recordHandler collects the records logged through it.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	h.records = append(h.records, r)
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *recordHandler) uncaptured() []slog.Record {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var out []slog.Record
+	for _, r := range h.records {
+		if strings.HasPrefix(r.Message, "wgpu: uncaptured") {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestUncapturedErrorsLoggedOnce(t *testing.T) {
+	device := newSoftwareDevice(t)
+	handler := &recordHandler{}
+	wgpu.SetLogger(slog.New(handler))
+	t.Cleanup(func() { wgpu.SetLogger(nil) })
+
+	for range 3 {
+		if _, err := device.CreateTexture(nil); err == nil {
+			t.Fatal("CreateTexture(nil) succeeded")
+		}
+	}
+	records := handler.uncaptured()
+	if len(records) != 1 {
+		t.Fatalf("logged %d uncaptured errors, want 1", len(records))
+	}
+	if records[0].Level != slog.LevelWarn {
+		t.Errorf("level = %v, want warn", records[0].Level)
+	}
+	var operation string
+	records[0].Attrs(func(a slog.Attr) bool {
+		if a.Key == "operation" {
+			operation = a.Value.String()
+		}
+		return true
+	})
+	if operation != "Device.CreateTexture" {
+		t.Errorf("operation = %q, want Device.CreateTexture", operation)
+	}
+
+	// A callback replaces the log; nil restores it.
+	calls := 0
+	device.SetUncapturedErrorCallback(func(*wgpu.GPUError) { calls++ })
+	_, _ = device.CreateBindGroupLayout(nil)
+	device.SetUncapturedErrorCallback(nil)
+	_, _ = device.CreateBindGroupLayout(nil)
+	if calls != 1 || len(handler.uncaptured()) != 2 {
+		t.Errorf("callback ran %d times and %d errors were logged, want 1 and 2", calls, len(handler.uncaptured()))
+	}
+
+	// Using a released device is not a GPU error.
+	device.Release()
+	_, _ = device.CreateTexture(nil)
+	if n := len(handler.uncaptured()); n != 2 {
+		t.Errorf("released device logged %d uncaptured errors, want 2", n)
+	}
+}
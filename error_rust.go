@@ -5,6 +5,8 @@ package wgpu
 import (
 	"errors"
 	"fmt"
+
+	"github.com/gogpu/wgpu/core"
 )
 
 // Public API sentinel errors.
@@ -12,12 +14,54 @@ var (
 	// ErrReleased is returned when operating on a released resource.
 	ErrReleased = errors.New("wgpu: resource already released")
 
+	// ErrSurfaceTextureExpired is returned when a view or texture derived
+	// from a SurfaceTexture is used after its frame ended: after Present,
+	// DiscardTexture, Unconfigure or Surface.Release. Using the
+	// SurfaceTexture itself then panics. It wraps ErrReleased.
+	ErrSurfaceTextureExpired = fmt.Errorf("%w: surface texture used after its frame was presented or discarded", ErrReleased)
+
 	// ErrNoAdapters is returned when no GPU adapters are found.
 	ErrNoAdapters = errors.New("wgpu: no GPU adapters available")
 
 	// ErrNoBackends is returned when no backends are registered.
 	ErrNoBackends = errors.New("wgpu: no backends registered (import a backend package)")
 
+	// ErrFeatureNotSupported is returned by RequestDevice when
+	// DeviceDescriptor.RequiredFeatures names a feature the adapter lacks.
+	ErrFeatureNotSupported = core.ErrFeatureNotSupported
+
+	// ErrExternalNotSupported is returned by Adapter.AdoptDevice,
+	// Device.ImportTexture and the fence sharing methods, which the Rust backend
+	// does not support.
+	ErrExternalNotSupported = errors.New("wgpu: backend cannot adopt native devices or textures")
+
+	// ErrLimitNotSupported is returned by RequestDevice when
+	// DeviceDescriptor.RequiredLimits exceeds the adapter's limits.
+	ErrLimitNotSupported = core.ErrLimitNotSupported
+
+	// ErrBufferUsage is reported when an instance created with
+	// gputypes.InstanceFlagsValidation sees a command use a buffer without
+	// the usage it requires. The error message names the buffer's label.
+	ErrBufferUsage = errors.New("wgpu: buffer used without required usage")
+
+	// ErrBufferRange is reported when SetVertexBuffer or SetIndexBuffer is
+	// given a misaligned offset or a range that extends past the buffer.
+	ErrBufferRange = errors.New("wgpu: buffer range out of bounds")
+
+	// ErrShaderReloadNotSupported is returned by Device.ReloadShaderModule on
+	// backends that cannot rebuild pipelines in place. The pure-Go backends
+	// always can.
+	ErrShaderReloadNotSupported = errors.New("wgpu: backend cannot reload shader modules")
+
+	// ErrBufferReadRange is returned by Queue.ReadBufferAsync when the range
+	// is not 4-byte aligned or extends past the end of the buffer.
+	ErrBufferReadRange = errors.New("wgpu: buffer read range not 4-byte aligned or out of bounds")
+
+	// ErrIncompatiblePipeline is reported when RenderPass.SetPipeline binds a
+	// pipeline whose color target formats, depth/stencil format or sample
+	// count differ from the pass attachments.
+	ErrIncompatiblePipeline = errors.New("wgpu: render pipeline incompatible with render pass")
+
 	// ErrDeviceLost is returned when the GPU device is lost.
 	ErrDeviceLost = errors.New("wgpu: device lost")
 
@@ -33,6 +77,11 @@ var (
 	// ErrTimeout is returned when an operation times out.
 	ErrTimeout = errors.New("wgpu: timeout")
 
+	// ErrWaitCanceled is returned by Device.WaitForFenceContext when its
+	// context ends before the fence is reached. The error also wraps the
+	// context's error.
+	ErrWaitCanceled = errors.New("wgpu: wait canceled")
+
 	// ErrSubmitCommandBufferInvalid is returned when a command buffer is submitted twice.
 	ErrSubmitCommandBufferInvalid = errors.New("wgpu: command buffer already submitted")
 
@@ -71,6 +120,8 @@ var (
 	ErrDispatchIndirectOffsetAlignment = errors.New("wgpu: indirect dispatch buffer offset not 4-byte aligned")
 	ErrDrawIndirectBufferOverrun       = errors.New("wgpu: indirect draw args exceed buffer size")
 	ErrDispatchIndirectBufferOverrun   = errors.New("wgpu: indirect dispatch args exceed buffer size")
+	ErrConditionalRenderingBuffer      = errors.New("wgpu: conditional rendering predicate must be an 8-byte aligned value in an INDIRECT buffer")
+	ErrConditionalRenderingState       = errors.New("wgpu: unbalanced BeginConditionalRendering/EndConditionalRendering")
 )
 
 // GPUError represents a captured GPU error from an error scope.
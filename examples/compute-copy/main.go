@@ -11,7 +11,6 @@ package main
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"log"
 	"math"
@@ -50,7 +49,7 @@ const (
 	numElements = 1024
 	scaleFactor = 2.5
 	bufSize     = uint64(numElements * 4)
-	uniformSize = uint64(8) // count (u32) + scale (f32)
+	uniformSize = uint64(8) // params: count (u32) + scale (f32)
 )
 
 func main() {
@@ -84,12 +83,12 @@ func run() error {
 	}
 	defer pipeline.release()
 
-	resultBytes, err := dispatchAndReadBack(device, pipeline.pipeline, bindGroup, buffers)
+	results, err := dispatchAndReadBack(device, pipeline.pipeline, bindGroup, buffers)
 	if err != nil {
 		return err
 	}
 
-	return verifyResults(resultBytes)
+	return verifyResults(results)
 }
 
 func initDevice() (*wgpu.Device, func(), error) {
@@ -125,10 +124,16 @@ func initDevice() (*wgpu.Device, func(), error) {
 	return device, cleanup, nil
 }
 
-func prepareInput() []byte {
-	inputData := make([]byte, bufSize)
-	for i := uint32(0); i < numElements; i++ {
-		binary.LittleEndian.PutUint32(inputData[i*4:], math.Float32bits(float32(i+1)))
+// params mirrors the shader's uniform struct.
+type params struct {
+	Count uint32
+	Scale float32
+}
+
+func prepareInput() []float32 {
+	inputData := make([]float32, numElements)
+	for i := range inputData {
+		inputData[i] = float32(i + 1)
 	}
 	return inputData
 }
@@ -144,7 +149,7 @@ func (b *bufferSet) release() {
 	b.input.Release()
 }
 
-func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
+func createBuffers(device *wgpu.Device, inputData []float32) (*bufferSet, error) {
 	fmt.Print("5. Creating buffers... ")
 	inputBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "src", Size: bufSize,
@@ -168,10 +173,6 @@ func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
 		return nil, fmt.Errorf("create staging buffer: %w", err)
 	}
 
-	uniformData := make([]byte, uniformSize)
-	binary.LittleEndian.PutUint32(uniformData[0:4], numElements)
-	binary.LittleEndian.PutUint32(uniformData[4:8], math.Float32bits(scaleFactor))
-
 	uniformBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "params", Size: uniformSize,
 		Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
@@ -180,10 +181,10 @@ func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
 		return nil, fmt.Errorf("create uniform buffer: %w", err)
 	}
 
-	if err := device.Queue().WriteBuffer(inputBuf, 0, inputData); err != nil {
+	if err := inputBuf.WriteStructured(0, inputData); err != nil {
 		return nil, fmt.Errorf("write input buffer: %w", err)
 	}
-	if err := device.Queue().WriteBuffer(uniformBuf, 0, uniformData); err != nil {
+	if err := uniformBuf.WriteStructured(0, params{Count: numElements, Scale: scaleFactor}); err != nil {
 		return nil, fmt.Errorf("write uniform buffer: %w", err)
 	}
 	fmt.Println("OK")
@@ -256,7 +257,7 @@ func createPipeline(device *wgpu.Device, bufs *bufferSet) (*pipelineSet, *wgpu.B
 	return ps, bindGroup, nil
 }
 
-func dispatchAndReadBack(device *wgpu.Device, pipeline *wgpu.ComputePipeline, bindGroup *wgpu.BindGroup, bufs *bufferSet) ([]byte, error) {
+func dispatchAndReadBack(device *wgpu.Device, pipeline *wgpu.ComputePipeline, bindGroup *wgpu.BindGroup, bufs *bufferSet) ([]float32, error) {
 	fmt.Print("7. Dispatching compute... ")
 	encoder, err := device.CreateCommandEncoder(nil)
 	if err != nil {
@@ -283,7 +284,6 @@ func dispatchAndReadBack(device *wgpu.Device, pipeline *wgpu.ComputePipeline, bi
 	fmt.Println("OK")
 
 	fmt.Print("8. Reading results... ")
-	resultBytes := make([]byte, bufSize)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := bufs.staging.Map(ctx, wgpu.MapModeRead, 0, bufSize); err != nil {
@@ -294,21 +294,24 @@ func dispatchAndReadBack(device *wgpu.Device, pipeline *wgpu.ComputePipeline, bi
 		_ = bufs.staging.Unmap()
 		return nil, fmt.Errorf("staging MappedRange: %w", err)
 	}
-	copy(resultBytes, rng.Bytes())
+	// BytesToSlice copies, so the results outlive the mapping.
+	results, err := wgpu.BytesToSlice[float32](rng.Bytes())
+	if err != nil {
+		_ = bufs.staging.Unmap()
+		return nil, fmt.Errorf("decode results: %w", err)
+	}
 	if err := bufs.staging.Unmap(); err != nil {
 		return nil, fmt.Errorf("unmap staging buffer: %w", err)
 	}
 	fmt.Println("OK")
-	return resultBytes, nil
+	return results, nil
 }
 
-func verifyResults(resultBytes []byte) error {
+func verifyResults(results []float32) error {
 	const tolerance = 0.001
 	mismatches := 0
 
-	for i := uint32(0); i < numElements; i++ {
-		bits := binary.LittleEndian.Uint32(resultBytes[i*4:])
-		got := math.Float32frombits(bits)
+	for i, got := range results {
 		want := float32(i+1) * scaleFactor
 		if math.Abs(float64(got-want)) > tolerance {
 			if mismatches < 5 {
@@ -320,9 +323,7 @@ func verifyResults(resultBytes []byte) error {
 
 	fmt.Println()
 	fmt.Println("Sample results (first 8):")
-	for i := uint32(0); i < 8; i++ {
-		bits := binary.LittleEndian.Uint32(resultBytes[i*4:])
-		got := math.Float32frombits(bits)
+	for i, got := range results[:8] {
 		fmt.Printf("  [%d] %.1f * %.1f = %.1f\n", i, float32(i+1), scaleFactor, got)
 	}
 
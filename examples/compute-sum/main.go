@@ -11,7 +11,6 @@ package main
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"log"
 	"time"
@@ -126,12 +125,12 @@ func initDevice() (*wgpu.Device, func(), error) {
 	return device, cleanup, nil
 }
 
-func prepareInput() ([]byte, uint32) {
-	inputData := make([]byte, inputBufSize)
+func prepareInput() ([]uint32, uint32) {
+	inputData := make([]uint32, numElements)
 	var cpuSum uint32
-	for i := uint32(0); i < numElements; i++ {
-		binary.LittleEndian.PutUint32(inputData[i*4:], i+1)
-		cpuSum += i + 1
+	for i := range inputData {
+		inputData[i] = uint32(i) + 1
+		cpuSum += inputData[i]
 	}
 	return inputData, cpuSum
 }
@@ -147,7 +146,7 @@ func (b *bufferSet) release() {
 	b.input.Release()
 }
 
-func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
+func createBuffers(device *wgpu.Device, inputData []uint32) (*bufferSet, error) {
 	fmt.Print("5. Creating buffers... ")
 	inputBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "input", Size: inputBufSize,
@@ -171,8 +170,6 @@ func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
 		return nil, fmt.Errorf("create staging buffer: %w", err)
 	}
 
-	uniformData := make([]byte, 4)
-	binary.LittleEndian.PutUint32(uniformData, outCount)
 	uniformBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "params", Size: 4,
 		Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
@@ -181,10 +178,10 @@ func createBuffers(device *wgpu.Device, inputData []byte) (*bufferSet, error) {
 		return nil, fmt.Errorf("create uniform buffer: %w", err)
 	}
 
-	if err := device.Queue().WriteBuffer(inputBuf, 0, inputData); err != nil {
+	if err := inputBuf.WriteStructured(0, inputData); err != nil {
 		return nil, fmt.Errorf("write input buffer: %w", err)
 	}
-	if err := device.Queue().WriteBuffer(uniformBuf, 0, uniformData); err != nil {
+	if err := uniformBuf.WriteStructured(0, uint32(outCount)); err != nil {
 		return nil, fmt.Errorf("write uniform buffer: %w", err)
 	}
 	fmt.Println("OK")
@@ -293,10 +290,14 @@ func dispatchAndReadBack(device *wgpu.Device, ps *pipelineSet, bufs *bufferSet)
 		_ = bufs.staging.Unmap()
 		return 0, fmt.Errorf("staging MappedRange: %w", err)
 	}
-	resultBytes := rng.Bytes()
+	partials, err := wgpu.BytesToSlice[uint32](rng.Bytes())
+	if err != nil {
+		_ = bufs.staging.Unmap()
+		return 0, fmt.Errorf("decode partial sums: %w", err)
+	}
 	var gpuSum uint32
-	for i := 0; i < outCount; i++ {
-		gpuSum += binary.LittleEndian.Uint32(resultBytes[i*4:])
+	for _, partial := range partials {
+		gpuSum += partial
 	}
 	if err := bufs.staging.Unmap(); err != nil {
 		return 0, fmt.Errorf("unmap staging buffer: %w", err)
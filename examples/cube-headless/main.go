@@ -0,0 +1,632 @@
+// Command cube-headless renders a rotating, lit cube with a depth buffer
+// using only the public wgpu API. Each frame updates the transform uniforms
+// with Queue.WriteBuffer and checks that the face nearest the camera, with
+// its lighting, covers the center of the image.
+//
+// On the software and GL backends the frames are presented to a headless
+// wgpu.Surface and read back with Surface.ReadPixels; other backends, which
+// have no headless surface, render into an offscreen texture instead.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=software go run ./examples/cube-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal, gl and software; by default
+// the first available adapter is used.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const shaderWGSL = `
+struct Uniforms {
+    mvp: mat4x4<f32>,
+    model: mat4x4<f32>,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) normal: vec3<f32>,
+    @location(1) color: vec3<f32>,
+}
+
+@group(0) @binding(0) var<uniform> uniforms: Uniforms;
+
+@vertex
+fn vs_main(
+    @location(0) position: vec3<f32>,
+    @location(1) normal: vec3<f32>,
+    @location(2) color: vec3<f32>,
+) -> VertexOutput {
+    var out: VertexOutput;
+    out.position = uniforms.mvp * vec4<f32>(position, 1.0);
+    out.normal = (uniforms.model * vec4<f32>(normal, 0.0)).xyz;
+    out.color = color;
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    let light = normalize(vec3<f32>(0.4, 0.6, 1.0));
+    let diffuse = max(dot(normalize(in.normal), light), 0.0);
+    return vec4<f32>(in.color * (0.2 + 0.8 * diffuse), 1.0);
+}
+`
+
+const (
+	texSize       = 64
+	bytesPerPixel = 4
+	bytesPerRow   = texSize * bytesPerPixel // 256, already copy-aligned
+)
+
+var clearColor = gputypes.Color{R: 0.1, G: 0.1, B: 0.1, A: 1}
+
+// light matches the light direction in the fragment shader.
+var light = normalize([3]float32{0.4, 0.6, 1})
+
+// face is one side of the unit cube: its outward normal, two axes spanning
+// it and its color.
+type face struct {
+	normal, u, v [3]float32
+	color        [3]float32
+}
+
+var faces = []face{
+	{[3]float32{0, 0, 1}, [3]float32{1, 0, 0}, [3]float32{0, 1, 0}, [3]float32{1, 0, 0}},
+	{[3]float32{0, 0, -1}, [3]float32{-1, 0, 0}, [3]float32{0, 1, 0}, [3]float32{0, 1, 0}},
+	{[3]float32{1, 0, 0}, [3]float32{0, 0, -1}, [3]float32{0, 1, 0}, [3]float32{0, 0, 1}},
+	{[3]float32{-1, 0, 0}, [3]float32{0, 0, 1}, [3]float32{0, 1, 0}, [3]float32{1, 1, 0}},
+	{[3]float32{0, 1, 0}, [3]float32{1, 0, 0}, [3]float32{0, 0, -1}, [3]float32{1, 0, 1}},
+	{[3]float32{0, -1, 0}, [3]float32{1, 0, 0}, [3]float32{0, 0, 1}, [3]float32{0, 1, 1}},
+}
+
+// vertex is a cube corner as seen from one face.
+type vertex struct {
+	position [3]float32
+	normal   [3]float32
+	color    [3]float32
+}
+
+// uniforms matches the WGSL Uniforms struct.
+type uniforms struct {
+	mvp   mat4
+	model mat4
+}
+
+// angles are the cube's rotation, in radians, in each rendered frame.
+var angles = []float32{0, 0.7, 1.4, 2.1, 2.8}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Println("SUCCESS: every frame shows the nearest face of the cube, lit")
+}
+
+func run() error {
+	gpu, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer gpu.release()
+
+	target, err := newTarget(gpu)
+	if err != nil {
+		return err
+	}
+	defer target.release()
+
+	r, err := newRenderer(gpu.device, target.format)
+	if err != nil {
+		return err
+	}
+	defer r.release()
+
+	for i, angle := range angles {
+		model := mul(rotateY(angle), rotateX(0.6*angle))
+		view := translate(0, 0, -3)
+		proj := perspective(math.Pi/3, 1, 0.1, 10)
+		frame := uniforms{mvp: mul(proj, mul(view, model)), model: model}
+		if err := wgpu.WriteBufferSlice(gpu.device.Queue(), r.uniforms, 0, []uniforms{frame}); err != nil {
+			return fmt.Errorf("frame %d: write uniforms: %w", i, err)
+		}
+		pixels, err := target.draw(r)
+		if err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+		if err := verify(pixels, model); err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// renderer holds the pipeline and buffers shared by every frame.
+type renderer struct {
+	device    *wgpu.Device
+	depth     *wgpu.Texture
+	depthView *wgpu.TextureView
+	uniforms  *wgpu.Buffer
+	vertices  *wgpu.Buffer
+	indices   *wgpu.Buffer
+	bindGroup *wgpu.BindGroup
+	pipeline  *wgpu.RenderPipeline
+	releases  []func()
+}
+
+func (r *renderer) keep(release func()) { r.releases = append(r.releases, release) }
+
+func (r *renderer) release() {
+	for i := len(r.releases) - 1; i >= 0; i-- {
+		r.releases[i]()
+	}
+}
+
+func newRenderer(device *wgpu.Device, format gputypes.TextureFormat) (_ *renderer, err error) {
+	r := &renderer{device: device}
+	defer func() {
+		if err != nil {
+			r.release()
+		}
+	}()
+
+	if r.depth, err = device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "cube-depth",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatDepth32Float,
+		Usage:         gputypes.TextureUsageRenderAttachment,
+	}); err != nil {
+		return nil, fmt.Errorf("create depth texture: %w", err)
+	}
+	r.keep(r.depth.Release)
+	if r.depthView, err = device.CreateTextureView(r.depth, nil); err != nil {
+		return nil, fmt.Errorf("create depth view: %w", err)
+	}
+	r.keep(r.depthView.Release)
+	if r.uniforms, err = device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "uniforms",
+		Size:  uint64(unsafe.Sizeof(uniforms{})),
+		Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+	}); err != nil {
+		return nil, fmt.Errorf("create uniform buffer: %w", err)
+	}
+	r.keep(r.uniforms.Release)
+
+	vertices, indices := cubeMesh()
+	if r.vertices, err = newBuffer(device, "vertices", wgpu.BufferUsageVertex, vertices); err != nil {
+		return nil, err
+	}
+	r.keep(r.vertices.Release)
+	if r.indices, err = newBuffer(device, "indices", wgpu.BufferUsageIndex, indices); err != nil {
+		return nil, err
+	}
+	r.keep(r.indices.Release)
+
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "cube-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{{
+			Binding:    0,
+			Visibility: gputypes.ShaderStageVertex,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bind group layout: %w", err)
+	}
+	r.keep(bgl.Release)
+	if r.bindGroup, err = device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:   "cube-bg",
+		Layout:  bgl,
+		Entries: []wgpu.BindGroupEntry{{Binding: 0, Buffer: r.uniforms, Size: r.uniforms.Size()}},
+	}); err != nil {
+		return nil, fmt.Errorf("create bind group: %w", err)
+	}
+	r.keep(r.bindGroup.Release)
+
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "cube", WGSL: shaderWGSL})
+	if err != nil {
+		return nil, fmt.Errorf("create shader: %w", err)
+	}
+	r.keep(shader.Release)
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            "cube-layout",
+		BindGroupLayouts: []*wgpu.BindGroupLayout{bgl},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pipeline layout: %w", err)
+	}
+	r.keep(layout.Release)
+
+	// Back faces are not culled, so only the depth test keeps the far side
+	// of the cube, drawn after the near side in some frames, hidden.
+	if r.pipeline, err = device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "cube",
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     shader,
+			EntryPoint: "vs_main",
+			Buffers: []wgpu.VertexBufferLayout{{
+				ArrayStride: uint64(unsafe.Sizeof(vertex{})),
+				StepMode:    gputypes.VertexStepModeVertex,
+				Attributes: []gputypes.VertexAttribute{
+					{Format: gputypes.VertexFormatFloat32x3, Offset: 0, ShaderLocation: 0},
+					{Format: gputypes.VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1},
+					{Format: gputypes.VertexFormatFloat32x3, Offset: 24, ShaderLocation: 2},
+				},
+			}},
+		},
+		Primitive: gputypes.PrimitiveState{
+			Topology: gputypes.PrimitiveTopologyTriangleList,
+			CullMode: gputypes.CullModeNone,
+		},
+		DepthStencil: &wgpu.DepthStencilState{
+			Format:            gputypes.TextureFormatDepth32Float,
+			DepthWriteEnabled: true,
+			DepthCompare:      gputypes.CompareFunctionLess,
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     shader,
+			EntryPoint: "fs_main",
+			Targets:    []gputypes.ColorTargetState{{Format: format, WriteMask: gputypes.ColorWriteMaskAll}},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("create pipeline: %w", err)
+	}
+	r.keep(r.pipeline.Release)
+	return r, nil
+}
+
+// cubeMesh returns four vertices and two triangles for every face of a cube
+// of side 1 centered on the origin.
+func cubeMesh() ([]vertex, []uint16) {
+	var vertices []vertex
+	var indices []uint16
+	for _, f := range faces {
+		base := uint16(len(vertices))
+		for _, corner := range [4][2]float32{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+			var p [3]float32
+			for c := range 3 {
+				p[c] = 0.5 * (f.normal[c] + corner[0]*f.u[c] + corner[1]*f.v[c])
+			}
+			vertices = append(vertices, vertex{position: p, normal: f.normal, color: f.color})
+		}
+		indices = append(indices, base, base+1, base+2, base, base+2, base+3)
+	}
+	return vertices, indices
+}
+
+// encode records the cube draw into view.
+func (r *renderer) encode(view *wgpu.TextureView) (*wgpu.CommandEncoder, error) {
+	encoder, err := r.device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "cube"})
+	if err != nil {
+		return nil, fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: clearColor,
+		}},
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:            r.depthView,
+			DepthLoadOp:     gputypes.LoadOpClear,
+			DepthStoreOp:    gputypes.StoreOpStore,
+			DepthClearValue: 1,
+		},
+	})
+	if err != nil {
+		encoder.DiscardEncoding()
+		return nil, fmt.Errorf("begin render pass: %w", err)
+	}
+	pass.SetPipeline(r.pipeline)
+	pass.SetBindGroup(0, r.bindGroup, nil)
+	pass.SetVertexBuffer(0, r.vertices, 0, wgpu.WholeSize)
+	pass.SetIndexBuffer(r.indices, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
+	pass.DrawIndexed(uint32(len(faces)*6), 1, 0, 0, 0)
+	if err := pass.End(); err != nil {
+		encoder.DiscardEncoding()
+		return nil, fmt.Errorf("end render pass: %w", err)
+	}
+	return encoder, nil
+}
+
+// target is where frames are drawn: a headless surface when the backend has
+// one, an offscreen texture otherwise.
+type target struct {
+	device   *wgpu.Device
+	surface  *wgpu.Surface
+	format   gputypes.TextureFormat
+	texture  *wgpu.Texture
+	view     *wgpu.TextureView
+	readback *wgpu.Buffer
+}
+
+func newTarget(gpu *gpu) (*target, error) {
+	t := &target{device: gpu.device, surface: gpu.surface}
+	if t.surface != nil {
+		caps := gpu.adapter.GetSurfaceCapabilities(t.surface)
+		if caps == nil || len(caps.Formats) == 0 {
+			return nil, fmt.Errorf("surface reports no formats")
+		}
+		t.format = caps.Formats[0]
+		for _, f := range caps.Formats {
+			if f == gputypes.TextureFormatRGBA8Unorm {
+				t.format = f
+			}
+		}
+		if err := t.surface.Configure(gpu.device, &wgpu.SurfaceConfiguration{
+			Width:       texSize,
+			Height:      texSize,
+			Format:      t.format,
+			Usage:       gputypes.TextureUsageRenderAttachment,
+			PresentMode: gputypes.PresentModeFifo,
+			AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+		}); err != nil {
+			return nil, fmt.Errorf("configure surface: %w", err)
+		}
+		fmt.Printf("Presenting to a headless surface (%v)\n", t.format)
+		return t, nil
+	}
+
+	t.format = gputypes.TextureFormatRGBA8Unorm
+	var err error
+	if t.texture, err = gpu.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "cube-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        t.format,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	}); err != nil {
+		return nil, fmt.Errorf("create target: %w", err)
+	}
+	if t.view, err = gpu.device.CreateTextureView(t.texture, nil); err != nil {
+		t.release()
+		return nil, fmt.Errorf("create target view: %w", err)
+	}
+	if t.readback, err = gpu.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  bytesPerRow * texSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	}); err != nil {
+		t.release()
+		return nil, fmt.Errorf("create readback buffer: %w", err)
+	}
+	fmt.Println("Rendering to an offscreen texture")
+	return t, nil
+}
+
+func (t *target) release() {
+	if t.surface != nil {
+		t.surface.Unconfigure()
+	}
+	if t.readback != nil {
+		t.readback.Release()
+	}
+	if t.view != nil {
+		t.view.Release()
+	}
+	if t.texture != nil {
+		t.texture.Release()
+	}
+}
+
+// draw renders one frame and returns its pixels as tightly packed RGBA8.
+func (t *target) draw(r *renderer) ([]byte, error) {
+	if t.surface == nil {
+		return t.drawOffscreen(r)
+	}
+
+	frame, _, err := t.surface.GetCurrentTexture()
+	if err != nil {
+		return nil, fmt.Errorf("acquire surface texture: %w", err)
+	}
+	view, err := frame.View()
+	if err != nil {
+		t.surface.DiscardTexture()
+		return nil, fmt.Errorf("surface texture view: %w", err)
+	}
+	encoder, err := r.encode(view)
+	if err != nil {
+		t.surface.DiscardTexture()
+		return nil, err
+	}
+	commands, err := encoder.Finish()
+	if err != nil {
+		t.surface.DiscardTexture()
+		return nil, fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := t.device.Queue().Submit(commands); err != nil {
+		t.surface.DiscardTexture()
+		return nil, fmt.Errorf("submit: %w", err)
+	}
+	if err := t.surface.Present(frame); err != nil {
+		return nil, fmt.Errorf("present: %w", err)
+	}
+	pixels, err := t.surface.ReadPixels()
+	if err != nil {
+		return nil, fmt.Errorf("read surface: %w", err)
+	}
+	return pixels, nil
+}
+
+func (t *target) drawOffscreen(r *renderer) ([]byte, error) {
+	encoder, err := r.encode(t.view)
+	if err != nil {
+		return nil, err
+	}
+	encoder.CopyTextureToBuffer(t.texture, t.readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: texSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: t.texture},
+		Size:         wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return nil, fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := t.device.Queue().Submit(commands); err != nil {
+		return nil, fmt.Errorf("submit: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	size := uint64(bytesPerRow * texSize)
+	if err := t.readback.Map(ctx, wgpu.MapModeRead, 0, size); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = t.readback.Unmap() }()
+	rng, err := t.readback.MappedRange(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks that the center pixel has the lit color of the face the
+// camera looks at, the one whose rotated normal points most towards +z,
+// and that the corners keep the clear color.
+func verify(pixels []byte, model mat4) error {
+	check := func(x, y int, want [4]byte) error {
+		off := (y*texSize + x) * bytesPerPixel
+		got := pixels[off : off+4]
+		for c := range 4 {
+			if diff := int(got[c]) - int(want[c]); diff < -3 || diff > 3 {
+				return fmt.Errorf("pixel (%d,%d): got %v, want %v", x, y, got, want)
+			}
+		}
+		return nil
+	}
+
+	var front face
+	var normal [3]float32
+	best := float32(-2)
+	for _, f := range faces {
+		n := model.transformDirection(f.normal)
+		if n[2] > best {
+			front, normal, best = f, n, n[2]
+		}
+	}
+	shade := 0.2 + 0.8*max(dot(normal, light), 0)
+	want := [4]byte{toByte(front.color[0] * shade), toByte(front.color[1] * shade), toByte(front.color[2] * shade), 255}
+	if err := check(texSize/2, texSize/2, want); err != nil {
+		return fmt.Errorf("front face %v: %w", front.normal, err)
+	}
+
+	background := [4]byte{toByte(float32(clearColor.R)), toByte(float32(clearColor.G)), toByte(float32(clearColor.B)), 255}
+	for _, p := range [][2]int{{1, 1}, {texSize - 2, 1}, {1, texSize - 2}, {texSize - 2, texSize - 2}} {
+		if err := check(p[0], p[1], background); err != nil {
+			return fmt.Errorf("background: %w", err)
+		}
+	}
+	fmt.Printf("front face %v, color %v OK\n", front.normal, want)
+	return nil
+}
+
+func toByte(v float32) byte { return byte(min(max(v, 0), 1)*255 + 0.5) }
+
+// gpu holds the device and, on backends with headless surfaces, the surface
+// it presents to.
+type gpu struct {
+	instance *wgpu.Instance
+	surface  *wgpu.Surface
+	adapter  *wgpu.Adapter
+	device   *wgpu.Device
+}
+
+func (g *gpu) release() {
+	if g.device != nil {
+		g.device.Release()
+	}
+	if g.surface != nil {
+		g.surface.Release()
+	}
+	if g.adapter != nil {
+		g.adapter.Release()
+	}
+	g.instance.Release()
+}
+
+func initDevice() (*gpu, error) {
+	backends := wgpu.BackendsAll
+	fallback := false
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	case "software":
+		fallback = true
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	g := &gpu{instance: instance}
+
+	// Prefer an adapter that can present to a headless surface; backends
+	// without one fall back to offscreen rendering.
+	if surface, err := instance.CreateSurfaceFromTarget(wgpu.HeadlessSurfaceTarget{}); err == nil {
+		g.adapter, err = instance.RequestAdapter(&wgpu.RequestAdapterOptions{
+			CompatibleSurface:    surface,
+			ForceFallbackAdapter: fallback,
+		})
+		if err == nil {
+			g.surface = surface
+		} else {
+			surface.Release()
+		}
+	}
+	if g.adapter == nil {
+		if g.adapter, err = instance.RequestAdapter(&wgpu.RequestAdapterOptions{ForceFallbackAdapter: fallback}); err != nil {
+			g.release()
+			return nil, fmt.Errorf("RequestAdapter: %w", err)
+		}
+	}
+	fmt.Printf("Adapter: %s (%v)\n", g.adapter.Info().Name, g.adapter.Info().Backend)
+
+	if g.device, err = g.adapter.RequestDevice(nil); err != nil {
+		g.release()
+		return nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return g, nil
+}
+
+// newBuffer creates a buffer with the given usage holding data.
+func newBuffer[T any](device *wgpu.Device, label string, usage wgpu.BufferUsage, data []T) (*wgpu.Buffer, error) {
+	var elem T
+	size := uint64(len(data)) * uint64(unsafe.Sizeof(elem))
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: label,
+		Size:  (size + 3) &^ 3,
+		Usage: usage | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s buffer: %w", label, err)
+	}
+	if err := wgpu.WriteBufferSlice(device.Queue(), buf, 0, data); err != nil {
+		buf.Release()
+		return nil, fmt.Errorf("write %s buffer: %w", label, err)
+	}
+	return buf, nil
+}
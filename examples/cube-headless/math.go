@@ -0,0 +1,78 @@
+package main
+
+import "math"
+
+// mat4 is a column-major 4x4 matrix, the layout of a WGSL mat4x4<f32>.
+type mat4 [16]float32
+
+func identity() mat4 {
+	return mat4{0: 1, 5: 1, 10: 1, 15: 1}
+}
+
+// mul returns a*b, which applies b first.
+func mul(a, b mat4) mat4 {
+	var m mat4
+	for col := range 4 {
+		for row := range 4 {
+			var sum float32
+			for k := range 4 {
+				sum += a[k*4+row] * b[col*4+k]
+			}
+			m[col*4+row] = sum
+		}
+	}
+	return m
+}
+
+func translate(x, y, z float32) mat4 {
+	m := identity()
+	m[12], m[13], m[14] = x, y, z
+	return m
+}
+
+func rotateX(angle float32) mat4 {
+	s, c := sincos(angle)
+	m := identity()
+	m[5], m[6], m[9], m[10] = c, s, -s, c
+	return m
+}
+
+func rotateY(angle float32) mat4 {
+	s, c := sincos(angle)
+	m := identity()
+	m[0], m[2], m[8], m[10] = c, -s, s, c
+	return m
+}
+
+// perspective is a right-handed projection onto WebGPU's [0, 1] depth range.
+func perspective(fovY, aspect, near, far float32) mat4 {
+	f := 1 / float32(math.Tan(float64(fovY)/2))
+	return mat4{
+		0:  f / aspect,
+		5:  f,
+		10: far / (near - far),
+		11: -1,
+		14: near * far / (near - far),
+	}
+}
+
+// transformDirection applies m to the direction d, ignoring translation.
+func (m mat4) transformDirection(d [3]float32) [3]float32 {
+	var out [3]float32
+	for row := range 3 {
+		out[row] = m[row]*d[0] + m[4+row]*d[1] + m[8+row]*d[2]
+	}
+	return out
+}
+
+func sincos(angle float32) (s, c float32) {
+	s64, c64 := math.Sincos(float64(angle))
+	return float32(s64), float32(c64)
+}
+
+func dot(a, b [3]float32) float32 { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+
+func normalize(v [3]float32) [3]float32 {
+	l := float32(math.Sqrt(float64(dot(v, v))))
+	return [3]float32{v[0] / l, v[1] / l, v[2] / l}
+}
@@ -0,0 +1,238 @@
+// Command frame-stats renders a few offscreen frames with a varying number of
+// draw calls and prints a profiler HUD line per frame from
+// Device.FrameStatistics. An engine would draw the same numbers as a text
+// overlay on top of the frame.
+//
+// Usage:
+//
+//	go run . [frames]
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const sceneWGSL = `
+struct Scene {
+    time: f32,
+    count: f32,
+}
+@group(0) @binding(0) var<uniform> scene: Scene;
+
+@vertex
+fn vs_main(@builtin(vertex_index) v: u32, @builtin(instance_index) i: u32) -> @builtin(position) vec4<f32> {
+    var corners = array<vec2<f32>, 3>(vec2<f32>(0.0, 0.05), vec2<f32>(-0.05, -0.05), vec2<f32>(0.05, -0.05));
+    let angle = scene.time + 6.2831853 * f32(i) / scene.count;
+    return vec4<f32>(corners[v] + 0.7 * vec2<f32>(cos(angle), sin(angle)), 0.0, 1.0);
+}
+
+@fragment
+fn fs_main() -> @location(0) vec4<f32> {
+    return vec4<f32>(1.0, 0.6, 0.1, 1.0);
+}
+`
+
+const (
+	texSize     = 256
+	uniformSize = 16
+)
+
+func main() {
+	frames := 8
+	if len(os.Args) > 1 {
+		n, err := strconv.Atoi(os.Args[1])
+		if err != nil || n <= 0 {
+			log.Fatalf("FATAL: invalid frame count %q", os.Args[1])
+		}
+		frames = n
+	}
+	if err := run(frames); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+}
+
+// scene holds the resources every frame renders with.
+type scene struct {
+	target    *wgpu.Texture
+	view      *wgpu.TextureView
+	uniform   *wgpu.Buffer
+	bgLayout  *wgpu.BindGroupLayout
+	bindGroup *wgpu.BindGroup
+	plLayout  *wgpu.PipelineLayout
+	shader    *wgpu.ShaderModule
+	pipeline  *wgpu.RenderPipeline
+}
+
+func (s *scene) release() {
+	s.pipeline.Release()
+	s.shader.Release()
+	s.plLayout.Release()
+	s.bindGroup.Release()
+	s.bgLayout.Release()
+	s.uniform.Release()
+	s.view.Release()
+	s.target.Release()
+}
+
+func run(frames int) error {
+	fmt.Println("=== Frame Statistics HUD ===")
+
+	instance, err := wgpu.CreateInstance(nil)
+	if err != nil {
+		return fmt.Errorf("CreateInstance: %w", err)
+	}
+	defer instance.Release()
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		return fmt.Errorf("RequestAdapter: %w", err)
+	}
+	defer adapter.Release()
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		return fmt.Errorf("RequestDevice: %w", err)
+	}
+	defer device.Release()
+
+	s, err := createScene(device)
+	if err != nil {
+		return err
+	}
+	defer s.release()
+
+	// Discard anything recorded while setting up so frame 0 starts clean.
+	_ = device.FrameStatistics()
+	fmt.Println("frame  draws  dispatches  pipelines  upload(B)  barriers")
+	for frame := 0; frame < frames; frame++ {
+		objects := 8 * (frame%4 + 1)
+		if err := renderFrame(device, s, frame, objects); err != nil {
+			return fmt.Errorf("frame %d: %w", frame, err)
+		}
+		st := device.FrameStatistics()
+		fmt.Printf("%5d  %5d  %10d  %9d  %9d  %8d\n",
+			frame, st.DrawCalls, st.Dispatches, st.PipelineBinds, st.BufferUploadBytes, st.Barriers)
+	}
+	return nil
+}
+
+func createScene(device *wgpu.Device) (*scene, error) {
+	s := &scene{}
+	var err error
+	s.target, err = device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "hud-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create texture: %w", err)
+	}
+	if s.view, err = device.CreateTextureView(s.target, nil); err != nil {
+		return nil, fmt.Errorf("create view: %w", err)
+	}
+	s.uniform, err = device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "scene-uniform", Size: uniformSize,
+		Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create uniform: %w", err)
+	}
+	s.bgLayout, err = device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "scene-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{
+			{Binding: 0, Visibility: wgpu.ShaderStageVertex, Buffer: &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bind group layout: %w", err)
+	}
+	s.bindGroup, err = device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label: "scene-bg", Layout: s.bgLayout,
+		Entries: []wgpu.BindGroupEntry{{Binding: 0, Buffer: s.uniform, Size: uniformSize}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bind group: %w", err)
+	}
+	s.plLayout, err = device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label: "scene-pl", BindGroupLayouts: []*wgpu.BindGroupLayout{s.bgLayout},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pipeline layout: %w", err)
+	}
+	s.shader, err = device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "scene", WGSL: sceneWGSL})
+	if err != nil {
+		return nil, fmt.Errorf("create shader: %w", err)
+	}
+	s.pipeline, err = device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "scene",
+		Layout: s.plLayout,
+		Vertex: wgpu.VertexState{Module: s.shader, EntryPoint: "vs_main"},
+		Fragment: &wgpu.FragmentState{
+			Module:     s.shader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pipeline: %w", err)
+	}
+	return s, nil
+}
+
+// renderFrame updates the scene uniform and draws objects triangles, one
+// draw call each, the way an engine without instancing would.
+func renderFrame(device *wgpu.Device, s *scene, frame, objects int) error {
+	var params [uniformSize]byte
+	binary.LittleEndian.PutUint32(params[0:], math.Float32bits(float32(frame)*0.1))
+	binary.LittleEndian.PutUint32(params[4:], math.Float32bits(float32(objects)))
+	if err := device.Queue().WriteBuffer(s.uniform, 0, params[:]); err != nil {
+		return fmt.Errorf("write uniform: %w", err)
+	}
+
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "frame"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       s.view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{R: 0.05, G: 0.05, B: 0.08, A: 1},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("begin render pass: %w", err)
+	}
+	pass.SetPipeline(s.pipeline)
+	pass.SetBindGroup(0, s.bindGroup, nil)
+	for i := 0; i < objects; i++ {
+		pass.Draw(3, 1, 0, uint32(i))
+	}
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end render pass: %w", err)
+	}
+	cmd, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(cmd); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	return nil
+}
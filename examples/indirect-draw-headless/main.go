@@ -0,0 +1,428 @@
+// Command indirect-draw-headless generates DrawIndirect arguments in a
+// compute pass and consumes them with MultiDrawIndirect in a render pass of
+// the same command encoder. The compute shader writes one record per quad and
+// culls one quad by giving it a zero vertex count; the check reads the target
+// back and expects every other quad drawn and the culled one left clear.
+// It then records a draw past the end of the argument buffer and expects
+// Finish to report ErrDrawIndirectBufferOverrun.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=vulkan go run ./examples/indirect-draw-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal, gl and software; by default
+// the first available adapter is used.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const (
+	quadCount  = 4
+	culledQuad = 2
+
+	texSize       = 64
+	bytesPerPixel = 4
+	bytesPerRow   = texSize * bytesPerPixel // 256, already copy-aligned
+
+	drawArgsSize = 16 // vertexCount, instanceCount, firstVertex, firstInstance
+)
+
+// cullWGSL writes one DrawIndirect record per quad. Quad i draws vertices
+// 6i..6i+5; the culled quad gets a vertex count of zero.
+var cullWGSL = fmt.Sprintf(`
+struct DrawArgs {
+    vertex_count: u32,
+    instance_count: u32,
+    first_vertex: u32,
+    first_instance: u32,
+}
+
+@group(0) @binding(0) var<storage, read_write> args: array<DrawArgs, %d>;
+
+@compute @workgroup_size(%d)
+fn cs_main(@builtin(global_invocation_id) id: vec3<u32>) {
+    let i = id.x;
+    var count = 6u;
+    if (i == %du) {
+        count = 0u;
+    }
+    args[i] = DrawArgs(count, 1u, i * 6u, 0u);
+}
+`, quadCount, quadCount, culledQuad)
+
+// drawWGSL places quad vertex_index/6 in its own quadrant with its own color.
+const drawWGSL = `
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) color: vec4<f32>,
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) vid: u32) -> VertexOutput {
+    var corners = array<vec2<f32>, 6>(
+        vec2<f32>(-0.4, -0.4), vec2<f32>(0.4, -0.4), vec2<f32>(0.4, 0.4),
+        vec2<f32>(-0.4, -0.4), vec2<f32>(0.4, 0.4), vec2<f32>(-0.4, 0.4),
+    );
+    var offsets = array<vec2<f32>, 4>(
+        vec2<f32>(-0.5, 0.5), vec2<f32>(0.5, 0.5),
+        vec2<f32>(-0.5, -0.5), vec2<f32>(0.5, -0.5),
+    );
+    var colors = array<vec4<f32>, 4>(
+        vec4<f32>(1.0, 0.0, 0.0, 1.0), vec4<f32>(0.0, 1.0, 0.0, 1.0),
+        vec4<f32>(0.0, 0.0, 1.0, 1.0), vec4<f32>(1.0, 1.0, 0.0, 1.0),
+    );
+    let quad = vid / 6u;
+    var out: VertexOutput;
+    out.position = vec4<f32>(corners[vid % 6u] + offsets[quad], 0.0, 1.0);
+    out.color = colors[quad];
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    return in.color;
+}
+`
+
+// quadCenters and quadColors mirror drawWGSL's offsets and colors.
+var (
+	quadCenters = [quadCount][2]float32{{-0.5, 0.5}, {0.5, 0.5}, {-0.5, -0.5}, {0.5, -0.5}}
+	quadColors  = [quadCount][4]byte{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}, {255, 255, 0, 255}}
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Println("SUCCESS: GPU-generated draws rendered and the overrun was rejected")
+}
+
+func run() error {
+	device, cleanup, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	texture, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "indirect-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	})
+	if err != nil {
+		return fmt.Errorf("create texture: %w", err)
+	}
+	defer texture.Release()
+
+	view, err := device.CreateTextureView(texture, nil)
+	if err != nil {
+		return fmt.Errorf("create view: %w", err)
+	}
+	defer view.Release()
+
+	// The compute pass writes the records as storage; the render pass reads
+	// them as indirect arguments.
+	args, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "draw-args",
+		Size:  quadCount * drawArgsSize,
+		Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageIndirect,
+	})
+	if err != nil {
+		return fmt.Errorf("create args buffer: %w", err)
+	}
+	defer args.Release()
+
+	readback, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  bytesPerRow * texSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	})
+	if err != nil {
+		return fmt.Errorf("create readback buffer: %w", err)
+	}
+	defer readback.Release()
+
+	p, err := newPipelines(device, args)
+	if err != nil {
+		return err
+	}
+	defer p.release()
+
+	if err := render(device, p, args, view, texture, readback); err != nil {
+		return err
+	}
+	pixels, err := readPixels(readback)
+	if err != nil {
+		return err
+	}
+	if err := verify(pixels); err != nil {
+		return err
+	}
+	return checkOverrun(device, p, args, view)
+}
+
+// pipelines holds the compute pipeline that generates the arguments and the
+// render pipeline that consumes them.
+type pipelines struct {
+	bindGroup *wgpu.BindGroup
+	cull      *wgpu.ComputePipeline
+	draw      *wgpu.RenderPipeline
+	// owned lists every created object in creation order.
+	owned []interface{ Release() }
+}
+
+func (p *pipelines) release() {
+	for i := len(p.owned) - 1; i >= 0; i-- {
+		p.owned[i].Release()
+	}
+}
+
+func newPipelines(device *wgpu.Device, args *wgpu.Buffer) (*pipelines, error) {
+	p := &pipelines{}
+	fail := func(what string, err error) (*pipelines, error) {
+		p.release()
+		return nil, fmt.Errorf("create %s: %w", what, err)
+	}
+
+	cullShader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "cull", WGSL: cullWGSL})
+	if err != nil {
+		return fail("cull shader", err)
+	}
+	p.owned = append(p.owned, cullShader)
+	drawShader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "draw", WGSL: drawWGSL})
+	if err != nil {
+		return fail("draw shader", err)
+	}
+	p.owned = append(p.owned, drawShader)
+	bgLayout, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "cull-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{
+			{Binding: 0, Visibility: wgpu.ShaderStageCompute, Buffer: &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage}},
+		},
+	})
+	if err != nil {
+		return fail("bind group layout", err)
+	}
+	p.owned = append(p.owned, bgLayout)
+	p.bindGroup, err = device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label: "cull-bg", Layout: bgLayout,
+		Entries: []wgpu.BindGroupEntry{{Binding: 0, Buffer: args, Size: quadCount * drawArgsSize}},
+	})
+	if err != nil {
+		return fail("bind group", err)
+	}
+	p.owned = append(p.owned, p.bindGroup)
+	cullLayout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label: "cull-pl", BindGroupLayouts: []*wgpu.BindGroupLayout{bgLayout},
+	})
+	if err != nil {
+		return fail("cull pipeline layout", err)
+	}
+	p.owned = append(p.owned, cullLayout)
+	p.cull, err = device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+		Label: "cull", Layout: cullLayout, Module: cullShader, EntryPoint: "cs_main",
+	})
+	if err != nil {
+		return fail("cull pipeline", err)
+	}
+	p.owned = append(p.owned, p.cull)
+	drawLayout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{Label: "draw-pl"})
+	if err != nil {
+		return fail("draw pipeline layout", err)
+	}
+	p.owned = append(p.owned, drawLayout)
+	p.draw, err = device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "draw",
+		Layout: drawLayout,
+		Vertex: wgpu.VertexState{Module: drawShader, EntryPoint: "vs_main"},
+		Fragment: &wgpu.FragmentState{
+			Module:     drawShader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	})
+	if err != nil {
+		return fail("draw pipeline", err)
+	}
+	p.owned = append(p.owned, p.draw)
+	return p, nil
+}
+
+// render generates the arguments, draws every record with one
+// MultiDrawIndirect, and copies the target into readback.
+func render(device *wgpu.Device, p *pipelines, args *wgpu.Buffer, view *wgpu.TextureView, texture *wgpu.Texture, readback *wgpu.Buffer) error {
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "indirect"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+
+	compute, err := encoder.BeginComputePass(&wgpu.ComputePassDescriptor{Label: "cull"})
+	if err != nil {
+		return fmt.Errorf("begin compute pass: %w", err)
+	}
+	compute.SetPipeline(p.cull)
+	compute.SetBindGroup(0, p.bindGroup, nil)
+	compute.Dispatch(1, 1, 1)
+	if err := compute.End(); err != nil {
+		return fmt.Errorf("end compute pass: %w", err)
+	}
+
+	pass, err := beginPass(encoder, view)
+	if err != nil {
+		return err
+	}
+	pass.SetPipeline(p.draw)
+	pass.MultiDrawIndirect(args, 0, quadCount)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end render pass: %w", err)
+	}
+
+	encoder.CopyTextureToBuffer(texture, readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: texSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: texture},
+		Size:         wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	return nil
+}
+
+// checkOverrun records a draw of one record more than args holds and expects
+// the encoder to reject it.
+func checkOverrun(device *wgpu.Device, p *pipelines, args *wgpu.Buffer, view *wgpu.TextureView) error {
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "overrun"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := beginPass(encoder, view)
+	if err != nil {
+		return err
+	}
+	pass.SetPipeline(p.draw)
+	pass.MultiDrawIndirect(args, 0, quadCount+1)
+	_ = pass.End()
+	commands, err := encoder.Finish()
+	if err == nil {
+		commands.Release()
+		return errors.New("MultiDrawIndirect past the end of the argument buffer was accepted")
+	}
+	if !errors.Is(err, wgpu.ErrDrawIndirectBufferOverrun) {
+		return fmt.Errorf("overrun: got %w, want ErrDrawIndirectBufferOverrun", err)
+	}
+	fmt.Printf("overrun rejected: %v\n", err)
+	return nil
+}
+
+func beginPass(encoder *wgpu.CommandEncoder, view *wgpu.TextureView) (*wgpu.RenderPassEncoder, error) {
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{A: 1},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("begin render pass: %w", err)
+	}
+	return pass, nil
+}
+
+func readPixels(readback *wgpu.Buffer) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	size := uint64(bytesPerRow * texSize)
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, size); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks the pixel at the center of every quad: drawn quads have their
+// color, the culled quad the clear color.
+func verify(pixels []byte) error {
+	for i, center := range quadCenters {
+		x := int((center[0] + 1) / 2 * texSize)
+		y := int((1 - center[1]) / 2 * texSize)
+		off := y*bytesPerRow + x*bytesPerPixel
+		got := pixels[off : off+4]
+		want := quadColors[i]
+		if i == culledQuad {
+			want = [4]byte{0, 0, 0, 255}
+		}
+		for c := range 4 {
+			if diff := int(got[c]) - int(want[c]); diff < -2 || diff > 2 {
+				return fmt.Errorf("quad %d at (%d,%d): got %v, want %v", i, x, y, got, want)
+			}
+		}
+		fmt.Printf("quad %d at (%d,%d): %v OK\n", i, x, y, got)
+	}
+	return nil
+}
+
+func initDevice() (*wgpu.Device, func(), error) {
+	backends := wgpu.BackendsAll
+	var opts *wgpu.RequestAdapterOptions
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	case "software":
+		opts = &wgpu.RequestAdapterOptions{ForceFallbackAdapter: true}
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	adapter, err := instance.RequestAdapter(opts)
+	if err != nil {
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestAdapter: %w", err)
+	}
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		adapter.Release()
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return device, func() {
+		device.Release()
+		adapter.Release()
+		instance.Release()
+	}, nil
+}
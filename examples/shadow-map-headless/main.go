@@ -0,0 +1,489 @@
+// Command shadow-map-headless renders a shadow with a shadow map. A first,
+// depth-only pass draws the scene from a directional light into a depth
+// texture with a pipeline that has no fragment stage and a render pass with
+// no color attachments. A second pass draws the ground seen from above and
+// compares each fragment's light-space depth against the shadow map with a
+// comparison sampler, darkening fragments behind the occluder.
+//
+// The light shines in at an angle, so the shadow lands beside the occluder
+// rather than under it; the program checks one pixel in the shadow and one
+// in the light.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=vulkan go run ./examples/shadow-map-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal and gl; by default the first
+// available adapter is used. The software backend does not keep depth
+// between draws and cannot render shadow maps.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+// lightWGSL maps world positions to the light's clip space: an orthographic
+// projection along +Z, sheared so the light comes in from the right.
+const lightWGSL = `
+fn light_clip(p: vec3<f32>) -> vec4<f32> {
+    return vec4<f32>(p.x + 0.5 * p.z, p.y, p.z, 1.0);
+}
+`
+
+const shadowWGSL = lightWGSL + `
+@vertex
+fn vs_shadow(@location(0) position: vec3<f32>) -> @builtin(position) vec4<f32> {
+    return light_clip(position);
+}
+`
+
+const sceneWGSL = lightWGSL + `
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) shadow_coord: vec3<f32>,
+}
+
+@group(0) @binding(0) var shadow_map: texture_depth_2d;
+@group(0) @binding(1) var shadow_sampler: sampler_comparison;
+
+@vertex
+fn vs_main(@location(0) position: vec3<f32>) -> VertexOutput {
+    var out: VertexOutput;
+    // The camera looks straight down the Z axis.
+    out.position = vec4<f32>(position.xy, 0.5, 1.0);
+    let light = light_clip(position);
+    out.shadow_coord = vec3<f32>(light.x * 0.5 + 0.5, 0.5 - light.y * 0.5, light.z);
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    let lit = textureSampleCompare(shadow_map, shadow_sampler, in.shadow_coord.xy, in.shadow_coord.z - 0.005);
+    let shade = 0.2 + 0.8 * lit;
+    return vec4<f32>(shade, shade, shade, 1.0);
+}
+`
+
+const (
+	texSize       = 64
+	bytesPerPixel = 4
+	bytesPerRow   = texSize * bytesPerPixel // 256, already copy-aligned
+)
+
+// ground covers the whole view at depth 0.75 from the light.
+var ground = []float32{
+	-1, -1, 0.75, 1, -1, 0.75, 1, 1, 0.75,
+	-1, -1, 0.75, 1, 1, 0.75, -1, 1, 0.75,
+}
+
+// occluder is a small quad at depth 0.25, between the light and the ground.
+var occluder = []float32{
+	-0.3, -0.3, 0.25, 0.3, -0.3, 0.25, 0.3, 0.3, 0.25,
+	-0.3, -0.3, 0.25, 0.3, 0.3, 0.25, -0.3, 0.3, 0.25,
+}
+
+// Probe points on the ground, in NDC. The occluder's shadow covers ground
+// x in [-0.55, 0.05].
+var (
+	shadowedPoint = [2]float32{-0.25, 0}
+	litPoint      = [2]float32{0.5, 0}
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Println("SUCCESS: the occluder cast its shadow through a depth-only pass")
+}
+
+func run() error {
+	device, cleanup, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	shadowMap, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "shadow-map",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatDepth32Float,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageTextureBinding,
+	})
+	if err != nil {
+		return fmt.Errorf("create shadow map: %w", err)
+	}
+	defer shadowMap.Release()
+	shadowView, err := device.CreateTextureView(shadowMap, nil)
+	if err != nil {
+		return fmt.Errorf("create shadow map view: %w", err)
+	}
+	defer shadowView.Release()
+
+	target, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "scene-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	})
+	if err != nil {
+		return fmt.Errorf("create target: %w", err)
+	}
+	defer target.Release()
+	targetView, err := device.CreateTextureView(target, nil)
+	if err != nil {
+		return fmt.Errorf("create target view: %w", err)
+	}
+	defer targetView.Release()
+
+	readback, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  bytesPerRow * texSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	})
+	if err != nil {
+		return fmt.Errorf("create readback buffer: %w", err)
+	}
+	defer readback.Release()
+
+	groundBuf, err := newVertexBuffer(device, "ground", ground)
+	if err != nil {
+		return err
+	}
+	defer groundBuf.Release()
+	occluderBuf, err := newVertexBuffer(device, "occluder", occluder)
+	if err != nil {
+		return err
+	}
+	defer occluderBuf.Release()
+
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "shadow-map"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	// The pass resources must outlive the submission.
+	var keep releaser
+	defer keep.release()
+	if err := renderShadowMap(device, encoder, shadowView, groundBuf, occluderBuf, &keep); err != nil {
+		return err
+	}
+	if err := renderScene(device, encoder, targetView, shadowView, groundBuf, &keep); err != nil {
+		return err
+	}
+	encoder.CopyTextureToBuffer(target, readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: texSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: target},
+		Size:         wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+
+	pixels, err := readPixels(readback)
+	if err != nil {
+		return err
+	}
+	return verify(pixels)
+}
+
+// vertexLayout is the layout of ground and occluder: one vec3 position.
+var vertexLayout = []wgpu.VertexBufferLayout{{
+	ArrayStride: 12,
+	StepMode:    gputypes.VertexStepModeVertex,
+	Attributes: []gputypes.VertexAttribute{
+		{Format: gputypes.VertexFormatFloat32x3, Offset: 0, ShaderLocation: 0},
+	},
+}}
+
+// renderShadowMap records the depth-only pass that draws the scene from the
+// light into the shadow map. The resources it creates are
+// added to keep.
+func renderShadowMap(device *wgpu.Device, encoder *wgpu.CommandEncoder, shadowView *wgpu.TextureView, groundBuf, occluderBuf *wgpu.Buffer, keep *releaser) error {
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "shadow", WGSL: shadowWGSL})
+	if err != nil {
+		return fmt.Errorf("create shadow shader: %w", err)
+	}
+	keep.add(shader.Release)
+
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{Label: "shadow-layout"})
+	if err != nil {
+		return fmt.Errorf("create shadow pipeline layout: %w", err)
+	}
+	keep.add(layout.Release)
+
+	// No Fragment state: the pass only writes depth.
+	pipeline, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "shadow",
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     shader,
+			EntryPoint: "vs_shadow",
+			Buffers:    vertexLayout,
+		},
+		DepthStencil: &wgpu.DepthStencilState{
+			Format:            gputypes.TextureFormatDepth32Float,
+			DepthWriteEnabled: true,
+			DepthCompare:      gputypes.CompareFunctionLess,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create shadow pipeline: %w", err)
+	}
+	keep.add(pipeline.Release)
+
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label: "shadow-pass",
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:            shadowView,
+			DepthLoadOp:     gputypes.LoadOpClear,
+			DepthStoreOp:    gputypes.StoreOpStore,
+			DepthClearValue: 1,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("begin shadow pass: %w", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.SetVertexBuffer(0, groundBuf, 0, wgpu.WholeSize)
+	pass.Draw(uint32(len(ground)/3), 1, 0, 0)
+	pass.SetVertexBuffer(0, occluderBuf, 0, wgpu.WholeSize)
+	pass.Draw(uint32(len(occluder)/3), 1, 0, 0)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end shadow pass: %w", err)
+	}
+	return nil
+}
+
+// renderScene records the pass that shades the ground using the shadow map.
+// The resources it creates are added to keep.
+func renderScene(device *wgpu.Device, encoder *wgpu.CommandEncoder, targetView, shadowView *wgpu.TextureView, groundBuf *wgpu.Buffer, keep *releaser) error {
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "scene", WGSL: sceneWGSL})
+	if err != nil {
+		return fmt.Errorf("create scene shader: %w", err)
+	}
+	keep.add(shader.Release)
+
+	sampler, err := device.CreateSampler(&wgpu.SamplerDescriptor{
+		Label:        "shadow-sampler",
+		AddressModeU: gputypes.AddressModeClampToEdge,
+		AddressModeV: gputypes.AddressModeClampToEdge,
+		AddressModeW: gputypes.AddressModeClampToEdge,
+		MagFilter:    gputypes.FilterModeNearest,
+		MinFilter:    gputypes.FilterModeNearest,
+		Compare:      gputypes.CompareFunctionLessEqual,
+	})
+	if err != nil {
+		return fmt.Errorf("create sampler: %w", err)
+	}
+	keep.add(sampler.Release)
+
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "scene-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{
+			{
+				Binding:    0,
+				Visibility: gputypes.ShaderStageFragment,
+				Texture: &gputypes.TextureBindingLayout{
+					SampleType:    gputypes.TextureSampleTypeDepth,
+					ViewDimension: gputypes.TextureViewDimension2D,
+				},
+			},
+			{
+				Binding:    1,
+				Visibility: gputypes.ShaderStageFragment,
+				Sampler:    &gputypes.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeComparison},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create bind group layout: %w", err)
+	}
+	keep.add(bgl.Release)
+
+	bindGroup, err := device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:  "scene-bg",
+		Layout: bgl,
+		Entries: []wgpu.BindGroupEntry{
+			{Binding: 0, TextureView: shadowView},
+			{Binding: 1, Sampler: sampler},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create bind group: %w", err)
+	}
+	keep.add(bindGroup.Release)
+
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            "scene-layout",
+		BindGroupLayouts: []*wgpu.BindGroupLayout{bgl},
+	})
+	if err != nil {
+		return fmt.Errorf("create scene pipeline layout: %w", err)
+	}
+	keep.add(layout.Release)
+
+	pipeline, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "scene",
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     shader,
+			EntryPoint: "vs_main",
+			Buffers:    vertexLayout,
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     shader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create scene pipeline: %w", err)
+	}
+	keep.add(pipeline.Release)
+
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label: "scene-pass",
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       targetView,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{A: 1},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("begin scene pass: %w", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, bindGroup, nil)
+	pass.SetVertexBuffer(0, groundBuf, 0, wgpu.WholeSize)
+	pass.Draw(uint32(len(ground)/3), 1, 0, 0)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end scene pass: %w", err)
+	}
+	return nil
+}
+
+// releaser collects Release calls to run once the commands using the
+// resources have been submitted.
+type releaser []func()
+
+func (r *releaser) add(f func()) { *r = append(*r, f) }
+
+func (r releaser) release() {
+	for i := len(r) - 1; i >= 0; i-- {
+		r[i]()
+	}
+}
+
+// newVertexBuffer creates a vertex buffer holding data.
+func newVertexBuffer[T any](device *wgpu.Device, label string, data []T) (*wgpu.Buffer, error) {
+	var elem T
+	size := uint64(len(data)) * uint64(unsafe.Sizeof(elem))
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: label,
+		Size:  size,
+		Usage: wgpu.BufferUsageVertex | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s buffer: %w", label, err)
+	}
+	if err := wgpu.WriteBufferSlice(device.Queue(), buf, 0, data); err != nil {
+		buf.Release()
+		return nil, fmt.Errorf("write %s buffer: %w", label, err)
+	}
+	return buf, nil
+}
+
+func readPixels(readback *wgpu.Buffer) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	size := uint64(bytesPerRow * texSize)
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, size); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks that the shadowed probe is dark and the lit probe bright.
+func verify(pixels []byte) error {
+	for _, probe := range []struct {
+		name  string
+		point [2]float32
+		want  byte
+	}{
+		{"shadowed", shadowedPoint, 51}, // 0.2 * 255
+		{"lit", litPoint, 255},
+	} {
+		x := int((probe.point[0] + 1) / 2 * texSize)
+		y := int((1 - probe.point[1]) / 2 * texSize)
+		off := y*bytesPerRow + x*bytesPerPixel
+		got := pixels[off : off+4]
+		if diff := int(got[0]) - int(probe.want); diff < -4 || diff > 4 {
+			return fmt.Errorf("%s ground at (%d,%d): got %v, want red %d", probe.name, x, y, got, probe.want)
+		}
+		fmt.Printf("%s ground at (%d,%d): %v OK\n", probe.name, x, y, got)
+	}
+	return nil
+}
+
+func initDevice() (*wgpu.Device, func(), error) {
+	backends := wgpu.BackendsAll
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestAdapter: %w", err)
+	}
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		adapter.Release()
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return device, func() {
+		device.Release()
+		adapter.Release()
+		instance.Release()
+	}, nil
+}
@@ -0,0 +1,391 @@
+// Command skybox-headless samples a cube map the way a skybox does: every
+// fragment samples a texture_cube with an interpolated view direction. The
+// cube has a distinct solid color per face, and the target is split into six
+// columns, each looking straight down one axis, so a wrong face order, a
+// 2D-array view bound as a cube, or a texture created with the wrong target
+// shows up as a wrong column color.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=vulkan go run ./examples/skybox-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal, gl and software; by default
+// the first available adapter is used.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const shaderWGSL = `
+@group(0) @binding(0) var sky: texture_cube<f32>;
+@group(0) @binding(1) var sky_sampler: sampler;
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) direction: vec3<f32>,
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    // Column i of six is a full-height quad looking down face i:
+    // +X, -X, +Y, -Y, +Z, -Z.
+    var dirs = array<vec3<f32>, 6>(
+        vec3<f32>(1.0, 0.0, 0.0),
+        vec3<f32>(-1.0, 0.0, 0.0),
+        vec3<f32>(0.0, 1.0, 0.0),
+        vec3<f32>(0.0, -1.0, 0.0),
+        vec3<f32>(0.0, 0.0, 1.0),
+        vec3<f32>(0.0, 0.0, -1.0),
+    );
+    var corners = array<vec2<f32>, 6>(
+        vec2<f32>(0.0, 0.0),
+        vec2<f32>(1.0, 0.0),
+        vec2<f32>(1.0, 1.0),
+        vec2<f32>(0.0, 0.0),
+        vec2<f32>(1.0, 1.0),
+        vec2<f32>(0.0, 1.0),
+    );
+    let column = index / 6u;
+    let corner = corners[index % 6u];
+    let x = (f32(column) + corner.x) / 6.0 * 2.0 - 1.0;
+    var out: VertexOutput;
+    out.position = vec4<f32>(x, corner.y * 2.0 - 1.0, 0.0, 1.0);
+    out.direction = dirs[column];
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    return textureSample(sky, sky_sampler, in.direction);
+}
+`
+
+const (
+	texSize       = 64
+	faceSize      = 4
+	bytesPerPixel = 4
+	bytesPerRow   = texSize * bytesPerPixel // 256, already copy-aligned
+)
+
+// faceColors is the RGBA8 color of each cube face, in layer order
+// (+X, -X, +Y, -Y, +Z, -Z).
+var faceColors = [6][4]byte{
+	{255, 0, 0, 255},
+	{0, 255, 0, 255},
+	{0, 0, 255, 255},
+	{255, 255, 0, 255},
+	{0, 255, 255, 255},
+	{255, 0, 255, 255},
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Println("SUCCESS: every cube face sampled through its own direction")
+}
+
+func run() error {
+	device, cleanup, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	target, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "skybox-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	})
+	if err != nil {
+		return fmt.Errorf("create target: %w", err)
+	}
+	defer target.Release()
+
+	targetView, err := device.CreateTextureView(target, nil)
+	if err != nil {
+		return fmt.Errorf("create target view: %w", err)
+	}
+	defer targetView.Release()
+
+	cubeView, release, err := newCubeMap(device)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	readback, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  bytesPerRow * texSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	})
+	if err != nil {
+		return fmt.Errorf("create readback buffer: %w", err)
+	}
+	defer readback.Release()
+
+	if err := render(device, targetView, cubeView, target, readback); err != nil {
+		return err
+	}
+	pixels, err := readPixels(readback)
+	if err != nil {
+		return err
+	}
+	return verify(pixels)
+}
+
+// newCubeMap creates a six-layer texture filled with faceColors and returns
+// a cube view of it. TextureBindingViewDimension lets GL create the texture
+// as a cube map up front.
+func newCubeMap(device *wgpu.Device) (*wgpu.TextureView, func(), error) {
+	cube, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:                       "skybox-cube",
+		Size:                        wgpu.Extent3D{Width: faceSize, Height: faceSize, DepthOrArrayLayers: 6},
+		MipLevelCount:               1,
+		SampleCount:                 1,
+		Dimension:                   gputypes.TextureDimension2D,
+		Format:                      gputypes.TextureFormatRGBA8Unorm,
+		Usage:                       gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		TextureBindingViewDimension: gputypes.TextureViewDimensionCube,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create cube texture: %w", err)
+	}
+
+	const faceBytes = faceSize * faceSize * bytesPerPixel
+	data := make([]byte, 0, 6*faceBytes)
+	for _, color := range faceColors {
+		for range faceSize * faceSize {
+			data = append(data, color[:]...)
+		}
+	}
+	err = device.Queue().WriteTexture(
+		&wgpu.ImageCopyTexture{Texture: cube},
+		data,
+		&wgpu.ImageDataLayout{BytesPerRow: faceSize * bytesPerPixel, RowsPerImage: faceSize},
+		&wgpu.Extent3D{Width: faceSize, Height: faceSize, DepthOrArrayLayers: 6},
+	)
+	if err != nil {
+		cube.Release()
+		return nil, nil, fmt.Errorf("write cube faces: %w", err)
+	}
+
+	view, err := device.CreateTextureView(cube, &wgpu.TextureViewDescriptor{
+		Label:     "skybox-cube-view",
+		Dimension: gputypes.TextureViewDimensionCube,
+	})
+	if err != nil {
+		cube.Release()
+		return nil, nil, fmt.Errorf("create cube view: %w", err)
+	}
+	return view, func() {
+		view.Release()
+		cube.Release()
+	}, nil
+}
+
+// render draws the skybox columns and copies the target into readback.
+func render(device *wgpu.Device, targetView, cubeView *wgpu.TextureView, target *wgpu.Texture, readback *wgpu.Buffer) error {
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "skybox", WGSL: shaderWGSL})
+	if err != nil {
+		return fmt.Errorf("create shader: %w", err)
+	}
+	defer shader.Release()
+
+	sampler, err := device.CreateSampler(&wgpu.SamplerDescriptor{
+		Label:        "skybox-sampler",
+		AddressModeU: gputypes.AddressModeClampToEdge,
+		AddressModeV: gputypes.AddressModeClampToEdge,
+		AddressModeW: gputypes.AddressModeClampToEdge,
+		MagFilter:    gputypes.FilterModeNearest,
+		MinFilter:    gputypes.FilterModeNearest,
+	})
+	if err != nil {
+		return fmt.Errorf("create sampler: %w", err)
+	}
+	defer sampler.Release()
+
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "skybox-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{
+			{
+				Binding:    0,
+				Visibility: gputypes.ShaderStageFragment,
+				Texture: &gputypes.TextureBindingLayout{
+					SampleType:    gputypes.TextureSampleTypeFloat,
+					ViewDimension: gputypes.TextureViewDimensionCube,
+				},
+			},
+			{
+				Binding:    1,
+				Visibility: gputypes.ShaderStageFragment,
+				Sampler:    &gputypes.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create bind group layout: %w", err)
+	}
+	defer bgl.Release()
+
+	bindGroup, err := device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:  "skybox-bg",
+		Layout: bgl,
+		Entries: []wgpu.BindGroupEntry{
+			{Binding: 0, TextureView: cubeView},
+			{Binding: 1, Sampler: sampler},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create bind group: %w", err)
+	}
+	defer bindGroup.Release()
+
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            "skybox-layout",
+		BindGroupLayouts: []*wgpu.BindGroupLayout{bgl},
+	})
+	if err != nil {
+		return fmt.Errorf("create pipeline layout: %w", err)
+	}
+	defer layout.Release()
+
+	pipeline, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "skybox",
+		Layout: layout,
+		Vertex: wgpu.VertexState{Module: shader, EntryPoint: "vs_main"},
+		Fragment: &wgpu.FragmentState{
+			Module:     shader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create pipeline: %w", err)
+	}
+	defer pipeline.Release()
+
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "skybox"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       targetView,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{A: 1},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("begin render pass: %w", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, bindGroup, nil)
+	pass.Draw(6*6, 1, 0, 0)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end render pass: %w", err)
+	}
+
+	encoder.CopyTextureToBuffer(target, readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: texSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: target},
+		Size:         wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	return nil
+}
+
+func readPixels(readback *wgpu.Buffer) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	size := uint64(bytesPerRow * texSize)
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, size); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks the pixel at the center of every face's column.
+func verify(pixels []byte) error {
+	names := [6]string{"+X", "-X", "+Y", "-Y", "+Z", "-Z"}
+	y := texSize / 2
+	for i, want := range faceColors {
+		x := (2*i + 1) * texSize / 12
+		off := y*bytesPerRow + x*bytesPerPixel
+		got := pixels[off : off+4]
+		for c := range 4 {
+			if diff := int(got[c]) - int(want[c]); diff < -2 || diff > 2 {
+				return fmt.Errorf("face %s at (%d,%d): got %v, want %v", names[i], x, y, got, want)
+			}
+		}
+		fmt.Printf("face %s at (%d,%d): %v OK\n", names[i], x, y, got)
+	}
+	return nil
+}
+
+func initDevice() (*wgpu.Device, func(), error) {
+	backends := wgpu.BackendsAll
+	var opts *wgpu.RequestAdapterOptions
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	case "software":
+		opts = &wgpu.RequestAdapterOptions{ForceFallbackAdapter: true}
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	adapter, err := instance.RequestAdapter(opts)
+	if err != nil {
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestAdapter: %w", err)
+	}
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		adapter.Release()
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return device, func() {
+		device.Release()
+		adapter.Release()
+		instance.Release()
+	}, nil
+}
@@ -0,0 +1,499 @@
+// Command textured-quad-headless draws an indexed quad textured with a 2x2
+// texture through a bind group holding the texture view, a sampler and a
+// uniform buffer, using only the public wgpu API. It renders two frames,
+// updating the uniform buffer in between to move and tint the quad, and
+// checks every texel's color in both.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=vulkan go run ./examples/textured-quad-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal, gl and software; by default
+// the first available adapter is used.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const shaderWGSL = `
+struct Uniforms {
+    tint: vec4<f32>,
+    offset: vec2<f32>,
+}
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+}
+
+@group(0) @binding(0) var tex: texture_2d<f32>;
+@group(0) @binding(1) var samp: sampler;
+@group(0) @binding(2) var<uniform> uniforms: Uniforms;
+
+@vertex
+fn vs_main(@location(0) position: vec2<f32>, @location(1) uv: vec2<f32>) -> VertexOutput {
+    var out: VertexOutput;
+    out.position = vec4<f32>(position + uniforms.offset, 0.0, 1.0);
+    out.uv = uv;
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    return textureSample(tex, samp, in.uv) * uniforms.tint;
+}
+`
+
+const (
+	texSize       = 64
+	bytesPerPixel = 4
+	bytesPerRow   = texSize * bytesPerPixel // 256, already copy-aligned
+)
+
+// vertex is a quad corner: NDC position and texture coordinate.
+type vertex struct {
+	position [2]float32
+	uv       [2]float32
+}
+
+// The quad covers NDC [-0.5, 0.5], the middle 32x32 pixels of the target,
+// with v = 0 at the top.
+var (
+	vertices = []vertex{
+		{[2]float32{-0.5, 0.5}, [2]float32{0, 0}},
+		{[2]float32{0.5, 0.5}, [2]float32{1, 0}},
+		{[2]float32{0.5, -0.5}, [2]float32{1, 1}},
+		{[2]float32{-0.5, -0.5}, [2]float32{0, 1}},
+	}
+	indices = []uint16{0, 3, 2, 0, 2, 1}
+)
+
+// texels is the 2x2 RGBA8 texture, row by row from the top.
+var texels = [2][2][4]byte{
+	{{255, 0, 0, 255}, {0, 255, 0, 255}},
+	{{0, 0, 255, 255}, {255, 255, 255, 255}},
+}
+
+// uniforms matches the WGSL Uniforms struct, padded to its 32-byte size.
+type uniforms struct {
+	tint   [4]float32
+	offset [2]float32
+	_      [2]float32
+}
+
+// frames are the uniforms of each rendered frame.
+var frames = []uniforms{
+	{tint: [4]float32{1, 1, 1, 1}},
+	{tint: [4]float32{0.5, 1, 1, 1}, offset: [2]float32{0.25, -0.25}},
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Println("SUCCESS: textured quad rendered with every uniform update")
+}
+
+func run() error {
+	device, cleanup, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	r, err := newRenderer(device)
+	if err != nil {
+		return err
+	}
+	defer r.release()
+
+	for i, frame := range frames {
+		if err := wgpu.WriteBufferSlice(device.Queue(), r.uniforms, 0, []uniforms{frame}); err != nil {
+			return fmt.Errorf("frame %d: write uniforms: %w", i, err)
+		}
+		if err := r.render(); err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+		pixels, err := readPixels(r.readback)
+		if err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+		if err := verify(pixels, frame); err != nil {
+			return fmt.Errorf("frame %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// renderer holds the resources shared by every frame.
+type renderer struct {
+	device    *wgpu.Device
+	target    *wgpu.Texture
+	view      *wgpu.TextureView
+	readback  *wgpu.Buffer
+	uniforms  *wgpu.Buffer
+	vertices  *wgpu.Buffer
+	indices   *wgpu.Buffer
+	bindGroup *wgpu.BindGroup
+	pipeline  *wgpu.RenderPipeline
+	releases  []func()
+}
+
+func (r *renderer) keep(release func()) { r.releases = append(r.releases, release) }
+
+func (r *renderer) release() {
+	for i := len(r.releases) - 1; i >= 0; i-- {
+		r.releases[i]()
+	}
+}
+
+func newRenderer(device *wgpu.Device) (_ *renderer, err error) {
+	r := &renderer{device: device}
+	defer func() {
+		if err != nil {
+			r.release()
+		}
+	}()
+
+	if r.target, err = device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "textured-quad-target",
+		Size:          wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	}); err != nil {
+		return nil, fmt.Errorf("create target: %w", err)
+	}
+	r.keep(r.target.Release)
+	if r.view, err = device.CreateTextureView(r.target, nil); err != nil {
+		return nil, fmt.Errorf("create target view: %w", err)
+	}
+	r.keep(r.view.Release)
+	if r.readback, err = device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  bytesPerRow * texSize,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	}); err != nil {
+		return nil, fmt.Errorf("create readback buffer: %w", err)
+	}
+	r.keep(r.readback.Release)
+	if r.uniforms, err = device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "uniforms",
+		Size:  uint64(unsafe.Sizeof(uniforms{})),
+		Usage: wgpu.BufferUsageUniform | wgpu.BufferUsageCopyDst,
+	}); err != nil {
+		return nil, fmt.Errorf("create uniform buffer: %w", err)
+	}
+	r.keep(r.uniforms.Release)
+	if r.vertices, err = newBuffer(device, "vertices", wgpu.BufferUsageVertex, vertices); err != nil {
+		return nil, err
+	}
+	r.keep(r.vertices.Release)
+	if r.indices, err = newBuffer(device, "indices", wgpu.BufferUsageIndex, indices); err != nil {
+		return nil, err
+	}
+	r.keep(r.indices.Release)
+
+	tex, texView, err := newTexture(device)
+	if err != nil {
+		return nil, err
+	}
+	r.keep(tex.Release)
+	r.keep(texView.Release)
+	sampler, err := device.CreateSampler(&wgpu.SamplerDescriptor{
+		Label:        "textured-quad-sampler",
+		AddressModeU: gputypes.AddressModeClampToEdge,
+		AddressModeV: gputypes.AddressModeClampToEdge,
+		AddressModeW: gputypes.AddressModeClampToEdge,
+		MagFilter:    gputypes.FilterModeNearest,
+		MinFilter:    gputypes.FilterModeNearest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create sampler: %w", err)
+	}
+	r.keep(sampler.Release)
+
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "textured-quad-bgl",
+		Entries: []wgpu.BindGroupLayoutEntry{
+			{
+				Binding:    0,
+				Visibility: gputypes.ShaderStageFragment,
+				Texture: &gputypes.TextureBindingLayout{
+					SampleType:    gputypes.TextureSampleTypeFloat,
+					ViewDimension: gputypes.TextureViewDimension2D,
+				},
+			},
+			{
+				Binding:    1,
+				Visibility: gputypes.ShaderStageFragment,
+				Sampler:    &gputypes.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+			},
+			{
+				Binding:    2,
+				Visibility: gputypes.ShaderStageVertex | gputypes.ShaderStageFragment,
+				Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create bind group layout: %w", err)
+	}
+	r.keep(bgl.Release)
+	if r.bindGroup, err = device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label:  "textured-quad-bg",
+		Layout: bgl,
+		Entries: []wgpu.BindGroupEntry{
+			{Binding: 0, TextureView: texView},
+			{Binding: 1, Sampler: sampler},
+			{Binding: 2, Buffer: r.uniforms, Size: r.uniforms.Size()},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("create bind group: %w", err)
+	}
+	r.keep(r.bindGroup.Release)
+
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "textured-quad", WGSL: shaderWGSL})
+	if err != nil {
+		return nil, fmt.Errorf("create shader: %w", err)
+	}
+	r.keep(shader.Release)
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            "textured-quad-layout",
+		BindGroupLayouts: []*wgpu.BindGroupLayout{bgl},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pipeline layout: %w", err)
+	}
+	r.keep(layout.Release)
+	if r.pipeline, err = device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+		Label:  "textured-quad",
+		Layout: layout,
+		Vertex: wgpu.VertexState{
+			Module:     shader,
+			EntryPoint: "vs_main",
+			Buffers: []wgpu.VertexBufferLayout{{
+				ArrayStride: uint64(unsafe.Sizeof(vertex{})),
+				StepMode:    gputypes.VertexStepModeVertex,
+				Attributes: []gputypes.VertexAttribute{
+					{Format: gputypes.VertexFormatFloat32x2, Offset: 0, ShaderLocation: 0},
+					{Format: gputypes.VertexFormatFloat32x2, Offset: 8, ShaderLocation: 1},
+				},
+			}},
+		},
+		Fragment: &wgpu.FragmentState{
+			Module:     shader,
+			EntryPoint: "fs_main",
+			Targets: []gputypes.ColorTargetState{
+				{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: gputypes.ColorWriteMaskAll},
+			},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("create pipeline: %w", err)
+	}
+	r.keep(r.pipeline.Release)
+	return r, nil
+}
+
+// newTexture uploads texels to a new texture and returns it with its view.
+func newTexture(device *wgpu.Device) (*wgpu.Texture, *wgpu.TextureView, error) {
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "textured-quad-texels",
+		Size:          wgpu.Extent3D{Width: 2, Height: 2, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create texture: %w", err)
+	}
+	data := make([]byte, 0, 2*2*bytesPerPixel)
+	for _, row := range texels {
+		for _, texel := range row {
+			data = append(data, texel[:]...)
+		}
+	}
+	err = device.Queue().WriteTexture(
+		&wgpu.ImageCopyTexture{Texture: tex},
+		data,
+		&wgpu.ImageDataLayout{BytesPerRow: 2 * bytesPerPixel, RowsPerImage: 2},
+		&wgpu.Extent3D{Width: 2, Height: 2, DepthOrArrayLayers: 1},
+	)
+	if err != nil {
+		tex.Release()
+		return nil, nil, fmt.Errorf("write texture: %w", err)
+	}
+	view, err := device.CreateTextureView(tex, nil)
+	if err != nil {
+		tex.Release()
+		return nil, nil, fmt.Errorf("create texture view: %w", err)
+	}
+	return tex, view, nil
+}
+
+// render draws the quad and copies the target into readback.
+func (r *renderer) render() error {
+	encoder, err := r.device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "textured-quad"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       r.view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{A: 1},
+		}},
+	})
+	if err != nil {
+		encoder.DiscardEncoding()
+		return fmt.Errorf("begin render pass: %w", err)
+	}
+	pass.SetPipeline(r.pipeline)
+	pass.SetBindGroup(0, r.bindGroup, nil)
+	pass.SetVertexBuffer(0, r.vertices, 0, wgpu.WholeSize)
+	pass.SetIndexBuffer(r.indices, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
+	pass.DrawIndexed(uint32(len(indices)), 1, 0, 0, 0)
+	if err := pass.End(); err != nil {
+		encoder.DiscardEncoding()
+		return fmt.Errorf("end render pass: %w", err)
+	}
+
+	encoder.CopyTextureToBuffer(r.target, r.readback, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: texSize},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: r.target},
+		Size:         wgpu.Extent3D{Width: texSize, Height: texSize, DepthOrArrayLayers: 1},
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := r.device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	return nil
+}
+
+// newBuffer creates a buffer with the given usage holding data.
+func newBuffer[T any](device *wgpu.Device, label string, usage wgpu.BufferUsage, data []T) (*wgpu.Buffer, error) {
+	var elem T
+	size := uint64(len(data)) * uint64(unsafe.Sizeof(elem))
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: label,
+		Size:  (size + 3) &^ 3,
+		Usage: usage | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s buffer: %w", label, err)
+	}
+	if err := wgpu.WriteBufferSlice(device.Queue(), buf, 0, data); err != nil {
+		buf.Release()
+		return nil, fmt.Errorf("write %s buffer: %w", label, err)
+	}
+	return buf, nil
+}
+
+func readPixels(readback *wgpu.Buffer) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	size := uint64(bytesPerRow * texSize)
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, size); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, size)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks the center of every texel's 16x16 pixel square and a pixel
+// outside the quad, which must keep the clear color.
+func verify(pixels []byte, frame uniforms) error {
+	check := func(x, y int, want [4]byte) error {
+		off := y*bytesPerRow + x*bytesPerPixel
+		got := pixels[off : off+4]
+		for c := range 4 {
+			if diff := int(got[c]) - int(want[c]); diff < -2 || diff > 2 {
+				return fmt.Errorf("pixel (%d,%d): got %v, want %v", x, y, got, want)
+			}
+		}
+		return nil
+	}
+
+	// Top-left pixel of the quad after the frame's offset.
+	left := int((frame.offset[0] - 0.5 + 1) / 2 * texSize)
+	top := int((1 - (frame.offset[1] + 0.5)) / 2 * texSize)
+	for ty, row := range texels {
+		for tx, texel := range row {
+			var want [4]byte
+			for c := range 4 {
+				want[c] = byte(float32(texel[c])*frame.tint[c] + 0.5)
+			}
+			x, y := left+tx*16+8, top+ty*16+8
+			if err := check(x, y, want); err != nil {
+				return fmt.Errorf("texel (%d,%d): %w", tx, ty, err)
+			}
+		}
+	}
+	if err := check(2, 2, [4]byte{0, 0, 0, 255}); err != nil {
+		return fmt.Errorf("background: %w", err)
+	}
+	fmt.Printf("quad at (%d,%d), tint %v OK\n", left, top, frame.tint)
+	return nil
+}
+
+func initDevice() (*wgpu.Device, func(), error) {
+	backends := wgpu.BackendsAll
+	var opts *wgpu.RequestAdapterOptions
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	case "software":
+		opts = &wgpu.RequestAdapterOptions{ForceFallbackAdapter: true}
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	adapter, err := instance.RequestAdapter(opts)
+	if err != nil {
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestAdapter: %w", err)
+	}
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		adapter.Release()
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return device, func() {
+		device.Release()
+		adapter.Release()
+		instance.Release()
+	}, nil
+}
@@ -0,0 +1,340 @@
+// Command volume-compute-headless fills a 3D texture from a compute shader
+// through a write-only storage binding, then reads every voxel back with
+// textureLoad from a second compute pass. Each voxel encodes its own
+// coordinate, so a storage image bound as a single slice, a wrong layered
+// flag on GL, or a missing barrier between the passes shows up as a
+// mismatched voxel.
+//
+// Usage:
+//
+//	GOGPU_GRAPHICS_API=vulkan go run ./examples/volume-compute-headless/
+//
+// GOGPU_GRAPHICS_API accepts dx12, vulkan, metal, gl and software; by default
+// the first available adapter is used.
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+
+	_ "github.com/gogpu/wgpu/hal/allbackends"
+)
+
+const fillWGSL = `
+@group(0) @binding(0) var volume: texture_storage_3d<rgba8unorm, write>;
+
+@compute @workgroup_size(4, 4, 4)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    let c = vec3<f32>(id) / 7.0;
+    textureStore(volume, vec3<i32>(id), vec4<f32>(c, 1.0));
+}
+`
+
+const readWGSL = `
+@group(0) @binding(0) var volume: texture_3d<f32>;
+@group(0) @binding(1) var<storage, read_write> voxels: array<vec4<f32>>;
+
+@compute @workgroup_size(4, 4, 4)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    let index = (id.z * 8u + id.y) * 8u + id.x;
+    voxels[index] = textureLoad(volume, vec3<i32>(id), 0);
+}
+`
+
+const (
+	volumeSize = 8
+	voxelCount = volumeSize * volumeSize * volumeSize
+	voxelBytes = voxelCount * 16 // one vec4<f32> per voxel
+	workgroups = volumeSize / 4
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+	fmt.Printf("SUCCESS: %d voxels written by compute and read back\n", voxelCount)
+}
+
+func run() error {
+	device, cleanup, err := initDevice()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	volume, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "volume",
+		Size:          wgpu.Extent3D{Width: volumeSize, Height: volumeSize, DepthOrArrayLayers: volumeSize},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension3D,
+		Format:        gputypes.TextureFormatRGBA8Unorm,
+		Usage:         gputypes.TextureUsageStorageBinding | gputypes.TextureUsageTextureBinding,
+	})
+	if err != nil {
+		return fmt.Errorf("create volume: %w", err)
+	}
+	defer volume.Release()
+
+	view, err := device.CreateTextureView(volume, nil)
+	if err != nil {
+		return fmt.Errorf("create volume view: %w", err)
+	}
+	defer view.Release()
+
+	voxels, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "voxels",
+		Size:  voxelBytes,
+		Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		return fmt.Errorf("create voxel buffer: %w", err)
+	}
+	defer voxels.Release()
+
+	readback, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "readback",
+		Size:  voxelBytes,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageMapRead,
+	})
+	if err != nil {
+		return fmt.Errorf("create readback buffer: %w", err)
+	}
+	defer readback.Release()
+
+	fill, err := newPass(device, "fill", fillWGSL, []wgpu.BindGroupLayoutEntry{{
+		Binding:    0,
+		Visibility: gputypes.ShaderStageCompute,
+		StorageTexture: &gputypes.StorageTextureBindingLayout{
+			Access:        gputypes.StorageTextureAccessWriteOnly,
+			Format:        gputypes.TextureFormatRGBA8Unorm,
+			ViewDimension: gputypes.TextureViewDimension3D,
+		},
+	}}, []wgpu.BindGroupEntry{{Binding: 0, TextureView: view}})
+	if err != nil {
+		return err
+	}
+	defer fill.release()
+
+	read, err := newPass(device, "read", readWGSL, []wgpu.BindGroupLayoutEntry{
+		{
+			Binding:    0,
+			Visibility: gputypes.ShaderStageCompute,
+			Texture: &gputypes.TextureBindingLayout{
+				SampleType:    gputypes.TextureSampleTypeFloat,
+				ViewDimension: gputypes.TextureViewDimension3D,
+			},
+		},
+		{
+			Binding:    1,
+			Visibility: gputypes.ShaderStageCompute,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage},
+		},
+	}, []wgpu.BindGroupEntry{
+		{Binding: 0, TextureView: view},
+		{Binding: 1, Buffer: voxels, Size: voxelBytes},
+	})
+	if err != nil {
+		return err
+	}
+	defer read.release()
+
+	if err := dispatch(device, volume, fill, read, voxels, readback); err != nil {
+		return err
+	}
+	data, err := readBuffer(readback)
+	if err != nil {
+		return err
+	}
+	return verify(data)
+}
+
+// computePass bundles a compute pipeline with its single bind group.
+type computePass struct {
+	shader    *wgpu.ShaderModule
+	bgl       *wgpu.BindGroupLayout
+	layout    *wgpu.PipelineLayout
+	bindGroup *wgpu.BindGroup
+	pipeline  *wgpu.ComputePipeline
+}
+
+func (p *computePass) release() {
+	for _, r := range []interface{ Release() }{p.pipeline, p.bindGroup, p.layout, p.bgl, p.shader} {
+		r.Release()
+	}
+}
+
+func newPass(device *wgpu.Device, label, source string, layoutEntries []wgpu.BindGroupLayoutEntry, entries []wgpu.BindGroupEntry) (*computePass, error) {
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: label, WGSL: source})
+	if err != nil {
+		return nil, fmt.Errorf("create %s shader: %w", label, err)
+	}
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{Label: label, Entries: layoutEntries})
+	if err != nil {
+		shader.Release()
+		return nil, fmt.Errorf("create %s bind group layout: %w", label, err)
+	}
+	bindGroup, err := device.CreateBindGroup(&wgpu.BindGroupDescriptor{Label: label, Layout: bgl, Entries: entries})
+	if err != nil {
+		bgl.Release()
+		shader.Release()
+		return nil, fmt.Errorf("create %s bind group: %w", label, err)
+	}
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{
+		Label:            label,
+		BindGroupLayouts: []*wgpu.BindGroupLayout{bgl},
+	})
+	if err != nil {
+		bindGroup.Release()
+		bgl.Release()
+		shader.Release()
+		return nil, fmt.Errorf("create %s pipeline layout: %w", label, err)
+	}
+	pipeline, err := device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+		Label: label, Layout: layout, Module: shader, EntryPoint: "main",
+	})
+	if err != nil {
+		layout.Release()
+		bindGroup.Release()
+		bgl.Release()
+		shader.Release()
+		return nil, fmt.Errorf("create %s pipeline: %w", label, err)
+	}
+	return &computePass{shader: shader, bgl: bgl, layout: layout, bindGroup: bindGroup, pipeline: pipeline}, nil
+}
+
+// dispatch runs the fill and read passes with the storage-to-sampled
+// transition between them, then copies the voxels into readback.
+func dispatch(device *wgpu.Device, volume *wgpu.Texture, fill, read *computePass, voxels, readback *wgpu.Buffer) error {
+	encoder, err := device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{Label: "volume"})
+	if err != nil {
+		return fmt.Errorf("create encoder: %w", err)
+	}
+	volumeRange := wgpu.TextureRange{MipLevelCount: 1, ArrayLayerCount: 1}
+
+	encoder.TransitionTextures([]wgpu.TextureBarrier{{
+		Texture: volume,
+		Range:   volumeRange,
+		Usage:   wgpu.TextureUsageTransition{NewUsage: gputypes.TextureUsageStorageBinding},
+	}})
+	if err := runPass(encoder, fill); err != nil {
+		return err
+	}
+
+	encoder.TransitionTextures([]wgpu.TextureBarrier{{
+		Texture: volume,
+		Range:   volumeRange,
+		Usage: wgpu.TextureUsageTransition{
+			OldUsage: gputypes.TextureUsageStorageBinding,
+			NewUsage: gputypes.TextureUsageTextureBinding,
+		},
+	}})
+	if err := runPass(encoder, read); err != nil {
+		return err
+	}
+
+	encoder.CopyBufferToBuffer(voxels, 0, readback, 0, voxelBytes)
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("finish encoder: %w", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		return fmt.Errorf("submit: %w", err)
+	}
+	return nil
+}
+
+func runPass(encoder *wgpu.CommandEncoder, p *computePass) error {
+	pass, err := encoder.BeginComputePass(nil)
+	if err != nil {
+		return fmt.Errorf("begin compute pass: %w", err)
+	}
+	pass.SetPipeline(p.pipeline)
+	pass.SetBindGroup(0, p.bindGroup, nil)
+	pass.Dispatch(workgroups, workgroups, workgroups)
+	if err := pass.End(); err != nil {
+		return fmt.Errorf("end compute pass: %w", err)
+	}
+	return nil
+}
+
+func readBuffer(readback *wgpu.Buffer) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := readback.Map(ctx, wgpu.MapModeRead, 0, voxelBytes); err != nil {
+		return nil, fmt.Errorf("map readback: %w", err)
+	}
+	defer func() { _ = readback.Unmap() }()
+	rng, err := readback.MappedRange(0, voxelBytes)
+	if err != nil {
+		return nil, fmt.Errorf("mapped range: %w", err)
+	}
+	return append([]byte(nil), rng.Bytes()...), nil
+}
+
+// verify checks that every voxel holds its own normalized coordinate. The
+// texture stores 8-bit unorm values, so one step of 1/255 is tolerated.
+func verify(data []byte) error {
+	for z := range volumeSize {
+		for y := range volumeSize {
+			for x := range volumeSize {
+				off := ((z*volumeSize+y)*volumeSize + x) * 16
+				want := [4]float32{float32(x) / 7, float32(y) / 7, float32(z) / 7, 1}
+				for c := range 4 {
+					got := math.Float32frombits(binary.LittleEndian.Uint32(data[off+4*c:]))
+					if math.Abs(float64(got-want[c])) > 1.0/255 {
+						return fmt.Errorf("voxel (%d,%d,%d) channel %d: got %.4f, want %.4f", x, y, z, c, got, want[c])
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func initDevice() (*wgpu.Device, func(), error) {
+	backends := wgpu.BackendsAll
+	var opts *wgpu.RequestAdapterOptions
+	switch os.Getenv("GOGPU_GRAPHICS_API") {
+	case "dx12", "d3d12":
+		backends = wgpu.BackendsDX12
+	case "vulkan", "vk":
+		backends = wgpu.BackendsVulkan
+	case "metal":
+		backends = wgpu.BackendsMetal
+	case "gl", "gles":
+		backends = wgpu.BackendsGL
+	case "software":
+		opts = &wgpu.RequestAdapterOptions{ForceFallbackAdapter: true}
+	}
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{Backends: backends})
+	if err != nil {
+		return nil, nil, fmt.Errorf("CreateInstance: %w", err)
+	}
+	adapter, err := instance.RequestAdapter(opts)
+	if err != nil {
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestAdapter: %w", err)
+	}
+	fmt.Printf("Adapter: %s (%v)\n", adapter.Info().Name, adapter.Info().Backend)
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		adapter.Release()
+		instance.Release()
+		return nil, nil, fmt.Errorf("RequestDevice: %w", err)
+	}
+	return device, func() {
+		device.Release()
+		adapter.Release()
+		instance.Release()
+	}, nil
+}
@@ -31,6 +31,10 @@ func (e *CommandEncoder) TestTrackedRefs() []*core.ResourceRef { return e.tracke
 // TestHALEncoder returns the HAL encoder reference on a CommandBuffer (testing only).
 func (cb *CommandBuffer) TestHALEncoder() interface{} { return cb.halEncoder }
 
+// TestReadbackPoolSize returns the number of idle ReadBufferAsync staging
+// buffers in the device's pool (testing only).
+func (d *Device) TestReadbackPoolSize() int { return d.readbacks.idle() }
+
 // TestCmdEncoderPoolSize returns the number of free encoders in the device's pool (testing only).
 // Returns -1 if no pool is configured.
 func (d *Device) TestCmdEncoderPoolSize() int {
@@ -102,3 +106,11 @@ func (d *Device) TestMaintainAfterIdle() { d.maintainAfterIdle() }
 // NewBareDeviceForTest returns a Device with no queue or HAL state (testing only).
 // Used to exercise nil-queue guard clauses in maintainAfterIdle.
 func NewBareDeviceForTest() *Device { return &Device{} }
+
+// TestPooled returns the device-wide layout a BindGroupLayout shares (testing only).
+func (l *BindGroupLayout) TestPooled() *core.PooledBindGroupLayout { return l.pooled }
+
+// CompileWGSLDiagnostics exposes compileWGSLDiagnostics (testing only).
+func CompileWGSLDiagnostics(label, source string, err error) *ShaderCompileError {
+	return compileWGSLDiagnostics(label, source, err)
+}
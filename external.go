@@ -0,0 +1,68 @@
+package wgpu
+
+// NativeDevice names a device and queue another library created and owns,
+// such as a game engine or UI toolkit that already drives the GPU. Pass it
+// to Adapter.AdoptDevice to render with gogpu on that device instead of
+// opening a second one.
+type NativeDevice struct {
+	// Device is the VkDevice, id<MTLDevice> or ID3D12Device*.
+	Device uintptr
+
+	// Queue is the VkQueue, id<MTLCommandQueue> or direct
+	// ID3D12CommandQueue* to submit to. Vulkan requires it; Metal and DX12
+	// create their own queue on Device when it is zero.
+	Queue uintptr
+
+	// QueueFamilyIndex is the Vulkan queue family Queue belongs to.
+	QueueFamilyIndex uint32
+
+	// Extensions are the Vulkan device extensions the owner enabled,
+	// without a NUL terminator.
+	Extensions []string
+}
+
+// NativeTexture names a texture another library created on an adopted or
+// shared device. Pass it to Device.ImportTexture.
+type NativeTexture struct {
+	// Handle is the VkImage, id<MTLTexture> or ID3D12Resource*.
+	Handle uintptr
+
+	// CurrentUsage is the usage whose image layout or resource state the
+	// texture is in when imported, such as TextureUsageTextureBinding for a
+	// texture the owner last sampled. DX12 starts tracking resource states
+	// from it. On Vulkan the first TransitionTextures call must name it as
+	// OldUsage; Metal ignores it.
+	CurrentUsage TextureUsage
+}
+
+// NativeFenceType identifies the kind of handle in a NativeFence.
+type NativeFenceType uint8
+
+// Native fence handle types.
+const (
+	// NativeFenceOpaqueFD is an opaque POSIX file descriptor of a Vulkan
+	// timeline semaphore, as CUDA and other Vulkan devices import it.
+	NativeFenceOpaqueFD NativeFenceType = iota + 1
+
+	// NativeFenceOpaqueWin32 is an opaque NT handle of a Vulkan timeline
+	// semaphore.
+	NativeFenceOpaqueWin32
+
+	// NativeFenceD3D12 is an NT handle of a shared ID3D12Fence. Vulkan can
+	// import it on Windows too.
+	NativeFenceD3D12
+
+	// NativeFenceMetalSharedEvent is an id<MTLSharedEvent>.
+	NativeFenceMetalSharedEvent
+)
+
+// NativeFence is a fence handle shared with another API or process, such
+// as a video decoder, CUDA or a compositor. Device.ExportFence produces
+// one and Device.ImportFence accepts one.
+type NativeFence struct {
+	// Type is the kind of handle.
+	Type NativeFenceType
+
+	// Handle is the file descriptor, NT handle or id<MTLSharedEvent>.
+	Handle uintptr
+}
@@ -0,0 +1,134 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// adoptsNative reports whether the adapter's backend can adopt native
+// devices and textures.
+func adoptsNative(adapter *wgpu.Adapter) bool {
+	switch adapter.Info().Backend {
+	case gputypes.BackendVulkan, gputypes.BackendMetal, gputypes.BackendDX12:
+		return true
+	}
+	return false
+}
+
+func TestAdoptDeviceUnsupportedBackend(t *testing.T) {
+	inst, adapter := newAdapter(t)
+	defer inst.Release()
+	if adoptsNative(adapter) {
+		t.Skipf("%s adopts native devices", adapter.Info().Backend)
+	}
+
+	_, err := adapter.AdoptDevice(&wgpu.NativeDevice{Device: 1, Queue: 1}, nil)
+	if !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Fatalf("AdoptDevice error = %v, want ErrExternalNotSupported", err)
+	}
+}
+
+func TestAdoptDeviceNil(t *testing.T) {
+	inst, adapter := newAdapter(t)
+	defer inst.Release()
+
+	if _, err := adapter.AdoptDevice(nil, nil); err == nil {
+		t.Fatal("AdoptDevice(nil) succeeded")
+	}
+}
+
+func TestImportTextureUnsupportedBackend(t *testing.T) {
+	inst, adapter, device := newDevice(t)
+	defer inst.Release()
+	defer device.Release()
+	requireHAL(t, device)
+	if adoptsNative(adapter) {
+		t.Skipf("%s imports native textures", adapter.Info().Backend)
+	}
+
+	_, err := device.ImportTexture(&wgpu.NativeTexture{Handle: 1}, &wgpu.TextureDescriptor{
+		Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatRGBA8Unorm,
+		Usage:         wgpu.TextureUsageRenderAttachment,
+	})
+	if !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Fatalf("ImportTexture error = %v, want ErrExternalNotSupported", err)
+	}
+}
+
+func TestImportTextureInvalidArgs(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+
+	desc := &wgpu.TextureDescriptor{Format: wgpu.TextureFormatRGBA8Unorm}
+	if _, err := device.ImportTexture(nil, desc); err == nil {
+		t.Error("ImportTexture(nil) succeeded")
+	}
+	if _, err := device.ImportTexture(&wgpu.NativeTexture{Handle: 1}, nil); err == nil {
+		t.Error("ImportTexture with nil descriptor succeeded")
+	}
+
+	device.Release()
+	if _, err := device.ImportTexture(&wgpu.NativeTexture{Handle: 1}, desc); !errors.Is(err, wgpu.ErrReleased) {
+		t.Errorf("ImportTexture after Release error = %v, want ErrReleased", err)
+	}
+}
+
+func TestSharedFenceUnsupportedBackend(t *testing.T) {
+	inst, adapter, device := newDevice(t)
+	defer inst.Release()
+	defer device.Release()
+	requireHAL(t, device)
+	if adoptsNative(adapter) {
+		t.Skipf("%s may share fences", adapter.Info().Backend)
+	}
+
+	if _, err := device.CreateSharedFence(); !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Errorf("CreateSharedFence error = %v, want ErrExternalNotSupported", err)
+	}
+	_, err := device.ImportFence(&wgpu.NativeFence{Type: wgpu.NativeFenceOpaqueFD, Handle: 3})
+	if !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Errorf("ImportFence error = %v, want ErrExternalNotSupported", err)
+	}
+
+	fence, err := device.CreateFence()
+	if err != nil {
+		t.Fatalf("CreateFence: %v", err)
+	}
+	defer fence.Release()
+	if _, err := device.ExportFence(fence); !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Errorf("ExportFence error = %v, want ErrExternalNotSupported", err)
+	}
+	_, err = device.Queue().SubmitBatch(context.Background(), &wgpu.SubmitDescriptor{
+		SignalFences: []wgpu.FenceValue{{Fence: fence, Value: 1}},
+	})
+	if !errors.Is(err, wgpu.ErrExternalNotSupported) {
+		t.Errorf("SubmitBatch with SignalFences error = %v, want ErrExternalNotSupported", err)
+	}
+}
+
+func TestSharedFenceInvalidArgs(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+
+	if _, err := device.ImportFence(nil); err == nil {
+		t.Error("ImportFence(nil) succeeded")
+	}
+	if _, err := device.ExportFence(nil); !errors.Is(err, wgpu.ErrReleased) {
+		t.Errorf("ExportFence(nil) error = %v, want ErrReleased", err)
+	}
+
+	device.Release()
+	if _, err := device.CreateSharedFence(); !errors.Is(err, wgpu.ErrReleased) {
+		t.Errorf("CreateSharedFence after Release error = %v, want ErrReleased", err)
+	}
+}
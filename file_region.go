@@ -0,0 +1,57 @@
+package wgpu
+
+import (
+	"fmt"
+	"os"
+)
+
+// FileRegion is a read-only memory mapping of part of a file, for streaming
+// large assets to the GPU with Queue.UploadStream. The mapped bytes live
+// outside the Go heap; the OS pages them in as they are read and may drop
+// them again under memory pressure.
+type FileRegion struct {
+	data  []byte
+	unmap func() error
+}
+
+// MapFileRegion maps size bytes of f starting at offset. The region stays
+// valid after f is closed, until Close. Offsets need no alignment, but the
+// region must be non-empty and lie within the file: on Unix, reading a
+// mapped page past the end of the file kills the process with SIGBUS.
+func MapFileRegion(f *os.File, offset, size int64) (*FileRegion, error) {
+	if f == nil {
+		return nil, fmt.Errorf("wgpu: MapFileRegion: file is nil")
+	}
+	if offset < 0 || size <= 0 {
+		return nil, fmt.Errorf("wgpu: MapFileRegion: offset %d must not be negative and size %d must be positive", offset, size)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: MapFileRegion: %w", err)
+	}
+	if offset > fi.Size() || size > fi.Size()-offset {
+		return nil, fmt.Errorf("wgpu: MapFileRegion: %s: region [%d, %d) extends past the end of the %d-byte file",
+			f.Name(), offset, offset+size, fi.Size())
+	}
+	data, unmap, err := mapFile(f, offset, size)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: MapFileRegion: %s: %w", f.Name(), err)
+	}
+	return &FileRegion{data: data, unmap: unmap}, nil
+}
+
+// Bytes returns the mapped bytes. They must not be written to or used
+// after Close.
+func (r *FileRegion) Bytes() []byte {
+	return r.data
+}
+
+// Close unmaps the region. It is safe to call more than once.
+func (r *FileRegion) Close() error {
+	unmap := r.unmap
+	r.data, r.unmap = nil, nil
+	if unmap == nil {
+		return nil
+	}
+	return unmap()
+}
@@ -0,0 +1,14 @@
+//go:build !unix && !windows
+
+package wgpu
+
+import (
+	"errors"
+	"os"
+)
+
+// mapFile reports that memory-mapped files are not available, as on
+// js/wasm and wasip1.
+func mapFile(_ *os.File, _, _ int64) ([]byte, func() error, error) {
+	return nil, nil, errors.ErrUnsupported
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package wgpu
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// mapFile maps size bytes of f at offset with mmap, which needs a
+// page-aligned file offset.
+func mapFile(f *os.File, offset, size int64) ([]byte, func() error, error) {
+	delta := offset % int64(os.Getpagesize())
+	mapping, err := unix.Mmap(int(f.Fd()), offset-delta, int(size+delta), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mapping[delta:], func() error { return unix.Munmap(mapping) }, nil
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package wgpu
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// allocationGranularity is the alignment MapViewOfFile needs for file
+// offsets on every Windows version.
+const allocationGranularity = 64 << 10
+
+// mapFile maps size bytes of f at offset with a read-only file mapping.
+func mapFile(f *os.File, offset, size int64) ([]byte, func() error, error) {
+	mapping, err := windows.CreateFileMapping(windows.Handle(f.Fd()), nil, windows.PAGE_READONLY, 0, 0, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	delta := offset % allocationGranularity
+	start := uint64(offset - delta)
+	addr, err := windows.MapViewOfFile(mapping, windows.FILE_MAP_READ, uint32(start>>32), uint32(start), uintptr(size+delta))
+	if err != nil {
+		_ = windows.CloseHandle(mapping)
+		return nil, nil, err
+	}
+	unmap := func() error {
+		err := windows.UnmapViewOfFile(addr)
+		if closeErr := windows.CloseHandle(mapping); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+	// addr is a mapped view outside the Go heap; reading it through a
+	// pointer-typed view of the variable avoids a uintptr conversion.
+	data := unsafe.Slice(*(**byte)(unsafe.Pointer(&addr)), size+delta)
+	return data[delta:], unmap, nil
+}
@@ -0,0 +1,81 @@
+package wgpu
+
+import "sync/atomic"
+
+// FrameStatistics counts the work recorded on a device between two
+// Device.FrameStatistics calls. Calling it once per frame yields per-frame
+// numbers for a stats overlay without instrumenting every call site.
+//
+// Counts are taken when commands are recorded, so work in command buffers
+// that are never submitted is included. Commands rejected by validation are
+// not counted.
+type FrameStatistics struct {
+	// DrawCalls is the number of draws. A multi-draw indirect call counts
+	// one draw per argument record.
+	DrawCalls uint64
+	// Dispatches is the number of direct and indirect compute dispatches.
+	Dispatches uint64
+	// PipelineBinds is the number of SetPipeline calls on render and
+	// compute passes.
+	PipelineBinds uint64
+	// BufferUploadBytes is the number of bytes written with
+	// Queue.WriteBuffer.
+	BufferUploadBytes uint64
+	// Barriers is the number of buffer and texture barriers wgpu recorded:
+	// CommandEncoder.TransitionTextures, the transitions around
+	// Queue.WriteBuffer and Queue.WriteTexture, and indirect dispatch
+	// validation. Barriers a backend inserts inside a pass are not counted,
+	// and the Rust and browser backends always report 0.
+	Barriers uint64
+}
+
+// frameCounters accumulates FrameStatistics from encoders recording on any
+// goroutine. A nil *frameCounters discards counts.
+type frameCounters struct {
+	drawCalls         atomic.Uint64
+	dispatches        atomic.Uint64
+	pipelineBinds     atomic.Uint64
+	bufferUploadBytes atomic.Uint64
+	barriers          atomic.Uint64
+}
+
+func (c *frameCounters) addDraws(n uint32) {
+	if c != nil {
+		c.drawCalls.Add(uint64(n))
+	}
+}
+
+func (c *frameCounters) addDispatch() {
+	if c != nil {
+		c.dispatches.Add(1)
+	}
+}
+
+func (c *frameCounters) addPipelineBind() {
+	if c != nil {
+		c.pipelineBinds.Add(1)
+	}
+}
+
+func (c *frameCounters) addUploadBytes(n int) {
+	if c != nil {
+		c.bufferUploadBytes.Add(uint64(n))
+	}
+}
+
+func (c *frameCounters) addBarriers(n int) {
+	if c != nil {
+		c.barriers.Add(uint64(n))
+	}
+}
+
+// take returns the accumulated counts and resets them to zero.
+func (c *frameCounters) take() FrameStatistics {
+	return FrameStatistics{
+		DrawCalls:         c.drawCalls.Swap(0),
+		Dispatches:        c.dispatches.Swap(0),
+		PipelineBinds:     c.pipelineBinds.Swap(0),
+		BufferUploadBytes: c.bufferUploadBytes.Swap(0),
+		Barriers:          c.barriers.Swap(0),
+	}
+}
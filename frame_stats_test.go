@@ -0,0 +1,93 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestDeviceFrameStatistics(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "frame-stats",
+		Size:  64,
+		Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+	if err := device.Queue().WriteBuffer(buf, 0, make([]byte, 48)); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{
+		Label: "frame-stats-shader",
+		WGSL:  "@compute @workgroup_size(1) fn main() {}",
+	})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	defer shader.Release()
+	pipeline, err := device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+		Label:      "frame-stats-pipeline",
+		Module:     shader,
+		EntryPoint: "main",
+	})
+	if err != nil {
+		t.Skipf("CreateComputePipeline not supported by this backend: %v", err)
+	}
+	defer pipeline.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := encoder.BeginComputePass(nil)
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.Dispatch(1, 1, 1)
+	pass.Dispatch(2, 1, 1)
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	if _, err := encoder.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	got := device.FrameStatistics()
+	want := wgpu.FrameStatistics{Dispatches: 2, PipelineBinds: 1, BufferUploadBytes: 48}
+	if got.Dispatches != want.Dispatches || got.PipelineBinds != want.PipelineBinds ||
+		got.BufferUploadBytes != want.BufferUploadBytes || got.DrawCalls != 0 {
+		t.Errorf("FrameStatistics = %+v, want %+v", got, want)
+	}
+	if again := device.FrameStatistics(); again != (wgpu.FrameStatistics{}) {
+		t.Errorf("FrameStatistics after reset = %+v, want zero", again)
+	}
+}
+
+func TestDeviceFrameStatisticsSkipsRejectedCommands(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := encoder.BeginComputePass(nil)
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	pass.SetPipeline(nil)
+	pass.Dispatch(1, 1, 1) // no pipeline: rejected by validation
+	_ = pass.End()
+	encoder.DiscardEncoding()
+
+	if got := device.FrameStatistics(); got != (wgpu.FrameStatistics{}) {
+		t.Errorf("FrameStatistics = %+v, want zero", got)
+	}
+}
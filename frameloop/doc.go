@@ -0,0 +1,38 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+// Package frameloop runs the acquire, render and present cycle of a
+// [wgpu.Surface].
+//
+// A correct frame loop has to configure the surface to the window's current
+// size, reconfigure it when an acquire or present reports
+// [wgpu.ErrSurfaceOutdated] or [wgpu.ErrSurfaceLost] or a frame comes back
+// suboptimal, skip frames while the window is minimized (a zero-sized
+// swapchain cannot be created), skip an acquire that timed out instead of
+// failing, and discard the texture when rendering fails so the next acquire
+// does not find the previous frame still held. A [Loop] does all of that and
+// calls back into the application only to render:
+//
+//	loop, err := frameloop.New(frameloop.Options{
+//		Device:  device,
+//		Surface: surface,
+//		Config:  wgpu.SurfaceConfiguration{Format: format, Usage: wgpu.TextureUsageRenderAttachment, PresentMode: wgpu.PresentModeFifo},
+//		Size:    window.Size,
+//		Render: func(f *frameloop.Frame) error {
+//			view, err := f.Texture.View()
+//			...
+//		},
+//	})
+//	err = loop.Run(ctx)
+//
+// Pacing follows the present mode unless [Options.Interval] asks for a fixed
+// frame rate: with Fifo, Present blocks until the next vertical blank, so the
+// loop runs at the display's refresh rate. When [Options.Step] and
+// [Options.Update] are set, simulation updates run at that fixed timestep
+// independent of the frame rate, and [Frame.Alpha] reports how far the
+// rendered frame lies between the last two updates.
+//
+// [Loop.Run] blocks the calling goroutine. In the browser, where frames are
+// driven by requestAnimationFrame, call [Loop.Frame] from the animation frame
+// callback instead.
+package frameloop
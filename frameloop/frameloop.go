@@ -0,0 +1,255 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package frameloop
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gogpu/wgpu"
+)
+
+// maxUpdateSteps bounds the fixed-timestep updates run for one frame. After
+// a long stall the loop drops the backlog instead of trying to catch up,
+// which would make the next frame slower still.
+const maxUpdateSteps = 8
+
+// minimizedPoll is how long Run waits before checking the size of a
+// minimized window again.
+const minimizedPoll = 50 * time.Millisecond
+
+// Surface is the part of [wgpu.Surface] a Loop drives.
+type Surface interface {
+	Configure(device *wgpu.Device, config *wgpu.SurfaceConfiguration) error
+	GetCurrentTexture() (*wgpu.SurfaceTexture, bool, error)
+	Present(texture *wgpu.SurfaceTexture) error
+	DiscardTexture()
+}
+
+// Options configures a [Loop].
+type Options struct {
+	// Device renders the frames and is passed to Surface.Configure.
+	Device *wgpu.Device
+	// Surface is presented to, usually a *wgpu.Surface.
+	Surface Surface
+	// Config is the surface configuration. Width and Height are ignored;
+	// the loop takes them from Size.
+	Config wgpu.SurfaceConfiguration
+	// Size returns the current drawable size of the window in pixels. A
+	// zero width or height means the window is minimized and no frame is
+	// rendered.
+	Size func() (width, height uint32)
+
+	// Interval is the minimum time between frames Run renders, 1/60 s for
+	// 60 frames per second. Zero leaves pacing to the present mode.
+	Interval time.Duration
+	// Step is the fixed timestep of Update. Zero disables Update.
+	Step time.Duration
+	// Update advances the simulation by step. It runs as many times per
+	// frame as whole steps have elapsed, before Render.
+	Update func(step time.Duration) error
+	// Render records and submits the frame. The loop presents the texture
+	// after Render returns nil and discards it otherwise.
+	Render func(frame *Frame) error
+}
+
+// Frame is the frame passed to [Options.Render].
+type Frame struct {
+	// Texture is the acquired surface texture.
+	Texture *wgpu.SurfaceTexture
+	// Width and Height are the size the surface is configured to.
+	Width, Height uint32
+	// Index counts rendered frames, starting at 0.
+	Index uint64
+	// Delta is the time since the previous rendered frame, zero for the
+	// first one.
+	Delta time.Duration
+	// Alpha is the fraction of a Step elapsed since the last Update, for
+	// interpolating between simulation states. It is 0 without Update.
+	Alpha float64
+}
+
+// Loop runs the frame loop of one surface. It is not safe for concurrent
+// use.
+type Loop struct {
+	opts Options
+
+	width, height uint32 // configured size, zero until the first Configure
+	reconfigure   bool   // reconfigure before the next acquire
+
+	frames      uint64
+	last        time.Time // time of the previous frame, zero after a pause
+	accumulated time.Duration
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New returns a Loop for opts. Surface, Size and Render are required.
+func New(opts Options) (*Loop, error) {
+	if opts.Surface == nil {
+		return nil, errors.New("frameloop: surface is nil")
+	}
+	if opts.Size == nil {
+		return nil, errors.New("frameloop: Size is nil")
+	}
+	if opts.Render == nil {
+		return nil, errors.New("frameloop: Render is nil")
+	}
+	if opts.Update != nil && opts.Step <= 0 {
+		return nil, errors.New("frameloop: Update needs a positive Step")
+	}
+	return &Loop{opts: opts, now: time.Now, sleep: sleepContext}, nil
+}
+
+// Run renders frames until ctx is done or a frame fails, returning ctx's
+// error in the first case and the frame's in the second. While the window is
+// minimized it polls Size without rendering.
+func (l *Loop) Run(ctx context.Context) error {
+	var next time.Time
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if l.opts.Interval > 0 {
+			if wait := next.Sub(l.now()); wait > 0 {
+				if err := l.sleep(ctx, wait); err != nil {
+					return err
+				}
+			}
+		}
+		start := l.now()
+		rendered, err := l.Frame()
+		if err != nil {
+			return err
+		}
+		if !rendered {
+			if err := l.sleep(ctx, max(l.opts.Interval, minimizedPoll)); err != nil {
+				return err
+			}
+			continue
+		}
+		next = start.Add(l.opts.Interval)
+	}
+}
+
+// Frame runs one iteration of the loop: it reconfigures the surface if the
+// size changed or the swapchain went stale, runs pending updates, and
+// acquires, renders and presents a texture. It reports whether a frame was
+// rendered; frames are skipped without error while the window is minimized
+// and when the surface could not provide a texture this time. Frame does not
+// pace; callers driving it from their own loop, such as a browser animation
+// frame, decide when to call it.
+func (l *Loop) Frame() (bool, error) {
+	width, height := l.opts.Size()
+	if width == 0 || height == 0 {
+		// Time does not pass for the simulation while minimized.
+		l.last = time.Time{}
+		return false, nil
+	}
+	if width != l.width || height != l.height || l.reconfigure {
+		if err := l.configure(width, height); err != nil {
+			return false, err
+		}
+	}
+
+	now := l.now()
+	var delta time.Duration
+	if !l.last.IsZero() {
+		delta = now.Sub(l.last)
+	}
+	l.last = now
+	alpha, err := l.update(delta)
+	if err != nil {
+		return false, err
+	}
+
+	texture, suboptimal, err := l.opts.Surface.GetCurrentTexture()
+	if isStale(err) {
+		// The window changed between the size check and the acquire.
+		if err := l.configure(width, height); err != nil {
+			return false, err
+		}
+		texture, suboptimal, err = l.opts.Surface.GetCurrentTexture()
+	}
+	switch {
+	case isStale(err), errors.Is(err, wgpu.ErrTimeout):
+		l.reconfigure = isStale(err)
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	l.reconfigure = suboptimal
+
+	frame := &Frame{
+		Texture: texture,
+		Width:   width,
+		Height:  height,
+		Index:   l.frames,
+		Delta:   delta,
+		Alpha:   alpha,
+	}
+	if err := l.opts.Render(frame); err != nil {
+		l.opts.Surface.DiscardTexture()
+		return false, err
+	}
+	l.frames++
+	if err := l.opts.Surface.Present(texture); err != nil {
+		if !isStale(err) {
+			return true, err
+		}
+		l.reconfigure = true
+	}
+	return true, nil
+}
+
+// configure configures the surface to width x height.
+func (l *Loop) configure(width, height uint32) error {
+	config := l.opts.Config
+	config.Width, config.Height = width, height
+	if err := l.opts.Surface.Configure(l.opts.Device, &config); err != nil {
+		return err
+	}
+	l.width, l.height = width, height
+	l.reconfigure = false
+	return nil
+}
+
+// update runs the whole steps elapsed by delta and returns the fraction of
+// a step left over.
+func (l *Loop) update(delta time.Duration) (float64, error) {
+	if l.opts.Update == nil {
+		return 0, nil
+	}
+	step := l.opts.Step
+	l.accumulated += delta
+	for n := 0; l.accumulated >= step; n++ {
+		if n == maxUpdateSteps {
+			l.accumulated %= step
+			break
+		}
+		if err := l.opts.Update(step); err != nil {
+			return 0, err
+		}
+		l.accumulated -= step
+	}
+	return float64(l.accumulated) / float64(step), nil
+}
+
+// isStale reports whether err asks for the surface to be configured again.
+func isStale(err error) bool {
+	return errors.Is(err, wgpu.ErrSurfaceOutdated) || errors.Is(err, wgpu.ErrSurfaceLost)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package frameloop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+)
+
+// fakeSurface records the calls a Loop makes. acquireErrs and presentErrs
+// are consumed one per call; a nil entry or an exhausted list succeeds.
+type fakeSurface struct {
+	configs     []wgpu.SurfaceConfiguration
+	acquireErrs []error
+	presentErrs []error
+	suboptimal  bool
+	acquired    int
+	presented   int
+	discarded   int
+}
+
+func (s *fakeSurface) Configure(_ *wgpu.Device, config *wgpu.SurfaceConfiguration) error {
+	s.configs = append(s.configs, *config)
+	return nil
+}
+
+func (s *fakeSurface) GetCurrentTexture() (*wgpu.SurfaceTexture, bool, error) {
+	s.acquired++
+	if err := pop(&s.acquireErrs); err != nil {
+		return nil, false, err
+	}
+	return nil, s.suboptimal, nil
+}
+
+func (s *fakeSurface) Present(*wgpu.SurfaceTexture) error {
+	s.presented++
+	return pop(&s.presentErrs)
+}
+
+func (s *fakeSurface) DiscardTexture() { s.discarded++ }
+
+func pop(errs *[]error) error {
+	if len(*errs) == 0 {
+		return nil
+	}
+	err := (*errs)[0]
+	*errs = (*errs)[1:]
+	return err
+}
+
+// fakeClock advances only when the loop sleeps or the test ticks it.
+type fakeClock struct {
+	t       time.Time
+	slept   []time.Duration
+	onSleep func(d time.Duration)
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(_ context.Context, d time.Duration) error {
+	c.slept = append(c.slept, d)
+	c.t = c.t.Add(d)
+	if c.onSleep != nil {
+		c.onSleep(d)
+	}
+	return nil
+}
+
+func newLoop(t *testing.T, opts Options) (*Loop, *fakeClock) {
+	t.Helper()
+	l, err := New(opts)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	l.now, l.sleep = clock.now, clock.sleep
+	return l, clock
+}
+
+func frame(t *testing.T, l *Loop) bool {
+	t.Helper()
+	rendered, err := l.Frame()
+	if err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+	return rendered
+}
+
+func TestNewValidation(t *testing.T) {
+	render := func(*Frame) error { return nil }
+	size := func() (uint32, uint32) { return 1, 1 }
+	for name, opts := range map[string]Options{
+		"no surface":    {Size: size, Render: render},
+		"no size":       {Surface: &fakeSurface{}, Render: render},
+		"no render":     {Surface: &fakeSurface{}, Size: size},
+		"update nostep": {Surface: &fakeSurface{}, Size: size, Render: render, Update: func(time.Duration) error { return nil }},
+	} {
+		if _, err := New(opts); err == nil {
+			t.Errorf("%s: New should fail", name)
+		}
+	}
+}
+
+func TestFrameResizeAndMinimize(t *testing.T) {
+	surface := &fakeSurface{}
+	width, height := uint32(640), uint32(480)
+	var frames []Frame
+	l, _ := newLoop(t, Options{
+		Surface: surface,
+		Config:  wgpu.SurfaceConfiguration{Format: wgpu.TextureFormatBGRA8Unorm, PresentMode: wgpu.PresentModeFifo},
+		Size:    func() (uint32, uint32) { return width, height },
+		Render: func(f *Frame) error {
+			frames = append(frames, *f)
+			return nil
+		},
+	})
+
+	frame(t, l)
+	frame(t, l)
+	width, height = 800, 600
+	frame(t, l)
+	width = 0
+	if frame(t, l) {
+		t.Error("minimized window rendered a frame")
+	}
+	width = 800
+	frame(t, l)
+
+	if len(surface.configs) != 2 {
+		t.Fatalf("configured %d times, want 2: %+v", len(surface.configs), surface.configs)
+	}
+	if c := surface.configs[1]; c.Width != 800 || c.Height != 600 || c.Format != wgpu.TextureFormatBGRA8Unorm {
+		t.Errorf("resize configured %+v", c)
+	}
+	if len(frames) != 4 || surface.presented != 4 {
+		t.Fatalf("rendered %d, presented %d frames, want 4", len(frames), surface.presented)
+	}
+	if f := frames[2]; f.Index != 2 || f.Width != 800 || f.Height != 600 {
+		t.Errorf("frame after resize = %+v", f)
+	}
+}
+
+func TestFrameRecoversFromStaleSurface(t *testing.T) {
+	surface := &fakeSurface{}
+	rendered := 0
+	l, _ := newLoop(t, Options{
+		Surface: surface,
+		Size:    func() (uint32, uint32) { return 64, 64 },
+		Render:  func(*Frame) error { rendered++; return nil },
+	})
+	frame(t, l)
+
+	// An outdated acquire reconfigures and retries within the frame.
+	surface.acquireErrs = []error{wgpu.ErrSurfaceOutdated}
+	if !frame(t, l) || len(surface.configs) != 2 {
+		t.Errorf("outdated acquire: rendered %d, configured %d times", rendered, len(surface.configs))
+	}
+
+	// A second failure skips the frame and reconfigures on the next one.
+	surface.acquireErrs = []error{wgpu.ErrSurfaceLost, wgpu.ErrSurfaceLost}
+	if frame(t, l) {
+		t.Error("frame rendered although the retry failed")
+	}
+	frame(t, l)
+	if len(surface.configs) != 4 {
+		t.Errorf("configured %d times, want 4", len(surface.configs))
+	}
+
+	// Timeouts skip the frame without reconfiguring.
+	surface.acquireErrs = []error{wgpu.ErrTimeout}
+	if frame(t, l) || len(surface.configs) != 4 {
+		t.Error("timeout should skip the frame only")
+	}
+
+	// Suboptimal frames and outdated presents reconfigure before the next acquire.
+	surface.suboptimal = true
+	frame(t, l)
+	surface.suboptimal = false
+	surface.presentErrs = []error{wgpu.ErrSurfaceOutdated}
+	frame(t, l)
+	frame(t, l)
+	if len(surface.configs) != 6 {
+		t.Errorf("configured %d times, want 6", len(surface.configs))
+	}
+	if rendered != 6 {
+		t.Errorf("rendered %d frames, want 6", rendered)
+	}
+
+	surface.acquireErrs = []error{wgpu.ErrDeviceLost}
+	if _, err := l.Frame(); !errors.Is(err, wgpu.ErrDeviceLost) {
+		t.Errorf("Frame = %v, want ErrDeviceLost", err)
+	}
+}
+
+func TestFrameDiscardsOnRenderError(t *testing.T) {
+	surface := &fakeSurface{}
+	failure := errors.New("render failed")
+	l, _ := newLoop(t, Options{
+		Surface: surface,
+		Size:    func() (uint32, uint32) { return 64, 64 },
+		Render:  func(*Frame) error { return failure },
+	})
+	if _, err := l.Frame(); !errors.Is(err, failure) {
+		t.Fatalf("Frame = %v, want the render error", err)
+	}
+	if surface.discarded != 1 || surface.presented != 0 {
+		t.Errorf("discarded %d, presented %d; want 1, 0", surface.discarded, surface.presented)
+	}
+}
+
+func TestFixedTimestep(t *testing.T) {
+	var steps int
+	var alphas []float64
+	l, clock := newLoop(t, Options{
+		Surface: &fakeSurface{},
+		Size:    func() (uint32, uint32) { return 64, 64 },
+		Step:    10 * time.Millisecond,
+		Update: func(step time.Duration) error {
+			if step != 10*time.Millisecond {
+				t.Errorf("Update(%v), want 10ms", step)
+			}
+			steps++
+			return nil
+		},
+		Render: func(f *Frame) error {
+			alphas = append(alphas, f.Alpha)
+			return nil
+		},
+	})
+
+	frame(t, l)
+	clock.t = clock.t.Add(25 * time.Millisecond)
+	frame(t, l)
+	if steps != 2 || alphas[1] != 0.5 {
+		t.Errorf("after 25ms: %d steps, alpha %v; want 2, 0.5", steps, alphas[1])
+	}
+
+	// A stall runs at most maxUpdateSteps and drops the rest.
+	clock.t = clock.t.Add(time.Second + 5*time.Millisecond)
+	frame(t, l)
+	if steps != 2+maxUpdateSteps {
+		t.Errorf("after a stall: %d steps, want %d", steps, 2+maxUpdateSteps)
+	}
+	if alphas[2] != 0 {
+		t.Errorf("alpha after a stall = %v, want 0", alphas[2])
+	}
+}
+
+func TestRunPacesToInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var clock *fakeClock
+	width := uint32(64)
+	rendered := 0
+	l, clock := newLoop(t, Options{
+		Surface:  &fakeSurface{},
+		Size:     func() (uint32, uint32) { return width, 64 },
+		Interval: 16 * time.Millisecond,
+		Render: func(*Frame) error {
+			rendered++
+			switch rendered {
+			case 2:
+				clock.t = clock.t.Add(4 * time.Millisecond) // render time
+			case 3:
+				width = 0
+			case 4:
+				cancel()
+			}
+			return nil
+		},
+	})
+	clock.onSleep = func(d time.Duration) {
+		if d == minimizedPoll {
+			width = 64 // the window is restored while Run waits
+		}
+	}
+
+	if err := l.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v, want context.Canceled", err)
+	}
+	// Frame 2 took 4ms of its interval; the minimized poll follows the
+	// regular wait before the skipped frame.
+	want := []time.Duration{16 * time.Millisecond, 12 * time.Millisecond, 16 * time.Millisecond, minimizedPoll}
+	if len(clock.slept) != len(want) {
+		t.Fatalf("slept %v, want %v", clock.slept, want)
+	}
+	for i := range want {
+		if clock.slept[i] != want[i] {
+			t.Errorf("slept %v, want %v", clock.slept, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package wgpu
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gogpu/gputypes"
+)
+
+// PassTiming is the measured GPU duration of one pass.
+type PassTiming struct {
+	Label    string
+	Duration time.Duration
+	// Offset is the pass's start relative to the earliest pass returned by
+	// the same GPUTimer.Read.
+	Offset time.Duration
+}
+
+// Milliseconds returns the duration in fractional milliseconds.
+func (p PassTiming) Milliseconds() float64 {
+	return float64(p.Duration) / float64(time.Millisecond)
+}
+
+// GPUTimer measures the GPU time of compute and render passes using
+// timestamp queries. It owns a timestamp QuerySet with two queries per pass,
+// a resolve buffer and a readback buffer.
+//
+// Typical frame:
+//
+//	pass, _ := encoder.BeginComputePass(&wgpu.ComputePassDescriptor{
+//		TimestampWrites: timer.BeginPass("simulate"),
+//	})
+//	...
+//	timer.Resolve(encoder)
+//	queue.Submit(encoder.Finish())
+//	timings, err := timer.Read(ctx)
+//
+// The device must have been created with gputypes.FeatureTimestampQuery.
+// A GPUTimer is not safe for concurrent use.
+type GPUTimer struct {
+	device    *Device
+	querySet  *QuerySet
+	resolve   *Buffer
+	readback  *Buffer
+	maxPasses uint32
+	labels    []string
+	resolved  int
+	// resolvedAt anchors the passes on a TraceRecorder's GPU track.
+	resolvedAt time.Time
+}
+
+// NewGPUTimer creates a timer that can measure up to maxPasses passes
+// between calls to Read.
+func NewGPUTimer(device *Device, maxPasses uint32) (*GPUTimer, error) {
+	if device == nil {
+		return nil, fmt.Errorf("wgpu: NewGPUTimer: device is nil")
+	}
+	if maxPasses == 0 || maxPasses > MaxQueriesPerSet/2 {
+		return nil, fmt.Errorf("wgpu: NewGPUTimer: maxPasses %d out of range [1, %d]", maxPasses, MaxQueriesPerSet/2)
+	}
+	if !device.Features().Contains(gputypes.FeatureTimestampQuery) {
+		return nil, fmt.Errorf("wgpu: NewGPUTimer requires gputypes.FeatureTimestampQuery: %w", ErrFeatureNotSupported)
+	}
+
+	querySet, err := device.CreateQuerySet(&QuerySetDescriptor{
+		Label: "GPUTimer",
+		Type:  QueryTypeTimestamp,
+		Count: 2 * maxPasses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	size := 16 * uint64(maxPasses)
+	resolve, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "GPUTimer resolve",
+		Size:  size,
+		Usage: BufferUsageQueryResolve | BufferUsageCopySrc,
+	})
+	if err != nil {
+		querySet.Release()
+		return nil, err
+	}
+	readback, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "GPUTimer readback",
+		Size:  size,
+		Usage: BufferUsageMapRead | BufferUsageCopyDst,
+	})
+	if err != nil {
+		resolve.Release()
+		querySet.Release()
+		return nil, err
+	}
+
+	return &GPUTimer{
+		device:    device,
+		querySet:  querySet,
+		resolve:   resolve,
+		readback:  readback,
+		maxPasses: maxPasses,
+	}, nil
+}
+
+// BeginPass reserves a query pair for a pass and returns the timestamp
+// writes to put in its descriptor. It returns nil once maxPasses passes have
+// been recorded since the last Read; a nil TimestampWrites simply leaves the
+// pass untimed.
+func (t *GPUTimer) BeginPass(label string) *PassTimestampWrites {
+	if uint32(len(t.labels)) >= t.maxPasses {
+		return nil
+	}
+	begin := 2 * uint32(len(t.labels))
+	end := begin + 1
+	t.labels = append(t.labels, label)
+	return &PassTimestampWrites{
+		QuerySet:                  t.querySet,
+		BeginningOfPassWriteIndex: &begin,
+		EndOfPassWriteIndex:       &end,
+	}
+}
+
+// Resolve records the resolve and readback copy for every pass begun so
+// far. Call it on the encoder that recorded the passes, after the last
+// timed pass has ended and before Finish.
+func (t *GPUTimer) Resolve(encoder *CommandEncoder) {
+	count := uint32(len(t.labels))
+	if count == 0 {
+		return
+	}
+	encoder.ResolveQuerySet(t.querySet, 0, 2*count, t.resolve, 0)
+	encoder.CopyBufferToBuffer(t.resolve, 0, t.readback, 0, 16*uint64(count))
+	t.resolved = len(t.labels)
+	t.resolvedAt = time.Now()
+}
+
+// Read waits for the resolved timestamps, converts them to durations using
+// Queue.GetTimestampPeriod, and resets the timer for the next frame. The
+// command buffer containing Resolve must have been submitted.
+func (t *GPUTimer) Read(ctx context.Context) ([]PassTiming, error) {
+	if t.resolved == 0 {
+		t.labels = t.labels[:0]
+		return nil, nil
+	}
+	size := 16 * uint64(t.resolved)
+	if err := t.readback.Map(ctx, MapModeRead, 0, size); err != nil {
+		return nil, err
+	}
+	rng, err := t.readback.MappedRange(0, size)
+	if err != nil {
+		_ = t.readback.Unmap()
+		return nil, err
+	}
+
+	period := float64(t.device.Queue().GetTimestampPeriod())
+	data := rng.Bytes()
+	timings := make([]PassTiming, t.resolved)
+	first := ^uint64(0)
+	for i := range timings {
+		first = min(first, binary.LittleEndian.Uint64(data[16*i:]))
+	}
+	for i := range timings {
+		begin := binary.LittleEndian.Uint64(data[16*i:])
+		end := binary.LittleEndian.Uint64(data[16*i+8:])
+		var ticks uint64
+		if end > begin {
+			ticks = end - begin
+		}
+		timings[i] = PassTiming{
+			Label:    t.labels[i],
+			Duration: time.Duration(float64(ticks) * period),
+			Offset:   time.Duration(float64(begin-first) * period),
+		}
+	}
+	rng.Release()
+	if err := t.readback.Unmap(); err != nil {
+		return nil, err
+	}
+
+	if rec := traceRecorder.Load(); rec != nil {
+		rec.addGPUTimings(t.resolvedAt, timings)
+	}
+
+	t.labels = t.labels[:0]
+	t.resolved = 0
+	return timings, nil
+}
+
+// Release destroys the query set and buffers owned by the timer.
+func (t *GPUTimer) Release() {
+	t.querySet.Release()
+	t.resolve.Release()
+	t.readback.Release()
+}
@@ -0,0 +1,106 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+func TestDeviceCreateQuerySetValidation(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	if _, err := device.CreateQuerySet(nil); err == nil {
+		t.Error("CreateQuerySet(nil) should return error")
+	}
+	if _, err := device.CreateQuerySet(&wgpu.QuerySetDescriptor{Type: wgpu.QueryTypeOcclusion}); err == nil {
+		t.Error("CreateQuerySet with zero count should return error")
+	}
+	if !device.Features().Contains(gputypes.FeatureTimestampQuery) {
+		_, err := device.CreateQuerySet(&wgpu.QuerySetDescriptor{Type: wgpu.QueryTypeTimestamp, Count: 2})
+		if !errors.Is(err, wgpu.ErrFeatureNotSupported) {
+			t.Errorf("timestamp CreateQuerySet err = %v, want ErrFeatureNotSupported", err)
+		}
+	}
+}
+
+func TestNewGPUTimerRequiresTimestampQuery(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	if device.Features().Contains(gputypes.FeatureTimestampQuery) {
+		t.Skip("device has FeatureTimestampQuery")
+	}
+	if _, err := wgpu.NewGPUTimer(device, 4); !errors.Is(err, wgpu.ErrFeatureNotSupported) {
+		t.Fatalf("NewGPUTimer err = %v, want ErrFeatureNotSupported", err)
+	}
+}
+
+func TestGPUTimerComputePass(t *testing.T) {
+	_, adapter := newAdapter(t)
+	if !adapter.Features().Contains(gputypes.FeatureTimestampQuery) {
+		t.Skip("adapter lacks FeatureTimestampQuery")
+	}
+	device, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{
+		RequiredFeatures: wgpu.Features(gputypes.FeatureTimestampQuery),
+	})
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	defer device.Release()
+	requireHAL(t, device)
+
+	timer, err := wgpu.NewGPUTimer(device, 2)
+	if err != nil {
+		t.Fatalf("NewGPUTimer: %v", err)
+	}
+	defer timer.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	for _, label := range []string{"first", "second", "dropped"} {
+		pass, err := encoder.BeginComputePass(&wgpu.ComputePassDescriptor{
+			Label:           label,
+			TimestampWrites: timer.BeginPass(label),
+		})
+		if err != nil {
+			t.Fatalf("BeginComputePass(%s): %v", label, err)
+		}
+		if err := pass.End(); err != nil {
+			t.Fatalf("End(%s): %v", label, err)
+		}
+	}
+	timer.Resolve(encoder)
+	cmd, err := encoder.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(cmd); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	timings, err := timer.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(timings) != 2 || timings[0].Label != "first" || timings[1].Label != "second" {
+		t.Fatalf("timings = %+v, want passes first and second", timings)
+	}
+	for _, timing := range timings {
+		if timing.Duration < 0 {
+			t.Errorf("%s: negative duration %v", timing.Label, timing.Duration)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+//go:build !(js && wasm)
+
+package hal
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+)
+
+// AdapterDetails identifies an adapter beyond gputypes.AdapterInfo, for
+// driver workarounds and for matching the same GPU across APIs. Fields a
+// backend cannot query are zero.
+type AdapterDetails struct {
+	// DriverVersion is the driver version as major, minor, patch and build.
+	DriverVersion [4]uint32
+
+	// LUID is the locally unique identifier Windows assigns the adapter
+	// (DXGI_ADAPTER_DESC1.AdapterLuid, VkPhysicalDeviceIDProperties.deviceLUID).
+	LUID [8]byte
+
+	// DeviceUUID is the Vulkan device UUID (VkPhysicalDeviceIDProperties.deviceUUID),
+	// which also identifies the device to CUDA and OpenCL.
+	DeviceUUID [16]byte
+
+	// MemoryHeaps lists the memory heaps resources are allocated from
+	// (VkPhysicalDeviceMemoryProperties.memoryHeaps, the dedicated and shared
+	// memory of DXGI_ADAPTER_DESC1, Metal's recommended working set).
+	MemoryHeaps []MemoryHeap
+}
+
+// MemoryHeap is a pool of memory an adapter allocates resources from.
+type MemoryHeap struct {
+	// Size is the heap size in bytes.
+	Size uint64
+	// DeviceLocal reports whether the heap is GPU memory. On unified memory
+	// adapters it is also host memory.
+	DeviceLocal bool
+}
+
+// PCI vendor IDs with vendor-specific driver version encodings.
+const (
+	vendorNVIDIA = 0x10DE
+	vendorIntel  = 0x8086
+	vendorApple  = 0x106B
+)
+
+// DecodeVulkanDriverVersion decodes VkPhysicalDeviceProperties.driverVersion.
+// The encoding is vendor specific: NVIDIA packs four fields in 10.8.8.6 bits,
+// Intel's Windows driver two in 18.14 bits, and other drivers, Mesa among
+// them, use VK_MAKE_API_VERSION.
+func DecodeVulkanDriverVersion(vendorID, raw uint32) [4]uint32 {
+	return decodeVulkanDriverVersion(vendorID, raw, runtime.GOOS)
+}
+
+func decodeVulkanDriverVersion(vendorID, raw uint32, goos string) [4]uint32 {
+	switch {
+	case vendorID == vendorNVIDIA:
+		return [4]uint32{raw >> 22, raw >> 14 & 0xFF, raw >> 6 & 0xFF, raw & 0x3F}
+	case vendorID == vendorIntel && goos == "windows":
+		return [4]uint32{raw >> 14, raw & 0x3FFF}
+	default:
+		return [4]uint32{raw >> 22 & 0x7F, raw >> 12 & 0x3FF, raw & 0xFFF}
+	}
+}
+
+// DecodeUMDVersion decodes the user-mode driver version DXGI reports from
+// IDXGIAdapter::CheckInterfaceSupport, four 16-bit fields such as
+// 31.0.15.3623.
+func DecodeUMDVersion(raw int64) [4]uint32 {
+	v := uint64(raw)
+	return [4]uint32{uint32(v >> 48 & 0xFFFF), uint32(v >> 32 & 0xFFFF), uint32(v >> 16 & 0xFFFF), uint32(v & 0xFFFF)}
+}
+
+// ParseDriverVersion extracts a driver version from a version string. It
+// takes the last word that starts with at least two dot-separated numbers,
+// which is the driver's own version in GL_VERSION strings such as
+// "4.6.0 NVIDIA 535.104.05" or "4.6 (Core Profile) Mesa 23.2.1-1ubuntu3".
+// It returns zero if there is none.
+func ParseDriverVersion(s string) [4]uint32 {
+	var best [4]uint32
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) || (i > 0 && s[i-1] != ' ' && s[i-1] != '(') {
+			i++
+			continue
+		}
+		// s[i:] starts a run of numbers separated by single dots.
+		var v [4]uint32
+		n := 0
+		for {
+			var part uint32
+			for i < len(s) && isDigit(s[i]) {
+				part = part*10 + uint32(s[i]-'0')
+				i++
+			}
+			if n < len(v) {
+				v[n] = part
+			}
+			n++
+			if i+1 < len(s) && s[i] == '.' && isDigit(s[i+1]) {
+				i++
+				continue
+			}
+			break
+		}
+		if n >= 2 {
+			best = v
+		}
+	}
+	return best
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// RefineDeviceType corrects the device type a backend reported, using the
+// adapter name and vendor: software rasterizers that present themselves as
+// GPUs become DeviceTypeCPU, paravirtualized drivers DeviceTypeVirtualGPU,
+// and an unknown type from a vendor that only makes integrated GPUs
+// DeviceTypeIntegratedGPU. Similar to the name checks of Rust wgpu-hal's GLES
+// backend (gles/adapter.rs).
+func RefineDeviceType(info *gputypes.AdapterInfo) gputypes.DeviceType {
+	name := strings.ToLower(info.Name)
+	for _, s := range []string{"llvmpipe", "softpipe", "lavapipe", "swiftshader", "microsoft basic render driver", "warp"} {
+		if strings.Contains(name, s) {
+			return gputypes.DeviceTypeCPU
+		}
+	}
+	for _, s := range []string{"virgl", "virtio", "venus", "vmware", "svga3d", "parallels"} {
+		if strings.Contains(name, s) {
+			return gputypes.DeviceTypeVirtualGPU
+		}
+	}
+	if info.DeviceType == gputypes.DeviceTypeOther && info.VendorID == vendorApple {
+		return gputypes.DeviceTypeIntegratedGPU
+	}
+	return info.DeviceType
+}
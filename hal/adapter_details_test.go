@@ -0,0 +1,74 @@
+//go:build !(js && wasm)
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestDecodeVulkanDriverVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		vendorID uint32
+		raw      uint32
+		goos     string
+		want     [4]uint32
+	}{
+		{"nvidia", vendorNVIDIA, 535<<22 | 104<<14 | 5<<6, "linux", [4]uint32{535, 104, 5, 0}},
+		{"intel windows", vendorIntel, 101<<14 | 4502, "windows", [4]uint32{101, 4502}},
+		{"intel mesa", vendorIntel, 23<<22 | 2<<12 | 1, "linux", [4]uint32{23, 2, 1}},
+		{"amd", 0x1002, 2<<22 | 0<<12 | 279, "windows", [4]uint32{2, 0, 279}},
+	}
+	for _, tt := range tests {
+		if got := decodeVulkanDriverVersion(tt.vendorID, tt.raw, tt.goos); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeUMDVersion(t *testing.T) {
+	raw := int64(31)<<48 | int64(0)<<32 | int64(15)<<16 | 3623
+	if got, want := DecodeUMDVersion(raw), [4]uint32{31, 0, 15, 3623}; got != want {
+		t.Errorf("DecodeUMDVersion = %v, want %v", got, want)
+	}
+}
+
+func TestParseDriverVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [4]uint32
+	}{
+		{"4.6.0 NVIDIA 535.104.05", [4]uint32{535, 104, 5}},
+		{"4.6 (Core Profile) Mesa 23.2.1-1ubuntu3.1~22.04.2", [4]uint32{23, 2, 1}},
+		{"4.6.0 - Build 31.0.101.4502", [4]uint32{31, 0, 101, 4502}},
+		{"OpenGL ES 3.2 v1.r32p1", [4]uint32{3, 2}},
+		{"1.2.3.4.5", [4]uint32{1, 2, 3, 4}},
+		{"no version", [4]uint32{}},
+		{"version 7.", [4]uint32{}},
+	}
+	for _, tt := range tests {
+		if got := ParseDriverVersion(tt.in); got != tt.want {
+			t.Errorf("ParseDriverVersion(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRefineDeviceType(t *testing.T) {
+	tests := []struct {
+		info gputypes.AdapterInfo
+		want gputypes.DeviceType
+	}{
+		{gputypes.AdapterInfo{Name: "llvmpipe (LLVM 15.0.7, 256 bits)", DeviceType: gputypes.DeviceTypeIntegratedGPU}, gputypes.DeviceTypeCPU},
+		{gputypes.AdapterInfo{Name: "Microsoft Basic Render Driver", DeviceType: gputypes.DeviceTypeDiscreteGPU}, gputypes.DeviceTypeCPU},
+		{gputypes.AdapterInfo{Name: "virgl (NVIDIA GeForce RTX 3080)", DeviceType: gputypes.DeviceTypeOther}, gputypes.DeviceTypeVirtualGPU},
+		{gputypes.AdapterInfo{Name: "Apple M2", VendorID: vendorApple, DeviceType: gputypes.DeviceTypeOther}, gputypes.DeviceTypeIntegratedGPU},
+		{gputypes.AdapterInfo{Name: "NVIDIA GeForce RTX 4090", DeviceType: gputypes.DeviceTypeDiscreteGPU}, gputypes.DeviceTypeDiscreteGPU},
+	}
+	for _, tt := range tests {
+		if got := RefineDeviceType(&tt.info); got != tt.want {
+			t.Errorf("RefineDeviceType(%q) = %v, want %v", tt.info.Name, got, tt.want)
+		}
+	}
+}
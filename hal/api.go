@@ -71,6 +71,9 @@ type ExposedAdapter struct {
 	// Info contains adapter metadata (name, vendor, device type).
 	Info gputypes.AdapterInfo
 
+	// Details identifies the adapter and its driver further.
+	Details AdapterDetails
+
 	// Features are the supported optional features.
 	Features gputypes.Features
 
@@ -78,6 +81,30 @@ type ExposedAdapter struct {
 	Capabilities Capabilities
 }
 
+// AdapterDescription names an adapter without opening it; see
+// AdapterDescriber.
+type AdapterDescription struct {
+	// Info contains adapter metadata (name, vendor, device type).
+	Info gputypes.AdapterInfo
+
+	// Details identifies the adapter and its driver further.
+	Details AdapterDetails
+}
+
+// AdapterDescriber is an optional interface implemented by instances that
+// can list their adapters from driver queries alone, without the feature
+// and limit probing of EnumerateAdapters. It is for tooling that only needs
+// to know which GPUs exist: DX12 enumeration otherwise creates a D3D12
+// device per adapter, which is slow and can fail under Remote Desktop.
+//
+// Vulkan and DX12 implement it.
+type AdapterDescriber interface {
+	// DescribeAdapters returns the adapters EnumerateAdapters would expose,
+	// in the same order, with Info and Details filled in as far as the
+	// queries allow.
+	DescribeAdapters() []AdapterDescription
+}
+
 // Adapter represents a physical GPU.
 // Adapters are enumerated from instances and provide capability queries.
 type Adapter interface {
@@ -202,7 +229,8 @@ type Device interface {
 	// DestroyComputePipeline destroys a compute pipeline.
 	DestroyComputePipeline(pipeline ComputePipeline)
 
-	// CreateQuerySet creates a query set for timestamp or occlusion queries.
+	// CreateQuerySet creates a query set for timestamp, occlusion or
+	// pipeline statistics queries.
 	// Returns ErrTimestampsNotSupported if the backend does not support the query type.
 	CreateQuerySet(desc *QuerySetDescriptor) (QuerySet, error)
 
@@ -234,6 +262,7 @@ type Device interface {
 	// Wait waits for a fence to reach the specified value.
 	// Returns true if the fence reached the value, false if timeout.
 	// Returns ErrDeviceLost if the device is lost.
+	// Use WaitContext to wait until a context ends instead.
 	Wait(fence Fence, value uint64, timeout time.Duration) (bool, error)
 
 	// ResetFence resets a fence to the unsignaled state.
@@ -277,7 +306,9 @@ type Queue interface {
 	WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDataLayout, size *Extent3D) error
 
 	// Present presents a surface texture to the screen.
-	// The texture must have been acquired via Surface.AcquireTexture.
+	// The texture must be the one most recently acquired from surface via
+	// Surface.AcquireTexture and not yet presented or discarded; callers
+	// validate this (core.Surface.PresentTexture) so backends may rely on it.
 	// After this call, the texture is consumed and must not be used.
 	//
 	// damageRects is an optional list of rectangles (physical pixels, top-left
@@ -342,3 +373,193 @@ type MaxStagingBufferSizer interface {
 	// staging buffer allocation. Returns 0 to use the default (64MB).
 	MaxStagingBufferSize() uint64
 }
+
+// TrimReport describes what Trimmer.Trim released.
+type TrimReport struct {
+	// MemoryBlocks is the number of device memory allocations freed.
+	MemoryBlocks int
+	// MemoryBytes is the total size of the freed allocations.
+	MemoryBytes uint64
+	// CommandPools is the number of idle command pools destroyed.
+	CommandPools int
+}
+
+// Trimmer is an optional interface implemented by HAL devices that pool
+// device memory or command pools. Trim releases the pooled objects that
+// hold no live allocation, so the footprint of long-running applications
+// shrinks after a peak instead of staying at it.
+//
+// Vulkan implements it: empty suballocation blocks and idle command pools
+// are freed. DX12 places each resource in its own committed allocation,
+// which the driver frees when the resource is destroyed, so it has nothing
+// to trim.
+type Trimmer interface {
+	// Trim releases pooled memory that no live resource uses. Memory of
+	// resources still waiting on the GPU is not released.
+	Trim() TrimReport
+}
+
+// MemoryBudget is a device's share of device memory as the operating system
+// reports it.
+type MemoryBudget struct {
+	// Budget is the number of bytes the process can use before the OS
+	// starts demoting its allocations to system memory or failing them.
+	Budget uint64
+	// Usage is the number of bytes the process currently uses.
+	Usage uint64
+}
+
+// MemoryBudgetReporter is an optional interface implemented by HAL devices
+// that can query their memory budget. It is cheap enough to poll every few
+// frames.
+//
+// Vulkan implements it with VK_EXT_memory_budget, summing the device-local
+// heaps. DX12 queries the local segment group of the adapter, and Metal
+// reports currentAllocatedSize against recommendedMaxWorkingSetSize.
+type MemoryBudgetReporter interface {
+	// MemoryBudget returns the device's budget and usage, or false when
+	// the device cannot report them.
+	MemoryBudget() (MemoryBudget, bool)
+}
+
+// NativeDevice names a device and queue another library created and owns,
+// such as a game engine or UI toolkit that already drives the GPU.
+type NativeDevice struct {
+	// Device is the VkDevice, id<MTLDevice> or ID3D12Device*.
+	Device uintptr
+
+	// Queue is the VkQueue, id<MTLCommandQueue> or direct
+	// ID3D12CommandQueue* submissions go to. Vulkan requires it; Metal and
+	// DX12 create their own queue on Device when it is zero.
+	Queue uintptr
+
+	// QueueFamilyIndex is the Vulkan queue family Queue belongs to.
+	QueueFamilyIndex uint32
+
+	// Extensions are the Vulkan device extensions the owner enabled,
+	// without a NUL terminator. Optional Vulkan paths, such as incremental
+	// present, are only used when their extension is listed.
+	Extensions []string
+}
+
+// DeviceAdopter is an optional interface implemented by adapters that can
+// wrap a NativeDevice instead of opening a new device. The native device
+// must have been created on the GPU the adapter represents.
+//
+// The returned device does not own the native device and queue: Destroy
+// releases only what the HAL created on them, and the owner must keep both
+// alive until then. Submissions from the owner and the HAL must not
+// overlap, as both use the same queue.
+//
+// Vulkan, Metal and DX12 implement it.
+type DeviceAdopter interface {
+	// Adopt wraps native. features and limits are those the native device
+	// was created with; the HAL does not enable anything on it.
+	Adopt(native *NativeDevice, features gputypes.Features, limits gputypes.Limits) (OpenDevice, error)
+}
+
+// TextureImporter is an optional interface implemented by devices that can
+// wrap a native texture created on the same device, so the HAL can render
+// into or sample from it.
+//
+// DestroyTexture on an imported texture releases only the HAL wrapper; the
+// owner keeps the native texture alive until the GPU work using it is done.
+//
+// Vulkan, Metal and DX12 implement it.
+type TextureImporter interface {
+	// ImportTexture wraps handle, a VkImage, id<MTLTexture> or
+	// ID3D12Resource*. desc describes the native texture as it was
+	// created. currentUsage is the usage whose layout or resource state the
+	// texture is in; DX12 tracks resource states from it, Vulkan and Metal
+	// leave transitions to the caller.
+	ImportTexture(handle uintptr, currentUsage gputypes.TextureUsage, desc *TextureDescriptor) (Texture, error)
+}
+
+// NativeFenceType identifies the kind of OS handle a NativeFence holds.
+type NativeFenceType uint8
+
+const (
+	// NativeFenceOpaqueFD is a file descriptor of a Vulkan timeline
+	// semaphore (VK_KHR_external_semaphore_fd), as CUDA imports with
+	// cudaExternalSemaphoreHandleTypeTimelineSemaphoreFd.
+	NativeFenceOpaqueFD NativeFenceType = iota + 1
+
+	// NativeFenceOpaqueWin32 is an NT handle of a Vulkan timeline semaphore
+	// (VK_KHR_external_semaphore_win32).
+	NativeFenceOpaqueWin32
+
+	// NativeFenceD3D12 is an NT handle of a shared ID3D12Fence. Vulkan can
+	// import it on Windows as well.
+	NativeFenceD3D12
+
+	// NativeFenceMetalSharedEvent is an id<MTLSharedEvent>.
+	NativeFenceMetalSharedEvent
+)
+
+// NativeFence is an OS handle to a timeline fence shared with another API,
+// such as CUDA, OpenCL or a media engine, or with another process.
+type NativeFence struct {
+	Type   NativeFenceType
+	Handle uintptr
+}
+
+// FenceValue is a point on the timeline of a fence.
+type FenceValue struct {
+	Fence Fence
+	Value uint64
+}
+
+// FenceSharer is an optional interface implemented by devices whose fences
+// can be shared with other APIs and processes. Shared fences are timelines:
+// their value starts at 0 and only grows, and Wait waits for a value.
+//
+// Vulkan implements it with timeline semaphores when the driver supports
+// exporting them, DX12 with shared fences and Metal with MTLSharedEvent.
+type FenceSharer interface {
+	// CreateSharedFence creates a fence that ExportFence can export.
+	CreateSharedFence() (Fence, error)
+
+	// ExportFence returns a handle to fence, which must come from
+	// CreateSharedFence or ImportFence. File descriptors and NT handles
+	// are new and owned by the caller, who closes them; an
+	// id<MTLSharedEvent> is the fence's own and valid while it lives.
+	ExportFence(fence Fence) (NativeFence, error)
+
+	// ImportFence wraps a fence another API or process exported. As in
+	// Vulkan, a successful import of a NativeFenceOpaqueFD takes ownership
+	// of the descriptor; other handles stay owned by the caller.
+	ImportFence(native NativeFence) (Fence, error)
+}
+
+// FenceSubmitter is an optional interface implemented by queues that can
+// order a submission after fence values and signal fence values when it
+// completes, all on the GPU timeline, so work on this queue and work on
+// another API's queue can depend on each other without a CPU round trip.
+//
+// Vulkan, DX12 and Metal implement it for fences from hal.FenceSharer.
+type FenceSubmitter interface {
+	// SubmitWithFences submits commandBuffers like Submit. They do not
+	// start before every fence in waits has reached its value, and every
+	// fence in signals is set to its value once they complete. With no
+	// command buffers only the waits and signals are submitted.
+	SubmitWithFences(commandBuffers []CommandBuffer, waits, signals []FenceValue) (submissionIndex uint64, err error)
+}
+
+// BatchCreator is an optional interface implemented by devices that create
+// many buffers or textures faster together than one at a time, by grouping
+// native calls and sharing the work of translating descriptors. Use the
+// CreateBuffers and CreateTextures helpers, which fall back to one call per
+// resource on other devices.
+//
+// Vulkan implements it: memory for the whole batch is bound with a single
+// vkBindBufferMemory2 or vkBindImageMemory2 call, and format support
+// queries are made once per distinct format.
+type BatchCreator interface {
+	// CreateBuffers creates a buffer for each descriptor, in order. On error
+	// no buffer is left behind.
+	CreateBuffers(descs []*BufferDescriptor) ([]Buffer, error)
+
+	// CreateTextures creates a texture for each descriptor, in order. On
+	// error no texture is left behind.
+	CreateTextures(descs []*TextureDescriptor) ([]Texture, error)
+}
@@ -0,0 +1,149 @@
+//go:build !(js && wasm)
+
+package hal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BackendOptions carries per-backend instance settings beyond what the
+// portable descriptor expresses. Each backend reads only its own section
+// and merges it with what it enables itself; the zero value changes
+// nothing.
+type BackendOptions struct {
+	Vulkan   VulkanOptions
+	DX12     DX12Options
+	Metal    MetalOptions
+	GL       GLOptions
+	Software SoftwareOptions
+}
+
+// VulkanOptions requests extensions and layers in addition to those the
+// Vulkan backend enables. Names are given without a NUL terminator. A name
+// the backend already enables is ignored; one the loader or device does not
+// expose fails instance or device creation.
+type VulkanOptions struct {
+	// InstanceExtensions are enabled on the VkInstance.
+	InstanceExtensions []string
+	// InstanceLayers are enabled on the VkInstance, after the validation
+	// layer when InstanceFlagsDebug adds it.
+	InstanceLayers []string
+	// DeviceExtensions are enabled on every VkDevice the instance opens.
+	// Their features are not enabled; pass-through use is up to the caller.
+	DeviceExtensions []string
+	// SynchronizationValidation enables the validation layer with its
+	// synchronization checks, which report missing or wrong barriers as
+	// SYNC-HAZARD messages. InstanceFlagsDebug with InstanceFlagsValidation
+	// does the same.
+	SynchronizationValidation bool
+	// GPUAssistedValidation enables the validation layer with GPU-assisted
+	// validation, which instruments shaders to catch out-of-bounds
+	// descriptor and buffer accesses at draw time, at a large performance
+	// cost. InstanceFlagsDebug with InstanceFlagsGPUBasedValidation does
+	// the same.
+	GPUAssistedValidation bool
+}
+
+// DXGICreateFactoryDebug is DXGI_CREATE_FACTORY_DEBUG, the only flag
+// CreateDXGIFactory2 defines.
+const DXGICreateFactoryDebug uint32 = 0x1
+
+// DX12Options tunes DXGI factory creation and the D3D12 debug layer.
+type DX12Options struct {
+	// FactoryFlags are passed to CreateDXGIFactory2 in addition to the
+	// debug flag InstanceFlagsDebug implies. Unlike the implied flag, an
+	// explicit DXGICreateFactoryDebug fails instance creation when the
+	// Graphics Tools are not installed.
+	FactoryFlags uint32
+	// DebugLayer enables the D3D12 debug layer and DRED as
+	// InstanceFlagsDebug does, without enabling validation on the other
+	// backends.
+	DebugLayer bool
+	// GPUBasedValidation enables GPU-based validation as
+	// InstanceFlagsValidation does. It has no effect without the debug
+	// layer, from DebugLayer or InstanceFlagsDebug.
+	GPUBasedValidation bool
+}
+
+// MaxMetalFramesInFlight bounds MetalOptions.MaxFramesInFlight.
+const MaxMetalFramesInFlight = 16
+
+// MetalOptions toggles Metal backend behavior.
+type MetalOptions struct {
+	// MaxFramesInFlight is how many submissions the CPU may queue ahead of
+	// the GPU before Submit blocks. 0 keeps the default of 2.
+	MaxFramesInFlight int
+	// DisableIndirectCommandBuffers keeps render pipelines off the indirect
+	// command buffer path Apple7+ GPUs use for indexed indirect draws, as a
+	// workaround for driver issues.
+	DisableIndirectCommandBuffers bool
+}
+
+// ImplicitLodRewrite selects which implicit-LOD texture samples the GL
+// backend turns into explicit-LOD samples when translating shaders.
+type ImplicitLodRewrite uint8
+
+const (
+	// ImplicitLodKeep translates samples as written.
+	ImplicitLodKeep ImplicitLodRewrite = iota
+	// ImplicitLodInControlFlow rewrites samples evaluated inside if, switch
+	// and loop bodies, directly or through a called function. Their
+	// implicit derivatives are undefined in non-uniform control flow, and
+	// some GLES drivers render garbage or fail to link them.
+	ImplicitLodInControlFlow
+	// ImplicitLodAlways rewrites every implicit-LOD sample.
+	ImplicitLodAlways
+)
+
+// GLOptions tunes shader translation on the GL backend.
+type GLOptions struct {
+	// ImplicitLod makes textureSample sample mip level 0 and
+	// textureSampleBias sample the level given by its bias, for the samples
+	// it selects. This trades mipmapping for correct results on hardware
+	// without reliable derivatives.
+	ImplicitLod ImplicitLodRewrite
+}
+
+// SoftwareOptions tunes the software backend.
+type SoftwareOptions struct {
+	// Deterministic rasterizes with fixed-point edge functions on a
+	// 1/256-pixel grid and rounds every interpolation product, so a draw
+	// produces the same pixels on every OS and architecture. Fuzzers and
+	// property tests comparing the software backend against GPU output use
+	// it to rule out floating-point variance in the reference image.
+	Deterministic bool
+}
+
+// Validate reports options no backend can honor: empty or NUL-containing
+// Vulkan names, unknown DXGI factory flags, an out-of-range Metal frame
+// count and an unknown GL implicit-LOD rewrite.
+func (o *BackendOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, list := range []struct {
+		field string
+		names []string
+	}{
+		{"Vulkan.InstanceExtensions", o.Vulkan.InstanceExtensions},
+		{"Vulkan.InstanceLayers", o.Vulkan.InstanceLayers},
+		{"Vulkan.DeviceExtensions", o.Vulkan.DeviceExtensions},
+	} {
+		for _, name := range list.names {
+			if name == "" || strings.ContainsRune(name, 0) {
+				return fmt.Errorf("hal: BackendOptions.%s: invalid name %q", list.field, name)
+			}
+		}
+	}
+	if unknown := o.DX12.FactoryFlags &^ DXGICreateFactoryDebug; unknown != 0 {
+		return fmt.Errorf("hal: BackendOptions.DX12.FactoryFlags: unknown flags %#x", unknown)
+	}
+	if n := o.Metal.MaxFramesInFlight; n < 0 || n > MaxMetalFramesInFlight {
+		return fmt.Errorf("hal: BackendOptions.Metal.MaxFramesInFlight %d is outside 0..%d", n, MaxMetalFramesInFlight)
+	}
+	if o.GL.ImplicitLod > ImplicitLodAlways {
+		return fmt.Errorf("hal: BackendOptions.GL.ImplicitLod: unknown rewrite %d", o.GL.ImplicitLod)
+	}
+	return nil
+}
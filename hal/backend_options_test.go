@@ -0,0 +1,29 @@
+//go:build !(js && wasm)
+
+package hal
+
+import "testing"
+
+func TestBackendOptionsValidate(t *testing.T) {
+	valid := BackendOptions{
+		Vulkan: VulkanOptions{InstanceExtensions: []string{"VK_EXT_debug_report"}},
+		DX12:   DX12Options{FactoryFlags: DXGICreateFactoryDebug},
+		Metal:  MetalOptions{MaxFramesInFlight: 3},
+		GL:     GLOptions{ImplicitLod: ImplicitLodAlways},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("valid options: %v", err)
+	}
+	for name, opts := range map[string]BackendOptions{
+		"empty name":      {Vulkan: VulkanOptions{DeviceExtensions: []string{""}}},
+		"NUL in name":     {Vulkan: VulkanOptions{InstanceLayers: []string{"VK_LAYER_x\x00"}}},
+		"unknown flag":    {DX12: DX12Options{FactoryFlags: 0x4}},
+		"frames too low":  {Metal: MetalOptions{MaxFramesInFlight: -1}},
+		"frames too high": {Metal: MetalOptions{MaxFramesInFlight: MaxMetalFramesInFlight + 1}},
+		"unknown rewrite": {GL: GLOptions{ImplicitLod: ImplicitLodAlways + 1}},
+	} {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
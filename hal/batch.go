@@ -0,0 +1,52 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "fmt"
+
+// CreateBuffers creates a buffer on device for each descriptor, in order.
+// Devices implementing BatchCreator create them together; on others each
+// buffer is created with Device.CreateBuffer. On error the buffers already
+// created are destroyed and the error names the failing descriptor.
+func CreateBuffers(device Device, descs []*BufferDescriptor) ([]Buffer, error) {
+	if batch, ok := device.(BatchCreator); ok {
+		return batch.CreateBuffers(descs)
+	}
+	buffers := make([]Buffer, 0, len(descs))
+	for i, desc := range descs {
+		buffer, err := device.CreateBuffer(desc)
+		if err != nil {
+			for _, b := range buffers {
+				device.DestroyBuffer(b)
+			}
+			return nil, fmt.Errorf("buffer %d: %w", i, err)
+		}
+		buffers = append(buffers, buffer)
+	}
+	return buffers, nil
+}
+
+// CreateTextures creates a texture on device for each descriptor, in order.
+// Devices implementing BatchCreator create them together; on others each
+// texture is created with Device.CreateTexture. On error the textures
+// already created are destroyed and the error names the failing descriptor.
+func CreateTextures(device Device, descs []*TextureDescriptor) ([]Texture, error) {
+	if batch, ok := device.(BatchCreator); ok {
+		return batch.CreateTextures(descs)
+	}
+	textures := make([]Texture, 0, len(descs))
+	for i, desc := range descs {
+		texture, err := device.CreateTexture(desc)
+		if err != nil {
+			for _, t := range textures {
+				device.DestroyTexture(t)
+			}
+			return nil, fmt.Errorf("texture %d: %w", i, err)
+		}
+		textures = append(textures, texture)
+	}
+	return textures, nil
+}
@@ -0,0 +1,88 @@
+//go:build !(js && wasm)
+
+package hal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// countingBuffer is a buffer created by batchTestDevice.
+type countingBuffer struct {
+	hal.Buffer
+	index int
+}
+
+// batchTestDevice creates buffers one at a time, failing the one at failAt
+// (counted from 1), and records which buffers it destroyed.
+type batchTestDevice struct {
+	hal.Device
+	created   int
+	failAt    int
+	destroyed []int
+}
+
+func (d *batchTestDevice) CreateBuffer(*hal.BufferDescriptor) (hal.Buffer, error) {
+	d.created++
+	if d.created == d.failAt {
+		return nil, hal.ErrDeviceOutOfMemory
+	}
+	return &countingBuffer{index: d.created}, nil
+}
+
+func (d *batchTestDevice) DestroyBuffer(b hal.Buffer) {
+	d.destroyed = append(d.destroyed, b.(*countingBuffer).index)
+}
+
+// batchCreatorDevice implements hal.BatchCreator.
+type batchCreatorDevice struct {
+	batchTestDevice
+	batches int
+}
+
+func (d *batchCreatorDevice) CreateBuffers(descs []*hal.BufferDescriptor) ([]hal.Buffer, error) {
+	d.batches++
+	return make([]hal.Buffer, len(descs)), nil
+}
+
+func (d *batchCreatorDevice) CreateTextures(descs []*hal.TextureDescriptor) ([]hal.Texture, error) {
+	d.batches++
+	return make([]hal.Texture, len(descs)), nil
+}
+
+func TestCreateBuffers(t *testing.T) {
+	descs := make([]*hal.BufferDescriptor, 3)
+	for i := range descs {
+		descs[i] = &hal.BufferDescriptor{Size: 256}
+	}
+
+	device := &batchTestDevice{}
+	buffers, err := hal.CreateBuffers(device, descs)
+	if err != nil {
+		t.Fatalf("CreateBuffers = %v", err)
+	}
+	if len(buffers) != 3 || device.created != 3 {
+		t.Errorf("CreateBuffers returned %d buffers from %d calls, want 3 from 3", len(buffers), device.created)
+	}
+
+	failing := &batchTestDevice{failAt: 3}
+	if _, err := hal.CreateBuffers(failing, descs); !errors.Is(err, hal.ErrDeviceOutOfMemory) {
+		t.Fatalf("CreateBuffers on a failing device = %v, want ErrDeviceOutOfMemory", err)
+	}
+	if len(failing.destroyed) != 2 {
+		t.Errorf("after a failed batch, destroyed buffers %v, want the 2 created", failing.destroyed)
+	}
+
+	batch := &batchCreatorDevice{}
+	if buffers, err := hal.CreateBuffers(batch, descs); err != nil || len(buffers) != 3 {
+		t.Fatalf("CreateBuffers on a BatchCreator = %d buffers, %v", len(buffers), err)
+	}
+	if _, err := hal.CreateTextures(batch, []*hal.TextureDescriptor{{}}); err != nil {
+		t.Fatalf("CreateTextures on a BatchCreator = %v", err)
+	}
+	if batch.batches != 2 || batch.created != 0 {
+		t.Errorf("BatchCreator got %d batch calls and %d single ones, want 2 and 0", batch.batches, batch.created)
+	}
+}
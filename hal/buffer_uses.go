@@ -0,0 +1,85 @@
+//go:build !(js && wasm)
+
+package hal
+
+import "github.com/gogpu/gputypes"
+
+// BufferUses is the state a buffer is in for a BufferBarrier. Unlike
+// gputypes.BufferUsage, which lists every way a buffer may ever be used,
+// a BufferUses value describes how the buffer is accessed right now, and
+// splits storage access into read-only and read-write so backends can
+// emit the narrowest native barrier. Matches Rust wgpu-hal BufferUses.
+type BufferUses uint32
+
+const (
+	// BufferUseMapRead is host read access through a mapping.
+	BufferUseMapRead BufferUses = 1 << iota
+	// BufferUseMapWrite is host write access through a mapping.
+	BufferUseMapWrite
+	// BufferUseCopySrc is the source of a copy command.
+	BufferUseCopySrc
+	// BufferUseCopyDst is the destination of a copy or clear command.
+	BufferUseCopyDst
+	// BufferUseIndex is an index buffer read by draws.
+	BufferUseIndex
+	// BufferUseVertex is a vertex buffer read by draws.
+	BufferUseVertex
+	// BufferUseUniform is a uniform buffer read by shaders.
+	BufferUseUniform
+	// BufferUseStorageRead is a read-only storage buffer.
+	BufferUseStorageRead
+	// BufferUseStorageReadWrite is a read-write storage buffer.
+	BufferUseStorageReadWrite
+	// BufferUseIndirect holds indirect draw or dispatch arguments.
+	BufferUseIndirect
+	// BufferUseQueryResolve is the destination of a query resolve.
+	BufferUseQueryResolve
+)
+
+// BufferUsesWrite is the set of states that write to the buffer.
+const BufferUsesWrite = BufferUseMapWrite | BufferUseCopyDst |
+	BufferUseStorageReadWrite | BufferUseQueryResolve
+
+// IsReadOnly reports whether u contains no writing state. Two read-only
+// states need no memory barrier between them.
+func (u BufferUses) IsReadOnly() bool {
+	return u&BufferUsesWrite == 0
+}
+
+// BufferUsesFromUsage returns the states a buffer created with usage can
+// be in. Storage maps to BufferUseStorageReadWrite because the creation
+// usage does not say whether shaders write to the buffer.
+func BufferUsesFromUsage(usage gputypes.BufferUsage) BufferUses {
+	var uses BufferUses
+	if usage&gputypes.BufferUsageMapRead != 0 {
+		uses |= BufferUseMapRead
+	}
+	if usage&gputypes.BufferUsageMapWrite != 0 {
+		uses |= BufferUseMapWrite
+	}
+	if usage&gputypes.BufferUsageCopySrc != 0 {
+		uses |= BufferUseCopySrc
+	}
+	if usage&gputypes.BufferUsageCopyDst != 0 {
+		uses |= BufferUseCopyDst
+	}
+	if usage&gputypes.BufferUsageIndex != 0 {
+		uses |= BufferUseIndex
+	}
+	if usage&gputypes.BufferUsageVertex != 0 {
+		uses |= BufferUseVertex
+	}
+	if usage&gputypes.BufferUsageUniform != 0 {
+		uses |= BufferUseUniform
+	}
+	if usage&gputypes.BufferUsageStorage != 0 {
+		uses |= BufferUseStorageReadWrite
+	}
+	if usage&gputypes.BufferUsageIndirect != 0 {
+		uses |= BufferUseIndirect
+	}
+	if usage&gputypes.BufferUsageQueryResolve != 0 {
+		uses |= BufferUseQueryResolve
+	}
+	return uses
+}
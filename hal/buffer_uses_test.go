@@ -0,0 +1,41 @@
+//go:build !(js && wasm)
+
+package hal_test
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestBufferUsesIsReadOnly(t *testing.T) {
+	tests := []struct {
+		uses hal.BufferUses
+		want bool
+	}{
+		{0, true},
+		{hal.BufferUseStorageRead | hal.BufferUseIndirect, true},
+		{hal.BufferUseCopySrc | hal.BufferUseVertex | hal.BufferUseUniform, true},
+		{hal.BufferUseStorageReadWrite, false},
+		{hal.BufferUseCopyDst, false},
+		{hal.BufferUseQueryResolve, false},
+		{hal.BufferUseMapWrite | hal.BufferUseCopySrc, false},
+	}
+	for _, test := range tests {
+		if got := test.uses.IsReadOnly(); got != test.want {
+			t.Errorf("BufferUses(%#x).IsReadOnly() = %v, want %v", test.uses, got, test.want)
+		}
+	}
+}
+
+func TestBufferUsesFromUsage(t *testing.T) {
+	usage := gputypes.BufferUsageVertex | gputypes.BufferUsageStorage | gputypes.BufferUsageCopyDst
+	want := hal.BufferUseVertex | hal.BufferUseStorageReadWrite | hal.BufferUseCopyDst
+	if got := hal.BufferUsesFromUsage(usage); got != want {
+		t.Errorf("BufferUsesFromUsage(%#x) = %#x, want %#x", usage, got, want)
+	}
+	if got := hal.BufferUsesFromUsage(0); got != 0 {
+		t.Errorf("BufferUsesFromUsage(0) = %#x, want 0", got)
+	}
+}
@@ -0,0 +1,181 @@
+//go:build !(js && wasm)
+
+package capture_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/capture"
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+// openSoftware opens a software device.
+func openSoftware(t *testing.T) hal.OpenDevice {
+	t.Helper()
+	inst, err := software.API{}.CreateInstance(&hal.InstanceDescriptor{})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Destroy)
+	adapters := inst.EnumerateAdapters(nil)
+	if len(adapters) == 0 {
+		t.Fatal("no software adapter")
+	}
+	open, err := adapters[0].Adapter.Open(0, gputypes.DefaultLimits())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return open
+}
+
+// readBuffer returns the contents of a software buffer.
+func readBuffer(t *testing.T, device hal.Device, buffer hal.Buffer, size uint64) []byte {
+	t.Helper()
+	m, err := device.MapBuffer(buffer, 0, size)
+	if err != nil {
+		t.Fatalf("MapBuffer: %v", err)
+	}
+	out := bytes.Clone(unsafe.Slice((*byte)(m.Ptr), size))
+	if err := device.UnmapBuffer(buffer); err != nil {
+		t.Fatalf("UnmapBuffer: %v", err)
+	}
+	return out
+}
+
+// recordCopy records uploading data through a mapped buffer, copying it to
+// a second buffer and clearing a texture, and returns the capture.
+func recordCopy(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	open := capture.Wrap(openSoftware(t), &out, capture.Header{Backend: gputypes.BackendEmpty})
+	device, queue := open.Device, open.Queue
+
+	size := uint64(len(data))
+	src, err := device.CreateBuffer(&hal.BufferDescriptor{Label: "src", Size: size, Usage: gputypes.BufferUsageMapWrite | gputypes.BufferUsageCopySrc})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	dst, err := device.CreateBuffer(&hal.BufferDescriptor{Label: "dst", Size: size, Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	m, err := device.MapBuffer(src, 0, size)
+	if err != nil {
+		t.Fatalf("MapBuffer: %v", err)
+	}
+	copy(unsafe.Slice((*byte)(m.Ptr), size), data)
+	if err := device.UnmapBuffer(src); err != nil {
+		t.Fatalf("UnmapBuffer: %v", err)
+	}
+
+	enc, err := device.CreateCommandEncoder(&hal.CommandEncoderDescriptor{Label: "copy"})
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	if err := enc.BeginEncoding("copy"); err != nil {
+		t.Fatalf("BeginEncoding: %v", err)
+	}
+	enc.CopyBufferToBuffer(src, dst, []hal.BufferCopy{{Size: size}})
+	cmd, err := enc.EndEncoding()
+	if err != nil {
+		t.Fatalf("EndEncoding: %v", err)
+	}
+	if _, err := queue.Submit([]hal.CommandBuffer{cmd}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := device.WaitIdle(); err != nil {
+		t.Fatalf("WaitIdle: %v", err)
+	}
+	if got := readBuffer(t, device, dst, size); !bytes.Equal(got, data) {
+		t.Fatalf("recorded copy = %v, want %v", got, data)
+	}
+	device.FreeCommandBuffer(cmd)
+	enc.Destroy()
+	device.Destroy()
+	if err := device.(*capture.Device).Err(); err != nil {
+		t.Fatalf("capture error: %v", err)
+	}
+	return out.Bytes()
+}
+
+func TestRecordReplay(t *testing.T) {
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	trace := recordCopy(t, data)
+
+	r, err := capture.NewReader(bytes.NewReader(trace))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if r.Header().Version != capture.Version || r.Header().Backend != gputypes.BackendEmpty {
+		t.Errorf("Header = %+v", r.Header())
+	}
+
+	open := openSoftware(t)
+	defer open.Device.Destroy()
+	p := capture.NewPlayer(open.Device, open.Queue)
+	if err := p.Play(r); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	defer p.Close()
+	if p.Commands() == 0 {
+		t.Fatal("no commands replayed")
+	}
+
+	// IDs are assigned in creation order: the destination buffer is 2.
+	dst, ok := p.Object(2).(hal.Buffer)
+	if !ok {
+		t.Fatalf("Object(2) = %T, want hal.Buffer", p.Object(2))
+	}
+	if got := readBuffer(t, open.Device, dst, uint64(len(data))); !bytes.Equal(got, data) {
+		t.Errorf("replayed copy = %v, want %v", got, data)
+	}
+}
+
+func TestReaderRejectsVersion(t *testing.T) {
+	_, err := capture.NewReader(strings.NewReader(`{"version":99}` + "\n"))
+	if !errors.Is(err, capture.ErrUnsupportedVersion) {
+		t.Fatalf("NewReader error = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestPlayerUnknownObject(t *testing.T) {
+	trace := `{"version":1}
+{"op":"destroyBuffer","id":7}
+`
+	r, err := capture.NewReader(strings.NewReader(trace))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	open := openSoftware(t)
+	defer open.Device.Destroy()
+	p := capture.NewPlayer(open.Device, open.Queue)
+	defer p.Close()
+	err = p.Play(r)
+	if err == nil || !strings.Contains(err.Error(), "line 2") || !strings.Contains(err.Error(), "unknown buffer 7") {
+		t.Fatalf("Play error = %v, want unknown buffer on line 2", err)
+	}
+}
+
+func TestPlayerRejectsKindMismatch(t *testing.T) {
+	trace := `{"version":1}
+{"op":"createBuffer","id":1,"args":{"Size":16,"Usage":8}}
+{"op":"destroyTexture","id":1}
+`
+	r, err := capture.NewReader(strings.NewReader(trace))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	open := openSoftware(t)
+	defer open.Device.Destroy()
+	p := capture.NewPlayer(open.Device, open.Queue)
+	defer p.Close()
+	if err := p.Play(r); err == nil || !strings.Contains(err.Error(), "is a buffer, not a texture") {
+		t.Fatalf("Play error = %v, want kind mismatch", err)
+	}
+}
@@ -0,0 +1,511 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"image"
+	"time"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Device is a hal.Device that records its calls. Create it with Wrap.
+type Device struct {
+	raw hal.Device
+	rec *recorder
+}
+
+// Err returns the first error writing the capture, if any.
+func (d *Device) Err() error { return d.rec.Err() }
+
+// CreateBuffer creates a buffer and records it.
+func (d *Device) CreateBuffer(desc *hal.BufferDescriptor) (hal.Buffer, error) {
+	buffer, err := d.raw.CreateBuffer(desc)
+	if err != nil {
+		return nil, err
+	}
+	id := d.rec.register(buffer)
+	d.rec.setHandle(d.rec.buffers, buffer.NativeHandle(), id)
+	if desc.Usage&gputypes.BufferUsageMapWrite != 0 || desc.MappedAtCreation {
+		d.rec.mu.Lock()
+		d.rec.hostWritten[id] = true
+		d.rec.mu.Unlock()
+	}
+	d.rec.emit(OpCreateBuffer, id, desc)
+	return buffer, nil
+}
+
+// DestroyBuffer destroys a buffer and records it.
+func (d *Device) DestroyBuffer(buffer hal.Buffer) {
+	if id := d.rec.forget(buffer); id != 0 {
+		d.rec.setHandle(d.rec.buffers, buffer.NativeHandle(), 0)
+		d.rec.mu.Lock()
+		delete(d.rec.hostWritten, id)
+		delete(d.rec.mappings, id)
+		d.rec.mu.Unlock()
+		d.rec.emit(OpDestroyBuffer, id, nil)
+	}
+	d.raw.DestroyBuffer(buffer)
+}
+
+// MapBuffer maps a buffer. Mappings of buffers the host can write are
+// recorded when they are unmapped.
+func (d *Device) MapBuffer(buffer hal.Buffer, offset, size uint64) (hal.BufferMapping, error) {
+	m, err := d.raw.MapBuffer(buffer, offset, size)
+	if err == nil {
+		d.rec.mapped(buffer, offset, size, m)
+	}
+	return m, err
+}
+
+// UnmapBuffer records the mapped contents of host-writable buffers and
+// unmaps the buffer.
+func (d *Device) UnmapBuffer(buffer hal.Buffer) error {
+	d.rec.unmapped(buffer)
+	return d.raw.UnmapBuffer(buffer)
+}
+
+// CreateTexture creates a texture and records it.
+func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error) {
+	texture, err := d.raw.CreateTexture(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateTexture, d.rec.register(texture), desc)
+	return texture, nil
+}
+
+// ImportTexture imports a native texture and records it as created with
+// desc, since the native texture does not exist at replay.
+func (d *Device) ImportTexture(handle uintptr, currentUsage gputypes.TextureUsage, desc *hal.TextureDescriptor) (hal.Texture, error) {
+	importer, ok := d.raw.(hal.TextureImporter)
+	if !ok {
+		return nil, hal.ErrExternalNotSupported
+	}
+	texture, err := importer.ImportTexture(handle, currentUsage, desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateTexture, d.rec.register(texture), desc)
+	return texture, nil
+}
+
+// DestroyTexture destroys a texture and records it.
+func (d *Device) DestroyTexture(texture hal.Texture) {
+	if id := d.rec.forget(texture); id != 0 {
+		d.rec.emit(OpDestroyTexture, id, nil)
+	}
+	d.raw.DestroyTexture(texture)
+}
+
+// CreateTextureView creates a texture view and records it.
+func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDescriptor) (hal.TextureView, error) {
+	view, err := d.raw.CreateTextureView(texture, desc)
+	if err != nil {
+		return nil, err
+	}
+	textureID := d.rec.texture(texture)
+	id := d.rec.register(view)
+	d.rec.setHandle(d.rec.views, view.NativeHandle(), id)
+	d.rec.emit(OpCreateTextureView, id, textureViewArgs{Texture: textureID, Desc: desc})
+	return view, nil
+}
+
+// DestroyTextureView destroys a texture view and records it.
+func (d *Device) DestroyTextureView(view hal.TextureView) {
+	if id := d.rec.forget(view); id != 0 {
+		d.rec.setHandle(d.rec.views, view.NativeHandle(), 0)
+		d.rec.emit(OpDestroyTextureView, id, nil)
+	}
+	d.raw.DestroyTextureView(view)
+}
+
+// CreateSampler creates a sampler and records it.
+func (d *Device) CreateSampler(desc *hal.SamplerDescriptor) (hal.Sampler, error) {
+	sampler, err := d.raw.CreateSampler(desc)
+	if err != nil {
+		return nil, err
+	}
+	id := d.rec.register(sampler)
+	d.rec.setHandle(d.rec.samplers, sampler.NativeHandle(), id)
+	d.rec.emit(OpCreateSampler, id, desc)
+	return sampler, nil
+}
+
+// DestroySampler destroys a sampler and records it.
+func (d *Device) DestroySampler(sampler hal.Sampler) {
+	if id := d.rec.forget(sampler); id != 0 {
+		d.rec.setHandle(d.rec.samplers, sampler.NativeHandle(), 0)
+		d.rec.emit(OpDestroySampler, id, nil)
+	}
+	d.raw.DestroySampler(sampler)
+}
+
+// CreateBindGroupLayout creates a bind group layout and records it.
+func (d *Device) CreateBindGroupLayout(desc *hal.BindGroupLayoutDescriptor) (hal.BindGroupLayout, error) {
+	layout, err := d.raw.CreateBindGroupLayout(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateBindGroupLayout, d.rec.register(layout), desc)
+	return layout, nil
+}
+
+// DestroyBindGroupLayout destroys a bind group layout and records it.
+func (d *Device) DestroyBindGroupLayout(layout hal.BindGroupLayout) {
+	if id := d.rec.forget(layout); id != 0 {
+		d.rec.emit(OpDestroyBindGroupLayout, id, nil)
+	}
+	d.raw.DestroyBindGroupLayout(layout)
+}
+
+// CreateBindGroup creates a bind group and records it.
+func (d *Device) CreateBindGroup(desc *hal.BindGroupDescriptor) (hal.BindGroup, error) {
+	group, err := d.raw.CreateBindGroup(desc)
+	if err != nil {
+		return nil, err
+	}
+	args := bindGroupArgs{
+		Label:   desc.Label,
+		Layout:  d.rec.id(desc.Layout),
+		Entries: make([]bindGroupEntry, len(desc.Entries)),
+	}
+	for i, entry := range desc.Entries {
+		e := bindGroupEntry{Binding: entry.Binding}
+		switch res := entry.Resource.(type) {
+		case gputypes.BufferBinding:
+			e.Buffer = d.rec.handleID(d.rec.buffers, res.Buffer)
+			e.Offset = res.Offset
+			e.Size = res.Size
+		case gputypes.SamplerBinding:
+			e.Sampler = d.rec.handleID(d.rec.samplers, res.Sampler)
+		case gputypes.TextureViewBinding:
+			e.TextureView = d.rec.handleID(d.rec.views, res.TextureView)
+		}
+		args.Entries[i] = e
+	}
+	d.rec.emit(OpCreateBindGroup, d.rec.register(group), args)
+	return group, nil
+}
+
+// DestroyBindGroup destroys a bind group and records it.
+func (d *Device) DestroyBindGroup(group hal.BindGroup) {
+	if id := d.rec.forget(group); id != 0 {
+		d.rec.emit(OpDestroyBindGroup, id, nil)
+	}
+	d.raw.DestroyBindGroup(group)
+}
+
+// CreatePipelineLayout creates a pipeline layout and records it.
+func (d *Device) CreatePipelineLayout(desc *hal.PipelineLayoutDescriptor) (hal.PipelineLayout, error) {
+	layout, err := d.raw.CreatePipelineLayout(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreatePipelineLayout, d.rec.register(layout), pipelineLayoutArgs{
+		Label:              desc.Label,
+		BindGroupLayouts:   idsOf(d.rec, desc.BindGroupLayouts),
+		PushConstantRanges: desc.PushConstantRanges,
+	})
+	return layout, nil
+}
+
+// DestroyPipelineLayout destroys a pipeline layout and records it.
+func (d *Device) DestroyPipelineLayout(layout hal.PipelineLayout) {
+	if id := d.rec.forget(layout); id != 0 {
+		d.rec.emit(OpDestroyPipelineLayout, id, nil)
+	}
+	d.raw.DestroyPipelineLayout(layout)
+}
+
+// CreateShaderModule creates a shader module and records it with its
+// source.
+func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.ShaderModule, error) {
+	module, err := d.raw.CreateShaderModule(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateShaderModule, d.rec.register(module), desc)
+	return module, nil
+}
+
+// DestroyShaderModule destroys a shader module and records it.
+func (d *Device) DestroyShaderModule(module hal.ShaderModule) {
+	if id := d.rec.forget(module); id != 0 {
+		d.rec.emit(OpDestroyShaderModule, id, nil)
+	}
+	d.raw.DestroyShaderModule(module)
+}
+
+// CreateRenderPipeline creates a render pipeline and records it.
+func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.RenderPipeline, error) {
+	pipeline, err := d.raw.CreateRenderPipeline(desc)
+	if err != nil {
+		return nil, err
+	}
+	args := renderPipelineArgs{
+		Label:  desc.Label,
+		Layout: d.rec.id(desc.Layout),
+		Vertex: vertexArgs{
+			Module:     d.rec.id(desc.Vertex.Module),
+			EntryPoint: desc.Vertex.EntryPoint,
+			Buffers:    desc.Vertex.Buffers,
+		},
+		Primitive:    desc.Primitive,
+		PolygonMode:  desc.PolygonMode,
+		DepthStencil: desc.DepthStencil,
+		Multisample:  desc.Multisample,
+		ViewCount:    desc.ViewCount,
+	}
+	if desc.Fragment != nil {
+		args.Fragment = &fragmentArgs{
+			Module:     d.rec.id(desc.Fragment.Module),
+			EntryPoint: desc.Fragment.EntryPoint,
+			Targets:    desc.Fragment.Targets,
+		}
+	}
+	d.rec.emit(OpCreateRenderPipeline, d.rec.register(pipeline), args)
+	return pipeline, nil
+}
+
+// DestroyRenderPipeline destroys a render pipeline and records it.
+func (d *Device) DestroyRenderPipeline(pipeline hal.RenderPipeline) {
+	if id := d.rec.forget(pipeline); id != 0 {
+		d.rec.emit(OpDestroyRenderPipeline, id, nil)
+	}
+	d.raw.DestroyRenderPipeline(pipeline)
+}
+
+// CreateComputePipeline creates a compute pipeline and records it.
+func (d *Device) CreateComputePipeline(desc *hal.ComputePipelineDescriptor) (hal.ComputePipeline, error) {
+	pipeline, err := d.raw.CreateComputePipeline(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateComputePipeline, d.rec.register(pipeline), computePipelineArgs{
+		Label:                         desc.Label,
+		Layout:                        d.rec.id(desc.Layout),
+		Module:                        d.rec.id(desc.Compute.Module),
+		EntryPoint:                    desc.Compute.EntryPoint,
+		Constants:                     desc.Compute.Constants,
+		ZeroInitializeWorkgroupMemory: desc.Compute.ZeroInitializeWorkgroupMemory,
+	})
+	return pipeline, nil
+}
+
+// DestroyComputePipeline destroys a compute pipeline and records it.
+func (d *Device) DestroyComputePipeline(pipeline hal.ComputePipeline) {
+	if id := d.rec.forget(pipeline); id != 0 {
+		d.rec.emit(OpDestroyComputePipeline, id, nil)
+	}
+	d.raw.DestroyComputePipeline(pipeline)
+}
+
+// CreateQuerySet creates a query set and records it.
+func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, error) {
+	querySet, err := d.raw.CreateQuerySet(desc)
+	if err != nil {
+		return nil, err
+	}
+	d.rec.emit(OpCreateQuerySet, d.rec.register(querySet), desc)
+	return querySet, nil
+}
+
+// DestroyQuerySet destroys a query set and records it.
+func (d *Device) DestroyQuerySet(querySet hal.QuerySet) {
+	if id := d.rec.forget(querySet); id != 0 {
+		d.rec.emit(OpDestroyQuerySet, id, nil)
+	}
+	d.raw.DestroyQuerySet(querySet)
+}
+
+// CreateCommandEncoder creates a recording command encoder.
+func (d *Device) CreateCommandEncoder(desc *hal.CommandEncoderDescriptor) (hal.CommandEncoder, error) {
+	raw, err := d.raw.CreateCommandEncoder(desc)
+	if err != nil {
+		return nil, err
+	}
+	enc := &CommandEncoder{raw: raw, rec: d.rec}
+	enc.id = d.rec.register(enc)
+	d.rec.emit(OpCreateCommandEncoder, enc.id, desc)
+	return enc, nil
+}
+
+// CreateRenderBundleEncoder creates a recording render bundle encoder.
+func (d *Device) CreateRenderBundleEncoder(desc *hal.RenderBundleEncoderDescriptor) (hal.RenderBundleEncoder, error) {
+	raw, err := d.raw.CreateRenderBundleEncoder(desc)
+	if err != nil {
+		return nil, err
+	}
+	enc := &RenderBundleEncoder{raw: raw, rec: d.rec}
+	enc.id = d.rec.register(enc)
+	d.rec.emit(OpCreateRenderBundleEncoder, enc.id, desc)
+	return enc, nil
+}
+
+// DestroyRenderBundle destroys a render bundle and records it.
+func (d *Device) DestroyRenderBundle(bundle hal.RenderBundle) {
+	if id := d.rec.forget(bundle); id != 0 {
+		d.rec.emit(OpDestroyRenderBundle, id, nil)
+	}
+	d.raw.DestroyRenderBundle(bundle)
+}
+
+// FreeCommandBuffer frees a command buffer and records it.
+func (d *Device) FreeCommandBuffer(cmdBuffer hal.CommandBuffer) {
+	if id := d.rec.forget(cmdBuffer); id != 0 {
+		d.rec.emit(OpFreeCommandBuffer, id, nil)
+	}
+	d.raw.FreeCommandBuffer(cmdBuffer)
+}
+
+// CreateFence creates a fence. Fences are not recorded.
+func (d *Device) CreateFence() (hal.Fence, error) { return d.raw.CreateFence() }
+
+// DestroyFence destroys a fence.
+func (d *Device) DestroyFence(fence hal.Fence) { d.raw.DestroyFence(fence) }
+
+// Wait waits for a fence.
+func (d *Device) Wait(fence hal.Fence, value uint64, timeout time.Duration) (bool, error) {
+	return d.raw.Wait(fence, value, timeout)
+}
+
+// ResetFence resets a fence.
+func (d *Device) ResetFence(fence hal.Fence) error { return d.raw.ResetFence(fence) }
+
+// GetFenceStatus reports whether a fence is signaled.
+func (d *Device) GetFenceStatus(fence hal.Fence) (bool, error) {
+	return d.raw.GetFenceStatus(fence)
+}
+
+// WaitIdle waits for the GPU.
+func (d *Device) WaitIdle() error { return d.raw.WaitIdle() }
+
+// Destroy flushes the capture and destroys the device.
+func (d *Device) Destroy() {
+	d.rec.flush()
+	d.raw.Destroy()
+}
+
+// Trim forwards to the wrapped device when it implements hal.Trimmer.
+func (d *Device) Trim() hal.TrimReport {
+	if t, ok := d.raw.(hal.Trimmer); ok {
+		return t.Trim()
+	}
+	return hal.TrimReport{}
+}
+
+// MaxStagingBufferSize forwards to the wrapped device when it implements
+// hal.MaxStagingBufferSizer, and returns 0 (the default) otherwise.
+func (d *Device) MaxStagingBufferSize() uint64 {
+	if s, ok := d.raw.(hal.MaxStagingBufferSizer); ok {
+		return s.MaxStagingBufferSize()
+	}
+	return 0
+}
+
+// MemoryBudget forwards to the wrapped device when it implements
+// hal.MemoryBudgetReporter.
+func (d *Device) MemoryBudget() (hal.MemoryBudget, bool) {
+	if r, ok := d.raw.(hal.MemoryBudgetReporter); ok {
+		return r.MemoryBudget()
+	}
+	return hal.MemoryBudget{}, false
+}
+
+// CreateSharedFence forwards to the wrapped device when it implements
+// hal.FenceSharer. Fences are not recorded.
+func (d *Device) CreateSharedFence() (hal.Fence, error) {
+	sharer, ok := d.raw.(hal.FenceSharer)
+	if !ok {
+		return nil, hal.ErrExternalNotSupported
+	}
+	return sharer.CreateSharedFence()
+}
+
+// ExportFence forwards to the wrapped device when it implements
+// hal.FenceSharer.
+func (d *Device) ExportFence(fence hal.Fence) (hal.NativeFence, error) {
+	sharer, ok := d.raw.(hal.FenceSharer)
+	if !ok {
+		return hal.NativeFence{}, hal.ErrExternalNotSupported
+	}
+	return sharer.ExportFence(fence)
+}
+
+// ImportFence forwards to the wrapped device when it implements
+// hal.FenceSharer.
+func (d *Device) ImportFence(native hal.NativeFence) (hal.Fence, error) {
+	sharer, ok := d.raw.(hal.FenceSharer)
+	if !ok {
+		return nil, hal.ErrExternalNotSupported
+	}
+	return sharer.ImportFence(native)
+}
+
+// Queue is a hal.Queue that records its calls. Create it with Wrap.
+type Queue struct {
+	raw hal.Queue
+	rec *recorder
+}
+
+// Submit submits command buffers, records the submission and flushes the
+// capture.
+func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
+	q.rec.emit(OpSubmit, 0, commandBuffersArgs{CommandBuffers: idsOf(q.rec, commandBuffers)})
+	q.rec.flush()
+	return q.raw.Submit(commandBuffers)
+}
+
+// SubmitWithFences records the submission like Submit and forwards it to
+// the wrapped queue when it implements hal.FenceSubmitter. The fence waits
+// and signals are not recorded, as the other side is not captured.
+func (q *Queue) SubmitWithFences(commandBuffers []hal.CommandBuffer, waits, signals []hal.FenceValue) (uint64, error) {
+	submitter, ok := q.raw.(hal.FenceSubmitter)
+	if !ok {
+		return 0, hal.ErrExternalNotSupported
+	}
+	q.rec.emit(OpSubmit, 0, commandBuffersArgs{CommandBuffers: idsOf(q.rec, commandBuffers)})
+	q.rec.flush()
+	return submitter.SubmitWithFences(commandBuffers, waits, signals)
+}
+
+// PollCompleted returns the highest completed submission index.
+func (q *Queue) PollCompleted() uint64 { return q.raw.PollCompleted() }
+
+// WriteBuffer writes a buffer and records the data.
+func (q *Queue) WriteBuffer(buffer hal.Buffer, offset uint64, data []byte) error {
+	q.rec.emit(OpWriteBuffer, 0, writeBufferArgs{Buffer: q.rec.id(buffer), Offset: offset, Data: data})
+	return q.raw.WriteBuffer(buffer, offset, data)
+}
+
+// WriteTexture writes a texture and records the data.
+func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal.ImageDataLayout, size *hal.Extent3D) error {
+	q.rec.emit(OpWriteTexture, 0, writeTextureArgs{
+		Dst:    q.rec.imageCopyTexture(dst),
+		Data:   data,
+		Layout: *layout,
+		Size:   *size,
+	})
+	return q.raw.WriteTexture(dst, data, layout, size)
+}
+
+// Present presents a surface texture and records it. The surface texture
+// is forgotten: surfaces hand out the same textures again.
+func (q *Queue) Present(surface hal.Surface, texture hal.SurfaceTexture, damageRects []image.Rectangle) error {
+	if id := q.rec.forget(texture); id != 0 {
+		q.rec.emit(OpPresent, 0, presentArgs{Texture: id})
+	}
+	return q.raw.Present(surface, texture, damageRects)
+}
+
+// GetTimestampPeriod returns the timestamp period in nanoseconds.
+func (q *Queue) GetTimestampPeriod() float32 { return q.raw.GetTimestampPeriod() }
+
+// SupportsCommandBufferCopies reports whether the queue batches copies in
+// command buffers.
+func (q *Queue) SupportsCommandBufferCopies() bool { return q.raw.SupportsCommandBufferCopies() }
+
+// SetSwapchainSuppressed forwards to the wrapped queue.
+func (q *Queue) SetSwapchainSuppressed(suppressed bool) { q.raw.SetSwapchainSuppressed(suppressed) }
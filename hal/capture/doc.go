@@ -0,0 +1,54 @@
+//go:build !(js && wasm)
+
+// Package capture records the HAL command stream of a device to a file and
+// replays it against any backend.
+//
+// A capture holds every resource creation, command encoding and queue
+// operation a device performed, together with the data uploaded through
+// Queue.WriteBuffer, Queue.WriteTexture and buffer mappings. Replaying it on
+// another backend or machine reproduces the GPU work without the
+// application that produced it, which makes backend-specific rendering
+// bugs reported by users reproducible.
+//
+// # Recording
+//
+// Wrap wraps the device and queue returned by Adapter.Open. The wgpu
+// package does this for devices requested with DeviceDescriptor.Capture:
+//
+//	f, _ := os.Create("frame.gpucapture")
+//	device, _ := adapter.RequestDevice(&wgpu.DeviceDescriptor{Capture: f})
+//
+// The stream is flushed after each queue submission and when the device is
+// destroyed, so a capture of an application that crashes ends at its last
+// submission.
+//
+// # Replaying
+//
+// NewReader reads the Header, which names the features and limits the
+// replay device needs; Player executes the commands:
+//
+//	r, _ := capture.NewReader(f)
+//	open, _ := adapter.Open(r.Header().Features, r.Header().Limits)
+//	p := capture.NewPlayer(open.Device, open.Queue)
+//	err := p.Play(r)
+//	p.Close()
+//
+// cmd/wgpu-replay wraps these steps in a command-line tool.
+//
+// # Format
+//
+// A capture is JSON Lines: a Header followed by one Command per line.
+// Objects are named by IDs assigned in creation order; byte payloads are
+// base64 encoded. The format is versioned by Header.Version.
+//
+// # Limitations
+//
+// Surfaces are not captured. The texture acquired from the most recently
+// configured surface is recorded as an offscreen texture of the configured
+// size and format, and presenting it is recorded as an OpPresent the player
+// hands to Player.Present. Fences and waits are not recorded: the player
+// waits for the GPU after each submission. Host reads of mapped buffers are
+// not recorded either, since they do not affect the GPU. Imported native
+// textures are recorded as textures created with the same descriptor,
+// without the contents they had when imported.
+package capture
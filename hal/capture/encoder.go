@@ -0,0 +1,468 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// CommandEncoder is a hal.CommandEncoder that records its commands.
+type CommandEncoder struct {
+	raw hal.CommandEncoder
+	rec *recorder
+	id  ID
+}
+
+// SetPoolManaged forwards encoder pooling to backends that need it.
+func (e *CommandEncoder) SetPoolManaged(managed bool) {
+	if setter, ok := e.raw.(interface{ SetPoolManaged(bool) }); ok {
+		setter.SetPoolManaged(managed)
+	}
+}
+
+// BeginEncoding begins recording.
+func (e *CommandEncoder) BeginEncoding(label string) error {
+	if err := e.raw.BeginEncoding(label); err != nil {
+		return err
+	}
+	e.rec.emit(OpBeginEncoding, e.id, nil)
+	return nil
+}
+
+// EndEncoding finishes recording and records the command buffer.
+func (e *CommandEncoder) EndEncoding() (hal.CommandBuffer, error) {
+	cmdBuffer, err := e.raw.EndEncoding()
+	if err != nil {
+		return nil, err
+	}
+	e.rec.emit(OpEndEncoding, e.id, endEncodingArgs{CommandBuffer: e.rec.register(cmdBuffer)})
+	return cmdBuffer, nil
+}
+
+// DiscardEncoding discards recording.
+func (e *CommandEncoder) DiscardEncoding() {
+	e.rec.emit(OpDiscardEncoding, e.id, nil)
+	e.raw.DiscardEncoding()
+}
+
+// ResetAll resets the encoder and forgets the command buffers.
+func (e *CommandEncoder) ResetAll(commandBuffers []hal.CommandBuffer) {
+	ids := make([]ID, 0, len(commandBuffers))
+	for _, cb := range commandBuffers {
+		if id := e.rec.forget(cb); id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	e.rec.emit(OpResetAll, e.id, commandBuffersArgs{CommandBuffers: ids})
+	e.raw.ResetAll(commandBuffers)
+}
+
+// Destroy destroys the encoder.
+func (e *CommandEncoder) Destroy() {
+	if id := e.rec.forget(e); id != 0 {
+		e.rec.emit(OpDestroyCommandEncoder, id, nil)
+	}
+	e.raw.Destroy()
+}
+
+// TransitionBuffers records buffer barriers.
+func (e *CommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) {
+	args := make([]bufferBarrierArgs, len(barriers))
+	for i, b := range barriers {
+		args[i] = bufferBarrierArgs{Buffer: e.rec.id(b.Buffer), Usage: b.Usage}
+	}
+	e.rec.emit(OpTransitionBuffers, e.id, args)
+	e.raw.TransitionBuffers(barriers)
+}
+
+// TransitionTextures records texture barriers.
+func (e *CommandEncoder) TransitionTextures(barriers []hal.TextureBarrier) {
+	args := make([]textureBarrierArgs, len(barriers))
+	for i, b := range barriers {
+		args[i] = textureBarrierArgs{Texture: e.rec.texture(b.Texture), Range: b.Range, Usage: b.Usage}
+	}
+	e.rec.emit(OpTransitionTextures, e.id, args)
+	e.raw.TransitionTextures(barriers)
+}
+
+// ClearBuffer records a buffer clear.
+func (e *CommandEncoder) ClearBuffer(buffer hal.Buffer, offset, size uint64) {
+	e.rec.emit(OpClearBuffer, e.id, clearBufferArgs{Buffer: e.rec.id(buffer), Offset: offset, Size: size})
+	e.raw.ClearBuffer(buffer, offset, size)
+}
+
+// CopyBufferToBuffer records a buffer copy.
+func (e *CommandEncoder) CopyBufferToBuffer(src, dst hal.Buffer, regions []hal.BufferCopy) {
+	e.rec.emit(OpCopyBufferToBuffer, e.id, copyBufferArgs{Src: e.rec.id(src), Dst: e.rec.id(dst), Regions: regions})
+	e.raw.CopyBufferToBuffer(src, dst, regions)
+}
+
+// CopyBufferToTexture records a buffer to texture copy.
+func (e *CommandEncoder) CopyBufferToTexture(src hal.Buffer, dst hal.Texture, regions []hal.BufferTextureCopy) {
+	e.rec.emit(OpCopyBufferToTexture, e.id, copyBufferTextureArgs{
+		Buffer:  e.rec.id(src),
+		Texture: e.rec.texture(dst),
+		Regions: e.bufferTextureCopies(regions),
+	})
+	e.raw.CopyBufferToTexture(src, dst, regions)
+}
+
+// CopyTextureToBuffer records a texture to buffer copy.
+func (e *CommandEncoder) CopyTextureToBuffer(src hal.Texture, dst hal.Buffer, regions []hal.BufferTextureCopy) {
+	e.rec.emit(OpCopyTextureToBuffer, e.id, copyBufferTextureArgs{
+		Buffer:  e.rec.id(dst),
+		Texture: e.rec.texture(src),
+		Regions: e.bufferTextureCopies(regions),
+	})
+	e.raw.CopyTextureToBuffer(src, dst, regions)
+}
+
+func (e *CommandEncoder) bufferTextureCopies(regions []hal.BufferTextureCopy) []bufferTextureCopy {
+	out := make([]bufferTextureCopy, len(regions))
+	for i := range regions {
+		out[i] = bufferTextureCopy{
+			BufferLayout: regions[i].BufferLayout,
+			TextureBase:  e.rec.imageCopyTexture(&regions[i].TextureBase),
+			Size:         regions[i].Size,
+		}
+	}
+	return out
+}
+
+// CopyTextureToTexture records a texture copy.
+func (e *CommandEncoder) CopyTextureToTexture(src, dst hal.Texture, regions []hal.TextureCopy) {
+	args := copyTextureArgs{
+		Src:     e.rec.texture(src),
+		Dst:     e.rec.texture(dst),
+		Regions: make([]textureCopy, len(regions)),
+	}
+	for i := range regions {
+		args.Regions[i] = textureCopy{
+			SrcBase: e.rec.imageCopyTexture(&regions[i].SrcBase),
+			DstBase: e.rec.imageCopyTexture(&regions[i].DstBase),
+			Size:    regions[i].Size,
+		}
+	}
+	e.rec.emit(OpCopyTextureToTexture, e.id, args)
+	e.raw.CopyTextureToTexture(src, dst, regions)
+}
+
+// ResolveQuerySet records a query resolve.
+func (e *CommandEncoder) ResolveQuerySet(querySet hal.QuerySet, firstQuery, queryCount uint32, destination hal.Buffer, destinationOffset uint64) {
+	e.rec.emit(OpResolveQuerySet, e.id, resolveQuerySetArgs{
+		QuerySet:          e.rec.id(querySet),
+		FirstQuery:        firstQuery,
+		QueryCount:        queryCount,
+		Destination:       e.rec.id(destination),
+		DestinationOffset: destinationOffset,
+	})
+	e.raw.ResolveQuerySet(querySet, firstQuery, queryCount, destination, destinationOffset)
+}
+
+// BeginRenderPass records the start of a render pass.
+func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.RenderPassEncoder {
+	args := renderPassArgs{
+		Label:            desc.Label,
+		ColorAttachments: make([]colorAttachmentArgs, len(desc.ColorAttachments)),
+		TimestampWrites:  e.timestampWrites(desc.TimestampWrites),
+		ViewCount:        desc.ViewCount,
+	}
+	for i, a := range desc.ColorAttachments {
+		args.ColorAttachments[i] = colorAttachmentArgs{
+			View:          e.rec.id(a.View),
+			ResolveTarget: e.rec.id(a.ResolveTarget),
+			LoadOp:        a.LoadOp,
+			StoreOp:       a.StoreOp,
+			ClearValue:    a.ClearValue,
+		}
+	}
+	if ds := desc.DepthStencilAttachment; ds != nil {
+		args.DepthStencilAttachment = &depthStencilAttachmentArgs{
+			View:              e.rec.id(ds.View),
+			DepthLoadOp:       ds.DepthLoadOp,
+			DepthStoreOp:      ds.DepthStoreOp,
+			DepthClearValue:   ds.DepthClearValue,
+			DepthReadOnly:     ds.DepthReadOnly,
+			StencilLoadOp:     ds.StencilLoadOp,
+			StencilStoreOp:    ds.StencilStoreOp,
+			StencilClearValue: ds.StencilClearValue,
+			StencilReadOnly:   ds.StencilReadOnly,
+		}
+	}
+	e.rec.emit(OpBeginRenderPass, e.id, args)
+	return &RenderPassEncoder{raw: e.raw.BeginRenderPass(desc), rec: e.rec, id: e.id}
+}
+
+// BeginComputePass records the start of a compute pass.
+func (e *CommandEncoder) BeginComputePass(desc *hal.ComputePassDescriptor) hal.ComputePassEncoder {
+	args := computePassArgs{}
+	if desc != nil {
+		args.Label = desc.Label
+		args.TimestampWrites = e.computeTimestampWrites(desc.TimestampWrites)
+	}
+	e.rec.emit(OpBeginComputePass, e.id, args)
+	return &ComputePassEncoder{raw: e.raw.BeginComputePass(desc), rec: e.rec, id: e.id}
+}
+
+func (e *CommandEncoder) timestampWrites(tw *hal.RenderPassTimestampWrites) *timestampWritesArgs {
+	if tw == nil {
+		return nil
+	}
+	return &timestampWritesArgs{
+		QuerySet:                  e.rec.id(tw.QuerySet),
+		BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+		EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+	}
+}
+
+func (e *CommandEncoder) computeTimestampWrites(tw *hal.ComputePassTimestampWrites) *timestampWritesArgs {
+	if tw == nil {
+		return nil
+	}
+	return &timestampWritesArgs{
+		QuerySet:                  e.rec.id(tw.QuerySet),
+		BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+		EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+	}
+}
+
+// RenderPassEncoder is a hal.RenderPassEncoder that records its commands.
+type RenderPassEncoder struct {
+	raw hal.RenderPassEncoder
+	rec *recorder
+	id  ID
+}
+
+// End records the end of the pass.
+func (p *RenderPassEncoder) End() {
+	p.rec.emit(OpRenderEnd, p.id, nil)
+	p.raw.End()
+}
+
+// SetPipeline records a pipeline change.
+func (p *RenderPassEncoder) SetPipeline(pipeline hal.RenderPipeline) {
+	p.rec.emit(OpRenderSetPipeline, p.id, pipelineArgs{Pipeline: p.rec.id(pipeline)})
+	p.raw.SetPipeline(pipeline)
+}
+
+// SetBindGroup records a bind group change.
+func (p *RenderPassEncoder) SetBindGroup(index uint32, group hal.BindGroup, offsets []uint32) {
+	p.rec.emit(OpRenderSetBindGroup, p.id, bindGroupSetArgs{Index: index, Group: p.rec.id(group), Offsets: offsets})
+	p.raw.SetBindGroup(index, group, offsets)
+}
+
+// SetVertexBuffer records a vertex buffer change.
+func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, size uint64) {
+	p.rec.emit(OpRenderSetVertexBuffer, p.id, vertexBufferArgs{Slot: slot, Buffer: p.rec.id(buffer), Offset: offset, Size: size})
+	p.raw.SetVertexBuffer(slot, buffer, offset, size)
+}
+
+// SetIndexBuffer records an index buffer change.
+func (p *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, size uint64) {
+	p.rec.emit(OpRenderSetIndexBuffer, p.id, indexBufferArgs{Buffer: p.rec.id(buffer), Format: format, Offset: offset, Size: size})
+	p.raw.SetIndexBuffer(buffer, format, offset, size)
+}
+
+// SetViewport records a viewport change.
+func (p *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth float32) {
+	p.rec.emit(OpRenderSetViewport, p.id, viewportArgs{X: x, Y: y, Width: width, Height: height, MinDepth: minDepth, MaxDepth: maxDepth})
+	p.raw.SetViewport(x, y, width, height, minDepth, maxDepth)
+}
+
+// SetScissorRect records a scissor change.
+func (p *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
+	p.rec.emit(OpRenderSetScissorRect, p.id, scissorRectArgs{X: x, Y: y, Width: width, Height: height})
+	p.raw.SetScissorRect(x, y, width, height)
+}
+
+// SetBlendConstant records a blend constant change.
+func (p *RenderPassEncoder) SetBlendConstant(color *gputypes.Color) {
+	p.rec.emit(OpRenderSetBlendConstant, p.id, blendConstantArgs{Color: *color})
+	p.raw.SetBlendConstant(color)
+}
+
+// SetStencilReference records a stencil reference change.
+func (p *RenderPassEncoder) SetStencilReference(reference uint32) {
+	p.rec.emit(OpRenderSetStencilReference, p.id, stencilReferenceArgs{Reference: reference})
+	p.raw.SetStencilReference(reference)
+}
+
+// Draw records a draw.
+func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.rec.emit(OpRenderDraw, p.id, drawArgs{vertexCount, instanceCount, firstVertex, firstInstance})
+	p.raw.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+// DrawIndexed records an indexed draw.
+func (p *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	p.rec.emit(OpRenderDrawIndexed, p.id, drawIndexedArgs{indexCount, instanceCount, firstIndex, baseVertex, firstInstance})
+	p.raw.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+// DrawIndirect records an indirect draw.
+func (p *RenderPassEncoder) DrawIndirect(buffer hal.Buffer, offset uint64, drawCount uint32) {
+	p.rec.emit(OpRenderDrawIndirect, p.id, indirectArgs{Buffer: p.rec.id(buffer), Offset: offset, DrawCount: drawCount})
+	p.raw.DrawIndirect(buffer, offset, drawCount)
+}
+
+// DrawIndexedIndirect records an indexed indirect draw.
+func (p *RenderPassEncoder) DrawIndexedIndirect(buffer hal.Buffer, offset uint64, drawCount uint32) {
+	p.rec.emit(OpRenderDrawIndexedIndirect, p.id, indirectArgs{Buffer: p.rec.id(buffer), Offset: offset, DrawCount: drawCount})
+	p.raw.DrawIndexedIndirect(buffer, offset, drawCount)
+}
+
+// ExecuteBundle records a bundle execution.
+func (p *RenderPassEncoder) ExecuteBundle(bundle hal.RenderBundle) {
+	p.rec.emit(OpRenderExecuteBundle, p.id, bundleArgs{Bundle: p.rec.id(bundle)})
+	p.raw.ExecuteBundle(bundle)
+}
+
+// BeginConditionalRendering records the start of conditional rendering.
+func (p *RenderPassEncoder) BeginConditionalRendering(buffer hal.Buffer, offset uint64, inverted bool) {
+	raw, ok := p.raw.(hal.ConditionalRenderPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpRenderBeginConditional, p.id, conditionalArgs{Buffer: p.rec.id(buffer), Offset: offset, Inverted: inverted})
+	raw.BeginConditionalRendering(buffer, offset, inverted)
+}
+
+// EndConditionalRendering records the end of conditional rendering.
+func (p *RenderPassEncoder) EndConditionalRendering() {
+	raw, ok := p.raw.(hal.ConditionalRenderPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpRenderEndConditional, p.id, nil)
+	raw.EndConditionalRendering()
+}
+
+// BeginPipelineStatisticsQuery records the start of a statistics query.
+func (p *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	raw, ok := p.raw.(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpRenderBeginPipelineStatistics, p.id, queryArgs{QuerySet: p.rec.id(querySet), Index: index})
+	raw.BeginPipelineStatisticsQuery(querySet, index)
+}
+
+// EndPipelineStatisticsQuery records the end of a statistics query.
+func (p *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	raw, ok := p.raw.(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpRenderEndPipelineStatistics, p.id, nil)
+	raw.EndPipelineStatisticsQuery()
+}
+
+// ComputePassEncoder is a hal.ComputePassEncoder that records its commands.
+type ComputePassEncoder struct {
+	raw hal.ComputePassEncoder
+	rec *recorder
+	id  ID
+}
+
+// End records the end of the pass.
+func (p *ComputePassEncoder) End() {
+	p.rec.emit(OpComputeEnd, p.id, nil)
+	p.raw.End()
+}
+
+// SetPipeline records a pipeline change.
+func (p *ComputePassEncoder) SetPipeline(pipeline hal.ComputePipeline) {
+	p.rec.emit(OpComputeSetPipeline, p.id, pipelineArgs{Pipeline: p.rec.id(pipeline)})
+	p.raw.SetPipeline(pipeline)
+}
+
+// SetBindGroup records a bind group change.
+func (p *ComputePassEncoder) SetBindGroup(index uint32, group hal.BindGroup, offsets []uint32) {
+	p.rec.emit(OpComputeSetBindGroup, p.id, bindGroupSetArgs{Index: index, Group: p.rec.id(group), Offsets: offsets})
+	p.raw.SetBindGroup(index, group, offsets)
+}
+
+// Dispatch records a dispatch.
+func (p *ComputePassEncoder) Dispatch(x, y, z uint32) {
+	p.rec.emit(OpComputeDispatch, p.id, dispatchArgs{X: x, Y: y, Z: z})
+	p.raw.Dispatch(x, y, z)
+}
+
+// DispatchIndirect records an indirect dispatch.
+func (p *ComputePassEncoder) DispatchIndirect(buffer hal.Buffer, offset uint64) {
+	p.rec.emit(OpComputeDispatchIndirect, p.id, indirectArgs{Buffer: p.rec.id(buffer), Offset: offset})
+	p.raw.DispatchIndirect(buffer, offset)
+}
+
+// BeginPipelineStatisticsQuery records the start of a statistics query.
+func (p *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	raw, ok := p.raw.(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpComputeBeginPipelineStatistics, p.id, queryArgs{QuerySet: p.rec.id(querySet), Index: index})
+	raw.BeginPipelineStatisticsQuery(querySet, index)
+}
+
+// EndPipelineStatisticsQuery records the end of a statistics query.
+func (p *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	raw, ok := p.raw.(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return
+	}
+	p.rec.emit(OpComputeEndPipelineStatistics, p.id, nil)
+	raw.EndPipelineStatisticsQuery()
+}
+
+// RenderBundleEncoder is a hal.RenderBundleEncoder that records its
+// commands.
+type RenderBundleEncoder struct {
+	raw hal.RenderBundleEncoder
+	rec *recorder
+	id  ID
+}
+
+// SetPipeline records a pipeline change.
+func (b *RenderBundleEncoder) SetPipeline(pipeline hal.RenderPipeline) {
+	b.rec.emit(OpBundleSetPipeline, b.id, pipelineArgs{Pipeline: b.rec.id(pipeline)})
+	b.raw.SetPipeline(pipeline)
+}
+
+// SetBindGroup records a bind group change.
+func (b *RenderBundleEncoder) SetBindGroup(index uint32, group hal.BindGroup, offsets []uint32) {
+	b.rec.emit(OpBundleSetBindGroup, b.id, bindGroupSetArgs{Index: index, Group: b.rec.id(group), Offsets: offsets})
+	b.raw.SetBindGroup(index, group, offsets)
+}
+
+// SetVertexBuffer records a vertex buffer change.
+func (b *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, size uint64) {
+	b.rec.emit(OpBundleSetVertexBuffer, b.id, vertexBufferArgs{Slot: slot, Buffer: b.rec.id(buffer), Offset: offset, Size: size})
+	b.raw.SetVertexBuffer(slot, buffer, offset, size)
+}
+
+// SetIndexBuffer records an index buffer change.
+func (b *RenderBundleEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, size uint64) {
+	b.rec.emit(OpBundleSetIndexBuffer, b.id, indexBufferArgs{Buffer: b.rec.id(buffer), Format: format, Offset: offset, Size: size})
+	b.raw.SetIndexBuffer(buffer, format, offset, size)
+}
+
+// Draw records a draw.
+func (b *RenderBundleEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	b.rec.emit(OpBundleDraw, b.id, drawArgs{vertexCount, instanceCount, firstVertex, firstInstance})
+	b.raw.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
+}
+
+// DrawIndexed records an indexed draw.
+func (b *RenderBundleEncoder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	b.rec.emit(OpBundleDrawIndexed, b.id, drawIndexedArgs{indexCount, instanceCount, firstIndex, baseVertex, firstInstance})
+	b.raw.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+}
+
+// Finish finishes the bundle and records it.
+func (b *RenderBundleEncoder) Finish() hal.RenderBundle {
+	bundle := b.raw.Finish()
+	b.rec.forget(b)
+	b.rec.emit(OpBundleFinish, b.id, bundleArgs{Bundle: b.rec.register(bundle)})
+	return bundle
+}
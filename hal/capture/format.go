@@ -0,0 +1,424 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"encoding/json"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Version is the capture format version written to Header.Version.
+const Version = 1
+
+// ID names a recorded object. IDs are assigned in creation order starting
+// at 1; zero means no object.
+type ID uint64
+
+// Header is the first line of a capture. It describes the device the
+// commands were recorded on.
+type Header struct {
+	// Version is the format version, Version when written by this package.
+	Version int `json:"version"`
+	// Backend is the backend of the recording device.
+	Backend gputypes.Backend `json:"backend"`
+	// Adapter is the name of the recording adapter.
+	Adapter string `json:"adapter,omitempty"`
+	// Features are the features enabled on the recording device.
+	Features gputypes.Features `json:"features"`
+	// Limits are the limits of the recording device.
+	Limits gputypes.Limits `json:"limits"`
+}
+
+// Command is one recorded HAL call.
+type Command struct {
+	// Op is the HAL method called.
+	Op Op `json:"op"`
+	// ID is the object the call created or acted on. Pass and bundle
+	// commands carry the ID of their encoder.
+	ID ID `json:"id,omitempty"`
+	// Args holds the op-specific arguments.
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// Op identifies the HAL method a Command records.
+type Op string
+
+// Device operations.
+const (
+	OpCreateBuffer              Op = "createBuffer"
+	OpDestroyBuffer             Op = "destroyBuffer"
+	OpWriteMapped               Op = "writeMapped"
+	OpCreateTexture             Op = "createTexture"
+	OpDestroyTexture            Op = "destroyTexture"
+	OpSurfaceTexture            Op = "surfaceTexture"
+	OpCreateTextureView         Op = "createTextureView"
+	OpDestroyTextureView        Op = "destroyTextureView"
+	OpCreateSampler             Op = "createSampler"
+	OpDestroySampler            Op = "destroySampler"
+	OpCreateBindGroupLayout     Op = "createBindGroupLayout"
+	OpDestroyBindGroupLayout    Op = "destroyBindGroupLayout"
+	OpCreateBindGroup           Op = "createBindGroup"
+	OpDestroyBindGroup          Op = "destroyBindGroup"
+	OpCreatePipelineLayout      Op = "createPipelineLayout"
+	OpDestroyPipelineLayout     Op = "destroyPipelineLayout"
+	OpCreateShaderModule        Op = "createShaderModule"
+	OpDestroyShaderModule       Op = "destroyShaderModule"
+	OpCreateRenderPipeline      Op = "createRenderPipeline"
+	OpDestroyRenderPipeline     Op = "destroyRenderPipeline"
+	OpCreateComputePipeline     Op = "createComputePipeline"
+	OpDestroyComputePipeline    Op = "destroyComputePipeline"
+	OpCreateQuerySet            Op = "createQuerySet"
+	OpDestroyQuerySet           Op = "destroyQuerySet"
+	OpCreateCommandEncoder      Op = "createCommandEncoder"
+	OpDestroyCommandEncoder     Op = "destroyCommandEncoder"
+	OpCreateRenderBundleEncoder Op = "createRenderBundleEncoder"
+	OpDestroyRenderBundle       Op = "destroyRenderBundle"
+	OpFreeCommandBuffer         Op = "freeCommandBuffer"
+)
+
+// Command encoder operations.
+const (
+	OpBeginEncoding        Op = "beginEncoding"
+	OpEndEncoding          Op = "endEncoding"
+	OpDiscardEncoding      Op = "discardEncoding"
+	OpResetAll             Op = "resetAll"
+	OpTransitionBuffers    Op = "transitionBuffers"
+	OpTransitionTextures   Op = "transitionTextures"
+	OpClearBuffer          Op = "clearBuffer"
+	OpCopyBufferToBuffer   Op = "copyBufferToBuffer"
+	OpCopyBufferToTexture  Op = "copyBufferToTexture"
+	OpCopyTextureToBuffer  Op = "copyTextureToBuffer"
+	OpCopyTextureToTexture Op = "copyTextureToTexture"
+	OpResolveQuerySet      Op = "resolveQuerySet"
+	OpBeginRenderPass      Op = "beginRenderPass"
+	OpBeginComputePass     Op = "beginComputePass"
+)
+
+// Render pass operations. Their ID is the pass's command encoder.
+const (
+	OpRenderEnd                     Op = "render.end"
+	OpRenderSetPipeline             Op = "render.setPipeline"
+	OpRenderSetBindGroup            Op = "render.setBindGroup"
+	OpRenderSetVertexBuffer         Op = "render.setVertexBuffer"
+	OpRenderSetIndexBuffer          Op = "render.setIndexBuffer"
+	OpRenderSetViewport             Op = "render.setViewport"
+	OpRenderSetScissorRect          Op = "render.setScissorRect"
+	OpRenderSetBlendConstant        Op = "render.setBlendConstant"
+	OpRenderSetStencilReference     Op = "render.setStencilReference"
+	OpRenderDraw                    Op = "render.draw"
+	OpRenderDrawIndexed             Op = "render.drawIndexed"
+	OpRenderDrawIndirect            Op = "render.drawIndirect"
+	OpRenderDrawIndexedIndirect     Op = "render.drawIndexedIndirect"
+	OpRenderExecuteBundle           Op = "render.executeBundle"
+	OpRenderBeginConditional        Op = "render.beginConditionalRendering"
+	OpRenderEndConditional          Op = "render.endConditionalRendering"
+	OpRenderBeginPipelineStatistics Op = "render.beginPipelineStatisticsQuery"
+	OpRenderEndPipelineStatistics   Op = "render.endPipelineStatisticsQuery"
+)
+
+// Compute pass operations. Their ID is the pass's command encoder.
+const (
+	OpComputeEnd                     Op = "compute.end"
+	OpComputeSetPipeline             Op = "compute.setPipeline"
+	OpComputeSetBindGroup            Op = "compute.setBindGroup"
+	OpComputeDispatch                Op = "compute.dispatch"
+	OpComputeDispatchIndirect        Op = "compute.dispatchIndirect"
+	OpComputeBeginPipelineStatistics Op = "compute.beginPipelineStatisticsQuery"
+	OpComputeEndPipelineStatistics   Op = "compute.endPipelineStatisticsQuery"
+)
+
+// Render bundle encoder operations. Their ID is the bundle encoder.
+const (
+	OpBundleSetPipeline     Op = "bundle.setPipeline"
+	OpBundleSetBindGroup    Op = "bundle.setBindGroup"
+	OpBundleSetVertexBuffer Op = "bundle.setVertexBuffer"
+	OpBundleSetIndexBuffer  Op = "bundle.setIndexBuffer"
+	OpBundleDraw            Op = "bundle.draw"
+	OpBundleDrawIndexed     Op = "bundle.drawIndexed"
+	OpBundleFinish          Op = "bundle.finish"
+)
+
+// Queue operations.
+const (
+	OpSubmit       Op = "submit"
+	OpWriteBuffer  Op = "writeBuffer"
+	OpWriteTexture Op = "writeTexture"
+	OpPresent      Op = "present"
+)
+
+// The argument types below mirror the hal descriptors with object
+// references replaced by IDs.
+
+type textureViewArgs struct {
+	Texture ID
+	Desc    *hal.TextureViewDescriptor `json:",omitempty"`
+}
+
+type surfaceTextureArgs struct {
+	Width  uint32
+	Height uint32
+	Format gputypes.TextureFormat
+	Usage  gputypes.TextureUsage
+}
+
+type writeMappedArgs struct {
+	Offset uint64
+	Data   []byte
+}
+
+type bindGroupArgs struct {
+	Label   string `json:",omitempty"`
+	Layout  ID
+	Entries []bindGroupEntry
+}
+
+type bindGroupEntry struct {
+	Binding     uint32
+	Buffer      ID     `json:",omitempty"`
+	Offset      uint64 `json:",omitempty"`
+	Size        uint64 `json:",omitempty"`
+	Sampler     ID     `json:",omitempty"`
+	TextureView ID     `json:",omitempty"`
+}
+
+type pipelineLayoutArgs struct {
+	Label              string `json:",omitempty"`
+	BindGroupLayouts   []ID
+	PushConstantRanges []hal.PushConstantRange `json:",omitempty"`
+}
+
+type vertexArgs struct {
+	Module     ID
+	EntryPoint string
+	Buffers    []gputypes.VertexBufferLayout `json:",omitempty"`
+}
+
+type fragmentArgs struct {
+	Module     ID
+	EntryPoint string
+	Targets    []gputypes.ColorTargetState
+}
+
+type renderPipelineArgs struct {
+	Label        string `json:",omitempty"`
+	Layout       ID
+	Vertex       vertexArgs
+	Primitive    gputypes.PrimitiveState
+	PolygonMode  hal.PolygonMode        `json:",omitempty"`
+	DepthStencil *hal.DepthStencilState `json:",omitempty"`
+	Multisample  gputypes.MultisampleState
+	Fragment     *fragmentArgs `json:",omitempty"`
+	ViewCount    uint32        `json:",omitempty"`
+}
+
+type computePipelineArgs struct {
+	Label                         string `json:",omitempty"`
+	Layout                        ID
+	Module                        ID
+	EntryPoint                    string
+	Constants                     map[string]float64 `json:",omitempty"`
+	ZeroInitializeWorkgroupMemory bool
+}
+
+type endEncodingArgs struct {
+	CommandBuffer ID
+}
+
+type commandBuffersArgs struct {
+	CommandBuffers []ID
+}
+
+type bufferBarrierArgs struct {
+	Buffer ID
+	Usage  hal.BufferUsageTransition
+}
+
+type textureBarrierArgs struct {
+	Texture ID
+	Range   hal.TextureRange
+	Usage   hal.TextureUsageTransition
+}
+
+type clearBufferArgs struct {
+	Buffer ID
+	Offset uint64
+	Size   uint64
+}
+
+type copyBufferArgs struct {
+	Src     ID
+	Dst     ID
+	Regions []hal.BufferCopy
+}
+
+type imageCopyTexture struct {
+	Texture  ID
+	MipLevel uint32
+	Origin   hal.Origin3D
+	Aspect   gputypes.TextureAspect
+}
+
+type bufferTextureCopy struct {
+	BufferLayout hal.ImageDataLayout
+	TextureBase  imageCopyTexture
+	Size         hal.Extent3D
+}
+
+type copyBufferTextureArgs struct {
+	Buffer  ID
+	Texture ID
+	Regions []bufferTextureCopy
+}
+
+type textureCopy struct {
+	SrcBase imageCopyTexture
+	DstBase imageCopyTexture
+	Size    hal.Extent3D
+}
+
+type copyTextureArgs struct {
+	Src     ID
+	Dst     ID
+	Regions []textureCopy
+}
+
+type resolveQuerySetArgs struct {
+	QuerySet          ID
+	FirstQuery        uint32
+	QueryCount        uint32
+	Destination       ID
+	DestinationOffset uint64
+}
+
+type timestampWritesArgs struct {
+	QuerySet                  ID
+	BeginningOfPassWriteIndex *uint32 `json:",omitempty"`
+	EndOfPassWriteIndex       *uint32 `json:",omitempty"`
+}
+
+type colorAttachmentArgs struct {
+	View          ID
+	ResolveTarget ID `json:",omitempty"`
+	LoadOp        gputypes.LoadOp
+	StoreOp       gputypes.StoreOp
+	ClearValue    gputypes.Color
+}
+
+type depthStencilAttachmentArgs struct {
+	View              ID
+	DepthLoadOp       gputypes.LoadOp
+	DepthStoreOp      gputypes.StoreOp
+	DepthClearValue   float32
+	DepthReadOnly     bool
+	StencilLoadOp     gputypes.LoadOp
+	StencilStoreOp    gputypes.StoreOp
+	StencilClearValue uint32
+	StencilReadOnly   bool
+}
+
+type renderPassArgs struct {
+	Label                  string `json:",omitempty"`
+	ColorAttachments       []colorAttachmentArgs
+	DepthStencilAttachment *depthStencilAttachmentArgs `json:",omitempty"`
+	TimestampWrites        *timestampWritesArgs        `json:",omitempty"`
+	ViewCount              uint32                      `json:",omitempty"`
+}
+
+type computePassArgs struct {
+	Label           string               `json:",omitempty"`
+	TimestampWrites *timestampWritesArgs `json:",omitempty"`
+}
+
+type pipelineArgs struct {
+	Pipeline ID
+}
+
+type bindGroupSetArgs struct {
+	Index   uint32
+	Group   ID
+	Offsets []uint32 `json:",omitempty"`
+}
+
+type vertexBufferArgs struct {
+	Slot   uint32
+	Buffer ID
+	Offset uint64
+	Size   uint64
+}
+
+type indexBufferArgs struct {
+	Buffer ID
+	Format gputypes.IndexFormat
+	Offset uint64
+	Size   uint64
+}
+
+type viewportArgs struct {
+	X, Y, Width, Height, MinDepth, MaxDepth float32
+}
+
+type scissorRectArgs struct {
+	X, Y, Width, Height uint32
+}
+
+type blendConstantArgs struct {
+	Color gputypes.Color
+}
+
+type stencilReferenceArgs struct {
+	Reference uint32
+}
+
+type drawArgs struct {
+	VertexCount, InstanceCount, FirstVertex, FirstInstance uint32
+}
+
+type drawIndexedArgs struct {
+	IndexCount, InstanceCount, FirstIndex uint32
+	BaseVertex                            int32
+	FirstInstance                         uint32
+}
+
+type indirectArgs struct {
+	Buffer    ID
+	Offset    uint64
+	DrawCount uint32 `json:",omitempty"`
+}
+
+type bundleArgs struct {
+	Bundle ID
+}
+
+type conditionalArgs struct {
+	Buffer   ID
+	Offset   uint64
+	Inverted bool
+}
+
+type queryArgs struct {
+	QuerySet ID
+	Index    uint32
+}
+
+type dispatchArgs struct {
+	X, Y, Z uint32
+}
+
+type writeBufferArgs struct {
+	Buffer ID
+	Offset uint64
+	Data   []byte
+}
+
+type writeTextureArgs struct {
+	Dst    imageCopyTexture
+	Data   []byte
+	Layout hal.ImageDataLayout
+	Size   hal.Extent3D
+}
+
+type presentArgs struct {
+	Texture ID
+}
@@ -0,0 +1,683 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// ErrUnsupportedVersion is returned by NewReader for captures written in a
+// format version this package does not read.
+var ErrUnsupportedVersion = errors.New("capture: unsupported format version")
+
+// maxLineSize bounds one command line. Large uploads are single lines.
+const maxLineSize = 1 << 30
+
+// Reader reads a capture.
+type Reader struct {
+	scanner *bufio.Scanner
+	header  Header
+	line    int
+}
+
+// NewReader reads the header of the capture in r.
+func NewReader(r io.Reader) (*Reader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	rd := &Reader{scanner: scanner}
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("capture: reading header: %w", err)
+		}
+		return nil, fmt.Errorf("capture: reading header: %w", io.ErrUnexpectedEOF)
+	}
+	rd.line = 1
+	if err := json.Unmarshal(scanner.Bytes(), &rd.header); err != nil {
+		return nil, fmt.Errorf("capture: reading header: %w", err)
+	}
+	if rd.header.Version != Version {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, rd.header.Version)
+	}
+	return rd, nil
+}
+
+// Header returns the capture header.
+func (r *Reader) Header() Header { return r.header }
+
+// Next returns the next command, or io.EOF at the end of the capture.
+func (r *Reader) Next() (Command, error) {
+	var cmd Command
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return cmd, err
+		}
+		return cmd, io.EOF
+	}
+	r.line++
+	if err := json.Unmarshal(r.scanner.Bytes(), &cmd); err != nil {
+		return cmd, fmt.Errorf("capture: line %d: %w", r.line, err)
+	}
+	return cmd, nil
+}
+
+// kind is the type of a replay object. HAL resource interfaces share
+// method sets, so objects carry their kind explicitly.
+type kind uint8
+
+const (
+	kindBuffer kind = iota + 1
+	kindTexture
+	kindSurfaceTexture
+	kindTextureView
+	kindSampler
+	kindBindGroupLayout
+	kindBindGroup
+	kindPipelineLayout
+	kindShaderModule
+	kindRenderPipeline
+	kindComputePipeline
+	kindQuerySet
+	kindCommandEncoder
+	kindRenderBundleEncoder
+	kindRenderBundle
+	kindCommandBuffer
+)
+
+var kindNames = [...]string{
+	kindBuffer:              "buffer",
+	kindTexture:             "texture",
+	kindSurfaceTexture:      "surface texture",
+	kindTextureView:         "texture view",
+	kindSampler:             "sampler",
+	kindBindGroupLayout:     "bind group layout",
+	kindBindGroup:           "bind group",
+	kindPipelineLayout:      "pipeline layout",
+	kindShaderModule:        "shader module",
+	kindRenderPipeline:      "render pipeline",
+	kindComputePipeline:     "compute pipeline",
+	kindQuerySet:            "query set",
+	kindCommandEncoder:      "command encoder",
+	kindRenderBundleEncoder: "render bundle encoder",
+	kindRenderBundle:        "render bundle",
+	kindCommandBuffer:       "command buffer",
+}
+
+func (k kind) String() string {
+	if int(k) < len(kindNames) && kindNames[k] != "" {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("kind(%d)", k)
+}
+
+// object is a replay object and its kind.
+type object struct {
+	kind  kind
+	value any
+}
+
+// Player executes captured commands on a device.
+//
+// Submissions are executed one at a time: the player waits for the GPU
+// after each, so GPU timing differs from the recording while the commands
+// and their order do not.
+type Player struct {
+	// Present, if set, is called with the replay texture standing in for a
+	// presented surface texture and its descriptor, after the GPU finished
+	// rendering to it. The texture can be copied from.
+	Present func(texture hal.Texture, desc *hal.TextureDescriptor)
+
+	device hal.Device
+	queue  hal.Queue
+
+	objects map[ID]object
+	// order lists object IDs in creation order, for Close.
+	order []ID
+	// passes holds the open pass of each command encoder.
+	passes map[ID]any
+	// surfaceDescs holds the descriptors of surface texture stand-ins.
+	surfaceDescs map[ID]*hal.TextureDescriptor
+	commands     int
+}
+
+// NewPlayer returns a Player that executes commands on device and queue.
+// The device must support the features and limits of the capture header.
+func NewPlayer(device hal.Device, queue hal.Queue) *Player {
+	return &Player{
+		device:  device,
+		queue:   queue,
+		objects: make(map[ID]object),
+		passes:  make(map[ID]any),
+
+		surfaceDescs: make(map[ID]*hal.TextureDescriptor),
+	}
+}
+
+// Play executes every remaining command of r. It stops at the first
+// command that fails and reports its position.
+func (p *Player) Play(r *Reader) error {
+	for {
+		cmd, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := p.Execute(cmd); err != nil {
+			return fmt.Errorf("capture: line %d: %w", r.line, err)
+		}
+	}
+}
+
+// Commands returns the number of commands executed.
+func (p *Player) Commands() int { return p.commands }
+
+// Object returns the replay object created for id, or nil. Use it to
+// inspect replay results, for example by reading back a buffer.
+func (p *Player) Object(id ID) any { return p.objects[id].value }
+
+// Close waits for the GPU and destroys the objects the capture left alive,
+// newest first.
+func (p *Player) Close() {
+	_ = p.device.WaitIdle()
+	for i := len(p.order) - 1; i >= 0; i-- {
+		id := p.order[i]
+		if obj, ok := p.objects[id]; ok {
+			p.destroy(obj)
+			delete(p.objects, id)
+		}
+	}
+	p.order = nil
+}
+
+// destroy destroys obj with the device method of its kind.
+//
+//nolint:forcetypeassert // the kind determines the type
+func (p *Player) destroy(obj object) {
+	switch obj.kind {
+	case kindBuffer:
+		p.device.DestroyBuffer(obj.value.(hal.Buffer))
+	case kindTexture, kindSurfaceTexture:
+		p.device.DestroyTexture(obj.value.(hal.Texture))
+	case kindTextureView:
+		p.device.DestroyTextureView(obj.value.(hal.TextureView))
+	case kindSampler:
+		p.device.DestroySampler(obj.value.(hal.Sampler))
+	case kindBindGroupLayout:
+		p.device.DestroyBindGroupLayout(obj.value.(hal.BindGroupLayout))
+	case kindBindGroup:
+		p.device.DestroyBindGroup(obj.value.(hal.BindGroup))
+	case kindPipelineLayout:
+		p.device.DestroyPipelineLayout(obj.value.(hal.PipelineLayout))
+	case kindShaderModule:
+		p.device.DestroyShaderModule(obj.value.(hal.ShaderModule))
+	case kindRenderPipeline:
+		p.device.DestroyRenderPipeline(obj.value.(hal.RenderPipeline))
+	case kindComputePipeline:
+		p.device.DestroyComputePipeline(obj.value.(hal.ComputePipeline))
+	case kindQuerySet:
+		p.device.DestroyQuerySet(obj.value.(hal.QuerySet))
+	case kindCommandEncoder:
+		obj.value.(hal.CommandEncoder).Destroy()
+	case kindRenderBundle:
+		p.device.DestroyRenderBundle(obj.value.(hal.RenderBundle))
+	case kindCommandBuffer:
+		p.device.FreeCommandBuffer(obj.value.(hal.CommandBuffer))
+	}
+}
+
+func (p *Player) add(id ID, k kind, value any) {
+	p.objects[id] = object{kind: k, value: value}
+	p.order = append(p.order, id)
+}
+
+// remove drops object id, which must be of kind k.
+func (p *Player) remove(id ID, k kind) (object, error) {
+	obj, ok := p.objects[id]
+	if !ok {
+		return obj, fmt.Errorf("unknown %s %d", k, id)
+	}
+	if obj.kind != k && (k != kindTexture || obj.kind != kindSurfaceTexture) {
+		return obj, fmt.Errorf("object %d is a %s, not a %s", id, obj.kind, k)
+	}
+	delete(p.objects, id)
+	return obj, nil
+}
+
+// lookup returns object id, which must be of kind k, as a T. ID 0 yields
+// the zero T. Surface textures are textures.
+func lookup[T any](p *Player, id ID, k kind) (T, error) {
+	var zero T
+	if id == 0 {
+		return zero, nil
+	}
+	obj, ok := p.objects[id]
+	if !ok {
+		return zero, fmt.Errorf("unknown %s %d", k, id)
+	}
+	if obj.kind != k && (k != kindTexture || obj.kind != kindSurfaceTexture) {
+		return zero, fmt.Errorf("object %d is a %s, not a %s", id, obj.kind, k)
+	}
+	t, ok := obj.value.(T)
+	if !ok {
+		return zero, fmt.Errorf("%s %d is %T", k, id, obj.value)
+	}
+	return t, nil
+}
+
+// lookupAll resolves ids of kind k.
+func lookupAll[T any](p *Player, ids []ID, k kind) ([]T, error) {
+	out := make([]T, len(ids))
+	for i, id := range ids {
+		obj, err := lookup[T](p, id, k)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = obj
+	}
+	return out, nil
+}
+
+// decode unmarshals the command arguments into a new T.
+func decode[T any](cmd Command) (*T, error) {
+	v := new(T)
+	if len(cmd.Args) == 0 {
+		return v, nil
+	}
+	if err := json.Unmarshal(cmd.Args, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// create decodes a hal descriptor D, calls fn and adds the result as an
+// object of kind k.
+func create[D, T any](p *Player, cmd Command, k kind, fn func(*D) (T, error)) error {
+	desc, err := decode[D](cmd)
+	if err != nil {
+		return err
+	}
+	obj, err := fn(desc)
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, k, obj)
+	return nil
+}
+
+// destroyOps maps the destroy ops to the kind they destroy.
+var destroyOps = map[Op]kind{
+	OpDestroyBuffer:          kindBuffer,
+	OpDestroyTexture:         kindTexture,
+	OpDestroyTextureView:     kindTextureView,
+	OpDestroySampler:         kindSampler,
+	OpDestroyBindGroupLayout: kindBindGroupLayout,
+	OpDestroyBindGroup:       kindBindGroup,
+	OpDestroyPipelineLayout:  kindPipelineLayout,
+	OpDestroyShaderModule:    kindShaderModule,
+	OpDestroyRenderPipeline:  kindRenderPipeline,
+	OpDestroyComputePipeline: kindComputePipeline,
+	OpDestroyQuerySet:        kindQuerySet,
+	OpDestroyCommandEncoder:  kindCommandEncoder,
+	OpDestroyRenderBundle:    kindRenderBundle,
+	OpFreeCommandBuffer:      kindCommandBuffer,
+}
+
+// Execute executes one command.
+func (p *Player) Execute(cmd Command) error {
+	p.commands++
+	if err := p.execute(cmd); err != nil {
+		return fmt.Errorf("%s %d: %w", cmd.Op, cmd.ID, err)
+	}
+	return nil
+}
+
+func (p *Player) execute(cmd Command) error {
+	if k, ok := destroyOps[cmd.Op]; ok {
+		obj, err := p.remove(cmd.ID, k)
+		if err != nil {
+			return err
+		}
+		delete(p.surfaceDescs, cmd.ID)
+		p.destroy(obj)
+		return nil
+	}
+	switch cmd.Op {
+	case OpCreateBuffer:
+		return create(p, cmd, kindBuffer, p.device.CreateBuffer)
+	case OpCreateTexture:
+		return create(p, cmd, kindTexture, p.device.CreateTexture)
+	case OpCreateSampler:
+		return create(p, cmd, kindSampler, p.device.CreateSampler)
+	case OpCreateBindGroupLayout:
+		return create(p, cmd, kindBindGroupLayout, p.device.CreateBindGroupLayout)
+	case OpCreateShaderModule:
+		return create(p, cmd, kindShaderModule, p.device.CreateShaderModule)
+	case OpCreateQuerySet:
+		return create(p, cmd, kindQuerySet, p.device.CreateQuerySet)
+	case OpCreateRenderBundleEncoder:
+		return create(p, cmd, kindRenderBundleEncoder, p.device.CreateRenderBundleEncoder)
+	case OpCreateCommandEncoder:
+		return create(p, cmd, kindCommandEncoder, p.createCommandEncoder)
+	case OpWriteMapped:
+		return p.writeMapped(cmd)
+	case OpSurfaceTexture:
+		return p.surfaceTexture(cmd)
+	case OpCreateTextureView:
+		return p.createTextureView(cmd)
+	case OpCreateBindGroup:
+		return p.createBindGroup(cmd)
+	case OpCreatePipelineLayout:
+		return p.createPipelineLayout(cmd)
+	case OpCreateRenderPipeline:
+		return p.createRenderPipeline(cmd)
+	case OpCreateComputePipeline:
+		return p.createComputePipeline(cmd)
+	case OpSubmit:
+		return p.submit(cmd)
+	case OpWriteBuffer:
+		return p.writeBuffer(cmd)
+	case OpWriteTexture:
+		return p.writeTexture(cmd)
+	case OpPresent:
+		return p.present(cmd)
+	}
+
+	switch p.objects[cmd.ID].kind {
+	case kindCommandEncoder:
+		return p.executeEncoder(cmd)
+	case kindRenderBundleEncoder:
+		return p.executeBundle(cmd)
+	}
+	return fmt.Errorf("unknown op, or no encoder %d", cmd.ID)
+}
+
+func (p *Player) createCommandEncoder(desc *hal.CommandEncoderDescriptor) (hal.CommandEncoder, error) {
+	enc, err := p.device.CreateCommandEncoder(desc)
+	if err != nil {
+		return nil, err
+	}
+	// Captured encoders come from the wgpu encoder pool and are reused
+	// after ResetAll.
+	if setter, ok := enc.(interface{ SetPoolManaged(bool) }); ok {
+		setter.SetPoolManaged(true)
+	}
+	return enc, nil
+}
+
+func (p *Player) writeMapped(cmd Command) error {
+	args, err := decode[writeMappedArgs](cmd)
+	if err != nil {
+		return err
+	}
+	buffer, err := lookup[hal.Buffer](p, cmd.ID, kindBuffer)
+	if err != nil {
+		return err
+	}
+	m, err := p.device.MapBuffer(buffer, args.Offset, uint64(len(args.Data)))
+	if err != nil {
+		return err
+	}
+	copy(unsafe.Slice((*byte)(m.Ptr), len(args.Data)), args.Data)
+	return p.device.UnmapBuffer(buffer)
+}
+
+func (p *Player) surfaceTexture(cmd Command) error {
+	args, err := decode[surfaceTextureArgs](cmd)
+	if err != nil {
+		return err
+	}
+	desc := &hal.TextureDescriptor{
+		Label:         "capture surface texture",
+		Size:          hal.Extent3D{Width: args.Width, Height: args.Height, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        args.Format,
+		Usage:         args.Usage | gputypes.TextureUsageCopySrc,
+	}
+	texture, err := p.device.CreateTexture(desc)
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindSurfaceTexture, texture)
+	p.surfaceDescs[cmd.ID] = desc
+	return nil
+}
+
+func (p *Player) createTextureView(cmd Command) error {
+	args, err := decode[textureViewArgs](cmd)
+	if err != nil {
+		return err
+	}
+	texture, err := lookup[hal.Texture](p, args.Texture, kindTexture)
+	if err != nil {
+		return err
+	}
+	view, err := p.device.CreateTextureView(texture, args.Desc)
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindTextureView, view)
+	return nil
+}
+
+func (p *Player) createBindGroup(cmd Command) error {
+	args, err := decode[bindGroupArgs](cmd)
+	if err != nil {
+		return err
+	}
+	layout, err := lookup[hal.BindGroupLayout](p, args.Layout, kindBindGroupLayout)
+	if err != nil {
+		return err
+	}
+	desc := &hal.BindGroupDescriptor{
+		Label:   args.Label,
+		Layout:  layout,
+		Entries: make([]gputypes.BindGroupEntry, len(args.Entries)),
+	}
+	for i, e := range args.Entries {
+		entry := gputypes.BindGroupEntry{Binding: e.Binding}
+		switch {
+		case e.Buffer != 0:
+			buffer, err := lookup[hal.Buffer](p, e.Buffer, kindBuffer)
+			if err != nil {
+				return err
+			}
+			entry.Resource = gputypes.BufferBinding{Buffer: buffer.NativeHandle(), Offset: e.Offset, Size: e.Size}
+		case e.Sampler != 0:
+			sampler, err := lookup[hal.Sampler](p, e.Sampler, kindSampler)
+			if err != nil {
+				return err
+			}
+			entry.Resource = gputypes.SamplerBinding{Sampler: sampler.NativeHandle()}
+		case e.TextureView != 0:
+			view, err := lookup[hal.TextureView](p, e.TextureView, kindTextureView)
+			if err != nil {
+				return err
+			}
+			entry.Resource = gputypes.TextureViewBinding{TextureView: view.NativeHandle()}
+		}
+		desc.Entries[i] = entry
+	}
+	group, err := p.device.CreateBindGroup(desc)
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindBindGroup, group)
+	return nil
+}
+
+func (p *Player) createPipelineLayout(cmd Command) error {
+	args, err := decode[pipelineLayoutArgs](cmd)
+	if err != nil {
+		return err
+	}
+	layouts, err := lookupAll[hal.BindGroupLayout](p, args.BindGroupLayouts, kindBindGroupLayout)
+	if err != nil {
+		return err
+	}
+	layout, err := p.device.CreatePipelineLayout(&hal.PipelineLayoutDescriptor{
+		Label:              args.Label,
+		BindGroupLayouts:   layouts,
+		PushConstantRanges: args.PushConstantRanges,
+	})
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindPipelineLayout, layout)
+	return nil
+}
+
+func (p *Player) createRenderPipeline(cmd Command) error {
+	args, err := decode[renderPipelineArgs](cmd)
+	if err != nil {
+		return err
+	}
+	layout, err := lookup[hal.PipelineLayout](p, args.Layout, kindPipelineLayout)
+	if err != nil {
+		return err
+	}
+	vertex, err := lookup[hal.ShaderModule](p, args.Vertex.Module, kindShaderModule)
+	if err != nil {
+		return err
+	}
+	desc := &hal.RenderPipelineDescriptor{
+		Label:  args.Label,
+		Layout: layout,
+		Vertex: hal.VertexState{
+			Module:     vertex,
+			EntryPoint: args.Vertex.EntryPoint,
+			Buffers:    args.Vertex.Buffers,
+		},
+		Primitive:    args.Primitive,
+		PolygonMode:  args.PolygonMode,
+		DepthStencil: args.DepthStencil,
+		Multisample:  args.Multisample,
+		ViewCount:    args.ViewCount,
+	}
+	if args.Fragment != nil {
+		fragment, err := lookup[hal.ShaderModule](p, args.Fragment.Module, kindShaderModule)
+		if err != nil {
+			return err
+		}
+		desc.Fragment = &hal.FragmentState{
+			Module:     fragment,
+			EntryPoint: args.Fragment.EntryPoint,
+			Targets:    args.Fragment.Targets,
+		}
+	}
+	pipeline, err := p.device.CreateRenderPipeline(desc)
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindRenderPipeline, pipeline)
+	return nil
+}
+
+func (p *Player) createComputePipeline(cmd Command) error {
+	args, err := decode[computePipelineArgs](cmd)
+	if err != nil {
+		return err
+	}
+	layout, err := lookup[hal.PipelineLayout](p, args.Layout, kindPipelineLayout)
+	if err != nil {
+		return err
+	}
+	module, err := lookup[hal.ShaderModule](p, args.Module, kindShaderModule)
+	if err != nil {
+		return err
+	}
+	pipeline, err := p.device.CreateComputePipeline(&hal.ComputePipelineDescriptor{
+		Label:  args.Label,
+		Layout: layout,
+		Compute: hal.ComputeState{
+			Module:                        module,
+			EntryPoint:                    args.EntryPoint,
+			Constants:                     args.Constants,
+			ZeroInitializeWorkgroupMemory: args.ZeroInitializeWorkgroupMemory,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	p.add(cmd.ID, kindComputePipeline, pipeline)
+	return nil
+}
+
+func (p *Player) submit(cmd Command) error {
+	args, err := decode[commandBuffersArgs](cmd)
+	if err != nil {
+		return err
+	}
+	buffers, err := lookupAll[hal.CommandBuffer](p, args.CommandBuffers, kindCommandBuffer)
+	if err != nil {
+		return err
+	}
+	if _, err := p.queue.Submit(buffers); err != nil {
+		return err
+	}
+	return p.device.WaitIdle()
+}
+
+func (p *Player) writeBuffer(cmd Command) error {
+	args, err := decode[writeBufferArgs](cmd)
+	if err != nil {
+		return err
+	}
+	buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+	if err != nil {
+		return err
+	}
+	return p.queue.WriteBuffer(buffer, args.Offset, args.Data)
+}
+
+func (p *Player) writeTexture(cmd Command) error {
+	args, err := decode[writeTextureArgs](cmd)
+	if err != nil {
+		return err
+	}
+	dst, err := p.imageCopyTexture(args.Dst)
+	if err != nil {
+		return err
+	}
+	return p.queue.WriteTexture(&dst, args.Data, &args.Layout, &args.Size)
+}
+
+func (p *Player) present(cmd Command) error {
+	args, err := decode[presentArgs](cmd)
+	if err != nil {
+		return err
+	}
+	obj, err := p.remove(args.Texture, kindSurfaceTexture)
+	if err != nil {
+		return err
+	}
+	texture := obj.value.(hal.Texture) //nolint:forcetypeassert // kind checked by remove
+	desc := p.surfaceDescs[args.Texture]
+	delete(p.surfaceDescs, args.Texture)
+	if err := p.device.WaitIdle(); err != nil {
+		return err
+	}
+	if p.Present != nil {
+		p.Present(texture, desc)
+	}
+	p.device.DestroyTexture(texture)
+	return nil
+}
+
+func (p *Player) imageCopyTexture(t imageCopyTexture) (hal.ImageCopyTexture, error) {
+	texture, err := lookup[hal.Texture](p, t.Texture, kindTexture)
+	if err != nil {
+		return hal.ImageCopyTexture{}, err
+	}
+	return hal.ImageCopyTexture{Texture: texture, MipLevel: t.MipLevel, Origin: t.Origin, Aspect: t.Aspect}, nil
+}
@@ -0,0 +1,529 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// executeEncoder executes a command encoder, render pass or compute pass
+// command. cmd.ID is the encoder.
+//
+//nolint:forcetypeassert // the kind determines the type
+func (p *Player) executeEncoder(cmd Command) error {
+	enc := p.objects[cmd.ID].value.(hal.CommandEncoder)
+	switch {
+	case strings.HasPrefix(string(cmd.Op), "render."):
+		pass, ok := p.passes[cmd.ID].(hal.RenderPassEncoder)
+		if !ok {
+			return fmt.Errorf("no render pass open")
+		}
+		return p.executeRenderPass(pass, cmd)
+	case strings.HasPrefix(string(cmd.Op), "compute."):
+		pass, ok := p.passes[cmd.ID].(hal.ComputePassEncoder)
+		if !ok {
+			return fmt.Errorf("no compute pass open")
+		}
+		return p.executeComputePass(pass, cmd)
+	}
+
+	switch cmd.Op {
+	case OpBeginEncoding:
+		return enc.BeginEncoding("")
+	case OpEndEncoding:
+		args, err := decode[endEncodingArgs](cmd)
+		if err != nil {
+			return err
+		}
+		cmdBuffer, err := enc.EndEncoding()
+		if err != nil {
+			return err
+		}
+		p.add(args.CommandBuffer, kindCommandBuffer, cmdBuffer)
+		return nil
+	case OpDiscardEncoding:
+		enc.DiscardEncoding()
+		return nil
+	case OpResetAll:
+		args, err := decode[commandBuffersArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffers := make([]hal.CommandBuffer, 0, len(args.CommandBuffers))
+		for _, id := range args.CommandBuffers {
+			obj, err := p.remove(id, kindCommandBuffer)
+			if err != nil {
+				return err
+			}
+			buffers = append(buffers, obj.value.(hal.CommandBuffer))
+		}
+		enc.ResetAll(buffers)
+		return nil
+	case OpTransitionBuffers:
+		args, err := decode[[]bufferBarrierArgs](cmd)
+		if err != nil {
+			return err
+		}
+		barriers := make([]hal.BufferBarrier, len(*args))
+		for i, b := range *args {
+			buffer, err := lookup[hal.Buffer](p, b.Buffer, kindBuffer)
+			if err != nil {
+				return err
+			}
+			barriers[i] = hal.BufferBarrier{Buffer: buffer, Usage: b.Usage}
+		}
+		enc.TransitionBuffers(barriers)
+		return nil
+	case OpTransitionTextures:
+		args, err := decode[[]textureBarrierArgs](cmd)
+		if err != nil {
+			return err
+		}
+		barriers := make([]hal.TextureBarrier, len(*args))
+		for i, b := range *args {
+			texture, err := lookup[hal.Texture](p, b.Texture, kindTexture)
+			if err != nil {
+				return err
+			}
+			barriers[i] = hal.TextureBarrier{Texture: texture, Range: b.Range, Usage: b.Usage}
+		}
+		enc.TransitionTextures(barriers)
+		return nil
+	case OpClearBuffer:
+		args, err := decode[clearBufferArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		enc.ClearBuffer(buffer, args.Offset, args.Size)
+		return nil
+	case OpCopyBufferToBuffer:
+		args, err := decode[copyBufferArgs](cmd)
+		if err != nil {
+			return err
+		}
+		src, err := lookup[hal.Buffer](p, args.Src, kindBuffer)
+		if err != nil {
+			return err
+		}
+		dst, err := lookup[hal.Buffer](p, args.Dst, kindBuffer)
+		if err != nil {
+			return err
+		}
+		enc.CopyBufferToBuffer(src, dst, args.Regions)
+		return nil
+	case OpCopyBufferToTexture, OpCopyTextureToBuffer:
+		return p.copyBufferTexture(enc, cmd)
+	case OpCopyTextureToTexture:
+		return p.copyTextureToTexture(enc, cmd)
+	case OpResolveQuerySet:
+		args, err := decode[resolveQuerySetArgs](cmd)
+		if err != nil {
+			return err
+		}
+		querySet, err := lookup[hal.QuerySet](p, args.QuerySet, kindQuerySet)
+		if err != nil {
+			return err
+		}
+		dst, err := lookup[hal.Buffer](p, args.Destination, kindBuffer)
+		if err != nil {
+			return err
+		}
+		enc.ResolveQuerySet(querySet, args.FirstQuery, args.QueryCount, dst, args.DestinationOffset)
+		return nil
+	case OpBeginRenderPass:
+		desc, err := p.renderPassDescriptor(cmd)
+		if err != nil {
+			return err
+		}
+		p.passes[cmd.ID] = enc.BeginRenderPass(desc)
+		return nil
+	case OpBeginComputePass:
+		args, err := decode[computePassArgs](cmd)
+		if err != nil {
+			return err
+		}
+		desc := &hal.ComputePassDescriptor{Label: args.Label}
+		if tw := args.TimestampWrites; tw != nil {
+			querySet, err := lookup[hal.QuerySet](p, tw.QuerySet, kindQuerySet)
+			if err != nil {
+				return err
+			}
+			desc.TimestampWrites = &hal.ComputePassTimestampWrites{
+				QuerySet:                  querySet,
+				BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+				EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+			}
+		}
+		p.passes[cmd.ID] = enc.BeginComputePass(desc)
+		return nil
+	}
+	return fmt.Errorf("unknown op")
+}
+
+func (p *Player) copyBufferTexture(enc hal.CommandEncoder, cmd Command) error {
+	args, err := decode[copyBufferTextureArgs](cmd)
+	if err != nil {
+		return err
+	}
+	buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+	if err != nil {
+		return err
+	}
+	texture, err := lookup[hal.Texture](p, args.Texture, kindTexture)
+	if err != nil {
+		return err
+	}
+	regions := make([]hal.BufferTextureCopy, len(args.Regions))
+	for i, r := range args.Regions {
+		base, err := p.imageCopyTexture(r.TextureBase)
+		if err != nil {
+			return err
+		}
+		regions[i] = hal.BufferTextureCopy{BufferLayout: r.BufferLayout, TextureBase: base, Size: r.Size}
+	}
+	if cmd.Op == OpCopyBufferToTexture {
+		enc.CopyBufferToTexture(buffer, texture, regions)
+	} else {
+		enc.CopyTextureToBuffer(texture, buffer, regions)
+	}
+	return nil
+}
+
+func (p *Player) copyTextureToTexture(enc hal.CommandEncoder, cmd Command) error {
+	args, err := decode[copyTextureArgs](cmd)
+	if err != nil {
+		return err
+	}
+	src, err := lookup[hal.Texture](p, args.Src, kindTexture)
+	if err != nil {
+		return err
+	}
+	dst, err := lookup[hal.Texture](p, args.Dst, kindTexture)
+	if err != nil {
+		return err
+	}
+	regions := make([]hal.TextureCopy, len(args.Regions))
+	for i, r := range args.Regions {
+		srcBase, err := p.imageCopyTexture(r.SrcBase)
+		if err != nil {
+			return err
+		}
+		dstBase, err := p.imageCopyTexture(r.DstBase)
+		if err != nil {
+			return err
+		}
+		regions[i] = hal.TextureCopy{SrcBase: srcBase, DstBase: dstBase, Size: r.Size}
+	}
+	enc.CopyTextureToTexture(src, dst, regions)
+	return nil
+}
+
+func (p *Player) renderPassDescriptor(cmd Command) (*hal.RenderPassDescriptor, error) {
+	args, err := decode[renderPassArgs](cmd)
+	if err != nil {
+		return nil, err
+	}
+	desc := &hal.RenderPassDescriptor{
+		Label:            args.Label,
+		ColorAttachments: make([]hal.RenderPassColorAttachment, len(args.ColorAttachments)),
+		ViewCount:        args.ViewCount,
+	}
+	for i, a := range args.ColorAttachments {
+		view, err := lookup[hal.TextureView](p, a.View, kindTextureView)
+		if err != nil {
+			return nil, err
+		}
+		resolve, err := lookup[hal.TextureView](p, a.ResolveTarget, kindTextureView)
+		if err != nil {
+			return nil, err
+		}
+		desc.ColorAttachments[i] = hal.RenderPassColorAttachment{
+			View:          view,
+			ResolveTarget: resolve,
+			LoadOp:        a.LoadOp,
+			StoreOp:       a.StoreOp,
+			ClearValue:    a.ClearValue,
+		}
+	}
+	if ds := args.DepthStencilAttachment; ds != nil {
+		view, err := lookup[hal.TextureView](p, ds.View, kindTextureView)
+		if err != nil {
+			return nil, err
+		}
+		desc.DepthStencilAttachment = &hal.RenderPassDepthStencilAttachment{
+			View:              view,
+			DepthLoadOp:       ds.DepthLoadOp,
+			DepthStoreOp:      ds.DepthStoreOp,
+			DepthClearValue:   ds.DepthClearValue,
+			DepthReadOnly:     ds.DepthReadOnly,
+			StencilLoadOp:     ds.StencilLoadOp,
+			StencilStoreOp:    ds.StencilStoreOp,
+			StencilClearValue: ds.StencilClearValue,
+			StencilReadOnly:   ds.StencilReadOnly,
+		}
+	}
+	if tw := args.TimestampWrites; tw != nil {
+		querySet, err := lookup[hal.QuerySet](p, tw.QuerySet, kindQuerySet)
+		if err != nil {
+			return nil, err
+		}
+		desc.TimestampWrites = &hal.RenderPassTimestampWrites{
+			QuerySet:                  querySet,
+			BeginningOfPassWriteIndex: tw.BeginningOfPassWriteIndex,
+			EndOfPassWriteIndex:       tw.EndOfPassWriteIndex,
+		}
+	}
+	return desc, nil
+}
+
+// drawState is the state commands shared by render passes and render
+// bundle encoders.
+type drawState interface {
+	SetPipeline(pipeline hal.RenderPipeline)
+	SetBindGroup(index uint32, group hal.BindGroup, offsets []uint32)
+	SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, size uint64)
+	SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, size uint64)
+	Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32)
+	DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32)
+}
+
+// executeDraw executes a command common to render passes and bundles.
+// The op is given without its "render." or "bundle." prefix.
+func (p *Player) executeDraw(enc drawState, op string, cmd Command) error {
+	switch op {
+	case "setPipeline":
+		args, err := decode[pipelineArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pipeline, err := lookup[hal.RenderPipeline](p, args.Pipeline, kindRenderPipeline)
+		if err != nil {
+			return err
+		}
+		enc.SetPipeline(pipeline)
+	case "setBindGroup":
+		args, err := decode[bindGroupSetArgs](cmd)
+		if err != nil {
+			return err
+		}
+		group, err := lookup[hal.BindGroup](p, args.Group, kindBindGroup)
+		if err != nil {
+			return err
+		}
+		enc.SetBindGroup(args.Index, group, args.Offsets)
+	case "setVertexBuffer":
+		args, err := decode[vertexBufferArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		enc.SetVertexBuffer(args.Slot, buffer, args.Offset, args.Size)
+	case "setIndexBuffer":
+		args, err := decode[indexBufferArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		enc.SetIndexBuffer(buffer, args.Format, args.Offset, args.Size)
+	case "draw":
+		args, err := decode[drawArgs](cmd)
+		if err != nil {
+			return err
+		}
+		enc.Draw(args.VertexCount, args.InstanceCount, args.FirstVertex, args.FirstInstance)
+	case "drawIndexed":
+		args, err := decode[drawIndexedArgs](cmd)
+		if err != nil {
+			return err
+		}
+		enc.DrawIndexed(args.IndexCount, args.InstanceCount, args.FirstIndex, args.BaseVertex, args.FirstInstance)
+	default:
+		return fmt.Errorf("unknown op")
+	}
+	return nil
+}
+
+//nolint:gocyclo,cyclop // one case per op
+func (p *Player) executeRenderPass(pass hal.RenderPassEncoder, cmd Command) error {
+	switch cmd.Op {
+	case OpRenderEnd:
+		pass.End()
+		delete(p.passes, cmd.ID)
+	case OpRenderSetViewport:
+		args, err := decode[viewportArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pass.SetViewport(args.X, args.Y, args.Width, args.Height, args.MinDepth, args.MaxDepth)
+	case OpRenderSetScissorRect:
+		args, err := decode[scissorRectArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pass.SetScissorRect(args.X, args.Y, args.Width, args.Height)
+	case OpRenderSetBlendConstant:
+		args, err := decode[blendConstantArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pass.SetBlendConstant(&args.Color)
+	case OpRenderSetStencilReference:
+		args, err := decode[stencilReferenceArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pass.SetStencilReference(args.Reference)
+	case OpRenderDrawIndirect, OpRenderDrawIndexedIndirect:
+		args, err := decode[indirectArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		if cmd.Op == OpRenderDrawIndirect {
+			pass.DrawIndirect(buffer, args.Offset, args.DrawCount)
+		} else {
+			pass.DrawIndexedIndirect(buffer, args.Offset, args.DrawCount)
+		}
+	case OpRenderExecuteBundle:
+		args, err := decode[bundleArgs](cmd)
+		if err != nil {
+			return err
+		}
+		bundle, err := lookup[hal.RenderBundle](p, args.Bundle, kindRenderBundle)
+		if err != nil {
+			return err
+		}
+		pass.ExecuteBundle(bundle)
+	case OpRenderBeginConditional:
+		args, err := decode[conditionalArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		raw, ok := pass.(hal.ConditionalRenderPassEncoder)
+		if !ok {
+			return fmt.Errorf("conditional rendering is not supported by this backend")
+		}
+		raw.BeginConditionalRendering(buffer, args.Offset, args.Inverted)
+	case OpRenderEndConditional:
+		if raw, ok := pass.(hal.ConditionalRenderPassEncoder); ok {
+			raw.EndConditionalRendering()
+		}
+	case OpRenderBeginPipelineStatistics:
+		return p.beginPipelineStatistics(pass, cmd)
+	case OpRenderEndPipelineStatistics:
+		if raw, ok := pass.(hal.PipelineStatisticsPassEncoder); ok {
+			raw.EndPipelineStatisticsQuery()
+		}
+	default:
+		return p.executeDraw(pass, strings.TrimPrefix(string(cmd.Op), "render."), cmd)
+	}
+	return nil
+}
+
+func (p *Player) executeComputePass(pass hal.ComputePassEncoder, cmd Command) error {
+	switch cmd.Op {
+	case OpComputeEnd:
+		pass.End()
+		delete(p.passes, cmd.ID)
+	case OpComputeSetPipeline:
+		args, err := decode[pipelineArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pipeline, err := lookup[hal.ComputePipeline](p, args.Pipeline, kindComputePipeline)
+		if err != nil {
+			return err
+		}
+		pass.SetPipeline(pipeline)
+	case OpComputeSetBindGroup:
+		args, err := decode[bindGroupSetArgs](cmd)
+		if err != nil {
+			return err
+		}
+		group, err := lookup[hal.BindGroup](p, args.Group, kindBindGroup)
+		if err != nil {
+			return err
+		}
+		pass.SetBindGroup(args.Index, group, args.Offsets)
+	case OpComputeDispatch:
+		args, err := decode[dispatchArgs](cmd)
+		if err != nil {
+			return err
+		}
+		pass.Dispatch(args.X, args.Y, args.Z)
+	case OpComputeDispatchIndirect:
+		args, err := decode[indirectArgs](cmd)
+		if err != nil {
+			return err
+		}
+		buffer, err := lookup[hal.Buffer](p, args.Buffer, kindBuffer)
+		if err != nil {
+			return err
+		}
+		pass.DispatchIndirect(buffer, args.Offset)
+	case OpComputeBeginPipelineStatistics:
+		return p.beginPipelineStatistics(pass, cmd)
+	case OpComputeEndPipelineStatistics:
+		if raw, ok := pass.(hal.PipelineStatisticsPassEncoder); ok {
+			raw.EndPipelineStatisticsQuery()
+		}
+	default:
+		return fmt.Errorf("unknown op")
+	}
+	return nil
+}
+
+func (p *Player) beginPipelineStatistics(pass any, cmd Command) error {
+	args, err := decode[queryArgs](cmd)
+	if err != nil {
+		return err
+	}
+	querySet, err := lookup[hal.QuerySet](p, args.QuerySet, kindQuerySet)
+	if err != nil {
+		return err
+	}
+	raw, ok := pass.(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return fmt.Errorf("pipeline statistics queries are not supported by this backend")
+	}
+	raw.BeginPipelineStatisticsQuery(querySet, args.Index)
+	return nil
+}
+
+// executeBundle executes a render bundle encoder command.
+//
+//nolint:forcetypeassert // the kind determines the type
+func (p *Player) executeBundle(cmd Command) error {
+	enc := p.objects[cmd.ID].value.(hal.RenderBundleEncoder)
+	if cmd.Op != OpBundleFinish {
+		return p.executeDraw(enc, strings.TrimPrefix(string(cmd.Op), "bundle."), cmd)
+	}
+	args, err := decode[bundleArgs](cmd)
+	if err != nil {
+		return err
+	}
+	delete(p.objects, cmd.ID)
+	p.add(args.Bundle, kindRenderBundle, enc.Finish())
+	return nil
+}
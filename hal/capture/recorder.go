@@ -0,0 +1,263 @@
+//go:build !(js && wasm)
+
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// recorder assigns IDs to HAL objects and writes commands. All wrappers of
+// one device share a recorder.
+type recorder struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	enc *json.Encoder
+	err error
+
+	next ID
+	ids  map[any]ID
+
+	// Bind group entries name resources by native handle.
+	buffers  map[uintptr]ID
+	samplers map[uintptr]ID
+	views    map[uintptr]ID
+
+	// hostWritten holds the buffers whose mapped contents are recorded on
+	// unmap, and mappings their current mapping.
+	hostWritten map[ID]bool
+	mappings    map[ID]mapping
+
+	// surface is the most recent surface configuration, used to describe
+	// surface textures.
+	surface *hal.SurfaceConfiguration
+}
+
+// mapping is a live MapBuffer range.
+type mapping struct {
+	offset uint64
+	size   uint64
+	ptr    unsafe.Pointer
+}
+
+func newRecorder(w io.Writer) *recorder {
+	bw := bufio.NewWriter(w)
+	return &recorder{
+		w:           bw,
+		enc:         json.NewEncoder(bw),
+		ids:         make(map[any]ID),
+		buffers:     make(map[uintptr]ID),
+		samplers:    make(map[uintptr]ID),
+		views:       make(map[uintptr]ID),
+		hostWritten: make(map[ID]bool),
+		mappings:    make(map[ID]mapping),
+	}
+}
+
+// register assigns a new ID to obj.
+func (r *recorder) register(obj any) ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	r.ids[obj] = r.next
+	return r.next
+}
+
+// id returns the ID of obj, or 0 for nil or unrecorded objects.
+func (r *recorder) id(obj any) ID {
+	if obj == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ids[obj]
+}
+
+// forget drops obj and returns the ID it had.
+func (r *recorder) forget(obj any) ID {
+	if obj == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.ids[obj]
+	delete(r.ids, obj)
+	return id
+}
+
+// idsOf returns the IDs of objs.
+func idsOf[T any](r *recorder, objs []T) []ID {
+	out := make([]ID, len(objs))
+	for i, obj := range objs {
+		out[i] = r.id(obj)
+	}
+	return out
+}
+
+// handleID returns the ID recorded for a native handle in table.
+func (r *recorder) handleID(table map[uintptr]ID, handle uintptr) ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return table[handle]
+}
+
+// setHandle records or, with id 0, removes a native handle.
+func (r *recorder) setHandle(table map[uintptr]ID, handle uintptr, id ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id == 0 {
+		delete(table, handle)
+		return
+	}
+	table[handle] = id
+}
+
+// emit writes one command. After the first write error the recorder stops
+// writing; Err reports the error.
+func (r *recorder) emit(op Op, id ID, args any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.emitLocked(op, id, args)
+}
+
+func (r *recorder) emitLocked(op Op, id ID, args any) {
+	if r.err != nil {
+		return
+	}
+	cmd := Command{Op: op, ID: id}
+	if args != nil {
+		raw, err := json.Marshal(args)
+		if err != nil {
+			r.err = err
+			return
+		}
+		cmd.Args = raw
+	}
+	r.err = r.enc.Encode(cmd)
+}
+
+// flush writes buffered commands to the underlying writer.
+func (r *recorder) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = r.w.Flush()
+	}
+}
+
+// Err returns the first error writing the capture, if any.
+func (r *recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// texture returns the ID of texture. A texture the recorder has not seen
+// is taken to be a texture acquired from the most recently configured
+// surface and is recorded as an OpSurfaceTexture.
+func (r *recorder) texture(texture hal.Texture) ID {
+	if texture == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[texture]; ok {
+		return id
+	}
+	if r.surface == nil {
+		return 0
+	}
+	r.next++
+	r.ids[texture] = r.next
+	r.emitLocked(OpSurfaceTexture, r.next, surfaceTextureArgs{
+		Width:  r.surface.Width,
+		Height: r.surface.Height,
+		Format: r.surface.Format,
+		Usage:  r.surface.Usage,
+	})
+	return r.next
+}
+
+// imageCopyTexture converts a hal.ImageCopyTexture.
+func (r *recorder) imageCopyTexture(t *hal.ImageCopyTexture) imageCopyTexture {
+	return imageCopyTexture{
+		Texture:  r.texture(t.Texture),
+		MipLevel: t.MipLevel,
+		Origin:   t.Origin,
+		Aspect:   t.Aspect,
+	}
+}
+
+// mapped records a MapBuffer range of buffer.
+func (r *recorder) mapped(buffer hal.Buffer, offset, size uint64, m hal.BufferMapping) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.ids[buffer]; ok && r.hostWritten[id] {
+		r.mappings[id] = mapping{offset: offset, size: size, ptr: m.Ptr}
+	}
+}
+
+// unmapped records the contents of buffer's mapping, when the host may
+// have written it. Must be called before the mapping is released.
+func (r *recorder) unmapped(buffer hal.Buffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.ids[buffer]
+	m, ok := r.mappings[id]
+	if !ok {
+		return
+	}
+	delete(r.mappings, id)
+	data := make([]byte, m.size)
+	copy(data, unsafe.Slice((*byte)(m.ptr), m.size))
+	r.emitLocked(OpWriteMapped, id, writeMappedArgs{Offset: m.offset, Data: data})
+}
+
+// Wrap returns a device and queue that forward to open and record every
+// call to w. The header is written first.
+//
+// The wrappers forward the optional interfaces the wgpu package uses:
+// hal.Trimmer, hal.MaxStagingBufferSizer and hal.MemoryBudgetReporter on the
+// device, encoder pooling on command encoders, and conditional rendering and
+// pipeline statistics queries on passes. Surfaces must be configured with the device returned
+// by Unwrap.
+func Wrap(open hal.OpenDevice, w io.Writer, header Header) hal.OpenDevice {
+	header.Version = Version
+	rec := newRecorder(w)
+	if err := rec.enc.Encode(header); err != nil {
+		rec.err = err
+	}
+	return hal.OpenDevice{
+		Device: &Device{raw: open.Device, rec: rec},
+		Queue:  &Queue{raw: open.Queue, rec: rec},
+	}
+}
+
+// Unwrap returns the device a capture Device wraps, or device itself.
+func Unwrap(device hal.Device) hal.Device {
+	if d, ok := device.(*Device); ok {
+		return d.raw
+	}
+	return device
+}
+
+// SurfaceConfigured tells a capture device the configuration of a surface
+// configured with it, so textures acquired from the surface can be
+// recorded. It does nothing for other devices.
+func SurfaceConfigured(device hal.Device, config *hal.SurfaceConfiguration) {
+	d, ok := device.(*Device)
+	if !ok || config == nil {
+		return
+	}
+	cfg := *config
+	cfg.Usage |= gputypes.TextureUsageRenderAttachment
+	d.rec.mu.Lock()
+	d.rec.surface = &cfg
+	d.rec.mu.Unlock()
+}
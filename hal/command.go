@@ -64,7 +64,8 @@ type CommandEncoder interface {
 	// queryCount is the number of queries to resolve.
 	// destination is the buffer to write results to.
 	// destinationOffset is the byte offset into the destination buffer.
-	// Each timestamp result is a uint64 (8 bytes).
+	// Each timestamp or occlusion result is a uint64 (8 bytes); a pipeline
+	// statistics result is one uint64 per selected counter.
 	ResolveQuerySet(querySet QuerySet, firstQuery, queryCount uint32, destination Buffer, destinationOffset uint64)
 
 	// BeginRenderPass begins a render pass.
@@ -90,11 +91,14 @@ type RenderPassEncoder interface {
 	// offsets are dynamic offsets for dynamic uniform/storage buffers.
 	SetBindGroup(index uint32, group BindGroup, offsets []uint32)
 
-	// SetVertexBuffer sets a vertex buffer for the given slot.
-	SetVertexBuffer(slot uint32, buffer Buffer, offset uint64)
+	// SetVertexBuffer binds size bytes of buffer, starting at offset, to
+	// the given slot. Core has checked the range against the buffer and
+	// resolved a whole-buffer size, so size is exact.
+	SetVertexBuffer(slot uint32, buffer Buffer, offset, size uint64)
 
-	// SetIndexBuffer sets the index buffer.
-	SetIndexBuffer(buffer Buffer, format gputypes.IndexFormat, offset uint64)
+	// SetIndexBuffer binds size bytes of buffer, starting at offset, as the
+	// index buffer. Core has checked the range as for SetVertexBuffer.
+	SetIndexBuffer(buffer Buffer, format gputypes.IndexFormat, offset, size uint64)
 
 	// SetViewport sets the viewport transformation.
 	SetViewport(x, y, width, height, minDepth, maxDepth float32)
@@ -136,6 +140,41 @@ type RenderPassEncoder interface {
 	ExecuteBundle(bundle RenderBundle)
 }
 
+// ConditionalRenderPassEncoder is an optional interface implemented by render
+// pass encoders of devices that report FeatureConditionalRendering.
+//
+// Between BeginConditionalRendering and EndConditionalRendering the GPU skips
+// draws when the 8-byte value at offset in buffer is zero (non-zero when
+// inverted is true); Vulkan only reads its low 32 bits. A resolved occlusion
+// query result is such a value. The buffer must have been created with BufferUsageIndirect, which Vulkan maps to
+// VK_BUFFER_USAGE_CONDITIONAL_RENDERING_BIT_EXT and DX12 to the
+// predication resource state.
+type ConditionalRenderPassEncoder interface {
+	// BeginConditionalRendering starts predicating draws on the value at offset.
+	BeginConditionalRendering(buffer Buffer, offset uint64, inverted bool)
+
+	// EndConditionalRendering stops predicating draws.
+	EndConditionalRendering()
+}
+
+// PipelineStatisticsPassEncoder is an optional interface implemented by render
+// and compute pass encoders of devices that report
+// gputypes.FeaturePipelineStatisticsQuery.
+//
+// BeginPipelineStatisticsQuery starts counting into query index of a
+// QueryTypePipelineStatistics set and EndPipelineStatisticsQuery stops; one
+// query may be active per pass. On Vulkan a render pass cannot reset
+// queries, so a query is reset when the set is created and after each
+// ResolveQuerySet that reads it: resolve a query before reusing it in a
+// render pass.
+type PipelineStatisticsPassEncoder interface {
+	// BeginPipelineStatisticsQuery starts the query at index.
+	BeginPipelineStatisticsQuery(querySet QuerySet, index uint32)
+
+	// EndPipelineStatisticsQuery ends the active query.
+	EndPipelineStatisticsQuery()
+}
+
 // ComputePassEncoder records compute commands within a compute pass.
 type ComputePassEncoder interface {
 	// End finishes the compute pass.
@@ -173,11 +212,13 @@ type RenderBundleEncoder interface {
 	// SetBindGroup sets a bind group for the given index.
 	SetBindGroup(index uint32, group BindGroup, offsets []uint32)
 
-	// SetVertexBuffer sets a vertex buffer for the given slot.
-	SetVertexBuffer(slot uint32, buffer Buffer, offset uint64)
+	// SetVertexBuffer binds size bytes of buffer, starting at offset, to
+	// the given slot.
+	SetVertexBuffer(slot uint32, buffer Buffer, offset, size uint64)
 
-	// SetIndexBuffer sets the index buffer.
-	SetIndexBuffer(buffer Buffer, format gputypes.IndexFormat, offset uint64)
+	// SetIndexBuffer binds size bytes of buffer, starting at offset, as the
+	// index buffer.
+	SetIndexBuffer(buffer Buffer, format gputypes.IndexFormat, offset, size uint64)
 
 	// Draw draws primitives.
 	Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32)
@@ -203,10 +244,12 @@ type TextureBarrier struct {
 	Usage   TextureUsageTransition
 }
 
-// BufferUsageTransition defines a buffer usage state transition.
+// BufferUsageTransition defines a buffer usage state transition. OldUsage
+// zero means the previous state is unknown; backends then wait for all
+// prior commands.
 type BufferUsageTransition struct {
-	OldUsage gputypes.BufferUsage
-	NewUsage gputypes.BufferUsage
+	OldUsage BufferUses
+	NewUsage BufferUses
 }
 
 // TextureUsageTransition defines a texture usage state transition.
@@ -248,6 +291,12 @@ type BufferTextureCopy struct {
 }
 
 // TextureCopy defines a texture-to-texture copy region.
+//
+// Size is in texels of the source texture. When the copy reinterprets
+// block-compressed data (DownlevelFlagsTextureCopyReinterpret), the region
+// written to the destination is Size scaled from source blocks to
+// destination blocks: copying 8x8 texels of a BC7 texture writes 2x2 texels
+// of an RGBA32Uint one, and the reverse copy of 2x2 texels writes 8x8.
 type TextureCopy struct {
 	SrcBase ImageCopyTexture
 	DstBase ImageCopyTexture
@@ -17,6 +17,14 @@ type InstanceDescriptor struct {
 
 	// GLBackend specifies the OpenGL backend flavor (GL or GLES).
 	GLBackend gputypes.GLBackend
+
+	// Log routes the instance's diagnostics. Nil uses the package logger
+	// set with SetLogger.
+	Log *Log
+
+	// BackendOptions holds backend-specific settings, already checked with
+	// BackendOptions.Validate.
+	BackendOptions BackendOptions
 }
 
 // Capabilities contains detailed adapter capabilities.
@@ -29,6 +37,16 @@ type Capabilities struct {
 
 	// DownlevelCapabilities for GL/GLES backends.
 	DownlevelCapabilities DownlevelCapabilities
+
+	// SubgroupMinSize and SubgroupMaxSize bound the number of invocations in
+	// a subgroup (Vulkan subgroup, Metal SIMD-group, D3D12 wave). Both are 0
+	// unless the adapter reports gputypes.FeatureSubgroupOperations.
+	SubgroupMinSize uint32
+	SubgroupMaxSize uint32
+
+	// MaxMultiviewViewCount is the largest ViewCount a render pass may use.
+	// It is 0 unless the adapter reports FeatureMultiview.
+	MaxMultiviewViewCount uint32
 }
 
 // Alignments specifies buffer alignment requirements.
@@ -70,12 +88,70 @@ const (
 
 	// DownlevelFlagsAnisotropicFiltering indicates anisotropic filtering support.
 	DownlevelFlagsAnisotropicFiltering
+
+	// DownlevelFlagsTransientAttachments indicates transient attachments are
+	// backed by tile memory without a committed device allocation (Metal
+	// memoryless storage, Vulkan lazily allocated memory).
+	DownlevelFlagsTransientAttachments
+
+	// DownlevelFlagsUnifiedMemory indicates the GPU shares physical memory
+	// with the CPU (integrated and mobile GPUs, Apple Silicon), so writing a
+	// host-visible buffer directly costs no more than writing staging memory.
+	DownlevelFlagsUnifiedMemory
+
+	// DownlevelFlagsCubeArrayTextures indicates texture views with
+	// TextureViewDimensionCubeArray can be created and sampled.
+	DownlevelFlagsCubeArrayTextures
+
+	// DownlevelFlagsTextureCopyReinterpret indicates CopyTextureToTexture
+	// can copy between a block-compressed format and an uncompressed color
+	// format whose texel is the size of a compressed block, such as
+	// BC7RGBAUnorm and RGBA32Uint (see TextureCopy).
+	DownlevelFlagsTextureCopyReinterpret
 )
 
 // TextureFormatCapabilities describes texture format capabilities.
 type TextureFormatCapabilities struct {
 	// Flags indicate what operations are supported for this format.
 	Flags TextureFormatCapabilityFlags
+
+	// SampleCounts is a bitmask of the supported sample counts, where each
+	// bit's value is the count itself (SampleCount1|SampleCount4 means 1x
+	// and 4x). Zero means single-sampled only.
+	SampleCounts uint32
+}
+
+// Sample count bits for TextureFormatCapabilities.SampleCounts. They match
+// VkSampleCountFlagBits, so Vulkan limits can be used unchanged.
+const (
+	SampleCount1  uint32 = 1
+	SampleCount2  uint32 = 2
+	SampleCount4  uint32 = 4
+	SampleCount8  uint32 = 8
+	SampleCount16 uint32 = 16
+
+	// SampleCountsWebGPU is the set every multisampled format supports in
+	// WebGPU: 1x and 4x.
+	SampleCountsWebGPU = SampleCount1 | SampleCount4
+)
+
+// SupportsSampleCount reports whether textures of this format may be created
+// with count samples. A count of 1 is always supported.
+func (c TextureFormatCapabilities) SupportsSampleCount(count uint32) bool {
+	if count == 1 {
+		return true
+	}
+	return count != 0 && count&(count-1) == 0 && c.SampleCounts&count != 0
+}
+
+// SampleCountsUpTo returns the power-of-two sample counts from 1 to maxSamples
+// as a SampleCounts bitmask.
+func SampleCountsUpTo(maxSamples uint32) uint32 {
+	var counts uint32
+	for n := SampleCount1; n <= SampleCount16 && n <= maxSamples; n <<= 1 {
+		counts |= n
+	}
+	return counts
 }
 
 // TextureFormatCapabilityFlags are capability flags for texture formats.
@@ -114,8 +190,41 @@ type SurfaceCapabilities struct {
 
 	// AlphaModes are the supported alpha modes.
 	AlphaModes []gputypes.CompositeAlphaMode
+
+	// CurrentTransform is the transform the presentation engine applies to
+	// reach the display's current orientation. Zero means identity.
+	CurrentTransform SurfaceTransform
+
+	// SupportedTransforms is the set of transforms SurfaceConfiguration.PreTransform
+	// accepts. Zero means identity only.
+	SupportedTransforms SurfaceTransform
+
+	// Protected reports whether SurfaceConfiguration.Protected is supported,
+	// that is whether the surface can present protected images.
+	Protected bool
+
+	// Usages is the set of texture usages surface textures support. Zero
+	// means the backend does not report it; such surfaces support
+	// RenderAttachment and, usually, CopySrc and CopyDst.
+	Usages gputypes.TextureUsage
 }
 
+// SurfaceTransform is a set of surface transforms: rotations, clockwise, and
+// horizontal mirroring. The bits match VkSurfaceTransformFlagBitsKHR.
+type SurfaceTransform uint32
+
+// Surface transform flags.
+const (
+	SurfaceTransformIdentity SurfaceTransform = 1 << iota
+	SurfaceTransformRotate90
+	SurfaceTransformRotate180
+	SurfaceTransformRotate270
+	SurfaceTransformHorizontalMirror
+	SurfaceTransformHorizontalMirrorRotate90
+	SurfaceTransformHorizontalMirrorRotate180
+	SurfaceTransformHorizontalMirrorRotate270
+)
+
 // PresentMode is an alias for gputypes.PresentMode for backward compatibility.
 type PresentMode = gputypes.PresentMode
 
@@ -170,6 +279,25 @@ type SurfaceConfiguration struct {
 	// updates are common. Games and full-screen renderers should leave this
 	// false because FLIP_DISCARD has lower overhead.
 	EnableDamagePresent bool
+
+	// PreTransform is the transform the application has already applied to
+	// its rendering (pre-rotation). It must be a single flag from
+	// SurfaceCapabilities.SupportedTransforms. Zero leaves the choice to the
+	// backend: the current transform on desktop Vulkan and identity, with
+	// rotation done by the compositor, on Android and other backends.
+	PreTransform SurfaceTransform
+
+	// Protected creates the surface textures in protected memory. Requires
+	// FeatureProtectedContent and SurfaceCapabilities.Protected; only
+	// protected command buffers may render to them.
+	Protected bool
+
+	// ScaleFactor is the window's ratio of pixels to logical units, 2 on a
+	// Retina display. Width and Height are always in pixels; backends that
+	// present through a compositor layer (Metal, software on macOS) set the
+	// layer's contents scale from it so the pixel-sized image fills the
+	// window instead of a quarter of it. Zero leaves the layer's scale alone.
+	ScaleFactor float64
 }
 
 // BufferDescriptor describes how to create a buffer.
@@ -183,7 +311,10 @@ type BufferDescriptor struct {
 	// Usage specifies how the buffer will be used.
 	Usage gputypes.BufferUsage
 
-	// MappedAtCreation creates the buffer pre-mapped for writing.
+	// MappedAtCreation creates the buffer pre-mapped for writing, which
+	// requires host-visible memory. Core sets it only for buffers with
+	// BufferUsageMapWrite and internal staging buffers; it maps other
+	// MappedAtCreation buffers onto host memory and uploads on unmap.
 	MappedAtCreation bool
 }
 
@@ -210,9 +341,29 @@ type TextureDescriptor struct {
 	// Usage specifies how the texture will be used.
 	Usage gputypes.TextureUsage
 
-	// ViewFormats are additional formats for texture views.
-	// Required for creating views with different (but compatible) formats.
+	// ViewFormats are additional formats for texture views, each the sRGB
+	// counterpart of Format (core.ViewFormatCompatible). Vulkan creates a
+	// mutable-format image with a format list, DX12 a typeless resource,
+	// and Metal adds MTLTextureUsagePixelFormatView.
 	ViewFormats []gputypes.TextureFormat
+
+	// Transient marks a render attachment whose contents never outlive a
+	// render pass. Backends reporting DownlevelFlagsTransientAttachments keep
+	// it in tile memory only (Metal memoryless storage, Vulkan lazily
+	// allocated memory); others allocate normally.
+	Transient bool
+
+	// Protected places the texture in protected memory, which the host
+	// cannot map or copy out of. Only protected command buffers may access
+	// it. Requires FeatureProtectedContent.
+	Protected bool
+
+	// TextureBindingViewDimension is the view dimension the texture will be
+	// bound with. OpenGL fixes a texture's target at creation, so the GLES
+	// backend uses it to choose between 2D array, cube and cube array
+	// storage; other backends ignore it. Undefined picks 2D for a single
+	// layer and 2D array otherwise.
+	TextureBindingViewDimension gputypes.TextureViewDimension
 }
 
 // TextureViewDescriptor describes how to create a texture view.
@@ -273,6 +424,11 @@ type SamplerDescriptor struct {
 	// LodMaxClamp is the maximum LOD clamp.
 	LodMaxClamp float32
 
+	// LodBias is added to the LOD computed for each sample, before the
+	// clamps. Vulkan clamps it to maxSamplerLodBias; Metal and OpenGL ES
+	// ignore it.
+	LodBias float32
+
 	// Compare is the comparison function for depth textures.
 	Compare gputypes.CompareFunction
 
@@ -336,6 +492,27 @@ type ShaderModuleDescriptor struct {
 	// Source is the shader source code.
 	// Can be WGSL source code or SPIR-V bytecode.
 	Source ShaderSource
+
+	// CompilationHints name entry points that pipelines will use, so the
+	// backend can compile them now instead of at the first pipeline
+	// creation. Backends may ignore them.
+	CompilationHints []ShaderCompilationHint
+}
+
+// ShaderCompilationHint names an entry point of a shader module and the
+// layout pipelines will use it with.
+//
+// Matches WebGPU GPUShaderModuleCompilationHint.
+type ShaderCompilationHint struct {
+	// EntryPoint is the entry point function name.
+	EntryPoint string
+
+	// Stage is the entry point's stage, or 0 when the caller does not know
+	// it (SPIR-V sources).
+	Stage gputypes.ShaderStages
+
+	// Layout is the pipeline layout, or nil for an automatic layout.
+	Layout PipelineLayout
 }
 
 // ShaderSource represents shader source code or bytecode.
@@ -361,6 +538,10 @@ type RenderPipelineDescriptor struct {
 	// Primitive is the primitive assembly state.
 	Primitive gputypes.PrimitiveState
 
+	// PolygonMode selects how triangles are rasterized. Non-fill modes
+	// require FeaturePolygonModeLine or FeaturePolygonModePoint.
+	PolygonMode PolygonMode
+
 	// DepthStencil is the depth/stencil state (optional).
 	DepthStencil *DepthStencilState
 
@@ -369,8 +550,28 @@ type RenderPipelineDescriptor struct {
 
 	// Fragment is the fragment stage (optional for depth-only passes).
 	Fragment *FragmentState
+
+	// ViewCount is the number of views the pipeline renders per draw, for
+	// use in render passes with the same ViewCount. 0 and 1 mean a single
+	// view; more require FeatureMultiview.
+	ViewCount uint32
 }
 
+// PolygonMode selects how triangles are rasterized. Line and point
+// primitives are not affected.
+type PolygonMode uint8
+
+const (
+	// PolygonModeFill fills triangles (the default).
+	PolygonModeFill PolygonMode = iota
+
+	// PolygonModeLine draws triangle edges as lines.
+	PolygonModeLine
+
+	// PolygonModePoint draws triangle vertices as points.
+	PolygonModePoint
+)
+
 // VertexState describes the vertex shader stage.
 type VertexState struct {
 	// Module is the shader module.
@@ -459,6 +660,11 @@ type ComputeState struct {
 type CommandEncoderDescriptor struct {
 	// Label is an optional debug name.
 	Label string
+
+	// Protected records into a protected command buffer, which may access
+	// protected resources but must not write to unprotected ones. Requires
+	// FeatureProtectedContent.
+	Protected bool
 }
 
 // RenderBundleEncoderDescriptor describes a render bundle encoder.
@@ -497,6 +703,12 @@ type RenderPassDescriptor struct {
 
 	// TimestampWrites are timestamp queries (optional).
 	TimestampWrites *RenderPassTimestampWrites
+
+	// ViewCount renders every draw once per view, into consecutive array
+	// layers of the attachments, which must be 2D array views with exactly
+	// ViewCount layers. Shaders read the view from the view_index built-in.
+	// 0 and 1 mean a single view; more require FeatureMultiview.
+	ViewCount uint32
 }
 
 // RenderPassColorAttachment describes a color attachment.
@@ -570,6 +782,32 @@ const (
 
 	// QueryTypeTimestamp writes GPU timestamps for profiling.
 	QueryTypeTimestamp
+
+	// QueryTypePipelineStatistics counts shader invocations and primitives
+	// between PipelineStatisticsPassEncoder begin and end calls.
+	QueryTypePipelineStatistics
+)
+
+// PipelineStatisticsTypes selects the counters of a pipeline statistics
+// query set. ResolveQuerySet writes one uint64 per selected counter for each
+// query, in ascending bit order, on every backend.
+type PipelineStatisticsTypes uint32
+
+const (
+	// PipelineStatisticsVertexShaderInvocations counts vertex shader invocations.
+	PipelineStatisticsVertexShaderInvocations PipelineStatisticsTypes = 1 << iota
+
+	// PipelineStatisticsClipperInvocations counts primitives entering the clipper.
+	PipelineStatisticsClipperInvocations
+
+	// PipelineStatisticsClipperPrimitivesOut counts primitives the clipper outputs.
+	PipelineStatisticsClipperPrimitivesOut
+
+	// PipelineStatisticsFragmentShaderInvocations counts fragment shader invocations.
+	PipelineStatisticsFragmentShaderInvocations
+
+	// PipelineStatisticsComputeShaderInvocations counts compute shader invocations.
+	PipelineStatisticsComputeShaderInvocations
 )
 
 // QuerySetDescriptor describes how to create a query set.
@@ -582,6 +820,10 @@ type QuerySetDescriptor struct {
 
 	// Count is the number of queries in the set.
 	Count uint32
+
+	// PipelineStatistics selects the counters of a
+	// QueryTypePipelineStatistics set.
+	PipelineStatistics PipelineStatisticsTypes
 }
 
 // QuerySet represents a set of queries.
@@ -6,6 +6,7 @@
 package dx12
 
 import (
+	"encoding/binary"
 	"fmt"
 	"unsafe"
 
@@ -61,6 +62,39 @@ type AdapterCapabilities struct {
 	// IsCacheCoherentUMA indicates cache-coherent UMA (GPU snoops CPU cache).
 	// Used for memory pool selection: D3D12_MEMORY_POOL_L0 (UMA) vs L1 (non-UMA).
 	IsCacheCoherentUMA bool
+
+	// WaveLaneCountMin and WaveLaneCountMax bound the wave size of SM 6.0
+	// wave intrinsics. Both are 0 when the driver does not support them.
+	WaveLaneCountMin uint32
+	WaveLaneCountMax uint32
+
+	// Native16BitShaderOps indicates SM 6.2 shaders may use true 16-bit
+	// types (DXC -enable-16bit-types).
+	Native16BitShaderOps bool
+
+	// ViewInstancingTier is the D3D12 view instancing support level.
+	ViewInstancingTier d3d12.D3D12_VIEW_INSTANCING_TIER
+
+	// SampleCounts holds the multisample counts each renderable format
+	// supports, as a hal.TextureFormatCapabilities.SampleCounts bitmask.
+	SampleCounts map[gputypes.TextureFormat]uint32
+
+	// PlanarFormats holds the features of the multi-planar video formats
+	// the adapter can sample as 2D textures (hal.FeatureTextureFormatNV12,
+	// hal.FeatureTextureFormatP010).
+	PlanarFormats gputypes.Features
+}
+
+// downlevelFlags returns the downlevel flags reported for these capabilities.
+func (c *AdapterCapabilities) downlevelFlags() hal.DownlevelFlags {
+	// CopyTextureRegion reinterprets BC blocks as texels of an uncompressed
+	// format of the block's size, and back, with the box in source texels.
+	flags := hal.DownlevelFlagsComputeShaders | hal.DownlevelFlagsAnisotropicFiltering |
+		hal.DownlevelFlagsCubeArrayTextures | hal.DownlevelFlagsTextureCopyReinterpret
+	if c.IsUMA {
+		flags |= hal.DownlevelFlagsUnifiedMemory
+	}
+	return flags
 }
 
 // probeCapabilities probes the adapter's capabilities by creating a temporary device.
@@ -98,9 +132,23 @@ func (a *Adapter) probeCapabilities() error {
 	// Query D3D12 options
 	a.queryD3D12Options(tempDevice)
 
+	// Query wave intrinsics (subgroups)
+	a.queryWaveOps(tempDevice)
+
+	// Query native 16-bit shader ops
+	a.query16BitOps(tempDevice)
+
+	// Query view instancing (multiview)
+	a.queryViewInstancing(tempDevice)
+
 	// Query architecture (UMA)
 	a.queryArchitecture(tempDevice)
 
+	// Query multi-planar video formats
+	a.queryPlanarFormats(tempDevice)
+
+	a.capabilities.SampleCounts = probeSampleCounts(tempDevice)
+
 	// Set default texture limits based on feature level
 	a.setTextureLimits()
 
@@ -162,12 +210,78 @@ func (a *Adapter) queryD3D12Options(device *d3d12.ID3D12Device) {
 	a.capabilities.SupportsTypedUAVLoadAdditionalFormats = options.TypedUAVLoadAdditionalFormats != 0
 	a.capabilities.SupportsROVs = options.ROVsSupported != 0
 
-	hal.Logger().Info("dx12: adapter capabilities",
+	a.instance.logger(hal.LogAdapter).Info("dx12: adapter capabilities",
 		"resourceBindingTier", a.capabilities.ResourceBindingTier,
 		"tiledResourcesTier", a.capabilities.TiledResourcesTier,
 	)
 }
 
+// queryWaveOps queries wave intrinsic support and the wave lane count range.
+func (a *Adapter) queryWaveOps(device *d3d12.ID3D12Device) {
+	var options1 d3d12.D3D12_FEATURE_DATA_D3D12_OPTIONS1
+
+	err := device.CheckFeatureSupport(
+		d3d12.D3D12_FEATURE_D3D12_OPTIONS1,
+		unsafe.Pointer(&options1),
+		uint32(unsafe.Sizeof(options1)),
+	)
+	if err != nil || options1.WaveOps == 0 {
+		return
+	}
+
+	a.capabilities.WaveLaneCountMin = options1.WaveLaneCountMin
+	a.capabilities.WaveLaneCountMax = options1.WaveLaneCountMax
+}
+
+// query16BitOps queries whether SM 6.2 shaders can use native 16-bit types.
+func (a *Adapter) query16BitOps(device *d3d12.ID3D12Device) {
+	var options4 d3d12.D3D12_FEATURE_DATA_D3D12_OPTIONS4
+
+	err := device.CheckFeatureSupport(
+		d3d12.D3D12_FEATURE_D3D12_OPTIONS4,
+		unsafe.Pointer(&options4),
+		uint32(unsafe.Sizeof(options4)),
+	)
+	a.capabilities.Native16BitShaderOps = err == nil && options4.Native16BitShaderOpsSupported != 0
+}
+
+// queryViewInstancing queries the view instancing tier.
+func (a *Adapter) queryViewInstancing(device *d3d12.ID3D12Device) {
+	var options3 d3d12.D3D12_FEATURE_DATA_D3D12_OPTIONS3
+
+	err := device.CheckFeatureSupport(
+		d3d12.D3D12_FEATURE_D3D12_OPTIONS3,
+		unsafe.Pointer(&options3),
+		uint32(unsafe.Sizeof(options3)),
+	)
+	if err == nil {
+		a.capabilities.ViewInstancingTier = options3.ViewInstancingTier
+	}
+}
+
+// queryPlanarFormats queries which multi-planar video formats can be
+// sampled as 2D textures.
+func (a *Adapter) queryPlanarFormats(device *d3d12.ID3D12Device) {
+	const want = d3d12.D3D12_FORMAT_SUPPORT1_TEXTURE2D | d3d12.D3D12_FORMAT_SUPPORT1_SHADER_SAMPLE
+	for _, planar := range []struct {
+		format  gputypes.TextureFormat
+		feature gputypes.Feature
+	}{
+		{hal.TextureFormatNV12, hal.FeatureTextureFormatNV12},
+		{hal.TextureFormatP010, hal.FeatureTextureFormatP010},
+	} {
+		data := d3d12.D3D12_FEATURE_DATA_FORMAT_SUPPORT{Format: textureFormatToD3D12(planar.format)}
+		err := device.CheckFeatureSupport(
+			d3d12.D3D12_FEATURE_FORMAT_SUPPORT,
+			unsafe.Pointer(&data),
+			uint32(unsafe.Sizeof(data)),
+		)
+		if err == nil && data.Support1&want == want {
+			a.capabilities.PlanarFormats.Insert(planar.feature)
+		}
+	}
+}
+
 // queryArchitecture queries the adapter's architecture (UMA).
 func (a *Adapter) queryArchitecture(device *d3d12.ID3D12Device) {
 	var arch d3d12.D3D12_FEATURE_DATA_ARCHITECTURE
@@ -182,13 +296,69 @@ func (a *Adapter) queryArchitecture(device *d3d12.ID3D12Device) {
 		a.capabilities.IsCacheCoherentUMA = arch.CacheCoherentUMA != 0
 	}
 
-	hal.Logger().Info("dx12: adapter architecture",
+	a.instance.logger(hal.LogAdapter).Info("dx12: adapter architecture",
 		"uma", a.capabilities.IsUMA,
 		"cacheCoherentUMA", a.capabilities.IsCacheCoherentUMA,
 		"tileBasedRenderer", arch.TileBasedRenderer != 0,
 	)
 }
 
+// multisampleFormats are the formats TextureFormatCapabilities reports as
+// multisample-capable.
+var multisampleFormats = []gputypes.TextureFormat{
+	gputypes.TextureFormatRGBA8Unorm,
+	gputypes.TextureFormatRGBA8UnormSrgb,
+	gputypes.TextureFormatBGRA8Unorm,
+	gputypes.TextureFormatBGRA8UnormSrgb,
+	gputypes.TextureFormatRGBA16Float,
+	gputypes.TextureFormatRGBA32Float,
+	gputypes.TextureFormatDepth16Unorm,
+	gputypes.TextureFormatDepth24Plus,
+	gputypes.TextureFormatDepth24PlusStencil8,
+	gputypes.TextureFormatDepth32Float,
+	gputypes.TextureFormatDepth32FloatStencil8,
+}
+
+// probeSampleCounts asks CheckFeatureSupport(MULTISAMPLE_QUALITY_LEVELS)
+// which of 2x, 4x, 8x and 16x each multisample format supports. A count is
+// supported when the driver reports at least one quality level for it.
+func probeSampleCounts(device *d3d12.ID3D12Device) map[gputypes.TextureFormat]uint32 {
+	counts := make(map[gputypes.TextureFormat]uint32, len(multisampleFormats))
+	for _, format := range multisampleFormats {
+		mask := hal.SampleCount1
+		for _, n := range []uint32{2, 4, 8, 16} {
+			data := d3d12.D3D12_FEATURE_DATA_MULTISAMPLE_QUALITY_LEVELS{
+				Format:      textureFormatToD3D12(format),
+				SampleCount: n,
+			}
+			err := device.CheckFeatureSupport(
+				d3d12.D3D12_FEATURE_MULTISAMPLE_QUALITY_LEVELS,
+				unsafe.Pointer(&data),
+				uint32(unsafe.Sizeof(data)),
+			)
+			if err == nil && data.NumQualityLevels > 0 {
+				mask |= n
+			}
+		}
+		counts[format] = mask
+	}
+	return counts
+}
+
+// sampleCountsFor returns the probed sample counts for a format with the
+// given capability flags. Formats that are not multisample-capable are
+// single-sampled; when probing failed, the WebGPU baseline of 1x and 4x
+// (guaranteed from feature level 11_0) is assumed.
+func sampleCountsFor(probed map[gputypes.TextureFormat]uint32, format gputypes.TextureFormat, flags hal.TextureFormatCapabilityFlags) uint32 {
+	if flags&hal.TextureFormatCapabilityMultisample == 0 {
+		return hal.SampleCount1
+	}
+	if counts, ok := probed[format]; ok {
+		return counts
+	}
+	return hal.SampleCountsWebGPU
+}
+
 // setTextureLimits sets texture dimension limits based on feature level.
 func (a *Adapter) setTextureLimits() {
 	// D3D12 limits based on feature level
@@ -217,6 +387,7 @@ func (a *Adapter) toExposedAdapter() hal.ExposedAdapter {
 	return hal.ExposedAdapter{
 		Adapter:      a,
 		Info:         a.Info(),
+		Details:      adapterDetails(&a.desc, a.raw.CheckInterfaceSupport),
 		Features:     a.Features(),
 		Capabilities: a.Capabilities(),
 	}
@@ -236,12 +407,36 @@ func (a *Adapter) Info() gputypes.AdapterInfo {
 	}
 }
 
+// adapterDetails returns the adapter LUID, its dedicated and shared memory,
+// and the user-mode driver version, which DXGI reports through
+// CheckInterfaceSupport for IDXGIDevice.
+func adapterDetails(desc *dxgi.DXGI_ADAPTER_DESC1, checkInterfaceSupport func(*dxgi.GUID) (int64, error)) hal.AdapterDetails {
+	var details hal.AdapterDetails
+	if desc.DedicatedVideoMemory != 0 {
+		details.MemoryHeaps = append(details.MemoryHeaps, hal.MemoryHeap{Size: desc.DedicatedVideoMemory, DeviceLocal: true})
+	}
+	if desc.SharedSystemMemory != 0 {
+		details.MemoryHeaps = append(details.MemoryHeaps, hal.MemoryHeap{Size: desc.SharedSystemMemory})
+	}
+	binary.LittleEndian.PutUint32(details.LUID[:4], desc.AdapterLuid.LowPart)
+	binary.LittleEndian.PutUint32(details.LUID[4:], uint32(desc.AdapterLuid.HighPart))
+	if version, err := checkInterfaceSupport(&dxgi.IID_IDXGIDevice); err == nil {
+		details.DriverVersion = hal.DecodeUMDVersion(version)
+	}
+	return details
+}
+
 // Features returns supported WebGPU features.
 func (a *Adapter) Features() gputypes.Features {
 	var features gputypes.Features
 	// ExecuteIndirect supports counted draws natively. This feature is a
 	// performance hint; the public MultiDraw APIs do not gate on it.
 	features |= gputypes.Features(gputypes.FeatureMultiDrawIndirect)
+	// D3D12 rasterizes wireframe natively but has no point fill mode.
+	// SetPredication is core API on every command list.
+	features |= gputypes.Features(hal.FeaturePolygonModeLine | hal.FeatureConditionalRendering)
+	// Pipeline statistics query heaps are core D3D12 on every feature level.
+	features |= gputypes.Features(gputypes.FeaturePipelineStatisticsQuery)
 
 	// Map D3D12 capabilities to WebGPU features
 	// Feature level 11.0+ guarantees basic compute and texture compression
@@ -254,16 +449,63 @@ func (a *Adapter) Features() gputypes.Features {
 		features |= gputypes.Features(gputypes.FeatureDepth32FloatStencil8)
 	}
 
-	// Shader model 6.0+ enables subgroups
 	if a.capabilities.ShaderModel >= d3d12.D3D_SHADER_MODEL_6_0 {
 		features |= gputypes.Features(gputypes.FeatureShaderF16)
 	}
 
+	if a.supportsSubgroups() {
+		features |= gputypes.Features(gputypes.FeatureSubgroupOperations | gputypes.FeatureSubgroupBarrier)
+	}
+
+	if a.supportsViewInstancing() {
+		features |= gputypes.Features(hal.FeatureMultiview)
+	}
+
+	features |= a.capabilities.PlanarFormats
+
+	// Every buffer binding goes through a descriptor table, and D3D12
+	// bounds-checks descriptor accesses: out-of-range reads return zero and
+	// writes are dropped.
+	features |= gputypes.Features(hal.FeatureRobustBufferAccess)
+
 	return features
 }
 
+// supportsSubgroups reports whether WGSL subgroup built-ins can run on the
+// adapter. They compile to SM 6.0 wave intrinsics, which the HLSL→DXC and
+// naga DXIL paths emit; FXC targets SM 5.1 and has no wave operations, so
+// Open switches a device without DXC that requests subgroup features to the
+// DXIL shader path.
+func (a *Adapter) supportsSubgroups() bool {
+	return a.capabilities.ShaderModel >= d3d12.D3D_SHADER_MODEL_6_0 &&
+		a.capabilities.WaveLaneCountMax != 0
+}
+
+// supportsViewInstancing reports whether multiview pipelines can run on the
+// adapter. The view_index built-in compiles to SV_ViewID, which needs SM 6.1
+// and so the naga DXIL or HLSL→DXC path.
+func (a *Adapter) supportsViewInstancing() bool {
+	if a.capabilities.ViewInstancingTier == d3d12.D3D12_VIEW_INSTANCING_TIER_NOT_SUPPORTED ||
+		a.capabilities.ShaderModel < d3d12.D3D_SHADER_MODEL_6_1 {
+		return false
+	}
+	if dxilRequested() {
+		return true
+	}
+	compiler, _ := loadDXC(a.capabilities.ShaderModel)
+	return compiler != nil
+}
+
 // Capabilities returns detailed adapter capabilities.
 func (a *Adapter) Capabilities() hal.Capabilities {
+	var subgroupMin, subgroupMax uint32
+	if a.supportsSubgroups() {
+		subgroupMin, subgroupMax = a.capabilities.WaveLaneCountMin, a.capabilities.WaveLaneCountMax
+	}
+	var maxViews uint32
+	if a.supportsViewInstancing() {
+		maxViews = d3d12.D3D12_MAX_VIEW_INSTANCE_COUNT
+	}
 	return hal.Capabilities{
 		Limits: a.limits(),
 		AlignmentsMask: hal.Alignments{
@@ -272,8 +514,11 @@ func (a *Adapter) Capabilities() hal.Capabilities {
 		},
 		DownlevelCapabilities: hal.DownlevelCapabilities{
 			ShaderModel: uint32(a.capabilities.ShaderModel),
-			Flags:       hal.DownlevelFlagsComputeShaders | hal.DownlevelFlagsAnisotropicFiltering,
+			Flags:       a.capabilities.downlevelFlags(),
 		},
+		SubgroupMinSize:       subgroupMin,
+		SubgroupMaxSize:       subgroupMax,
+		MaxMultiviewViewCount: maxViews,
 	}
 }
 
@@ -332,10 +577,19 @@ func (a *Adapter) Open(features gputypes.Features, limits gputypes.Limits) (hal.
 	}
 
 	// Create device using the adapter
-	device, err := newDevice(a.instance, unsafe.Pointer(a.raw), a.capabilities.FeatureLevel)
+	device, err := newDevice(a.instance, unsafe.Pointer(a.raw), &a.capabilities)
 	if err != nil {
 		return hal.OpenDevice{}, err
 	}
+	a.raw.AddRef()
+	device.adapter = a.raw
+
+	// Subgroup built-ins need SM 6.0 wave intrinsics, which FXC cannot emit.
+	subgroups := gputypes.Features(gputypes.FeatureSubgroupOperations | gputypes.FeatureSubgroupBarrier)
+	if features&subgroups != 0 && !device.useDXIL && device.dxc == nil {
+		device.useDXIL = true
+		hal.Logger().Info("dx12: subgroup features requested, using DXIL direct compilation")
+	}
 
 	// Create queue wrapper
 	queue := newQueue(device)
@@ -346,6 +600,42 @@ func (a *Adapter) Open(features gputypes.Features, limits gputypes.Limits) (hal.
 	}, nil
 }
 
+// Adopt wraps an ID3D12Device another library created on this adapter,
+// submitting to its direct queue when native names one. Both get a
+// reference of their own, released by Destroy.
+func (a *Adapter) Adopt(native *hal.NativeDevice, features gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+	if native == nil || native.Device == 0 {
+		return hal.OpenDevice{}, fmt.Errorf("dx12: native device is nil")
+	}
+	if features&^a.Features() != 0 {
+		return hal.OpenDevice{}, fmt.Errorf("dx12: adapter does not support requested features")
+	}
+	rawDevice := (*d3d12.ID3D12Device)(unsafe.Pointer(native.Device)) //nolint:govet // COM pointer from the owner
+	luid := rawDevice.GetAdapterLuid()
+	if luid.LowPart != a.desc.AdapterLuid.LowPart || luid.HighPart != a.desc.AdapterLuid.HighPart {
+		return hal.OpenDevice{}, fmt.Errorf("dx12: native device was not created on this adapter")
+	}
+
+	var queue *d3d12.ID3D12CommandQueue
+	if native.Queue != 0 {
+		queue = (*d3d12.ID3D12CommandQueue)(unsafe.Pointer(native.Queue)) //nolint:govet // COM pointer from the owner
+		if queue.GetDesc().Type != d3d12.D3D12_COMMAND_LIST_TYPE_DIRECT {
+			return hal.OpenDevice{}, fmt.Errorf("dx12: native queue is not a direct queue")
+		}
+		queue.AddRef()
+	}
+	rawDevice.AddRef()
+
+	device, err := initDevice(a.instance, rawDevice, queue, &a.capabilities)
+	if err != nil {
+		return hal.OpenDevice{}, err
+	}
+	return hal.OpenDevice{
+		Device: device,
+		Queue:  newQueue(device),
+	}, nil
+}
+
 // TextureFormatCapabilities returns capabilities for a specific texture format.
 func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
 	// Note: CheckFormatSupport can query exact format capabilities per resource type.
@@ -374,7 +664,8 @@ func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.T
 	}
 
 	return hal.TextureFormatCapabilities{
-		Flags: flags,
+		Flags:        flags,
+		SampleCounts: sampleCountsFor(a.capabilities.SampleCounts, format, flags),
 	}
 }
 
@@ -534,12 +825,14 @@ func (a *AdapterLegacy) probeCapabilities() error {
 		a.capabilities.IsCacheCoherentUMA = arch.CacheCoherentUMA != 0
 	}
 
-	hal.Logger().Info("dx12: legacy adapter architecture",
+	a.instance.logger(hal.LogAdapter).Info("dx12: legacy adapter architecture",
 		"uma", a.capabilities.IsUMA,
 		"cacheCoherentUMA", a.capabilities.IsCacheCoherentUMA,
 		"tileBasedRenderer", arch.TileBasedRenderer != 0,
 	)
 
+	a.capabilities.SampleCounts = probeSampleCounts(tempDevice)
+
 	// Set default texture limits based on feature level
 	a.setTextureLimits()
 
@@ -583,6 +876,7 @@ func (a *AdapterLegacy) toExposedAdapter() hal.ExposedAdapter {
 	return hal.ExposedAdapter{
 		Adapter:      a,
 		Info:         info,
+		Details:      adapterDetails(&a.desc, a.raw.CheckInterfaceSupport),
 		Features:     a.Features(),
 		Capabilities: a.Capabilities(),
 	}
@@ -592,6 +886,9 @@ func (a *AdapterLegacy) toExposedAdapter() hal.ExposedAdapter {
 func (a *AdapterLegacy) Features() gputypes.Features {
 	var features gputypes.Features
 	features |= gputypes.Features(gputypes.FeatureMultiDrawIndirect)
+	features |= gputypes.Features(hal.FeaturePolygonModeLine | hal.FeatureConditionalRendering)
+	// Pipeline statistics query heaps are core D3D12 on every feature level.
+	features |= gputypes.Features(gputypes.FeaturePipelineStatisticsQuery)
 	if a.capabilities.FeatureLevel >= d3d12.D3D_FEATURE_LEVEL_11_0 {
 		features |= gputypes.Features(gputypes.FeatureTextureCompressionBC)
 	}
@@ -615,7 +912,7 @@ func (a *AdapterLegacy) Capabilities() hal.Capabilities {
 		},
 		DownlevelCapabilities: hal.DownlevelCapabilities{
 			ShaderModel: uint32(a.capabilities.ShaderModel),
-			Flags:       hal.DownlevelFlagsComputeShaders | hal.DownlevelFlagsAnisotropicFiltering,
+			Flags:       a.capabilities.downlevelFlags(),
 		},
 	}
 }
@@ -640,7 +937,7 @@ func (a *AdapterLegacy) Open(features gputypes.Features, limits gputypes.Limits)
 	}
 
 	// Create device using the legacy adapter
-	device, err := newDevice(a.instance, unsafe.Pointer(a.raw), a.capabilities.FeatureLevel)
+	device, err := newDevice(a.instance, unsafe.Pointer(a.raw), &a.capabilities)
 	if err != nil {
 		return hal.OpenDevice{}, err
 	}
@@ -666,7 +963,10 @@ func (a *AdapterLegacy) TextureFormatCapabilities(format gputypes.TextureFormat)
 			hal.TextureFormatCapabilityBlendable |
 			hal.TextureFormatCapabilityMultisample
 	}
-	return hal.TextureFormatCapabilities{Flags: flags}
+	return hal.TextureFormatCapabilities{
+		Flags:        flags,
+		SampleCounts: sampleCountsFor(a.capabilities.SampleCounts, format, flags),
+	}
 }
 
 // SurfaceCapabilities returns surface capabilities.
@@ -50,6 +50,11 @@ type CommandEncoder struct {
 	label       string
 	isRecording bool
 
+	// statisticsSet and statisticsIndex identify the active pipeline
+	// statistics query, or statisticsSet is nil.
+	statisticsSet   *QuerySet
+	statisticsIndex uint32
+
 	// descriptorHeaps is a pre-allocated array for SetDescriptorHeaps calls,
 	// avoiding a slice allocation per render/compute pass. At most 2 heaps
 	// are needed: one for CBV/SRV/UAV views and one for samplers.
@@ -165,11 +170,11 @@ func (e *CommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) {
 	for _, b := range barriers {
 		buf, ok := b.Buffer.(*Buffer)
 		if !ok || buf.raw == nil {
-			hal.Logger().Warn("TransitionBuffers: skipping invalid buffer (nil or destroyed)")
+			e.device.logger(hal.LogBarrier).Warn("TransitionBuffers: skipping invalid buffer (nil or destroyed)")
 			continue
 		}
 
-		afterState := bufferUsageToD3D12State(b.Usage.NewUsage)
+		afterState := bufferUsesToD3D12State(b.Usage.NewUsage)
 		beforeState, needsBarrier := e.stateTracker.transitionBuffer(buf, afterState)
 		if needsBarrier {
 			plans = append(plans, stateBarrierPlan{resource: buf, subresource: d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES, before: beforeState, after: afterState})
@@ -188,7 +193,7 @@ func (e *CommandEncoder) TransitionTextures(barriers []hal.TextureBarrier) {
 	for _, b := range barriers {
 		tex, ok := b.Texture.(*Texture)
 		if !ok || tex.raw == nil {
-			hal.Logger().Warn("TransitionTextures: skipping invalid texture (nil or destroyed)")
+			e.device.logger(hal.LogBarrier).Warn("TransitionTextures: skipping invalid texture (nil or destroyed)")
 			continue
 		}
 
@@ -219,7 +224,7 @@ func (e *CommandEncoder) emitStateBarrierPlans(plans []stateBarrierPlan) {
 		d3dBarriers = append(d3dBarriers, d3d12.NewTransitionBarrier(raw, plan.before, plan.after, plan.subresource))
 	}
 	if len(d3dBarriers) > 0 {
-		hal.Logger().Debug("dx12: resource barrier", "label", e.label, "count", len(d3dBarriers))
+		e.device.logger(hal.LogBarrier).Debug("dx12: resource barrier", "label", e.label, "count", len(d3dBarriers))
 		e.cmdList.ResourceBarrier(uint32(len(d3dBarriers)), &d3dBarriers[0])
 	}
 }
@@ -341,6 +346,10 @@ func (e *CommandEncoder) ResolveQuerySet(querySet hal.QuerySet, firstQuery, quer
 	if before, needsBarrier := e.stateTracker.transitionBuffer(buf, d3d12.D3D12_RESOURCE_STATE_COPY_DEST); needsBarrier {
 		e.emitStateBarrierPlans([]stateBarrierPlan{{resource: buf, subresource: d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES, before: before, after: d3d12.D3D12_RESOURCE_STATE_COPY_DEST}})
 	}
+	if qs.resolveScratch != nil {
+		e.resolvePipelineStatistics(qs, firstQuery, queryCount, buf.raw, destinationOffset)
+		return
+	}
 	e.cmdList.ResolveQueryData(qs.raw, qs.rawTy, firstQuery, queryCount, buf.raw, destinationOffset)
 }
 
@@ -450,7 +459,9 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 		e.cmdList.OMSetRenderTargets(0, nil, 0, dsvHandle)
 	}
 
-	// Set default viewport and scissor based on first color attachment or depth attachment
+	// The default viewport and scissor cover the first color attachment, or
+	// the depth attachment without one. Dynamic state reaches the command
+	// list at the first draw.
 	var width, height uint32
 	if len(desc.ColorAttachments) > 0 {
 		if view, ok := desc.ColorAttachments[0].View.(*TextureView); ok {
@@ -464,25 +475,7 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 		}
 	}
 
-	if width > 0 && height > 0 {
-		viewport := d3d12.D3D12_VIEWPORT{
-			TopLeftX: 0,
-			TopLeftY: 0,
-			Width:    float32(width),
-			Height:   float32(height),
-			MinDepth: 0,
-			MaxDepth: 1,
-		}
-		e.cmdList.RSSetViewports(1, &viewport)
-
-		scissor := d3d12.D3D12_RECT{
-			Left:   0,
-			Top:    0,
-			Right:  int32(width),
-			Bottom: int32(height),
-		}
-		e.cmdList.RSSetScissorRects(1, &scissor)
-	}
+	rpe.dynamic.reset(width, height)
 
 	// Write beginning-of-pass timestamp and store end-of-pass for later.
 	if desc.TimestampWrites != nil {
@@ -520,6 +513,20 @@ type RenderPassEncoder struct {
 	// Rust wgpu-hal reference: dx12/mod.rs end_of_pass_timer_query field.
 	endOfPassTimerHeap  *d3d12.ID3D12QueryHeap
 	endOfPassTimerIndex uint32
+
+	// predicated is true between BeginConditionalRendering and
+	// EndConditionalRendering. Predication is command list state, so End
+	// clears it before the resolves below would be skipped too.
+	predicated bool
+
+	// dynamic holds the viewport, scissor, blend constant and stencil
+	// reference, written to the command list before each draw.
+	dynamic dynamicState
+}
+
+// flushDynamicState writes the dynamic state changed since the last draw.
+func (e *RenderPassEncoder) flushDynamicState() {
+	e.dynamic.flush(e.encoder.cmdList)
 }
 
 // End finishes the render pass.
@@ -529,6 +536,7 @@ func (e *RenderPassEncoder) End() {
 	if e.desc == nil || e.encoder == nil || !e.encoder.isRecording {
 		return
 	}
+	e.EndConditionalRendering()
 
 	// Write end-of-pass timestamp before state transitions.
 	// Rust wgpu-hal reference: dx12/command.rs end_render_pass calls
@@ -620,6 +628,9 @@ func (e *RenderPassEncoder) SetPipeline(pipeline hal.RenderPipeline) {
 		e.encoder.cmdList.SetGraphicsRootSignature(p.rootSignature)
 	}
 	e.encoder.cmdList.IASetPrimitiveTopology(p.topology)
+	// Dynamic state outlives pipeline changes: values set before this call
+	// are written again ahead of the next draw, as Vulkan keeps them bound.
+	e.dynamic.invalidate()
 
 	// Bind the global sampler descriptor heap to the sampler root parameter.
 	// This is the ONE root parameter that covers all 2048+2048 sampler slots.
@@ -662,7 +673,7 @@ func (e *RenderPassEncoder) SetBindGroup(index uint32, group hal.BindGroup, offs
 }
 
 // SetVertexBuffer sets a vertex buffer.
-func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset uint64) {
+func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, size uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || !e.encoder.isRecording {
 		return
@@ -679,7 +690,7 @@ func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offs
 
 	vbv := d3d12.D3D12_VERTEX_BUFFER_VIEW{
 		BufferLocation: buf.gpuVA + offset,
-		SizeInBytes:    uint32(buf.size - offset),
+		SizeInBytes:    uint32(size),
 		StrideInBytes:  stride,
 	}
 
@@ -687,7 +698,7 @@ func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offs
 }
 
 // SetIndexBuffer sets the index buffer.
-func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, size uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || !e.encoder.isRecording {
 		return
@@ -704,70 +715,65 @@ func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.In
 
 	ibv := d3d12.D3D12_INDEX_BUFFER_VIEW{
 		BufferLocation: buf.gpuVA + offset,
-		SizeInBytes:    uint32(buf.size - offset),
+		SizeInBytes:    uint32(size),
 		Format:         dxgiFormat,
 	}
 
 	e.encoder.cmdList.IASetIndexBuffer(&ibv)
 }
 
-// SetViewport sets the viewport.
+// SetViewport sets the viewport for the following draws.
 func (e *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDepth float32) {
 	if !e.encoder.isRecording {
 		return
 	}
 
-	viewport := d3d12.D3D12_VIEWPORT{
+	e.dynamic.setViewport(d3d12.D3D12_VIEWPORT{
 		TopLeftX: x,
 		TopLeftY: y,
 		Width:    width,
 		Height:   height,
 		MinDepth: minDepth,
 		MaxDepth: maxDepth,
-	}
-
-	e.encoder.cmdList.RSSetViewports(1, &viewport)
+	})
 }
 
-// SetScissorRect sets the scissor rectangle.
+// SetScissorRect sets the scissor rectangle for the following draws.
 func (e *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
 	if !e.encoder.isRecording {
 		return
 	}
 
-	scissor := d3d12.D3D12_RECT{
+	e.dynamic.setScissor(d3d12.D3D12_RECT{
 		Left:   int32(x),
 		Top:    int32(y),
 		Right:  int32(x + width),
 		Bottom: int32(y + height),
-	}
-
-	e.encoder.cmdList.RSSetScissorRects(1, &scissor)
+	})
 }
 
-// SetBlendConstant sets the blend constant.
+// SetBlendConstant sets the blend constant for the following draws.
 func (e *RenderPassEncoder) SetBlendConstant(color *gputypes.Color) {
 	if !e.encoder.isRecording || color == nil {
 		return
 	}
 
-	blendFactor := [4]float32{
+	e.dynamic.setBlendFactor([4]float32{
 		float32(color.R),
 		float32(color.G),
 		float32(color.B),
 		float32(color.A),
-	}
-
-	e.encoder.cmdList.OMSetBlendFactor(&blendFactor)
+	})
 }
 
-// SetStencilReference sets the stencil reference value.
+// SetStencilReference sets the stencil reference value for the following
+// draws.
 func (e *RenderPassEncoder) SetStencilReference(ref uint32) {
 	if !e.encoder.isRecording {
 		return
 	}
 
-	e.encoder.cmdList.OMSetStencilRef(ref)
+	e.dynamic.setStencilRef(ref)
 }
 
 // Draw draws primitives.
@@ -776,6 +782,7 @@ func (e *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstI
 		return
 	}
 
+	e.flushDynamicState()
 	e.encoder.cmdList.DrawInstanced(vertexCount, instanceCount, firstVertex, firstInstance)
 }
 
@@ -785,6 +792,7 @@ func (e *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex ui
 		return
 	}
 
+	e.flushDynamicState()
 	e.encoder.cmdList.DrawIndexedInstanced(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
 }
 
@@ -806,6 +814,7 @@ func (e *RenderPassEncoder) DrawIndirect(buffer hal.Buffer, offset uint64, drawC
 		e.encoder.emitStateBarrierPlans([]stateBarrierPlan{{resource: buf, subresource: d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES, before: before, after: target}})
 	}
 
+	e.flushDynamicState()
 	e.encoder.cmdList.ExecuteIndirect(
 		e.encoder.device.cmdSignatures.draw,
 		drawCount, buf.raw, offset, nil, 0,
@@ -830,6 +839,7 @@ func (e *RenderPassEncoder) DrawIndexedIndirect(buffer hal.Buffer, offset uint64
 		e.encoder.emitStateBarrierPlans([]stateBarrierPlan{{resource: buf, subresource: d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES, before: before, after: target}})
 	}
 
+	e.flushDynamicState()
 	e.encoder.cmdList.ExecuteIndirect(
 		e.encoder.device.cmdSignatures.drawIndexed,
 		drawCount, buf.raw, offset, nil, 0,
@@ -842,6 +852,37 @@ func (e *RenderPassEncoder) ExecuteBundle(bundle hal.RenderBundle) {
 	_ = bundle
 }
 
+// BeginConditionalRendering predicates the following draws on the 64-bit
+// value at offset via SetPredication. The buffer moves to the PREDICATION
+// state, which D3D12 defines as the same bit as INDIRECT_ARGUMENT.
+// Implements hal.ConditionalRenderPassEncoder.
+func (e *RenderPassEncoder) BeginConditionalRendering(buffer hal.Buffer, offset uint64, inverted bool) {
+	buf, ok := buffer.(*Buffer)
+	if !ok || !e.encoder.isRecording || offset%8 != 0 || offset+8 > buf.size {
+		return
+	}
+	if before, target, needsBarrier := e.encoder.stateTracker.transitionBufferRead(buf, d3d12.D3D12_RESOURCE_STATE_PREDICATION); needsBarrier {
+		e.encoder.emitStateBarrierPlans([]stateBarrierPlan{{resource: buf, subresource: d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES, before: before, after: target}})
+	}
+	// EQUAL_ZERO skips commands when the value is zero.
+	op := d3d12.D3D12_PREDICATION_OP_EQUAL_ZERO
+	if inverted {
+		op = d3d12.D3D12_PREDICATION_OP_NOT_EQUAL_ZERO
+	}
+	e.encoder.cmdList.SetPredication(buf.raw, offset, op)
+	e.predicated = true
+}
+
+// EndConditionalRendering disables predication.
+// Implements hal.ConditionalRenderPassEncoder.
+func (e *RenderPassEncoder) EndConditionalRendering() {
+	if !e.predicated || !e.encoder.isRecording {
+		return
+	}
+	e.encoder.cmdList.SetPredication(nil, 0, d3d12.D3D12_PREDICATION_OP_EQUAL_ZERO)
+	e.predicated = false
+}
+
 // ComputePassEncoder implements hal.ComputePassEncoder for DirectX 12.
 type ComputePassEncoder struct {
 	encoder            *CommandEncoder
@@ -1247,29 +1288,31 @@ func (e *CommandEncoder) setupDepthStencilAttachment(dsa *hal.RenderPassDepthSte
 	return dsvHandle
 }
 
-// bufferUsageToD3D12State converts buffer usage to D3D12 resource state.
-func bufferUsageToD3D12State(usage gputypes.BufferUsage) d3d12.D3D12_RESOURCE_STATES {
+// bufferUsesToD3D12State converts a buffer state to a D3D12 resource state.
+// Read-only storage buffers are bound through SRVs, so they use the shader
+// resource states rather than UNORDERED_ACCESS.
+func bufferUsesToD3D12State(uses hal.BufferUses) d3d12.D3D12_RESOURCE_STATES {
 	var state d3d12.D3D12_RESOURCE_STATES
 
-	if usage&gputypes.BufferUsageCopySrc != 0 {
+	if uses&hal.BufferUseCopySrc != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_COPY_SOURCE
 	}
-	if usage&gputypes.BufferUsageCopyDst != 0 {
+	if uses&(hal.BufferUseCopyDst|hal.BufferUseQueryResolve) != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_COPY_DEST
 	}
-	if usage&gputypes.BufferUsageVertex != 0 {
+	if uses&(hal.BufferUseVertex|hal.BufferUseUniform) != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_VERTEX_AND_CONSTANT_BUFFER
 	}
-	if usage&gputypes.BufferUsageIndex != 0 {
+	if uses&hal.BufferUseIndex != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_INDEX_BUFFER
 	}
-	if usage&gputypes.BufferUsageUniform != 0 {
-		state |= d3d12.D3D12_RESOURCE_STATE_VERTEX_AND_CONSTANT_BUFFER
+	if uses&hal.BufferUseStorageRead != 0 {
+		state |= d3d12.D3D12_RESOURCE_STATE_NON_PIXEL_SHADER_RESOURCE | d3d12.D3D12_RESOURCE_STATE_PIXEL_SHADER_RESOURCE
 	}
-	if usage&gputypes.BufferUsageStorage != 0 {
+	if uses&hal.BufferUseStorageReadWrite != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_UNORDERED_ACCESS
 	}
-	if usage&gputypes.BufferUsageIndirect != 0 {
+	if uses&hal.BufferUseIndirect != 0 {
 		state |= d3d12.D3D12_RESOURCE_STATE_INDIRECT_ARGUMENT
 	}
 
@@ -137,13 +137,21 @@ func TestDX12ComputeBarriers(t *testing.T) {
 		}
 	})
 
-	t.Run("bufferUsageToD3D12State storage", func(t *testing.T) {
-		state := bufferUsageToD3D12State(gputypes.BufferUsageStorage)
+	t.Run("bufferUsesToD3D12State storage", func(t *testing.T) {
+		state := bufferUsesToD3D12State(hal.BufferUseStorageReadWrite)
 		if state != d3d12.D3D12_RESOURCE_STATE_UNORDERED_ACCESS {
 			t.Errorf("state = %d, want UNORDERED_ACCESS", state)
 		}
 	})
 
+	t.Run("bufferUsesToD3D12State storage read", func(t *testing.T) {
+		state := bufferUsesToD3D12State(hal.BufferUseStorageRead)
+		want := d3d12.D3D12_RESOURCE_STATE_NON_PIXEL_SHADER_RESOURCE | d3d12.D3D12_RESOURCE_STATE_PIXEL_SHADER_RESOURCE
+		if state != want {
+			t.Errorf("state = %d, want %d", state, want)
+		}
+	})
+
 	t.Run("textureUsageToD3D12State storage", func(t *testing.T) {
 		state := textureUsageToD3D12State(gputypes.TextureUsageStorageBinding)
 		if state != d3d12.D3D12_RESOURCE_STATE_UNORDERED_ACCESS {
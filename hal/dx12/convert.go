@@ -142,6 +142,12 @@ func textureFormatToD3D12(format gputypes.TextureFormat) d3d12.DXGI_FORMAT {
 	case gputypes.TextureFormatBC7RGBAUnormSrgb:
 		return d3d12.DXGI_FORMAT_BC7_UNORM_SRGB
 
+	// Multi-planar video formats
+	case hal.TextureFormatNV12:
+		return d3d12.DXGI_FORMAT_NV12
+	case hal.TextureFormatP010:
+		return d3d12.DXGI_FORMAT_P010
+
 	default:
 		return d3d12.DXGI_FORMAT_UNKNOWN
 	}
@@ -226,6 +232,28 @@ func isDepthFormat(format gputypes.TextureFormat) bool {
 	}
 }
 
+// srgbFamilyTypeless returns the typeless format shared by format and its
+// sRGB counterpart, or DXGI_FORMAT_UNKNOWN when format has none. Resources
+// created typeless can be viewed in either encoding.
+func srgbFamilyTypeless(format gputypes.TextureFormat) d3d12.DXGI_FORMAT {
+	switch format {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb:
+		return d3d12.DXGI_FORMAT_R8G8B8A8_TYPELESS
+	case gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb:
+		return d3d12.DXGI_FORMAT_B8G8R8A8_TYPELESS
+	case gputypes.TextureFormatBC1RGBAUnorm, gputypes.TextureFormatBC1RGBAUnormSrgb:
+		return d3d12.DXGI_FORMAT_BC1_TYPELESS
+	case gputypes.TextureFormatBC2RGBAUnorm, gputypes.TextureFormatBC2RGBAUnormSrgb:
+		return d3d12.DXGI_FORMAT_BC2_TYPELESS
+	case gputypes.TextureFormatBC3RGBAUnorm, gputypes.TextureFormatBC3RGBAUnormSrgb:
+		return d3d12.DXGI_FORMAT_BC3_TYPELESS
+	case gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatBC7RGBAUnormSrgb:
+		return d3d12.DXGI_FORMAT_BC7_TYPELESS
+	default:
+		return d3d12.DXGI_FORMAT_UNKNOWN
+	}
+}
+
 // depthFormatToTypeless converts a depth format to its typeless equivalent for SRV.
 func depthFormatToTypeless(format gputypes.TextureFormat) d3d12.DXGI_FORMAT {
 	switch format {
@@ -265,6 +293,9 @@ func depthFormatToSRV(format gputypes.TextureFormat) d3d12.DXGI_FORMAT {
 // stencil plane through an X*TYPELESS_G8*UINT SRV. Standalone Stencil8 uses a
 // D24S8 backing resource on DX12 and therefore follows the same representation.
 func textureFormatToSRV(format gputypes.TextureFormat, aspect gputypes.TextureAspect) (d3d12.DXGI_FORMAT, uint32) {
+	if plane, ok := hal.PlaneIndex(aspect); ok {
+		return planeFormatToSRV(format), plane
+	}
 	if aspect == gputypes.TextureAspectStencilOnly || format == gputypes.TextureFormatStencil8 {
 		switch format {
 		case gputypes.TextureFormatDepth24PlusStencil8, gputypes.TextureFormatStencil8:
@@ -281,6 +312,23 @@ func textureFormatToSRV(format gputypes.TextureFormat, aspect gputypes.TextureAs
 	return textureFormatToD3D12(format), 0
 }
 
+// planeFormatToSRV returns the SRV format of a view of one plane of a
+// multi-planar texture, whose format is the plane's format.
+func planeFormatToSRV(format gputypes.TextureFormat) d3d12.DXGI_FORMAT {
+	switch format {
+	case gputypes.TextureFormatR8Unorm:
+		return d3d12.DXGI_FORMAT_R8_UNORM
+	case gputypes.TextureFormatRG8Unorm:
+		return d3d12.DXGI_FORMAT_R8G8_UNORM
+	case gputypes.TextureFormatR16Unorm:
+		return d3d12.DXGI_FORMAT_R16_UNORM
+	case gputypes.TextureFormatRG16Unorm:
+		return d3d12.DXGI_FORMAT_R16G16_UNORM
+	default:
+		return d3d12.DXGI_FORMAT_UNKNOWN
+	}
+}
+
 // addressModeToD3D12 converts a WebGPU address mode to D3D12.
 func addressModeToD3D12(mode gputypes.AddressMode) d3d12.D3D12_TEXTURE_ADDRESS_MODE {
 	switch mode {
@@ -422,6 +470,15 @@ func cullModeToD3D12(mode gputypes.CullMode) d3d12.D3D12_CULL_MODE {
 	}
 }
 
+// fillModeToD3D12 converts a HAL polygon mode to a D3D12 fill mode.
+// PolygonModePoint is not supported by D3D12 and is never enabled.
+func fillModeToD3D12(mode hal.PolygonMode) d3d12.D3D12_FILL_MODE {
+	if mode == hal.PolygonModeLine {
+		return d3d12.D3D12_FILL_MODE_WIREFRAME
+	}
+	return d3d12.D3D12_FILL_MODE_SOLID
+}
+
 // frontFaceToD3D12 converts a WebGPU front face to D3D12 winding order.
 // Returns 1 (TRUE) if counter-clockwise, 0 (FALSE) if clockwise.
 func frontFaceToD3D12(face gputypes.FrontFace) int32 {
@@ -250,6 +250,35 @@ func TestDepthFormatToTypeless(t *testing.T) {
 	}
 }
 
+func TestSrgbFamilyTypeless(t *testing.T) {
+	tests := []struct {
+		format gputypes.TextureFormat
+		expect d3d12.DXGI_FORMAT
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, d3d12.DXGI_FORMAT_R8G8B8A8_TYPELESS},
+		{gputypes.TextureFormatRGBA8UnormSrgb, d3d12.DXGI_FORMAT_R8G8B8A8_TYPELESS},
+		{gputypes.TextureFormatBGRA8UnormSrgb, d3d12.DXGI_FORMAT_B8G8R8A8_TYPELESS},
+		{gputypes.TextureFormatBC7RGBAUnorm, d3d12.DXGI_FORMAT_BC7_TYPELESS},
+		{gputypes.TextureFormatRGBA16Float, d3d12.DXGI_FORMAT_UNKNOWN},
+	}
+	for _, tt := range tests {
+		if got := srgbFamilyTypeless(tt.format); got != tt.expect {
+			t.Errorf("srgbFamilyTypeless(%v) = %v, want %v", tt.format, got, tt.expect)
+		}
+	}
+}
+
+func TestSwapchainBufferFormatIsLinear(t *testing.T) {
+	for _, format := range []gputypes.TextureFormat{
+		gputypes.TextureFormatBGRA8UnormSrgb, gputypes.TextureFormatRGBA8UnormSrgb,
+		gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatRGBA16Float,
+	} {
+		if got := swapchainBufferFormat(format); got.IsSrgb() {
+			t.Errorf("swapchainBufferFormat(%v) = %v, want a linear format", format, got)
+		}
+	}
+}
+
 func TestTextureFormatToSRVSelectsAspectPlane(t *testing.T) {
 	tests := []struct {
 		name       string
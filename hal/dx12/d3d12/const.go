@@ -318,6 +318,10 @@ const (
 	D3D12_FENCE_FLAG_NON_MONITORED        D3D12_FENCE_FLAGS = 4
 )
 
+// GENERIC_ALL is the access right CreateSharedHandle grants on shared
+// fences and resources.
+const GENERIC_ALL uint32 = 0x10000000
+
 // D3D12_PRIMITIVE_TOPOLOGY_TYPE specifies primitive topology type.
 type D3D12_PRIMITIVE_TOPOLOGY_TYPE uint32
 
@@ -689,6 +693,63 @@ const (
 	D3D12_INDIRECT_ARGUMENT_TYPE_DISPATCH_MESH         D3D12_INDIRECT_ARGUMENT_TYPE = 10
 )
 
+// D3D12_PIPELINE_STATE_SUBOBJECT_TYPE identifies a subobject of a pipeline
+// state stream.
+type D3D12_PIPELINE_STATE_SUBOBJECT_TYPE uint32
+
+// Pipeline state subobject type constants.
+const (
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_ROOT_SIGNATURE        D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 0
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VS                    D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 1
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_PS                    D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 2
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_BLEND                 D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 8
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_SAMPLE_MASK           D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 9
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_RASTERIZER            D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 10
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_DEPTH_STENCIL         D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 11
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_INPUT_LAYOUT          D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 12
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_IB_STRIP_CUT_VALUE    D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 13
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_PRIMITIVE_TOPOLOGY    D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 14
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_RENDER_TARGET_FORMATS D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 15
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_DEPTH_STENCIL_FORMAT  D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 16
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_SAMPLE_DESC           D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 17
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_NODE_MASK             D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 18
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_FLAGS                 D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 20
+	D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VIEW_INSTANCING       D3D12_PIPELINE_STATE_SUBOBJECT_TYPE = 22
+)
+
+// D3D12_FORMAT_SUPPORT1 specifies the resource types and operations a
+// format supports.
+type D3D12_FORMAT_SUPPORT1 uint32
+
+// Format support flags used by the backend.
+const (
+	D3D12_FORMAT_SUPPORT1_TEXTURE2D     D3D12_FORMAT_SUPPORT1 = 0x20
+	D3D12_FORMAT_SUPPORT1_SHADER_SAMPLE D3D12_FORMAT_SUPPORT1 = 0x200
+)
+
+// D3D12_VIEW_INSTANCING_TIER specifies view instancing support.
+type D3D12_VIEW_INSTANCING_TIER uint32
+
+// View instancing tier constants.
+const (
+	D3D12_VIEW_INSTANCING_TIER_NOT_SUPPORTED D3D12_VIEW_INSTANCING_TIER = 0
+	D3D12_VIEW_INSTANCING_TIER_1             D3D12_VIEW_INSTANCING_TIER = 1
+	D3D12_VIEW_INSTANCING_TIER_2             D3D12_VIEW_INSTANCING_TIER = 2
+	D3D12_VIEW_INSTANCING_TIER_3             D3D12_VIEW_INSTANCING_TIER = 3
+)
+
+// D3D12_VIEW_INSTANCING_FLAGS specifies view instancing options.
+type D3D12_VIEW_INSTANCING_FLAGS uint32
+
+// View instancing flag constants.
+const (
+	D3D12_VIEW_INSTANCING_FLAG_NONE                         D3D12_VIEW_INSTANCING_FLAGS = 0
+	D3D12_VIEW_INSTANCING_FLAG_ENABLE_VIEW_INSTANCE_MASKING D3D12_VIEW_INSTANCING_FLAGS = 0x1
+)
+
+// D3D12_MAX_VIEW_INSTANCE_COUNT is the most view instances a pipeline may have.
+const D3D12_MAX_VIEW_INSTANCE_COUNT = 4
+
 // D3D12_PIPELINE_STATE_FLAGS specifies pipeline state flags.
 type D3D12_PIPELINE_STATE_FLAGS uint32
 
@@ -829,6 +890,8 @@ const (
 	DXGI_FORMAT_BC7_TYPELESS             DXGI_FORMAT = 97
 	DXGI_FORMAT_BC7_UNORM                DXGI_FORMAT = 98
 	DXGI_FORMAT_BC7_UNORM_SRGB           DXGI_FORMAT = 99
+	DXGI_FORMAT_NV12                     DXGI_FORMAT = 103
+	DXGI_FORMAT_P010                     DXGI_FORMAT = 104
 	DXGI_FORMAT_R32G8X24_TYPELESS        DXGI_FORMAT = 19
 	DXGI_FORMAT_D32_FLOAT_S8X24_UINT     DXGI_FORMAT = 20
 	DXGI_FORMAT_R32_FLOAT_X8X24_TYPELESS DXGI_FORMAT = 21
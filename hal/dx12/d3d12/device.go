@@ -466,6 +466,51 @@ func (d *ID3D12Device) CreateFence(initialValue uint64, flags D3D12_FENCE_FLAGS)
 	return fence, nil
 }
 
+// CreateSharedHandle creates an NT handle to a fence created with
+// D3D12_FENCE_FLAG_SHARED, for another API or process to open. The caller
+// closes the handle.
+func (d *ID3D12Device) CreateSharedHandle(fence *ID3D12Fence, access uint32) (uintptr, error) {
+	var handle uintptr
+
+	ret, _, _ := syscall.Syscall6(
+		d.vtbl.CreateSharedHandle,
+		6,
+		uintptr(unsafe.Pointer(d)),
+		uintptr(unsafe.Pointer(fence)),
+		0, // pAttributes
+		uintptr(access),
+		0, // Name
+		uintptr(unsafe.Pointer(&handle)),
+	)
+
+	if ret != 0 {
+		return 0, HRESULTError(ret)
+	}
+	return handle, nil
+}
+
+// OpenSharedFence opens an NT handle to a shared fence, as another API or
+// process created it with CreateSharedHandle. The handle stays open.
+func (d *ID3D12Device) OpenSharedFence(handle uintptr) (*ID3D12Fence, error) {
+	var fence *ID3D12Fence
+
+	ret, _, _ := syscall.Syscall6(
+		d.vtbl.OpenSharedHandle,
+		4,
+		uintptr(unsafe.Pointer(d)),
+		handle,
+		uintptr(unsafe.Pointer(&IID_ID3D12Fence)),
+		uintptr(unsafe.Pointer(&fence)),
+		0,
+		0,
+	)
+
+	if ret != 0 {
+		return nil, HRESULTError(ret)
+	}
+	return fence, nil
+}
+
 // GetDeviceRemovedReason returns the reason the device was removed.
 func (d *ID3D12Device) GetDeviceRemovedReason() error {
 	ret, _, _ := syscall.Syscall(
@@ -545,6 +590,22 @@ func (d *ID3D12Device) GetResourceAllocationInfo(visibleMask uint32, numResource
 	return info
 }
 
+// GetAdapterLuid returns the LUID of the adapter the device was created on.
+// Note: Same calling convention issue as GetCPUDescriptorHandleForHeapStart.
+func (d *ID3D12Device) GetAdapterLuid() LUID {
+	var luid LUID
+
+	_, _, _ = syscall.Syscall(
+		d.vtbl.GetAdapterLuid,
+		2,
+		uintptr(unsafe.Pointer(d)),     // this pointer first
+		uintptr(unsafe.Pointer(&luid)), // output pointer second
+		0,
+	)
+
+	return luid
+}
+
 // -----------------------------------------------------------------------------
 // ID3D12CommandQueue methods
 // -----------------------------------------------------------------------------
@@ -560,6 +621,17 @@ func (q *ID3D12CommandQueue) Release() uint32 {
 	return uint32(ret)
 }
 
+// AddRef increments the reference count.
+func (q *ID3D12CommandQueue) AddRef() uint32 {
+	ret, _, _ := syscall.Syscall(
+		q.vtbl.AddRef,
+		1,
+		uintptr(unsafe.Pointer(q)),
+		0, 0,
+	)
+	return uint32(ret)
+}
+
 // ExecuteCommandLists submits command lists for execution.
 func (q *ID3D12CommandQueue) ExecuteCommandLists(numCommandLists uint32, commandLists **ID3D12GraphicsCommandList) {
 	_, _, _ = syscall.Syscall(
@@ -1077,6 +1149,20 @@ func (c *ID3D12GraphicsCommandList) ExecuteIndirect(
 	)
 }
 
+// BeginQuery starts a query, such as an occlusion or pipeline statistics
+// query, that EndQuery completes.
+func (c *ID3D12GraphicsCommandList) BeginQuery(queryHeap *ID3D12QueryHeap, queryType D3D12_QUERY_TYPE, index uint32) {
+	_, _, _ = syscall.Syscall6(
+		c.vtbl.BeginQuery,
+		4,
+		uintptr(unsafe.Pointer(c)),
+		uintptr(unsafe.Pointer(queryHeap)),
+		uintptr(queryType),
+		uintptr(index),
+		0, 0,
+	)
+}
+
 // EndQuery ends a query (also used for timestamp writes).
 // DX12 uses EndQuery for timestamps — there is no begin/end pair for timestamp queries.
 // Rust wgpu-hal: command.rs write_timestamp uses EndQuery with D3D12_QUERY_TYPE_TIMESTAMP.
@@ -1116,6 +1202,21 @@ func (c *ID3D12GraphicsCommandList) ResolveQueryData(
 	)
 }
 
+// SetPredication makes subsequent rendering and resource manipulation
+// commands conditional on the 64-bit value at alignedBufferOffset in buffer.
+// A nil buffer disables predication.
+func (c *ID3D12GraphicsCommandList) SetPredication(buffer *ID3D12Resource, alignedBufferOffset uint64, operation D3D12_PREDICATION_OP) {
+	_, _, _ = syscall.Syscall6(
+		c.vtbl.SetPredication,
+		4,
+		uintptr(unsafe.Pointer(c)),
+		uintptr(unsafe.Pointer(buffer)),
+		uintptr(alignedBufferOffset),
+		uintptr(operation),
+		0, 0,
+	)
+}
+
 // -----------------------------------------------------------------------------
 // ID3D12Fence methods
 // -----------------------------------------------------------------------------
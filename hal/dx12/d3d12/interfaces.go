@@ -124,6 +124,23 @@ type id3d12DeviceVtbl struct {
 	GetAdapterLuid                   uintptr
 }
 
+// ID3D12Device2 extends ID3D12Device with pipeline state streams.
+type ID3D12Device2 struct {
+	vtbl *id3d12Device2Vtbl
+}
+
+type id3d12Device2Vtbl struct {
+	id3d12DeviceVtbl
+
+	// ID3D12Device1
+	CreatePipelineLibrary             uintptr
+	SetEventOnMultipleFenceCompletion uintptr
+	SetResidencyPriority              uintptr
+
+	// ID3D12Device2
+	CreatePipelineState uintptr
+}
+
 // ID3D12CommandQueue represents a command queue.
 type ID3D12CommandQueue struct {
 	vtbl *id3d12CommandQueueVtbl
@@ -0,0 +1,121 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package d3d12
+
+import (
+	"encoding/binary"
+	"syscall"
+	"unsafe"
+)
+
+// GraphicsPipelineStream converts desc into a pipeline state stream for
+// ID3D12Device2.CreatePipelineState, followed by a view instancing
+// subobject when viewInstancing is not nil. The stream holds desc's
+// pointers without keeping their targets alive; the caller must keep desc
+// and viewInstancing reachable until the pipeline is created.
+//
+// Each subobject is a D3D12_PIPELINE_STATE_SUBOBJECT_TYPE followed by its
+// value, aligned to a pointer as CD3DX12_PIPELINE_STATE_STREAM_SUBOBJECT
+// does. Stream and geometry-stage shaders are not used and are omitted.
+func GraphicsPipelineStream(desc *D3D12_GRAPHICS_PIPELINE_STATE_DESC, viewInstancing *D3D12_VIEW_INSTANCING_DESC) []uint64 {
+	var stream []byte
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_ROOT_SIGNATURE, &desc.RootSignature)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VS, &desc.VS)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_PS, &desc.PS)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_BLEND, &desc.BlendState)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_SAMPLE_MASK, &desc.SampleMask)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_RASTERIZER, &desc.RasterizerState)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_DEPTH_STENCIL, &desc.DepthStencilState)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_INPUT_LAYOUT, &desc.InputLayout)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_IB_STRIP_CUT_VALUE, &desc.IBStripCutValue)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_PRIMITIVE_TOPOLOGY, &desc.PrimitiveTopologyType)
+	rtFormats := D3D12_RT_FORMAT_ARRAY{RTFormats: desc.RTVFormats, NumRenderTargets: desc.NumRenderTargets}
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_RENDER_TARGET_FORMATS, &rtFormats)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_DEPTH_STENCIL_FORMAT, &desc.DSVFormat)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_SAMPLE_DESC, &desc.SampleDesc)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_NODE_MASK, &desc.NodeMask)
+	stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_FLAGS, &desc.Flags)
+	if viewInstancing != nil {
+		stream = appendSubobject(stream, D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VIEW_INSTANCING, viewInstancing)
+	}
+
+	// Copy into 8-byte words so the stream itself is pointer aligned.
+	words := make([]uint64, len(stream)/8)
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(words))), len(stream)), stream)
+	return words
+}
+
+// appendSubobject appends one pointer-aligned subobject holding *v.
+func appendSubobject[T any](stream []byte, typ D3D12_PIPELINE_STATE_SUBOBJECT_TYPE, v *T) []byte {
+	const ptrSize = unsafe.Sizeof(uintptr(0))
+	size := unsafe.Sizeof(*v)
+	start := alignUp(uintptr(len(stream)), ptrSize)
+	value := alignUp(start+4, unsafe.Alignof(*v))
+	end := alignUp(value+size, ptrSize)
+	stream = append(stream, make([]byte, end-uintptr(len(stream)))...)
+	binary.LittleEndian.PutUint32(stream[start:], uint32(typ))
+	copy(stream[value:], unsafe.Slice((*byte)(unsafe.Pointer(v)), size))
+	return stream
+}
+
+func alignUp(n, align uintptr) uintptr {
+	return (n + align - 1) &^ (align - 1)
+}
+
+// QueryDevice2 returns the ID3D12Device2 interface of the device. The
+// caller must Release it.
+func (d *ID3D12Device) QueryDevice2() (*ID3D12Device2, error) {
+	var device2 *ID3D12Device2
+
+	ret, _, _ := syscall.Syscall(
+		d.vtbl.QueryInterface,
+		3,
+		uintptr(unsafe.Pointer(d)),
+		uintptr(unsafe.Pointer(&IID_ID3D12Device2)),
+		uintptr(unsafe.Pointer(&device2)),
+	)
+
+	if ret != 0 {
+		return nil, HRESULTError(ret)
+	}
+	return device2, nil
+}
+
+// Release decrements the reference count.
+func (d *ID3D12Device2) Release() uint32 {
+	ret, _, _ := syscall.Syscall(
+		d.vtbl.Release,
+		1,
+		uintptr(unsafe.Pointer(d)),
+		0, 0,
+	)
+	return uint32(ret)
+}
+
+// CreatePipelineState creates a pipeline state from a pipeline state
+// stream, such as one built by GraphicsPipelineStream.
+func (d *ID3D12Device2) CreatePipelineState(stream []uint64) (*ID3D12PipelineState, error) {
+	var pso *ID3D12PipelineState
+	desc := D3D12_PIPELINE_STATE_STREAM_DESC{
+		SizeInBytes:                   uintptr(len(stream)) * 8,
+		PPipelineStateSubobjectStream: unsafe.Pointer(unsafe.SliceData(stream)),
+	}
+
+	ret, _, _ := syscall.Syscall6(
+		d.vtbl.CreatePipelineState,
+		4,
+		uintptr(unsafe.Pointer(d)),
+		uintptr(unsafe.Pointer(&desc)),
+		uintptr(unsafe.Pointer(&IID_ID3D12PipelineState)),
+		uintptr(unsafe.Pointer(&pso)),
+		0, 0,
+	)
+
+	if ret != 0 {
+		return nil, HRESULTError(ret)
+	}
+	return pso, nil
+}
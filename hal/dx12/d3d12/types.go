@@ -71,6 +71,13 @@ type D3D12_DEPTH_STENCIL_VALUE struct {
 	Stencil uint8
 }
 
+// LUID is a locally unique identifier, as returned by
+// ID3D12Device::GetAdapterLuid.
+type LUID struct {
+	LowPart  uint32
+	HighPart int32
+}
+
 // D3D12_RANGE describes a memory range.
 type D3D12_RANGE struct {
 	Begin uintptr
@@ -220,11 +227,14 @@ type D3D12_SHADER_RESOURCE_VIEW_DESC struct {
 }
 
 // D3D12_UNORDERED_ACCESS_VIEW_DESC describes an unordered access view.
+// In C, the union's largest member is D3D12_BUFFER_UAV (UINT64 FirstElement,
+// UINT NumElements, UINT StructureByteStride, UINT64 CounterOffsetInBytes,
+// UINT Flags), 32 bytes with padding, starting at offset 8.
 type D3D12_UNORDERED_ACCESS_VIEW_DESC struct {
 	Format        DXGI_FORMAT
 	ViewDimension D3D12_UAV_DIMENSION
 	// Union of different view types
-	Union [16]byte
+	Union [32]byte
 }
 
 // D3D12_RENDER_TARGET_VIEW_DESC describes a render target view.
@@ -636,6 +646,33 @@ type D3D12_FEATURE_DATA_D3D12_OPTIONS struct {
 	ResourceHeapTier                                                           uint32 // D3D12_RESOURCE_HEAP_TIER
 }
 
+// D3D12_FEATURE_DATA_D3D12_OPTIONS1 describes wave intrinsic support.
+type D3D12_FEATURE_DATA_D3D12_OPTIONS1 struct {
+	WaveOps                       int32 // BOOL
+	WaveLaneCountMin              uint32
+	WaveLaneCountMax              uint32
+	TotalLaneCount                uint32
+	ExpandedComputeResourceStates int32 // BOOL
+	Int64ShaderOps                int32 // BOOL
+}
+
+// D3D12_FEATURE_DATA_D3D12_OPTIONS3 describes view instancing and
+// barycentrics support.
+type D3D12_FEATURE_DATA_D3D12_OPTIONS3 struct {
+	CopyQueueTimestampQueriesSupported int32  // BOOL
+	CastingFullyTypedFormatSupported   int32  // BOOL
+	WriteBufferImmediateSupportFlags   uint32 // D3D12_COMMAND_LIST_SUPPORT_FLAGS
+	ViewInstancingTier                 D3D12_VIEW_INSTANCING_TIER
+	BarycentricsSupported              int32 // BOOL
+}
+
+// D3D12_FEATURE_DATA_D3D12_OPTIONS4 describes native 16-bit shader op support.
+type D3D12_FEATURE_DATA_D3D12_OPTIONS4 struct {
+	MSAA64KBAlignedTextureSupported int32  // BOOL
+	SharedResourceCompatibilityTier uint32 // D3D12_SHARED_RESOURCE_COMPATIBILITY_TIER
+	Native16BitShaderOpsSupported   int32  // BOOL
+}
+
 // D3D12_FEATURE_DATA_FEATURE_LEVELS describes feature levels.
 type D3D12_FEATURE_DATA_FEATURE_LEVELS struct {
 	NumFeatureLevels         uint32
@@ -651,6 +688,22 @@ type D3D12_FEATURE_DATA_ARCHITECTURE struct {
 	CacheCoherentUMA  int32
 }
 
+// D3D12_FEATURE_DATA_FORMAT_SUPPORT queries the support of a format.
+type D3D12_FEATURE_DATA_FORMAT_SUPPORT struct {
+	Format   DXGI_FORMAT
+	Support1 D3D12_FORMAT_SUPPORT1
+	Support2 uint32
+}
+
+// D3D12_FEATURE_DATA_MULTISAMPLE_QUALITY_LEVELS queries the quality levels
+// available for a format at a sample count.
+type D3D12_FEATURE_DATA_MULTISAMPLE_QUALITY_LEVELS struct {
+	Format           DXGI_FORMAT
+	SampleCount      uint32
+	Flags            uint32
+	NumQualityLevels uint32
+}
+
 // D3D12_RENDER_PASS_RENDER_TARGET_DESC describes a render pass render target.
 type D3D12_RENDER_PASS_RENDER_TARGET_DESC struct {
 	CPUDescriptor   D3D12_CPU_DESCRIPTOR_HANDLE
@@ -725,3 +778,30 @@ const (
 	D3D12_WRITEBUFFERIMMEDIATE_MODE_MARKER_IN  D3D12_WRITEBUFFERIMMEDIATE_MODE = 1
 	D3D12_WRITEBUFFERIMMEDIATE_MODE_MARKER_OUT D3D12_WRITEBUFFERIMMEDIATE_MODE = 2
 )
+
+// D3D12_PIPELINE_STATE_STREAM_DESC points at a pipeline state stream.
+type D3D12_PIPELINE_STATE_STREAM_DESC struct {
+	SizeInBytes                   uintptr
+	PPipelineStateSubobjectStream unsafe.Pointer
+}
+
+// D3D12_RT_FORMAT_ARRAY holds the render target formats of a pipeline
+// state stream.
+type D3D12_RT_FORMAT_ARRAY struct {
+	RTFormats        [8]DXGI_FORMAT
+	NumRenderTargets uint32
+}
+
+// D3D12_VIEW_INSTANCE_LOCATION maps a view instance to a viewport and a
+// render target array slice.
+type D3D12_VIEW_INSTANCE_LOCATION struct {
+	ViewportArrayIndex     uint32
+	RenderTargetArrayIndex uint32
+}
+
+// D3D12_VIEW_INSTANCING_DESC describes the view instances of a pipeline.
+type D3D12_VIEW_INSTANCING_DESC struct {
+	ViewInstanceCount      uint32
+	PViewInstanceLocations *D3D12_VIEW_INSTANCE_LOCATION
+	Flags                  D3D12_VIEW_INSTANCING_FLAGS
+}
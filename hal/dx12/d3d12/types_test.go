@@ -15,3 +15,41 @@ func TestIndirectArgumentDescABI(t *testing.T) {
 		t.Fatalf("D3D12_INDIRECT_ARGUMENT_DESC.Union offset = %d, want 4", got)
 	}
 }
+
+func TestUnorderedAccessViewDescABI(t *testing.T) {
+	if got := unsafe.Sizeof(D3D12_UNORDERED_ACCESS_VIEW_DESC{}); got != 40 {
+		t.Fatalf("D3D12_UNORDERED_ACCESS_VIEW_DESC size = %d, want 40", got)
+	}
+	if got := unsafe.Offsetof(D3D12_UNORDERED_ACCESS_VIEW_DESC{}.Union); got != 8 {
+		t.Fatalf("D3D12_UNORDERED_ACCESS_VIEW_DESC.Union offset = %d, want 8", got)
+	}
+}
+
+func TestGraphicsPipelineStreamLayout(t *testing.T) {
+	if got := unsafe.Sizeof(D3D12_VIEW_INSTANCING_DESC{}); got != 24 {
+		t.Fatalf("D3D12_VIEW_INSTANCING_DESC size = %d, want 24", got)
+	}
+	if got := unsafe.Sizeof(D3D12_BLEND_DESC{}); got != 328 {
+		t.Fatalf("D3D12_BLEND_DESC size = %d, want 328", got)
+	}
+	desc := D3D12_GRAPHICS_PIPELINE_STATE_DESC{SampleMask: 0xFFFFFFFF}
+	stream := GraphicsPipelineStream(&desc, &D3D12_VIEW_INSTANCING_DESC{ViewInstanceCount: 2})
+
+	// Root signature, VS and PS take 16 bytes each with their type; the
+	// 328-byte blend desc follows its 4-byte type and pads to 400.
+	if got := uint32(stream[16/8]); got != uint32(D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VS) {
+		t.Errorf("subobject at 16 = %d, want VS", got)
+	}
+	if got := stream[400/8]; got != uint64(0xFFFFFFFF)<<32|uint64(D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_SAMPLE_MASK) {
+		t.Errorf("sample mask subobject = %#x", got)
+	}
+	// The view instancing subobject comes last: type, then the 24-byte desc
+	// at the next pointer boundary.
+	n := len(stream)
+	if got := uint32(stream[n-4]); got != uint32(D3D12_PIPELINE_STATE_SUBOBJECT_TYPE_VIEW_INSTANCING) {
+		t.Errorf("last subobject type = %d, want VIEW_INSTANCING", got)
+	}
+	if got := uint32(stream[n-3]); got != 2 {
+		t.Errorf("ViewInstanceCount = %d, want 2", got)
+	}
+}
@@ -73,6 +73,41 @@ func (d *D3D12_SHADER_RESOURCE_VIEW_DESC) SetTextureCubeArray(mostDetailedMip, m
 	_ = resourceMinLODClamp // Extended union space
 }
 
+// -----------------------------------------------------------------------------
+// D3D12_UNORDERED_ACCESS_VIEW_DESC helpers
+// -----------------------------------------------------------------------------
+
+// SetTexture1D sets up a 1D texture UAV.
+func (d *D3D12_UNORDERED_ACCESS_VIEW_DESC) SetTexture1D(mipSlice uint32) {
+	d.ViewDimension = D3D12_UAV_DIMENSION_TEXTURE1D
+	binary.LittleEndian.PutUint32(d.Union[0:4], mipSlice)
+}
+
+// SetTexture2D sets up a 2D texture UAV.
+func (d *D3D12_UNORDERED_ACCESS_VIEW_DESC) SetTexture2D(mipSlice, planeSlice uint32) {
+	d.ViewDimension = D3D12_UAV_DIMENSION_TEXTURE2D
+	binary.LittleEndian.PutUint32(d.Union[0:4], mipSlice)
+	binary.LittleEndian.PutUint32(d.Union[4:8], planeSlice)
+}
+
+// SetTexture2DArray sets up a 2D texture array UAV.
+func (d *D3D12_UNORDERED_ACCESS_VIEW_DESC) SetTexture2DArray(mipSlice, firstArraySlice, arraySize, planeSlice uint32) {
+	d.ViewDimension = D3D12_UAV_DIMENSION_TEXTURE2DARRAY
+	binary.LittleEndian.PutUint32(d.Union[0:4], mipSlice)
+	binary.LittleEndian.PutUint32(d.Union[4:8], firstArraySlice)
+	binary.LittleEndian.PutUint32(d.Union[8:12], arraySize)
+	binary.LittleEndian.PutUint32(d.Union[12:16], planeSlice)
+}
+
+// SetTexture3D sets up a 3D texture UAV. wSize of 0xFFFFFFFF covers every
+// depth slice from firstWSlice.
+func (d *D3D12_UNORDERED_ACCESS_VIEW_DESC) SetTexture3D(mipSlice, firstWSlice, wSize uint32) {
+	d.ViewDimension = D3D12_UAV_DIMENSION_TEXTURE3D
+	binary.LittleEndian.PutUint32(d.Union[0:4], mipSlice)
+	binary.LittleEndian.PutUint32(d.Union[4:8], firstWSlice)
+	binary.LittleEndian.PutUint32(d.Union[8:12], wSize)
+}
+
 // -----------------------------------------------------------------------------
 // D3D12_RENDER_TARGET_VIEW_DESC helpers
 // -----------------------------------------------------------------------------
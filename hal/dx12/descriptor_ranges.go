@@ -0,0 +1,192 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// descriptorRanges allocates contiguous descriptor ranges in a
+// shader-visible heap. Free space is kept as sorted, coalesced segments, so
+// a destroyed bind group's range can be reused by a bind group of any size
+// instead of the heap growing until it overflows.
+//
+// A range freed while submitted command lists may still read it is retired
+// with the fence value of the last submission and returns to the free
+// segments once the GPU completes that value. Matches the role of Rust
+// wgpu-hal's range-allocated GeneralHeap (dx12/descriptor.rs), with the
+// fencing wgpu-core's lifetime tracker would otherwise provide.
+type descriptorRanges struct {
+	free    []descriptorRange // sorted by start; neighbors never touch
+	retired []retiredRange    // in increasing fence order
+}
+
+// descriptorRange is count descriptors starting at heap index start.
+type descriptorRange struct {
+	start, count uint32
+}
+
+// retiredRange is a freed range the GPU may read until fence completes.
+type retiredRange struct {
+	descriptorRange
+	fence uint64
+}
+
+func newDescriptorRanges(capacity uint32) *descriptorRanges {
+	r := &descriptorRanges{}
+	if capacity > 0 {
+		r.free = []descriptorRange{{start: 0, count: capacity}}
+	}
+	return r
+}
+
+// allocate takes count descriptors from the first segment large enough.
+func (r *descriptorRanges) allocate(count uint32) (uint32, bool) {
+	for i, seg := range r.free {
+		if seg.count < count {
+			continue
+		}
+		if seg.count == count {
+			r.free = append(r.free[:i], r.free[i+1:]...)
+		} else {
+			r.free[i] = descriptorRange{start: seg.start + count, count: seg.count - count}
+		}
+		return seg.start, true
+	}
+	return 0, false
+}
+
+// release returns a range to the free segments, merging it with its
+// neighbors.
+func (r *descriptorRanges) release(start, count uint32) {
+	if count == 0 {
+		return
+	}
+	i := 0
+	for i < len(r.free) && r.free[i].start < start {
+		i++
+	}
+	mergePrev := i > 0 && r.free[i-1].start+r.free[i-1].count == start
+	mergeNext := i < len(r.free) && start+count == r.free[i].start
+	switch {
+	case mergePrev && mergeNext:
+		r.free[i-1].count += count + r.free[i].count
+		r.free = append(r.free[:i], r.free[i+1:]...)
+	case mergePrev:
+		r.free[i-1].count += count
+	case mergeNext:
+		r.free[i].start = start
+		r.free[i].count += count
+	default:
+		r.free = append(r.free, descriptorRange{})
+		copy(r.free[i+1:], r.free[i:])
+		r.free[i] = descriptorRange{start: start, count: count}
+	}
+}
+
+// retire holds a range back until the GPU completes fence.
+func (r *descriptorRanges) retire(start, count uint32, fence uint64) {
+	if count == 0 {
+		return
+	}
+	r.retired = append(r.retired, retiredRange{descriptorRange{start, count}, fence})
+}
+
+// reclaim releases every retired range whose fence has completed and
+// returns how many descriptors it freed.
+func (r *descriptorRanges) reclaim(completed uint64) uint32 {
+	var freed uint32
+	n := 0
+	for n < len(r.retired) && r.retired[n].fence <= completed {
+		freed += r.retired[n].count
+		r.release(r.retired[n].start, r.retired[n].count)
+		n++
+	}
+	r.retired = append(r.retired[:0], r.retired[n:]...)
+	return freed
+}
+
+// freeCount returns the number of free, not retired, descriptors.
+func (r *descriptorRanges) freeCount() uint32 {
+	var n uint32
+	for _, seg := range r.free {
+		n += seg.count
+	}
+	return n
+}
+
+// allocateRangeLocked allocates count descriptors from a shader-visible
+// heap, first reclaiming retired ranges the GPU has finished with if the
+// free segments fall short. h.mu must be held.
+func (h *DescriptorHeap) allocateRangeLocked(count uint32) (uint32, error) {
+	if start, ok := h.ranges.allocate(count); ok {
+		return start, nil
+	}
+	if len(h.ranges.retired) > 0 && h.fenceValues != nil {
+		_, completed := h.fenceValues()
+		if h.ranges.reclaim(completed) > 0 {
+			if start, ok := h.ranges.allocate(count); ok {
+				return start, nil
+			}
+		}
+	}
+
+	free := h.ranges.freeCount()
+	hal.Logger().Error("dx12: descriptor heap exhausted",
+		"heapType", h.heapType,
+		"capacity", h.capacity,
+		"free", free,
+		"segments", len(h.ranges.free),
+		"retired", len(h.ranges.retired),
+		"requested", count,
+	)
+	return 0, fmt.Errorf("dx12: descriptor heap exhausted (capacity=%d, free=%d in %d segments, requested=%d)",
+		h.capacity, free, len(h.ranges.free), count)
+}
+
+// retireRangeLocked frees a range of a shader-visible heap, holding it
+// back while submitted work may still read it. h.mu must be held.
+func (h *DescriptorHeap) retireRangeLocked(start, count uint32) {
+	if h.fenceValues == nil {
+		h.ranges.release(start, count)
+		return
+	}
+	signaled, completed := h.fenceValues()
+	if signaled <= completed {
+		h.ranges.release(start, count)
+		return
+	}
+	h.ranges.retire(start, count, signaled)
+}
+
+// Reclaim returns retired ranges of a shader-visible heap whose fence value
+// is at most completed to the free segments. It is a no-op for other heaps.
+func (h *DescriptorHeap) Reclaim(completed uint64) {
+	if h == nil || h.ranges == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ranges.reclaim(completed)
+}
+
+// descriptorFenceValues returns the last signaled and the completed values
+// of the device fence, which order descriptor range reuse.
+func (d *Device) descriptorFenceValues() (signaled, completed uint64) {
+	if d.fence == nil {
+		return 0, 0
+	}
+	return d.currentFrameFenceValue(), d.completedFrameFenceValue()
+}
+
+// reclaimDescriptors recycles shader-visible descriptor ranges whose last
+// possible use completed by the given fence value.
+func (d *Device) reclaimDescriptors(completed uint64) {
+	d.viewHeap.Reclaim(completed)
+	d.samplerHeap.Reclaim(completed)
+}
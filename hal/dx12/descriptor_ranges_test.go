@@ -0,0 +1,119 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal/dx12/d3d12"
+)
+
+func TestDescriptorRangesCoalesce(t *testing.T) {
+	r := newDescriptorRanges(16)
+	var starts []uint32
+	for _, n := range []uint32{4, 4, 4, 4} {
+		start, ok := r.allocate(n)
+		if !ok {
+			t.Fatalf("allocate(%d) failed", n)
+		}
+		starts = append(starts, start)
+	}
+	if !slices.Equal(starts, []uint32{0, 4, 8, 12}) {
+		t.Fatalf("starts = %v, want [0 4 8 12]", starts)
+	}
+	if _, ok := r.allocate(1); ok {
+		t.Fatal("allocate from a full heap succeeded")
+	}
+
+	// Freeing 0-3 and 8-11 leaves two segments too small for 8 descriptors;
+	// freeing 4-7 between them merges all three.
+	r.release(0, 4)
+	r.release(8, 4)
+	if _, ok := r.allocate(8); ok {
+		t.Fatal("allocate(8) succeeded across a used range")
+	}
+	r.release(4, 4)
+	if want := []descriptorRange{{0, 12}}; !slices.Equal(r.free, want) {
+		t.Fatalf("free = %v, want %v", r.free, want)
+	}
+	if start, ok := r.allocate(8); !ok || start != 0 {
+		t.Fatalf("allocate(8) = %d, %v; want 0, true", start, ok)
+	}
+	r.release(12, 4)
+	if want := []descriptorRange{{8, 8}}; !slices.Equal(r.free, want) {
+		t.Fatalf("free = %v, want %v", r.free, want)
+	}
+}
+
+func TestDescriptorRangesReclaim(t *testing.T) {
+	r := newDescriptorRanges(8)
+	a, _ := r.allocate(4)
+	b, _ := r.allocate(4)
+	r.retire(a, 4, 1)
+	r.retire(b, 4, 2)
+
+	if _, ok := r.allocate(1); ok {
+		t.Fatal("retired range was reused before its fence completed")
+	}
+	if freed := r.reclaim(1); freed != 4 {
+		t.Fatalf("reclaim(1) freed %d, want 4", freed)
+	}
+	if len(r.retired) != 1 || r.freeCount() != 4 {
+		t.Fatalf("after reclaim(1): retired %d, free %d; want 1, 4", len(r.retired), r.freeCount())
+	}
+	r.reclaim(5)
+	if want := []descriptorRange{{0, 8}}; len(r.retired) != 0 || !slices.Equal(r.free, want) {
+		t.Fatalf("after reclaim(5): retired %v, free %v", r.retired, r.free)
+	}
+}
+
+func TestShaderVisibleHeapRetiresFreedRanges(t *testing.T) {
+	signaled, completed := uint64(3), uint64(2)
+	heap := &DescriptorHeap{
+		cpuStart:      d3d12.D3D12_CPU_DESCRIPTOR_HANDLE{Ptr: 0x1000},
+		gpuStart:      d3d12.D3D12_GPU_DESCRIPTOR_HANDLE{Ptr: 0x2000},
+		incrementSize: 32,
+		capacity:      8,
+		ranges:        newDescriptorRanges(8),
+		fenceValues:   func() (uint64, uint64) { return signaled, completed },
+	}
+
+	cpu, gpu, err := heap.AllocateGPU(8)
+	if err != nil || cpu.Ptr != 0x1000 || gpu.Ptr != 0x2000 {
+		t.Fatalf("AllocateGPU(8) = %#x, %#x, %v", cpu.Ptr, gpu.Ptr, err)
+	}
+
+	// Submission 3 may still read the range.
+	heap.Free(0, 8)
+	if _, _, err := heap.AllocateGPU(2); err == nil {
+		t.Fatal("range reused while its submission was in flight")
+	}
+
+	// Allocation reclaims it once the fence passes.
+	completed = 3
+	cpu, gpu, err = heap.AllocateGPU(2)
+	if err != nil || cpu.Ptr != 0x1000 || gpu.Ptr != 0x2000 {
+		t.Fatalf("AllocateGPU(2) after completion = %#x, %#x, %v", cpu.Ptr, gpu.Ptr, err)
+	}
+
+	// With no work in flight a freed range is reusable at once.
+	heap.Free(0, 2)
+	if cpu, err := heap.Allocate(8); err != nil || cpu.Ptr != 0x1000 {
+		t.Fatalf("Allocate(8) when idle = %#x, %v", cpu.Ptr, err)
+	}
+}
+
+func TestDescriptorHeapReclaimIgnoresLinearHeaps(t *testing.T) {
+	heap := &DescriptorHeap{capacity: 4}
+	heap.Free(1, 1)
+	heap.Reclaim(10)
+	if len(heap.freeList) != 1 {
+		t.Fatalf("free list = %v, want [1]", heap.freeList)
+	}
+	var nilHeap *DescriptorHeap
+	nilHeap.Reclaim(10)
+}
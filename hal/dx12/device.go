@@ -23,6 +23,8 @@ import (
 	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/dx12/d3d12"
 	"github.com/gogpu/wgpu/hal/dx12/d3dcompile"
+	"github.com/gogpu/wgpu/hal/dx12/dxc"
+	"github.com/gogpu/wgpu/hal/dx12/dxgi"
 	"golang.org/x/sys/windows"
 )
 
@@ -50,6 +52,10 @@ type Device struct {
 	raw      *d3d12.ID3D12Device
 	instance *Instance
 
+	// adapter is a reference to the DXGI adapter the device was opened on,
+	// for MemoryBudget. It is nil for adopted and legacy-adapter devices.
+	adapter *dxgi.IDXGIAdapter4
+
 	// Command queue for graphics/compute operations.
 	directQueue *d3d12.ID3D12CommandQueue
 	queueState  *queueState // shared noncyclic lifetime/preamble owner
@@ -110,6 +116,18 @@ type Device struct {
 	// pipeline creation. Opt-in via GOGPU_DX12_DXIL_VALIDATE=1.
 	dxilValidate bool
 
+	// dxc compiles naga HLSL to SM 6 DXIL instead of FXC when the adapter
+	// supports SM 6.0 and dxcompiler.dll loads; nil selects FXC. See loadDXC.
+	dxc *dxc.Lib
+
+	// dxcShaderModel is the shader model naga HLSL and DXC target profiles
+	// use on the DXC path: the adapter's highest supported model.
+	dxcShaderModel hlsl.ShaderModel
+
+	// dxcArgs holds extra DXC arguments, e.g. -enable-16bit-types when the
+	// adapter runs native 16-bit shader ops.
+	dxcArgs []string
+
 	// Pre-created command signatures for indirect draw/dispatch.
 	// DX12 ExecuteIndirect requires an ID3D12CommandSignature that describes
 	// the indirect argument layout. These are created once at device init
@@ -131,6 +149,10 @@ type commandSignatures struct {
 // Supports descriptor recycling via a free list — freed indices are reused
 // before bumping the linear allocator. This prevents heap exhaustion during
 // operations like swapchain resize that repeatedly allocate/free RTVs.
+//
+// Shader-visible heaps instead allocate from coalesced free ranges and hold
+// freed ranges back until the GPU has finished with them; see
+// descriptorRanges.
 type DescriptorHeap struct {
 	raw           *d3d12.ID3D12DescriptorHeap
 	heapType      d3d12.D3D12_DESCRIPTOR_HEAP_TYPE
@@ -141,6 +163,12 @@ type DescriptorHeap struct {
 	nextFree      uint32
 	freeList      []uint32 // Recycled descriptor indices (LIFO stack)
 	mu            sync.Mutex
+
+	// ranges is set for shader-visible heaps and replaces nextFree and
+	// freeList. fenceValues reports the device's last signaled and
+	// completed fence values; nil frees ranges immediately.
+	ranges      *descriptorRanges
+	fenceValues func() (signaled, completed uint64)
 }
 
 // Allocate allocates descriptors from the heap.
@@ -150,6 +178,14 @@ func (h *DescriptorHeap) Allocate(count uint32) (d3d12.D3D12_CPU_DESCRIPTOR_HAND
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if h.ranges != nil {
+		start, err := h.allocateRangeLocked(count)
+		if err != nil {
+			return d3d12.D3D12_CPU_DESCRIPTOR_HANDLE{}, err
+		}
+		return h.cpuStart.Offset(int(start), h.incrementSize), nil
+	}
+
 	// Recycle from free list for single-descriptor allocations
 	if count == 1 && len(h.freeList) > 0 {
 		idx := h.freeList[len(h.freeList)-1]
@@ -181,6 +217,14 @@ func (h *DescriptorHeap) AllocateGPU(count uint32) (d3d12.D3D12_CPU_DESCRIPTOR_H
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if h.ranges != nil {
+		start, err := h.allocateRangeLocked(count)
+		if err != nil {
+			return d3d12.D3D12_CPU_DESCRIPTOR_HANDLE{}, d3d12.D3D12_GPU_DESCRIPTOR_HANDLE{}, err
+		}
+		return h.cpuStart.Offset(int(start), h.incrementSize), h.gpuStart.Offset(int(start), h.incrementSize), nil
+	}
+
 	// Recycle from free list for single-descriptor allocations
 	if count == 1 && len(h.freeList) > 0 {
 		idx := h.freeList[len(h.freeList)-1]
@@ -210,11 +254,17 @@ func (h *DescriptorHeap) AllocateGPU(count uint32) (d3d12.D3D12_CPU_DESCRIPTOR_H
 }
 
 // Free returns descriptor indices to the free list for reuse.
-// The descriptors must no longer be referenced by any in-flight GPU work.
+// The descriptors must no longer be referenced by any in-flight GPU work,
+// except in shader-visible heaps, which retire them until it completes.
 func (h *DescriptorHeap) Free(baseIndex, count uint32) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if h.ranges != nil {
+		h.retireRangeLocked(baseIndex, count)
+		return
+	}
+
 	for i := uint32(0); i < count; i++ {
 		h.freeList = append(h.freeList, baseIndex+i)
 	}
@@ -225,25 +275,45 @@ func (h *DescriptorHeap) HandleToIndex(handle d3d12.D3D12_CPU_DESCRIPTOR_HANDLE)
 	return uint32((handle.Ptr - h.cpuStart.Ptr) / uintptr(h.incrementSize))
 }
 
+// logger returns the owning instance's logger for category c. It is safe
+// to call on a Device built without an instance, as unit tests do.
+func (d *Device) logger(c hal.LogCategory) *slog.Logger {
+	if d == nil {
+		return hal.CategoryLogger(c)
+	}
+	return d.instance.logger(c)
+}
+
 // newDevice creates a new DX12 device from a DXGI adapter.
 // adapterPtr is the IUnknown pointer to the DXGI adapter.
-func newDevice(instance *Instance, adapterPtr unsafe.Pointer, featureLevel d3d12.D3D_FEATURE_LEVEL) (*Device, error) {
+func newDevice(instance *Instance, adapterPtr unsafe.Pointer, caps *AdapterCapabilities) (*Device, error) {
 	// Create D3D12 device
-	rawDevice, err := instance.d3d12Lib.CreateDevice(adapterPtr, featureLevel)
+	rawDevice, err := instance.d3d12Lib.CreateDevice(adapterPtr, caps.FeatureLevel)
 	if err != nil {
 		return nil, fmt.Errorf("dx12: D3D12CreateDevice failed: %w", err)
 	}
+	return initDevice(instance, rawDevice, nil, caps)
+}
+
+// initDevice sets up a device on rawDevice, taking over the caller's
+// reference to it. Work is submitted to queue, whose reference it also takes
+// over, or to a new direct queue when queue is nil.
+func initDevice(instance *Instance, rawDevice *d3d12.ID3D12Device, queue *d3d12.ID3D12CommandQueue, caps *AdapterCapabilities) (*Device, error) {
+	featureLevel := caps.FeatureLevel
 
 	dev := &Device{
 		raw:          rawDevice,
 		instance:     instance,
 		featureLevel: featureLevel,
+		directQueue:  queue,
 	}
 
 	// Create the direct (graphics) command queue
-	if err := dev.createCommandQueue(); err != nil {
-		rawDevice.Release()
-		return nil, err
+	if queue == nil {
+		if err := dev.createCommandQueue(); err != nil {
+			rawDevice.Release()
+			return nil, err
+		}
 	}
 
 	// Create descriptor heaps
@@ -270,9 +340,23 @@ func newDevice(instance *Instance, adapterPtr unsafe.Pointer, featureLevel d3d12
 
 	// Enable DXIL direct compilation if requested via environment variable.
 	// DXIL path uses naga dxil backend (SM 6.0, BYPASS hash) instead of HLSL->FXC.
-	dev.useDXIL = os.Getenv("GOGPU_DX12_DXIL") == "1"
+	dev.useDXIL = dxilRequested()
 	dev.dxilValidate = os.Getenv("GOGPU_DX12_DXIL_VALIDATE") == "1"
 
+	// Otherwise prefer HLSL→DXC (SM 6) and fall back to HLSL→FXC (SM 5.1)
+	// when dxcompiler.dll is missing or the adapter lacks SM 6.0.
+	dxcLib, dxcErr := loadDXC(caps.ShaderModel)
+	if dxcErr != nil {
+		dev.logger(hal.LogShader).Info("dx12: DXC unavailable, using FXC", "reason", dxcErr)
+	}
+	if dxcLib != nil {
+		dev.dxc = dxcLib
+		dev.dxcShaderModel = hlslShaderModel(caps.ShaderModel)
+		if caps.Native16BitShaderOps && dev.dxcShaderModel >= hlsl.ShaderModel6_2 {
+			dev.dxcArgs = []string{dxc.ArgEnable16BitTypes}
+		}
+	}
+
 	// Create pre-built command signatures for indirect draw/dispatch.
 	// DX12 ExecuteIndirect requires an ID3D12CommandSignature that describes
 	// the indirect argument layout. Created once, shared across all encoders.
@@ -290,6 +374,12 @@ func newDevice(instance *Instance, adapterPtr unsafe.Pointer, featureLevel d3d12
 			"featureLevel", fmt.Sprintf("0x%x", featureLevel),
 			"debugLayer", instance.flags&gputypes.InstanceFlagsDebug != 0,
 		)
+	} else if dev.dxc != nil {
+		hal.Logger().Info("dx12: device created (HLSL→DXC compilation)",
+			"featureLevel", fmt.Sprintf("0x%x", featureLevel),
+			"shaderModel", dev.dxcShaderModel.String(),
+			"debugLayer", instance.flags&gputypes.InstanceFlagsDebug != 0,
+		)
 	} else {
 		hal.Logger().Info("dx12: device created (HLSL→FXC compilation)",
 			"featureLevel", fmt.Sprintf("0x%x", featureLevel),
@@ -422,6 +512,8 @@ func (d *Device) createHeap(heapType d3d12.D3D12_DESCRIPTOR_HEAP_TYPE, numDescri
 
 	if shaderVisible {
 		heap.gpuStart = rawHeap.GetGPUDescriptorHandleForHeapStart()
+		heap.ranges = newDescriptorRanges(numDescriptors)
+		heap.fenceValues = d.descriptorFenceValues
 	}
 
 	return heap, nil
@@ -890,6 +982,11 @@ func (d *Device) cleanup() {
 		d.infoQueue = nil
 	}
 
+	if d.adapter != nil {
+		d.adapter.Release()
+		d.adapter = nil
+	}
+
 	if d.emptyRootSignature != nil {
 		d.emptyRootSignature.Release()
 		d.emptyRootSignature = nil
@@ -1267,6 +1364,16 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 			createFormat = dxgiFormat
 		}
 	}
+	// Views in the sRGB counterpart need the typeless family; each view
+	// then names its typed format.
+	for _, viewFormat := range desc.ViewFormats {
+		if viewFormat != desc.Format {
+			if typeless := srgbFamilyTypeless(desc.Format); typeless != d3d12.DXGI_FORMAT_UNKNOWN {
+				createFormat = typeless
+			}
+			break
+		}
+	}
 
 	// Use optimal texture layout for all dimensions - let driver choose
 	layout := d3d12.D3D12_TEXTURE_LAYOUT_UNKNOWN
@@ -1374,6 +1481,48 @@ func (d *Device) DestroyTexture(texture hal.Texture) {
 	}
 }
 
+// ImportTexture wraps an ID3D12Resource another library created on this
+// device, taking a reference of its own. Every subresource is tracked from
+// the state currentUsage maps to, so the first barrier transitions out of
+// the state the owner left the texture in.
+func (d *Device) ImportTexture(handle uintptr, currentUsage gputypes.TextureUsage, desc *hal.TextureDescriptor) (hal.Texture, error) {
+	if handle == 0 {
+		return nil, fmt.Errorf("dx12: native texture is nil")
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("BUG: texture descriptor is nil in DX12.ImportTexture — core validation gap")
+	}
+	resource := (*d3d12.ID3D12Resource)(unsafe.Pointer(handle)) //nolint:govet // COM pointer from the owner
+	if got := resource.GetDesc(); got.Width != uint64(desc.Size.Width) || got.Height != desc.Size.Height {
+		return nil, fmt.Errorf("dx12: native texture is %dx%d, descriptor says %dx%d",
+			got.Width, got.Height, desc.Size.Width, desc.Size.Height)
+	}
+	resource.AddRef()
+
+	state := textureUsageToD3D12State(currentUsage)
+	tex := &Texture{
+		raw:       resource,
+		format:    desc.Format,
+		dimension: desc.Dimension,
+		size: hal.Extent3D{
+			Width:              desc.Size.Width,
+			Height:             desc.Size.Height,
+			DepthOrArrayLayers: max(desc.Size.DepthOrArrayLayers, 1),
+		},
+		mipLevels:    max(desc.MipLevelCount, 1),
+		samples:      max(desc.SampleCount, 1),
+		usage:        desc.Usage,
+		device:       d,
+		currentState: state,
+	}
+	textureStates := make([]d3d12.D3D12_RESOURCE_STATES, tex.subresourceCount())
+	for i := range textureStates {
+		textureStates[i] = state
+	}
+	tex.stateOwner.setTextureStates(textureStates)
+	return tex, nil
+}
+
 // CreateTextureView creates a view into a texture.
 //
 //nolint:maintidx // inherent D3D12 complexity: one WebGPU view → RTV + DSV + SRV descriptors
@@ -1424,10 +1573,15 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		return nil, fmt.Errorf("dx12: texture is not a DX12 texture")
 	}
 
-	// Determine view format
+	// Determine view format. A plane view of a multi-planar texture uses
+	// the plane's format.
 	viewFormat := tex.format
 	if desc != nil && desc.Format != gputypes.TextureFormatUndefined {
 		viewFormat = desc.Format
+	} else if desc != nil {
+		if plane, ok := hal.PlaneFormat(tex.format, desc.Aspect); ok {
+			viewFormat = plane
+		}
 	}
 
 	// Determine view dimension
@@ -1435,12 +1589,16 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 	if desc != nil && desc.Dimension != gputypes.TextureViewDimensionUndefined {
 		viewDim = desc.Dimension
 	} else {
-		// Infer from texture dimension
+		// Infer from texture dimension. A layered 2D texture defaults to a
+		// 2D array view unless the view selects a single layer.
 		switch tex.dimension {
 		case gputypes.TextureDimension1D:
 			viewDim = gputypes.TextureViewDimension1D
 		case gputypes.TextureDimension2D:
 			viewDim = gputypes.TextureViewDimension2D
+			if tex.size.DepthOrArrayLayers > 1 && (desc == nil || desc.ArrayLayerCount != 1) {
+				viewDim = gputypes.TextureViewDimension2DArray
+			}
 		case gputypes.TextureDimension3D:
 			viewDim = gputypes.TextureViewDimension3D
 		}
@@ -1467,6 +1625,9 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 			layerCount = desc.ArrayLayerCount
 		} else {
 			layerCount = tex.size.DepthOrArrayLayers - baseLayer
+			if viewDim == gputypes.TextureViewDimensionCube {
+				layerCount = min(layerCount, 6)
+			}
 		}
 	}
 
@@ -1621,9 +1782,15 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		case gputypes.TextureViewDimension2DArray:
 			srvDesc.SetTexture2DArray(baseMip, mipCount, baseLayer, layerCount, srvPlane, 0)
 		case gputypes.TextureViewDimensionCube:
-			srvDesc.SetTextureCube(baseMip, mipCount, 0)
+			// TEXTURECUBE always starts at face 0; a cube further into the
+			// array is a one-element cube array starting at baseLayer.
+			if baseLayer == 0 {
+				srvDesc.SetTextureCube(baseMip, mipCount, 0)
+			} else {
+				srvDesc.SetTextureCubeArray(baseMip, mipCount, baseLayer, 1, 0)
+			}
 		case gputypes.TextureViewDimensionCubeArray:
-			srvDesc.SetTextureCubeArray(baseMip, mipCount, baseLayer/6, layerCount/6, 0)
+			srvDesc.SetTextureCubeArray(baseMip, mipCount, baseLayer, layerCount/6, 0)
 		case gputypes.TextureViewDimension3D:
 			srvDesc.SetTexture3D(baseMip, mipCount, 0)
 		}
@@ -1634,6 +1801,34 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		view.hasSRV = true
 	}
 
+	// Create UAV if texture supports storage binding. Storage views address
+	// a single mip level.
+	if tex.usage&gputypes.TextureUsageStorageBinding != 0 {
+		uavHandle, uavIndex, err := d.allocateSRVDescriptor()
+		if err != nil {
+			return failTextureViewCreation(view, fmt.Errorf("dx12: failed to allocate UAV descriptor: %w", err))
+		}
+
+		uavDesc := d3d12.D3D12_UNORDERED_ACCESS_VIEW_DESC{
+			Format: textureFormatToD3D12(viewFormat),
+		}
+		switch viewDim {
+		case gputypes.TextureViewDimension1D:
+			uavDesc.SetTexture1D(baseMip)
+		case gputypes.TextureViewDimension2DArray, gputypes.TextureViewDimensionCube, gputypes.TextureViewDimensionCubeArray:
+			uavDesc.SetTexture2DArray(baseMip, baseLayer, layerCount, 0)
+		case gputypes.TextureViewDimension3D:
+			uavDesc.SetTexture3D(baseMip, 0, 0xFFFFFFFF)
+		default:
+			uavDesc.SetTexture2D(baseMip, 0)
+		}
+
+		d.raw.CreateUnorderedAccessView(tex.raw, nil, &uavDesc, uavHandle)
+		view.uavHandle = uavHandle
+		view.uavHeapIndex = uavIndex
+		view.hasUAV = true
+	}
+
 	return view, nil
 }
 
@@ -1685,7 +1880,7 @@ func (d *Device) CreateSampler(desc *hal.SamplerDescriptor) (hal.Sampler, error)
 		AddressU:       addressModeToD3D12(desc.AddressModeU),
 		AddressV:       addressModeToD3D12(desc.AddressModeV),
 		AddressW:       addressModeToD3D12(desc.AddressModeW),
-		MipLODBias:     0,
+		MipLODBias:     desc.LodBias,
 		MaxAnisotropy:  uint32(desc.Anisotropy),
 		ComparisonFunc: compareFunctionToD3D12(desc.Compare),
 		BorderColor:    [4]float32{0, 0, 0, 0},
@@ -1886,7 +2081,7 @@ func (d *Device) populateBindGroupDescriptors(bg *BindGroup, totalViewDescs uint
 	bg.viewCount = totalViewDescs
 
 	if len(viewEntries) > 0 {
-		if err := d.writeViewDescriptorsBatched(cpuStart, viewEntries); err != nil {
+		if err := d.writeViewDescriptorsBatched(cpuStart, bg.layout, viewEntries); err != nil {
 			return err
 		}
 	}
@@ -1979,7 +2174,7 @@ func (d *Device) createBufferSRV(resource *d3d12.ID3D12Resource, numElements, st
 // writeViewDescriptorsBatched writes CBV/SRV/UAV descriptors for all view entries.
 // CBVs are created inline (cannot be batched), while SRV copies from scattered
 // source handles are batched into a single CopyDescriptors call.
-func (d *Device) writeViewDescriptorsBatched(cpuStart d3d12.D3D12_CPU_DESCRIPTOR_HANDLE, entries []gputypes.BindGroupEntry) error {
+func (d *Device) writeViewDescriptorsBatched(cpuStart d3d12.D3D12_CPU_DESCRIPTOR_HANDLE, layout *BindGroupLayout, entries []gputypes.BindGroupEntry) error {
 	// Collect SRV copy sources for batching.
 	// destHandles[i] = destination in GPU-visible heap, srcHandles[i] = source from staging heap.
 	var srvDestHandles []d3d12.D3D12_CPU_DESCRIPTOR_HANDLE
@@ -2004,6 +2199,14 @@ func (d *Device) writeViewDescriptorsBatched(cpuStart d3d12.D3D12_CPU_DESCRIPTOR
 
 		case gputypes.TextureViewBinding:
 			view := (*TextureView)(unsafe.Pointer(res.TextureView)) //nolint:govet // intentional: HAL handle → concrete type
+			if layout != nil && layout.bindingType(entry.Binding) == BindingTypeStorageTexture {
+				if !view.hasUAV {
+					return fmt.Errorf("dx12: texture view has no UAV for storage binding %d", entry.Binding)
+				}
+				srvDestHandles = append(srvDestHandles, dest)
+				srvSrcHandles = append(srvSrcHandles, view.uavHandle)
+				continue
+			}
 			if !view.hasSRV {
 				return fmt.Errorf("dx12: texture view has no SRV for binding %d", entry.Binding)
 			}
@@ -2108,7 +2311,7 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 	case desc.Source.WGSL != "":
 		// Store raw WGSL for deferred compilation during pipeline creation.
 		module.wgslSource = desc.Source.WGSL
-		hal.Logger().Debug("dx12: shader module created (deferred compilation)",
+		d.logger(hal.LogShader).Debug("dx12: shader module created (deferred compilation)",
 			"source", "WGSL",
 		)
 	case len(desc.Source.SPIRV) > 0:
@@ -2128,11 +2331,79 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 	if err := d.checkHealth("CreateShaderModule(" + desc.Label + ")"); err != nil {
 		return nil, err
 	}
+	d.compileHinted(module, desc)
 	return module, nil
 }
 
+// compileHinted performs the deferred WGSL compilation of a module now when
+// a compilation hint gives the pipeline layout it will be used with, so the
+// first pipeline does not pay for it. Compilation errors are left for
+// pipeline creation to report.
+func (d *Device) compileHinted(module *ShaderModule, desc *hal.ShaderModuleDescriptor) {
+	if !module.IsDeferred() {
+		return
+	}
+	for _, hint := range desc.CompilationHints {
+		layout, ok := hint.Layout.(*PipelineLayout)
+		if !ok || layout == nil || layout.nagaOptions == nil {
+			continue
+		}
+		start := time.Now()
+		if err := d.ensureShaderCompiled(module, layout); err != nil {
+			// Drop partial results so pipeline creation compiles again.
+			clear(module.entryPoints)
+			d.logger(hal.LogShader).Debug("dx12: hinted shader compilation failed",
+				"label", desc.Label, "entryPoint", hint.EntryPoint, "err", err)
+			return
+		}
+		d.logger(hal.LogShader).Debug("dx12: shader module compiled from hint",
+			"label", desc.Label,
+			"entryPoint", hint.EntryPoint,
+			"elapsed", time.Since(start),
+		)
+		return
+	}
+}
+
+// dxilRequested reports whether GOGPU_DX12_DXIL=1 selects direct DXIL
+// compilation instead of HLSL→FXC.
+func dxilRequested() bool {
+	return os.Getenv("GOGPU_DX12_DXIL") == "1"
+}
+
+// fxcRequested reports whether GOGPU_DX12_FXC=1 forces HLSL→FXC even when
+// DXC is available.
+func fxcRequested() bool {
+	return os.Getenv("GOGPU_DX12_FXC") == "1"
+}
+
+// loadDXC returns the DXC compiler the HLSL path should use on an adapter
+// with the given highest shader model. It returns nil, nil when DXC is not
+// wanted (naga DXIL or FXC forced, or no SM 6.0) and nil plus the load error
+// when dxcompiler.dll is unavailable; both mean HLSL→FXC.
+func loadDXC(sm d3d12.D3D_SHADER_MODEL) (*dxc.Lib, error) {
+	if dxilRequested() || fxcRequested() || sm < d3d12.D3D_SHADER_MODEL_6_0 {
+		return nil, nil
+	}
+	return dxc.Load()
+}
+
+// hlslShaderModel maps a D3D12 shader model to the naga HLSL one, clamped to
+// the newest model naga knows.
+func hlslShaderModel(sm d3d12.D3D_SHADER_MODEL) hlsl.ShaderModel {
+	switch {
+	case sm >= d3d12.D3D_SHADER_MODEL_6_7:
+		return hlsl.ShaderModel6_7
+	case sm >= d3d12.D3D_SHADER_MODEL_6_0:
+		return hlsl.ShaderModel6_0 + hlsl.ShaderModel(sm-d3d12.D3D_SHADER_MODEL_6_0)
+	default:
+		return hlsl.ShaderModel5_1
+	}
+}
+
 // compileWGSLModule compiles WGSL source to per-entry-point bytecode.
-// Routes to either DXIL direct compilation or HLSL→FXC based on Device.useDXIL.
+// Routes to either DXIL direct compilation or HLSL→DXC/FXC based on
+// Device.useDXIL and Device.dxc.
 // The naga options must come from the PipelineLayout, which contains the proper
 // BindingMap and SamplerBufferBindingMap matching the root signature layout.
 func (d *Device) compileWGSLModule(wgslSource string, nagaOpts *hlsl.Options, module *ShaderModule) error {
@@ -2202,8 +2473,9 @@ func hlslToDXILSamplerHeapTargets(t hlsl.SamplerHeapBindTargets) *dxil.SamplerHe
 	}
 }
 
-// compileWGSLModuleHLSL compiles IR to per-entry-point DXBC via HLSL→FXC.
-// Pipeline: IR → HLSL → D3DCompile (d3dcompiler_47.dll) → DXBC
+// compileWGSLModuleHLSL compiles IR to per-entry-point bytecode via HLSL.
+// Pipeline: IR → HLSL → D3DCompile (d3dcompiler_47.dll) → DXBC, or
+// IR → HLSL → DXC (dxcompiler.dll) → DXIL when Device.dxc is set.
 func (d *Device) compileWGSLModuleHLSL(irModule *ir.Module, nagaOpts *hlsl.Options, module *ShaderModule) error {
 	// Generate HLSL using pipeline-specific naga options.
 	// The options contain BindingMap (register assignments matching root signature)
@@ -2214,7 +2486,7 @@ func (d *Device) compileWGSLModuleHLSL(irModule *ir.Module, nagaOpts *hlsl.Optio
 		return fmt.Errorf("HLSL generation: %w", err)
 	}
 
-	hal.Logger().Debug("dx12: compiling HLSL",
+	d.logger(hal.LogShader).Debug("dx12: compiling HLSL",
 		"sourceLen", len(hlslSource),
 		"entryPoints", len(irModule.EntryPoints),
 	)
@@ -2222,28 +2494,43 @@ func (d *Device) compileWGSLModuleHLSL(irModule *ir.Module, nagaOpts *hlsl.Optio
 	// Load d3dcompiler_47.dll (deferred until cache miss)
 	var compiler *d3dcompile.Lib
 
+	// naga renames entry points that are HLSL keywords or reserved names;
+	// the compilers must be given the generated name.
+	var names hal.EntryPointNames
+	if info != nil {
+		names = info.EntryPointNames
+	}
+
 	// Compile each entry point separately, using shader cache.
 	// Cache key = SHA-256(HLSL source) + entry point + stage + target.
 	// This matches Rust wgpu's ShaderCache pattern (device.rs:390-428).
 	for i := range irModule.EntryPoints {
 		ep := &irModule.EntryPoints[i]
 		target := shaderStageToTarget(ep.Stage)
-
-		// Use the HLSL entry point name (naga may rename it)
-		hlslName := ep.Name
-		if info != nil && info.EntryPointNames != nil {
-			if mapped, ok := info.EntryPointNames[ep.Name]; ok {
-				hlslName = mapped
-			}
+		if d.dxc != nil {
+			target = shaderStageToDXCTarget(ep.Stage, d.dxcShaderModel)
 		}
 
-		// Check shader cache before calling FXC.
+		hlslName := names.Resolve(ep.Name)
+
+		// Check shader cache before calling the compiler.
 		cacheKey := NewShaderCacheKey(hlslSource, hlslName, ep.Stage, target)
 		if cached, ok := d.shaderCache.Get(cacheKey); ok {
 			module.entryPoints[ep.Name] = cached
 			continue
 		}
 
+		if d.dxc != nil {
+			bytecode, err := d.dxc.Compile(hlslSource, hlslName, target, d.dxcArgs...)
+			if err != nil {
+				return fmt.Errorf("DXC entry point %s (target: %s): %w",
+					names.Describe(ep.Name), target, err)
+			}
+			d.shaderCache.Put(cacheKey, bytecode)
+			module.entryPoints[ep.Name] = bytecode
+			continue
+		}
+
 		// Cache miss — load compiler if not yet loaded and compile via FXC.
 		if compiler == nil {
 			compiler, err = d3dcompile.Load()
@@ -2254,8 +2541,8 @@ func (d *Device) compileWGSLModuleHLSL(irModule *ir.Module, nagaOpts *hlsl.Optio
 
 		bytecode, err := compiler.Compile(hlslSource, hlslName, target)
 		if err != nil {
-			return fmt.Errorf("D3DCompile entry point %q (hlsl: %q, target: %s): %w",
-				ep.Name, hlslName, target, err)
+			return fmt.Errorf("D3DCompile entry point %s (target: %s): %w",
+				names.Describe(ep.Name), target, err)
 		}
 
 		// Store in cache for future pipelines using the same shader.
@@ -2276,7 +2563,7 @@ func (d *Device) compileWGSLModuleHLSL(irModule *ir.Module, nagaOpts *hlsl.Optio
 // Without this, DXIL would use raw WGSL @group/@binding numbers and D3D12
 // would reject the PSO with E_INVALIDARG (see FEAT-DXIL-002, BUG-DX12-011).
 func (d *Device) compileWGSLModuleDXIL(wgslSource string, irModule *ir.Module, nagaOpts *hlsl.Options, module *ShaderModule) error {
-	hal.Logger().Debug("dx12: compiling DXIL direct",
+	d.logger(hal.LogShader).Debug("dx12: compiling DXIL direct",
 		"entryPoints", len(irModule.EntryPoints),
 	)
 
@@ -2375,6 +2662,19 @@ func shaderStageToTarget(stage ir.ShaderStage) string {
 	}
 }
 
+// shaderStageToDXCTarget maps naga IR shader stage to a DXC target profile
+// for the given shader model, e.g. "cs_6_0".
+func shaderStageToDXCTarget(stage ir.ShaderStage, sm hlsl.ShaderModel) string {
+	prefix := dxc.ProfileVS
+	switch stage {
+	case ir.StageFragment:
+		prefix = dxc.ProfilePS
+	case ir.StageCompute:
+		prefix = dxc.ProfileCS
+	}
+	return prefix + "_" + sm.ProfileSuffix()
+}
+
 // DestroyShaderModule destroys a shader module.
 func (d *Device) DestroyShaderModule(module hal.ShaderModule) {
 	if m, ok := module.(*ShaderModule); ok && m != nil {
@@ -2436,7 +2736,7 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 	}
 
 	// Create the pipeline state object
-	pso, err := d.raw.CreateGraphicsPipelineState(psoDesc)
+	pso, err := d.createGraphicsPipelineState(psoDesc, desc.ViewCount)
 	d.DrainDebugMessages() // Check for validation warnings/errors during PSO creation
 	if err != nil {
 		slog.Error("dx12: CreateGraphicsPipelineState failed",
@@ -2646,18 +2946,7 @@ func (d *Device) CreateFence() (hal.Fence, error) {
 	if err != nil {
 		return nil, fmt.Errorf("dx12: CreateFence failed: %w", err)
 	}
-
-	// Create Windows event for this fence
-	event, err := windows.CreateEvent(nil, 0, 0, nil)
-	if err != nil {
-		fence.Release()
-		return nil, fmt.Errorf("dx12: CreateEvent for fence failed: %w", err)
-	}
-
-	return &Fence{
-		raw:   fence,
-		event: event,
-	}, nil
+	return newFence(fence)
 }
 
 // DestroyFence destroys a fence.
@@ -2762,6 +3051,20 @@ func (d *Device) CreateRenderBundleEncoder(desc *hal.RenderBundleEncoderDescript
 // DestroyRenderBundle destroys a render bundle.
 func (d *Device) DestroyRenderBundle(bundle hal.RenderBundle) {}
 
+// MemoryBudget returns the budget and usage of the adapter's local memory
+// segment group: dedicated video memory, or on UMA adapters the memory the
+// GPU shares with the CPU. Implements hal.MemoryBudgetReporter.
+func (d *Device) MemoryBudget() (hal.MemoryBudget, bool) {
+	if d.adapter == nil {
+		return hal.MemoryBudget{}, false
+	}
+	info, err := d.adapter.QueryVideoMemoryInfo(0, dxgi.DXGI_MEMORY_SEGMENT_GROUP_LOCAL)
+	if err != nil || info.Budget == 0 {
+		return hal.MemoryBudget{}, false
+	}
+	return hal.MemoryBudget{Budget: info.Budget, Usage: info.CurrentUsage}, true
+}
+
 // WaitIdle waits for all GPU work to complete.
 func (d *Device) WaitIdle() error {
 	if d == nil {
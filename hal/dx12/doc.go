@@ -16,6 +16,7 @@
 //
 //   - d3d12 — Low-level Direct3D 12 COM bindings
 //   - dxgi  — DXGI adapter enumeration and swap chain management
+//   - dxc   — dxcompiler.dll bindings for HLSL → SM 6 DXIL (FXC fallback)
 //
 // # References
 //
@@ -0,0 +1,284 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+// Package dxc provides Pure Go bindings to dxcompiler.dll.
+//
+// The DirectX Shader Compiler compiles HLSL to Shader Model 6 DXIL, which
+// unlocks wave intrinsics and native 16-bit types that FXC (d3dcompiler_47.dll)
+// cannot target. dxcompiler.dll does not ship with Windows; it comes with the
+// Windows SDK or next to the application. dxil.dll must be loadable too,
+// otherwise DXC emits unsigned DXIL that D3D12 rejects.
+//
+// Zero CGO — uses syscall.NewLazyDLL for dynamic loading.
+package dxc
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	lib     *Lib
+	libOnce sync.Once
+	errLib  error
+)
+
+// Lib provides access to an IDxcCompiler3 instance.
+type Lib struct {
+	dll      *syscall.LazyDLL
+	compiler *idxcCompiler3
+	mu       sync.Mutex // IDxcCompiler3 is not free-threaded
+}
+
+// Load loads dxcompiler.dll and creates a compiler instance. Safe to call
+// multiple times; the result of the first call is cached.
+func Load() (*Lib, error) {
+	libOnce.Do(func() {
+		lib, errLib = loadInternal()
+	})
+	return lib, errLib
+}
+
+func loadInternal() (*Lib, error) {
+	dll := syscall.NewLazyDLL("dxcompiler.dll")
+	if err := dll.Load(); err != nil {
+		return nil, fmt.Errorf("dxc: failed to load dxcompiler.dll: %w", err)
+	}
+	if err := syscall.NewLazyDLL("dxil.dll").Load(); err != nil {
+		return nil, fmt.Errorf("dxc: failed to load dxil.dll (needed to sign DXIL): %w", err)
+	}
+
+	create := dll.NewProc("DxcCreateInstance")
+	if err := create.Find(); err != nil {
+		return nil, fmt.Errorf("dxc: DxcCreateInstance not found: %w", err)
+	}
+
+	var compiler *idxcCompiler3
+	ret, _, _ := syscall.SyscallN(
+		create.Addr(),
+		uintptr(unsafe.Pointer(&clsidDxcCompiler)),
+		uintptr(unsafe.Pointer(&iidIDxcCompiler3)),
+		uintptr(unsafe.Pointer(&compiler)),
+	)
+	if int32(ret) < 0 || compiler == nil {
+		return nil, fmt.Errorf("dxc: DxcCreateInstance(IDxcCompiler3) failed (HRESULT 0x%08X)", uint32(ret))
+	}
+
+	return &Lib{dll: dll, compiler: compiler}, nil
+}
+
+// Shader model 6 target profile prefixes. Append a ProfileSuffix such as
+// "6_0" to form the -T argument.
+const (
+	ProfileVS = "vs" // Vertex shader
+	ProfilePS = "ps" // Pixel (fragment) shader
+	ProfileCS = "cs" // Compute shader
+)
+
+// Compiler arguments.
+const (
+	// ArgEnable16BitTypes makes half and int16_t real 16-bit types.
+	// Requires a 6_2 or newer target.
+	ArgEnable16BitTypes = "-enable-16bit-types"
+)
+
+// dxcUTF8 is DXC_CP_UTF8, the code page of DxcBuffer sources.
+const dxcUTF8 = 65001
+
+type guid struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// CLSID_DxcCompiler {73E22D93-E6CE-47F3-B5BF-F0664F39C1B0}
+var clsidDxcCompiler = guid{0x73E22D93, 0xE6CE, 0x47F3, [8]byte{0xB5, 0xBF, 0xF0, 0x66, 0x4F, 0x39, 0xC1, 0xB0}}
+
+// IID_IDxcCompiler3 {228B4687-5A6A-4730-900C-9702B2203F54}
+var iidIDxcCompiler3 = guid{0x228B4687, 0x5A6A, 0x4730, [8]byte{0x90, 0x0C, 0x97, 0x02, 0xB2, 0x20, 0x3F, 0x54}}
+
+// IID_IDxcResult {58346CDA-DDE7-4497-9461-6F87AF5E0659}
+var iidIDxcResult = guid{0x58346CDA, 0xDDE7, 0x4497, [8]byte{0x94, 0x61, 0x6F, 0x87, 0xAF, 0x5E, 0x06, 0x59}}
+
+// dxcBuffer mirrors DxcBuffer.
+type dxcBuffer struct {
+	Ptr      uintptr
+	Size     uintptr
+	Encoding uint32
+}
+
+// idxcCompiler3Vtbl is the COM vtable for IDxcCompiler3.
+type idxcCompiler3Vtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	Compile        uintptr
+	Disassemble    uintptr
+}
+
+type idxcCompiler3 struct {
+	vtbl *idxcCompiler3Vtbl
+}
+
+// idxcResultVtbl is the IDxcOperationResult prefix of the IDxcResult vtable.
+type idxcResultVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	GetStatus      uintptr
+	GetResult      uintptr
+	GetErrorBuffer uintptr
+}
+
+type idxcResult struct {
+	vtbl *idxcResultVtbl
+}
+
+func (r *idxcResult) release() {
+	//nolint:errcheck // COM Release returns ref count, not error
+	syscall.SyscallN(r.vtbl.Release, uintptr(unsafe.Pointer(r)))
+}
+
+func (r *idxcResult) status() int32 {
+	var hr int32
+	ret, _, _ := syscall.SyscallN(r.vtbl.GetStatus, uintptr(unsafe.Pointer(r)), uintptr(unsafe.Pointer(&hr)))
+	if int32(ret) < 0 {
+		return int32(ret)
+	}
+	return hr
+}
+
+func (r *idxcResult) object() *idxcBlob {
+	var blob *idxcBlob
+	ret, _, _ := syscall.SyscallN(r.vtbl.GetResult, uintptr(unsafe.Pointer(r)), uintptr(unsafe.Pointer(&blob)))
+	if int32(ret) < 0 {
+		return nil
+	}
+	return blob
+}
+
+func (r *idxcResult) errors() *idxcBlob {
+	var blob *idxcBlob
+	ret, _, _ := syscall.SyscallN(r.vtbl.GetErrorBuffer, uintptr(unsafe.Pointer(r)), uintptr(unsafe.Pointer(&blob)))
+	if int32(ret) < 0 {
+		return nil
+	}
+	return blob
+}
+
+// idxcBlobVtbl is the COM vtable for IDxcBlob. IDxcBlobEncoding extends it,
+// so error buffers share it.
+type idxcBlobVtbl struct {
+	QueryInterface   uintptr
+	AddRef           uintptr
+	Release          uintptr
+	GetBufferPointer uintptr
+	GetBufferSize    uintptr
+}
+
+type idxcBlob struct {
+	vtbl *idxcBlobVtbl
+}
+
+func (b *idxcBlob) release() {
+	//nolint:errcheck // COM Release returns ref count, not error
+	syscall.SyscallN(b.vtbl.Release, uintptr(unsafe.Pointer(b)))
+}
+
+// bytes returns the blob content as a copied byte slice.
+func (b *idxcBlob) bytes() []byte {
+	var ptr unsafe.Pointer
+	ret, _, _ := syscall.SyscallN(b.vtbl.GetBufferPointer, uintptr(unsafe.Pointer(b)))
+	// Store return value via intermediate to satisfy go vet.
+	*(*uintptr)(unsafe.Pointer(&ptr)) = ret
+	size, _, _ := syscall.SyscallN(b.vtbl.GetBufferSize, uintptr(unsafe.Pointer(b)))
+	if ptr == nil || size == 0 {
+		return nil
+	}
+	result := make([]byte, size)
+	copy(result, unsafe.Slice((*byte)(ptr), size))
+	return result
+}
+
+// Compile compiles HLSL source code to DXIL.
+//
+// Parameters:
+//   - source: HLSL source code
+//   - entryPoint: entry point function name (e.g. "vs_main")
+//   - target: shader model target (e.g. "cs_6_0")
+//   - args: extra compiler arguments such as ArgEnable16BitTypes
+//
+// Returns signed DXIL bytecode or an error with the compiler error message.
+func (l *Lib) Compile(source, entryPoint, target string, args ...string) ([]byte, error) {
+	if len(source) == 0 {
+		return nil, fmt.Errorf("dxc: empty source")
+	}
+	all := append([]string{"-E", entryPoint, "-T", target}, args...)
+	argv := make([]*uint16, len(all))
+	for i, a := range all {
+		p, err := syscall.UTF16PtrFromString(a)
+		if err != nil {
+			return nil, fmt.Errorf("dxc: invalid argument %q: %w", a, err)
+		}
+		argv[i] = p
+	}
+
+	src := []byte(source)
+	buf := dxcBuffer{
+		Ptr:      uintptr(unsafe.Pointer(&src[0])),
+		Size:     uintptr(len(src)),
+		Encoding: dxcUTF8,
+	}
+
+	l.mu.Lock()
+	var result *idxcResult
+	// IDxcCompiler3::Compile(pSource, pArguments, argCount, pIncludeHandler,
+	//                        riid, ppResult)
+	ret, _, _ := syscall.SyscallN(
+		l.compiler.vtbl.Compile,
+		uintptr(unsafe.Pointer(l.compiler)),
+		uintptr(unsafe.Pointer(&buf)),
+		uintptr(unsafe.Pointer(&argv[0])),
+		uintptr(len(argv)),
+		0, // pIncludeHandler (NULL): naga output has no #include
+		uintptr(unsafe.Pointer(&iidIDxcResult)),
+		uintptr(unsafe.Pointer(&result)),
+	)
+	// buf holds src only as a uintptr, which does not keep it alive.
+	runtime.KeepAlive(src)
+	l.mu.Unlock()
+
+	if int32(ret) < 0 || result == nil {
+		return nil, fmt.Errorf("dxc: Compile call failed (HRESULT 0x%08X)", uint32(ret))
+	}
+	defer result.release()
+
+	if hr := result.status(); hr < 0 {
+		errMsg := "unknown error"
+		if eb := result.errors(); eb != nil {
+			if text := eb.bytes(); len(text) != 0 {
+				errMsg = string(text)
+			}
+			eb.release()
+		}
+		return nil, fmt.Errorf("dxc: compilation failed (HRESULT 0x%08X): %s", uint32(hr), errMsg)
+	}
+
+	object := result.object()
+	if object == nil {
+		return nil, fmt.Errorf("dxc: compilation succeeded but object blob is nil")
+	}
+	defer object.release()
+
+	bytecode := object.bytes()
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("dxc: empty bytecode output")
+	}
+	return bytecode, nil
+}
@@ -286,6 +286,16 @@ const (
 	DXGI_CREATE_FACTORY_DEBUG uint32 = 0x01
 )
 
+// DXGI_MEMORY_SEGMENT_GROUP selects the memory an adapter's video memory
+// queries describe.
+type DXGI_MEMORY_SEGMENT_GROUP uint32
+
+// Memory segment group constants.
+const (
+	DXGI_MEMORY_SEGMENT_GROUP_LOCAL     DXGI_MEMORY_SEGMENT_GROUP = 0
+	DXGI_MEMORY_SEGMENT_GROUP_NON_LOCAL DXGI_MEMORY_SEGMENT_GROUP = 1
+)
+
 // DXGI_FEATURE specifies DXGI features.
 type DXGI_FEATURE uint32
 
@@ -490,6 +490,38 @@ func (a *IDXGIAdapter4) Release() uint32 {
 	return uint32(ret)
 }
 
+// AddRef increments the reference count.
+func (a *IDXGIAdapter4) AddRef() uint32 {
+	ret, _, _ := syscall.Syscall(
+		a.vtbl.AddRef,
+		1,
+		uintptr(unsafe.Pointer(a)),
+		0, 0,
+	)
+	return uint32(ret)
+}
+
+// QueryVideoMemoryInfo returns the process's budget and usage of a memory
+// segment group of the adapter node.
+func (a *IDXGIAdapter4) QueryVideoMemoryInfo(nodeIndex uint32, group DXGI_MEMORY_SEGMENT_GROUP) (DXGI_QUERY_VIDEO_MEMORY_INFO, error) {
+	var info DXGI_QUERY_VIDEO_MEMORY_INFO
+
+	ret, _, _ := syscall.Syscall6(
+		a.vtbl.QueryVideoMemoryInfo,
+		4,
+		uintptr(unsafe.Pointer(a)),
+		uintptr(nodeIndex),
+		uintptr(group),
+		uintptr(unsafe.Pointer(&info)),
+		0, 0,
+	)
+
+	if ret != 0 {
+		return info, d3d12.HRESULTError(ret)
+	}
+	return info, nil
+}
+
 // GetDesc1 returns the adapter description.
 func (a *IDXGIAdapter4) GetDesc1() (DXGI_ADAPTER_DESC1, error) {
 	var desc DXGI_ADAPTER_DESC1
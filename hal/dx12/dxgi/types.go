@@ -162,6 +162,15 @@ type DXGI_PRESENT_PARAMETERS struct {
 	ScrollOffset    *POINT
 }
 
+// DXGI_QUERY_VIDEO_MEMORY_INFO describes the video memory budget the OS
+// grants the process and how much of it the process uses.
+type DXGI_QUERY_VIDEO_MEMORY_INFO struct {
+	Budget                  uint64
+	CurrentUsage            uint64
+	AvailableForReservation uint64
+	CurrentReservation      uint64
+}
+
 // POINT represents a Windows POINT structure.
 type POINT struct {
 	X int32
@@ -0,0 +1,104 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import "github.com/gogpu/wgpu/hal/dx12/d3d12"
+
+// dynamicStateTarget is the part of ID3D12GraphicsCommandList that receives
+// a render pass's dynamic state.
+type dynamicStateTarget interface {
+	RSSetViewports(numViewports uint32, viewports *d3d12.D3D12_VIEWPORT)
+	RSSetScissorRects(numRects uint32, rects *d3d12.D3D12_RECT)
+	OMSetBlendFactor(blendFactor *[4]float32)
+	OMSetStencilRef(stencilRef uint32)
+}
+
+// dynamicStateDirty marks the parts of a dynamicState not yet written to the
+// command list.
+type dynamicStateDirty uint8
+
+const (
+	dirtyViewport dynamicStateDirty = 1 << iota
+	dirtyScissor
+	dirtyBlendFactor
+	dirtyStencilRef
+
+	dirtyAll = dirtyViewport | dirtyScissor | dirtyBlendFactor | dirtyStencilRef
+)
+
+// dynamicState is the viewport, scissor, blend constant and stencil
+// reference of a render pass. Setters only record the value; flush writes
+// what changed to the command list right before a draw. Like Vulkan dynamic
+// state, a value holds from the point it is set until the pass ends or it is
+// set again, whatever pipelines are bound in between.
+type dynamicState struct {
+	viewport    d3d12.D3D12_VIEWPORT
+	scissor     d3d12.D3D12_RECT
+	blendFactor [4]float32
+	stencilRef  uint32
+	dirty       dynamicStateDirty
+}
+
+// reset starts a pass over width x height: a viewport and scissor covering
+// the attachments, and a zero blend constant and stencil reference. The
+// previous pass's values remain in the command list, so all of it is dirty.
+func (s *dynamicState) reset(width, height uint32) {
+	*s = dynamicState{
+		viewport: d3d12.D3D12_VIEWPORT{Width: float32(width), Height: float32(height), MaxDepth: 1},
+		scissor:  d3d12.D3D12_RECT{Right: int32(width), Bottom: int32(height)},
+		dirty:    dirtyAll,
+	}
+}
+
+func (s *dynamicState) setViewport(viewport d3d12.D3D12_VIEWPORT) {
+	if s.viewport != viewport {
+		s.viewport = viewport
+		s.dirty |= dirtyViewport
+	}
+}
+
+func (s *dynamicState) setScissor(scissor d3d12.D3D12_RECT) {
+	if s.scissor != scissor {
+		s.scissor = scissor
+		s.dirty |= dirtyScissor
+	}
+}
+
+func (s *dynamicState) setBlendFactor(blendFactor [4]float32) {
+	if s.blendFactor != blendFactor {
+		s.blendFactor = blendFactor
+		s.dirty |= dirtyBlendFactor
+	}
+}
+
+func (s *dynamicState) setStencilRef(ref uint32) {
+	if s.stencilRef != ref {
+		s.stencilRef = ref
+		s.dirty |= dirtyStencilRef
+	}
+}
+
+// invalidate marks all of the state dirty, so the next flush writes it again.
+func (s *dynamicState) invalidate() {
+	s.dirty = dirtyAll
+}
+
+// flush writes the state changed since the last flush to target.
+func (s *dynamicState) flush(target dynamicStateTarget) {
+	if s.dirty&dirtyViewport != 0 {
+		target.RSSetViewports(1, &s.viewport)
+	}
+	if s.dirty&dirtyScissor != 0 {
+		target.RSSetScissorRects(1, &s.scissor)
+	}
+	if s.dirty&dirtyBlendFactor != 0 {
+		target.OMSetBlendFactor(&s.blendFactor)
+	}
+	if s.dirty&dirtyStencilRef != 0 {
+		target.OMSetStencilRef(s.stencilRef)
+	}
+	s.dirty = 0
+}
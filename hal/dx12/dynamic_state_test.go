@@ -0,0 +1,89 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal/dx12/d3d12"
+)
+
+// recordingStateTarget records the dynamic state calls made on it.
+type recordingStateTarget struct {
+	calls       []string
+	viewport    d3d12.D3D12_VIEWPORT
+	scissor     d3d12.D3D12_RECT
+	blendFactor [4]float32
+	stencilRef  uint32
+}
+
+func (r *recordingStateTarget) RSSetViewports(_ uint32, viewports *d3d12.D3D12_VIEWPORT) {
+	r.calls = append(r.calls, "viewport")
+	r.viewport = *viewports
+}
+
+func (r *recordingStateTarget) RSSetScissorRects(_ uint32, rects *d3d12.D3D12_RECT) {
+	r.calls = append(r.calls, "scissor")
+	r.scissor = *rects
+}
+
+func (r *recordingStateTarget) OMSetBlendFactor(blendFactor *[4]float32) {
+	r.calls = append(r.calls, "blend")
+	r.blendFactor = *blendFactor
+}
+
+func (r *recordingStateTarget) OMSetStencilRef(stencilRef uint32) {
+	r.calls = append(r.calls, "stencil")
+	r.stencilRef = stencilRef
+}
+
+func TestDynamicStateFlush(t *testing.T) {
+	var state dynamicState
+	target := &recordingStateTarget{}
+
+	state.reset(64, 32)
+	state.flush(target)
+	if want := []string{"viewport", "scissor", "blend", "stencil"}; !slices.Equal(target.calls, want) {
+		t.Fatalf("first flush calls = %v, want %v", target.calls, want)
+	}
+	if target.viewport.Width != 64 || target.viewport.Height != 32 || target.viewport.MaxDepth != 1 {
+		t.Errorf("default viewport = %+v, want 64x32 with depth 0..1", target.viewport)
+	}
+	if target.scissor != (d3d12.D3D12_RECT{Right: 64, Bottom: 32}) {
+		t.Errorf("default scissor = %+v, want the whole attachment", target.scissor)
+	}
+
+	target.calls = nil
+	state.flush(target)
+	if len(target.calls) != 0 {
+		t.Errorf("flush without changes wrote %v", target.calls)
+	}
+
+	// A value set again unchanged is not written; changed values are, in
+	// whatever order they were set relative to pipeline changes.
+	state.setViewport(target.viewport)
+	state.setScissor(d3d12.D3D12_RECT{Left: 8, Top: 4, Right: 16, Bottom: 12})
+	state.setStencilRef(7)
+	state.flush(target)
+	if want := []string{"scissor", "stencil"}; !slices.Equal(target.calls, want) {
+		t.Errorf("flush after changes wrote %v, want %v", target.calls, want)
+	}
+	if target.stencilRef != 7 || target.scissor.Left != 8 {
+		t.Errorf("flushed stencil %d, scissor %+v", target.stencilRef, target.scissor)
+	}
+
+	target.calls = nil
+	state.invalidate()
+	state.setBlendFactor([4]float32{0.25, 0.5, 0.75, 1})
+	state.flush(target)
+	if want := []string{"viewport", "scissor", "blend", "stencil"}; !slices.Equal(target.calls, want) {
+		t.Errorf("flush after invalidate wrote %v, want %v", target.calls, want)
+	}
+	if target.blendFactor != [4]float32{0.25, 0.5, 0.75, 1} || target.stencilRef != 7 {
+		t.Errorf("flush after invalidate wrote blend %v, stencil %d", target.blendFactor, target.stencilRef)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/dx12/d3d12"
+	"golang.org/x/sys/windows"
+)
+
+// CreateSharedFence creates a fence with D3D12_FENCE_FLAG_SHARED, which
+// ExportFence can hand to CUDA, Vulkan or another process. Implements
+// hal.FenceSharer.
+func (d *Device) CreateSharedFence() (hal.Fence, error) {
+	fence, err := d.raw.CreateFence(0, d3d12.D3D12_FENCE_FLAG_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("dx12: CreateFence (shared) failed: %w", err)
+	}
+	return newFence(fence)
+}
+
+// ExportFence returns a new NT handle to fence, which must have been
+// created by CreateSharedFence or ImportFence. The caller closes it.
+// Implements hal.FenceSharer.
+func (d *Device) ExportFence(fence hal.Fence) (hal.NativeFence, error) {
+	f, ok := fence.(*Fence)
+	if !ok || f == nil || f.raw == nil {
+		return hal.NativeFence{}, fmt.Errorf("dx12: invalid fence")
+	}
+	handle, err := d.raw.CreateSharedHandle(f.raw, d3d12.GENERIC_ALL)
+	if err != nil {
+		return hal.NativeFence{}, fmt.Errorf("dx12: CreateSharedHandle failed: %w", err)
+	}
+	return hal.NativeFence{Type: hal.NativeFenceD3D12, Handle: handle}, nil
+}
+
+// ImportFence opens the NT handle of a shared ID3D12Fence. The handle stays
+// owned by the caller. Implements hal.FenceSharer.
+func (d *Device) ImportFence(native hal.NativeFence) (hal.Fence, error) {
+	if native.Type != hal.NativeFenceD3D12 {
+		return nil, fmt.Errorf("dx12: cannot import native fence type %d: %w", native.Type, hal.ErrExternalNotSupported)
+	}
+	if native.Handle == 0 {
+		return nil, fmt.Errorf("dx12: native fence handle is nil")
+	}
+	fence, err := d.raw.OpenSharedFence(native.Handle)
+	if err != nil {
+		return nil, fmt.Errorf("dx12: OpenSharedHandle failed: %w", err)
+	}
+	return newFence(fence)
+}
+
+// newFence wraps fence with the event Wait blocks on. On failure fence is
+// released.
+func newFence(fence *d3d12.ID3D12Fence) (*Fence, error) {
+	event, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		fence.Release()
+		return nil, fmt.Errorf("dx12: CreateEvent for fence failed: %w", err)
+	}
+	return &Fence{raw: fence, event: event}, nil
+}
+
+// SubmitWithFences submits commandBuffers like Submit between a queue Wait
+// for every fence in waits and a queue Signal of every fence in signals.
+// Implements hal.FenceSubmitter.
+func (q *Queue) SubmitWithFences(commandBuffers []hal.CommandBuffer, waits, signals []hal.FenceValue) (uint64, error) {
+	for _, values := range [][]hal.FenceValue{waits, signals} {
+		for i, v := range values {
+			if f, ok := v.Fence.(*Fence); !ok || f == nil || f.raw == nil {
+				return 0, fmt.Errorf("dx12: fence %d is not a DX12 fence", i)
+			}
+		}
+	}
+
+	if err := q.lockOpen(); err != nil {
+		return 0, err
+	}
+	defer q.state.submitMu.Unlock()
+
+	for _, w := range waits {
+		if err := q.raw.Wait(w.Fence.(*Fence).raw, w.Value); err != nil {
+			return 0, fmt.Errorf("dx12: queue Wait failed: %w", err)
+		}
+	}
+	submission, err := q.submitLocked(commandBuffers)
+	if err != nil {
+		return 0, err
+	}
+	if len(commandBuffers) == 0 {
+		submission = q.device.currentFrameFenceValue()
+	}
+	for _, s := range signals {
+		if err := q.raw.Signal(s.Fence.(*Fence).raw, s.Value); err != nil {
+			return 0, fmt.Errorf("dx12: queue Signal failed: %w", err)
+		}
+	}
+	return submission, nil
+}
@@ -16,13 +16,14 @@
 //
 // # Debug Layer
 //
-// When InstanceFlagsDebug is set, the D3D12 debug layer is enabled via
-// D3D12GetDebugInterface. This provides detailed validation messages but
+// When InstanceFlagsDebug or hal.DX12Options.DebugLayer is set, the D3D12
+// debug layer is enabled via D3D12GetDebugInterface. This provides detailed validation messages but
 // significantly impacts performance. Only use in development.
 package dx12
 
 import (
 	"fmt"
+	"log/slog"
 	"runtime"
 	"unsafe"
 
@@ -45,6 +46,9 @@ func (Backend) Variant() gputypes.Backend {
 // CreateInstance creates a new DirectX 12 instance.
 func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error) {
 	instance := &Instance{}
+	if desc != nil {
+		instance.log = desc.Log
+	}
 
 	// Load DXGI library first
 	dxgiLib, err := dxgi.LoadDXGI()
@@ -53,21 +57,35 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	}
 	instance.dxgiLib = dxgiLib
 
-	// Determine factory creation flags
-	var factoryFlags uint32
-	debugRequested := desc != nil && desc.Flags&gputypes.InstanceFlagsDebug != 0
+	// Determine factory creation flags. hal.DX12Options can turn on the
+	// debug layer for this backend alone and add explicit factory flags,
+	// which, unlike the implied debug flag, are not dropped on failure.
+	var opts hal.DX12Options
+	var flags gputypes.InstanceFlags
+	if desc != nil {
+		opts = desc.BackendOptions.DX12
+		flags = desc.Flags
+	}
+	if opts.DebugLayer {
+		flags |= gputypes.InstanceFlagsDebug
+	}
+	if opts.GPUBasedValidation {
+		flags |= gputypes.InstanceFlagsValidation
+	}
+	factoryFlags := opts.FactoryFlags
+	debugRequested := flags&gputypes.InstanceFlagsDebug != 0
 	if debugRequested {
 		factoryFlags |= dxgi.DXGI_CREATE_FACTORY_DEBUG
-		instance.flags = desc.Flags
+		instance.flags = flags
 	}
 
 	// Create DXGI factory (with graceful debug fallback)
 	factory, err := dxgiLib.CreateFactory2(factoryFlags)
-	if err != nil && debugRequested {
+	if err != nil && debugRequested && factoryFlags != opts.FactoryFlags {
 		// Debug layer not installed (requires Windows "Graphics Tools" optional feature).
 		// Fall back to non-debug factory.
-		hal.Logger().Warn("dx12: debug layer not available, falling back to non-debug mode", "err", err)
-		factoryFlags = 0
+		instance.logger(hal.LogAdapter).Warn("dx12: debug layer not available, falling back to non-debug mode", "err", err)
+		factoryFlags = opts.FactoryFlags
 		instance.flags = 0
 		factory, err = dxgiLib.CreateFactory2(factoryFlags)
 	}
@@ -87,7 +105,7 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	// Enable debug layer if requested (and factory was created with debug flags)
 	if instance.flags&gputypes.InstanceFlagsDebug != 0 {
 		if err := instance.enableDebugLayer(); err != nil {
-			hal.Logger().Warn("dx12: debug layer enable failed (non-fatal)", "err", err)
+			instance.logger(hal.LogAdapter).Warn("dx12: debug layer enable failed (non-fatal)", "err", err)
 		}
 	}
 
@@ -97,7 +115,7 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	// Set a finalizer to ensure cleanup
 	runtime.SetFinalizer(instance, (*Instance).Destroy)
 
-	hal.Logger().Info("dx12: instance created",
+	instance.logger(hal.LogAdapter).Info("dx12: instance created",
 		"tearing", instance.allowTearing,
 	)
 
@@ -112,6 +130,16 @@ type Instance struct {
 	debugLayer   *d3d12.ID3D12Debug
 	allowTearing bool
 	flags        gputypes.InstanceFlags
+	log          *hal.Log
+}
+
+// logger returns the instance's logger for category c. It is safe to call
+// on a nil Instance, which logs through the package logger.
+func (i *Instance) logger(c hal.LogCategory) *slog.Logger {
+	if i == nil {
+		return hal.CategoryLogger(c)
+	}
+	return i.log.For(c)
 }
 
 // enableDebugLayer enables the D3D12 debug layer for validation.
@@ -231,7 +259,7 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 
 		exposed := adapter.toExposedAdapter()
 
-		hal.Logger().Info("dx12: adapter found",
+		i.logger(hal.LogAdapter).Info("dx12: adapter found",
 			"name", exposed.Info.Name,
 			"type", exposed.Info.DeviceType,
 			"vendorID", fmt.Sprintf("0x%04X", exposed.Info.VendorID),
@@ -284,7 +312,7 @@ func (i *Instance) enumerateAdaptersLegacy(surfaceHint hal.Surface) []hal.Expose
 
 		exposed := adapter.toExposedAdapter()
 
-		hal.Logger().Info("dx12: adapter found (legacy)",
+		i.logger(hal.LogAdapter).Info("dx12: adapter found (legacy)",
 			"name", exposed.Info.Name,
 			"type", exposed.Info.DeviceType,
 			"vendorID", fmt.Sprintf("0x%04X", exposed.Info.VendorID),
@@ -309,6 +337,72 @@ func (i *Instance) enumerateAdaptersLegacy(surfaceHint hal.Surface) []hal.Expose
 	return adapters
 }
 
+// dxgiAdapter is the part of IDXGIAdapter1 and IDXGIAdapter4 that
+// DescribeAdapters uses.
+type dxgiAdapter interface {
+	GetDesc1() (dxgi.DXGI_ADAPTER_DESC1, error)
+	CheckInterfaceSupport(interfaceName *dxgi.GUID) (int64, error)
+	Release() uint32
+}
+
+// DescribeAdapters lists the hardware adapters from their DXGI descriptions
+// alone. Unlike EnumerateAdapters it creates no D3D12 device, so the feature
+// level is not reported and, as DXGI does not say whether an adapter shares
+// system memory, DeviceType is DeviceTypeOther.
+func (i *Instance) DescribeAdapters() []hal.AdapterDescription {
+	var descriptions []hal.AdapterDescription
+	for idx := uint32(0); ; idx++ {
+		raw, err := i.factory.EnumAdapterByGpuPreference(
+			idx, dxgi.DXGI_GPU_PREFERENCE_HIGH_PERFORMANCE)
+		if err != nil {
+			if idx == 0 {
+				return i.describeAdaptersLegacy()
+			}
+			return descriptions
+		}
+		if description, ok := describeAdapter(raw); ok {
+			descriptions = append(descriptions, description)
+		}
+	}
+}
+
+// describeAdaptersLegacy is DescribeAdapters over IDXGIFactory1
+// enumeration, in DXGI order.
+func (i *Instance) describeAdaptersLegacy() []hal.AdapterDescription {
+	var descriptions []hal.AdapterDescription
+	for idx := uint32(0); ; idx++ {
+		raw, err := i.factory.EnumAdapters1(idx)
+		if err != nil {
+			return descriptions
+		}
+		if description, ok := describeAdapter(raw); ok {
+			descriptions = append(descriptions, description)
+		}
+	}
+}
+
+// describeAdapter describes raw and releases it. Software adapters are
+// skipped, as EnumerateAdapters skips them.
+func describeAdapter(raw dxgiAdapter) (hal.AdapterDescription, bool) {
+	defer raw.Release()
+	desc, err := raw.GetDesc1()
+	if err != nil || desc.Flags&dxgi.DXGI_ADAPTER_FLAG_SOFTWARE != 0 {
+		return hal.AdapterDescription{}, false
+	}
+	return hal.AdapterDescription{
+		Info: gputypes.AdapterInfo{
+			Name:       utf16ToString(desc.Description[:]),
+			Vendor:     vendorIDToName(desc.VendorID),
+			VendorID:   desc.VendorID,
+			DeviceID:   desc.DeviceID,
+			DeviceType: gputypes.DeviceTypeOther,
+			Driver:     "DirectX 12",
+			Backend:    gputypes.BackendDX12,
+		},
+		Details: adapterDetails(&desc, raw.CheckInterfaceSupport),
+	}, true
+}
+
 // Destroy releases the DirectX 12 instance and all associated resources.
 func (i *Instance) Destroy() {
 	if i == nil {
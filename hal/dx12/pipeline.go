@@ -8,6 +8,7 @@ package dx12
 import (
 	"fmt"
 	"os"
+	"runtime"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -82,6 +83,17 @@ type BindGroupLayout struct {
 	device  *Device
 }
 
+// bindingType returns the type of the entry at binding, or
+// BindingTypeSampledTexture if the layout has no such entry.
+func (l *BindGroupLayout) bindingType(binding uint32) BindingType {
+	for _, e := range l.entries {
+		if e.Binding == binding {
+			return e.Type
+		}
+	}
+	return BindingTypeSampledTexture
+}
+
 // Destroy releases the bind group layout resources.
 func (l *BindGroupLayout) Destroy() {
 	l.entries = nil
@@ -438,6 +450,9 @@ func (d *Device) createRootSignatureFromLayouts(layouts []hal.BindGroupLayout) (
 		StandardSamplers:   hlsl.BindTarget{Space: 0, Register: 0},
 		ComparisonSamplers: hlsl.BindTarget{Space: 0, Register: 2048},
 	}
+	if d.dxc != nil {
+		nagaOpts.ShaderModel = d.dxcShaderModel
+	}
 
 	return &pipelineLayoutResult{
 		rootSignature:    rootSig,
@@ -472,6 +487,34 @@ func bindingTypeToD3D12DescriptorRangeType(t BindingType) (d3d12.D3D12_DESCRIPTO
 // RenderPipeline Creation
 // -----------------------------------------------------------------------------
 
+// createGraphicsPipelineState creates the PSO for psoDesc. Multiview
+// pipelines go through a pipeline state stream with a view instancing
+// subobject that renders view i into render target array slice i.
+func (d *Device) createGraphicsPipelineState(psoDesc *d3d12.D3D12_GRAPHICS_PIPELINE_STATE_DESC, viewCount uint32) (*d3d12.ID3D12PipelineState, error) {
+	if viewCount <= 1 {
+		return d.raw.CreateGraphicsPipelineState(psoDesc)
+	}
+	device2, err := d.raw.QueryDevice2()
+	if err != nil {
+		return nil, fmt.Errorf("view instancing needs ID3D12Device2: %w", err)
+	}
+	defer device2.Release()
+
+	locations := make([]d3d12.D3D12_VIEW_INSTANCE_LOCATION, viewCount)
+	for i := range locations {
+		locations[i].RenderTargetArrayIndex = uint32(i) //nolint:gosec // at most D3D12_MAX_VIEW_INSTANCE_COUNT
+	}
+	viewInstancing := &d3d12.D3D12_VIEW_INSTANCING_DESC{
+		ViewInstanceCount:      viewCount,
+		PViewInstanceLocations: &locations[0],
+	}
+	pso, err := device2.CreatePipelineState(d3d12.GraphicsPipelineStream(psoDesc, viewInstancing))
+	runtime.KeepAlive(psoDesc)
+	runtime.KeepAlive(viewInstancing)
+	runtime.KeepAlive(locations)
+	return pso, err
+}
+
 // buildGraphicsPipelineStateDesc builds a D3D12_GRAPHICS_PIPELINE_STATE_DESC from a render pipeline descriptor.
 // Note: semanticNames is passed to keep the byte slices alive during PSO creation (D3D12 reads the pointers).
 func (d *Device) buildGraphicsPipelineStateDesc(
@@ -547,7 +590,7 @@ func (d *Device) buildGraphicsPipelineStateDesc(
 
 	// Rasterizer state
 	psoDesc.RasterizerState = d3d12.D3D12_RASTERIZER_DESC{
-		FillMode:              d3d12.D3D12_FILL_MODE_SOLID,
+		FillMode:              fillModeToD3D12(desc.PolygonMode),
 		CullMode:              cullModeToD3D12(desc.Primitive.CullMode),
 		FrontCounterClockwise: frontFaceToD3D12(desc.Primitive.FrontFace),
 		DepthBias:             0,
@@ -623,7 +666,11 @@ func resolveShaderBytecode(module hal.ShaderModule, entryPoint, overrideEnv stri
 			return nil, fmt.Errorf("failed to read %s %q: %w", overrideEnv, path, err)
 		}
 		bytecode = override
-		fmt.Fprintf(os.Stderr, "[dx12] shader overridden from %s (%d bytes)\n", path, len(override))
+		sm.device.logger(hal.LogShader).Warn("dx12: shader overridden",
+			"env", overrideEnv,
+			"path", path,
+			"bytes", len(override),
+		)
 	}
 	if len(bytecode) == 0 {
 		return nil, fmt.Errorf("entry point %q not found in module", entryPoint)
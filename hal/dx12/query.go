@@ -7,6 +7,7 @@ package dx12
 
 import (
 	"fmt"
+	"unsafe"
 
 	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/dx12/d3d12"
@@ -19,14 +20,29 @@ type QuerySet struct {
 	raw   *d3d12.ID3D12QueryHeap
 	rawTy d3d12.D3D12_QUERY_TYPE
 	count uint32
+
+	// statisticOffsets are the byte offsets, within
+	// D3D12_QUERY_DATA_PIPELINE_STATISTICS, of the counters a pipeline
+	// statistics set selects, in hal flag order.
+	statisticOffsets []uint64
+	// resolveScratch receives full D3D12_QUERY_DATA_PIPELINE_STATISTICS
+	// records, from which ResolveQuerySet copies the selected counters.
+	resolveScratch *d3d12.ID3D12Resource
 }
 
+// pipelineStatisticsSize is the size of D3D12_QUERY_DATA_PIPELINE_STATISTICS.
+const pipelineStatisticsSize = uint64(unsafe.Sizeof(d3d12.D3D12_QUERY_DATA_PIPELINE_STATISTICS{}))
+
 // Destroy releases the DX12 query heap.
 func (q *QuerySet) Destroy() {
 	if q.raw != nil {
 		q.raw.Release()
 		q.raw = nil
 	}
+	if q.resolveScratch != nil {
+		q.resolveScratch.Release()
+		q.resolveScratch = nil
+	}
 }
 
 // CreateQuerySet creates a DX12 query heap.
@@ -42,6 +58,7 @@ func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, err
 
 	var heapTy d3d12.D3D12_QUERY_HEAP_TYPE
 	var rawTy d3d12.D3D12_QUERY_TYPE
+	var statisticOffsets []uint64
 	switch desc.Type {
 	case hal.QueryTypeTimestamp:
 		heapTy = d3d12.D3D12_QUERY_HEAP_TYPE_TIMESTAMP
@@ -49,6 +66,13 @@ func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, err
 	case hal.QueryTypeOcclusion:
 		heapTy = d3d12.D3D12_QUERY_HEAP_TYPE_OCCLUSION
 		rawTy = d3d12.D3D12_QUERY_TYPE_BINARY_OCCLUSION
+	case hal.QueryTypePipelineStatistics:
+		heapTy = d3d12.D3D12_QUERY_HEAP_TYPE_PIPELINE_STATISTICS
+		rawTy = d3d12.D3D12_QUERY_TYPE_PIPELINE_STATISTICS
+		statisticOffsets = pipelineStatisticOffsets(desc.PipelineStatistics)
+		if len(statisticOffsets) == 0 {
+			return nil, fmt.Errorf("dx12: pipeline statistics query set selects no counters")
+		}
 	default:
 		return nil, fmt.Errorf("dx12: unsupported query type: %d", desc.Type)
 	}
@@ -64,11 +88,140 @@ func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, err
 		return nil, fmt.Errorf("dx12: CreateQueryHeap failed: %w", err)
 	}
 
-	return &QuerySet{
-		raw:   heap,
-		rawTy: rawTy,
-		count: desc.Count,
-	}, nil
+	qs := &QuerySet{
+		raw:              heap,
+		rawTy:            rawTy,
+		count:            desc.Count,
+		statisticOffsets: statisticOffsets,
+	}
+	if statisticOffsets != nil {
+		qs.resolveScratch, err = d.createResolveScratch(uint64(desc.Count) * pipelineStatisticsSize)
+		if err != nil {
+			heap.Release()
+			return nil, err
+		}
+	}
+	return qs, nil
+}
+
+// pipelineStatisticOffsets returns the offsets of the selected counters
+// within D3D12_QUERY_DATA_PIPELINE_STATISTICS, in hal flag order.
+func pipelineStatisticOffsets(types hal.PipelineStatisticsTypes) []uint64 {
+	var data d3d12.D3D12_QUERY_DATA_PIPELINE_STATISTICS
+	fields := []struct {
+		flag   hal.PipelineStatisticsTypes
+		offset uintptr
+	}{
+		{hal.PipelineStatisticsVertexShaderInvocations, unsafe.Offsetof(data.VSInvocations)},
+		{hal.PipelineStatisticsClipperInvocations, unsafe.Offsetof(data.CInvocations)},
+		{hal.PipelineStatisticsClipperPrimitivesOut, unsafe.Offsetof(data.CPrimitives)},
+		{hal.PipelineStatisticsFragmentShaderInvocations, unsafe.Offsetof(data.PSInvocations)},
+		{hal.PipelineStatisticsComputeShaderInvocations, unsafe.Offsetof(data.CSInvocations)},
+	}
+	var offsets []uint64
+	for _, f := range fields {
+		if types&f.flag != 0 {
+			offsets = append(offsets, uint64(f.offset))
+		}
+	}
+	return offsets
+}
+
+// createResolveScratch creates the default-heap buffer a pipeline
+// statistics set resolves into before its selected counters are copied out.
+func (d *Device) createResolveScratch(size uint64) (*d3d12.ID3D12Resource, error) {
+	heapProps := d3d12.D3D12_HEAP_PROPERTIES{
+		Type: d3d12.D3D12_HEAP_TYPE_DEFAULT,
+	}
+	resourceDesc := d3d12.D3D12_RESOURCE_DESC{
+		Dimension:        d3d12.D3D12_RESOURCE_DIMENSION_BUFFER,
+		Width:            size,
+		Height:           1,
+		DepthOrArraySize: 1,
+		MipLevels:        1,
+		SampleDesc:       d3d12.DXGI_SAMPLE_DESC{Count: 1},
+		Layout:           d3d12.D3D12_TEXTURE_LAYOUT_ROW_MAJOR,
+		Flags:            d3d12.D3D12_RESOURCE_FLAG_NONE,
+	}
+	resource, err := d.raw.CreateCommittedResource(
+		&heapProps,
+		d3d12.D3D12_HEAP_FLAG_NONE,
+		&resourceDesc,
+		d3d12.D3D12_RESOURCE_STATE_COMMON,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dx12: create query resolve buffer: %w", err)
+	}
+	return resource, nil
+}
+
+// resolvePipelineStatistics resolves full D3D12 statistics records into the
+// set's scratch buffer and copies the selected counters to dst, packed as
+// one uint64 per counter per query like Vulkan writes them.
+//
+// The scratch buffer starts each resolve in COPY_DEST: either promoted from
+// COMMON, to which buffers decay between ExecuteCommandLists calls, or left
+// there explicitly by the previous resolve in this command list.
+func (e *CommandEncoder) resolvePipelineStatistics(qs *QuerySet, firstQuery, queryCount uint32, dst *d3d12.ID3D12Resource, dstOffset uint64) {
+	scratchOffset := uint64(firstQuery) * pipelineStatisticsSize
+	e.cmdList.ResolveQueryData(qs.raw, qs.rawTy, firstQuery, queryCount, qs.resolveScratch, scratchOffset)
+
+	toSource := d3d12.NewTransitionBarrier(qs.resolveScratch,
+		d3d12.D3D12_RESOURCE_STATE_COPY_DEST, d3d12.D3D12_RESOURCE_STATE_COPY_SOURCE,
+		d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES)
+	e.cmdList.ResourceBarrier(1, &toSource)
+
+	for q := uint64(0); q < uint64(queryCount); q++ {
+		record := scratchOffset + q*pipelineStatisticsSize
+		for i, offset := range qs.statisticOffsets {
+			out := dstOffset + (q*uint64(len(qs.statisticOffsets))+uint64(i))*8
+			e.cmdList.CopyBufferRegion(dst, out, qs.resolveScratch, record+offset, 8)
+		}
+	}
+
+	toDest := d3d12.NewTransitionBarrier(qs.resolveScratch,
+		d3d12.D3D12_RESOURCE_STATE_COPY_SOURCE, d3d12.D3D12_RESOURCE_STATE_COPY_DEST,
+		d3d12.D3D12_RESOURCE_BARRIER_ALL_SUBRESOURCES)
+	e.cmdList.ResourceBarrier(1, &toDest)
+}
+
+// beginPipelineStatisticsQuery starts a pipeline statistics query.
+func (e *CommandEncoder) beginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	qs, ok := querySet.(*QuerySet)
+	if !e.isRecording || !ok || qs == nil || qs.raw == nil || qs.rawTy != d3d12.D3D12_QUERY_TYPE_PIPELINE_STATISTICS {
+		return
+	}
+	e.cmdList.BeginQuery(qs.raw, qs.rawTy, index)
+	e.statisticsSet, e.statisticsIndex = qs, index
+}
+
+// endPipelineStatisticsQuery ends the active pipeline statistics query.
+func (e *CommandEncoder) endPipelineStatisticsQuery() {
+	if e.isRecording && e.statisticsSet != nil {
+		e.cmdList.EndQuery(e.statisticsSet.raw, e.statisticsSet.rawTy, e.statisticsIndex)
+	}
+	e.statisticsSet = nil
+}
+
+// BeginPipelineStatisticsQuery implements hal.PipelineStatisticsPassEncoder.
+func (e *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	e.encoder.beginPipelineStatisticsQuery(querySet, index)
+}
+
+// EndPipelineStatisticsQuery implements hal.PipelineStatisticsPassEncoder.
+func (e *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	e.encoder.endPipelineStatisticsQuery()
+}
+
+// BeginPipelineStatisticsQuery implements hal.PipelineStatisticsPassEncoder.
+func (e *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	e.encoder.beginPipelineStatisticsQuery(querySet, index)
+}
+
+// EndPipelineStatisticsQuery implements hal.PipelineStatisticsPassEncoder.
+func (e *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	e.encoder.endPipelineStatisticsQuery()
 }
 
 // DestroyQuerySet destroys a DX12 query set.
@@ -0,0 +1,30 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestPipelineStatisticOffsets(t *testing.T) {
+	// D3D12_QUERY_DATA_PIPELINE_STATISTICS: IAVertices, IAPrimitives,
+	// VSInvocations, GSInvocations, GSPrimitives, CInvocations, CPrimitives,
+	// PSInvocations, HSInvocations, DSInvocations, CSInvocations.
+	all := hal.PipelineStatisticsVertexShaderInvocations |
+		hal.PipelineStatisticsClipperInvocations |
+		hal.PipelineStatisticsClipperPrimitivesOut |
+		hal.PipelineStatisticsFragmentShaderInvocations |
+		hal.PipelineStatisticsComputeShaderInvocations
+	if got, want := pipelineStatisticOffsets(all), []uint64{16, 40, 48, 56, 80}; !slices.Equal(got, want) {
+		t.Errorf("offsets(all) = %v, want %v", got, want)
+	}
+	if got := pipelineStatisticOffsets(hal.PipelineStatisticsFragmentShaderInvocations); !slices.Equal(got, []uint64{56}) {
+		t.Errorf("offsets(fragment) = %v, want [56]", got)
+	}
+}
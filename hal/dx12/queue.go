@@ -157,6 +157,7 @@ func (q *Queue) submitLocked(commandBuffers []hal.CommandBuffer) (uint64, error)
 	completed := q.device.completedFrameFenceValue()
 	q.releaseCompletedPreambles(completed)
 	q.releaseCompletedOneShots(completed)
+	q.device.reclaimDescriptors(completed)
 
 	if len(commandBuffers) == 0 {
 		return 0, nil
@@ -831,7 +832,7 @@ func (q *Queue) Present(surface hal.Surface, _ hal.SurfaceTexture, damageRects [
 		}
 	}
 
-	hal.Logger().Debug("dx12: present",
+	q.device.logger(hal.LogSwapchain).Debug("dx12: present",
 		"syncInterval", syncInterval,
 		"damageRects", len(damageRects),
 		"elapsed", time.Since(presentStart),
@@ -251,6 +251,9 @@ func (t *Texture) planeCount() uint32 {
 		// DX12 represents Depth24Plus and Stencil8 with D24S8, so retain
 		// both physical planes even when WebGPU exposes only one aspect.
 		return 2
+	case hal.TextureFormatNV12, hal.TextureFormatP010:
+		// Luma and chroma planes.
+		return 2
 	default:
 		return 1
 	}
@@ -351,14 +354,17 @@ type TextureView struct {
 	layerCount     uint32
 	device         *Device
 	srvHandle      d3d12.D3D12_CPU_DESCRIPTOR_HANDLE    // Shader resource view (for sampling)
+	uavHandle      d3d12.D3D12_CPU_DESCRIPTOR_HANDLE    // Unordered access view (for storage binding)
 	rtvHandle      d3d12.D3D12_CPU_DESCRIPTOR_HANDLE    // Render target view
 	dsvHandle      d3d12.D3D12_CPU_DESCRIPTOR_HANDLE    // Depth stencil view
 	dsvHandles     [4]d3d12.D3D12_CPU_DESCRIPTOR_HANDLE // DSV variants keyed by D3D12_DSV_FLAGS
 	hasSRV         bool
+	hasUAV         bool
 	hasRTV         bool
 	hasDSV         bool
 	hasDSVVariants [4]bool
 	srvHeapIndex   uint32
+	uavHeapIndex   uint32
 	rtvHeapIndex   uint32
 	dsvHeapIndex   [4]uint32
 }
@@ -371,6 +377,9 @@ func (v *TextureView) Destroy() {
 		if v.hasSRV && v.device.stagingViewHeap != nil {
 			v.device.stagingViewHeap.Free(v.srvHeapIndex, 1)
 		}
+		if v.hasUAV && v.device.stagingViewHeap != nil {
+			v.device.stagingViewHeap.Free(v.uavHeapIndex, 1)
+		}
 		if v.hasRTV && v.device.rtvHeap != nil {
 			v.device.rtvHeap.Free(v.rtvHeapIndex, 1)
 		}
@@ -383,6 +392,7 @@ func (v *TextureView) Destroy() {
 		}
 	}
 	v.hasSRV = false
+	v.hasUAV = false
 	v.hasRTV = false
 	v.hasDSV = false
 	v.hasDSVVariants = [4]bool{}
@@ -8,7 +8,9 @@ package dx12
 import (
 	"testing"
 
+	"github.com/gogpu/naga/hlsl"
 	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/hal/dx12/d3d12"
 )
 
 func TestShaderCache_GetMiss(t *testing.T) {
@@ -174,3 +176,57 @@ func itoa(n int) string {
 	}
 	return string(buf)
 }
+
+func TestHLSLShaderModel(t *testing.T) {
+	tests := []struct {
+		in   d3d12.D3D_SHADER_MODEL
+		want hlsl.ShaderModel
+	}{
+		{d3d12.D3D_SHADER_MODEL_5_1, hlsl.ShaderModel5_1},
+		{d3d12.D3D_SHADER_MODEL_6_0, hlsl.ShaderModel6_0},
+		{d3d12.D3D_SHADER_MODEL_6_2, hlsl.ShaderModel6_2},
+		{d3d12.D3D_SHADER_MODEL_6_7, hlsl.ShaderModel6_7},
+		{d3d12.D3D_SHADER_MODEL_6_7 + 1, hlsl.ShaderModel6_7},
+	}
+	for _, tt := range tests {
+		if got := hlslShaderModel(tt.in); got != tt.want {
+			t.Errorf("hlslShaderModel(0x%x) = %v, want %v", uint32(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestShaderStageToDXCTarget(t *testing.T) {
+	tests := []struct {
+		stage ir.ShaderStage
+		sm    hlsl.ShaderModel
+		want  string
+	}{
+		{ir.StageVertex, hlsl.ShaderModel6_0, "vs_6_0"},
+		{ir.StageFragment, hlsl.ShaderModel6_2, "ps_6_2"},
+		{ir.StageCompute, hlsl.ShaderModel6_6, "cs_6_6"},
+	}
+	for _, tt := range tests {
+		if got := shaderStageToDXCTarget(tt.stage, tt.sm); got != tt.want {
+			t.Errorf("shaderStageToDXCTarget(%v, %v) = %q, want %q", tt.stage, tt.sm, got, tt.want)
+		}
+	}
+}
+
+func TestLoadDXCHonorsOverrides(t *testing.T) {
+	t.Setenv("GOGPU_DX12_DXIL", "")
+	t.Setenv("GOGPU_DX12_FXC", "")
+	if lib, err := loadDXC(d3d12.D3D_SHADER_MODEL_5_1); lib != nil || err != nil {
+		t.Errorf("loadDXC(SM 5.1) = (%v, %v), want (nil, nil)", lib, err)
+	}
+
+	t.Setenv("GOGPU_DX12_FXC", "1")
+	if lib, err := loadDXC(d3d12.D3D_SHADER_MODEL_6_6); lib != nil || err != nil {
+		t.Errorf("loadDXC with GOGPU_DX12_FXC=1 = (%v, %v), want (nil, nil)", lib, err)
+	}
+
+	t.Setenv("GOGPU_DX12_FXC", "")
+	t.Setenv("GOGPU_DX12_DXIL", "1")
+	if lib, err := loadDXC(d3d12.D3D_SHADER_MODEL_6_6); lib != nil || err != nil {
+		t.Errorf("loadDXC with GOGPU_DX12_DXIL=1 = (%v, %v), want (nil, nil)", lib, err)
+	}
+}
@@ -290,8 +290,10 @@ func textureAspectPlanes(texture *Texture, aspect gputypes.TextureAspect) []uint
 	switch aspect {
 	case gputypes.TextureAspectDepthOnly:
 		return []uint32{0}
-	case gputypes.TextureAspectStencilOnly:
+	case gputypes.TextureAspectStencilOnly, hal.TextureAspectPlane1:
 		return []uint32{1}
+	case hal.TextureAspectPlane0:
+		return []uint32{0}
 	default:
 		// Undefined follows WebGPU's default/all-aspects behavior. For a
 		// packed D24S8/D32S8 resource this selects both physical planes.
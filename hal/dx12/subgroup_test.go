@@ -0,0 +1,44 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build windows && !(js && wasm)
+
+package dx12
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal/dx12/d3d12"
+)
+
+func TestAdapterSubgroupsRequireDXIL(t *testing.T) {
+	a := &Adapter{capabilities: AdapterCapabilities{
+		FeatureLevel:     d3d12.D3D_FEATURE_LEVEL_12_0,
+		ShaderModel:      d3d12.D3D_SHADER_MODEL_6_0,
+		WaveLaneCountMin: 32,
+		WaveLaneCountMax: 64,
+	}}
+
+	t.Setenv("GOGPU_DX12_DXIL", "")
+	t.Setenv("GOGPU_DX12_FXC", "1")
+	if a.Features().Contains(gputypes.FeatureSubgroupOperations) {
+		t.Error("FXC path reports FeatureSubgroupOperations")
+	}
+	if caps := a.Capabilities(); caps.SubgroupMaxSize != 0 {
+		t.Errorf("FXC path SubgroupMaxSize = %d, want 0", caps.SubgroupMaxSize)
+	}
+
+	t.Setenv("GOGPU_DX12_DXIL", "1")
+	if !a.Features().Contains(gputypes.FeatureSubgroupOperations) {
+		t.Error("DXIL path lacks FeatureSubgroupOperations")
+	}
+	if caps := a.Capabilities(); caps.SubgroupMinSize != 32 || caps.SubgroupMaxSize != 64 {
+		t.Errorf("SubgroupSizes = (%d, %d), want (32, 64)", caps.SubgroupMinSize, caps.SubgroupMaxSize)
+	}
+
+	a.capabilities.ShaderModel = d3d12.D3D_SHADER_MODEL_5_1
+	if a.Features().Contains(gputypes.FeatureSubgroupOperations) {
+		t.Error("SM 5.1 reports FeatureSubgroupOperations")
+	}
+}
@@ -35,7 +35,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	s.device = device
 
 	// Determine DXGI format
-	format := textureFormatToDXGI(config.Format)
+	format := textureFormatToDXGI(swapchainBufferFormat(config.Format))
 	if format == dxgi.DXGI_FORMAT_UNKNOWN {
 		return fmt.Errorf("dx12: unsupported surface format: %v", config.Format)
 	}
@@ -137,7 +137,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 		return err
 	}
 
-	hal.Logger().Info("dx12: surface configured",
+	device.logger(hal.LogSwapchain).Info("dx12: surface configured",
 		"width", config.Width,
 		"height", config.Height,
 		"format", config.Format,
@@ -180,8 +180,14 @@ func (s *Surface) createBackBufferRTVs() error {
 		// Track heap index for recycling on release
 		rtvIndex := s.device.rtvHeap.HandleToIndex(rtvHandle)
 
-		// Create RTV (nil desc = use resource format)
-		s.device.raw.CreateRenderTargetView(resource, nil, rtvHandle)
+		// Create the RTV in the surface format, which is the sRGB view of
+		// linear buffers for sRGB surfaces.
+		rtvDesc := d3d12.D3D12_RENDER_TARGET_VIEW_DESC{
+			Format:        textureFormatToD3D12(s.halFormat),
+			ViewDimension: d3d12.D3D12_RTV_DIMENSION_TEXTURE2D,
+		}
+		rtvDesc.SetTexture2D(0, 0)
+		s.device.raw.CreateRenderTargetView(resource, &rtvDesc, rtvHandle)
 
 		s.backBuffers[i] = backBuffer{
 			resource: resource,
@@ -240,7 +246,7 @@ func (s *Surface) resizeSwapchain(config *hal.SurfaceConfiguration) error {
 	s.releaseBackBuffers()
 
 	// Determine new format
-	format := textureFormatToDXGI(config.Format)
+	format := textureFormatToDXGI(swapchainBufferFormat(config.Format))
 	if format == dxgi.DXGI_FORMAT_UNKNOWN {
 		return fmt.Errorf("dx12: unsupported surface format: %v", config.Format)
 	}
@@ -268,6 +274,21 @@ func (s *Surface) resizeSwapchain(config *hal.SurfaceConfiguration) error {
 	return s.createBackBufferRTVs()
 }
 
+// swapchainBufferFormat returns the buffer format of a swapchain for a
+// surface format. Flip-model swapchains reject sRGB buffer formats, so sRGB
+// surfaces get linear buffers rendered through sRGB render target views,
+// which gamma-encode on write like sRGB buffers would.
+func swapchainBufferFormat(format gputypes.TextureFormat) gputypes.TextureFormat {
+	switch format {
+	case gputypes.TextureFormatRGBA8UnormSrgb:
+		return gputypes.TextureFormatRGBA8Unorm
+	case gputypes.TextureFormatBGRA8UnormSrgb:
+		return gputypes.TextureFormatBGRA8Unorm
+	default:
+		return format
+	}
+}
+
 // querySwapChain4 queries IDXGISwapChain1 for IDXGISwapChain4 interface.
 func querySwapChain4(swapchain1 *dxgi.IDXGISwapChain1) (*dxgi.IDXGISwapChain4, error) {
 	return swapchain1.QueryInterface()
@@ -0,0 +1,31 @@
+//go:build !(js && wasm)
+
+package hal
+
+import "fmt"
+
+// EntryPointNames maps the entry point names of a shader module's source to
+// the names in a backend's translated shader. naga renames entry points that
+// collide with reserved identifiers of the target language, such as main in
+// MSL or HLSL keywords, and reports the renames in its TranslationInfo.
+// Backends resolve every entry point through this map instead of using the
+// name from the pipeline descriptor directly. Names without an entry are
+// unchanged, so a nil map is the identity.
+type EntryPointNames map[string]string
+
+// Resolve returns the translated name of the entry point name.
+func (m EntryPointNames) Resolve(name string) string {
+	if translated, ok := m[name]; ok {
+		return translated
+	}
+	return name
+}
+
+// Describe quotes name for error messages, adding the translated name when
+// it differs.
+func (m EntryPointNames) Describe(name string) string {
+	if translated := m.Resolve(name); translated != name {
+		return fmt.Sprintf("%q (translated to %q)", name, translated)
+	}
+	return fmt.Sprintf("%q", name)
+}
@@ -0,0 +1,24 @@
+//go:build !(js && wasm)
+
+package hal
+
+import "testing"
+
+func TestEntryPointNames(t *testing.T) {
+	names := EntryPointNames{"main": "main_"}
+	if got := names.Resolve("main"); got != "main_" {
+		t.Errorf("Resolve(main) = %q, want main_", got)
+	}
+	if got := names.Resolve("vs_main"); got != "vs_main" {
+		t.Errorf("Resolve(vs_main) = %q, want it unchanged", got)
+	}
+	if got := EntryPointNames(nil).Resolve("main"); got != "main" {
+		t.Errorf("nil map Resolve(main) = %q, want it unchanged", got)
+	}
+	if got, want := names.Describe("main"), `"main" (translated to "main_")`; got != want {
+		t.Errorf("Describe(main) = %s, want %s", got, want)
+	}
+	if got, want := names.Describe("fs"), `"fs"`; got != want {
+		t.Errorf("Describe(fs) = %s, want %s", got, want)
+	}
+}
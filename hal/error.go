@@ -79,4 +79,15 @@ var (
 	// range that exceeds the buffer, or the buffer has no host-visible memory
 	// so it cannot be mapped on the CPU.
 	ErrInvalidMapRange = errors.New("hal: invalid buffer map range or non-mappable buffer")
+
+	// ErrExternalNotSupported indicates the backend cannot adopt a native
+	// device or texture created outside the HAL, or share fences with
+	// other APIs. Vulkan, Metal and DX12 support adoption; GLES, software
+	// and noop do not.
+	ErrExternalNotSupported = errors.New("hal: backend cannot adopt native devices or textures")
+
+	// ErrWaitCanceled indicates WaitContext stopped waiting because its
+	// context ended. The error also wraps the context's error, so
+	// errors.Is matches context.Canceled or context.DeadlineExceeded.
+	ErrWaitCanceled = errors.New("hal: wait canceled")
 )
@@ -0,0 +1,75 @@
+package hal
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// Native-only features. gputypes.Feature holds the WebGPU features in its
+// low bits; like Rust wgpu's native features, these take the high bits.
+// Unlike the rest of hal this file has no build constraints, so the root
+// package can alias them in every build, including js/wasm.
+const (
+	// FeaturePolygonModeLine allows PolygonModeLine in render pipelines.
+	FeaturePolygonModeLine gputypes.Feature = 1 << 48
+
+	// FeaturePolygonModePoint allows PolygonModePoint in render pipelines.
+	FeaturePolygonModePoint gputypes.Feature = 1 << 49
+
+	// FeatureConditionalRendering marks render pass encoders that implement
+	// ConditionalRenderPassEncoder.
+	FeatureConditionalRendering gputypes.Feature = 1 << 50
+
+	// FeatureMultiview allows render passes and pipelines with a ViewCount
+	// above 1, up to Capabilities.MaxMultiviewViewCount.
+	FeatureMultiview gputypes.Feature = 1 << 51
+
+	// FeatureProtectedContent allows protected textures, command encoders
+	// and surface configurations. Protected resources live in memory the
+	// host cannot read back.
+	FeatureProtectedContent gputypes.Feature = 1 << 52
+
+	// FeatureRobustBufferAccess makes out-of-bounds shader accesses to
+	// buffers safe: loads return zero or a value from inside the binding
+	// and stores are discarded or stay inside it. Backends that only
+	// guarantee this for some devices enable their extra checks when it is
+	// requested.
+	FeatureRobustBufferAccess gputypes.Feature = 1 << 53
+
+	// FeatureTextureFormatNV12 allows TextureFormatNV12 textures and their
+	// plane views.
+	FeatureTextureFormatNV12 gputypes.Feature = 1 << 54
+
+	// FeatureTextureFormatP010 allows TextureFormatP010 textures and their
+	// plane views.
+	FeatureTextureFormatP010 gputypes.Feature = 1 << 55
+)
+
+// FeatureName returns the name of a single feature, including the
+// native-only features gputypes does not know. Bits neither names are
+// written as Feature(0x...).
+func FeatureName(feature gputypes.Feature) string {
+	switch feature {
+	case FeaturePolygonModeLine:
+		return "PolygonModeLine"
+	case FeaturePolygonModePoint:
+		return "PolygonModePoint"
+	case FeatureConditionalRendering:
+		return "ConditionalRendering"
+	case FeatureMultiview:
+		return "Multiview"
+	case FeatureProtectedContent:
+		return "ProtectedContent"
+	case FeatureRobustBufferAccess:
+		return "RobustBufferAccess"
+	case FeatureTextureFormatNV12:
+		return "TextureFormatNV12"
+	case FeatureTextureFormatP010:
+		return "TextureFormatP010"
+	}
+	if name := feature.String(); name != "Unknown" {
+		return name
+	}
+	return fmt.Sprintf("Feature(%#x)", uint64(feature))
+}
@@ -0,0 +1,26 @@
+//go:build !(js && wasm)
+
+package hal
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestFeatureName(t *testing.T) {
+	tests := []struct {
+		feature gputypes.Feature
+		want    string
+	}{
+		{gputypes.FeatureTimestampQuery, "TimestampQuery"},
+		{FeaturePolygonModeLine, "PolygonModeLine"},
+		{FeatureTextureFormatP010, "TextureFormatP010"},
+		{gputypes.Feature(1 << 63), "Feature(0x8000000000000000)"},
+	}
+	for _, tt := range tests {
+		if got := FeatureName(tt.feature); got != tt.want {
+			t.Errorf("FeatureName(%#x) = %q, want %q", uint64(tt.feature), got, tt.want)
+		}
+	}
+}
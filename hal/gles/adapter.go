@@ -24,12 +24,18 @@ type Adapter struct {
 	// limits, MSAA support). Populated by queryAdapterCapabilities during
 	// adapter enumeration.
 	caps AdapterCapabilities
+
+	// log is the owning instance's logger configuration.
+	log *hal.Log
+
+	// options are the owning instance's GL backend options.
+	options hal.GLOptions
 }
 
 // Open creates a logical device with the requested features and limits.
 // The GL context is owned by Instance's AdapterContext; Device and Queue
 // share the same *AdapterContext pointer.
-func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+func (a *Adapter) Open(features gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
 	if a.ctx == nil {
 		return hal.OpenDevice{}, fmt.Errorf("gles: adapter context not initialized")
 	}
@@ -51,7 +57,7 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 
 	vendor := glCtx.GetString(gl.VENDOR)
 
-	hal.Logger().Info("gles: device opened",
+	a.log.For(hal.LogAdapter).Info("gles: device opened",
 		"vendor", vendor,
 		"version", a.version,
 		"renderer", a.renderer,
@@ -66,6 +72,12 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 		maxTextureUnits:     maxTexUnits,
 		glslVersion:         glslVer,
 		shaderBindingLayout: glslVer.SupportsExplicitLocations(),
+		vertexFormats:       a.caps.VertexFormats,
+		log:                 a.log,
+		implicitLod:         a.options.ImplicitLod,
+	}
+	if features.Contains(hal.FeatureRobustBufferAccess) {
+		device.boundsChecks = robustBoundsChecks
 	}
 
 	queue := &Queue{
@@ -82,7 +94,7 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 // TextureFormatCapabilities returns capabilities for a texture format.
 // Uses probed GL extension and MSAA information for accurate per-format detection.
 func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
-	return queryTextureFormatCapabilities(format, a.caps.Features, a.caps.MaxMSAASamples, a.caps.Extensions)
+	return queryTextureFormatCapabilities(format, a.caps.Features, a.caps.sampleCounts(format), a.caps.Extensions)
 }
 
 // SurfaceCapabilities returns surface capabilities.
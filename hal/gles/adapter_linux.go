@@ -27,10 +27,16 @@ type Adapter struct {
 	// limits, MSAA support). Populated by queryAdapterCapabilities during
 	// adapter enumeration.
 	caps AdapterCapabilities
+
+	// log is the owning instance's logger configuration.
+	log *hal.Log
+
+	// options are the owning instance's GL backend options.
+	options hal.GLOptions
 }
 
 // Open creates a logical device with the requested features and limits.
-func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+func (a *Adapter) Open(features gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
 	// EnumerateAdapters(nil) path returns an adapter with nil glCtx because no
 	// EGL context can be created without a display/window handle. Return a
 	// descriptive error instead of a nil pointer dereference at GenVertexArrays.
@@ -58,7 +64,7 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 
 	vendor := a.glCtx.GetString(gl.VENDOR)
 
-	hal.Logger().Info("gles: device opened",
+	a.log.For(hal.LogAdapter).Info("gles: device opened",
 		"vendor", vendor,
 		"version", a.version,
 		"renderer", a.renderer,
@@ -77,6 +83,12 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 		maxMSAA:             a.caps.MaxMSAASamples,
 		glslVersion:         glslVer,
 		shaderBindingLayout: glslVer.SupportsExplicitLocations(),
+		vertexFormats:       a.caps.VertexFormats,
+		log:                 a.log,
+		implicitLod:         a.options.ImplicitLod,
+	}
+	if features.Contains(hal.FeatureRobustBufferAccess) {
+		device.boundsChecks = robustBoundsChecks
 	}
 
 	queue := &Queue{
@@ -94,7 +106,7 @@ func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice,
 // TextureFormatCapabilities returns capabilities for a texture format.
 // Uses probed GL extension and MSAA information for accurate per-format detection.
 func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
-	return queryTextureFormatCapabilities(format, a.caps.Features, a.caps.MaxMSAASamples, a.caps.Extensions)
+	return queryTextureFormatCapabilities(format, a.caps.Features, a.caps.sampleCounts(format), a.caps.Extensions)
 }
 
 // SurfaceCapabilities returns surface capabilities.
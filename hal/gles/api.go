@@ -27,7 +27,7 @@ func (Backend) Variant() gputypes.Backend {
 // Creates a hidden 1×1 window and initializes a GL context on it. The context
 // lives for the Instance lifetime and survives any user Surface destruction.
 // Follows Rust wgpu-hal/src/gles/wgl.rs Instance::init (lines 448-563).
-func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
+func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error) {
 	if err := wgl.Init(); err != nil {
 		return nil, fmt.Errorf("gles: failed to initialize WGL: %w", err)
 	}
@@ -55,7 +55,13 @@ func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
 
 	ctx := NewAdapterContext(hiddenWindow.DC())
 
-	hal.Logger().Info("gles: instance created",
+	var log *hal.Log
+	var options hal.GLOptions
+	if desc != nil {
+		log = desc.Log
+		options = desc.BackendOptions.GL
+	}
+	log.For(hal.LogAdapter).Info("gles: instance created",
 		"platform", "windows",
 		"hiddenDC", fmt.Sprintf("0x%x", hiddenWindow.DC()),
 	)
@@ -63,6 +69,8 @@ func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
 	return &Instance{
 		ctx:          ctx,
 		hiddenWindow: hiddenWindow,
+		log:          log,
+		options:      options,
 	}, nil
 }
 
@@ -72,6 +80,10 @@ func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
 type Instance struct {
 	ctx          *AdapterContext
 	hiddenWindow *wgl.HiddenWindow
+	log          *hal.Log
+
+	// options are the instance's GL backend options.
+	options hal.GLOptions
 }
 
 // CreateSurface creates an OpenGL surface from window handles.
@@ -85,6 +97,12 @@ type Instance struct {
 //
 // Follows Rust wgpu-hal/src/gles/wgl.rs Instance::create_surface (lines 624-670).
 func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error) {
+	// Headless surfaces render into the swapchain FBO on the hidden window's
+	// context and never touch a user window.
+	if target.Kind == hal.SurfaceTargetHeadless {
+		i.log.For(hal.LogSwapchain).Info("gles: headless surface created")
+		return &Surface{ctx: i.ctx, headless: true, log: i.log, options: i.options}, nil
+	}
 	if err := target.RequireKind(hal.SurfaceTargetWindowsHWND); err != nil {
 		return nil, fmt.Errorf("gles: %w", err)
 	}
@@ -109,11 +127,13 @@ func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error)
 	}
 	wgl.ReleaseDC(hwnd, hdc)
 
-	hal.Logger().Info("gles: surface created", "hwnd", fmt.Sprintf("0x%x", windowHandle))
+	i.log.For(hal.LogSwapchain).Info("gles: surface created", "hwnd", fmt.Sprintf("0x%x", windowHandle))
 
 	return &Surface{
-		hwnd: hwnd,
-		ctx:  i.ctx,
+		hwnd:    hwnd,
+		ctx:     i.ctx,
+		log:     i.log,
+		options: i.options,
 	}, nil
 }
 
@@ -124,7 +144,7 @@ func (i *Instance) EnumerateAdapters(_ hal.Surface) []hal.ExposedAdapter {
 	defer i.ctx.Unlock()
 
 	if glCtx == nil {
-		hal.Logger().Error("gles: EnumerateAdapters: GL context not available")
+		i.log.For(hal.LogAdapter).Error("gles: EnumerateAdapters: GL context not available")
 		return nil
 	}
 
@@ -147,6 +167,8 @@ func (i *Instance) EnumerateAdapters(_ hal.Surface) []hal.ExposedAdapter {
 				version:  version,
 				renderer: renderer,
 				caps:     caps,
+				log:      i.log,
+				options:  i.options,
 			},
 			Info: gputypes.AdapterInfo{
 				Name:       caps.Renderer,
@@ -158,6 +180,7 @@ func (i *Instance) EnumerateAdapters(_ hal.Surface) []hal.ExposedAdapter {
 				DriverInfo: driverInfo,
 				Backend:    gputypes.BackendGL,
 			},
+			Details:  hal.AdapterDetails{DriverVersion: hal.ParseDriverVersion(caps.Version)},
 			Features: caps.Features,
 			Capabilities: hal.Capabilities{
 				Limits: caps.Limits,
@@ -33,10 +33,16 @@ func (Backend) Variant() gputypes.Backend {
 //
 // On Wayland, this may fail (EGL needs wl_display*) — that's OK, CreateSurface
 // provides the proper context later. On X11/headless, this succeeds.
-func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
+func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error) {
 	if err := egl.Init(); err != nil {
 		return nil, fmt.Errorf("gles: failed to initialize EGL: %w", err)
 	}
+	var log *hal.Log
+	var options hal.GLOptions
+	if desc != nil {
+		log = desc.Log
+		options = desc.BackendOptions.GL
+	}
 
 	// Try to create instance-level EGL context (Rust wgpu-hal parity).
 	// Skip on Wayland: surfaceless context would create GL objects (VAO, FBO) that
@@ -44,36 +50,36 @@ func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
 	// EGL contexts). Device/Queue must use the SAME context as the window surface.
 	// On X11/headless, instance context IS the presentation context — safe to create.
 	if egl.DetectWindowKind() == egl.WindowKindWayland {
-		hal.Logger().Info("gles: skipping instance context on Wayland (surface provides context)")
-		return &Instance{}, nil
+		log.For(hal.LogAdapter).Info("gles: skipping instance context on Wayland (surface provides context)")
+		return &Instance{log: log, options: options}, nil
 	}
 
 	config := egl.DefaultContextConfig()
 	config.GLES = false
 	ctx, err := egl.NewContext(config)
 	if err != nil {
-		hal.Logger().Info("gles: instance context unavailable (expected on Wayland)", "err", err)
-		return &Instance{}, nil
+		log.For(hal.LogAdapter).Info("gles: instance context unavailable (expected on Wayland)", "err", err)
+		return &Instance{log: log, options: options}, nil
 	}
 
 	if err := ctx.MakeCurrent(); err != nil {
 		ctx.Destroy()
-		hal.Logger().Warn("gles: instance context MakeCurrent failed", "err", err)
-		return &Instance{}, nil
+		log.For(hal.LogAdapter).Warn("gles: instance context MakeCurrent failed", "err", err)
+		return &Instance{log: log, options: options}, nil
 	}
 
 	glCtx := &gl.Context{}
 	if err := glCtx.Load(egl.GetGLProcAddress); err != nil {
 		ctx.Destroy()
-		hal.Logger().Warn("gles: instance GL load failed", "err", err)
-		return &Instance{}, nil
+		log.For(hal.LogAdapter).Warn("gles: instance GL load failed", "err", err)
+		return &Instance{log: log, options: options}, nil
 	}
 
-	hal.Logger().Info("gles: instance created with EGL context",
+	log.For(hal.LogAdapter).Info("gles: instance created with EGL context",
 		"version", glCtx.GetString(gl.VERSION),
 		"renderer", glCtx.GetString(gl.RENDERER))
 
-	return &Instance{eglCtx: ctx, glCtx: glCtx}, nil
+	return &Instance{eglCtx: ctx, glCtx: glCtx, log: log, options: options}, nil
 }
 
 // Instance implements hal.Instance for the OpenGL backend on Linux.
@@ -83,6 +89,10 @@ func (Backend) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
 type Instance struct {
 	eglCtx *egl.Context
 	glCtx  *gl.Context
+	log    *hal.Log
+
+	// options are the instance's GL backend options.
+	options hal.GLOptions
 }
 
 // CreateSurface creates an OpenGL surface from window handles.
@@ -100,12 +110,14 @@ type Instance struct {
 func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error) {
 	var targetWindowKind egl.WindowKind
 	switch target.Kind {
+	case hal.SurfaceTargetHeadless:
+		return i.createHeadlessSurface()
 	case hal.SurfaceTargetXlibWindow:
 		targetWindowKind = egl.WindowKindX11
 	case hal.SurfaceTargetWaylandSurface:
 		targetWindowKind = egl.WindowKindWayland
 	default:
-		return nil, fmt.Errorf("gles: %w: got %s, backend requires Xlib window or Wayland surface", hal.ErrUnsupportedSurfaceTarget, target.Kind)
+		return nil, fmt.Errorf("gles: %w: got %s, backend requires Xlib window, Wayland surface or headless", hal.ErrUnsupportedSurfaceTarget, target.Kind)
 	}
 	displayHandle, windowHandle := target.DisplayHandle, target.WindowHandle
 
@@ -113,7 +125,7 @@ func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error)
 	// Do NOT share if Instance context is surfaceless (headless/Wayland fallback)
 	// and Surface needs a window — the EGL display won't support eglCreateWindowSurface.
 	if i.eglCtx != nil && i.glCtx != nil && i.eglCtx.WindowKind() == targetWindowKind {
-		hal.Logger().Info("gles: surface sharing Instance EGL context")
+		i.log.For(hal.LogSwapchain).Info("gles: surface sharing Instance EGL context")
 		return &Surface{
 			displayHandle: displayHandle,
 			windowHandle:  windowHandle,
@@ -123,6 +135,8 @@ func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error)
 			ownsContext:   false,
 			version:       i.glCtx.GetString(gl.VERSION),
 			renderer:      i.glCtx.GetString(gl.RENDERER),
+			log:           i.log,
+			options:       i.options,
 		}, nil
 	}
 
@@ -157,7 +171,7 @@ func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error)
 
 	version := glCtx.GetString(gl.VERSION)
 	renderer := glCtx.GetString(gl.RENDERER)
-	hal.Logger().Info("gles: surface created with new EGL context",
+	i.log.For(hal.LogSwapchain).Info("gles: surface created with new EGL context",
 		"version", version, "renderer", renderer, "gles", config.GLES)
 
 	return &Surface{
@@ -169,6 +183,71 @@ func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error)
 		ownsContext:   true,
 		version:       version,
 		renderer:      renderer,
+		log:           i.log,
+		options:       i.options,
+	}, nil
+}
+
+// createHeadlessSurface creates a window-less surface whose swapchain is
+// only the offscreen FBO: Present finishes rendering instead of swapping
+// and ReadPixels captures the frame. It shares the Instance context, which
+// is current on a pbuffer or no surface at all. On Wayland, where the
+// Instance has no context, a surfaceless EGL context is created instead.
+func (i *Instance) createHeadlessSurface() (hal.Surface, error) {
+	if i.eglCtx != nil && i.glCtx != nil {
+		return &Surface{
+			eglCtx:      i.eglCtx,
+			eglDisplay:  i.eglCtx.Display(),
+			glCtx:       i.glCtx,
+			ownsContext: false,
+			headless:    true,
+			version:     i.glCtx.GetString(gl.VERSION),
+			renderer:    i.glCtx.GetString(gl.RENDERER),
+			log:         i.log,
+			options:     i.options,
+		}, nil
+	}
+
+	surfaceless := egl.WindowKindSurfaceless
+	config := egl.DefaultContextConfig()
+	config.Surfaceless = true
+	config.WindowKind = &surfaceless
+	ctx, err := egl.NewContext(config)
+	if err != nil {
+		config.GLES = true
+		config.GLVersionMajor = 3
+		config.GLVersionMinor = 0
+		config.CoreProfile = false
+		ctx, err = egl.NewContext(config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gles: failed to create surfaceless EGL context: %w", err)
+	}
+	if err := ctx.MakeCurrent(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("gles: failed to make context current: %w", err)
+	}
+	glCtx := &gl.Context{}
+	if err := glCtx.Load(egl.GetGLProcAddress, config.GLES); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("gles: failed to load GL functions: %w", err)
+	}
+
+	version := glCtx.GetString(gl.VERSION)
+	renderer := glCtx.GetString(gl.RENDERER)
+	i.log.For(hal.LogSwapchain).Info("gles: headless surface created with surfaceless EGL context",
+		"version", version, "renderer", renderer, "gles", config.GLES)
+
+	return &Surface{
+		eglCtx:      ctx,
+		eglDisplay:  ctx.Display(),
+		glCtx:       glCtx,
+		ownsContext: true,
+		headless:    true,
+		version:     version,
+		renderer:    renderer,
+		log:         i.log,
+		options:     i.options,
 	}, nil
 }
 
@@ -183,7 +262,7 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 	// Priority 2: instance-level context (created in CreateInstance via pbuffer/surfaceless)
 	if i.glCtx != nil {
 		return []hal.ExposedAdapter{
-			makeAdapterFromGL(i.glCtx, i.eglCtx),
+			makeAdapterFromGL(i.glCtx, i.eglCtx, i.log, i.options),
 		}
 	}
 
@@ -191,7 +270,7 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 	// Return placeholder — Open() has nil guard from PR #210.
 	return []hal.ExposedAdapter{
 		{
-			Adapter: &Adapter{},
+			Adapter: &Adapter{log: i.log, options: i.options},
 			Info: gputypes.AdapterInfo{
 				Name:       "OpenGL Adapter",
 				Vendor:     vendorUnknown,
@@ -212,15 +291,17 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 }
 
 // makeAdapterFromGL creates an ExposedAdapter using a live GL context.
-func makeAdapterFromGL(glCtx *gl.Context, eglCtx *egl.Context) hal.ExposedAdapter {
+func makeAdapterFromGL(glCtx *gl.Context, eglCtx *egl.Context, log *hal.Log, options hal.GLOptions) hal.ExposedAdapter {
 	version := glCtx.GetString(gl.VERSION)
 	renderer := glCtx.GetString(gl.RENDERER)
 	vendor := glCtx.GetString(gl.VENDOR)
 
 	return hal.ExposedAdapter{
 		Adapter: &Adapter{
-			glCtx:  glCtx,
-			eglCtx: eglCtx,
+			glCtx:   glCtx,
+			eglCtx:  eglCtx,
+			log:     log,
+			options: options,
 		},
 		Info: gputypes.AdapterInfo{
 			Name:       renderer,
@@ -230,6 +311,7 @@ func makeAdapterFromGL(glCtx *gl.Context, eglCtx *egl.Context) hal.ExposedAdapte
 			DriverInfo: version,
 			Backend:    gputypes.BackendGL,
 		},
+		Details: hal.AdapterDetails{DriverVersion: hal.ParseDriverVersion(version)},
 		Capabilities: hal.Capabilities{
 			Limits: gputypes.DefaultLimits(),
 			AlignmentsMask: hal.Alignments{
@@ -46,6 +46,10 @@ type AdapterCapabilities struct {
 	// Maximum MSAA sample count (from GL_MAX_SAMPLES).
 	MaxMSAASamples int32
 
+	// Sample counts per renderable format, from glGetInternalformativ with
+	// GL_SAMPLES. Nil when the driver cannot answer the query.
+	FormatSampleCounts map[gputypes.TextureFormat]uint32
+
 	// Detected features.
 	Features gputypes.Features
 
@@ -55,6 +59,10 @@ type AdapterCapabilities struct {
 	// Downlevel capability flags.
 	DownlevelFlags hal.DownlevelFlags
 
+	// Vertex formats fetched natively, and whether the others can be
+	// pulled from storage buffers.
+	VertexFormats vertexFormatSupport
+
 	// Inferred device type.
 	DeviceType gputypes.DeviceType
 
@@ -95,11 +103,17 @@ func queryAdapterCapabilities(glCtx *gl.Context) AdapterCapabilities {
 	// --- 6. Downlevel flags ---
 	caps.DownlevelFlags = queryDownlevelFlags(glCtx, caps.Extensions, caps.GLMajor, caps.GLMinor, caps.IsES)
 
-	// --- 7. Device type and vendor ID ---
+	// --- 7. Vertex formats ---
+	caps.VertexFormats = queryVertexFormatSupport(glCtx, caps.Extensions, caps.GLMajor, caps.GLMinor, caps.IsES)
+
+	// --- 8. Per-format sample counts ---
+	caps.FormatSampleCounts = queryFormatSampleCounts(glCtx, &caps)
+
+	// --- 9. Device type and vendor ID ---
 	caps.DeviceType = inferDeviceType(caps.Vendor, caps.Renderer)
 	caps.VendorID = inferVendorID(caps.Vendor)
 
-	hal.Logger().Info("gles: adapter capabilities detected",
+	hal.CategoryLogger(hal.LogAdapter).Info("gles: adapter capabilities detected",
 		"vendor", caps.Vendor,
 		"renderer", caps.Renderer,
 		"version", caps.Version,
@@ -273,6 +287,10 @@ func queryFeatures(exts map[string]bool, glMajor, glMinor int, isES bool, glCtx
 	// TextureAdapterSpecificFormatFeatures -- always reported
 	features.Insert(gputypes.FeatureTextureAdapterSpecificFormatFeatures)
 
+	// Polygon modes -- emulated with GL_LINE_LOOP and GL_POINTS draws
+	features.Insert(hal.FeaturePolygonModeLine)
+	features.Insert(hal.FeaturePolygonModePoint)
+
 	// Depth clip control: GL_EXT_depth_clamp or GL_ARB_depth_clamp
 	if hasExtension(exts, "GL_EXT_depth_clamp", "GL_ARB_depth_clamp") {
 		features.Insert(gputypes.FeatureDepthClipControl)
@@ -323,6 +341,12 @@ func queryFeatures(exts map[string]bool, glMajor, glMinor int, isES bool, glCtx
 		features.Insert(gputypes.FeatureRG11B10UfloatRenderable)
 	}
 
+	// Robust buffer access -- buffer bounds are only checked in a context
+	// created with robust access; naga adds the image checks at compile time
+	if hasRobustAccess(glCtx, exts, glMajor, glMinor, isES) {
+		features.Insert(hal.FeatureRobustBufferAccess)
+	}
+
 	// IndirectFirstInstance -- desktop GL 4.2+ with ARB_shader_draw_parameters
 	if !isES && glMajor >= 4 && glMinor >= 2 &&
 		hasExtension(exts, "GL_ARB_shader_draw_parameters") {
@@ -332,6 +356,24 @@ func queryFeatures(exts map[string]bool, glMajor, glMinor int, isES bool, glCtx
 	return features
 }
 
+// hasRobustAccess reports whether the context was created with robust buffer
+// access, in which out-of-bounds buffer reads return zero or in-bounds
+// values and out-of-bounds writes are discarded. Desktop GL reports it in
+// GL_CONTEXT_FLAGS, OpenGL ES through GL_CONTEXT_ROBUST_ACCESS.
+func hasRobustAccess(glCtx *gl.Context, exts map[string]bool, glMajor, glMinor int, isES bool) bool {
+	if !glVersionAtLeast(glMajor, glMinor, isES, [2]int{3, 2}, [2]int{4, 5}) &&
+		!hasExtension(exts, "GL_KHR_robustness", "GL_ARB_robustness", "GL_EXT_robustness") {
+		return false
+	}
+	var value int32
+	if isES {
+		glCtx.GetIntegerv(gl.CONTEXT_ROBUST_ACCESS, &value)
+		return value != 0
+	}
+	glCtx.GetIntegerv(gl.CONTEXT_FLAGS, &value)
+	return value&gl.CONTEXT_FLAG_ROBUST_ACCESS_BIT != 0
+}
+
 // ---------------------------------------------------------------------------
 // Limits querying
 // ---------------------------------------------------------------------------
@@ -420,11 +462,12 @@ func queryLimits(glCtx *gl.Context, glMajor, glMinor int, isES bool, exts map[st
 		maxVertexStride = 2048
 	}
 
-	// Varying components (inter-stage shader variables)
+	// Varying components. WebGPU counts inter-stage variables, each up to a
+	// vec4, so the limit is a quarter of the component count.
 	maxVaryingComponents := getGLInt(glCtx, gl.MAX_VARYING_COMPONENTS, 60)
 	if maxVaryingComponents == 0 {
 		// MAX_VARYING_COMPONENTS is deprecated in OpenGL 3.2+ core profile;
-		// some drivers return 0. Use the WebGPU default.
+		// some drivers return 0. Use the GLES 3.0 minimum.
 		maxVaryingComponents = 60
 	}
 
@@ -471,7 +514,7 @@ func queryLimits(glCtx *gl.Context, glMajor, glMinor int, isES bool, exts map[st
 		MaxBufferSize:                             1<<31 - 1, // i32::MAX
 		MaxVertexAttributes:                       uint32(maxVertexAttribs),
 		MaxVertexBufferArrayStride:                uint32(maxVertexStride),
-		MaxInterStageShaderVariables:              uint32(maxVaryingComponents),
+		MaxInterStageShaderVariables:              uint32(maxVaryingComponents / 4), // vec4 slots
 		MaxColorAttachments:                       uint32(maxColorAttachments),
 		MaxColorAttachmentBytesPerSample:          uint32(maxColorAttachments) * 16, // 16 bytes max per attachment
 		MaxComputeWorkgroupStorageSize:            uint32(computeSharedMem),
@@ -516,6 +559,12 @@ func queryDownlevelFlags(glCtx *gl.Context, exts map[string]bool, glMajor, glMin
 		flags |= hal.DownlevelFlagsBaseVertexBaseInstance
 	}
 
+	// Cube map arrays: ES 3.2+ / GL 4.0+
+	if glVersionAtLeast(glMajor, glMinor, isES, [2]int{3, 2}, [2]int{4, 0}) ||
+		hasExtension(exts, "GL_EXT_texture_cube_map_array", "GL_ARB_texture_cube_map_array") {
+		flags |= hal.DownlevelFlagsCubeArrayTextures
+	}
+
 	// Anisotropic filtering
 	if hasExtension(exts, "EXT_texture_filter_anisotropic", "GL_EXT_texture_filter_anisotropic") {
 		var maxAniso int32
@@ -528,6 +577,86 @@ func queryDownlevelFlags(glCtx *gl.Context, exts map[string]bool, glMajor, glMin
 	return flags
 }
 
+// queryVertexFormatSupport reports which vertex formats glVertexAttribPointer
+// accepts, and how many storage blocks vertex shaders can read for pulling
+// the rest.
+func queryVertexFormatSupport(glCtx *gl.Context, exts map[string]bool, glMajor, glMinor int, isES bool) vertexFormatSupport {
+	support := vertexFormatSupport{
+		halfFloat: glVersionAtLeast(glMajor, glMinor, isES, [2]int{3, 0}, [2]int{3, 0}) ||
+			hasExtension(exts, "GL_ARB_half_float_vertex", "GL_OES_vertex_half_float"),
+		packed1010102: glVersionAtLeast(glMajor, glMinor, isES, [2]int{3, 0}, [2]int{3, 3}) ||
+			hasExtension(exts, "GL_ARB_vertex_type_2_10_10_10_rev"),
+		maxVertexAttributes: uint32(getGLInt(glCtx, gl.MAX_VERTEX_ATTRIBS, 16)), //nolint:gosec // getGLInt returns a positive value
+	}
+	if glVersionAtLeast(glMajor, glMinor, isES, [2]int{3, 1}, [2]int{4, 3}) ||
+		hasExtension(exts, "GL_ARB_shader_storage_buffer_object") {
+		glCtx.GetIntegerv(gl.MAX_VERTEX_SHADER_STORAGE_BLOCKS, &support.vertexStorageBlocks)
+	}
+	return support
+}
+
+// queryFormatSampleCounts asks the driver which sample counts each renderable
+// format supports. GL_MAX_SAMPLES is only an upper bound: integer formats are
+// limited by GL_MAX_INTEGER_SAMPLES and drivers may leave out counts for
+// individual formats. Returns nil if glGetInternalformativ is unavailable
+// (desktop GL before 4.2 without GL_ARB_internalformat_query).
+// Follows Rust wgpu-hal adapter.rs texture_format_capabilities.
+func queryFormatSampleCounts(glCtx *gl.Context, caps *AdapterCapabilities) map[gputypes.TextureFormat]uint32 {
+	if !glVersionAtLeast(caps.GLMajor, caps.GLMinor, caps.IsES, [2]int{3, 0}, [2]int{4, 2}) &&
+		!hasExtension(caps.Extensions, "GL_ARB_internalformat_query") {
+		return nil
+	}
+
+	// Drop errors left by earlier probes so they are not charged to a format.
+	for range 8 {
+		if glCtx.GetError() == gl.NO_ERROR {
+			break
+		}
+	}
+
+	counts := make(map[gputypes.TextureFormat]uint32)
+	for f := gputypes.TextureFormatR8Unorm; f <= gputypes.TextureFormatASTC12x12UnormSrgb; f++ {
+		flags := queryTextureFormatCapabilities(f, caps.Features, hal.SampleCount1, caps.Extensions).Flags
+		if flags&hal.TextureFormatCapabilityRenderAttachment == 0 {
+			continue
+		}
+		internalFormat, _, _ := textureFormatToGL(f)
+		if internalFormat == 0 {
+			continue
+		}
+
+		num := []int32{0}
+		if !glCtx.GetInternalformativ(gl.RENDERBUFFER, internalFormat, gl.NUM_SAMPLE_COUNTS, num) {
+			return nil
+		}
+		if glCtx.GetError() != gl.NO_ERROR || num[0] <= 0 {
+			counts[f] = hal.SampleCount1
+			continue
+		}
+		samples := make([]int32, num[0])
+		glCtx.GetInternalformativ(gl.RENDERBUFFER, internalFormat, gl.SAMPLES, samples)
+
+		mask := hal.SampleCount1
+		for _, s := range samples {
+			if s > 0 && s <= int32(hal.SampleCount16) && s&(s-1) == 0 {
+				mask |= uint32(s)
+			}
+		}
+		counts[f] = mask
+	}
+	return counts
+}
+
+// sampleCounts returns the sample counts the driver supports for format: the
+// per-format GL_SAMPLES answer when available, otherwise every power of two up
+// to GL_MAX_SAMPLES.
+func (c *AdapterCapabilities) sampleCounts(format gputypes.TextureFormat) uint32 {
+	if c.FormatSampleCounts != nil {
+		return c.FormatSampleCounts[format] | hal.SampleCount1
+	}
+	return hal.SampleCount1 | hal.SampleCountsUpTo(uint32(max(c.MaxMSAASamples, 0)))
+}
+
 // ---------------------------------------------------------------------------
 // Device type inference
 // ---------------------------------------------------------------------------
@@ -629,17 +758,17 @@ func inferVendorID(vendor string) uint32 {
 //
 // Follows Rust wgpu-hal adapter.rs texture_format_capabilities, matching the
 // OpenGL ES 3.0 spec table 3.8 (base types) and table 8.26 (image stores).
+// sampleCounts is the SampleCounts mask the driver reports for the format
+// (see AdapterCapabilities.sampleCounts).
 func queryTextureFormatCapabilities(
 	format gputypes.TextureFormat,
 	features gputypes.Features,
-	maxMSAA int32,
+	sampleCounts uint32,
 	exts map[string]bool,
 ) hal.TextureFormatCapabilities {
-	// MSAA capability tier based on GL_MAX_SAMPLES.
-	// GL ES 3.0 guarantees at least 4x. Drivers may report 0 (e.g., iOS Safari),
-	// in which case we still advertise 4x as a safe baseline (Rust wgpu-hal parity).
+	// MSAA capability follows the driver's answer for this format.
 	var msaa hal.TextureFormatCapabilityFlags
-	if maxMSAA >= 4 || maxMSAA == 0 {
+	if sampleCounts&^hal.SampleCount1 != 0 {
 		msaa = hal.TextureFormatCapabilityMultisample | hal.TextureFormatCapabilityMultisampleResolve
 	}
 
@@ -786,7 +915,11 @@ func queryTextureFormatCapabilities(
 		flags = 0
 	}
 
-	return hal.TextureFormatCapabilities{Flags: flags}
+	counts := hal.SampleCount1
+	if flags&hal.TextureFormatCapabilityMultisample != 0 {
+		counts |= sampleCounts
+	}
+	return hal.TextureFormatCapabilities{Flags: flags, SampleCounts: counts}
 }
 
 // ---------------------------------------------------------------------------
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
 )
 
 func TestParseGLVersion(t *testing.T) {
@@ -211,3 +212,53 @@ func TestGlVersionAtLeast(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryTextureFormatSampleCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   gputypes.TextureFormat
+		counts   uint32
+		want     uint32
+		wantMSAA bool
+	}{
+		{"driver 1,2,4,8", gputypes.TextureFormatRGBA8Unorm, hal.SampleCountsUpTo(8), hal.SampleCountsUpTo(8), true},
+		{"driver 1,4", gputypes.TextureFormatDepth32Float, hal.SampleCount1 | hal.SampleCount4, hal.SampleCount1 | hal.SampleCount4, true},
+		{"driver 1 only", gputypes.TextureFormatRGBA8Uint, hal.SampleCount1, hal.SampleCount1, false},
+		{"not multisampleable", gputypes.TextureFormatRGBA8Snorm, hal.SampleCountsUpTo(8), hal.SampleCount1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caps := queryTextureFormatCapabilities(tt.format, 0, tt.counts, nil)
+			if caps.SampleCounts != tt.want {
+				t.Errorf("SampleCounts = %#x, want %#x", caps.SampleCounts, tt.want)
+			}
+			if got := caps.Flags&hal.TextureFormatCapabilityMultisample != 0; got != tt.wantMSAA {
+				t.Errorf("Multisample = %v, want %v", got, tt.wantMSAA)
+			}
+		})
+	}
+}
+
+func TestAdapterCapabilitiesSampleCounts(t *testing.T) {
+	perFormat := AdapterCapabilities{
+		MaxMSAASamples: 16,
+		FormatSampleCounts: map[gputypes.TextureFormat]uint32{
+			gputypes.TextureFormatRGBA8Unorm: hal.SampleCount1 | hal.SampleCount2 | hal.SampleCount4,
+		},
+	}
+	if got, want := perFormat.sampleCounts(gputypes.TextureFormatRGBA8Unorm), hal.SampleCountsUpTo(4); got != want {
+		t.Errorf("reported format: sampleCounts = %#x, want %#x", got, want)
+	}
+	if got := perFormat.sampleCounts(gputypes.TextureFormatRGBA8Snorm); got != hal.SampleCount1 {
+		t.Errorf("unreported format: sampleCounts = %#x, want %#x", got, hal.SampleCount1)
+	}
+
+	// Without the per-format query, GL_MAX_SAMPLES is the only answer.
+	fallback := AdapterCapabilities{MaxMSAASamples: 8}
+	if got, want := fallback.sampleCounts(gputypes.TextureFormatRGBA8Unorm), hal.SampleCountsUpTo(8); got != want {
+		t.Errorf("fallback: sampleCounts = %#x, want %#x", got, want)
+	}
+	if got := (&AdapterCapabilities{}).sampleCounts(gputypes.TextureFormatRGBA8Unorm); got != hal.SampleCount1 {
+		t.Errorf("no MSAA: sampleCounts = %#x, want %#x", got, hal.SampleCount1)
+	}
+}
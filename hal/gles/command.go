@@ -15,9 +15,10 @@ import (
 	"github.com/gogpu/wgpu/hal/gles/gl"
 )
 
-// Command represents a recorded GL command.
+// Command represents a recorded GL command. Commands set shadowed state
+// through state, which may be nil, so that replay skips redundant GL calls.
 type Command interface {
-	Execute(ctx *gl.Context)
+	Execute(ctx *gl.Context, state *stateCache)
 }
 
 // CommandBuffer holds recorded commands for later execution.
@@ -76,7 +77,7 @@ func (e *CommandEncoder) Destroy() {}
 func (e *CommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) {
 	var bits uint32
 	for _, bar := range barriers {
-		if bar.Usage.OldUsage&gputypes.BufferUsageStorage != 0 {
+		if bar.Usage.OldUsage&hal.BufferUseStorageReadWrite != 0 {
 			bits |= gl.SHADER_STORAGE_BARRIER_BIT | gl.BUFFER_UPDATE_BARRIER_BIT |
 				gl.VERTEX_ATTRIB_ARRAY_BARRIER_BIT | gl.ELEMENT_ARRAY_BARRIER_BIT |
 				gl.UNIFORM_BARRIER_BIT | gl.COMMAND_BARRIER_BIT
@@ -244,6 +245,8 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 	// Reference wgpu sets viewport at render pass start — required for correct rendering.
 	if len(desc.ColorAttachments) > 0 {
 		e.setupColorAttachment(desc, rpe)
+	} else if desc.DepthStencilAttachment != nil {
+		e.setupDepthOnlyTarget(desc.DepthStencilAttachment, rpe)
 	}
 
 	// Record clear commands
@@ -369,6 +372,22 @@ func (e *CommandEncoder) setupOffscreenTarget(
 	}
 }
 
+// setupDepthOnlyTarget configures the framebuffer and viewport of a pass
+// without color attachments, such as a shadow map pass.
+func (e *CommandEncoder) setupDepthOnlyTarget(dsa *hal.RenderPassDepthStencilAttachment, rpe *RenderPassEncoder) {
+	dsView, ok := dsa.View.(*TextureView)
+	if !ok || dsView.texture == nil {
+		return
+	}
+	e.commands = append(e.commands, &EnsureDepthOnlyFBOCommand{texture: dsView.texture})
+
+	rpe.fbHeight = dsView.texture.size.Height
+	e.commands = append(e.commands, &SetViewportCommand{
+		width:  float32(dsView.texture.size.Width),
+		height: float32(dsView.texture.size.Height),
+	})
+}
+
 // BeginComputePass begins a compute pass.
 func (e *CommandEncoder) BeginComputePass(desc *hal.ComputePassDescriptor) hal.ComputePassEncoder {
 	cpe := &ComputePassEncoder{
@@ -408,11 +427,17 @@ type RenderPassEncoder struct {
 	desc          *hal.RenderPassDescriptor
 	pipeline      *RenderPipeline
 	vertexBuffers []*Buffer
+	vertexOffsets []uint64
 	indexBuffer   *Buffer
 	indexFormat   gputypes.IndexFormat
 	stencilRef    uint32
 	fbHeight      uint32 // Framebuffer height for MSAA resolve blit Y-flip
 
+	// instanceBase is the firstInstance the instance-step vertex buffers are
+	// currently bound for. GLES has no base instance, so per-instance
+	// attributes are offset by instanceBase * ArrayStride instead.
+	instanceBase uint32
+
 	// MSAA resolve state: set during BeginRenderPass when ResolveTarget is present.
 	msaaTexture      *Texture // The MSAA color texture (source for resolve)
 	resolveTexture   *Texture // The single-sample resolve target (nil when resolveToSurface)
@@ -494,6 +519,7 @@ func (e *RenderPassEncoder) SetPipeline(pipeline hal.RenderPipeline) {
 	if !ok {
 		return
 	}
+	changed := e.pipeline != p
 	e.pipeline = p
 	e.encoder.commands = append(e.encoder.commands,
 		&UseProgramCommand{programID: p.programID},
@@ -507,6 +533,11 @@ func (e *RenderPassEncoder) SetPipeline(pipeline hal.RenderPipeline) {
 			stencilRef:     e.stencilRef,
 		},
 	)
+	// Attribute setup depends on the pipeline's vertex layouts, so buffers
+	// bound before this pipeline are configured again.
+	if changed {
+		e.rebindVertexBuffers(false)
+	}
 }
 
 // SetBindGroup sets a bind group.
@@ -537,34 +568,74 @@ func (e *RenderPassEncoder) SetBindGroup(index uint32, group hal.BindGroup, offs
 // In OpenGL, vertex attribute configuration (glVertexAttribPointer +
 // glEnableVertexAttribArray) must be done explicitly. The layout is taken
 // from the currently bound render pipeline's vertex buffer descriptors.
-func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset uint64) {
+// GL attribute pointers take no size; core has checked it.
+func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok {
 		return
 	}
 
-	// Grow slice if needed
+	// Grow slices if needed
 	for len(e.vertexBuffers) <= int(slot) {
 		e.vertexBuffers = append(e.vertexBuffers, nil)
+		e.vertexOffsets = append(e.vertexOffsets, 0)
 	}
 	e.vertexBuffers[slot] = buf
+	e.vertexOffsets[slot] = offset
+	e.bindVertexBuffer(slot)
+}
 
-	// Get vertex layout from the current pipeline for this slot.
+// bindVertexBuffer records the binding and attribute setup of slot using the
+// current pipeline's layout. Instance-step buffers start at instanceBase.
+func (e *RenderPassEncoder) bindVertexBuffer(slot uint32) {
 	var layout *gputypes.VertexBufferLayout
 	if e.pipeline != nil && int(slot) < len(e.pipeline.vertexBuffers) {
 		layout = &e.pipeline.vertexBuffers[slot]
 	}
-
+	offset := e.vertexOffsets[slot]
+	if layout != nil && layout.StepMode == gputypes.VertexStepModeInstance {
+		offset += uint64(e.instanceBase) * layout.ArrayStride
+	}
+	var pull *pulledVertexBuffer
+	if e.pipeline != nil {
+		pull = e.pipeline.vertexPulling.buffer(slot)
+	}
 	e.encoder.commands = append(e.encoder.commands, &SetVertexBufferCommand{
 		slot:   slot,
-		buffer: buf,
+		buffer: e.vertexBuffers[slot],
 		offset: offset,
 		layout: layout,
+		pull:   pull,
 	})
 }
 
+// rebindVertexBuffers binds every bound vertex buffer again, or only the
+// instance-step ones when instanceOnly is set.
+func (e *RenderPassEncoder) rebindVertexBuffers(instanceOnly bool) {
+	for slot, buf := range e.vertexBuffers {
+		if buf == nil {
+			continue
+		}
+		if instanceOnly && (e.pipeline == nil || slot >= len(e.pipeline.vertexBuffers) ||
+			e.pipeline.vertexBuffers[slot].StepMode != gputypes.VertexStepModeInstance) {
+			continue
+		}
+		e.bindVertexBuffer(uint32(slot)) //nolint:gosec // slot count is bounded by MaxVertexBuffers
+	}
+}
+
+// setInstanceBase offsets the instance-step vertex buffers for a draw
+// starting at firstInstance.
+func (e *RenderPassEncoder) setInstanceBase(firstInstance uint32) {
+	if firstInstance == e.instanceBase {
+		return
+	}
+	e.instanceBase = firstInstance
+	e.rebindVertexBuffers(true)
+}
+
 // SetIndexBuffer sets the index buffer.
-func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok {
 		return
@@ -620,9 +691,12 @@ func (e *RenderPassEncoder) SetStencilReference(ref uint32) {
 
 // Draw draws primitives.
 func (e *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	e.setInstanceBase(firstInstance)
 	topology := gputypes.PrimitiveTopologyTriangleList // default
+	polygonMode := hal.PolygonModeFill
 	if e.pipeline != nil {
 		topology = e.pipeline.primitiveTopology
+		polygonMode = e.pipeline.polygonMode
 	}
 	e.encoder.commands = append(e.encoder.commands, &DrawCommand{
 		vertexCount:   vertexCount,
@@ -630,14 +704,18 @@ func (e *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstI
 		firstVertex:   firstVertex,
 		firstInstance: firstInstance,
 		topology:      topology,
+		polygonMode:   polygonMode,
 	})
 }
 
 // DrawIndexed draws indexed primitives.
 func (e *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	e.setInstanceBase(firstInstance)
 	topology := gputypes.PrimitiveTopologyTriangleList // default
+	polygonMode := hal.PolygonModeFill
 	if e.pipeline != nil {
 		topology = e.pipeline.primitiveTopology
+		polygonMode = e.pipeline.polygonMode
 	}
 	e.encoder.commands = append(e.encoder.commands, &DrawIndexedCommand{
 		indexCount:    indexCount,
@@ -647,6 +725,7 @@ func (e *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex ui
 		firstInstance: firstInstance,
 		indexFormat:   e.indexFormat,
 		topology:      topology,
+		polygonMode:   polygonMode,
 	})
 }
 
@@ -750,7 +829,7 @@ type MemoryBarrierCommand struct {
 	barriers uint32
 }
 
-func (c *MemoryBarrierCommand) Execute(ctx *gl.Context) {
+func (c *MemoryBarrierCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.MemoryBarrier(c.barriers)
 }
 
@@ -761,7 +840,7 @@ type ClearBufferCommand struct {
 	size   uint64
 }
 
-func (c *ClearBufferCommand) Execute(_ *gl.Context) {
+func (c *ClearBufferCommand) Execute(_ *gl.Context, _ *stateCache) {
 	// Note: glClearBufferSubData requires GL 4.3+ / GLES 3.1+.
 	// For older versions, map buffer and memset, or use compute shader.
 }
@@ -771,8 +850,8 @@ type BindVAOCommand struct {
 	vao uint32
 }
 
-func (c *BindVAOCommand) Execute(ctx *gl.Context) {
-	ctx.BindVertexArray(c.vao)
+func (c *BindVAOCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.bindVertexArray(ctx, c.vao)
 }
 
 // BindFramebufferCommand binds a framebuffer object.
@@ -780,7 +859,7 @@ type BindFramebufferCommand struct {
 	fbo uint32
 }
 
-func (c *BindFramebufferCommand) Execute(ctx *gl.Context) {
+func (c *BindFramebufferCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.BindFramebuffer(gl.FRAMEBUFFER, c.fbo)
 }
 
@@ -792,7 +871,7 @@ type BindSurfaceFramebufferCommand struct {
 	surface *Surface
 }
 
-func (c *BindSurfaceFramebufferCommand) Execute(ctx *gl.Context) {
+func (c *BindSurfaceFramebufferCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	if c.surface == nil {
 		ctx.BindFramebuffer(gl.FRAMEBUFFER, 0)
 		return
@@ -806,7 +885,7 @@ type EnsureOffscreenFBOCommand struct {
 	texture *Texture
 }
 
-func (c *EnsureOffscreenFBOCommand) Execute(ctx *gl.Context) {
+func (c *EnsureOffscreenFBOCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	if c.texture.fbo == 0 {
 		// Create FBO.
 		fbo := ctx.GenFramebuffers(1)
@@ -836,7 +915,7 @@ type AttachDepthStencilCommand struct {
 	depthTexture *Texture
 }
 
-func (c *AttachDepthStencilCommand) Execute(ctx *gl.Context) {
+func (c *AttachDepthStencilCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	if c.colorTexture.fbo == 0 {
 		return // No FBO was created; nothing to attach to.
 	}
@@ -847,6 +926,39 @@ func (c *AttachDepthStencilCommand) Execute(ctx *gl.Context) {
 	ctx.FramebufferTexture2D(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, c.depthTexture.target, c.depthTexture.id, 0)
 }
 
+// EnsureDepthOnlyFBOCommand lazily creates and binds a framebuffer object
+// with a depth/stencil texture as its only attachment.
+type EnsureDepthOnlyFBOCommand struct {
+	texture *Texture
+}
+
+func (c *EnsureDepthOnlyFBOCommand) Execute(ctx *gl.Context, _ *stateCache) {
+	if c.texture.fbo != 0 {
+		ctx.BindFramebuffer(gl.FRAMEBUFFER, c.texture.fbo)
+		return
+	}
+	attachment := uint32(gl.DEPTH_STENCIL_ATTACHMENT)
+	switch {
+	case !c.texture.format.HasStencil():
+		attachment = gl.DEPTH_ATTACHMENT
+	case !c.texture.format.HasDepth():
+		attachment = gl.STENCIL_ATTACHMENT
+	}
+	fbo := ctx.GenFramebuffers(1)
+	ctx.BindFramebuffer(gl.FRAMEBUFFER, fbo)
+	ctx.FramebufferTexture2D(gl.FRAMEBUFFER, attachment, c.texture.target, c.texture.id, 0)
+	// GL before 4.1 reports a framebuffer without color attachments as
+	// incomplete unless its draw and read buffers are GL_NONE.
+	ctx.DrawBuffers([]uint32{gl.NONE})
+	ctx.ReadBuffer(gl.NONE)
+	if ctx.CheckFramebufferStatus(gl.FRAMEBUFFER) != gl.FRAMEBUFFER_COMPLETE {
+		ctx.DeleteFramebuffers(fbo)
+		ctx.BindFramebuffer(gl.FRAMEBUFFER, 0)
+		return
+	}
+	c.texture.fbo = fbo
+}
+
 // MSAAResolveCommand resolves an MSAA framebuffer to a single-sample framebuffer
 // using glBlitFramebuffer. This is recorded at render pass End() when a
 // ResolveTarget is specified in the color attachment.
@@ -864,11 +976,11 @@ type MSAAResolveCommand struct {
 	width, height    int32
 }
 
-func (c *MSAAResolveCommand) Execute(ctx *gl.Context) {
+func (c *MSAAResolveCommand) Execute(ctx *gl.Context, state *stateCache) {
 	// Disable scissor test before blit — glBlitFramebuffer respects GL_SCISSOR_TEST
 	// on the draw framebuffer. Without this, only the last scissor rect's pixels
 	// are copied, leaving the rest of the surface black (gg#226).
-	ctx.Disable(gl.SCISSOR_TEST)
+	state.setCapability(ctx, gl.SCISSOR_TEST, false)
 
 	// Bind MSAA FBO as read source.
 	ctx.BindFramebuffer(gl.READ_FRAMEBUFFER, c.msaaTexture.fbo)
@@ -924,8 +1036,8 @@ type ClearColorCommand struct {
 	r, g, b, a float32
 }
 
-func (c *ClearColorCommand) Execute(ctx *gl.Context) {
-	ctx.Disable(gl.SCISSOR_TEST) // Ensure clear covers full framebuffer (not clipped by stale scissor)
+func (c *ClearColorCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.setCapability(ctx, gl.SCISSOR_TEST, false) // Ensure clear covers full framebuffer (not clipped by stale scissor)
 	ctx.ClearColor(c.r, c.g, c.b, c.a)
 	ctx.Clear(gl.COLOR_BUFFER_BIT)
 }
@@ -935,8 +1047,8 @@ type ClearDepthCommand struct {
 	depth float64
 }
 
-func (c *ClearDepthCommand) Execute(ctx *gl.Context) {
-	ctx.Disable(gl.SCISSOR_TEST)
+func (c *ClearDepthCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.setCapability(ctx, gl.SCISSOR_TEST, false)
 	ctx.Clear(gl.DEPTH_BUFFER_BIT)
 }
 
@@ -945,8 +1057,8 @@ type ClearStencilCommand struct {
 	stencil int32
 }
 
-func (c *ClearStencilCommand) Execute(ctx *gl.Context) {
-	ctx.Disable(gl.SCISSOR_TEST)
+func (c *ClearStencilCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.setCapability(ctx, gl.SCISSOR_TEST, false)
 	// Ensure stencil write mask allows the clear to take effect.
 	ctx.StencilMaskSeparate(gl.FRONT_AND_BACK, 0xFF)
 	ctx.Clear(gl.STENCIL_BUFFER_BIT)
@@ -957,8 +1069,8 @@ type UseProgramCommand struct {
 	programID uint32
 }
 
-func (c *UseProgramCommand) Execute(ctx *gl.Context) {
-	ctx.UseProgram(c.programID)
+func (c *UseProgramCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.useProgram(ctx, c.programID)
 }
 
 // SetPipelineStateCommand sets pipeline state (culling, depth, stencil, blending, color mask).
@@ -972,17 +1084,17 @@ type SetPipelineStateCommand struct {
 	stencilRef     uint32
 }
 
-func (c *SetPipelineStateCommand) Execute(ctx *gl.Context) {
+func (c *SetPipelineStateCommand) Execute(ctx *gl.Context, state *stateCache) {
 	// Culling
 	if c.cullMode == gputypes.CullModeNone {
-		ctx.Disable(gl.CULL_FACE)
+		state.setCapability(ctx, gl.CULL_FACE, false)
 	} else {
-		ctx.Enable(gl.CULL_FACE)
+		state.setCapability(ctx, gl.CULL_FACE, true)
 		switch c.cullMode {
 		case gputypes.CullModeFront:
-			ctx.CullFace(gl.FRONT)
+			state.setCullFace(ctx, gl.FRONT)
 		case gputypes.CullModeBack:
-			ctx.CullFace(gl.BACK)
+			state.setCullFace(ctx, gl.BACK)
 		}
 	}
 
@@ -992,16 +1104,16 @@ func (c *SetPipelineStateCommand) Execute(ctx *gl.Context) {
 	// Matches Rust wgpu-hal GLES (conv.rs:298-303).
 	switch c.frontFace {
 	case gputypes.FrontFaceCCW:
-		ctx.FrontFace(gl.CW)
+		state.setFrontFace(ctx, gl.CW)
 	case gputypes.FrontFaceCW:
-		ctx.FrontFace(gl.CCW)
+		state.setFrontFace(ctx, gl.CCW)
 	}
 
 	// Depth and stencil
-	c.applyDepthStencilState(ctx)
+	c.applyDepthStencilState(ctx, state)
 
 	// Color write mask
-	ctx.ColorMask(
+	state.setColorMask(ctx,
 		c.colorWriteMask&gputypes.ColorWriteMaskRed != 0,
 		c.colorWriteMask&gputypes.ColorWriteMaskGreen != 0,
 		c.colorWriteMask&gputypes.ColorWriteMaskBlue != 0,
@@ -1010,53 +1122,55 @@ func (c *SetPipelineStateCommand) Execute(ctx *gl.Context) {
 
 	// Blending
 	if c.blend != nil {
-		ctx.Enable(gl.BLEND)
-		ctx.BlendFuncSeparate(
+		state.setCapability(ctx, gl.BLEND, true)
+		state.blendFuncSeparate(ctx,
 			blendFactorToGL(c.blend.Color.SrcFactor),
 			blendFactorToGL(c.blend.Color.DstFactor),
 			blendFactorToGL(c.blend.Alpha.SrcFactor),
 			blendFactorToGL(c.blend.Alpha.DstFactor),
 		)
-		ctx.BlendEquationSeparate(
+		state.blendEquationSeparate(ctx,
 			blendOperationToGL(c.blend.Color.Operation),
 			blendOperationToGL(c.blend.Alpha.Operation),
 		)
 	} else {
-		ctx.Disable(gl.BLEND)
+		state.setCapability(ctx, gl.BLEND, false)
 	}
 }
 
 // applyDepthStencilState configures GL depth test and stencil test from pipeline state.
-func (c *SetPipelineStateCommand) applyDepthStencilState(ctx *gl.Context) {
+func (c *SetPipelineStateCommand) applyDepthStencilState(ctx *gl.Context, state *stateCache) {
 	if c.depthStencil == nil {
-		ctx.Disable(gl.DEPTH_TEST)
-		ctx.Disable(gl.STENCIL_TEST)
+		state.setCapability(ctx, gl.DEPTH_TEST, false)
+		state.setCapability(ctx, gl.STENCIL_TEST, false)
 		return
 	}
 
 	// Depth test
 	if c.depthStencil.DepthWriteEnabled || c.depthStencil.DepthCompare != gputypes.CompareFunctionAlways {
-		ctx.Enable(gl.DEPTH_TEST)
-		ctx.DepthMask(c.depthStencil.DepthWriteEnabled)
-		ctx.DepthFunc(compareFunctionToGL(c.depthStencil.DepthCompare))
+		state.setCapability(ctx, gl.DEPTH_TEST, true)
+		state.setDepthMask(ctx, c.depthStencil.DepthWriteEnabled)
+		state.setDepthFunc(ctx, compareFunctionToGL(c.depthStencil.DepthCompare))
 	} else {
-		ctx.Disable(gl.DEPTH_TEST)
+		state.setCapability(ctx, gl.DEPTH_TEST, false)
 	}
 
 	// Stencil test
 	hasStencilOps := c.depthStencil.StencilFront.PassOp != hal.StencilOperationKeep ||
 		c.depthStencil.StencilFront.FailOp != hal.StencilOperationKeep ||
+		c.depthStencil.StencilFront.DepthFailOp != hal.StencilOperationKeep ||
 		c.depthStencil.StencilBack.PassOp != hal.StencilOperationKeep ||
 		c.depthStencil.StencilBack.FailOp != hal.StencilOperationKeep ||
+		c.depthStencil.StencilBack.DepthFailOp != hal.StencilOperationKeep ||
 		c.depthStencil.StencilFront.Compare != gputypes.CompareFunctionAlways ||
 		c.depthStencil.StencilBack.Compare != gputypes.CompareFunctionAlways
 
 	if !hasStencilOps && c.depthStencil.StencilWriteMask == 0 {
-		ctx.Disable(gl.STENCIL_TEST)
+		state.setCapability(ctx, gl.STENCIL_TEST, false)
 		return
 	}
 
-	ctx.Enable(gl.STENCIL_TEST)
+	state.setCapability(ctx, gl.STENCIL_TEST, true)
 	ref := int32(c.stencilRef)
 
 	ctx.StencilFuncSeparate(gl.FRONT,
@@ -1094,7 +1208,7 @@ type SetBindGroupCommand struct {
 	samplerBindMap *[maxTextureSlots]int8
 }
 
-func (c *SetBindGroupCommand) Execute(ctx *gl.Context) {
+func (c *SetBindGroupCommand) Execute(ctx *gl.Context, state *stateCache) {
 	if c.group == nil {
 		return
 	}
@@ -1125,11 +1239,7 @@ func (c *SetBindGroupCommand) Execute(ctx *gl.Context) {
 			target, dynOff := c.resolveBufferTarget(entry.Binding, &dynamicIdx)
 			offset += dynOff
 
-			if size > 0 {
-				ctx.BindBufferRange(target, glBinding, bufID, offset, size)
-			} else {
-				ctx.BindBufferBase(target, glBinding, bufID)
-			}
+			state.bindBufferRange(ctx, target, glBinding, bufID, offset, size)
 
 		case gputypes.TextureViewBinding:
 			// TextureView handle is the GL texture object ID (from NativeHandle()).
@@ -1137,6 +1247,16 @@ func (c *SetBindGroupCommand) Execute(ctx *gl.Context) {
 			if texID == 0 {
 				continue
 			}
+			if st := c.lookupStorageTexture(entry.Binding); st != nil {
+				// Storage textures go to image units, not texture units.
+				// Views are identified by GL texture name alone, so the
+				// image is bound at level 0 with all layers.
+				internalFormat, _, _ := textureFormatToGL(st.Format)
+				ctx.BindImageTexture(glBinding, texID, 0,
+					isLayeredViewDimension(st.ViewDimension), 0,
+					storageAccessToGL(st.Access), internalFormat)
+				continue
+			}
 			// Validate texture unit index against hardware limit.
 			// Without this check, textures silently fail to bind when
 			// glBinding >= GL_MAX_TEXTURE_IMAGE_UNITS (typically 8 on Intel).
@@ -1151,8 +1271,8 @@ func (c *SetBindGroupCommand) Execute(ctx *gl.Context) {
 				}
 				continue
 			}
-			ctx.ActiveTexture(gl.TEXTURE0 + glBinding)
-			ctx.BindTexture(gl.TEXTURE_2D, texID)
+			state.activeTexture(ctx, gl.TEXTURE0+glBinding)
+			state.bindTexture(ctx, c.resolveTextureTarget(entry.Binding), texID)
 
 		case gputypes.SamplerBinding:
 			// Sampler handle is the GL sampler object ID (from NativeHandle()).
@@ -1173,7 +1293,7 @@ func (c *SetBindGroupCommand) Execute(ctx *gl.Context) {
 					"samplerID", samplerID,
 				)
 			}
-			ctx.BindSampler(bindUnit, samplerID)
+			state.bindSampler(ctx, bindUnit, samplerID)
 		}
 	}
 }
@@ -1230,6 +1350,61 @@ func (c *SetBindGroupCommand) resolveBufferTarget(binding uint32, dynamicIdx *in
 	return target, dynOffset
 }
 
+// resolveTextureTarget returns the GL target a texture bound at binding must
+// use, derived from the layout entry's view dimension. Cube and array
+// textures bound to GL_TEXTURE_2D would sample as incomplete (black).
+func (c *SetBindGroupCommand) resolveTextureTarget(binding uint32) uint32 {
+	if c.group.layout == nil {
+		return gl.TEXTURE_2D
+	}
+	for _, le := range c.group.layout.entries {
+		if le.Binding != binding || le.Texture == nil {
+			continue
+		}
+		return viewDimensionToGLTarget(le.Texture.ViewDimension, le.Texture.Multisampled)
+	}
+	return gl.TEXTURE_2D
+}
+
+// lookupStorageTexture returns the storage texture layout for binding, or
+// nil when the binding is not a storage texture.
+func (c *SetBindGroupCommand) lookupStorageTexture(binding uint32) *gputypes.StorageTextureBindingLayout {
+	if c.group.layout == nil {
+		return nil
+	}
+	for _, le := range c.group.layout.entries {
+		if le.Binding == binding {
+			return le.StorageTexture
+		}
+	}
+	return nil
+}
+
+// isLayeredViewDimension reports whether an image of this view dimension
+// must be bound layered so the shader can address every layer or slice.
+func isLayeredViewDimension(dim gputypes.TextureViewDimension) bool {
+	switch dim {
+	case gputypes.TextureViewDimension2DArray, gputypes.TextureViewDimension3D,
+		gputypes.TextureViewDimensionCube, gputypes.TextureViewDimensionCubeArray:
+		return true
+	default:
+		return false
+	}
+}
+
+// storageAccessToGL converts a storage texture access mode to the
+// glBindImageTexture access enum.
+func storageAccessToGL(access gputypes.StorageTextureAccess) uint32 {
+	switch access {
+	case gputypes.StorageTextureAccessReadOnly:
+		return gl.READ_ONLY
+	case gputypes.StorageTextureAccessReadWrite:
+		return gl.READ_WRITE
+	default:
+		return gl.WRITE_ONLY
+	}
+}
+
 // SetVertexBufferCommand binds a vertex buffer and configures vertex attributes.
 // In OpenGL, vertex attributes must be configured explicitly via
 // glVertexAttribPointer + glEnableVertexAttribArray. The layout describes
@@ -1239,11 +1414,22 @@ type SetVertexBufferCommand struct {
 	buffer *Buffer
 	offset uint64
 	layout *gputypes.VertexBufferLayout // from the render pipeline descriptor
+
+	// pull is set when the vertex shader pulls some of the buffer's
+	// attributes: the buffer is then also bound as a storage buffer, and
+	// the byte offset is passed through the pull's base attribute.
+	pull *pulledVertexBuffer
 }
 
-func (c *SetVertexBufferCommand) Execute(ctx *gl.Context) {
+func (c *SetVertexBufferCommand) Execute(ctx *gl.Context, state *stateCache) {
 	ctx.BindBuffer(gl.ARRAY_BUFFER, c.buffer.id)
 
+	if c.pull != nil {
+		state.bindBufferRange(ctx, gl.SHADER_STORAGE_BUFFER, c.pull.storageBinding, c.buffer.id, 0, 0)
+		ctx.DisableVertexAttribArray(c.pull.baseLocation)
+		ctx.VertexAttribI4ui(c.pull.baseLocation, uint32(c.offset), 0, 0, 0) //nolint:gosec // pulled buffers are addressed with 32-bit offsets
+	}
+
 	// Configure vertex attributes from the pipeline's vertex layout.
 	if c.layout == nil {
 		return
@@ -1257,6 +1443,11 @@ func (c *SetVertexBufferCommand) Execute(ctx *gl.Context) {
 	}
 	for _, attr := range c.layout.Attributes {
 		loc := attr.ShaderLocation
+		if c.pull != nil && c.pull.locations&(1<<loc) != 0 {
+			// Read by the shader from the storage buffer instead.
+			ctx.DisableVertexAttribArray(loc)
+			continue
+		}
 		size, typ, normalized := vertexFormatToGL(attr.Format)
 		attrOffset := uintptr(c.offset) + uintptr(attr.Offset)
 		ctx.EnableVertexAttribArray(loc)
@@ -1275,7 +1466,7 @@ type SetIndexBufferCommand struct {
 	offset uint64
 }
 
-func (c *SetIndexBufferCommand) Execute(ctx *gl.Context) {
+func (c *SetIndexBufferCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, c.buffer.id)
 }
 
@@ -1285,7 +1476,7 @@ type SetViewportCommand struct {
 	minDepth, maxDepth  float32
 }
 
-func (c *SetViewportCommand) Execute(ctx *gl.Context) {
+func (c *SetViewportCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.Viewport(int32(c.x), int32(c.y), int32(c.width), int32(c.height))
 }
 
@@ -1298,8 +1489,8 @@ type SetScissorCommand struct {
 	x, y, width, height uint32
 }
 
-func (c *SetScissorCommand) Execute(ctx *gl.Context) {
-	ctx.Enable(gl.SCISSOR_TEST)
+func (c *SetScissorCommand) Execute(ctx *gl.Context, state *stateCache) {
+	state.setCapability(ctx, gl.SCISSOR_TEST, true)
 	// No Y-flip: ADJUST_COORDINATE_SPACE flips the scene in the vertex shader,
 	// so GL pixel Y=0 corresponds to the top of the scene (WebGPU Y=0).
 	// The scissor rect in WebGPU coords maps directly to GL coords.
@@ -1311,7 +1502,7 @@ type SetBlendConstantCommand struct {
 	r, g, b, a float32
 }
 
-func (c *SetBlendConstantCommand) Execute(ctx *gl.Context) {
+func (c *SetBlendConstantCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.BlendColor(c.r, c.g, c.b, c.a)
 }
 
@@ -1323,7 +1514,7 @@ type SetStencilRefCommand struct {
 	depthStencil *hal.DepthStencilState
 }
 
-func (c *SetStencilRefCommand) Execute(ctx *gl.Context) {
+func (c *SetStencilRefCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	if c.depthStencil == nil {
 		return
 	}
@@ -1341,15 +1532,24 @@ type DrawCommand struct {
 	vertexCount, instanceCount uint32
 	firstVertex, firstInstance uint32
 	topology                   gputypes.PrimitiveTopology
+	polygonMode                hal.PolygonMode
 }
 
-func (c *DrawCommand) Execute(ctx *gl.Context) {
+func (c *DrawCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	mode := primitiveTopologyToGL(c.topology)
-	if c.instanceCount <= 1 {
-		ctx.DrawArrays(mode, int32(c.firstVertex), int32(c.vertexCount))
-	} else {
-		ctx.DrawArraysInstanced(mode, int32(c.firstVertex), int32(c.vertexCount), int32(c.instanceCount))
+	draw := func(mode uint32, first, count uint32) {
+		if c.instanceCount <= 1 {
+			ctx.DrawArrays(mode, int32(first), int32(count))
+		} else {
+			ctx.DrawArraysInstanced(mode, int32(first), int32(count), int32(c.instanceCount))
+		}
 	}
+	if emulatePolygonMode(c.polygonMode, c.topology, c.vertexCount, func(mode uint32, first, count uint32) {
+		draw(mode, c.firstVertex+first, count)
+	}) {
+		return
+	}
+	draw(mode, c.firstVertex, c.vertexCount)
 }
 
 // DrawIndexedCommand executes an indexed draw.
@@ -1360,9 +1560,10 @@ type DrawIndexedCommand struct {
 	firstInstance             uint32
 	indexFormat               gputypes.IndexFormat
 	topology                  gputypes.PrimitiveTopology
+	polygonMode               hal.PolygonMode
 }
 
-func (c *DrawIndexedCommand) Execute(ctx *gl.Context) {
+func (c *DrawIndexedCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	indexType := uint32(gl.UNSIGNED_SHORT)
 	indexSize := uintptr(2)
 	if c.indexFormat == gputypes.IndexFormatUint32 {
@@ -1372,12 +1573,48 @@ func (c *DrawIndexedCommand) Execute(ctx *gl.Context) {
 
 	offset := uintptr(c.firstIndex) * indexSize
 	mode := primitiveTopologyToGL(c.topology)
+	draw := func(mode uint32, offset uintptr, count uint32) {
+		if c.instanceCount <= 1 {
+			ctx.DrawElements(mode, int32(count), indexType, offset)
+		} else {
+			ctx.DrawElementsInstanced(mode, int32(count), indexType, offset, int32(c.instanceCount))
+		}
+	}
+	if emulatePolygonMode(c.polygonMode, c.topology, c.indexCount, func(mode uint32, first, count uint32) {
+		draw(mode, offset+uintptr(first)*indexSize, count)
+	}) {
+		return
+	}
+	draw(mode, offset, c.indexCount)
+}
 
-	if c.instanceCount <= 1 {
-		ctx.DrawElements(mode, int32(c.indexCount), indexType, offset)
-	} else {
-		ctx.DrawElementsInstanced(mode, int32(c.indexCount), indexType, offset, int32(c.instanceCount))
+// emulatePolygonMode issues the draws that rasterize a triangle topology in
+// line or point polygon mode, since GLES has no glPolygonMode. Line mode
+// draws each triangle as a 3-vertex GL_LINE_LOOP, point mode draws the
+// vertices as GL_POINTS. draw receives a range relative to the first
+// vertex or index. Culling does not apply to the emulated primitives.
+// Reports false, issuing nothing, when no emulation is needed.
+func emulatePolygonMode(polygonMode hal.PolygonMode, topology gputypes.PrimitiveTopology, count uint32, draw func(mode uint32, first, count uint32)) bool {
+	if polygonMode == hal.PolygonModeFill {
+		return false
 	}
+	var step uint32
+	switch topology {
+	case gputypes.PrimitiveTopologyTriangleList:
+		step = 3
+	case gputypes.PrimitiveTopologyTriangleStrip:
+		step = 1
+	default:
+		return false
+	}
+	if polygonMode == hal.PolygonModePoint {
+		draw(gl.POINTS, 0, count)
+		return true
+	}
+	for first := uint32(0); first+3 <= count; first += step {
+		draw(gl.LINE_LOOP, first, 3)
+	}
+	return true
 }
 
 // CopyBufferCommand copies between buffers.
@@ -1387,7 +1624,7 @@ type CopyBufferCommand struct {
 	size                 uint64
 }
 
-func (c *CopyBufferCommand) Execute(ctx *gl.Context) {
+func (c *CopyBufferCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.BindBuffer(gl.COPY_READ_BUFFER, c.srcID)
 	ctx.BindBuffer(gl.COPY_WRITE_BUFFER, c.dstID)
 	// glCopyBufferSubData would go here
@@ -1401,7 +1638,7 @@ type DispatchCommand struct {
 }
 
 // Execute dispatches compute work and inserts a memory barrier.
-func (c *DispatchCommand) Execute(ctx *gl.Context) {
+func (c *DispatchCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.DispatchCompute(c.x, c.y, c.z)
 	// VERTEX_ATTRIB_ARRAY_BARRIER_BIT is required when compute writes an SSBO that
 	// is later read as a vertex buffer (e.g. particles ping-pong). Without it,
@@ -1416,7 +1653,7 @@ type DispatchIndirectCommand struct {
 }
 
 // Execute dispatches compute work from indirect buffer and inserts a memory barrier.
-func (c *DispatchIndirectCommand) Execute(ctx *gl.Context) {
+func (c *DispatchIndirectCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	// Bind the buffer containing dispatch parameters
 	ctx.BindBuffer(gl.DISPATCH_INDIRECT_BUFFER, c.buffer.id)
 	// Dispatch with parameters from the buffer at the given offset
@@ -1440,7 +1677,7 @@ type CopyTextureToBufferCommand struct {
 }
 
 // Execute reads pixels from the source texture's FBO into the destination buffer.
-func (c *CopyTextureToBufferCommand) Execute(ctx *gl.Context) {
+func (c *CopyTextureToBufferCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	width := int32(c.copySize[0])
 	height := int32(c.copySize[1])
 	if width == 0 || height == 0 {
@@ -1484,11 +1721,17 @@ func (c *CopyTextureToBufferCommand) Execute(ctx *gl.Context) {
 	ctx.PixelStorei(gl.PACK_ALIGNMENT, 1)
 
 	// Read pixels from the bound FBO into a temporary CPU buffer.
+	// Read in the texture's own channel order: a fixed GL_BGRA swapped red
+	// and blue for RGBA8 textures.
+	_, format, _ := textureFormatToGL(c.srcTexture.format)
+	if format != gl.BGRA {
+		format = gl.RGBA
+	}
 	tmpBuf := make([]byte, totalBytes)
 	ctx.ReadPixels(
 		int32(c.srcOrigin[0]), int32(c.srcOrigin[1]),
 		width, height,
-		gl.BGRA, gl.UNSIGNED_BYTE,
+		format, gl.UNSIGNED_BYTE,
 		unsafe.Pointer(&tmpBuf[0]),
 	)
 
@@ -1519,7 +1762,7 @@ type CopyBufferToTextureCommand struct {
 	bufOffset uint64
 }
 
-func (c *CopyBufferToTextureCommand) Execute(ctx *gl.Context) {
+func (c *CopyBufferToTextureCommand) Execute(ctx *gl.Context, state *stateCache) {
 	width := int32(c.copySize[0])
 	height := int32(c.copySize[1])
 	if width == 0 || height == 0 {
@@ -1532,7 +1775,7 @@ func (c *CopyBufferToTextureCommand) Execute(ctx *gl.Context) {
 	ctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, c.srcBuffer.id)
 
 	// Bind destination texture.
-	ctx.BindTexture(c.dstTex.target, c.dstTex.id)
+	state.bindTexture(ctx, c.dstTex.target, c.dstTex.id)
 
 	// Set pixel alignment to 1 for formats whose rows may not be 4-byte aligned.
 	ctx.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
@@ -1549,7 +1792,7 @@ func (c *CopyBufferToTextureCommand) Execute(ctx *gl.Context) {
 	// Restore defaults.
 	ctx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
 	ctx.BindBuffer(gl.PIXEL_UNPACK_BUFFER, 0)
-	ctx.BindTexture(c.dstTex.target, 0)
+	state.bindTexture(ctx, c.dstTex.target, 0)
 }
 
 // CopyTextureToTextureCommand copies pixels between textures using an FBO.
@@ -1566,7 +1809,7 @@ type CopyTextureToTextureCommand struct {
 	dstMip    uint32
 }
 
-func (c *CopyTextureToTextureCommand) Execute(ctx *gl.Context) {
+func (c *CopyTextureToTextureCommand) Execute(ctx *gl.Context, state *stateCache) {
 	width := int32(c.copySize[0])
 	height := int32(c.copySize[1])
 	if width == 0 || height == 0 {
@@ -1584,12 +1827,12 @@ func (c *CopyTextureToTextureCommand) Execute(ctx *gl.Context) {
 		c.srcTex.target, c.srcTex.id, int32(c.srcMip))
 
 	// Bind destination texture and copy pixels from the read framebuffer.
-	ctx.BindTexture(c.dstTex.target, c.dstTex.id)
+	state.bindTexture(ctx, c.dstTex.target, c.dstTex.id)
 	ctx.CopyTexSubImage2D(c.dstTex.target, int32(c.dstMip),
 		int32(c.dstOrigin[0]), int32(c.dstOrigin[1]),
 		int32(c.srcOrigin[0]), int32(c.srcOrigin[1]),
 		width, height)
-	ctx.BindTexture(c.dstTex.target, 0)
+	state.bindTexture(ctx, c.dstTex.target, 0)
 
 	// Clean up temporary FBO.
 	ctx.DeleteFramebuffers(readFBO)
@@ -1609,7 +1852,7 @@ type ResolveQuerySetCommand struct {
 	dstOffset  uint64
 }
 
-func (c *ResolveQuerySetCommand) Execute(ctx *gl.Context) {
+func (c *ResolveQuerySetCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	if c.querySet == nil || len(c.querySet.queries) == 0 {
 		return
 	}
@@ -1641,7 +1884,7 @@ type TimestampQueryCommand struct {
 	query uint32 // GL query object ID
 }
 
-func (c *TimestampQueryCommand) Execute(ctx *gl.Context) {
+func (c *TimestampQueryCommand) Execute(ctx *gl.Context, _ *stateCache) {
 	ctx.QueryCounter(c.query, gl.TIMESTAMP)
 }
 
@@ -1704,6 +1947,12 @@ func vertexFormatToGL(format gputypes.VertexFormat) (size int32, typ uint32, nor
 		return 3, gl.INT, false
 	case gputypes.VertexFormatSint32x4:
 		return 4, gl.INT, false
+	case gputypes.VertexFormatFloat16x2:
+		return 2, gl.HALF_FLOAT, false
+	case gputypes.VertexFormatFloat16x4:
+		return 4, gl.HALF_FLOAT, false
+	case gputypes.VertexFormatUnorm1010102:
+		return 4, gl.UNSIGNED_INT_2_10_10_10_REV, true
 	default:
 		return 4, gl.FLOAT, false
 	}
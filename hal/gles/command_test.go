@@ -177,7 +177,7 @@ func TestRenderPassEncoder_DrawIndexed(t *testing.T) {
 
 	// Set index format
 	idxBuf := &Buffer{id: 5}
-	rpe.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint32, 0)
+	rpe.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint32, 0, 0)
 
 	rpe.DrawIndexed(36, 2, 0, 0, 0)
 
@@ -328,7 +328,7 @@ func TestRenderPassEncoder_SetVertexBuffer(t *testing.T) {
 	rpe := enc.BeginRenderPass(desc).(*RenderPassEncoder)
 
 	buf := &Buffer{id: 10}
-	rpe.SetVertexBuffer(0, buf, 64)
+	rpe.SetVertexBuffer(0, buf, 64, 0)
 
 	if len(rpe.vertexBuffers) != 1 {
 		t.Errorf("vertexBuffers length = %d, want 1", len(rpe.vertexBuffers))
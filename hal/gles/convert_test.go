@@ -277,6 +277,11 @@ func TestVertexFormatToGL(t *testing.T) {
 		{"Sint32x3", gputypes.VertexFormatSint32x3, 3, gl.INT, false},
 		{"Sint32x4", gputypes.VertexFormatSint32x4, 4, gl.INT, false},
 
+		// Half floats and packed formats
+		{"Float16x2", gputypes.VertexFormatFloat16x2, 2, gl.HALF_FLOAT, false},
+		{"Float16x4", gputypes.VertexFormatFloat16x4, 4, gl.HALF_FLOAT, false},
+		{"Unorm1010102", gputypes.VertexFormatUnorm1010102, 4, gl.UNSIGNED_INT_2_10_10_10_REV, true},
+
 		// Unknown defaults to Float32x4
 		{"Unknown", gputypes.VertexFormat(255), 4, gl.FLOAT, false},
 	}
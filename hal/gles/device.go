@@ -35,6 +35,21 @@ type Device struct {
 	// be assigned at runtime after linking via glGetUniformBlockIndex etc.
 	// Mirrors Rust wgpu-hal PrivateCapabilities::SHADER_BINDING_LAYOUT.
 	shaderBindingLayout bool
+
+	// boundsChecks are the naga bounds checks compiled into every shader;
+	// set for devices opened with hal.FeatureRobustBufferAccess.
+	boundsChecks glsl.BoundsCheckPolicies
+
+	// implicitLod selects the implicit-LOD samples rewritten to explicit
+	// ones before shaders are translated to GLSL.
+	implicitLod hal.ImplicitLodRewrite
+
+	// vertexFormats records the vertex formats the context fetches
+	// natively; pipelines using others pull them in the vertex shader.
+	vertexFormats vertexFormatSupport
+
+	// log is the owning instance's logger configuration.
+	log *hal.Log
 }
 
 // CreateBuffer creates a GPU buffer.
@@ -169,36 +184,7 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (hal.Texture, error) {
 		sampleCount = 1
 	}
 
-	// Map dimension to GL target
-	target := uint32(gl.TEXTURE_2D)
-	switch desc.Dimension {
-	case gputypes.TextureDimension1D:
-		// GL doesn't have 1D textures in ES, use 2D with height=1
-		target = gl.TEXTURE_2D
-	case gputypes.TextureDimension2D:
-		switch {
-		case sampleCount > 1:
-			target = gl.TEXTURE_2D_MULTISAMPLE
-		case desc.Size.DepthOrArrayLayers > 1:
-			target = gl.TEXTURE_2D_ARRAY
-		default:
-			target = gl.TEXTURE_2D
-		}
-	case gputypes.TextureDimension3D:
-		target = gl.TEXTURE_3D
-	}
-
-	// Handle cube maps (only for single-sample textures)
-	if sampleCount <= 1 && desc.ViewFormats != nil {
-		for _, vf := range desc.ViewFormats {
-			if vf == desc.Format {
-				// Check if this should be a cube map
-				if desc.Size.DepthOrArrayLayers == 6 {
-					target = gl.TEXTURE_CUBE_MAP
-				}
-			}
-		}
-	}
+	target := textureTarget(desc, sampleCount)
 
 	glCtx.BindTexture(target, id)
 
@@ -229,6 +215,24 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (hal.Texture, error) {
 					width, height, 0, format, dataType, nil)
 			}
 		}
+
+	case gl.TEXTURE_2D_ARRAY, gl.TEXTURE_CUBE_MAP_ARRAY, gl.TEXTURE_3D:
+		// Array layers stay constant across mips; only 3D depth shrinks.
+		for level := uint32(0); level < desc.MipLevelCount; level++ {
+			width := maxInt32(1, int32(desc.Size.Width>>level))
+			height := maxInt32(1, int32(desc.Size.Height>>level))
+			depth := int32(desc.Size.DepthOrArrayLayers)
+			if target == gl.TEXTURE_3D {
+				depth = maxInt32(1, depth>>level)
+			}
+			glCtx.TexImage3D(target, int32(level), int32(internalFormat),
+				width, height, depth, 0, format, dataType, nil)
+			if glErr := glCtx.GetError(); glErr != 0 {
+				glCtx.DeleteTextures(id)
+				return nil, fmt.Errorf("gles: TexImage3D failed: GL error 0x%x (format=0x%x, level=%d, %dx%dx%d)",
+					glErr, internalFormat, level, width, height, depth)
+			}
+		}
 	}
 
 	if target != gl.TEXTURE_2D_MULTISAMPLE {
@@ -237,6 +241,9 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (hal.Texture, error) {
 		glCtx.TexParameteri(target, gl.TEXTURE_MAX_LEVEL, int32(desc.MipLevelCount-1))
 		glCtx.TexParameteri(target, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
 		glCtx.TexParameteri(target, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		if target == gl.TEXTURE_3D || target == gl.TEXTURE_CUBE_MAP || target == gl.TEXTURE_CUBE_MAP_ARRAY {
+			glCtx.TexParameteri(target, gl.TEXTURE_WRAP_R, gl.CLAMP_TO_EDGE)
+		}
 	}
 
 	glCtx.BindTexture(target, 0)
@@ -309,7 +316,7 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (hal.Sampler, error) {
 	if desc == nil {
 		return &Sampler{glCtx: glCtx}, nil
 	}
-	id := configureSampler(glCtx, desc)
+	id := configureSampler(glCtx, desc, d.glslVersion.ES)
 	return &Sampler{
 		id:    id,
 		glCtx: glCtx,
@@ -421,8 +428,15 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 		return nil, fmt.Errorf("gles: invalid vertex shader module type")
 	}
 
+	// Attributes in formats the context cannot fetch are pulled by the
+	// vertex shader from storage buffers bound after the layout's own.
+	pulling, err := planVertexPulling(desc.Vertex.Buffers, d.vertexFormats, layout.storageBufferCount())
+	if err != nil {
+		return nil, err
+	}
+
 	// Compile WGSL → GLSL for vertex stage.
-	vertexGLSL, vertexTranslationInfo, err := compileWGSLToGLSL(d.glslVersion, vertexModule.source, desc.Vertex.EntryPoint, layout.bindingMap)
+	vertexGLSL, vertexTranslationInfo, err := compileVertexWGSLToGLSL(d.shaderTarget(), vertexModule.source, desc.Vertex.EntryPoint, layout.bindingMap, pulling)
 	if err != nil {
 		return nil, fmt.Errorf("gles: vertex shader: %w", err)
 	}
@@ -439,7 +453,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 		return nil, fmt.Errorf("gles: vertex shader compilation failed: %s", log)
 	}
 	if infoLog := glCtx.GetShaderInfoLog(vertexID); infoLog != "" {
-		hal.Logger().Debug("gles: vertex shader compile info", "info", infoLog)
+		d.log.For(hal.LogShader).Debug("gles: vertex shader compile info", "info", infoLog)
 	}
 
 	// Compile fragment shader
@@ -447,7 +461,14 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 	var fragmentTranslationInfo glsl.TranslationInfo
 	if desc.Fragment != nil {
 		var err error
-		fragmentID, fragmentTranslationInfo, err = compileFragmentShader(glCtx, d.glslVersion, desc.Fragment, layout.bindingMap)
+		fragmentID, fragmentTranslationInfo, err = compileFragmentShader(glCtx, d.shaderTarget(), desc.Fragment, layout.bindingMap)
+		if err != nil {
+			glCtx.DeleteShader(vertexID)
+			return nil, err
+		}
+	} else if d.glslVersion.ES {
+		var err error
+		fragmentID, err = compileEmptyFragmentShader(glCtx, d.glslVersion)
 		if err != nil {
 			glCtx.DeleteShader(vertexID)
 			return nil, err
@@ -473,7 +494,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 		return nil, fmt.Errorf("gles: program linking failed: %s", log)
 	}
 	if infoLog := glCtx.GetProgramInfoLog(programID); infoLog != "" {
-		hal.Logger().Debug("gles: program link info", "info", infoLog)
+		d.log.For(hal.LogShader).Debug("gles: program link info", "info", infoLog)
 	}
 
 	// On GL < 4.2 (GLSL < 420), layout(binding=N) is unavailable so naga
@@ -515,6 +536,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 		layout:            layout,
 		glCtx:             glCtx,
 		primitiveTopology: desc.Primitive.Topology,
+		polygonMode:       desc.PolygonMode,
 		cullMode:          desc.Primitive.CullMode,
 		frontFace:         desc.Primitive.FrontFace,
 		depthStencil:      desc.DepthStencil,
@@ -522,6 +544,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (hal.Rende
 		blend:             blend,
 		colorWriteMask:    colorWriteMask,
 		vertexBuffers:     desc.Vertex.Buffers,
+		vertexPulling:     pulling,
 	}
 
 	// Build SamplerBindMap from TextureMappings using pre-computed BindingMap.
@@ -584,7 +607,7 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (hal.Com
 	}
 
 	// Compile WGSL → GLSL for compute stage.
-	computeGLSL, computeTranslationInfo, err := compileWGSLToGLSL(d.glslVersion, computeModule.source, desc.Compute.EntryPoint, layout.bindingMap)
+	computeGLSL, computeTranslationInfo, err := compileWGSLToGLSL(d.shaderTarget(), computeModule.source, desc.Compute.EntryPoint, layout.bindingMap)
 	if err != nil {
 		return nil, fmt.Errorf("gles: compute shader: %w", err)
 	}
@@ -601,7 +624,7 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (hal.Com
 		return nil, fmt.Errorf("gles: compute shader compilation failed: %s", log)
 	}
 	if infoLog := glCtx.GetShaderInfoLog(computeID); infoLog != "" {
-		hal.Logger().Debug("gles: compute shader compile info", "info", infoLog)
+		d.log.For(hal.LogShader).Debug("gles: compute shader compile info", "info", infoLog)
 	}
 
 	// Link program
@@ -617,7 +640,7 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (hal.Com
 		return nil, fmt.Errorf("gles: compute program linking failed: %s", log)
 	}
 	if infoLog := glCtx.GetProgramInfoLog(programID); infoLog != "" {
-		hal.Logger().Debug("gles: compute program link info", "info", infoLog)
+		d.log.For(hal.LogShader).Debug("gles: compute program link info", "info", infoLog)
 	}
 
 	// Runtime binding fallback for GL < 4.2 (see CreateRenderPipeline).
@@ -852,13 +875,13 @@ func maxInt32(a, b int32) int32 {
 // compileFragmentShader compiles a fragment shader from WGSL source via GLSL.
 // Caller must hold the AdapterContext lock. glCtx is passed explicitly to avoid
 // re-locking (this is called from CreateRenderPipeline which already holds the lock).
-func compileFragmentShader(glCtx *gl.Context, version glsl.Version, frag *hal.FragmentState, bindingMap map[glsl.BindingMapKey]uint8) (uint32, glsl.TranslationInfo, error) {
+func compileFragmentShader(glCtx *gl.Context, target glslTarget, frag *hal.FragmentState, bindingMap map[glsl.BindingMapKey]uint8) (uint32, glsl.TranslationInfo, error) {
 	fragmentModule, ok := frag.Module.(*ShaderModule)
 	if !ok {
 		return 0, glsl.TranslationInfo{}, fmt.Errorf("gles: invalid fragment shader module type")
 	}
 
-	fragmentGLSL, translationInfo, err := compileWGSLToGLSL(version, fragmentModule.source, frag.EntryPoint, bindingMap)
+	fragmentGLSL, translationInfo, err := compileWGSLToGLSL(target, fragmentModule.source, frag.EntryPoint, bindingMap)
 	if err != nil {
 		return 0, glsl.TranslationInfo{}, fmt.Errorf("gles: fragment shader: %w", err)
 	}
@@ -875,7 +898,7 @@ func compileFragmentShader(glCtx *gl.Context, version glsl.Version, frag *hal.Fr
 		return 0, glsl.TranslationInfo{}, fmt.Errorf("gles: fragment shader compilation failed: %s", log)
 	}
 	if infoLog := glCtx.GetShaderInfoLog(fragmentID); infoLog != "" {
-		hal.Logger().Debug("gles: fragment shader compile info", "info", infoLog)
+		hal.CategoryLogger(hal.LogShader).Debug("gles: fragment shader compile info", "info", infoLog)
 	}
 
 	return fragmentID, translationInfo, nil
@@ -883,3 +906,8 @@ func compileFragmentShader(glCtx *gl.Context, version glsl.Version, frag *hal.Fr
 
 // Ensure we use unsafe for later
 var _ = unsafe.Pointer(nil)
+
+// shaderTarget returns what the device compiles WGSL shaders for.
+func (d *Device) shaderTarget() glslTarget {
+	return glslTarget{version: d.glslVersion, boundsChecks: d.boundsChecks, implicitLod: d.implicitLod}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Explicit-LOD rewrite.
+//
+// textureSample and textureSampleBias pick their mip level from screen-space
+// derivatives, which GLSL leaves undefined outside uniform control flow. Some
+// GLES drivers then sample garbage levels or refuse to link. With
+// hal.GLOptions.ImplicitLod set, the selected samples are rewritten in naga
+// IR before GLSL is emitted: an automatic level becomes level 0 and a biased
+// one samples the bias as its level, so naga writes textureLod instead of
+// texture.
+
+// rewriteImplicitLod rewrites the implicit-LOD samples of module that mode
+// selects. Samples in a function count as in control flow when the function
+// is called from control flow, directly or through other calls.
+func rewriteImplicitLod(module *ir.Module, mode hal.ImplicitLodRewrite) {
+	if mode == hal.ImplicitLodKeep {
+		return
+	}
+	always := mode == hal.ImplicitLodAlways
+	r := lodRewriter{reached: make([]bool, len(module.Functions))}
+	for i := range module.EntryPoints {
+		fn := &module.EntryPoints[i].Function
+		r.block(fn, fn.Body, always)
+	}
+	for i := range module.Functions {
+		fn := &module.Functions[i]
+		r.block(fn, fn.Body, always)
+	}
+	// A function reached from control flow is walked again as a whole;
+	// the calls it makes reach their callees in turn.
+	for len(r.worklist) > 0 {
+		handle := r.worklist[len(r.worklist)-1]
+		r.worklist = r.worklist[:len(r.worklist)-1]
+		fn := &module.Functions[handle]
+		r.block(fn, fn.Body, true)
+	}
+}
+
+// lodRewriter walks function bodies for rewriteImplicitLod.
+type lodRewriter struct {
+	// reached marks the functions called from control flow.
+	reached []bool
+	// worklist holds reached functions not yet walked as such.
+	worklist []ir.FunctionHandle
+}
+
+// block walks the statements of one block of fn; inFlow is whether the block
+// runs under an if, switch or loop.
+func (r *lodRewriter) block(fn *ir.Function, block ir.Block, inFlow bool) {
+	for _, stmt := range block {
+		switch s := stmt.Kind.(type) {
+		case ir.StmtEmit:
+			if inFlow {
+				explicitLod(fn, s.Range)
+			}
+		case ir.StmtBlock:
+			r.block(fn, s.Block, inFlow)
+		case ir.StmtIf:
+			r.block(fn, s.Accept, true)
+			r.block(fn, s.Reject, true)
+		case ir.StmtSwitch:
+			for _, c := range s.Cases {
+				r.block(fn, c.Body, true)
+			}
+		case ir.StmtLoop:
+			r.block(fn, s.Body, true)
+			r.block(fn, s.Continuing, true)
+		case ir.StmtCall:
+			if inFlow && int(s.Function) < len(r.reached) && !r.reached[s.Function] {
+				r.reached[s.Function] = true
+				r.worklist = append(r.worklist, s.Function)
+			}
+		}
+	}
+}
+
+// explicitLod rewrites the implicit-LOD samples among the expressions of fn
+// emitted by rng.
+func explicitLod(fn *ir.Function, rng ir.Range) {
+	for h := rng.Start; h < rng.End && int(h) < len(fn.Expressions); h++ {
+		sample, ok := fn.Expressions[h].Kind.(ir.ExprImageSample)
+		if !ok || sample.Gather != nil {
+			continue
+		}
+		switch level := sample.Level.(type) {
+		case ir.SampleLevelAuto:
+			sample.Level = ir.SampleLevelZero{}
+		case ir.SampleLevelBias:
+			sample.Level = ir.SampleLevelExact{Level: level.Bias}
+		default:
+			continue
+		}
+		fn.Expressions[h].Kind = sample
+	}
+}
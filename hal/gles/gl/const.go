@@ -17,15 +17,16 @@ const (
 	TRUE  = 1
 
 	// Data types
-	BYTE              = 0x1400
-	UNSIGNED_BYTE     = 0x1401
-	SHORT             = 0x1402
-	UNSIGNED_SHORT    = 0x1403
-	INT               = 0x1404
-	UNSIGNED_INT      = 0x1405
-	FLOAT             = 0x1406
-	HALF_FLOAT        = 0x140B
-	UNSIGNED_INT_24_8 = 0x84FA
+	BYTE                        = 0x1400
+	UNSIGNED_BYTE               = 0x1401
+	SHORT                       = 0x1402
+	UNSIGNED_SHORT              = 0x1403
+	INT                         = 0x1404
+	UNSIGNED_INT                = 0x1405
+	FLOAT                       = 0x1406
+	HALF_FLOAT                  = 0x140B
+	UNSIGNED_INT_24_8           = 0x84FA
+	UNSIGNED_INT_2_10_10_10_REV = 0x8368
 
 	// Errors
 	NO_ERROR                      = 0
@@ -84,6 +85,7 @@ const (
 	TEXTURE_CUBE_MAP_NEGATIVE_Y = 0x8518
 	TEXTURE_CUBE_MAP_POSITIVE_Z = 0x8519
 	TEXTURE_CUBE_MAP_NEGATIVE_Z = 0x851A
+	TEXTURE_CUBE_MAP_ARRAY      = 0x9009
 
 	// Texture parameters
 	TEXTURE_MAG_FILTER     = 0x2800
@@ -93,6 +95,7 @@ const (
 	TEXTURE_WRAP_R         = 0x8072
 	TEXTURE_MIN_LOD        = 0x813A
 	TEXTURE_MAX_LOD        = 0x813B
+	TEXTURE_LOD_BIAS       = 0x8501
 	TEXTURE_COMPARE_MODE   = 0x884C
 	TEXTURE_COMPARE_FUNC   = 0x884D
 	COMPARE_REF_TO_TEXTURE = 0x884E
@@ -248,6 +251,7 @@ const (
 	STENCIL_ATTACHMENT       = 0x8D20
 	DEPTH_STENCIL_ATTACHMENT = 0x821A
 	FRAMEBUFFER_COMPLETE     = 0x8CD5
+	NONE                     = 0
 
 	// Pixel storage
 	PACK_ALIGNMENT   = 0x0D05
@@ -284,6 +288,8 @@ const (
 	MAX_DRAW_BUFFERS                 = 0x8824
 	MAX_RENDERBUFFER_SIZE            = 0x84E8
 	MAX_SAMPLES                      = 0x8D57
+	SAMPLES                          = 0x80A9
+	NUM_SAMPLE_COUNTS                = 0x9380
 	MAX_ELEMENT_INDEX                = 0x8D6B
 	MAX_VARYING_COMPONENTS           = 0x8B4B
 
@@ -302,6 +308,11 @@ const (
 	// Anisotropic filtering
 	MAX_TEXTURE_MAX_ANISOTROPY = 0x84FF
 
+	// Context robustness (GL 4.5 / ES 3.2, KHR_robustness, ARB_robustness)
+	CONTEXT_FLAGS                  = 0x821E
+	CONTEXT_FLAG_ROBUST_ACCESS_BIT = 0x00000004
+	CONTEXT_ROBUST_ACCESS          = 0x90F3
+
 	// VAO
 	VERTEX_ARRAY_BINDING = 0x85B5
 
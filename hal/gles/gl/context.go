@@ -87,6 +87,8 @@ type Context struct {
 	glActiveTexture  uintptr
 	glTexImage2D     uintptr
 	glTexSubImage2D  uintptr
+	glTexImage3D     uintptr
+	glTexSubImage3D  uintptr
 	glTexParameteri  uintptr
 	glGenerateMipmap uintptr
 
@@ -97,6 +99,7 @@ type Context struct {
 	glFramebufferTexture2D   uintptr
 	glCheckFramebufferStatus uintptr
 	glDrawBuffers            uintptr
+	glReadBuffer             uintptr
 
 	// Pixel read/store (GL 1.0+)
 	glReadPixels  uintptr
@@ -148,11 +151,13 @@ type Context struct {
 	glDrawArraysInstanced   uintptr
 	glDrawElementsInstanced uintptr
 	glVertexAttribDivisor   uintptr
+	glVertexAttribI4ui      uintptr
 
 	// Compute shaders (GL 4.3+ / ES 3.1+)
 	glDispatchCompute         uintptr
 	glDispatchComputeIndirect uintptr
 	glMemoryBarrier           uintptr
+	glBindImageTexture        uintptr
 
 	// MSAA (GL 3.2+ / ES 3.1+)
 	glTexImage2DMultisample uintptr
@@ -176,6 +181,9 @@ type Context struct {
 
 	// Indexed string query (GL 3.0+ / ES 3.0+)
 	glGetStringi uintptr
+
+	// Internal format query (GL 4.2+ / ES 3.0+ / GL_ARB_internalformat_query)
+	glGetInternalformativ uintptr
 }
 
 // ProcAddressFunc is a function that returns the address of an OpenGL function.
@@ -256,6 +264,8 @@ func (c *Context) Load(getProcAddr ProcAddressFunc) error {
 	c.glActiveTexture = getProcAddr("glActiveTexture")
 	c.glTexImage2D = getProcAddr("glTexImage2D")
 	c.glTexSubImage2D = getProcAddr("glTexSubImage2D")
+	c.glTexImage3D = getProcAddr("glTexImage3D")
+	c.glTexSubImage3D = getProcAddr("glTexSubImage3D")
 	c.glTexParameteri = getProcAddr("glTexParameteri")
 	c.glGenerateMipmap = getProcAddr("glGenerateMipmap")
 
@@ -266,6 +276,7 @@ func (c *Context) Load(getProcAddr ProcAddressFunc) error {
 	c.glFramebufferTexture2D = getProcAddr("glFramebufferTexture2D")
 	c.glCheckFramebufferStatus = getProcAddr("glCheckFramebufferStatus")
 	c.glDrawBuffers = getProcAddr("glDrawBuffers")
+	c.glReadBuffer = getProcAddr("glReadBuffer")
 
 	// Pixel read/store
 	c.glReadPixels = getProcAddr("glReadPixels")
@@ -317,11 +328,13 @@ func (c *Context) Load(getProcAddr ProcAddressFunc) error {
 	c.glDrawArraysInstanced = getProcAddr("glDrawArraysInstanced")
 	c.glDrawElementsInstanced = getProcAddr("glDrawElementsInstanced")
 	c.glVertexAttribDivisor = getProcAddr("glVertexAttribDivisor")
+	c.glVertexAttribI4ui = getProcAddr("glVertexAttribI4ui")
 
 	// Compute shaders (optional - may be nil on older GL versions)
 	c.glDispatchCompute = getProcAddr("glDispatchCompute")
 	c.glDispatchComputeIndirect = getProcAddr("glDispatchComputeIndirect")
 	c.glMemoryBarrier = getProcAddr("glMemoryBarrier")
+	c.glBindImageTexture = getProcAddr("glBindImageTexture")
 
 	// MSAA (optional - may be nil on older GL versions)
 	c.glTexImage2DMultisample = getProcAddr("glTexImage2DMultisample")
@@ -346,6 +359,9 @@ func (c *Context) Load(getProcAddr ProcAddressFunc) error {
 	// Indexed string query (GL 3.0+ / ES 3.0+)
 	c.glGetStringi = getProcAddr("glGetStringi")
 
+	// Internal format query (GL 4.2+ / ES 3.0+ / GL_ARB_internalformat_query)
+	c.glGetInternalformativ = getProcAddr("glGetInternalformativ")
+
 	return nil
 }
 
@@ -382,6 +398,21 @@ func (c *Context) GetStringi(name, index uint32) string {
 	return goString(r)
 }
 
+// GetInternalformativ queries a property of an internal format, writing at
+// most len(params) values (GL 4.2+ / ES 3.0+). Returns false if
+// glGetInternalformativ is not available.
+func (c *Context) GetInternalformativ(target, internalFormat, pname uint32, params []int32) bool {
+	if c.glGetInternalformativ == 0 {
+		return false
+	}
+	if len(params) == 0 {
+		return true
+	}
+	syscall.SyscallN(c.glGetInternalformativ, uintptr(target), uintptr(internalFormat),
+		uintptr(pname), uintptr(len(params)), uintptr(unsafe.Pointer(&params[0])))
+	return true
+}
+
 func (c *Context) Enable(capability uint32) {
 	syscall.SyscallN(c.glEnable, uintptr(capability))
 }
@@ -612,6 +643,12 @@ func (c *Context) VertexAttribDivisor(index, divisor uint32) {
 	syscall.SyscallN(c.glVertexAttribDivisor, uintptr(index), uintptr(divisor))
 }
 
+// VertexAttribI4ui sets the current value of an integer vertex attribute,
+// which the shader reads while the attribute's array is disabled.
+func (c *Context) VertexAttribI4ui(index, x, y, z, w uint32) {
+	syscall.SyscallN(c.glVertexAttribI4ui, uintptr(index), uintptr(x), uintptr(y), uintptr(z), uintptr(w))
+}
+
 // --- Textures ---
 
 func (c *Context) GenTextures(n int32) uint32 {
@@ -653,6 +690,30 @@ func (c *Context) GenerateMipmap(target uint32) {
 	syscall.SyscallN(c.glGenerateMipmap, uintptr(target))
 }
 
+// TexImage3D allocates a level of a 2D array, cube map array or 3D texture.
+// No-op if not supported.
+func (c *Context) TexImage3D(target uint32, level int32, internalformat int32, width, height, depth int32, border int32, format, typ uint32, pixels unsafe.Pointer) {
+	if c.glTexImage3D == 0 {
+		return
+	}
+	syscall.SyscallN(c.glTexImage3D, uintptr(target), uintptr(level),
+		uintptr(internalformat), uintptr(width), uintptr(height), uintptr(depth),
+		uintptr(border), uintptr(format), uintptr(typ), uintptr(pixels))
+}
+
+// TexSubImage3D uploads a box of texels into a 2D array, cube map array or
+// 3D texture. For arrays, zoffset and depth select layers.
+// No-op if not supported.
+func (c *Context) TexSubImage3D(target uint32, level int32, xoffset, yoffset, zoffset, width, height, depth int32, format, typ uint32, pixels unsafe.Pointer) {
+	if c.glTexSubImage3D == 0 {
+		return
+	}
+	syscall.SyscallN(c.glTexSubImage3D, uintptr(target), uintptr(level),
+		uintptr(xoffset), uintptr(yoffset), uintptr(zoffset),
+		uintptr(width), uintptr(height), uintptr(depth),
+		uintptr(format), uintptr(typ), uintptr(pixels))
+}
+
 // TexImage2DMultisample creates a multisample 2D texture image.
 // Requires OpenGL 3.2+ or OpenGL ES 3.1+.
 // No-op if not supported.
@@ -765,6 +826,16 @@ func (c *Context) CheckFramebufferStatus(target uint32) uint32 {
 	return uint32(r)
 }
 
+// DrawBuffers selects the attachments fragment outputs are written to.
+func (c *Context) DrawBuffers(bufs []uint32) {
+	syscall.SyscallN(c.glDrawBuffers, uintptr(len(bufs)), uintptr(unsafe.Pointer(&bufs[0])))
+}
+
+// ReadBuffer selects the attachment pixel reads and blits read from.
+func (c *Context) ReadBuffer(mode uint32) {
+	syscall.SyscallN(c.glReadBuffer, uintptr(mode))
+}
+
 // --- Renderbuffers ---
 
 // GenRenderbuffers generates a single renderbuffer object and returns its name.
@@ -981,6 +1052,24 @@ func (c *Context) MemoryBarrier(barriers uint32) {
 	syscall.SyscallN(c.glMemoryBarrier, uintptr(barriers))
 }
 
+// BindImageTexture binds a texture level to an image unit for image
+// load/store (storage textures). layered binds every layer of an array,
+// cube or 3D texture; otherwise only layer is bound.
+// access: GL_READ_ONLY, GL_WRITE_ONLY, GL_READ_WRITE.
+// Requires OpenGL 4.2+ or OpenGL ES 3.1+.
+// No-op if image load/store is not supported.
+func (c *Context) BindImageTexture(unit, texture uint32, level int32, layered bool, layer int32, access, format uint32) {
+	if c.glBindImageTexture == 0 {
+		return
+	}
+	var l uintptr
+	if layered {
+		l = 1
+	}
+	syscall.SyscallN(c.glBindImageTexture, uintptr(unit), uintptr(texture), uintptr(level),
+		l, uintptr(layer), uintptr(access), uintptr(format))
+}
+
 // SupportsCompute returns true if compute shaders are supported.
 func (c *Context) SupportsCompute() bool {
 	return c.glDispatchCompute != 0
@@ -42,6 +42,7 @@ var (
 	cifVoid4SubBuf   types.CallInterface // void fn(uint32, uintptr, uintptr, void*)
 	cifVoid6Attrib   types.CallInterface // void fn(uint32, int32, uint32, uint8, int32, uintptr)
 	cifVoid5FBO      types.CallInterface // void fn(uint32, uint32, uint32, uint32, int32)
+	cifVoid5U        types.CallInterface // void fn(uint32, uint32, uint32, uint32, uint32)
 	cifVoid9TexImg   types.CallInterface // void fn(uint32, int32, int32, int32, int32, int32, uint32, uint32, void*)
 	cifVoid4Draw     types.CallInterface // void fn(uint32, int32, int32, int32)
 	cifVoid5DrawElem types.CallInterface // void fn(uint32, int32, uint32, void*, int32)
@@ -50,7 +51,15 @@ var (
 	cifVoid7ReadPx   types.CallInterface // void fn(int32, int32, int32, int32, uint32, uint32, void*)
 	cifVoid6TexMS    types.CallInterface // void fn(uint32, int32, uint32, int32, int32, uint8) - TexImage2DMultisample
 	cifVoid10Blit    types.CallInterface // void fn(int32*8, uint32, uint32) - BlitFramebuffer
+	cifVoid10TexImg  types.CallInterface // void fn(uint32, int32*6, uint32, uint32, void*) - TexImage3D
+	cifVoid11TexSub  types.CallInterface // void fn(uint32, int32*7, uint32, uint32, void*) - TexSubImage3D
 	cifVoid3UUF      types.CallInterface // void fn(uint32, uint32, float32) - SamplerParameterf
+	cifVoid7BindImg  types.CallInterface // void fn(uint32, uint32, int32, uint8, int32, uint32, uint32) - BindImageTexture
+	cifUInt32Wait    types.CallInterface // uint32 fn(void*, uint32, uint64) - ClientWaitSync
+	cifVoid5Sync     types.CallInterface // void fn(void*, uint32, int32, void*, void*) - GetSynciv
+	cifVoid5IFormat  types.CallInterface // void fn(uint32, uint32, uint32, int32, void*) - GetInternalformativ
+	cifVoid5Copy     types.CallInterface // void fn(uint32, uint32, void*, void*, void*) - CopyBufferSubData
+	cifVoid8CopyTex  types.CallInterface // void fn(uint32, int32*7) - CopyTexSubImage2D
 	cifInitialized   bool
 )
 
@@ -276,6 +285,20 @@ func initCommonCallInterfaces() error {
 		return err
 	}
 
+	// void fn(uint32, uint32, uint32, uint32, uint32) - VertexAttribI4ui
+	err = ffi.PrepareCallInterface(&cifVoid5U, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor,
+			types.UInt32TypeDescriptor,
+			types.UInt32TypeDescriptor,
+			types.UInt32TypeDescriptor,
+			types.UInt32TypeDescriptor,
+		})
+	if err != nil {
+		return err
+	}
+
 	// void fn(uint32, int32, int32, int32, int32, int32, uint32, uint32, void*) - TexImage2D
 	err = ffi.PrepareCallInterface(&cifVoid9TexImg, types.DefaultCall,
 		types.VoidTypeDescriptor,
@@ -337,6 +360,22 @@ func initCommonCallInterfaces() error {
 		return err
 	}
 
+	// void fn(uint32, uint32, int32, uint8, int32, uint32, uint32) - BindImageTexture
+	err = ffi.PrepareCallInterface(&cifVoid7BindImg, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // unit
+			types.UInt32TypeDescriptor, // texture
+			types.SInt32TypeDescriptor, // level
+			types.UInt8TypeDescriptor,  // layered
+			types.SInt32TypeDescriptor, // layer
+			types.UInt32TypeDescriptor, // access
+			types.UInt32TypeDescriptor, // format
+		})
+	if err != nil {
+		return err
+	}
+
 	// void fn(uint32, int32, uint32, int32, int32, uint8) - TexImage2DMultisample
 	err = ffi.PrepareCallInterface(&cifVoid6TexMS, types.DefaultCall,
 		types.VoidTypeDescriptor,
@@ -352,6 +391,45 @@ func initCommonCallInterfaces() error {
 		return err
 	}
 
+	// void fn(uint32, int32, int32, int32, int32, int32, int32, uint32, uint32, void*) - TexImage3D
+	err = ffi.PrepareCallInterface(&cifVoid10TexImg, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // target
+			types.SInt32TypeDescriptor, // level
+			types.SInt32TypeDescriptor, // internalformat
+			types.SInt32TypeDescriptor, // width
+			types.SInt32TypeDescriptor, // height
+			types.SInt32TypeDescriptor, // depth
+			types.SInt32TypeDescriptor, // border
+			types.UInt32TypeDescriptor, // format
+			types.UInt32TypeDescriptor, // type
+			types.PointerTypeDescriptor,
+		})
+	if err != nil {
+		return err
+	}
+
+	// void fn(uint32, int32, int32, int32, int32, int32, int32, int32, uint32, uint32, void*) - TexSubImage3D
+	err = ffi.PrepareCallInterface(&cifVoid11TexSub, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // target
+			types.SInt32TypeDescriptor, // level
+			types.SInt32TypeDescriptor, // xoffset
+			types.SInt32TypeDescriptor, // yoffset
+			types.SInt32TypeDescriptor, // zoffset
+			types.SInt32TypeDescriptor, // width
+			types.SInt32TypeDescriptor, // height
+			types.SInt32TypeDescriptor, // depth
+			types.UInt32TypeDescriptor, // format
+			types.UInt32TypeDescriptor, // type
+			types.PointerTypeDescriptor,
+		})
+	if err != nil {
+		return err
+	}
+
 	// void fn(int32, int32, int32, int32, int32, int32, int32, int32, uint32, uint32) - BlitFramebuffer
 	err = ffi.PrepareCallInterface(&cifVoid10Blit, types.DefaultCall,
 		types.VoidTypeDescriptor,
@@ -383,6 +461,77 @@ func initCommonCallInterfaces() error {
 		return err
 	}
 
+	// uint32 fn(void*, uint32, uint64) - ClientWaitSync
+	err = ffi.PrepareCallInterface(&cifUInt32Wait, types.DefaultCall,
+		types.UInt32TypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // sync
+			types.UInt32TypeDescriptor,  // flags
+			types.UInt64TypeDescriptor,  // timeout
+		})
+	if err != nil {
+		return err
+	}
+
+	// void fn(void*, uint32, int32, void*, void*) - GetSynciv
+	err = ffi.PrepareCallInterface(&cifVoid5Sync, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.PointerTypeDescriptor, // sync
+			types.UInt32TypeDescriptor,  // pname
+			types.SInt32TypeDescriptor,  // count
+			types.PointerTypeDescriptor, // length
+			types.PointerTypeDescriptor, // values
+		})
+	if err != nil {
+		return err
+	}
+
+	// void fn(uint32, uint32, uint32, int32, void*) - GetInternalformativ
+	err = ffi.PrepareCallInterface(&cifVoid5IFormat, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor,  // target
+			types.UInt32TypeDescriptor,  // internalformat
+			types.UInt32TypeDescriptor,  // pname
+			types.SInt32TypeDescriptor,  // bufSize
+			types.PointerTypeDescriptor, // params
+		})
+	if err != nil {
+		return err
+	}
+
+	// void fn(uint32, uint32, void*, void*, void*) - CopyBufferSubData
+	err = ffi.PrepareCallInterface(&cifVoid5Copy, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor,  // readTarget
+			types.UInt32TypeDescriptor,  // writeTarget
+			types.PointerTypeDescriptor, // readOffset
+			types.PointerTypeDescriptor, // writeOffset
+			types.PointerTypeDescriptor, // size
+		})
+	if err != nil {
+		return err
+	}
+
+	// void fn(uint32, int32*7) - CopyTexSubImage2D
+	err = ffi.PrepareCallInterface(&cifVoid8CopyTex, types.DefaultCall,
+		types.VoidTypeDescriptor,
+		[]*types.TypeDescriptor{
+			types.UInt32TypeDescriptor, // target
+			types.SInt32TypeDescriptor, // level
+			types.SInt32TypeDescriptor, // xoffset
+			types.SInt32TypeDescriptor, // yoffset
+			types.SInt32TypeDescriptor, // x
+			types.SInt32TypeDescriptor, // y
+			types.SInt32TypeDescriptor, // width
+			types.SInt32TypeDescriptor, // height
+		})
+	if err != nil {
+		return err
+	}
+
 	cifInitialized = true
 	return nil
 }
@@ -466,6 +615,10 @@ type Context struct {
 	glTexParameteri  unsafe.Pointer
 	glGenerateMipmap unsafe.Pointer
 
+	// Array and 3D textures (GL 1.2+ / ES 3.0+)
+	glTexImage3D    unsafe.Pointer
+	glTexSubImage3D unsafe.Pointer
+
 	// Framebuffers (GL 3.0+)
 	glGenFramebuffers        unsafe.Pointer
 	glDeleteFramebuffers     unsafe.Pointer
@@ -473,6 +626,7 @@ type Context struct {
 	glFramebufferTexture2D   unsafe.Pointer
 	glCheckFramebufferStatus unsafe.Pointer
 	glDrawBuffers            unsafe.Pointer
+	glReadBuffer             unsafe.Pointer
 
 	// Pixel read/store (GL 1.0+)
 	glReadPixels  unsafe.Pointer
@@ -524,11 +678,13 @@ type Context struct {
 	glDrawArraysInstanced   unsafe.Pointer
 	glDrawElementsInstanced unsafe.Pointer
 	glVertexAttribDivisor   unsafe.Pointer
+	glVertexAttribI4ui      unsafe.Pointer
 
 	// Compute shaders (GL 4.3+ / ES 3.1+)
 	glDispatchCompute         unsafe.Pointer
 	glDispatchComputeIndirect unsafe.Pointer
 	glMemoryBarrier           unsafe.Pointer
+	glBindImageTexture        unsafe.Pointer
 
 	// MSAA (GL 3.2+ / ES 3.1+)
 	glTexImage2DMultisample unsafe.Pointer
@@ -552,6 +708,9 @@ type Context struct {
 
 	// Indexed string query (GL 3.0+ / ES 3.0+)
 	glGetStringi unsafe.Pointer
+
+	// Internal format query (GL 4.2+ / ES 3.0+ / GL_ARB_internalformat_query)
+	glGetInternalformativ unsafe.Pointer
 }
 
 // ProcAddressFunc is a function that returns the address of an OpenGL function.
@@ -662,6 +821,8 @@ func (c *Context) Load(getProcAddr ProcAddressFunc, isGLES ...bool) error {
 	c.glTexSubImage2D = getProcAddr("glTexSubImage2D")
 	c.glTexParameteri = getProcAddr("glTexParameteri")
 	c.glGenerateMipmap = getProcAddr("glGenerateMipmap")
+	c.glTexImage3D = getProcAddr("glTexImage3D")
+	c.glTexSubImage3D = getProcAddr("glTexSubImage3D")
 
 	// Framebuffers
 	c.glGenFramebuffers = getProcAddr("glGenFramebuffers")
@@ -670,6 +831,7 @@ func (c *Context) Load(getProcAddr ProcAddressFunc, isGLES ...bool) error {
 	c.glFramebufferTexture2D = getProcAddr("glFramebufferTexture2D")
 	c.glCheckFramebufferStatus = getProcAddr("glCheckFramebufferStatus")
 	c.glDrawBuffers = getProcAddr("glDrawBuffers")
+	c.glReadBuffer = getProcAddr("glReadBuffer")
 
 	// Pixel read/store
 	c.glReadPixels = getProcAddr("glReadPixels")
@@ -721,11 +883,13 @@ func (c *Context) Load(getProcAddr ProcAddressFunc, isGLES ...bool) error {
 	c.glDrawArraysInstanced = getProcAddr("glDrawArraysInstanced")
 	c.glDrawElementsInstanced = getProcAddr("glDrawElementsInstanced")
 	c.glVertexAttribDivisor = getProcAddr("glVertexAttribDivisor")
+	c.glVertexAttribI4ui = getProcAddr("glVertexAttribI4ui")
 
 	// Compute shaders (optional - may be nil on older GL versions)
 	c.glDispatchCompute = getProcAddr("glDispatchCompute")
 	c.glDispatchComputeIndirect = getProcAddr("glDispatchComputeIndirect")
 	c.glMemoryBarrier = getProcAddr("glMemoryBarrier")
+	c.glBindImageTexture = getProcAddr("glBindImageTexture")
 
 	// MSAA (optional - may be nil on older GL versions)
 	c.glTexImage2DMultisample = getProcAddr("glTexImage2DMultisample")
@@ -750,6 +914,9 @@ func (c *Context) Load(getProcAddr ProcAddressFunc, isGLES ...bool) error {
 	// Indexed string query (GL 3.0+ / ES 3.0+)
 	c.glGetStringi = getProcAddr("glGetStringi")
 
+	// Internal format query (GL 4.2+ / ES 3.0+ / GL_ARB_internalformat_query)
+	c.glGetInternalformativ = getProcAddr("glGetInternalformativ")
+
 	return nil
 }
 
@@ -799,6 +966,30 @@ func (c *Context) GetStringi(name, index uint32) string {
 	return goString(ptr)
 }
 
+// GetInternalformativ queries a property of an internal format, writing at
+// most len(params) values (GL 4.2+ / ES 3.0+). Returns false if
+// glGetInternalformativ is not available.
+func (c *Context) GetInternalformativ(target, internalFormat, pname uint32, params []int32) bool {
+	if c.glGetInternalformativ == nil {
+		return false
+	}
+	if len(params) == 0 {
+		return true
+	}
+	// glGetInternalformativ(GLenum target, GLenum internalformat, GLenum pname, GLsizei bufSize, GLint *params)
+	bufSize := int32(len(params)) //nolint:gosec // sample count lists are tiny
+	pParams := &params[0]
+	args := [5]unsafe.Pointer{
+		unsafe.Pointer(&target),
+		unsafe.Pointer(&internalFormat),
+		unsafe.Pointer(&pname),
+		unsafe.Pointer(&bufSize),
+		unsafe.Pointer(&pParams),
+	}
+	_, _ = ffi.CallFunction(&cifVoid5IFormat, c.glGetInternalformativ, nil, args[:])
+	return true
+}
+
 func (c *Context) Enable(capability uint32) {
 	args := [1]unsafe.Pointer{unsafe.Pointer(&capability)}
 	_, _ = ffi.CallFunction(&cifVoid1, c.glEnable, nil, args[:])
@@ -855,16 +1046,7 @@ func (c *Context) DrawArrays(mode uint32, first, count int32) {
 		unsafe.Pointer(&ufirst),
 		unsafe.Pointer(&ucount),
 	}
-	// Need a 3-arg void signature
-	var cif3 types.CallInterface
-	_ = ffi.PrepareCallInterface(&cif3, types.DefaultCall,
-		types.VoidTypeDescriptor,
-		[]*types.TypeDescriptor{
-			types.UInt32TypeDescriptor,
-			types.UInt32TypeDescriptor,
-			types.UInt32TypeDescriptor,
-		})
-	_, _ = ffi.CallFunction(&cif3, c.glDrawArrays, nil, args[:])
+	_, _ = ffi.CallFunction(&cifVoid3, c.glDrawArrays, nil, args[:])
 }
 
 func (c *Context) DrawElements(mode uint32, count int32, typ uint32, indices uintptr) {
@@ -1190,6 +1372,22 @@ func (c *Context) VertexAttribDivisor(index, divisor uint32) {
 	_, _ = ffi.CallFunction(&cifVoid2UU, c.glVertexAttribDivisor, nil, args[:])
 }
 
+// VertexAttribI4ui sets the current value of an integer vertex attribute,
+// which the shader reads while the attribute's array is disabled.
+func (c *Context) VertexAttribI4ui(index, x, y, z, w uint32) {
+	if c.glVertexAttribI4ui == nil {
+		return
+	}
+	args := [5]unsafe.Pointer{
+		unsafe.Pointer(&index),
+		unsafe.Pointer(&x),
+		unsafe.Pointer(&y),
+		unsafe.Pointer(&z),
+		unsafe.Pointer(&w),
+	}
+	_, _ = ffi.CallFunction(&cifVoid5U, c.glVertexAttribI4ui, nil, args[:])
+}
+
 // --- Textures ---
 
 func (c *Context) GenTextures(n int32) uint32 {
@@ -1271,6 +1469,50 @@ func (c *Context) GenerateMipmap(target uint32) {
 	_, _ = ffi.CallFunction(&cifVoid1, c.glGenerateMipmap, nil, args[:])
 }
 
+// TexImage3D allocates a level of a 2D array, cube map array or 3D texture.
+// No-op if not supported.
+func (c *Context) TexImage3D(target uint32, level int32, internalformat int32, width, height, depth int32, border int32, format, typ uint32, pixels unsafe.Pointer) {
+	if c.glTexImage3D == nil {
+		return
+	}
+	args := [10]unsafe.Pointer{
+		unsafe.Pointer(&target),
+		unsafe.Pointer(&level),
+		unsafe.Pointer(&internalformat),
+		unsafe.Pointer(&width),
+		unsafe.Pointer(&height),
+		unsafe.Pointer(&depth),
+		unsafe.Pointer(&border),
+		unsafe.Pointer(&format),
+		unsafe.Pointer(&typ),
+		unsafe.Pointer(&pixels),
+	}
+	_, _ = ffi.CallFunction(&cifVoid10TexImg, c.glTexImage3D, nil, args[:])
+}
+
+// TexSubImage3D uploads a box of texels into a 2D array, cube map array or
+// 3D texture. For arrays, zoffset and depth select layers.
+// No-op if not supported.
+func (c *Context) TexSubImage3D(target uint32, level int32, xoffset, yoffset, zoffset, width, height, depth int32, format, typ uint32, pixels unsafe.Pointer) {
+	if c.glTexSubImage3D == nil {
+		return
+	}
+	args := [11]unsafe.Pointer{
+		unsafe.Pointer(&target),
+		unsafe.Pointer(&level),
+		unsafe.Pointer(&xoffset),
+		unsafe.Pointer(&yoffset),
+		unsafe.Pointer(&zoffset),
+		unsafe.Pointer(&width),
+		unsafe.Pointer(&height),
+		unsafe.Pointer(&depth),
+		unsafe.Pointer(&format),
+		unsafe.Pointer(&typ),
+		unsafe.Pointer(&pixels),
+	}
+	_, _ = ffi.CallFunction(&cifVoid11TexSub, c.glTexSubImage3D, nil, args[:])
+}
+
 // TexImage2DMultisample creates a multisample 2D texture image.
 // Requires OpenGL 3.2+ or OpenGL ES 3.1+.
 // No-op if not supported.
@@ -1444,6 +1686,23 @@ func (c *Context) CheckFramebufferStatus(target uint32) uint32 {
 	return result
 }
 
+// DrawBuffers selects the attachments fragment outputs are written to.
+func (c *Context) DrawBuffers(bufs []uint32) {
+	n := int32(len(bufs))
+	pBufs := &bufs[0]
+	args := [2]unsafe.Pointer{
+		unsafe.Pointer(&n),
+		unsafe.Pointer(&pBufs),
+	}
+	_, _ = ffi.CallFunction(&cifVoid2, c.glDrawBuffers, nil, args[:])
+}
+
+// ReadBuffer selects the attachment pixel reads and blits read from.
+func (c *Context) ReadBuffer(mode uint32) {
+	args := [1]unsafe.Pointer{unsafe.Pointer(&mode)}
+	_, _ = ffi.CallFunction(&cifVoid1, c.glReadBuffer, nil, args[:])
+}
+
 // --- Renderbuffers ---
 
 // GenRenderbuffers generates a single renderbuffer object and returns its name.
@@ -1789,6 +2048,32 @@ func (c *Context) MemoryBarrier(barriers uint32) {
 	_, _ = ffi.CallFunction(&cifVoid1, c.glMemoryBarrier, nil, args[:])
 }
 
+// BindImageTexture binds a texture level to an image unit for image
+// load/store (storage textures). layered binds every layer of an array,
+// cube or 3D texture; otherwise only layer is bound.
+// access: GL_READ_ONLY, GL_WRITE_ONLY, GL_READ_WRITE.
+// Requires OpenGL 4.2+ or OpenGL ES 3.1+.
+// No-op if image load/store is not supported.
+func (c *Context) BindImageTexture(unit, texture uint32, level int32, layered bool, layer int32, access, format uint32) {
+	if c.glBindImageTexture == nil {
+		return
+	}
+	var l uint8
+	if layered {
+		l = 1
+	}
+	args := [7]unsafe.Pointer{
+		unsafe.Pointer(&unit),
+		unsafe.Pointer(&texture),
+		unsafe.Pointer(&level),
+		unsafe.Pointer(&l),
+		unsafe.Pointer(&layer),
+		unsafe.Pointer(&access),
+		unsafe.Pointer(&format),
+	}
+	_, _ = ffi.CallFunction(&cifVoid7BindImg, c.glBindImageTexture, nil, args[:])
+}
+
 // SupportsCompute returns true if compute shaders are supported.
 func (c *Context) SupportsCompute() bool {
 	return c.glDispatchCompute != nil
@@ -1888,22 +2173,13 @@ func (c *Context) ClientWaitSync(sync uintptr, flags uint32, timeout uint64) uin
 		return WAIT_FAILED
 	}
 	// glClientWaitSync(GLsync sync, GLbitfield flags, GLuint64 timeout)
-	// goffi needs a custom CIF for (pointer, uint32, uint64) -> uint32
-	var cifCWS types.CallInterface
-	_ = ffi.PrepareCallInterface(&cifCWS, types.DefaultCall,
-		types.UInt32TypeDescriptor,
-		[]*types.TypeDescriptor{
-			types.PointerTypeDescriptor,
-			types.UInt32TypeDescriptor,
-			types.UInt64TypeDescriptor,
-		})
 	var result uint32
 	args := [3]unsafe.Pointer{
 		unsafe.Pointer(&sync),
 		unsafe.Pointer(&flags),
 		unsafe.Pointer(&timeout),
 	}
-	_, _ = ffi.CallFunction(&cifCWS, c.glClientWaitSync, unsafe.Pointer(&result), args[:])
+	_, _ = ffi.CallFunction(&cifUInt32Wait, c.glClientWaitSync, unsafe.Pointer(&result), args[:])
 	return result
 }
 
@@ -1913,16 +2189,6 @@ func (c *Context) GetSyncStatus(sync uintptr) uint32 {
 		return SIGNALED // assume signaled if not supported
 	}
 	// glGetSynciv(GLsync sync, GLenum pname, GLsizei count, GLsizei *length, GLint *values)
-	var cifGSI types.CallInterface
-	_ = ffi.PrepareCallInterface(&cifGSI, types.DefaultCall,
-		types.VoidTypeDescriptor,
-		[]*types.TypeDescriptor{
-			types.PointerTypeDescriptor, // sync
-			types.UInt32TypeDescriptor,  // pname
-			types.SInt32TypeDescriptor,  // count
-			types.PointerTypeDescriptor, // length
-			types.PointerTypeDescriptor, // values
-		})
 	pname := uint32(SYNC_STATUS)
 	count := int32(1)
 	var length int32
@@ -1936,7 +2202,7 @@ func (c *Context) GetSyncStatus(sync uintptr) uint32 {
 		unsafe.Pointer(&pLength), // FFI reads pLength (= &length) → OpenGL writes to length
 		unsafe.Pointer(&pValue),  // FFI reads pValue (= &value) → OpenGL writes to value
 	}
-	_, _ = ffi.CallFunction(&cifGSI, c.glGetSynciv, nil, args[:])
+	_, _ = ffi.CallFunction(&cifVoid5Sync, c.glGetSynciv, nil, args[:])
 	return uint32(value)
 }
 
@@ -1956,16 +2222,6 @@ func (c *Context) CopyBufferSubData(readTarget, writeTarget uint32, readOffset,
 	ro := uintptr(readOffset)
 	wo := uintptr(writeOffset)
 	sz := uintptr(size)
-	var cifCBS types.CallInterface
-	_ = ffi.PrepareCallInterface(&cifCBS, types.DefaultCall,
-		types.VoidTypeDescriptor,
-		[]*types.TypeDescriptor{
-			types.UInt32TypeDescriptor,
-			types.UInt32TypeDescriptor,
-			types.PointerTypeDescriptor,
-			types.PointerTypeDescriptor,
-			types.PointerTypeDescriptor,
-		})
 	args := [5]unsafe.Pointer{
 		unsafe.Pointer(&readTarget),
 		unsafe.Pointer(&writeTarget),
@@ -1973,7 +2229,7 @@ func (c *Context) CopyBufferSubData(readTarget, writeTarget uint32, readOffset,
 		unsafe.Pointer(&wo),
 		unsafe.Pointer(&sz),
 	}
-	_, _ = ffi.CallFunction(&cifCBS, c.glCopyBufferSubData, nil, args[:])
+	_, _ = ffi.CallFunction(&cifVoid5Copy, c.glCopyBufferSubData, nil, args[:])
 }
 
 // CopyTexSubImage2D copies pixels from the read framebuffer into a 2D texture.
@@ -1982,20 +2238,6 @@ func (c *Context) CopyTexSubImage2D(target uint32, level, xoffset, yoffset, x, y
 	if c.glCopyTexSubImage2D == nil {
 		return
 	}
-	// 8 arguments: uint32, int32, int32, int32, int32, int32, int32, int32
-	var cifCTSI types.CallInterface
-	_ = ffi.PrepareCallInterface(&cifCTSI, types.DefaultCall,
-		types.VoidTypeDescriptor,
-		[]*types.TypeDescriptor{
-			types.UInt32TypeDescriptor, // target
-			types.SInt32TypeDescriptor, // level
-			types.SInt32TypeDescriptor, // xoffset
-			types.SInt32TypeDescriptor, // yoffset
-			types.SInt32TypeDescriptor, // x
-			types.SInt32TypeDescriptor, // y
-			types.SInt32TypeDescriptor, // width
-			types.SInt32TypeDescriptor, // height
-		})
 	args := [8]unsafe.Pointer{
 		unsafe.Pointer(&target),
 		unsafe.Pointer(&level),
@@ -2006,7 +2248,7 @@ func (c *Context) CopyTexSubImage2D(target uint32, level, xoffset, yoffset, x, y
 		unsafe.Pointer(&width),
 		unsafe.Pointer(&height),
 	}
-	_, _ = ffi.CallFunction(&cifCTSI, c.glCopyTexSubImage2D, nil, args[:])
+	_, _ = ffi.CallFunction(&cifVoid8CopyTex, c.glCopyTexSubImage2D, nil, args[:])
 }
 
 // --- Helpers ---
@@ -17,7 +17,7 @@ func TestRenderPassEncoderCountedIndirectRemainsUnsupported(t *testing.T) {
 	pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{ColorAttachments: []hal.RenderPassColorAttachment{}})
 
 	pass.DrawIndirect(&Buffer{id: 7, size: 64}, 0, 2)
-	pass.SetIndexBuffer(&Buffer{id: 9, size: 64}, gputypes.IndexFormatUint32, 16)
+	pass.SetIndexBuffer(&Buffer{id: 9, size: 64}, gputypes.IndexFormatUint32, 16, 48)
 	pass.DrawIndexedIndirect(&Buffer{id: 8, size: 64}, 0, 2)
 
 	if len(enc.commands) != 1 {
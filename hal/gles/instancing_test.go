@@ -0,0 +1,83 @@
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func instancedPipeline() *RenderPipeline {
+	return &RenderPipeline{vertexBuffers: []gputypes.VertexBufferLayout{
+		{ArrayStride: 8, StepMode: gputypes.VertexStepModeVertex, Attributes: []gputypes.VertexAttribute{
+			{Format: gputypes.VertexFormatFloat32x2, ShaderLocation: 0},
+		}},
+		{ArrayStride: 16, StepMode: gputypes.VertexStepModeInstance, Attributes: []gputypes.VertexAttribute{
+			{Format: gputypes.VertexFormatFloat32x4, ShaderLocation: 1},
+		}},
+	}}
+}
+
+// vertexBindings returns the SetVertexBufferCommands recorded after start.
+func vertexBindings(enc *CommandEncoder, start int) []*SetVertexBufferCommand {
+	var out []*SetVertexBufferCommand
+	for _, cmd := range enc.commands[start:] {
+		if c, ok := cmd.(*SetVertexBufferCommand); ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func TestSetPipelineConfiguresEarlierVertexBuffers(t *testing.T) {
+	enc := &CommandEncoder{}
+	_ = enc.BeginEncoding("instancing")
+	pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{ColorAttachments: []hal.RenderPassColorAttachment{}}).(*RenderPassEncoder)
+
+	pass.SetVertexBuffer(0, &Buffer{id: 1}, 0, 0)
+	pass.SetVertexBuffer(1, &Buffer{id: 2}, 32, 0)
+	start := len(enc.commands)
+	pass.SetPipeline(instancedPipeline())
+
+	binds := vertexBindings(enc, start)
+	if len(binds) != 2 {
+		t.Fatalf("SetPipeline rebound %d vertex buffers, want 2", len(binds))
+	}
+	if binds[1].layout == nil || binds[1].layout.StepMode != gputypes.VertexStepModeInstance {
+		t.Errorf("slot 1 layout = %+v, want instance step", binds[1].layout)
+	}
+}
+
+func TestDrawFirstInstanceOffsetsInstanceBuffers(t *testing.T) {
+	enc := &CommandEncoder{}
+	_ = enc.BeginEncoding("instancing")
+	pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{ColorAttachments: []hal.RenderPassColorAttachment{}}).(*RenderPassEncoder)
+	pass.SetPipeline(instancedPipeline())
+	pass.SetVertexBuffer(0, &Buffer{id: 1}, 0, 0)
+	pass.SetVertexBuffer(1, &Buffer{id: 2}, 32, 0)
+
+	start := len(enc.commands)
+	pass.Draw(3, 2, 0, 0)
+	if binds := vertexBindings(enc, start); len(binds) != 0 {
+		t.Fatalf("draw at instance 0 rebound %d buffers, want 0", len(binds))
+	}
+
+	start = len(enc.commands)
+	pass.Draw(3, 2, 0, 5)
+	binds := vertexBindings(enc, start)
+	if len(binds) != 1 || binds[0].slot != 1 {
+		t.Fatalf("draw at instance 5 rebound %v, want only slot 1", binds)
+	}
+	if want := uint64(32 + 5*16); binds[0].offset != want {
+		t.Errorf("instance buffer offset = %d, want %d", binds[0].offset, want)
+	}
+
+	start = len(enc.commands)
+	pass.DrawIndexed(3, 1, 0, 0, 0)
+	binds = vertexBindings(enc, start)
+	if len(binds) != 1 || binds[0].offset != 32 {
+		t.Fatalf("draw back at instance 0 rebound %v, want slot 1 at offset 32", binds)
+	}
+}
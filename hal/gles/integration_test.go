@@ -274,3 +274,67 @@ func TestHALInterface(t *testing.T) {
 	var _ hal.Queue = (*Queue)(nil)
 	t.Log("Queue implements hal.Queue")
 }
+
+// TestHeadlessSurfaceReadPixels tests the window-less surface: the frame
+// stays in the swapchain FBO after Present and ReadPixels captures it.
+func TestHeadlessSurfaceReadPixels(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	instance, err := Backend{}.CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance() failed: %v", err)
+	}
+	defer instance.Destroy()
+
+	halSurface, err := instance.CreateSurface(hal.SurfaceTarget{Kind: hal.SurfaceTargetHeadless})
+	if err != nil {
+		t.Fatalf("CreateSurface(headless) failed: %v", err)
+	}
+	defer halSurface.Destroy()
+	surface := halSurface.(*Surface)
+
+	adapters := instance.EnumerateAdapters(surface)
+	if len(adapters) == 0 {
+		t.Fatal("no adapters for headless surface")
+	}
+	open, err := adapters[0].Adapter.Open(0, gputypes.DefaultLimits())
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer open.Device.Destroy()
+
+	if pixels := surface.ReadPixels(); pixels != nil {
+		t.Fatalf("unconfigured ReadPixels() = %d bytes, want nil", len(pixels))
+	}
+
+	const width, height = 4, 3
+	if err := surface.Configure(open.Device, &hal.SurfaceConfiguration{
+		Width:  width,
+		Height: height,
+		Format: gputypes.TextureFormatBGRA8Unorm,
+		Usage:  gputypes.TextureUsageRenderAttachment,
+	}); err != nil {
+		t.Fatalf("Configure() failed: %v", err)
+	}
+
+	glCtx := surface.glCtx
+	glCtx.BindFramebuffer(gl.FRAMEBUFFER, surface.swapchainFBO)
+	glCtx.ClearColor(1, 0, 0, 1)
+	glCtx.Clear(gl.COLOR_BUFFER_BIT)
+	glCtx.BindFramebuffer(gl.FRAMEBUFFER, 0)
+
+	if err := open.Queue.Present(surface, nil, nil); err != nil {
+		t.Fatalf("Present() failed: %v", err)
+	}
+
+	pixels := surface.ReadPixels()
+	if len(pixels) != width*height*4 {
+		t.Fatalf("ReadPixels() = %d bytes, want %d", len(pixels), width*height*4)
+	}
+	for i := 0; i < len(pixels); i += 4 {
+		if pixels[i] != 0xff || pixels[i+1] != 0 || pixels[i+2] != 0 || pixels[i+3] != 0xff {
+			t.Fatalf("pixel %d = %v, want RGBA red", i/4, pixels[i:i+4])
+		}
+	}
+}
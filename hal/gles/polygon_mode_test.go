@@ -0,0 +1,77 @@
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/gles/gl"
+)
+
+type emulatedDraw struct {
+	mode, first, count uint32
+}
+
+func recordEmulatedDraws(polygonMode hal.PolygonMode, topology gputypes.PrimitiveTopology, count uint32) ([]emulatedDraw, bool) {
+	var draws []emulatedDraw
+	ok := emulatePolygonMode(polygonMode, topology, count, func(mode, first, count uint32) {
+		draws = append(draws, emulatedDraw{mode, first, count})
+	})
+	return draws, ok
+}
+
+func TestEmulatePolygonMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     hal.PolygonMode
+		topology gputypes.PrimitiveTopology
+		count    uint32
+		want     []emulatedDraw
+		emulated bool
+	}{
+		{"fill", hal.PolygonModeFill, gputypes.PrimitiveTopologyTriangleList, 6, nil, false},
+		{"line topology", hal.PolygonModeLine, gputypes.PrimitiveTopologyLineList, 4, nil, false},
+		{"line list", hal.PolygonModeLine, gputypes.PrimitiveTopologyTriangleList, 7,
+			[]emulatedDraw{{gl.LINE_LOOP, 0, 3}, {gl.LINE_LOOP, 3, 3}}, true},
+		{"line strip", hal.PolygonModeLine, gputypes.PrimitiveTopologyTriangleStrip, 5,
+			[]emulatedDraw{{gl.LINE_LOOP, 0, 3}, {gl.LINE_LOOP, 1, 3}, {gl.LINE_LOOP, 2, 3}}, true},
+		{"point", hal.PolygonModePoint, gputypes.PrimitiveTopologyTriangleList, 6,
+			[]emulatedDraw{{gl.POINTS, 0, 6}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws, emulated := recordEmulatedDraws(tt.mode, tt.topology, tt.count)
+			if emulated != tt.emulated {
+				t.Fatalf("emulated = %v, want %v", emulated, tt.emulated)
+			}
+			if len(draws) != len(tt.want) {
+				t.Fatalf("draws = %v, want %v", draws, tt.want)
+			}
+			for i := range draws {
+				if draws[i] != tt.want[i] {
+					t.Errorf("draw %d = %v, want %v", i, draws[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRenderPassEncoderDrawCarriesPolygonMode(t *testing.T) {
+	enc := &CommandEncoder{}
+	if err := enc.BeginEncoding("wireframe"); err != nil {
+		t.Fatal(err)
+	}
+	pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{ColorAttachments: []hal.RenderPassColorAttachment{}})
+	pass.SetPipeline(&RenderPipeline{polygonMode: hal.PolygonModeLine})
+	pass.Draw(3, 1, 0, 0)
+
+	draw, ok := enc.commands[len(enc.commands)-1].(*DrawCommand)
+	if !ok {
+		t.Fatalf("last command = %T, want *DrawCommand", enc.commands[len(enc.commands)-1])
+	}
+	if draw.polygonMode != hal.PolygonModeLine {
+		t.Errorf("polygonMode = %v, want line", draw.polygonMode)
+	}
+}
@@ -22,6 +22,7 @@ type Queue struct {
 	ctx             *AdapterContext
 	submissionIndex uint64
 	fence           *Fence // signaled at each submit for GPU completion tracking
+	state           stateCache
 }
 
 // Submit submits command buffers to the GPU.
@@ -31,6 +32,8 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 	glCtx := q.ctx.Lock()
 	defer q.ctx.Unlock()
 
+	// GL state may have changed since the last submit; start from unknown.
+	q.state.reset()
 	for _, cb := range commandBuffers {
 		cmdBuf, ok := cb.(*CommandBuffer)
 		if !ok {
@@ -38,7 +41,7 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 		}
 
 		for i, cmd := range cmdBuf.commands {
-			cmd.Execute(glCtx)
+			cmd.Execute(glCtx, &q.state)
 			if glErr := glCtx.GetError(); glErr != 0 {
 				hal.Logger().Warn("gles: GL error after command", "error", fmt.Sprintf("0x%x", glErr), "index", i, "command", fmt.Sprintf("%T", cmd))
 			}
@@ -108,16 +111,24 @@ func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal
 
 	glCtx.BindTexture(tex.target, tex.id)
 
-	if tex.target == gl.TEXTURE_2D {
-		if tex.format == gputypes.TextureFormatR8Unorm {
-			glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
-		}
+	// Set alignment to 1 for single-channel formats (R8) whose row stride
+	// may not be a multiple of the default 4-byte GL_UNPACK_ALIGNMENT.
+	if tex.format == gputypes.TextureFormatR8Unorm {
+		glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	}
+	switch tex.target {
+	case gl.TEXTURE_2D:
+		// Use TexSubImage2D to update existing texture data (Rust wgpu-hal pattern).
+		// TexImage2D reallocates storage on every call; TexSubImage2D updates in-place.
 		glCtx.TexSubImage2D(tex.target, int32(dst.MipLevel),
 			0, 0, int32(size.Width), int32(size.Height), format, dataType,
 			unsafe.Pointer(&data[0]))
-		if tex.format == gputypes.TextureFormatR8Unorm {
-			glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
-		}
+	case gl.TEXTURE_CUBE_MAP, gl.TEXTURE_2D_ARRAY, gl.TEXTURE_CUBE_MAP_ARRAY, gl.TEXTURE_3D:
+		writeTextureLayers(glCtx, tex.target, dst, data, layout, size, format, dataType)
+	}
+	// Restore default alignment after upload.
+	if tex.format == gputypes.TextureFormatR8Unorm {
+		glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
 	}
 
 	glCtx.BindTexture(tex.target, 0)
@@ -146,6 +157,15 @@ func (q *Queue) Present(surface hal.Surface, _ hal.SurfaceTexture, _ []image.Rec
 		return fmt.Errorf("gles: invalid surface type")
 	}
 
+	// Headless surfaces have nothing to swap; the frame stays in the
+	// swapchain FBO for ReadPixels.
+	if surf.headless {
+		glCtx := q.ctx.Lock()
+		defer q.ctx.Unlock()
+		glCtx.Finish()
+		return nil
+	}
+
 	// Get fresh DC for the user window (Rust: Gdi::GetDC(self.window)).
 	hdc := wgl.GetDC(surf.hwnd)
 	if hdc == 0 {
@@ -22,12 +22,15 @@ type Queue struct {
 	eglCtx          *egl.Context
 	submissionIndex uint64
 	fence           *Fence // signaled at each submit for GPU completion tracking
+	state           stateCache
 }
 
 // Submit submits command buffers to the GPU.
 // After executing all commands, signals the fence with a GL sync object then
 // flushes — the fence must precede flush so PollCompleted sees it.
 func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
+	// GL state may have changed since the last submit; start from unknown.
+	q.state.reset()
 	for _, cb := range commandBuffers {
 		cmdBuf, ok := cb.(*CommandBuffer)
 		if !ok {
@@ -36,7 +39,7 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 
 		// Execute recorded commands with GL error checking.
 		for i, cmd := range cmdBuf.commands {
-			cmd.Execute(q.glCtx)
+			cmd.Execute(q.glCtx, &q.state)
 			if glErr := q.glCtx.GetError(); glErr != 0 {
 				detail := fmt.Sprintf("%T", cmd)
 				if vaoCmd, ok := cmd.(*BindVAOCommand); ok {
@@ -104,21 +107,24 @@ func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal
 
 	q.glCtx.BindTexture(tex.target, tex.id)
 
-	if tex.target == gl.TEXTURE_2D {
-		// Set alignment to 1 for single-channel formats (R8) whose row stride
-		// may not be a multiple of the default 4-byte GL_UNPACK_ALIGNMENT.
-		if tex.format == gputypes.TextureFormatR8Unorm {
-			q.glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
-		}
+	// Set alignment to 1 for single-channel formats (R8) whose row stride
+	// may not be a multiple of the default 4-byte GL_UNPACK_ALIGNMENT.
+	if tex.format == gputypes.TextureFormatR8Unorm {
+		q.glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	}
+	switch tex.target {
+	case gl.TEXTURE_2D:
 		// Use TexSubImage2D to update existing texture data (Rust wgpu-hal pattern).
 		// TexImage2D reallocates storage on every call; TexSubImage2D updates in-place.
 		q.glCtx.TexSubImage2D(tex.target, int32(dst.MipLevel),
 			0, 0, int32(size.Width), int32(size.Height), format, dataType,
 			unsafe.Pointer(&data[0]))
-		// Restore default alignment after upload.
-		if tex.format == gputypes.TextureFormatR8Unorm {
-			q.glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
-		}
+	case gl.TEXTURE_CUBE_MAP, gl.TEXTURE_2D_ARRAY, gl.TEXTURE_CUBE_MAP_ARRAY, gl.TEXTURE_3D:
+		writeTextureLayers(q.glCtx, tex.target, dst, data, layout, size, format, dataType)
+	}
+	// Restore default alignment after upload.
+	if tex.format == gputypes.TextureFormatR8Unorm {
+		q.glCtx.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
 	}
 
 	q.glCtx.BindTexture(tex.target, 0)
@@ -152,6 +158,13 @@ func (q *Queue) Present(surface hal.Surface, _ hal.SurfaceTexture, damageRects [
 		return fmt.Errorf("gles: invalid surface type")
 	}
 
+	// Headless surfaces have nothing to swap; the frame stays in the
+	// swapchain FBO for ReadPixels.
+	if surf.headless {
+		surf.glCtx.Finish()
+		return nil
+	}
+
 	surf.blitSwapchainToDefault()
 
 	// Use damage-aware swap when the extension is available and rects provided.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/naga/glsl"
@@ -53,6 +54,95 @@ type Texture struct {
 	glCtx       *gl.Context
 }
 
+// textureTarget picks the GL target for a texture. GL fixes the target when
+// the texture is created, so layered 2D textures rely on the descriptor's
+// TextureBindingViewDimension to choose cube or cube array storage over a
+// plain 2D array. Matches Rust wgpu-hal gles/device.rs create_texture.
+func textureTarget(desc *hal.TextureDescriptor, sampleCount uint32) uint32 {
+	if desc.Dimension == gputypes.TextureDimension3D {
+		return gl.TEXTURE_3D
+	}
+	if sampleCount > 1 {
+		return gl.TEXTURE_2D_MULTISAMPLE
+	}
+	switch desc.TextureBindingViewDimension {
+	case gputypes.TextureViewDimensionCube:
+		return gl.TEXTURE_CUBE_MAP
+	case gputypes.TextureViewDimensionCubeArray:
+		return gl.TEXTURE_CUBE_MAP_ARRAY
+	case gputypes.TextureViewDimension2DArray:
+		return gl.TEXTURE_2D_ARRAY
+	}
+	if desc.Size.DepthOrArrayLayers > 1 {
+		return gl.TEXTURE_2D_ARRAY
+	}
+	// GL ES has no 1D textures; 1D uses 2D with height 1.
+	return gl.TEXTURE_2D
+}
+
+// isLayeredTarget reports whether target stores its layers (or depth
+// slices) along the third TexImage3D axis.
+func isLayeredTarget(target uint32) bool {
+	return target == gl.TEXTURE_2D_ARRAY || target == gl.TEXTURE_CUBE_MAP_ARRAY || target == gl.TEXTURE_3D
+}
+
+// viewDimensionToGLTarget returns the target a texture bound through a
+// layout entry of dim must be bound to.
+func viewDimensionToGLTarget(dim gputypes.TextureViewDimension, multisampled bool) uint32 {
+	switch dim {
+	case gputypes.TextureViewDimension2DArray:
+		return gl.TEXTURE_2D_ARRAY
+	case gputypes.TextureViewDimensionCube:
+		return gl.TEXTURE_CUBE_MAP
+	case gputypes.TextureViewDimensionCubeArray:
+		return gl.TEXTURE_CUBE_MAP_ARRAY
+	case gputypes.TextureViewDimension3D:
+		return gl.TEXTURE_3D
+	}
+	if multisampled {
+		return gl.TEXTURE_2D_MULTISAMPLE
+	}
+	return gl.TEXTURE_2D
+}
+
+// writeTextureLayers uploads size.DepthOrArrayLayers layers of data into a
+// cube map, array or 3D texture bound to target, starting at layer (or
+// face, or depth slice) dst.Origin.Z. Layers are BytesPerRow*RowsPerImage
+// bytes apart in data, or evenly split when the layout leaves that unset.
+func writeTextureLayers(ctx *gl.Context, target uint32, dst *hal.ImageCopyTexture, data []byte,
+	layout *hal.ImageDataLayout, size *hal.Extent3D, format, dataType uint32) {
+	layers := max(size.DepthOrArrayLayers, 1)
+	offset := layout.Offset
+	if offset >= uint64(len(data)) {
+		return
+	}
+	layerStride := uint64(layout.BytesPerRow) * uint64(layout.RowsPerImage)
+	if layout.RowsPerImage == 0 {
+		layerStride = uint64(layout.BytesPerRow) * uint64(size.Height)
+	}
+	if layerStride == 0 {
+		layerStride = (uint64(len(data)) - offset) / uint64(layers)
+	}
+
+	for i := uint32(0); i < layers; i++ {
+		start := offset + uint64(i)*layerStride
+		if start >= uint64(len(data)) {
+			return
+		}
+		pixels := unsafe.Pointer(&data[start])
+		z := dst.Origin.Z + i
+		if target == gl.TEXTURE_CUBE_MAP {
+			ctx.TexSubImage2D(gl.TEXTURE_CUBE_MAP_POSITIVE_X+z, int32(dst.MipLevel),
+				int32(dst.Origin.X), int32(dst.Origin.Y), int32(size.Width), int32(size.Height),
+				format, dataType, pixels)
+			continue
+		}
+		ctx.TexSubImage3D(target, int32(dst.MipLevel),
+			int32(dst.Origin.X), int32(dst.Origin.Y), int32(z), int32(size.Width), int32(size.Height), 1,
+			format, dataType, pixels)
+	}
+}
+
 // CurrentUsage returns 0 — GLES has no explicit resource state tracking.
 func (t *Texture) CurrentUsage() gputypes.TextureUsage { return 0 }
 func (t *Texture) AddPendingRef()                      {}
@@ -179,6 +269,23 @@ type PipelineLayout struct {
 // Destroy is a no-op for pipeline layouts.
 func (l *PipelineLayout) Destroy() {}
 
+// storageBufferCount returns the number of GL storage buffer binding points
+// the layout uses.
+func (l *PipelineLayout) storageBufferCount() uint32 {
+	var n uint32
+	for _, bgl := range l.bindGroupLayouts {
+		if bgl == nil {
+			continue
+		}
+		for _, entry := range bgl.entries {
+			if classifyBindGroupEntry(entry) == bindingClassStorageBuffer {
+				n++
+			}
+		}
+	}
+	return n
+}
+
 // RenderPipeline implements hal.RenderPipeline for OpenGL.
 type RenderPipeline struct {
 	programID uint32 // GL program object ID
@@ -187,6 +294,7 @@ type RenderPipeline struct {
 
 	// Pipeline state
 	primitiveTopology gputypes.PrimitiveTopology
+	polygonMode       hal.PolygonMode
 	cullMode          gputypes.CullMode
 	frontFace         gputypes.FrontFace
 	depthStencil      *hal.DepthStencilState
@@ -204,6 +312,10 @@ type RenderPipeline struct {
 	// SetVertexBuffer can configure attributes using the pipeline's layout.
 	vertexBuffers []gputypes.VertexBufferLayout
 
+	// vertexPulling lists the vertex buffers whose attributes the vertex
+	// shader reads from storage buffers, nil when all are fetched natively.
+	vertexPulling *vertexPulling
+
 	// samplerBindMap maps texture unit indices to sampler unit indices.
 	// Built from naga GLSL TranslationInfo.TextureMappings at pipeline creation.
 	// When binding textures, the associated sampler must be bound to the SAME
@@ -38,6 +38,17 @@ type Surface struct {
 	eglWindow uintptr // wl_egl_window* (0 on X11 or before Configure)
 	isWayland bool    // true if the Context was created for Wayland
 
+	// log is the owning instance's logger configuration.
+	log *hal.Log
+
+	// options are the owning instance's GL backend options.
+	options hal.GLOptions
+
+	// headless is true for surfaces created from hal.SurfaceTargetHeadless.
+	// They have no EGL window surface: the swapchain FBO is the whole
+	// swapchain, Present only finishes rendering and ReadPixels captures it.
+	headless bool
+
 	// Swapchain offscreen framebuffer. User render passes that target this
 	// Surface render into swapchainFBO (backed by colorRenderbuffer), not FBO 0.
 	// Queue.Present blits this FBO to the default framebuffer with an explicit
@@ -70,6 +81,8 @@ func (s *Surface) GetAdapterInfo() hal.ExposedAdapter {
 			version:       s.version,
 			renderer:      s.renderer,
 			caps:          caps,
+			log:           s.log,
+			options:       s.options,
 		},
 		Info: gputypes.AdapterInfo{
 			Name:       caps.Renderer,
@@ -81,6 +94,7 @@ func (s *Surface) GetAdapterInfo() hal.ExposedAdapter {
 			DriverInfo: driverInfo,
 			Backend:    gputypes.BackendGL,
 		},
+		Details:  hal.AdapterDetails{DriverVersion: hal.ParseDriverVersion(caps.Version)},
 		Features: caps.Features,
 		Capabilities: hal.Capabilities{
 			Limits: caps.Limits,
@@ -129,10 +143,15 @@ func (s *Surface) Configure(_ hal.Device, config *hal.SurfaceConfiguration) erro
 	}
 
 	// Make the EGL window surface current so we can allocate GL resources.
-	if s.eglSurface != 0 && s.eglDisplay != 0 {
+	// Headless surfaces use the context's own pbuffer (or no surface).
+	if s.headless && s.eglCtx != nil {
+		if err := s.eglCtx.MakeCurrent(); err != nil {
+			return fmt.Errorf("gles: headless surface: %w", err)
+		}
+	} else if s.eglSurface != 0 && s.eglDisplay != 0 {
 		result := egl.MakeCurrent(s.eglDisplay, s.eglSurface, s.eglSurface, s.eglCtx.EGLContext())
 		if result == egl.False {
-			hal.Logger().Error("gles: Configure eglMakeCurrent FAILED", "error", fmt.Sprintf("0x%x", egl.GetError()))
+			s.log.For(hal.LogSwapchain).Error("gles: Configure eglMakeCurrent FAILED", "error", fmt.Sprintf("0x%x", egl.GetError()))
 		}
 	}
 
@@ -189,7 +208,7 @@ func (s *Surface) createWaylandEGLSurface(width, height uint32) error {
 	if egl.HasPlatformWindowSurface() {
 		eglPath = "eglCreatePlatformWindowSurface (EGL 1.5)"
 	}
-	hal.Logger().Info("gles: Wayland EGL window surface created",
+	s.log.For(hal.LogSwapchain).Info("gles: Wayland EGL window surface created",
 		"path", eglPath,
 		"eglWindow", fmt.Sprintf("0x%x", eglWin),
 		"eglSurface", fmt.Sprintf("0x%x", eglSurface),
@@ -207,7 +226,7 @@ func (s *Surface) createX11EGLSurface() error {
 	}
 	s.eglSurface = eglSurface
 
-	hal.Logger().Info("gles: X11 EGL window surface created",
+	s.log.For(hal.LogSwapchain).Info("gles: X11 EGL window surface created",
 		"eglSurface", fmt.Sprintf("0x%x", eglSurface),
 		"window", fmt.Sprintf("0x%x", s.windowHandle),
 	)
@@ -260,6 +279,13 @@ func (s *Surface) ActualExtent() (width, height uint32) {
 	return s.config.Width, s.config.Height
 }
 
+// ReadPixels returns the last frame rendered to the surface as tightly
+// packed RGBA8 rows in top-left order, or nil if the surface is not
+// configured. Implements hal.PixelReader.
+func (s *Surface) ReadPixels() []byte {
+	return s.readSwapchainPixelsWith(s.glCtx)
+}
+
 // Destroy releases the surface resources.
 // Order: GL resources → EGL surface → wl_egl_window → EGL context.
 func (s *Surface) Destroy() {
@@ -25,6 +25,17 @@ type Surface struct {
 	configured bool
 	config     *hal.SurfaceConfiguration
 
+	// log is the owning instance's logger configuration.
+	log *hal.Log
+
+	// options are the owning instance's GL backend options.
+	options hal.GLOptions
+
+	// headless is true for surfaces created from hal.SurfaceTargetHeadless.
+	// They have no HWND: the swapchain FBO is the whole swapchain, Present
+	// only finishes rendering and ReadPixels captures it.
+	headless bool
+
 	// Swapchain offscreen framebuffer. User render passes that target this
 	// Surface render into swapchainFBO (backed by colorRenderbuffer), not FBO 0.
 	// Queue.Present blits this FBO to the default framebuffer with an explicit
@@ -55,6 +66,8 @@ func (s *Surface) GetAdapterInfo() hal.ExposedAdapter {
 			ctx:     s.ctx,
 			version: fmt.Sprintf("%d.%d", caps.GLMajor, caps.GLMinor),
 			caps:    caps,
+			log:     s.log,
+			options: s.options,
 		},
 		Info: gputypes.AdapterInfo{
 			Name:       caps.Renderer,
@@ -66,6 +79,7 @@ func (s *Surface) GetAdapterInfo() hal.ExposedAdapter {
 			DriverInfo: driverInfo,
 			Backend:    gputypes.BackendGL,
 		},
+		Details:  hal.AdapterDetails{DriverVersion: hal.ParseDriverVersion(caps.Version)},
 		Features: caps.Features,
 		Capabilities: hal.Capabilities{
 			Limits: caps.Limits,
@@ -93,7 +107,10 @@ func (s *Surface) Configure(_ hal.Device, config *hal.SurfaceConfiguration) erro
 
 	// Scope 1: Set swap interval on user window DC.
 	// SetSwapInterval requires a current context on the target DC.
-	hdc := wgl.GetDC(s.hwnd)
+	var hdc wgl.HDC
+	if !s.headless {
+		hdc = wgl.GetDC(s.hwnd)
+	}
 	if hdc != 0 {
 		s.ctx.LockForDC(hdc)
 		wgl.LoadExtensions(hdc)
@@ -161,6 +178,15 @@ func (s *Surface) ActualExtent() (width, height uint32) {
 	return s.config.Width, s.config.Height
 }
 
+// ReadPixels returns the last frame rendered to the surface as tightly
+// packed RGBA8 rows in top-left order, or nil if the surface is not
+// configured. Implements hal.PixelReader.
+func (s *Surface) ReadPixels() []byte {
+	glCtx := s.ctx.Lock()
+	defer s.ctx.Unlock()
+	return s.readSwapchainPixelsWith(glCtx)
+}
+
 // Destroy releases the surface resources.
 // Does NOT destroy the GL context — that's owned by Instance.
 func (s *Surface) Destroy() {
@@ -13,7 +13,8 @@ import (
 
 // configureSampler allocates a GL sampler object and sets its parameters from the descriptor.
 // Returns the GL sampler object ID, or 0 if sampler objects are not supported.
-func configureSampler(glCtx *gl.Context, desc *hal.SamplerDescriptor) uint32 {
+// OpenGL ES has no sampler LOD bias, so es drops desc.LodBias.
+func configureSampler(glCtx *gl.Context, desc *hal.SamplerDescriptor, es bool) uint32 {
 	if !glCtx.SupportsSamplerObjects() {
 		return 0
 	}
@@ -42,6 +43,9 @@ func configureSampler(glCtx *gl.Context, desc *hal.SamplerDescriptor) uint32 {
 		lodMax = 32.0
 	}
 	glCtx.SamplerParameterf(id, gl.TEXTURE_MAX_LOD, lodMax)
+	if desc.LodBias != 0 && !es {
+		glCtx.SamplerParameterf(id, gl.TEXTURE_LOD_BIAS, desc.LodBias)
+	}
 
 	// Anisotropic filtering (if requested and > 1).
 	if desc.Anisotropy > 1 {
@@ -13,14 +13,32 @@ import (
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/naga"
 	"github.com/gogpu/naga/glsl"
+	"github.com/gogpu/naga/ir"
 	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/gles/gl"
 )
 
+// glslTarget is what a device compiles WGSL for: the GLSL version, the
+// bounds checks naga adds to image accesses and the implicit-LOD samples
+// rewritten to explicit ones.
+type glslTarget struct {
+	version      glsl.Version
+	boundsChecks glsl.BoundsCheckPolicies
+	implicitLod  hal.ImplicitLodRewrite
+}
+
+// robustBoundsChecks are the naga checks of a device opened with
+// hal.FeatureRobustBufferAccess. Out-of-bounds image loads return zero and
+// stores are skipped; buffer accesses are guarded by the robust context.
+var robustBoundsChecks = glsl.BoundsCheckPolicies{
+	ImageLoad:  glsl.BoundsCheckReadZeroSkipWrite,
+	ImageStore: glsl.BoundsCheckReadZeroSkipWrite,
+}
+
 // compileWGSLToGLSL compiles a WGSL shader source to GLSL for the given entry point.
 // OpenGL does not understand WGSL, so we use naga to parse WGSL and emit GLSL.
 //
-// The version parameter specifies the target GLSL version. On GL 4.3+ this is typically
+// The target's version is the GLSL version to emit. On GL 4.3+ this is typically
 // Version430; on older drivers (e.g., GL 4.1 / GLSL 410) it must match the driver's
 // reported GLSL version. When version < 420 (desktop) or < 310 (ES), naga omits
 // layout(binding=N) qualifiers and the caller must assign bindings at runtime via
@@ -32,32 +50,65 @@ import (
 //
 // Returns the GLSL source and TranslationInfo containing TextureMappings for
 // SamplerBindMap construction (which sampler goes with which texture unit).
-func compileWGSLToGLSL(version glsl.Version, source hal.ShaderSource, entryPoint string, bindingMap map[glsl.BindingMapKey]uint8) (string, glsl.TranslationInfo, error) {
+func compileWGSLToGLSL(target glslTarget, source hal.ShaderSource, entryPoint string, bindingMap map[glsl.BindingMapKey]uint8) (string, glsl.TranslationInfo, error) {
+	module, err := parseWGSLModule(source)
+	if err != nil {
+		return "", glsl.TranslationInfo{}, err
+	}
+	return compileModuleToGLSL(target, module, entryPoint, bindingMap)
+}
+
+// compileVertexWGSLToGLSL compiles a vertex entry point like compileWGSLToGLSL,
+// first rewriting it to pull the attributes listed in pulling from storage
+// buffers. A nil pulling compiles the entry point unchanged.
+func compileVertexWGSLToGLSL(target glslTarget, source hal.ShaderSource, entryPoint string, bindingMap map[glsl.BindingMapKey]uint8, pulling *vertexPulling) (string, glsl.TranslationInfo, error) {
+	if pulling == nil {
+		return compileWGSLToGLSL(target, source, entryPoint, bindingMap)
+	}
+	module, err := parseWGSLModule(source)
+	if err != nil {
+		return "", glsl.TranslationInfo{}, err
+	}
+	if err := pulling.apply(module, entryPoint); err != nil {
+		return "", glsl.TranslationInfo{}, err
+	}
+	return compileModuleToGLSL(target, module, entryPoint, pulling.bindingMap(bindingMap))
+}
+
+// parseWGSLModule parses and lowers WGSL source to naga IR.
+func parseWGSLModule(source hal.ShaderSource) (*ir.Module, error) {
 	if source.WGSL == "" {
-		return "", glsl.TranslationInfo{}, fmt.Errorf("gles: shader source has no WGSL code")
+		return nil, fmt.Errorf("gles: shader source has no WGSL code")
 	}
 
 	// Parse WGSL to AST.
 	ast, err := naga.Parse(source.WGSL)
 	if err != nil {
-		return "", glsl.TranslationInfo{}, fmt.Errorf("gles: WGSL parse error: %w", err)
+		return nil, fmt.Errorf("gles: WGSL parse error: %w", err)
 	}
 
 	// Lower AST to IR.
 	module, err := naga.Lower(ast)
 	if err != nil {
-		return "", glsl.TranslationInfo{}, fmt.Errorf("gles: WGSL lower error: %w", err)
+		return nil, fmt.Errorf("gles: WGSL lower error: %w", err)
 	}
+	return module, nil
+}
+
+// compileModuleToGLSL emits GLSL for one entry point of a lowered module.
+func compileModuleToGLSL(target glslTarget, module *ir.Module, entryPoint string, bindingMap map[glsl.BindingMapKey]uint8) (string, glsl.TranslationInfo, error) {
+	rewriteImplicitLod(module, target.implicitLod)
 
 	// Compile IR to the target GLSL version.
 	// On GL 4.3+ this emits layout(binding=N) qualifiers inline. On older versions
 	// (< 420 desktop / < 310 ES) naga omits them and the HAL assigns bindings at
 	// runtime after linking (see assignBindingsAfterLink).
 	glslCode, translationInfo, err := glsl.Compile(module, glsl.Options{
-		LangVersion:        version,
-		EntryPoint:         entryPoint,
-		ForceHighPrecision: true,
-		BindingMap:         bindingMap,
+		LangVersion:         target.version,
+		EntryPoint:          entryPoint,
+		ForceHighPrecision:  true,
+		BoundsCheckPolicies: target.boundsChecks,
+		BindingMap:          bindingMap,
 		// ADJUST_COORDINATE_SPACE: naga appends gl_Position.yz = vec2(-gl_Position.y, gl_Position.z * 2.0 - gl_Position.w)
 		// at the end of vertex shaders. This flips Y and remaps Z from [0,1] to [-1,1].
 		// The scene renders upside-down inside the Surface's swapchain offscreen FBO
@@ -75,21 +126,41 @@ func compileWGSLToGLSL(version glsl.Version, source hal.ShaderSource, entryPoint
 		return "", glsl.TranslationInfo{}, fmt.Errorf("gles: GLSL compile error for entry point %q: %w", entryPoint, err)
 	}
 
-	hal.Logger().Debug("gles: GLSL generated",
+	log := hal.CategoryLogger(hal.LogShader)
+	log.Debug("gles: GLSL generated",
 		"entryPoint", entryPoint,
 		"sourceLen", len(glslCode),
 	)
-	if hal.Logger().Enabled(context.Background(), slog.LevelDebug) {
+	if log.Enabled(context.Background(), slog.LevelDebug) {
 		preview := glslCode
 		if len(preview) > 2000 {
 			preview = preview[:2000] + "..."
 		}
-		hal.Logger().Debug("gles: GLSL source", "glsl", preview)
+		log.Debug("gles: GLSL source", "glsl", preview)
 	}
 
 	return glslCode, translationInfo, nil
 }
 
+// compileEmptyFragmentShader compiles a fragment shader that writes nothing,
+// for pipelines without a fragment stage. OpenGL ES programs do not link
+// without a fragment shader; desktop GL does, so this is only used on ES.
+// Matches Rust wgpu-hal gles/device.rs create_pipeline.
+func compileEmptyFragmentShader(glCtx *gl.Context, version glsl.Version) (uint32, error) {
+	fragmentID := glCtx.CreateShader(gl.FRAGMENT_SHADER)
+	glCtx.ShaderSource(fragmentID, "#version "+version.String()+"\nvoid main(void) {}\n")
+	glCtx.CompileShader(fragmentID)
+
+	var status int32
+	glCtx.GetShaderiv(fragmentID, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		log := glCtx.GetShaderInfoLog(fragmentID)
+		glCtx.DeleteShader(fragmentID)
+		return 0, fmt.Errorf("gles: empty fragment shader compilation failed: %s", log)
+	}
+	return fragmentID, nil
+}
+
 // assignBindingsAfterLink assigns uniform block and sampler bindings at runtime
 // after glLinkProgram on GL < 4.2 where layout(binding=N) is unavailable.
 //
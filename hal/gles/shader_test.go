@@ -0,0 +1,88 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/naga/glsl"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestCompileWGSLToGLSLBoundsChecks(t *testing.T) {
+	const source = `
+@group(0) @binding(0) var target: texture_storage_2d<rgba8unorm, write>;
+@group(0) @binding(1) var source: texture_2d<f32>;
+
+@compute @workgroup_size(1)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    textureStore(target, vec2<i32>(id.xy), textureLoad(source, vec2<i32>(id.xy), 0));
+}
+`
+	compile := func(target glslTarget) string {
+		t.Helper()
+		code, _, err := compileWGSLToGLSL(target, hal.ShaderSource{WGSL: source}, "main", nil)
+		if err != nil {
+			t.Fatalf("compileWGSLToGLSL: %v", err)
+		}
+		return code
+	}
+
+	if code := compile(glslTarget{version: glsl.VersionES310}); strings.Contains(code, "textureSize") {
+		t.Errorf("unchecked target bounds-checks the texel fetch:\n%s", code)
+	}
+	if code := compile(glslTarget{version: glsl.VersionES310, boundsChecks: robustBoundsChecks}); !strings.Contains(code, "textureSize") {
+		t.Errorf("robust target does not bounds-check the texel fetch:\n%s", code)
+	}
+}
+
+func TestCompileWGSLToGLSLImplicitLod(t *testing.T) {
+	const source = `
+@group(0) @binding(0) var tex: texture_2d<f32>;
+@group(0) @binding(1) var samp: sampler;
+
+fn shade(uv: vec2<f32>) -> vec4<f32> {
+    return textureSampleBias(tex, samp, uv, 1.0);
+}
+
+@fragment
+fn main(@location(0) uv: vec2<f32>) -> @location(0) vec4<f32> {
+    var color = textureSample(tex, samp, uv);
+    if uv.x > 0.5 {
+        color += shade(uv);
+    }
+    return color;
+}
+`
+	compile := func(rewrite hal.ImplicitLodRewrite) string {
+		t.Helper()
+		target := glslTarget{version: glsl.VersionES300, implicitLod: rewrite}
+		code, _, err := compileWGSLToGLSL(target, hal.ShaderSource{WGSL: source}, "main", nil)
+		if err != nil {
+			t.Fatalf("compileWGSLToGLSL: %v", err)
+		}
+		return code
+	}
+
+	for _, tc := range []struct {
+		rewrite       hal.ImplicitLodRewrite
+		texture, lods int
+	}{
+		{hal.ImplicitLodKeep, 2, 0},
+		// The sample in shade is reached from the if.
+		{hal.ImplicitLodInControlFlow, 1, 1},
+		{hal.ImplicitLodAlways, 0, 2},
+	} {
+		code := compile(tc.rewrite)
+		if got := strings.Count(code, "texture("); got != tc.texture {
+			t.Errorf("rewrite %d: %d implicit samples, want %d:\n%s", tc.rewrite, got, tc.texture, code)
+		}
+		if got := strings.Count(code, "textureLod("); got != tc.lods {
+			t.Errorf("rewrite %d: %d explicit samples, want %d:\n%s", tc.rewrite, got, tc.lods, code)
+		}
+	}
+}
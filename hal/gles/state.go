@@ -0,0 +1,243 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import "github.com/gogpu/wgpu/hal/gles/gl"
+
+// stateCache shadows the GL state that command replay sets on every draw —
+// program, VAO, texture, sampler and indexed buffer bindings, enabled
+// capabilities, blend, cull, depth and color mask state — and skips calls
+// that would set a value already in place.
+//
+// The cache only knows what it set itself. Queue.Submit resets it before
+// replaying, since resource creation, uploads and presentation change the
+// same state between submissions without going through it. A nil cache
+// forwards every call.
+type stateCache struct {
+	program    cachedValue[uint32]
+	vao        cachedValue[uint32]
+	activeUnit cachedValue[uint32]
+	textures   map[textureBinding]uint32
+	samplers   map[uint32]uint32
+	buffers    map[bufferBinding]bufferRange
+	caps       map[uint32]bool
+
+	blendFunc     cachedValue[[4]uint32]
+	blendEquation cachedValue[[2]uint32]
+	cullFace      cachedValue[uint32]
+	frontFace     cachedValue[uint32]
+	colorMask     cachedValue[[4]bool]
+	depthMask     cachedValue[bool]
+	depthFunc     cachedValue[uint32]
+}
+
+// textureBinding is a texture binding point: a texture unit, as the
+// GL_TEXTUREi enum, and a target such as GL_TEXTURE_2D.
+type textureBinding struct {
+	unit, target uint32
+}
+
+// bufferBinding is an indexed buffer binding point, such as binding 2 of
+// GL_UNIFORM_BUFFER.
+type bufferBinding struct {
+	target, index uint32
+}
+
+// bufferRange is the buffer range bound to a bufferBinding. A zero size
+// stands for the whole buffer, as bound by glBindBufferBase.
+type bufferRange struct {
+	buffer       uint32
+	offset, size int
+}
+
+// cachedValue is one piece of shadowed state; the zero value is unknown.
+type cachedValue[T comparable] struct {
+	value T
+	known bool
+}
+
+// update records v and reports whether it differs from the cached value,
+// that is, whether the GL call setting it must be made.
+func (c *cachedValue[T]) update(v T) bool {
+	if c.known && c.value == v {
+		return false
+	}
+	c.value, c.known = v, true
+	return true
+}
+
+// reset forgets all shadowed state.
+func (s *stateCache) reset() {
+	if s == nil {
+		return
+	}
+	*s = stateCache{textures: s.textures, samplers: s.samplers, buffers: s.buffers, caps: s.caps}
+	clear(s.textures)
+	clear(s.samplers)
+	clear(s.buffers)
+	clear(s.caps)
+}
+
+func (s *stateCache) useProgram(ctx *gl.Context, program uint32) {
+	if s == nil || s.program.update(program) {
+		ctx.UseProgram(program)
+	}
+}
+
+func (s *stateCache) bindVertexArray(ctx *gl.Context, vao uint32) {
+	if s == nil || s.vao.update(vao) {
+		ctx.BindVertexArray(vao)
+	}
+}
+
+// activeTexture selects the texture unit, given as GL_TEXTURE0 + i.
+func (s *stateCache) activeTexture(ctx *gl.Context, unit uint32) {
+	if s == nil || s.activeUnit.update(unit) {
+		ctx.ActiveTexture(unit)
+	}
+}
+
+// bindTexture binds texture to target on the active texture unit.
+func (s *stateCache) bindTexture(ctx *gl.Context, target, texture uint32) {
+	if s.textureChanged(target, texture) {
+		ctx.BindTexture(target, texture)
+	}
+}
+
+// textureChanged records texture as bound to target on the active unit and
+// reports whether it was not already. Bindings on an unknown unit are not
+// recorded.
+func (s *stateCache) textureChanged(target, texture uint32) bool {
+	if s == nil || !s.activeUnit.known {
+		return true
+	}
+	key := textureBinding{unit: s.activeUnit.value, target: target}
+	if bound, ok := s.textures[key]; ok && bound == texture {
+		return false
+	}
+	if s.textures == nil {
+		s.textures = make(map[textureBinding]uint32)
+	}
+	s.textures[key] = texture
+	return true
+}
+
+func (s *stateCache) bindSampler(ctx *gl.Context, unit, sampler uint32) {
+	if s.samplerChanged(unit, sampler) {
+		ctx.BindSampler(unit, sampler)
+	}
+}
+
+func (s *stateCache) samplerChanged(unit, sampler uint32) bool {
+	if s == nil {
+		return true
+	}
+	if bound, ok := s.samplers[unit]; ok && bound == sampler {
+		return false
+	}
+	if s.samplers == nil {
+		s.samplers = make(map[uint32]uint32)
+	}
+	s.samplers[unit] = sampler
+	return true
+}
+
+// bindBufferRange binds size bytes of buffer from offset to an indexed
+// binding point, or the whole buffer when size is zero. Bind groups set
+// again for each draw rebind the same ranges, which this skips.
+func (s *stateCache) bindBufferRange(ctx *gl.Context, target, index, buffer uint32, offset, size int) {
+	if !s.bufferRangeChanged(target, index, bufferRange{buffer: buffer, offset: offset, size: size}) {
+		return
+	}
+	if size > 0 {
+		ctx.BindBufferRange(target, index, buffer, offset, size)
+	} else {
+		ctx.BindBufferBase(target, index, buffer)
+	}
+}
+
+func (s *stateCache) bufferRangeChanged(target, index uint32, r bufferRange) bool {
+	if s == nil {
+		return true
+	}
+	key := bufferBinding{target: target, index: index}
+	if bound, ok := s.buffers[key]; ok && bound == r {
+		return false
+	}
+	if s.buffers == nil {
+		s.buffers = make(map[bufferBinding]bufferRange)
+	}
+	s.buffers[key] = r
+	return true
+}
+
+// setCapability enables or disables a capability such as GL_BLEND.
+func (s *stateCache) setCapability(ctx *gl.Context, capability uint32, enabled bool) {
+	if !s.capabilityChanged(capability, enabled) {
+		return
+	}
+	if enabled {
+		ctx.Enable(capability)
+	} else {
+		ctx.Disable(capability)
+	}
+}
+
+func (s *stateCache) capabilityChanged(capability uint32, enabled bool) bool {
+	if s == nil {
+		return true
+	}
+	if on, ok := s.caps[capability]; ok && on == enabled {
+		return false
+	}
+	if s.caps == nil {
+		s.caps = make(map[uint32]bool)
+	}
+	s.caps[capability] = enabled
+	return true
+}
+
+func (s *stateCache) blendFuncSeparate(ctx *gl.Context, srcRGB, dstRGB, srcAlpha, dstAlpha uint32) {
+	if s == nil || s.blendFunc.update([4]uint32{srcRGB, dstRGB, srcAlpha, dstAlpha}) {
+		ctx.BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha)
+	}
+}
+
+func (s *stateCache) blendEquationSeparate(ctx *gl.Context, modeRGB, modeAlpha uint32) {
+	if s == nil || s.blendEquation.update([2]uint32{modeRGB, modeAlpha}) {
+		ctx.BlendEquationSeparate(modeRGB, modeAlpha)
+	}
+}
+
+func (s *stateCache) setCullFace(ctx *gl.Context, mode uint32) {
+	if s == nil || s.cullFace.update(mode) {
+		ctx.CullFace(mode)
+	}
+}
+
+func (s *stateCache) setFrontFace(ctx *gl.Context, mode uint32) {
+	if s == nil || s.frontFace.update(mode) {
+		ctx.FrontFace(mode)
+	}
+}
+
+func (s *stateCache) setColorMask(ctx *gl.Context, r, g, b, a bool) {
+	if s == nil || s.colorMask.update([4]bool{r, g, b, a}) {
+		ctx.ColorMask(r, g, b, a)
+	}
+}
+
+func (s *stateCache) setDepthMask(ctx *gl.Context, enabled bool) {
+	if s == nil || s.depthMask.update(enabled) {
+		ctx.DepthMask(enabled)
+	}
+}
+
+func (s *stateCache) setDepthFunc(ctx *gl.Context, fn uint32) {
+	if s == nil || s.depthFunc.update(fn) {
+		ctx.DepthFunc(fn)
+	}
+}
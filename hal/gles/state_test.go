@@ -0,0 +1,133 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu/hal/gles/gl"
+)
+
+func TestCachedValue_Update(t *testing.T) {
+	var c cachedValue[uint32]
+	steps := []struct {
+		value uint32
+		want  bool
+	}{
+		{0, true}, // unknown: even the zero value is set
+		{0, false},
+		{7, true},
+		{7, false},
+		{0, true},
+	}
+	for i, s := range steps {
+		if got := c.update(s.value); got != s.want {
+			t.Errorf("step %d: update(%d) = %v, want %v", i, s.value, got, s.want)
+		}
+	}
+}
+
+func TestStateCache_Textures(t *testing.T) {
+	var s stateCache
+
+	// Without a known active unit nothing is recorded.
+	if !s.textureChanged(gl.TEXTURE_2D, 5) || !s.textureChanged(gl.TEXTURE_2D, 5) {
+		t.Fatal("binding on an unknown unit was skipped")
+	}
+
+	s.activeUnit.update(gl.TEXTURE0)
+	if !s.textureChanged(gl.TEXTURE_2D, 5) {
+		t.Error("first binding on unit 0 was skipped")
+	}
+	if s.textureChanged(gl.TEXTURE_2D, 5) {
+		t.Error("repeated binding on unit 0 was not skipped")
+	}
+	if !s.textureChanged(gl.TEXTURE_CUBE_MAP, 5) {
+		t.Error("binding to another target was skipped")
+	}
+
+	s.activeUnit.update(gl.TEXTURE0 + 1)
+	if !s.textureChanged(gl.TEXTURE_2D, 5) {
+		t.Error("binding on unit 1 was skipped")
+	}
+
+	// An unbind, such as after a copy, must be followed by a rebind.
+	if !s.textureChanged(gl.TEXTURE_2D, 0) || !s.textureChanged(gl.TEXTURE_2D, 5) {
+		t.Error("rebind after unbind was skipped")
+	}
+}
+
+func TestStateCache_CapabilitiesAndSamplers(t *testing.T) {
+	var s stateCache
+	if !s.capabilityChanged(gl.BLEND, false) {
+		t.Error("first disable was skipped")
+	}
+	if s.capabilityChanged(gl.BLEND, false) {
+		t.Error("repeated disable was not skipped")
+	}
+	if !s.capabilityChanged(gl.BLEND, true) {
+		t.Error("enable after disable was skipped")
+	}
+	if !s.capabilityChanged(gl.DEPTH_TEST, true) {
+		t.Error("another capability was skipped")
+	}
+
+	if !s.samplerChanged(0, 3) || s.samplerChanged(0, 3) || !s.samplerChanged(1, 3) {
+		t.Error("sampler bindings not tracked per unit")
+	}
+}
+
+func TestStateCache_BufferRanges(t *testing.T) {
+	var s stateCache
+	ubo := bufferRange{buffer: 4, offset: 256, size: 64}
+	if !s.bufferRangeChanged(gl.UNIFORM_BUFFER, 0, ubo) {
+		t.Error("first binding was skipped")
+	}
+	if s.bufferRangeChanged(gl.UNIFORM_BUFFER, 0, ubo) {
+		t.Error("repeated binding was not skipped")
+	}
+	// A dynamic offset moves the range within the same buffer.
+	if !s.bufferRangeChanged(gl.UNIFORM_BUFFER, 0, bufferRange{buffer: 4, offset: 512, size: 64}) {
+		t.Error("binding at a new offset was skipped")
+	}
+	if !s.bufferRangeChanged(gl.SHADER_STORAGE_BUFFER, 0, ubo) || !s.bufferRangeChanged(gl.UNIFORM_BUFFER, 1, ubo) {
+		t.Error("bindings not tracked per target and index")
+	}
+
+	s.reset()
+	if !s.bufferRangeChanged(gl.SHADER_STORAGE_BUFFER, 0, ubo) {
+		t.Error("reset kept buffer bindings")
+	}
+}
+
+func TestStateCache_Reset(t *testing.T) {
+	var s stateCache
+	s.program.update(1)
+	s.activeUnit.update(gl.TEXTURE0)
+	s.textureChanged(gl.TEXTURE_2D, 5)
+	s.capabilityChanged(gl.BLEND, true)
+	s.samplerChanged(0, 3)
+
+	s.reset()
+	if s.program.known || s.activeUnit.known {
+		t.Error("reset kept scalar state")
+	}
+	s.activeUnit.update(gl.TEXTURE0)
+	if !s.textureChanged(gl.TEXTURE_2D, 5) || !s.capabilityChanged(gl.BLEND, true) || !s.samplerChanged(0, 3) {
+		t.Error("reset kept bindings")
+	}
+}
+
+func TestStateCache_Nil(t *testing.T) {
+	var s *stateCache
+	s.reset()
+	if !s.textureChanged(gl.TEXTURE_2D, 5) || !s.textureChanged(gl.TEXTURE_2D, 5) ||
+		!s.capabilityChanged(gl.BLEND, true) || !s.capabilityChanged(gl.BLEND, true) ||
+		!s.samplerChanged(0, 3) || !s.samplerChanged(0, 3) ||
+		!s.bufferRangeChanged(gl.UNIFORM_BUFFER, 0, bufferRange{}) || !s.bufferRangeChanged(gl.UNIFORM_BUFFER, 0, bufferRange{}) {
+		t.Error("nil cache skipped a call")
+	}
+}
@@ -7,6 +7,7 @@ package gles
 
 import (
 	"fmt"
+	"unsafe"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal"
@@ -107,6 +108,28 @@ func (s *Surface) blitSwapchainToDefaultWith(glCtx *gl.Context) {
 	glCtx.BindFramebuffer(gl.DRAW_FRAMEBUFFER, 0)
 }
 
+// Surfaces expose the swapchain FBO for capture, which is how headless
+// surfaces hand their frames back.
+var _ hal.PixelReader = (*Surface)(nil)
+
+// readSwapchainPixelsWith reads the swapchain FBO back as tightly packed
+// RGBA8. The FBO holds the image top row first (Present flips it when
+// blitting to the window), so rows come back in top-left order as is.
+// Must be called with the GL context current.
+func (s *Surface) readSwapchainPixelsWith(glCtx *gl.Context) []byte {
+	if glCtx == nil || s.swapchainFBO == 0 || s.fboWidth == 0 || s.fboHeight == 0 {
+		return nil
+	}
+
+	pixels := make([]byte, int(s.fboWidth)*int(s.fboHeight)*4)
+	glCtx.BindFramebuffer(gl.READ_FRAMEBUFFER, s.swapchainFBO)
+	glCtx.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	glCtx.ReadPixels(0, 0, int32(s.fboWidth), int32(s.fboHeight), gl.RGBA, gl.UNSIGNED_BYTE, unsafe.Pointer(&pixels[0]))
+	glCtx.PixelStorei(gl.PACK_ALIGNMENT, 4)
+	glCtx.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	return pixels
+}
+
 // reconfigureSwapchainFBOWith destroys the existing swapchain FBO and
 // allocates a new one. Caller must hold the AdapterContext lock.
 func (s *Surface) reconfigureSwapchainFBOWith(glCtx *gl.Context, format gputypes.TextureFormat, width, height uint32) error {
@@ -0,0 +1,490 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/glsl"
+	"github.com/gogpu/naga/ir"
+)
+
+// Vertex pulling fallback.
+//
+// Vertex formats the GL context cannot fetch as attributes (half floats and
+// packed 10-10-10-2 on contexts without the matching attribute types) are
+// read by the vertex shader itself: the pipeline's vertex entry point is
+// rewritten in naga IR to load the raw words from the vertex buffer bound as
+// a read-only storage buffer and decode them before the original body runs.
+// Pipelines therefore accept the same vertex formats as on the other
+// backends, at the cost of requiring storage buffers in vertex shaders.
+//
+// Each pulled vertex buffer gets a storage buffer binding point after the
+// pipeline layout's own, and an extra integer attribute location whose
+// array stays disabled: its current value (glVertexAttribI4ui) carries the
+// byte offset the buffer was bound at, since storage buffer ranges must be
+// aligned more strictly than vertex buffer offsets.
+
+// vertexPullingGroup is the bind group the pulled vertex buffers are declared
+// in. It lies past any group a pipeline layout can have, so the storage
+// buffers never collide with the layout's bindings.
+const vertexPullingGroup = 1 << 16
+
+// vertexFormatSupport records which vertex formats the context fetches
+// natively, and whether vertex shaders can read storage buffers to pull the
+// others.
+type vertexFormatSupport struct {
+	// halfFloat is GL_HALF_FLOAT attributes (GL 3.0 / ES 3.0,
+	// GL_ARB_half_float_vertex, GL_OES_vertex_half_float).
+	halfFloat bool
+	// packed1010102 is GL_UNSIGNED_INT_2_10_10_10_REV attributes (GL 3.3 /
+	// ES 3.0, GL_ARB_vertex_type_2_10_10_10_rev).
+	packed1010102 bool
+	// vertexStorageBlocks is GL_MAX_VERTEX_SHADER_STORAGE_BLOCKS, zero when
+	// vertex shaders cannot read storage buffers.
+	vertexStorageBlocks int32
+	// maxVertexAttributes is GL_MAX_VERTEX_ATTRIBS.
+	maxVertexAttributes uint32
+}
+
+// native reports whether attributes of format can be fetched by
+// glVertexAttribPointer.
+func (s vertexFormatSupport) native(format gputypes.VertexFormat) bool {
+	switch format {
+	case gputypes.VertexFormatFloat16x2, gputypes.VertexFormatFloat16x4:
+		return s.halfFloat
+	case gputypes.VertexFormatUnorm1010102:
+		return s.packed1010102
+	default:
+		return true
+	}
+}
+
+// pulledVertexBuffer is a vertex buffer slot whose attributes, or some of
+// them, are pulled by the vertex shader.
+type pulledVertexBuffer struct {
+	slot     uint32
+	stride   uint64
+	instance bool
+
+	// storageBinding is the GL shader storage buffer binding point the
+	// vertex buffer is bound to.
+	storageBinding uint32
+	// baseLocation is the attribute location whose current value holds the
+	// buffer's byte offset.
+	baseLocation uint32
+	// locations is the set of shader locations pulled from this buffer,
+	// one bit per location.
+	locations uint32
+}
+
+// pulledVertexAttribute is a vertex attribute decoded by the shader.
+type pulledVertexAttribute struct {
+	buffer   int // index into vertexPulling.buffers
+	location uint32
+	format   gputypes.VertexFormat
+	offset   uint32
+}
+
+// vertexPulling is the vertex pulling plan of one render pipeline.
+type vertexPulling struct {
+	buffers    []pulledVertexBuffer
+	attributes []pulledVertexAttribute
+}
+
+// planVertexPulling returns the attributes of buffers that support cannot
+// fetch natively, or nil when every attribute is native. firstStorageBinding
+// is the first GL storage buffer binding point not used by the pipeline
+// layout.
+func planVertexPulling(buffers []gputypes.VertexBufferLayout, support vertexFormatSupport, firstStorageBinding uint32) (*vertexPulling, error) {
+	var plan vertexPulling
+	var maxLocation uint32
+	for slot, layout := range buffers {
+		pulled := -1
+		for _, attr := range layout.Attributes {
+			maxLocation = max(maxLocation, attr.ShaderLocation)
+			if support.native(attr.Format) {
+				continue
+			}
+			if support.vertexStorageBlocks == 0 {
+				return nil, fmt.Errorf("gles: vertex format %v is not supported by this context, and vertex shaders cannot read storage buffers to pull it", attr.Format)
+			}
+			if layout.ArrayStride%4 != 0 || attr.Offset%4 != 0 {
+				return nil, fmt.Errorf("gles: pulled vertex attribute at location %d needs 4-byte aligned stride and offset", attr.ShaderLocation)
+			}
+			if pulled < 0 {
+				pulled = len(plan.buffers)
+				plan.buffers = append(plan.buffers, pulledVertexBuffer{
+					slot:     uint32(slot), //nolint:gosec // slot count is bounded by MaxVertexBuffers
+					stride:   layout.ArrayStride,
+					instance: layout.StepMode == gputypes.VertexStepModeInstance,
+				})
+			}
+			plan.buffers[pulled].locations |= 1 << attr.ShaderLocation
+			plan.attributes = append(plan.attributes, pulledVertexAttribute{
+				buffer:   pulled,
+				location: attr.ShaderLocation,
+				format:   attr.Format,
+				offset:   uint32(attr.Offset), //nolint:gosec // attribute offsets are bounded by MaxVertexBufferArrayStride
+			})
+		}
+	}
+	if len(plan.buffers) == 0 {
+		return nil, nil
+	}
+	if len(plan.buffers) > int(support.vertexStorageBlocks) {
+		return nil, fmt.Errorf("gles: %d pulled vertex buffers exceed the %d storage blocks available to vertex shaders",
+			len(plan.buffers), support.vertexStorageBlocks)
+	}
+	if maxLocation+1+uint32(len(plan.buffers)) > support.maxVertexAttributes { //nolint:gosec // bounded by MaxVertexBuffers
+		return nil, fmt.Errorf("gles: vertex pulling needs %d attribute locations past location %d, only %d are available",
+			len(plan.buffers), maxLocation, support.maxVertexAttributes)
+	}
+	for i := range plan.buffers {
+		plan.buffers[i].storageBinding = firstStorageBinding + uint32(i) //nolint:gosec // bounded above
+		plan.buffers[i].baseLocation = maxLocation + 1 + uint32(i)       //nolint:gosec // bounded above
+	}
+	return &plan, nil
+}
+
+// buffer returns the pulling state of vertex buffer slot, or nil when the
+// slot is fetched natively. It is safe to call on a nil plan.
+func (p *vertexPulling) buffer(slot uint32) *pulledVertexBuffer {
+	if p == nil {
+		return nil
+	}
+	for i := range p.buffers {
+		if p.buffers[i].slot == slot {
+			return &p.buffers[i]
+		}
+	}
+	return nil
+}
+
+// bindingMap returns base extended with the storage buffer bindings of the
+// pulled vertex buffers.
+func (p *vertexPulling) bindingMap(base map[glsl.BindingMapKey]uint8) map[glsl.BindingMapKey]uint8 {
+	m := make(map[glsl.BindingMapKey]uint8, len(base)+len(p.buffers))
+	for k, v := range base {
+		m[k] = v
+	}
+	for i, buf := range p.buffers {
+		m[glsl.BindingMapKey{Group: vertexPullingGroup, Binding: uint32(i)}] = uint8(buf.storageBinding) //nolint:gosec // binding points are below 256
+	}
+	return m
+}
+
+// attribute returns the pulled attribute at location, or nil.
+func (p *vertexPulling) attribute(location uint32) *pulledVertexAttribute {
+	for i := range p.attributes {
+		if p.attributes[i].location == location {
+			return &p.attributes[i]
+		}
+	}
+	return nil
+}
+
+// locationOf returns the location of a @location binding.
+func locationOf(binding *ir.Binding) (uint32, bool) {
+	if binding == nil {
+		return 0, false
+	}
+	loc, ok := (*binding).(ir.LocationBinding)
+	return loc.Location, ok
+}
+
+// pullRewrite is an entry point argument whose value is replaced by a local
+// variable initialized from pulled data.
+type pullRewrite struct {
+	arg     uint32
+	pointer ir.ExpressionHandle // the local variable
+	value   ir.ExpressionHandle // a fresh reference to the original argument
+	direct  *pulledVertexAttribute
+	members map[int]*pulledVertexAttribute // struct member index -> attribute
+}
+
+// apply rewrites the vertex entry point of module so the pulled attributes
+// are read from storage buffers.
+//
+// Arguments fed by pulled locations, directly or through struct members,
+// are shadowed by local variables: every existing reference to such an
+// argument becomes a load of its local, and a prologue stores the decoded
+// values (plus the argument's remaining members) into the locals. The
+// original inputs stay declared but unread, so the compiler drops them.
+func (p *vertexPulling) apply(module *ir.Module, entryPoint string) error {
+	var fn *ir.Function
+	for i := range module.EntryPoints {
+		if module.EntryPoints[i].Name == entryPoint && module.EntryPoints[i].Stage == ir.StageVertex {
+			fn = &module.EntryPoints[i].Function
+			break
+		}
+	}
+	if fn == nil {
+		return fmt.Errorf("gles: vertex entry point %q not found", entryPoint)
+	}
+
+	b := &pullBuilder{module: module, fn: fn}
+	b.resolveTypes()
+	origExpressions := len(fn.Expressions)
+
+	var rewrites []*pullRewrite
+	for i, arg := range fn.Arguments {
+		rw := &pullRewrite{arg: uint32(i)} //nolint:gosec // argument count is small
+		if loc, ok := locationOf(arg.Binding); ok {
+			rw.direct = p.attribute(loc)
+		} else if st, ok := module.Types[arg.Type].Inner.(ir.StructType); ok {
+			for m, member := range st.Members {
+				if loc, ok := locationOf(member.Binding); ok {
+					if attr := p.attribute(loc); attr != nil {
+						if rw.members == nil {
+							rw.members = make(map[int]*pulledVertexAttribute)
+						}
+						rw.members[m] = attr
+					}
+				}
+			}
+		}
+		if rw.direct != nil || rw.members != nil {
+			rewrites = append(rewrites, rw)
+		}
+	}
+	if len(rewrites) == 0 {
+		// The shader reads none of the pulled locations.
+		return nil
+	}
+
+	// Expressions that need no emitting: arguments, globals and locals.
+	u32 := b.typeOf(ir.ScalarType{Kind: ir.ScalarUint, Width: 4})
+	words := b.typeOf(ir.ArrayType{Base: u32, Stride: 4})
+	vertexIndex := b.argument("vertex_pull_vertex_index", u32, ir.BuiltinBinding{Builtin: ir.BuiltinVertexIndex})
+	instanceIndex := b.argument("vertex_pull_instance_index", u32, ir.BuiltinBinding{Builtin: ir.BuiltinInstanceIndex})
+	storage := make([]ir.ExpressionHandle, len(p.buffers))
+	bases := make([]ir.ExpressionHandle, len(p.buffers))
+	for i, buf := range p.buffers {
+		module.GlobalVariables = append(module.GlobalVariables, ir.GlobalVariable{
+			Name:    fmt.Sprintf("vertex_pull_buffer%d", i),
+			Space:   ir.SpaceStorage,
+			Access:  ir.StorageRead,
+			Binding: &ir.ResourceBinding{Group: vertexPullingGroup, Binding: uint32(i)}, //nolint:gosec // bounded by MaxVertexBuffers
+			Type:    words,
+		})
+		storage[i] = b.add(ir.ExprGlobalVariable{Variable: ir.GlobalVariableHandle(len(module.GlobalVariables) - 1)}) //nolint:gosec // global count is small
+		bases[i] = b.argument(fmt.Sprintf("vertex_pull_base%d", i), u32, ir.LocationBinding{Location: buf.baseLocation})
+	}
+	for _, rw := range rewrites {
+		arg := fn.Arguments[rw.arg]
+		fn.LocalVars = append(fn.LocalVars, ir.LocalVariable{Name: arg.Name, Type: arg.Type})
+		rw.pointer = b.add(ir.ExprLocalVariable{Variable: uint32(len(fn.LocalVars) - 1)}) //nolint:gosec // local count is small
+		rw.value = b.add(ir.ExprFunctionArgument{Index: rw.arg})
+	}
+
+	// The prologue: decode every pulled attribute and initialize the locals.
+	emitStart := ir.ExpressionHandle(len(fn.Expressions)) //nolint:gosec // expression count fits in a handle
+	var stores []ir.Statement
+	for _, rw := range rewrites {
+		arg := fn.Arguments[rw.arg]
+		var value ir.ExpressionHandle
+		if rw.direct != nil {
+			value = b.pull(p, rw.direct, arg.Type, storage, bases, vertexIndex, instanceIndex)
+		} else {
+			st := module.Types[arg.Type].Inner.(ir.StructType)
+			components := make([]ir.ExpressionHandle, len(st.Members))
+			for m, member := range st.Members {
+				if attr := rw.members[m]; attr != nil {
+					components[m] = b.pull(p, attr, member.Type, storage, bases, vertexIndex, instanceIndex)
+				} else {
+					components[m] = b.add(ir.ExprAccessIndex{Base: rw.value, Index: uint32(m)}) //nolint:gosec // member count is small
+				}
+			}
+			value = b.add(ir.ExprCompose{Type: arg.Type, Components: components})
+		}
+		stores = append(stores, ir.Statement{Kind: ir.StmtStore{Pointer: rw.pointer, Value: value}})
+	}
+	if b.err != nil {
+		return fmt.Errorf("gles: vertex pulling: %w", b.err)
+	}
+	emitEnd := ir.ExpressionHandle(len(fn.Expressions)) //nolint:gosec // expression count fits in a handle
+
+	// Existing references to the arguments read the locals instead.
+	for h := range origExpressions {
+		arg, ok := fn.Expressions[h].Kind.(ir.ExprFunctionArgument)
+		if !ok {
+			continue
+		}
+		for _, rw := range rewrites {
+			if rw.arg == arg.Index {
+				fn.Expressions[h].Kind = ir.ExprLoad{Pointer: rw.pointer}
+			}
+		}
+	}
+
+	prologue := make([]ir.Statement, 0, 1+len(stores)+len(fn.Body))
+	prologue = append(prologue, ir.Statement{Kind: ir.StmtEmit{Range: ir.Range{Start: emitStart, End: emitEnd}}})
+	prologue = append(prologue, stores...)
+	fn.Body = append(prologue, fn.Body...)
+	return nil
+}
+
+// pullBuilder appends expressions and types to an entry point, keeping
+// Function.ExpressionTypes in step. The first failure sticks in err.
+type pullBuilder struct {
+	module *ir.Module
+	fn     *ir.Function
+	err    error
+}
+
+// resolveTypes fills in any expression types the front end left out.
+func (b *pullBuilder) resolveTypes() {
+	for h := len(b.fn.ExpressionTypes); h < len(b.fn.Expressions); h++ {
+		res, err := ir.ResolveExpressionType(b.module, b.fn, ir.ExpressionHandle(h)) //nolint:gosec // expression count fits in a handle
+		if err != nil && b.err == nil {
+			b.err = err
+		}
+		b.fn.ExpressionTypes = append(b.fn.ExpressionTypes, res)
+	}
+}
+
+func (b *pullBuilder) add(kind ir.ExpressionKind) ir.ExpressionHandle {
+	h := ir.ExpressionHandle(len(b.fn.Expressions)) //nolint:gosec // expression count fits in a handle
+	b.fn.Expressions = append(b.fn.Expressions, ir.Expression{Kind: kind})
+	b.resolveTypes()
+	return h
+}
+
+func (b *pullBuilder) argument(name string, ty ir.TypeHandle, binding ir.Binding) ir.ExpressionHandle {
+	b.fn.Arguments = append(b.fn.Arguments, ir.FunctionArgument{Name: name, Type: ty, Binding: &binding})
+	return b.add(ir.ExprFunctionArgument{Index: uint32(len(b.fn.Arguments) - 1)}) //nolint:gosec // argument count is small
+}
+
+// typeOf returns the handle of an unnamed scalar, vector or runtime array
+// type, adding it to the module when missing.
+func (b *pullBuilder) typeOf(inner ir.TypeInner) ir.TypeHandle {
+	for i, t := range b.module.Types {
+		if t.Name != "" {
+			continue
+		}
+		same := false
+		switch want := inner.(type) {
+		case ir.ScalarType:
+			have, ok := t.Inner.(ir.ScalarType)
+			same = ok && have == want
+		case ir.VectorType:
+			have, ok := t.Inner.(ir.VectorType)
+			same = ok && have == want
+		case ir.ArrayType:
+			have, ok := t.Inner.(ir.ArrayType)
+			same = ok && have.Base == want.Base && have.Stride == want.Stride && have.Size.Constant == nil && want.Size.Constant == nil
+		}
+		if same {
+			return ir.TypeHandle(i) //nolint:gosec // type count fits in a handle
+		}
+	}
+	b.module.Types = append(b.module.Types, ir.Type{Inner: inner})
+	return ir.TypeHandle(len(b.module.Types) - 1) //nolint:gosec // type count fits in a handle
+}
+
+func (b *pullBuilder) u32(v uint32) ir.ExpressionHandle {
+	return b.add(ir.Literal{Value: ir.LiteralU32(v)})
+}
+
+func (b *pullBuilder) f32(v float32) ir.ExpressionHandle {
+	return b.add(ir.Literal{Value: ir.LiteralF32(v)})
+}
+
+func (b *pullBuilder) binary(op ir.BinaryOperator, left, right ir.ExpressionHandle) ir.ExpressionHandle {
+	return b.add(ir.ExprBinary{Op: op, Left: left, Right: right})
+}
+
+// pull decodes attr for the current vertex or instance and converts it to
+// the shader type ty.
+func (b *pullBuilder) pull(p *vertexPulling, attr *pulledVertexAttribute, ty ir.TypeHandle,
+	storage, bases []ir.ExpressionHandle, vertexIndex, instanceIndex ir.ExpressionHandle,
+) ir.ExpressionHandle {
+	buf := p.buffers[attr.buffer]
+	index := vertexIndex
+	if buf.instance {
+		index = instanceIndex
+	}
+	// word = (base + index * stride + offset) / 4
+	addr := b.binary(ir.BinaryMultiply, index, b.u32(uint32(buf.stride))) //nolint:gosec // strides are bounded by MaxVertexBufferArrayStride
+	addr = b.binary(ir.BinaryAdd, bases[attr.buffer], addr)
+	if attr.offset != 0 {
+		addr = b.binary(ir.BinaryAdd, addr, b.u32(attr.offset))
+	}
+	word := b.binary(ir.BinaryShiftRight, addr, b.u32(2))
+	load := func(i uint32) ir.ExpressionHandle {
+		index := word
+		if i != 0 {
+			index = b.binary(ir.BinaryAdd, word, b.u32(i))
+		}
+		return b.add(ir.ExprLoad{Pointer: b.add(ir.ExprAccess{Base: storage[attr.buffer], Index: index})})
+	}
+
+	f32 := ir.ScalarType{Kind: ir.ScalarFloat, Width: 4}
+	u32 := ir.ScalarType{Kind: ir.ScalarUint, Width: 4}
+	vec4f := b.typeOf(ir.VectorType{Size: ir.Vec4, Scalar: f32})
+	vec4u := b.typeOf(ir.VectorType{Size: ir.Vec4, Scalar: u32})
+
+	var value ir.ExpressionHandle
+	var size ir.VectorSize
+	switch attr.format {
+	case gputypes.VertexFormatFloat16x2:
+		value, size = b.add(ir.ExprMath{Fun: ir.MathUnpack2x16float, Arg: load(0)}), ir.Vec2
+	case gputypes.VertexFormatFloat16x4:
+		low := b.add(ir.ExprMath{Fun: ir.MathUnpack2x16float, Arg: load(0)})
+		high := b.add(ir.ExprMath{Fun: ir.MathUnpack2x16float, Arg: load(1)})
+		value, size = b.add(ir.ExprCompose{Type: vec4f, Components: []ir.ExpressionHandle{low, high}}), ir.Vec4
+	case gputypes.VertexFormatUnorm1010102:
+		// vec4<f32>((vec4(w) >> vec4(0, 10, 20, 30)) & vec4(1023, 1023, 1023, 3)) / vec4(1023, 1023, 1023, 3)
+		shifts := b.add(ir.ExprCompose{Type: vec4u, Components: []ir.ExpressionHandle{b.u32(0), b.u32(10), b.u32(20), b.u32(30)}})
+		masks := b.add(ir.ExprCompose{Type: vec4u, Components: []ir.ExpressionHandle{b.u32(1023), b.u32(1023), b.u32(1023), b.u32(3)}})
+		bits := b.binary(ir.BinaryAnd, b.binary(ir.BinaryShiftRight, b.add(ir.ExprSplat{Size: ir.Vec4, Value: load(0)}), shifts), masks)
+		width := uint8(4)
+		scale := b.add(ir.ExprCompose{Type: vec4f, Components: []ir.ExpressionHandle{b.f32(1023), b.f32(1023), b.f32(1023), b.f32(3)}})
+		value, size = b.binary(ir.BinaryDivide, b.add(ir.ExprAs{Expr: bits, Kind: ir.ScalarFloat, Convert: &width}), scale), ir.Vec4
+	default:
+		if b.err == nil {
+			b.err = fmt.Errorf("vertex format %v cannot be pulled", attr.format)
+		}
+		return 0
+	}
+
+	// WebGPU lets the shader declare fewer or more components than the
+	// format has; missing ones read as (0, 0, 0, 1).
+	switch target := b.module.Types[ty].Inner.(type) {
+	case ir.ScalarType:
+		if target.Kind != ir.ScalarFloat {
+			break
+		}
+		return b.add(ir.ExprAccessIndex{Base: value, Index: 0})
+	case ir.VectorType:
+		if target.Scalar.Kind != ir.ScalarFloat {
+			break
+		}
+		switch {
+		case target.Size == size:
+			return value
+		case target.Size < size:
+			return b.add(ir.ExprSwizzle{Size: target.Size, Vector: value,
+				Pattern: [4]ir.SwizzleComponent{ir.SwizzleX, ir.SwizzleY, ir.SwizzleZ, ir.SwizzleW}})
+		default:
+			components := []ir.ExpressionHandle{value}
+			for c := size; c < target.Size; c++ {
+				if c == ir.Vec4-1 {
+					components = append(components, b.f32(1))
+				} else {
+					components = append(components, b.f32(0))
+				}
+			}
+			return b.add(ir.ExprCompose{Type: ty, Components: components})
+		}
+	}
+	if b.err == nil {
+		b.err = fmt.Errorf("location %d: vertex format %v does not match the shader input type", attr.location, attr.format)
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (windows || linux) && !(js && wasm)
+
+package gles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/naga/glsl"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// noHalfFloat is a context that fetches neither half floats nor packed
+// 10-10-10-2 attributes but can read storage buffers from vertex shaders.
+var noHalfFloat = vertexFormatSupport{vertexStorageBlocks: 4, maxVertexAttributes: 16}
+
+func pulledLayouts() []gputypes.VertexBufferLayout {
+	return []gputypes.VertexBufferLayout{
+		{ArrayStride: 12, StepMode: gputypes.VertexStepModeVertex, Attributes: []gputypes.VertexAttribute{
+			{Format: gputypes.VertexFormatFloat32x2, ShaderLocation: 0},
+			{Format: gputypes.VertexFormatFloat16x2, Offset: 8, ShaderLocation: 1},
+		}},
+		{ArrayStride: 12, StepMode: gputypes.VertexStepModeInstance, Attributes: []gputypes.VertexAttribute{
+			{Format: gputypes.VertexFormatFloat16x4, ShaderLocation: 2},
+			{Format: gputypes.VertexFormatUnorm1010102, Offset: 8, ShaderLocation: 3},
+		}},
+	}
+}
+
+func TestPlanVertexPulling(t *testing.T) {
+	full := vertexFormatSupport{halfFloat: true, packed1010102: true, maxVertexAttributes: 16}
+	if plan, err := planVertexPulling(pulledLayouts(), full, 0); err != nil || plan != nil {
+		t.Fatalf("native formats: plan = %+v, err = %v; want nil, nil", plan, err)
+	}
+
+	plan, err := planVertexPulling(pulledLayouts(), noHalfFloat, 2)
+	if err != nil {
+		t.Fatalf("planVertexPulling: %v", err)
+	}
+	if len(plan.buffers) != 2 || len(plan.attributes) != 3 {
+		t.Fatalf("plan = %+v, want 2 buffers and 3 attributes", plan)
+	}
+	vertex, instance := plan.buffer(0), plan.buffer(1)
+	if vertex.locations != 1<<1 || instance.locations != 1<<2|1<<3 || !instance.instance {
+		t.Errorf("buffers = %+v, %+v", *vertex, *instance)
+	}
+	if vertex.storageBinding != 2 || instance.storageBinding != 3 {
+		t.Errorf("storage bindings = %d, %d, want 2, 3", vertex.storageBinding, instance.storageBinding)
+	}
+	if vertex.baseLocation != 4 || instance.baseLocation != 5 {
+		t.Errorf("base locations = %d, %d, want 4, 5", vertex.baseLocation, instance.baseLocation)
+	}
+
+	if _, err := planVertexPulling(pulledLayouts(), vertexFormatSupport{maxVertexAttributes: 16}, 0); err == nil {
+		t.Error("pulling without vertex storage buffers should fail")
+	}
+}
+
+func TestVertexPullingRewritesShader(t *testing.T) {
+	const wgsl = `
+struct Instance {
+	@location(2) color: vec4<f32>,
+	@location(3) packed: vec3<f32>,
+}
+
+@vertex
+fn vs_main(@location(0) pos: vec2<f32>, @location(1) uv: vec2<f32>, inst: Instance) -> @builtin(position) vec4<f32> {
+	return vec4<f32>(pos + uv, inst.color.x, inst.packed.z);
+}
+`
+	plan, err := planVertexPulling(pulledLayouts(), noHalfFloat, 0)
+	if err != nil {
+		t.Fatalf("planVertexPulling: %v", err)
+	}
+	code, _, err := compileVertexWGSLToGLSL(glslTarget{version: glsl.VersionES310}, hal.ShaderSource{WGSL: wgsl}, "vs_main", nil, plan)
+	if err != nil {
+		t.Fatalf("compileVertexWGSLToGLSL: %v", err)
+	}
+
+	for _, want := range []string{
+		"buffer ",                  // the pulled vertex buffers
+		"layout(location = 4) in ", // byte offset of buffer 0
+		"layout(location = 5) in ", // byte offset of buffer 1
+		"unpackHalf2x16(",
+		"gl_VertexID",
+		"gl_InstanceID",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("GLSL lacks %q:\n%s", want, code)
+		}
+	}
+
+	// An entry point that reads no pulled location is left alone.
+	const plain = `
+@vertex
+fn vs_main(@location(0) pos: vec2<f32>) -> @builtin(position) vec4<f32> {
+	return vec4<f32>(pos, 0.0, 1.0);
+}
+`
+	code, _, err = compileVertexWGSLToGLSL(glslTarget{version: glsl.VersionES310}, hal.ShaderSource{WGSL: plain}, "vs_main", nil, plan)
+	if err != nil {
+		t.Fatalf("compileVertexWGSLToGLSL: %v", err)
+	}
+	if strings.Contains(code, "buffer ") {
+		t.Errorf("shader without pulled inputs declares a storage buffer:\n%s", code)
+	}
+}
+
+func TestPulledVertexBufferBinding(t *testing.T) {
+	plan, err := planVertexPulling(pulledLayouts(), noHalfFloat, 0)
+	if err != nil {
+		t.Fatalf("planVertexPulling: %v", err)
+	}
+	enc := &CommandEncoder{}
+	_ = enc.BeginEncoding("pulling")
+	pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{ColorAttachments: []hal.RenderPassColorAttachment{}}).(*RenderPassEncoder)
+	pass.SetPipeline(&RenderPipeline{vertexBuffers: pulledLayouts(), vertexPulling: plan})
+	start := len(enc.commands)
+	pass.SetVertexBuffer(0, &Buffer{id: 1}, 16, 0)
+	pass.SetVertexBuffer(1, &Buffer{id: 2}, 0, 0)
+
+	binds := vertexBindings(enc, start)
+	if len(binds) != 2 {
+		t.Fatalf("recorded %d vertex buffer bindings, want 2", len(binds))
+	}
+	if binds[0].pull != plan.buffer(0) || binds[1].pull != plan.buffer(1) {
+		t.Errorf("bindings carry pull state %p, %p; want the pipeline's", binds[0].pull, binds[1].pull)
+	}
+}
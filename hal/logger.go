@@ -28,6 +28,7 @@ var loggerPtr atomic.Pointer[slog.Logger]
 func init() {
 	l := slog.New(nopHandler{})
 	loggerPtr.Store(l)
+	storeCategoryLoggers(l)
 }
 
 // SetLogger configures the logger for the wgpu HAL layer and all backends
@@ -43,6 +44,10 @@ func init() {
 //   - [slog.LevelWarn]: non-fatal issues (debug layer fallback, device errors)
 //   - [slog.LevelError]: critical issues (device removed, validation errors)
 //
+// Records from adapter enumeration, swapchain events, barrier decisions and
+// shader compilation carry a "category" attribute (see [LogCategory]); an
+// instance created with its own [Log] bypasses this logger.
+//
 // Example:
 //
 //	// Enable info-level logging to stderr:
@@ -57,6 +62,7 @@ func SetLogger(l *slog.Logger) {
 		l = slog.New(nopHandler{})
 	}
 	loggerPtr.Store(l)
+	storeCategoryLoggers(l)
 }
 
 // Logger returns the current logger used by the wgpu HAL layer.
@@ -67,3 +73,122 @@ func SetLogger(l *slog.Logger) {
 func Logger() *slog.Logger {
 	return loggerPtr.Load()
 }
+
+// LogCategory groups backend diagnostics by subsystem. Records logged
+// through a category logger carry a "category" attribute, and an instance
+// can raise or lower the minimum level of each category independently.
+type LogCategory uint8
+
+const (
+	// LogGeneral covers records that belong to no specific subsystem.
+	LogGeneral LogCategory = iota
+	// LogAdapter covers backend initialization and adapter enumeration.
+	LogAdapter
+	// LogSwapchain covers surface configuration, acquire and present events.
+	LogSwapchain
+	// LogBarrier covers resource state transitions and barrier decisions.
+	LogBarrier
+	// LogShader covers shader translation and compilation diagnostics.
+	LogShader
+
+	numLogCategories
+)
+
+// String returns the value of the category's "category" attribute.
+func (c LogCategory) String() string {
+	switch c {
+	case LogGeneral:
+		return "general"
+	case LogAdapter:
+		return "adapter"
+	case LogSwapchain:
+		return "swapchain"
+	case LogBarrier:
+		return "barrier"
+	case LogShader:
+		return "shader"
+	default:
+		return "unknown"
+	}
+}
+
+// categoryLoggers caches one logger per category for the package logger,
+// rebuilt by SetLogger, so category logging allocates nothing per call.
+var categoryLoggers atomic.Pointer[[numLogCategories]*slog.Logger]
+
+func storeCategoryLoggers(l *slog.Logger) {
+	var loggers [numLogCategories]*slog.Logger
+	for c := range loggers {
+		loggers[c] = l.With("category", LogCategory(c).String())
+	}
+	categoryLoggers.Store(&loggers)
+}
+
+// CategoryLogger returns the package logger tagged with category c.
+// Backends without a per-instance Log use it directly.
+func CategoryLogger(c LogCategory) *slog.Logger {
+	if c >= numLogCategories {
+		c = LogGeneral
+	}
+	return categoryLoggers.Load()[c]
+}
+
+// Log is a per-instance logging configuration. It routes backend
+// diagnostics to the instance's own logger and filters each category by
+// its minimum level. A nil *Log logs through the package logger set with
+// SetLogger, without extra filtering.
+type Log struct {
+	loggers [numLogCategories]*slog.Logger
+}
+
+// NewLog builds the per-instance Log for logger and levels. A nil logger
+// uses the package logger current at call time. Categories missing from
+// levels are not filtered beyond the logger's own handler. NewLog returns
+// nil when both arguments are empty, so the instance keeps following
+// SetLogger.
+func NewLog(logger *slog.Logger, levels map[LogCategory]slog.Level) *Log {
+	if logger == nil && len(levels) == 0 {
+		return nil
+	}
+	if logger == nil {
+		logger = Logger()
+	}
+	l := &Log{}
+	for c := range l.loggers {
+		h := logger.Handler()
+		if minLevel, ok := levels[LogCategory(c)]; ok {
+			h = &minLevelHandler{Handler: h, min: minLevel}
+		}
+		l.loggers[c] = slog.New(h).With("category", LogCategory(c).String())
+	}
+	return l
+}
+
+// For returns the logger for category c.
+func (l *Log) For(c LogCategory) *slog.Logger {
+	if l == nil {
+		return CategoryLogger(c)
+	}
+	if c >= numLogCategories {
+		c = LogGeneral
+	}
+	return l.loggers[c]
+}
+
+// minLevelHandler drops records below min before they reach Handler.
+type minLevelHandler struct {
+	slog.Handler
+	min slog.Level
+}
+
+func (h *minLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.min && h.Handler.Enabled(ctx, level)
+}
+
+func (h *minLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &minLevelHandler{Handler: h.Handler.WithAttrs(attrs), min: h.min}
+}
+
+func (h *minLevelHandler) WithGroup(name string) slog.Handler {
+	return &minLevelHandler{Handler: h.Handler.WithGroup(name), min: h.min}
+}
@@ -130,6 +130,51 @@ func TestLoggerConcurrentAccess(t *testing.T) {
 	wg.Wait()
 }
 
+func TestCategoryLoggerTagsRecords(t *testing.T) {
+	orig := Logger()
+	t.Cleanup(func() { SetLogger(orig) })
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	CategoryLogger(LogSwapchain).Info("swapchain created")
+	if !strings.Contains(buf.String(), "category=swapchain") {
+		t.Errorf("expected category=swapchain attribute, got: %s", buf.String())
+	}
+
+	// A nil Log follows the package logger.
+	buf.Reset()
+	var l *Log
+	l.For(LogShader).Debug("compiled")
+	if !strings.Contains(buf.String(), "category=shader") {
+		t.Errorf("nil Log should log through the package logger, got: %s", buf.String())
+	}
+}
+
+func TestNewLogCategoryLevels(t *testing.T) {
+	if NewLog(nil, nil) != nil {
+		t.Error("NewLog(nil, nil) should return nil")
+	}
+
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	l := NewLog(base, map[LogCategory]slog.Level{LogBarrier: slog.LevelWarn})
+
+	l.For(LogBarrier).Debug("barrier dropped")
+	l.For(LogBarrier).Warn("barrier kept")
+	l.For(LogAdapter).Debug("adapter kept")
+
+	out := buf.String()
+	if strings.Contains(out, "barrier dropped") {
+		t.Errorf("debug barrier record should be filtered, got: %s", out)
+	}
+	for _, want := range []string{"barrier kept", "adapter kept", "category=adapter"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
+
 func BenchmarkLoggerLoad(b *testing.B) {
 	b.ReportAllocs()
 	for b.Loop() {
@@ -6,6 +6,8 @@
 package metal
 
 import (
+	"fmt"
+
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -13,8 +15,9 @@ import (
 // Adapter implements hal.Adapter for Metal.
 type Adapter struct {
 	instance              *Instance
-	raw                   ID   // id<MTLDevice>
-	formatDepth24Stencil8 bool // true if Depth24UnormStencil8 supported (Intel-era AMD only)
+	raw                   ID     // id<MTLDevice>
+	formatDepth24Stencil8 bool   // true if Depth24UnormStencil8 supported (Intel-era AMD only)
+	sampleCounts          uint32 // supportsTextureSampleCount: results, see DeviceSampleCounts
 }
 
 // mapTextureFormat converts a WebGPU texture format to Metal pixel format,
@@ -42,19 +45,45 @@ func (a *Adapter) Open(features gputypes.Features, limits gputypes.Limits) (hal.
 	if err != nil {
 		return hal.OpenDevice{}, err
 	}
+	return a.openQueue(device), nil
+}
 
+// Adopt wraps an MTLDevice another library owns, submitting to its command
+// queue when native names one. Metal hands out one MTLDevice per GPU, so
+// native.Device must be this adapter's device.
+func (a *Adapter) Adopt(native *hal.NativeDevice, _ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+	if native == nil || native.Device == 0 {
+		return hal.OpenDevice{}, fmt.Errorf("metal: native device is nil")
+	}
+	if DeviceRegistryID(ID(native.Device)) != DeviceRegistryID(a.raw) {
+		return hal.OpenDevice{}, fmt.Errorf("metal: native device %q is not the adapter's %q",
+			DeviceName(ID(native.Device)), DeviceName(a.raw))
+	}
+	device, err := newDeviceOnQueue(a, ID(native.Queue))
+	if err != nil {
+		return hal.OpenDevice{}, err
+	}
+	return a.openQueue(device), nil
+}
+
+// openQueue wraps device's command queue and sets up frame throttling.
+func (a *Adapter) openQueue(device *Device) hal.OpenDevice {
 	queue := &Queue{
 		device:       device,
 		commandQueue: device.commandQueue,
 	}
 
 	// Initialize frame semaphore for CPU-ahead-of-GPU throttling.
-	// Uses a buffered channel of size maxFramesInFlight pre-filled with tokens.
+	// Uses a buffered channel of size framesInFlight pre-filled with tokens.
 	// Each Submit() consumes a token; the GPU's addCompletedHandler: returns it.
 	// If block support is unavailable, frameSemaphore stays nil (no throttling).
+	framesInFlight := maxFramesInFlight
+	if a.instance != nil && a.instance.options.MaxFramesInFlight > 0 {
+		framesInFlight = a.instance.options.MaxFramesInFlight
+	}
 	if symNSConcreteGlobalBlock != 0 {
-		queue.frameSemaphore = make(chan struct{}, maxFramesInFlight)
-		for i := 0; i < maxFramesInFlight; i++ {
+		queue.frameSemaphore = make(chan struct{}, framesInFlight)
+		for i := 0; i < framesInFlight; i++ {
 			queue.frameSemaphore <- struct{}{}
 		}
 	}
@@ -63,14 +92,14 @@ func (a *Adapter) Open(features gputypes.Features, limits gputypes.Limits) (hal.
 	device.queue = queue
 
 	hal.Logger().Debug("metal: adapter opened",
-		"maxFramesInFlight", maxFramesInFlight,
+		"maxFramesInFlight", framesInFlight,
 		"blockSupport", symNSConcreteGlobalBlock != 0,
 	)
 
 	return hal.OpenDevice{
 		Device: device,
 		Queue:  queue,
-	}, nil
+	}
 }
 
 // TextureFormatCapabilities returns capabilities for a specific texture format.
@@ -92,6 +121,7 @@ func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.T
 			hal.TextureFormatCapabilityMultisampleResolve
 
 	case gputypes.TextureFormatDepth32Float,
+		gputypes.TextureFormatDepth24Plus,
 		gputypes.TextureFormatDepth16Unorm:
 		flags |= hal.TextureFormatCapabilityRenderAttachment |
 			hal.TextureFormatCapabilityMultisample
@@ -101,8 +131,16 @@ func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.T
 		flags |= hal.TextureFormatCapabilityRenderAttachment
 	}
 
+	// Sample count support is device-wide on Metal; formats without MSAA
+	// support stay single-sampled.
+	sampleCounts := hal.SampleCount1
+	if flags&hal.TextureFormatCapabilityMultisample != 0 {
+		sampleCounts = a.sampleCounts | hal.SampleCount1
+	}
+
 	return hal.TextureFormatCapabilities{
-		Flags: flags,
+		Flags:        flags,
+		SampleCounts: sampleCounts,
 	}
 }
 
@@ -7,6 +7,7 @@ package metal
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal"
@@ -25,15 +26,44 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	if err := Init(); err != nil {
 		return nil, fmt.Errorf("metal: failed to initialize: %w", err)
 	}
-	hal.Logger().Info("metal: instance created")
-	return &Instance{}, nil
+	inst := &Instance{}
+	if desc != nil {
+		inst.log = desc.Log
+		inst.options = desc.BackendOptions.Metal
+	}
+	inst.logger(hal.LogAdapter).Info("metal: instance created")
+	return inst, nil
 }
 
 // Instance implements hal.Instance for Metal.
-type Instance struct{}
+type Instance struct {
+	log     *hal.Log
+	options hal.MetalOptions
+}
+
+// logger returns the instance's logger for category c. It is safe to call
+// on a nil Instance, which logs through the package logger.
+func (i *Instance) logger(c hal.LogCategory) *slog.Logger {
+	if i == nil {
+		return hal.CategoryLogger(c)
+	}
+	return i.log.For(c)
+}
 
-// CreateSurface creates a rendering surface from a CAMetalLayer target.
+// CreateSurface creates a rendering surface from a CAMetalLayer target, or
+// from a UIKit UIView on iOS/tvOS.
 func (i *Instance) CreateSurface(target hal.SurfaceTarget) (hal.Surface, error) {
+	if target.Kind == hal.SurfaceTargetUIView {
+		view := ID(target.WindowHandle)
+		if view == 0 {
+			return nil, fmt.Errorf("metal: view handle is nil")
+		}
+		layer, err := metalLayerFromView(view)
+		if err != nil {
+			return nil, err
+		}
+		return &Surface{layer: layer}, nil
+	}
 	if err := target.RequireKind(hal.SurfaceTargetMetalLayer); err != nil {
 		return nil, fmt.Errorf("metal: %w", err)
 	}
@@ -52,7 +82,7 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 		return nil
 	}
 
-	hal.Logger().Debug("metal: enumerating adapters", "count", len(devices))
+	i.logger(hal.LogAdapter).Debug("metal: enumerating adapters", "count", len(devices))
 
 	adapters := make([]hal.ExposedAdapter, 0, len(devices))
 	for _, device := range devices {
@@ -76,16 +106,47 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 		}
 		features.Insert(gputypes.FeatureDepthClipControl)
 		features.Insert(gputypes.FeatureTextureCompressionBC)
+		features.Insert(hal.FeaturePolygonModeLine)
+		// naga's MSL output bounds-checks every buffer, index and image
+		// access (msl.DefaultBoundsCheckPolicies), so shaders are always
+		// robust.
+		features.Insert(hal.FeatureRobustBufferAccess)
+		subgroupMin, subgroupMax := simdGroupSizes(device)
+		if subgroupMax != 0 {
+			features.Insert(gputypes.FeatureSubgroupOperations)
+			features.Insert(gputypes.FeatureSubgroupBarrier)
+		}
+		maxViews := DeviceMaxVertexAmplificationCount(device)
+		if maxViews != 0 {
+			features.Insert(hal.FeatureMultiview)
+		}
 
 		adapter := &Adapter{
 			instance:              i,
 			raw:                   device,
 			formatDepth24Stencil8: MsgSendBool(device, Sel("isDepth24Stencil8PixelFormatSupported")),
+			sampleCounts:          DeviceSampleCounts(device),
 		}
 
 		maxBuf := DeviceMaxBufferLength(device)
 
-		hal.Logger().Info("metal: adapter found",
+		// Memoryless storage for transient attachments needs a tile-based
+		// Apple GPU; Intel/AMD Macs allocate transient textures normally.
+		var downlevelFlags hal.DownlevelFlags
+		memoryless := DeviceSupportsFamily(device, MTLGPUFamilyApple1)
+		if memoryless {
+			downlevelFlags |= hal.DownlevelFlagsTransientAttachments
+		}
+		// Cube texture arrays need an A11 (Apple4) or any Mac GPU.
+		if DeviceSupportsFamily(device, MTLGPUFamilyApple4) || DeviceSupportsFamily(device, MTLGPUFamilyMac1) {
+			downlevelFlags |= hal.DownlevelFlagsCubeArrayTextures
+		}
+		unifiedMemory := MsgSendBool(device, Sel("hasUnifiedMemory"))
+		if unifiedMemory {
+			downlevelFlags |= hal.DownlevelFlagsUnifiedMemory
+		}
+
+		i.logger(hal.LogAdapter).Info("metal: adapter found",
 			"name", deviceName,
 			"type", deviceType,
 			"lowPower", DeviceIsLowPower(device),
@@ -93,6 +154,8 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 			"headless", DeviceIsHeadless(device),
 			"maxBuffer", maxBuf,
 			"depth24Stencil8", adapter.formatDepth24Stencil8,
+			"memoryless", memoryless,
+			"unifiedMemory", unifiedMemory,
 		)
 
 		adapters = append(adapters, hal.ExposedAdapter{
@@ -107,6 +170,9 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 				DriverInfo: "Metal API",
 				Backend:    gputypes.BackendMetal,
 			},
+			Details: hal.AdapterDetails{
+				MemoryHeaps: []hal.MemoryHeap{{Size: DeviceRecommendedMaxWorkingSetSize(device), DeviceLocal: true}},
+			},
 			Features: features,
 			Capabilities: hal.Capabilities{
 				Limits: gputypes.Limits{
@@ -149,8 +215,11 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 				},
 				DownlevelCapabilities: hal.DownlevelCapabilities{
 					ShaderModel: 60,
-					Flags:       0,
+					Flags:       downlevelFlags,
 				},
+				SubgroupMinSize:       subgroupMin,
+				SubgroupMaxSize:       subgroupMax,
+				MaxMultiviewViewCount: maxViews,
 			},
 		})
 	}
@@ -158,6 +227,21 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 	return adapters
 }
 
+// simdGroupSizes returns the SIMD-group size range of device, or (0, 0) when
+// it lacks the SIMD-group reduction, vote, and shuffle functions WGSL's
+// subgroup built-ins compile to (Apple7 or Mac2 family). Apple GPUs always run
+// 32-wide SIMD-groups; Mac2 covers AMD and Intel GPUs whose width depends on
+// the pipeline, so the range is the one Metal allows.
+func simdGroupSizes(device ID) (minSize, maxSize uint32) {
+	switch {
+	case DeviceSupportsFamily(device, MTLGPUFamilyApple7):
+		return 32, 32
+	case DeviceSupportsFamily(device, MTLGPUFamilyMac2):
+		return 4, 64
+	}
+	return 0, 0
+}
+
 // Destroy releases the instance.
 func (i *Instance) Destroy() {
 	// Nothing to release
@@ -5,7 +5,10 @@
 
 package metal
 
-import "github.com/gogpu/gputypes"
+import (
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
 
 // textureFormatToMTL converts WebGPU texture format to Metal pixel format.
 func textureFormatToMTL(format gputypes.TextureFormat) MTLPixelFormat {
@@ -363,6 +366,29 @@ func storeOpToMTL(op gputypes.StoreOp) MTLStoreAction {
 	}
 }
 
+// memorylessLoadAction returns a load action valid for a memoryless
+// attachment. Memoryless textures have no backing memory to load from.
+func memorylessLoadAction(action MTLLoadAction) MTLLoadAction {
+	if action == MTLLoadActionLoad {
+		return MTLLoadActionDontCare
+	}
+	return action
+}
+
+// memorylessStoreAction returns a store action valid for a memoryless
+// attachment. The attachment itself cannot be stored, but an MSAA resolve
+// into a separate texture is still allowed.
+func memorylessStoreAction(action MTLStoreAction) MTLStoreAction {
+	switch action {
+	case MTLStoreActionStore:
+		return MTLStoreActionDontCare
+	case MTLStoreActionStoreAndMultisampleResolve:
+		return MTLStoreActionMultisampleResolve
+	default:
+		return action
+	}
+}
+
 // cullModeToMTL converts WebGPU cull mode to Metal cull mode.
 func cullModeToMTL(mode gputypes.CullMode) MTLCullMode {
 	switch mode {
@@ -377,6 +403,15 @@ func cullModeToMTL(mode gputypes.CullMode) MTLCullMode {
 	}
 }
 
+// fillModeToMTL converts a HAL polygon mode to a Metal triangle fill mode.
+// Metal has no point fill mode, so PolygonModePoint is never enabled.
+func fillModeToMTL(mode hal.PolygonMode) MTLTriangleFillMode {
+	if mode == hal.PolygonModeLine {
+		return MTLTriangleFillModeLines
+	}
+	return MTLTriangleFillModeFill
+}
+
 // frontFaceToMTL converts WebGPU front face to Metal winding order.
 func frontFaceToMTL(face gputypes.FrontFace) MTLWinding {
 	switch face {
@@ -161,6 +161,37 @@ func TestTextureStorageMode(t *testing.T) {
 	}
 }
 
+// TestMemorylessAttachmentActions tests that memoryless attachments never
+// load from or store to memory, while MSAA resolve is preserved.
+func TestMemorylessAttachmentActions(t *testing.T) {
+	loads := []struct {
+		in, want MTLLoadAction
+	}{
+		{MTLLoadActionClear, MTLLoadActionClear},
+		{MTLLoadActionDontCare, MTLLoadActionDontCare},
+		{MTLLoadActionLoad, MTLLoadActionDontCare},
+	}
+	for _, tt := range loads {
+		if got := memorylessLoadAction(tt.in); got != tt.want {
+			t.Errorf("memorylessLoadAction(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	stores := []struct {
+		in, want MTLStoreAction
+	}{
+		{MTLStoreActionDontCare, MTLStoreActionDontCare},
+		{MTLStoreActionStore, MTLStoreActionDontCare},
+		{MTLStoreActionMultisampleResolve, MTLStoreActionMultisampleResolve},
+		{MTLStoreActionStoreAndMultisampleResolve, MTLStoreActionMultisampleResolve},
+	}
+	for _, tt := range stores {
+		if got := memorylessStoreAction(tt.in); got != tt.want {
+			t.Errorf("memorylessStoreAction(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
 // TestTextureTypeFromDimension tests texture type from dimension conversions.
 func TestTextureTypeFromDimension(t *testing.T) {
 	tests := []struct {
@@ -7,6 +7,7 @@ package metal
 
 import (
 	"fmt"
+	"log/slog"
 	"runtime"
 	"strings"
 	"sync"
@@ -51,11 +52,21 @@ type Device struct {
 
 // newDevice creates a new Device from a Metal device.
 func newDevice(adapter *Adapter) (*Device, error) {
+	return newDeviceOnQueue(adapter, 0)
+}
+
+// newDeviceOnQueue creates a device that submits to queue, retaining it,
+// or to a new command queue when queue is zero.
+func newDeviceOnQueue(adapter *Adapter, queue ID) (*Device, error) {
 	if adapter.raw == 0 {
 		return nil, fmt.Errorf("metal: adapter has no device")
 	}
 
-	queue := MsgSend(adapter.raw, Sel("newCommandQueue"))
+	if queue != 0 {
+		Retain(queue)
+	} else {
+		queue = MsgSend(adapter.raw, Sel("newCommandQueue"))
+	}
 	if queue == 0 {
 		return nil, fmt.Errorf("metal: failed to create command queue")
 	}
@@ -87,6 +98,15 @@ func newDevice(adapter *Adapter) (*Device, error) {
 	}, nil
 }
 
+// logger returns the owning instance's logger for category c. It is safe
+// to call on a nil Device, such as an unconfigured surface's.
+func (d *Device) logger(c hal.LogCategory) *slog.Logger {
+	if d == nil || d.adapter == nil {
+		return hal.CategoryLogger(c)
+	}
+	return d.adapter.instance.logger(c)
+}
+
 // CreateBuffer creates a GPU buffer.
 func (d *Device) CreateBuffer(desc *hal.BufferDescriptor) (hal.Buffer, error) {
 	if desc == nil {
@@ -227,6 +247,14 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 	_ = MsgSend(texDesc, Sel("setSampleCount:"), uintptr(sampleCount))
 
 	usage := textureUsageToMTL(desc.Usage)
+	// Views in another pixel format (the sRGB counterpart) need
+	// MTLTextureUsagePixelFormatView.
+	for _, viewFormat := range desc.ViewFormats {
+		if viewFormat != desc.Format {
+			usage |= MTLTextureUsagePixelFormatView
+			break
+		}
+	}
 	_ = MsgSend(texDesc, Sel("setUsage:"), uintptr(usage))
 
 	// Select storage mode based on GPU family and sample count.
@@ -234,6 +262,14 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 	// Apple GPU + multisample → Private (MSAA has no Shared benefit).
 	// Non-Apple GPU (Intel/AMD) → Private always (spec requirement for MSAA).
 	storageMode, isShared := textureStorageMode(d.isAppleGPU, sampleCount)
+
+	// Transient attachments (MSAA color, depth/stencil that is cleared and
+	// discarded each pass) need no device memory on Apple GPUs: tile memory
+	// holds them for the pass. Other GPUs allocate them normally.
+	memoryless := desc.Transient && d.supportsMemoryless()
+	if memoryless {
+		storageMode, isShared = MTLStorageModeMemoryless, false
+	}
 	_ = MsgSend(texDesc, Sel("setStorageMode:"), uintptr(storageMode))
 
 	raw := MsgSend(d.raw, Sel("newTextureWithDescriptor:"), uintptr(texDesc))
@@ -260,9 +296,17 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 		device:     d,
 		isExternal: false,
 		isShared:   isShared,
+		memoryless: memoryless,
 	}, nil
 }
 
+// supportsMemoryless reports whether the device can allocate
+// MTLStorageModeMemoryless textures. Memoryless storage requires a tile-based
+// Apple GPU (MTLGPUFamilyApple1+): every iOS/tvOS device and Apple Silicon Macs.
+func (d *Device) supportsMemoryless() bool {
+	return d.isAppleGPU
+}
+
 // DestroyTexture destroys a GPU texture.
 //
 // setPurgeableState(empty) is called before Release so that Metal can reclaim
@@ -277,13 +321,41 @@ func (d *Device) DestroyTexture(texture hal.Texture) {
 		return
 	}
 	if mtlTexture.raw != 0 && !mtlTexture.isExternal {
-		_ = MsgSend(mtlTexture.raw, Sel("setPurgeableState:"), uintptr(MTLPurgeableStateEmpty))
+		// Memoryless textures have no pages to purge.
+		if !mtlTexture.memoryless {
+			_ = MsgSend(mtlTexture.raw, Sel("setPurgeableState:"), uintptr(MTLPurgeableStateEmpty))
+		}
 		Release(mtlTexture.raw)
 		mtlTexture.raw = 0
 	}
 	mtlTexture.device = nil
 }
 
+// ImportTexture wraps an MTLTexture another library created on this
+// device. Metal tracks hazards itself, so currentUsage is not needed.
+// DestroyTexture does not release the texture.
+func (d *Device) ImportTexture(handle uintptr, _ gputypes.TextureUsage, desc *hal.TextureDescriptor) (hal.Texture, error) {
+	if handle == 0 {
+		return nil, fmt.Errorf("metal: native texture is nil")
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("BUG: texture descriptor is nil in Metal.ImportTexture — core validation gap")
+	}
+	return &Texture{
+		raw:        ID(handle),
+		format:     desc.Format,
+		width:      desc.Size.Width,
+		height:     desc.Size.Height,
+		depth:      max(desc.Size.DepthOrArrayLayers, 1),
+		mipLevels:  max(desc.MipLevelCount, 1),
+		samples:    max(desc.SampleCount, 1),
+		dimension:  desc.Dimension,
+		usage:      desc.Usage,
+		device:     d,
+		isExternal: true,
+	}, nil
+}
+
 // CreateTextureView creates a view into a texture.
 func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDescriptor) (hal.TextureView, error) {
 	var mtlTexture *Texture
@@ -318,21 +390,30 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		mipCount = mtlTexture.mipLevels - baseMip
 	}
 
+	var viewType MTLTextureType
+	if desc.Dimension == gputypes.TextureViewDimensionUndefined {
+		viewType = textureTypeFromDimension(mtlTexture.dimension, mtlTexture.samples, mtlTexture.depth)
+		if viewType == MTLTextureType2DArray && desc.ArrayLayerCount == 1 {
+			viewType = MTLTextureType2D
+		}
+	} else {
+		viewType = textureViewDimensionToMTL(desc.Dimension)
+	}
+
+	// A 3D texture has a single slice; its depth is not an array length.
 	baseLayer := desc.BaseArrayLayer
 	layerCount := desc.ArrayLayerCount
-	if layerCount == 0 {
+	if mtlTexture.dimension == gputypes.TextureDimension3D {
+		baseLayer, layerCount = 0, 1
+	} else if layerCount == 0 {
 		// 0 means "all remaining array layers" in WebGPU spec
 		layerCount = mtlTexture.depth - baseLayer
 		if layerCount == 0 {
 			layerCount = 1
 		}
-	}
-
-	var viewType MTLTextureType
-	if desc.Dimension == gputypes.TextureViewDimensionUndefined {
-		viewType = textureTypeFromDimension(mtlTexture.dimension, mtlTexture.samples, mtlTexture.depth)
-	} else {
-		viewType = textureViewDimensionToMTL(desc.Dimension)
+		if viewType == MTLTextureTypeCube {
+			layerCount = min(layerCount, 6)
+		}
 	}
 
 	// Metal requires the texture view type to match the source texture's
@@ -536,7 +617,7 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 			return nil, fmt.Errorf("metal: failed to compile to MSL: %w", err)
 		}
 
-		hal.Logger().Debug("metal: WGSL→MSL compilation",
+		d.logger(hal.LogShader).Debug("metal: WGSL→MSL compilation",
 			"elapsed", time.Since(start),
 			"mslBytes", len(mslSource),
 		)
@@ -562,17 +643,19 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 			return nil, fmt.Errorf("metal: failed to compile MSL: %s\nMSL:\n%s", errMsg, mslSource)
 		}
 
-		hal.Logger().Info("metal: shader module compiled",
+		d.logger(hal.LogShader).Info("metal: shader module compiled",
 			"entryPoints", len(workgroupSizes),
 		)
 
-		return &ShaderModule{
+		module := &ShaderModule{
 			source:          desc.Source,
 			library:         library,
 			device:          d,
 			workgroupSizes:  workgroupSizes,
-			entrypointNames: info.EntryPointNames,
-		}, nil
+			entryPointNames: info.EntryPointNames,
+		}
+		d.prebuildHinted(module, desc)
+		return module, nil
 	}
 
 	// No WGSL source - just store the descriptor for later
@@ -607,6 +690,12 @@ func (d *Device) DestroyShaderModule(module hal.ShaderModule) {
 	if !ok || mtlModule == nil {
 		return
 	}
+	mtlModule.mu.Lock()
+	for _, state := range mtlModule.prebuilt {
+		Release(state)
+	}
+	mtlModule.prebuilt = nil
+	mtlModule.mu.Unlock()
 	if mtlModule.library != 0 {
 		Release(mtlModule.library)
 		mtlModule.library = 0
@@ -647,18 +736,10 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 		Release(label)
 	}
 
-	// Resolve translated entrypoint name
-	entrypointName := desc.Vertex.EntryPoint
-	if translated, ok := vertexModule.entrypointNames[entrypointName]; ok {
-		entrypointName = translated
-	}
-
 	// Get vertex function from library
-	vertexFuncName := NSString(entrypointName)
-	vertexFunc := MsgSend(vertexModule.library, Sel("newFunctionWithName:"), uintptr(vertexFuncName))
-	Release(vertexFuncName)
-	if vertexFunc == 0 {
-		return nil, fmt.Errorf("metal: vertex function '%s' not found", entrypointName)
+	vertexFunc, err := vertexModule.newFunction("vertex", desc.Vertex.EntryPoint)
+	if err != nil {
+		return nil, err
 	}
 	defer Release(vertexFunc)
 
@@ -676,17 +757,9 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 
 	// Get and set fragment function if present
 	if fragmentModule != nil && desc.Fragment != nil { //nolint:nestif // sequential Metal pipeline setup
-		// Resolve translated entrypoint name
-		entrypointName := desc.Fragment.EntryPoint
-		if translated, ok := fragmentModule.entrypointNames[entrypointName]; ok {
-			entrypointName = translated
-		}
-
-		fragmentFuncName := NSString(entrypointName)
-		fragmentFunc := MsgSend(fragmentModule.library, Sel("newFunctionWithName:"), uintptr(fragmentFuncName))
-		Release(fragmentFuncName)
-		if fragmentFunc == 0 {
-			return nil, fmt.Errorf("metal: fragment function '%s' not found", entrypointName)
+		fragmentFunc, err := fragmentModule.newFunction("fragment", desc.Fragment.EntryPoint)
+		if err != nil {
+			return nil, err
 		}
 		defer Release(fragmentFunc)
 
@@ -766,6 +839,12 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 	}
 	_ = MsgSend(pipelineDesc, Sel("setSampleCount:"), uintptr(sampleCount))
 
+	// Multiview renders each view as a vertex amplification; the pass maps
+	// view i to render target array slice i.
+	if desc.ViewCount > 1 {
+		_ = MsgSend(pipelineDesc, Sel("setMaxVertexAmplificationCount:"), uintptr(desc.ViewCount))
+	}
+
 	// Create pipeline state. ICB support stays entirely private: eligible
 	// pipelines get one flagged attempt and transparently retry ordinary
 	// creation if Metal rejects the stricter descriptor.
@@ -812,6 +891,7 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 		layout:        pipeLayout,
 		cullMode:      cullModeToMTL(desc.Primitive.CullMode),
 		frontFace:     frontFaceToMTL(desc.Primitive.FrontFace),
+		fillMode:      fillModeToMTL(desc.PolygonMode),
 		icbCompatible: icbCompatible,
 
 		depthStencil:    depthStencilState,
@@ -903,18 +983,47 @@ func (d *Device) CreateComputePipeline(desc *hal.ComputePipelineDescriptor) (hal
 		return nil, fmt.Errorf("metal: invalid compute shader module")
 	}
 
-	// Resolve translated entrypoint name
-	entrypointName := desc.Compute.EntryPoint
-	if translated, ok := computeModule.entrypointNames[entrypointName]; ok {
-		entrypointName = translated
+	// A pipeline state built from a compilation hint has no overridden
+	// constants.
+	var pipelineState ID
+	if len(desc.Compute.Constants) == 0 {
+		pipelineState = computeModule.takePrebuilt(desc.Compute.EntryPoint)
+	}
+	if pipelineState == 0 {
+		var err error
+		pipelineState, err = d.newComputePipelineState(computeModule, desc.Compute.EntryPoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get workgroup size from shader module metadata
+	workgroupSize := getWorkgroupSize(computeModule, desc.Compute.EntryPoint)
+
+	hal.Logger().Debug("metal: compute pipeline created",
+		"entryPoint", desc.Compute.EntryPoint,
+		"workgroupSize", fmt.Sprintf("%dx%dx%d", workgroupSize.Width, workgroupSize.Height, workgroupSize.Depth),
+	)
+
+	var pipeLayout *PipelineLayout
+	if pl, ok := desc.Layout.(*PipelineLayout); ok {
+		pipeLayout = pl
 	}
+	return &ComputePipeline{
+		raw:           pipelineState,
+		device:        d,
+		layout:        pipeLayout,
+		workgroupSize: workgroupSize,
+	}, nil
+}
 
+// newComputePipelineState compiles the compute pipeline state of an entry
+// point of module.
+func (d *Device) newComputePipelineState(module *ShaderModule, entryPoint string) (ID, error) {
 	// Get compute function from library
-	funcName := NSString(entrypointName)
-	computeFunc := MsgSend(computeModule.library, Sel("newFunctionWithName:"), uintptr(funcName))
-	Release(funcName)
-	if computeFunc == 0 {
-		return nil, fmt.Errorf("metal: compute function '%s' not found", entrypointName)
+	computeFunc, err := module.newFunction("compute", entryPoint)
+	if err != nil {
+		return 0, err
 	}
 	defer Release(computeFunc)
 
@@ -932,27 +1041,43 @@ func (d *Device) CreateComputePipeline(desc *hal.ComputePipelineDescriptor) (hal
 			}
 			// Object is autoreleased
 		}
-		return nil, fmt.Errorf("metal: failed to create compute pipeline state: %s", errMsg)
+		return 0, fmt.Errorf("metal: failed to create compute pipeline state: %s", errMsg)
 	}
+	return pipelineState, nil
+}
 
-	// Get workgroup size from shader module metadata
-	workgroupSize := getWorkgroupSize(computeModule, desc.Compute.EntryPoint)
-
-	hal.Logger().Debug("metal: compute pipeline created",
-		"entryPoint", desc.Compute.EntryPoint,
-		"workgroupSize", fmt.Sprintf("%dx%dx%d", workgroupSize.Width, workgroupSize.Height, workgroupSize.Depth),
-	)
+// prebuildHinted compiles the compute pipeline states of the entry points
+// the compilation hints of desc name, so the driver compiles them while the
+// module is loaded. CreateComputePipeline takes them over. Render entry
+// points need the full pipeline descriptor and are left alone; failures
+// are left for pipeline creation to report.
+func (d *Device) prebuildHinted(module *ShaderModule, desc *hal.ShaderModuleDescriptor) {
+	if len(desc.CompilationHints) == 0 {
+		return
+	}
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
 
-	var pipeLayout *PipelineLayout
-	if pl, ok := desc.Layout.(*PipelineLayout); ok {
-		pipeLayout = pl
+	for _, hint := range desc.CompilationHints {
+		if hint.Stage != gputypes.ShaderStageCompute || module.prebuilt[hint.EntryPoint] != 0 {
+			continue
+		}
+		start := time.Now()
+		state, err := d.newComputePipelineState(module, hint.EntryPoint)
+		if err != nil {
+			d.logger(hal.LogShader).Debug("metal: hinted compute pipeline failed",
+				"entryPoint", hint.EntryPoint, "err", err)
+			continue
+		}
+		if module.prebuilt == nil {
+			module.prebuilt = make(map[string]ID)
+		}
+		module.prebuilt[hint.EntryPoint] = state
+		d.logger(hal.LogShader).Debug("metal: compute pipeline built from hint",
+			"entryPoint", hint.EntryPoint,
+			"elapsed", time.Since(start),
+		)
 	}
-	return &ComputePipeline{
-		raw:           pipelineState,
-		device:        d,
-		layout:        pipeLayout,
-		workgroupSize: workgroupSize,
-	}, nil
 }
 
 // getWorkgroupSize retrieves workgroup size for a compute entry point.
@@ -1204,6 +1329,17 @@ func (d *Device) CreateRenderBundleEncoder(desc *hal.RenderBundleEncoderDescript
 // DestroyRenderBundle is not supported in Metal backend.
 func (d *Device) DestroyRenderBundle(bundle hal.RenderBundle) {}
 
+// MemoryBudget reports the memory the device has allocated against its
+// recommended maximum working set, beyond which Metal starts paging
+// resources. Implements hal.MemoryBudgetReporter.
+func (d *Device) MemoryBudget() (hal.MemoryBudget, bool) {
+	budget := DeviceRecommendedMaxWorkingSetSize(d.raw)
+	if budget == 0 || !MsgSendBool(d.raw, Sel("respondsToSelector:"), uintptr(Sel("currentAllocatedSize"))) {
+		return hal.MemoryBudget{}, false
+	}
+	return hal.MemoryBudget{Budget: budget, Usage: uint64(MsgSend(d.raw, Sel("currentAllocatedSize")))}, true
+}
+
 // WaitIdle waits for all GPU work to complete.
 //
 // Metal has no device-level wait API like Vulkan's vkDeviceWaitIdle. Instead,
@@ -1251,7 +1387,7 @@ func (d *Device) WaitIdle() error {
 
 	// Drain and refill the frame semaphore. After waitUntilCompleted, all
 	// in-flight completion handlers have fired and returned their tokens.
-	// We drain any remaining tokens and refill to maxFramesInFlight so the
+	// We drain any remaining tokens and refill to capacity so the
 	// semaphore is in a clean state for subsequent submissions.
 	if d.queue != nil && d.queue.frameSemaphore != nil {
 		// Drain all available tokens (non-blocking).
@@ -1264,7 +1400,7 @@ func (d *Device) WaitIdle() error {
 		}
 	refill:
 		// Refill to capacity.
-		for i := 0; i < maxFramesInFlight; i++ {
+		for i := 0; i < cap(d.queue.frameSemaphore); i++ {
 			d.queue.frameSemaphore <- struct{}{}
 		}
 	}
@@ -0,0 +1,208 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build darwin && !(js && wasm)
+
+package metal
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// CoreVideo CVDisplayLink bindings. CVDisplayLink is macOS-only; on iOS and
+// tvOS the framework loads but the symbols are missing, and display link
+// pacing reports an error.
+var (
+	coreVideoOnce sync.Once
+	errCoreVideo  error
+
+	symCVDisplayLinkCreateWithActiveCGDisplays unsafe.Pointer
+	symCVDisplayLinkSetOutputCallback          unsafe.Pointer
+	symCVDisplayLinkStart                      unsafe.Pointer
+	symCVDisplayLinkStop                       unsafe.Pointer
+	symCVDisplayLinkRelease                    unsafe.Pointer
+
+	cifCVDisplayLinkCreate            types.CallInterface // CVReturn(CVDisplayLinkRef*)
+	cifCVDisplayLinkSetOutputCallback types.CallInterface // CVReturn(link, callback, ctx)
+	cifCVDisplayLinkCall              types.CallInterface // CVReturn(link): Start, Stop
+	cifCVDisplayLinkRelease           types.CallInterface // void(link)
+)
+
+func initCoreVideo() error {
+	coreVideoOnce.Do(func() {
+		errCoreVideo = doInitCoreVideo()
+	})
+	return errCoreVideo
+}
+
+func doInitCoreVideo() error {
+	lib, err := ffi.LoadLibrary("/System/Library/Frameworks/CoreVideo.framework/CoreVideo")
+	if err != nil {
+		return fmt.Errorf("metal: failed to load CoreVideo.framework: %w", err)
+	}
+	syms := []struct {
+		name string
+		dst  *unsafe.Pointer
+	}{
+		{"CVDisplayLinkCreateWithActiveCGDisplays", &symCVDisplayLinkCreateWithActiveCGDisplays},
+		{"CVDisplayLinkSetOutputCallback", &symCVDisplayLinkSetOutputCallback},
+		{"CVDisplayLinkStart", &symCVDisplayLinkStart},
+		{"CVDisplayLinkStop", &symCVDisplayLinkStop},
+		{"CVDisplayLinkRelease", &symCVDisplayLinkRelease},
+	}
+	for _, s := range syms {
+		if *s.dst, err = ffi.GetSymbol(lib, s.name); err != nil {
+			return fmt.Errorf("metal: %s not found (CVDisplayLink is macOS-only): %w", s.name, err)
+		}
+	}
+
+	ptr := types.PointerTypeDescriptor
+	ret := types.SInt32TypeDescriptor
+	if err := ffi.PrepareCallInterface(&cifCVDisplayLinkCreate, types.DefaultCall,
+		ret, []*types.TypeDescriptor{ptr}); err != nil {
+		return fmt.Errorf("metal: failed to prepare CVDisplayLinkCreateWithActiveCGDisplays: %w", err)
+	}
+	if err := ffi.PrepareCallInterface(&cifCVDisplayLinkSetOutputCallback, types.DefaultCall,
+		ret, []*types.TypeDescriptor{ptr, ptr, ptr}); err != nil {
+		return fmt.Errorf("metal: failed to prepare CVDisplayLinkSetOutputCallback: %w", err)
+	}
+	if err := ffi.PrepareCallInterface(&cifCVDisplayLinkCall, types.DefaultCall,
+		ret, []*types.TypeDescriptor{ptr}); err != nil {
+		return fmt.Errorf("metal: failed to prepare CVDisplayLinkStart: %w", err)
+	}
+	if err := ffi.PrepareCallInterface(&cifCVDisplayLinkRelease, types.DefaultCall,
+		types.VoidTypeDescriptor, []*types.TypeDescriptor{ptr}); err != nil {
+		return fmt.Errorf("metal: failed to prepare CVDisplayLinkRelease: %w", err)
+	}
+	return nil
+}
+
+// cvTimeStamp is the leading part of CoreVideo's CVTimeStamp; the callback
+// only reads through a pointer, so the trailing SMPTE fields are omitted.
+type cvTimeStamp struct {
+	version            uint32
+	videoTimeScale     int32
+	videoTime          int64
+	hostTime           uint64
+	rateScalar         float64
+	videoRefreshPeriod int64
+}
+
+// duration converts the video time to a duration since the display link
+// time base.
+func (ts *cvTimeStamp) duration() time.Duration {
+	if ts == nil || ts.videoTimeScale <= 0 {
+		return 0
+	}
+	sec := ts.videoTime / int64(ts.videoTimeScale)
+	rem := ts.videoTime % int64(ts.videoTimeScale)
+	return time.Duration(sec)*time.Second + time.Duration(rem)*time.Second/time.Duration(ts.videoTimeScale)
+}
+
+// displayLink drives a surface's frame callback from a CVDisplayLink.
+//
+// The output callback runs on a CoreVideo thread once per display refresh.
+// A tick is delivered only while the surface has no drawable outstanding,
+// so an application that renders from the callback never waits in
+// nextDrawable for a drawable still held by its previous frame: slow frames
+// drop ticks instead of queueing behind each other.
+type displayLink struct {
+	id      uintptr
+	link    uintptr // CVDisplayLinkRef
+	surface *Surface
+	onFrame func(outputTime time.Duration)
+}
+
+// displayLinkRegistry maps the context pointer passed to CoreVideo to its
+// displayLink. Go pointers cannot be handed to C, so the context is an ID.
+var (
+	displayLinkRegistry sync.Map // map[uintptr]*displayLink
+	displayLinkNextID   atomic.Uintptr
+
+	displayLinkCallbackOnce sync.Once
+	displayLinkCallbackPtr  uintptr
+)
+
+// getDisplayLinkCallback returns the CVDisplayLinkOutputCallback trampoline,
+// shared by all display links.
+func getDisplayLinkCallback() uintptr {
+	displayLinkCallbackOnce.Do(func() {
+		// CVReturn (*)(CVDisplayLinkRef, const CVTimeStamp *inNow,
+		//     const CVTimeStamp *inOutputTime, CVOptionFlags flagsIn,
+		//     CVOptionFlags *flagsOut, void *ctx)
+		displayLinkCallbackPtr = ffi.NewCallback(func(_, _, outputTime uintptr, _ uint64, _, ctx uintptr) int32 {
+			entry, ok := displayLinkRegistry.Load(ctx)
+			if !ok {
+				return 0
+			}
+			dl := entry.(*displayLink)
+			if dl.surface.acquired.Load() > 0 {
+				return 0
+			}
+			ts := (*cvTimeStamp)(unsafe.Pointer(outputTime)) //nolint:govet // CoreVideo-owned timestamp
+			dl.onFrame(ts.duration())
+			return 0
+		})
+	})
+	return displayLinkCallbackPtr
+}
+
+// startDisplayLink creates and starts a display link calling onFrame.
+func startDisplayLink(s *Surface, onFrame func(time.Duration)) (*displayLink, error) {
+	if err := initCoreVideo(); err != nil {
+		return nil, err
+	}
+	dl := &displayLink{
+		id:      displayLinkNextID.Add(1),
+		surface: s,
+		onFrame: onFrame,
+	}
+
+	var status uintptr
+	linkPtr := uintptr(unsafe.Pointer(&dl.link))
+	_, _ = ffi.CallFunction(&cifCVDisplayLinkCreate, symCVDisplayLinkCreateWithActiveCGDisplays,
+		unsafe.Pointer(&status), []unsafe.Pointer{unsafe.Pointer(&linkPtr)})
+	if int32(status) != 0 || dl.link == 0 {
+		return nil, fmt.Errorf("metal: CVDisplayLinkCreateWithActiveCGDisplays failed (CVReturn %d)", int32(status))
+	}
+
+	displayLinkRegistry.Store(dl.id, dl)
+	callback := getDisplayLinkCallback()
+	_, _ = ffi.CallFunction(&cifCVDisplayLinkSetOutputCallback, symCVDisplayLinkSetOutputCallback,
+		unsafe.Pointer(&status), []unsafe.Pointer{
+			unsafe.Pointer(&dl.link), unsafe.Pointer(&callback), unsafe.Pointer(&dl.id),
+		})
+	if int32(status) == 0 {
+		_, _ = ffi.CallFunction(&cifCVDisplayLinkCall, symCVDisplayLinkStart,
+			unsafe.Pointer(&status), []unsafe.Pointer{unsafe.Pointer(&dl.link)})
+	}
+	if int32(status) != 0 {
+		dl.stop()
+		return nil, fmt.Errorf("metal: failed to start CVDisplayLink (CVReturn %d)", int32(status))
+	}
+	return dl, nil
+}
+
+// stop stops and releases the display link. CVDisplayLinkStop waits for an
+// output callback in progress, so onFrame is not called after stop returns.
+func (dl *displayLink) stop() {
+	if dl == nil || dl.link == 0 {
+		return
+	}
+	var status uintptr
+	_, _ = ffi.CallFunction(&cifCVDisplayLinkCall, symCVDisplayLinkStop,
+		unsafe.Pointer(&status), []unsafe.Pointer{unsafe.Pointer(&dl.link)})
+	_, _ = ffi.CallFunction(&cifCVDisplayLinkRelease, symCVDisplayLinkRelease,
+		nil, []unsafe.Pointer{unsafe.Pointer(&dl.link)})
+	displayLinkRegistry.Delete(dl.id)
+	dl.link = 0
+	hal.Logger().Debug("metal: display link stopped", "id", dl.id)
+}
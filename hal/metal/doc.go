@@ -36,7 +36,15 @@
 // Metal uses storage modes for memory management:
 //   - Shared: CPU and GPU can both access (for mappable buffers)
 //   - Private: GPU-only access (fastest for GPU operations)
-//   - Memoryless: Tile-based deferred rendering (iOS optimization)
+//   - Memoryless: Tile memory only, no device allocation. Used for
+//     transient attachments (TextureDescriptor.Transient) on Apple GPUs.
+//
+// # iOS and tvOS
+//
+// The package builds for GOOS=ios (iOS and tvOS). Surfaces accept either a
+// CAMetalLayer or a UIView; for a view whose layer is not a CAMetalLayer, a
+// CAMetalLayer sublayer is attached. macOS-only layer properties such as
+// displaySyncEnabled are skipped when the layer does not support them.
 //
 // # Autorelease Pools
 //
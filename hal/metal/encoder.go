@@ -7,7 +7,9 @@ package metal
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
+	"unsafe"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal"
@@ -362,10 +364,16 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 		if attachment == 0 {
 			continue
 		}
+		memoryless := false
 		if tv, ok := ca.View.(*TextureView); ok && tv != nil {
 			_ = MsgSend(attachment, Sel("setTexture:"), uintptr(tv.raw))
+			memoryless = tv.isMemoryless()
 		}
-		_ = MsgSend(attachment, Sel("setLoadAction:"), uintptr(loadOpToMTL(ca.LoadOp)))
+		loadAction := loadOpToMTL(ca.LoadOp)
+		if memoryless {
+			loadAction = memorylessLoadAction(loadAction)
+		}
+		_ = MsgSend(attachment, Sel("setLoadAction:"), uintptr(loadAction))
 		if ca.LoadOp == gputypes.LoadOpClear {
 			clearColor := MTLClearColor{Red: ca.ClearValue.R, Green: ca.ClearValue.G, Blue: ca.ClearValue.B, Alpha: ca.ClearValue.A}
 			msgSendClearColor(attachment, Sel("setClearColor:"), clearColor)
@@ -384,21 +392,52 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 				}
 			}
 		}
+		if memoryless {
+			storeAction = memorylessStoreAction(storeAction)
+		}
 		_ = MsgSend(attachment, Sel("setStoreAction:"), uintptr(storeAction))
 	}
 	if desc.DepthStencilAttachment != nil { //nolint:nestif // sequential Metal descriptor setup
 		dsa := desc.DepthStencilAttachment
 
-		// Depth attachment
-		depthAttachment := MsgSend(rpDesc, Sel("depthAttachment"))
+		// Attach only the aspects the format has, like the pipeline's
+		// depth and stencil pixel formats. Metal rejects a depth-only
+		// texture as the stencil attachment, which breaks Depth32Float
+		// shadow map passes. Reference: Rust wgpu-hal metal/command.rs.
+		dsMemoryless := false
+		hasDepth, hasStencil := true, true
 		if tv, ok := dsa.View.(*TextureView); ok && tv != nil {
-			_ = MsgSend(depthAttachment, Sel("setTexture:"), uintptr(tv.raw))
+			dsMemoryless = tv.isMemoryless()
+			if tv.texture != nil {
+				hasDepth = tv.texture.format.HasDepth()
+				hasStencil = tv.texture.format.HasStencil()
+			}
+		}
+		dsLoad := func(op gputypes.LoadOp) uintptr {
+			if dsMemoryless {
+				return uintptr(memorylessLoadAction(loadOpToMTL(op)))
+			}
+			return uintptr(loadOpToMTL(op))
+		}
+		dsStore := func(op gputypes.StoreOp) uintptr {
+			if dsMemoryless {
+				return uintptr(memorylessStoreAction(storeOpToMTL(op)))
+			}
+			return uintptr(storeOpToMTL(op))
 		}
-		_ = MsgSend(depthAttachment, Sel("setLoadAction:"), uintptr(loadOpToMTL(dsa.DepthLoadOp)))
-		if dsa.DepthLoadOp == gputypes.LoadOpClear {
-			msgSendVoid(depthAttachment, Sel("setClearDepth:"), argFloat64(float64(dsa.DepthClearValue)))
+
+		// Depth attachment
+		if hasDepth {
+			depthAttachment := MsgSend(rpDesc, Sel("depthAttachment"))
+			if tv, ok := dsa.View.(*TextureView); ok && tv != nil {
+				_ = MsgSend(depthAttachment, Sel("setTexture:"), uintptr(tv.raw))
+			}
+			_ = MsgSend(depthAttachment, Sel("setLoadAction:"), dsLoad(dsa.DepthLoadOp))
+			if dsa.DepthLoadOp == gputypes.LoadOpClear {
+				msgSendVoid(depthAttachment, Sel("setClearDepth:"), argFloat64(float64(dsa.DepthClearValue)))
+			}
+			_ = MsgSend(depthAttachment, Sel("setStoreAction:"), dsStore(dsa.DepthStoreOp))
 		}
-		_ = MsgSend(depthAttachment, Sel("setStoreAction:"), uintptr(storeOpToMTL(dsa.DepthStoreOp)))
 
 		// Stencil attachment — same texture, separate load/store/clear.
 		// Metal requires both depth and stencil attachments to be configured
@@ -408,22 +447,27 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 		// undefined and causing progressive rendering artifacts on Apple
 		// Silicon TBDR GPUs.
 		// Reference: Rust wgpu-hal metal/command.rs:705-727.
-		stencilAttachment := MsgSend(rpDesc, Sel("stencilAttachment"))
-		if tv, ok := dsa.View.(*TextureView); ok && tv != nil {
-			_ = MsgSend(stencilAttachment, Sel("setTexture:"), uintptr(tv.raw))
-		}
-		_ = MsgSend(stencilAttachment, Sel("setLoadAction:"), uintptr(loadOpToMTL(dsa.StencilLoadOp)))
-		if dsa.StencilLoadOp == gputypes.LoadOpClear {
-			_ = MsgSend(stencilAttachment, Sel("setClearStencil:"), uintptr(dsa.StencilClearValue))
+		if hasStencil {
+			stencilAttachment := MsgSend(rpDesc, Sel("stencilAttachment"))
+			if tv, ok := dsa.View.(*TextureView); ok && tv != nil {
+				_ = MsgSend(stencilAttachment, Sel("setTexture:"), uintptr(tv.raw))
+			}
+			_ = MsgSend(stencilAttachment, Sel("setLoadAction:"), dsLoad(dsa.StencilLoadOp))
+			if dsa.StencilLoadOp == gputypes.LoadOpClear {
+				_ = MsgSend(stencilAttachment, Sel("setClearStencil:"), uintptr(dsa.StencilClearValue))
+			}
+			_ = MsgSend(stencilAttachment, Sel("setStoreAction:"), dsStore(dsa.StencilStoreOp))
 		}
-		_ = MsgSend(stencilAttachment, Sel("setStoreAction:"), uintptr(storeOpToMTL(dsa.StencilStoreOp)))
+	}
+	if desc.ViewCount > 1 {
+		_ = MsgSend(rpDesc, Sel("setRenderTargetArrayLength:"), uintptr(desc.ViewCount))
 	}
 	// Keep the descriptor alive but delay creation of the native render encoder
 	// until the first draw. Metal requires the ICB translator to run on a compute
 	// encoder before the render encoder exists; retaining the descriptor gives
 	// that backend-private lowering a narrow seam without journaling draw calls.
 	e.passState = renderPassPendingState{}
-	return &RenderPassEncoder{descriptor: rpDesc, commandEncoder: e, device: e.device, pending: &e.passState}
+	return &RenderPassEncoder{descriptor: rpDesc, commandEncoder: e, device: e.device, pending: &e.passState, viewCount: desc.ViewCount}
 }
 
 // BeginComputePass begins a compute pass.
@@ -492,6 +536,7 @@ type RenderPassEncoder struct {
 	indexFormat    gputypes.IndexFormat
 	indexOffset    uint64
 	pending        *renderPassPendingState
+	viewCount      uint32 // multiview views; above 1 enables vertex amplification
 }
 
 const (
@@ -548,10 +593,26 @@ func (e *RenderPassEncoder) beginNative() bool {
 	Retain(encoder)
 	e.raw = encoder
 	pool.Drain()
+	if e.viewCount > 1 {
+		e.setVertexAmplification(e.viewCount)
+	}
 	e.replayPendingState()
 	return true
 }
 
+// setVertexAmplification renders every draw once per view, sending view i
+// to render target array slice i as the view_index built-in expects.
+// Reference: Rust wgpu-hal metal/command.rs begin_render_pass.
+func (e *RenderPassEncoder) setVertexAmplification(count uint32) {
+	mappings := make([]MTLVertexAmplificationViewMapping, count)
+	for i := range mappings {
+		mappings[i].RenderTargetArrayIndexOffset = uint32(i)
+	}
+	_ = MsgSend(e.raw, Sel("setVertexAmplificationCount:viewMappings:"),
+		uintptr(count), uintptr(unsafe.Pointer(unsafe.SliceData(mappings))))
+	runtime.KeepAlive(mappings)
+}
+
 func (e *RenderPassEncoder) replayPendingState() {
 	if e.pipeline != nil {
 		e.applyPipeline(e.pipeline)
@@ -617,6 +678,7 @@ func (e *RenderPassEncoder) applyPipeline(p *RenderPipeline) {
 	_ = MsgSend(e.raw, Sel("setRenderPipelineState:"), uintptr(p.raw))
 	_ = MsgSend(e.raw, Sel("setCullMode:"), uintptr(p.cullMode))
 	_ = MsgSend(e.raw, Sel("setFrontFacingWinding:"), uintptr(p.frontFace))
+	_ = MsgSend(e.raw, Sel("setTriangleFillMode:"), uintptr(p.fillMode))
 	if p.depthStencil != 0 {
 		_ = MsgSend(e.raw, Sel("setDepthStencilState:"), uintptr(p.depthStencil))
 		_ = MsgSend(e.raw, Sel("setDepthBias:slopeScale:clamp:"), uintptr(p.depthBias), uintptr(p.depthSlopeScale), uintptr(p.depthClamp))
@@ -731,7 +793,8 @@ func (e *RenderPassEncoder) applyBindGroup(index uint32, bg *BindGroup, offsets
 }
 
 // SetVertexBuffer sets a vertex buffer.
-func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset uint64) {
+// Metal binds to the end of the buffer; core has checked size.
+func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || buf == nil || slot >= maxVertexBuffers {
 		return
@@ -754,7 +817,8 @@ func (e *RenderPassEncoder) applyVertexBuffer(slot uint32, buf *Buffer, offset u
 }
 
 // SetIndexBuffer sets the index buffer.
-func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+// Indexed draws pass only an offset, so size is not needed.
+func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || buf == nil {
 		return
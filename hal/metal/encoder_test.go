@@ -128,9 +128,9 @@ func TestRenderPassDeferredStateKeepsLatestValues(t *testing.T) {
 	pass.SetPipeline(latestPipeline)
 	pass.SetBindGroup(1, firstGroup, []uint32{4, 8})
 	pass.SetBindGroup(1, latestGroup, []uint32{12})
-	pass.SetVertexBuffer(2, firstVertex, 16)
-	pass.SetVertexBuffer(2, latestVertex, 24)
-	pass.SetIndexBuffer(index, gputypes.IndexFormatUint32, 32)
+	pass.SetVertexBuffer(2, firstVertex, 16, 0)
+	pass.SetVertexBuffer(2, latestVertex, 24, 0)
+	pass.SetIndexBuffer(index, gputypes.IndexFormatUint32, 32, 0)
 	pass.SetViewport(1, 2, 3, 4, 0.25, 0.75)
 	pass.SetScissorRect(5, 6, 7, 8)
 	pass.SetBlendConstant(&gputypes.Color{R: 0.1, G: 0.2, B: 0.3, A: 0.4})
@@ -178,8 +178,8 @@ func TestRenderPassDeferredStateUsesBoundedStorage(t *testing.T) {
 		pass = RenderPassEncoder{pending: &state}
 		pass.SetPipeline(pipeline)
 		pass.SetBindGroup(0, group, offsets)
-		pass.SetVertexBuffer(0, buffer, 12)
-		pass.SetIndexBuffer(buffer, gputypes.IndexFormatUint16, 4)
+		pass.SetVertexBuffer(0, buffer, 12, 0)
+		pass.SetIndexBuffer(buffer, gputypes.IndexFormatUint16, 4, 0)
 		pass.SetViewport(0, 0, 10, 10, 0, 1)
 		pass.SetScissorRect(0, 0, 10, 10)
 		pass.SetBlendConstant(&gputypes.Color{A: 1})
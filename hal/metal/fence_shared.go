@@ -0,0 +1,94 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build darwin && !(js && wasm)
+
+package metal
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// CreateSharedFence creates a fence. Every Metal fence is an
+// MTLSharedEvent, which ExportFence hands out as it is. Implements
+// hal.FenceSharer.
+func (d *Device) CreateSharedFence() (hal.Fence, error) {
+	return d.CreateFence()
+}
+
+// ExportFence returns the id<MTLSharedEvent> of fence. It stays owned by
+// the fence; retain it to keep it past DestroyFence. Implements
+// hal.FenceSharer.
+func (d *Device) ExportFence(fence hal.Fence) (hal.NativeFence, error) {
+	mtlFence, ok := fence.(*Fence)
+	if !ok || mtlFence == nil || mtlFence.event == 0 {
+		return hal.NativeFence{}, fmt.Errorf("metal: invalid fence")
+	}
+	return hal.NativeFence{Type: hal.NativeFenceMetalSharedEvent, Handle: uintptr(mtlFence.event)}, nil
+}
+
+// ImportFence wraps an id<MTLSharedEvent> created on this device, taking a
+// reference of its own. Implements hal.FenceSharer.
+func (d *Device) ImportFence(native hal.NativeFence) (hal.Fence, error) {
+	if native.Type != hal.NativeFenceMetalSharedEvent {
+		return nil, fmt.Errorf("metal: cannot import native fence type %d: %w", native.Type, hal.ErrExternalNotSupported)
+	}
+	if native.Handle == 0 {
+		return nil, fmt.Errorf("metal: native fence handle is nil")
+	}
+	return &Fence{event: Retain(ID(native.Handle)), device: d}, nil
+}
+
+// SubmitWithFences submits commandBuffers like Submit. The waits are
+// encoded into a command buffer committed ahead of them, which holds back
+// the queue's later command buffers, and the signals at the end of the
+// last one, so they fire when all of them have completed. Implements
+// hal.FenceSubmitter.
+func (q *Queue) SubmitWithFences(commandBuffers []hal.CommandBuffer, waits, signals []hal.FenceValue) (uint64, error) {
+	for _, values := range [][]hal.FenceValue{waits, signals} {
+		for i, v := range values {
+			if f, ok := v.Fence.(*Fence); !ok || f == nil || f.event == 0 {
+				return 0, fmt.Errorf("metal: fence %d is not a Metal fence", i)
+			}
+		}
+	}
+
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	// The signals need a command buffer to go at the end of, and the
+	// submission one to report completion with.
+	var last *CommandBuffer
+	for _, buf := range slices.Backward(commandBuffers) {
+		if cb, ok := buf.(*CommandBuffer); ok && cb != nil {
+			last = cb
+			break
+		}
+	}
+	if last == nil {
+		raw := MsgSend(q.commandQueue, Sel("commandBuffer"))
+		if raw == 0 {
+			return 0, fmt.Errorf("metal: failed to create command buffer for fences")
+		}
+		last = &CommandBuffer{raw: raw, device: q.device}
+		commandBuffers = append(slices.Clip(commandBuffers), last)
+	}
+
+	if len(waits) > 0 {
+		waitBuffer := MsgSend(q.commandQueue, Sel("commandBuffer"))
+		if waitBuffer == 0 {
+			return 0, fmt.Errorf("metal: failed to create command buffer for fence waits")
+		}
+		for _, w := range waits {
+			_ = MsgSend(waitBuffer, Sel("encodeWaitForEvent:value:"), uintptr(w.Fence.(*Fence).event), uintptr(w.Value))
+		}
+		_ = MsgSend(waitBuffer, Sel("commit"))
+	}
+	for _, s := range signals {
+		_ = MsgSend(last.raw, Sel("encodeSignalEvent:value:"), uintptr(s.Fence.(*Fence).event), uintptr(s.Value))
+	}
+	return q.Submit(commandBuffers)
+}
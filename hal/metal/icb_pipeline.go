@@ -37,6 +37,9 @@ func (d *Device) canCreateICBPipeline(desc *hal.RenderPipelineDescriptor, pipeli
 	if d == nil || d.raw == 0 || pipelineDesc == 0 || !renderPipelineICBCandidate(desc) {
 		return false
 	}
+	if d.adapter != nil && d.adapter.instance != nil && d.adapter.instance.options.DisableIndirectCommandBuffers {
+		return false
+	}
 	// Metal family support is cumulative. Apple8+ devices also report Apple7,
 	// so this admits the M1 proof family and later Apple GPU families while
 	// leaving Intel and AMD devices on the ordinary path.
@@ -157,6 +157,7 @@ func preRegisterSelectors() {
 		"signaledValue",
 		"setSignaledValue:",
 		"encodeSignalEvent:value:",
+		"encodeWaitForEvent:value:",
 		"notifyListener:atValue:block:",
 		// MTLTexture
 		"width", "height", "depth",
@@ -237,6 +238,39 @@ func DeviceSupportsFamily(device ID, family MTLGPUFamily) bool {
 	return MsgSendBool(device, Sel("supportsFamily:"), uintptr(family))
 }
 
+// DeviceSampleCounts returns the texture sample counts the device supports as
+// a hal.TextureFormatCapabilities.SampleCounts bitmask, probed with
+// supportsTextureSampleCount: for 1, 2, 4 and 8 samples.
+func DeviceSampleCounts(device ID) uint32 {
+	if device == 0 {
+		return 0
+	}
+	var counts uint32
+	for _, n := range []uint32{1, 2, 4, 8} {
+		if MsgSendBool(device, Sel("supportsTextureSampleCount:"), uintptr(n)) {
+			counts |= n
+		}
+	}
+	return counts
+}
+
+// DeviceMaxVertexAmplificationCount returns the largest vertex amplification
+// count the device supports, probed with supportsVertexAmplificationCount:
+// for 2, 4 and 8 views, or 0 when it has no vertex amplification.
+func DeviceMaxVertexAmplificationCount(device ID) uint32 {
+	if device == 0 || !MsgSendBool(device, Sel("respondsToSelector:"), uintptr(Sel("supportsVertexAmplificationCount:"))) {
+		return 0
+	}
+	var maxCount uint32
+	for _, n := range []uint32{2, 4, 8} {
+		if !MsgSendBool(device, Sel("supportsVertexAmplificationCount:"), uintptr(n)) {
+			break
+		}
+		maxCount = n
+	}
+	return maxCount
+}
+
 // DeviceRegistryID returns the IORegistry ID of the device.
 func DeviceRegistryID(device ID) uint64 {
 	if device == 0 {
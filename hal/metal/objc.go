@@ -48,6 +48,15 @@ var (
 			types.DoubleTypeDescriptor,
 		},
 	}
+	cgRectType = &types.TypeDescriptor{
+		Kind: types.StructType,
+		Members: []*types.TypeDescriptor{
+			types.DoubleTypeDescriptor,
+			types.DoubleTypeDescriptor,
+			types.DoubleTypeDescriptor,
+			types.DoubleTypeDescriptor,
+		},
+	}
 	mtlClearColorType = &types.TypeDescriptor{
 		Kind: types.StructType,
 		Members: []*types.TypeDescriptor{
@@ -18,7 +18,8 @@ import (
 // the GPU. A value of 2 matches the Vulkan and DX12 backends and provides good
 // latency/throughput balance. When the CPU tries to submit a frame beyond this
 // limit, it blocks until the GPU finishes an earlier frame, preventing unbounded
-// resource growth and drawable pool exhaustion.
+// resource growth and drawable pool exhaustion. hal.MetalOptions can change it
+// per instance.
 const maxFramesInFlight = 2
 
 // Queue implements hal.Queue for Metal.
@@ -411,7 +412,7 @@ func (q *Queue) writeTextureShared(tex *Texture, dst *hal.ImageCopyTexture, data
 //
 // damageRects is accepted but ignored — Metal has no compositor damage API.
 func (q *Queue) Present(surface hal.Surface, texture hal.SurfaceTexture, _ []image.Rectangle) error {
-	hal.Logger().Debug("metal: Present")
+	q.device.logger(hal.LogSwapchain).Debug("metal: Present")
 	st, ok := texture.(*SurfaceTexture)
 	if !ok || st == nil {
 		return nil
@@ -444,9 +445,9 @@ func (q *Queue) Present(surface hal.Surface, texture hal.SurfaceTexture, _ []ima
 	if useTransaction {
 		_ = MsgSend(cmdBuffer, Sel("waitUntilScheduled"))
 		_ = MsgSend(st.drawable, Sel("present"))
-		hal.Logger().Debug("metal: presentDrawable (transaction) committed")
+		q.device.logger(hal.LogSwapchain).Debug("metal: presentDrawable (transaction) committed")
 	} else {
-		hal.Logger().Debug("metal: presentDrawable committed")
+		q.device.logger(hal.LogSwapchain).Debug("metal: presentDrawable committed")
 	}
 
 	// Present consumes the surface texture: release both retains taken in
@@ -6,6 +6,8 @@
 package metal
 
 import (
+	"fmt"
+	"sync"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -58,6 +60,10 @@ type Texture struct {
 	// (Apple Silicon UMA only). Shared textures support direct CPU writes via
 	// replaceRegion: and honour setPurgeableState(empty) for immediate OS reclaim.
 	isShared bool
+	// memoryless is true when this texture was created with
+	// MTLStorageModeMemoryless. It lives in tile memory for the duration of a
+	// render pass and can never be loaded or stored.
+	memoryless bool
 }
 
 // IsHostWritable reports whether this texture was allocated with Shared storage,
@@ -87,6 +93,11 @@ type TextureView struct {
 	device  *Device
 }
 
+// isMemoryless reports whether the view's texture uses memoryless storage.
+func (v *TextureView) isMemoryless() bool {
+	return v.texture != nil && v.texture.memoryless
+}
+
 // Destroy releases the texture view.
 func (v *TextureView) Destroy() {
 	if v.device != nil {
@@ -119,7 +130,35 @@ type ShaderModule struct {
 	library         ID // id<MTLLibrary>
 	device          *Device
 	workgroupSizes  map[string][3]uint32 // entry point name -> workgroup size
-	entrypointNames map[string]string
+	entryPointNames hal.EntryPointNames  // entry point name -> MSL function name
+
+	// mu guards prebuilt.
+	mu sync.Mutex
+	// prebuilt holds the compute pipeline states built from compilation
+	// hints, by entry point, until CreateComputePipeline takes them over.
+	prebuilt map[string]ID // entry point name -> id<MTLComputePipelineState>
+}
+
+// takePrebuilt removes and returns the compute pipeline state built from a
+// hint for entryPoint, or 0 when there is none.
+func (m *ShaderModule) takePrebuilt(entryPoint string) ID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := m.prebuilt[entryPoint]
+	delete(m.prebuilt, entryPoint)
+	return state
+}
+
+// newFunction returns the library function for entryPoint, resolving the
+// name naga gave it in MSL. The caller must Release the function.
+func (m *ShaderModule) newFunction(stage, entryPoint string) (ID, error) {
+	name := NSString(m.entryPointNames.Resolve(entryPoint))
+	fn := MsgSend(m.library, Sel("newFunctionWithName:"), uintptr(name))
+	Release(name)
+	if fn == 0 {
+		return 0, fmt.Errorf("metal: %s function %s not found", stage, m.entryPointNames.Describe(entryPoint))
+	}
+	return fn, nil
 }
 
 // Destroy releases the shader module.
@@ -203,6 +242,7 @@ type RenderPipeline struct {
 	layout        *PipelineLayout // for SetBindGroup slot offset lookup
 	cullMode      MTLCullMode
 	frontFace     MTLWinding
+	fillMode      MTLTriangleFillMode
 	icbCompatible bool
 
 	depthStencil    ID // id<MTLDepthStencilState>
@@ -7,7 +7,11 @@ package metal
 
 import (
 	"fmt"
+	"sync/atomic"
+	"time"
+	"unsafe"
 
+	"github.com/go-webgpu/goffi/types"
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal"
 )
@@ -20,10 +24,22 @@ type Surface struct {
 	width       uint32
 	height      uint32
 	presentMode hal.PresentMode
+	// scale is the last contents scale set from SurfaceConfiguration, 0 if
+	// none was given.
+	scale float64
 	// presentsWithTransaction enables Core Animation transaction-based present.
 	// Required for smooth live window resize on macOS (wgpu #3756, Flutter/Skia).
 	presentsWithTransaction bool
 	configured              bool
+
+	// maxDrawables bounds the CAMetalLayer drawable pool; 0 means the
+	// Metal default of 3.
+	maxDrawables uint32
+	// acquired counts drawables handed out by AcquireTexture and not yet
+	// presented or discarded.
+	acquired atomic.Int32
+	// displayLink paces frames when SetDisplayLink is active.
+	displayLink *displayLink
 }
 
 // Configure configures the surface for presentation.
@@ -45,11 +61,17 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 
 	// Skip no-op reconfigure (present mode / vsync updates still run below).
 	sizeChanged := s.configured && (s.width != config.Width || s.height != config.Height)
+	if config.ScaleFactor > 0 && config.ScaleFactor != s.scale {
+		// The drawable is pixel-sized; contentsScale maps it onto the layer's
+		// point-sized bounds. Left at 1 on Retina, Core Animation would treat
+		// a 2x drawable as twice the window size.
+		s.scale = config.ScaleFactor
+		msgSendVoid(s.layer, Sel("setContentsScale:"), argFloat64(config.ScaleFactor))
+	}
 	if s.configured && !sizeChanged &&
 		s.format == config.Format && s.device == mtlDevice {
 		s.presentMode = config.PresentMode
-		vsync := config.PresentMode == hal.PresentModeFifo
-		msgSendVoid(s.layer, Sel("setDisplaySyncEnabled:"), argBool(vsync))
+		s.setDisplaySync(config.PresentMode == hal.PresentModeFifo)
 		return nil
 	}
 
@@ -91,8 +113,9 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 
 	// Set maximum drawable count for frame latency control.
 	// Rust wgpu: set_maximum_drawable_count(maximum_frame_latency + 1).
-	// Default maximum_frame_latency=2 → drawable_count=3 (Metal default).
-	_ = MsgSend(s.layer, Sel("setMaximumDrawableCount:"), uintptr(3))
+	// Default maximum_frame_latency=2 → drawable_count=3 (Metal default);
+	// SetMaximumDrawableCount(2) trades throughput for a frame less latency.
+	_ = MsgSend(s.layer, Sel("setMaximumDrawableCount:"), uintptr(s.drawableCount()))
 
 	// Disable the 1-second timeout on nextDrawable (Rio/zed/ghostty pattern).
 	// With the timeout enabled, nextDrawable returns nil under drawable-pool
@@ -108,7 +131,7 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 	s.presentMode = config.PresentMode
 	// VSync is controlled by displaySyncEnabled (available since macOS 10.13)
 	vsync := config.PresentMode == hal.PresentModeFifo
-	msgSendVoid(s.layer, Sel("setDisplaySyncEnabled:"), argBool(vsync))
+	s.setDisplaySync(vsync)
 
 	// presentsWithTransaction default is false: normal rendering presents via
 	// [commandBuffer presentDrawable:] from the render goroutine, and enabling
@@ -144,7 +167,7 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 		Release(gravity)
 	}
 
-	hal.Logger().Info("metal: surface configured",
+	s.device.logger(hal.LogSwapchain).Info("metal: surface configured",
 		"width", config.Width,
 		"height", config.Height,
 		"format", config.Format,
@@ -156,9 +179,20 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 	return nil
 }
 
+// setDisplaySync sets CAMetalLayer.displaySyncEnabled. The property is
+// macOS-only; on iOS/tvOS presentation is always synchronized to the display,
+// so the call is skipped.
+func (s *Surface) setDisplaySync(enabled bool) {
+	sel := Sel("setDisplaySyncEnabled:")
+	if !MsgSendBool(s.layer, Sel("respondsToSelector:"), uintptr(sel)) {
+		return
+	}
+	msgSendVoid(s.layer, sel, argBool(enabled))
+}
+
 // Unconfigure removes surface configuration.
 func (s *Surface) Unconfigure(_ hal.Device) {
-	hal.Logger().Debug("metal: surface unconfigured")
+	s.device.logger(hal.LogSwapchain).Debug("metal: surface unconfigured")
 	// Nothing to release for Metal layer
 	s.device = nil
 }
@@ -178,7 +212,58 @@ func (s *Surface) SetPresentsWithTransaction(enabled bool) {
 	if s.layer != 0 {
 		msgSendVoid(s.layer, Sel("setPresentsWithTransaction:"), argBool(enabled))
 	}
-	hal.Logger().Debug("metal: presentsWithTransaction", "enabled", enabled)
+	s.device.logger(hal.LogSwapchain).Debug("metal: presentsWithTransaction", "enabled", enabled)
+}
+
+// SetMaximumDrawableCount bounds the CAMetalLayer drawable pool.
+// CAMetalLayer accepts 2 or 3; other values are clamped, and 0 restores the
+// default of 3. Takes effect immediately on a configured surface.
+func (s *Surface) SetMaximumDrawableCount(count uint32) {
+	s.maxDrawables = count
+	if s.layer != 0 && s.configured {
+		_ = MsgSend(s.layer, Sel("setMaximumDrawableCount:"), uintptr(s.drawableCount()))
+	}
+	s.device.logger(hal.LogSwapchain).Debug("metal: maximumDrawableCount", "count", s.drawableCount())
+}
+
+// drawableCount returns the drawable pool size to configure.
+func (s *Surface) drawableCount() uint32 {
+	switch {
+	case s.maxDrawables == 0:
+		return 3
+	case s.maxDrawables < 2:
+		return 2
+	case s.maxDrawables > 3:
+		return 3
+	}
+	return s.maxDrawables
+}
+
+// SetDisplayLink paces rendering with a CVDisplayLink: onFrame is called
+// on a CoreVideo thread once per display refresh with the time the next
+// frame will be shown, measured from the display link time base. Ticks
+// that arrive while a drawable is still acquired are dropped, so an
+// application that acquires, renders and presents from onFrame does not
+// block in nextDrawable, and the main thread never waits on drawables.
+//
+// Passing nil stops the display link. onFrame must not call
+// SetDisplayLink or Destroy, which wait for a running callback to finish.
+// Returns an error where CVDisplayLink is unavailable (iOS, tvOS).
+func (s *Surface) SetDisplayLink(onFrame func(outputTime time.Duration)) error {
+	if s.displayLink != nil {
+		s.displayLink.stop()
+		s.displayLink = nil
+	}
+	if onFrame == nil {
+		return nil
+	}
+	dl, err := startDisplayLink(s, onFrame)
+	if err != nil {
+		return err
+	}
+	s.displayLink = dl
+	s.device.logger(hal.LogSwapchain).Debug("metal: display link started", "id", dl.id)
+	return nil
 }
 
 // AcquireTexture acquires the next surface texture for rendering.
@@ -188,15 +273,17 @@ func (s *Surface) AcquireTexture(_ hal.Fence) (*hal.AcquiredSurfaceTexture, erro
 
 	drawable := MsgSend(s.layer, Sel("nextDrawable"))
 	if drawable == 0 {
-		hal.Logger().Error("metal: nextDrawable failed", "layer", s.layer)
+		s.device.logger(hal.LogSwapchain).Error("metal: nextDrawable failed", "layer", s.layer)
 		return nil, fmt.Errorf("metal: failed to get next drawable")
 	}
 	Retain(drawable)
+	s.acquired.Add(1)
 
 	texture := MsgSend(drawable, Sel("texture"))
 	if texture == 0 {
-		hal.Logger().Error("metal: drawable has no texture", "drawable", drawable)
+		s.device.logger(hal.LogSwapchain).Error("metal: drawable has no texture", "drawable", drawable)
 		Release(drawable)
+		s.acquired.Add(-1)
 		return nil, fmt.Errorf("metal: drawable has no texture")
 	}
 	Retain(texture)
@@ -215,7 +302,7 @@ func (s *Surface) AcquireTexture(_ hal.Fence) (*hal.AcquiredSurfaceTexture, erro
 		isExternal: true,
 	}
 
-	hal.Logger().Debug("metal: surface texture acquired",
+	s.device.logger(hal.LogSwapchain).Debug("metal: surface texture acquired",
 		"drawable", drawable,
 		"texture", texture,
 		"width", s.width,
@@ -226,6 +313,7 @@ func (s *Surface) AcquireTexture(_ hal.Fence) (*hal.AcquiredSurfaceTexture, erro
 		Texture: &SurfaceTexture{
 			texture:  mtlTexture,
 			drawable: drawable,
+			surface:  s,
 		},
 		Suboptimal: false,
 	}, nil
@@ -247,7 +335,11 @@ func (s *Surface) ActualExtent() (width, height uint32) {
 
 // Destroy releases the surface.
 func (s *Surface) Destroy() {
-	hal.Logger().Debug("metal: surface destroyed")
+	s.device.logger(hal.LogSwapchain).Debug("metal: surface destroyed")
+	if s.displayLink != nil {
+		s.displayLink.stop()
+		s.displayLink = nil
+	}
 	if s.layer != 0 {
 		Release(s.layer)
 		s.layer = 0
@@ -258,6 +350,7 @@ func (s *Surface) Destroy() {
 type SurfaceTexture struct {
 	texture  *Texture
 	drawable ID // id<CAMetalDrawable>
+	surface  *Surface
 }
 
 // CurrentUsage returns 0 — Metal has no explicit resource state tracking.
@@ -288,6 +381,10 @@ func (st *SurfaceTexture) releaseAcquired() {
 	if st.drawable != 0 {
 		Release(st.drawable)
 		st.drawable = 0
+		if st.surface != nil {
+			st.surface.acquired.Add(-1)
+			st.surface = nil
+		}
 	}
 }
 
@@ -305,6 +402,46 @@ func (st *SurfaceTexture) Drawable() ID {
 	return st.drawable
 }
 
+// metalLayerFromView returns a retained CAMetalLayer that renders into a
+// UIKit view. A view whose layerClass is CAMetalLayer is used directly;
+// otherwise a CAMetalLayer sublayer covering the view's bounds is attached,
+// matching wgpu-hal's Surface::from_view.
+func metalLayerFromView(view ID) (ID, error) {
+	pool := NewAutoreleasePool()
+	defer pool.Drain()
+
+	mainLayer := MsgSend(view, Sel("layer"))
+	if mainLayer == 0 {
+		return 0, fmt.Errorf("metal: view has no backing layer")
+	}
+	metalLayerClass := GetClass("CAMetalLayer")
+	if metalLayerClass == 0 {
+		return 0, fmt.Errorf("metal: CAMetalLayer class not found")
+	}
+	if MsgSendBool(mainLayer, Sel("isKindOfClass:"), uintptr(metalLayerClass)) {
+		return Retain(mainLayer), nil
+	}
+
+	layer := MsgSend(ID(metalLayerClass), Sel("new"))
+	if layer == 0 {
+		return 0, fmt.Errorf("metal: failed to create CAMetalLayer")
+	}
+	var bounds CGRect
+	if err := msgSend(mainLayer, Sel("bounds"), cgRectType, unsafe.Pointer(&bounds)); err != nil {
+		Release(layer)
+		return 0, fmt.Errorf("metal: view layer bounds: %w", err)
+	}
+	msgSendVoid(layer, Sel("setFrame:"), argStruct(bounds, cgRectType))
+	if MsgSendBool(view, Sel("respondsToSelector:"), uintptr(Sel("contentScaleFactor"))) {
+		var scale float64
+		if err := msgSend(view, Sel("contentScaleFactor"), types.DoubleTypeDescriptor, unsafe.Pointer(&scale)); err == nil && scale > 0 {
+			msgSendVoid(layer, Sel("setContentsScale:"), argFloat64(scale))
+		}
+	}
+	_ = MsgSend(mainLayer, Sel("addSublayer:"), uintptr(layer))
+	return layer, nil
+}
+
 // msgSendCGSize sends an Objective-C message with a CGSize argument.
 func msgSendCGSize(obj ID, sel SEL, size CGSize) {
 	if obj == 0 {
@@ -92,3 +92,15 @@ func TestSurfaceTextureCreateView(t *testing.T) {
 
 	surface.DiscardTexture(acquired.Texture)
 }
+
+func TestSurfaceDrawableCount(t *testing.T) {
+	for _, tc := range []struct{ set, want uint32 }{
+		{0, 3}, {1, 2}, {2, 2}, {3, 3}, {8, 3},
+	} {
+		s := &Surface{}
+		s.SetMaximumDrawableCount(tc.set)
+		if got := s.drawableCount(); got != tc.want {
+			t.Errorf("SetMaximumDrawableCount(%d): drawableCount = %d, want %d", tc.set, got, tc.want)
+		}
+	}
+}
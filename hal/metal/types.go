@@ -64,6 +64,17 @@ type CGSize struct {
 	Width, Height CGFloat
 }
 
+// CGPoint represents a 2D point in Core Graphics.
+type CGPoint struct {
+	X, Y CGFloat
+}
+
+// CGRect represents a rectangle in Core Graphics.
+type CGRect struct {
+	Origin CGPoint
+	Size   CGSize
+}
+
 // MTLClearColor represents an RGBA clear color.
 type MTLClearColor struct {
 	Red, Green, Blue, Alpha float64
@@ -82,6 +93,13 @@ type MTLScissorRect struct {
 	Width, Height NSUInteger
 }
 
+// MTLVertexAmplificationViewMapping maps one amplified view to the viewport
+// and render target array slice it renders into.
+type MTLVertexAmplificationViewMapping struct {
+	ViewportArrayIndexOffset     uint32
+	RenderTargetArrayIndexOffset uint32
+}
+
 // MTLIndirectCommandType values accepted by MTLIndirectCommandBufferDescriptor.
 type MTLIndirectCommandType NSUInteger
 
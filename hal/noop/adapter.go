@@ -29,6 +29,7 @@ func (a *Adapter) TextureFormatCapabilities(_ gputypes.TextureFormat) hal.Textur
 			hal.TextureFormatCapabilityBlendable |
 			hal.TextureFormatCapabilityMultisample |
 			hal.TextureFormatCapabilityMultisampleResolve,
+		SampleCounts: hal.SampleCountsUpTo(hal.SampleCount16),
 	}
 }
 
@@ -337,7 +337,7 @@ func BenchmarkNoopFullFrame(b *testing.B) {
 
 		rp := encoder.BeginRenderPass(rpDesc)
 		rp.SetPipeline(pipeline)
-		rp.SetVertexBuffer(0, buffer, 0)
+		rp.SetVertexBuffer(0, buffer, 0, 0)
 		rp.Draw(3, 1, 0, 0)
 		rp.End()
 
@@ -78,10 +78,10 @@ func (r *RenderPassEncoder) SetPipeline(_ hal.RenderPipeline) {}
 func (r *RenderPassEncoder) SetBindGroup(_ uint32, _ hal.BindGroup, _ []uint32) {}
 
 // SetVertexBuffer is a no-op.
-func (r *RenderPassEncoder) SetVertexBuffer(_ uint32, _ hal.Buffer, _ uint64) {}
+func (r *RenderPassEncoder) SetVertexBuffer(_ uint32, _ hal.Buffer, _, _ uint64) {}
 
 // SetIndexBuffer is a no-op.
-func (r *RenderPassEncoder) SetIndexBuffer(_ hal.Buffer, _ gputypes.IndexFormat, _ uint64) {}
+func (r *RenderPassEncoder) SetIndexBuffer(_ hal.Buffer, _ gputypes.IndexFormat, _, _ uint64) {}
 
 // SetViewport is a no-op.
 func (r *RenderPassEncoder) SetViewport(_, _, _, _, _, _ float32) {}
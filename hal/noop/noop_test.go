@@ -1196,7 +1196,7 @@ func TestNoopFullLifecycle(t *testing.T) {
 		},
 	})
 	renderPass.SetPipeline(pipeline)
-	renderPass.SetVertexBuffer(0, buffer, 0)
+	renderPass.SetVertexBuffer(0, buffer, 0, 0)
 	renderPass.Draw(3, 1, 0, 0)
 	renderPass.End()
 
@@ -1558,8 +1558,8 @@ func TestNoopRenderPassEncoder(t *testing.T) {
 	pass.SetPipeline(nil)
 	pass.SetBindGroup(0, nil, nil)
 	pass.SetBindGroup(1, nil, []uint32{0, 256})
-	pass.SetVertexBuffer(0, buf, 0)
-	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 0)
+	pass.SetVertexBuffer(0, buf, 0, 0)
+	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 0, 0)
 	pass.SetViewport(0, 0, 800, 600, 0, 1)
 	pass.SetScissorRect(0, 0, 800, 600)
 	pass.SetBlendConstant(&gputypes.Color{R: 1, G: 0, B: 0, A: 1})
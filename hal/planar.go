@@ -0,0 +1,76 @@
+//go:build !(js && wasm)
+
+package hal
+
+import "github.com/gogpu/gputypes"
+
+// Multi-planar video formats. Hardware video decoders (VA-API, Media
+// Foundation, VideoToolbox) write frames as a full-resolution luma plane
+// followed by a half-resolution plane of interleaved chroma. These formats
+// let such frames be created or imported and sampled plane by plane, without
+// a conversion copy. They are native extensions outside the gputypes
+// enumeration, numbered like wgpu-native's native formats.
+const (
+	// TextureFormatNV12 is 8-bit 4:2:0 YCbCr: an R8Unorm luma plane and an
+	// RG8Unorm plane of interleaved Cb and Cr at half width and height.
+	// Requires FeatureTextureFormatNV12.
+	TextureFormatNV12 gputypes.TextureFormat = 0x00030007
+
+	// TextureFormatP010 is 10-bit 4:2:0 YCbCr stored in the high bits of
+	// 16-bit samples: an R16Unorm luma plane and an RG16Unorm chroma plane
+	// at half width and height. Requires FeatureTextureFormatP010.
+	TextureFormatP010 gputypes.TextureFormat = 0x00030008
+)
+
+// Plane aspects select one plane of a multi-planar texture in a texture
+// view. Views of multi-planar textures must select a plane; the view's
+// format is the plane's format (see PlaneFormat).
+const (
+	// TextureAspectPlane0 selects the luma plane.
+	TextureAspectPlane0 gputypes.TextureAspect = 0x00030001
+
+	// TextureAspectPlane1 selects the chroma plane.
+	TextureAspectPlane1 gputypes.TextureAspect = 0x00030002
+)
+
+// IsMultiPlanarFormat reports whether format is a multi-planar video format.
+func IsMultiPlanarFormat(format gputypes.TextureFormat) bool {
+	return format == TextureFormatNV12 || format == TextureFormatP010
+}
+
+// PlaneIndex returns the index of the plane aspect selects, or false when
+// aspect is not a plane aspect.
+func PlaneIndex(aspect gputypes.TextureAspect) (uint32, bool) {
+	switch aspect {
+	case TextureAspectPlane0:
+		return 0, true
+	case TextureAspectPlane1:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+// PlaneFormat returns the format of the plane of a multi-planar format that
+// aspect selects, or false when format is not multi-planar or aspect is not
+// a plane aspect.
+func PlaneFormat(format gputypes.TextureFormat, aspect gputypes.TextureAspect) (gputypes.TextureFormat, bool) {
+	plane, ok := PlaneIndex(aspect)
+	if !ok {
+		return gputypes.TextureFormatUndefined, false
+	}
+	switch format {
+	case TextureFormatNV12:
+		if plane == 0 {
+			return gputypes.TextureFormatR8Unorm, true
+		}
+		return gputypes.TextureFormatRG8Unorm, true
+	case TextureFormatP010:
+		if plane == 0 {
+			return gputypes.TextureFormatR16Unorm, true
+		}
+		return gputypes.TextureFormatRG16Unorm, true
+	default:
+		return gputypes.TextureFormatUndefined, false
+	}
+}
@@ -8,13 +8,15 @@ import (
 )
 
 // Adapter implements hal.Adapter for the software backend.
-type Adapter struct{}
+type Adapter struct {
+	options hal.SoftwareOptions
+}
 
 // Open creates a software device with the requested features and limits.
 // Always succeeds and returns a device/queue pair.
 func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
 	return hal.OpenDevice{
-		Device: &Device{},
+		Device: &Device{deterministic: a.options.Deterministic},
 		Queue:  &Queue{},
 	}, nil
 }
@@ -27,6 +29,7 @@ func (a *Adapter) TextureFormatCapabilities(_ gputypes.TextureFormat) hal.Textur
 			hal.TextureFormatCapabilityStorageReadWrite |
 			hal.TextureFormatCapabilityRenderAttachment |
 			hal.TextureFormatCapabilityBlendable,
+		SampleCounts: hal.SampleCount1,
 	}
 }
 
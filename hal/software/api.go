@@ -26,12 +26,18 @@ func (API) Variant() gputypes.Backend {
 
 // CreateInstance creates a new software rendering instance.
 // Always succeeds and returns a CPU-based rendering instance.
-func (API) CreateInstance(_ *hal.InstanceDescriptor) (hal.Instance, error) {
-	return &Instance{}, nil
+func (API) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error) {
+	inst := &Instance{}
+	if desc != nil {
+		inst.options = desc.BackendOptions.Software
+	}
+	return inst, nil
 }
 
 // Instance implements hal.Instance for the software backend.
-type Instance struct{}
+type Instance struct {
+	options hal.SoftwareOptions
+}
 
 // CreateSurface creates a software rendering surface.
 // If a valid window handle is provided, Present() will automatically blit
@@ -73,7 +79,7 @@ func supportsSurfaceTarget(goos string, kind hal.SurfaceTargetKind) bool {
 func (i *Instance) EnumerateAdapters(_ hal.Surface) []hal.ExposedAdapter {
 	return []hal.ExposedAdapter{
 		{
-			Adapter: &Adapter{},
+			Adapter: &Adapter{options: i.options},
 			Info: gputypes.AdapterInfo{
 				Name:       "Software Renderer",
 				Vendor:     "GoGPU",
@@ -39,6 +39,10 @@ type platformBlit struct {
 	mtlDevice     mtlDevice
 	mtlQueue      mtlCommandQueue
 	isInitialized bool
+
+	// contentsScale is the layer contents scale last set from the surface
+	// configuration.
+	contentsScale float64
 }
 
 func (p *platformBlit) init() (err error) {
@@ -115,6 +119,7 @@ func (s *Surface) blitFramebufferToWindow(data []byte, width, height int32) {
 
 	objc, cg, colorSpace, mtl := s.objc, s.cg, s.colorSpace, s.mtl
 	l := caLayer{objcAnyOpaqueFromPointer(unsafe.Pointer(s.hwnd))}
+	s.syncContentsScale(l)
 
 	// true when s.hwnd is CAMetalLayer
 	isMetalNeeded := false
@@ -151,6 +156,7 @@ func (s *Surface) blitDamageRectsToWindow(src []byte, w, h int32, rects []image.
 
 	objc, cg, colorSpace, mtl := s.objc, s.cg, s.colorSpace, s.mtl
 	l := caLayer{objcAnyOpaqueFromPointer(unsafe.Pointer(s.hwnd))}
+	s.syncContentsScale(l)
 
 	isMetalNeeded := false
 	if mtl != nil {
@@ -171,6 +177,24 @@ func (s *Surface) blitDamageRectsToWindow(src []byte, w, h int32, rects []image.
 	}
 }
 
+// syncContentsScale sets the layer's contents scale to the configured scale
+// factor. The framebuffer is pixel-sized; with contentsScale left at 1 on a
+// Retina display the layer would map it onto twice its point size and show
+// only the top-left quarter.
+func (s *Surface) syncContentsScale(l caLayer) {
+	s.mu.RLock()
+	scale := s.scale
+	s.mu.RUnlock()
+	if scale <= 0 || scale == s.contentsScale {
+		return
+	}
+	if err := l.SetContentsScale(s.objc, scale); err != nil {
+		slog.Debug("software: failed to [caLayer setContentsScale:scale]", slog.Any("error", err))
+		return
+	}
+	s.contentsScale = scale
+}
+
 func createCGImageByFramebuffer(cg *coreGraphics, colorSpace cgColorSpace, data []byte, width, height int32) (img cgImage, release func()) {
 	dataProvider, err := cg.DataProviderCreateWithData(objcAnyOpaqueNil, objcAnyOpaqueFromPointer(unsafe.Pointer(unsafe.SliceData(data))), uintptr(len(data)), objcAnyOpaqueNil)
 	if err != nil {
@@ -693,6 +717,17 @@ func (c *caLayer) SetNeedsDisplay(objc *objcReflect) error {
 	return objc.MsgSend(c.objcAnyOpaque, sel.objcAnyOpaque, &ret)
 }
 
+// ref: https://developer.apple.com/documentation/quartzcore/calayer/contentsscale?language=objc
+func (c *caLayer) SetContentsScale(objc *objcReflect, scale float64) error {
+	sel, err := objc.SelRegisterName("setContentsScale:")
+	if err != nil {
+		return err
+	}
+
+	ret := objcAnyOpaqueNil
+	return objc.MsgSend(c.objcAnyOpaque, sel.objcAnyOpaque, &ret, objcBoxedWith(scale, types.DoubleTypeDescriptor))
+}
+
 func (c *caLayer) SetContents(objc *objcReflect, obj objcAnyOpaque) error {
 	sel, err := objc.SelRegisterName("setContents:")
 	if err != nil {
@@ -4,6 +4,7 @@ package software
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"image"
 	"log/slog"
@@ -150,12 +151,13 @@ func (c *CommandEncoder) CopyTextureToBuffer(src hal.Texture, dst hal.Buffer, re
 	}
 }
 
-// CopyTextureToTexture copies data between textures.
+// CopyTextureToTexture copies data between textures. Either may be a surface
+// texture, whose data is the surface framebuffer.
 func (c *CommandEncoder) CopyTextureToTexture(src, dst hal.Texture, regions []hal.TextureCopy) {
-	srcTex, srcOK := src.(*Texture)
-	dstTex, dstOK := dst.(*Texture)
+	srcTex := resolveTexture(src)
+	dstTex := resolveTexture(dst)
 
-	if !srcOK || !dstOK {
+	if srcTex == nil || dstTex == nil {
 		return
 	}
 
@@ -184,7 +186,8 @@ func (c *CommandEncoder) ResolveQuerySet(_ hal.QuerySet, _, _ uint32, _ hal.Buff
 // is the attachment texture, not recreated per draw call).
 func (c *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.RenderPassEncoder {
 	r := &RenderPassEncoder{
-		desc: desc,
+		desc:          desc,
+		deterministic: c.device != nil && c.device.deterministic,
 	}
 
 	if hal.Logger().Enabled(context.Background(), slog.LevelDebug) {
@@ -230,10 +233,23 @@ func (c *CommandEncoder) BeginComputePass(desc *hal.ComputePassDescriptor) hal.C
 	}
 }
 
-// vertexBufferBinding holds a vertex buffer and its byte offset.
+// vertexBufferBinding holds a vertex buffer and its bound byte range.
 type vertexBufferBinding struct {
 	buffer *Buffer
 	offset uint64
+	size   uint64
+}
+
+// data returns the buffer contents cut off at the end of the bound range,
+// so vertex fetches past the binding read nothing.
+func (b *vertexBufferBinding) data() []byte {
+	b.buffer.mu.RLock()
+	data := b.buffer.data
+	b.buffer.mu.RUnlock()
+	if end := b.offset + b.size; end < uint64(len(data)) {
+		data = data[:end]
+	}
+	return data
 }
 
 // RenderPassEncoder implements hal.RenderPassEncoder for the software backend.
@@ -242,6 +258,9 @@ type vertexBufferBinding struct {
 type RenderPassEncoder struct {
 	desc *hal.RenderPassDescriptor
 
+	// deterministic selects fixed-point rasterization for every draw.
+	deterministic bool
+
 	// Pipeline and resource state set during encoding.
 	pipeline    *RenderPipeline
 	bindGroups  [4]*BindGroup          // max 4 per WebGPU spec
@@ -249,6 +268,7 @@ type RenderPassEncoder struct {
 	indexBuffer *Buffer
 	indexFormat gputypes.IndexFormat
 	indexOffset uint64
+	indexSize   uint64
 
 	// activeIndices, when non-nil, remaps the sequential draw position to a
 	// vertex index for the current DrawIndexed call (already including
@@ -373,20 +393,21 @@ func (r *RenderPassEncoder) SetBindGroup(index uint32, bg hal.BindGroup, dynamic
 }
 
 // SetVertexBuffer stores a vertex buffer binding at the given slot.
-func (r *RenderPassEncoder) SetVertexBuffer(slot uint32, buf hal.Buffer, offset uint64) {
+func (r *RenderPassEncoder) SetVertexBuffer(slot uint32, buf hal.Buffer, offset, size uint64) {
 	if slot < 8 {
 		if b, ok := buf.(*Buffer); ok {
-			r.vertexBufs[slot] = vertexBufferBinding{buffer: b, offset: offset}
+			r.vertexBufs[slot] = vertexBufferBinding{buffer: b, offset: offset, size: size}
 		}
 	}
 }
 
 // SetIndexBuffer stores the index buffer for indexed draw calls.
-func (r *RenderPassEncoder) SetIndexBuffer(buf hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+func (r *RenderPassEncoder) SetIndexBuffer(buf hal.Buffer, format gputypes.IndexFormat, offset, size uint64) {
 	if b, ok := buf.(*Buffer); ok {
 		r.indexBuffer = b
 		r.indexFormat = format
 		r.indexOffset = offset
+		r.indexSize = size
 	}
 }
 
@@ -449,8 +470,8 @@ func (r *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex ui
 
 // resolveIndices reads indexCount entries from the bound index buffer starting
 // at firstIndex, honoring the index format (Uint16/Uint32) and the buffer
-// offset set by SetIndexBuffer, and adds baseVertex to each. Returns nil if the
-// requested range lies outside the buffer.
+// range set by SetIndexBuffer, and adds baseVertex to each. Returns nil if the
+// requested range lies outside the bound range.
 func (r *RenderPassEncoder) resolveIndices(indexCount, firstIndex uint32, baseVertex int32) []uint32 {
 	indexSize := uint64(2)
 	if r.indexFormat == gputypes.IndexFormatUint32 {
@@ -463,7 +484,7 @@ func (r *RenderPassEncoder) resolveIndices(indexCount, firstIndex uint32, baseVe
 
 	start := r.indexOffset + uint64(firstIndex)*indexSize
 	end := start + uint64(indexCount)*indexSize
-	if end > uint64(len(data)) {
+	if end > uint64(len(data)) || end > r.indexOffset+r.indexSize {
 		return nil
 	}
 
@@ -494,11 +515,50 @@ func (r *RenderPassEncoder) drawVertexIndex(firstVertex, pos uint32) uint32 {
 	return firstVertex + pos
 }
 
-// DrawIndirect is a no-op.
-func (r *RenderPassEncoder) DrawIndirect(_ hal.Buffer, _ uint64, _ uint32) {}
+// DrawIndirect reads drawCount 16-byte argument records from buffer and runs
+// Draw for each. Commands execute as they are recorded, so records written by
+// an earlier compute pass are already in place.
+func (r *RenderPassEncoder) DrawIndirect(buffer hal.Buffer, offset uint64, drawCount uint32) {
+	for i := uint64(0); i < uint64(drawCount); i++ {
+		args, ok := readIndirectArgs(buffer, offset+i*16, 4)
+		if !ok {
+			return
+		}
+		r.Draw(args[0], args[1], args[2], args[3])
+	}
+}
 
-// DrawIndexedIndirect is a no-op.
-func (r *RenderPassEncoder) DrawIndexedIndirect(_ hal.Buffer, _ uint64, _ uint32) {}
+// DrawIndexedIndirect reads drawCount 20-byte argument records from buffer and
+// runs DrawIndexed for each.
+func (r *RenderPassEncoder) DrawIndexedIndirect(buffer hal.Buffer, offset uint64, drawCount uint32) {
+	for i := uint64(0); i < uint64(drawCount); i++ {
+		args, ok := readIndirectArgs(buffer, offset+i*20, 5)
+		if !ok {
+			return
+		}
+		r.DrawIndexed(args[0], args[1], args[2], int32(args[3]), args[4])
+	}
+}
+
+// readIndirectArgs reads words little-endian uint32 values from buffer at
+// offset. ok is false when buffer is not a software buffer or the values lie
+// outside it.
+func readIndirectArgs(buffer hal.Buffer, offset uint64, words int) (args []uint32, ok bool) {
+	buf, isBuf := buffer.(*Buffer)
+	if !isBuf || buf == nil {
+		return nil, false
+	}
+	buf.mu.RLock()
+	defer buf.mu.RUnlock()
+	if offset > uint64(len(buf.data)) || uint64(len(buf.data))-offset < uint64(words)*4 {
+		return nil, false
+	}
+	args = make([]uint32, words)
+	for i := range args {
+		args[i] = binary.LittleEndian.Uint32(buf.data[offset+uint64(i)*4:])
+	}
+	return args, true
+}
 
 // ExecuteBundle is a no-op.
 func (r *RenderPassEncoder) ExecuteBundle(_ hal.RenderBundle) {}
@@ -584,25 +644,43 @@ func (c *ComputePassEncoder) Dispatch(x, y, z uint32) {
 
 	// Build the execution context with buffer bindings from all bind groups.
 	ctx := &shader.ExecutionContext{
-		Buffers: make(map[shader.BindingKey][]byte),
+		Buffers:  make(map[shader.BindingKey][]byte),
+		Textures: make(map[shader.BindingKey]*shader.Texture2D),
 	}
 
+	// Textures are shared with storage texture writes, so each one is
+	// write-locked for the whole dispatch.
+	locked := make(map[*Texture]struct{})
+	defer func() {
+		for tex := range locked {
+			tex.mu.Unlock()
+		}
+	}()
+
 	for groupIdx, bg := range c.bindGroups {
 		if bg == nil {
 			continue
 		}
-		dynIdx := 0
+		for bindingIdx, tv := range bg.textureViews {
+			if tv == nil || tv.texture == nil {
+				continue
+			}
+			if _, ok := locked[tv.texture]; !ok {
+				tv.texture.mu.Lock()
+				locked[tv.texture] = struct{}{}
+			}
+			ctx.Textures[shader.BindingKey{
+				Group:   uint32(groupIdx),
+				Binding: bindingIdx,
+			}] = textureViewToShader(tv)
+		}
 		for bindingIdx, bs := range bg.bufferBindings {
 			if bs.buf == nil {
 				continue
 			}
 			bs.buf.mu.Lock()
 			data := bs.buf.data
-			off := bs.offset
-			if dynIdx < len(bg.dynamicOffsets) {
-				off += uint64(bg.dynamicOffsets[dynIdx])
-				dynIdx++
-			}
+			off := bg.bufferOffset(bindingIdx)
 			end := uint64(len(data))
 			if bs.size > 0 && off+bs.size <= end {
 				end = off + bs.size
@@ -628,9 +706,13 @@ func (c *ComputePassEncoder) Dispatch(x, y, z uint32) {
 	}
 }
 
-// DispatchIndirect is not yet implemented in the software backend.
-// Indirect dispatch requires reading the dispatch parameters from a GPU buffer,
-// which is straightforward but not needed for the current use cases.
-func (c *ComputePassEncoder) DispatchIndirect(_ hal.Buffer, _ uint64) {
-	slog.Warn("software: DispatchIndirect not implemented")
+// DispatchIndirect reads the x, y and z workgroup counts from buffer at
+// offset and dispatches them.
+func (c *ComputePassEncoder) DispatchIndirect(buffer hal.Buffer, offset uint64) {
+	args, ok := readIndirectArgs(buffer, offset, 3)
+	if !ok {
+		slog.Warn("software: DispatchIndirect: arguments out of range")
+		return
+	}
+	c.Dispatch(args[0], args[1], args[2])
 }
@@ -330,6 +330,87 @@ func TestSoftwareComputeMultipleWorkgroups(t *testing.T) {
 	}
 }
 
+// TestSoftwareComputeDynamicOffset verifies that a dynamic offset applies to
+// the binding the layout marks as dynamic and to no other.
+func TestSoftwareComputeDynamicOffset(t *testing.T) {
+	dev, _, cleanup := createSoftwareDevice(t)
+	defer cleanup()
+
+	sm, err := dev.CreateShaderModule(&hal.ShaderModuleDescriptor{
+		Label:  "scaled-copy",
+		Source: hal.ShaderSource{SPIRV: buildScaledCopySPIRV()},
+	})
+	if err != nil {
+		t.Fatalf("CreateShaderModule failed: %v", err)
+	}
+	defer dev.DestroyShaderModule(sm)
+
+	pipeline, err := dev.CreateComputePipeline(&hal.ComputePipelineDescriptor{
+		Compute: hal.ComputeState{Module: sm, EntryPoint: "main"},
+	})
+	if err != nil {
+		t.Fatalf("CreateComputePipeline failed: %v", err)
+	}
+	defer dev.DestroyComputePipeline(pipeline)
+
+	layout, err := dev.CreateBindGroupLayout(&hal.BindGroupLayoutDescriptor{
+		Entries: []gputypes.BindGroupLayoutEntry{
+			{Binding: 0, Buffer: &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeReadOnlyStorage}},
+			{Binding: 1, Buffer: &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage, HasDynamicOffset: true}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroupLayout failed: %v", err)
+	}
+	defer dev.DestroyBindGroupLayout(layout)
+
+	// The output buffer holds two 64-element halves; the dynamic offset
+	// selects the second.
+	const numElements = 64
+	const bufSize = numElements * 4
+	inputBuf, _ := dev.CreateBuffer(&hal.BufferDescriptor{Size: bufSize, Usage: gputypes.BufferUsageStorage})
+	outputBuf, _ := dev.CreateBuffer(&hal.BufferDescriptor{Size: 2 * bufSize, Usage: gputypes.BufferUsageStorage})
+	defer dev.DestroyBuffer(inputBuf)
+	defer dev.DestroyBuffer(outputBuf)
+
+	inputData := make([]byte, bufSize)
+	for i := uint32(0); i < numElements; i++ {
+		binary.LittleEndian.PutUint32(inputData[i*4:], i+1)
+	}
+	inputBuf.(*Buffer).WriteData(0, inputData)
+
+	bg, err := dev.CreateBindGroup(&hal.BindGroupDescriptor{
+		Layout: layout,
+		Entries: []gputypes.BindGroupEntry{
+			{Binding: 0, Resource: gputypes.BufferBinding{Buffer: inputBuf.NativeHandle(), Size: bufSize}},
+			{Binding: 1, Resource: gputypes.BufferBinding{Buffer: outputBuf.NativeHandle(), Size: bufSize}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroup failed: %v", err)
+	}
+	defer dev.DestroyBindGroup(bg)
+
+	enc, _ := dev.CreateCommandEncoder(&hal.CommandEncoderDescriptor{})
+	pass := enc.BeginComputePass(&hal.ComputePassDescriptor{})
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, bg, []uint32{bufSize})
+	pass.Dispatch(1, 1, 1)
+	pass.End()
+
+	outData := outputBuf.(*Buffer).GetData()
+	for i := uint32(0); i < 2*numElements; i++ {
+		got := binary.LittleEndian.Uint32(outData[i*4:])
+		var want uint32
+		if i >= numElements {
+			want = (i - numElements + 1) * 3
+		}
+		if got != want {
+			t.Errorf("output[%d] = %d, want %d", i, got, want)
+		}
+	}
+}
+
 // TestSoftwareComputePipelineCreationErrors tests error paths for compute
 // pipeline creation.
 func TestSoftwareComputePipelineCreationErrors(t *testing.T) {
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 	"unsafe"
@@ -28,6 +29,10 @@ type Device struct {
 	textureViews map[uintptr]*TextureView     // handle -> TextureView
 	buffers      map[uintptr]*Buffer          // handle -> Buffer
 	samplers     map[uintptr]*SamplerResource // handle -> SamplerResource
+
+	// deterministic selects fixed-point rasterization
+	// (hal.SoftwareOptions.Deterministic).
+	deterministic bool
 }
 
 // CreateBuffer creates a software buffer with real data storage.
@@ -102,13 +107,18 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 func (d *Device) DestroyTexture(_ hal.Texture) {}
 
 // CreateTextureView creates a software texture view.
-func (d *Device) CreateTextureView(texture hal.Texture, _ *hal.TextureViewDescriptor) (hal.TextureView, error) {
-	// Views in software backend just reference the original texture
+func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDescriptor) (hal.TextureView, error) {
+	// Views in software backend reference the original texture's data.
 	if tex, ok := texture.(*Texture); ok {
 		view := &TextureView{
 			id:      nextResourceID.Add(1),
 			texture: tex,
 		}
+		if desc != nil {
+			view.dimension = desc.Dimension
+			view.baseLayer = desc.BaseArrayLayer
+			view.layerCount = desc.ArrayLayerCount
+		}
 		d.registerTextureView(view)
 		return view, nil
 	}
@@ -143,8 +153,17 @@ func (d *Device) CreateSampler(desc *hal.SamplerDescriptor) (hal.Sampler, error)
 func (d *Device) DestroySampler(_ hal.Sampler) {}
 
 // CreateBindGroupLayout creates a software bind group layout.
-func (d *Device) CreateBindGroupLayout(_ *hal.BindGroupLayoutDescriptor) (hal.BindGroupLayout, error) {
-	return &Resource{}, nil
+func (d *Device) CreateBindGroupLayout(desc *hal.BindGroupLayoutDescriptor) (hal.BindGroupLayout, error) {
+	layout := &BindGroupLayout{}
+	if desc != nil {
+		for _, entry := range desc.Entries {
+			if entry.Buffer != nil && entry.Buffer.HasDynamicOffset {
+				layout.dynamicBindings = append(layout.dynamicBindings, entry.Binding)
+			}
+		}
+		slices.Sort(layout.dynamicBindings)
+	}
+	return layout, nil
 }
 
 // DestroyBindGroupLayout is a no-op.
@@ -161,6 +180,9 @@ func (d *Device) CreateBindGroup(desc *hal.BindGroupDescriptor) (hal.BindGroup,
 		samplers:       make(map[uint32]*SamplerResource),
 	}
 	if desc != nil {
+		if layout, ok := desc.Layout.(*BindGroupLayout); ok {
+			bg.dynamicBindings = layout.dynamicBindings
+		}
 		for _, entry := range desc.Entries {
 			switch res := entry.Resource.(type) {
 			case gputypes.TextureViewBinding:
@@ -17,6 +17,7 @@
 //   - Real data storage for buffers and textures
 //   - SPIR-V interpreter (~10K LOC): vertex, fragment, compute shaders on CPU
 //   - Compute shaders: CreateComputePipeline + Dispatch via SPIR-V interpreter
+//   - Indirect draws and dispatches
 //   - Texture sampling (nearest, bilinear, 3 wrap modes)
 //   - GLSL.std.450 math intrinsics (30+ functions)
 //   - Control flow (loops, phi, function calls, switch)
@@ -26,11 +27,11 @@
 //   - Clear operations
 //   - Windowed presentation (Windows GDI, Linux X11, macOS CG+Metal)
 //   - Thread-safe resource access
+//   - Deterministic fixed-point rasterization (hal.SoftwareOptions.Deterministic)
 //
 // Limitations:
 //   - Much slower than GPU backends (CPU-bound, interpreter, not JIT)
 //   - No hardware acceleration
-//   - DispatchIndirect not implemented
 //
 // Always compiled (no build tags required).
 //
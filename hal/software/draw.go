@@ -173,7 +173,7 @@ func (r *RenderPassEncoder) executeFullscreenBlit(target *Texture) bool {
 		return false
 	}
 	srcView := r.findBoundTexture()
-	if srcView == nil || srcView.texture == nil {
+	if srcView == nil || srcView.texture == nil || !srcView.isSingle2D() {
 		return false
 	}
 
@@ -359,8 +359,8 @@ func (r *RenderPassEncoder) executeVertexDraw(target *Texture, vertexCount, inst
 	// and per-vertex output attributes for interpolation).
 	triangles := r.fetchTrianglesSPIRV(layouts, vertexCount, instanceCount, firstVertex, firstInstance, w, h)
 	if triangles == nil {
-		// Fallback: raw vertex buffer fetch (non-SPIR-V path, single instance only).
-		triangles = r.fetchTriangles(layouts, vertexCount, firstVertex, w, h)
+		// Fallback: raw vertex buffer fetch (non-SPIR-V path).
+		triangles = r.fetchTriangles(layouts, vertexCount, instanceCount, firstVertex, firstInstance, w, h)
 	}
 
 	// Tier 1 fast-path: fullscreen textured quad → direct memcpy (#241).
@@ -396,89 +396,124 @@ func (r *RenderPassEncoder) executeVertexDraw(target *Texture, vertexCount, inst
 }
 
 // fetchTriangles reads vertex data from bound buffers, applies viewport transform,
-// and groups vertices into triangles (TriangleList topology).
-// This is the legacy non-SPIR-V path for single-instance draws without a shader module.
+// and groups vertices into triangles, one primitive list per instance.
+// This is the legacy non-SPIR-V path for pipelines without a shader module:
+// location 0 is the NDC position and the other attributes, in buffer and
+// declaration order, are interpolated. Instance-step buffers are indexed by
+// the instance ID, so every instance draws the same geometry with its own
+// per-instance attributes.
 func (r *RenderPassEncoder) fetchTriangles(
 	layouts []gputypes.VertexBufferLayout,
-	vertexCount, firstVertex uint32,
+	vertexCount, instanceCount, firstVertex, firstInstance uint32,
 	targetW, targetH int,
 ) []raster.Triangle {
 	if vertexCount < 3 {
 		return nil
 	}
 
-	layout := layouts[0]
-	vb := r.vertexBufs[0]
-	if vb.buffer == nil {
-		return nil
-	}
-
-	vb.buffer.mu.RLock()
-	bufData := vb.buffer.data
-	vb.buffer.mu.RUnlock()
-
-	stride := layout.ArrayStride
-	if stride == 0 {
-		return nil
-	}
-
-	// Classify attributes: find position (location 0) and others.
-	var posAttr *gputypes.VertexAttribute
-	var extraAttrs []gputypes.VertexAttribute
-	for i := range layout.Attributes {
-		attr := &layout.Attributes[i]
-		if attr.ShaderLocation == 0 {
-			posAttr = attr
-		} else {
-			extraAttrs = append(extraAttrs, *attr)
+	// Classify attributes of every bound buffer: find position (location 0)
+	// and others.
+	var posAttr *boundVertexAttribute
+	var extraAttrs []boundVertexAttribute
+	for slot := range layouts {
+		if slot >= len(r.vertexBufs) {
+			break
+		}
+		layout := &layouts[slot]
+		vb := r.vertexBufs[slot]
+		if vb.buffer == nil || layout.ArrayStride == 0 {
+			continue
+		}
+		bufData := vb.data()
+		for _, attr := range layout.Attributes {
+			ba := boundVertexAttribute{
+				data:     bufData,
+				offset:   vb.offset,
+				stride:   layout.ArrayStride,
+				instance: layout.StepMode == gputypes.VertexStepModeInstance,
+				attr:     attr,
+			}
+			if attr.ShaderLocation == 0 {
+				posAttr = &ba
+			} else {
+				extraAttrs = append(extraAttrs, ba)
+			}
 		}
 	}
 	if posAttr == nil {
 		return nil
 	}
 
-	// Read all vertices.
-	vertices := make([]raster.ScreenVertex, 0, vertexCount)
-	for i := uint32(0); i < vertexCount; i++ {
-		vi := r.drawVertexIndex(firstVertex, i)
-		base := vb.offset + uint64(vi)*stride
+	var triangles []raster.Triangle
+	for inst := uint32(0); inst < instanceCount; inst++ {
+		instanceID := firstInstance + inst
 
-		// Read position.
-		pos := readVertexAttribute(bufData, base+posAttr.Offset, posAttr.Format)
+		// Read all vertices of this instance.
+		vertices := make([]raster.ScreenVertex, 0, vertexCount)
+		for i := uint32(0); i < vertexCount; i++ {
+			vi := r.drawVertexIndex(firstVertex, i)
 
-		// NDC to screen transform.
-		// Position is expected in NDC: x,y in [-1,1], z in [0,1].
-		// Screen: x = (ndcX+1)/2 * width, y = (1-ndcY)/2 * height (Y flipped).
-		sx := (pos[0] + 1.0) * 0.5 * float32(targetW)
-		sy := (1.0 - pos[1]) * 0.5 * float32(targetH)
-		sz := float32(0)
-		if len(pos) > 2 {
-			sz = pos[2]
-		}
+			// Read position.
+			pos := posAttr.read(vi, instanceID)
+			if len(pos) < 2 {
+				return nil
+			}
 
-		sv := raster.ScreenVertex{
-			X: sx,
-			Y: sy,
-			Z: sz,
-			W: 1.0,
-		}
+			// NDC to screen transform.
+			// Position is expected in NDC: x,y in [-1,1], z in [0,1].
+			// Screen: x = (ndcX+1)/2 * width, y = (1-ndcY)/2 * height (Y flipped).
+			sx := (pos[0] + 1.0) * 0.5 * float32(targetW)
+			sy := (1.0 - pos[1]) * 0.5 * float32(targetH)
+			sz := float32(0)
+			if len(pos) > 2 {
+				sz = pos[2]
+			}
 
-		// Read extra attributes (color, UV, etc.).
-		for _, attr := range extraAttrs {
-			vals := readVertexAttribute(bufData, base+attr.Offset, attr.Format)
-			sv.Attributes = append(sv.Attributes, vals...)
-		}
+			sv := raster.ScreenVertex{
+				X: sx,
+				Y: sy,
+				Z: sz,
+				W: 1.0,
+			}
 
-		// Ensure at least 4 attribute components (RGBA) for interpolated color.
-		// RGB vertex colors (Float32x3) need alpha=1.0 padding.
-		for len(sv.Attributes) < 4 {
-			sv.Attributes = append(sv.Attributes, 1.0)
+			// Read extra attributes (color, UV, etc.).
+			for j := range extraAttrs {
+				sv.Attributes = append(sv.Attributes, extraAttrs[j].read(vi, instanceID)...)
+			}
+
+			// Ensure at least 4 attribute components (RGBA) for interpolated color.
+			// RGB vertex colors (Float32x3) need alpha=1.0 padding.
+			for len(sv.Attributes) < 4 {
+				sv.Attributes = append(sv.Attributes, 1.0)
+			}
+
+			vertices = append(vertices, sv)
 		}
 
-		vertices = append(vertices, sv)
+		// Strips must not connect across instances.
+		triangles = append(triangles, r.verticesToTriangles(vertices)...)
 	}
+	return triangles
+}
 
-	return r.verticesToTriangles(vertices)
+// boundVertexAttribute is a vertex attribute resolved against its bound
+// buffer for fetchTriangles.
+type boundVertexAttribute struct {
+	data     []byte
+	offset   uint64
+	stride   uint64
+	instance bool // instance-step buffer
+	attr     gputypes.VertexAttribute
+}
+
+// read returns the attribute of the given vertex or, for instance-step
+// buffers, of the given instance.
+func (a *boundVertexAttribute) read(vertexID, instanceID uint32) []float32 {
+	idx := vertexID
+	if a.instance {
+		idx = instanceID
+	}
+	return readVertexAttribute(a.data, a.offset+uint64(idx)*a.stride+a.attr.Offset, a.attr.Format)
 }
 
 // fetchTrianglesSPIRV executes the SPIR-V vertex shader per-vertex with both
@@ -566,12 +601,10 @@ func (r *RenderPassEncoder) fetchTrianglesSPIRV(
 	var bufSnaps [8]bufSnapshot
 	for i := range r.vertexBufs {
 		if r.vertexBufs[i].buffer != nil {
-			r.vertexBufs[i].buffer.mu.RLock()
 			bufSnaps[i] = bufSnapshot{
-				data:   r.vertexBufs[i].buffer.data,
+				data:   r.vertexBufs[i].data(),
 				offset: r.vertexBufs[i].offset,
 			}
-			r.vertexBufs[i].buffer.mu.RUnlock()
 		}
 	}
 
@@ -770,18 +803,13 @@ func (r *RenderPassEncoder) buildExecutionContext() *shader.ExecutionContext {
 			continue
 		}
 		// Buffers (uniform/storage) — apply offset/size from BufferBinding + dynamic offsets.
-		dynIdx := 0
 		for bindingIdx, bs := range bg.bufferBindings {
 			if bs.buf == nil {
 				continue
 			}
 			bs.buf.mu.RLock()
 			data := bs.buf.data
-			off := bs.offset
-			if dynIdx < len(bg.dynamicOffsets) {
-				off += uint64(bg.dynamicOffsets[dynIdx])
-				dynIdx++
-			}
+			off := bg.bufferOffset(bindingIdx)
 			end := uint64(len(data))
 			if bs.size > 0 && off+bs.size <= end {
 				end = off + bs.size
@@ -804,13 +832,7 @@ func (r *RenderPassEncoder) buildExecutionContext() *shader.ExecutionContext {
 			ctx.Textures[shader.BindingKey{
 				Group:   uint32(groupIdx),
 				Binding: bindingIdx,
-			}] = &shader.Texture2D{
-				Width:         tv.texture.width,
-				Height:        tv.texture.height,
-				Data:          tv.texture.data,
-				Format:        uint32(tv.texture.format),
-				BytesPerPixel: uint32(formatBytesPerPixel(tv.texture.format)),
-			}
+			}] = textureViewToShader(tv)
 			tv.texture.mu.RUnlock()
 		}
 		// Samplers.
@@ -828,6 +850,45 @@ func (r *RenderPassEncoder) buildExecutionContext() *shader.ExecutionContext {
 	return ctx
 }
 
+// textureViewToShader converts a texture view to a shader.Texture2D over
+// the view's array layers. Cube and array views expose their layers so the
+// interpreter can pick a face or layer per sample. The caller holds the
+// texture's read lock.
+func textureViewToShader(tv *TextureView) *shader.Texture2D {
+	tex := tv.texture
+	bpp := formatBytesPerPixel(tex.format)
+	out := &shader.Texture2D{
+		Width:         tex.width,
+		Height:        tex.height,
+		Data:          tex.data,
+		Format:        uint32(tex.format),
+		BytesPerPixel: uint32(bpp),
+	}
+	if tv.dimension == gputypes.TextureViewDimension3D {
+		out.Depth = tex.depth
+		return out
+	}
+	if tex.depth <= 1 {
+		return out
+	}
+
+	layerSize := uint64(tex.width) * uint64(tex.height) * bpp
+	base := min(tv.baseLayer, tex.depth-1)
+	layers := tex.depth - base
+	if tv.layerCount != 0 {
+		layers = min(tv.layerCount, layers)
+	}
+	start := uint64(base) * layerSize
+	end := min(start+uint64(layers)*layerSize, uint64(len(tex.data)))
+	if start < end {
+		out.Data = tex.data[start:end]
+	}
+	out.Layers = layers
+	out.Cube = tv.dimension == gputypes.TextureViewDimensionCube ||
+		tv.dimension == gputypes.TextureViewDimensionCubeArray
+	return out
+}
+
 // samplerResourceToShader converts a SamplerResource to a shader.Sampler
 // using the addressing and filtering modes from the HAL descriptor.
 func samplerResourceToShader(s *SamplerResource) *shader.Sampler {
@@ -1388,11 +1449,13 @@ func clampBlitBounds(minX, minY, maxX, maxY int, scissor [4]uint32) (int, int, i
 	return minX, minY, maxX, maxY
 }
 
-// configureRasterPipeline applies scissor, depth, stencil, and blend state
-// from the command encoder to a newly created raster.Pipeline. This is the
-// single point where WebGPU render pass state is translated to the raster
-// package's config.
+// configureRasterPipeline applies the rasterization mode and the scissor,
+// depth, stencil, and blend state from the command encoder to a newly
+// created raster.Pipeline. This is the single point where WebGPU render
+// pass state is translated to the raster package's config.
 func (r *RenderPassEncoder) configureRasterPipeline(pipe *raster.Pipeline) {
+	pipe.SetDeterministic(r.deterministic)
+
 	// Scissor: clip fragments to the scissor rectangle set by SetScissorRect.
 	if r.hasScissor {
 		pipe.SetScissor(&raster.Rect{
@@ -1427,25 +1490,46 @@ func (r *RenderPassEncoder) configureRasterPipeline(pipe *raster.Pipeline) {
 	pipe.SetDepthTest(depthEnabled, depthCompare)
 	pipe.SetDepthWrite(ds.DepthWriteEnabled)
 
-	// Stencil state: use front-face state (software rasterizer does not
-	// distinguish front/back face stencil; use front as the common case).
-	sf := ds.StencilFront
-	stencilEnabled := isStencilEnabled(sf)
+	// Stencil state. Front and back faces keep separate tests and
+	// operations, as two-sided stencil techniques such as shadow volumes
+	// count front and back faces differently.
+	sf, sb := ds.StencilFront, ds.StencilBack
+	stencilEnabled := isStencilEnabled(sf) || isStencilEnabled(sb)
 	if stencilEnabled && r.passStencilBuffer != nil {
 		// Use the persistent stencil buffer created at BeginRenderPass.
 		// This is the same buffer for ALL draw calls in this pass, matching
 		// GPU behavior where the depth/stencil attachment texture persists.
 		pipe.SetStencilBuffer(r.passStencilBuffer)
+		front := convertStencilFace(sf)
+		back := convertStencilFace(sb)
 		pipe.SetStencilState(raster.StencilState{
 			Enabled:     true,
 			ReadMask:    uint8(ds.StencilReadMask),
 			WriteMask:   uint8(ds.StencilWriteMask),
-			Compare:     convertCompareFunction(sf.Compare),
-			FailOp:      convertStencilOp(sf.FailOp),
-			DepthFailOp: convertStencilOp(sf.DepthFailOp),
-			PassOp:      convertStencilOp(sf.PassOp),
+			Compare:     front.Compare,
+			FailOp:      front.FailOp,
+			DepthFailOp: front.DepthFailOp,
+			PassOp:      front.PassOp,
 			Reference:   uint8(r.stencilRef),
+			Back:        &back,
 		})
+		// Facing is decided in framebuffer space, where Y points down and
+		// the winding of every triangle is mirrored.
+		if r.pipeline.desc.Primitive.FrontFace == gputypes.FrontFaceCW {
+			pipe.SetFrontFace(raster.FrontFaceCCW)
+		} else {
+			pipe.SetFrontFace(raster.FrontFaceCW)
+		}
+	}
+}
+
+// convertStencilFace maps a WebGPU stencil face state to the raster package.
+func convertStencilFace(sf hal.StencilFaceState) raster.StencilFace {
+	return raster.StencilFace{
+		Compare:     convertCompareFunction(sf.Compare),
+		FailOp:      convertStencilOp(sf.FailOp),
+		DepthFailOp: convertStencilOp(sf.DepthFailOp),
+		PassOp:      convertStencilOp(sf.PassOp),
 	}
 }
 
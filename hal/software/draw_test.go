@@ -737,7 +737,7 @@ func TestDrawTriangleFromVertexBuffer(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	pass.Draw(3, 1, 0, 0)
 	pass.End()
 
@@ -830,7 +830,7 @@ func TestDrawTriangleWithVertexColors(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	pass.Draw(3, 1, 0, 0)
 	pass.End()
 
@@ -850,6 +850,91 @@ func TestDrawTriangleWithVertexColors(t *testing.T) {
 	}
 }
 
+func TestDrawInstanceStepVertexBuffer(t *testing.T) {
+	dev, _, cleanup := createSoftwareDevice(t)
+	defer cleanup()
+
+	dstTex, _ := dev.CreateTexture(&hal.TextureDescriptor{
+		Size:   hal.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+		Format: gputypes.TextureFormatRGBA8Unorm,
+		Usage:  gputypes.TextureUsageRenderAttachment,
+	})
+	defer dev.DestroyTexture(dstTex)
+	dstView, _ := dev.CreateTextureView(dstTex, &hal.TextureViewDescriptor{})
+	defer dev.DestroyTextureView(dstView)
+
+	// Slot 0: fullscreen triangle positions, one per vertex.
+	posData := make([]byte, 8*3)
+	for i, v := range []float32{-1, -1, 3, -1, -1, 3} {
+		writeFloat32(posData, i*4, v)
+	}
+	// Slot 1: one RGBA color per instance: red, green, blue.
+	colorData := make([]byte, 16*3)
+	for i, v := range []float32{1, 0, 0, 1, 0, 1, 0, 1, 0, 0, 1, 1} {
+		writeFloat32(colorData, i*4, v)
+	}
+	posBuf, _ := dev.CreateBuffer(&hal.BufferDescriptor{Size: uint64(len(posData))})
+	defer dev.DestroyBuffer(posBuf)
+	posBuf.(*Buffer).WriteData(0, posData)
+	colorBuf, _ := dev.CreateBuffer(&hal.BufferDescriptor{Size: uint64(len(colorData))})
+	defer dev.DestroyBuffer(colorBuf)
+	colorBuf.(*Buffer).WriteData(0, colorData)
+
+	pipeline, _ := dev.CreateRenderPipeline(&hal.RenderPipelineDescriptor{
+		Label: "instance-color",
+		Vertex: hal.VertexState{
+			Buffers: []gputypes.VertexBufferLayout{
+				{
+					ArrayStride: 8,
+					StepMode:    gputypes.VertexStepModeVertex,
+					Attributes: []gputypes.VertexAttribute{
+						{Format: gputypes.VertexFormatFloat32x2, ShaderLocation: 0},
+					},
+				},
+				{
+					ArrayStride: 16,
+					StepMode:    gputypes.VertexStepModeInstance,
+					Attributes: []gputypes.VertexAttribute{
+						{Format: gputypes.VertexFormatFloat32x4, ShaderLocation: 1},
+					},
+				},
+			},
+		},
+	})
+	defer dev.DestroyRenderPipeline(pipeline)
+
+	tests := []struct {
+		name                         string
+		instanceCount, firstInstance uint32
+		want                         [4]byte
+	}{
+		{"first instance", 1, 2, [4]byte{0, 0, 255, 255}},
+		// Instances draw in order, so the last one covers the target.
+		{"two instances", 2, 0, [4]byte{0, 255, 0, 255}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, _ := dev.CreateCommandEncoder(&hal.CommandEncoderDescriptor{})
+			pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{
+				ColorAttachments: []hal.RenderPassColorAttachment{
+					{View: dstView, LoadOp: gputypes.LoadOpClear, ClearValue: gputypes.Color{}},
+				},
+			})
+			pass.SetPipeline(pipeline)
+			pass.SetVertexBuffer(0, posBuf, 0, uint64(len(posData)))
+			pass.SetVertexBuffer(1, colorBuf, 0, uint64(len(colorData)))
+			pass.Draw(3, tt.instanceCount, 0, tt.firstInstance)
+			pass.End()
+
+			data := dstTex.(*Texture).GetData()
+			idx := (2*4 + 2) * 4 // pixel (2,2)
+			if got := [4]byte(data[idx : idx+4]); got != tt.want {
+				t.Errorf("center pixel = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDrawMultipleTriangles(t *testing.T) {
 	dev, _, cleanup := createSoftwareDevice(t)
 	defer cleanup()
@@ -917,7 +1002,7 @@ func TestDrawMultipleTriangles(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	pass.Draw(6, 1, 0, 0)
 	pass.End()
 
@@ -991,7 +1076,7 @@ func TestDrawWithVertexBufferOffset(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, padding) // offset=64
+	pass.SetVertexBuffer(0, vb, padding, stride*3) // offset=64
 	pass.Draw(3, 1, 0, 0)
 	pass.End()
 
@@ -1104,7 +1189,7 @@ func TestDrawWithFirstVertex(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	pass.Draw(3, 1, 3, 0) // firstVertex=3
 	pass.End()
 
@@ -1131,8 +1216,8 @@ func TestSetVertexBufferSlot(t *testing.T) {
 
 	encoder := pass.(*RenderPassEncoder)
 
-	pass.SetVertexBuffer(0, buf, 0)
-	pass.SetVertexBuffer(3, buf, 32)
+	pass.SetVertexBuffer(0, buf, 0, 64)
+	pass.SetVertexBuffer(3, buf, 32, 32)
 
 	if encoder.vertexBufs[0].buffer == nil {
 		t.Error("slot 0 should have buffer")
@@ -1145,7 +1230,7 @@ func TestSetVertexBufferSlot(t *testing.T) {
 	}
 
 	// Out of range slot.
-	pass.SetVertexBuffer(8, buf, 0)
+	pass.SetVertexBuffer(8, buf, 0, 64)
 	// Should not panic, slot 8 out of range.
 
 	pass.End()
@@ -1165,7 +1250,7 @@ func TestSetIndexBuffer(t *testing.T) {
 
 	encoder := pass.(*RenderPassEncoder)
 
-	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 10)
+	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 10, 54)
 
 	if encoder.indexBuffer == nil {
 		t.Error("index buffer should be set")
@@ -1480,7 +1565,7 @@ fn fs_main(@location(0) col: vec3<f32>) -> @location(0) vec4<f32> {
 		}},
 	})
 	rpEnc.SetPipeline(rp)
-	rpEnc.SetVertexBuffer(0, bufB, 0)
+	rpEnc.SetVertexBuffer(0, bufB, 0, bufSize)
 	rpEnc.Draw(4, numParticles, 0, 0) // 4 vertices per quad, numParticles instances
 	rpEnc.End()
 
@@ -1635,7 +1720,7 @@ func TestScissorTriangleDrawClipsToRect(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	// Scissor: right half only (x=4, y=0, w=4, h=8).
 	pass.SetScissorRect(4, 0, 4, 8)
 	pass.Draw(3, 1, 0, 0)
@@ -1853,7 +1938,7 @@ func TestDepthStencilStateWiring(t *testing.T) {
 		},
 	})
 	pass.SetPipeline(pipeline1)
-	pass.SetVertexBuffer(0, vb, 0)
+	pass.SetVertexBuffer(0, vb, 0, uint64(len(vbData)))
 	// Draw near triangle (vertices 0-2).
 	pass.Draw(3, 1, 0, 0)
 	// Draw far triangle (vertices 3-5) — should be hidden by depth test.
@@ -6,26 +6,28 @@ import (
 	"fmt"
 	"image"
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/gogpu/wgpu/hal"
 )
 
 // Queue implements hal.Queue for the software backend.
 type Queue struct {
-	submissionIndex uint64
+	// submissionIndex is atomic because PollCompleted may be called
+	// from other goroutines while Submit runs.
+	submissionIndex atomic.Uint64
 }
 
 // Submit simulates command buffer submission.
 // Software backend is synchronous — work is complete immediately.
 func (q *Queue) Submit(_ []hal.CommandBuffer) (uint64, error) {
-	q.submissionIndex++
-	return q.submissionIndex, nil
+	return q.submissionIndex.Add(1), nil
 }
 
 // PollCompleted returns the highest submission index known to be completed.
 // Software backend is synchronous — all submissions are immediately complete.
 func (q *Queue) PollCompleted() uint64 {
-	return q.submissionIndex
+	return q.submissionIndex.Load()
 }
 
 // WriteBuffer performs immediate buffer writes with real data storage.
@@ -73,26 +75,39 @@ func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal
 		srcBytesPerRow = uint64(size.Width) * bytesPerPixel
 	}
 
+	srcRowsPerImage := uint64(layout.RowsPerImage)
+	if srcRowsPerImage == 0 {
+		srcRowsPerImage = uint64(size.Height)
+	}
+
 	dstBytesPerRow := uint64(tex.width) * bytesPerPixel
+	dstBytesPerLayer := dstBytesPerRow * uint64(tex.height)
 	rowCopyBytes := uint64(size.Width) * bytesPerPixel
 
 	tex.mu.Lock()
 	defer tex.mu.Unlock()
 
-	for row := uint32(0); row < size.Height; row++ {
-		srcStart := layout.Offset + uint64(row)*srcBytesPerRow
-		srcEnd := srcStart + rowCopyBytes
-		if srcEnd > uint64(len(data)) {
-			break
+	// Layers (array layers, cube faces or 3D slices) are stored back to
+	// back; Origin.Z selects the first one written.
+	layers := max(size.DepthOrArrayLayers, 1)
+	for layer := uint32(0); layer < layers; layer++ {
+		srcLayerStart := layout.Offset + uint64(layer)*srcRowsPerImage*srcBytesPerRow
+		dstLayerStart := uint64(dst.Origin.Z+layer) * dstBytesPerLayer
+		for row := uint32(0); row < size.Height; row++ {
+			srcStart := srcLayerStart + uint64(row)*srcBytesPerRow
+			srcEnd := srcStart + rowCopyBytes
+			if srcEnd > uint64(len(data)) {
+				return nil
+			}
+
+			dstStart := dstLayerStart + uint64(dst.Origin.Y+row)*dstBytesPerRow + uint64(dst.Origin.X)*bytesPerPixel
+			dstEnd := dstStart + rowCopyBytes
+			if dstEnd > uint64(len(tex.data)) {
+				return nil
+			}
+
+			copy(tex.data[dstStart:dstEnd], data[srcStart:srcEnd])
 		}
-
-		dstStart := uint64(dst.Origin.Y+row)*dstBytesPerRow + uint64(dst.Origin.X)*bytesPerPixel
-		dstEnd := dstStart + rowCopyBytes
-		if dstEnd > uint64(len(tex.data)) {
-			break
-		}
-
-		copy(tex.data[dstStart:dstEnd], data[srcStart:srcEnd])
 	}
 
 	return nil
@@ -0,0 +1,303 @@
+//go:build !(js && wasm)
+
+package raster
+
+import (
+	"math"
+)
+
+// SubpixelBits is the number of fractional bits of the fixed-point grid
+// RasterizeFixed snaps vertex positions to, as on D3D11-class hardware.
+const SubpixelBits = 8
+
+const (
+	// subpixelOne is one pixel in fixed-point units.
+	subpixelOne = 1 << SubpixelBits
+
+	// subpixelLimit bounds snapped coordinates so that edge functions,
+	// which multiply two coordinate differences, cannot overflow int64.
+	subpixelLimit = 1 << 28
+)
+
+// fixedEdge is an edge function over fixed-point coordinates. It is
+// evaluated relative to the edge's start vertex, so values stay small.
+type fixedEdge struct {
+	a, b   int64 // y0 - y1 and x1 - x0
+	x0, y0 int64 // start vertex
+
+	// bias is 0 for top-left edges and -1 otherwise, so pixel centers
+	// exactly on other edges are left out.
+	bias int64
+}
+
+func newFixedEdge(x0, y0, x1, y1 int64) fixedEdge {
+	return fixedEdge{a: y0 - y1, b: x1 - x0, x0: x0, y0: y0}
+}
+
+func (e fixedEdge) evaluate(x, y int64) int64 {
+	return e.a*(x-e.x0) + e.b*(y-e.y0)
+}
+
+// isTopLeft applies the same rule as EdgeFunction.IsTopLeft to an edge of
+// a counter-clockwise triangle.
+func (e fixedEdge) isTopLeft() bool {
+	return e.a > 0 || (e.a == 0 && e.b < 0)
+}
+
+// fixedTriangle is a triangle snapped to the fixed-point grid, with its
+// edges oriented so that inside points are positive whatever its winding.
+type fixedTriangle struct {
+	e12, e20, e01          fixedEdge
+	area                   int64 // twice the area, always positive
+	minX, minY, maxX, maxY int64
+}
+
+// snapCoord rounds a screen coordinate to the fixed-point grid, halfway
+// cases away from zero. It reports false for NaN.
+func snapCoord(v float32) (int64, bool) {
+	if v != v {
+		return 0, false
+	}
+	f := math.Round(float64(v) * subpixelOne)
+	f = math.Max(-subpixelLimit, math.Min(subpixelLimit, f))
+	return int64(f), true
+}
+
+// snapTriangle snaps the vertex positions of tri to the fixed-point grid.
+// It reports false when a position is NaN.
+func snapTriangle(tri *Triangle) (xs, ys [3]int64, ok bool) {
+	for i, v := range [3]*ScreenVertex{&tri.V0, &tri.V1, &tri.V2} {
+		x, okX := snapCoord(v.X)
+		y, okY := snapCoord(v.Y)
+		if !okX || !okY {
+			return xs, ys, false
+		}
+		xs[i], ys[i] = x, y
+	}
+	return xs, ys, true
+}
+
+// newFixedTriangle snaps tri to the fixed-point grid. It reports false for
+// degenerate triangles and triangles with NaN positions.
+func newFixedTriangle(tri *Triangle) (fixedTriangle, bool) {
+	xs, ys, ok := snapTriangle(tri)
+	if !ok {
+		return fixedTriangle{}, false
+	}
+
+	ft := fixedTriangle{
+		e12: newFixedEdge(xs[1], ys[1], xs[2], ys[2]),
+		e20: newFixedEdge(xs[2], ys[2], xs[0], ys[0]),
+		e01: newFixedEdge(xs[0], ys[0], xs[1], ys[1]),
+	}
+	ft.area = ft.e01.evaluate(xs[2], ys[2])
+	if ft.area == 0 {
+		return fixedTriangle{}, false
+	}
+	if ft.area < 0 {
+		// Flip clockwise triangles so inside is positive; the flipped
+		// edges run the other way, which is what the fill rule expects.
+		ft.area = -ft.area
+		for _, e := range []*fixedEdge{&ft.e12, &ft.e20, &ft.e01} {
+			e.a, e.b = -e.a, -e.b
+		}
+	}
+	for _, e := range []*fixedEdge{&ft.e12, &ft.e20, &ft.e01} {
+		if !e.isTopLeft() {
+			e.bias = -1
+		}
+	}
+
+	ft.minX, ft.maxX = min(xs[0], xs[1], xs[2]), max(xs[0], xs[1], xs[2])
+	ft.minY, ft.maxY = min(ys[0], ys[1], ys[2]), max(ys[0], ys[1], ys[2])
+	return ft, true
+}
+
+// pixelBounds returns the pixels [startX, endX) x [startY, endY) whose
+// centers may be covered.
+func (ft *fixedTriangle) pixelBounds() (startX, startY, endX, endY int) {
+	// Arithmetic shifts floor, also for negative coordinates.
+	startX = int(ft.minX >> SubpixelBits)
+	startY = int(ft.minY >> SubpixelBits)
+	endX = int((ft.maxX + subpixelOne - 1) >> SubpixelBits)
+	endY = int((ft.maxY + subpixelOne - 1) >> SubpixelBits)
+	return startX, startY, endX, endY
+}
+
+// FixedArea returns twice the signed area of tri after snapping its
+// vertices to the fixed-point grid, in squared subpixel units. Positive for
+// CCW winding, negative for CW winding in screen space, and 0 for
+// degenerate triangles or NaN positions.
+func FixedArea(tri Triangle) int64 {
+	xs, ys, ok := snapTriangle(&tri)
+	if !ok {
+		return 0
+	}
+	return newFixedEdge(xs[0], ys[0], xs[1], ys[1]).evaluate(xs[2], ys[2])
+}
+
+// IsBackFacingFixed is IsBackFacing with the winding taken from FixedArea.
+func IsBackFacingFixed(tri Triangle, frontFace FrontFace) bool {
+	area := FixedArea(tri)
+	switch frontFace {
+	case FrontFaceCCW:
+		return area < 0
+	case FrontFaceCW:
+		return area > 0
+	}
+	return false
+}
+
+// ShouldCullFixed is ShouldCull with the winding taken from FixedArea.
+func ShouldCullFixed(tri Triangle, cullMode CullMode, frontFace FrontFace) bool {
+	if cullMode == CullNone {
+		return false
+	}
+	isBack := IsBackFacingFixed(tri, frontFace)
+	switch cullMode {
+	case CullBack:
+		return isBack
+	case CullFront:
+		return !isBack
+	}
+	return false
+}
+
+// RasterizeFixed generates fragments for all pixels inside the triangle,
+// like Rasterize, but bit-for-bit the same on every platform. Vertex
+// positions are snapped to a grid of 1/2^SubpixelBits pixel, coverage is
+// decided with exact integer edge functions and the top-left fill rule, and
+// barycentrics, depth and attributes are interpolated with every product
+// rounded, so no compiler can fuse them into FMA instructions.
+func RasterizeFixed(tri Triangle, viewport Viewport, callback RasterCallback) {
+	ft, ok := newFixedTriangle(&tri)
+	if !ok {
+		return
+	}
+	startX, startY, endX, endY := ft.pixelBounds()
+	startX = maxInt(startX, viewport.X)
+	endX = minInt(endX, viewport.X+viewport.Width)
+	startY = maxInt(startY, viewport.Y)
+	endY = minInt(endY, viewport.Y+viewport.Height)
+	rasterizeFixedCore(&tri, &ft, startX, startY, endX, endY, callback)
+}
+
+// RasterizeTileFixed is RasterizeTile with the coverage and interpolation
+// of RasterizeFixed.
+func RasterizeTileFixed(tri Triangle, tile Tile, callback RasterCallback) {
+	ft, ok := newFixedTriangle(&tri)
+	if !ok {
+		return
+	}
+	startX, startY, endX, endY := ft.pixelBounds()
+	startX = maxInt(startX, tile.MinX)
+	endX = minInt(endX, tile.MaxX)
+	startY = maxInt(startY, tile.MinY)
+	endY = minInt(endY, tile.MaxY)
+	rasterizeFixedCore(&tri, &ft, startX, startY, endX, endY, callback)
+}
+
+// rasterizeFixedCore rasterizes ft within [startX, endX) x [startY, endY),
+// stepping the edge functions by whole pixels.
+func rasterizeFixedCore(tri *Triangle, ft *fixedTriangle, startX, startY, endX, endY int, callback RasterCallback) {
+	if startX >= endX || startY >= endY {
+		return
+	}
+
+	attrCount := len(tri.V0.Attributes)
+	area := float64(ft.area)
+
+	// Pixel centers lie half a pixel into the pixel.
+	px := int64(startX)<<SubpixelBits + subpixelOne/2
+	py := int64(startY)<<SubpixelBits + subpixelOne/2
+	row0 := ft.e12.evaluate(px, py)
+	row1 := ft.e20.evaluate(px, py)
+	row2 := ft.e01.evaluate(px, py)
+
+	for y := startY; y < endY; y++ {
+		w0, w1, w2 := row0, row1, row2
+		for x := startX; x < endX; x++ {
+			if w0+ft.e12.bias >= 0 && w1+ft.e20.bias >= 0 && w2+ft.e01.bias >= 0 {
+				b0 := float32(float64(w0) / area)
+				b1 := float32(float64(w1) / area)
+				b2 := float32(float64(w2) / area)
+				callback(fixedFragment(x, y, tri, b0, b1, b2, attrCount))
+			}
+			w0 += ft.e12.a * subpixelOne
+			w1 += ft.e20.a * subpixelOne
+			w2 += ft.e01.a * subpixelOne
+		}
+		row0 += ft.e12.b * subpixelOne
+		row1 += ft.e20.b * subpixelOne
+		row2 += ft.e01.b * subpixelOne
+	}
+}
+
+// fixedFragment computes a fragment with perspective-correct depth and
+// attributes like computeFragment, using dot3 for every weighted sum.
+func fixedFragment(x, y int, tri *Triangle, b0, b1, b2 float32, attrCount int) Fragment {
+	w0, w1, w2 := tri.V0.W, tri.V1.W, tri.V2.W
+	oneOverW := dot3(b0, b1, b2, w0, w1, w2)
+
+	var depth float32
+	if oneOverW != 0 {
+		depth = dot3(b0, b1, b2,
+			float32(tri.V0.Z*w0), float32(tri.V1.Z*w1), float32(tri.V2.Z*w2)) / oneOverW
+	} else {
+		depth = dot3(b0, b1, b2, tri.V0.Z, tri.V1.Z, tri.V2.Z)
+	}
+
+	var attrs []float32
+	if attrCount > 0 {
+		attrs = make([]float32, attrCount)
+		for i := range attrs {
+			a0, a1, a2 := tri.V0.Attributes[i], tri.V1.Attributes[i], tri.V2.Attributes[i]
+			if oneOverW != 0 {
+				attrs[i] = dot3(b0, b1, b2, float32(a0*w0), float32(a1*w1), float32(a2*w2)) / oneOverW
+			} else {
+				attrs[i] = dot3(b0, b1, b2, a0, a1, a2)
+			}
+		}
+	}
+
+	return Fragment{
+		X:          x,
+		Y:          y,
+		Depth:      depth,
+		Bary:       [3]float32{b0, b1, b2},
+		Attributes: attrs,
+	}
+}
+
+// dot3 returns b0*v0 + b1*v1 + b2*v2. The explicit conversions round each
+// product, which keeps the Go compiler from fusing them into FMA
+// instructions where the target has them (arm64, ppc64, s390x, amd64 v3).
+func dot3(b0, b1, b2, v0, v1, v2 float32) float32 {
+	return float32(b0*v0) + float32(b1*v1) + float32(b2*v2)
+}
+
+// rasterize calls RasterizeFixed or Rasterize.
+func rasterize(tri Triangle, viewport Viewport, deterministic bool, callback RasterCallback) {
+	if deterministic {
+		RasterizeFixed(tri, viewport, callback)
+		return
+	}
+	Rasterize(tri, viewport, callback)
+}
+
+// rasterizeTile calls RasterizeTileFixed or RasterizeTile.
+func rasterizeTile(tri Triangle, tile Tile, deterministic bool, callback RasterCallback) {
+	if deterministic {
+		RasterizeTileFixed(tri, tile, callback)
+		return
+	}
+	RasterizeTile(tri, tile, callback)
+}
+
+// shouldCull calls ShouldCullFixed or ShouldCull.
+func shouldCull(tri Triangle, cullMode CullMode, frontFace FrontFace, deterministic bool) bool {
+	if deterministic {
+		return ShouldCullFixed(tri, cullMode, frontFace)
+	}
+	return ShouldCull(tri, cullMode, frontFace)
+}
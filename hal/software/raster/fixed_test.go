@@ -0,0 +1,200 @@
+//go:build !(js && wasm)
+
+package raster
+
+import (
+	"math"
+	"testing"
+)
+
+// collectFixed returns the fragments RasterizeFixed generates for tri.
+func collectFixed(tri Triangle, viewport Viewport) []Fragment {
+	var frags []Fragment
+	RasterizeFixed(tri, viewport, func(frag Fragment) {
+		frags = append(frags, frag)
+	})
+	return frags
+}
+
+func TestRasterizeFixedSharedEdge(t *testing.T) {
+	// Two triangles covering a 16x16 quad split along a diagonal that runs
+	// through pixel centers; every pixel must be drawn exactly once.
+	viewport := Viewport{Width: 32, Height: 32, MaxDepth: 1}
+	quad := []Triangle{
+		CreateScreenTriangle(4, 4, 0, 20, 4, 0, 4, 20, 0),
+		CreateScreenTriangle(20, 4, 0, 20, 20, 0, 4, 20, 0),
+	}
+
+	var counts [32 * 32]int
+	for _, tri := range quad {
+		for _, f := range collectFixed(tri, viewport) {
+			counts[f.Y*32+f.X]++
+		}
+	}
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			want := 0
+			if x >= 4 && x < 20 && y >= 4 && y < 20 {
+				want = 1
+			}
+			if got := counts[y*32+x]; got != want {
+				t.Errorf("pixel (%d, %d) drawn %d times, want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestRasterizeFixedWindingIndependent(t *testing.T) {
+	viewport := Viewport{Width: 64, Height: 64, MaxDepth: 1}
+	ccw := CreateScreenTriangle(3.3, 2.7, 0, 50.1, 9.9, 0, 17.6, 47.2, 0)
+	cw := Triangle{V0: ccw.V0, V1: ccw.V2, V2: ccw.V1}
+
+	a, b := collectFixed(ccw, viewport), collectFixed(cw, viewport)
+	if len(a) == 0 || len(a) != len(b) {
+		t.Fatalf("CCW drew %d fragments, CW drew %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i].X != b[i].X || a[i].Y != b[i].Y {
+			t.Fatalf("fragment %d at (%d, %d) for CCW, (%d, %d) for CW", i, a[i].X, a[i].Y, b[i].X, b[i].Y)
+		}
+	}
+}
+
+func TestRasterizeFixedSnapsToSubpixels(t *testing.T) {
+	// Offsets below half a subpixel snap to the same grid positions, so
+	// the fragments match exactly.
+	viewport := Viewport{Width: 32, Height: 32, MaxDepth: 1}
+	base := CreateScreenTriangleWithColor(
+		2, 2, 0.25, [4]float32{1, 0, 0, 1},
+		28, 5, 0.5, [4]float32{0, 1, 0, 1},
+		9, 27, 0.75, [4]float32{0, 0, 1, 1},
+	)
+	jittered := base
+	jittered.V0.X += 0.4 / subpixelOne
+	jittered.V1.Y -= 0.4 / subpixelOne
+	jittered.V2.X -= 0.3 / subpixelOne
+
+	a, b := collectFixed(base, viewport), collectFixed(jittered, viewport)
+	if len(a) != len(b) {
+		t.Fatalf("jittered triangle drew %d fragments, want %d", len(b), len(a))
+	}
+	for i := range a {
+		if a[i].X != b[i].X || a[i].Y != b[i].Y || a[i].Bary != b[i].Bary {
+			t.Fatalf("fragment %d differs: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestRasterizeFixedInterpolation(t *testing.T) {
+	viewport := Viewport{Width: 40, Height: 40, MaxDepth: 1}
+	tri := CreateScreenTriangleWithColor(
+		10, 10, 0.5, [4]float32{1, 0, 0, 1},
+		30, 10, 0.5, [4]float32{0, 1, 0, 1},
+		20, 30, 0.5, [4]float32{0, 0, 1, 1},
+	)
+
+	frags := collectFixed(tri, viewport)
+	if len(frags) == 0 {
+		t.Fatal("no fragments")
+	}
+	for _, f := range frags {
+		sum := f.Bary[0] + f.Bary[1] + f.Bary[2]
+		if math.Abs(float64(sum)-1) > 1e-5 {
+			t.Errorf("fragment (%d, %d) barycentrics sum to %v", f.X, f.Y, sum)
+		}
+		if math.Abs(float64(f.Depth)-0.5) > 1e-6 {
+			t.Errorf("fragment (%d, %d) depth %v, want 0.5", f.X, f.Y, f.Depth)
+		}
+		if len(f.Attributes) != 4 || f.Attributes[3] < 0.99999 || f.Attributes[3] > 1.00001 {
+			t.Errorf("fragment (%d, %d) attributes %v, want alpha 1", f.X, f.Y, f.Attributes)
+		}
+	}
+}
+
+func TestRasterizeFixedRejects(t *testing.T) {
+	viewport := Viewport{Width: 16, Height: 16, MaxDepth: 1}
+	nan := float32(math.NaN())
+	for name, tri := range map[string]Triangle{
+		"degenerate":   CreateScreenTriangle(1, 1, 0, 5, 5, 0, 9, 9, 0),
+		"sub-subpixel": CreateScreenTriangle(4, 4, 0, 4.001, 4, 0, 4, 4.001, 0),
+		"nan":          CreateScreenTriangle(nan, 1, 0, 10, 1, 0, 1, 10, 0),
+		"offscreen":    CreateScreenTriangle(-20, -20, 0, -10, -20, 0, -20, -10, 0),
+	} {
+		if frags := collectFixed(tri, viewport); len(frags) != 0 {
+			t.Errorf("%s: drew %d fragments, want 0", name, len(frags))
+		}
+	}
+}
+
+func TestRasterizeTileFixedMatchesRasterizeFixed(t *testing.T) {
+	viewport := Viewport{Width: 64, Height: 64, MaxDepth: 1}
+	tri := CreateScreenTriangle(1.5, 3.25, 0, 60.75, 12.5, 0, 22.125, 61, 0)
+
+	want := collectFixed(tri, viewport)
+	var got []Fragment
+	for _, tile := range []Tile{
+		{MinX: 0, MinY: 0, MaxX: 32, MaxY: 32},
+		{MinX: 32, MinY: 0, MaxX: 64, MaxY: 32},
+		{MinX: 0, MinY: 32, MaxX: 32, MaxY: 64},
+		{MinX: 32, MinY: 32, MaxX: 64, MaxY: 64},
+	} {
+		RasterizeTileFixed(tri, tile, func(frag Fragment) {
+			got = append(got, frag)
+		})
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tiles drew %d fragments, want %d", len(got), len(want))
+	}
+	seen := make(map[[2]int]Fragment, len(want))
+	for _, f := range want {
+		seen[[2]int{f.X, f.Y}] = f
+	}
+	for _, f := range got {
+		w, ok := seen[[2]int{f.X, f.Y}]
+		if !ok || w.Bary != f.Bary {
+			t.Errorf("tile fragment %+v not drawn the same without tiles", f)
+		}
+	}
+}
+
+func TestShouldCullFixed(t *testing.T) {
+	ccw := CreateScreenTriangle(0, 0, 0, 10, 0, 0, 0, 10, 0)
+	cw := Triangle{V0: ccw.V0, V1: ccw.V2, V2: ccw.V1}
+
+	for _, tt := range []struct {
+		tri  Triangle
+		mode CullMode
+		face FrontFace
+	}{
+		{ccw, CullNone, FrontFaceCCW},
+		{ccw, CullBack, FrontFaceCCW},
+		{cw, CullBack, FrontFaceCCW},
+		{cw, CullFront, FrontFaceCCW},
+		{ccw, CullBack, FrontFaceCW},
+	} {
+		if got, want := ShouldCullFixed(tt.tri, tt.mode, tt.face), ShouldCull(tt.tri, tt.mode, tt.face); got != want {
+			t.Errorf("ShouldCullFixed(%v, %v) = %v, ShouldCull says %v", tt.mode, tt.face, got, want)
+		}
+	}
+}
+
+func TestPipelineDeterministic(t *testing.T) {
+	p := NewPipeline(16, 16)
+	if p.IsDeterministic() {
+		t.Fatal("new pipeline is deterministic")
+	}
+	p.SetDeterministic(true)
+	if !p.IsDeterministic() {
+		t.Fatal("SetDeterministic(true) did not take effect")
+	}
+	p.DrawTriangles([]Triangle{CreateScreenTriangle(0, 0, 0, 16, 0, 0, 0, 16, 0)}, [4]float32{1, 0, 0, 1})
+
+	buf := p.GetColorBuffer()
+	// The pixel at (2, 2) is inside, (14, 14) is outside the hypotenuse.
+	if r := buf[(2*16+2)*4]; r != 255 {
+		t.Errorf("pixel (2, 2) red = %d, want 255", r)
+	}
+	if r := buf[(14*16+14)*4]; r != 0 {
+		t.Errorf("pixel (14, 14) red = %d, want 0", r)
+	}
+}
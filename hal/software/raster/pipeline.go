@@ -49,6 +49,9 @@ type Pipeline struct {
 	// Clipping configuration
 	clippingEnabled bool
 
+	// Fixed-point rasterization (see SetDeterministic)
+	deterministic bool
+
 	// Parallel rasterization
 	parallelRasterizer *ParallelRasterizer
 	useParallel        bool
@@ -266,6 +269,23 @@ func (p *Pipeline) IsClippingEnabled() bool {
 	return p.clippingEnabled
 }
 
+// SetDeterministic selects fixed-point rasterization with RasterizeFixed,
+// which produces the same pixels on every platform, instead of the faster
+// floating-point Rasterize. Culling and stencil face selection then use the
+// fixed-point winding too.
+func (p *Pipeline) SetDeterministic(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deterministic = enabled
+}
+
+// IsDeterministic returns whether fixed-point rasterization is enabled.
+func (p *Pipeline) IsDeterministic() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.deterministic
+}
+
 // SetParallelConfig sets the parallel rasterization configuration.
 // If enabled, the pipeline will use tile-based parallel rasterization.
 func (p *Pipeline) SetParallelConfig(config ParallelConfig) {
@@ -378,6 +398,7 @@ func (p *Pipeline) DrawTriangles(triangles []Triangle, color [4]float32) {
 	depthCompare := p.depthCompare
 	cullMode := p.cullMode
 	frontFace := p.frontFace
+	deterministic := p.deterministic
 	blendState := p.blendState
 	stencilBuffer := p.stencilBuffer
 	stencilState := p.stencilState
@@ -396,12 +417,13 @@ func (p *Pipeline) DrawTriangles(triangles []Triangle, color [4]float32) {
 		tri := &triangles[i]
 
 		// Face culling
-		if ShouldCull(*tri, cullMode, frontFace) {
+		if shouldCull(*tri, cullMode, frontFace, deterministic) {
 			continue
 		}
+		triStencil := stencilState.forTriangle(tri, frontFace, deterministic)
 
 		// Rasterize triangle
-		Rasterize(*tri, viewport, func(frag Fragment) {
+		rasterize(*tri, viewport, deterministic, func(frag Fragment) {
 			// Bounds check
 			if frag.X < 0 || frag.X >= p.width || frag.Y < 0 || frag.Y >= p.height {
 				return
@@ -416,7 +438,7 @@ func (p *Pipeline) DrawTriangles(triangles []Triangle, color [4]float32) {
 			result := p.performDepthStencilTest(
 				frag.X, frag.Y, frag.Depth,
 				depthTest, depthWrite, depthCompare,
-				stencilBuffer, stencilState,
+				stencilBuffer, triStencil,
 			)
 			if !result.passed {
 				return
@@ -458,6 +480,7 @@ func (p *Pipeline) DrawTrianglesInterpolated(triangles []Triangle) {
 	depthCompare := p.depthCompare
 	cullMode := p.cullMode
 	frontFace := p.frontFace
+	deterministic := p.deterministic
 	blendState := p.blendState
 	stencilBuffer := p.stencilBuffer
 	stencilState := p.stencilState
@@ -476,12 +499,13 @@ func (p *Pipeline) DrawTrianglesInterpolated(triangles []Triangle) {
 		tri := &triangles[i]
 
 		// Face culling
-		if ShouldCull(*tri, cullMode, frontFace) {
+		if shouldCull(*tri, cullMode, frontFace, deterministic) {
 			continue
 		}
+		triStencil := stencilState.forTriangle(tri, frontFace, deterministic)
 
 		// Rasterize triangle
-		Rasterize(*tri, viewport, func(frag Fragment) {
+		rasterize(*tri, viewport, deterministic, func(frag Fragment) {
 			// Bounds check
 			if frag.X < 0 || frag.X >= p.width || frag.Y < 0 || frag.Y >= p.height {
 				return
@@ -496,7 +520,7 @@ func (p *Pipeline) DrawTrianglesInterpolated(triangles []Triangle) {
 			result := p.performDepthStencilTest(
 				frag.X, frag.Y, frag.Depth,
 				depthTest, depthWrite, depthCompare,
-				stencilBuffer, stencilState,
+				stencilBuffer, triStencil,
 			)
 			if !result.passed {
 				return
@@ -556,6 +580,7 @@ func (p *Pipeline) DrawTrianglesWithFragmentShader(triangles []Triangle, fragFun
 	depthCompare := p.depthCompare
 	cullMode := p.cullMode
 	frontFace := p.frontFace
+	deterministic := p.deterministic
 	blendState := p.blendState
 	stencilBuffer := p.stencilBuffer
 	stencilState := p.stencilState
@@ -574,12 +599,13 @@ func (p *Pipeline) DrawTrianglesWithFragmentShader(triangles []Triangle, fragFun
 		tri := &triangles[i]
 
 		// Face culling
-		if ShouldCull(*tri, cullMode, frontFace) {
+		if shouldCull(*tri, cullMode, frontFace, deterministic) {
 			continue
 		}
+		triStencil := stencilState.forTriangle(tri, frontFace, deterministic)
 
 		// Rasterize triangle
-		Rasterize(*tri, viewport, func(frag Fragment) {
+		rasterize(*tri, viewport, deterministic, func(frag Fragment) {
 			// Bounds check
 			if frag.X < 0 || frag.X >= p.width || frag.Y < 0 || frag.Y >= p.height {
 				return
@@ -594,7 +620,7 @@ func (p *Pipeline) DrawTrianglesWithFragmentShader(triangles []Triangle, fragFun
 			result := p.performDepthStencilTest(
 				frag.X, frag.Y, frag.Depth,
 				depthTest, depthWrite, depthCompare,
-				stencilBuffer, stencilState,
+				stencilBuffer, triStencil,
 			)
 			if !result.passed {
 				return
@@ -645,6 +671,7 @@ func (p *Pipeline) DrawTrianglesParallel(triangles []Triangle, color [4]float32)
 	depthCompare := p.depthCompare
 	cullMode := p.cullMode
 	frontFace := p.frontFace
+	deterministic := p.deterministic
 	blendState := p.blendState
 	stencilBuffer := p.stencilBuffer
 	stencilState := p.stencilState
@@ -669,7 +696,7 @@ func (p *Pipeline) DrawTrianglesParallel(triangles []Triangle, color [4]float32)
 	validTriangles := make([]Triangle, 0, len(triangles))
 	for i := range triangles {
 		tri := &triangles[i]
-		if !ShouldCull(*tri, cullMode, frontFace) {
+		if !shouldCull(*tri, cullMode, frontFace, deterministic) {
 			validTriangles = append(validTriangles, *tri)
 		}
 	}
@@ -683,8 +710,9 @@ func (p *Pipeline) DrawTrianglesParallel(triangles []Triangle, color [4]float32)
 		// Process all triangles in this tile
 		for i := range tileTriangles {
 			tri := &tileTriangles[i]
+			triStencil := stencilState.forTriangle(tri, frontFace, deterministic)
 
-			RasterizeTile(*tri, tile, func(frag Fragment) {
+			rasterizeTile(*tri, tile, deterministic, func(frag Fragment) {
 				// Bounds check (should always pass for properly clipped tiles)
 				if frag.X < viewport.X || frag.X >= viewport.X+viewport.Width ||
 					frag.Y < viewport.Y || frag.Y >= viewport.Y+viewport.Height {
@@ -700,7 +728,7 @@ func (p *Pipeline) DrawTrianglesParallel(triangles []Triangle, color [4]float32)
 				result := p.performDepthStencilTest(
 					frag.X, frag.Y, frag.Depth,
 					depthTest, depthWrite, depthCompare,
-					stencilBuffer, stencilState,
+					stencilBuffer, triStencil,
 				)
 				if !result.passed {
 					return
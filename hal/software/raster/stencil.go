@@ -60,6 +60,38 @@ type StencilState struct {
 
 	// Reference is the reference value for stencil comparison and StencilOpReplace.
 	Reference uint8
+
+	// Back, when set, replaces Compare and the operations for back-facing
+	// triangles. Otherwise both facings use the same test and operations.
+	Back *StencilFace
+}
+
+// StencilFace is the stencil test and operations for one triangle facing.
+type StencilFace struct {
+	Compare     CompareFunc
+	FailOp      StencilOp
+	DepthFailOp StencilOp
+	PassOp      StencilOp
+}
+
+// forTriangle returns the state that applies to tri: s itself, or s with
+// the Back test and operations when tri is back-facing.
+func (s StencilState) forTriangle(tri *Triangle, frontFace FrontFace, deterministic bool) StencilState {
+	if !s.Enabled || s.Back == nil {
+		return s
+	}
+	back := IsBackFacing(*tri, frontFace)
+	if deterministic {
+		back = IsBackFacingFixed(*tri, frontFace)
+	}
+	if !back {
+		return s
+	}
+	s.Compare = s.Back.Compare
+	s.FailOp = s.Back.FailOp
+	s.DepthFailOp = s.Back.DepthFailOp
+	s.PassOp = s.Back.PassOp
+	return s
 }
 
 // DefaultStencilState returns a default stencil state with testing disabled.
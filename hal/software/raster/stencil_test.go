@@ -629,6 +629,40 @@ func BenchmarkStencilBufferTest(b *testing.B) {
 	}
 }
 
+// =============================================================================
+// Two-Sided Stencil Tests
+// =============================================================================
+
+func TestStencilStateForTriangle(t *testing.T) {
+	state := StencilState{
+		Enabled: true,
+		Compare: CompareAlways,
+		PassOp:  StencilOpIncrementWrap,
+		Back:    &StencilFace{Compare: CompareEqual, PassOp: StencilOpDecrementWrap},
+	}
+	// Positive area: counter-clockwise in the raster's coordinates.
+	ccw := Triangle{V0: ScreenVertex{X: 0, Y: 0}, V1: ScreenVertex{X: 10, Y: 0}, V2: ScreenVertex{X: 0, Y: 10}}
+	cw := Triangle{V0: ccw.V0, V1: ccw.V2, V2: ccw.V1}
+
+	for _, deterministic := range []bool{false, true} {
+		state := state
+		if got := state.forTriangle(&ccw, FrontFaceCCW, deterministic); got.PassOp != StencilOpIncrementWrap || got.Compare != CompareAlways {
+			t.Errorf("deterministic=%v: front-facing triangle got PassOp %v, Compare %v; want front state", deterministic, got.PassOp, got.Compare)
+		}
+		if got := state.forTriangle(&cw, FrontFaceCCW, deterministic); got.PassOp != StencilOpDecrementWrap || got.Compare != CompareEqual {
+			t.Errorf("deterministic=%v: back-facing triangle got PassOp %v, Compare %v; want back state", deterministic, got.PassOp, got.Compare)
+		}
+		if got := state.forTriangle(&ccw, FrontFaceCW, deterministic); got.PassOp != StencilOpDecrementWrap {
+			t.Errorf("deterministic=%v: FrontFaceCW: CCW triangle got PassOp %v, want back state", deterministic, got.PassOp)
+		}
+
+		state.Back = nil
+		if got := state.forTriangle(&cw, FrontFaceCCW, deterministic); got.PassOp != StencilOpIncrementWrap {
+			t.Errorf("deterministic=%v: without Back, back-facing triangle got PassOp %v, want front state", deterministic, got.PassOp)
+		}
+	}
+}
+
 func BenchmarkStencilBufferTestAndApply(b *testing.B) {
 	sb := NewStencilBuffer(800, 600)
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"image"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -149,16 +150,32 @@ func (t *Texture) Clear(color gputypes.Color) {
 }
 
 // TextureView implements hal.TextureView.
-// In software backend, views just reference the original texture.
+// In software backend, views reference the original texture plus the
+// array layer range and dimension they select.
 type TextureView struct {
 	Resource
-	id      uint64 // unique ID for handle resolution
-	texture *Texture
+	id         uint64 // unique ID for handle resolution
+	texture    *Texture
+	dimension  gputypes.TextureViewDimension
+	baseLayer  uint32
+	layerCount uint32 // 0 = all layers from baseLayer
 }
 
 // NativeHandle returns the view's unique ID for handle resolution.
 func (v *TextureView) NativeHandle() uintptr { return uintptr(v.id) }
 
+// isSingle2D reports whether the view is a plain 2D image that can be
+// blitted directly. Cube, array and 3D views need the shader to pick a
+// face, layer or slice.
+func (v *TextureView) isSingle2D() bool {
+	switch v.dimension {
+	case gputypes.TextureViewDimensionUndefined, gputypes.TextureViewDimension2D:
+		return v.texture.depth <= 1
+	default:
+		return false
+	}
+}
+
 // Surface implements hal.Surface for the software backend.
 type Surface struct {
 	Resource
@@ -170,6 +187,7 @@ type Surface struct {
 	mu            sync.RWMutex // Protects framebuffer access
 	presentMode   hal.PresentMode
 	alphaMode     hal.CompositeAlphaMode
+	scale         float64 // SurfaceConfiguration.ScaleFactor, 0 if unknown
 	targetKind    hal.SurfaceTargetKind
 	displayHandle uintptr // X11: Display*, macOS/Windows: 0
 	hwnd          uintptr // window handle for platform blit (0 = headless)
@@ -199,6 +217,7 @@ func (s *Surface) Configure(_ hal.Device, config *hal.SurfaceConfiguration) erro
 	s.format = config.Format
 	s.presentMode = config.PresentMode
 	s.alphaMode = config.AlphaMode
+	s.scale = config.ScaleFactor
 
 	// Detect Wayland and bind wl_shm eagerly on the main thread, before any
 	// render thread calls Present. See BUG-SW-WAYLAND-001: lazy init on first
@@ -422,14 +441,32 @@ type bufferSlice struct {
 	size   uint64
 }
 
+// BindGroupLayout records which bindings of a layout take dynamic offsets.
+type BindGroupLayout struct {
+	Resource
+	dynamicBindings []uint32 // buffer bindings with HasDynamicOffset, ascending
+}
+
 type BindGroup struct {
 	Resource
-	desc           *hal.BindGroupDescriptor
-	textureViews   map[uint32]*TextureView     // binding index -> resolved texture view
-	buffers        map[uint32]*Buffer          // binding index -> resolved buffer (legacy, offset=0)
-	bufferBindings map[uint32]bufferSlice      // binding index -> buffer + offset/size
-	samplers       map[uint32]*SamplerResource // binding index -> resolved sampler
-	dynamicOffsets []uint32                    // applied via SetBindGroup
+	desc            *hal.BindGroupDescriptor
+	textureViews    map[uint32]*TextureView     // binding index -> resolved texture view
+	buffers         map[uint32]*Buffer          // binding index -> resolved buffer (legacy, offset=0)
+	bufferBindings  map[uint32]bufferSlice      // binding index -> buffer + offset/size
+	samplers        map[uint32]*SamplerResource // binding index -> resolved sampler
+	dynamicBindings []uint32                    // from the layout; dynamicOffsets[i] applies to dynamicBindings[i]
+	dynamicOffsets  []uint32                    // applied via SetBindGroup
+}
+
+// bufferOffset returns the offset of the buffer slice bound at binding,
+// including its dynamic offset. As in WebGPU, dynamic offsets are matched to
+// the layout's dynamic bindings in binding order.
+func (bg *BindGroup) bufferOffset(binding uint32) uint64 {
+	off := bg.bufferBindings[binding].offset
+	if i := slices.Index(bg.dynamicBindings, binding); i >= 0 && i < len(bg.dynamicOffsets) {
+		off += uint64(bg.dynamicOffsets[i])
+	}
+	return off
 }
 
 // ComputePipeline stores compute pipeline configuration for the software backend.
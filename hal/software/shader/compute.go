@@ -264,30 +264,29 @@ func (interp *interpreter) executeAtomicOp(inst Instruction) Value {
 	// scope and semantics are inst.Operands[1] and [2] -- ignored in single-threaded.
 
 	pv := interp.values[ptrID]
-	if pv.Tag != TagPointer {
+	if pv.Tag != TagPointer && pv.Tag != TagSubPointer && pv.Tag != TagBufferPointer {
 		return ValUint(0)
 	}
-	ptr := pv.AsPointer()
 
 	atomicMu.Lock()
 	defer atomicMu.Unlock()
 
-	oldVal := toUint32(ptr.Val)
+	oldVal := toUint32(interp.atomicLoad(pv))
 
 	switch inst.Opcode {
 	case OpAtomicIAdd:
 		if len(inst.Operands) >= 4 {
 			addVal := toUint32(interp.values[inst.Operands[3]])
-			ptr.Val = ValUint(oldVal + addVal)
+			atomicStore(pv, ValUint(oldVal+addVal))
 		}
 	case OpAtomicISub:
 		if len(inst.Operands) >= 4 {
 			subVal := toUint32(interp.values[inst.Operands[3]])
-			ptr.Val = ValUint(oldVal - subVal)
+			atomicStore(pv, ValUint(oldVal-subVal))
 		}
 	case OpAtomicExchange:
 		if len(inst.Operands) >= 4 {
-			ptr.Val = interp.values[inst.Operands[3]]
+			atomicStore(pv, interp.values[inst.Operands[3]])
 		}
 	case OpAtomicCompareExchange:
 		// Operands: pointer, scope, equal_sem, unequal_sem, value, comparator
@@ -295,7 +294,7 @@ func (interp *interpreter) executeAtomicOp(inst Instruction) Value {
 			newVal := toUint32(interp.values[inst.Operands[4]])
 			comparator := toUint32(interp.values[inst.Operands[5]])
 			if oldVal == comparator {
-				ptr.Val = ValUint(newVal)
+				atomicStore(pv, ValUint(newVal))
 			}
 		}
 	case OpAtomicSMin:
@@ -303,14 +302,14 @@ func (interp *interpreter) executeAtomicOp(inst Instruction) Value {
 			v := int32(toUint32(interp.values[inst.Operands[3]]))
 			old := int32(oldVal)
 			if v < old {
-				ptr.Val = ValUint(uint32(v))
+				atomicStore(pv, ValUint(uint32(v)))
 			}
 		}
 	case OpAtomicUMin:
 		if len(inst.Operands) >= 4 {
 			v := toUint32(interp.values[inst.Operands[3]])
 			if v < oldVal {
-				ptr.Val = ValUint(v)
+				atomicStore(pv, ValUint(v))
 			}
 		}
 	case OpAtomicSMax:
@@ -318,26 +317,26 @@ func (interp *interpreter) executeAtomicOp(inst Instruction) Value {
 			v := int32(toUint32(interp.values[inst.Operands[3]]))
 			old := int32(oldVal)
 			if v > old {
-				ptr.Val = ValUint(uint32(v))
+				atomicStore(pv, ValUint(uint32(v)))
 			}
 		}
 	case OpAtomicUMax:
 		if len(inst.Operands) >= 4 {
 			v := toUint32(interp.values[inst.Operands[3]])
 			if v > oldVal {
-				ptr.Val = ValUint(v)
+				atomicStore(pv, ValUint(v))
 			}
 		}
 	case OpAtomicIIncrement:
-		ptr.Val = ValUint(oldVal + 1)
+		atomicStore(pv, ValUint(oldVal+1))
 	case OpAtomicIDecrement:
-		ptr.Val = ValUint(oldVal - 1)
+		atomicStore(pv, ValUint(oldVal-1))
 	case OpAtomicLoad:
 		// Load is just a read -- no modification.
 	case OpAtomicStore:
 		// Store is special: no return value.
 		if len(inst.Operands) >= 4 {
-			ptr.Val = interp.values[inst.Operands[3]]
+			atomicStore(pv, interp.values[inst.Operands[3]])
 		}
 		return Value{}
 	}
@@ -345,6 +344,38 @@ func (interp *interpreter) executeAtomicOp(inst Instruction) Value {
 	return ValUint(oldVal)
 }
 
+// atomicLoad reads the value an atomic operation's pointer refers to. Atomics
+// on storage array elements arrive as sub-pointers or buffer pointers, the
+// same way OpLoad sees them.
+func (interp *interpreter) atomicLoad(pv Value) Value {
+	switch pv.Tag {
+	case TagSubPointer:
+		return subPointerLoad(pv.AsSubPointer())
+	case TagBufferPointer:
+		bp := pv.AsBufferPointer()
+		if bp.Type == nil {
+			return ValUint(0)
+		}
+		return interp.readValueFromBuffer(bp.Buffer, bp.Offset, bp.Type)
+	default:
+		return pv.AsPointer().Val
+	}
+}
+
+// atomicStore writes the result of an atomic operation through its pointer,
+// mirroring OpStore.
+func atomicStore(pv Value, v Value) {
+	switch pv.Tag {
+	case TagSubPointer:
+		subPointerStore(pv.AsSubPointer(), v)
+	case TagBufferPointer:
+		bp := pv.AsBufferPointer()
+		writeValueToBuffer(bp.Buffer, bp.Offset, v)
+	default:
+		pv.AsPointer().Val = v
+	}
+}
+
 // writeStorageBufferBack writes modified storage buffer values back to the
 // context's raw buffer data. Called after compute shader execution to
 // reflect in-memory changes to the bound buffers.
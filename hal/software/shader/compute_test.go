@@ -659,6 +659,61 @@ fn main(@builtin(global_invocation_id) id: vec3<u32>) {
 	}
 }
 
+// TestNagaComputeAtomicStorageArray tests atomics on elements of a storage
+// array, which the interpreter addresses through buffer pointers rather than
+// plain pointers.
+func TestNagaComputeAtomicStorageArray(t *testing.T) {
+	wgsl := `
+@group(0) @binding(0) var<storage, read> input: array<u32>;
+@group(0) @binding(1) var<storage, read_write> bins: array<atomic<u32>>;
+@compute @workgroup_size(8)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    atomicAdd(&bins[input[id.x]], 1u);
+    atomicMax(&bins[3], id.x);
+}
+`
+	spirvBytes, err := naga.Compile(wgsl)
+	if err != nil {
+		t.Fatalf("naga.Compile failed: %v", err)
+	}
+
+	words := make([]uint32, len(spirvBytes)/4)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(spirvBytes[i*4:])
+	}
+
+	m, err := ParseModule(words)
+	if err != nil {
+		t.Fatalf("ParseModule failed: %v", err)
+	}
+
+	values := []uint32{0, 2, 2, 1, 0, 2, 2, 2}
+	inputBuf := make([]byte, len(values)*4)
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(inputBuf[i*4:], v)
+	}
+	binsBuf := make([]byte, 4*4)
+
+	ctx := &ExecutionContext{
+		Buffers: map[BindingKey][]byte{
+			{Group: 0, Binding: 0}: inputBuf,
+			{Group: 0, Binding: 1}: binsBuf,
+		},
+	}
+
+	err = m.DispatchCompute("main", ctx, 1, 1, 1)
+	if err != nil {
+		t.Fatalf("DispatchCompute failed: %v", err)
+	}
+
+	want := []uint32{2, 1, 5, 7}
+	for i, w := range want {
+		if got := binary.LittleEndian.Uint32(binsBuf[i*4:]); got != w {
+			t.Errorf("bins[%d] = %d, want %d", i, got, w)
+		}
+	}
+}
+
 // Ensure the Phase 1 triangle tests still pass.
 func TestTriangleStillWorks(t *testing.T) {
 	words := buildTriangleVertexSPIRV()
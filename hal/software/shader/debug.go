@@ -338,6 +338,8 @@ var opcodeNames = map[uint16]string{
 	OpImageSampleImplicitLod: "OpImageSampleImplicitLod",
 	OpImageSampleExplicitLod: "OpImageSampleExplicitLod",
 	OpImageFetch:             "OpImageFetch",
+	OpImageRead:              "OpImageRead",
+	OpImageWrite:             "OpImageWrite",
 	OpImageQuerySize:         "OpImageQuerySize",
 	OpAtomicLoad:             "OpAtomicLoad",
 	OpAtomicStore:            "OpAtomicStore",
@@ -1226,6 +1226,23 @@ func (interp *interpreter) run() error {
 				interp.values[inst.ResultID] = interp.fetchTexel(imgVal, coord)
 			}
 
+		case OpImageRead:
+			// OpImageRead: type resultID image coordinate [ImageOperands...]
+			// Storage texture load; same addressing as OpImageFetch.
+			if len(inst.Operands) >= 2 {
+				imgVal := interp.values[inst.Operands[0]]
+				coord := interp.values[inst.Operands[1]]
+				interp.values[inst.ResultID] = interp.fetchTexel(imgVal, coord)
+			}
+
+		case OpImageWrite:
+			// OpImageWrite: image coordinate texel [ImageOperands...]
+			if len(inst.Operands) >= 3 {
+				imgVal := interp.values[inst.Operands[0]]
+				coord := interp.values[inst.Operands[1]]
+				interp.writeTexelAt(imgVal, coord, interp.values[inst.Operands[2]])
+			}
+
 		case OpImageQuerySize:
 			// OpImageQuerySize: type resultID image
 			if len(inst.Operands) >= 1 {
@@ -1808,23 +1825,36 @@ func (interp *interpreter) sampleTexture(sampledImg Value, coord Value, lod floa
 		samp = &Sampler{} // Default: nearest, repeat.
 	}
 
-	// Extract UV coordinates.
+	// Extract UV coordinates. Arrayed images carry the layer in the last
+	// coordinate component; cube images take a direction instead of UVs.
 	var u, v float32
-	switch coord.Tag {
-	case TagVec2:
-		u, v = coord.F[0], coord.F[1]
-	case TagVec3:
-		u, v = coord.F[0], coord.F[1]
-	case TagVec4:
-		u, v = coord.F[0], coord.F[1]
+	switch {
+	case tex.Cube && (coord.Tag == TagVec3 || coord.Tag == TagVec4):
+		var face uint32
+		face, u, v = cubeFaceUV(coord.F[0], coord.F[1], coord.F[2])
+		if coord.Tag == TagVec4 {
+			face += 6 * arrayLayerIndex(coord.F[3])
+		}
+		tex = tex.Layer(face)
+		// Faces are clamped, not wrapped: seams come from the neighbouring face.
+		u = applyWrapMode(u, WrapClampToEdge)
+		v = applyWrapMode(v, WrapClampToEdge)
 	default:
-		u = toFloat32(coord)
+		switch coord.Tag {
+		case TagVec2:
+			u, v = coord.F[0], coord.F[1]
+		case TagVec3:
+			u, v = coord.F[0], coord.F[1]
+			tex = tex.Layer(arrayLayerIndex(coord.F[2]))
+		case TagVec4:
+			u, v = coord.F[0], coord.F[1]
+		default:
+			u = toFloat32(coord)
+		}
+		u = applyWrapMode(u, samp.WrapU)
+		v = applyWrapMode(v, samp.WrapV)
 	}
 
-	// Apply wrap mode.
-	u = applyWrapMode(u, samp.WrapU)
-	v = applyWrapMode(v, samp.WrapV)
-
 	// Determine filter from the magnification filter.
 	filter := samp.MagFilter
 	if filter == FilterLinear {
@@ -1833,6 +1863,51 @@ func (interp *interpreter) sampleTexture(sampledImg Value, coord Value, lod floa
 	return ValVec4From(sampleNearest(tex, u, v))
 }
 
+// cubeFaceUV selects the cube face a direction points at and returns the
+// face index (+X, -X, +Y, -Y, +Z, -Z) and the [0,1] UV within that face.
+// Follows the face selection table of the Vulkan spec (cube map face
+// selection and transformations).
+func cubeFaceUV(x, y, z float32) (face uint32, u, v float32) {
+	ax, ay, az := float32(math.Abs(float64(x))), float32(math.Abs(float64(y))), float32(math.Abs(float64(z)))
+	var sc, tc, ma float32
+	switch {
+	case ax >= ay && ax >= az:
+		ma = ax
+		if x >= 0 {
+			face, sc, tc = 0, -z, -y
+		} else {
+			face, sc, tc = 1, z, -y
+		}
+	case ay >= az:
+		ma = ay
+		if y >= 0 {
+			face, sc, tc = 2, x, z
+		} else {
+			face, sc, tc = 3, x, -z
+		}
+	default:
+		ma = az
+		if z >= 0 {
+			face, sc, tc = 4, x, -y
+		} else {
+			face, sc, tc = 5, -x, -y
+		}
+	}
+	if ma == 0 {
+		return 0, 0.5, 0.5
+	}
+	return face, (sc/ma + 1) * 0.5, (tc/ma + 1) * 0.5
+}
+
+// arrayLayerIndex rounds an array layer coordinate to a layer index, as
+// the Vulkan spec prescribes for arrayed image sampling.
+func arrayLayerIndex(layer float32) uint32 {
+	if layer <= 0 {
+		return 0
+	}
+	return uint32(math.RoundToEven(float64(layer)))
+}
+
 // fetchTexel fetches a single texel by integer coordinates (no filtering).
 func (interp *interpreter) fetchTexel(imgVal Value, coord Value) Value {
 	tex := interp.resolveTexture(imgVal)
@@ -1840,16 +1915,9 @@ func (interp *interpreter) fetchTexel(imgVal Value, coord Value) Value {
 		return ValVec4(0, 0, 0, 0)
 	}
 
-	var x, y int
-	switch coord.Tag {
-	case TagVec2:
-		x, y = int(coord.F[0]), int(coord.F[1])
-	case TagVec3:
-		x, y = int(coord.F[0]), int(coord.F[1])
-	case TagVec4:
-		x, y = int(coord.F[0]), int(coord.F[1])
-	default:
-		x = int(toUint32(coord))
+	x, y, z, n := texelCoord(coord)
+	if n == 3 {
+		tex = tex.Layer(uint32(max(z, 0)))
 	}
 
 	// Clamp to texture bounds.
@@ -1869,6 +1937,48 @@ func (interp *interpreter) fetchTexel(imgVal Value, coord Value) Value {
 	return ValVec4From(readTexel(tex, x, y))
 }
 
+// writeTexelAt stores a texel at integer coordinates for a storage texture
+// write. The layer or 3D slice comes from the third coordinate component.
+// Out-of-bounds writes are discarded, as WGSL textureStore requires.
+func (interp *interpreter) writeTexelAt(imgVal Value, coord Value, texel Value) {
+	tex := interp.resolveTexture(imgVal)
+	if tex == nil || len(tex.Data) == 0 {
+		return
+	}
+
+	x, y, z, _ := texelCoord(coord)
+	if x < 0 || y < 0 || z < 0 || x >= int(tex.Width) || y >= int(tex.Height) ||
+		z >= int(max(tex.Layers, tex.Depth, 1)) {
+		return
+	}
+
+	writeTexel(tex.Layer(uint32(z)), x, y, Vec4{texel.F[0], texel.F[1], texel.F[2], texel.F[3]})
+}
+
+// texelCoord extracts integer texel coordinates and the component count
+// from an image coordinate. Integer vectors arrive as arrays of scalars;
+// float vectors are truncated.
+func texelCoord(coord Value) (x, y, z, n int) {
+	var c [3]int
+	switch coord.Tag {
+	case TagVec2, TagVec3, TagVec4:
+		n = int(coord.Tag-TagVec2) + 2
+		for i := range min(n, 3) {
+			c[i] = int(coord.F[i])
+		}
+	case TagArray:
+		elems := coord.AsArray()
+		n = len(elems)
+		for i := range min(n, 3) {
+			c[i] = int(int32(toUint32(elems[i])))
+		}
+	default:
+		n = 1
+		c[0] = int(toUint32(coord))
+	}
+	return c[0], c[1], c[2], n
+}
+
 // queryImageSize returns the size of a texture as a vec2 of uint32 values.
 func (interp *interpreter) queryImageSize(imgVal Value) Value {
 	tex := interp.resolveTexture(imgVal)
@@ -2001,6 +2111,30 @@ func readTexel(tex *Texture2D, x, y int) Vec4 {
 	}
 }
 
+// writeTexel packs c into the texel at (x, y), the inverse of readTexel.
+// Channels are clamped to [0, 1] and stored as 8-bit unorm values.
+func writeTexel(tex *Texture2D, x, y int, c Vec4) {
+	bpp := int(tex.BytesPerPixel)
+	if bpp == 0 {
+		bpp = 4
+	}
+	idx := (y*int(tex.Width) + x) * bpp
+	if idx+bpp > len(tex.Data) {
+		return
+	}
+	if bpp >= 4 && isBGRAFormat(tex.Format) {
+		c[0], c[2] = c[2], c[0]
+	}
+	for i := range min(bpp, 4) {
+		tex.Data[idx+i] = uint8(math.Round(float64(clampUnit(c[i]) * 255)))
+	}
+}
+
+// clampUnit clamps v to [0, 1].
+func clampUnit(v float32) float32 {
+	return min(max(v, 0), 1)
+}
+
 // isBGRAFormat returns true if the format stores bytes in BGRA order.
 func isBGRAFormat(format uint32) bool {
 	return format == uint32(gputypes.TextureFormatBGRA8Unorm) || format == uint32(gputypes.TextureFormatBGRA8UnormSrgb)
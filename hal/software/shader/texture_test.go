@@ -3,6 +3,7 @@
 package shader
 
 import (
+	"bytes"
 	"math"
 	"testing"
 )
@@ -525,3 +526,92 @@ func TestQueryImageSize(t *testing.T) {
 		t.Errorf("queryImageSize(nil) = %v, want {0, 0}", got)
 	}
 }
+
+func TestCubeFaceUV(t *testing.T) {
+	tests := []struct {
+		name    string
+		x, y, z float32
+		face    uint32
+		u, v    float32
+	}{
+		{"+X", 1, 0, 0, 0, 0.5, 0.5},
+		{"-X", -1, 0, 0, 1, 0.5, 0.5},
+		{"+Y", 0, 1, 0, 2, 0.5, 0.5},
+		{"-Y", 0, -1, 0, 3, 0.5, 0.5},
+		{"+Z", 0, 0, 1, 4, 0.5, 0.5},
+		{"-Z", 0, 0, -1, 5, 0.5, 0.5},
+		{"+X corner", 1, -1, -1, 0, 1, 1},
+		{"+Z off-center", -0.5, 0.5, 1, 4, 0.25, 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			face, u, v := cubeFaceUV(tt.x, tt.y, tt.z)
+			if face != tt.face || u != tt.u || v != tt.v {
+				t.Errorf("cubeFaceUV(%v, %v, %v) = (%d, %v, %v), want (%d, %v, %v)",
+					tt.x, tt.y, tt.z, face, u, v, tt.face, tt.u, tt.v)
+			}
+		})
+	}
+}
+
+func TestTexture2DLayer(t *testing.T) {
+	// 1x1 RGBA8 texture with three layers: red, green, blue.
+	tex := &Texture2D{
+		Width: 1, Height: 1, Layers: 3,
+		Data: []byte{
+			255, 0, 0, 255,
+			0, 255, 0, 255,
+			0, 0, 255, 255,
+		},
+	}
+
+	if got := tex.Layer(1).Data; got[1] != 255 || got[0] != 0 {
+		t.Errorf("Layer(1) = %v, want green", got)
+	}
+	// Out-of-range layers clamp to the last layer.
+	if got := tex.Layer(7).Data; got[2] != 255 {
+		t.Errorf("Layer(7) = %v, want blue", got)
+	}
+	single := &Texture2D{Width: 1, Height: 1, Data: []byte{1, 2, 3, 4}}
+	if single.Layer(2) != single {
+		t.Error("Layer on a single-image texture should return the texture itself")
+	}
+}
+
+func TestWriteTexelAt3D(t *testing.T) {
+	// 2x1 RGBA8 texture with two depth slices.
+	tex := &Texture2D{Width: 2, Height: 1, Depth: 2, Data: make([]byte, 2*1*2*4)}
+	bk := BindingKey{Group: 0, Binding: 0}
+	interp := &interpreter{ctx: &ExecutionContext{Textures: map[BindingKey]*Texture2D{bk: tex}}}
+	img := ValBindingKey(bk)
+
+	// Integer vectors are arrays of scalars in the interpreter.
+	coord := ValArray([]Value{ValInt(1), ValInt(0), ValInt(1)})
+	interp.writeTexelAt(img, coord, ValVec4(1, 0.5, 0, 1))
+
+	want := []byte{255, 128, 0, 255}
+	if got := tex.Data[12:16]; !bytes.Equal(got, want) {
+		t.Errorf("texel (1,0,1) = %v, want %v", got, want)
+	}
+	if got := interp.fetchTexel(img, coord).AsVec4(); got[0] != 1 || got[3] != 1 {
+		t.Errorf("fetchTexel(1,0,1) = %v, want the written texel", got)
+	}
+
+	// Out-of-bounds writes are discarded.
+	interp.writeTexelAt(img, ValArray([]Value{ValInt(0), ValInt(0), ValInt(2)}), ValVec4(1, 1, 1, 1))
+	interp.writeTexelAt(img, ValArray([]Value{ValInt(-1), ValInt(0), ValInt(0)}), ValVec4(1, 1, 1, 1))
+	if !bytes.Equal(tex.Data[:12], make([]byte, 12)) {
+		t.Errorf("out-of-bounds write modified texture: %v", tex.Data)
+	}
+}
+
+func TestConvertVectorToFloat(t *testing.T) {
+	got := convertToFloat(ValArray([]Value{ValUint(1), ValUint(2), ValUint(3)}))
+	if got.Tag != TagVec3 || got.AsVec3() != (Vec3{1, 2, 3}) {
+		t.Errorf("convertToFloat(uvec3) = %v, want vec3(1, 2, 3)", got)
+	}
+	got = convertSignedToFloat(ValArray([]Value{ValInt(-1), ValInt(4)}))
+	if got.Tag != TagVec2 || got.AsVec2() != (Vec2{-1, 4}) {
+		t.Errorf("convertSignedToFloat(ivec2) = %v, want vec2(-1, 4)", got)
+	}
+}
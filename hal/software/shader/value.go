@@ -80,6 +80,41 @@ type Texture2D struct {
 	// RG8/R16, 4 for RGBA8/BGRA8). When zero, sampling assumes 4 (RGBA8) for
 	// backward compatibility. Single-channel formats unpack as (r, 0, 0, 1).
 	BytesPerPixel uint32
+	// Layers is the number of Width x Height images stored back to back in
+	// Data (array layers or cube faces). Zero or one means a single image.
+	Layers uint32
+	// Cube marks a cube or cube array view: sampling takes a direction and
+	// picks one of six faces per cube from Layers.
+	Cube bool
+	// Depth is the number of Width x Height slices of a 3D texture, stored
+	// back to back in Data. Zero or one means a 2D texture.
+	Depth uint32
+}
+
+// Layer returns a single-layer texture over layer i of t, or t itself when
+// t holds one image. For 3D textures i selects a depth slice. Out-of-range
+// layers clamp to the last layer.
+func (t *Texture2D) Layer(i uint32) *Texture2D {
+	n := max(t.Layers, t.Depth)
+	if n <= 1 {
+		return t
+	}
+	i = min(i, n-1)
+	bpp := t.BytesPerPixel
+	if bpp == 0 {
+		bpp = 4
+	}
+	layerSize := uint64(t.Width) * uint64(t.Height) * uint64(bpp)
+	start := uint64(i) * layerSize
+	if start+layerSize > uint64(len(t.Data)) {
+		return t
+	}
+	layer := *t
+	layer.Data = t.Data[start : start+layerSize]
+	layer.Layers = 1
+	layer.Depth = 0
+	layer.Cube = false
+	return &layer
 }
 
 // Sampler describes texture sampling parameters.
@@ -453,6 +488,8 @@ func Float32BitsToUint32(f float32) uint32 {
 // convertToFloat converts an unsigned integer value to float32.
 func convertToFloat(val Value) Value {
 	switch val.Tag {
+	case TagArray:
+		return convertVectorToFloat(val, convertToFloat)
 	case TagUint32:
 		return ValFloat(float32(val.U[0]))
 	case TagInt32:
@@ -467,6 +504,8 @@ func convertToFloat(val Value) Value {
 // convertSignedToFloat converts a signed integer value to float32.
 func convertSignedToFloat(val Value) Value {
 	switch val.Tag {
+	case TagArray:
+		return convertVectorToFloat(val, convertSignedToFloat)
 	case TagInt32:
 		return ValFloat(float32(int32(val.U[0])))
 	case TagUint32:
@@ -478,6 +517,20 @@ func convertSignedToFloat(val Value) Value {
 	}
 }
 
+// convertVectorToFloat converts an integer vector, which the interpreter
+// stores as an array of scalars, to a float vector component by component.
+func convertVectorToFloat(val Value, conv func(Value) Value) Value {
+	elems := val.AsArray()
+	if len(elems) < 2 || len(elems) > 4 {
+		return ValFloat(0)
+	}
+	out := Value{Tag: TagVec2 + ValueTag(len(elems)-2)}
+	for i, e := range elems {
+		out.F[i] = conv(e).F[0]
+	}
+	return out
+}
+
 // convertFloatToUint converts a float value to uint32.
 func convertFloatToUint(val Value) Value {
 	switch val.Tag {
@@ -116,6 +116,25 @@ func TestDeviceCreation(t *testing.T) {
 	openDev.Device.Destroy()
 }
 
+func TestDeterministicOption(t *testing.T) {
+	for _, deterministic := range []bool{false, true} {
+		instance, _ := API{}.CreateInstance(&hal.InstanceDescriptor{
+			BackendOptions: hal.BackendOptions{Software: hal.SoftwareOptions{Deterministic: deterministic}},
+		})
+		openDev, err := instance.EnumerateAdapters(nil)[0].Adapter.Open(0, gputypes.DefaultLimits())
+		if err != nil {
+			t.Fatalf("Failed to open device: %v", err)
+		}
+		enc, _ := openDev.Device.CreateCommandEncoder(&hal.CommandEncoderDescriptor{})
+		pass := enc.BeginRenderPass(&hal.RenderPassDescriptor{}).(*RenderPassEncoder)
+		if pass.deterministic != deterministic {
+			t.Errorf("Deterministic %v: render pass deterministic = %v", deterministic, pass.deterministic)
+		}
+		openDev.Device.Destroy()
+		instance.Destroy()
+	}
+}
+
 func TestBufferCreation(t *testing.T) {
 	backend := API{}
 	instance, _ := backend.CreateInstance(&hal.InstanceDescriptor{})
@@ -1010,8 +1029,8 @@ func TestRenderPassEncoderAllNoOps(t *testing.T) {
 	// All no-ops
 	pass.SetPipeline(nil)
 	pass.SetBindGroup(0, nil, nil)
-	pass.SetVertexBuffer(0, buf, 0)
-	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 0)
+	pass.SetVertexBuffer(0, buf, 0, 256)
+	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 0, 256)
 	pass.SetViewport(0, 0, 800, 600, 0, 1)
 	pass.SetScissorRect(0, 0, 800, 600)
 	pass.SetBlendConstant(&gputypes.Color{R: 1, G: 1, B: 1, A: 1})
@@ -23,13 +23,15 @@ const (
 	SurfaceTargetWaylandSurface
 	SurfaceTargetAndroidNativeWindow
 	SurfaceTargetMetalLayer
+	SurfaceTargetUIView
 )
 
 // SurfaceTarget is the typed raw-window contract passed from core to HAL.
-// DisplayHandle is unused for Android and Metal. WindowHandle is HWND, Xlib
-// Window, wl_surface*, ANativeWindow*, or CAMetalLayer* according to Kind.
+// DisplayHandle is unused for Android and Apple targets. WindowHandle is HWND,
+// Xlib Window, wl_surface*, ANativeWindow*, CAMetalLayer*, or UIView*
+// according to Kind.
 // HAL never owns these raw handles; they must outlive the created Surface.
-// Headless is a Go software/noop extension and carries no handles.
+// Headless is a Go software/noop/GLES extension and carries no handles.
 type SurfaceTarget struct {
 	Kind          SurfaceTargetKind
 	DisplayHandle uintptr
@@ -59,6 +61,8 @@ func (k SurfaceTargetKind) String() string {
 		return "Android native window"
 	case SurfaceTargetMetalLayer:
 		return "Metal layer"
+	case SurfaceTargetUIView:
+		return "UIKit view"
 	case SurfaceTargetInvalid:
 		return "invalid"
 	default:
@@ -7,6 +7,7 @@ package vulkan
 
 import (
 	"fmt"
+	"runtime"
 	"unsafe"
 
 	"github.com/go-webgpu/goffi/ffi"
@@ -24,14 +25,16 @@ type Adapter struct {
 }
 
 // Open creates a logical device with the requested features and limits.
-func (a *Adapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
-	return a.open(nil)
+func (a *Adapter) Open(features gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+	return a.open(nil, features)
 }
 
 // open creates a logical device, optionally constraining it to one queue
 // family. Surface-qualified adapters use the constrained path so the queue
 // selected during the surface query is the same queue passed into Open.
-func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
+// Protected memory is only enabled when features requests it, because a
+// protected-capable queue has to be created with the protected flag.
+func (a *Adapter) open(requestedQueueFamily *uint32, features gputypes.Features) (hal.OpenDevice, error) {
 	// Find queue families
 	var queueFamilyCount uint32
 	vkGetPhysicalDeviceQueueFamilyProperties(a.instance, a.physicalDevice, &queueFamilyCount, nil)
@@ -50,6 +53,15 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 	if err != nil {
 		return hal.OpenDevice{}, err
 	}
+	hasProtectedMemory := features.Contains(hal.FeatureProtectedContent)
+	if hasProtectedMemory {
+		if !a.supportsProtectedMemory() {
+			return hal.OpenDevice{}, fmt.Errorf("vulkan: protected memory is not supported")
+		}
+		if queueFamilies[graphicsFamily].QueueFlags&vk.QueueFlags(vk.QueueProtectedBit) == 0 {
+			return hal.OpenDevice{}, fmt.Errorf("vulkan: queue family %d has no protected queue", graphicsFamily)
+		}
+	}
 
 	// Create device with graphics queue
 	queuePriority := float32(1.0)
@@ -59,9 +71,14 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 		QueueCount:       1,
 		PQueuePriorities: &queuePriority,
 	}
+	if hasProtectedMemory {
+		queueCreateInfo.Flags = vk.DeviceQueueCreateFlags(vk.DeviceQueueCreateProtectedBit)
+	}
 
 	// Query supported device extensions to enable optional features.
 	hasIncrementalPresent := false
+	hasSwapchainMaintenance1 := false
+	availableExtensions := make(map[string]struct{})
 	{
 		var extCount uint32
 		a.instance.cmds.EnumerateDeviceExtensionProperties(a.physicalDevice, 0, &extCount, nil)
@@ -70,68 +87,155 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 			a.instance.cmds.EnumerateDeviceExtensionProperties(a.physicalDevice, 0, &extCount, &extProps[0])
 			for i := range extProps {
 				name := cStringToGo(extProps[i].ExtensionName[:])
-				if name == "VK_KHR_incremental_present" {
+				availableExtensions[name] = struct{}{}
+				switch name {
+				case "VK_KHR_incremental_present":
 					hasIncrementalPresent = true
-					break
+				case "VK_EXT_swapchain_maintenance1":
+					hasSwapchainMaintenance1 = true
 				}
 			}
 		}
 	}
 
 	// Required extensions
-	extensions := []string{
-		"VK_KHR_swapchain\x00",
-	}
+	var chain featureChain
+	chain.enableExtensions("VK_KHR_swapchain")
 	// Optional: VK_KHR_incremental_present for damage-aware presentation.
 	// Allows chaining VkPresentRegionsKHR into VkPresentInfoKHR.PNext
 	// so the compositor can skip recompositing unchanged pixels.
 	if hasIncrementalPresent {
-		extensions = append(extensions, "VK_KHR_incremental_present\x00")
+		chain.enableExtensions("VK_KHR_incremental_present")
 	}
-	extensionPtrs := make([]uintptr, len(extensions))
-	for i, ext := range extensions {
-		extensionPtrs[i] = uintptr(unsafe.Pointer(unsafe.StringData(ext)))
+	// Optional: VK_EXT_swapchain_maintenance1 for present fences. Requires the
+	// instance-level VK_EXT_surface_maintenance1 and the feature bit below;
+	// the extension alone does not make VkSwapchainPresentFenceInfoEXT legal.
+	hasSwapchainMaintenance1 = hasSwapchainMaintenance1 &&
+		a.instance.hasSurfaceMaintenance1 &&
+		a.querySwapchainMaintenance1Feature()
+	if hasSwapchainMaintenance1 {
+		chain.enableExtensions("VK_EXT_swapchain_maintenance1")
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceSwapchainMaintenance1FeaturesExt,
+			new(vk.PhysicalDeviceSwapchainMaintenance1FeaturesEXT)).SwapchainMaintenance1 = vk.Bool32(vk.True)
 	}
-
-	// Detect timeline semaphore support (VK-IMPL-001).
-	// Query via PhysicalDeviceVulkan12Features with PNext chain on GetPhysicalDeviceFeatures2.
-	hasTimelineSemaphore := false
-	if a.instance.cmds.HasPhysicalDeviceFeatures2() {
-		var vulkan12Features vk.PhysicalDeviceVulkan12Features
-		vulkan12Features.SType = vk.StructureTypePhysicalDeviceVulkan12Features
-
-		features2 := vk.PhysicalDeviceFeatures2{
-			SType: vk.StructureTypePhysicalDeviceFeatures2,
-			PNext: (*uintptr)(unsafe.Pointer(&vulkan12Features)),
+	// Optional: VK_EXT_conditional_rendering for predicated draws
+	// (hal.FeatureConditionalRendering).
+	hasConditionalRendering := a.supportsConditionalRendering()
+	if hasConditionalRendering {
+		chain.enableExtensions("VK_EXT_conditional_rendering")
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceConditionalRenderingFeaturesExt,
+			new(vk.PhysicalDeviceConditionalRenderingFeaturesEXT)).ConditionalRendering = vk.Bool32(vk.True)
+	}
+	// Optional: the Vulkan 1.1 multiview feature (hal.FeatureMultiview).
+	hasMultiview := a.multiviewViewCount() > 0
+	if hasMultiview {
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceMultiviewFeatures,
+			new(vk.PhysicalDeviceMultiviewFeatures)).Multiview = vk.Bool32(vk.True)
+	}
+	// Optional: VK_KHR_synchronization2 for per-barrier stage masks and
+	// vkQueueSubmit2. Without it barriers use vkCmdPipelineBarrier.
+	hasSynchronization2 := a.supportsSynchronization2()
+	if hasSynchronization2 {
+		chain.enableExtensions("VK_KHR_synchronization2")
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceSynchronization2Features,
+			new(vk.PhysicalDeviceSynchronization2Features)).Synchronization2 = vk.Bool32(vk.True)
+	}
+	// Optional: timeline semaphores (VK-IMPL-001), core in Vulkan 1.2.
+	hasTimelineSemaphore := a.supportsTimelineSemaphore()
+	if hasTimelineSemaphore {
+		chain.vulkan12Features().TimelineSemaphore = vk.Bool32(vk.True)
+	}
+	// Optional: separate depth and stencil layouts, core in Vulkan 1.2 and
+	// VK_KHR_separate_depth_stencil_layouts (which needs
+	// VK_KHR_create_renderpass2) on Vulkan 1.1.
+	hasSeparateDepthStencil, separateDepthStencilExt := a.separateDepthStencilLayoutsSupport()
+	switch {
+	case separateDepthStencilExt:
+		chain.enableExtensions("VK_KHR_create_renderpass2", "VK_KHR_separate_depth_stencil_layouts")
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceSeparateDepthStencilLayoutsFeatures,
+			new(vk.PhysicalDeviceSeparateDepthStencilLayoutsFeatures)).SeparateDepthStencilLayouts = vk.Bool32(vk.True)
+	case hasSeparateDepthStencil:
+		chain.vulkan12Features().SeparateDepthStencilLayouts = vk.Bool32(vk.True)
+	}
+	// VkImageFormatListCreateInfo is core in Vulkan 1.2.
+	hasImageFormatList := a.properties.ApiVersion >= vkMakeVersion(1, 2, 0)
+	if _, ok := availableExtensions["VK_KHR_image_format_list"]; ok && !hasImageFormatList {
+		hasImageFormatList = true
+		chain.enableExtensions("VK_KHR_image_format_list")
+	}
+	// Protected memory is a Vulkan 1.1 feature (hal.FeatureProtectedContent).
+	if hasProtectedMemory {
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceProtectedMemoryFeatures,
+			new(vk.PhysicalDeviceProtectedMemoryFeatures)).ProtectedMemory = vk.Bool32(vk.True)
+	}
+	// Multi-planar formats need the Vulkan 1.1 samplerYcbcrConversion
+	// feature (hal.FeatureTextureFormatNV12, hal.FeatureTextureFormatP010).
+	if features.Contains(hal.FeatureTextureFormatNV12) || features.Contains(hal.FeatureTextureFormatP010) {
+		if !a.supportsYcbcrConversion() {
+			return hal.OpenDevice{}, fmt.Errorf("vulkan: sampler YCbCr conversion is not supported")
 		}
-		a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
-		hasTimelineSemaphore = vulkan12Features.TimelineSemaphore != 0
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceSamplerYcbcrConversionFeatures,
+			new(vk.PhysicalDeviceSamplerYcbcrConversionFeatures)).SamplerYcbcrConversion = vk.Bool32(vk.True)
+	}
+	// hal.FeatureRobustBufferAccess: robustBufferAccess is enabled with the
+	// other core features; robustBufferAccess2 from robustness2 tightens it
+	// so out-of-bounds loads return zero instead of any in-bounds value.
+	if features.Contains(hal.FeatureRobustBufferAccess) {
+		if a.features.RobustBufferAccess == 0 {
+			return hal.OpenDevice{}, fmt.Errorf("vulkan: robust buffer access is not supported")
+		}
+		if ext, sType := a.robustness2Extension(); ext != "" {
+			chain.enableExtensions(ext)
+			linkFeature(&chain, sType, new(vk.PhysicalDeviceRobustness2FeaturesKHR)).RobustBufferAccess2 = vk.Bool32(vk.True)
+		}
+	}
+	// Optional: VK_EXT_host_image_copy lets WriteTexture copy straight from
+	// host memory on UMA devices. Its dependencies are core in Vulkan 1.3 but
+	// the instance targets 1.2, so they are enabled explicitly.
+	hostCopyLayout := a.hostImageCopyLayout()
+	hasHostImageCopy := hostCopyLayout != vk.ImageLayoutUndefined
+	if hasHostImageCopy {
+		chain.enableExtensions("VK_KHR_copy_commands2", "VK_KHR_format_feature_flags2", "VK_EXT_host_image_copy")
+		linkFeature(&chain, vk.StructureTypePhysicalDeviceHostImageCopyFeaturesExt,
+			new(vk.PhysicalDeviceHostImageCopyFeatures)).HostImageCopy = vk.Bool32(vk.True)
+	}
+	// Optional: VK_EXT_memory_budget reports the heap budgets the OS grants
+	// the process (hal.MemoryBudgetReporter).
+	hasMemoryBudget := a.instance.cmds.HasPhysicalDeviceMemoryProperties2() &&
+		a.deviceExtensionSupported("VK_EXT_memory_budget")
+	if hasMemoryBudget {
+		chain.enableExtensions("VK_EXT_memory_budget")
+	}
+	// Optional: exportable timeline semaphores back shared fences
+	// (hal.FenceSharer).
+	var externalSemaphoreType vk.ExternalSemaphoreHandleTypeFlagBits
+	if hasTimelineSemaphore {
+		if ext, handleType := a.externalSemaphoreSupport(); ext != "" {
+			chain.enableExtensions(ext)
+			externalSemaphoreType = handleType
+		}
+	}
+	chain.extensions, err = appendRequestedNames(chain.extensions, a.instance.deviceExtensions, availableExtensions, "device extension")
+	if err != nil {
+		return hal.OpenDevice{}, err
 	}
 
 	// Device create info
 	deviceCreateInfo := vk.DeviceCreateInfo{
-		SType:                   vk.StructureTypeDeviceCreateInfo,
-		QueueCreateInfoCount:    1,
-		PQueueCreateInfos:       &queueCreateInfo,
-		EnabledExtensionCount:   uint32(len(extensions)),
-		PpEnabledExtensionNames: uintptr(unsafe.Pointer(&extensionPtrs[0])),
-		PEnabledFeatures:        &a.features,
-	}
-
-	// Enable timeline semaphore feature if supported.
-	// Vulkan 1.2 requires explicitly enabling features via PNext chain.
-	var vulkan12Enable vk.PhysicalDeviceVulkan12Features
-	if hasTimelineSemaphore {
-		vulkan12Enable.SType = vk.StructureTypePhysicalDeviceVulkan12Features
-		vulkan12Enable.TimelineSemaphore = vk.Bool32(vk.True)
-		deviceCreateInfo.PNext = (*uintptr)(unsafe.Pointer(&vulkan12Enable))
+		SType:                vk.StructureTypeDeviceCreateInfo,
+		QueueCreateInfoCount: 1,
+		PQueueCreateInfos:    &queueCreateInfo,
+		PEnabledFeatures:     &a.features,
 	}
+	extensionPtrs := chain.apply(&deviceCreateInfo)
 
 	var device vk.Device
 	result := vkCreateDevice(a.instance, a.physicalDevice, &deviceCreateInfo, nil, &device)
 	if result != vk.Success {
 		return hal.OpenDevice{}, fmt.Errorf("vulkan: vkCreateDevice failed: %d", result)
 	}
+	runtime.KeepAlive(extensionPtrs)
+	runtime.KeepAlive(&chain)
 
 	// Load device-level commands
 	var deviceCmds vk.Commands
@@ -140,9 +244,23 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 		return hal.OpenDevice{}, fmt.Errorf("vulkan: failed to load device commands: %w", err)
 	}
 
-	// Get queue handle
+	// Get queue handle. A queue created with the protected flag is only
+	// returned by vkGetDeviceQueue2.
 	var queue vk.Queue
-	vkGetDeviceQueue(&deviceCmds, device, graphicsFamily, 0, &queue)
+	if hasProtectedMemory {
+		queueInfo := vk.DeviceQueueInfo2{
+			SType:            vk.StructureTypeDeviceQueueInfo2,
+			Flags:            vk.DeviceQueueCreateFlags(vk.DeviceQueueCreateProtectedBit),
+			QueueFamilyIndex: graphicsFamily,
+		}
+		deviceCmds.GetDeviceQueue2(device, &queueInfo, &queue)
+		if queue == 0 {
+			vkDestroyDevice(device, nil)
+			return hal.OpenDevice{}, fmt.Errorf("vulkan: vkGetDeviceQueue2 returned no protected queue")
+		}
+	} else {
+		vkGetDeviceQueue(&deviceCmds, device, graphicsFamily, 0, &queue)
+	}
 
 	dev := &Device{
 		handle:                     device,
@@ -153,63 +271,158 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 		supportsMultiDrawIndirect:  a.features.MultiDrawIndirect != 0,
 		maxDrawIndirectCount:       a.properties.Limits.MaxDrawIndirectCount,
 		supportsIncrementalPresent: hasIncrementalPresent,
+		supportsPresentFences:      hasSwapchainMaintenance1,
+
+		supportsConditionalRendering: hasConditionalRendering,
+		supportsSynchronization2:     hasSynchronization2 && deviceCmds.HasSynchronization2(),
+		supportsImageFormatList:      hasImageFormatList,
+		supportsMemoryBudget:         hasMemoryBudget,
+		protectedMemory:              hasProtectedMemory,
+		depthStencilLayouts:          depthStencilLayoutsGeneral,
+	}
+	switch {
+	case hasSeparateDepthStencil:
+		dev.depthStencilLayouts = depthStencilLayoutsSeparate
+	case a.properties.ApiVersion >= vkMakeVersion(1, 1, 0):
+		dev.depthStencilLayouts = depthStencilLayoutsCombined
+	}
+	if hasHostImageCopy && deviceCmds.HasHostImageCopy() {
+		dev.hostCopyLayout = hostCopyLayout
+	}
+	if deviceCmds.HasExternalSemaphoreFd() || deviceCmds.HasExternalSemaphoreWin32() {
+		dev.externalSemaphoreType = externalSemaphoreType
+	}
+
+	q, err := dev.initQueue(queue, hasTimelineSemaphore)
+	if err != nil {
+		vkDestroyDevice(device, nil)
+		return hal.OpenDevice{}, err
 	}
 
+	syncMode := "binary fence pool (VK-IMPL-003)"
+	if dev.timelineFence.isTimeline {
+		syncMode = "timeline semaphore (VK-IMPL-001)"
+	}
+	hal.Logger().Info("vulkan: device created",
+		"name", cStringToGo(a.properties.DeviceName[:]),
+		"queueFamily", graphicsFamily,
+		"syncMode", syncMode,
+		"presentFences", hasSwapchainMaintenance1,
+		"synchronization2", dev.supportsSynchronization2,
+		"separateDepthStencilLayouts", hasSeparateDepthStencil,
+		"hostImageCopy", dev.hostCopyLayout != vk.ImageLayoutUndefined,
+		"protectedMemory", hasProtectedMemory,
+		"sharedFences", dev.externalSemaphoreType != 0,
+	)
+
+	return hal.OpenDevice{
+		Device: dev,
+		Queue:  q,
+	}, nil
+}
+
+// initQueue creates the synchronization fence, memory allocator and relay
+// semaphores of a freshly created or adopted device and wraps its queue.
+// On failure everything it created is destroyed; the VkDevice is left to
+// the caller.
+func (d *Device) initQueue(queue vk.Queue, hasTimelineSemaphore bool) (*Queue, error) {
 	// Initialize synchronization fence (VK-IMPL-001 / VK-IMPL-003).
 	// Prefer timeline semaphore (Vulkan 1.2+); fall back to fencePool of binary
-	// VkFences on older drivers. Either way, dev.timelineFence is always set so
+	// VkFences on older drivers. Either way, d.timelineFence is always set so
 	// the rest of the codebase can use a single path without nil checks.
 	if hasTimelineSemaphore {
-		tlFence, err := initTimelineFence(dev.cmds, dev.handle)
+		tlFence, err := initTimelineFence(d.cmds, d.handle)
 		if err != nil {
 			hal.Logger().Warn("vulkan: timeline semaphore feature reported but init failed, using binary fence pool",
 				"error", err,
 			)
-			dev.timelineFence = initBinaryFence()
+			d.timelineFence = initBinaryFence()
 		} else {
-			dev.timelineFence = tlFence
+			d.timelineFence = tlFence
 			hal.Logger().Info("vulkan: using timeline semaphore fence (VK-IMPL-001)")
 		}
 	} else {
-		dev.timelineFence = initBinaryFence()
+		d.timelineFence = initBinaryFence()
 	}
 
 	// Initialize memory allocator
-	if err := dev.initAllocator(); err != nil {
-		dev.timelineFence.destroy(dev.cmds, dev.handle)
-		vkDestroyDevice(device, nil)
-		return hal.OpenDevice{}, fmt.Errorf("vulkan: failed to initialize allocator: %w", err)
+	if err := d.initAllocator(); err != nil {
+		d.timelineFence.destroy(d.cmds, d.handle)
+		return nil, fmt.Errorf("vulkan: failed to initialize allocator: %w", err)
 	}
 
 	// VK-SYNC-001: Create relay semaphores for GPU-side submission ordering.
 	// This ensures consecutive vkQueueSubmit calls execute in order on the GPU,
 	// which is required by the wgpu_hal Queue trait but not guaranteed by Vulkan.
-	relay, err := newRelaySemaphores(dev.cmds, dev.handle)
+	relay, err := newRelaySemaphores(d.cmds, d.handle)
 	if err != nil {
-		dev.allocator.Destroy()
-		dev.timelineFence.destroy(dev.cmds, dev.handle)
-		vkDestroyDevice(device, nil)
-		return hal.OpenDevice{}, fmt.Errorf("vulkan: failed to create relay semaphores: %w", err)
+		d.allocator.Destroy()
+		d.timelineFence.destroy(d.cmds, d.handle)
+		return nil, fmt.Errorf("vulkan: failed to create relay semaphores: %w", err)
 	}
 
 	q := &Queue{
 		handle:      queue,
-		device:      dev,
-		familyIndex: graphicsFamily,
+		device:      d,
+		familyIndex: d.graphicsFamily,
 		relay:       relay,
 	}
 
 	// Store queue reference in device for swapchain synchronization
-	dev.queue = q
+	d.queue = q
+	return q, nil
+}
 
-	syncMode := "binary fence pool (VK-IMPL-003)"
-	if dev.timelineFence.isTimeline {
-		syncMode = "timeline semaphore (VK-IMPL-001)"
+// Adopt wraps a VkDevice another library created on this adapter's
+// physical device, possibly through its own VkInstance.
+//
+// Nothing is known about the features the owner enabled, so the device
+// takes the conservative path everywhere: binary fences instead of timeline
+// semaphores, vkCmdPipelineBarrier, general depth/stencil layouts and no
+// multi-draw indirect. Extension-only paths are used when native lists the
+// extension. Destroy leaves the VkDevice to its owner.
+func (a *Adapter) Adopt(native *hal.NativeDevice, _ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+	if native == nil || native.Device == 0 {
+		return hal.OpenDevice{}, fmt.Errorf("vulkan: native device is nil")
 	}
-	hal.Logger().Info("vulkan: device created",
+	if native.Queue == 0 {
+		return hal.OpenDevice{}, fmt.Errorf("vulkan: native device has no queue")
+	}
+	device := vk.Device(native.Device)
+
+	var deviceCmds vk.Commands
+	if err := deviceCmds.LoadDevice(device); err != nil {
+		return hal.OpenDevice{}, fmt.Errorf("vulkan: failed to load device commands: %w", err)
+	}
+
+	enabled := make(map[string]struct{}, len(native.Extensions))
+	for _, name := range native.Extensions {
+		enabled[name] = struct{}{}
+	}
+	_, hasIncrementalPresent := enabled["VK_KHR_incremental_present"]
+	_, hasImageFormatList := enabled["VK_KHR_image_format_list"]
+
+	dev := &Device{
+		handle:                     device,
+		physicalDevice:             a.physicalDevice,
+		instance:                   a.instance,
+		graphicsFamily:             native.QueueFamilyIndex,
+		cmds:                       &deviceCmds,
+		maxDrawIndirectCount:       a.properties.Limits.MaxDrawIndirectCount,
+		supportsIncrementalPresent: hasIncrementalPresent,
+		supportsImageFormatList:    hasImageFormatList,
+		depthStencilLayouts:        depthStencilLayoutsGeneral,
+		external:                   true,
+	}
+	q, err := dev.initQueue(vk.Queue(native.Queue), false)
+	if err != nil {
+		return hal.OpenDevice{}, err
+	}
+
+	hal.Logger().Info("vulkan: device adopted",
 		"name", cStringToGo(a.properties.DeviceName[:]),
-		"queueFamily", graphicsFamily,
-		"syncMode", syncMode,
+		"queueFamily", native.QueueFamilyIndex,
+		"extensions", len(native.Extensions),
 	)
 
 	return hal.OpenDevice{
@@ -218,6 +431,448 @@ func (a *Adapter) open(requestedQueueFamily *uint32) (hal.OpenDevice, error) {
 	}, nil
 }
 
+// querySwapchainMaintenance1Feature reports whether the physical device
+// exposes the swapchainMaintenance1 feature bit.
+func (a *Adapter) querySwapchainMaintenance1Feature() bool {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	maintenance1 := vk.PhysicalDeviceSwapchainMaintenance1FeaturesEXT{
+		SType: vk.StructureTypePhysicalDeviceSwapchainMaintenance1FeaturesExt,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&maintenance1)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	return maintenance1.SwapchainMaintenance1 != 0
+}
+
+// supportsConditionalRendering reports whether the physical device offers
+// VK_EXT_conditional_rendering with the conditionalRendering feature bit.
+func (a *Adapter) supportsConditionalRendering() bool {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	if !a.deviceExtensionSupported("VK_EXT_conditional_rendering") {
+		return false
+	}
+	conditional := vk.PhysicalDeviceConditionalRenderingFeaturesEXT{
+		SType: vk.StructureTypePhysicalDeviceConditionalRenderingFeaturesExt,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&conditional)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	return conditional.ConditionalRendering != 0
+}
+
+// supportsProtectedMemory reports whether the physical device has the
+// Vulkan 1.1 protectedMemory feature and its first graphics queue family
+// can create protected queues.
+func (a *Adapter) supportsProtectedMemory() bool {
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) || !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	protected := vk.PhysicalDeviceProtectedMemoryFeatures{
+		SType: vk.StructureTypePhysicalDeviceProtectedMemoryFeatures,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&protected)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	if protected.ProtectedMemory == 0 {
+		return false
+	}
+	families, err := a.queueFamilies()
+	if err != nil {
+		return false
+	}
+	family, err := selectGraphicsQueueFamily(families, nil)
+	return err == nil && families[family].QueueFlags&vk.QueueFlags(vk.QueueProtectedBit) != 0
+}
+
+// supportsYcbcrConversion reports whether the physical device has the
+// Vulkan 1.1 samplerYcbcrConversion feature, which multi-planar formats
+// need.
+func (a *Adapter) supportsYcbcrConversion() bool {
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) || !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	ycbcr := vk.PhysicalDeviceSamplerYcbcrConversionFeatures{
+		SType: vk.StructureTypePhysicalDeviceSamplerYcbcrConversionFeatures,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&ycbcr)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	return ycbcr.SamplerYcbcrConversion != 0
+}
+
+// supportsMultiPlanarFormat reports whether the physical device can create
+// and sample images of a multi-planar format (hal.FeatureTextureFormatNV12,
+// hal.FeatureTextureFormatP010).
+func (a *Adapter) supportsMultiPlanarFormat(format gputypes.TextureFormat) bool {
+	if !a.supportsYcbcrConversion() {
+		return false
+	}
+	var props vk.FormatProperties
+	a.instance.cmds.GetPhysicalDeviceFormatProperties(a.physicalDevice, textureFormatToVk(format), &props)
+	return props.OptimalTilingFeatures&vk.FormatFeatureFlags(vk.FormatFeatureSampledImageBit) != 0
+}
+
+// supportsSynchronization2 reports whether the physical device offers
+// VK_KHR_synchronization2 with the synchronization2 feature bit.
+func (a *Adapter) supportsSynchronization2() bool {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	if !a.deviceExtensionSupported("VK_KHR_synchronization2") {
+		return false
+	}
+	sync2 := vk.PhysicalDeviceSynchronization2Features{
+		SType: vk.StructureTypePhysicalDeviceSynchronization2Features,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&sync2)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	return sync2.Synchronization2 != 0
+}
+
+// supportsTimelineSemaphore reports whether the physical device supports
+// the Vulkan 1.2 timelineSemaphore feature (VK-IMPL-001).
+func (a *Adapter) supportsTimelineSemaphore() bool {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false
+	}
+	vulkan12 := vk.PhysicalDeviceVulkan12Features{
+		SType: vk.StructureTypePhysicalDeviceVulkan12Features,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&vulkan12)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	return vulkan12.TimelineSemaphore != 0
+}
+
+// separateDepthStencilLayoutsSupport reports whether the physical device
+// lets the depth and stencil aspects of an image be in different layouts,
+// and whether that needs VK_KHR_separate_depth_stencil_layouts enabled
+// rather than the Vulkan 1.2 feature bit.
+func (a *Adapter) separateDepthStencilLayoutsSupport() (supported, extension bool) {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return false, false
+	}
+	if a.properties.ApiVersion >= vkMakeVersion(1, 2, 0) {
+		vulkan12 := vk.PhysicalDeviceVulkan12Features{
+			SType: vk.StructureTypePhysicalDeviceVulkan12Features,
+		}
+		features2 := vk.PhysicalDeviceFeatures2{
+			SType: vk.StructureTypePhysicalDeviceFeatures2,
+			PNext: (*uintptr)(unsafe.Pointer(&vulkan12)),
+		}
+		a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+		return vulkan12.SeparateDepthStencilLayouts != 0, false
+	}
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) ||
+		!a.deviceExtensionSupported("VK_KHR_create_renderpass2") ||
+		!a.deviceExtensionSupported("VK_KHR_separate_depth_stencil_layouts") {
+		return false, false
+	}
+	separate := vk.PhysicalDeviceSeparateDepthStencilLayoutsFeatures{
+		SType: vk.StructureTypePhysicalDeviceSeparateDepthStencilLayoutsFeatures,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&separate)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	if separate.SeparateDepthStencilLayouts == 0 {
+		return false, false
+	}
+	return true, true
+}
+
+// structureTypePhysicalDeviceRobustness2FeaturesExt is the VK_EXT_robustness2
+// structure type. VK_KHR_robustness2 promoted the structure unchanged under
+// a new type, and the generated bindings only carry the KHR one.
+const structureTypePhysicalDeviceRobustness2FeaturesExt vk.StructureType = 1000286000
+
+// robustness2Extension returns the robustness2 extension to enable for
+// robustBufferAccess2 and the structure type its feature struct uses, or ""
+// when the physical device has neither VK_KHR_robustness2 nor
+// VK_EXT_robustness2 with the feature.
+func (a *Adapter) robustness2Extension() (string, vk.StructureType) {
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return "", 0
+	}
+	for _, ext := range []struct {
+		name  string
+		sType vk.StructureType
+	}{
+		{"VK_KHR_robustness2", vk.StructureTypePhysicalDeviceRobustness2FeaturesKhr},
+		{"VK_EXT_robustness2", structureTypePhysicalDeviceRobustness2FeaturesExt},
+	} {
+		if !a.deviceExtensionSupported(ext.name) {
+			continue
+		}
+		robustness2 := vk.PhysicalDeviceRobustness2FeaturesKHR{SType: ext.sType}
+		features2 := vk.PhysicalDeviceFeatures2{
+			SType: vk.StructureTypePhysicalDeviceFeatures2,
+			PNext: (*uintptr)(unsafe.Pointer(&robustness2)),
+		}
+		a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+		if robustness2.RobustBufferAccess2 != 0 {
+			return ext.name, ext.sType
+		}
+	}
+	return "", 0
+}
+
+// multiviewViewCount returns the most views a multiview render pass may
+// have on the physical device, or 0 when the Vulkan 1.1 multiview feature
+// is missing. The count is capped at 32, the width of a view mask.
+func (a *Adapter) multiviewViewCount() uint32 {
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) || !a.instance.cmds.HasPhysicalDeviceFeatures2() {
+		return 0
+	}
+	multiview := vk.PhysicalDeviceMultiviewFeatures{
+		SType: vk.StructureTypePhysicalDeviceMultiviewFeatures,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&multiview)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	if multiview.Multiview == 0 {
+		return 0
+	}
+	props := vk.PhysicalDeviceMultiviewProperties{
+		SType: vk.StructureTypePhysicalDeviceMultiviewProperties,
+	}
+	props2 := vk.PhysicalDeviceProperties2{
+		SType: vk.StructureTypePhysicalDeviceProperties2,
+		PNext: (*uintptr)(unsafe.Pointer(&props)),
+	}
+	a.instance.cmds.GetPhysicalDeviceProperties2(a.physicalDevice, &props2)
+	return min(props.MaxMultiviewViewCount, 32)
+}
+
+// hostImageCopyLayout returns the layout WriteTexture should copy into with
+// VK_EXT_host_image_copy, or ImageLayoutUndefined when host copies should
+// not be used. Only UMA devices (integrated or CPU) qualify: on discrete
+// GPUs host copies go over the bus and host-transfer images may lose
+// framebuffer compression, so the staging path stays faster there.
+// SHADER_READ_ONLY_OPTIMAL is preferred because it is the layout sampled
+// textures end up in anyway; GENERAL is the fallback.
+func (a *Adapter) hostImageCopyLayout() vk.ImageLayout {
+	switch a.properties.DeviceType {
+	case vk.PhysicalDeviceTypeIntegratedGpu, vk.PhysicalDeviceTypeCpu:
+	default:
+		return vk.ImageLayoutUndefined
+	}
+	if !a.instance.cmds.HasPhysicalDeviceFeatures2() ||
+		!a.instance.cmds.HasPhysicalDeviceImageFormatProperties2() {
+		return vk.ImageLayoutUndefined
+	}
+	for _, ext := range []string{"VK_EXT_host_image_copy", "VK_KHR_copy_commands2", "VK_KHR_format_feature_flags2"} {
+		if !a.deviceExtensionSupported(ext) {
+			return vk.ImageLayoutUndefined
+		}
+	}
+	hostCopy := vk.PhysicalDeviceHostImageCopyFeatures{
+		SType: vk.StructureTypePhysicalDeviceHostImageCopyFeaturesExt,
+	}
+	features2 := vk.PhysicalDeviceFeatures2{
+		SType: vk.StructureTypePhysicalDeviceFeatures2,
+		PNext: (*uintptr)(unsafe.Pointer(&hostCopy)),
+	}
+	a.instance.cmds.GetPhysicalDeviceFeatures2(a.physicalDevice, &features2)
+	if hostCopy.HostImageCopy == 0 {
+		return vk.ImageLayoutUndefined
+	}
+
+	// Two-call idiom: the first query fills in the layout counts.
+	props := vk.PhysicalDeviceHostImageCopyProperties{
+		SType: vk.StructureTypePhysicalDeviceHostImageCopyPropertiesExt,
+	}
+	props2 := vk.PhysicalDeviceProperties2{
+		SType: vk.StructureTypePhysicalDeviceProperties2,
+		PNext: (*uintptr)(unsafe.Pointer(&props)),
+	}
+	a.instance.cmds.GetPhysicalDeviceProperties2(a.physicalDevice, &props2)
+	if props.CopyDstLayoutCount == 0 {
+		return vk.ImageLayoutUndefined
+	}
+	dstLayouts := make([]vk.ImageLayout, props.CopyDstLayoutCount)
+	props.PCopyDstLayouts = &dstLayouts[0]
+	props.CopySrcLayoutCount = 0
+	a.instance.cmds.GetPhysicalDeviceProperties2(a.physicalDevice, &props2)
+	return selectHostCopyLayout(dstLayouts[:props.CopyDstLayoutCount])
+}
+
+// selectHostCopyLayout picks the host copy destination layout from the
+// layouts the device supports, preferring SHADER_READ_ONLY_OPTIMAL.
+func selectHostCopyLayout(supported []vk.ImageLayout) vk.ImageLayout {
+	layout := vk.ImageLayoutUndefined
+	for _, l := range supported {
+		switch l {
+		case vk.ImageLayoutShaderReadOnlyOptimal:
+			return l
+		case vk.ImageLayoutGeneral:
+			layout = l
+		}
+	}
+	return layout
+}
+
+// externalSemaphoreSupport returns the device extension and handle type
+// timeline semaphores are shared with on this platform, opaque file
+// descriptors or opaque NT handles on Windows, or "" when the driver cannot
+// both export and import them.
+func (a *Adapter) externalSemaphoreSupport() (string, vk.ExternalSemaphoreHandleTypeFlagBits) {
+	ext, handleType := "VK_KHR_external_semaphore_fd", vk.ExternalSemaphoreHandleTypeOpaqueFdBit
+	if runtime.GOOS == "windows" {
+		ext, handleType = "VK_KHR_external_semaphore_win32", vk.ExternalSemaphoreHandleTypeOpaqueWin32Bit
+	}
+	if !a.instance.cmds.HasPhysicalDeviceExternalSemaphoreProperties() || !a.deviceExtensionSupported(ext) {
+		return "", 0
+	}
+
+	typeInfo := vk.SemaphoreTypeCreateInfo{
+		SType:         vk.StructureTypeSemaphoreTypeCreateInfo,
+		SemaphoreType: vk.SemaphoreTypeTimeline,
+	}
+	info := vk.PhysicalDeviceExternalSemaphoreInfo{
+		SType:      vk.StructureTypePhysicalDeviceExternalSemaphoreInfo,
+		PNext:      (*uintptr)(unsafe.Pointer(&typeInfo)),
+		HandleType: handleType,
+	}
+	props := vk.ExternalSemaphoreProperties{
+		SType: vk.StructureTypeExternalSemaphoreProperties,
+	}
+	a.instance.cmds.GetPhysicalDeviceExternalSemaphoreProperties(a.physicalDevice, &info, &props)
+
+	want := vk.ExternalSemaphoreFeatureFlags(vk.ExternalSemaphoreFeatureExportableBit | vk.ExternalSemaphoreFeatureImportableBit)
+	if props.ExternalSemaphoreFeatures&want != want {
+		return "", 0
+	}
+	return ext, handleType
+}
+
+// deviceExtensionSupported reports whether the physical device offers the
+// named device extension.
+func (a *Adapter) deviceExtensionSupported(name string) bool {
+	var extCount uint32
+	a.instance.cmds.EnumerateDeviceExtensionProperties(a.physicalDevice, 0, &extCount, nil)
+	if extCount == 0 {
+		return false
+	}
+	extProps := make([]vk.ExtensionProperties, extCount)
+	a.instance.cmds.EnumerateDeviceExtensionProperties(a.physicalDevice, 0, &extCount, &extProps[0])
+	for i := range extProps[:extCount] {
+		if cStringToGo(extProps[i].ExtensionName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredSubgroupOperations are the subgroup operations WGSL's subgroup
+// built-ins lower to: subgroupElect and subgroupBroadcast (basic),
+// subgroupAll/Any (vote), subgroupAdd and friends (arithmetic),
+// subgroupBallot and subgroupBroadcastFirst (ballot), and subgroupShuffle*.
+const requiredSubgroupOperations = vk.SubgroupFeatureFlags(vk.SubgroupFeatureBasicBit |
+	vk.SubgroupFeatureVoteBit |
+	vk.SubgroupFeatureArithmeticBit |
+	vk.SubgroupFeatureBallotBit |
+	vk.SubgroupFeatureShuffleBit |
+	vk.SubgroupFeatureShuffleRelativeBit)
+
+// querySubgroupSizes returns the range of subgroup sizes compute shaders on
+// the physical device may run with, or (0, 0) when they cannot use every
+// subgroup operation WGSL exposes. Subgroup properties are Vulkan 1.1 core;
+// the size range comes from VK_EXT_subgroup_size_control (core in 1.3) and
+// otherwise collapses to the default subgroup size.
+func (a *Adapter) querySubgroupSizes() (minSize, maxSize uint32) {
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) {
+		return 0, 0
+	}
+	subgroup := vk.PhysicalDeviceSubgroupProperties{
+		SType: vk.StructureTypePhysicalDeviceSubgroupProperties,
+	}
+	var sizeControl vk.PhysicalDeviceSubgroupSizeControlProperties
+	if a.properties.ApiVersion >= vkMakeVersion(1, 3, 0) || a.deviceExtensionSupported("VK_EXT_subgroup_size_control") {
+		sizeControl.SType = vk.StructureTypePhysicalDeviceSubgroupSizeControlProperties
+		subgroup.PNext = (*uintptr)(unsafe.Pointer(&sizeControl))
+	}
+	props2 := vk.PhysicalDeviceProperties2{
+		SType: vk.StructureTypePhysicalDeviceProperties2,
+		PNext: (*uintptr)(unsafe.Pointer(&subgroup)),
+	}
+	a.instance.cmds.GetPhysicalDeviceProperties2(a.physicalDevice, &props2)
+	return subgroupSizeRange(&subgroup, &sizeControl)
+}
+
+// queryDetails returns the driver version, the memory heaps and, on Vulkan
+// 1.1 devices, the device UUID and LUID.
+func (a *Adapter) queryDetails() hal.AdapterDetails {
+	var memProps vk.PhysicalDeviceMemoryProperties
+	a.instance.cmds.GetPhysicalDeviceMemoryProperties(a.physicalDevice, &memProps)
+	details := hal.AdapterDetails{
+		DriverVersion: hal.DecodeVulkanDriverVersion(a.properties.VendorID, a.properties.DriverVersion),
+		MemoryHeaps:   memoryHeaps(&memProps),
+	}
+	if a.properties.ApiVersion < vkMakeVersion(1, 1, 0) {
+		return details
+	}
+	id := vk.PhysicalDeviceIDProperties{
+		SType: vk.StructureTypePhysicalDeviceIDProperties,
+	}
+	props2 := vk.PhysicalDeviceProperties2{
+		SType: vk.StructureTypePhysicalDeviceProperties2,
+		PNext: (*uintptr)(unsafe.Pointer(&id)),
+	}
+	a.instance.cmds.GetPhysicalDeviceProperties2(a.physicalDevice, &props2)
+	details.DeviceUUID = id.DeviceUUID
+	if id.DeviceLUIDValid != 0 {
+		details.LUID = id.DeviceLUID
+	}
+	return details
+}
+
+// memoryHeaps converts the heaps of VkPhysicalDeviceMemoryProperties.
+func memoryHeaps(props *vk.PhysicalDeviceMemoryProperties) []hal.MemoryHeap {
+	heaps := make([]hal.MemoryHeap, 0, props.MemoryHeapCount)
+	for _, heap := range props.MemoryHeaps[:props.MemoryHeapCount] {
+		heaps = append(heaps, hal.MemoryHeap{
+			Size:        uint64(heap.Size),
+			DeviceLocal: heap.Flags&vk.MemoryHeapFlags(vk.MemoryHeapDeviceLocalBit) != 0,
+		})
+	}
+	return heaps
+}
+
+// subgroupSizeRange derives the compute subgroup size range from the queried
+// properties. sizeControl is zero when VK_EXT_subgroup_size_control is absent.
+func subgroupSizeRange(subgroup *vk.PhysicalDeviceSubgroupProperties, sizeControl *vk.PhysicalDeviceSubgroupSizeControlProperties) (minSize, maxSize uint32) {
+	if subgroup.SubgroupSize == 0 ||
+		subgroup.SupportedStages&vk.ShaderStageFlags(vk.ShaderStageComputeBit) == 0 ||
+		subgroup.SupportedOperations&requiredSubgroupOperations != requiredSubgroupOperations {
+		return 0, 0
+	}
+	if sizeControl.MinSubgroupSize != 0 && sizeControl.MaxSubgroupSize >= sizeControl.MinSubgroupSize {
+		return sizeControl.MinSubgroupSize, sizeControl.MaxSubgroupSize
+	}
+	return subgroup.SubgroupSize, subgroup.SubgroupSize
+}
+
 // selectGraphicsQueueFamily preserves the exact family chosen during surface
 // qualification, while keeping the ordinary headless path first-graphics.
 func selectGraphicsQueueFamily(families []vk.QueueFamilyProperties, requested *uint32) (uint32, error) {
@@ -256,18 +911,40 @@ func (a *Adapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.T
 	// Use OptimalTilingFeatures for texture capabilities (most common use case)
 	flags := vkFormatFeaturesToHAL(props.OptimalTilingFeatures)
 
-	// Check multisampling support via image format properties
-	// TODO: Query vkGetPhysicalDeviceImageFormatProperties for accurate multisample support
-	// For now, assume common formats support multisampling if they support rendering
+	// Renderable formats multisample at the counts the framebuffer limits
+	// allow for their aspect (Rust wgpu-hal: texture_format_capabilities).
+	var sampleCounts uint32
 	if flags&hal.TextureFormatCapabilityRenderAttachment != 0 {
-		flags |= hal.TextureFormatCapabilityMultisample | hal.TextureFormatCapabilityMultisampleResolve
+		sampleCounts = framebufferSampleCounts(&a.properties.Limits, format)
+		if sampleCounts&^hal.SampleCount1 != 0 {
+			flags |= hal.TextureFormatCapabilityMultisample | hal.TextureFormatCapabilityMultisampleResolve
+		}
 	}
 
 	return hal.TextureFormatCapabilities{
-		Flags: flags,
+		Flags:        flags,
+		SampleCounts: sampleCounts,
 	}
 }
 
+// framebufferSampleCounts returns the sample counts a render attachment of
+// format supports: framebufferColorSampleCounts for color formats, and the
+// intersection of the depth and stencil counts for the aspects a
+// depth/stencil format has.
+func framebufferSampleCounts(limits *vk.PhysicalDeviceLimits, format gputypes.TextureFormat) uint32 {
+	counts := uint32(limits.FramebufferColorSampleCounts)
+	if isDepthStencilFormat(format) {
+		counts = ^uint32(0)
+		if format != gputypes.TextureFormatStencil8 {
+			counts &= uint32(limits.FramebufferDepthSampleCounts)
+		}
+		if hasStencilAspect(format) {
+			counts &= uint32(limits.FramebufferStencilSampleCounts)
+		}
+	}
+	return counts & hal.SampleCountsUpTo(hal.SampleCount16)
+}
+
 // SurfaceCapabilities returns surface capabilities.
 func (a *Adapter) SurfaceCapabilities(surface hal.Surface) *hal.SurfaceCapabilities {
 	vkSurface, ok := surface.(*Surface)
@@ -303,8 +980,8 @@ type qualifiedAdapter struct {
 	snapshot    surfaceSnapshot
 }
 
-func (a *qualifiedAdapter) Open(_ gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
-	return a.base.open(&a.queueFamily)
+func (a *qualifiedAdapter) Open(features gputypes.Features, _ gputypes.Limits) (hal.OpenDevice, error) {
+	return a.base.open(&a.queueFamily, features)
 }
 
 func (a *qualifiedAdapter) TextureFormatCapabilities(format gputypes.TextureFormat) hal.TextureFormatCapabilities {
@@ -425,7 +1102,33 @@ func (a *Adapter) querySurfaceSnapshot(surface *Surface) (surfaceSnapshot, error
 	if err != nil {
 		return surfaceSnapshot{}, err
 	}
-	return makeSurfaceSnapshot(capabilities, formats, presentModes)
+	snapshot, err := makeSurfaceSnapshot(capabilities, formats, presentModes)
+	if err != nil {
+		return surfaceSnapshot{}, err
+	}
+	snapshot.public.Protected = surfaceSupportsProtected(a.instance, a.physicalDevice, surface.handle)
+	return snapshot, nil
+}
+
+// surfaceSupportsProtected reports whether the surface accepts protected
+// swapchains, via VK_KHR_surface_protected_capabilities.
+func surfaceSupportsProtected(instance *Instance, device vk.PhysicalDevice, surface vk.SurfaceKHR) bool {
+	if !instance.hasSurfaceProtectedCapabilities || !instance.cmds.HasSurfaceCapabilities2() {
+		return false
+	}
+	protected := vk.SurfaceProtectedCapabilitiesKHR{
+		SType: vk.StructureTypeSurfaceProtectedCapabilitiesKhr,
+	}
+	capabilities := vk.SurfaceCapabilities2KHR{
+		SType: vk.StructureTypeSurfaceCapabilities2Khr,
+		PNext: (*uintptr)(unsafe.Pointer(&protected)),
+	}
+	info := vk.PhysicalDeviceSurfaceInfo2KHR{
+		SType:   vk.StructureTypePhysicalDeviceSurfaceInfo2Khr,
+		Surface: surface,
+	}
+	result := instance.cmds.GetPhysicalDeviceSurfaceCapabilities2KHR(device, &info, &capabilities)
+	return result == vk.Success && protected.SupportsProtected != 0
 }
 
 func querySurfaceFormats(instance *Instance, device vk.PhysicalDevice, surface vk.SurfaceKHR) ([]vk.SurfaceFormatKHR, error) {
@@ -487,9 +1190,12 @@ func makeSurfaceSnapshot(capabilities vk.SurfaceCapabilitiesKHR, formats []vk.Su
 	}
 
 	public := hal.SurfaceCapabilities{
-		Formats:      make([]gputypes.TextureFormat, 0, len(formats)),
-		PresentModes: make([]hal.PresentMode, 0, len(presentModes)),
-		AlphaModes:   alphaModes,
+		Formats:             make([]gputypes.TextureFormat, 0, len(formats)),
+		PresentModes:        make([]hal.PresentMode, 0, len(presentModes)),
+		AlphaModes:          alphaModes,
+		CurrentTransform:    vkSurfaceTransformToHAL(vk.Flags(capabilities.CurrentTransform)),
+		SupportedTransforms: vkSurfaceTransformToHAL(vk.Flags(capabilities.SupportedTransforms)),
+		Usages:              vkImageUsageToTextureUsage(capabilities.SupportedUsageFlags),
 	}
 	for _, format := range formats {
 		if textureFormat := textureFormatForSurfacePair(format); textureFormat != gputypes.TextureFormatUndefined {
@@ -550,9 +1256,13 @@ func vkCompositeAlphaToHALChecked(flags vk.CompositeAlphaFlagsKHR) []hal.Composi
 
 func cloneSurfaceCapabilities(capabilities hal.SurfaceCapabilities) *hal.SurfaceCapabilities {
 	return &hal.SurfaceCapabilities{
-		Formats:      append([]gputypes.TextureFormat(nil), capabilities.Formats...),
-		PresentModes: append([]hal.PresentMode(nil), capabilities.PresentModes...),
-		AlphaModes:   append([]hal.CompositeAlphaMode(nil), capabilities.AlphaModes...),
+		Formats:             append([]gputypes.TextureFormat(nil), capabilities.Formats...),
+		PresentModes:        append([]hal.PresentMode(nil), capabilities.PresentModes...),
+		AlphaModes:          append([]hal.CompositeAlphaMode(nil), capabilities.AlphaModes...),
+		CurrentTransform:    capabilities.CurrentTransform,
+		SupportedTransforms: capabilities.SupportedTransforms,
+		Protected:           capabilities.Protected,
+		Usages:              capabilities.Usages,
 	}
 }
 
@@ -7,7 +7,9 @@ package vulkan
 
 import (
 	"fmt"
+	"log/slog"
 	"runtime"
+	"slices"
 	"strings"
 	"unsafe"
 
@@ -74,18 +76,67 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	}
 	extensions = append(extensions, selectAvailableExtensions(platformSurfaceExtensions(), availableExtensions)...)
 
+	// Optional: VK_EXT_surface_maintenance1 (and its VK_KHR_get_surface_capabilities2
+	// dependency) is the instance half of VK_EXT_swapchain_maintenance1, which
+	// provides per-present fences for swapchain resource lifetime tracking.
+	surfaceMaintenance := surfaceMaintenanceExtensions(availableExtensions)
+	extensions = append(extensions, surfaceMaintenance...)
+
+	// Optional: VK_KHR_surface_protected_capabilities reports which surfaces
+	// accept protected swapchains (hal.FeatureProtectedContent).
+	surfaceProtected := surfaceProtectedExtensions(availableExtensions, extensions)
+	extensions = append(extensions, surfaceProtected...)
+
 	// Optional: validation layers for debug (only if available)
 	var layers []string
 	var validationEnabled bool
-	if desc != nil && desc.Flags&gputypes.InstanceFlagsDebug != 0 {
-		if isLayerAvailable(cmds, "VK_LAYER_KHRONOS_validation") {
-			layers = append(layers, "VK_LAYER_KHRONOS_validation\x00")
+	flags := validationFlags(desc)
+	if flags&gputypes.InstanceFlagsDebug != 0 {
+		if isLayerAvailable(cmds, validationLayerName) {
+			layers = append(layers, validationLayerName+"\x00")
 			extensions = append(extensions, "VK_EXT_debug_utils\x00")
 			validationEnabled = true
 		}
 		// Silently skip if validation layers not installed (Vulkan SDK not present)
 	}
 
+	// Optional: synchronization and GPU-assisted validation. The layer
+	// provides VK_EXT_validation_features itself; its findings arrive
+	// through the same debug messenger as the basic checks.
+	var validationFeatures vk.ValidationFeaturesEXT
+	featureEnables := validationFeatureEnables(flags)
+	if validationEnabled && len(featureEnables) > 0 {
+		layerExtensions, err := enumerateLayerExtensions(cmds, validationLayerName)
+		if _, ok := layerExtensions[extensionValidationFeatures]; err == nil && ok {
+			extensions = append(extensions, extensionValidationFeatures+"\x00")
+			validationFeatures = vk.ValidationFeaturesEXT{
+				SType:                         vk.StructureTypeValidationFeaturesExt,
+				EnabledValidationFeatureCount: uint32(len(featureEnables)),
+				PEnabledValidationFeatures:    &featureEnables[0],
+			}
+		} else {
+			hal.Logger().Warn("vulkan: validation layer lacks "+extensionValidationFeatures+
+				", synchronization and GPU-assisted validation stay off", "err", err)
+			featureEnables = nil
+		}
+	}
+
+	// Extensions and layers requested through hal.VulkanOptions.
+	var vkOpts hal.VulkanOptions
+	if desc != nil {
+		vkOpts = desc.BackendOptions.Vulkan
+	}
+	extensions, err = appendRequestedNames(extensions, vkOpts.InstanceExtensions, availableExtensions, "instance extension")
+	if err != nil {
+		return nil, err
+	}
+	if len(vkOpts.InstanceLayers) > 0 {
+		layers, err = appendRequestedNames(layers, vkOpts.InstanceLayers, enumerateInstanceLayers(cmds), "instance layer")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert to C strings
 	extensionPtrs := make([]uintptr, len(extensions))
 	for i, ext := range extensions {
@@ -111,6 +162,9 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	if len(layerPtrs) > 0 {
 		createInfo.PpEnabledLayerNames = uintptr(unsafe.Pointer(&layerPtrs[0]))
 	}
+	if len(featureEnables) > 0 {
+		createInfo.PNext = (*uintptr)(unsafe.Pointer(&validationFeatures))
+	}
 
 	var instance vk.Instance
 	result := cmds.CreateInstance(&createInfo, nil, &instance)
@@ -135,12 +189,21 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 	runtime.KeepAlive(layers)
 	runtime.KeepAlive(extensionPtrs)
 	runtime.KeepAlive(layerPtrs)
+	runtime.KeepAlive(featureEnables)
 
 	inst := &Instance{
 		handle:       instance,
 		cmds:         *cmds,
 		debugEnabled: validationEnabled,
 		platform:     platform,
+
+		deviceExtensions: vkOpts.DeviceExtensions,
+
+		hasSurfaceMaintenance1:          len(surfaceMaintenance) > 0,
+		hasSurfaceProtectedCapabilities: len(surfaceProtected) > 0,
+	}
+	if desc != nil {
+		inst.log = desc.Log
 	}
 
 	// Create debug messenger when validation layers are active.
@@ -149,9 +212,11 @@ func (Backend) CreateInstance(desc *hal.InstanceDescriptor) (hal.Instance, error
 		inst.debugMessenger = createDebugMessenger(inst)
 	}
 
-	hal.Logger().Info("vulkan: instance created",
+	inst.logger(hal.LogAdapter).Info("vulkan: instance created",
 		"apiVersion", fmt.Sprintf("%d.%d.%d", vkVersionMajor(appInfo.ApiVersion), vkVersionMinor(appInfo.ApiVersion), vkVersionPatch(appInfo.ApiVersion)),
 		"validation", validationEnabled,
+		"syncValidation", slices.Contains(featureEnables, vk.ValidationFeatureEnableSynchronizationValidationExt),
+		"gpuAssistedValidation", slices.Contains(featureEnables, vk.ValidationFeatureEnableGpuAssistedExt),
 	)
 
 	return inst, nil
@@ -164,6 +229,21 @@ type Instance struct {
 	debugMessenger vk.DebugUtilsMessengerEXT
 	debugEnabled   bool
 	platform       platformInstanceState
+	log            *hal.Log
+
+	// hasSurfaceMaintenance1 is true when VK_EXT_surface_maintenance1 is
+	// enabled. Devices may only enable VK_EXT_swapchain_maintenance1 when the
+	// instance extension is present.
+	hasSurfaceMaintenance1 bool
+
+	// hasSurfaceProtectedCapabilities is true when
+	// VK_KHR_surface_protected_capabilities is enabled, so surface queries can
+	// report whether protected swapchains are supported.
+	hasSurfaceProtectedCapabilities bool
+
+	// deviceExtensions are the hal.VulkanOptions device extensions every
+	// opened device enables.
+	deviceExtensions []string
 }
 
 // EnumerateAdapters returns available Vulkan adapters (physical devices).
@@ -180,7 +260,8 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 	i.cmds.EnumeratePhysicalDevices(i.handle, &count, &devices[0])
 
 	adapters := make([]hal.ExposedAdapter, 0, count)
-	hal.Logger().Debug("vulkan: enumerating adapters", "count", count)
+	log := i.logger(hal.LogAdapter)
+	log.Debug("vulkan: enumerating adapters", "count", count)
 	for _, device := range devices {
 		// Get device properties
 		var props vk.PhysicalDeviceProperties
@@ -190,21 +271,23 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 		var features vk.PhysicalDeviceFeatures
 		i.cmds.GetPhysicalDeviceFeatures(device, &features)
 
-		// Convert device type
-		deviceType := gputypes.DeviceTypeOther
+		// Integrated and CPU devices allocate from system memory, so
+		// host-visible device memory is unified memory.
+		var downlevelFlags hal.DownlevelFlags
 		switch props.DeviceType {
-		case vk.PhysicalDeviceTypeDiscreteGpu:
-			deviceType = gputypes.DeviceTypeDiscreteGPU
-		case vk.PhysicalDeviceTypeIntegratedGpu:
-			deviceType = gputypes.DeviceTypeIntegratedGPU
-		case vk.PhysicalDeviceTypeVirtualGpu:
-			deviceType = gputypes.DeviceTypeVirtualGPU
-		case vk.PhysicalDeviceTypeCpu:
-			deviceType = gputypes.DeviceTypeCPU
+		case vk.PhysicalDeviceTypeIntegratedGpu, vk.PhysicalDeviceTypeCpu:
+			downlevelFlags |= hal.DownlevelFlagsUnifiedMemory
 		}
 
-		// Extract device name
-		deviceName := cStringToGo(props.DeviceName[:])
+		if features.ImageCubeArray != 0 {
+			downlevelFlags |= hal.DownlevelFlagsCubeArrayTextures
+		}
+		if hasLazilyAllocatedMemory(&i.cmds, device) {
+			downlevelFlags |= hal.DownlevelFlagsTransientAttachments
+		}
+		// vkCmdCopyImage copies between any size-compatible formats,
+		// compressed or not, with the extent in source texels.
+		downlevelFlags |= hal.DownlevelFlagsTextureCopyReinterpret
 
 		adapter := &Adapter{
 			instance:       i,
@@ -213,39 +296,52 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 			features:       features,
 		}
 
+		halFeatures := featuresFromPhysicalDevice(&features)
+		if adapter.supportsConditionalRendering() {
+			halFeatures |= gputypes.Features(hal.FeatureConditionalRendering)
+		}
+		maxViews := adapter.multiviewViewCount()
+		if maxViews > 0 {
+			halFeatures.Insert(hal.FeatureMultiview)
+		}
+		if adapter.supportsProtectedMemory() {
+			halFeatures.Insert(hal.FeatureProtectedContent)
+		}
+		if adapter.supportsMultiPlanarFormat(hal.TextureFormatNV12) {
+			halFeatures.Insert(hal.FeatureTextureFormatNV12)
+		}
+		if adapter.supportsMultiPlanarFormat(hal.TextureFormatP010) {
+			halFeatures.Insert(hal.FeatureTextureFormatP010)
+		}
+		subgroupMin, subgroupMax := adapter.querySubgroupSizes()
+		if subgroupMax != 0 {
+			halFeatures.Insert(gputypes.FeatureSubgroupOperations)
+			halFeatures.Insert(gputypes.FeatureSubgroupBarrier)
+		}
+
+		info := adapterInfo(&props)
 		adapterForExpose := hal.Adapter(adapter)
 		if surfaceHint != nil {
 			qualified, err := adapter.QualifySurface(surfaceHint)
 			if err != nil {
-				hal.Logger().Debug("vulkan: adapter rejected surface hint", "name", deviceName, "error", err)
+				log.Debug("vulkan: adapter rejected surface hint", "name", info.Name, "error", err)
 				continue
 			}
 			adapterForExpose = qualified
 		}
 
-		hal.Logger().Info("vulkan: adapter found",
-			"name", deviceName,
-			"type", deviceType,
-			"vendor", vendorIDToName(props.VendorID),
+		log.Info("vulkan: adapter found",
+			"name", info.Name,
+			"type", info.DeviceType,
+			"vendor", info.Vendor,
 			"apiVersion", fmt.Sprintf("%d.%d.%d", vkVersionMajor(props.ApiVersion), vkVersionMinor(props.ApiVersion), vkVersionPatch(props.ApiVersion)),
 		)
 
 		adapters = append(adapters, hal.ExposedAdapter{
-			Adapter: adapterForExpose,
-			Info: gputypes.AdapterInfo{
-				Name:       deviceName,
-				Vendor:     vendorIDToName(props.VendorID),
-				VendorID:   props.VendorID,
-				DeviceID:   props.DeviceID,
-				DeviceType: deviceType,
-				Driver:     "Vulkan",
-				DriverInfo: fmt.Sprintf("Vulkan %d.%d.%d",
-					vkVersionMajor(props.ApiVersion),
-					vkVersionMinor(props.ApiVersion),
-					vkVersionPatch(props.ApiVersion)),
-				Backend: gputypes.BackendVulkan,
-			},
-			Features: featuresFromPhysicalDevice(&features),
+			Adapter:  adapterForExpose,
+			Info:     info,
+			Details:  adapter.queryDetails(),
+			Features: halFeatures,
 			Capabilities: hal.Capabilities{
 				Limits: limitsFromProps(&props),
 				AlignmentsMask: hal.Alignments{
@@ -254,8 +350,11 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 				},
 				DownlevelCapabilities: hal.DownlevelCapabilities{
 					ShaderModel: 60, // SM6.0 equivalent
-					Flags:       0,
+					Flags:       downlevelFlags,
 				},
+				SubgroupMinSize:       subgroupMin,
+				SubgroupMaxSize:       subgroupMax,
+				MaxMultiviewViewCount: maxViews,
 			},
 		})
 	}
@@ -263,6 +362,67 @@ func (i *Instance) EnumerateAdapters(surfaceHint hal.Surface) []hal.ExposedAdapt
 	return adapters
 }
 
+// DescribeAdapters lists the physical devices from their properties and
+// memory heaps alone, without querying features, limits or surface support.
+func (i *Instance) DescribeAdapters() []hal.AdapterDescription {
+	var count uint32
+	i.cmds.EnumeratePhysicalDevices(i.handle, &count, nil)
+	if count == 0 {
+		return nil
+	}
+	devices := make([]vk.PhysicalDevice, count)
+	i.cmds.EnumeratePhysicalDevices(i.handle, &count, &devices[0])
+
+	descriptions := make([]hal.AdapterDescription, 0, count)
+	for _, device := range devices[:count] {
+		adapter := &Adapter{instance: i, physicalDevice: device}
+		i.cmds.GetPhysicalDeviceProperties(device, &adapter.properties)
+		descriptions = append(descriptions, hal.AdapterDescription{
+			Info:    adapterInfo(&adapter.properties),
+			Details: adapter.queryDetails(),
+		})
+	}
+	return descriptions
+}
+
+// adapterInfo builds the AdapterInfo of a physical device from its
+// properties.
+func adapterInfo(props *vk.PhysicalDeviceProperties) gputypes.AdapterInfo {
+	deviceType := gputypes.DeviceTypeOther
+	switch props.DeviceType {
+	case vk.PhysicalDeviceTypeDiscreteGpu:
+		deviceType = gputypes.DeviceTypeDiscreteGPU
+	case vk.PhysicalDeviceTypeIntegratedGpu:
+		deviceType = gputypes.DeviceTypeIntegratedGPU
+	case vk.PhysicalDeviceTypeVirtualGpu:
+		deviceType = gputypes.DeviceTypeVirtualGPU
+	case vk.PhysicalDeviceTypeCpu:
+		deviceType = gputypes.DeviceTypeCPU
+	}
+	return gputypes.AdapterInfo{
+		Name:       cStringToGo(props.DeviceName[:]),
+		Vendor:     vendorIDToName(props.VendorID),
+		VendorID:   props.VendorID,
+		DeviceID:   props.DeviceID,
+		DeviceType: deviceType,
+		Driver:     "Vulkan",
+		DriverInfo: fmt.Sprintf("Vulkan %d.%d.%d",
+			vkVersionMajor(props.ApiVersion),
+			vkVersionMinor(props.ApiVersion),
+			vkVersionPatch(props.ApiVersion)),
+		Backend: gputypes.BackendVulkan,
+	}
+}
+
+// logger returns the instance's logger for category c. It is safe to call
+// on a nil Instance, which logs through the package logger.
+func (i *Instance) logger(c hal.LogCategory) *slog.Logger {
+	if i == nil {
+		return hal.CategoryLogger(c)
+	}
+	return i.log.For(c)
+}
+
 // Destroy releases the Vulkan instance.
 func (i *Instance) Destroy() {
 	if i.handle != 0 {
@@ -306,7 +466,7 @@ func (s *Surface) Configure(device hal.Device, config *hal.SurfaceConfiguration)
 	if !ok {
 		return fmt.Errorf("vulkan: device is not a Vulkan device")
 	}
-	hal.Logger().Info("vulkan: surface configuring",
+	s.instance.logger(hal.LogSwapchain).Info("vulkan: surface configuring",
 		"width", config.Width,
 		"height", config.Height,
 		"format", config.Format,
@@ -347,7 +507,7 @@ func (s *Surface) Unconfigure(_ hal.Device) {
 	if s.swapchain != nil {
 		swapchain := s.swapchain
 		if err := swapchain.destroyWithError(); err != nil {
-			hal.Logger().Error("vulkan: failed to destroy swapchain during unconfigure", "error", err)
+			s.instance.logger(hal.LogSwapchain).Error("vulkan: failed to destroy swapchain during unconfigure", "error", err)
 		}
 		s.detachSwapchainFromQueue(swapchain)
 		s.swapchain = nil
@@ -416,7 +576,7 @@ func (s *Surface) Destroy() {
 	if s.swapchain != nil {
 		swapchain := s.swapchain
 		if err := swapchain.destroyWithError(); err != nil {
-			hal.Logger().Error("vulkan: failed to destroy swapchain", "error", err)
+			s.instance.logger(hal.LogSwapchain).Error("vulkan: failed to destroy swapchain", "error", err)
 		}
 		s.detachSwapchainFromQueue(swapchain)
 		s.swapchain = nil
@@ -533,6 +693,11 @@ func featuresFromPhysicalDevice(features *vk.PhysicalDeviceFeatures) gputypes.Fe
 		result |= gputypes.Features(gputypes.FeatureDepthClipControl)
 	}
 
+	// Rasterization features
+	if features.FillModeNonSolid != 0 {
+		result |= gputypes.Features(hal.FeaturePolygonModeLine | hal.FeaturePolygonModePoint)
+	}
+
 	// Shader features
 	if features.ShaderFloat64 != 0 {
 		result |= gputypes.Features(gputypes.FeatureShaderFloat64)
@@ -543,6 +708,11 @@ func featuresFromPhysicalDevice(features *vk.PhysicalDeviceFeatures) gputypes.Fe
 		result |= gputypes.Features(gputypes.FeaturePipelineStatisticsQuery)
 	}
 
+	// Robustness features
+	if features.RobustBufferAccess != 0 {
+		result |= gputypes.Features(hal.FeatureRobustBufferAccess)
+	}
+
 	// Depth32FloatStencil8 is always available in Vulkan 1.0+
 	result |= gputypes.Features(gputypes.FeatureDepth32FloatStencil8)
 
@@ -600,32 +770,61 @@ func limitsFromProps(props *vk.PhysicalDeviceProperties) gputypes.Limits {
 
 // isLayerAvailable checks if a Vulkan instance layer is available.
 // Used to gracefully skip validation layers when Vulkan SDK is not installed.
+// hasLazilyAllocatedMemory reports whether device offers a LAZILY_ALLOCATED
+// memory type, which tile-based GPUs use to keep transient attachments in
+// tile memory.
+func hasLazilyAllocatedMemory(cmds *vk.Commands, device vk.PhysicalDevice) bool {
+	var props vk.PhysicalDeviceMemoryProperties
+	cmds.GetPhysicalDeviceMemoryProperties(device, &props)
+	for _, mt := range props.MemoryTypes[:props.MemoryTypeCount] {
+		if mt.PropertyFlags&vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func isLayerAvailable(cmds *vk.Commands, layerName string) bool {
-	// Get layer count
+	_, ok := enumerateInstanceLayers(cmds)[layerName]
+	return ok
+}
+
+// enumerateInstanceLayers returns the names of the available instance
+// layers.
+func enumerateInstanceLayers(cmds *vk.Commands) map[string]struct{} {
 	var count uint32
 	cmds.EnumerateInstanceLayerProperties(&count, nil)
 	if count == 0 {
-		return false
+		return map[string]struct{}{}
 	}
 
-	// Get layer properties
 	layers := make([]vk.LayerProperties, count)
 	cmds.EnumerateInstanceLayerProperties(&count, &layers[0])
 
-	// Check if requested layer is available
-	for i := range layers {
-		name := cStringToGo(layers[i].LayerName[:])
-		if name == layerName {
-			return true
-		}
+	available := make(map[string]struct{}, count)
+	for i := range layers[:count] {
+		available[cStringToGo(layers[i].LayerName[:])] = struct{}{}
 	}
-	return false
+	return available
 }
 
 func enumerateInstanceExtensions(cmds *vk.Commands) (map[string]struct{}, error) {
+	return enumerateLayerExtensions(cmds, "")
+}
+
+// enumerateLayerExtensions returns the names of the instance extensions
+// that layer provides, or of those the loader and drivers provide when
+// layer is empty.
+func enumerateLayerExtensions(cmds *vk.Commands, layer string) (map[string]struct{}, error) {
+	var layerName uintptr
+	if layer != "" {
+		terminated := append([]byte(layer), 0)
+		defer runtime.KeepAlive(terminated)
+		layerName = uintptr(unsafe.Pointer(&terminated[0]))
+	}
 	for range 3 {
 		var count uint32
-		result := cmds.EnumerateInstanceExtensionProperties(0, &count, nil)
+		result := cmds.EnumerateInstanceExtensionProperties(layerName, &count, nil)
 		if result != vk.Success && result != vk.Incomplete {
 			return nil, fmt.Errorf("vkEnumerateInstanceExtensionProperties(count) failed: %d", result)
 		}
@@ -634,7 +833,7 @@ func enumerateInstanceExtensions(cmds *vk.Commands) (map[string]struct{}, error)
 		}
 
 		properties := make([]vk.ExtensionProperties, count)
-		result = cmds.EnumerateInstanceExtensionProperties(0, &count, &properties[0])
+		result = cmds.EnumerateInstanceExtensionProperties(layerName, &count, &properties[0])
 		if result == vk.Incomplete {
 			continue
 		}
@@ -650,6 +849,36 @@ func enumerateInstanceExtensions(cmds *vk.Commands) (map[string]struct{}, error)
 	return nil, fmt.Errorf("vkEnumerateInstanceExtensionProperties remained incomplete")
 }
 
+// surfaceMaintenanceExtensions returns the instance extensions required by
+// VK_EXT_swapchain_maintenance1, or nil when the loader lacks either of them.
+func surfaceMaintenanceExtensions(available map[string]struct{}) []string {
+	candidates := []string{
+		"VK_KHR_get_surface_capabilities2\x00",
+		"VK_EXT_surface_maintenance1\x00",
+	}
+	selected := selectAvailableExtensions(candidates, available)
+	if len(selected) != len(candidates) {
+		return nil
+	}
+	return selected
+}
+
+// surfaceProtectedExtensions returns the instance extensions needed to query
+// VkSurfaceProtectedCapabilitiesKHR that are not already in enabled, or nil
+// when the loader lacks them.
+func surfaceProtectedExtensions(available map[string]struct{}, enabled []string) []string {
+	candidates := []string{
+		"VK_KHR_get_surface_capabilities2\x00",
+		"VK_KHR_surface_protected_capabilities\x00",
+	}
+	if len(selectAvailableExtensions(candidates, available)) != len(candidates) {
+		return nil
+	}
+	return slices.DeleteFunc(candidates, func(name string) bool {
+		return slices.Contains(enabled, name)
+	})
+}
+
 func selectAvailableExtensions(candidates []string, available map[string]struct{}) []string {
 	selected := make([]string, 0, len(candidates))
 	for _, candidate := range candidates {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/vulkan/vk"
 )
 
@@ -551,6 +552,20 @@ func TestFeaturesFromPhysicalDevice(t *testing.T) {
 			},
 			want: gputypes.Features(gputypes.FeaturePipelineStatisticsQuery) | gputypes.Features(gputypes.FeatureDepth32FloatStencil8),
 		},
+		{
+			name: "robust buffer access",
+			features: vk.PhysicalDeviceFeatures{
+				RobustBufferAccess: 1,
+			},
+			want: gputypes.Features(hal.FeatureRobustBufferAccess) | gputypes.Features(gputypes.FeatureDepth32FloatStencil8),
+		},
+		{
+			name: "fill mode non-solid",
+			features: vk.PhysicalDeviceFeatures{
+				FillModeNonSolid: 1,
+			},
+			want: gputypes.Features(hal.FeaturePolygonModeLine|hal.FeaturePolygonModePoint) | gputypes.Features(gputypes.FeatureDepth32FloatStencil8),
+		},
 		{
 			name: "all features",
 			features: vk.PhysicalDeviceFeatures{
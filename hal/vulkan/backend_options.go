@@ -0,0 +1,29 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"fmt"
+	"slices"
+)
+
+// appendRequestedNames merges the extension or layer names a caller
+// requested through hal.VulkanOptions into enabled, the NUL-terminated names
+// the backend selected itself. Names already enabled are skipped; a name
+// missing from available is an error naming kind.
+func appendRequestedNames(enabled, requested []string, available map[string]struct{}, kind string) ([]string, error) {
+	for _, name := range requested {
+		terminated := name + "\x00"
+		if slices.Contains(enabled, terminated) {
+			continue
+		}
+		if _, ok := available[name]; !ok {
+			return nil, fmt.Errorf("vulkan: requested %s %s is not available", kind, name)
+		}
+		enabled = append(enabled, terminated)
+	}
+	return enabled, nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !(js && wasm)
+
+package vulkan
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAppendRequestedNames(t *testing.T) {
+	available := map[string]struct{}{"VK_KHR_surface": {}, "VK_EXT_debug_report": {}}
+	enabled := []string{"VK_KHR_surface\x00"}
+
+	got, err := appendRequestedNames(enabled, []string{"VK_KHR_surface", "VK_EXT_debug_report"}, available, "instance extension")
+	if err != nil {
+		t.Fatalf("appendRequestedNames: %v", err)
+	}
+	if want := []string{"VK_KHR_surface\x00", "VK_EXT_debug_report\x00"}; !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	_, err = appendRequestedNames(enabled, []string{"VK_EXT_missing"}, available, "device extension")
+	if err == nil || !strings.Contains(err.Error(), "device extension VK_EXT_missing") {
+		t.Errorf("missing extension error = %v", err)
+	}
+}
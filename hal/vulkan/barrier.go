@@ -0,0 +1,427 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !(js && wasm)
+
+package vulkan
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+// Barriers are built as synchronization2 structures, which carry stage masks
+// per resource. Devices without VK_KHR_synchronization2 get the same
+// dependency through vkCmdPipelineBarrier with the stage masks merged.
+
+// shaderStages2 covers every shader stage a WebGPU binding can be visible to.
+const shaderStages2 = vk.PipelineStageFlags2(vk.PipelineStage2VertexShaderBit |
+	vk.PipelineStage2FragmentShaderBit |
+	vk.PipelineStage2ComputeShaderBit)
+
+// pipelineBarrier records one dependency made of the given barriers.
+func pipelineBarrier(d *Device, cmdBuffer vk.CommandBuffer,
+	memory []vk.MemoryBarrier2, buffers []vk.BufferMemoryBarrier2, images []vk.ImageMemoryBarrier2) {
+	if len(memory)+len(buffers)+len(images) == 0 {
+		return
+	}
+	if log := d.logger(hal.LogBarrier); log.Enabled(context.Background(), slog.LevelDebug) {
+		log.Debug("vulkan: pipeline barrier",
+			"memory", len(memory),
+			"buffers", len(buffers),
+			"images", len(images),
+			"sync2", d.supportsSynchronization2,
+		)
+	}
+
+	if d.supportsSynchronization2 {
+		dep := vk.DependencyInfo{SType: vk.StructureTypeDependencyInfo}
+		if len(memory) > 0 {
+			dep.MemoryBarrierCount = uint32(len(memory))
+			dep.PMemoryBarriers = &memory[0]
+		}
+		if len(buffers) > 0 {
+			dep.BufferMemoryBarrierCount = uint32(len(buffers))
+			dep.PBufferMemoryBarriers = &buffers[0]
+		}
+		if len(images) > 0 {
+			dep.ImageMemoryBarrierCount = uint32(len(images))
+			dep.PImageMemoryBarriers = &images[0]
+		}
+		d.cmds.CmdPipelineBarrier2(cmdBuffer, &dep)
+		return
+	}
+
+	b := legacyBarriers(memory, buffers, images)
+	var (
+		pMemory  *vk.MemoryBarrier
+		pBuffers *vk.BufferMemoryBarrier
+		pImages  *vk.ImageMemoryBarrier
+	)
+	if len(b.memory) > 0 {
+		pMemory = &b.memory[0]
+	}
+	if len(b.buffers) > 0 {
+		pBuffers = &b.buffers[0]
+	}
+	if len(b.images) > 0 {
+		pImages = &b.images[0]
+	}
+	vkCmdPipelineBarrier(d.cmds, cmdBuffer, b.srcStage, b.dstStage, 0,
+		uint32(len(b.memory)), pMemory,
+		uint32(len(b.buffers)), pBuffers,
+		uint32(len(b.images)), pImages)
+}
+
+// legacyBarrierSet is a synchronization2 dependency lowered to the
+// arguments of vkCmdPipelineBarrier.
+type legacyBarrierSet struct {
+	srcStage vk.PipelineStageFlags
+	dstStage vk.PipelineStageFlags
+	memory   []vk.MemoryBarrier
+	buffers  []vk.BufferMemoryBarrier
+	images   []vk.ImageMemoryBarrier
+}
+
+// legacyBarriers merges the per-barrier stage masks into one source and one
+// destination scope. Only stage and access bits that exist in Vulkan 1.0 are
+// used by this backend, so truncating to 32 bits is lossless. An empty scope
+// becomes TOP_OF_PIPE (source) or BOTTOM_OF_PIPE (destination), the 1.0
+// spelling of VK_PIPELINE_STAGE_2_NONE.
+func legacyBarriers(memory []vk.MemoryBarrier2, buffers []vk.BufferMemoryBarrier2, images []vk.ImageMemoryBarrier2) legacyBarrierSet {
+	var src, dst vk.PipelineStageFlags2
+	out := legacyBarrierSet{
+		memory:  make([]vk.MemoryBarrier, len(memory)),
+		buffers: make([]vk.BufferMemoryBarrier, len(buffers)),
+		images:  make([]vk.ImageMemoryBarrier, len(images)),
+	}
+	for i, m := range memory {
+		src |= m.SrcStageMask
+		dst |= m.DstStageMask
+		out.memory[i] = vk.MemoryBarrier{
+			SType:         vk.StructureTypeMemoryBarrier,
+			SrcAccessMask: vk.AccessFlags(m.SrcAccessMask),
+			DstAccessMask: vk.AccessFlags(m.DstAccessMask),
+		}
+	}
+	for i, b := range buffers {
+		src |= b.SrcStageMask
+		dst |= b.DstStageMask
+		out.buffers[i] = vk.BufferMemoryBarrier{
+			SType:               vk.StructureTypeBufferMemoryBarrier,
+			SrcAccessMask:       vk.AccessFlags(b.SrcAccessMask),
+			DstAccessMask:       vk.AccessFlags(b.DstAccessMask),
+			SrcQueueFamilyIndex: b.SrcQueueFamilyIndex,
+			DstQueueFamilyIndex: b.DstQueueFamilyIndex,
+			Buffer:              b.Buffer,
+			Offset:              b.Offset,
+			Size:                b.Size,
+		}
+	}
+	for i, img := range images {
+		src |= img.SrcStageMask
+		dst |= img.DstStageMask
+		out.images[i] = vk.ImageMemoryBarrier{
+			SType:               vk.StructureTypeImageMemoryBarrier,
+			SrcAccessMask:       vk.AccessFlags(img.SrcAccessMask),
+			DstAccessMask:       vk.AccessFlags(img.DstAccessMask),
+			OldLayout:           img.OldLayout,
+			NewLayout:           img.NewLayout,
+			SrcQueueFamilyIndex: img.SrcQueueFamilyIndex,
+			DstQueueFamilyIndex: img.DstQueueFamilyIndex,
+			Image:               img.Image,
+			SubresourceRange:    img.SubresourceRange,
+		}
+	}
+
+	out.srcStage = vk.PipelineStageFlags(src)
+	if out.srcStage == 0 {
+		out.srcStage = vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit)
+	}
+	out.dstStage = vk.PipelineStageFlags(dst)
+	if out.dstStage == 0 {
+		out.dstStage = vk.PipelineStageFlags(vk.PipelineStageBottomOfPipeBit)
+	}
+	return out
+}
+
+// unknownUsageStage is the scope of usage 0, whose prior accesses are not
+// tracked: an execution dependency on all commands with no memory access.
+const unknownUsageStage = vk.PipelineStageFlags2(vk.PipelineStage2AllCommandsBit)
+
+// bufferUsesToAccessAndStage returns the accesses a buffer state performs
+// and the pipeline stages that perform them.
+func bufferUsesToAccessAndStage(uses hal.BufferUses) (vk.AccessFlags2, vk.PipelineStageFlags2) {
+	if uses == 0 {
+		return 0, unknownUsageStage
+	}
+
+	var access vk.AccessFlags2
+	var stage vk.PipelineStageFlags2
+
+	if uses&hal.BufferUseMapRead != 0 {
+		access |= vk.AccessFlags2(vk.Access2HostReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2HostBit)
+	}
+	if uses&hal.BufferUseMapWrite != 0 {
+		access |= vk.AccessFlags2(vk.Access2HostWriteBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2HostBit)
+	}
+	if uses&hal.BufferUseCopySrc != 0 {
+		access |= vk.AccessFlags2(vk.Access2TransferReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit)
+	}
+	if uses&(hal.BufferUseCopyDst|hal.BufferUseQueryResolve) != 0 {
+		access |= vk.AccessFlags2(vk.Access2TransferWriteBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit)
+	}
+	if uses&hal.BufferUseVertex != 0 {
+		access |= vk.AccessFlags2(vk.Access2VertexAttributeReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2VertexInputBit)
+	}
+	if uses&hal.BufferUseIndex != 0 {
+		access |= vk.AccessFlags2(vk.Access2IndexReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2VertexInputBit)
+	}
+	if uses&hal.BufferUseUniform != 0 {
+		access |= vk.AccessFlags2(vk.Access2UniformReadBit)
+		stage |= shaderStages2
+	}
+	if uses&hal.BufferUseStorageRead != 0 {
+		access |= vk.AccessFlags2(vk.Access2ShaderReadBit)
+		stage |= shaderStages2
+	}
+	if uses&hal.BufferUseStorageReadWrite != 0 {
+		access |= vk.AccessFlags2(vk.Access2ShaderReadBit | vk.Access2ShaderWriteBit)
+		stage |= shaderStages2
+	}
+	if uses&hal.BufferUseIndirect != 0 {
+		// DRAW_INDIRECT also covers vkCmdDispatchIndirect parameter reads.
+		access |= vk.AccessFlags2(vk.Access2IndirectCommandReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2DrawIndirectBit)
+	}
+
+	return access, stage
+}
+
+// textureUsageToAccessStageLayout returns the accesses, stages and image
+// layout of a texture usage. depth selects depth/stencil semantics: a
+// sampled depth/stencil texture is in DEPTH_STENCIL_READ_ONLY_OPTIMAL, and
+// RenderAttachment together with TextureBinding is a read-only attachment
+// that is sampled in the same pass.
+func textureUsageToAccessStageLayout(usage gputypes.TextureUsage, depth bool) (vk.AccessFlags2, vk.PipelineStageFlags2, vk.ImageLayout) {
+	// Usage 0 means "initial/undefined" — the image has no prior usage.
+	// Newly created Vulkan images start in VK_IMAGE_LAYOUT_UNDEFINED.
+	// Using ImageLayoutGeneral here would lie about the old layout,
+	// causing validation errors and undefined behavior on the barrier.
+	if usage == 0 {
+		return 0, unknownUsageStage, vk.ImageLayoutUndefined
+	}
+
+	var access vk.AccessFlags2
+	var stage vk.PipelineStageFlags2
+	layout := vk.ImageLayoutGeneral
+
+	if usage&gputypes.TextureUsageCopySrc != 0 {
+		access |= vk.AccessFlags2(vk.Access2TransferReadBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit)
+		layout = vk.ImageLayoutTransferSrcOptimal
+	}
+	if usage&gputypes.TextureUsageCopyDst != 0 {
+		access |= vk.AccessFlags2(vk.Access2TransferWriteBit)
+		stage |= vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit)
+		layout = vk.ImageLayoutTransferDstOptimal
+	}
+	if usage&gputypes.TextureUsageTextureBinding != 0 {
+		access |= vk.AccessFlags2(vk.Access2ShaderReadBit)
+		stage |= shaderStages2
+		layout = vk.ImageLayoutShaderReadOnlyOptimal
+		if depth {
+			layout = vk.ImageLayoutDepthStencilReadOnlyOptimal
+		}
+	}
+	if usage&gputypes.TextureUsageStorageBinding != 0 {
+		access |= vk.AccessFlags2(vk.Access2ShaderReadBit | vk.Access2ShaderWriteBit)
+		stage |= shaderStages2
+		layout = vk.ImageLayoutGeneral
+	}
+	if usage&gputypes.TextureUsageRenderAttachment != 0 {
+		switch {
+		case depth && usage&gputypes.TextureUsageTextureBinding != 0:
+			access |= vk.AccessFlags2(vk.Access2DepthStencilAttachmentReadBit)
+			stage |= vk.PipelineStageFlags2(vk.PipelineStage2EarlyFragmentTestsBit | vk.PipelineStage2LateFragmentTestsBit)
+			layout = vk.ImageLayoutDepthStencilReadOnlyOptimal
+		case depth:
+			access |= vk.AccessFlags2(vk.Access2DepthStencilAttachmentReadBit | vk.Access2DepthStencilAttachmentWriteBit)
+			stage |= vk.PipelineStageFlags2(vk.PipelineStage2EarlyFragmentTestsBit | vk.PipelineStage2LateFragmentTestsBit)
+			layout = vk.ImageLayoutDepthStencilAttachmentOptimal
+		default:
+			access |= vk.AccessFlags2(vk.Access2ColorAttachmentWriteBit | vk.Access2ColorAttachmentReadBit)
+			stage |= vk.PipelineStageFlags2(vk.PipelineStage2ColorAttachmentOutputBit)
+			layout = vk.ImageLayoutColorAttachmentOptimal
+		}
+	}
+
+	return access, stage, layout
+}
+
+// depthStencilLayoutMode is how a device lays out the depth and stencil
+// aspects of one image when they are used differently, such as a sampled
+// read-only depth aspect next to a written stencil attachment.
+type depthStencilLayoutMode uint8
+
+const (
+	// depthStencilLayoutsGeneral transitions both aspects together and
+	// falls back to GENERAL when they are in different states (Vulkan 1.0).
+	depthStencilLayoutsGeneral depthStencilLayoutMode = iota
+	// depthStencilLayoutsCombined transitions both aspects together into
+	// the mixed read-only/attachment layouts of Vulkan 1.1.
+	depthStencilLayoutsCombined
+	// depthStencilLayoutsSeparate transitions each aspect on its own
+	// (VK_KHR_separate_depth_stencil_layouts, core in Vulkan 1.2).
+	depthStencilLayoutsSeparate
+)
+
+// combineDepthStencilLayouts returns the layout of an image whose depth
+// aspect needs layout depth and whose stencil aspect needs layout stencil,
+// both as returned by textureUsageToAccessStageLayout. An UNDEFINED aspect
+// has no contents to keep and takes the other aspect's layout. Aspects in
+// different states share the closest layout that allows both, GENERAL when
+// no other does.
+func combineDepthStencilLayouts(depth, stencil vk.ImageLayout, mode depthStencilLayoutMode) vk.ImageLayout {
+	switch {
+	case depth == stencil || stencil == vk.ImageLayoutUndefined:
+		return depth
+	case depth == vk.ImageLayoutUndefined:
+		return stencil
+	case mode == depthStencilLayoutsGeneral:
+		return vk.ImageLayoutGeneral
+	case depth == vk.ImageLayoutDepthStencilReadOnlyOptimal && stencil == vk.ImageLayoutDepthStencilAttachmentOptimal:
+		return vk.ImageLayoutDepthReadOnlyStencilAttachmentOptimal
+	case depth == vk.ImageLayoutDepthStencilAttachmentOptimal && stencil == vk.ImageLayoutDepthStencilReadOnlyOptimal:
+		return vk.ImageLayoutDepthAttachmentStencilReadOnlyOptimal
+	default:
+		return vk.ImageLayoutGeneral
+	}
+}
+
+// separateAspectLayout returns the single-aspect spelling of a depth/stencil
+// layout for a barrier on only the depth or only the stencil aspect.
+func separateAspectLayout(layout vk.ImageLayout, stencil bool) vk.ImageLayout {
+	switch {
+	case layout == vk.ImageLayoutDepthStencilAttachmentOptimal && stencil:
+		return vk.ImageLayoutStencilAttachmentOptimal
+	case layout == vk.ImageLayoutDepthStencilAttachmentOptimal:
+		return vk.ImageLayoutDepthAttachmentOptimal
+	case layout == vk.ImageLayoutDepthStencilReadOnlyOptimal && stencil:
+		return vk.ImageLayoutStencilReadOnlyOptimal
+	case layout == vk.ImageLayoutDepthStencilReadOnlyOptimal:
+		return vk.ImageLayoutDepthReadOnlyOptimal
+	default:
+		return layout
+	}
+}
+
+// depthStencilAttachmentLayout returns the layout a render pass uses for a
+// depth/stencil attachment of format whose aspects are read-only as dsa
+// requests. It is the layout TransitionTextures gives the aspects with
+// RenderAttachment usage, plus TextureBinding for the read-only ones.
+func depthStencilAttachmentLayout(dsa *hal.RenderPassDepthStencilAttachment, format gputypes.TextureFormat, mode depthStencilLayoutMode) vk.ImageLayout {
+	aspect := func(readOnly bool) vk.ImageLayout {
+		if readOnly {
+			return vk.ImageLayoutDepthStencilReadOnlyOptimal
+		}
+		return vk.ImageLayoutDepthStencilAttachmentOptimal
+	}
+	switch {
+	case format == gputypes.TextureFormatStencil8:
+		return aspect(dsa.StencilReadOnly)
+	case !hasStencilAspect(format):
+		return aspect(dsa.DepthReadOnly)
+	default:
+		return combineDepthStencilLayouts(aspect(dsa.DepthReadOnly), aspect(dsa.StencilReadOnly), mode)
+	}
+}
+
+// transitionAspects records a usage transition of aspect of a combined
+// depth/stencil texture and returns the old and new usage of its depth
+// (index 0) and stencil (index 1) aspects. An aspect the transition leaves
+// out keeps the usage recorded for it, or the transition's old usage when
+// none is.
+func (t *Texture) transitionAspects(aspect gputypes.TextureAspect, usage hal.TextureUsageTransition) (oldUsage, newUsage [2]gputypes.TextureUsage) {
+	t.aspectMu.Lock()
+	defer t.aspectMu.Unlock()
+	for i := range oldUsage {
+		covered := aspect != gputypes.TextureAspectDepthOnly && aspect != gputypes.TextureAspectStencilOnly ||
+			aspect == gputypes.TextureAspectDepthOnly && i == 0 ||
+			aspect == gputypes.TextureAspectStencilOnly && i == 1
+		switch {
+		case covered:
+			oldUsage[i], newUsage[i] = usage.OldUsage, usage.NewUsage
+		case t.aspectUsage[i] != 0:
+			oldUsage[i], newUsage[i] = t.aspectUsage[i], t.aspectUsage[i]
+		default:
+			oldUsage[i], newUsage[i] = usage.OldUsage, usage.OldUsage
+		}
+	}
+	t.aspectUsage = newUsage
+	return oldUsage, newUsage
+}
+
+// depthStencilBarrier returns the image barrier of b on tex, a texture with
+// both a depth and a stencil aspect. With separate depth/stencil layouts a
+// barrier on one aspect transitions only that aspect. Otherwise Vulkan
+// requires both aspects in every barrier, so the aspect b leaves out is
+// transitioned along with its current usage and the image moves between
+// layouts that fit both aspects.
+func depthStencilBarrier(tex *Texture, b hal.TextureBarrier, mode depthStencilLayoutMode) vk.ImageMemoryBarrier2 {
+	oldUsage, newUsage := tex.transitionAspects(b.Range.Aspect, b.Usage)
+	barrier := vk.ImageMemoryBarrier2{
+		SType:               vk.StructureTypeImageMemoryBarrier2,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               tex.handle,
+		SubresourceRange: vk.ImageSubresourceRange{
+			AspectMask:     vk.ImageAspectFlags(vk.ImageAspectDepthBit | vk.ImageAspectStencilBit),
+			BaseMipLevel:   b.Range.BaseMipLevel,
+			LevelCount:     mipLevelCountOrRemaining(b.Range.MipLevelCount),
+			BaseArrayLayer: b.Range.BaseArrayLayer,
+			LayerCount:     arrayLayerCountOrRemaining(b.Range.ArrayLayerCount),
+		},
+	}
+
+	if mode == depthStencilLayoutsSeparate &&
+		(b.Range.Aspect == gputypes.TextureAspectDepthOnly || b.Range.Aspect == gputypes.TextureAspectStencilOnly) {
+		stencil := b.Range.Aspect == gputypes.TextureAspectStencilOnly
+		i := 0
+		if stencil {
+			i = 1
+		}
+		var oldLayout, newLayout vk.ImageLayout
+		barrier.SrcAccessMask, barrier.SrcStageMask, oldLayout = textureUsageToAccessStageLayout(oldUsage[i], true)
+		barrier.DstAccessMask, barrier.DstStageMask, newLayout = textureUsageToAccessStageLayout(newUsage[i], true)
+		barrier.OldLayout = separateAspectLayout(oldLayout, stencil)
+		barrier.NewLayout = separateAspectLayout(newLayout, stencil)
+		barrier.SubresourceRange.AspectMask = textureAspectToVk(b.Range.Aspect, tex.format)
+		return barrier
+	}
+
+	var oldLayouts, newLayouts [2]vk.ImageLayout
+	for i := range oldLayouts {
+		access, stage, layout := textureUsageToAccessStageLayout(oldUsage[i], true)
+		barrier.SrcAccessMask |= access
+		barrier.SrcStageMask |= stage
+		oldLayouts[i] = layout
+		access, stage, layout = textureUsageToAccessStageLayout(newUsage[i], true)
+		barrier.DstAccessMask |= access
+		barrier.DstStageMask |= stage
+		newLayouts[i] = layout
+	}
+	barrier.OldLayout = combineDepthStencilLayouts(oldLayouts[0], oldLayouts[1], mode)
+	barrier.NewLayout = combineDepthStencilLayouts(newLayouts[0], newLayouts[1], mode)
+	return barrier
+}
@@ -0,0 +1,269 @@
+//go:build !(js && wasm)
+
+package vulkan
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+func TestBufferUsageStagesArePerUsage(t *testing.T) {
+	tests := []struct {
+		name       string
+		uses       hal.BufferUses
+		wantAccess vk.AccessFlags2
+		wantStage  vk.PipelineStageFlags2
+	}{
+		{
+			name:       "unknown",
+			uses:       0,
+			wantAccess: 0,
+			wantStage:  vk.PipelineStageFlags2(vk.PipelineStage2AllCommandsBit),
+		},
+		{
+			name:       "copy dst",
+			uses:       hal.BufferUseCopyDst,
+			wantAccess: vk.AccessFlags2(vk.Access2TransferWriteBit),
+			wantStage:  vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit),
+		},
+		{
+			name:       "vertex and index",
+			uses:       hal.BufferUseVertex | hal.BufferUseIndex,
+			wantAccess: vk.AccessFlags2(vk.Access2VertexAttributeReadBit | vk.Access2IndexReadBit),
+			wantStage:  vk.PipelineStageFlags2(vk.PipelineStage2VertexInputBit),
+		},
+		{
+			name:       "indirect",
+			uses:       hal.BufferUseIndirect,
+			wantAccess: vk.AccessFlags2(vk.Access2IndirectCommandReadBit),
+			wantStage:  vk.PipelineStageFlags2(vk.PipelineStage2DrawIndirectBit),
+		},
+		{
+			name:       "map read",
+			uses:       hal.BufferUseMapRead,
+			wantAccess: vk.AccessFlags2(vk.Access2HostReadBit),
+			wantStage:  vk.PipelineStageFlags2(vk.PipelineStage2HostBit),
+		},
+		{
+			name:       "storage read",
+			uses:       hal.BufferUseStorageRead,
+			wantAccess: vk.AccessFlags2(vk.Access2ShaderReadBit),
+			wantStage:  shaderStages2,
+		},
+		{
+			name:       "storage read write",
+			uses:       hal.BufferUseStorageReadWrite,
+			wantAccess: vk.AccessFlags2(vk.Access2ShaderReadBit | vk.Access2ShaderWriteBit),
+			wantStage:  shaderStages2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			access, stage := bufferUsesToAccessAndStage(test.uses)
+			if access != test.wantAccess || stage != test.wantStage {
+				t.Fatalf("access/stage = %#x/%#x, want %#x/%#x", access, stage, test.wantAccess, test.wantStage)
+			}
+		})
+	}
+}
+
+func TestTextureUsageDepthAttachment(t *testing.T) {
+	access, stage, layout := textureUsageToAccessStageLayout(gputypes.TextureUsageRenderAttachment, true)
+	if layout != vk.ImageLayoutDepthStencilAttachmentOptimal {
+		t.Fatalf("layout = %d, want DEPTH_STENCIL_ATTACHMENT_OPTIMAL", layout)
+	}
+	wantStage := vk.PipelineStageFlags2(vk.PipelineStage2EarlyFragmentTestsBit | vk.PipelineStage2LateFragmentTestsBit)
+	if stage != wantStage {
+		t.Fatalf("stage = %#x, want %#x", stage, wantStage)
+	}
+	if access&vk.AccessFlags2(vk.Access2DepthStencilAttachmentWriteBit) == 0 {
+		t.Fatalf("access = %#x, missing depth/stencil attachment write", access)
+	}
+
+	_, stage, layout = textureUsageToAccessStageLayout(gputypes.TextureUsageRenderAttachment, false)
+	if layout != vk.ImageLayoutColorAttachmentOptimal || stage != vk.PipelineStageFlags2(vk.PipelineStage2ColorAttachmentOutputBit) {
+		t.Fatalf("color attachment = layout %d stage %#x", layout, stage)
+	}
+}
+
+func TestTextureUsageUndefinedHasNoAccess(t *testing.T) {
+	access, stage, layout := textureUsageToAccessStageLayout(0, false)
+	if access != 0 || stage != unknownUsageStage || layout != vk.ImageLayoutUndefined {
+		t.Fatalf("usage 0 = access %#x stage %#x layout %d", access, stage, layout)
+	}
+}
+
+func TestLegacyBarriersMergeStageMasks(t *testing.T) {
+	buffers := []vk.BufferMemoryBarrier2{
+		{
+			SrcStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit),
+			SrcAccessMask: vk.AccessFlags2(vk.Access2TransferWriteBit),
+			DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2VertexInputBit),
+			DstAccessMask: vk.AccessFlags2(vk.Access2VertexAttributeReadBit),
+			Buffer:        1,
+			Size:          vk.DeviceSize(vk.WholeSize),
+		},
+		{
+			SrcStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit),
+			SrcAccessMask: vk.AccessFlags2(vk.Access2ShaderWriteBit),
+			DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2DrawIndirectBit),
+			DstAccessMask: vk.AccessFlags2(vk.Access2IndirectCommandReadBit),
+			Buffer:        2,
+		},
+	}
+	set := legacyBarriers(nil, buffers, nil)
+
+	wantSrc := vk.PipelineStageFlags(vk.PipelineStageTransferBit | vk.PipelineStageComputeShaderBit)
+	wantDst := vk.PipelineStageFlags(vk.PipelineStageVertexInputBit | vk.PipelineStageDrawIndirectBit)
+	if set.srcStage != wantSrc || set.dstStage != wantDst {
+		t.Fatalf("stages = %#x -> %#x, want %#x -> %#x", set.srcStage, set.dstStage, wantSrc, wantDst)
+	}
+	if len(set.buffers) != 2 || len(set.memory) != 0 || len(set.images) != 0 {
+		t.Fatalf("barrier counts = %d/%d/%d, want 0/2/0", len(set.memory), len(set.buffers), len(set.images))
+	}
+	b := set.buffers[1]
+	if b.SType != vk.StructureTypeBufferMemoryBarrier || b.Buffer != 2 ||
+		b.SrcAccessMask != vk.AccessFlags(vk.AccessShaderWriteBit) ||
+		b.DstAccessMask != vk.AccessFlags(vk.AccessIndirectCommandReadBit) {
+		t.Fatalf("lowered barrier = %+v", b)
+	}
+}
+
+func TestLegacyBarriersEmptyScopes(t *testing.T) {
+	images := []vk.ImageMemoryBarrier2{{
+		DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2FragmentShaderBit),
+		DstAccessMask: vk.AccessFlags2(vk.Access2ShaderReadBit),
+		OldLayout:     vk.ImageLayoutUndefined,
+		NewLayout:     vk.ImageLayoutShaderReadOnlyOptimal,
+	}}
+	set := legacyBarriers(nil, nil, images)
+	if set.srcStage != vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit) {
+		t.Fatalf("empty source scope = %#x, want TOP_OF_PIPE", set.srcStage)
+	}
+	if set.images[0].NewLayout != vk.ImageLayoutShaderReadOnlyOptimal {
+		t.Fatalf("layout not carried over: %+v", set.images[0])
+	}
+
+	memory := []vk.MemoryBarrier2{{
+		SrcStageMask: vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit),
+	}}
+	set = legacyBarriers(memory, nil, nil)
+	if set.dstStage != vk.PipelineStageFlags(vk.PipelineStageBottomOfPipeBit) {
+		t.Fatalf("empty destination scope = %#x, want BOTTOM_OF_PIPE", set.dstStage)
+	}
+}
+
+func TestTextureUsageDepthReadOnlyAttachment(t *testing.T) {
+	access, _, layout := textureUsageToAccessStageLayout(gputypes.TextureUsageRenderAttachment|gputypes.TextureUsageTextureBinding, true)
+	if layout != vk.ImageLayoutDepthStencilReadOnlyOptimal {
+		t.Fatalf("layout = %d, want DEPTH_STENCIL_READ_ONLY_OPTIMAL", layout)
+	}
+	if access&vk.AccessFlags2(vk.Access2DepthStencilAttachmentWriteBit) != 0 {
+		t.Fatalf("access = %#x, read-only attachment must not write", access)
+	}
+
+	_, _, layout = textureUsageToAccessStageLayout(gputypes.TextureUsageTextureBinding, true)
+	if layout != vk.ImageLayoutDepthStencilReadOnlyOptimal {
+		t.Fatalf("sampled depth layout = %d, want DEPTH_STENCIL_READ_ONLY_OPTIMAL", layout)
+	}
+	_, _, layout = textureUsageToAccessStageLayout(gputypes.TextureUsageTextureBinding, false)
+	if layout != vk.ImageLayoutShaderReadOnlyOptimal {
+		t.Fatalf("sampled color layout = %d, want SHADER_READ_ONLY_OPTIMAL", layout)
+	}
+}
+
+func TestCombineDepthStencilLayouts(t *testing.T) {
+	const (
+		attachment = vk.ImageLayoutDepthStencilAttachmentOptimal
+		readOnly   = vk.ImageLayoutDepthStencilReadOnlyOptimal
+	)
+	tests := []struct {
+		name           string
+		depth, stencil vk.ImageLayout
+		mode           depthStencilLayoutMode
+		want           vk.ImageLayout
+	}{
+		{"same", readOnly, readOnly, depthStencilLayoutsGeneral, readOnly},
+		{"undefined stencil", attachment, vk.ImageLayoutUndefined, depthStencilLayoutsGeneral, attachment},
+		{"undefined depth", vk.ImageLayoutUndefined, readOnly, depthStencilLayoutsGeneral, readOnly},
+		{"depth read stencil write", readOnly, attachment, depthStencilLayoutsCombined, vk.ImageLayoutDepthReadOnlyStencilAttachmentOptimal},
+		{"depth write stencil read", attachment, readOnly, depthStencilLayoutsSeparate, vk.ImageLayoutDepthAttachmentStencilReadOnlyOptimal},
+		{"mixed without maintenance2", readOnly, attachment, depthStencilLayoutsGeneral, vk.ImageLayoutGeneral},
+		{"copy and attachment", vk.ImageLayoutTransferDstOptimal, attachment, depthStencilLayoutsCombined, vk.ImageLayoutGeneral},
+	}
+	for _, tt := range tests {
+		if got := combineDepthStencilLayouts(tt.depth, tt.stencil, tt.mode); got != tt.want {
+			t.Errorf("%s: layout = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDepthStencilBarrierReadDepthWriteStencil moves the depth aspect of a
+// depth/stencil attachment to read-only + sampled while the stencil aspect
+// stays a written attachment.
+func TestDepthStencilBarrierReadDepthWriteStencil(t *testing.T) {
+	const attachment = gputypes.TextureUsageRenderAttachment
+	depthRead := hal.TextureBarrier{
+		Range: hal.TextureRange{Aspect: gputypes.TextureAspectDepthOnly},
+		Usage: hal.TextureUsageTransition{
+			OldUsage: attachment,
+			NewUsage: attachment | gputypes.TextureUsageTextureBinding,
+		},
+	}
+	bothAspects := vk.ImageAspectFlags(vk.ImageAspectDepthBit | vk.ImageAspectStencilBit)
+
+	tests := []struct {
+		mode       depthStencilLayoutMode
+		wantAspect vk.ImageAspectFlags
+		wantOld    vk.ImageLayout
+		wantNew    vk.ImageLayout
+	}{
+		{depthStencilLayoutsSeparate, vk.ImageAspectFlags(vk.ImageAspectDepthBit),
+			vk.ImageLayoutDepthAttachmentOptimal, vk.ImageLayoutDepthReadOnlyOptimal},
+		{depthStencilLayoutsCombined, bothAspects,
+			vk.ImageLayoutDepthStencilAttachmentOptimal, vk.ImageLayoutDepthReadOnlyStencilAttachmentOptimal},
+		{depthStencilLayoutsGeneral, bothAspects,
+			vk.ImageLayoutDepthStencilAttachmentOptimal, vk.ImageLayoutGeneral},
+	}
+	for _, tt := range tests {
+		tex := &Texture{handle: 1, format: gputypes.TextureFormatDepth24PlusStencil8}
+		// Both aspects become attachments, then only depth turns read-only.
+		depthStencilBarrier(tex, hal.TextureBarrier{Usage: hal.TextureUsageTransition{NewUsage: attachment}}, tt.mode)
+		b := depthStencilBarrier(tex, depthRead, tt.mode)
+		if b.SubresourceRange.AspectMask != tt.wantAspect || b.OldLayout != tt.wantOld || b.NewLayout != tt.wantNew {
+			t.Errorf("mode %d: aspect %#x layout %d -> %d, want aspect %#x layout %d -> %d", tt.mode,
+				b.SubresourceRange.AspectMask, b.OldLayout, b.NewLayout, tt.wantAspect, tt.wantOld, tt.wantNew)
+		}
+		if b.DstAccessMask&vk.AccessFlags2(vk.Access2ShaderReadBit) == 0 {
+			t.Errorf("mode %d: dst access %#x misses shader read", tt.mode, b.DstAccessMask)
+		}
+		if tex.aspectUsage != [2]gputypes.TextureUsage{depthRead.Usage.NewUsage, attachment} {
+			t.Errorf("mode %d: aspect usage = %v", tt.mode, tex.aspectUsage)
+		}
+	}
+}
+
+func TestDepthStencilAttachmentLayout(t *testing.T) {
+	tests := []struct {
+		format             gputypes.TextureFormat
+		depthRO, stencilRO bool
+		want               vk.ImageLayout
+	}{
+		{gputypes.TextureFormatDepth24PlusStencil8, false, false, vk.ImageLayoutDepthStencilAttachmentOptimal},
+		{gputypes.TextureFormatDepth24PlusStencil8, true, false, vk.ImageLayoutDepthReadOnlyStencilAttachmentOptimal},
+		{gputypes.TextureFormatDepth24PlusStencil8, true, true, vk.ImageLayoutDepthStencilReadOnlyOptimal},
+		{gputypes.TextureFormatDepth32Float, true, false, vk.ImageLayoutDepthStencilReadOnlyOptimal},
+		{gputypes.TextureFormatDepth32Float, false, true, vk.ImageLayoutDepthStencilAttachmentOptimal},
+		{gputypes.TextureFormatStencil8, false, true, vk.ImageLayoutDepthStencilReadOnlyOptimal},
+	}
+	for _, tt := range tests {
+		dsa := &hal.RenderPassDepthStencilAttachment{DepthReadOnly: tt.depthRO, StencilReadOnly: tt.stencilRO}
+		if got := depthStencilAttachmentLayout(dsa, tt.format, depthStencilLayoutsCombined); got != tt.want {
+			t.Errorf("%v depthRO=%v stencilRO=%v: layout = %d, want %d", tt.format, tt.depthRO, tt.stencilRO, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+// Vulkan 1.1 structure types of vkBindBufferMemory2 and vkBindImageMemory2,
+// missing from the generated constants.
+const (
+	structureTypeBindBufferMemoryInfo vk.StructureType = 1000157000
+	structureTypeBindImageMemoryInfo  vk.StructureType = 1000157001
+)
+
+// CreateBuffers creates a buffer for each descriptor. Each VkBuffer is
+// created and given memory as in CreateBuffer, then the memory of the whole
+// batch is bound with one vkBindBufferMemory2 call.
+// Implements hal.BatchCreator.
+func (d *Device) CreateBuffers(descs []*hal.BufferDescriptor) ([]hal.Buffer, error) {
+	buffers := make([]*Buffer, 0, len(descs))
+	destroy := func() {
+		for _, b := range buffers {
+			d.DestroyBuffer(b)
+		}
+	}
+
+	for i, desc := range descs {
+		b, err := d.newBuffer(desc)
+		if err != nil {
+			destroy()
+			return nil, fmt.Errorf("buffer %d: %w", i, err)
+		}
+		buffers = append(buffers, b)
+	}
+	if err := d.bindBuffers(buffers); err != nil {
+		destroy()
+		return nil, err
+	}
+
+	result := make([]hal.Buffer, len(buffers))
+	for i, b := range buffers {
+		if err := d.finishBuffer(b, descs[i]); err != nil {
+			destroy()
+			return nil, fmt.Errorf("buffer %d: %w", i, err)
+		}
+		result[i] = b
+	}
+	return result, nil
+}
+
+// bindBuffers binds each buffer to its memory, with a single call when
+// vkBindBufferMemory2 is available.
+func (d *Device) bindBuffers(buffers []*Buffer) error {
+	if len(buffers) == 0 {
+		return nil
+	}
+	if !d.cmds.HasBindMemory2() {
+		for i, b := range buffers {
+			result := d.cmds.BindBufferMemory(d.handle, b.handle, b.memory.Memory, vk.DeviceSize(b.memory.Offset))
+			if result != vk.Success {
+				return fmt.Errorf("buffer %d: vulkan: vkBindBufferMemory failed: %d", i, result)
+			}
+		}
+		return nil
+	}
+
+	infos := make([]vk.BindBufferMemoryInfo, len(buffers))
+	for i, b := range buffers {
+		infos[i] = vk.BindBufferMemoryInfo{
+			SType:        structureTypeBindBufferMemoryInfo,
+			Buffer:       b.handle,
+			Memory:       b.memory.Memory,
+			MemoryOffset: vk.DeviceSize(b.memory.Offset),
+		}
+	}
+	if result := d.cmds.BindBufferMemory2(d.handle, uint32(len(infos)), &infos[0]); result != vk.Success {
+		return fmt.Errorf("vulkan: vkBindBufferMemory2 failed: %d", result)
+	}
+	return nil
+}
+
+// CreateTextures creates a texture for each descriptor. Each VkImage is
+// created and given memory as in CreateTexture, then the memory of the
+// whole batch is bound with one vkBindImageMemory2 call. Format support
+// queries are made once per distinct format, usage and flags.
+// Implements hal.BatchCreator.
+func (d *Device) CreateTextures(descs []*hal.TextureDescriptor) ([]hal.Texture, error) {
+	type formatKey struct {
+		format    vk.Format
+		imageType vk.ImageType
+		usage     vk.ImageUsageFlags
+		flags     vk.ImageCreateFlags
+	}
+	supported := make(map[formatKey]bool)
+	formatSupported := func(format vk.Format, imageType vk.ImageType, usage vk.ImageUsageFlags, flags vk.ImageCreateFlags) bool {
+		key := formatKey{format, imageType, usage, flags}
+		ok, cached := supported[key]
+		if !cached {
+			ok = d.imageFormatSupported(format, imageType, usage, flags)
+			supported[key] = ok
+		}
+		return ok
+	}
+
+	textures := make([]*Texture, 0, len(descs))
+	destroy := func() {
+		for _, t := range textures {
+			d.DestroyTexture(t)
+		}
+	}
+
+	for i, desc := range descs {
+		t, err := d.newTexture(desc, formatSupported)
+		if err != nil {
+			destroy()
+			return nil, fmt.Errorf("texture %d: %w", i, err)
+		}
+		textures = append(textures, t)
+	}
+	if err := d.bindTextures(textures); err != nil {
+		destroy()
+		return nil, err
+	}
+
+	result := make([]hal.Texture, len(textures))
+	for i, t := range textures {
+		d.nameTexture(t, descs[i].Label)
+		result[i] = t
+	}
+	return result, nil
+}
+
+// bindTextures binds each texture's image to its memory, with a single call
+// when vkBindImageMemory2 is available.
+func (d *Device) bindTextures(textures []*Texture) error {
+	if len(textures) == 0 {
+		return nil
+	}
+	if !d.cmds.HasBindMemory2() {
+		for i, t := range textures {
+			result := d.cmds.BindImageMemory(d.handle, t.handle, t.memory.Memory, vk.DeviceSize(t.memory.Offset))
+			if result != vk.Success {
+				return fmt.Errorf("texture %d: vulkan: vkBindImageMemory failed: %d", i, result)
+			}
+		}
+		return nil
+	}
+
+	infos := make([]vk.BindImageMemoryInfo, len(textures))
+	for i, t := range textures {
+		infos[i] = vk.BindImageMemoryInfo{
+			SType:        structureTypeBindImageMemoryInfo,
+			Image:        t.handle,
+			Memory:       t.memory.Memory,
+			MemoryOffset: vk.DeviceSize(t.memory.Offset),
+		}
+	}
+	if result := d.cmds.BindImageMemory2(d.handle, uint32(len(infos)), &infos[0]); result != vk.Success {
+		return fmt.Errorf("vulkan: vkBindImageMemory2 failed: %d", result)
+	}
+	return nil
+}
@@ -77,7 +77,7 @@ func (e *RenderBundleEncoder) SetBindGroup(index uint32, group hal.BindGroup, of
 }
 
 // SetVertexBuffer sets a vertex buffer for the given slot.
-func (e *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset uint64) {
+func (e *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, _ uint64) {
 	if e.finished {
 		return
 	}
@@ -91,7 +91,7 @@ func (e *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, of
 }
 
 // SetIndexBuffer sets the index buffer.
-func (e *RenderBundleEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+func (e *RenderBundleEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, _ uint64) {
 	if e.finished {
 		return
 	}
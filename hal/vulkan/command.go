@@ -20,6 +20,10 @@ import (
 type CommandBuffer struct {
 	handle vk.CommandBuffer
 	pool   vk.CommandPool
+
+	// protected is true for buffers recorded by a protected encoder. They
+	// must be submitted with VkProtectedSubmitInfo.
+	protected bool
 }
 
 // Destroy releases the command buffer resources.
@@ -31,6 +35,7 @@ func (c *CommandBuffer) Destroy() {
 	// 2. Proper solution requires fence-based tracking or pool reset after WaitIdle
 	c.handle = 0
 	c.pool = 0
+	c.protected = false
 	cmdBufferResultPool.Put(c)
 }
 
@@ -66,6 +71,9 @@ type CommandEncoder struct {
 	device *Device
 	pool   vk.CommandPool
 
+	// protected is true when pool was created with the protected flag.
+	protected bool
+
 	// active is the current recording VkCommandBuffer. Zero means not recording.
 	// Replaces the old isRecording bool — matches Rust wgpu-hal's self.active
 	// null check pattern (vulkan/command.rs:153).
@@ -83,6 +91,11 @@ type CommandEncoder struct {
 
 	label       string
 	poolManaged bool // true when managed by wgpu-level encoder pool
+
+	// statisticsSet and statisticsIndex identify the active pipeline
+	// statistics query, or statisticsSet is nil.
+	statisticsSet   *QuerySet
+	statisticsIndex uint32
 }
 
 // BeginEncoding begins command recording.
@@ -166,6 +179,7 @@ func (e *CommandEncoder) EndEncoding() (hal.CommandBuffer, error) {
 	// Reuse CommandBuffer struct from pool (VK-PERF-004).
 	cb := cmdBufferResultPool.Get().(*CommandBuffer)
 	cb.handle = e.active
+	cb.protected = e.protected
 	e.active = 0
 
 	if e.poolManaged {
@@ -184,6 +198,7 @@ func (e *CommandEncoder) EndEncoding() (hal.CommandBuffer, error) {
 		// the pool is recycled via FreeCommandBuffer).
 		e.device = nil
 		e.pool = 0
+		e.protected = false
 		e.free = e.free[:0]
 		e.discarded = e.discarded[:0]
 		e.label = ""
@@ -211,10 +226,11 @@ func (e *CommandEncoder) DiscardEncoding() {
 		// The pool contains all free+discarded buffers; they will be reset
 		// when the pool is recycled via FreeCommandBuffer/acquireAllocator.
 		if e.device != nil && e.pool != 0 {
-			e.device.recyclePool(e.pool)
+			e.device.recyclePool(e.pool, e.protected)
 		}
 		e.device = nil
 		e.pool = 0
+		e.protected = false
 		e.free = e.free[:0]
 		e.discarded = e.discarded[:0]
 		e.label = ""
@@ -276,20 +292,27 @@ func (e *CommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) {
 		return
 	}
 
-	bufferBarriers := make([]vk.BufferMemoryBarrier, 0, len(barriers))
+	bufferBarriers := make([]vk.BufferMemoryBarrier2, 0, len(barriers))
 	for _, b := range barriers {
 		buf, ok := b.Buffer.(*Buffer)
 		if !ok || buf.handle == 0 {
-			hal.Logger().Warn("TransitionBuffers: skipping invalid buffer (nil or destroyed)")
+			e.device.logger(hal.LogBarrier).Warn("TransitionBuffers: skipping invalid buffer (nil or destroyed)")
 			continue
 		}
 
-		srcAccess, srcStage := bufferUsageToAccessAndStage(b.Usage.OldUsage)
-		dstAccess, dstStage := bufferUsageToAccessAndStage(b.Usage.NewUsage)
+		srcAccess, srcStage := bufferUsesToAccessAndStage(b.Usage.OldUsage)
+		dstAccess, dstStage := bufferUsesToAccessAndStage(b.Usage.NewUsage)
+		if e.device.supportsConditionalRendering && b.Usage.NewUsage&hal.BufferUseIndirect != 0 {
+			// Indirect buffers double as draw predicates.
+			dstAccess |= vk.AccessFlags2(vk.Access2ConditionalRenderingReadBitExt)
+			dstStage |= vk.PipelineStageFlags2(vk.PipelineStage2ConditionalRenderingBitExt)
+		}
 
-		bufferBarriers = append(bufferBarriers, vk.BufferMemoryBarrier{
-			SType:               vk.StructureTypeBufferMemoryBarrier,
+		bufferBarriers = append(bufferBarriers, vk.BufferMemoryBarrier2{
+			SType:               vk.StructureTypeBufferMemoryBarrier2,
+			SrcStageMask:        srcStage,
 			SrcAccessMask:       srcAccess,
+			DstStageMask:        dstStage,
 			DstAccessMask:       dstAccess,
 			SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
 			DstQueueFamilyIndex: vk.QueueFamilyIgnored,
@@ -297,25 +320,9 @@ func (e *CommandEncoder) TransitionBuffers(barriers []hal.BufferBarrier) {
 			Offset:              0,
 			Size:                vk.DeviceSize(vk.WholeSize),
 		})
-
-		_ = srcStage
-		_ = dstStage
-	}
-
-	if len(bufferBarriers) == 0 {
-		return
 	}
 
-	vkCmdPipelineBarrier(
-		e.device.cmds,
-		e.active,
-		vk.PipelineStageFlags(vk.PipelineStageAllCommandsBit),
-		vk.PipelineStageFlags(vk.PipelineStageAllCommandsBit),
-		0,      // dependencyFlags
-		0, nil, // memory barriers
-		uint32(len(bufferBarriers)), &bufferBarriers[0],
-		0, nil, // image barriers
-	)
+	pipelineBarrier(e.device, e.active, nil, bufferBarriers, nil)
 }
 
 // TransitionTextures transitions texture states for synchronization.
@@ -324,20 +331,28 @@ func (e *CommandEncoder) TransitionTextures(barriers []hal.TextureBarrier) {
 		return
 	}
 
-	imageBarriers := make([]vk.ImageMemoryBarrier, 0, len(barriers))
+	imageBarriers := make([]vk.ImageMemoryBarrier2, 0, len(barriers))
 	for _, b := range barriers {
 		tex, ok := b.Texture.(*Texture)
 		if !ok || tex.handle == 0 {
-			hal.Logger().Warn("TransitionTextures: skipping invalid texture (nil or destroyed)")
+			e.device.logger(hal.LogBarrier).Warn("TransitionTextures: skipping invalid texture (nil or destroyed)")
+			continue
+		}
+
+		if hasDepthAndStencil(tex.format) {
+			imageBarriers = append(imageBarriers, depthStencilBarrier(tex, b, e.device.depthStencilLayouts))
 			continue
 		}
 
-		srcAccess, srcStage, oldLayout := textureUsageToAccessStageLayout(b.Usage.OldUsage)
-		dstAccess, dstStage, newLayout := textureUsageToAccessStageLayout(b.Usage.NewUsage)
+		depth := isDepthStencilFormat(tex.format)
+		srcAccess, srcStage, oldLayout := textureUsageToAccessStageLayout(b.Usage.OldUsage, depth)
+		dstAccess, dstStage, newLayout := textureUsageToAccessStageLayout(b.Usage.NewUsage, depth)
 
-		imageBarriers = append(imageBarriers, vk.ImageMemoryBarrier{
-			SType:               vk.StructureTypeImageMemoryBarrier,
+		imageBarriers = append(imageBarriers, vk.ImageMemoryBarrier2{
+			SType:               vk.StructureTypeImageMemoryBarrier2,
+			SrcStageMask:        srcStage,
 			SrcAccessMask:       srcAccess,
+			DstStageMask:        dstStage,
 			DstAccessMask:       dstAccess,
 			OldLayout:           oldLayout,
 			NewLayout:           newLayout,
@@ -352,25 +367,9 @@ func (e *CommandEncoder) TransitionTextures(barriers []hal.TextureBarrier) {
 				LayerCount:     arrayLayerCountOrRemaining(b.Range.ArrayLayerCount),
 			},
 		})
-
-		_ = srcStage
-		_ = dstStage
-	}
-
-	if len(imageBarriers) == 0 {
-		return
 	}
 
-	vkCmdPipelineBarrier(
-		e.device.cmds,
-		e.active,
-		vk.PipelineStageFlags(vk.PipelineStageAllCommandsBit),
-		vk.PipelineStageFlags(vk.PipelineStageAllCommandsBit),
-		0,
-		0, nil,
-		0, nil,
-		uint32(len(imageBarriers)), &imageBarriers[0],
-	)
+	pipelineBarrier(e.device, e.active, nil, nil, imageBarriers)
 }
 
 // ClearBuffer clears a buffer region to zero.
@@ -413,9 +412,7 @@ func (e *CommandEncoder) CopyBufferToBuffer(src, dst hal.Buffer, regions []hal.B
 }
 
 // convertBufferImageCopyRegions converts HAL BufferTextureCopy regions to Vulkan BufferImageCopy.
-// The format parameter is the texture format, used to determine block copy size
-// for correct bytes-to-texels conversion of bufferRowLength.
-func convertBufferImageCopyRegions(regions []hal.BufferTextureCopy, format gputypes.TextureFormat) []vk.BufferImageCopy {
+func convertBufferImageCopyRegions(regions []hal.BufferTextureCopy, format gputypes.TextureFormat, dim gputypes.TextureDimension) []vk.BufferImageCopy {
 	vkRegions := make([]vk.BufferImageCopy, len(regions))
 	blockSize := format.BlockCopySize()
 	if blockSize == 0 {
@@ -430,6 +427,7 @@ func convertBufferImageCopyRegions(regions []hal.BufferTextureCopy, format gputy
 		if r.BufferLayout.BytesPerRow > 0 {
 			bufferRowLength = r.BufferLayout.BytesPerRow / blockSize
 		}
+		baseLayer, layerCount, z, depth := imageCopyLayers(dim, r.TextureBase.Origin.Z, r.Size.DepthOrArrayLayers)
 
 		vkRegions[i] = vk.BufferImageCopy{
 			BufferOffset:      vk.DeviceSize(r.BufferLayout.Offset),
@@ -438,24 +436,35 @@ func convertBufferImageCopyRegions(regions []hal.BufferTextureCopy, format gputy
 			ImageSubresource: vk.ImageSubresourceLayers{
 				AspectMask:     textureAspectToVkSimple(r.TextureBase.Aspect),
 				MipLevel:       r.TextureBase.MipLevel,
-				BaseArrayLayer: 0,
-				LayerCount:     1,
+				BaseArrayLayer: baseLayer,
+				LayerCount:     layerCount,
 			},
 			ImageOffset: vk.Offset3D{
 				X: int32(r.TextureBase.Origin.X),
 				Y: int32(r.TextureBase.Origin.Y),
-				Z: int32(r.TextureBase.Origin.Z),
+				Z: z,
 			},
 			ImageExtent: vk.Extent3D{
 				Width:  r.Size.Width,
 				Height: r.Size.Height,
-				Depth:  r.Size.DepthOrArrayLayers,
+				Depth:  depth,
 			},
 		}
 	}
 	return vkRegions
 }
 
+// imageCopyLayers maps a WebGPU origin Z and DepthOrArrayLayers onto a
+// Vulkan copy: 3D images address slices through the offset and extent
+// depth, every other dimension through the subresource's array layers
+// with a depth of 1.
+func imageCopyLayers(dim gputypes.TextureDimension, originZ, depthOrLayers uint32) (baseLayer, layerCount uint32, z int32, depth uint32) {
+	if dim == gputypes.TextureDimension3D {
+		return 0, 1, int32(originZ), max(depthOrLayers, 1)
+	}
+	return originZ, max(depthOrLayers, 1), 0, 1
+}
+
 // CopyBufferToTexture copies data from a buffer to a texture.
 func (e *CommandEncoder) CopyBufferToTexture(src hal.Buffer, dst hal.Texture, regions []hal.BufferTextureCopy) {
 	if e.active == 0 {
@@ -468,7 +477,7 @@ func (e *CommandEncoder) CopyBufferToTexture(src hal.Buffer, dst hal.Texture, re
 		return
 	}
 
-	vkRegions := convertBufferImageCopyRegions(regions, dstTex.format)
+	vkRegions := convertBufferImageCopyRegions(regions, dstTex.format, dstTex.dimension)
 	vkCmdCopyBufferToImage(
 		e.device.cmds,
 		e.active,
@@ -492,7 +501,7 @@ func (e *CommandEncoder) CopyTextureToBuffer(src hal.Texture, dst hal.Buffer, re
 		return
 	}
 
-	vkRegions := convertBufferImageCopyRegions(regions, srcTex.format)
+	vkRegions := convertBufferImageCopyRegions(regions, srcTex.format, srcTex.dimension)
 	vkCmdCopyImageToBuffer(
 		e.device.cmds,
 		e.active,
@@ -518,33 +527,37 @@ func (e *CommandEncoder) CopyTextureToTexture(src, dst hal.Texture, regions []ha
 
 	vkRegions := make([]vk.ImageCopy, len(regions))
 	for i, r := range regions {
+		srcLayer, srcCount, srcZ, srcDepth := imageCopyLayers(srcTex.dimension, r.SrcBase.Origin.Z, r.Size.DepthOrArrayLayers)
+		dstLayer, dstCount, dstZ, dstDepth := imageCopyLayers(dstTex.dimension, r.DstBase.Origin.Z, r.Size.DepthOrArrayLayers)
 		vkRegions[i] = vk.ImageCopy{
 			SrcSubresource: vk.ImageSubresourceLayers{
 				AspectMask:     textureAspectToVk(r.SrcBase.Aspect, srcTex.format),
 				MipLevel:       r.SrcBase.MipLevel,
-				BaseArrayLayer: 0,
-				LayerCount:     1,
+				BaseArrayLayer: srcLayer,
+				LayerCount:     srcCount,
 			},
 			SrcOffset: vk.Offset3D{
 				X: int32(r.SrcBase.Origin.X),
 				Y: int32(r.SrcBase.Origin.Y),
-				Z: int32(r.SrcBase.Origin.Z),
+				Z: srcZ,
 			},
 			DstSubresource: vk.ImageSubresourceLayers{
 				AspectMask:     textureAspectToVk(r.DstBase.Aspect, dstTex.format),
 				MipLevel:       r.DstBase.MipLevel,
-				BaseArrayLayer: 0,
-				LayerCount:     1,
+				BaseArrayLayer: dstLayer,
+				LayerCount:     dstCount,
 			},
 			DstOffset: vk.Offset3D{
 				X: int32(r.DstBase.Origin.X),
 				Y: int32(r.DstBase.Origin.Y),
-				Z: int32(r.DstBase.Origin.Z),
+				Z: dstZ,
 			},
+			// Between a 3D and a layered image the slice count moves into
+			// the extent depth, matching the other side's layer count.
 			Extent: vk.Extent3D{
 				Width:  r.Size.Width,
 				Height: r.Size.Height,
-				Depth:  r.Size.DepthOrArrayLayers,
+				Depth:  max(srcDepth, dstDepth),
 			},
 		}
 	}
@@ -575,24 +588,17 @@ func (e *CommandEncoder) ResolveQuerySet(querySet hal.QuerySet, firstQuery, quer
 	}
 
 	// Pipeline barrier: ensure timestamps are written before copy.
-	memBarrier := vk.MemoryBarrier{
-		SType:         vk.StructureTypeMemoryBarrier,
-		SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
-		DstAccessMask: vk.AccessFlags(vk.AccessTransferReadBit),
-	}
-	vkCmdPipelineBarrier(
-		e.device.cmds,
-		e.active,
-		vk.PipelineStageFlags(vk.PipelineStageAllCommandsBit),
-		vk.PipelineStageFlags(vk.PipelineStageTransferBit),
-		0,
-		1, &memBarrier,
-		0, nil,
-		0, nil,
-	)
-
-	// Use vkCmdCopyQueryPoolResults to copy timestamp values to the buffer.
-	// Stride is 8 bytes per timestamp (uint64).
+	pipelineBarrier(e.device, e.active, []vk.MemoryBarrier2{{
+		SType:         vk.StructureTypeMemoryBarrier2,
+		SrcStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2AllCommandsBit),
+		SrcAccessMask: vk.AccessFlags2(vk.Access2TransferWriteBit),
+		DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2AllTransferBit),
+		DstAccessMask: vk.AccessFlags2(vk.Access2TransferReadBit),
+	}}, nil, nil)
+
+	// Use vkCmdCopyQueryPoolResults to copy query results to the buffer.
+	// Stride is one uint64 per timestamp or occlusion query, or per
+	// selected counter of a pipeline statistics query.
 	// Flags: VK_QUERY_RESULT_64_BIT | VK_QUERY_RESULT_WAIT_BIT.
 	vkCmdCopyQueryPoolResults(
 		e.device.cmds,
@@ -602,9 +608,15 @@ func (e *CommandEncoder) ResolveQuerySet(querySet hal.QuerySet, firstQuery, quer
 		queryCount,
 		buf.handle,
 		destinationOffset,
-		8, // stride: sizeof(uint64)
+		qs.resultStride, // sizeof(uint64) per timestamp or selected statistic
 		vk.QueryResultFlags(vk.QueryResult64Bit|vk.QueryResultWaitBit),
 	)
+
+	// Render passes cannot reset queries, so pipeline statistics queries
+	// are reset here, after their results are copied, for their next use.
+	if qs.queryType == hal.QueryTypePipelineStatistics {
+		e.device.cmds.CmdResetQueryPool(e.active, qs.pool, firstQuery, queryCount)
+	}
 }
 
 // BeginRenderPass begins a render pass using VkRenderPass (classic Vulkan approach).
@@ -620,7 +632,11 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 	rpe.renderPass = 0
 	rpe.framebuffer = 0
 
-	if e.active == 0 || len(desc.ColorAttachments) == 0 {
+	if e.active == 0 {
+		return rpe
+	}
+	if len(desc.ColorAttachments) == 0 {
+		e.beginDepthOnlyRenderPass(desc.DepthStencilAttachment, desc.ViewCount, rpe)
 		return rpe
 	}
 
@@ -695,17 +711,14 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 		SampleCount:      sampleCount,
 		ColorFinalLayout: colorFinalLayout,
 		HasResolve:       hasMSAAResolve,
+		ViewCount:        desc.ViewCount,
 	}
 
 	// Handle depth/stencil attachment
 	if desc.DepthStencilAttachment != nil {
 		dsa := desc.DepthStencilAttachment
 		if dsView, ok := dsa.View.(*TextureView); ok && dsView.texture != nil {
-			rpKey.DepthFormat = textureFormatToVk(dsView.texture.format)
-			rpKey.DepthLoadOp = loadOpToVk(dsa.DepthLoadOp)
-			rpKey.DepthStoreOp = storeOpToVk(dsa.DepthStoreOp)
-			rpKey.StencilLoadOp = loadOpToVk(dsa.StencilLoadOp)
-			rpKey.StencilStoreOp = storeOpToVk(dsa.StencilStoreOp)
+			e.setDepthStencilKey(&rpKey, dsa, dsView.texture.format)
 		}
 	}
 
@@ -787,6 +800,96 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 	vkCmdBeginRenderPass(e.device.cmds, e.active, &renderPassBegin, vk.SubpassContentsInline)
 	runtime.KeepAlive(clearValues)
 
+	e.setRenderPassDefaults(renderWidth, renderHeight)
+	return rpe
+}
+
+// beginDepthOnlyRenderPass begins a render pass whose only attachment is
+// depth/stencil, such as a shadow map pass. Without one, no render pass is
+// begun and the encoder records nothing.
+func (e *CommandEncoder) beginDepthOnlyRenderPass(dsa *hal.RenderPassDepthStencilAttachment, viewCount uint32, rpe *RenderPassEncoder) {
+	if dsa == nil {
+		return
+	}
+	dsView, ok := dsa.View.(*TextureView)
+	if !ok || dsView.texture == nil {
+		return
+	}
+	sampleCount := vk.SampleCountFlagBits(1)
+	if dsView.texture.samples > 1 {
+		sampleCount = vk.SampleCountFlagBits(dsView.texture.samples)
+	}
+
+	rpKey := RenderPassKey{
+		SampleCount: sampleCount,
+		ViewCount:   viewCount,
+	}
+	e.setDepthStencilKey(&rpKey, dsa, dsView.texture.format)
+	cache := e.device.GetRenderPassCache()
+	renderPass, err := cache.GetOrCreateRenderPass(rpKey)
+	if err != nil {
+		return
+	}
+	rpe.renderPass = renderPass
+
+	renderWidth := dsView.size.Width
+	renderHeight := dsView.size.Height
+	framebuffer, err := cache.GetOrCreateFramebuffer(FramebufferKey{
+		RenderPass: renderPass,
+		DepthView:  dsView.handle,
+		Width:      renderWidth,
+		Height:     renderHeight,
+	})
+	if err != nil {
+		return
+	}
+	rpe.framebuffer = framebuffer
+
+	clearValue := vk.ClearValueDepthStencil(dsa.DepthClearValue, dsa.StencilClearValue)
+	renderPassBegin := vk.RenderPassBeginInfo{
+		SType:       vk.StructureTypeRenderPassBeginInfo,
+		RenderPass:  renderPass,
+		Framebuffer: framebuffer,
+		RenderArea: vk.Rect2D{
+			Offset: vk.Offset2D{X: 0, Y: 0},
+			Extent: vk.Extent2D{Width: renderWidth, Height: renderHeight},
+		},
+		ClearValueCount: 1,
+		PClearValues:    &clearValue,
+	}
+
+	vkCmdBeginRenderPass(e.device.cmds, e.active, &renderPassBegin, vk.SubpassContentsInline)
+	runtime.KeepAlive(clearValue)
+
+	e.setRenderPassDefaults(renderWidth, renderHeight)
+}
+
+// setDepthStencilKey fills in the depth/stencil attachment of a render pass
+// key. A read-only aspect is loaded and kept whatever its load and store
+// operations say, and the attachment is in the layout TransitionTextures
+// gives its usage: RenderAttachment, plus TextureBinding for read-only
+// aspects.
+func (e *CommandEncoder) setDepthStencilKey(key *RenderPassKey, dsa *hal.RenderPassDepthStencilAttachment, format gputypes.TextureFormat) {
+	key.DepthFormat = textureFormatToVk(format)
+	key.DepthLoadOp = loadOpToVk(dsa.DepthLoadOp)
+	key.DepthStoreOp = storeOpToVk(dsa.DepthStoreOp)
+	if dsa.DepthReadOnly {
+		key.DepthLoadOp = vk.AttachmentLoadOpLoad
+		key.DepthStoreOp = vk.AttachmentStoreOpStore
+	}
+	key.StencilLoadOp = loadOpToVk(dsa.StencilLoadOp)
+	key.StencilStoreOp = storeOpToVk(dsa.StencilStoreOp)
+	if dsa.StencilReadOnly {
+		key.StencilLoadOp = vk.AttachmentLoadOpLoad
+		key.StencilStoreOp = vk.AttachmentStoreOpStore
+	}
+	key.DepthLayout = depthStencilAttachmentLayout(dsa, format, e.device.depthStencilLayouts)
+}
+
+// setRenderPassDefaults sets the dynamic state every render pass starts with:
+// a viewport and scissor covering the render area, zero blend constants and a
+// zero stencil reference.
+func (e *CommandEncoder) setRenderPassDefaults(renderWidth, renderHeight uint32) {
 	// Set default viewport and scissor for the render area.
 	// These are required since the pipeline uses dynamic viewport/scissor state.
 	// NOTE: Viewport Y-flip is required for WebGPU/OpenGL coordinate system compatibility.
@@ -822,8 +925,6 @@ func (e *CommandEncoder) BeginRenderPass(desc *hal.RenderPassDescriptor) hal.Ren
 	vkCmdSetBlendConstants(e.device.cmds, e.active, &[4]float32{0, 0, 0, 0})
 	vkCmdSetStencilReference(e.device.cmds, e.active,
 		vk.StencilFaceFlags(vk.StencilFaceFrontAndBack), 0)
-
-	return rpe
 }
 
 // BeginComputePass begins a compute pass.
@@ -930,7 +1031,8 @@ func (e *RenderPassEncoder) SetBindGroup(index uint32, group hal.BindGroup, offs
 
 // SetVertexBuffer sets a vertex buffer.
 // Uses stack variables instead of slice allocations (VK-PERF-007).
-func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset uint64) {
+// vkCmdBindVertexBuffers takes no size; core has already checked it.
+func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || e.encoder.active == 0 {
 		return
@@ -944,7 +1046,8 @@ func (e *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer hal.Buffer, offs
 }
 
 // SetIndexBuffer sets the index buffer.
-func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset uint64) {
+// As for vertex buffers, Vulkan takes no size; core has checked it.
+func (e *RenderPassEncoder) SetIndexBuffer(buffer hal.Buffer, format gputypes.IndexFormat, offset, _ uint64) {
 	buf, ok := buffer.(*Buffer)
 	if !ok || e.encoder.active == 0 {
 		return
@@ -1141,6 +1244,33 @@ func (e *RenderPassEncoder) ExecuteBundle(bundle hal.RenderBundle) {
 	)
 }
 
+// BeginConditionalRendering starts VK_EXT_conditional_rendering on the
+// 32-bit value at offset. Implements hal.ConditionalRenderPassEncoder.
+func (e *RenderPassEncoder) BeginConditionalRendering(buffer hal.Buffer, offset uint64, inverted bool) {
+	buf, ok := buffer.(*Buffer)
+	if !ok || e.encoder.active == 0 || !e.encoder.device.supportsConditionalRendering {
+		return
+	}
+	info := vk.ConditionalRenderingBeginInfoEXT{
+		SType:  vk.StructureTypeConditionalRenderingBeginInfoExt,
+		Buffer: buf.handle,
+		Offset: vk.DeviceSize(offset),
+	}
+	if inverted {
+		info.Flags = vk.ConditionalRenderingFlagsEXT(vk.ConditionalRenderingInvertedBitExt)
+	}
+	e.encoder.device.cmds.CmdBeginConditionalRenderingEXT(e.encoder.active, &info)
+}
+
+// EndConditionalRendering ends the conditional rendering block.
+// Implements hal.ConditionalRenderPassEncoder.
+func (e *RenderPassEncoder) EndConditionalRendering() {
+	if e.encoder.active == 0 || !e.encoder.device.supportsConditionalRendering {
+		return
+	}
+	e.encoder.device.cmds.CmdEndConditionalRenderingEXT(e.encoder.active)
+}
+
 // ComputePassEncoder implements hal.ComputePassEncoder for Vulkan.
 type ComputePassEncoder struct {
 	encoder         *CommandEncoder
@@ -1179,21 +1309,13 @@ func (e *ComputePassEncoder) End() {
 	}
 
 	// Global memory barrier: compute writes → everything after.
-	memBarrier := vk.MemoryBarrier{
-		SType:         vk.StructureTypeMemoryBarrier,
-		SrcAccessMask: vk.AccessFlags(vk.AccessShaderWriteBit),
-		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit | vk.AccessTransferReadBit | vk.AccessTransferWriteBit | vk.AccessHostReadBit),
-	}
-	vkCmdPipelineBarrier(
-		e.encoder.device.cmds,
-		e.encoder.active,
-		vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit),
-		vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit|vk.PipelineStageTransferBit|vk.PipelineStageHostBit),
-		0,
-		1, &memBarrier,
-		0, nil,
-		0, nil,
-	)
+	pipelineBarrier(e.encoder.device, e.encoder.active, []vk.MemoryBarrier2{{
+		SType:         vk.StructureTypeMemoryBarrier2,
+		SrcStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit),
+		SrcAccessMask: vk.AccessFlags2(vk.Access2ShaderWriteBit),
+		DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit | vk.PipelineStage2AllTransferBit | vk.PipelineStage2HostBit),
+		DstAccessMask: vk.AccessFlags2(vk.Access2ShaderReadBit | vk.Access2TransferReadBit | vk.Access2TransferWriteBit | vk.Access2HostReadBit),
+	}}, nil, nil)
 
 	// Return to pool for reuse.
 	e.encoder = nil
@@ -1264,21 +1386,13 @@ func (e *ComputePassEncoder) DispatchIndirect(buffer hal.Buffer, offset uint64)
 // dispatches. This is the "global barrier" approach (Option B) — always correct,
 // slightly over-synchronizes but avoids the complexity of per-resource usage tracking.
 func (e *ComputePassEncoder) insertComputeBarrier() {
-	memBarrier := vk.MemoryBarrier{
-		SType:         vk.StructureTypeMemoryBarrier,
-		SrcAccessMask: vk.AccessFlags(vk.AccessShaderWriteBit),
-		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit | vk.AccessShaderWriteBit),
-	}
-	vkCmdPipelineBarrier(
-		e.encoder.device.cmds,
-		e.encoder.active,
-		vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit),
-		vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit),
-		0,
-		1, &memBarrier,
-		0, nil,
-		0, nil,
-	)
+	pipelineBarrier(e.encoder.device, e.encoder.active, []vk.MemoryBarrier2{{
+		SType:         vk.StructureTypeMemoryBarrier2,
+		SrcStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit),
+		SrcAccessMask: vk.AccessFlags2(vk.Access2ShaderWriteBit),
+		DstStageMask:  vk.PipelineStageFlags2(vk.PipelineStage2ComputeShaderBit),
+		DstAccessMask: vk.AccessFlags2(vk.Access2ShaderReadBit | vk.Access2ShaderWriteBit),
+	}}, nil, nil)
 }
 
 // --- Helper functions ---
@@ -1316,94 +1430,6 @@ func updateSwapchainLayout(view *TextureView, resolveView *TextureView, hasMSAAR
 	}
 }
 
-//nolint:unparam // stage will be used when barrier optimization is implemented
-func bufferUsageToAccessAndStage(usage gputypes.BufferUsage) (vk.AccessFlags, vk.PipelineStageFlags) {
-	var access vk.AccessFlags
-	var stage vk.PipelineStageFlags
-
-	if usage&gputypes.BufferUsageCopySrc != 0 {
-		access |= vk.AccessFlags(vk.AccessTransferReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageTransferBit)
-	}
-	if usage&gputypes.BufferUsageCopyDst != 0 {
-		access |= vk.AccessFlags(vk.AccessTransferWriteBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageTransferBit)
-	}
-	if usage&gputypes.BufferUsageVertex != 0 {
-		access |= vk.AccessFlags(vk.AccessVertexAttributeReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageVertexInputBit)
-	}
-	if usage&gputypes.BufferUsageIndex != 0 {
-		access |= vk.AccessFlags(vk.AccessIndexReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageVertexInputBit)
-	}
-	if usage&gputypes.BufferUsageUniform != 0 {
-		access |= vk.AccessFlags(vk.AccessUniformReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageVertexShaderBit | vk.PipelineStageFragmentShaderBit)
-	}
-	if usage&gputypes.BufferUsageStorage != 0 {
-		access |= vk.AccessFlags(vk.AccessShaderReadBit | vk.AccessShaderWriteBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageVertexShaderBit | vk.PipelineStageFragmentShaderBit | vk.PipelineStageComputeShaderBit)
-	}
-	if usage&gputypes.BufferUsageIndirect != 0 {
-		access |= vk.AccessFlags(vk.AccessIndirectCommandReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageDrawIndirectBit)
-	}
-
-	if stage == 0 {
-		stage = vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit)
-	}
-
-	return access, stage
-}
-
-//nolint:unparam // stage will be used when barrier optimization is implemented
-func textureUsageToAccessStageLayout(usage gputypes.TextureUsage) (vk.AccessFlags, vk.PipelineStageFlags, vk.ImageLayout) {
-	// Usage 0 means "initial/undefined" — the image has no prior usage.
-	// Newly created Vulkan images start in VK_IMAGE_LAYOUT_UNDEFINED.
-	// Using ImageLayoutGeneral here would lie about the old layout,
-	// causing validation errors and undefined behavior on the barrier.
-	if usage == 0 {
-		return 0, vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit), vk.ImageLayoutUndefined
-	}
-
-	var access vk.AccessFlags
-	var stage vk.PipelineStageFlags
-	layout := vk.ImageLayoutGeneral
-
-	if usage&gputypes.TextureUsageCopySrc != 0 {
-		access |= vk.AccessFlags(vk.AccessTransferReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageTransferBit)
-		layout = vk.ImageLayoutTransferSrcOptimal
-	}
-	if usage&gputypes.TextureUsageCopyDst != 0 {
-		access |= vk.AccessFlags(vk.AccessTransferWriteBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageTransferBit)
-		layout = vk.ImageLayoutTransferDstOptimal
-	}
-	if usage&gputypes.TextureUsageTextureBinding != 0 {
-		access |= vk.AccessFlags(vk.AccessShaderReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit)
-		layout = vk.ImageLayoutShaderReadOnlyOptimal
-	}
-	if usage&gputypes.TextureUsageStorageBinding != 0 {
-		access |= vk.AccessFlags(vk.AccessShaderReadBit | vk.AccessShaderWriteBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit)
-		layout = vk.ImageLayoutGeneral
-	}
-	if usage&gputypes.TextureUsageRenderAttachment != 0 {
-		access |= vk.AccessFlags(vk.AccessColorAttachmentWriteBit | vk.AccessColorAttachmentReadBit)
-		stage |= vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit)
-		layout = vk.ImageLayoutColorAttachmentOptimal
-	}
-
-	if stage == 0 {
-		stage = vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit)
-	}
-
-	return access, stage, layout
-}
-
 func mipLevelCountOrRemaining(count uint32) uint32 {
 	if count == 0 {
 		return vk.RemainingMipLevels
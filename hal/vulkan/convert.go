@@ -63,6 +63,31 @@ func textureUsageToVk(usage gputypes.TextureUsage) vk.ImageUsageFlags {
 	return flags
 }
 
+// vkImageUsageToTextureUsage converts Vulkan image usage flags to the WebGPU
+// texture usages they allow. Flags without a WebGPU equivalent are dropped.
+func vkImageUsageToTextureUsage(flags vk.ImageUsageFlags) gputypes.TextureUsage {
+	var usage gputypes.TextureUsage
+	bits := vk.Flags(flags)
+
+	if bits&vk.Flags(vk.ImageUsageTransferSrcBit) != 0 {
+		usage |= gputypes.TextureUsageCopySrc
+	}
+	if bits&vk.Flags(vk.ImageUsageTransferDstBit) != 0 {
+		usage |= gputypes.TextureUsageCopyDst
+	}
+	if bits&vk.Flags(vk.ImageUsageSampledBit) != 0 {
+		usage |= gputypes.TextureUsageTextureBinding
+	}
+	if bits&vk.Flags(vk.ImageUsageStorageBit) != 0 {
+		usage |= gputypes.TextureUsageStorageBinding
+	}
+	if bits&vk.Flags(vk.ImageUsageColorAttachmentBit) != 0 {
+		usage |= gputypes.TextureUsageRenderAttachment
+	}
+
+	return usage
+}
+
 // textureDimensionToVkImageType converts WebGPU texture dimension to Vulkan image type.
 func textureDimensionToVkImageType(dim gputypes.TextureDimension) vk.ImageType {
 	switch dim {
@@ -86,6 +111,21 @@ func textureFormatToVk(format gputypes.TextureFormat) vk.Format {
 	return vk.FormatUndefined
 }
 
+// viewFormatToVk converts the format of a texture view with aspect to a
+// Vulkan format. Plane views of multi-planar textures may use the 16-bit
+// normalized formats, which are not otherwise mapped.
+func viewFormatToVk(format gputypes.TextureFormat, aspect gputypes.TextureAspect) vk.Format {
+	if _, ok := hal.PlaneIndex(aspect); ok {
+		switch format {
+		case gputypes.TextureFormatR16Unorm:
+			return vk.FormatR16Unorm
+		case gputypes.TextureFormatRG16Unorm:
+			return vk.FormatR16g16Unorm
+		}
+	}
+	return textureFormatToVk(format)
+}
+
 // textureFormatMap maps WebGPU texture formats to Vulkan formats.
 var textureFormatMap = map[gputypes.TextureFormat]vk.Format{
 	// 8-bit formats
@@ -202,6 +242,10 @@ var textureFormatMap = map[gputypes.TextureFormat]vk.Format{
 	gputypes.TextureFormatASTC12x10UnormSrgb: vk.FormatAstc12x10SrgbBlock,
 	gputypes.TextureFormatASTC12x12Unorm:     vk.FormatAstc12x12UnormBlock,
 	gputypes.TextureFormatASTC12x12UnormSrgb: vk.FormatAstc12x12SrgbBlock,
+
+	// Multi-planar video formats
+	hal.TextureFormatNV12: vk.FormatG8B8r82plane420Unorm,
+	hal.TextureFormatP010: vk.FormatG10x6B10x6r10x62plane420Unorm3pack16,
 }
 
 // addressModeToVk converts WebGPU address mode to Vulkan sampler address mode.
@@ -419,6 +463,18 @@ func cullModeToVk(mode gputypes.CullMode) vk.CullModeFlags {
 	}
 }
 
+// polygonModeToVk converts a HAL polygon mode to Vulkan polygon mode.
+func polygonModeToVk(mode hal.PolygonMode) vk.PolygonMode {
+	switch mode {
+	case hal.PolygonModeLine:
+		return vk.PolygonModeLine
+	case hal.PolygonModePoint:
+		return vk.PolygonModePoint
+	default:
+		return vk.PolygonModeFill
+	}
+}
+
 // frontFaceToVk converts WebGPU front face to Vulkan front face.
 func frontFaceToVk(face gputypes.FrontFace) vk.FrontFace {
 	switch face {
@@ -562,6 +618,10 @@ func textureAspectToVk(aspect gputypes.TextureAspect, format gputypes.TextureFor
 		return vk.ImageAspectFlags(vk.ImageAspectDepthBit)
 	case gputypes.TextureAspectStencilOnly:
 		return vk.ImageAspectFlags(vk.ImageAspectStencilBit)
+	case hal.TextureAspectPlane0:
+		return vk.ImageAspectFlags(vk.ImageAspectPlane0Bit)
+	case hal.TextureAspectPlane1:
+		return vk.ImageAspectFlags(vk.ImageAspectPlane1Bit)
 	default:
 		// TextureAspectAll and TextureAspectUndefined both derive
 		// the correct aspect mask from the texture format.
@@ -589,6 +649,23 @@ func textureAspectToVkSimple(aspect gputypes.TextureAspect) vk.ImageAspectFlags
 	}
 }
 
+// hostCopyEligible reports whether a texture may be created with
+// VK_IMAGE_USAGE_HOST_TRANSFER_BIT_EXT so WriteTexture can upload it through
+// host image copy. Only upload-and-sample textures of uncompressed color
+// formats qualify: the host transfer bit can disable compression on
+// attachments and storage images, and compressed or depth/stencil data
+// would need per-format row length and aspect handling.
+func hostCopyEligible(usage gputypes.TextureUsage, format gputypes.TextureFormat, samples uint32) bool {
+	const allowed = gputypes.TextureUsageCopyDst | gputypes.TextureUsageCopySrc | gputypes.TextureUsageTextureBinding
+	if usage&gputypes.TextureUsageCopyDst == 0 || usage&^allowed != 0 || samples > 1 {
+		return false
+	}
+	if isDepthStencilFormat(format) || format.BlockCopySize() == 0 {
+		return false
+	}
+	return format < gputypes.TextureFormatBC1RGBAUnorm || format > gputypes.TextureFormatASTC12x12UnormSrgb
+}
+
 // isDepthStencilFormat returns true if the format is a depth or depth-stencil format.
 func isDepthStencilFormat(format gputypes.TextureFormat) bool {
 	switch format {
@@ -616,6 +693,12 @@ func hasStencilAspect(format gputypes.TextureFormat) bool {
 	}
 }
 
+// hasDepthAndStencil returns true if the format has both a depth and a
+// stencil aspect.
+func hasDepthAndStencil(format gputypes.TextureFormat) bool {
+	return hasStencilAspect(format) && format != gputypes.TextureFormatStencil8
+}
+
 // textureDimensionToViewType converts WebGPU texture dimension to default Vulkan image view type.
 func textureDimensionToViewType(dim gputypes.TextureDimension) vk.ImageViewType {
 	switch dim {
@@ -128,6 +128,23 @@ func TestTextureUsageToVk(t *testing.T) {
 	}
 }
 
+// TestVkImageUsageToTextureUsage tests that supported swapchain image usage
+// flags convert back to the texture usages they allow.
+func TestVkImageUsageToTextureUsage(t *testing.T) {
+	all := gputypes.TextureUsageCopySrc | gputypes.TextureUsageCopyDst |
+		gputypes.TextureUsageTextureBinding | gputypes.TextureUsageStorageBinding |
+		gputypes.TextureUsageRenderAttachment
+	if got := vkImageUsageToTextureUsage(textureUsageToVk(all)); got != all {
+		t.Errorf("round trip = %v, want %v", got, all)
+	}
+
+	flags := vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit) |
+		vk.ImageUsageFlags(vk.ImageUsageInputAttachmentBit)
+	if got := vkImageUsageToTextureUsage(flags); got != gputypes.TextureUsageRenderAttachment {
+		t.Errorf("vkImageUsageToTextureUsage(color|input) = %v, want RenderAttachment", got)
+	}
+}
+
 // TestTextureDimensionToVkImageType tests texture dimension conversions.
 func TestTextureDimensionToVkImageType(t *testing.T) {
 	tests := []struct {
@@ -954,3 +971,131 @@ func TestBoolToVk(t *testing.T) {
 		})
 	}
 }
+
+// TestFramebufferSampleCounts tests per-aspect sample count selection.
+func TestFramebufferSampleCounts(t *testing.T) {
+	limits := vk.PhysicalDeviceLimits{
+		FramebufferColorSampleCounts:   vk.SampleCountFlags(vk.SampleCount1Bit | vk.SampleCount2Bit | vk.SampleCount4Bit | vk.SampleCount8Bit | vk.SampleCount64Bit),
+		FramebufferDepthSampleCounts:   vk.SampleCountFlags(vk.SampleCount1Bit | vk.SampleCount4Bit | vk.SampleCount8Bit),
+		FramebufferStencilSampleCounts: vk.SampleCountFlags(vk.SampleCount1Bit | vk.SampleCount4Bit),
+	}
+	tests := []struct {
+		format gputypes.TextureFormat
+		want   uint32
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, hal.SampleCount1 | hal.SampleCount2 | hal.SampleCount4 | hal.SampleCount8},
+		{gputypes.TextureFormatDepth32Float, hal.SampleCount1 | hal.SampleCount4 | hal.SampleCount8},
+		{gputypes.TextureFormatDepth24PlusStencil8, hal.SampleCount1 | hal.SampleCount4},
+		{gputypes.TextureFormatStencil8, hal.SampleCount1 | hal.SampleCount4},
+	}
+	for _, tt := range tests {
+		if got := framebufferSampleCounts(&limits, tt.format); got != tt.want {
+			t.Errorf("framebufferSampleCounts(%v) = %#x, want %#x", tt.format, got, tt.want)
+		}
+	}
+}
+
+// TestConvertBufferImageCopyRegionsLayers tests that array layers go into the
+// subresource and 3D slices into the offset and extent.
+func TestConvertBufferImageCopyRegionsLayers(t *testing.T) {
+	region := hal.BufferTextureCopy{
+		BufferLayout: hal.ImageDataLayout{BytesPerRow: 16, RowsPerImage: 4},
+		TextureBase:  hal.ImageCopyTexture{Origin: hal.Origin3D{Z: 2}},
+		Size:         hal.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 3},
+	}
+
+	got := convertBufferImageCopyRegions([]hal.BufferTextureCopy{region}, gputypes.TextureFormatRGBA8Unorm, gputypes.TextureDimension2D)[0]
+	if got.ImageSubresource.BaseArrayLayer != 2 || got.ImageSubresource.LayerCount != 3 ||
+		got.ImageOffset.Z != 0 || got.ImageExtent.Depth != 1 {
+		t.Errorf("2D array copy = layers %d+%d, z %d, depth %d; want layers 2+3, z 0, depth 1",
+			got.ImageSubresource.BaseArrayLayer, got.ImageSubresource.LayerCount, got.ImageOffset.Z, got.ImageExtent.Depth)
+	}
+
+	got = convertBufferImageCopyRegions([]hal.BufferTextureCopy{region}, gputypes.TextureFormatRGBA8Unorm, gputypes.TextureDimension3D)[0]
+	if got.ImageSubresource.BaseArrayLayer != 0 || got.ImageSubresource.LayerCount != 1 ||
+		got.ImageOffset.Z != 2 || got.ImageExtent.Depth != 3 {
+		t.Errorf("3D copy = layers %d+%d, z %d, depth %d; want layers 0+1, z 2, depth 3",
+			got.ImageSubresource.BaseArrayLayer, got.ImageSubresource.LayerCount, got.ImageOffset.Z, got.ImageExtent.Depth)
+	}
+}
+
+// TestHostCopyEligible tests which textures get the host transfer usage.
+func TestHostCopyEligible(t *testing.T) {
+	sampled := gputypes.TextureUsageCopyDst | gputypes.TextureUsageTextureBinding
+	tests := []struct {
+		name    string
+		usage   gputypes.TextureUsage
+		format  gputypes.TextureFormat
+		samples uint32
+		expect  bool
+	}{
+		{"SampledRGBA8", sampled, gputypes.TextureFormatRGBA8Unorm, 1, true},
+		{"CopyRoundTrip", sampled | gputypes.TextureUsageCopySrc, gputypes.TextureFormatR32Float, 1, true},
+		{"NoCopyDst", gputypes.TextureUsageTextureBinding, gputypes.TextureFormatRGBA8Unorm, 1, false},
+		{"RenderAttachment", sampled | gputypes.TextureUsageRenderAttachment, gputypes.TextureFormatRGBA8Unorm, 1, false},
+		{"Storage", sampled | gputypes.TextureUsageStorageBinding, gputypes.TextureFormatRGBA8Unorm, 1, false},
+		{"Multisampled", sampled, gputypes.TextureFormatRGBA8Unorm, 4, false},
+		{"Depth", sampled, gputypes.TextureFormatDepth32Float, 1, false},
+		{"BC1", sampled, gputypes.TextureFormatBC1RGBAUnorm, 1, false},
+		{"ASTC", sampled, gputypes.TextureFormatASTC4x4Unorm, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hostCopyEligible(tt.usage, tt.format, tt.samples); got != tt.expect {
+				t.Errorf("hostCopyEligible() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestSelectHostCopyLayout tests the host copy destination layout choice.
+func TestSelectHostCopyLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported []vk.ImageLayout
+		expect    vk.ImageLayout
+	}{
+		{"None", nil, vk.ImageLayoutUndefined},
+		{"GeneralOnly", []vk.ImageLayout{vk.ImageLayoutGeneral}, vk.ImageLayoutGeneral},
+		{"PreferShaderRead", []vk.ImageLayout{vk.ImageLayoutGeneral, vk.ImageLayoutShaderReadOnlyOptimal}, vk.ImageLayoutShaderReadOnlyOptimal},
+		{"Unusable", []vk.ImageLayout{vk.ImageLayoutTransferDstOptimal}, vk.ImageLayoutUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectHostCopyLayout(tt.supported); got != tt.expect {
+				t.Errorf("selectHostCopyLayout() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestPipelineStatisticsToVk tests pipeline statistics counter conversions.
+func TestPipelineStatisticsToVk(t *testing.T) {
+	tests := []struct {
+		name  string
+		types hal.PipelineStatisticsTypes
+		want  vk.QueryPipelineStatisticFlagBits
+	}{
+		{"none", 0, 0},
+		{"vertex", hal.PipelineStatisticsVertexShaderInvocations, vk.QueryPipelineStatisticVertexShaderInvocationsBit},
+		{
+			"clipper",
+			hal.PipelineStatisticsClipperInvocations | hal.PipelineStatisticsClipperPrimitivesOut,
+			vk.QueryPipelineStatisticClippingInvocationsBit | vk.QueryPipelineStatisticClippingPrimitivesBit,
+		},
+		{
+			"fragment and compute",
+			hal.PipelineStatisticsFragmentShaderInvocations | hal.PipelineStatisticsComputeShaderInvocations,
+			vk.QueryPipelineStatisticFragmentShaderInvocationsBit | vk.QueryPipelineStatisticComputeShaderInvocationsBit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pipelineStatisticsToVk(tt.types); got != vk.QueryPipelineStatisticFlags(tt.want) {
+				t.Errorf("pipelineStatisticsToVk(%v) = %#x, want %#x", tt.types, got, tt.want)
+			}
+		})
+	}
+}
@@ -66,20 +66,8 @@ func vulkanDebugCallback(severity, types, callbackData, userData uintptr) uintpt
 		level = slog.LevelDebug
 	}
 
-	// Determine message type.
-	typeBits := vk.DebugUtilsMessageTypeFlagBitsEXT(types)
-	var typeStr string
-	switch {
-	case typeBits&vk.DebugUtilsMessageTypeValidationBitExt != 0:
-		typeStr = "Validation"
-	case typeBits&vk.DebugUtilsMessageTypePerformanceBitExt != 0:
-		typeStr = "Performance"
-	default:
-		typeStr = "General"
-	}
-
 	attrs := []slog.Attr{
-		slog.String("type", typeStr),
+		slog.String("type", debugMessageType(vk.DebugUtilsMessageTypeFlagBitsEXT(types), msgID)),
 	}
 	if msgID != "" {
 		attrs = append(attrs, slog.String("id", msgID))
@@ -174,7 +162,9 @@ func (d *Device) setObjectName(objectType vk.ObjectType, handle uint64, name str
 	// Vulkan object creation. The buffer is grown once and reused for all
 	// subsequent setObjectName calls. Vulkan consumes the name synchronously
 	// in vkSetDebugUtilsObjectNameEXT, so the buffer is safe to reuse after
-	// the call returns. Not thread-safe — resource creation is single-threaded.
+	// the call returns. The lock covers concurrent resource creation.
+	d.debugNameMu.Lock()
+	defer d.debugNameMu.Unlock()
 	needed := len(name) + 1 // +1 for null terminator
 	if cap(d.debugNameBuf) < needed {
 		d.debugNameBuf = make([]byte, needed)
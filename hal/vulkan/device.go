@@ -8,6 +8,8 @@ package vulkan
 import (
 	"encoding/binary"
 	"fmt"
+	"log/slog"
+	"slices"
 	"sync"
 	"time"
 	"unsafe"
@@ -28,6 +30,10 @@ import (
 // CommandEncoder owns its own VkCommandPool with internal free list.
 type commandAllocator struct {
 	pool vk.CommandPool
+
+	// protected pools were created with VK_COMMAND_POOL_CREATE_PROTECTED_BIT
+	// and only serve protected encoders.
+	protected bool
 }
 
 // encoderPool reuses CommandEncoder structs across CreateCommandEncoder calls.
@@ -74,6 +80,63 @@ type Device struct {
 	// compositor about which surface regions changed (damage rects).
 	supportsIncrementalPresent bool
 
+	// supportsPresentFences is true when VK_EXT_swapchain_maintenance1 is
+	// enabled. Present then chains VkSwapchainPresentFenceInfoEXT so the
+	// swapchain knows exactly when the presentation engine released each
+	// image's present semaphore, instead of inferring it from later acquires.
+	supportsPresentFences bool
+
+	// supportsConditionalRendering is true when VK_EXT_conditional_rendering
+	// is enabled. Indirect buffers then also get the conditional rendering
+	// usage bit so they can hold draw predicates.
+	supportsConditionalRendering bool
+
+	// supportsSynchronization2 is true when VK_KHR_synchronization2 is
+	// enabled and its commands loaded. Barriers then go through
+	// vkCmdPipelineBarrier2KHR and submits through vkQueueSubmit2KHR.
+	supportsSynchronization2 bool
+
+	// supportsImageFormatList is true when VkImageFormatListCreateInfo may
+	// be chained into vkCreateImage (Vulkan 1.2 or VK_KHR_image_format_list),
+	// letting the driver keep compression on mutable-format images.
+	supportsImageFormatList bool
+
+	// supportsMemoryBudget is true when VK_EXT_memory_budget is enabled, so
+	// MemoryBudget can chain VkPhysicalDeviceMemoryBudgetPropertiesEXT.
+	supportsMemoryBudget bool
+
+	// externalSemaphoreType is the handle type shared fences are exported
+	// with (VK_KHR_external_semaphore_fd or _win32), or 0 when the device
+	// cannot export timeline semaphores (hal.FenceSharer).
+	externalSemaphoreType vk.ExternalSemaphoreHandleTypeFlagBits
+
+	// depthStencilLayouts is how TransitionTextures and render passes lay
+	// out the aspects of a combined depth/stencil image that are used
+	// differently, such as a sampled read-only depth aspect next to a
+	// written stencil attachment.
+	depthStencilLayouts depthStencilLayoutMode
+
+	// depthStencilViews holds the views of depth/stencil textures. Bind
+	// groups sample them in DEPTH_STENCIL_READ_ONLY_OPTIMAL, the layout
+	// TransitionTextures gives sampled depth/stencil textures, which a
+	// read-only depth attachment can stay in while it is sampled.
+	depthStencilViews   map[vk.ImageView]struct{}
+	depthStencilViewsMu sync.Mutex
+
+	// external is true for a device adopted through Adapter.Adopt. Destroy
+	// then releases what the HAL created but not the VkDevice itself.
+	external bool
+
+	// protectedMemory is true when the device was opened with
+	// hal.FeatureProtectedContent: the queue is protected-capable and
+	// protected images, command pools and swapchains may be created.
+	protectedMemory bool
+
+	// hostCopyLayout is the image layout Queue.WriteTexture copies into
+	// through VK_EXT_host_image_copy, or ImageLayoutUndefined when host
+	// image copy is not enabled on this device.
+	hostCopyLayout vk.ImageLayout
+
 	// Timeline semaphore fence (VK-IMPL-001).
 	// When available (Vulkan 1.2+), replaces both frame fences and transfer fence
 	// with a single timeline semaphore. Falls back to binary fences on older drivers.
@@ -98,6 +161,10 @@ type Device struct {
 	// AMD/NVIDIA vary. Queried during initAllocator.
 	timestampPeriod float32
 
+	// maxSamplerLodBias bounds SamplerDescriptor.LodBias (from
+	// VkPhysicalDeviceLimits). Queried during initAllocator.
+	maxSamplerLodBias float32
+
 	// mappedMemory tracks persistently mapped VkDeviceMemory objects.
 	// Vulkan only allows one active vkMapMemory per VkDeviceMemory;
 	// with suballocation multiple buffers share the same VkDeviceMemory,
@@ -107,9 +174,11 @@ type Device struct {
 
 	// debugNameBuf is a reusable buffer for null-terminating debug label strings
 	// passed to vkSetDebugUtilsObjectNameEXT. Avoids heap allocation per
-	// Vulkan object creation (PERF-VK-001). Not thread-safe — setObjectName
-	// is only called during resource creation which is single-threaded per device.
+	// Vulkan object creation (PERF-VK-001). Resources and command encoders
+	// may be created from several goroutines at once, so setObjectName holds
+	// debugNameMu while it uses the buffer.
 	debugNameBuf []byte
+	debugNameMu  sync.Mutex // protects debugNameBuf
 
 	// configuredSurfaces contains surfaces whose live swapchains belong to this
 	// device. Device teardown retires them before destroying VkDevice.
@@ -118,6 +187,15 @@ type Device struct {
 	destroying         bool
 }
 
+// logger returns the owning instance's logger for category c. It is safe
+// to call on a Device built without an instance, as unit tests do.
+func (d *Device) logger(c hal.LogCategory) *slog.Logger {
+	if d == nil {
+		return hal.CategoryLogger(c)
+	}
+	return d.instance.logger(c)
+}
+
 func (d *Device) registerConfiguredSurface(surface *Surface) error {
 	if d == nil || surface == nil {
 		return fmt.Errorf("vulkan: cannot register a nil configured surface")
@@ -271,6 +349,7 @@ func (d *Device) queryNonCoherentAtomSize() {
 	if d.timestampPeriod <= 0 {
 		d.timestampPeriod = 1.0
 	}
+	d.maxSamplerLodBias = props.Limits.MaxSamplerLodBias
 
 	hal.Logger().Debug("vulkan: device limits queried",
 		"nonCoherentAtomSize", atomSize,
@@ -308,12 +387,54 @@ func (d *Device) MaxStagingBufferSize() uint64 {
 
 // CreateBuffer creates a GPU buffer.
 func (d *Device) CreateBuffer(desc *hal.BufferDescriptor) (hal.Buffer, error) {
+	b, err := d.newBuffer(desc)
+	if err != nil {
+		return nil, err
+	}
+	result := d.cmds.BindBufferMemory(d.handle, b.handle, b.memory.Memory, vk.DeviceSize(b.memory.Offset))
+	if result != vk.Success {
+		d.DestroyBuffer(b)
+		return nil, fmt.Errorf("vulkan: vkBindBufferMemory failed: %d", result)
+	}
+	if err := d.finishBuffer(b, desc); err != nil {
+		d.DestroyBuffer(b)
+		return nil, err
+	}
+	return b, nil
+}
+
+// bufferMemoryUsage returns the allocation usage for a buffer. Only
+// MAP_READ/MAP_WRITE buffers (and MappedAtCreation) need host-visible
+// memory. CopyDst buffers stay DEVICE_LOCAL — writes go through the staging
+// belt (CopySrc staging buffer + GPU CopyBufferToBuffer). Matches Rust
+// wgpu-hal device.rs:971-988. (BUG-VK-009 Fix 1)
+func bufferMemoryUsage(desc *hal.BufferDescriptor) memory.UsageFlags {
+	memUsage := memory.UsageFastDeviceAccess
+	if desc.Usage&(gputypes.BufferUsageMapRead|gputypes.BufferUsageMapWrite) != 0 || desc.MappedAtCreation {
+		memUsage = memory.UsageHostAccess
+		if desc.Usage&gputypes.BufferUsageMapWrite != 0 || desc.MappedAtCreation {
+			memUsage |= memory.UsageUpload
+		}
+		if desc.Usage&gputypes.BufferUsageMapRead != 0 {
+			memUsage |= memory.UsageDownload
+		}
+	}
+	return memUsage
+}
+
+// newBuffer creates the VkBuffer for desc and allocates its memory. Binding
+// the memory is left to the caller, so a batch can bind all of its buffers
+// in one call; finishBuffer completes the buffer once bound.
+func (d *Device) newBuffer(desc *hal.BufferDescriptor) (*Buffer, error) {
 	if desc == nil {
 		return nil, fmt.Errorf("BUG: buffer descriptor is nil in Vulkan.CreateBuffer — core validation gap")
 	}
 
 	// Convert usage flags
 	vkUsage := bufferUsageToVk(desc.Usage)
+	if d.supportsConditionalRendering && desc.Usage&gputypes.BufferUsageIndirect != 0 {
+		vkUsage |= vk.BufferUsageFlags(vk.BufferUsageConditionalRenderingBitExt)
+	}
 
 	// Create VkBuffer (without memory)
 	createInfo := vk.BufferCreateInfo{
@@ -333,27 +454,11 @@ func (d *Device) CreateBuffer(desc *hal.BufferDescriptor) (hal.Buffer, error) {
 	var memReqs vk.MemoryRequirements
 	d.cmds.GetBufferMemoryRequirements(d.handle, buffer, &memReqs)
 
-	// Determine usage flags for memory allocation.
-	// Only MAP_READ/MAP_WRITE buffers (and MappedAtCreation) need host-visible memory.
-	// CopyDst buffers stay DEVICE_LOCAL — writes go through the staging belt
-	// (CopySrc staging buffer + GPU CopyBufferToBuffer). Matches Rust wgpu-hal
-	// device.rs:971-988. (BUG-VK-009 Fix 1)
-	memUsage := memory.UsageFastDeviceAccess
-	if desc.Usage&(gputypes.BufferUsageMapRead|gputypes.BufferUsageMapWrite) != 0 || desc.MappedAtCreation {
-		memUsage = memory.UsageHostAccess
-		if desc.Usage&gputypes.BufferUsageMapWrite != 0 || desc.MappedAtCreation {
-			memUsage |= memory.UsageUpload
-		}
-		if desc.Usage&gputypes.BufferUsageMapRead != 0 {
-			memUsage |= memory.UsageDownload
-		}
-	}
-
 	// Allocate memory
 	memBlock, err := d.allocator.Alloc(memory.AllocationRequest{
 		Size:           uint64(memReqs.Size),
 		Alignment:      uint64(memReqs.Alignment),
-		Usage:          memUsage,
+		Usage:          bufferMemoryUsage(desc),
 		MemoryTypeBits: memReqs.MemoryTypeBits,
 	})
 	if err != nil {
@@ -361,36 +466,29 @@ func (d *Device) CreateBuffer(desc *hal.BufferDescriptor) (hal.Buffer, error) {
 		return nil, fmt.Errorf("vulkan: failed to allocate buffer memory: %w", err)
 	}
 
-	// Bind memory to buffer
-	result = d.cmds.BindBufferMemory(d.handle, buffer, memBlock.Memory, vk.DeviceSize(memBlock.Offset))
-	if result != vk.Success {
-		_ = d.allocator.Free(memBlock)
-		d.cmds.DestroyBuffer(d.handle, buffer, nil)
-		return nil, fmt.Errorf("vulkan: vkBindBufferMemory failed: %d", result)
-	}
-
-	// Map memory for host-visible buffers so WriteBuffer can write directly.
-	if memUsage&memory.UsageHostAccess != 0 {
-		if err := d.ensureMemoryMapped(memBlock); err != nil {
-			_ = d.allocator.Free(memBlock)
-			d.cmds.DestroyBuffer(d.handle, buffer, nil)
-			return nil, err
-		}
-	}
-
-	b := &Buffer{
+	return &Buffer{
 		handle: buffer,
 		memory: memBlock,
 		size:   desc.Size,
 		usage:  desc.Usage,
 		device: d,
+	}, nil
+}
+
+// finishBuffer maps a bound buffer's memory if it is host-visible, so
+// WriteBuffer can write directly, and names it.
+func (d *Device) finishBuffer(b *Buffer, desc *hal.BufferDescriptor) error {
+	if bufferMemoryUsage(desc)&memory.UsageHostAccess != 0 {
+		if err := d.ensureMemoryMapped(b.memory); err != nil {
+			return err
+		}
 	}
 	if desc.Label != "" {
-		d.setObjectName(vk.ObjectTypeBuffer, uint64(buffer), desc.Label)
+		d.setObjectName(vk.ObjectTypeBuffer, uint64(b.handle), desc.Label)
 	} else {
-		d.setObjectName(vk.ObjectTypeBuffer, uint64(buffer), "Buffer")
+		d.setObjectName(vk.ObjectTypeBuffer, uint64(b.handle), "Buffer")
 	}
-	return b, nil
+	return nil
 }
 
 // ensureMemoryMapped maps the VkDeviceMemory backing block if not already mapped.
@@ -537,11 +635,47 @@ func (d *Device) DestroyBuffer(buffer hal.Buffer) {
 	vkBuffer.device = nil
 }
 
+// imageViewFormats returns the Vulkan formats views of a texture may use:
+// format followed by the distinct entries of viewFormats.
+func imageViewFormats(format gputypes.TextureFormat, viewFormats []gputypes.TextureFormat) []vk.Format {
+	formats := []vk.Format{textureFormatToVk(format)}
+	for _, viewFormat := range viewFormats {
+		if vkFormat := textureFormatToVk(viewFormat); !slices.Contains(formats, vkFormat) {
+			formats = append(formats, vkFormat)
+		}
+	}
+	return formats
+}
+
 // CreateTexture creates a GPU texture.
 func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error) {
+	t, err := d.newTexture(desc, d.imageFormatSupported)
+	if err != nil {
+		return nil, err
+	}
+	result := d.cmds.BindImageMemory(d.handle, t.handle, t.memory.Memory, vk.DeviceSize(t.memory.Offset))
+	if result != vk.Success {
+		d.DestroyTexture(t)
+		return nil, fmt.Errorf("vulkan: vkBindImageMemory failed: %d", result)
+	}
+	d.nameTexture(t, desc.Label)
+	return t, nil
+}
+
+// imageFormatQuery reports whether an image with the given format, type,
+// usage and flags can be created; see Device.imageFormatSupported.
+type imageFormatQuery func(format vk.Format, imageType vk.ImageType, usage vk.ImageUsageFlags, flags vk.ImageCreateFlags) bool
+
+// newTexture creates the VkImage for desc and allocates its memory, asking
+// formatSupported about optional usages. Binding the memory is left to the
+// caller, so a batch can bind all of its images in one call.
+func (d *Device) newTexture(desc *hal.TextureDescriptor, formatSupported imageFormatQuery) (*Texture, error) {
 	if desc == nil {
 		return nil, fmt.Errorf("BUG: texture descriptor is nil in Vulkan.CreateTexture — core validation gap")
 	}
+	if desc.Protected && !d.protectedMemory {
+		return nil, fmt.Errorf("vulkan: protected texture requires FeatureProtectedContent")
+	}
 
 	// Convert parameters
 	vkFormat := textureFormatToVk(desc.Format)
@@ -583,15 +717,49 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 
 	// Determine image creation flags
 	var imageFlags vk.ImageCreateFlags
-	// Enable cube compatibility for 2D textures with 6+ layers (potential cubemaps)
-	if desc.Dimension == gputypes.TextureDimension2D && arrayLayers >= 6 {
+	// Cube and cube array views need CUBE_COMPATIBLE, which Vulkan only
+	// allows on square, single-sampled 2D images with at least 6 layers.
+	if desc.Dimension == gputypes.TextureDimension2D && arrayLayers >= 6 &&
+		desc.Size.Width == desc.Size.Height && samples == 1 {
 		imageFlags |= vk.ImageCreateFlags(vk.ImageCreateCubeCompatibleBit)
 	}
-	// Enable mutable format if view formats are specified
-	if len(desc.ViewFormats) > 0 {
+	// Views in another format (the sRGB counterpart) need a mutable-format
+	// image. The format list tells the driver which formats will be used.
+	viewFormats := imageViewFormats(desc.Format, desc.ViewFormats)
+	if len(viewFormats) > 1 {
+		imageFlags |= vk.ImageCreateFlags(vk.ImageCreateMutableFormatBit)
+	}
+	// Plane views of a multi-planar image use the plane's format, which
+	// also needs a mutable-format image.
+	if hal.IsMultiPlanarFormat(desc.Format) {
 		imageFlags |= vk.ImageCreateFlags(vk.ImageCreateMutableFormatBit)
 	}
 
+	// Transient attachments (TextureDescriptor.Transient) never leave the
+	// render pass. TRANSIENT_ATTACHMENT lets them live in lazily allocated
+	// memory, which tile-based GPUs (Android, Apple via MoltenVK) commit only
+	// if the pass spills out of tile memory.
+	allocUsage := memory.UsageFastDeviceAccess
+	if desc.Transient {
+		vkUsage |= vk.ImageUsageFlags(vk.ImageUsageTransientAttachmentBit)
+		allocUsage |= memory.UsageTransient
+	}
+	// Protected textures live in protected memory and are only accessible
+	// from protected command buffers.
+	if desc.Protected {
+		imageFlags |= vk.ImageCreateFlags(vk.ImageCreateProtectedBit)
+		allocUsage |= memory.UsageProtected
+	}
+
+	// On UMA devices with host image copy, upload-and-sample textures get
+	// the host transfer usage so WriteTexture can skip the staging buffer.
+	hostCopy := d.hostCopyLayout != vk.ImageLayoutUndefined && !desc.Protected &&
+		hostCopyEligible(desc.Usage, desc.Format, samples) &&
+		formatSupported(vkFormat, imageType, vkUsage|vk.ImageUsageFlags(vk.ImageUsageHostTransferBitExt), imageFlags)
+	if hostCopy {
+		vkUsage |= vk.ImageUsageFlags(vk.ImageUsageHostTransferBitExt)
+	}
+
 	// Create VkImage (without memory)
 	createInfo := vk.ImageCreateInfo{
 		SType:     vk.StructureTypeImageCreateInfo,
@@ -611,6 +779,15 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 		SharingMode:   vk.SharingModeExclusive,
 		InitialLayout: vk.ImageLayoutUndefined,
 	}
+	var formatList vk.ImageFormatListCreateInfo
+	if len(viewFormats) > 1 && d.supportsImageFormatList {
+		formatList = vk.ImageFormatListCreateInfo{
+			SType:           vk.StructureTypeImageFormatListCreateInfo,
+			ViewFormatCount: uint32(len(viewFormats)),
+			PViewFormats:    &viewFormats[0],
+		}
+		createInfo.PNext = (*uintptr)(unsafe.Pointer(&formatList))
+	}
 
 	var image vk.Image
 	result := d.cmds.CreateImage(d.handle, &createInfo, nil, &image)
@@ -622,11 +799,11 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 	var memReqs vk.MemoryRequirements
 	d.cmds.GetImageMemoryRequirements(d.handle, image, &memReqs)
 
-	// Allocate memory (textures always use device-local)
+	// Allocate memory (device-local, lazily allocated for transients)
 	memBlock, err := d.allocator.Alloc(memory.AllocationRequest{
 		Size:           uint64(memReqs.Size),
 		Alignment:      uint64(memReqs.Alignment),
-		Usage:          memory.UsageFastDeviceAccess,
+		Usage:          allocUsage,
 		MemoryTypeBits: memReqs.MemoryTypeBits,
 	})
 	if err != nil {
@@ -634,15 +811,7 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 		return nil, fmt.Errorf("vulkan: failed to allocate texture memory: %w", err)
 	}
 
-	// Bind memory to image
-	result = d.cmds.BindImageMemory(d.handle, image, memBlock.Memory, vk.DeviceSize(memBlock.Offset))
-	if result != vk.Success {
-		_ = d.allocator.Free(memBlock)
-		d.cmds.DestroyImage(d.handle, image, nil)
-		return nil, fmt.Errorf("vulkan: vkBindImageMemory failed: %d", result)
-	}
-
-	t := &Texture{
+	return &Texture{
 		handle:      image,
 		memory:      memBlock,
 		size:        Extent3D{Width: desc.Size.Width, Height: desc.Size.Height, Depth: depth},
@@ -653,13 +822,35 @@ func (d *Device) CreateTexture(desc *hal.TextureDescriptor) (hal.Texture, error)
 		samples:     samples,
 		dimension:   desc.Dimension,
 		device:      d,
-	}
-	if desc.Label != "" {
-		d.setObjectName(vk.ObjectTypeImage, uint64(image), desc.Label)
+		hostCopy:    hostCopy,
+		protected:   desc.Protected,
+	}, nil
+}
+
+// nameTexture labels a texture's image for debug tools.
+func (d *Device) nameTexture(t *Texture, label string) {
+	if label != "" {
+		d.setObjectName(vk.ObjectTypeImage, uint64(t.handle), label)
 	} else {
-		d.setObjectName(vk.ObjectTypeImage, uint64(image), "Texture")
+		d.setObjectName(vk.ObjectTypeImage, uint64(t.handle), "Texture")
 	}
-	return t, nil
+}
+
+// imageFormatSupported reports whether an optimally tiled image with the
+// given format, type, usage and flags can be created on this device.
+func (d *Device) imageFormatSupported(format vk.Format, imageType vk.ImageType, usage vk.ImageUsageFlags, flags vk.ImageCreateFlags) bool {
+	info := vk.PhysicalDeviceImageFormatInfo2{
+		SType:  vk.StructureTypePhysicalDeviceImageFormatInfo2,
+		Format: format,
+		Type:   imageType,
+		Tiling: vk.ImageTilingOptimal,
+		Usage:  usage,
+		Flags:  flags,
+	}
+	props := vk.ImageFormatProperties2{
+		SType: vk.StructureTypeImageFormatProperties2,
+	}
+	return d.instance.cmds.GetPhysicalDeviceImageFormatProperties2(d.physicalDevice, &info, &props) == vk.Success
 }
 
 // DestroyTexture destroys a GPU texture.
@@ -682,6 +873,36 @@ func (d *Device) DestroyTexture(texture hal.Texture) {
 	vkTexture.device = nil
 }
 
+// ImportTexture wraps a VkImage another library created on this device.
+// The image stays in whatever layout its owner left it in; the caller
+// transitions it, and DestroyTexture does not destroy it.
+func (d *Device) ImportTexture(handle uintptr, _ gputypes.TextureUsage, desc *hal.TextureDescriptor) (hal.Texture, error) {
+	if handle == 0 {
+		return nil, fmt.Errorf("vulkan: native texture is nil")
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("BUG: texture descriptor is nil in Vulkan.ImportTexture — core validation gap")
+	}
+	depth := max(desc.Size.DepthOrArrayLayers, 1)
+	arrayLayers := uint32(1)
+	if desc.Dimension != gputypes.TextureDimension3D {
+		arrayLayers = depth
+	}
+	return &Texture{
+		handle:      vk.Image(handle),
+		size:        Extent3D{Width: desc.Size.Width, Height: desc.Size.Height, Depth: depth},
+		format:      desc.Format,
+		usage:       desc.Usage,
+		mipLevels:   max(desc.MipLevelCount, 1),
+		arrayLayers: arrayLayers,
+		samples:     max(desc.SampleCount, 1),
+		dimension:   desc.Dimension,
+		device:      d,
+		isExternal:  true,
+		protected:   desc.Protected,
+	}, nil
+}
+
 // CreateTextureView creates a view into a texture.
 func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDescriptor) (hal.TextureView, error) {
 	// Extract image handle and metadata based on texture type.
@@ -734,16 +955,25 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		desc = &hal.TextureViewDescriptor{}
 	}
 
-	// Determine format - use texture format if not specified
+	// Determine format - use texture format if not specified. A plane
+	// view of a multi-planar texture uses the plane's format.
 	format := desc.Format
 	if format == gputypes.TextureFormatUndefined {
 		format = textureFormat
+		if plane, ok := hal.PlaneFormat(textureFormat, desc.Aspect); ok {
+			format = plane
+		}
 	}
 
-	// Determine view type - derive from texture dimension if not specified
+	// Determine view type - derive from texture dimension if not specified.
+	// A layered 2D texture defaults to a 2D array view unless the view
+	// selects a single layer.
 	var viewType vk.ImageViewType
 	if desc.Dimension == gputypes.TextureViewDimensionUndefined {
 		viewType = textureDimensionToViewType(dimension)
+		if viewType == vk.ImageViewType2d && arrayLayers > 1 && desc.ArrayLayerCount != 1 {
+			viewType = vk.ImageViewType2dArray
+		}
 	} else {
 		viewType = textureViewDimensionToVk(desc.Dimension)
 	}
@@ -773,7 +1003,7 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		SType:    vk.StructureTypeImageViewCreateInfo,
 		Image:    imageHandle,
 		ViewType: viewType,
-		Format:   textureFormatToVk(format),
+		Format:   viewFormatToVk(format, desc.Aspect),
 		Components: vk.ComponentMapping{
 			R: vk.ComponentSwizzleIdentity,
 			G: vk.ComponentSwizzleIdentity,
@@ -813,6 +1043,14 @@ func (d *Device) CreateTextureView(texture hal.Texture, desc *hal.TextureViewDes
 		image:       imageHandle,
 		isSwapchain: isSwapchain,
 	}
+	if isDepthStencilFormat(textureFormat) {
+		d.depthStencilViewsMu.Lock()
+		if d.depthStencilViews == nil {
+			d.depthStencilViews = make(map[vk.ImageView]struct{})
+		}
+		d.depthStencilViews[imageView] = struct{}{}
+		d.depthStencilViewsMu.Unlock()
+	}
 	if desc.Label != "" {
 		d.setObjectName(vk.ObjectTypeImageView, uint64(imageView), desc.Label)
 	} else {
@@ -841,6 +1079,9 @@ func (d *Device) DestroyTextureView(view hal.TextureView) {
 		if d.renderPassCache != nil {
 			d.renderPassCache.InvalidateFramebuffer(vkView.handle)
 		}
+		d.depthStencilViewsMu.Lock()
+		delete(d.depthStencilViews, vkView.handle)
+		d.depthStencilViewsMu.Unlock()
 		vkDestroyImageView(d.cmds, d.handle, vkView.handle, nil)
 		vkView.handle = 0
 	}
@@ -876,6 +1117,7 @@ func (d *Device) CreateSampler(desc *hal.SamplerDescriptor) (hal.Sampler, error)
 	if lodMaxClamp == 0 {
 		lodMaxClamp = vk.LodClampNone
 	}
+	lodBias := max(-d.maxSamplerLodBias, min(desc.LodBias, d.maxSamplerLodBias))
 
 	createInfo := vk.SamplerCreateInfo{
 		SType:                   vk.StructureTypeSamplerCreateInfo,
@@ -885,7 +1127,7 @@ func (d *Device) CreateSampler(desc *hal.SamplerDescriptor) (hal.Sampler, error)
 		AddressModeU:            addressModeToVk(desc.AddressModeU),
 		AddressModeV:            addressModeToVk(desc.AddressModeV),
 		AddressModeW:            addressModeToVk(desc.AddressModeW),
-		MipLodBias:              0.0,
+		MipLodBias:              lodBias,
 		AnisotropyEnable:        anisotropyEnable,
 		MaxAnisotropy:           maxAnisotropy,
 		CompareEnable:           compareEnable,
@@ -1052,6 +1294,15 @@ func (d *Device) CreateBindGroup(desc *hal.BindGroupDescriptor) (hal.BindGroup,
 	}, nil
 }
 
+// isDepthStencilView reports whether view is a view of a depth/stencil
+// texture.
+func (d *Device) isDepthStencilView(view vk.ImageView) bool {
+	d.depthStencilViewsMu.Lock()
+	defer d.depthStencilViewsMu.Unlock()
+	_, ok := d.depthStencilViews[view]
+	return ok
+}
+
 // updateDescriptorSet writes resource bindings to a descriptor set.
 func (d *Device) updateDescriptorSet(set vk.DescriptorSet, entries []gputypes.BindGroupEntry, bindingTypes map[uint32]vk.DescriptorType) error {
 	if len(entries) == 0 {
@@ -1105,8 +1356,17 @@ func (d *Device) updateDescriptorSet(set vk.DescriptorSet, entries []gputypes.Bi
 				ImageView:   vk.ImageView(res.TextureView),
 				ImageLayout: vk.ImageLayoutShaderReadOnlyOptimal,
 			}
-			imageInfos = append(imageInfos, imageInfo)
+			if d.isDepthStencilView(imageInfo.ImageView) {
+				imageInfo.ImageLayout = vk.ImageLayoutDepthStencilReadOnlyOptimal
+			}
 			write.DescriptorType = vk.DescriptorTypeSampledImage
+			// Storage images are accessed in GENERAL layout, matching the
+			// layout TransitionTextures picks for TextureUsageStorageBinding.
+			if bindingTypes[entry.Binding] == vk.DescriptorTypeStorageImage {
+				imageInfo.ImageLayout = vk.ImageLayoutGeneral
+				write.DescriptorType = vk.DescriptorTypeStorageImage
+			}
+			imageInfos = append(imageInfos, imageInfo)
 			write.PImageInfo = &imageInfos[len(imageInfos)-1]
 
 		default:
@@ -1259,7 +1519,7 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 	if desc.Source.WGSL != "" {
 		sourceType = "WGSL"
 	}
-	hal.Logger().Debug("vulkan: shader module compiled",
+	d.logger(hal.LogShader).Debug("vulkan: shader module compiled",
 		"source", sourceType,
 		"spirvWords", len(spirv),
 	)
@@ -1273,6 +1533,7 @@ func (d *Device) CreateShaderModule(desc *hal.ShaderModuleDescriptor) (hal.Shade
 	} else {
 		d.setObjectName(vk.ObjectTypeShaderModule, uint64(module), "ShaderModule("+sourceType+")")
 	}
+	d.prebuildHinted(sm, desc)
 	return sm, nil
 }
 
@@ -1283,6 +1544,13 @@ func (d *Device) DestroyShaderModule(module hal.ShaderModule) {
 		return
 	}
 
+	vkModule.mu.Lock()
+	for _, p := range vkModule.prebuilt {
+		vkDestroyPipeline(d.cmds, d.handle, p.handle, nil)
+	}
+	vkModule.prebuilt = nil
+	vkModule.mu.Unlock()
+
 	if vkModule.handle != 0 {
 		vkDestroyShaderModule(d.cmds, d.handle, vkModule.handle, nil)
 		vkModule.handle = 0
@@ -1297,13 +1565,15 @@ func (d *Device) DestroyShaderModule(module hal.ShaderModule) {
 //
 // Command buffer allocation is deferred to BeginEncoding — the encoder's
 // internal free list batch-allocates via vkAllocateCommandBuffers on demand.
-func (d *Device) acquireAllocator() (commandAllocator, error) {
+// Protected and unprotected pools share the free list but are never swapped.
+func (d *Device) acquireAllocator(protected bool) (commandAllocator, error) {
 	d.allocatorMu.Lock()
-	if n := len(d.freeAllocators); n > 0 {
-		alloc := d.freeAllocators[n-1]
-		d.freeAllocators = d.freeAllocators[:n-1]
-		d.allocatorMu.Unlock()
-		return alloc, nil
+	for i := len(d.freeAllocators) - 1; i >= 0; i-- {
+		if alloc := d.freeAllocators[i]; alloc.protected == protected {
+			d.freeAllocators = append(d.freeAllocators[:i], d.freeAllocators[i+1:]...)
+			d.allocatorMu.Unlock()
+			return alloc, nil
+		}
 	}
 	d.allocatorMu.Unlock()
 
@@ -1316,6 +1586,9 @@ func (d *Device) acquireAllocator() (commandAllocator, error) {
 		Flags:            vk.CommandPoolCreateFlags(vk.CommandPoolCreateTransientBit),
 		QueueFamilyIndex: d.graphicsFamily,
 	}
+	if protected {
+		createInfo.Flags |= vk.CommandPoolCreateFlags(vk.CommandPoolCreateProtectedBit)
+	}
 
 	var pool vk.CommandPool
 	result := vkCreateCommandPool(d.cmds, d.handle, &createInfo, nil, &pool)
@@ -1325,7 +1598,7 @@ func (d *Device) acquireAllocator() (commandAllocator, error) {
 
 	d.setObjectName(vk.ObjectTypeCommandPool, uint64(pool), "CommandPool")
 
-	return commandAllocator{pool: pool}, nil
+	return commandAllocator{pool: pool, protected: protected}, nil
 }
 
 // recyclePool resets a command pool and returns it to the free list for reuse.
@@ -1333,13 +1606,13 @@ func (d *Device) acquireAllocator() (commandAllocator, error) {
 // allowing them to be re-allocated by the next encoder that acquires this pool.
 // Without this reset, vkAllocateCommandBuffers may return CBs still in
 // "executable" state, causing VUID-vkBeginCommandBuffer-commandBuffer-00049.
-func (d *Device) recyclePool(pool vk.CommandPool) {
+func (d *Device) recyclePool(pool vk.CommandPool, protected bool) {
 	if pool == 0 {
 		return
 	}
 	d.cmds.ResetCommandPool(d.handle, pool, 0)
 	d.allocatorMu.Lock()
-	d.freeAllocators = append(d.freeAllocators, commandAllocator{pool: pool})
+	d.freeAllocators = append(d.freeAllocators, commandAllocator{pool: pool, protected: protected})
 	d.allocatorMu.Unlock()
 }
 
@@ -1349,8 +1622,19 @@ func (d *Device) recyclePool(pool vk.CommandPool) {
 // Rust wgpu-hal and eliminates races between pool reset and buffer freeing
 // that caused "Couldn't find VkCommandBuffer Object" crashes (VK-POOL-001).
 // Uses sync.Pool for CommandEncoder struct reuse (VK-PERF-003).
+//
+// Since no two encoders share a VkCommandPool, which Vulkan requires to be
+// externally synchronized, encoders may record concurrently on different
+// goroutines. Recording touches device state only through the render pass
+// cache and the allocator free list, both guarded by their own locks.
+//
+// A protected encoder records into a pool created with the protected flag
+// and requires a device opened with hal.FeatureProtectedContent.
 func (d *Device) CreateCommandEncoder(desc *hal.CommandEncoderDescriptor) (hal.CommandEncoder, error) {
-	alloc, err := d.acquireAllocator()
+	if desc.Protected && !d.protectedMemory {
+		return nil, fmt.Errorf("vulkan: protected command encoder requires FeatureProtectedContent")
+	}
+	alloc, err := d.acquireAllocator(desc.Protected)
 	if err != nil {
 		return nil, err
 	}
@@ -1359,6 +1643,7 @@ func (d *Device) CreateCommandEncoder(desc *hal.CommandEncoderDescriptor) (hal.C
 	e := encoderPool.Get().(*CommandEncoder)
 	e.device = d
 	e.pool = alloc.pool
+	e.protected = alloc.protected
 	e.active = 0
 	e.label = desc.Label
 	// free and discarded slices may retain capacity from previous use — clear length.
@@ -1406,16 +1691,19 @@ func (d *Device) FreeCommandBuffer(cmdBuffer hal.CommandBuffer) {
 	// The pool contains all command buffers (active + free + discarded from
 	// the encoder). Destroying the pool frees them all; resetting the pool
 	// puts them all back to initial state.
-	d.recyclePool(vkCmdBuf.pool)
+	d.recyclePool(vkCmdBuf.pool, vkCmdBuf.protected)
 
 	vkCmdBuf.handle = 0
 	vkCmdBuf.pool = 0
+	vkCmdBuf.protected = false
 	cmdBufferResultPool.Put(vkCmdBuf)
 }
 
-// destroyAllocators destroys all recycled command pools in the free list.
-// Called during device shutdown after all GPU work is complete.
-func (d *Device) destroyAllocators() {
+// destroyAllocators destroys all recycled command pools in the free list
+// and returns how many it destroyed. Pools in the free list hold no pending
+// work, so this is safe at any time; it runs during device shutdown and
+// from Trim.
+func (d *Device) destroyAllocators() int {
 	d.allocatorMu.Lock()
 	defer d.allocatorMu.Unlock()
 
@@ -1423,7 +1711,47 @@ func (d *Device) destroyAllocators() {
 		// Destroying a pool implicitly frees all command buffers allocated from it.
 		vkDestroyCommandPool(d.cmds, d.handle, alloc.pool, nil)
 	}
+	n := len(d.freeAllocators)
 	d.freeAllocators = d.freeAllocators[:0]
+	return n
+}
+
+// Trim frees empty memory blocks and the idle command pools in the free
+// list. Implements hal.Trimmer.
+func (d *Device) Trim() hal.TrimReport {
+	var report hal.TrimReport
+	report.CommandPools = d.destroyAllocators()
+
+	if d.allocator != nil {
+		report.MemoryBlocks, report.MemoryBytes = d.allocator.Trim()
+	}
+	return report
+}
+
+// MemoryBudget returns the budget and usage of the device-local heaps.
+// Implements hal.MemoryBudgetReporter.
+func (d *Device) MemoryBudget() (hal.MemoryBudget, bool) {
+	if !d.supportsMemoryBudget {
+		return hal.MemoryBudget{}, false
+	}
+	budget := vk.PhysicalDeviceMemoryBudgetPropertiesEXT{
+		SType: vk.StructureTypePhysicalDeviceMemoryBudgetPropertiesExt,
+	}
+	props := vk.PhysicalDeviceMemoryProperties2{
+		SType: vk.StructureTypePhysicalDeviceMemoryProperties2,
+		PNext: (*uintptr)(unsafe.Pointer(&budget)),
+	}
+	d.instance.cmds.GetPhysicalDeviceMemoryProperties2(d.physicalDevice, &props)
+
+	var report hal.MemoryBudget
+	heaps := props.MemoryProperties.MemoryHeaps[:min(props.MemoryProperties.MemoryHeapCount, vk.MaxMemoryHeaps)]
+	for i, heap := range heaps {
+		if heap.Flags&vk.MemoryHeapFlags(vk.MemoryHeapDeviceLocalBit) != 0 {
+			report.Budget += uint64(budget.HeapBudget[i])
+			report.Usage += uint64(budget.HeapUsage[i])
+		}
+	}
+	return report, report.Budget != 0
 }
 
 // CreateFence creates a synchronization fence.
@@ -1458,14 +1786,18 @@ func (d *Device) DestroyFence(fence hal.Fence) {
 		vkDestroyFence(d.cmds, d.handle, vkFence.handle, nil)
 		vkFence.handle = 0
 	}
+	if vkFence.semaphore != 0 {
+		d.cmds.DestroySemaphore(d.handle, vkFence.semaphore, nil)
+		vkFence.semaphore = 0
+	}
 
 	vkFence.device = nil
 }
 
 // Wait waits for a fence to reach the specified value.
-// Note: Standard Vulkan fences don't support timeline values, so value is ignored.
-// For timeline semantics, use VK_KHR_timeline_semaphore extension.
-func (d *Device) Wait(fence hal.Fence, _ uint64, timeout time.Duration) (bool, error) {
+// Note: Standard Vulkan fences don't support timeline values, so value is
+// ignored unless the fence is a shared timeline semaphore.
+func (d *Device) Wait(fence hal.Fence, value uint64, timeout time.Duration) (bool, error) {
 	vkFence, ok := fence.(*Fence)
 	if !ok || vkFence == nil {
 		return false, fmt.Errorf("vulkan: invalid fence")
@@ -1477,6 +1809,10 @@ func (d *Device) Wait(fence hal.Fence, _ uint64, timeout time.Duration) (bool, e
 		timeoutNs = ^uint64(0) // UINT64_MAX for infinite wait
 	}
 
+	if vkFence.semaphore != 0 {
+		return d.waitSemaphoreValue(vkFence.semaphore, value, timeoutNs)
+	}
+
 	result := vkWaitForFences(d.cmds, d.handle, 1, &vkFence.handle, vk.Bool32(vk.True), timeoutNs)
 	switch result {
 	case vk.Success:
@@ -1496,6 +1832,11 @@ func (d *Device) ResetFence(fence hal.Fence) error {
 	if !ok || vkFence == nil {
 		return fmt.Errorf("vulkan: invalid fence")
 	}
+	if vkFence.semaphore != 0 {
+		// Timeline semaphores only count up; like D3D12 fences they
+		// cannot be reset.
+		return nil
+	}
 
 	result := vkResetFences(d.cmds, d.handle, 1, &vkFence.handle)
 	if result != vk.Success {
@@ -1511,6 +1852,10 @@ func (d *Device) GetFenceStatus(fence hal.Fence) (bool, error) {
 	if !ok || vkFence == nil {
 		return false, fmt.Errorf("vulkan: invalid fence")
 	}
+	if vkFence.semaphore != 0 {
+		value, err := d.semaphoreValue(vkFence.semaphore)
+		return value > 0, err
+	}
 
 	result := d.cmds.GetFenceStatus(d.handle, vkFence.handle)
 	switch result {
@@ -1570,7 +1915,9 @@ func (d *Device) Destroy() {
 	}
 
 	if d.handle != 0 {
-		vkDestroyDevice(d.handle, nil)
+		if !d.external {
+			vkDestroyDevice(d.handle, nil)
+		}
 		d.handle = 0
 	}
 }
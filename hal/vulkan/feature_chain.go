@@ -0,0 +1,85 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"slices"
+	"unsafe"
+
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+// chainHeader is the sType/pNext prefix every extensible Vulkan structure
+// starts with.
+type chainHeader struct {
+	SType vk.StructureType
+	PNext *uintptr
+}
+
+// featureChain assembles the extensions and the pNext chain of feature
+// structures passed to vkCreateDevice. Each optional capability the adapter
+// supports adds its extensions and links its feature structure, instead of
+// splicing pNext pointers by hand at every call site.
+//
+// Features that are core in Vulkan 1.2 (timeline semaphores, separate depth
+// stencil layouts, descriptor indexing) are enabled on one shared
+// VkPhysicalDeviceVulkan12Features: the spec forbids chaining it next to
+// the per-feature structures it subsumes, so a second capability needing it
+// must reuse the first one's.
+type featureChain struct {
+	// extensions are NUL-terminated, as vkCreateDevice expects them.
+	extensions []string
+	head       *uintptr
+	vulkan12   *vk.PhysicalDeviceVulkan12Features
+}
+
+// enableExtensions adds device extensions, skipping those already enabled.
+// Names are given without a NUL terminator.
+func (c *featureChain) enableExtensions(names ...string) {
+	for _, name := range names {
+		if terminated := name + "\x00"; !slices.Contains(c.extensions, terminated) {
+			c.extensions = append(c.extensions, terminated)
+		}
+	}
+}
+
+// linkFeature puts feature, a Vulkan structure starting with sType and
+// pNext, at the front of the chain and returns it so the caller can fill in
+// the feature bits. sType is set here; pNext is owned by the chain.
+func linkFeature[T any](c *featureChain, sType vk.StructureType, feature *T) *T {
+	header := (*chainHeader)(unsafe.Pointer(feature))
+	header.SType = sType
+	header.PNext = c.head
+	c.head = (*uintptr)(unsafe.Pointer(feature))
+	return feature
+}
+
+// vulkan12Features returns the chain's VkPhysicalDeviceVulkan12Features,
+// linking it on first use.
+func (c *featureChain) vulkan12Features() *vk.PhysicalDeviceVulkan12Features {
+	if c.vulkan12 == nil {
+		c.vulkan12 = linkFeature(c, vk.StructureTypePhysicalDeviceVulkan12Features, new(vk.PhysicalDeviceVulkan12Features))
+	}
+	return c.vulkan12
+}
+
+// apply points info at the chain's extensions and feature structures. The
+// returned extension name pointers back info.PpEnabledExtensionNames and
+// must stay reachable until vkCreateDevice returns.
+func (c *featureChain) apply(info *vk.DeviceCreateInfo) []uintptr {
+	info.PNext = c.head
+	info.EnabledExtensionCount = uint32(len(c.extensions))
+	if len(c.extensions) == 0 {
+		info.PpEnabledExtensionNames = 0
+		return nil
+	}
+	ptrs := make([]uintptr, len(c.extensions))
+	for i, ext := range c.extensions {
+		ptrs[i] = uintptr(unsafe.Pointer(unsafe.StringData(ext)))
+	}
+	info.PpEnabledExtensionNames = uintptr(unsafe.Pointer(&ptrs[0]))
+	return ptrs
+}
@@ -0,0 +1,63 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+// chainTypes walks a pNext chain and returns the sType of each structure.
+func chainTypes(head *uintptr) []vk.StructureType {
+	var types []vk.StructureType
+	for p := head; p != nil; {
+		header := (*chainHeader)(unsafe.Pointer(p))
+		types = append(types, header.SType)
+		p = header.PNext
+	}
+	return types
+}
+
+func TestFeatureChain(t *testing.T) {
+	var chain featureChain
+	chain.enableExtensions("VK_KHR_swapchain", "VK_KHR_synchronization2")
+	chain.enableExtensions("VK_KHR_synchronization2")
+
+	sync2 := linkFeature(&chain, vk.StructureTypePhysicalDeviceSynchronization2Features, new(vk.PhysicalDeviceSynchronization2Features))
+	sync2.Synchronization2 = vk.Bool32(vk.True)
+	chain.vulkan12Features().TimelineSemaphore = vk.Bool32(vk.True)
+	chain.vulkan12Features().SeparateDepthStencilLayouts = vk.Bool32(vk.True)
+
+	var info vk.DeviceCreateInfo
+	ptrs := chain.apply(&info)
+
+	if info.EnabledExtensionCount != 2 || len(ptrs) != 2 {
+		t.Errorf("enabled %d extensions (%q), want 2", info.EnabledExtensionCount, chain.extensions)
+	}
+	want := []vk.StructureType{
+		vk.StructureTypePhysicalDeviceVulkan12Features,
+		vk.StructureTypePhysicalDeviceSynchronization2Features,
+	}
+	if got := chainTypes(info.PNext); !slices.Equal(got, want) {
+		t.Fatalf("chain = %v, want %v", got, want)
+	}
+	vulkan12 := (*vk.PhysicalDeviceVulkan12Features)(unsafe.Pointer(info.PNext))
+	if vulkan12.TimelineSemaphore == 0 || vulkan12.SeparateDepthStencilLayouts == 0 {
+		t.Errorf("shared Vulkan 1.2 features lost a bit: %+v", vulkan12)
+	}
+	if sync2.Synchronization2 == 0 {
+		t.Error("linked feature lost its bit")
+	}
+
+	var empty featureChain
+	info = vk.DeviceCreateInfo{}
+	if ptrs := empty.apply(&info); ptrs != nil || info.PNext != nil || info.EnabledExtensionCount != 0 {
+		t.Errorf("empty chain applied %d extensions, pNext %v", info.EnabledExtensionCount, info.PNext)
+	}
+}
@@ -0,0 +1,218 @@
+//go:build !(js && wasm)
+
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+// semaphoreValue is a point on the timeline of a timeline semaphore.
+type semaphoreValue struct {
+	semaphore vk.Semaphore
+	value     uint64
+}
+
+// CreateSharedFence creates a fence backed by a timeline semaphore that
+// can be exported with the platform's handle type: an opaque file
+// descriptor, or an opaque NT handle on Windows. Implements
+// hal.FenceSharer.
+func (d *Device) CreateSharedFence() (hal.Fence, error) {
+	if d.externalSemaphoreType == 0 {
+		return nil, fmt.Errorf("vulkan: exportable timeline semaphores are not supported: %w", hal.ErrExternalNotSupported)
+	}
+	exportInfo := vk.ExportSemaphoreCreateInfo{
+		SType:       vk.StructureTypeExportSemaphoreCreateInfo,
+		HandleTypes: vk.ExternalSemaphoreHandleTypeFlags(d.externalSemaphoreType),
+	}
+	sem, err := d.createTimelineSemaphore((*uintptr)(unsafe.Pointer(&exportInfo)))
+	if err != nil {
+		return nil, err
+	}
+	d.setObjectName(vk.ObjectTypeSemaphore, uint64(sem), "SharedFence")
+	return &Fence{semaphore: sem, device: d}, nil
+}
+
+// ExportFence returns a new file descriptor or NT handle to the timeline
+// semaphore of fence. The caller owns it. Implements hal.FenceSharer.
+func (d *Device) ExportFence(fence hal.Fence) (hal.NativeFence, error) {
+	vkFence, ok := fence.(*Fence)
+	if !ok || vkFence == nil || vkFence.semaphore == 0 {
+		return hal.NativeFence{}, fmt.Errorf("vulkan: fence is not a shared fence")
+	}
+
+	switch d.externalSemaphoreType {
+	case vk.ExternalSemaphoreHandleTypeOpaqueFdBit:
+		info := vk.SemaphoreGetFdInfoKHR{
+			SType:      vk.StructureTypeSemaphoreGetFdInfoKhr,
+			Semaphore:  vkFence.semaphore,
+			HandleType: vk.ExternalSemaphoreHandleTypeOpaqueFdBit,
+		}
+		var fd int
+		if result := d.cmds.GetSemaphoreFdKHR(d.handle, &info, &fd); result != vk.Success {
+			return hal.NativeFence{}, fmt.Errorf("vulkan: vkGetSemaphoreFdKHR failed: %d", result)
+		}
+		return hal.NativeFence{Type: hal.NativeFenceOpaqueFD, Handle: uintptr(fd)}, nil
+	case vk.ExternalSemaphoreHandleTypeOpaqueWin32Bit:
+		info := vk.SemaphoreGetWin32HandleInfoKHR{
+			SType:      vk.StructureTypeSemaphoreGetWin32HandleInfoKhr,
+			Semaphore:  vkFence.semaphore,
+			HandleType: vk.ExternalSemaphoreHandleTypeOpaqueWin32Bit,
+		}
+		var handle uintptr
+		if result := d.cmds.GetSemaphoreWin32HandleKHR(d.handle, &info, &handle); result != vk.Success {
+			return hal.NativeFence{}, fmt.Errorf("vulkan: vkGetSemaphoreWin32HandleKHR failed: %d", result)
+		}
+		return hal.NativeFence{Type: hal.NativeFenceOpaqueWin32, Handle: handle}, nil
+	}
+	return hal.NativeFence{}, fmt.Errorf("vulkan: exportable timeline semaphores are not supported: %w", hal.ErrExternalNotSupported)
+}
+
+// ImportFence imports an opaque file descriptor, an opaque NT handle or
+// the NT handle of a shared D3D12 fence into a new timeline semaphore. A
+// file descriptor belongs to the semaphore once the import succeeds; NT
+// handles stay owned by the caller. Implements hal.FenceSharer.
+func (d *Device) ImportFence(native hal.NativeFence) (hal.Fence, error) {
+	if native.Handle == 0 && native.Type != hal.NativeFenceOpaqueFD {
+		return nil, fmt.Errorf("vulkan: native fence handle is nil")
+	}
+
+	var win32Type vk.ExternalSemaphoreHandleTypeFlagBits
+	switch native.Type {
+	case hal.NativeFenceOpaqueFD:
+		if !d.cmds.HasExternalSemaphoreFd() {
+			return nil, fmt.Errorf("vulkan: VK_KHR_external_semaphore_fd is not enabled: %w", hal.ErrExternalNotSupported)
+		}
+	case hal.NativeFenceOpaqueWin32:
+		win32Type = vk.ExternalSemaphoreHandleTypeOpaqueWin32Bit
+	case hal.NativeFenceD3D12:
+		win32Type = vk.ExternalSemaphoreHandleTypeD3d12FenceBit
+	default:
+		return nil, fmt.Errorf("vulkan: cannot import native fence type %d: %w", native.Type, hal.ErrExternalNotSupported)
+	}
+	if win32Type != 0 && !d.cmds.HasExternalSemaphoreWin32() {
+		return nil, fmt.Errorf("vulkan: VK_KHR_external_semaphore_win32 is not enabled: %w", hal.ErrExternalNotSupported)
+	}
+
+	sem, err := d.createTimelineSemaphore(nil)
+	if err != nil {
+		return nil, err
+	}
+	var result vk.Result
+	if win32Type != 0 {
+		info := vk.ImportSemaphoreWin32HandleInfoKHR{
+			SType:      vk.StructureTypeImportSemaphoreWin32HandleInfoKhr,
+			Semaphore:  sem,
+			HandleType: win32Type,
+			Handle:     native.Handle,
+		}
+		result = d.cmds.ImportSemaphoreWin32HandleKHR(d.handle, &info)
+	} else {
+		info := vk.ImportSemaphoreFdInfoKHR{
+			SType:      vk.StructureTypeImportSemaphoreFdInfoKhr,
+			Semaphore:  sem,
+			HandleType: vk.ExternalSemaphoreHandleTypeOpaqueFdBit,
+			Fd:         int(native.Handle),
+		}
+		result = d.cmds.ImportSemaphoreFdKHR(d.handle, &info)
+	}
+	if result != vk.Success {
+		d.cmds.DestroySemaphore(d.handle, sem, nil)
+		return nil, fmt.Errorf("vulkan: semaphore import failed: %d", result)
+	}
+	d.setObjectName(vk.ObjectTypeSemaphore, uint64(sem), "ImportedFence")
+	return &Fence{semaphore: sem, device: d}, nil
+}
+
+// createTimelineSemaphore creates a timeline semaphore starting at 0, with
+// next chained behind its VkSemaphoreTypeCreateInfo.
+func (d *Device) createTimelineSemaphore(next *uintptr) (vk.Semaphore, error) {
+	typeInfo := vk.SemaphoreTypeCreateInfo{
+		SType:         vk.StructureTypeSemaphoreTypeCreateInfo,
+		PNext:         next,
+		SemaphoreType: vk.SemaphoreTypeTimeline,
+	}
+	createInfo := vk.SemaphoreCreateInfo{
+		SType: vk.StructureTypeSemaphoreCreateInfo,
+		PNext: (*uintptr)(unsafe.Pointer(&typeInfo)),
+	}
+	var sem vk.Semaphore
+	if result := d.cmds.CreateSemaphore(d.handle, &createInfo, nil, &sem); result != vk.Success {
+		return 0, fmt.Errorf("vulkan: vkCreateSemaphore (timeline) failed: %d", result)
+	}
+	return sem, nil
+}
+
+// semaphoreValue returns the current value of a timeline semaphore.
+func (d *Device) semaphoreValue(sem vk.Semaphore) (uint64, error) {
+	var value uint64
+	switch result := d.cmds.GetSemaphoreCounterValue(d.handle, sem, &value); result {
+	case vk.Success:
+		return value, nil
+	case vk.ErrorDeviceLost:
+		return 0, hal.ErrDeviceLost
+	default:
+		return 0, fmt.Errorf("vulkan: vkGetSemaphoreCounterValue failed: %d", result)
+	}
+}
+
+// waitSemaphoreValue waits until a timeline semaphore reaches value.
+func (d *Device) waitSemaphoreValue(sem vk.Semaphore, value, timeoutNs uint64) (bool, error) {
+	waitInfo := vk.SemaphoreWaitInfo{
+		SType:          vk.StructureTypeSemaphoreWaitInfo,
+		SemaphoreCount: 1,
+		PSemaphores:    &sem,
+		PValues:        &value,
+	}
+	switch result := d.cmds.WaitSemaphores(d.handle, &waitInfo, timeoutNs); result {
+	case vk.Success:
+		return true, nil
+	case vk.Timeout:
+		return false, nil
+	case vk.ErrorDeviceLost:
+		return false, hal.ErrDeviceLost
+	default:
+		return false, fmt.Errorf("vulkan: vkWaitSemaphores failed: %d", result)
+	}
+}
+
+// SubmitWithFences submits commandBuffers like Submit, after the timeline
+// semaphores of waits reach their values, and sets those of signals when
+// the commands complete. Implements hal.FenceSubmitter.
+func (q *Queue) SubmitWithFences(commandBuffers []hal.CommandBuffer, waits, signals []hal.FenceValue) (uint64, error) {
+	semWaits, err := sharedSemaphoreValues(waits)
+	if err != nil {
+		return 0, err
+	}
+	semSignals, err := sharedSemaphoreValues(signals)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.submitLocked(commandBuffers, semWaits, semSignals)
+}
+
+// sharedSemaphoreValues resolves fence values to the timeline semaphores
+// of shared fences.
+func sharedSemaphoreValues(values []hal.FenceValue) ([]semaphoreValue, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	out := make([]semaphoreValue, len(values))
+	for i, v := range values {
+		f, ok := v.Fence.(*Fence)
+		if !ok || f == nil || f.semaphore == 0 {
+			return nil, fmt.Errorf("vulkan: fence %d is not a shared fence", i)
+		}
+		out[i] = semaphoreValue{semaphore: f.semaphore, value: v.Value}
+	}
+	return out, nil
+}
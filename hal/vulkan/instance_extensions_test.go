@@ -26,3 +26,51 @@ func TestSelectAvailableExtensionsPreservesCandidateOrder(t *testing.T) {
 		t.Fatalf("selectAvailableExtensions() = %q, want %q", got, want)
 	}
 }
+
+func TestSurfaceMaintenanceExtensionsRequiresBoth(t *testing.T) {
+	partial := map[string]struct{}{
+		"VK_EXT_surface_maintenance1": {},
+	}
+	if got := surfaceMaintenanceExtensions(partial); got != nil {
+		t.Fatalf("surfaceMaintenanceExtensions(partial) = %q, want nil", got)
+	}
+
+	full := map[string]struct{}{
+		"VK_KHR_get_surface_capabilities2": {},
+		"VK_EXT_surface_maintenance1":      {},
+	}
+	want := []string{
+		"VK_KHR_get_surface_capabilities2\x00",
+		"VK_EXT_surface_maintenance1\x00",
+	}
+	if got := surfaceMaintenanceExtensions(full); !slices.Equal(got, want) {
+		t.Fatalf("surfaceMaintenanceExtensions(full) = %q, want %q", got, want)
+	}
+}
+
+func TestSurfaceProtectedExtensionsSkipsEnabled(t *testing.T) {
+	available := map[string]struct{}{
+		"VK_KHR_get_surface_capabilities2":      {},
+		"VK_KHR_surface_protected_capabilities": {},
+	}
+	if got := surfaceProtectedExtensions(map[string]struct{}{"VK_KHR_get_surface_capabilities2": {}}, nil); got != nil {
+		t.Fatalf("surfaceProtectedExtensions(partial) = %q, want nil", got)
+	}
+
+	want := []string{
+		"VK_KHR_get_surface_capabilities2\x00",
+		"VK_KHR_surface_protected_capabilities\x00",
+	}
+	if got := surfaceProtectedExtensions(available, nil); !slices.Equal(got, want) {
+		t.Fatalf("surfaceProtectedExtensions() = %q, want %q", got, want)
+	}
+
+	enabled := surfaceMaintenanceExtensions(map[string]struct{}{
+		"VK_KHR_get_surface_capabilities2": {},
+		"VK_EXT_surface_maintenance1":      {},
+	})
+	want = []string{"VK_KHR_surface_protected_capabilities\x00"}
+	if got := surfaceProtectedExtensions(available, enabled); !slices.Equal(got, want) {
+		t.Fatalf("surfaceProtectedExtensions(after maintenance) = %q, want %q", got, want)
+	}
+}
@@ -207,8 +207,11 @@ func (a *GpuAllocator) Alloc(req AllocationRequest) (*MemoryBlock, error) {
 		size = ((size / alignment) + 1) * alignment
 	}
 
-	// Choose allocation strategy
-	if size >= a.config.DedicatedThreshold {
+	// Choose allocation strategy. Lazily allocated memory is committed per
+	// allocation as the GPU touches it, so each transient attachment gets
+	// its own instead of sharing a pooled block. Protected memory is scarce
+	// and never mapped, so it is not pooled either.
+	if size >= a.config.DedicatedThreshold || a.selector.IsLazilyAllocated(memTypeIndex) || a.selector.IsProtected(memTypeIndex) {
 		return a.allocDedicated(size, memTypeIndex)
 	}
 
@@ -414,6 +417,33 @@ func (a *GpuAllocator) PoolStats(memTypeIndex uint32) (PoolStats, bool) {
 	return a.pools[memTypeIndex].stats, true
 }
 
+// Trim frees pool blocks that hold no suballocation and returns how many
+// blocks and bytes went back to the driver. Dedicated allocations are
+// freed with their resource and are not affected.
+func (a *GpuAllocator) Trim() (blocks int, bytes uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, pool := range a.pools {
+		kept := pool.blocks[:0]
+		for _, block := range pool.blocks {
+			if block.buddy.Stats().AllocationCount > 0 {
+				kept = append(kept, block)
+				continue
+			}
+			a.vulkanFree(block.memory)
+			pool.stats.BlockCount--
+			pool.stats.TotalSize -= block.size
+			a.stats.TotalAllocated -= block.size
+			blocks++
+			bytes += block.size
+		}
+		clear(pool.blocks[len(kept):])
+		pool.blocks = kept
+	}
+	return blocks, bytes
+}
+
 // Destroy releases all allocations and cleans up.
 //
 // Call this before destroying the Vulkan device.
@@ -27,9 +27,15 @@ const (
 	// Prefers HOST_VISIBLE + HOST_CACHED.
 	UsageDownload
 
-	// UsageTransient indicates memory for short-lived allocations.
-	// May use LAZILY_ALLOCATED if available.
+	// UsageTransient indicates memory for transient attachments, images
+	// created with TRANSIENT_ATTACHMENT usage. Prefers LAZILY_ALLOCATED,
+	// which tile-based GPUs commit only if a pass spills out of tile memory.
+	// Only transient requests may receive lazily allocated memory.
 	UsageTransient
+
+	// UsageProtected indicates memory for protected resources. Requires
+	// PROTECTED memory; only protected requests may receive it.
+	UsageProtected
 )
 
 // AllocationRequest describes a memory allocation request.
@@ -131,6 +137,12 @@ type MemoryTypeSelector struct {
 	// validTypes is a bitmask of memory types we consider safe to use.
 	// Excludes exotic/vendor-specific types.
 	validTypes uint32
+
+	// lazyTypes is a bitmask of LAZILY_ALLOCATED memory types.
+	lazyTypes uint32
+
+	// protectedTypes is a bitmask of PROTECTED memory types.
+	protectedTypes uint32
 }
 
 // knownMemoryFlags are memory property flags we understand and can use.
@@ -138,23 +150,32 @@ const knownMemoryFlags = vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit)
 	vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit) |
 	vk.MemoryPropertyFlags(vk.MemoryPropertyHostCoherentBit) |
 	vk.MemoryPropertyFlags(vk.MemoryPropertyHostCachedBit) |
-	vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit)
+	vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit) |
+	vk.MemoryPropertyFlags(vk.MemoryPropertyProtectedBit)
 
 // NewMemoryTypeSelector creates a selector from device memory properties.
 func NewMemoryTypeSelector(props DeviceMemoryProperties) *MemoryTypeSelector {
 	// Build bitmask of valid (known) memory types
-	var validTypes uint32
+	var validTypes, lazyTypes, protectedTypes uint32
 	for i, mt := range props.MemoryTypes {
 		// Only include types where we understand all flags
 		unknownFlags := mt.PropertyFlags & ^knownMemoryFlags
 		if unknownFlags == 0 {
 			validTypes |= 1 << i
 		}
+		if mt.PropertyFlags&vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit) != 0 {
+			lazyTypes |= 1 << i
+		}
+		if mt.PropertyFlags&vk.MemoryPropertyFlags(vk.MemoryPropertyProtectedBit) != 0 {
+			protectedTypes |= 1 << i
+		}
 	}
 
 	return &MemoryTypeSelector{
-		properties: props,
-		validTypes: validTypes,
+		properties:     props,
+		validTypes:     validTypes,
+		lazyTypes:      lazyTypes,
+		protectedTypes: protectedTypes,
 	}
 }
 
@@ -166,13 +187,33 @@ func (s *MemoryTypeSelector) SelectMemoryType(req AllocationRequest) (uint32, bo
 	// Determine required and preferred flags based on usage
 	required, preferred := s.usageToFlags(req.Usage)
 
+	// Lazily allocated memory cannot back anything but transient
+	// attachments, so hide it from every other request.
+	typeBits := req.MemoryTypeBits
+	if req.Usage&UsageTransient == 0 {
+		typeBits &^= s.lazyTypes
+	}
+	// Likewise protected memory is only for protected resources.
+	if req.Usage&UsageProtected == 0 {
+		typeBits &^= s.protectedTypes
+	}
+
 	// First pass: try to find type with all preferred flags
-	if idx, ok := s.findMemoryType(req.MemoryTypeBits, required|preferred); ok {
+	if idx, ok := s.findMemoryType(typeBits, required|preferred); ok {
 		return idx, true
 	}
 
-	// Second pass: fall back to just required flags
-	if idx, ok := s.findMemoryType(req.MemoryTypeBits, required); ok {
+	// Second pass: without a lazily allocated type (desktop GPUs), a
+	// transient attachment still prefers device-local memory.
+	lazy := vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit)
+	if preferred&lazy != 0 {
+		if idx, ok := s.findMemoryType(typeBits, required|preferred&^lazy); ok {
+			return idx, true
+		}
+	}
+
+	// Last pass: fall back to just required flags
+	if idx, ok := s.findMemoryType(typeBits, required); ok {
 		return idx, true
 	}
 
@@ -228,6 +269,10 @@ func (s *MemoryTypeSelector) usageToFlags(usage UsageFlags) (required, preferred
 		preferred |= vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit)
 	}
 
+	if usage&UsageProtected != 0 {
+		required |= vk.MemoryPropertyFlags(vk.MemoryPropertyProtectedBit)
+	}
+
 	return required, preferred
 }
 
@@ -256,6 +301,22 @@ func (s *MemoryTypeSelector) IsDeviceLocal(typeIndex uint32) bool {
 	return mt.PropertyFlags&vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit) != 0
 }
 
+// IsLazilyAllocated returns true if the memory type is lazily allocated.
+func (s *MemoryTypeSelector) IsLazilyAllocated(typeIndex uint32) bool {
+	return typeIndex < 32 && s.lazyTypes&(1<<typeIndex) != 0
+}
+
+// IsProtected returns true if the memory type is protected.
+func (s *MemoryTypeSelector) IsProtected(typeIndex uint32) bool {
+	return typeIndex < 32 && s.protectedTypes&(1<<typeIndex) != 0
+}
+
+// HasLazilyAllocated returns true if the device offers a lazily allocated
+// memory type, as tile-based GPUs do.
+func (s *MemoryTypeSelector) HasLazilyAllocated() bool {
+	return s.lazyTypes&s.validTypes != 0
+}
+
 // IsHostVisible returns true if the memory type is host visible.
 func (s *MemoryTypeSelector) IsHostVisible(typeIndex uint32) bool {
 	mt, ok := s.GetMemoryType(typeIndex)
@@ -127,6 +127,66 @@ func TestSelectMemoryType(t *testing.T) {
 	}
 }
 
+func TestSelectMemoryTypeLazilyAllocated(t *testing.T) {
+	deviceLocal := vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit)
+	lazy := deviceLocal | vk.MemoryPropertyFlags(vk.MemoryPropertyLazilyAllocatedBit)
+	hostVisible := vk.MemoryPropertyFlags(vk.MemoryPropertyHostVisibleBit | vk.MemoryPropertyHostCoherentBit)
+	transient := UsageFastDeviceAccess | UsageTransient
+
+	// Tile-based GPU: the lazy type comes first and must still be skipped
+	// for ordinary allocations.
+	tiler := NewMemoryTypeSelector(DeviceMemoryProperties{
+		MemoryTypes: []MemoryType{{PropertyFlags: lazy}, {PropertyFlags: deviceLocal}, {PropertyFlags: hostVisible}},
+		MemoryHeaps: []MemoryHeap{{Size: 4 << 30}},
+	})
+	if !tiler.HasLazilyAllocated() || !tiler.IsLazilyAllocated(0) || tiler.IsLazilyAllocated(1) {
+		t.Fatalf("lazy types = %b, want 0b001", tiler.lazyTypes)
+	}
+	if idx, ok := tiler.SelectMemoryType(AllocationRequest{Usage: transient, MemoryTypeBits: 0b111}); !ok || idx != 0 {
+		t.Errorf("transient attachment: got type %d (%v), want lazily allocated type 0", idx, ok)
+	}
+	if idx, ok := tiler.SelectMemoryType(AllocationRequest{Usage: UsageFastDeviceAccess, MemoryTypeBits: 0b111}); !ok || idx != 1 {
+		t.Errorf("ordinary texture: got type %d (%v), want device-local type 1", idx, ok)
+	}
+	if _, ok := tiler.SelectMemoryType(AllocationRequest{Usage: UsageFastDeviceAccess, MemoryTypeBits: 0b001}); ok {
+		t.Error("ordinary texture was given lazily allocated memory")
+	}
+
+	// Desktop GPU: no lazy type, transient attachments stay device-local.
+	desktop := NewMemoryTypeSelector(DeviceMemoryProperties{
+		MemoryTypes: []MemoryType{{PropertyFlags: hostVisible}, {PropertyFlags: deviceLocal}},
+		MemoryHeaps: []MemoryHeap{{Size: 4 << 30}},
+	})
+	if desktop.HasLazilyAllocated() {
+		t.Error("HasLazilyAllocated() = true without a lazy type")
+	}
+	if idx, ok := desktop.SelectMemoryType(AllocationRequest{Usage: transient, MemoryTypeBits: 0b11}); !ok || idx != 1 {
+		t.Errorf("transient attachment without lazy memory: got type %d (%v), want device-local type 1", idx, ok)
+	}
+}
+
+func TestSelectMemoryTypeProtected(t *testing.T) {
+	deviceLocal := vk.MemoryPropertyFlags(vk.MemoryPropertyDeviceLocalBit)
+	protected := deviceLocal | vk.MemoryPropertyFlags(vk.MemoryPropertyProtectedBit)
+
+	s := NewMemoryTypeSelector(DeviceMemoryProperties{
+		MemoryTypes: []MemoryType{{PropertyFlags: protected}, {PropertyFlags: deviceLocal}},
+		MemoryHeaps: []MemoryHeap{{Size: 4 << 30}},
+	})
+	if !s.IsProtected(0) || s.IsProtected(1) {
+		t.Fatalf("protected types = %b, want 0b01", s.protectedTypes)
+	}
+	if idx, ok := s.SelectMemoryType(AllocationRequest{Usage: UsageFastDeviceAccess | UsageProtected, MemoryTypeBits: 0b11}); !ok || idx != 0 {
+		t.Errorf("protected texture: got type %d (%v), want protected type 0", idx, ok)
+	}
+	if idx, ok := s.SelectMemoryType(AllocationRequest{Usage: UsageFastDeviceAccess, MemoryTypeBits: 0b11}); !ok || idx != 1 {
+		t.Errorf("ordinary texture: got type %d (%v), want device-local type 1", idx, ok)
+	}
+	if _, ok := s.SelectMemoryType(AllocationRequest{Usage: UsageFastDeviceAccess | UsageProtected, MemoryTypeBits: 0b10}); ok {
+		t.Error("protected texture was given unprotected memory")
+	}
+}
+
 func TestMemoryTypeSelectorHelpers(t *testing.T) {
 	props := DeviceMemoryProperties{
 		MemoryTypes: []MemoryType{
@@ -217,6 +277,7 @@ func TestUsageFlags(t *testing.T) {
 		UsageUpload,
 		UsageDownload,
 		UsageTransient,
+		UsageProtected,
 	}
 
 	for i := 0; i < len(flags); i++ {
@@ -8,6 +8,7 @@ package vulkan
 import (
 	"fmt"
 	"runtime"
+	"time"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -154,7 +155,7 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 		SType:                   vk.StructureTypePipelineRasterizationStateCreateInfo,
 		DepthClampEnable:        boolToVk(desc.Primitive.UnclippedDepth),
 		RasterizerDiscardEnable: vk.Bool32(vk.False),
-		PolygonMode:             vk.PolygonModeFill,
+		PolygonMode:             polygonModeToVk(desc.PolygonMode),
 		CullMode:                cullModeToVk(desc.Primitive.CullMode),
 		FrontFace:               frontFaceToVk(desc.Primitive.FrontFace),
 		DepthBiasEnable:         vk.Bool32(vk.False),
@@ -283,6 +284,7 @@ func (d *Device) CreateRenderPipeline(desc *hal.RenderPipelineDescriptor) (hal.R
 		SampleCount:      vk.SampleCountFlagBits(sampleCount),
 		ColorFinalLayout: vk.ImageLayoutPresentSrcKhr,
 		HasResolve:       sampleCount > 1, // MSAA pipelines need resolve attachment
+		ViewCount:        desc.ViewCount,
 	}
 	if depthFormat != vk.FormatUndefined {
 		rpKey.DepthFormat = depthFormat
@@ -392,14 +394,11 @@ func (d *Device) CreateComputePipeline(desc *hal.ComputePipelineDescriptor) (hal
 	}
 
 	// Get pipeline layout
-	var pipelineLayout vk.PipelineLayout
-	if desc.Layout != nil {
-		vkLayout, ok := desc.Layout.(*PipelineLayout)
-		if !ok || vkLayout == nil {
-			return nil, fmt.Errorf("vulkan: invalid pipeline layout")
-		}
-		pipelineLayout = vkLayout.handle
+	vkLayout, ok := desc.Layout.(*PipelineLayout)
+	if !ok || vkLayout == nil {
+		return nil, fmt.Errorf("vulkan: invalid pipeline layout")
 	}
+	pipelineLayout := vkLayout.handle
 
 	// Get compute shader module
 	if desc.Compute.Module == nil {
@@ -414,45 +413,93 @@ func (d *Device) CreateComputePipeline(desc *hal.ComputePipelineDescriptor) (hal
 	if entryPoint == "" {
 		entryPoint = defaultEntryPoint
 	}
+
+	// A pipeline built from a compilation hint has no overridden constants.
+	var pipeline vk.Pipeline
+	if len(desc.Compute.Constants) == 0 {
+		pipeline = computeModule.takePrebuilt(entryPoint, vkLayout)
+	}
+	if pipeline == 0 {
+		var err error
+		pipeline, err = d.buildComputePipeline(computeModule, entryPoint, pipelineLayout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cp := &ComputePipeline{
+		handle: pipeline,
+		layout: pipelineLayout,
+		device: d,
+	}
+	if desc.Label != "" {
+		d.setObjectName(vk.ObjectTypePipeline, uint64(pipeline), desc.Label)
+	} else {
+		d.setObjectName(vk.ObjectTypePipeline, uint64(pipeline), "ComputePipeline")
+	}
+	return cp, nil
+}
+
+// buildComputePipeline creates the VkPipeline of a compute entry point.
+func (d *Device) buildComputePipeline(module *ShaderModule, entryPoint string, layout vk.PipelineLayout) (vk.Pipeline, error) {
 	entryPointBytes := append([]byte(entryPoint), 0)
 
 	stage := vk.PipelineShaderStageCreateInfo{
 		SType:  vk.StructureTypePipelineShaderStageCreateInfo,
 		Stage:  vk.ShaderStageComputeBit,
-		Module: computeModule.handle,
+		Module: module.handle,
 		PName:  uintptr(unsafe.Pointer(&entryPointBytes[0])),
 	}
 
 	createInfo := vk.ComputePipelineCreateInfo{
 		SType:  vk.StructureTypeComputePipelineCreateInfo,
 		Stage:  stage,
-		Layout: pipelineLayout,
+		Layout: layout,
 	}
 
 	var pipeline vk.Pipeline
 	result := vkCreateComputePipelines(d.cmds, d.handle, 0, 1, &createInfo, nil, &pipeline)
 	if result != vk.Success {
-		return nil, fmt.Errorf("vulkan: vkCreateComputePipelines failed: %d", result)
+		return 0, fmt.Errorf("vulkan: vkCreateComputePipelines failed: %d", result)
 	}
 
 	// Defensive check: Intel Vulkan drivers may return VK_SUCCESS but write VK_NULL_HANDLE.
 	// This is a Vulkan spec violation, but we must handle it to prevent undefined behavior.
 	// See: https://github.com/gogpu/wgpu/issues/24
 	if pipeline == 0 {
-		return nil, hal.ErrDriverBug
+		return 0, hal.ErrDriverBug
 	}
+	return pipeline, nil
+}
 
-	cp := &ComputePipeline{
-		handle: pipeline,
-		layout: pipelineLayout,
-		device: d,
-	}
-	if desc.Label != "" {
-		d.setObjectName(vk.ObjectTypePipeline, uint64(pipeline), desc.Label)
-	} else {
-		d.setObjectName(vk.ObjectTypePipeline, uint64(pipeline), "ComputePipeline")
+// prebuildHinted builds the compute pipelines that the compilation hints of
+// desc describe, so the driver compiles them while the module is loaded.
+// CreateComputePipeline takes them over. Render entry points need the full
+// pipeline state and are left alone; build failures are left for pipeline
+// creation to report.
+func (d *Device) prebuildHinted(module *ShaderModule, desc *hal.ShaderModuleDescriptor) {
+	for _, hint := range desc.CompilationHints {
+		layout, ok := hint.Layout.(*PipelineLayout)
+		if hint.Stage != gputypes.ShaderStageCompute || !ok || layout == nil || layout.handle == 0 {
+			continue
+		}
+		entryPoint := hint.EntryPoint
+		if entryPoint == "" {
+			entryPoint = defaultEntryPoint
+		}
+		start := time.Now()
+		pipeline, err := d.buildComputePipeline(module, entryPoint, layout.handle)
+		if err != nil {
+			d.logger(hal.LogShader).Debug("vulkan: hinted compute pipeline failed",
+				"entryPoint", entryPoint, "err", err)
+			continue
+		}
+		module.prebuilt = append(module.prebuilt, prebuiltPipeline{entryPoint: entryPoint, layout: layout, handle: pipeline})
+		d.logger(hal.LogShader).Debug("vulkan: compute pipeline built from hint",
+			"entryPoint", entryPoint,
+			"elapsed", time.Since(start),
+		)
 	}
-	return cp, nil
 }
 
 // DestroyComputePipeline destroys a compute pipeline.
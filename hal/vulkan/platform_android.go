@@ -19,10 +19,8 @@ type platformInstanceState struct {
 }
 
 func newPlatformInstanceState(desc *hal.InstanceDescriptor) (platformInstanceState, error) {
-	if desc != nil {
-		if err := validateAndroidInstanceFlags(desc.Flags); err != nil {
-			return platformInstanceState{}, err
-		}
+	if err := validateAndroidInstanceFlags(validationFlags(desc)); err != nil {
+		return platformInstanceState{}, err
 	}
 
 	sdk, err := loadAndroidSDKVersion()
@@ -4,6 +4,7 @@ package vulkan
 
 import (
 	"fmt"
+	"math/bits"
 
 	"github.com/gogpu/wgpu/hal"
 	"github.com/gogpu/wgpu/hal/vulkan/vk"
@@ -15,6 +16,8 @@ type QuerySet struct {
 	device    *Device
 	queryType hal.QueryType
 	count     uint32
+	// resultStride is the bytes vkCmdCopyQueryPoolResults writes per query.
+	resultStride uint64
 }
 
 // Destroy releases the Vulkan query pool.
@@ -36,19 +39,29 @@ func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, err
 	}
 
 	var vkQueryType vk.QueryType
+	var statistics vk.QueryPipelineStatisticFlags
+	stride := uint64(8)
 	switch desc.Type {
 	case hal.QueryTypeTimestamp:
 		vkQueryType = vk.QueryTypeTimestamp
 	case hal.QueryTypeOcclusion:
 		vkQueryType = vk.QueryTypeOcclusion
+	case hal.QueryTypePipelineStatistics:
+		vkQueryType = vk.QueryTypePipelineStatistics
+		statistics = pipelineStatisticsToVk(desc.PipelineStatistics)
+		if statistics == 0 {
+			return nil, fmt.Errorf("vulkan: pipeline statistics query set selects no counters")
+		}
+		stride = 8 * uint64(bits.OnesCount32(uint32(statistics)))
 	default:
 		return nil, fmt.Errorf("vulkan: unsupported query type: %d", desc.Type)
 	}
 
 	createInfo := vk.QueryPoolCreateInfo{
-		SType:      vk.StructureTypeQueryPoolCreateInfo,
-		QueryType:  vkQueryType,
-		QueryCount: desc.Count,
+		SType:              vk.StructureTypeQueryPoolCreateInfo,
+		QueryType:          vkQueryType,
+		QueryCount:         desc.Count,
+		PipelineStatistics: statistics,
 	}
 
 	var pool vk.QueryPool
@@ -61,10 +74,11 @@ func (d *Device) CreateQuerySet(desc *hal.QuerySetDescriptor) (hal.QuerySet, err
 	d.cmds.ResetQueryPool(d.handle, pool, 0, desc.Count)
 
 	qs := &QuerySet{
-		pool:      pool,
-		device:    d,
-		queryType: desc.Type,
-		count:     desc.Count,
+		pool:         pool,
+		device:       d,
+		queryType:    desc.Type,
+		count:        desc.Count,
+		resultStride: stride,
 	}
 	if desc.Label != "" {
 		d.setObjectName(vk.ObjectTypeQueryPool, uint64(pool), desc.Label)
@@ -80,3 +94,75 @@ func (d *Device) DestroyQuerySet(querySet hal.QuerySet) {
 		qs.Destroy()
 	}
 }
+
+// pipelineStatisticsToVk maps the hal counter selection to Vulkan flags.
+// Vulkan writes results in ascending bit order, which matches the order of
+// the hal flags, so resolved data needs no reordering.
+func pipelineStatisticsToVk(types hal.PipelineStatisticsTypes) vk.QueryPipelineStatisticFlags {
+	var flags vk.QueryPipelineStatisticFlagBits
+	if types&hal.PipelineStatisticsVertexShaderInvocations != 0 {
+		flags |= vk.QueryPipelineStatisticVertexShaderInvocationsBit
+	}
+	if types&hal.PipelineStatisticsClipperInvocations != 0 {
+		flags |= vk.QueryPipelineStatisticClippingInvocationsBit
+	}
+	if types&hal.PipelineStatisticsClipperPrimitivesOut != 0 {
+		flags |= vk.QueryPipelineStatisticClippingPrimitivesBit
+	}
+	if types&hal.PipelineStatisticsFragmentShaderInvocations != 0 {
+		flags |= vk.QueryPipelineStatisticFragmentShaderInvocationsBit
+	}
+	if types&hal.PipelineStatisticsComputeShaderInvocations != 0 {
+		flags |= vk.QueryPipelineStatisticComputeShaderInvocationsBit
+	}
+	return vk.QueryPipelineStatisticFlags(flags)
+}
+
+// beginPipelineStatisticsQuery starts a pipeline statistics query, first
+// resetting it when the caller is outside a render pass.
+func (e *CommandEncoder) beginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32, reset bool) {
+	qs, ok := querySet.(*QuerySet)
+	if !ok || qs.pool == 0 || e.active == 0 || qs.queryType != hal.QueryTypePipelineStatistics {
+		return
+	}
+	if reset {
+		e.device.cmds.CmdResetQueryPool(e.active, qs.pool, index, 1)
+	}
+	e.device.cmds.CmdBeginQuery(e.active, qs.pool, index, 0)
+	e.statisticsSet, e.statisticsIndex = qs, index
+}
+
+// endPipelineStatisticsQuery ends the active pipeline statistics query.
+func (e *CommandEncoder) endPipelineStatisticsQuery() {
+	if e.statisticsSet != nil && e.active != 0 {
+		e.device.cmds.CmdEndQuery(e.active, e.statisticsSet.pool, e.statisticsIndex)
+	}
+	e.statisticsSet = nil
+}
+
+// BeginPipelineStatisticsQuery starts a query inside the render pass. A
+// render pass cannot reset queries; the query was reset when the set was
+// created or by the last ResolveQuerySet. Implements
+// hal.PipelineStatisticsPassEncoder.
+func (e *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	e.encoder.beginPipelineStatisticsQuery(querySet, index, false)
+}
+
+// EndPipelineStatisticsQuery ends the active query. Implements
+// hal.PipelineStatisticsPassEncoder.
+func (e *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	e.encoder.endPipelineStatisticsQuery()
+}
+
+// BeginPipelineStatisticsQuery resets and starts a query. Implements
+// hal.PipelineStatisticsPassEncoder.
+func (e *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet hal.QuerySet, index uint32) {
+	e.encoder.beginPipelineStatisticsQuery(querySet, index, true)
+}
+
+// EndPipelineStatisticsQuery ends the active query. Compute passes only
+// delimit recording on Vulkan, so the query may have begun on an earlier
+// pass of the same encoder. Implements hal.PipelineStatisticsPassEncoder.
+func (e *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	e.encoder.endPipelineStatisticsQuery()
+}
@@ -8,6 +8,7 @@ package vulkan
 import (
 	"fmt"
 	"image"
+	"runtime"
 	"sync"
 	"time"
 	"unsafe"
@@ -185,37 +186,228 @@ func (q *Queue) advanceRelay() (wait, signal vk.Semaphore, err error) {
 	return 0, 0, err
 }
 
-func (q *Queue) submitTimeline(
-	submitInfo *vk.SubmitInfo,
-	signalSems *[3]vk.Semaphore,
-	waitCount, signalCount uint32,
-	consumedAcquire bool,
-	signalValue uint64,
-) (uint64, error) {
-	if consumedAcquire {
-		q.activeSwapchain.acquireFenceValues[q.activeSwapchain.currentAcquireIdx] = signalValue
+// submitBatch collects one queue submission. Maximum sizes:
+//
+//	wait:   acquire(1) + relay(1) = 2
+//	signal: present(1) + relay(1) + timeline(1) = 3
+//
+// plus the shared fences of a SubmitWithFences.
+type submitBatch struct {
+	cmdBuffers []vk.CommandBuffer
+
+	waitSems   [2]vk.Semaphore
+	waitStages [2]vk.PipelineStageFlags2
+	waitValues [2]uint64 // always 0: these are binary semaphores
+	waitCount  uint32
+
+	signalSems   [3]vk.Semaphore
+	signalValues [3]uint64 // 0 for binary semaphores
+	signalCount  uint32
+
+	// fenceWaits and fenceSignals are the timeline semaphores of shared
+	// fences (hal.FenceSubmitter).
+	fenceWaits   []semaphoreValue
+	fenceSignals []semaphoreValue
+
+	// timeline is set when a signal is a timeline semaphore, so the
+	// legacy path must chain VkTimelineSemaphoreSubmitInfo.
+	timeline bool
+
+	// protected marks a submission of protected command buffers.
+	protected bool
+}
+
+// submissionProtected reports whether commandBuffers were recorded by
+// protected encoders. A Vulkan submission is either protected or not, so
+// mixing both kinds in one Submit is rejected.
+func submissionProtected(commandBuffers []hal.CommandBuffer) (bool, error) {
+	protected := false
+	for i, cb := range commandBuffers {
+		vkCB, ok := cb.(*CommandBuffer)
+		if !ok {
+			continue
+		}
+		if i == 0 {
+			protected = vkCB.protected
+		} else if vkCB.protected != protected {
+			return false, fmt.Errorf("vulkan: cannot submit protected and unprotected command buffers together")
+		}
+	}
+	return protected, nil
+}
+
+func (b *submitBatch) wait(sem vk.Semaphore, stage vk.PipelineStageFlagBits2) {
+	b.waitSems[b.waitCount] = sem
+	b.waitStages[b.waitCount] = vk.PipelineStageFlags2(stage)
+	b.waitCount++
+}
+
+func (b *submitBatch) signal(sem vk.Semaphore) {
+	b.signalSems[b.signalCount] = sem
+	b.signalCount++
+}
+
+func (b *submitBatch) signalTimeline(sem vk.Semaphore, value uint64) {
+	b.signalValues[b.signalCount] = value
+	b.signal(sem)
+	b.timeline = true
+}
+
+// addFences adds the shared fences a SubmitWithFences waits for and signals.
+func (b *submitBatch) addFences(waits, signals []semaphoreValue) {
+	b.fenceWaits, b.fenceSignals = waits, signals
+	if len(waits) > 0 || len(signals) > 0 {
+		b.timeline = true
 	}
+}
 
-	signalSems[signalCount] = q.device.timelineFence.timelineSemaphore
-	signalCount++
-	submitInfo.SignalSemaphoreCount = signalCount
-	submitInfo.PSignalSemaphores = &signalSems[0]
+// waits returns the semaphores the batch waits on, with their stages and
+// values. Shared fences are waited for before any command.
+func (b *submitBatch) waits() ([]vk.Semaphore, []vk.PipelineStageFlags2, []uint64) {
+	sems, stages, values := b.waitSems[:b.waitCount], b.waitStages[:b.waitCount], b.waitValues[:b.waitCount]
+	for _, w := range b.fenceWaits {
+		sems = append(sems, w.semaphore)
+		stages = append(stages, vk.PipelineStageFlags2(vk.PipelineStage2AllCommandsBit))
+		values = append(values, w.value)
+	}
+	return sems, stages, values
+}
 
-	var waitValues [2]uint64
-	var signalValues [3]uint64
-	signalValues[signalCount-1] = signalValue
-	timelineSubmitInfo := vk.TimelineSemaphoreSubmitInfo{
-		SType: vk.StructureTypeTimelineSemaphoreSubmitInfo,
+// signals returns the semaphores the batch signals, with their values.
+func (b *submitBatch) signals() ([]vk.Semaphore, []uint64) {
+	sems, values := b.signalSems[:b.signalCount], b.signalValues[:b.signalCount]
+	for _, s := range b.fenceSignals {
+		sems = append(sems, s.semaphore)
+		values = append(values, s.value)
 	}
-	if waitCount > 0 {
-		timelineSubmitInfo.WaitSemaphoreValueCount = waitCount
-		timelineSubmitInfo.PWaitSemaphoreValues = &waitValues[0]
+	return sems, values
+}
+
+// queueSubmit submits the batch with vkQueueSubmit2KHR when
+// synchronization2 is enabled, otherwise with vkQueueSubmit.
+func (q *Queue) queueSubmit(b *submitBatch, fence vk.Fence) vk.Result {
+	if q.device.supportsSynchronization2 {
+		return q.queueSubmit2(b, fence)
 	}
-	timelineSubmitInfo.SignalSemaphoreValueCount = signalCount
-	timelineSubmitInfo.PSignalSemaphoreValues = &signalValues[0]
-	submitInfo.PNext = (*uintptr)(unsafe.Pointer(&timelineSubmitInfo))
 
-	result := vkQueueSubmit(q, 1, submitInfo, vk.Fence(0))
+	waitSems, waitStages2, waitValues := b.waits()
+	signalSems, signalValues := b.signals()
+
+	var waitStagesBuf [2]vk.PipelineStageFlags
+	waitStages := waitStagesBuf[:0]
+	for _, stage := range waitStages2 {
+		waitStages = append(waitStages, vk.PipelineStageFlags(stage))
+	}
+	submitInfo := vk.SubmitInfo{
+		SType: vk.StructureTypeSubmitInfo,
+	}
+	if len(b.cmdBuffers) > 0 {
+		submitInfo.CommandBufferCount = uint32(len(b.cmdBuffers))
+		submitInfo.PCommandBuffers = &b.cmdBuffers[0]
+	}
+	if len(waitSems) > 0 {
+		submitInfo.WaitSemaphoreCount = uint32(len(waitSems))
+		submitInfo.PWaitSemaphores = &waitSems[0]
+		submitInfo.PWaitDstStageMask = &waitStages[0]
+	}
+	if len(signalSems) > 0 {
+		submitInfo.SignalSemaphoreCount = uint32(len(signalSems))
+		submitInfo.PSignalSemaphores = &signalSems[0]
+	}
+
+	// Timeline path (VK-IMPL-001): binary semaphores get value 0 (ignored).
+	var timelineSubmitInfo vk.TimelineSemaphoreSubmitInfo
+	if b.timeline {
+		timelineSubmitInfo = vk.TimelineSemaphoreSubmitInfo{
+			SType: vk.StructureTypeTimelineSemaphoreSubmitInfo,
+		}
+		if len(signalSems) > 0 {
+			timelineSubmitInfo.SignalSemaphoreValueCount = uint32(len(signalSems))
+			timelineSubmitInfo.PSignalSemaphoreValues = &signalValues[0]
+		}
+		if len(waitSems) > 0 {
+			timelineSubmitInfo.WaitSemaphoreValueCount = uint32(len(waitSems))
+			timelineSubmitInfo.PWaitSemaphoreValues = &waitValues[0]
+		}
+		submitInfo.PNext = (*uintptr)(unsafe.Pointer(&timelineSubmitInfo))
+	}
+
+	var protectedSubmitInfo vk.ProtectedSubmitInfo
+	if b.protected {
+		protectedSubmitInfo = vk.ProtectedSubmitInfo{
+			SType:           vk.StructureTypeProtectedSubmitInfo,
+			PNext:           submitInfo.PNext,
+			ProtectedSubmit: vk.Bool32(vk.True),
+		}
+		submitInfo.PNext = (*uintptr)(unsafe.Pointer(&protectedSubmitInfo))
+	}
+
+	return vkQueueSubmit(q, 1, &submitInfo, fence)
+}
+
+// queueSubmit2 is the synchronization2 form of queueSubmit. Semaphore
+// values travel in VkSemaphoreSubmitInfo, so no timeline chain is needed.
+func (q *Queue) queueSubmit2(b *submitBatch, fence vk.Fence) vk.Result {
+	var (
+		waitsBuf   [2]vk.SemaphoreSubmitInfo
+		signalsBuf [3]vk.SemaphoreSubmitInfo
+	)
+	cmdInfos := make([]vk.CommandBufferSubmitInfo, len(b.cmdBuffers))
+	for i, cb := range b.cmdBuffers {
+		cmdInfos[i] = vk.CommandBufferSubmitInfo{
+			SType:         vk.StructureTypeCommandBufferSubmitInfo,
+			CommandBuffer: cb,
+		}
+	}
+	waitSems, waitStages, waitValues := b.waits()
+	waits := waitsBuf[:0]
+	for i, sem := range waitSems {
+		waits = append(waits, vk.SemaphoreSubmitInfo{
+			SType:     vk.StructureTypeSemaphoreSubmitInfo,
+			Semaphore: sem,
+			Value:     waitValues[i],
+			StageMask: waitStages[i],
+		})
+	}
+	signalSems, signalValues := b.signals()
+	signals := signalsBuf[:0]
+	for i, sem := range signalSems {
+		signals = append(signals, vk.SemaphoreSubmitInfo{
+			SType:     vk.StructureTypeSemaphoreSubmitInfo,
+			Semaphore: sem,
+			Value:     signalValues[i],
+			StageMask: vk.PipelineStageFlags2(vk.PipelineStage2AllCommandsBit),
+		})
+	}
+
+	submitInfo := vk.SubmitInfo2{
+		SType: vk.StructureTypeSubmitInfo2,
+	}
+	if len(cmdInfos) > 0 {
+		submitInfo.CommandBufferInfoCount = uint32(len(cmdInfos))
+		submitInfo.PCommandBufferInfos = &cmdInfos[0]
+	}
+	if b.protected {
+		submitInfo.Flags = vk.SubmitFlags(vk.SubmitProtectedBit)
+	}
+	if len(waits) > 0 {
+		submitInfo.WaitSemaphoreInfoCount = uint32(len(waits))
+		submitInfo.PWaitSemaphoreInfos = &waits[0]
+	}
+	if len(signals) > 0 {
+		submitInfo.SignalSemaphoreInfoCount = uint32(len(signals))
+		submitInfo.PSignalSemaphoreInfos = &signals[0]
+	}
+	return q.device.cmds.QueueSubmit2(q.handle, 1, &submitInfo, fence)
+}
+
+func (q *Queue) submitTimeline(b *submitBatch, consumedAcquire bool, signalValue uint64) (uint64, error) {
+	if consumedAcquire {
+		q.activeSwapchain.acquireFenceValues[q.activeSwapchain.currentAcquireIdx] = signalValue
+	}
+
+	b.signalTimeline(q.device.timelineFence.timelineSemaphore, signalValue)
+	result := q.queueSubmit(b, vk.Fence(0))
 	if result != vk.Success {
 		err := fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result)
 		if consumedAcquire {
@@ -235,13 +427,22 @@ func (q *Queue) submitTimeline(
 func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
+	return q.submitLocked(commandBuffers, nil, nil)
+}
 
-	if len(commandBuffers) == 0 {
+// submitLocked submits commandBuffers behind waits and ahead of signals,
+// the timeline semaphores of shared fences. q.mu must be held.
+func (q *Queue) submitLocked(commandBuffers []hal.CommandBuffer, waits, signals []semaphoreValue) (uint64, error) {
+	if len(commandBuffers) == 0 && len(waits) == 0 && len(signals) == 0 {
 		return 0, nil
 	}
 	if err := validateSwapchainSubmission(q.activeSwapchain, q.device); err != nil {
 		return 0, err
 	}
+	protected, err := submissionProtected(commandBuffers)
+	if err != nil {
+		return 0, err
+	}
 
 	// Convert command buffers to Vulkan handles.
 	// Use sync.Pool to avoid per-frame heap allocation (VK-PERF-001).
@@ -261,25 +462,17 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 		cmdBufferPool.Put(pooledSlice)
 	}()
 
-	// Build wait/signal semaphore arrays. Maximum sizes:
-	//   wait:   acquire(1) + relay(1) = 2
-	//   signal: present(1) + relay(1) + timeline(1) = 3
-	var (
-		waitSems   [2]vk.Semaphore
-		waitStages [2]vk.PipelineStageFlags
-		waitCount  uint32
-
-		signalSems  [3]vk.Semaphore
-		signalCount uint32
-	)
+	b := submitBatch{cmdBuffers: vkCmdBuffers, protected: protected}
+	b.addFences(waits, signals)
 
 	// If we have an active swapchain, use its semaphores for GPU-side synchronization.
 	// CRITICAL: Semaphores can only be used ONCE per frame.
 	// - Wait on currentAcquireSem: ONLY on first submit (signaled by acquire)
 	// - Signal presentSemaphores: ONLY on first submit (waited on by present)
 	// Subsequent submits in the same frame run without semaphore synchronization.
+	// A submission of fences alone renders nothing and leaves them alone.
 	consumedAcquire := false
-	if q.activeSwapchain != nil && !q.acquireUsed {
+	if q.activeSwapchain != nil && !q.acquireUsed && len(vkCmdBuffers) > 0 {
 		if q.activeSwapchain.currentAcquireSem == 0 || q.activeSwapchain.currentImage >= uint32(len(q.activeSwapchain.presentSemaphores)) {
 			err := fmt.Errorf("vulkan: active swapchain semaphore state is invalid")
 			q.activeSwapchain.markBroken(err)
@@ -291,12 +484,8 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 			q.activeSwapchain.markBroken(err)
 			return 0, err
 		}
-		waitSems[waitCount] = q.activeSwapchain.currentAcquireSem
-		waitStages[waitCount] = vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit)
-		waitCount++
-
-		signalSems[signalCount] = presentSemaphore
-		signalCount++
+		b.wait(q.activeSwapchain.currentAcquireSem, vk.PipelineStage2ColorAttachmentOutputBit)
+		b.signal(presentSemaphore)
 
 		q.acquireUsed = true
 		consumedAcquire = true
@@ -310,34 +499,16 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 		return 0, err
 	}
 	if relayWait != 0 {
-		waitSems[waitCount] = relayWait
-		waitStages[waitCount] = vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit)
-		waitCount++
+		b.wait(relayWait, vk.PipelineStage2TopOfPipeBit)
 	}
 	if relaySignal != 0 {
-		signalSems[signalCount] = relaySignal
-		signalCount++
-	}
-
-	submitInfo := vk.SubmitInfo{
-		SType:              vk.StructureTypeSubmitInfo,
-		CommandBufferCount: uint32(len(vkCmdBuffers)),
-		PCommandBuffers:    &vkCmdBuffers[0],
-	}
-	if waitCount > 0 {
-		submitInfo.WaitSemaphoreCount = waitCount
-		submitInfo.PWaitSemaphores = &waitSems[0]
-		submitInfo.PWaitDstStageMask = &waitStages[0]
-	}
-	if signalCount > 0 {
-		submitInfo.SignalSemaphoreCount = signalCount
-		submitInfo.PSignalSemaphores = &signalSems[0]
+		b.signal(relaySignal)
 	}
 
 	signalValue := q.device.timelineFence.nextSignalValue()
 
 	if q.device.timelineFence.isTimeline {
-		return q.submitTimeline(&submitInfo, &signalSems, waitCount, signalCount, consumedAcquire, signalValue)
+		return q.submitTimeline(&b, consumedAcquire, signalValue)
 	}
 
 	// Binary path (VK-IMPL-003): Get a fence from the pool to track this submission.
@@ -359,7 +530,7 @@ func (q *Queue) Submit(commandBuffers []hal.CommandBuffer) (uint64, error) {
 	}
 
 	// Single vkQueueSubmit with pool fence — no more double submit.
-	result := vkQueueSubmit(q, 1, &submitInfo, poolFence)
+	result := q.queueSubmit(&b, poolFence)
 	if result != vk.Success {
 		if consumedAcquire {
 			q.activeSwapchain.markBroken(fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result))
@@ -430,6 +601,10 @@ func (q *Queue) SubmitForPresent(commandBuffers []hal.CommandBuffer, swapchain *
 		swapchain.markBroken(err)
 		return err
 	}
+	protected, err := submissionProtected(commandBuffers)
+	if err != nil {
+		return err
+	}
 
 	// Convert command buffers to Vulkan handles.
 	// Use sync.Pool to avoid per-frame heap allocation (VK-PERF-001).
@@ -449,26 +624,13 @@ func (q *Queue) SubmitForPresent(commandBuffers []hal.CommandBuffer, swapchain *
 		cmdBufferPool.Put(pooledSlice)
 	}()
 
-	// Build wait/signal semaphore arrays. Maximum sizes:
-	//   wait:   acquire(1) + relay(1) = 2
-	//   signal: present(1) + relay(1) + timeline(1) = 3
-	var (
-		waitSems   [2]vk.Semaphore
-		waitStages [2]vk.PipelineStageFlags
-		waitCount  uint32
-
-		signalSems  [3]vk.Semaphore
-		signalCount uint32
-	)
+	b := submitBatch{cmdBuffers: vkCmdBuffers, protected: protected}
 
 	// Acquire semaphore: always present for SubmitForPresent.
-	waitSems[waitCount] = swapchain.currentAcquireSem
-	waitStages[waitCount] = vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit)
-	waitCount++
+	b.wait(swapchain.currentAcquireSem, vk.PipelineStage2ColorAttachmentOutputBit)
 
 	// Present semaphore: always present for SubmitForPresent.
-	signalSems[signalCount] = presentSemaphore
-	signalCount++
+	b.signal(presentSemaphore)
 
 	// VK-SYNC-001: Add relay semaphores for GPU-side submission ordering.
 	if q.relay != nil {
@@ -478,23 +640,9 @@ func (q *Queue) SubmitForPresent(commandBuffers []hal.CommandBuffer, swapchain *
 			return fmt.Errorf("vulkan: relay semaphore advance: %w", err)
 		}
 		if relayWait != 0 {
-			waitSems[waitCount] = relayWait
-			waitStages[waitCount] = vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit)
-			waitCount++
+			b.wait(relayWait, vk.PipelineStage2TopOfPipeBit)
 		}
-		signalSems[signalCount] = relaySignal
-		signalCount++
-	}
-
-	submitInfo := vk.SubmitInfo{
-		SType:                vk.StructureTypeSubmitInfo,
-		WaitSemaphoreCount:   waitCount,
-		PWaitSemaphores:      &waitSems[0],
-		PWaitDstStageMask:    &waitStages[0],
-		CommandBufferCount:   uint32(len(vkCmdBuffers)),
-		PCommandBuffers:      &vkCmdBuffers[0],
-		SignalSemaphoreCount: signalCount,
-		PSignalSemaphores:    &signalSems[0],
+		b.signal(relaySignal)
 	}
 
 	// Timeline path (VK-IMPL-001): Also signal the timeline semaphore on this submit.
@@ -504,28 +652,8 @@ func (q *Queue) SubmitForPresent(commandBuffers []hal.CommandBuffer, swapchain *
 		// VK-IMPL-004: Record which submission consumed this acquire semaphore.
 		swapchain.acquireFenceValues[swapchain.currentAcquireIdx] = signalValue
 
-		// Add timeline semaphore to the signal list.
-		signalSems[signalCount] = q.device.timelineFence.timelineSemaphore
-		signalCount++
-		submitInfo.SignalSemaphoreCount = signalCount
-		submitInfo.PSignalSemaphores = &signalSems[0]
-
-		// Build timeline values arrays. Binary semaphores get value 0 (ignored),
-		// timeline semaphore (always last) gets signalValue.
-		var waitValues [2]uint64
-		var signalValues [3]uint64
-		signalValues[signalCount-1] = signalValue
-
-		timelineSubmitInfo := vk.TimelineSemaphoreSubmitInfo{
-			SType:                     vk.StructureTypeTimelineSemaphoreSubmitInfo,
-			WaitSemaphoreValueCount:   waitCount,
-			PWaitSemaphoreValues:      &waitValues[0],
-			SignalSemaphoreValueCount: signalCount,
-			PSignalSemaphoreValues:    &signalValues[0],
-		}
-		submitInfo.PNext = (*uintptr)(unsafe.Pointer(&timelineSubmitInfo))
-
-		result := vkQueueSubmit(q, 1, &submitInfo, vk.Fence(0))
+		b.signalTimeline(q.device.timelineFence.timelineSemaphore, signalValue)
+		result := q.queueSubmit(&b, vk.Fence(0))
 		if result != vk.Success {
 			swapchain.markBroken(fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result))
 			return fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result)
@@ -549,7 +677,7 @@ func (q *Queue) SubmitForPresent(commandBuffers []hal.CommandBuffer, swapchain *
 		return fmt.Errorf("vulkan: SubmitForPresent fencePool signal: %w", err)
 	}
 
-	result := vkQueueSubmit(q, 1, &submitInfo, poolFence)
+	result := q.queueSubmit(&b, poolFence)
 	if result != vk.Success {
 		swapchain.markBroken(fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result))
 		return fmt.Errorf("vulkan: vkQueueSubmit failed: %d", result)
@@ -624,6 +752,17 @@ func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal
 	if !ok || vkTexture == nil {
 		return fmt.Errorf("vulkan: WriteTexture: invalid texture type")
 	}
+	if vkTexture.protected {
+		return fmt.Errorf("vulkan: WriteTexture: cannot write to a protected texture from the host")
+	}
+
+	if vkTexture.hostCopy {
+		err := q.writeTextureHost(vkTexture, dst, data, layout, size)
+		if err == nil {
+			return nil
+		}
+		hal.Logger().Debug("vulkan: host image copy failed, using staging upload", "err", err)
+	}
 
 	// Create staging buffer
 	stagingDesc := &hal.BufferDescriptor{
@@ -772,6 +911,89 @@ func (q *Queue) WriteTexture(dst *hal.ImageCopyTexture, data []byte, layout *hal
 	return nil
 }
 
+// writeTextureHost uploads data with VK_EXT_host_image_copy: the CPU writes
+// the image directly, with no staging buffer, command buffer or submit.
+// Host copies need the image idle, so pending GPU work is drained first;
+// the staging path blocks on the same work, so callers see no difference.
+// The image is left in SHADER_READ_ONLY_OPTIMAL like the staging path.
+func (q *Queue) writeTextureHost(tex *Texture, dst *hal.ImageCopyTexture, data []byte, layout *hal.ImageDataLayout, size *hal.Extent3D) error {
+	d := q.device
+	if layout.Offset >= uint64(len(data)) {
+		return fmt.Errorf("vulkan: WriteTexture: offset %d beyond data size %d", layout.Offset, len(data))
+	}
+
+	// MemoryRowLength is in texels; zero means tightly packed, matching
+	// BytesPerRow == 0. hostCopyEligible excludes block-compressed formats.
+	var rowLength uint32
+	if layout.BytesPerRow != 0 {
+		rowLength = layout.BytesPerRow / tex.format.BlockCopySize()
+	}
+	baseLayer, layerCount, z, depth := imageCopyLayers(tex.dimension, dst.Origin.Z, size.DepthOrArrayLayers)
+	aspect := textureAspectToVk(dst.Aspect, tex.format)
+	subresource := vk.ImageSubresourceRange{
+		AspectMask:     aspect,
+		BaseMipLevel:   dst.MipLevel,
+		LevelCount:     1,
+		BaseArrayLayer: baseLayer,
+		LayerCount:     layerCount,
+	}
+
+	q.waitForGPU()
+
+	transition := vk.HostImageLayoutTransitionInfo{
+		SType:            vk.StructureTypeHostImageLayoutTransitionInfoExt,
+		Image:            tex.handle,
+		OldLayout:        vk.ImageLayoutUndefined,
+		NewLayout:        d.hostCopyLayout,
+		SubresourceRange: subresource,
+	}
+	if result := d.cmds.TransitionImageLayout(d.handle, 1, &transition); result != vk.Success {
+		return fmt.Errorf("vulkan: vkTransitionImageLayoutEXT failed: %d", result)
+	}
+
+	region := vk.MemoryToImageCopy{
+		SType:             vk.StructureTypeMemoryToImageCopyExt,
+		PHostPointer:      (*uintptr)(unsafe.Pointer(&data[layout.Offset])),
+		MemoryRowLength:   rowLength,
+		MemoryImageHeight: layout.RowsPerImage,
+		ImageSubresource: vk.ImageSubresourceLayers{
+			AspectMask:     aspect,
+			MipLevel:       dst.MipLevel,
+			BaseArrayLayer: baseLayer,
+			LayerCount:     layerCount,
+		},
+		ImageOffset: vk.Offset3D{X: int32(dst.Origin.X), Y: int32(dst.Origin.Y), Z: z},
+		ImageExtent: vk.Extent3D{Width: size.Width, Height: size.Height, Depth: depth},
+	}
+	copyInfo := vk.CopyMemoryToImageInfo{
+		SType:          vk.StructureTypeCopyMemoryToImageInfoExt,
+		DstImage:       tex.handle,
+		DstImageLayout: d.hostCopyLayout,
+		RegionCount:    1,
+		PRegions:       &region,
+	}
+	result := d.cmds.CopyMemoryToImage(d.handle, &copyInfo)
+	runtime.KeepAlive(data)
+	if result != vk.Success {
+		return fmt.Errorf("vulkan: vkCopyMemoryToImageEXT failed: %d", result)
+	}
+
+	if d.hostCopyLayout != vk.ImageLayoutShaderReadOnlyOptimal {
+		transition.OldLayout = d.hostCopyLayout
+		transition.NewLayout = vk.ImageLayoutShaderReadOnlyOptimal
+		if result := d.cmds.TransitionImageLayout(d.handle, 1, &transition); result != vk.Success {
+			return fmt.Errorf("vulkan: vkTransitionImageLayoutEXT failed: %d", result)
+		}
+	}
+
+	hal.Logger().Debug("vulkan: WriteTexture completed via host image copy",
+		"width", size.Width,
+		"height", size.Height,
+		"dataSize", len(data),
+	)
+	return nil
+}
+
 // waitForGPU waits for the latest GPU submission to complete.
 // Both paths use the unified deviceFence: timeline semaphore (VK-IMPL-001)
 // or binary fence pool (VK-IMPL-003).
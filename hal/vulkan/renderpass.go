@@ -26,9 +26,11 @@ type RenderPassKey struct {
 	DepthStoreOp     vk.AttachmentStoreOp
 	StencilLoadOp    vk.AttachmentLoadOp
 	StencilStoreOp   vk.AttachmentStoreOp
+	DepthLayout      vk.ImageLayout // depth/stencil layout; Undefined means DEPTH_STENCIL_ATTACHMENT_OPTIMAL
 	SampleCount      vk.SampleCountFlagBits
 	ColorFinalLayout vk.ImageLayout
-	HasResolve       bool // true when MSAA resolve target is present
+	HasResolve       bool   // true when MSAA resolve target is present
+	ViewCount        uint32 // multiview views; 0 or 1 for a single view
 }
 
 // FramebufferKey uniquely identifies a framebuffer configuration.
@@ -198,9 +200,13 @@ func (c *RenderPassCache) createRenderPass(key RenderPassKey) (vk.RenderPass, er
 
 	// Depth/stencil attachment (last attachment)
 	if key.DepthFormat != vk.FormatUndefined {
+		depthLayout := key.DepthLayout
+		if depthLayout == vk.ImageLayoutUndefined {
+			depthLayout = vk.ImageLayoutDepthStencilAttachmentOptimal
+		}
 		depthInitialLayout := vk.ImageLayoutUndefined
-		if key.DepthLoadOp == vk.AttachmentLoadOpLoad {
-			depthInitialLayout = vk.ImageLayoutDepthStencilAttachmentOptimal
+		if key.DepthLoadOp == vk.AttachmentLoadOpLoad || key.StencilLoadOp == vk.AttachmentLoadOpLoad {
+			depthInitialLayout = depthLayout
 		}
 		attachments = append(attachments, vk.AttachmentDescription{
 			Format:         key.DepthFormat,
@@ -210,11 +216,11 @@ func (c *RenderPassCache) createRenderPass(key RenderPassKey) (vk.RenderPass, er
 			StencilLoadOp:  key.StencilLoadOp,
 			StencilStoreOp: key.StencilStoreOp,
 			InitialLayout:  depthInitialLayout,
-			FinalLayout:    vk.ImageLayoutDepthStencilAttachmentOptimal,
+			FinalLayout:    depthLayout,
 		})
 		depthRef = &vk.AttachmentReference{
 			Attachment: uint32(len(attachments) - 1),
-			Layout:     vk.ImageLayoutDepthStencilAttachmentOptimal,
+			Layout:     depthLayout,
 		}
 	}
 
@@ -244,8 +250,28 @@ func (c *RenderPassCache) createRenderPass(key RenderPassKey) (vk.RenderPass, er
 		createInfo.PAttachments = &attachments[0]
 	}
 
+	// Multiview renders the subpass once per bit of the view mask, into the
+	// matching layer of every attachment. The views are correlated so the
+	// driver may render them concurrently (Rust: vulkan/device.rs
+	// make_render_pass).
+	var viewMask uint32
+	var multiview vk.RenderPassMultiviewCreateInfo
+	if key.ViewCount > 1 {
+		viewMask = uint32(1)<<key.ViewCount - 1
+		multiview = vk.RenderPassMultiviewCreateInfo{
+			SType:                vk.StructureTypeRenderPassMultiviewCreateInfo,
+			SubpassCount:         1,
+			PViewMasks:           &viewMask,
+			CorrelationMaskCount: 1,
+			PCorrelationMasks:    &viewMask,
+		}
+		createInfo.PNext = (*uintptr)(unsafe.Pointer(&multiview))
+	}
+
 	var renderPass vk.RenderPass
 	result := c.cmds.CreateRenderPass(c.device, &createInfo, nil, &renderPass)
+	runtime.KeepAlive(viewMask)
+	runtime.KeepAlive(multiview)
 	runtime.KeepAlive(attachments)
 	runtime.KeepAlive(colorRef)
 	runtime.KeepAlive(resolveRef)
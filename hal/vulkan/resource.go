@@ -6,6 +6,9 @@
 package vulkan
 
 import (
+	"slices"
+	"sync"
+
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/hal/vulkan/memory"
 	"github.com/gogpu/wgpu/hal/vulkan/vk"
@@ -55,6 +58,13 @@ type Texture struct {
 	dimension   gputypes.TextureDimension
 	device      *Device
 	isExternal  bool // True if memory is not owned by us (swapchain images)
+	hostCopy    bool // Created with HOST_TRANSFER usage (VK_EXT_host_image_copy)
+	protected   bool // Created in protected memory (hal.FeatureProtectedContent)
+
+	// aspectUsage is the usage of the depth and stencil aspects of a
+	// combined depth/stencil texture after the barriers recorded so far.
+	aspectMu    sync.Mutex
+	aspectUsage [2]gputypes.TextureUsage
 }
 
 // Extent3D represents 3D dimensions.
@@ -142,6 +152,33 @@ func (s *Sampler) NativeHandle() uintptr {
 type ShaderModule struct {
 	handle vk.ShaderModule
 	device *Device
+
+	// mu guards prebuilt.
+	mu sync.Mutex
+	// prebuilt holds compute pipelines built from compilation hints until
+	// CreateComputePipeline takes them over.
+	prebuilt []prebuiltPipeline
+}
+
+// prebuiltPipeline is a compute pipeline built from a compilation hint.
+type prebuiltPipeline struct {
+	entryPoint string
+	layout     *PipelineLayout
+	handle     vk.Pipeline
+}
+
+// takePrebuilt removes and returns the pipeline built from a hint for
+// entryPoint and layout, or 0 when there is none.
+func (m *ShaderModule) takePrebuilt(entryPoint string, layout *PipelineLayout) vk.Pipeline {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, p := range m.prebuilt {
+		if p.entryPoint == entryPoint && p.layout == layout && layout.handle != 0 {
+			m.prebuilt = slices.Delete(m.prebuilt, i, i+1)
+			return p.handle
+		}
+	}
+	return 0
 }
 
 // Destroy releases the shader module.
@@ -249,8 +286,11 @@ func (p *ComputePipeline) Destroy() {
 // Fence implements hal.Fence for Vulkan.
 type Fence struct {
 	handle vk.Fence
-	value  uint64 //nolint:unused // Will be used for timeline semaphores
 	device *Device
+
+	// semaphore is the timeline semaphore of a fence from CreateSharedFence
+	// or ImportFence, which have no VkFence handle.
+	semaphore vk.Semaphore
 }
 
 // Destroy releases the fence.
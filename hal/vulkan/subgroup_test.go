@@ -0,0 +1,58 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+func TestSubgroupSizeRange(t *testing.T) {
+	compute := vk.ShaderStageFlags(vk.ShaderStageComputeBit)
+	tests := []struct {
+		name        string
+		subgroup    vk.PhysicalDeviceSubgroupProperties
+		sizeControl vk.PhysicalDeviceSubgroupSizeControlProperties
+		wantMin     uint32
+		wantMax     uint32
+	}{
+		{
+			name:     "default size only",
+			subgroup: vk.PhysicalDeviceSubgroupProperties{SubgroupSize: 32, SupportedStages: compute, SupportedOperations: requiredSubgroupOperations},
+			wantMin:  32, wantMax: 32,
+		},
+		{
+			name:        "size control range",
+			subgroup:    vk.PhysicalDeviceSubgroupProperties{SubgroupSize: 32, SupportedStages: compute, SupportedOperations: requiredSubgroupOperations},
+			sizeControl: vk.PhysicalDeviceSubgroupSizeControlProperties{MinSubgroupSize: 8, MaxSubgroupSize: 32},
+			wantMin:     8, wantMax: 32,
+		},
+		{
+			name:     "no compute stage",
+			subgroup: vk.PhysicalDeviceSubgroupProperties{SubgroupSize: 32, SupportedStages: vk.ShaderStageFlags(vk.ShaderStageFragmentBit), SupportedOperations: requiredSubgroupOperations},
+		},
+		{
+			name: "missing arithmetic",
+			subgroup: vk.PhysicalDeviceSubgroupProperties{
+				SubgroupSize:        64,
+				SupportedStages:     compute,
+				SupportedOperations: requiredSubgroupOperations &^ vk.SubgroupFeatureFlags(vk.SubgroupFeatureArithmeticBit),
+			},
+		},
+		{
+			name: "unqueried",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, gotMax := subgroupSizeRange(&tt.subgroup, &tt.sizeControl)
+			if gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("subgroupSizeRange = (%d, %d), want (%d, %d)", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
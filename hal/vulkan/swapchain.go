@@ -27,6 +27,9 @@ type Swapchain struct {
 	format      vk.Format
 	extent      vk.Extent2D
 	presentMode vk.PresentModeKHR
+	// preRotated is set when the application chose the pre-transform and
+	// so must hear about orientation changes through SUBOPTIMAL.
+	preRotated bool
 	// Acquire semaphores - rotated through for each acquire (like wgpu).
 	// We don't know which image we'll get, so we can't index by image.
 	acquireSemaphores  []vk.Semaphore
@@ -42,6 +45,14 @@ type Swapchain struct {
 	surfaceTextures   []*SwapchainTexture
 	acquireFence      vk.Fence // Post-acquire fence for frame pacing (Rust wgpu pattern)
 
+	// Present fences - one per swapchain image, only with
+	// VK_EXT_swapchain_maintenance1. vkQueuePresentKHR signals the fence once
+	// the presentation engine no longer needs the image's present semaphore,
+	// so the semaphore is recycled on proof of completion rather than on the
+	// assumption that a re-acquired image implies a finished present.
+	presentFences       []vk.Fence
+	presentFencePending []bool
+
 	// BUG-WGPU-VK-006: Swapchain image layout tracking.
 	// Tracks the current Vulkan image layout for each swapchain image. Used to
 	// determine whether an explicit barrier to PRESENT_SRC_KHR is needed before
@@ -69,6 +80,11 @@ type Swapchain struct {
 	broken     bool
 	failureErr error
 	destroyed  bool
+
+	// presentSuboptimal is set when a present returned SUBOPTIMAL. The
+	// swapchain keeps working, but acquires report Suboptimal so the caller
+	// reconfigures when convenient; a new swapchain starts clear.
+	presentSuboptimal bool
 }
 
 // SwapchainTexture wraps a swapchain image as a SurfaceTexture.
@@ -444,10 +460,18 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	}
 	capabilities := snapshot.capabilities
 	policy := swapchainPolicyForSurface(s)
-	preTransform, err := policy.preTransform(capabilities)
+	preTransform, err := policy.preTransform(capabilities, config.PreTransform)
 	if err != nil {
 		return err
 	}
+	if config.Protected {
+		if !device.protectedMemory {
+			return fmt.Errorf("vulkan: protected surface requires FeatureProtectedContent")
+		}
+		if !surfaceSupportsProtected(s.instance, device.physicalDevice, s.handle) {
+			return fmt.Errorf("vulkan: surface does not support protected swapchains")
+		}
+	}
 
 	// Determine image count
 	if capabilities.MinImageCount == 0 {
@@ -467,13 +491,22 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 		return fmt.Errorf("vulkan: surface returned invalid image count range")
 	}
 
-	extent, err := selectSwapchainExtent(capabilities, config.Width, config.Height)
+	// Width and Height are in the application's orientation. A pre-rotated
+	// swapchain holds images in the display's native orientation, so a quarter
+	// turn swaps an application-chosen extent; a defined current extent is
+	// already native.
+	requestedWidth, requestedHeight := config.Width, config.Height
+	if config.PreTransform != 0 && swapsDimensions(preTransform) {
+		requestedWidth, requestedHeight = requestedHeight, requestedWidth
+	}
+	extent, err := selectSwapchainExtent(capabilities, requestedWidth, requestedHeight)
 	if err != nil {
 		return err
 	}
 
 	// Log surface capabilities for HiDPI diagnostics (BUG-VK-HIDPI-001).
-	hal.Logger().Debug("vulkan: surface capabilities",
+	log := device.logger(hal.LogSwapchain)
+	log.Debug("vulkan: surface capabilities",
 		"requestedWidth", config.Width,
 		"requestedHeight", config.Height,
 		"currentExtent", [2]uint32{capabilities.CurrentExtent.Width, capabilities.CurrentExtent.Height},
@@ -487,7 +520,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	// pixels. Downstream code (e.g., MSAA textures) should use
 	// Surface.ActualExtent() to match the real swapchain size.
 	if extent.Width != config.Width || extent.Height != config.Height {
-		hal.Logger().Warn("vulkan: swapchain extent clamped by driver",
+		log.Warn("vulkan: swapchain extent clamped by driver",
 			"requestedWidth", config.Width,
 			"requestedHeight", config.Height,
 			"actualWidth", extent.Width,
@@ -530,6 +563,9 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 		Clipped:          vk.True,
 		OldSwapchain:     oldSwapchainHandle,
 	}
+	if config.Protected {
+		createInfo.Flags = vk.SwapchainCreateFlagsKHR(vk.SwapchainCreateProtectedBitKhr)
+	}
 
 	var swapchainHandle vk.SwapchainKHR
 	result := vkCreateSwapchainKHR(device, &createInfo, nil, &swapchainHandle)
@@ -546,7 +582,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	swapchainImageCount := uint32(len(images))
 
 	// Log actual swapchain creation result (wgpu#185: HiDPI diagnostic).
-	hal.Logger().Info("vulkan: swapchain created",
+	log.Info("vulkan: swapchain created",
 		"extent", fmt.Sprintf("%dx%d", extent.Width, extent.Height),
 		"images", swapchainImageCount,
 		"format", vkFormat,
@@ -603,8 +639,9 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 		SType: vk.StructureTypeSemaphoreCreateInfo,
 	}
 
-	// Create arrays for rotating semaphores (same count as images).
-	acquireSemaphores := make([]vk.Semaphore, len(images))
+	// Acquire semaphores get one spare slot over the image count; present
+	// semaphores match the images one-to-one.
+	acquireSemaphores := make([]vk.Semaphore, acquireSemaphoreCount(len(images)))
 	presentSemaphores := make([]vk.Semaphore, len(images))
 
 	// Create acquire semaphores
@@ -690,6 +727,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 		format:             vkFormat,
 		extent:             extent,
 		presentMode:        presentMode,
+		preRotated:         config.PreTransform != 0,
 		acquireSemaphores:  acquireSemaphores,
 		acquireFenceValues: make([]uint64, len(acquireSemaphores)),
 		nextAcquireIdx:     0,
@@ -714,6 +752,14 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	}
 	swapchain.acquireFence = acquireFence
 
+	if device.supportsPresentFences {
+		if err := swapchain.createPresentFences(); err != nil {
+			_ = swapchain.destroyResources()
+			vkDestroySwapchainKHR(device, swapchainHandle, nil)
+			return err
+		}
+	}
+
 	// Link swapchain to surface textures
 	for _, tex := range surfaceTextures {
 		tex.swapchain = swapchain
@@ -722,7 +768,7 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	oldDevice := s.device
 	if err := device.registerConfiguredSurface(s); err != nil {
 		if destroyErr := swapchain.destroyWithError(); destroyErr != nil {
-			hal.Logger().Error("vulkan: failed to clean up unregistered swapchain", "error", destroyErr)
+			log.Error("vulkan: failed to clean up unregistered swapchain", "error", destroyErr)
 		}
 		return fmt.Errorf("vulkan: register configured surface: %w", err)
 	}
@@ -745,6 +791,73 @@ func (s *Surface) createSwapchain(device *Device, config *hal.SurfaceConfigurati
 	return nil
 }
 
+// acquireSemaphoreCount returns the size of the acquire semaphore ring.
+//
+// With exactly one semaphore per image, the pre-acquire wait targets the
+// submission issued imageCount frames ago, which at high frame rates is often
+// still executing. The spare slot (matching wgpu-hal) pushes that wait one
+// frame further back so it is almost always already satisfied.
+func acquireSemaphoreCount(imageCount int) int {
+	return imageCount + 1
+}
+
+// createPresentFences creates one unsignaled fence per swapchain image for
+// VkSwapchainPresentFenceInfoEXT.
+func (sc *Swapchain) createPresentFences() error {
+	fenceInfo := vk.FenceCreateInfo{
+		SType: vk.StructureTypeFenceCreateInfo,
+	}
+	sc.presentFences = make([]vk.Fence, len(sc.images))
+	sc.presentFencePending = make([]bool, len(sc.images))
+	for i := range sc.presentFences {
+		result := sc.device.cmds.CreateFence(sc.device.handle, &fenceInfo, nil, &sc.presentFences[i])
+		if result != vk.Success {
+			return fmt.Errorf("vulkan: vkCreateFence (presentFence[%d]) failed: %d", i, result)
+		}
+		sc.device.setObjectName(vk.ObjectTypeFence, uint64(sc.presentFences[i]),
+			fmt.Sprintf("PresentFence(%d)", i))
+	}
+	return nil
+}
+
+// waitPresentFence blocks until the previous present of imageIndex has
+// released its present semaphore, then resets the fence for the next present.
+// It is a no-op when present fences are unavailable or nothing is pending.
+func (sc *Swapchain) waitPresentFence(imageIndex uint32, timeout uint64) error {
+	if int(imageIndex) >= len(sc.presentFences) || !sc.presentFencePending[imageIndex] {
+		return nil
+	}
+	fence := sc.presentFences[imageIndex]
+	if result := sc.device.cmds.WaitForFences(sc.device.handle, 1, &fence, vk.True, timeout); result != vk.Success {
+		return mapVulkanResult("vkWaitForFences (present fence)", result)
+	}
+	if result := sc.device.cmds.ResetFences(sc.device.handle, 1, &fence); result != vk.Success {
+		return mapVulkanResult("vkResetFences (present fence)", result)
+	}
+	sc.presentFencePending[imageIndex] = false
+	return nil
+}
+
+// releasePresentFences waits for outstanding presents and destroys the
+// present fences. vkDeviceWaitIdle does not cover presentation, so this is
+// the only guarantee that present semaphores are idle before destruction.
+func (sc *Swapchain) releasePresentFences() {
+	const presentDrainTimeout = uint64(1_000_000_000) // 1s, same as acquire
+	for i, fence := range sc.presentFences {
+		if fence == 0 {
+			continue
+		}
+		if err := sc.waitPresentFence(uint32(i), presentDrainTimeout); err != nil {
+			sc.device.logger(hal.LogSwapchain).Warn("vulkan: present fence did not signal before teardown",
+				"imageIndex", i, "error", err)
+		}
+		sc.device.cmds.DestroyFence(sc.device.handle, fence, nil)
+		sc.presentFences[i] = 0
+	}
+	sc.presentFences = nil
+	sc.presentFencePending = nil
+}
+
 func retireOldSwapchain(device *Device, oldSwapchain *Swapchain, oldHandle vk.SwapchainKHR) error {
 	if oldSwapchain == nil {
 		return nil
@@ -787,6 +900,8 @@ func (sc *Swapchain) releaseSyncResourcesAfterIdle() {
 	if sc == nil || sc.device == nil {
 		return
 	}
+	sc.releasePresentFences()
+
 	for i, sem := range sc.acquireSemaphores {
 		if sem != 0 {
 			vkDestroySemaphore(sc.device, sem, nil)
@@ -862,7 +977,7 @@ func (sc *Swapchain) destroyResourcesAfterIdle() error {
 // destroyWithError when they need to preserve a failed synchronization result.
 func (sc *Swapchain) Destroy() {
 	if err := sc.destroyWithError(); err != nil {
-		hal.Logger().Error("vulkan: failed to destroy swapchain", "error", err)
+		sc.device.logger(hal.LogSwapchain).Error("vulkan: failed to destroy swapchain", "error", err)
 	}
 }
 
@@ -933,6 +1048,8 @@ func (sc *Swapchain) abandonDeviceResources() {
 	sc.acquireSemaphores = nil
 	sc.acquireFenceValues = nil
 	sc.presentSemaphores = nil
+	sc.presentFences = nil
+	sc.presentFencePending = nil
 	sc.surfaceTextures = nil
 	sc.imageLayouts = nil
 	sc.acquireFence = 0
@@ -1015,32 +1132,7 @@ func (sc *Swapchain) acquireNextImage() (*SwapchainTexture, bool, error) {
 	fence := sc.acquireFence
 	var imageIndex uint32
 	result := vkAcquireNextImageKHR(sc.device, sc.handle, timeout, acquireSem, fence, &imageIndex)
-
-	switch result {
-	case vk.Success, vk.SuboptimalKhr:
-		// OK - continue
-	case vk.Timeout:
-		// Timeout - return nil to skip frame. DON'T advance.
-		// (wgpu: returns Ok(None))
-		return nil, false, nil
-	case vk.NotReady, vk.ErrorOutOfDateKhr:
-		// Surface needs reconfiguration
-		// (wgpu: returns Err(Outdated))
-		if result == vk.ErrorOutOfDateKhr {
-			sc.markBroken(hal.ErrSurfaceOutdated)
-		}
-		return nil, false, hal.ErrSurfaceOutdated
-	case vk.ErrorSurfaceLostKhr:
-		// Surface destroyed (e.g., Wayland compositor killed it).
-		// (wgpu: returns Err(Lost))
-		sc.markBroken(hal.ErrSurfaceLost)
-		return nil, false, hal.ErrSurfaceLost
-	case vk.ErrorDeviceLost:
-		sc.markBroken(hal.ErrDeviceLost)
-		return nil, false, hal.ErrDeviceLost
-	default:
-		err := mapVulkanResult("vkAcquireNextImageKHR", result)
-		sc.markBroken(err)
+	if acquired, err := sc.handleAcquireResult(result); !acquired {
 		return nil, false, err
 	}
 
@@ -1067,6 +1159,14 @@ func (sc *Swapchain) acquireNextImage() (*SwapchainTexture, bool, error) {
 		return nil, false, err
 	}
 
+	// VK_EXT_swapchain_maintenance1: the present semaphore for this image is
+	// signaled again by the coming Submit, so the previous present that waited
+	// on it must be finished. Usually the fence is long signaled by now.
+	if err := sc.waitPresentFence(imageIndex, timeout); err != nil {
+		sc.markBroken(fmt.Errorf("vulkan: wait for present fence %d: %w", imageIndex, err))
+		return nil, false, sc.failureErr
+	}
+
 	// Store the current acquire index and semaphore for use in Submit.
 	sc.currentAcquireIdx = acquireIdx
 	sc.currentAcquireSem = acquireSem
@@ -1082,7 +1182,42 @@ func (sc *Swapchain) acquireNextImage() (*SwapchainTexture, bool, error) {
 	// can insert a barrier if no render pass transitions to PRESENT_SRC_KHR.
 	sc.imageLayouts[imageIndex] = vk.ImageLayoutUndefined
 
-	return sc.surfaceTextures[imageIndex], policy.reportSuboptimal(result == vk.SuboptimalKhr), nil
+	suboptimal := policy.reportSuboptimal(result == vk.SuboptimalKhr, sc.preRotated) || sc.presentSuboptimal
+	return sc.surfaceTextures[imageIndex], suboptimal, nil
+}
+
+// handleAcquireResult classifies a vkAcquireNextImageKHR result. acquired is
+// false when no image was acquired; err is then nil for a skipped frame.
+//
+// SUBOPTIMAL still delivers a usable image: rendering continues and the
+// caller sees AcquiredSurfaceTexture.Suboptimal. Only OUT_OF_DATE makes the
+// swapchain unusable until it is reconfigured.
+func (sc *Swapchain) handleAcquireResult(result vk.Result) (acquired bool, err error) {
+	switch result {
+	case vk.Success, vk.SuboptimalKhr:
+		return true, nil
+	case vk.Timeout, vk.NotReady:
+		// No image within the timeout (NOT_READY is the zero-timeout form):
+		// skip the frame. The semaphore and fence stay unsignaled, so the
+		// swapchain remains usable. (wgpu: returns Ok(None))
+		return false, nil
+	case vk.ErrorOutOfDateKhr:
+		// (wgpu: returns Err(Outdated))
+		sc.markBroken(hal.ErrSurfaceOutdated)
+		return false, hal.ErrSurfaceOutdated
+	case vk.ErrorSurfaceLostKhr:
+		// Surface destroyed (e.g., Wayland compositor killed it).
+		// (wgpu: returns Err(Lost))
+		sc.markBroken(hal.ErrSurfaceLost)
+		return false, hal.ErrSurfaceLost
+	case vk.ErrorDeviceLost:
+		sc.markBroken(hal.ErrDeviceLost)
+		return false, hal.ErrDeviceLost
+	default:
+		err := mapVulkanResult("vkAcquireNextImageKHR", result)
+		sc.markBroken(err)
+		return false, err
+	}
 }
 
 // present presents the current image to the screen.
@@ -1127,7 +1262,7 @@ func (sc *Swapchain) present(queue *Queue, damageRects []image.Rectangle) error
 	// this inserts an explicit pipeline barrier to transition the layout.
 	if err := sc.ensurePresentLayout(queue); err != nil {
 		sc.markBroken(fmt.Errorf("vulkan: present layout transition failed: %w", err))
-		hal.Logger().Error("vulkan: present layout transition failed",
+		sc.device.logger(hal.LogSwapchain).Error("vulkan: present layout transition failed",
 			"err", err, "imageIndex", sc.currentImage)
 		// The image's semaphore/layout state is no longer safe to reuse. A
 		// reconfigure or destroy must drain the device before cleanup.
@@ -1171,15 +1306,46 @@ func (sc *Swapchain) present(queue *Queue, damageRects []image.Rectangle) error
 		presentInfo.PNext = (*uintptr)(unsafe.Pointer(&presentRegions))
 	}
 
+	// VK_EXT_swapchain_maintenance1: request a fence signaled when this
+	// present's semaphore wait completes. Prepended so any damage regions
+	// chained above stay reachable.
+	var presentFenceInfo vk.SwapchainPresentFenceInfoEXT
+	presentFenceUsed := false
+	if int(sc.currentImage) < len(sc.presentFences) && sc.presentFences[sc.currentImage] != 0 {
+		presentFenceInfo = vk.SwapchainPresentFenceInfoEXT{
+			SType:          vk.StructureTypeSwapchainPresentFenceInfoExt,
+			PNext:          presentInfo.PNext,
+			SwapchainCount: 1,
+			PFences:        &sc.presentFences[sc.currentImage],
+		}
+		presentInfo.PNext = (*uintptr)(unsafe.Pointer(&presentFenceInfo))
+		presentFenceUsed = true
+	}
+
 	result := vkQueuePresentKHR(queue, &presentInfo)
 	sc.imageAcquired = false
+	// The fence is signaled for any result except device loss, so track it as
+	// pending unconditionally; teardown only logs if it never signals.
+	if presentFenceUsed {
+		sc.presentFencePending[sc.currentImage] = true
+	}
 
+	return sc.handlePresentResult(result)
+}
+
+// handlePresentResult classifies a vkQueuePresentKHR result. SUBOPTIMAL
+// presents succeed; the swapchain remembers it so every later acquire reports
+// Suboptimal until the surface is reconfigured.
+func (sc *Swapchain) handlePresentResult(result vk.Result) error {
 	switch result {
 	case vk.Success:
 		return nil
 	case vk.SuboptimalKhr:
-		if swapchainPolicyForSurface(sc.surface).reportSuboptimal(true) {
-			hal.Logger().Debug("vulkan: suboptimal swapchain present", "imageIndex", sc.currentImage)
+		if swapchainPolicyForSurface(sc.surface).reportSuboptimal(true, sc.preRotated) {
+			if !sc.presentSuboptimal {
+				sc.device.logger(hal.LogSwapchain).Debug("vulkan: suboptimal swapchain present", "imageIndex", sc.currentImage)
+			}
+			sc.presentSuboptimal = true
 		}
 		return nil
 	case vk.ErrorOutOfDateKhr:
@@ -1401,7 +1567,7 @@ func (sc *Swapchain) ensurePresentLayout(queue *Queue) error {
 	// buffer was safely recycled.
 	sc.imageLayouts[idx] = vk.ImageLayoutPresentSrcKhr
 
-	hal.Logger().Debug("vulkan: inserted PRESENT_SRC_KHR barrier",
+	sc.device.logger(hal.LogBarrier).Debug("vulkan: inserted PRESENT_SRC_KHR barrier",
 		"imageIndex", idx, "oldLayout", currentLayout)
 
 	return nil
@@ -361,3 +361,76 @@ func TestSwapchainLifecycleGuardsFailClosed(t *testing.T) {
 	}
 	withoutDevice.Destroy()
 }
+
+func TestAcquireSemaphoreRingHasSpareSlot(t *testing.T) {
+	for _, images := range []int{2, 3, 4} {
+		if got := acquireSemaphoreCount(images); got != images+1 {
+			t.Fatalf("acquireSemaphoreCount(%d) = %d, want %d", images, got, images+1)
+		}
+	}
+}
+
+func TestWaitPresentFenceWithoutMaintenance1IsNoop(t *testing.T) {
+	sc := &Swapchain{}
+	if err := sc.waitPresentFence(0, 0); err != nil {
+		t.Fatalf("waitPresentFence() without present fences = %v, want nil", err)
+	}
+
+	sc.presentFences = []vk.Fence{1, 2}
+	sc.presentFencePending = []bool{false, false}
+	if err := sc.waitPresentFence(1, 0); err != nil {
+		t.Fatalf("waitPresentFence() with nothing pending = %v, want nil", err)
+	}
+}
+
+func TestAcquireResultSkipsFrameWithoutBreaking(t *testing.T) {
+	for _, result := range []vk.Result{vk.Timeout, vk.NotReady} {
+		sc := &Swapchain{}
+		acquired, err := sc.handleAcquireResult(result)
+		if acquired || err != nil {
+			t.Errorf("handleAcquireResult(%d) = (%v, %v), want (false, nil)", result, acquired, err)
+		}
+		if sc.broken {
+			t.Errorf("handleAcquireResult(%d) broke the swapchain", result)
+		}
+	}
+
+	sc := &Swapchain{}
+	if acquired, err := sc.handleAcquireResult(vk.SuboptimalKhr); !acquired || err != nil {
+		t.Errorf("handleAcquireResult(SUBOPTIMAL) = (%v, %v), want (true, nil)", acquired, err)
+	}
+	if sc.broken {
+		t.Error("SUBOPTIMAL acquire broke the swapchain")
+	}
+}
+
+func TestAcquireResultOutOfDateBreaksSwapchain(t *testing.T) {
+	sc := &Swapchain{}
+	acquired, err := sc.handleAcquireResult(vk.ErrorOutOfDateKhr)
+	if acquired || !errors.Is(err, hal.ErrSurfaceOutdated) {
+		t.Fatalf("handleAcquireResult(OUT_OF_DATE) = (%v, %v), want ErrSurfaceOutdated", acquired, err)
+	}
+	if !sc.broken {
+		t.Error("OUT_OF_DATE acquire left the swapchain usable")
+	}
+}
+
+func TestPresentResultSuboptimalKeepsSwapchain(t *testing.T) {
+	sc := &Swapchain{}
+	if err := sc.handlePresentResult(vk.SuboptimalKhr); err != nil {
+		t.Fatalf("handlePresentResult(SUBOPTIMAL) = %v, want nil", err)
+	}
+	if sc.broken {
+		t.Error("SUBOPTIMAL present broke the swapchain")
+	}
+	if !sc.presentSuboptimal {
+		t.Error("SUBOPTIMAL present was not remembered for the next acquire")
+	}
+
+	if err := sc.handlePresentResult(vk.ErrorOutOfDateKhr); !errors.Is(err, hal.ErrSurfaceOutdated) {
+		t.Errorf("handlePresentResult(OUT_OF_DATE) = %v, want ErrSurfaceOutdated", err)
+	}
+	if !sc.broken {
+		t.Error("OUT_OF_DATE present left the swapchain usable")
+	}
+}
@@ -36,7 +36,19 @@ func (p swapchainPlatformPolicy) acquireTimeout(requested uint64) uint64 {
 	return requested
 }
 
-func (p swapchainPlatformPolicy) preTransform(capabilities vk.SurfaceCapabilitiesKHR) (vk.SurfaceTransformFlagBitsKHR, error) {
+// preTransform selects the swapchain pre-transform. A requested transform
+// means the application pre-rotates its rendering and is used as is on every
+// platform; otherwise the platform default applies.
+func (p swapchainPlatformPolicy) preTransform(capabilities vk.SurfaceCapabilitiesKHR, requested hal.SurfaceTransform) (vk.SurfaceTransformFlagBitsKHR, error) {
+	if requested != 0 {
+		if requested&(requested-1) != 0 || requested&surfaceTransformMask != requested {
+			return 0, fmt.Errorf("vulkan: pre-transform %#x is not a single surface transform", uint32(requested))
+		}
+		if vkSurfaceTransformToHAL(vk.Flags(capabilities.SupportedTransforms))&requested == 0 {
+			return 0, fmt.Errorf("vulkan: surface does not support pre-transform %#x", uint32(requested))
+		}
+		return vk.SurfaceTransformFlagBitsKHR(requested), nil
+	}
 	transform := capabilities.CurrentTransform
 	if p.android {
 		// Rust wgpu v29 leaves pre-rotation to the compositor on Android.
@@ -54,10 +66,32 @@ func (p swapchainPlatformPolicy) preTransform(capabilities vk.SurfaceCapabilitie
 	return transform, nil
 }
 
-func (p swapchainPlatformPolicy) reportSuboptimal(suboptimal bool) bool {
+func (p swapchainPlatformPolicy) reportSuboptimal(suboptimal, preRotated bool) bool {
 	// Android reports SUBOPTIMAL when identity pre-transform differs from the
 	// current orientation. Rust wgpu v29 intentionally treats that as success.
-	return suboptimal && !p.android
+	// A pre-rotating application needs it to reconfigure with the new
+	// transform after an orientation change.
+	return suboptimal && (!p.android || preRotated)
+}
+
+// surfaceTransformMask covers the transforms a swapchain can pre-apply;
+// VK_SURFACE_TRANSFORM_INHERIT_BIT_KHR is left to the platform.
+const surfaceTransformMask = hal.SurfaceTransformIdentity | hal.SurfaceTransformRotate90 |
+	hal.SurfaceTransformRotate180 | hal.SurfaceTransformRotate270 |
+	hal.SurfaceTransformHorizontalMirror | hal.SurfaceTransformHorizontalMirrorRotate90 |
+	hal.SurfaceTransformHorizontalMirrorRotate180 | hal.SurfaceTransformHorizontalMirrorRotate270
+
+// vkSurfaceTransformToHAL converts VkSurfaceTransformFlagsKHR. The HAL bits
+// match Vulkan's, so this only drops the inherit bit.
+func vkSurfaceTransformToHAL(flags vk.Flags) hal.SurfaceTransform {
+	return hal.SurfaceTransform(flags) & surfaceTransformMask
+}
+
+// swapsDimensions reports whether a transform turns the image a quarter turn.
+func swapsDimensions(transform vk.SurfaceTransformFlagBitsKHR) bool {
+	const quarterTurns = vk.SurfaceTransformRotate90BitKhr | vk.SurfaceTransformRotate270BitKhr |
+		vk.SurfaceTransformHorizontalMirrorRotate90BitKhr | vk.SurfaceTransformHorizontalMirrorRotate270BitKhr
+	return transform&quarterTurns != 0
 }
 
 func swapchainPolicyForSurface(surface *Surface) swapchainPlatformPolicy {
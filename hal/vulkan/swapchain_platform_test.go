@@ -42,7 +42,7 @@ func TestSwapchainPlatformPolicyTransform(t *testing.T) {
 			vk.SurfaceTransformIdentityBitKhr | vk.SurfaceTransformRotate90BitKhr,
 		),
 	}
-	desktop, err := defaultSwapchainPlatformPolicy().preTransform(capabilities)
+	desktop, err := defaultSwapchainPlatformPolicy().preTransform(capabilities, 0)
 	if err != nil {
 		t.Fatalf("desktop preTransform() error: %v", err)
 	}
@@ -50,7 +50,7 @@ func TestSwapchainPlatformPolicyTransform(t *testing.T) {
 		t.Fatalf("desktop transform = %v, want current transform", desktop)
 	}
 
-	android, err := androidSwapchainPlatformPolicy(29).preTransform(capabilities)
+	android, err := androidSwapchainPlatformPolicy(29).preTransform(capabilities, 0)
 	if err != nil {
 		t.Fatalf("Android preTransform() error: %v", err)
 	}
@@ -59,18 +59,51 @@ func TestSwapchainPlatformPolicyTransform(t *testing.T) {
 	}
 
 	capabilities.SupportedTransforms = vk.SurfaceTransformFlagsKHR(vk.SurfaceTransformRotate90BitKhr)
-	if _, err := androidSwapchainPlatformPolicy(29).preTransform(capabilities); err == nil {
+	if _, err := androidSwapchainPlatformPolicy(29).preTransform(capabilities, 0); err == nil {
 		t.Fatal("Android identity transform was accepted when unsupported")
 	}
 }
 
 func TestSwapchainPlatformPolicySuboptimal(t *testing.T) {
-	if !defaultSwapchainPlatformPolicy().reportSuboptimal(true) {
+	if !defaultSwapchainPlatformPolicy().reportSuboptimal(true, false) {
 		t.Fatal("desktop suboptimal result was suppressed")
 	}
-	if androidSwapchainPlatformPolicy(29).reportSuboptimal(true) {
+	if androidSwapchainPlatformPolicy(29).reportSuboptimal(true, false) {
 		t.Fatal("Android suboptimal result was reported")
 	}
+	if !androidSwapchainPlatformPolicy(29).reportSuboptimal(true, true) {
+		t.Fatal("Android suboptimal result was suppressed for a pre-rotated swapchain")
+	}
+}
+
+func TestSwapchainPlatformPolicyRequestedTransform(t *testing.T) {
+	capabilities := vk.SurfaceCapabilitiesKHR{
+		CurrentTransform: vk.SurfaceTransformRotate90BitKhr,
+		SupportedTransforms: vk.SurfaceTransformFlagsKHR(
+			vk.SurfaceTransformIdentityBitKhr | vk.SurfaceTransformRotate90BitKhr | vk.SurfaceTransformInheritBitKhr,
+		),
+	}
+	got, err := androidSwapchainPlatformPolicy(33).preTransform(capabilities, hal.SurfaceTransformRotate90)
+	if err != nil {
+		t.Fatalf("Android requested preTransform() error: %v", err)
+	}
+	if got != vk.SurfaceTransformRotate90BitKhr || !swapsDimensions(got) {
+		t.Fatalf("Android requested transform = %v, want rotate 90", got)
+	}
+
+	for _, requested := range []hal.SurfaceTransform{
+		hal.SurfaceTransformRotate180,
+		hal.SurfaceTransformIdentity | hal.SurfaceTransformRotate90,
+		hal.SurfaceTransform(vk.SurfaceTransformInheritBitKhr),
+	} {
+		if _, err := defaultSwapchainPlatformPolicy().preTransform(capabilities, requested); err == nil {
+			t.Errorf("preTransform(%#x) was accepted", uint32(requested))
+		}
+	}
+
+	if got := vkSurfaceTransformToHAL(vk.Flags(capabilities.SupportedTransforms)); got != hal.SurfaceTransformIdentity|hal.SurfaceTransformRotate90 {
+		t.Errorf("supported transforms = %#x, want identity|rotate90 without inherit", uint32(got))
+	}
 }
 
 func TestMapVulkanResultPreservesRecoverableErrors(t *testing.T) {
@@ -0,0 +1,78 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vulkan
+
+import (
+	"strings"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+const (
+	validationLayerName         = "VK_LAYER_KHRONOS_validation"
+	extensionValidationFeatures = "VK_EXT_validation_features"
+)
+
+// validationFlags returns the instance flags that decide Vulkan validation:
+// desc.Flags plus those implied by hal.VulkanOptions. Each of the options'
+// validation toggles implies InstanceFlagsDebug, which loads the validation
+// layer.
+func validationFlags(desc *hal.InstanceDescriptor) gputypes.InstanceFlags {
+	if desc == nil {
+		return 0
+	}
+	flags := desc.Flags
+	opts := desc.BackendOptions.Vulkan
+	if opts.SynchronizationValidation {
+		flags |= gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsValidation
+	}
+	if opts.GPUAssistedValidation {
+		flags |= gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsGPUBasedValidation
+	}
+	return flags
+}
+
+// validationFeatureEnables returns the VK_EXT_validation_features checks to
+// enable on top of the validation layer's defaults: synchronization
+// validation for InstanceFlagsValidation and GPU-assisted validation for
+// InstanceFlagsGPUBasedValidation. Without InstanceFlagsDebug there is no
+// layer to configure and the result is nil.
+func validationFeatureEnables(flags gputypes.InstanceFlags) []vk.ValidationFeatureEnableEXT {
+	if flags&gputypes.InstanceFlagsDebug == 0 {
+		return nil
+	}
+	var enables []vk.ValidationFeatureEnableEXT
+	if flags&gputypes.InstanceFlagsValidation != 0 {
+		enables = append(enables, vk.ValidationFeatureEnableSynchronizationValidationExt)
+	}
+	if flags&gputypes.InstanceFlagsGPUBasedValidation != 0 {
+		// The reserved slot keeps the layer's instrumentation descriptor set
+		// from colliding with the highest set a pipeline layout uses.
+		enables = append(enables,
+			vk.ValidationFeatureEnableGpuAssistedExt,
+			vk.ValidationFeatureEnableGpuAssistedReserveBindingSlotExt)
+	}
+	return enables
+}
+
+// debugMessageType names the kind of a debug messenger message for the
+// log's "type" attribute. Synchronization hazards are validation messages
+// whose ID starts with "SYNC-"; they get their own type so barrier bugs
+// stand out from other validation errors.
+func debugMessageType(typeBits vk.DebugUtilsMessageTypeFlagBitsEXT, msgID string) string {
+	switch {
+	case typeBits&vk.DebugUtilsMessageTypeValidationBitExt != 0 && strings.HasPrefix(msgID, "SYNC-"):
+		return "Synchronization"
+	case typeBits&vk.DebugUtilsMessageTypeValidationBitExt != 0:
+		return "Validation"
+	case typeBits&vk.DebugUtilsMessageTypePerformanceBitExt != 0:
+		return "Performance"
+	default:
+		return "General"
+	}
+}
@@ -0,0 +1,81 @@
+//go:build !(js && wasm)
+
+package vulkan
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/vulkan/vk"
+)
+
+func TestValidationFlags(t *testing.T) {
+	if got := validationFlags(nil); got != 0 {
+		t.Errorf("validationFlags(nil) = %v, want 0", got)
+	}
+
+	desc := &hal.InstanceDescriptor{}
+	desc.BackendOptions.Vulkan.SynchronizationValidation = true
+	want := gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsValidation
+	if got := validationFlags(desc); got != want {
+		t.Errorf("SynchronizationValidation: flags = %v, want %v", got, want)
+	}
+
+	desc = &hal.InstanceDescriptor{Flags: gputypes.InstanceFlagsDiscardHalLabels}
+	desc.BackendOptions.Vulkan.GPUAssistedValidation = true
+	want = gputypes.InstanceFlagsDiscardHalLabels | gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsGPUBasedValidation
+	if got := validationFlags(desc); got != want {
+		t.Errorf("GPUAssistedValidation: flags = %v, want %v", got, want)
+	}
+}
+
+func TestValidationFeatureEnables(t *testing.T) {
+	sync := vk.ValidationFeatureEnableSynchronizationValidationExt
+	gpuAV := []vk.ValidationFeatureEnableEXT{
+		vk.ValidationFeatureEnableGpuAssistedExt,
+		vk.ValidationFeatureEnableGpuAssistedReserveBindingSlotExt,
+	}
+
+	tests := []struct {
+		name  string
+		flags gputypes.InstanceFlags
+		want  []vk.ValidationFeatureEnableEXT
+	}{
+		{"debug only", gputypes.InstanceFlagsDebug, nil},
+		{"no layer", gputypes.InstanceFlagsValidation | gputypes.InstanceFlagsGPUBasedValidation, nil},
+		{"sync", gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsValidation, []vk.ValidationFeatureEnableEXT{sync}},
+		{"gpu assisted", gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsGPUBasedValidation, gpuAV},
+		{"both", gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsValidation | gputypes.InstanceFlagsGPUBasedValidation,
+			append([]vk.ValidationFeatureEnableEXT{sync}, gpuAV...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validationFeatureEnables(tt.flags); !slices.Equal(got, tt.want) {
+				t.Errorf("validationFeatureEnables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDebugMessageType(t *testing.T) {
+	validation := vk.DebugUtilsMessageTypeFlagBitsEXT(vk.DebugUtilsMessageTypeValidationBitExt)
+	performance := vk.DebugUtilsMessageTypeFlagBitsEXT(vk.DebugUtilsMessageTypePerformanceBitExt)
+
+	tests := []struct {
+		typeBits vk.DebugUtilsMessageTypeFlagBitsEXT
+		msgID    string
+		want     string
+	}{
+		{validation, "SYNC-HAZARD-WRITE-AFTER-READ", "Synchronization"},
+		{validation, "VUID-vkCmdDraw-None-02699", "Validation"},
+		{performance, "SYNC-HAZARD-WRITE-AFTER-READ", "Performance"},
+		{0, "", "General"},
+	}
+	for _, tt := range tests {
+		if got := debugMessageType(tt.typeBits, tt.msgID); got != tt.want {
+			t.Errorf("debugMessageType(%v, %q) = %q, want %q", tt.typeBits, tt.msgID, got, tt.want)
+		}
+	}
+}
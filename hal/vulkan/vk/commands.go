@@ -110,6 +110,12 @@ func (c *Commands) LoadInstance(instance Instance) error {
 	// Vulkan 1.1+ instance functions
 	c.getPhysicalDeviceFeatures2 = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceFeatures2")
 	c.getPhysicalDeviceProperties2 = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceProperties2")
+	c.getPhysicalDeviceImageFormatProperties2 = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceImageFormatProperties2")
+	c.getPhysicalDeviceMemoryProperties2 = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceMemoryProperties2")
+	c.getPhysicalDeviceExternalSemaphoreProperties = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceExternalSemaphoreProperties")
+
+	// VK_KHR_get_surface_capabilities2 (nil unless the extension is enabled)
+	c.getPhysicalDeviceSurfaceCapabilities2KHR = GetInstanceProcAddr(instance, "vkGetPhysicalDeviceSurfaceCapabilities2KHR")
 
 	// VK_EXT_debug_utils (instance extension — MUST use GetInstanceProcAddr).
 	// Loading via GetDeviceProcAddr bypasses the validation layer's handle
@@ -269,11 +275,36 @@ func (c *Commands) LoadDevice(device Device) error {
 	c.cmdEndRenderPass = GetDeviceProcAddr(device, "vkCmdEndRenderPass")
 	c.cmdExecuteCommands = GetDeviceProcAddr(device, "vkCmdExecuteCommands")
 
+	// Vulkan 1.1+ protected queue retrieval
+	c.getDeviceQueue2 = GetDeviceProcAddr(device, "vkGetDeviceQueue2")
+
+	// Vulkan 1.1+ batched memory binding
+	c.bindBufferMemory2 = GetDeviceProcAddr(device, "vkBindBufferMemory2")
+	c.bindImageMemory2 = GetDeviceProcAddr(device, "vkBindImageMemory2")
+
 	// Vulkan 1.2+ timeline semaphore functions
 	c.getSemaphoreCounterValue = GetDeviceProcAddr(device, "vkGetSemaphoreCounterValue")
 	c.waitSemaphores = GetDeviceProcAddr(device, "vkWaitSemaphores")
 	c.signalSemaphore = GetDeviceProcAddr(device, "vkSignalSemaphore")
 
+	// VK_EXT_conditional_rendering (nil unless the extension is enabled)
+	c.cmdBeginConditionalRenderingEXT = GetDeviceProcAddr(device, "vkCmdBeginConditionalRenderingEXT")
+	c.cmdEndConditionalRenderingEXT = GetDeviceProcAddr(device, "vkCmdEndConditionalRenderingEXT")
+
+	// VK_KHR_external_semaphore_fd / _win32 (nil unless the extension is enabled)
+	c.getSemaphoreFdKHR = GetDeviceProcAddr(device, "vkGetSemaphoreFdKHR")
+	c.importSemaphoreFdKHR = GetDeviceProcAddr(device, "vkImportSemaphoreFdKHR")
+	c.getSemaphoreWin32HandleKHR = GetDeviceProcAddr(device, "vkGetSemaphoreWin32HandleKHR")
+	c.importSemaphoreWin32HandleKHR = GetDeviceProcAddr(device, "vkImportSemaphoreWin32HandleKHR")
+
+	// VK_KHR_synchronization2 (nil unless the extension is enabled)
+	c.cmdPipelineBarrier2 = GetDeviceProcAddr(device, "vkCmdPipelineBarrier2KHR")
+	c.queueSubmit2 = GetDeviceProcAddr(device, "vkQueueSubmit2KHR")
+
+	// VK_EXT_host_image_copy (nil unless the extension is enabled)
+	c.copyMemoryToImage = GetDeviceProcAddr(device, "vkCopyMemoryToImageEXT")
+	c.transitionImageLayout = GetDeviceProcAddr(device, "vkTransitionImageLayoutEXT")
+
 	// Swapchain functions (WSI)
 	c.createSwapchainKHR = GetDeviceProcAddr(device, "vkCreateSwapchainKHR")
 	c.destroySwapchainKHR = GetDeviceProcAddr(device, "vkDestroySwapchainKHR")
@@ -297,6 +328,68 @@ func (c *Commands) HasTimelineSemaphore() bool {
 		c.signalSemaphore != nil
 }
 
+// HasSynchronization2 returns true if vkCmdPipelineBarrier2KHR and
+// vkQueueSubmit2KHR were loaded.
+func (c *Commands) HasSynchronization2() bool {
+	return c.cmdPipelineBarrier2 != nil && c.queueSubmit2 != nil
+}
+
+// HasExternalSemaphoreFd returns true if vkGetSemaphoreFdKHR and
+// vkImportSemaphoreFdKHR were loaded.
+func (c *Commands) HasExternalSemaphoreFd() bool {
+	return c.getSemaphoreFdKHR != nil && c.importSemaphoreFdKHR != nil
+}
+
+// HasExternalSemaphoreWin32 returns true if vkGetSemaphoreWin32HandleKHR
+// and vkImportSemaphoreWin32HandleKHR were loaded.
+func (c *Commands) HasExternalSemaphoreWin32() bool {
+	return c.getSemaphoreWin32HandleKHR != nil && c.importSemaphoreWin32HandleKHR != nil
+}
+
+// HasPhysicalDeviceExternalSemaphoreProperties returns true if
+// vkGetPhysicalDeviceExternalSemaphoreProperties is available (Vulkan 1.1
+// core).
+func (c *Commands) HasPhysicalDeviceExternalSemaphoreProperties() bool {
+	return c.getPhysicalDeviceExternalSemaphoreProperties != nil
+}
+
+// HasHostImageCopy returns true if vkCopyMemoryToImageEXT and
+// vkTransitionImageLayoutEXT were loaded.
+func (c *Commands) HasHostImageCopy() bool {
+	return c.copyMemoryToImage != nil && c.transitionImageLayout != nil
+}
+
+// HasGetDeviceQueue2 returns true if vkGetDeviceQueue2 is available
+// (Vulkan 1.1 core). Protected queues can only be retrieved through it.
+func (c *Commands) HasGetDeviceQueue2() bool {
+	return c.getDeviceQueue2 != nil
+}
+
+// HasBindMemory2 returns true if vkBindBufferMemory2 and vkBindImageMemory2
+// are available (Vulkan 1.1 core).
+func (c *Commands) HasBindMemory2() bool {
+	return c.bindBufferMemory2 != nil && c.bindImageMemory2 != nil
+}
+
+// HasSurfaceCapabilities2 returns true if
+// vkGetPhysicalDeviceSurfaceCapabilities2KHR was loaded
+// (VK_KHR_get_surface_capabilities2).
+func (c *Commands) HasSurfaceCapabilities2() bool {
+	return c.getPhysicalDeviceSurfaceCapabilities2KHR != nil
+}
+
+// HasPhysicalDeviceImageFormatProperties2 returns true if
+// vkGetPhysicalDeviceImageFormatProperties2 is available (Vulkan 1.1 core).
+func (c *Commands) HasPhysicalDeviceImageFormatProperties2() bool {
+	return c.getPhysicalDeviceImageFormatProperties2 != nil
+}
+
+// HasPhysicalDeviceMemoryProperties2 returns true if
+// vkGetPhysicalDeviceMemoryProperties2 is available (Vulkan 1.1 core).
+func (c *Commands) HasPhysicalDeviceMemoryProperties2() bool {
+	return c.getPhysicalDeviceMemoryProperties2 != nil
+}
+
 // HasPhysicalDeviceFeatures2 returns true if vkGetPhysicalDeviceFeatures2 is available.
 // This is a Vulkan 1.1 core function used to query extended feature support via PNext chains.
 func (c *Commands) HasPhysicalDeviceFeatures2() bool {
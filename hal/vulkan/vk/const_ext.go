@@ -21,10 +21,96 @@ const (
 	// StructureTypePhysicalDeviceProperties2 = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROPERTIES_2
 	StructureTypePhysicalDeviceProperties2 StructureType = 1000059001
 
+	// StructureTypePhysicalDeviceMemoryProperties2 = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MEMORY_PROPERTIES_2
+	StructureTypePhysicalDeviceMemoryProperties2 StructureType = 1000059006
+
+	// StructureTypeImageFormatProperties2 = VK_STRUCTURE_TYPE_IMAGE_FORMAT_PROPERTIES_2
+	StructureTypeImageFormatProperties2 StructureType = 1000059003
+
+	// StructureTypePhysicalDeviceImageFormatInfo2 = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_IMAGE_FORMAT_INFO_2
+	StructureTypePhysicalDeviceImageFormatInfo2 StructureType = 1000059004
+
 	// StructureTypePhysicalDeviceMaintenance3Properties = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MAINTENANCE_3_PROPERTIES
 	// Vulkan 1.1 core — used to query maxMemoryAllocationSize.
 	StructureTypePhysicalDeviceMaintenance3Properties StructureType = 1000168000
 
+	// StructureTypePhysicalDeviceSubgroupProperties = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SUBGROUP_PROPERTIES
+	StructureTypePhysicalDeviceSubgroupProperties StructureType = 1000094000
+
+	// StructureTypePhysicalDeviceIDProperties = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_ID_PROPERTIES
+	StructureTypePhysicalDeviceIDProperties StructureType = 1000071004
+
+	// StructureTypeRenderPassMultiviewCreateInfo = VK_STRUCTURE_TYPE_RENDER_PASS_MULTIVIEW_CREATE_INFO
+	StructureTypeRenderPassMultiviewCreateInfo StructureType = 1000053000
+
+	// StructureTypePhysicalDeviceMultiviewFeatures = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MULTIVIEW_FEATURES
+	StructureTypePhysicalDeviceMultiviewFeatures StructureType = 1000053001
+
+	// StructureTypePhysicalDeviceMultiviewProperties = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_MULTIVIEW_PROPERTIES
+	StructureTypePhysicalDeviceMultiviewProperties StructureType = 1000053002
+
+	// === Vulkan 1.1 Core (protected memory) ===
+
+	// StructureTypeProtectedSubmitInfo = VK_STRUCTURE_TYPE_PROTECTED_SUBMIT_INFO
+	StructureTypeProtectedSubmitInfo StructureType = 1000145000
+
+	// StructureTypePhysicalDeviceProtectedMemoryFeatures = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_PROTECTED_MEMORY_FEATURES
+	StructureTypePhysicalDeviceProtectedMemoryFeatures StructureType = 1000145001
+
+	// StructureTypeDeviceQueueInfo2 = VK_STRUCTURE_TYPE_DEVICE_QUEUE_INFO_2
+	StructureTypeDeviceQueueInfo2 StructureType = 1000145003
+
+	// QueueProtectedBit = VK_QUEUE_PROTECTED_BIT
+	QueueProtectedBit QueueFlagBits = 1 << 4
+
+	// DeviceQueueCreateProtectedBit = VK_DEVICE_QUEUE_CREATE_PROTECTED_BIT
+	DeviceQueueCreateProtectedBit DeviceQueueCreateFlagBits = 1 << 0
+
+	// MemoryPropertyProtectedBit = VK_MEMORY_PROPERTY_PROTECTED_BIT
+	MemoryPropertyProtectedBit MemoryPropertyFlagBits = 1 << 5
+
+	// ImageCreateProtectedBit = VK_IMAGE_CREATE_PROTECTED_BIT
+	ImageCreateProtectedBit ImageCreateFlagBits = 1 << 11
+
+	// CommandPoolCreateProtectedBit = VK_COMMAND_POOL_CREATE_PROTECTED_BIT
+	CommandPoolCreateProtectedBit CommandPoolCreateFlagBits = 1 << 2
+
+	// === Vulkan 1.1 Core (promoted from VK_KHR_sampler_ycbcr_conversion) ===
+
+	// StructureTypePhysicalDeviceSamplerYcbcrConversionFeatures = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SAMPLER_YCBCR_CONVERSION_FEATURES
+	StructureTypePhysicalDeviceSamplerYcbcrConversionFeatures StructureType = 1000156004
+
+	// FormatG8B8r82plane420Unorm = VK_FORMAT_G8_B8R8_2PLANE_420_UNORM (NV12)
+	FormatG8B8r82plane420Unorm Format = 1000156003
+
+	// FormatG10x6B10x6r10x62plane420Unorm3pack16 = VK_FORMAT_G10X6_B10X6R10X6_2PLANE_420_UNORM_3PACK16 (P010)
+	FormatG10x6B10x6r10x62plane420Unorm3pack16 Format = 1000156020
+
+	// ImageAspectPlane0Bit = VK_IMAGE_ASPECT_PLANE_0_BIT
+	ImageAspectPlane0Bit ImageAspectFlagBits = 1 << 4
+
+	// ImageAspectPlane1Bit = VK_IMAGE_ASPECT_PLANE_1_BIT
+	ImageAspectPlane1Bit ImageAspectFlagBits = 1 << 5
+
+	// === Vulkan 1.1 Core (promoted from VK_KHR_external_semaphore) ===
+
+	// StructureTypePhysicalDeviceExternalSemaphoreInfo = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_EXTERNAL_SEMAPHORE_INFO
+	StructureTypePhysicalDeviceExternalSemaphoreInfo StructureType = 1000076000
+
+	// StructureTypeExternalSemaphoreProperties = VK_STRUCTURE_TYPE_EXTERNAL_SEMAPHORE_PROPERTIES
+	StructureTypeExternalSemaphoreProperties StructureType = 1000076001
+
+	// StructureTypeExportSemaphoreCreateInfo = VK_STRUCTURE_TYPE_EXPORT_SEMAPHORE_CREATE_INFO
+	StructureTypeExportSemaphoreCreateInfo StructureType = 1000077000
+
+	// === Vulkan 1.1 Core (promoted from VK_KHR_maintenance2) ===
+
+	// ImageLayoutDepthReadOnlyStencilAttachmentOptimal = VK_IMAGE_LAYOUT_DEPTH_READ_ONLY_STENCIL_ATTACHMENT_OPTIMAL
+	ImageLayoutDepthReadOnlyStencilAttachmentOptimal ImageLayout = 1000117000
+
+	// ImageLayoutDepthAttachmentStencilReadOnlyOptimal = VK_IMAGE_LAYOUT_DEPTH_ATTACHMENT_STENCIL_READ_ONLY_OPTIMAL
+	ImageLayoutDepthAttachmentStencilReadOnlyOptimal ImageLayout = 1000117001
+
 	// === Vulkan 1.2 Core (promoted from VK_KHR_timeline_semaphore) ===
 
 	// StructureTypePhysicalDeviceTimelineSemaphoreFeatures = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_TIMELINE_SEMAPHORE_FEATURES
@@ -45,6 +131,28 @@ const (
 	// StructureTypePhysicalDeviceVulkan12Features = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_VULKAN_1_2_FEATURES
 	StructureTypePhysicalDeviceVulkan12Features StructureType = 51
 
+	// === Vulkan 1.2 Core (promoted from VK_KHR_image_format_list) ===
+
+	// StructureTypeImageFormatListCreateInfo = VK_STRUCTURE_TYPE_IMAGE_FORMAT_LIST_CREATE_INFO
+	StructureTypeImageFormatListCreateInfo StructureType = 1000147000
+
+	// === Vulkan 1.2 Core (promoted from VK_KHR_separate_depth_stencil_layouts) ===
+
+	// StructureTypePhysicalDeviceSeparateDepthStencilLayoutsFeatures = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SEPARATE_DEPTH_STENCIL_LAYOUTS_FEATURES
+	StructureTypePhysicalDeviceSeparateDepthStencilLayoutsFeatures StructureType = 1000241000
+
+	// ImageLayoutDepthAttachmentOptimal = VK_IMAGE_LAYOUT_DEPTH_ATTACHMENT_OPTIMAL
+	ImageLayoutDepthAttachmentOptimal ImageLayout = 1000241000
+
+	// ImageLayoutDepthReadOnlyOptimal = VK_IMAGE_LAYOUT_DEPTH_READ_ONLY_OPTIMAL
+	ImageLayoutDepthReadOnlyOptimal ImageLayout = 1000241001
+
+	// ImageLayoutStencilAttachmentOptimal = VK_IMAGE_LAYOUT_STENCIL_ATTACHMENT_OPTIMAL
+	ImageLayoutStencilAttachmentOptimal ImageLayout = 1000241002
+
+	// ImageLayoutStencilReadOnlyOptimal = VK_IMAGE_LAYOUT_STENCIL_READ_ONLY_OPTIMAL
+	ImageLayoutStencilReadOnlyOptimal ImageLayout = 1000241003
+
 	// === Vulkan 1.3 Core (promoted from VK_KHR_dynamic_rendering) ===
 
 	// StructureTypeRenderingInfo = VK_STRUCTURE_TYPE_RENDERING_INFO
@@ -61,6 +169,65 @@ const (
 
 	// StructureTypeCommandBufferInheritanceRenderingInfo = VK_STRUCTURE_TYPE_COMMAND_BUFFER_INHERITANCE_RENDERING_INFO
 	StructureTypeCommandBufferInheritanceRenderingInfo StructureType = 1000044004
+
+	// === Vulkan 1.3 Core (promoted from VK_EXT_subgroup_size_control) ===
+
+	// StructureTypePhysicalDeviceSubgroupSizeControlProperties = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SUBGROUP_SIZE_CONTROL_PROPERTIES
+	StructureTypePhysicalDeviceSubgroupSizeControlProperties StructureType = 1000225000
+
+	// === Vulkan 1.3 Core (promoted from VK_KHR_synchronization2) ===
+
+	// StructureTypeMemoryBarrier2 = VK_STRUCTURE_TYPE_MEMORY_BARRIER_2
+	StructureTypeMemoryBarrier2 StructureType = 1000314000
+
+	// StructureTypeBufferMemoryBarrier2 = VK_STRUCTURE_TYPE_BUFFER_MEMORY_BARRIER_2
+	StructureTypeBufferMemoryBarrier2 StructureType = 1000314001
+
+	// StructureTypeImageMemoryBarrier2 = VK_STRUCTURE_TYPE_IMAGE_MEMORY_BARRIER_2
+	StructureTypeImageMemoryBarrier2 StructureType = 1000314002
+
+	// StructureTypeDependencyInfo = VK_STRUCTURE_TYPE_DEPENDENCY_INFO
+	StructureTypeDependencyInfo StructureType = 1000314003
+
+	// StructureTypeSubmitInfo2 = VK_STRUCTURE_TYPE_SUBMIT_INFO_2
+	StructureTypeSubmitInfo2 StructureType = 1000314004
+
+	// StructureTypeSemaphoreSubmitInfo = VK_STRUCTURE_TYPE_SEMAPHORE_SUBMIT_INFO
+	StructureTypeSemaphoreSubmitInfo StructureType = 1000314005
+
+	// StructureTypeCommandBufferSubmitInfo = VK_STRUCTURE_TYPE_COMMAND_BUFFER_SUBMIT_INFO
+	StructureTypeCommandBufferSubmitInfo StructureType = 1000314006
+
+	// StructureTypePhysicalDeviceSynchronization2Features = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SYNCHRONIZATION_2_FEATURES
+	StructureTypePhysicalDeviceSynchronization2Features StructureType = 1000314007
+
+	// === VK_EXT_swapchain_maintenance1 ===
+
+	// StructureTypePhysicalDeviceSwapchainMaintenance1FeaturesExt = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_SWAPCHAIN_MAINTENANCE_1_FEATURES_EXT
+	StructureTypePhysicalDeviceSwapchainMaintenance1FeaturesExt StructureType = 1000275000
+
+	// StructureTypeSwapchainPresentFenceInfoExt = VK_STRUCTURE_TYPE_SWAPCHAIN_PRESENT_FENCE_INFO_EXT
+	StructureTypeSwapchainPresentFenceInfoExt StructureType = 1000275001
+
+	// === VK_EXT_host_image_copy ===
+
+	// StructureTypePhysicalDeviceHostImageCopyFeaturesExt = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_HOST_IMAGE_COPY_FEATURES_EXT
+	StructureTypePhysicalDeviceHostImageCopyFeaturesExt StructureType = 1000270000
+
+	// StructureTypePhysicalDeviceHostImageCopyPropertiesExt = VK_STRUCTURE_TYPE_PHYSICAL_DEVICE_HOST_IMAGE_COPY_PROPERTIES_EXT
+	StructureTypePhysicalDeviceHostImageCopyPropertiesExt StructureType = 1000270001
+
+	// StructureTypeMemoryToImageCopyExt = VK_STRUCTURE_TYPE_MEMORY_TO_IMAGE_COPY_EXT
+	StructureTypeMemoryToImageCopyExt StructureType = 1000270002
+
+	// StructureTypeCopyMemoryToImageInfoExt = VK_STRUCTURE_TYPE_COPY_MEMORY_TO_IMAGE_INFO_EXT
+	StructureTypeCopyMemoryToImageInfoExt StructureType = 1000270005
+
+	// StructureTypeHostImageLayoutTransitionInfoExt = VK_STRUCTURE_TYPE_HOST_IMAGE_LAYOUT_TRANSITION_INFO_EXT
+	StructureTypeHostImageLayoutTransitionInfoExt StructureType = 1000270006
+
+	// ImageUsageHostTransferBitExt = VK_IMAGE_USAGE_HOST_TRANSFER_BIT_EXT
+	ImageUsageHostTransferBitExt ImageUsageFlagBits = 4194304
 )
 
 // ClearValueColor creates a ClearValue from RGBA float values.
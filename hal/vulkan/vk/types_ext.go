@@ -0,0 +1,25 @@
+//go:build !(js && wasm)
+
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package vk
+
+// Extension structures not present in the vk.xml revision used by vk-gen.
+
+// PhysicalDeviceSwapchainMaintenance1FeaturesEXT mirrors
+// VkPhysicalDeviceSwapchainMaintenance1FeaturesEXT.
+type PhysicalDeviceSwapchainMaintenance1FeaturesEXT struct {
+	SType                 StructureType
+	PNext                 *uintptr
+	SwapchainMaintenance1 Bool32
+}
+
+// SwapchainPresentFenceInfoEXT mirrors VkSwapchainPresentFenceInfoEXT.
+// Chained into VkPresentInfoKHR.PNext; one fence per presented swapchain.
+type SwapchainPresentFenceInfoEXT struct {
+	SType          StructureType
+	PNext          *uintptr
+	SwapchainCount uint32
+	PFences        *Fence
+}
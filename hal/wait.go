@@ -0,0 +1,55 @@
+//go:build !(js && wasm)
+
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// waitSlice bounds each Device.Wait call WaitContext makes, and with it how
+// long a canceled context can go unnoticed.
+const waitSlice = 10 * time.Millisecond
+
+// WaitContext waits for fence to reach value on device until ctx ends. It
+// returns nil once the fence reaches value and the device's error if the
+// wait fails. When ctx ends first it returns an error wrapping both
+// ErrWaitCanceled and ctx.Err().
+//
+// Driver waits cannot be interrupted, so WaitContext calls Device.Wait with
+// timeouts of at most 10ms and checks ctx in between. A fence that takes
+// long costs a driver call per slice, and no goroutine is left blocked in
+// the driver when ctx ends.
+func WaitContext(ctx context.Context, device Device, fence Fence, value uint64) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %w", ErrWaitCanceled, err)
+		}
+		slice := waitSlice
+		if deadline, ok := ctx.Deadline(); ok {
+			slice = min(slice, max(time.Until(deadline), 0))
+		}
+		start := time.Now()
+		reached, err := device.Wait(fence, value, slice)
+		if err != nil {
+			return err
+		}
+		if reached {
+			return nil
+		}
+		// The software and noop backends ignore the timeout and return at
+		// once; sleep out the slice rather than spin.
+		if rest := slice - time.Since(start); rest > 0 {
+			timer := time.NewTimer(rest)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+	}
+}
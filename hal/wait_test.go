@@ -0,0 +1,70 @@
+//go:build !(js && wasm)
+
+package hal_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// countingWaitDevice reaches its fence on the given Wait call, or never when
+// reachAt is 0. Wait returns at once, ignoring the timeout.
+type countingWaitDevice struct {
+	hal.Device
+	calls   atomic.Int32
+	reachAt int32
+	err     error
+}
+
+func (d *countingWaitDevice) Wait(hal.Fence, uint64, time.Duration) (bool, error) {
+	n := d.calls.Add(1)
+	if d.err != nil {
+		return false, d.err
+	}
+	return d.reachAt != 0 && n >= d.reachAt, nil
+}
+
+func TestWaitContext(t *testing.T) {
+	device := &countingWaitDevice{reachAt: 3}
+	if err := hal.WaitContext(context.Background(), device, nil, 1); err != nil {
+		t.Fatalf("WaitContext = %v, want nil", err)
+	}
+	if got := device.calls.Load(); got != 3 {
+		t.Errorf("Wait called %d times, want 3", got)
+	}
+
+	lost := &countingWaitDevice{err: hal.ErrDeviceLost}
+	if err := hal.WaitContext(context.Background(), lost, nil, 1); !errors.Is(err, hal.ErrDeviceLost) {
+		t.Errorf("WaitContext on a lost device = %v, want ErrDeviceLost", err)
+	}
+}
+
+func TestWaitContextCanceled(t *testing.T) {
+	device := &countingWaitDevice{}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(30*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := hal.WaitContext(ctx, device, nil, 1)
+	if !errors.Is(err, hal.ErrWaitCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitContext = %v, want ErrWaitCanceled wrapping context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitContext returned %v after cancel", elapsed)
+	}
+	// A backend ignoring the timeout is polled once per slice, not spun on.
+	if calls := device.calls.Load(); calls > 20 {
+		t.Errorf("Wait called %d times in 30ms", calls)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if err := hal.WaitContext(ctx, device, nil, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitContext past the deadline = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -294,8 +294,8 @@ func runCountedIndexedICBCase(t *testing.T, vertices []float32, indexData []byte
 	}
 	pass.SetPipeline(pipeline)
 	pass.SetBindGroup(0, renderGroup, nil)
-	pass.SetVertexBuffer(0, vertexBuffer, 0)
-	pass.SetIndexBuffer(indexBuffer, indexFormat, indexOffset)
+	pass.SetVertexBuffer(0, vertexBuffer, 0, wgpu.WholeSize)
+	pass.SetIndexBuffer(indexBuffer, indexFormat, indexOffset, wgpu.WholeSize)
 	if forceLoop {
 		pass.Draw(0, 0, 0, 0)
 	}
@@ -0,0 +1,37 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/core"
+)
+
+// indirectArgsErrors are the sentinels a family of indirect commands reports
+// for each core.IndirectArgsErrorKind.
+type indirectArgsErrors struct {
+	usage, alignment, overrun error
+}
+
+var (
+	drawIndirectErrors     = indirectArgsErrors{ErrDrawIndirectBufferUsage, ErrDrawIndirectOffsetAlignment, ErrDrawIndirectBufferOverrun}
+	dispatchIndirectErrors = indirectArgsErrors{ErrDispatchIndirectBufferUsage, ErrDispatchIndirectOffsetAlignment, ErrDispatchIndirectBufferOverrun}
+)
+
+// validateIndirectArgs checks buffer with core.ValidateIndirectArgs and wraps
+// a failure in both the *core.IndirectArgsError and the matching sentinel.
+func validateIndirectArgs(command string, buffer *Buffer, offset, recordSize uint64, count uint32, sentinels indirectArgsErrors) error {
+	err := core.ValidateIndirectArgs(command, buffer.Label(), buffer.Usage(), buffer.Size(), offset, recordSize, count)
+	if err == nil {
+		return nil
+	}
+	sentinel := sentinels.overrun
+	switch err.(*core.IndirectArgsError).Kind {
+	case core.IndirectArgsErrorMissingUsage:
+		sentinel = sentinels.usage
+	case core.IndirectArgsErrorUnalignedOffset:
+		sentinel = sentinels.alignment
+	}
+	return fmt.Errorf("wgpu: %w: %w", err, sentinel)
+}
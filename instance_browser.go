@@ -3,6 +3,8 @@
 package wgpu
 
 import (
+	"fmt"
+	"log/slog"
 	"syscall/js"
 
 	"github.com/gogpu/gputypes"
@@ -10,11 +12,21 @@ import (
 )
 
 // InstanceDescriptor configures instance creation.
-// On browser, Backends and Flags are accepted for API compatibility but
-// ignored — the browser has exactly one WebGPU backend.
+// On browser, Backends, Flags, BackendOrder, the adapter blocklist,
+// Software, logging, SkipValidation and Watchdog fields are accepted for API compatibility but ignored — the browser has exactly one
+// WebGPU backend, which always validates and detects GPU hangs itself.
 type InstanceDescriptor struct {
-	Backends Backends
-	Flags    gputypes.InstanceFlags
+	Backends               Backends
+	Flags                  gputypes.InstanceFlags
+	BackendOrder           []Backend
+	AdapterBlocklist       []AdapterBlocklistEntry
+	IgnoreAdapterBlocklist bool
+	Software               SoftwareMode
+	Logger                 *slog.Logger
+	LogLevels              map[LogCategory]slog.Level
+	BackendOptions         BackendOptions
+	SkipValidation         bool
+	Watchdog               *WatchdogDescriptor
 }
 
 // Instance is the entry point for GPU operations.
@@ -35,6 +47,11 @@ func CreateInstance(desc *InstanceDescriptor) (*Instance, error) {
 	return &Instance{browser: bi}, nil
 }
 
+// BackendReport returns an empty report; the browser exposes a single WebGPU backend.
+func (i *Instance) BackendReport() BackendReport {
+	return BackendReport{}
+}
+
 // RequestAdapter requests a GPU adapter matching the options.
 // If opts is nil, the best available adapter is returned.
 func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error) {
@@ -75,6 +92,35 @@ func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error)
 
 // CreateSurface and CreateSurfaceFromCanvas are defined in surface_browser.go.
 
+// EnumerateAdapters returns the default adapter, the one RequestAdapter(nil)
+// returns: this implementation cannot list adapters.
+func (i *Instance) EnumerateAdapters() ([]*Adapter, error) {
+	adapter, err := i.RequestAdapter(nil)
+	if err != nil {
+		return nil, err
+	}
+	return []*Adapter{adapter}, nil
+}
+
+// DescribeAdapters lists the default adapter, the one RequestAdapter(nil)
+// returns: the browser exposes a single adapter. The instance and adapter it
+// creates are released before it returns.
+func DescribeAdapters(desc *InstanceDescriptor) ([]AdapterDescription, BackendReport, error) {
+	instance, err := CreateInstance(desc)
+	if err != nil {
+		return nil, BackendReport{}, fmt.Errorf("wgpu: DescribeAdapters: %w", err)
+	}
+	defer instance.Release()
+
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		return nil, BackendReport{}, fmt.Errorf("wgpu: DescribeAdapters: %w", err)
+	}
+	defer adapter.Release()
+
+	return []AdapterDescription{{Info: adapter.Info(), Details: adapter.Details()}}, instance.BackendReport(), nil
+}
+
 // Release releases the instance. Surfaces must be released explicitly.
 func (i *Instance) Release() {
 	if i.released {
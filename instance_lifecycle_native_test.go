@@ -3,8 +3,11 @@
 package wgpu
 
 import (
+	"bytes"
 	"errors"
+	"log/slog"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/gogpu/gputypes"
@@ -272,3 +275,149 @@ func TestReleasedInstanceRejectsLateOwnership(t *testing.T) {
 	device.Release()
 	surface.Release()
 }
+
+func TestCreateInstanceBackendOrderReport(t *testing.T) {
+	instance, err := CreateInstance(&InstanceDescriptor{
+		Backends:     BackendsAll,
+		BackendOrder: []Backend{BackendDX12, BackendEmpty},
+	})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	defer instance.Release()
+
+	report := instance.BackendReport()
+	if len(report.Attempts) < 2 {
+		t.Fatalf("report = %v, want at least the two requested backends", report)
+	}
+	if report.Attempts[0].Backend != BackendDX12 || report.Attempts[1].Backend != BackendEmpty {
+		t.Fatalf("attempt order = %v, want DX12 then Empty", report)
+	}
+	for _, a := range report.Attempts[2:] {
+		if a.Status != BackendStatusDisabled {
+			t.Errorf("%v status = %v, want disabled", a.Backend, a.Status)
+		}
+	}
+}
+
+func TestCreateInstanceSoftwareModeReport(t *testing.T) {
+	t.Setenv(SoftwareModeEnv, "")
+
+	only, err := CreateInstance(&InstanceDescriptor{Backends: BackendsAll, Software: SoftwareOnly})
+	if err != nil {
+		t.Fatalf("CreateInstance(SoftwareOnly): %v", err)
+	}
+	defer only.Release()
+	report := only.BackendReport()
+	if len(report.Attempts) == 0 || report.Attempts[0].Backend != BackendEmpty {
+		t.Fatalf("SoftwareOnly report = %v, want Empty first", report)
+	}
+	for _, a := range report.Attempts[1:] {
+		if a.Status != BackendStatusDisabled {
+			t.Errorf("SoftwareOnly: %v status = %v, want disabled", a.Backend, a.Status)
+		}
+	}
+
+	disabled, err := CreateInstance(&InstanceDescriptor{Backends: BackendsAll, Software: SoftwareDisabled})
+	if err != nil {
+		t.Fatalf("CreateInstance(SoftwareDisabled): %v", err)
+	}
+	defer disabled.Release()
+	for _, a := range disabled.BackendReport().Attempts {
+		if a.Backend == BackendEmpty && a.Status != BackendStatusDisabled {
+			t.Errorf("SoftwareDisabled: Empty status = %v, want disabled", a.Status)
+		}
+	}
+}
+
+func TestDescribeAdapters(t *testing.T) {
+	t.Setenv(SoftwareModeEnv, "")
+
+	descriptions, report, err := DescribeAdapters(&InstanceDescriptor{Backends: BackendsAll, Software: SoftwareOnly})
+	if err != nil {
+		t.Fatalf("DescribeAdapters: %v", err)
+	}
+	if len(report.Attempts) == 0 || report.Attempts[0].Backend != BackendEmpty {
+		t.Fatalf("report = %v, want Empty first", report)
+	}
+	if report.Attempts[0].Status != BackendStatusReady {
+		t.Skipf("software backend not available: %v", report)
+	}
+	if len(descriptions) != report.Attempts[0].Adapters {
+		t.Fatalf("described %d adapters, report counts %d", len(descriptions), report.Attempts[0].Adapters)
+	}
+	if info := descriptions[0].Info; info.Backend != BackendEmpty || info.DeviceType != gputypes.DeviceTypeCPU {
+		t.Errorf("software adapter described as %v on %v, want CPU on Empty", info.DeviceType, info.Backend)
+	}
+
+	instance, err := CreateInstance(&InstanceDescriptor{Backends: BackendsAll, Software: SoftwareOnly})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	defer instance.Release()
+	adapters, err := instance.EnumerateAdapters()
+	if err != nil {
+		t.Fatalf("EnumerateAdapters: %v", err)
+	}
+	if len(adapters) != len(descriptions) {
+		t.Fatalf("EnumerateAdapters found %d adapters, DescribeAdapters %d", len(adapters), len(descriptions))
+	}
+	for idx, adapter := range adapters {
+		if got, want := descriptions[idx].Info.Name, adapter.Info().Name; got != want {
+			t.Errorf("adapter %d described as %q, enumerated as %q", idx, got, want)
+		}
+		adapter.Release()
+	}
+
+	if _, _, err := DescribeAdapters(&InstanceDescriptor{
+		BackendOptions: BackendOptions{Vulkan: VulkanOptions{InstanceExtensions: []string{""}}},
+	}); err == nil {
+		t.Error("DescribeAdapters accepted invalid backend options")
+	}
+}
+
+func TestCreateInstanceLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	inst, err := CreateInstance(&InstanceDescriptor{
+		Backends:  BackendsAll,
+		Software:  SoftwareDisabled,
+		Logger:    logger,
+		LogLevels: map[LogCategory]slog.Level{LogAdapter: slog.LevelInfo},
+	})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	defer inst.Release()
+
+	// The disabled software backend is always reported through the
+	// instance logger, tagged with the adapter category.
+	if !strings.Contains(buf.String(), "core: backend unavailable") || !strings.Contains(buf.String(), "category=adapter") {
+		t.Errorf("instance logger did not receive adapter records, got: %s", buf.String())
+	}
+	if strings.Contains(buf.String(), "level=DEBUG category=adapter") {
+		t.Errorf("LogLevels should drop debug adapter records, got: %s", buf.String())
+	}
+}
+
+func TestCreateInstanceRejectsInvalidBackendOptions(t *testing.T) {
+	_, err := CreateInstance(&InstanceDescriptor{
+		Backends:       BackendsAll,
+		BackendOptions: BackendOptions{Vulkan: VulkanOptions{DeviceExtensions: []string{""}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "Vulkan.DeviceExtensions") {
+		t.Errorf("CreateInstance = %v, want an error naming the invalid field", err)
+	}
+	if DXGICreateFactoryDebug != hal.DXGICreateFactoryDebug {
+		t.Error("root DXGICreateFactoryDebug differs from hal")
+	}
+}
+
+func TestLogCategoryMatchesHAL(t *testing.T) {
+	for _, c := range []LogCategory{LogGeneral, LogAdapter, LogSwapchain, LogBarrier, LogShader} {
+		if got := hal.LogCategory(c).String(); got != c.String() {
+			t.Errorf("hal.LogCategory(%v) = %q, want %q", c, got, c.String())
+		}
+	}
+}
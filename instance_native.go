@@ -4,6 +4,7 @@ package wgpu
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/gogpu/gputypes"
@@ -17,6 +18,47 @@ type InstanceDescriptor struct {
 	// Flags controls instance features like debug layers and validation.
 	// Use gputypes.InstanceFlagsDebug to enable GPU debug layer.
 	Flags gputypes.InstanceFlags
+	// BackendOrder is the backend fallback chain. Backends are initialized in
+	// this order and their adapters are preferred in this order, so the first
+	// backend that loads and exposes a GPU adapter wins. Enabled backends
+	// missing from the list are not initialized. Nil uses DefaultBackendOrder.
+	// Instance.BackendReport explains why any backend was skipped. The
+	// WGPU_BACKEND environment variable overrides Backends, BackendOrder and
+	// Software; see BackendEnv.
+	BackendOrder []Backend
+	// AdapterBlocklist adds entries to the built-in blocklist of known-broken
+	// drivers. Matching adapters are fallback-only.
+	AdapterBlocklist []AdapterBlocklistEntry
+	// IgnoreAdapterBlocklist disables the blocklist, including the built-in
+	// entries. Setting GOGPU_IGNORE_ADAPTER_BLOCKLIST=1 has the same effect.
+	IgnoreAdapterBlocklist bool
+	// Software selects whether the software backend joins the fallback
+	// chain: after the GPU backends (SoftwareAuto, the default), alone
+	// (SoftwareOnly) or never (SoftwareDisabled). With SoftwareAuto the
+	// GOGPU_SOFTWARE environment variable decides; see SoftwareModeEnv.
+	Software SoftwareMode
+	// Logger receives this instance's diagnostics, from adapter enumeration
+	// down to the HAL backends, instead of the logger set with SetLogger.
+	// Nil keeps the SetLogger logger.
+	Logger *slog.Logger
+	// LogLevels sets a minimum level per LogCategory on top of the logger's
+	// own level, for example to see LogBarrier decisions at debug level
+	// while keeping everything else at info.
+	LogLevels map[LogCategory]slog.Level
+	// BackendOptions passes backend-specific settings to the Vulkan, DX12,
+	// Metal, GL and software backends. Invalid options fail CreateInstance.
+	BackendOptions BackendOptions
+	// SkipValidation turns off descriptor validation (limits, formats,
+	// bindings, pipeline state) on this instance's devices, to save CPU in
+	// draw-call-heavy release builds. Invalid descriptors then reach the
+	// backend unchecked. Feature requirements are still checked. Building
+	// with the wgpu_novalidate tag compiles the checks out entirely and
+	// implies SkipValidation.
+	SkipValidation bool
+	// Watchdog, if set, enables the GPU hang watchdog, which loses a device
+	// whose submission has not completed within a deadline and reports the
+	// command buffers and passes in flight. Nil disables it.
+	Watchdog *WatchdogDescriptor
 }
 
 // Instance is the entry point for GPU operations.
@@ -29,22 +71,86 @@ type Instance struct {
 	released bool
 	devices  map[*Device]struct{}
 	surfaces map[*Surface]struct{}
+	// watchdog is the GPU hang watchdog, nil unless
+	// InstanceDescriptor.Watchdog is set.
+	watchdog *watchdog
 }
 
 // CreateInstance creates a new GPU instance.
 // If desc is nil, all available backends are used.
 func CreateInstance(desc *InstanceDescriptor) (*Instance, error) {
-	var gpuDesc *gputypes.InstanceDescriptor
-	if desc != nil {
-		d := gputypes.DefaultInstanceDescriptor()
-		d.Backends = desc.Backends
-		d.Flags = desc.Flags
-		gpuDesc = &d
+	defer startSpan("wgpu.CreateInstance").End()
+
+	gpuDesc, opts, err := desc.toCore()
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: CreateInstance: %w", err)
 	}
 
-	coreInstance := core.NewInstance(gpuDesc)
+	coreInstance := core.NewInstanceWithOptions(gpuDesc, opts)
+
+	instance := &Instance{core: coreInstance}
+	if desc != nil && desc.Watchdog != nil {
+		instance.watchdog = newWatchdog(desc.Watchdog)
+		go instance.watchdog.run(instance)
+	}
+	return instance, nil
+}
 
-	return &Instance{core: coreInstance}, nil
+// toCore converts the descriptor to core's instance descriptor and options,
+// both nil for a nil descriptor. It fails when BackendOptions are invalid.
+func (desc *InstanceDescriptor) toCore() (*gputypes.InstanceDescriptor, *core.InstanceOptions, error) {
+	if desc == nil {
+		return nil, nil, nil
+	}
+	d := gputypes.DefaultInstanceDescriptor()
+	d.Backends = desc.Backends
+	d.Flags = desc.Flags
+	opts := &core.InstanceOptions{
+		BackendOrder:           desc.BackendOrder,
+		AdapterBlocklist:       make([]core.AdapterBlocklistEntry, len(desc.AdapterBlocklist)),
+		IgnoreAdapterBlocklist: desc.IgnoreAdapterBlocklist,
+		Software:               core.SoftwareMode(desc.Software),
+		Logger:                 desc.Logger,
+		BackendOptions:         desc.BackendOptions.toHAL(),
+		SkipValidation:         desc.SkipValidation,
+	}
+	if err := opts.BackendOptions.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if len(desc.LogLevels) > 0 {
+		opts.LogLevels = make(map[hal.LogCategory]slog.Level, len(desc.LogLevels))
+		for c, level := range desc.LogLevels {
+			opts.LogLevels[hal.LogCategory(c)] = level
+		}
+	}
+	for idx, e := range desc.AdapterBlocklist {
+		opts.AdapterBlocklist[idx] = core.AdapterBlocklistEntry(e)
+	}
+	return &d, opts, nil
+}
+
+// DescribeAdapters lists the adapters CreateInstance with desc would find,
+// without creating an instance or opening any adapter, for tools that only
+// report which GPUs exist. Vulkan reads physical device properties and DX12
+// reads DXGI adapter descriptions, so no device is created; this is faster
+// than EnumerateAdapters and works under Remote Desktop, where creating a
+// D3D12 device can fail. DX12 cannot tell integrated from discrete GPUs this
+// way and reports DeviceTypeOther. GL adapters need a surface and are not
+// listed. The report says why any backend contributed nothing.
+func DescribeAdapters(desc *InstanceDescriptor) ([]AdapterDescription, BackendReport, error) {
+	gpuDesc, opts, err := desc.toCore()
+	if err != nil {
+		return nil, BackendReport{}, fmt.Errorf("wgpu: DescribeAdapters: %w", err)
+	}
+	found, coreReport := core.DescribeAdapters(gpuDesc, opts)
+	descriptions := make([]AdapterDescription, len(found))
+	for idx := range found {
+		descriptions[idx] = AdapterDescription{
+			Info:    found[idx].Info,
+			Details: adapterDetailsFromHAL(&found[idx].Details),
+		}
+	}
+	return descriptions, backendReportFromCore(coreReport), nil
 }
 
 // RequestAdapter requests a GPU adapter matching the options.
@@ -55,6 +161,8 @@ func CreateInstance(desc *InstanceDescriptor) (*Instance, error) {
 // the surface's GL context. This follows the WebGPU spec pattern where
 // requestAdapter accepts a compatible surface hint.
 func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error) {
+	defer startSpan("wgpu.Instance.RequestAdapter").End()
+
 	if i.isReleased() {
 		return nil, ErrReleased
 	}
@@ -83,13 +191,43 @@ func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error)
 	if err != nil {
 		return nil, err
 	}
-	keepAdapter := false
-	defer func() {
-		if !keepAdapter {
-			i.core.ReleaseSurfaceAdapter(adapterID)
+	adapter, err := i.wrapAdapter(adapterID)
+	if err != nil {
+		i.core.ReleaseSurfaceAdapter(adapterID)
+		return nil, err
+	}
+
+	i.core.Logger(hal.LogAdapter).Info("wgpu: adapter selected",
+		"name", adapter.info.Name,
+		"backend", adapter.info.Backend,
+		"type", adapter.info.DeviceType,
+	)
+	return adapter, nil
+}
+
+// EnumerateAdapters returns every adapter of the instance's backends, in
+// backend order, including blocklisted and software adapters. GL adapters
+// need a surface to be created and are not listed until a RequestAdapter
+// call has enumerated them.
+func (i *Instance) EnumerateAdapters() ([]*Adapter, error) {
+	if i.isReleased() {
+		return nil, ErrReleased
+	}
+
+	ids := i.core.EnumerateAdapters()
+	adapters := make([]*Adapter, 0, len(ids))
+	for _, id := range ids {
+		adapter, err := i.wrapAdapter(id)
+		if err != nil {
+			return nil, err
 		}
-	}()
+		adapters = append(adapters, adapter)
+	}
+	return adapters, nil
+}
 
+// wrapAdapter returns the Adapter for a core adapter of the instance.
+func (i *Instance) wrapAdapter(adapterID core.AdapterID) (*Adapter, error) {
 	info, err := core.GetAdapterInfo(adapterID)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to get adapter info: %w", err)
@@ -103,28 +241,44 @@ func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error)
 		return nil, fmt.Errorf("wgpu: failed to get adapter limits: %w", err)
 	}
 
-	hal.Logger().Info("wgpu: adapter selected",
-		"name", info.Name,
-		"backend", info.Backend,
-		"type", info.DeviceType,
-	)
-
 	hub := core.GetGlobal().Hub()
 	coreAdapter, err := hub.GetAdapter(adapterID)
 	if err != nil {
 		return nil, fmt.Errorf("wgpu: failed to get adapter: %w", err)
 	}
 
-	adapter := &Adapter{
+	return &Adapter{
 		id:       adapterID,
 		core:     &coreAdapter,
 		info:     info,
 		features: features,
 		limits:   limits,
 		instance: i,
+	}, nil
+}
+
+// BackendReport returns the outcome of every backend considered by
+// CreateInstance: which loaded, which found adapters, and why the others
+// were skipped (driver library missing, no adapters, disabled).
+func (i *Instance) BackendReport() BackendReport {
+	if i == nil || i.core == nil {
+		return BackendReport{}
 	}
-	keepAdapter = true
-	return adapter, nil
+	return backendReportFromCore(i.core.BackendReport())
+}
+
+// backendReportFromCore converts core's backend report.
+func backendReportFromCore(coreReport core.BackendReport) BackendReport {
+	report := BackendReport{Attempts: make([]BackendAttempt, len(coreReport.Attempts))}
+	for idx, a := range coreReport.Attempts {
+		report.Attempts[idx] = BackendAttempt{
+			Backend:  a.Backend,
+			Status:   a.Status,
+			Adapters: a.Adapters,
+			Err:      a.Err,
+		}
+	}
+	return report
 }
 
 func (i *Instance) isReleased() bool {
@@ -235,6 +389,9 @@ func (i *Instance) Release() {
 	if !ok {
 		return
 	}
+	if i.watchdog != nil {
+		i.watchdog.close()
+	}
 
 	// Devices own configured swapchains, surfaces own platform surface handles,
 	// and the instance owns the native instance. Release in that order.
@@ -246,3 +403,14 @@ func (i *Instance) Release() {
 	}
 	i.core.Destroy()
 }
+
+// toHAL converts BackendOptions to hal.BackendOptions.
+func (o *BackendOptions) toHAL() hal.BackendOptions {
+	return hal.BackendOptions{
+		Vulkan:   hal.VulkanOptions(o.Vulkan),
+		DX12:     hal.DX12Options(o.DX12),
+		Metal:    hal.MetalOptions(o.Metal),
+		GL:       hal.GLOptions{ImplicitLod: hal.ImplicitLodRewrite(o.GL.ImplicitLod)},
+		Software: hal.SoftwareOptions(o.Software),
+	}
+}
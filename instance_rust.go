@@ -4,6 +4,7 @@ package wgpu
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/gogpu/gputypes"
 
@@ -11,11 +12,21 @@ import (
 )
 
 // InstanceDescriptor configures instance creation.
-// On Rust backend, Backends and Flags are accepted for API compatibility
-// but the Rust wgpu-native handles backend selection internally.
+// On Rust backend, Backends, Flags, BackendOrder, the adapter blocklist,
+// Software, logging, SkipValidation and Watchdog fields are accepted for API compatibility but the Rust
+// wgpu-native handles backend selection, validation and device loss internally.
 type InstanceDescriptor struct {
-	Backends Backends
-	Flags    gputypes.InstanceFlags
+	Backends               Backends
+	Flags                  gputypes.InstanceFlags
+	BackendOrder           []Backend
+	AdapterBlocklist       []AdapterBlocklistEntry
+	IgnoreAdapterBlocklist bool
+	Software               SoftwareMode
+	Logger                 *slog.Logger
+	LogLevels              map[LogCategory]slog.Level
+	BackendOptions         BackendOptions
+	SkipValidation         bool
+	Watchdog               *WatchdogDescriptor
 }
 
 // Instance is the entry point for GPU operations.
@@ -40,6 +51,11 @@ func CreateInstance(desc *InstanceDescriptor) (*Instance, error) {
 	return &Instance{r: ri}, nil
 }
 
+// BackendReport returns an empty report; wgpu-native selects backends itself.
+func (i *Instance) BackendReport() BackendReport {
+	return BackendReport{}
+}
+
 // RequestAdapter requests a GPU adapter matching the options.
 // If opts is nil, the best available adapter is returned.
 func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error) {
@@ -96,6 +112,35 @@ func (i *Instance) RequestAdapter(opts *RequestAdapterOptions) (*Adapter, error)
 	}, nil
 }
 
+// EnumerateAdapters returns the default adapter, the one RequestAdapter(nil)
+// returns: this implementation cannot list adapters.
+func (i *Instance) EnumerateAdapters() ([]*Adapter, error) {
+	adapter, err := i.RequestAdapter(nil)
+	if err != nil {
+		return nil, err
+	}
+	return []*Adapter{adapter}, nil
+}
+
+// DescribeAdapters lists the default adapter, the one RequestAdapter(nil)
+// returns: wgpu-native cannot list adapters without requesting them. The
+// instance and adapter it creates are released before it returns.
+func DescribeAdapters(desc *InstanceDescriptor) ([]AdapterDescription, BackendReport, error) {
+	instance, err := CreateInstance(desc)
+	if err != nil {
+		return nil, BackendReport{}, fmt.Errorf("wgpu: DescribeAdapters: %w", err)
+	}
+	defer instance.Release()
+
+	adapter, err := instance.RequestAdapter(nil)
+	if err != nil {
+		return nil, BackendReport{}, fmt.Errorf("wgpu: DescribeAdapters: %w", err)
+	}
+	defer adapter.Release()
+
+	return []AdapterDescription{{Info: adapter.Info(), Details: adapter.Details()}}, instance.BackendReport(), nil
+}
+
 // Release releases the instance. Surfaces must be released explicitly.
 func (i *Instance) Release() {
 	if i.released {
@@ -117,6 +162,7 @@ func convertRustLimits(rl rwgpu.Limits) gputypes.Limits {
 		MaxTextureDimension3D:                     rl.MaxTextureDimension3D,
 		MaxTextureArrayLayers:                     rl.MaxTextureArrayLayers,
 		MaxBindGroups:                             rl.MaxBindGroups,
+		MaxBindGroupsPlusVertexBuffers:            rl.MaxBindGroupsPlusVertexBuffers,
 		MaxBindingsPerBindGroup:                   rl.MaxBindingsPerBindGroup,
 		MaxDynamicUniformBuffersPerPipelineLayout: rl.MaxDynamicUniformBuffersPerPipelineLayout,
 		MaxDynamicStorageBuffersPerPipelineLayout: rl.MaxDynamicStorageBuffersPerPipelineLayout,
@@ -133,6 +179,10 @@ func convertRustLimits(rl rwgpu.Limits) gputypes.Limits {
 		MaxBufferSize:                             rl.MaxBufferSize,
 		MaxVertexAttributes:                       rl.MaxVertexAttributes,
 		MaxVertexBufferArrayStride:                rl.MaxVertexBufferArrayStride,
+		MaxInterStageShaderVariables:              rl.MaxInterStageShaderVariables,
+		MaxColorAttachments:                       rl.MaxColorAttachments,
+		MaxColorAttachmentBytesPerSample:          rl.MaxColorAttachmentBytesPerSample,
+		MaxComputeWorkgroupStorageSize:            rl.MaxComputeWorkgroupStorageSize,
 		MaxComputeWorkgroupSizeX:                  rl.MaxComputeWorkgroupSizeX,
 		MaxComputeWorkgroupSizeY:                  rl.MaxComputeWorkgroupSizeY,
 		MaxComputeWorkgroupSizeZ:                  rl.MaxComputeWorkgroupSizeZ,
@@ -154,6 +204,18 @@ func convertRustFeatures(names []rwgpu.FeatureName) gputypes.Features {
 	return features
 }
 
+// convertToRustFeatures converts a gputypes.Features bitmask to go-webgpu
+// FeatureNames. Features without a go-webgpu equivalent are dropped.
+func convertToRustFeatures(features gputypes.Features) []rwgpu.FeatureName {
+	var names []rwgpu.FeatureName
+	for name, f := range rustFeatureMap {
+		if features.Contains(f) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // rustFeatureMap maps go-webgpu FeatureName constants to gputypes.Feature bitmask bits.
 // Only features that exist in both go-webgpu and gputypes are included.
 var rustFeatureMap = map[rwgpu.FeatureName]gputypes.Feature{
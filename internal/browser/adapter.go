@@ -71,6 +71,16 @@ func (a *Adapter) Limits() js.Value {
 	return a.limits
 }
 
+// SubgroupSizes reads subgroupMinSize and subgroupMaxSize from the adapter's
+// GPUAdapterInfo. Both are 0 when the browser does not expose them.
+func (a *Adapter) SubgroupSizes() (minSize, maxSize uint32) {
+	info := a.ref_.Get("info")
+	if info.IsUndefined() || info.IsNull() {
+		return 0, 0
+	}
+	return getUint32(info, "subgroupMinSize"), getUint32(info, "subgroupMaxSize")
+}
+
 // Ref returns the underlying GPUAdapter js.Value.
 func (a *Adapter) Ref() js.Value {
 	return a.ref_
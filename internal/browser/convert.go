@@ -108,6 +108,7 @@ func ExtractLimits(jsLimits js.Value) gputypes.Limits {
 		MaxTextureDimension3D:                     getUint32(jsLimits, "maxTextureDimension3D"),
 		MaxTextureArrayLayers:                     getUint32(jsLimits, "maxTextureArrayLayers"),
 		MaxBindGroups:                             getUint32(jsLimits, "maxBindGroups"),
+		MaxBindGroupsPlusVertexBuffers:            getUint32(jsLimits, "maxBindGroupsPlusVertexBuffers"),
 		MaxBindingsPerBindGroup:                   getUint32(jsLimits, "maxBindingsPerBindGroup"),
 		MaxDynamicUniformBuffersPerPipelineLayout: getUint32(jsLimits, "maxDynamicUniformBuffersPerPipelineLayout"),
 		MaxDynamicStorageBuffersPerPipelineLayout: getUint32(jsLimits, "maxDynamicStorageBuffersPerPipelineLayout"),
@@ -124,6 +125,7 @@ func ExtractLimits(jsLimits js.Value) gputypes.Limits {
 		MaxBufferSize:                             getUint64(jsLimits, "maxBufferSize"),
 		MaxVertexAttributes:                       getUint32(jsLimits, "maxVertexAttributes"),
 		MaxVertexBufferArrayStride:                getUint32(jsLimits, "maxVertexBufferArrayStride"),
+		MaxInterStageShaderVariables:              getUint32(jsLimits, "maxInterStageShaderVariables"),
 		MaxColorAttachments:                       getUint32(jsLimits, "maxColorAttachments"),
 		MaxColorAttachmentBytesPerSample:          getUint32(jsLimits, "maxColorAttachmentBytesPerSample"),
 		MaxComputeWorkgroupStorageSize:            getUint32(jsLimits, "maxComputeWorkgroupStorageSize"),
@@ -155,6 +157,7 @@ var featuresMappingTable = []featureMapping{
 	{gputypes.FeatureRG11B10UfloatRenderable, "rg11b10ufloat-renderable"},
 	{gputypes.FeatureBGRA8UnormStorage, "bgra8unorm-storage"},
 	{gputypes.FeatureFloat32Filterable, "float32-filterable"},
+	{gputypes.FeatureSubgroupOperations, "subgroups"},
 }
 
 // featuresToJSArray builds a JS Array of feature name strings from Go flags.
@@ -190,6 +193,7 @@ func limitsToJSObject(limits gputypes.Limits) js.Value {
 	setNonZeroU32("maxTextureDimension3D", limits.MaxTextureDimension3D)
 	setNonZeroU32("maxTextureArrayLayers", limits.MaxTextureArrayLayers)
 	setNonZeroU32("maxBindGroups", limits.MaxBindGroups)
+	setNonZeroU32("maxBindGroupsPlusVertexBuffers", limits.MaxBindGroupsPlusVertexBuffers)
 	setNonZeroU32("maxBindingsPerBindGroup", limits.MaxBindingsPerBindGroup)
 	setNonZeroU32("maxDynamicUniformBuffersPerPipelineLayout", limits.MaxDynamicUniformBuffersPerPipelineLayout)
 	setNonZeroU32("maxDynamicStorageBuffersPerPipelineLayout", limits.MaxDynamicStorageBuffersPerPipelineLayout)
@@ -206,6 +210,7 @@ func limitsToJSObject(limits gputypes.Limits) js.Value {
 	setNonZeroU64("maxBufferSize", limits.MaxBufferSize)
 	setNonZeroU32("maxVertexAttributes", limits.MaxVertexAttributes)
 	setNonZeroU32("maxVertexBufferArrayStride", limits.MaxVertexBufferArrayStride)
+	setNonZeroU32("maxInterStageShaderVariables", limits.MaxInterStageShaderVariables)
 	setNonZeroU32("maxColorAttachments", limits.MaxColorAttachments)
 	setNonZeroU32("maxColorAttachmentBytesPerSample", limits.MaxColorAttachmentBytesPerSample)
 	setNonZeroU32("maxComputeWorkgroupStorageSize", limits.MaxComputeWorkgroupStorageSize)
@@ -183,14 +183,35 @@ func BuildSamplerDescriptor(
 
 // --- Shader module descriptor ---
 
+// ShaderCompilationHintJS holds one GPUShaderModuleCompilationHint.
+type ShaderCompilationHintJS struct {
+	EntryPoint string
+	LayoutRef  js.Value // GPUPipelineLayout or js.Undefined() for "auto"
+}
+
 // BuildShaderModuleDescriptor constructs a JS GPUShaderModuleDescriptor object.
 // On browser, WGSL code goes directly to the browser's createShaderModule.
-func BuildShaderModuleDescriptor(label string, code string) js.Value {
+func BuildShaderModuleDescriptor(label string, code string, hints []ShaderCompilationHintJS) js.Value {
 	desc := newJSObject()
 	if label != "" {
 		desc.Set("label", label)
 	}
 	desc.Set("code", code)
+
+	if len(hints) > 0 {
+		arr := newJSArray()
+		for _, hint := range hints {
+			obj := newJSObject()
+			obj.Set("entryPoint", hint.EntryPoint)
+			if hint.LayoutRef.IsUndefined() || hint.LayoutRef.IsNull() {
+				obj.Set("layout", "auto")
+			} else {
+				obj.Set("layout", hint.LayoutRef)
+			}
+			arr.Call("push", obj)
+		}
+		desc.Set("compilationHints", arr)
+	}
 	return desc
 }
 
@@ -831,6 +852,32 @@ func BuildComputePassDescriptor(label string) js.Value {
 	return desc
 }
 
+// BuildQuerySetDescriptor constructs a JS GPUQuerySetDescriptor.
+// queryType is "occlusion" or "timestamp".
+func BuildQuerySetDescriptor(label, queryType string, count uint32) js.Value {
+	desc := newJSObject()
+	if label != "" {
+		desc.Set("label", label)
+	}
+	desc.Set("type", queryType)
+	desc.Set("count", count)
+	return desc
+}
+
+// BuildPassTimestampWrites constructs a JS GPUComputePassTimestampWrites /
+// GPURenderPassTimestampWrites. Nil indices are omitted.
+func BuildPassTimestampWrites(querySet js.Value, beginning, end *uint32) js.Value {
+	tw := newJSObject()
+	tw.Set("querySet", querySet)
+	if beginning != nil {
+		tw.Set("beginningOfPassWriteIndex", *beginning)
+	}
+	if end != nil {
+		tw.Set("endOfPassWriteIndex", *end)
+	}
+	return tw
+}
+
 // --- Image copy descriptors (for copyBufferToTexture / copyTextureToBuffer) ---
 
 // BuildImageCopyBuffer constructs a JS GPUTexelCopyBufferInfo (formerly GPUImageCopyBuffer).
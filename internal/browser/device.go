@@ -190,6 +190,12 @@ func (d *Device) CreateComputePipelineFromDesc(desc js.Value) *ComputePipeline {
 	return NewComputePipeline(jsPipeline)
 }
 
+// CreateQuerySetFromDesc creates a GPUQuerySet from a JS descriptor object.
+func (d *Device) CreateQuerySetFromDesc(desc js.Value) *QuerySet {
+	jsQuerySet := d.fnCreateQuerySet.Invoke(desc)
+	return NewQuerySet(jsQuerySet)
+}
+
 // Destroy calls GPUDevice.destroy() to release GPU resources.
 // After this call the device is no longer usable.
 func (d *Device) Destroy() {
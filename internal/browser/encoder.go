@@ -23,6 +23,7 @@ type CommandEncoder struct {
 	fnCopyTextureToBuffer  js.Value
 	fnCopyTextureToTexture js.Value
 	fnClearBuffer          js.Value
+	fnResolveQuerySet      js.Value
 	fnFinish               js.Value
 }
 
@@ -38,6 +39,7 @@ func NewCommandEncoder(ref js.Value) *CommandEncoder {
 		fnCopyTextureToBuffer:  bindMethod(ref, "copyTextureToBuffer"),
 		fnCopyTextureToTexture: bindMethod(ref, "copyTextureToTexture"),
 		fnClearBuffer:          bindMethod(ref, "clearBuffer"),
+		fnResolveQuerySet:      bindMethod(ref, "resolveQuerySet"),
 		fnFinish:               bindMethod(ref, "finish"),
 	}
 }
@@ -85,6 +87,11 @@ func (e *CommandEncoder) ClearBuffer(buffer js.Value, offset, size uint64) {
 	e.fnClearBuffer.Invoke(buffer, float64(offset), float64(size))
 }
 
+// ResolveQuerySet records a query resolve into a buffer.
+func (e *CommandEncoder) ResolveQuerySet(querySet js.Value, firstQuery, queryCount uint32, destination js.Value, destinationOffset uint64) {
+	e.fnResolveQuerySet.Invoke(querySet, firstQuery, queryCount, destination, float64(destinationOffset))
+}
+
 // Finish completes command recording and returns a CommandBuffer.
 // An optional descriptor (or js.Undefined()) can be passed for the label.
 func (e *CommandEncoder) Finish(desc js.Value) *CommandBuffer {
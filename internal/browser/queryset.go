@@ -0,0 +1,24 @@
+//go:build js && wasm
+
+package browser
+
+import "syscall/js"
+
+// QuerySet wraps a browser GPUQuerySet.
+type QuerySet struct {
+	// ref_ is the GPUQuerySet JavaScript object.
+	ref_ js.Value
+}
+
+// NewQuerySet constructs a QuerySet from a GPUQuerySet js.Value.
+func NewQuerySet(ref js.Value) *QuerySet {
+	return &QuerySet{ref_: ref}
+}
+
+// Ref returns the underlying GPUQuerySet js.Value.
+func (q *QuerySet) Ref() js.Value { return q.ref_ }
+
+// Destroy calls GPUQuerySet.destroy().
+func (q *QuerySet) Destroy() {
+	q.ref_.Call("destroy")
+}
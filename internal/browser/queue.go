@@ -44,6 +44,14 @@ func (q *Queue) Submit(commandBuffers []js.Value) {
 	q.fnSubmit.Invoke(arr)
 }
 
+// OnSubmittedWorkDone blocks the calling goroutine until the work submitted
+// so far completes. It must not be called from the main goroutine; see
+// AwaitPromise.
+func (q *Queue) OnSubmittedWorkDone() error {
+	_, err := AwaitPromise(q.ref_.Call("onSubmittedWorkDone"))
+	return err
+}
+
 // WriteBuffer writes Go byte data to a GPU buffer.
 //
 // Rust wgpu creates a Uint8Array from the data, then passes its .buffer() (ArrayBuffer)
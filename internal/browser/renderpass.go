@@ -4,6 +4,10 @@ package browser
 
 import "syscall/js"
 
+// wholeSize is the size callers pass for "rest of buffer"; it matches
+// wgpu.WholeSize.
+const wholeSize = ^uint64(0)
+
 // RenderPassEncoder wraps a browser GPURenderPassEncoder with pre-bound methods.
 //
 // Pre-binding JS methods at construction time avoids repeated property lookups
@@ -76,10 +80,10 @@ func (p *RenderPassEncoder) SetBindGroup(index uint32, group js.Value, dynamicOf
 
 // SetVertexBuffer sets a vertex buffer for the given slot.
 //
-// If size is 0, the size parameter is omitted (meaning "rest of buffer"),
-// matching Rust wgpu's set_vertex_buffer_with_f64 (no size variant).
+// If size is wholeSize, the size parameter is omitted (meaning "rest of
+// buffer"), matching Rust wgpu's set_vertex_buffer_with_f64 (no size variant).
 func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer js.Value, offset uint64, size uint64) {
-	if size == 0 {
+	if size == wholeSize {
 		// Omit size to use the rest of the buffer.
 		p.fnSetVertexBuffer.Invoke(slot, buffer, float64(offset))
 	} else {
@@ -90,10 +94,10 @@ func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer js.Value, offset
 // SetIndexBuffer sets the index buffer.
 //
 // format is a WebGPU string: "uint16" or "uint32".
-// If size is 0, the size parameter is omitted (meaning "rest of buffer"),
-// matching Rust wgpu's set_index_buffer_with_f64 (no size variant).
+// If size is wholeSize, the size parameter is omitted (meaning "rest of
+// buffer"), matching Rust wgpu's set_index_buffer_with_f64 (no size variant).
 func (p *RenderPassEncoder) SetIndexBuffer(buffer js.Value, format string, offset uint64, size uint64) {
-	if size == 0 {
+	if size == wholeSize {
 		p.fnSetIndexBuffer.Invoke(buffer, format, float64(offset))
 	} else {
 		p.fnSetIndexBuffer.Invoke(buffer, format, float64(offset), float64(size))
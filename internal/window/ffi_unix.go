@@ -0,0 +1,72 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build (linux && !android) || (darwin && !ios)
+
+package window
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// cfunc is a C function called through goffi.
+type cfunc struct {
+	sym unsafe.Pointer
+	cif types.CallInterface
+}
+
+// load resolves name in lib and prepares its call interface.
+func (f *cfunc) load(lib unsafe.Pointer, name string, ret *types.TypeDescriptor, args ...*types.TypeDescriptor) error {
+	sym, err := ffi.GetSymbol(lib, name)
+	if err != nil {
+		return fmt.Errorf("window: missing symbol %s: %w", name, err)
+	}
+	if err := ffi.PrepareCallInterface(&f.cif, types.DefaultCall, ret, args); err != nil {
+		return fmt.Errorf("window: prepare %s: %w", name, err)
+	}
+	f.sym = sym
+	return nil
+}
+
+// call calls the function. Each of args points to an argument value, and
+// ret to storage for the result (nil for void).
+func (f *cfunc) call(ret unsafe.Pointer, args ...unsafe.Pointer) {
+	_, _ = ffi.CallFunction(&f.cif, f.sym, ret, args)
+}
+
+// loadLibrary loads the first of names that exists.
+func loadLibrary(names ...string) (unsafe.Pointer, error) {
+	var err error
+	for _, name := range names {
+		var lib unsafe.Pointer
+		if lib, err = ffi.LoadLibrary(name); err == nil {
+			return lib, nil
+		}
+	}
+	return nil, fmt.Errorf("window: load %s: %w", names[0], err)
+}
+
+// cString returns s as a NUL-terminated byte slice. Keep the slice alive
+// while C uses its address.
+func cString(s string) []byte {
+	return append([]byte(s), 0)
+}
+
+// goString copies the NUL-terminated C string at p.
+//
+//go:nocheckptr
+func goString(p uintptr) string {
+	if p == 0 {
+		return ""
+	}
+	ptr := *(*unsafe.Pointer)(unsafe.Pointer(&p))
+	n := 0
+	for *(*byte)(unsafe.Add(ptr, n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice((*byte)(ptr), n))
+}
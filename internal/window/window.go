@@ -0,0 +1,156 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+// Package window provides a minimal native window for the cmd/ examples and
+// for tests that present to a real surface.
+//
+// A Window is created with New and driven by calling PollEvents once per
+// frame from the goroutine that created it, which must be locked to the main
+// OS thread (call runtime.LockOSThread from an init function). Size reports
+// the drawable size in pixels, Scale the HiDPI factor, and Handle the native
+// handles. A *Window is a wgpu.SurfaceTarget:
+//
+//	w, err := window.New("Triangle", 800, 600)
+//	...
+//	surface, err := instance.CreateSurfaceFromTarget(w)
+//	for w.PollEvents() {
+//		if w.NeedsResize() { /* reconfigure with w.Size() and w.Scale() */ }
+//		...
+//	}
+//
+// Platforms:
+//   - Windows: Win32, per-monitor DPI aware.
+//   - Linux: Wayland (xdg-shell) when WAYLAND_DISPLAY is set and the
+//     compositor supports it, otherwise X11 through Xlib. Set
+//     GOGPU_WINDOW=x11 to force X11 under Wayland.
+//   - macOS: Cocoa, with a CAMetalLayer as the content view's layer.
+//
+// Everything is loaded at run time through goffi, without cgo. Input events
+// are not reported; the package only covers what the examples need.
+package window
+
+import (
+	"errors"
+	"math"
+	"sync/atomic"
+
+	"github.com/gogpu/wgpu"
+)
+
+// ErrUnsupported is returned by New on platforms without a window system
+// implementation.
+var ErrUnsupported = errors.New("window: unsupported platform")
+
+// Kind identifies the window system of a Window.
+type Kind uint8
+
+const (
+	// KindWin32 is a Win32 window. Handle.Display is the HINSTANCE and
+	// Handle.Window the HWND.
+	KindWin32 Kind = iota + 1
+
+	// KindXlib is an X11 window. Handle.Display is the Xlib Display* and
+	// Handle.Window the Window XID.
+	KindXlib
+
+	// KindWayland is a Wayland xdg_toplevel. Handle.Display is the
+	// wl_display* and Handle.Window the wl_surface*.
+	KindWayland
+
+	// KindCocoa is an NSWindow. Handle.Window is the NSWindow* and
+	// Handle.Layer the CAMetalLayer* backing its content view.
+	KindCocoa
+)
+
+// String returns the name of the window system.
+func (k Kind) String() string {
+	switch k {
+	case KindWin32:
+		return "Win32"
+	case KindXlib:
+		return "Xlib"
+	case KindWayland:
+		return "Wayland"
+	case KindCocoa:
+		return "Cocoa"
+	default:
+		return "Unknown"
+	}
+}
+
+// Handle holds the native handles of a Window. They stay valid until the
+// window is destroyed.
+type Handle struct {
+	Kind    Kind
+	Display uintptr
+	Window  uintptr
+	Layer   uintptr
+}
+
+// SurfaceTarget returns the raw surface target of the window, making a
+// *Window usable with wgpu.Instance.CreateSurfaceFromTarget.
+func (w *Window) SurfaceTarget() (wgpu.SurfaceTargetUnsafe, error) {
+	h := w.Handle()
+	switch h.Kind {
+	case KindWin32:
+		return wgpu.SurfaceTargetFromWindowsHWND(h.Display, h.Window), nil
+	case KindXlib:
+		return wgpu.SurfaceTargetFromXlibWindow(h.Display, h.Window), nil
+	case KindWayland:
+		return wgpu.SurfaceTargetFromWaylandSurface(h.Display, h.Window), nil
+	case KindCocoa:
+		return wgpu.SurfaceTargetFromMetalLayer(h.Layer), nil
+	default:
+		return wgpu.SurfaceTargetUnsafe{}, wgpu.ErrInvalidSurfaceTarget
+	}
+}
+
+// physicalSize converts a logical size to pixels with wgpu.PhysicalSize, so
+// the size a Window reports matches what a surface configured from it
+// expects.
+func physicalSize(width, height int32, scale float64) (int32, int32) {
+	w, h := wgpu.PhysicalSize(uint32(max(width, 0)), uint32(max(height, 0)), scale)
+	return int32(min(w, math.MaxInt32)), int32(min(h, math.MaxInt32)) //nolint:gosec // G115: clamped to int32
+}
+
+// resizeState is the resize tracking every platform Window embeds. The
+// platform event handlers call resized and setSizeMove.
+type resizeState struct {
+	inSizeMove  atomic.Bool // true during an interactive resize or move
+	needsResize atomic.Bool // size changed since the last NeedsResize
+	animating   atomic.Bool // PollEvents does not block
+}
+
+// NeedsResize reports whether the window size changed since the last call,
+// and clears the flag. During an interactive resize on Windows and macOS the
+// flag is set once the resize ends.
+func (s *resizeState) NeedsResize() bool {
+	if s.inSizeMove.Load() {
+		return false
+	}
+	return s.needsResize.Swap(false)
+}
+
+// InSizeMove reports whether the window is being resized or moved
+// interactively. Rendering should continue, but surface reconfiguration can
+// wait until NeedsResize reports the final size.
+func (s *resizeState) InSizeMove() bool {
+	return s.inSizeMove.Load()
+}
+
+// SetAnimating selects whether PollEvents returns as soon as pending events
+// are handled (true, the default, for continuous rendering) or waits for at
+// least one event first.
+func (s *resizeState) SetAnimating(animating bool) {
+	s.animating.Store(animating)
+}
+
+// resized records a size change.
+func (s *resizeState) resized() {
+	s.needsResize.Store(true)
+}
+
+// setSizeMove records the start or end of an interactive resize or move.
+func (s *resizeState) setSizeMove(active bool) {
+	s.inSizeMove.Store(active)
+}
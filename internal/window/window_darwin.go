@@ -0,0 +1,343 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build darwin && !ios
+
+package window
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// Objective-C runtime functions and AppKit constants, loaded once.
+var (
+	objcOnce sync.Once
+	objcErr  error
+
+	objcGetClass    cfunc
+	selRegisterName cfunc
+	symMsgSend      unsafe.Pointer
+	symMsgSendStret unsafe.Pointer // amd64 only: struct results over 16 bytes
+
+	nsDefaultRunLoopMode uintptr // NSString*
+	nsApp                uintptr // NSApplication*, set up by the first New
+)
+
+// AppKit enumerations.
+const (
+	nsApplicationActivationPolicyRegular                   = 0
+	nsWindowStyleMaskTitledClosableMiniaturizableResizable = 1 | 2 | 4 | 8
+	nsBackingStoreBuffered                                 = 2
+	nsEventMaskAny                                         = ^uint64(0)
+)
+
+// nsRect is an NSRect (CGRect) on 64-bit platforms.
+type nsRect struct {
+	X, Y, Width, Height float64
+}
+
+var nsRectType = &types.TypeDescriptor{
+	Kind: types.StructType,
+	Members: []*types.TypeDescriptor{
+		types.DoubleTypeDescriptor, types.DoubleTypeDescriptor,
+		types.DoubleTypeDescriptor, types.DoubleTypeDescriptor,
+	},
+}
+
+// loadObjC loads the Objective-C runtime and the frameworks whose classes
+// New uses.
+func loadObjC() {
+	objc, err := loadLibrary("/usr/lib/libobjc.A.dylib")
+	if err != nil {
+		objcErr = err
+		return
+	}
+	for _, framework := range []string{
+		"/System/Library/Frameworks/Foundation.framework/Foundation",
+		"/System/Library/Frameworks/QuartzCore.framework/QuartzCore",
+	} {
+		if _, objcErr = loadLibrary(framework); objcErr != nil {
+			return
+		}
+	}
+	ptr := types.PointerTypeDescriptor
+	if objcErr = objcGetClass.load(objc, "objc_getClass", ptr, ptr); objcErr != nil {
+		return
+	}
+	if objcErr = selRegisterName.load(objc, "sel_registerName", ptr, ptr); objcErr != nil {
+		return
+	}
+	if symMsgSend, objcErr = ffi.GetSymbol(objc, "objc_msgSend"); objcErr != nil {
+		return
+	}
+	if runtime.GOARCH == "amd64" {
+		if symMsgSendStret, objcErr = ffi.GetSymbol(objc, "objc_msgSend_stret"); objcErr != nil {
+			return
+		}
+	}
+	appKit, err := loadLibrary("/System/Library/Frameworks/AppKit.framework/AppKit")
+	if err != nil {
+		objcErr = err
+		return
+	}
+	// NSDefaultRunLoopMode is an NSString* variable.
+	mode, err := ffi.GetSymbol(appKit, "NSDefaultRunLoopMode")
+	if err != nil {
+		objcErr = err
+		return
+	}
+	nsDefaultRunLoopMode = *(*uintptr)(mode)
+}
+
+// objcArg is an argument of msgSend: its type and a pointer to its value.
+type objcArg struct {
+	typ *types.TypeDescriptor
+	ptr unsafe.Pointer
+}
+
+func argID(v uintptr) objcArg {
+	return objcArg{types.PointerTypeDescriptor, unsafe.Pointer(&v)}
+}
+
+func argUint64(v uint64) objcArg {
+	return objcArg{types.UInt64TypeDescriptor, unsafe.Pointer(&v)}
+}
+
+func argBool(v bool) objcArg {
+	var b uint8
+	if v {
+		b = 1
+	}
+	return objcArg{types.UInt8TypeDescriptor, unsafe.Pointer(&b)}
+}
+
+func argFloat64(v float64) objcArg {
+	return objcArg{types.DoubleTypeDescriptor, unsafe.Pointer(&v)}
+}
+
+func argRect(v nsRect) objcArg {
+	return objcArg{nsRectType, unsafe.Pointer(&v)}
+}
+
+// class returns the class named name.
+func class(name string) uintptr {
+	cname := cString(name)
+	p := uintptr(unsafe.Pointer(&cname[0]))
+	var cls uintptr
+	objcGetClass.call(unsafe.Pointer(&cls), unsafe.Pointer(&p))
+	runtime.KeepAlive(cname)
+	return cls
+}
+
+// msgSendRet sends the message sel to obj, storing a result of type ret.
+func msgSendRet(ret *types.TypeDescriptor, result unsafe.Pointer, obj uintptr, sel string, args ...objcArg) {
+	if obj == 0 {
+		return
+	}
+	cname := cString(sel)
+	p := uintptr(unsafe.Pointer(&cname[0]))
+	var selector uintptr
+	selRegisterName.call(unsafe.Pointer(&selector), unsafe.Pointer(&p))
+	runtime.KeepAlive(cname)
+
+	argTypes := []*types.TypeDescriptor{types.PointerTypeDescriptor, types.PointerTypeDescriptor}
+	argPtrs := []unsafe.Pointer{unsafe.Pointer(&obj), unsafe.Pointer(&selector)}
+	for _, arg := range args {
+		argTypes = append(argTypes, arg.typ)
+		argPtrs = append(argPtrs, arg.ptr)
+	}
+	var cif types.CallInterface
+	if err := ffi.PrepareCallInterface(&cif, types.DefaultCall, ret, argTypes); err != nil {
+		return
+	}
+	fn := symMsgSend
+	if ret == nsRectType && symMsgSendStret != nil {
+		fn = symMsgSendStret
+	}
+	_, _ = ffi.CallFunction(&cif, fn, result, argPtrs)
+	runtime.KeepAlive(args)
+}
+
+// msgSend sends sel to obj and returns an object result.
+func msgSend(obj uintptr, sel string, args ...objcArg) uintptr {
+	var result uintptr
+	msgSendRet(types.PointerTypeDescriptor, unsafe.Pointer(&result), obj, sel, args...)
+	return result
+}
+
+// msgSendBool sends sel to obj and returns a BOOL result.
+func msgSendBool(obj uintptr, sel string, args ...objcArg) bool {
+	var result uint8
+	msgSendRet(types.UInt8TypeDescriptor, unsafe.Pointer(&result), obj, sel, args...)
+	return result != 0
+}
+
+// msgSendFloat64 sends sel to obj and returns a CGFloat result.
+func msgSendFloat64(obj uintptr, sel string) float64 {
+	var result float64
+	msgSendRet(types.DoubleTypeDescriptor, unsafe.Pointer(&result), obj, sel)
+	return result
+}
+
+// msgSendRect sends sel to obj and returns an NSRect result.
+func msgSendRect(obj uintptr, sel string) nsRect {
+	var result nsRect
+	msgSendRet(nsRectType, unsafe.Pointer(&result), obj, sel)
+	return result
+}
+
+// nsString returns a retained NSString holding s.
+func nsString(s string) uintptr {
+	cs := cString(s)
+	str := msgSend(msgSend(class("NSString"), "alloc"), "initWithUTF8String:", argID(uintptr(unsafe.Pointer(&cs[0]))))
+	runtime.KeepAlive(cs)
+	return str
+}
+
+// autoreleasePool returns a new NSAutoreleasePool; drain it with
+// msgSend(pool, "drain").
+func autoreleasePool() uintptr {
+	return msgSend(msgSend(class("NSAutoreleasePool"), "alloc"), "init")
+}
+
+// Window is an NSWindow whose content view is backed by a CAMetalLayer.
+type Window struct {
+	resizeState
+
+	window uintptr // NSWindow*
+	view   uintptr // NSView*
+	layer  uintptr // CAMetalLayer*
+
+	width, height int32 // pixels
+	backingScale  float64
+}
+
+// New creates and shows a window with the given title and content size in
+// points. The calling goroutine must be locked to the main thread.
+func New(title string, width, height int32) (*Window, error) {
+	objcOnce.Do(loadObjC)
+	if objcErr != nil {
+		return nil, objcErr
+	}
+	pool := autoreleasePool()
+	defer msgSend(pool, "drain")
+
+	if nsApp == 0 {
+		nsApp = msgSend(class("NSApplication"), "sharedApplication")
+		if nsApp == 0 {
+			return nil, errors.New("window: NSApplication unavailable")
+		}
+		msgSend(nsApp, "setActivationPolicy:", argUint64(nsApplicationActivationPolicyRegular))
+		msgSend(nsApp, "finishLaunching")
+	}
+
+	w := &Window{}
+	w.animating.Store(true)
+	w.window = msgSend(msgSend(class("NSWindow"), "alloc"), "initWithContentRect:styleMask:backing:defer:",
+		argRect(nsRect{Width: float64(width), Height: float64(height)}),
+		argUint64(nsWindowStyleMaskTitledClosableMiniaturizableResizable),
+		argUint64(nsBackingStoreBuffered),
+		argBool(false))
+	if w.window == 0 {
+		return nil, errors.New("window: NSWindow creation failed")
+	}
+	msgSend(w.window, "setReleasedWhenClosed:", argBool(false))
+	nsTitle := nsString(title)
+	msgSend(w.window, "setTitle:", argID(nsTitle))
+	msgSend(nsTitle, "release")
+	msgSend(w.window, "center")
+
+	w.view = msgSend(w.window, "contentView")
+	w.layer = msgSend(msgSend(class("CAMetalLayer"), "layer"), "retain")
+	msgSend(w.view, "setWantsLayer:", argBool(true))
+	msgSend(w.view, "setLayer:", argID(w.layer))
+	w.updateSize()
+
+	msgSend(w.window, "makeKeyAndOrderFront:", argID(0))
+	msgSend(nsApp, "activateIgnoringOtherApps:", argBool(true))
+	return w, nil
+}
+
+// Destroy closes and releases the window.
+func (w *Window) Destroy() {
+	if w.window == 0 {
+		return
+	}
+	msgSend(w.window, "close")
+	msgSend(w.layer, "release")
+	msgSend(w.window, "release")
+	w.window, w.view, w.layer = 0, 0, 0
+}
+
+// Handle returns the NSWindow and its CAMetalLayer.
+func (w *Window) Handle() Handle {
+	return Handle{Kind: KindCocoa, Window: w.window, Layer: w.layer}
+}
+
+// Size returns the size of the content view in pixels.
+func (w *Window) Size() (width, height int32) {
+	return w.width, w.height
+}
+
+// Scale returns the backing scale factor of the window, 2 on Retina
+// displays.
+func (w *Window) Scale() float64 {
+	return w.backingScale
+}
+
+// PollEvents processes pending window events.
+// Returns false when the window should close.
+func (w *Window) PollEvents() bool {
+	pool := autoreleasePool()
+	defer msgSend(pool, "drain")
+
+	until := msgSend(class("NSDate"), "distantPast")
+	if !w.animating.Load() {
+		until = msgSend(class("NSDate"), "distantFuture")
+	}
+	for {
+		event := msgSend(nsApp, "nextEventMatchingMask:untilDate:inMode:dequeue:",
+			argUint64(nsEventMaskAny), argID(until), argID(nsDefaultRunLoopMode), argBool(true))
+		if event == 0 {
+			break
+		}
+		msgSend(nsApp, "sendEvent:", argID(event))
+		until = msgSend(class("NSDate"), "distantPast")
+	}
+	msgSend(nsApp, "updateWindows")
+
+	w.setSizeMove(msgSendBool(w.view, "inLiveResize"))
+	if w.updateSize() {
+		w.resized()
+	}
+	// The close button orders the window out; it is not released.
+	return msgSendBool(w.window, "isVisible") || msgSendBool(w.window, "isMiniaturized")
+}
+
+// updateSize reads the content view size and backing scale, keeps the
+// layer's contents scale in step, and reports whether the pixel size
+// changed.
+func (w *Window) updateSize() bool {
+	scale := msgSendFloat64(w.window, "backingScaleFactor")
+	if scale <= 0 {
+		scale = 1
+	}
+	if scale != w.backingScale {
+		w.backingScale = scale
+		msgSend(w.layer, "setContentsScale:", argFloat64(scale))
+	}
+	bounds := msgSendRect(w.view, "bounds")
+	width, height := physicalSize(int32(math.Round(bounds.Width)), int32(math.Round(bounds.Height)), scale)
+	if width == w.width && height == w.height {
+		return false
+	}
+	w.width, w.height = width, height
+	return true
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package window
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Window is a Wayland or X11 window.
+type Window struct {
+	resizeState
+
+	native nativeWindow
+}
+
+// nativeWindow is the window system behind a Linux Window.
+type nativeWindow interface {
+	handle() Handle
+	size() (width, height int32)
+	scale() float64
+
+	// pollEvents handles pending events, first waiting for one when block
+	// is set, and returns false once the window was closed.
+	pollEvents(rs *resizeState, block bool) bool
+
+	destroy()
+}
+
+// New creates and shows a window with the given title and size in logical
+// units. Under Wayland the window has no decorations unless the compositor
+// draws them.
+func New(title string, width, height int32) (*Window, error) {
+	w := &Window{}
+	w.animating.Store(true)
+
+	useWayland := os.Getenv("WAYLAND_DISPLAY") != "" && !strings.EqualFold(os.Getenv("GOGPU_WINDOW"), "x11")
+	var waylandErr error
+	if useWayland {
+		native, err := newWaylandWindow(title, width, height)
+		if err == nil {
+			w.native = native
+			return w, nil
+		}
+		waylandErr = err
+	}
+	native, err := newX11Window(title, width, height)
+	if err != nil {
+		if waylandErr != nil {
+			err = errors.Join(waylandErr, err)
+		}
+		return nil, fmt.Errorf("window: no window system available: %w", err)
+	}
+	w.native = native
+	return w, nil
+}
+
+// Destroy closes the window and its display connection.
+func (w *Window) Destroy() {
+	if w.native != nil {
+		w.native.destroy()
+		w.native = nil
+	}
+}
+
+// Handle returns the native display and window handles.
+func (w *Window) Handle() Handle {
+	return w.native.handle()
+}
+
+// Size returns the size of the window in pixels.
+func (w *Window) Size() (width, height int32) {
+	return w.native.size()
+}
+
+// Scale returns the HiDPI scale of the window: the output scale under
+// Wayland, and Xft.dpi / 96 under X11.
+func (w *Window) Scale() float64 {
+	return w.native.scale()
+}
+
+// PollEvents processes pending window events.
+// Returns false when the window should close.
+func (w *Window) PollEvents() bool {
+	return w.native.pollEvents(&w.resizeState, !w.animating.Load())
+}
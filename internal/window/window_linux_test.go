@@ -0,0 +1,47 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package window
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestParseXftDPI(t *testing.T) {
+	tests := []struct {
+		resources string
+		want      float64
+	}{
+		{"", 1},
+		{"Xft.antialias:\t1\nXft.dpi:\t192\nXft.hinting:\t1\n", 2},
+		{"Xft.dpi: 144", 1.5},
+		{"Xft.dpi:\tlarge\n", 1},
+		{"Xcursor.size:\t24\n", 1},
+	}
+	for _, tt := range tests {
+		if got := parseXftDPI(tt.resources); got != tt.want {
+			t.Errorf("parseXftDPI(%q) = %v, want %v", tt.resources, got, tt.want)
+		}
+	}
+}
+
+func TestWindowSurfaceTarget(t *testing.T) {
+	tests := []struct {
+		native nativeWindow
+		want   wgpu.SurfaceTargetUnsafe
+	}{
+		{&x11Window{display: 0x10, window: 0x20}, wgpu.SurfaceTargetFromXlibWindow(0x10, 0x20)},
+		{&waylandWindow{display: 0x30, surface: 0x40}, wgpu.SurfaceTargetFromWaylandSurface(0x30, 0x40)},
+	}
+	for _, tt := range tests {
+		w := &Window{native: tt.native}
+		got, err := w.SurfaceTarget()
+		if err != nil || got != tt.want {
+			t.Errorf("SurfaceTarget() of a %s window = %+v, %v; want %+v", w.Handle().Kind, got, err, tt.want)
+		}
+	}
+}
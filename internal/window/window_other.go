@@ -0,0 +1,31 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !windows && !(linux && !android) && !(darwin && !ios)
+
+package window
+
+// Window is not implemented on this platform.
+type Window struct {
+	resizeState
+}
+
+// New returns ErrUnsupported.
+func New(string, int32, int32) (*Window, error) {
+	return nil, ErrUnsupported
+}
+
+// Destroy does nothing.
+func (w *Window) Destroy() {}
+
+// Handle returns the zero Handle.
+func (w *Window) Handle() Handle { return Handle{} }
+
+// Size returns zero.
+func (w *Window) Size() (width, height int32) { return 0, 0 }
+
+// Scale returns 1.
+func (w *Window) Scale() float64 { return 1 }
+
+// PollEvents returns false.
+func (w *Window) PollEvents() bool { return false }
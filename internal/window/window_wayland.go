@@ -0,0 +1,505 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package window
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+	"golang.org/x/sys/unix"
+)
+
+// libwayland-client functions and interfaces, loaded once.
+var (
+	wlOnce sync.Once
+	wlErr  error
+
+	wlDisplayConnect       cfunc
+	wlDisplayDisconnect    cfunc
+	wlDisplayRoundtrip     cfunc
+	wlDisplayFlush         cfunc
+	wlDisplayDispatchPend  cfunc
+	wlDisplayPrepareRead   cfunc
+	wlDisplayReadEvents    cfunc
+	wlDisplayCancelRead    cfunc
+	wlDisplayGetFd         cfunc
+	wlProxyMarshalArray    cfunc // wl_proxy_marshal_array(proxy, opcode, args)
+	wlProxyMarshalArrayNew cfunc // wl_proxy_marshal_array_constructor_versioned(proxy, opcode, args, iface, version)
+	wlProxyAddListener     cfunc
+	wlProxyDestroy         cfunc
+
+	// Core interfaces, data symbols of libwayland-client.
+	wlRegistryInterface   uintptr
+	wlCompositorInterface uintptr
+	wlSurfaceInterface    uintptr
+	wlOutputInterface     uintptr
+)
+
+// Request opcodes and the versions bound.
+const (
+	wlDisplayGetRegistry      = 1
+	wlRegistryBind            = 0
+	wlCompositorCreateSurf    = 0
+	wlSurfaceDestroy          = 0
+	wlSurfaceCommit           = 6
+	wlSurfaceSetBufferScale   = 8 // since wl_surface version 3
+	xdgWmBaseDestroy          = 0
+	xdgWmBaseGetXdgSurface    = 2
+	xdgWmBasePong             = 3
+	xdgSurfaceDestroy         = 0
+	xdgSurfaceGetToplevel     = 1
+	xdgSurfaceAckConfigure    = 4
+	xdgToplevelDestroy        = 0
+	xdgToplevelSetTitle       = 2
+	wlCompositorMaxVersion    = 4
+	wlOutputMaxVersion        = 2 // the scale event
+	wlSurfaceBufferScaleSince = 3
+)
+
+// wlMessage and wlInterface mirror struct wl_message and struct
+// wl_interface, for the xdg-shell interfaces libwayland-client does not
+// export.
+type wlMessage struct {
+	name      *byte
+	signature *byte
+	types     *uintptr
+}
+
+type wlInterface struct {
+	name        *byte
+	version     int32
+	methodCount int32
+	methods     *wlMessage
+	eventCount  int32
+	events      *wlMessage
+}
+
+// xdg-shell interfaces at version 1, built like wayland-scanner output. No
+// message refers to the interface of its object arguments, so every
+// message's types point at wlNullTypes.
+var (
+	wlNullTypes [8]uintptr
+
+	xdgWmBaseInterface   = newWlInterface("xdg_wm_base", []string{"destroy", "", "create_positioner", "n", "get_xdg_surface", "no", "pong", "u"}, []string{"ping", "u"})
+	xdgSurfaceInterface  = newWlInterface("xdg_surface", []string{"destroy", "", "get_toplevel", "n", "get_popup", "n?oo", "set_window_geometry", "iiii", "ack_configure", "u"}, []string{"configure", "u"})
+	xdgToplevelInterface = newWlInterface("xdg_toplevel", []string{
+		"destroy", "", "set_parent", "?o", "set_title", "s", "set_app_id", "s",
+		"show_window_menu", "ouii", "move", "ou", "resize", "ouu", "set_max_size", "ii",
+		"set_min_size", "ii", "set_maximized", "", "unset_maximized", "", "set_fullscreen", "?o",
+		"unset_fullscreen", "", "set_minimized", "",
+	}, []string{"configure", "iia", "close", ""})
+)
+
+// newWlInterface builds a version 1 wl_interface from name/signature pairs.
+// The result is never freed, so C may keep pointers into it.
+func newWlInterface(name string, methods, events []string) *wlInterface {
+	messages := func(pairs []string) []wlMessage {
+		out := make([]wlMessage, len(pairs)/2)
+		for i := range out {
+			out[i] = wlMessage{name: &cString(pairs[2*i])[0], signature: &cString(pairs[2*i+1])[0], types: &wlNullTypes[0]}
+		}
+		return out
+	}
+	iface := &wlInterface{name: &cString(name)[0], version: 1}
+	if m := messages(methods); len(m) > 0 {
+		iface.methodCount, iface.methods = int32(len(m)), &m[0] //nolint:gosec // G115: small message count
+	}
+	if e := messages(events); len(e) > 0 {
+		iface.eventCount, iface.events = int32(len(e)), &e[0] //nolint:gosec // G115: small message count
+	}
+	return iface
+}
+
+// Listener tables, shared by all Wayland windows. The listener data is the
+// window's id in waylandWindows.
+var (
+	wlListenersOnce     sync.Once
+	wlRegistryListener  [2]uintptr
+	wlOutputListener    [4]uintptr
+	wlSurfaceListener   [2]uintptr
+	xdgWmBaseListener   [1]uintptr
+	xdgSurfaceListener  [1]uintptr
+	xdgToplevelListener [2]uintptr
+
+	waylandWindows = map[uintptr]*waylandWindow{}
+	waylandNextID  uintptr
+)
+
+// loadWayland loads libwayland-client and its functions.
+func loadWayland() {
+	lib, err := loadLibrary("libwayland-client.so.0", "libwayland-client.so")
+	if err != nil {
+		wlErr = err
+		return
+	}
+	ptr, i32, u32, void := types.PointerTypeDescriptor, types.SInt32TypeDescriptor, types.UInt32TypeDescriptor, types.VoidTypeDescriptor
+	funcs := []struct {
+		f    *cfunc
+		name string
+		ret  *types.TypeDescriptor
+		args []*types.TypeDescriptor
+	}{
+		{&wlDisplayConnect, "wl_display_connect", ptr, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayDisconnect, "wl_display_disconnect", void, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayRoundtrip, "wl_display_roundtrip", i32, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayFlush, "wl_display_flush", i32, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayDispatchPend, "wl_display_dispatch_pending", i32, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayPrepareRead, "wl_display_prepare_read", i32, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayReadEvents, "wl_display_read_events", i32, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayCancelRead, "wl_display_cancel_read", void, []*types.TypeDescriptor{ptr}},
+		{&wlDisplayGetFd, "wl_display_get_fd", i32, []*types.TypeDescriptor{ptr}},
+		{&wlProxyMarshalArray, "wl_proxy_marshal_array", void, []*types.TypeDescriptor{ptr, u32, ptr}},
+		{&wlProxyMarshalArrayNew, "wl_proxy_marshal_array_constructor_versioned", ptr, []*types.TypeDescriptor{ptr, u32, ptr, ptr, u32}},
+		{&wlProxyAddListener, "wl_proxy_add_listener", i32, []*types.TypeDescriptor{ptr, ptr, ptr}},
+		{&wlProxyDestroy, "wl_proxy_destroy", void, []*types.TypeDescriptor{ptr}},
+	}
+	for _, fn := range funcs {
+		if wlErr = fn.f.load(lib, fn.name, fn.ret, fn.args...); wlErr != nil {
+			return
+		}
+	}
+	interfaces := []struct {
+		dst  *uintptr
+		name string
+	}{
+		{&wlRegistryInterface, "wl_registry_interface"},
+		{&wlCompositorInterface, "wl_compositor_interface"},
+		{&wlSurfaceInterface, "wl_surface_interface"},
+		{&wlOutputInterface, "wl_output_interface"},
+	}
+	for _, iface := range interfaces {
+		sym, err := ffi.GetSymbol(lib, iface.name)
+		if err != nil {
+			wlErr = fmt.Errorf("window: missing symbol %s: %w", iface.name, err)
+			return
+		}
+		*iface.dst = uintptr(sym)
+	}
+}
+
+// waylandWindow is an xdg_toplevel with its own display connection.
+type waylandWindow struct {
+	id      uintptr
+	display uintptr
+
+	registry   uintptr
+	compositor uintptr
+	wmBase     uintptr
+	surface    uintptr
+	xdgSurface uintptr
+	toplevel   uintptr
+
+	compositorName, compositorVersion uint32
+	wmBaseName                        uint32
+	outputs                           map[uintptr]int32 // wl_output -> scale
+	entered                           map[uintptr]bool  // outputs the surface is on
+
+	// Logical size, the size of the last toplevel configure, and the
+	// buffer scale.
+	width, height               int32
+	pendingWidth, pendingHeight int32
+	bufferScale                 int32
+	configured                  bool
+	closed                      bool
+	changed                     bool
+}
+
+// newWaylandWindow connects to the compositor and creates a toplevel.
+func newWaylandWindow(title string, width, height int32) (*waylandWindow, error) {
+	wlOnce.Do(loadWayland)
+	if wlErr != nil {
+		return nil, wlErr
+	}
+	wlListenersOnce.Do(initWaylandListeners)
+
+	waylandNextID++
+	w := &waylandWindow{
+		id:          waylandNextID,
+		width:       width,
+		height:      height,
+		bufferScale: 1,
+		outputs:     map[uintptr]int32{},
+		entered:     map[uintptr]bool{},
+	}
+	var name uintptr
+	wlDisplayConnect.call(unsafe.Pointer(&w.display), unsafe.Pointer(&name))
+	if w.display == 0 {
+		return nil, errors.New("window: wl_display_connect failed")
+	}
+	waylandWindows[w.id] = w
+
+	w.registry = w.marshalNew(w.display, wlDisplayGetRegistry, wlRegistryInterface, 1, 0)
+	w.addListener(w.registry, &wlRegistryListener[0])
+	w.roundtrip()
+	if w.compositorName == 0 || w.wmBaseName == 0 {
+		w.destroy()
+		return nil, errors.New("window: compositor lacks wl_compositor or xdg_wm_base")
+	}
+
+	w.compositorVersion = min(w.compositorVersion, wlCompositorMaxVersion)
+	w.compositor = w.bind(w.compositorName, wlCompositorInterface, "wl_compositor", w.compositorVersion)
+	w.wmBase = w.bind(w.wmBaseName, uintptr(unsafe.Pointer(xdgWmBaseInterface)), "xdg_wm_base", 1)
+	w.addListener(w.wmBase, &xdgWmBaseListener[0])
+
+	w.surface = w.marshalNew(w.compositor, wlCompositorCreateSurf, wlSurfaceInterface, w.compositorVersion, 0)
+	w.addListener(w.surface, &wlSurfaceListener[0])
+	w.xdgSurface = w.marshalNew(w.wmBase, xdgWmBaseGetXdgSurface, uintptr(unsafe.Pointer(xdgSurfaceInterface)), 1, 0, w.surface)
+	w.addListener(w.xdgSurface, &xdgSurfaceListener[0])
+	w.toplevel = w.marshalNew(w.xdgSurface, xdgSurfaceGetToplevel, uintptr(unsafe.Pointer(xdgToplevelInterface)), 1, 0)
+	w.addListener(w.toplevel, &xdgToplevelListener[0])
+
+	ctitle := cString(title)
+	w.marshal(w.toplevel, xdgToplevelSetTitle, uintptr(unsafe.Pointer(&ctitle[0])))
+	runtime.KeepAlive(ctitle)
+
+	// The first commit without a buffer asks for the initial configure. The
+	// surface maps when the first frame is presented.
+	w.marshal(w.surface, wlSurfaceCommit)
+	for !w.configured && !w.closed {
+		if !w.roundtrip() {
+			w.destroy()
+			return nil, errors.New("window: Wayland connection lost before the first configure")
+		}
+	}
+	w.applyConfigure()
+	w.changed = false
+	return w, nil
+}
+
+func (w *waylandWindow) handle() Handle {
+	return Handle{Kind: KindWayland, Display: w.display, Window: w.surface}
+}
+
+func (w *waylandWindow) size() (width, height int32) {
+	return w.width * w.bufferScale, w.height * w.bufferScale
+}
+
+func (w *waylandWindow) scale() float64 {
+	return float64(w.bufferScale)
+}
+
+func (w *waylandWindow) pollEvents(rs *resizeState, block bool) bool {
+	var ret int32
+	for wlDisplayPrepareRead.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display)); ret != 0; wlDisplayPrepareRead.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display)) {
+		if !w.dispatchPending() {
+			return false
+		}
+	}
+	wlDisplayFlush.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display))
+
+	var fd int32
+	wlDisplayGetFd.call(unsafe.Pointer(&fd), unsafe.Pointer(&w.display))
+	timeout := 0
+	if block {
+		timeout = -1
+	}
+	fds := []unix.PollFd{{Fd: fd, Events: unix.POLLIN}}
+	if n, err := unix.Poll(fds, timeout); err == nil && n > 0 {
+		wlDisplayReadEvents.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display))
+	} else {
+		wlDisplayCancelRead.call(nil, unsafe.Pointer(&w.display))
+	}
+	if !w.dispatchPending() {
+		return false
+	}
+
+	w.applyConfigure()
+	if w.changed {
+		w.changed = false
+		rs.resized()
+	}
+	return !w.closed
+}
+
+func (w *waylandWindow) destroy() {
+	if w.display == 0 {
+		return
+	}
+	for _, p := range []struct {
+		proxy  *uintptr
+		opcode int32 // destructor request, -1 for none
+	}{
+		{&w.toplevel, xdgToplevelDestroy},
+		{&w.xdgSurface, xdgSurfaceDestroy},
+		{&w.surface, wlSurfaceDestroy},
+		{&w.wmBase, xdgWmBaseDestroy},
+		{&w.compositor, -1},
+		{&w.registry, -1},
+	} {
+		if *p.proxy == 0 {
+			continue
+		}
+		if p.opcode >= 0 {
+			w.marshal(*p.proxy, uint32(p.opcode))
+		}
+		wlProxyDestroy.call(nil, unsafe.Pointer(p.proxy))
+		*p.proxy = 0
+	}
+	for output := range w.outputs {
+		wlProxyDestroy.call(nil, unsafe.Pointer(&output))
+	}
+	wlDisplayDisconnect.call(nil, unsafe.Pointer(&w.display))
+	w.display = 0
+	delete(waylandWindows, w.id)
+}
+
+// applyConfigure takes the size of the last toplevel configure and the
+// scale of the outputs the surface is on, and notes whether either changed.
+func (w *waylandWindow) applyConfigure() {
+	if w.pendingWidth > 0 && w.pendingHeight > 0 && (w.pendingWidth != w.width || w.pendingHeight != w.height) {
+		w.width, w.height = w.pendingWidth, w.pendingHeight
+		w.changed = true
+	}
+	scale := int32(1)
+	for output := range w.entered {
+		scale = max(scale, w.outputs[output])
+	}
+	if scale != w.bufferScale && w.compositorVersion >= wlSurfaceBufferScaleSince {
+		// Applies with the next commit, which presents a buffer of the new
+		// Size once the application reconfigures its surface.
+		w.bufferScale = scale
+		w.marshal(w.surface, wlSurfaceSetBufferScale, uintptr(scale))
+		w.changed = true
+	}
+}
+
+// roundtrip blocks until the compositor handled every request, and returns
+// false when the connection failed.
+func (w *waylandWindow) roundtrip() bool {
+	var ret int32
+	wlDisplayRoundtrip.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display))
+	return ret >= 0
+}
+
+// dispatchPending runs the listeners of the events read, and returns false
+// when the connection failed.
+func (w *waylandWindow) dispatchPending() bool {
+	var ret int32
+	wlDisplayDispatchPend.call(unsafe.Pointer(&ret), unsafe.Pointer(&w.display))
+	if ret < 0 {
+		w.closed = true
+	}
+	return ret >= 0
+}
+
+// marshal sends a request without a new object. Each of args is one
+// union wl_argument: an integer, or a pointer to a string or object.
+func (w *waylandWindow) marshal(proxy uintptr, opcode uint32, args ...uintptr) {
+	argv := make([]uintptr, max(len(args), 1))
+	copy(argv, args)
+	argp := uintptr(unsafe.Pointer(&argv[0]))
+	wlProxyMarshalArray.call(nil, unsafe.Pointer(&proxy), unsafe.Pointer(&opcode), unsafe.Pointer(&argp))
+	runtime.KeepAlive(argv)
+}
+
+// marshalNew sends a request that creates an object of iface at version,
+// and returns its proxy. args include a zero for the new_id argument.
+func (w *waylandWindow) marshalNew(proxy uintptr, opcode uint32, iface uintptr, version uint32, args ...uintptr) uintptr {
+	argp := uintptr(unsafe.Pointer(&args[0]))
+	var result uintptr
+	wlProxyMarshalArrayNew.call(unsafe.Pointer(&result), unsafe.Pointer(&proxy), unsafe.Pointer(&opcode),
+		unsafe.Pointer(&argp), unsafe.Pointer(&iface), unsafe.Pointer(&version))
+	runtime.KeepAlive(args)
+	return result
+}
+
+// bind binds the registry global name as iface at version.
+func (w *waylandWindow) bind(name uint32, iface uintptr, ifaceName string, version uint32) uintptr {
+	cname := cString(ifaceName)
+	proxy := w.marshalNew(w.registry, wlRegistryBind, iface, version,
+		uintptr(name), uintptr(unsafe.Pointer(&cname[0])), uintptr(version), 0)
+	runtime.KeepAlive(cname)
+	return proxy
+}
+
+// addListener sets the listener table of proxy, with the window id as data.
+func (w *waylandWindow) addListener(proxy uintptr, listener *uintptr) {
+	impl := uintptr(unsafe.Pointer(listener))
+	var ret int32
+	wlProxyAddListener.call(unsafe.Pointer(&ret), unsafe.Pointer(&proxy), unsafe.Pointer(&impl), unsafe.Pointer(&w.id))
+}
+
+// initWaylandListeners creates the listener callbacks. goffi callbacks are
+// never freed, so there is one set for all windows.
+func initWaylandListeners() {
+	wlRegistryListener = [2]uintptr{
+		ffi.NewCallback(func(data, _, name, iface, version uintptr) {
+			w := waylandWindows[data]
+			if w == nil {
+				return
+			}
+			switch goString(iface) {
+			case "wl_compositor":
+				w.compositorName, w.compositorVersion = uint32(name), uint32(version) //nolint:gosec // G115: uint32 arguments
+			case "xdg_wm_base":
+				w.wmBaseName = uint32(name) //nolint:gosec // G115: uint32 argument
+			case "wl_output":
+				if uint32(version) < wlOutputMaxVersion { //nolint:gosec // G115: uint32 argument
+					return
+				}
+				output := w.bind(uint32(name), wlOutputInterface, "wl_output", wlOutputMaxVersion) //nolint:gosec // G115: uint32 argument
+				w.outputs[output] = 1
+				w.addListener(output, &wlOutputListener[0])
+			}
+		}),
+		ffi.NewCallback(func(_, _, _ uintptr) {}), // global_remove
+	}
+	wlOutputListener = [4]uintptr{
+		ffi.NewCallback(func(_, _, _, _, _, _, _, _, _, _ uintptr) {}), // geometry
+		ffi.NewCallback(func(_, _, _, _, _, _ uintptr) {}),             // mode
+		ffi.NewCallback(func(_, _ uintptr) {}),                         // done
+		ffi.NewCallback(func(data, output, factor uintptr) { // scale
+			if w := waylandWindows[data]; w != nil {
+				w.outputs[output] = max(int32(factor), 1) //nolint:gosec // G115: int32 argument
+			}
+		}),
+	}
+	wlSurfaceListener = [2]uintptr{
+		ffi.NewCallback(func(data, _, output uintptr) { // enter
+			if w := waylandWindows[data]; w != nil {
+				w.entered[output] = true
+			}
+		}),
+		ffi.NewCallback(func(data, _, output uintptr) { // leave
+			if w := waylandWindows[data]; w != nil {
+				delete(w.entered, output)
+			}
+		}),
+	}
+	xdgWmBaseListener = [1]uintptr{
+		ffi.NewCallback(func(data, wmBase, serial uintptr) { // ping
+			if w := waylandWindows[data]; w != nil {
+				w.marshal(wmBase, xdgWmBasePong, serial)
+			}
+		}),
+	}
+	xdgSurfaceListener = [1]uintptr{
+		ffi.NewCallback(func(data, xdgSurface, serial uintptr) { // configure
+			if w := waylandWindows[data]; w != nil {
+				w.marshal(xdgSurface, xdgSurfaceAckConfigure, serial)
+				w.configured = true
+			}
+		}),
+	}
+	xdgToplevelListener = [2]uintptr{
+		ffi.NewCallback(func(data, _, width, height, _ uintptr) { // configure
+			if w := waylandWindows[data]; w != nil {
+				// Zero leaves the size to the client.
+				w.pendingWidth, w.pendingHeight = int32(width), int32(height) //nolint:gosec // G115: int32 arguments
+			}
+		}),
+		ffi.NewCallback(func(data, _ uintptr) { // close
+			if w := waylandWindows[data]; w != nil {
+				w.closed = true
+			}
+		}),
+	}
+}
@@ -3,11 +3,10 @@
 
 //go:build windows
 
-package main
+package window
 
 import (
 	"fmt"
-	"sync/atomic"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -31,9 +30,16 @@ var (
 	procPostQuitMessage    = user32.NewProc("PostQuitMessage")
 	procGetClientRect      = user32.NewProc("GetClientRect")
 	procAdjustWindowRectEx = user32.NewProc("AdjustWindowRectEx")
-	procSetWindowLongPtrW  = user32.NewProc("SetWindowLongPtrW")
+	procSetWindowPos       = user32.NewProc("SetWindowPos")
 	procLoadCursorW        = user32.NewProc("LoadCursorW")
 	procSetCursor          = user32.NewProc("SetCursor")
+	procBeginPaint         = user32.NewProc("BeginPaint")
+	procEndPaint           = user32.NewProc("EndPaint")
+
+	// Windows 10 1607+; Scale falls back to 1 without them.
+	procSetProcessDpiAwarenessContext = user32.NewProc("SetProcessDpiAwarenessContext")
+	procGetDpiForWindow               = user32.NewProc("GetDpiForWindow")
+	procGetDpiForSystem               = user32.NewProc("GetDpiForSystem")
 )
 
 const (
@@ -41,26 +47,36 @@ const (
 
 	// Window styles
 	wsOverlappedWindow = 0x00CF0000 // Standard overlapped window with all buttons
-	wsVisible          = 0x10000000
 
 	swShow = 5
 
 	// Window messages
 	wmDestroy       = 0x0002
 	wmSize          = 0x0005
+	wmPaint         = 0x000F
 	wmClose         = 0x0010
 	wmQuit          = 0x0012
 	wmSetCursor     = 0x0020
 	wmEnterSizeMove = 0x0231
 	wmExitSizeMove  = 0x0232
+	wmDpiChanged    = 0x02E0
 
 	pmRemove = 0x0001
 
+	swpNoZOrder   = 0x0004
+	swpNoActivate = 0x0010
+
 	// Cursor constants
 	idcArrow = 32512
 
 	// WM_SETCURSOR hit test codes
 	htClient = 1
+
+	// DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2
+	dpiAwarenessContextPerMonitorAwareV2 = ^uintptr(3)
+
+	// USER_DEFAULT_SCREEN_DPI
+	defaultDPI = 96
 )
 
 type wndClassExW struct {
@@ -99,65 +115,87 @@ type rect struct {
 	Bottom int32
 }
 
-// Window represents a platform window with professional event handling.
-// Uses hybrid GetMessage/PeekMessage pattern from Gio for responsiveness.
+// paintStruct is the Windows PAINTSTRUCT.
+type paintStruct struct {
+	HDC         uintptr
+	FErase      int32
+	RcPaint     rect
+	FRestore    int32
+	FIncUpdate  int32
+	RGBReserved [32]byte
+}
+
+// Window is a Win32 window. Uses the hybrid GetMessage/PeekMessage pattern
+// from Gio for responsiveness.
 type Window struct {
+	resizeState
+
 	hwnd      uintptr
 	hInstance uintptr
 	cursor    uintptr // Default arrow cursor
-	width     int32
-	height    int32
 	running   bool
+}
 
-	// Resize handling (professional pattern from Gio/wgpu)
-	inSizeMove  atomic.Bool // True during modal resize/move loop
-	needsResize atomic.Bool // Resize pending after size move ends
-	pendingW    atomic.Int32
-	pendingH    atomic.Int32
+// windowClassName is the class every Window registers under.
+const windowClassName = "GoGPUWindow"
 
-	// Animation state for hybrid event loop
-	animating atomic.Bool
-}
+// classRegistered reports whether the window class is registered.
+var classRegistered bool
 
-// Global window pointer for wndProc callback
-var globalWindow *Window
+// windowsByHWND maps window handles to their Window for wndProc. Only the
+// thread that creates windows touches it.
+var windowsByHWND = map[uintptr]*Window{}
 
-// NewWindow creates a new window with the given title and size.
-func NewWindow(title string, width, height int32) (*Window, error) {
+// New creates and shows a window with the given title and client area size
+// in logical units, scaled by the system DPI.
+func New(title string, width, height int32) (*Window, error) {
 	hInstance, _, _ := procGetModuleHandleW.Call(0)
 
-	className, err := windows.UTF16PtrFromString("VulkanTriangleWindow")
+	className, err := windows.UTF16PtrFromString(windowClassName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create class name: %w", err)
+		return nil, fmt.Errorf("window: class name: %w", err)
 	}
 	windowTitle, err := windows.UTF16PtrFromString(title)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create window title: %w", err)
+		return nil, fmt.Errorf("window: title: %w", err)
+	}
+
+	// Opt into per-monitor DPI so GetClientRect reports pixels and the
+	// system does not stretch the surface. Fails harmlessly when the
+	// awareness was already set, for example by a manifest.
+	if procSetProcessDpiAwarenessContext.Find() == nil {
+		_, _, _ = procSetProcessDpiAwarenessContext.Call(dpiAwarenessContextPerMonitorAwareV2)
 	}
 
 	// Load default arrow cursor
 	cursor, _, _ := procLoadCursorW.Call(0, uintptr(idcArrow))
 
-	wc := wndClassExW{
-		Size:      uint32(unsafe.Sizeof(wndClassExW{})),
-		Style:     csOwnDC,
-		WndProc:   windows.NewCallback(wndProc),
-		Instance:  hInstance,
-		Cursor:    cursor,
-		ClassName: className,
-	}
-
-	ret, _, callErr := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))) //nolint:gosec // G103: Win32 API
-	if ret == 0 {
-		return nil, fmt.Errorf("RegisterClassExW failed: %w", callErr)
+	if !classRegistered {
+		wc := wndClassExW{
+			Size:      uint32(unsafe.Sizeof(wndClassExW{})),
+			Style:     csOwnDC,
+			WndProc:   windows.NewCallback(wndProc),
+			Instance:  hInstance,
+			Cursor:    cursor,
+			ClassName: className,
+		}
+		ret, _, callErr := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))) //nolint:gosec // G103: Win32 API
+		if ret == 0 {
+			return nil, fmt.Errorf("window: RegisterClassExW failed: %w", callErr)
+		}
+		classRegistered = true
 	}
 
 	style := uint32(wsOverlappedWindow)
 
-	// Adjust window size to account for borders
+	// Scale to pixels, then adjust the window size to account for borders
+	dpi := int32(defaultDPI)
+	if procGetDpiForSystem.Find() == nil {
+		ret, _, _ := procGetDpiForSystem.Call()
+		dpi = int32(ret) //nolint:gosec // G115: DPI fits in int32
+	}
 	var rc rect
-	rc.Right = width
-	rc.Bottom = height
+	rc.Right, rc.Bottom = physicalSize(width, height, float64(dpi)/defaultDPI)
 	procAdjustWindowRectEx.Call( //nolint:errcheck,gosec // G103: Win32 API
 		uintptr(unsafe.Pointer(&rc)), //nolint:gosec // G103: Win32 API
 		uintptr(style),
@@ -176,25 +214,18 @@ func NewWindow(title string, width, height int32) (*Window, error) {
 		0, 0, hInstance, 0,
 	)
 	if hwnd == 0 {
-		return nil, fmt.Errorf("CreateWindowExW failed: %w", callErr)
+		return nil, fmt.Errorf("window: CreateWindowExW failed: %w", callErr)
 	}
 
 	w := &Window{
 		hwnd:      hwnd,
 		hInstance: hInstance,
 		cursor:    cursor,
-		width:     width,
-		height:    height,
 		running:   true,
 	}
 	w.animating.Store(true) // Start in animating mode for games
+	windowsByHWND[hwnd] = w
 
-	// Store window pointer for wndProc
-	globalWindow = w
-	// Store window pointer for wndProc (gwlpUserData = -21)
-	procSetWindowLongPtrW.Call(hwnd, ^uintptr(20), uintptr(unsafe.Pointer(w))) //nolint:errcheck,gosec
-
-	// Show window
 	procShowWindow.Call(hwnd, uintptr(swShow)) //nolint:errcheck,gosec // Win32 API
 	procUpdateWindow.Call(hwnd)                //nolint:errcheck,gosec // Win32 API
 
@@ -204,57 +235,44 @@ func NewWindow(title string, width, height int32) (*Window, error) {
 // Destroy destroys the window.
 func (w *Window) Destroy() {
 	if w.hwnd != 0 {
+		delete(windowsByHWND, w.hwnd)
 		_, _, _ = procDestroyWindow.Call(w.hwnd)
 		w.hwnd = 0
 	}
-	if globalWindow == w {
-		globalWindow = nil
-	}
 }
 
-// Handle returns the native window handle (HWND).
-func (w *Window) Handle() uintptr {
-	return w.hwnd
+// Handle returns the HINSTANCE and HWND of the window.
+func (w *Window) Handle() Handle {
+	return Handle{Kind: KindWin32, Display: w.hInstance, Window: w.hwnd}
 }
 
-// Size returns the client area size of the window.
+// Size returns the client area size of the window in pixels.
 func (w *Window) Size() (width, height int32) {
 	var rc rect
 	procGetClientRect.Call(w.hwnd, uintptr(unsafe.Pointer(&rc))) //nolint:errcheck,gosec // Win32 API
 	return rc.Right - rc.Left, rc.Bottom - rc.Top
 }
 
-// SetAnimating sets whether the window should use continuous rendering mode.
-// When true, uses PeekMessage (non-blocking) for maximum FPS.
-// When false, uses GetMessage (blocking) for lower CPU usage.
-func (w *Window) SetAnimating(animating bool) {
-	w.animating.Store(animating)
-}
-
-// NeedsResize returns true if a resize event occurred and clears the flag.
-func (w *Window) NeedsResize() bool {
-	return w.needsResize.Swap(false)
-}
-
-// InSizeMove returns true if the window is currently being resized/moved.
-// During this time, rendering should continue but swapchain recreation should be deferred.
-func (w *Window) InSizeMove() bool {
-	return w.inSizeMove.Load()
+// Scale returns the DPI scale of the monitor the window is on, 1 at 96 DPI.
+func (w *Window) Scale() float64 {
+	if procGetDpiForWindow.Find() != nil {
+		return 1
+	}
+	dpi, _, _ := procGetDpiForWindow.Call(w.hwnd)
+	if dpi == 0 {
+		return 1
+	}
+	return float64(dpi) / defaultDPI
 }
 
 // PollEvents processes pending window events using hybrid GetMessage/PeekMessage.
-// This is the professional pattern from Gio that prevents "Not Responding".
+// This is the pattern from Gio that prevents "Not Responding".
 // Returns false when the window should close.
 //
 //nolint:nestif // Hybrid event loop requires different paths for animating/idle modes
 func (w *Window) PollEvents() bool {
 	var m msg
 
-	// Hybrid event loop pattern from Gio:
-	// - When animating: use PeekMessage (non-blocking) for max FPS
-	// - When idle: would use GetMessage (blocking) for CPU efficiency
-	// For games/realtime apps, we always use PeekMessage
-
 	if w.animating.Load() {
 		// Non-blocking: process all pending messages, then return for rendering
 		for {
@@ -298,11 +316,9 @@ func (w *Window) PollEvents() bool {
 }
 
 // wndProc is the window procedure callback.
-// Handles resize events professionally like Gio/wgpu.
 func wndProc(hwnd, message, wParam, lParam uintptr) uintptr {
-	// Get window pointer
-	w := globalWindow
-	if w == nil || w.hwnd != hwnd {
+	w := windowsByHWND[hwnd]
+	if w == nil {
 		ret, _, _ := procDefWindowProcW.Call(hwnd, message, wParam, lParam)
 		return ret
 	}
@@ -312,39 +328,44 @@ func wndProc(hwnd, message, wParam, lParam uintptr) uintptr {
 		_, _, _ = procPostQuitMessage.Call(0)
 		return 0
 
+	case wmPaint:
+		// Validate the region to prevent continuous WM_PAINT. Surfaces
+		// present on their own, including the software backend's GDI blit.
+		var ps paintStruct
+		procBeginPaint.Call(hwnd, uintptr(unsafe.Pointer(&ps))) //nolint:errcheck,gosec // Win32 API
+		procEndPaint.Call(hwnd, uintptr(unsafe.Pointer(&ps)))   //nolint:errcheck,gosec // Win32 API
+		return 0
+
 	case wmEnterSizeMove:
-		// User started resizing/moving - enter modal loop
-		// Windows blocks the message pump during resize, so we track this
-		w.inSizeMove.Store(true)
+		// Windows blocks the message pump during the modal resize loop,
+		// so reconfiguration waits for WM_EXITSIZEMOVE.
+		w.setSizeMove(true)
 		return 0
 
 	case wmExitSizeMove:
-		// User finished resizing/moving - safe to recreate swapchain now
-		w.inSizeMove.Store(false)
-		// Signal that resize handling is needed
-		if w.pendingW.Load() > 0 && w.pendingH.Load() > 0 {
-			w.needsResize.Store(true)
-		}
+		w.setSizeMove(false)
 		return 0
 
 	case wmSize:
-		// Window size changed
 		width := int32(lParam & 0xFFFF)
 		height := int32((lParam >> 16) & 0xFFFF)
-
 		if width > 0 && height > 0 {
-			w.width = width
-			w.height = height
-			w.pendingW.Store(width)
-			w.pendingH.Store(height)
-
-			// If not in modal resize loop, signal resize immediately
-			if !w.inSizeMove.Load() {
-				w.needsResize.Store(true)
-			}
+			w.resized()
 		}
 		return 0
 
+	case wmDpiChanged:
+		// Moved to a monitor with another DPI: take the suggested size,
+		// which keeps the logical size. WM_SIZE follows.
+		rc := *(**rect)(unsafe.Pointer(&lParam)) //nolint:gosec // G103: lParam is a RECT* for WM_DPICHANGED
+		procSetWindowPos.Call(                   //nolint:errcheck,gosec // Win32 API
+			hwnd, 0,
+			uintptr(rc.Left), uintptr(rc.Top), //nolint:gosec // G115: screen coordinates
+			uintptr(rc.Right-rc.Left), uintptr(rc.Bottom-rc.Top), //nolint:gosec // G115: window dimensions always positive
+			swpNoZOrder|swpNoActivate,
+		)
+		return 0
+
 	case wmSetCursor:
 		// Restore cursor to arrow when in client area
 		// This fixes the resize cursor staying after resize ends
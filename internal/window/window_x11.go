@@ -0,0 +1,242 @@
+// Copyright 2025 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+//go:build linux && !android
+
+package window
+
+import (
+	"encoding/binary"
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/types"
+)
+
+// Xlib functions, loaded once from libX11.
+var (
+	x11Once sync.Once
+	x11Err  error
+
+	xInitThreads           cfunc
+	xOpenDisplay           cfunc
+	xCloseDisplay          cfunc
+	xDefaultScreen         cfunc
+	xRootWindow            cfunc
+	xCreateSimpleWindow    cfunc
+	xDestroyWindow         cfunc
+	xStoreName             cfunc
+	xInternAtom            cfunc
+	xSetWMProtocols        cfunc
+	xSelectInput           cfunc
+	xMapWindow             cfunc
+	xFlush                 cfunc
+	xPending               cfunc
+	xNextEvent             cfunc
+	xResourceManagerString cfunc
+)
+
+// Xlib event types and masks.
+const (
+	x11ExposureMask        = 1 << 15
+	x11StructureNotifyMask = 1 << 17
+
+	x11ConfigureNotify = 22
+	x11ClientMessage   = 33
+)
+
+// Field offsets in XEvent on LP64: XConfigureEvent.width and height, and
+// XClientMessageEvent.data.l[0].
+const (
+	x11ConfigureWidthOffset  = 56
+	x11ConfigureHeightOffset = 60
+	x11ClientDataOffset      = 56
+)
+
+// x11Event is an XEvent, a union of 24 longs.
+type x11Event [24 * 8]byte
+
+// loadX11 loads libX11 and its functions.
+func loadX11() {
+	lib, err := loadLibrary("libX11.so.6", "libX11.so")
+	if err != nil {
+		x11Err = err
+		return
+	}
+	ptr, i32, u32, u64 := types.PointerTypeDescriptor, types.SInt32TypeDescriptor, types.UInt32TypeDescriptor, types.UInt64TypeDescriptor
+	funcs := []struct {
+		f    *cfunc
+		name string
+		ret  *types.TypeDescriptor
+		args []*types.TypeDescriptor
+	}{
+		{&xInitThreads, "XInitThreads", i32, nil},
+		{&xOpenDisplay, "XOpenDisplay", ptr, []*types.TypeDescriptor{ptr}},
+		{&xCloseDisplay, "XCloseDisplay", i32, []*types.TypeDescriptor{ptr}},
+		{&xDefaultScreen, "XDefaultScreen", i32, []*types.TypeDescriptor{ptr}},
+		{&xRootWindow, "XRootWindow", u64, []*types.TypeDescriptor{ptr, i32}},
+		{&xCreateSimpleWindow, "XCreateSimpleWindow", u64, []*types.TypeDescriptor{ptr, u64, i32, i32, u32, u32, u32, u64, u64}},
+		{&xDestroyWindow, "XDestroyWindow", i32, []*types.TypeDescriptor{ptr, u64}},
+		{&xStoreName, "XStoreName", i32, []*types.TypeDescriptor{ptr, u64, ptr}},
+		{&xInternAtom, "XInternAtom", u64, []*types.TypeDescriptor{ptr, ptr, i32}},
+		{&xSetWMProtocols, "XSetWMProtocols", i32, []*types.TypeDescriptor{ptr, u64, ptr, i32}},
+		{&xSelectInput, "XSelectInput", i32, []*types.TypeDescriptor{ptr, u64, u64}},
+		{&xMapWindow, "XMapWindow", i32, []*types.TypeDescriptor{ptr, u64}},
+		{&xFlush, "XFlush", i32, []*types.TypeDescriptor{ptr}},
+		{&xPending, "XPending", i32, []*types.TypeDescriptor{ptr}},
+		{&xNextEvent, "XNextEvent", i32, []*types.TypeDescriptor{ptr, ptr}},
+		{&xResourceManagerString, "XResourceManagerString", ptr, []*types.TypeDescriptor{ptr}},
+	}
+	for _, fn := range funcs {
+		if x11Err = fn.f.load(lib, fn.name, fn.ret, fn.args...); x11Err != nil {
+			return
+		}
+	}
+	// Surfaces may present from another thread on the same display.
+	var status int32
+	xInitThreads.call(unsafe.Pointer(&status))
+}
+
+// x11Window is an Xlib window with its own display connection.
+type x11Window struct {
+	display       uintptr
+	window        uint64
+	wmDelete      uint64
+	width, height int32
+	dpiScale      float64
+}
+
+// newX11Window opens the default display and maps a window on it.
+func newX11Window(title string, width, height int32) (*x11Window, error) {
+	x11Once.Do(loadX11)
+	if x11Err != nil {
+		return nil, x11Err
+	}
+
+	w := &x11Window{}
+	var name uintptr
+	xOpenDisplay.call(unsafe.Pointer(&w.display), unsafe.Pointer(&name))
+	if w.display == 0 {
+		return nil, errors.New("window: XOpenDisplay failed; is DISPLAY set?")
+	}
+
+	var resources uintptr
+	xResourceManagerString.call(unsafe.Pointer(&resources), unsafe.Pointer(&w.display))
+	w.dpiScale = parseXftDPI(goString(resources))
+	w.width, w.height = physicalSize(width, height, w.dpiScale)
+
+	var screen int32
+	xDefaultScreen.call(unsafe.Pointer(&screen), unsafe.Pointer(&w.display))
+	var root uint64
+	xRootWindow.call(unsafe.Pointer(&root), unsafe.Pointer(&w.display), unsafe.Pointer(&screen))
+
+	var x, y int32
+	pw, ph := uint32(w.width), uint32(w.height) //nolint:gosec // G115: window dimensions always positive
+	var border uint32
+	var borderColor, background uint64
+	xCreateSimpleWindow.call(unsafe.Pointer(&w.window),
+		unsafe.Pointer(&w.display), unsafe.Pointer(&root),
+		unsafe.Pointer(&x), unsafe.Pointer(&y), unsafe.Pointer(&pw), unsafe.Pointer(&ph),
+		unsafe.Pointer(&border), unsafe.Pointer(&borderColor), unsafe.Pointer(&background))
+	if w.window == 0 {
+		w.destroy()
+		return nil, errors.New("window: XCreateSimpleWindow failed")
+	}
+
+	var status int32
+	ctitle := cString(title)
+	titlePtr := uintptr(unsafe.Pointer(&ctitle[0]))
+	xStoreName.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&w.window), unsafe.Pointer(&titlePtr))
+
+	// Ask for a ClientMessage instead of a killed connection on close.
+	atomName := cString("WM_DELETE_WINDOW")
+	atomNamePtr := uintptr(unsafe.Pointer(&atomName[0]))
+	var onlyIfExists int32
+	xInternAtom.call(unsafe.Pointer(&w.wmDelete), unsafe.Pointer(&w.display), unsafe.Pointer(&atomNamePtr), unsafe.Pointer(&onlyIfExists))
+	protocols := uintptr(unsafe.Pointer(&w.wmDelete))
+	count := int32(1)
+	xSetWMProtocols.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&w.window), unsafe.Pointer(&protocols), unsafe.Pointer(&count))
+
+	mask := uint64(x11ExposureMask | x11StructureNotifyMask)
+	xSelectInput.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&w.window), unsafe.Pointer(&mask))
+	xMapWindow.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&w.window))
+	xFlush.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display))
+	runtime.KeepAlive(ctitle)
+	runtime.KeepAlive(atomName)
+	return w, nil
+}
+
+func (w *x11Window) handle() Handle {
+	return Handle{Kind: KindXlib, Display: w.display, Window: uintptr(w.window)}
+}
+
+func (w *x11Window) size() (width, height int32) {
+	return w.width, w.height
+}
+
+func (w *x11Window) scale() float64 {
+	return w.dpiScale
+}
+
+func (w *x11Window) pollEvents(rs *resizeState, block bool) bool {
+	open := true
+	var ev x11Event
+	evPtr := uintptr(unsafe.Pointer(&ev))
+	for {
+		var pending, status int32
+		xPending.call(unsafe.Pointer(&pending), unsafe.Pointer(&w.display))
+		if pending == 0 && !block {
+			return open
+		}
+		block = false
+		xNextEvent.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&evPtr))
+
+		switch int32(binary.LittleEndian.Uint32(ev[0:])) { //nolint:gosec // G115: XEvent.type is an int
+		case x11ConfigureNotify:
+			width := int32(binary.LittleEndian.Uint32(ev[x11ConfigureWidthOffset:]))   //nolint:gosec // G115: C int
+			height := int32(binary.LittleEndian.Uint32(ev[x11ConfigureHeightOffset:])) //nolint:gosec // G115: C int
+			if width > 0 && height > 0 && (width != w.width || height != w.height) {
+				w.width, w.height = width, height
+				rs.resized()
+			}
+		case x11ClientMessage:
+			if binary.LittleEndian.Uint64(ev[x11ClientDataOffset:]) == w.wmDelete {
+				open = false
+			}
+		}
+	}
+}
+
+func (w *x11Window) destroy() {
+	if w.display == 0 {
+		return
+	}
+	var status int32
+	if w.window != 0 {
+		xDestroyWindow.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display), unsafe.Pointer(&w.window))
+		w.window = 0
+	}
+	xCloseDisplay.call(unsafe.Pointer(&status), unsafe.Pointer(&w.display))
+	w.display = 0
+}
+
+// parseXftDPI returns the scale set by the Xft.dpi X resource, as returned
+// by XResourceManagerString, or 1 when it is missing.
+func parseXftDPI(resources string) float64 {
+	for _, line := range strings.Split(resources, "\n") {
+		value, ok := strings.CutPrefix(line, "Xft.dpi:")
+		if !ok {
+			continue
+		}
+		dpi, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || dpi <= 0 {
+			return 1
+		}
+		return dpi / 96
+	}
+	return 1
+}
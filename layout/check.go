@@ -0,0 +1,87 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package layout
+
+import (
+	"fmt"
+
+	naga "github.com/gogpu/naga"
+	"github.com/gogpu/naga/ir"
+)
+
+// CheckWGSL parses source and compares the layout with the resource it
+// declares at @group(group) @binding(binding). See [Layout.Check].
+func (l *Layout) CheckWGSL(source string, group, binding uint32) error {
+	ast, err := naga.Parse(source)
+	if err != nil {
+		return fmt.Errorf("layout: parse WGSL: %w", err)
+	}
+	module, err := naga.Lower(ast)
+	if err != nil {
+		return fmt.Errorf("layout: lower WGSL: %w", err)
+	}
+	return l.Check(module, group, binding)
+}
+
+// Check compares the layout with the resource module declares at
+// @group(group) @binding(binding). Members are matched to Go fields by name,
+// the gpu tag name or else the Go field name. It fails with ErrMismatch when
+// the binding is missing, when a struct member (including members of nested
+// structs) has no Go field of the same name or sits at a different offset
+// than it, when the member counts differ, or when Size is smaller than the
+// shader's binding size, which would make the layout unusable as
+// MinBindingSize.
+func (l *Layout) Check(module *ir.Module, group, binding uint32) error {
+	if module == nil {
+		return fmt.Errorf("layout: nil shader module: %w", ErrMismatch)
+	}
+	want := ir.ResourceBinding{Group: group, Binding: binding}
+	for i := range module.GlobalVariables {
+		gv := &module.GlobalVariables[i]
+		if gv.Binding == nil || *gv.Binding != want || int(gv.Type) >= len(module.Types) {
+			continue
+		}
+		if st, ok := module.Types[gv.Type].Inner.(ir.StructType); ok {
+			if err := compareStruct(module, st, l.root, gv.Name); err != nil {
+				return err
+			}
+		}
+		if need := uint64(ir.TypeSize(module, gv.Type)); l.root.size < need {
+			return fmt.Errorf("layout: %v is %d bytes but %s at @group(%d) @binding(%d) needs %d: %w",
+				l.typ, l.root.size, gv.Name, group, binding, need, ErrMismatch)
+		}
+		return nil
+	}
+	return fmt.Errorf("layout: shader has no resource at @group(%d) @binding(%d): %w", group, binding, ErrMismatch)
+}
+
+func compareStruct(module *ir.Module, st ir.StructType, n *node, path string) error {
+	if len(st.Members) != len(n.fields) {
+		return fmt.Errorf("layout: %s has %d members, Go struct has %d fields: %w",
+			path, len(st.Members), len(n.fields), ErrMismatch)
+	}
+	byName := make(map[string]*fieldNode, len(n.fields))
+	for i := range n.fields {
+		byName[n.fields[i].Name] = &n.fields[i]
+	}
+	for _, m := range st.Members {
+		f, ok := byName[m.Name]
+		if !ok {
+			return fmt.Errorf("layout: %s.%s has no Go field of that name: %w", path, m.Name, ErrMismatch)
+		}
+		if uint64(m.Offset) != f.Offset {
+			return fmt.Errorf("layout: %s.%s is at offset %d, Go field %s is at %d: %w",
+				path, m.Name, m.Offset, f.GoName, f.Offset, ErrMismatch)
+		}
+		if int(m.Type) >= len(module.Types) {
+			continue
+		}
+		if nested, ok := module.Types[m.Type].Inner.(ir.StructType); ok && f.node.kind == kindStruct {
+			if err := compareStruct(module, nested, f.node, path+"."+m.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
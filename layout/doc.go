@@ -0,0 +1,42 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+// Package layout computes std140 and std430 buffer layouts for Go structs and
+// encodes struct values into the bytes a shader expects.
+//
+// Go lays structs out by Go alignment rules, which disagree with GPU rules as
+// soon as a vec3 or an array appears, so writing a Go struct straight into a
+// uniform buffer silently shifts every member after the first mismatch.
+// [Of] derives the GPU layout once from the struct type and returns a [Layout]
+// whose Encode writes each field at its GPU offset:
+//
+//	type Camera struct {
+//		ViewProj [4][4]float32 `gpu:"view_proj"`
+//		Eye      [3]float32    `gpu:"eye"`
+//		Exposure float32       `gpu:"exposure"`
+//	}
+//
+//	l, err := layout.Of(Camera{}, layout.Std140)
+//	data, err := l.Encode(&cam)
+//	queue.WriteBuffer(ubo, 0, data)
+//
+// Supported field types are float32, int32, uint32 and bool (as a 32-bit
+// integer), arrays of those ([2]T to [4]T are vectors, [C][R]float32 is a
+// column-major matrix), arrays of vectors and structs, and nested structs.
+// Unexported and blank fields are ignored, so hand-written padding can stay
+// in the Go struct without affecting the result.
+//
+// The gpu tag names the shader member and takes optional, comma-separated
+// settings mirroring WGSL attributes:
+//
+//	gpu:"name"          shader member name, reported in Field.Name
+//	gpu:"name,align=16" raise the member alignment (@align)
+//	gpu:"name,size=32"  reserve at least 32 bytes for the member (@size)
+//	gpu:",array"        treat [2]T..[4]T as an array, not a vector or matrix
+//	gpu:"-"             skip the field
+//
+// [Layout.CheckWGSL] and [Layout.Check] compare a layout with the struct a
+// shader declares at a given @group/@binding, reporting the first member whose
+// offset differs and whether the layout is smaller than the shader requires.
+// [Layout.Size] is the value to use as the binding's MinBindingSize.
+package layout
@@ -0,0 +1,70 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package layout
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Encode returns v laid out as l.Size() little-endian bytes. Padding bytes
+// are zero. v must be a value of, or a non-nil pointer to, the layout's type.
+func (l *Layout) Encode(v any) ([]byte, error) {
+	return l.Append(nil, v)
+}
+
+// Append appends the encoding of v to dst and returns the extended slice.
+func (l *Layout) Append(dst []byte, v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || rv.Type() != l.typ {
+		return dst, fmt.Errorf("layout: cannot encode %T with the layout of %v: %w", v, l.typ, ErrMismatch)
+	}
+	start := len(dst)
+	dst = append(dst, make([]byte, l.root.size)...)
+	l.root.encode(dst[start:], rv)
+	return dst, nil
+}
+
+// encode writes v into buf, which starts at the node's offset.
+func (n *node) encode(buf []byte, v reflect.Value) {
+	switch n.kind {
+	case kindScalar:
+		putScalar(buf, v)
+	case kindVector:
+		for i := 0; i < n.length; i++ {
+			putScalar(buf[4*i:], v.Index(i))
+		}
+	case kindArray:
+		for i := 0; i < n.length; i++ {
+			n.elem.encode(buf[uint64(i)*n.stride:], v.Index(i))
+		}
+	case kindStruct:
+		for i := range n.fields {
+			f := &n.fields[i]
+			f.node.encode(buf[f.Offset:], v.Field(f.index))
+		}
+	}
+}
+
+func putScalar(buf []byte, v reflect.Value) {
+	var bits uint32
+	switch v.Kind() {
+	case reflect.Float32:
+		bits = math.Float32bits(float32(v.Float()))
+	case reflect.Int32:
+		bits = uint32(int32(v.Int()))
+	case reflect.Uint32:
+		bits = uint32(v.Uint())
+	case reflect.Bool:
+		if v.Bool() {
+			bits = 1
+		}
+	}
+	binary.LittleEndian.PutUint32(buf, bits)
+}
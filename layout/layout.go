@@ -0,0 +1,308 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Rules selects the GLSL memory layout a [Layout] follows.
+type Rules uint8
+
+const (
+	// Std140 is the uniform buffer layout: array elements and nested structs
+	// are aligned to 16 bytes.
+	Std140 Rules = iota
+	// Std430 is the storage buffer layout: arrays and structs keep the
+	// alignment of their members.
+	Std430
+)
+
+// String returns "std140" or "std430".
+func (r Rules) String() string {
+	switch r {
+	case Std140:
+		return "std140"
+	case Std430:
+		return "std430"
+	default:
+		return fmt.Sprintf("Rules(%d)", uint8(r))
+	}
+}
+
+var (
+	// ErrUnsupportedType is returned by Of for Go types with no GPU layout.
+	ErrUnsupportedType = errors.New("layout: unsupported type")
+
+	// ErrMismatch is returned when a layout disagrees with a shader binding
+	// or a value does not match the layout's type.
+	ErrMismatch = errors.New("layout: mismatch")
+)
+
+// Field describes one member of a laid-out struct.
+type Field struct {
+	// Name is the shader member name from the gpu tag, or the Go field name.
+	Name string
+	// GoName is the Go field name.
+	GoName string
+	// Offset is the member's byte offset from the start of its struct.
+	Offset uint64
+	// Size is the number of bytes reserved for the member.
+	Size uint64
+	// Align is the member's alignment.
+	Align uint64
+}
+
+// Layout is the GPU layout of a Go struct type under one set of [Rules].
+// It is immutable and safe for concurrent use.
+type Layout struct {
+	rules Rules
+	typ   reflect.Type
+	root  *node
+}
+
+type nodeKind uint8
+
+const (
+	kindScalar nodeKind = iota
+	kindVector
+	kindArray
+	kindStruct
+)
+
+// node is the computed layout of one Go type.
+type node struct {
+	kind   nodeKind
+	scalar reflect.Kind // component kind for scalars and vectors
+	length int          // vector components or array elements
+	elem   *node        // array element
+	stride uint64       // array element stride
+	fields []fieldNode  // struct members
+	size   uint64
+	align  uint64
+}
+
+type fieldNode struct {
+	Field
+	index int
+	node  *node
+}
+
+type cacheKey struct {
+	typ   reflect.Type
+	rules Rules
+}
+
+var cache sync.Map // cacheKey -> *Layout
+
+// Of returns the layout of v's struct type under rules. v may be a struct
+// value or a pointer to one; only its type is used. Layouts are cached per
+// type and rules.
+func Of(v any, rules Rules) (*Layout, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return OfType(t, rules)
+}
+
+// OfType is like Of for a reflect.Type.
+func OfType(t reflect.Type, rules Rules) (*Layout, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("layout: %v is not a struct: %w", t, ErrUnsupportedType)
+	}
+	if rules != Std140 && rules != Std430 {
+		return nil, fmt.Errorf("layout: unknown rules %v", rules)
+	}
+	key := cacheKey{typ: t, rules: rules}
+	if l, ok := cache.Load(key); ok {
+		return l.(*Layout), nil
+	}
+	root, err := rules.structNode(t)
+	if err != nil {
+		return nil, err
+	}
+	l, _ := cache.LoadOrStore(key, &Layout{rules: rules, typ: t, root: root})
+	return l.(*Layout), nil
+}
+
+// Rules returns the rules the layout was computed with.
+func (l *Layout) Rules() Rules { return l.rules }
+
+// Type returns the Go struct type the layout describes.
+func (l *Layout) Type() reflect.Type { return l.typ }
+
+// Size returns the encoded size in bytes, rounded up to the struct alignment.
+// Use it as the binding's MinBindingSize.
+func (l *Layout) Size() uint64 { return l.root.size }
+
+// Align returns the struct alignment.
+func (l *Layout) Align() uint64 { return l.root.align }
+
+// Fields returns the top-level members in declaration order.
+func (l *Layout) Fields() []Field {
+	fields := make([]Field, len(l.root.fields))
+	for i := range l.root.fields {
+		fields[i] = l.root.fields[i].Field
+	}
+	return fields
+}
+
+// Field returns the top-level member with the given shader or Go name.
+func (l *Layout) Field(name string) (Field, bool) {
+	for i := range l.root.fields {
+		if f := l.root.fields[i].Field; f.Name == name || f.GoName == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func (r Rules) structNode(t reflect.Type) (*node, error) {
+	n := &node{kind: kindStruct, align: 1}
+	var offset uint64
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, err := parseTag(sf)
+		if err != nil {
+			return nil, err
+		}
+		if tag.skip {
+			continue
+		}
+		member, err := r.typeNode(sf.Type, tag.array)
+		if err != nil {
+			return nil, fmt.Errorf("layout: %s.%s: %w", t.Name(), sf.Name, err)
+		}
+		align, size := member.align, member.size
+		if tag.align != 0 {
+			if tag.align < align {
+				return nil, fmt.Errorf("layout: %s.%s: align=%d is below the natural alignment %d: %w",
+					t.Name(), sf.Name, tag.align, align, ErrUnsupportedType)
+			}
+			align = tag.align
+		}
+		if tag.size != 0 {
+			if tag.size < size {
+				return nil, fmt.Errorf("layout: %s.%s: size=%d is below the natural size %d: %w",
+					t.Name(), sf.Name, tag.size, size, ErrUnsupportedType)
+			}
+			size = tag.size
+		}
+		offset = alignUp(offset, align)
+		n.fields = append(n.fields, fieldNode{
+			Field: Field{Name: tag.name, GoName: sf.Name, Offset: offset, Size: size, Align: align},
+			index: i,
+			node:  member,
+		})
+		offset += size
+		n.align = max(n.align, align)
+	}
+	if len(n.fields) == 0 {
+		return nil, fmt.Errorf("layout: %v has no fields: %w", t, ErrUnsupportedType)
+	}
+	if r == Std140 {
+		n.align = alignUp(n.align, 16)
+	}
+	n.size = alignUp(offset, n.align)
+	return n, nil
+}
+
+// typeNode lays out t. asArray forces [2]T..[4]T of scalars to be treated as
+// arrays rather than vectors, and [2]T..[4]T of float32 vectors as arrays
+// rather than matrices.
+func (r Rules) typeNode(t reflect.Type, asArray bool) (*node, error) {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32, reflect.Bool:
+		return &node{kind: kindScalar, scalar: t.Kind(), size: 4, align: 4}, nil
+	case reflect.Struct:
+		return r.structNode(t)
+	case reflect.Array:
+		elem, err := r.typeNode(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		if t.Len() == 0 {
+			return nil, fmt.Errorf("zero-length array %v: %w", t, ErrUnsupportedType)
+		}
+		if elem.kind == kindScalar && t.Len() <= 4 && t.Len() >= 2 && !asArray {
+			// vec3 is aligned like vec4 but only 12 bytes long, so a
+			// following scalar fills its fourth component.
+			align := uint64(8)
+			if t.Len() > 2 {
+				align = 16
+			}
+			return &node{kind: kindVector, scalar: elem.scalar, length: t.Len(), size: 4 * uint64(t.Len()), align: align}, nil
+		}
+		if elem.kind == kindVector && elem.scalar == reflect.Float32 && t.Len() <= 4 && t.Len() >= 2 && !asArray {
+			// A matrix is an array of column vectors, but its columns keep
+			// the vector alignment under both rules: mat2x2<f32> is 16
+			// bytes with an 8-byte column stride, even in a uniform buffer.
+			stride := alignUp(elem.size, elem.align)
+			return &node{kind: kindArray, length: t.Len(), elem: elem, stride: stride, size: stride * uint64(t.Len()), align: elem.align}, nil
+		}
+		align := elem.align
+		if r == Std140 {
+			align = alignUp(align, 16)
+		}
+		stride := alignUp(elem.size, align)
+		return &node{kind: kindArray, length: t.Len(), elem: elem, stride: stride, size: stride * uint64(t.Len()), align: align}, nil
+	default:
+		return nil, fmt.Errorf("%v: %w", t, ErrUnsupportedType)
+	}
+}
+
+type fieldTag struct {
+	name        string
+	skip, array bool
+	align, size uint64
+}
+
+func parseTag(sf reflect.StructField) (fieldTag, error) {
+	tag := fieldTag{name: sf.Name}
+	value, ok := sf.Tag.Lookup("gpu")
+	if !ok {
+		return tag, nil
+	}
+	if value == "-" {
+		tag.skip = true
+		return tag, nil
+	}
+	parts := strings.Split(value, ",")
+	if parts[0] != "" {
+		tag.name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		key, arg, _ := strings.Cut(opt, "=")
+		switch key {
+		case "array":
+			tag.array = true
+		case "align", "size":
+			v, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil || v == 0 || (key == "align" && v&(v-1) != 0) {
+				return tag, fmt.Errorf("layout: field %s: invalid gpu tag option %q: %w", sf.Name, opt, ErrUnsupportedType)
+			}
+			if key == "align" {
+				tag.align = v
+			} else {
+				tag.size = v
+			}
+		default:
+			return tag, fmt.Errorf("layout: field %s: unknown gpu tag option %q: %w", sf.Name, opt, ErrUnsupportedType)
+		}
+	}
+	return tag, nil
+}
+
+func alignUp(v, align uint64) uint64 {
+	return (v + align - 1) &^ (align - 1)
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package layout
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+type light struct {
+	Position  [3]float32 `gpu:"position"`
+	Intensity float32    `gpu:"intensity"`
+	Color     [3]float32 `gpu:"color"`
+}
+
+type scene struct {
+	ViewProj [4][4]float32 `gpu:"view_proj"`
+	Lights   [2]light      `gpu:"lights"`
+	Count    uint32        `gpu:"count"`
+	Weights  [3]float32    `gpu:"weights,array"`
+	Enabled  bool          `gpu:"enabled"`
+	internal int
+	_        [3]float32
+}
+
+func offsets(l *Layout) map[string]uint64 {
+	m := make(map[string]uint64)
+	for _, f := range l.Fields() {
+		m[f.Name] = f.Offset
+	}
+	return m
+}
+
+func TestLayoutRules(t *testing.T) {
+	tests := []struct {
+		rules Rules
+		want  map[string]uint64
+		size  uint64
+	}{
+		// light is 32 bytes in both rules: vec3 + f32 packs into 16 bytes.
+		// std140 pads the f32 array to a 16-byte stride.
+		{Std140, map[string]uint64{"view_proj": 0, "lights": 64, "count": 128, "weights": 144, "enabled": 192}, 208},
+		{Std430, map[string]uint64{"view_proj": 0, "lights": 64, "count": 128, "weights": 132, "enabled": 144}, 160},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rules.String(), func(t *testing.T) {
+			l, err := Of(scene{}, tt.rules)
+			if err != nil {
+				t.Fatalf("Of: %v", err)
+			}
+			got := offsets(l)
+			if len(got) != len(tt.want) {
+				t.Fatalf("fields = %v, want %v", got, tt.want)
+			}
+			for name, off := range tt.want {
+				if got[name] != off {
+					t.Errorf("%s offset = %d, want %d", name, got[name], off)
+				}
+			}
+			if l.Size() != tt.size {
+				t.Errorf("Size = %d, want %d", l.Size(), tt.size)
+			}
+		})
+	}
+}
+
+func TestLayoutTagOverrides(t *testing.T) {
+	type padded struct {
+		A float32 `gpu:"a,size=12"`
+		B float32 `gpu:"b,align=16"`
+		C float32 `gpu:"-"`
+	}
+	l, err := Of(&padded{}, Std430)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	if b, _ := l.Field("b"); b.Offset != 16 {
+		t.Errorf("b offset = %d, want 16", b.Offset)
+	}
+	if _, ok := l.Field("C"); ok {
+		t.Error("skipped field C is part of the layout")
+	}
+	if l.Size() != 32 {
+		t.Errorf("Size = %d, want 32", l.Size())
+	}
+}
+
+func TestLayoutRejectsUnsupportedTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"float64", struct{ X float64 }{}},
+		{"slice", struct{ X []float32 }{}},
+		{"bad align", struct {
+			X float32 `gpu:"x,align=3"`
+		}{}},
+		{"align below natural", struct {
+			X [4]float32 `gpu:"x,align=4"`
+		}{}},
+		{"unknown option", struct {
+			X float32 `gpu:"x,packed"`
+		}{}},
+		{"no fields", struct{ x float32 }{}},
+		{"not a struct", 1.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Of(tt.v, Std140); !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("Of = %v, want ErrUnsupportedType", err)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	l, err := Of(scene{}, Std140)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	s := scene{Count: 7, Enabled: true, Weights: [3]float32{1, 2, 3}}
+	s.ViewProj[3][0] = 5
+	s.Lights[1].Intensity = 0.5
+	data, err := l.Encode(&s)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if uint64(len(data)) != l.Size() {
+		t.Fatalf("len = %d, want %d", len(data), l.Size())
+	}
+	f32 := func(off int) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(data[off:])) }
+	u32 := func(off int) uint32 { return binary.LittleEndian.Uint32(data[off:]) }
+	checks := []struct {
+		name      string
+		got, want float32
+	}{
+		{"view_proj[3][0]", f32(48), 5},
+		{"lights[1].intensity", f32(64 + 32 + 12), 0.5},
+		{"weights[1]", f32(144 + 16), 2},
+		{"weights[2]", f32(144 + 32), 3},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if u32(128) != 7 || u32(192) != 1 {
+		t.Errorf("count, enabled = %d, %d; want 7, 1", u32(128), u32(192))
+	}
+
+	if _, err := l.Encode(light{}); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Encode(wrong type) = %v, want ErrMismatch", err)
+	}
+	if _, err := l.Encode((*scene)(nil)); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Encode(nil) = %v, want ErrMismatch", err)
+	}
+}
+
+const sceneWGSL = `
+struct Light {
+    position: vec3<f32>,
+    intensity: f32,
+    color: vec3<f32>,
+}
+
+struct Scene {
+    view_proj: mat4x4<f32>,
+    lights: array<Light, 2>,
+    count: u32,
+}
+
+@group(0) @binding(1) var<uniform> scene: Scene;
+
+@vertex
+fn vs_main() -> @builtin(position) vec4<f32> {
+    return scene.view_proj * vec4<f32>(scene.lights[0].position, f32(scene.count));
+}
+`
+
+func TestCheckWGSL(t *testing.T) {
+	type sceneUniform struct {
+		ViewProj [4][4]float32 `gpu:"view_proj"`
+		Lights   [2]light      `gpu:"lights"`
+		Count    uint32        `gpu:"count"`
+	}
+	l, err := Of(sceneUniform{}, Std140)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	if err := l.CheckWGSL(sceneWGSL, 0, 1); err != nil {
+		t.Errorf("CheckWGSL: %v", err)
+	}
+	if err := l.CheckWGSL(sceneWGSL, 0, 0); !errors.Is(err, ErrMismatch) {
+		t.Errorf("CheckWGSL(missing binding) = %v, want ErrMismatch", err)
+	}
+
+	// Fields declared in a different order than the shader members.
+	type misaligned struct {
+		ViewProj [4][4]float32 `gpu:"view_proj"`
+		Count    uint32        `gpu:"count"`
+		Lights   [2]light      `gpu:"lights"`
+	}
+	bad, err := Of(misaligned{}, Std430)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	if err := bad.CheckWGSL(sceneWGSL, 0, 1); !errors.Is(err, ErrMismatch) {
+		t.Errorf("CheckWGSL(misaligned) = %v, want ErrMismatch", err)
+	}
+}
+
+const matricesWGSL = `
+struct Transforms {
+    rotation: mat2x2<f32>,
+    skew: mat3x2<f32>,
+    normal: mat3x3<f32>,
+    scale: f32,
+}
+
+@group(0) @binding(0) var<uniform> transforms: Transforms;
+
+@vertex
+fn vs_main() -> @builtin(position) vec4<f32> {
+    let p = transforms.rotation * transforms.skew[0] * transforms.scale;
+    return vec4<f32>(transforms.normal * vec3<f32>(p, 1.0), 1.0);
+}
+`
+
+func TestMatrixLayout(t *testing.T) {
+	type transforms struct {
+		Rotation [2][2]float32 `gpu:"rotation"`
+		Skew     [3][2]float32 `gpu:"skew"`
+		Normal   [3][3]float32 `gpu:"normal"`
+		Scale    float32       `gpu:"scale"`
+	}
+	// mat2x2 and mat3x2 keep 8-byte columns in uniform buffers too; only
+	// mat3x3's vec3 columns are padded to 16.
+	want := map[string]uint64{"rotation": 0, "skew": 16, "normal": 48, "scale": 96}
+	for _, rules := range []Rules{Std140, Std430} {
+		l, err := Of(transforms{}, rules)
+		if err != nil {
+			t.Fatalf("Of(%v): %v", rules, err)
+		}
+		got := offsets(l)
+		for name, off := range want {
+			if got[name] != off {
+				t.Errorf("%v: %s offset = %d, want %d", rules, name, got[name], off)
+			}
+		}
+		if err := l.CheckWGSL(matricesWGSL, 0, 0); err != nil {
+			t.Errorf("%v: CheckWGSL: %v", rules, err)
+		}
+	}
+
+	// Tagged as an array, [2][2]float32 is array<vec2<f32>, 2> and takes a
+	// 16-byte stride under std140.
+	type vecArray struct {
+		Points [2][2]float32 `gpu:"points,array"`
+	}
+	l, err := Of(vecArray{}, Std140)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	if l.Size() != 32 {
+		t.Errorf("std140 array<vec2<f32>, 2> size = %d, want 32", l.Size())
+	}
+}
+
+func TestCheckWGSLMatchesNames(t *testing.T) {
+	// Same types and offsets as the shader, but a member renamed.
+	type renamed struct {
+		Rotation [2][2]float32 `gpu:"rotation"`
+		Skew     [3][2]float32 `gpu:"shear"`
+		Normal   [3][3]float32 `gpu:"normal"`
+		Scale    float32       `gpu:"scale"`
+	}
+	l, err := Of(renamed{}, Std140)
+	if err != nil {
+		t.Fatalf("Of: %v", err)
+	}
+	if err := l.CheckWGSL(matricesWGSL, 0, 0); !errors.Is(err, ErrMismatch) {
+		t.Errorf("CheckWGSL(renamed member) = %v, want ErrMismatch", err)
+	}
+}
@@ -0,0 +1,30 @@
+package wgpu
+
+// DownlevelWebGL2Limits returns the limits OpenGL ES 3.0 and WebGL 2 class
+// hardware guarantees: DownlevelLimits without storage buffers, storage
+// textures or compute, 11 uniform buffers per stage, 4 color attachments,
+// 15 inter-stage variables and a 255-byte vertex stride.
+//
+// Use it to check that content fits such hardware, for example by
+// comparing Adapter.Limits against it. Zero fields mean the capability is
+// absent; as DeviceDescriptor.RequiredLimits a zero field keeps the
+// adapter's value instead, so requesting these limits does not disable
+// storage or compute on more capable adapters.
+func DownlevelWebGL2Limits() Limits {
+	limits := DownlevelLimits()
+	limits.MaxUniformBuffersPerShaderStage = 11
+	limits.MaxStorageBuffersPerShaderStage = 0
+	limits.MaxStorageTexturesPerShaderStage = 0
+	limits.MaxDynamicStorageBuffersPerPipelineLayout = 0
+	limits.MaxStorageBufferBindingSize = 0
+	limits.MaxVertexBufferArrayStride = 255
+	limits.MaxInterStageShaderVariables = 15
+	limits.MaxColorAttachments = 4
+	limits.MaxComputeWorkgroupStorageSize = 0
+	limits.MaxComputeInvocationsPerWorkgroup = 0
+	limits.MaxComputeWorkgroupSizeX = 0
+	limits.MaxComputeWorkgroupSizeY = 0
+	limits.MaxComputeWorkgroupSizeZ = 0
+	limits.MaxComputeWorkgroupsPerDimension = 0
+	return limits
+}
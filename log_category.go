@@ -0,0 +1,37 @@
+package wgpu
+
+// LogCategory groups the diagnostics wgpu logs by subsystem. Every record
+// from a category carries a "category" attribute with the category name,
+// and InstanceDescriptor.LogLevels can set a minimum level per category.
+type LogCategory uint8
+
+const (
+	// LogGeneral covers records that belong to no specific subsystem.
+	LogGeneral LogCategory = iota
+	// LogAdapter covers backend initialization and adapter enumeration.
+	LogAdapter
+	// LogSwapchain covers surface configuration, acquire and present events.
+	LogSwapchain
+	// LogBarrier covers resource state transitions and barrier decisions.
+	LogBarrier
+	// LogShader covers shader translation and compilation diagnostics.
+	LogShader
+)
+
+// String returns the value of the category's "category" attribute.
+func (c LogCategory) String() string {
+	switch c {
+	case LogGeneral:
+		return "general"
+	case LogAdapter:
+		return "adapter"
+	case LogSwapchain:
+		return "swapchain"
+	case LogBarrier:
+		return "barrier"
+	case LogShader:
+		return "shader"
+	default:
+		return "unknown"
+	}
+}
@@ -7,6 +7,7 @@ package wgpu
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gogpu/wgpu/core"
 )
@@ -153,6 +154,10 @@ func coreErrToTyped(e *core.BufferMapError) error {
 	case core.BufferMapErrKindAlignment:
 		return ErrMapAlignment
 	case core.BufferMapErrKindInvalidMode:
+		if e.Wrapped != nil {
+			// Buffer usage validation names the offending buffer.
+			return fmt.Errorf("%w: %w", ErrMapInvalidMode, e.Wrapped)
+		}
 		return ErrMapInvalidMode
 	case core.BufferMapErrKindRangeOverflow:
 		return ErrMapRangeOverflow
@@ -0,0 +1,66 @@
+package wgpu
+
+// MemoryBudget is the device memory the operating system grants the process
+// and how much of it the process uses, as Device.MemoryBudget reports it.
+type MemoryBudget struct {
+	// Budget is the number of bytes the process can use before the OS
+	// starts demoting its allocations to system memory, paging them or
+	// failing new ones.
+	Budget uint64
+	// Usage is the number of bytes the process currently uses.
+	Usage uint64
+}
+
+// Pressure returns the memory pressure level of the budget's usage.
+func (b MemoryBudget) Pressure() MemoryPressureLevel {
+	switch {
+	case b.Budget == 0:
+		return MemoryPressureNone
+	case b.Usage >= b.Budget/100*95:
+		return MemoryPressureCritical
+	case b.Usage >= b.Budget/100*80:
+		return MemoryPressureModerate
+	default:
+		return MemoryPressureNone
+	}
+}
+
+// MemoryPressureLevel grades how close a device is to its memory budget.
+type MemoryPressureLevel uint8
+
+const (
+	// MemoryPressureNone means usage is below 80% of the budget.
+	MemoryPressureNone MemoryPressureLevel = iota
+	// MemoryPressureModerate means usage has reached 80% of the budget.
+	// Engines should stop growing caches and drop what is cheap to rebuild.
+	MemoryPressureModerate
+	// MemoryPressureCritical means usage has reached 95% of the budget.
+	// Further allocations may be demoted to system memory or fail.
+	MemoryPressureCritical
+)
+
+// String returns the name of the pressure level.
+func (l MemoryPressureLevel) String() string {
+	switch l {
+	case MemoryPressureNone:
+		return "none"
+	case MemoryPressureModerate:
+		return "moderate"
+	case MemoryPressureCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// MemoryPressure is the change of memory pressure reported to the callback
+// set with Device.SetMemoryPressureCallback.
+type MemoryPressure struct {
+	// Level is the new pressure level.
+	Level MemoryPressureLevel
+	// Budget is the budget and usage the level was computed from.
+	Budget MemoryBudget
+	// Trimmed is what the device released before the callback ran, when
+	// Device.SetTrimOnMemoryPressure is enabled and the level rose.
+	Trimmed TrimReport
+}
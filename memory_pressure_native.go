@@ -0,0 +1,110 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// memoryPressureInterval is how often Poll queries the memory budget while
+// a memory pressure callback or trimming on pressure is set.
+const memoryPressureInterval = 250 * time.Millisecond
+
+// memoryMonitor tracks a device's memory pressure level between polls.
+type memoryMonitor struct {
+	mu       sync.Mutex
+	callback func(MemoryPressure)
+	trim     bool
+	level    MemoryPressureLevel
+	checked  time.Time
+}
+
+// MemoryBudget returns the device memory budget the operating system grants
+// the process and the process's usage of it. Vulkan reports it with
+// VK_EXT_memory_budget, DX12 for the adapter's local memory and Metal
+// against the device's recommended working set. It returns false on other
+// backends and on drivers that do not report a budget.
+func (d *Device) MemoryBudget() (MemoryBudget, bool) {
+	if d == nil || d.released.Load() {
+		return MemoryBudget{}, false
+	}
+	reporter, ok := d.halDevice().(hal.MemoryBudgetReporter)
+	if !ok {
+		return MemoryBudget{}, false
+	}
+	budget, ok := reporter.MemoryBudget()
+	if !ok {
+		return MemoryBudget{}, false
+	}
+	return MemoryBudget{Budget: budget.Budget, Usage: budget.Usage}, true
+}
+
+// SetMemoryPressureCallback sets callback to be called when the device's
+// memory pressure level changes, so engines can drop caches before the OS
+// starts paging or allocations fail. Device.Poll and Device.Tick check the
+// budget a few times a second and run callback on their caller's goroutine.
+// A nil callback removes it. Devices that cannot report a budget (see
+// MemoryBudget) never call it.
+func (d *Device) SetMemoryPressureCallback(callback func(MemoryPressure)) {
+	if d == nil {
+		return
+	}
+	d.memory.mu.Lock()
+	d.memory.callback = callback
+	d.memory.mu.Unlock()
+}
+
+// SetTrimOnMemoryPressure sets whether the device calls Trim itself when
+// its memory pressure level rises, releasing idle staging buffers, command
+// encoders and pooled device memory before the callback set with
+// SetMemoryPressureCallback runs. Trimming happens inside Device.Poll and
+// Device.Tick.
+func (d *Device) SetTrimOnMemoryPressure(enabled bool) {
+	if d == nil {
+		return
+	}
+	d.memory.mu.Lock()
+	d.memory.trim = enabled
+	d.memory.mu.Unlock()
+}
+
+// pollMemoryPressure queries the memory budget when a callback or trimming
+// is set and the interval has passed, and reports a change of pressure
+// level. It returns whether the level changed.
+func (d *Device) pollMemoryPressure(now time.Time) bool {
+	m := &d.memory
+	m.mu.Lock()
+	if (m.callback == nil && !m.trim) || now.Sub(m.checked) < memoryPressureInterval {
+		m.mu.Unlock()
+		return false
+	}
+	m.checked = now
+	m.mu.Unlock()
+
+	budget, ok := d.MemoryBudget()
+	if !ok {
+		return false
+	}
+	level := budget.Pressure()
+
+	m.mu.Lock()
+	previous := m.level
+	m.level = level
+	callback, trim := m.callback, m.trim
+	m.mu.Unlock()
+	if level == previous {
+		return false
+	}
+
+	event := MemoryPressure{Level: level, Budget: budget}
+	if trim && level > previous {
+		event.Trimmed = d.Trim()
+	}
+	if callback != nil {
+		callback(event)
+	}
+	return true
+}
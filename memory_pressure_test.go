@@ -0,0 +1,86 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestMemoryBudgetPressure(t *testing.T) {
+	tests := []struct {
+		budget wgpu.MemoryBudget
+		want   wgpu.MemoryPressureLevel
+	}{
+		{wgpu.MemoryBudget{}, wgpu.MemoryPressureNone},
+		{wgpu.MemoryBudget{Usage: 1 << 20}, wgpu.MemoryPressureNone},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 0}, wgpu.MemoryPressureNone},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 799}, wgpu.MemoryPressureNone},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 800}, wgpu.MemoryPressureModerate},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 949}, wgpu.MemoryPressureModerate},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 950}, wgpu.MemoryPressureCritical},
+		{wgpu.MemoryBudget{Budget: 1000, Usage: 2000}, wgpu.MemoryPressureCritical},
+	}
+	for _, tt := range tests {
+		if got := tt.budget.Pressure(); got != tt.want {
+			t.Errorf("%+v.Pressure() = %v, want %v", tt.budget, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryPressureLevelString(t *testing.T) {
+	for level, want := range map[wgpu.MemoryPressureLevel]string{
+		wgpu.MemoryPressureNone:     "none",
+		wgpu.MemoryPressureModerate: "moderate",
+		wgpu.MemoryPressureCritical: "critical",
+		wgpu.MemoryPressureLevel(9): "unknown",
+	} {
+		if got := level.String(); got != want {
+			t.Errorf("MemoryPressureLevel(%d).String() = %q, want %q", level, got, want)
+		}
+	}
+}
+
+func TestDeviceMemoryPressureCallback(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+	defer device.Release()
+
+	budget, ok := device.MemoryBudget()
+	if ok && budget.Budget == 0 {
+		t.Errorf("MemoryBudget() = %+v, true; want a non-zero budget", budget)
+	}
+
+	var events []wgpu.MemoryPressure
+	device.SetMemoryPressureCallback(func(p wgpu.MemoryPressure) {
+		events = append(events, p)
+	})
+	device.SetTrimOnMemoryPressure(true)
+	device.Poll(wgpu.PollPoll)
+
+	// Only a device that reports a budget under pressure calls back, and then
+	// with the level its budget gives.
+	for _, e := range events {
+		if !ok {
+			t.Fatalf("callback called with %+v on a device without a budget", e)
+		}
+		if e.Level != e.Budget.Pressure() {
+			t.Errorf("event level %v, budget %+v gives %v", e.Level, e.Budget, e.Budget.Pressure())
+		}
+	}
+
+	device.SetMemoryPressureCallback(nil)
+	device.SetTrimOnMemoryPressure(false)
+	device.Poll(wgpu.PollPoll)
+}
+
+func TestDeviceMemoryBudgetAfterRelease(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+	device.Release()
+
+	if got, ok := device.MemoryBudget(); ok {
+		t.Errorf("MemoryBudget() on released device = %+v, true; want false", got)
+	}
+}
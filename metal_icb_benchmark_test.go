@@ -141,8 +141,8 @@ func benchmarkMetalIndexedFrame(b *testing.B, count uint32, forceLoop bool) {
 			b.Fatal(err)
 		}
 		pass.SetPipeline(pipeline)
-		pass.SetVertexBuffer(0, vertex, 0)
-		pass.SetIndexBuffer(index, gputypes.IndexFormatUint16, 0)
+		pass.SetVertexBuffer(0, vertex, 0, wgpu.WholeSize)
+		pass.SetIndexBuffer(index, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 		if count == 0 {
 			pass.DrawIndexed(3, 1, 0, 0, 0)
 		} else {
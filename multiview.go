@@ -0,0 +1,23 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// validateViewCount checks that the device enables FeatureMultiview for a
+// view count above 1 and that the count is within maxViews, the adapter's
+// MaxMultiviewViewCount (0 when unknown).
+func validateViewCount(count uint32, features Features, maxViews uint32) error {
+	if count <= 1 {
+		return nil
+	}
+	if !features.Contains(FeatureMultiview) {
+		return fmt.Errorf("wgpu: view count %d requires %s: %w", count, hal.FeatureName(FeatureMultiview), ErrFeatureNotSupported)
+	}
+	if maxViews != 0 && count > maxViews {
+		return fmt.Errorf("wgpu: view count %d exceeds the adapter maximum of %d", count, maxViews)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestValidateViewCount(t *testing.T) {
+	if FeatureMultiview != hal.FeatureMultiview {
+		t.Error("root FeatureMultiview differs from hal")
+	}
+	for _, count := range []uint32{0, 1} {
+		if err := validateViewCount(count, 0, 0); err != nil {
+			t.Errorf("view count %d without features: %v", count, err)
+		}
+	}
+	err := validateViewCount(2, 0, 0)
+	if !errors.Is(err, ErrFeatureNotSupported) || !strings.Contains(err.Error(), "Multiview") {
+		t.Errorf("view count 2 without FeatureMultiview = %v, want ErrFeatureNotSupported naming the feature", err)
+	}
+	multiview := Features(FeatureMultiview)
+	if err := validateViewCount(4, multiview, 4); err != nil {
+		t.Errorf("view count 4 within the maximum: %v", err)
+	}
+	if err := validateViewCount(6, multiview, 4); err == nil {
+		t.Error("view count above the adapter maximum accepted")
+	}
+}
+
+func TestTextureViewCheckViewCount(t *testing.T) {
+	layered := &TextureView{dimension: gputypes.TextureViewDimension2DArray, arrayLayerCount: 2}
+	if err := layered.checkViewCount(2); err != nil {
+		t.Errorf("2-layer array view with 2 views: %v", err)
+	}
+	if err := layered.checkViewCount(4); err == nil {
+		t.Error("2-layer array view accepted for 4 views")
+	}
+	flat := &TextureView{dimension: gputypes.TextureViewDimension2D, arrayLayerCount: 1}
+	if err := flat.checkViewCount(2); err == nil {
+		t.Error("2D view accepted for 2 views")
+	}
+	if err := flat.checkViewCount(1); err != nil {
+		t.Errorf("2D view without multiview: %v", err)
+	}
+	if err := (&TextureView{}).checkViewCount(2); err != nil {
+		t.Errorf("view of unknown shape: %v", err)
+	}
+}
@@ -34,6 +34,10 @@ type pendingWrites struct {
 	// halQueue is the underlying HAL queue for direct-write backends.
 	halQueue hal.Queue
 
+	// counters receives the barriers recorded around staged writes. nil
+	// discards them.
+	counters *frameCounters
+
 	// pool is a reference to the shared device-level encoder pool.
 	// Not owned by pendingWrites — owned by Device. destroy() clears the
 	// reference without destroying the pool. nil for non-batching backends.
@@ -296,6 +300,13 @@ func (pw *pendingWrites) writeTextureFor(
 		return fmt.Errorf("wgpu: pending writes: activate encoder: %w", err)
 	}
 
+	// Barriers cover every array layer (or cube face) written. 3D textures
+	// have a single layer; their depth slices share it.
+	baseLayer, layerCount := dst.Origin.Z, depthOrLayers
+	if owner != nil && owner.dimension == gputypes.TextureDimension3D {
+		baseLayer, layerCount = 0, 1
+	}
+
 	// Transition texture to COPY_DST using its actual tracked state.
 	currentUsage := dst.Texture.CurrentUsage()
 	if currentUsage != gputypes.TextureUsageCopyDst {
@@ -305,14 +316,15 @@ func (pw *pendingWrites) writeTextureFor(
 				Aspect:          gputypes.TextureAspectAll,
 				BaseMipLevel:    dst.MipLevel,
 				MipLevelCount:   1,
-				BaseArrayLayer:  0,
-				ArrayLayerCount: 1,
+				BaseArrayLayer:  baseLayer,
+				ArrayLayerCount: layerCount,
 			},
 			Usage: hal.TextureUsageTransition{
 				OldUsage: currentUsage,
 				NewUsage: gputypes.TextureUsageCopyDst,
 			},
 		}}
+		pw.counters.addBarriers(1)
 		enc.TransitionTextures(barrier[:])
 	}
 
@@ -338,14 +350,15 @@ func (pw *pendingWrites) writeTextureFor(
 			Aspect:          gputypes.TextureAspectAll,
 			BaseMipLevel:    dst.MipLevel,
 			MipLevelCount:   1,
-			BaseArrayLayer:  0,
-			ArrayLayerCount: 1,
+			BaseArrayLayer:  baseLayer,
+			ArrayLayerCount: layerCount,
 		},
 		Usage: hal.TextureUsageTransition{
 			OldUsage: gputypes.TextureUsageCopyDst,
 			NewUsage: gputypes.TextureUsageTextureBinding,
 		},
 	}}
+	pw.counters.addBarriers(1)
 	enc.TransitionTextures(postBarrier[:])
 
 	// Track destination texture. AddPendingRef prevents premature Destroy (BUG-DX12-006).
@@ -505,13 +518,14 @@ func (pw *pendingWrites) flush() (hal.CommandBuffer, hal.CommandEncoder, []hal.T
 				barriers = append(barriers, hal.BufferBarrier{
 					Buffer: buf,
 					Usage: hal.BufferUsageTransition{
-						OldUsage: gputypes.BufferUsageCopyDst,
-						NewUsage: readUsage,
+						OldUsage: hal.BufferUseCopyDst,
+						NewUsage: hal.BufferUsesFromUsage(readUsage),
 					},
 				})
 			}
 		}
 		if len(barriers) > 0 {
+			pw.counters.addBarriers(len(barriers))
 			pw.encoder.TransitionBuffers(barriers)
 		}
 	}
@@ -59,7 +59,12 @@ func makeLateSizedBufferGroups(
 type RenderPipeline struct {
 	hal      hal.RenderPipeline
 	device   *Device
+	label    string
 	released bool
+	// passContext holds the color target formats, depth/stencil format and
+	// sample count the pipeline was created for. SetPipeline rejects passes
+	// whose attachments differ.
+	passContext core.RenderPassContext
 	// bindGroupCount is the number of bind group layouts in this pipeline's
 	// layout. Used by RenderPassEncoder.SetBindGroup to validate that
 	// the group index is within bounds before issuing the HAL call.
@@ -88,6 +93,9 @@ type RenderPipeline struct {
 	// ref is the GPU-aware reference counter for this pipeline (Phase 2).
 	// Clone'd when used in a render pass, Drop'd when GPU completes submission.
 	ref *core.ResourceRef
+	// desc is the descriptor the pipeline was created with, kept so
+	// Device.ReloadShaderModule can rebuild it.
+	desc RenderPipelineDescriptor
 }
 
 // Release destroys the render pipeline. Destruction is deferred until the GPU
@@ -97,6 +105,8 @@ func (p *RenderPipeline) Release() {
 		return
 	}
 	p.released = true
+	p.desc.Vertex.Module.untrackRender(p)
+	fragmentShaderModule(p.desc.Fragment).untrackRender(p)
 
 	halDevice := p.device.halDevice()
 	if halDevice == nil {
@@ -135,6 +145,9 @@ type ComputePipeline struct {
 	// ref is the GPU-aware reference counter for this pipeline (Phase 2).
 	// Clone'd when used in a compute pass, Drop'd when GPU completes submission.
 	ref *core.ResourceRef
+	// desc is the descriptor the pipeline was created with, kept so
+	// Device.ReloadShaderModule can rebuild it.
+	desc ComputePipelineDescriptor
 }
 
 // Release destroys the compute pipeline. Destruction is deferred until the GPU
@@ -144,6 +157,7 @@ func (p *ComputePipeline) Release() {
 		return
 	}
 	p.released = true
+	p.desc.Module.untrackCompute(p)
 
 	halDevice := p.device.halDevice()
 	if halDevice == nil {
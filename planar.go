@@ -0,0 +1,65 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// Multi-planar video formats, for sampling decoder output without a
+// conversion copy. A texture of these formats is a single-mip 2D texture
+// with TextureUsageTextureBinding only, typically brought in with
+// Device.ImportTexture. Shaders read it through one view per plane, created
+// with TextureAspectPlane0 and TextureAspectPlane1, and convert YCbCr to RGB
+// themselves.
+const (
+	// TextureFormatNV12 is 8-bit 4:2:0 YCbCr: an R8Unorm luma plane and an
+	// RG8Unorm chroma plane at half width and height. Requires
+	// FeatureTextureFormatNV12.
+	TextureFormatNV12 gputypes.TextureFormat = 0x00030007
+	// TextureFormatP010 is 10-bit 4:2:0 YCbCr in the high bits of 16-bit
+	// samples: an R16Unorm luma plane and an RG16Unorm chroma plane.
+	// Requires FeatureTextureFormatP010.
+	TextureFormatP010 gputypes.TextureFormat = 0x00030008
+)
+
+// Plane aspects select one plane of a multi-planar texture in
+// TextureViewDescriptor.Aspect. The view's format is the plane's format.
+const (
+	// TextureAspectPlane0 selects the luma plane.
+	TextureAspectPlane0 gputypes.TextureAspect = 0x00030001
+	// TextureAspectPlane1 selects the chroma plane.
+	TextureAspectPlane1 gputypes.TextureAspect = 0x00030002
+)
+
+// validatePlanarFormat checks that the device enables the feature a
+// multi-planar texture format needs. Other formats need nothing.
+func validatePlanarFormat(format gputypes.TextureFormat, features Features) error {
+	var feature gputypes.Feature
+	switch format {
+	case TextureFormatNV12:
+		feature = FeatureTextureFormatNV12
+	case TextureFormatP010:
+		feature = FeatureTextureFormatP010
+	default:
+		return nil
+	}
+	if !features.Contains(feature) {
+		return fmt.Errorf("wgpu: texture format %s requires %s: %w", planarFormatName(format), hal.FeatureName(feature), ErrFeatureNotSupported)
+	}
+	return nil
+}
+
+// planarFormatName returns the name of a multi-planar format, which
+// gputypes does not know.
+func planarFormatName(format gputypes.TextureFormat) string {
+	switch format {
+	case TextureFormatNV12:
+		return "NV12"
+	case TextureFormatP010:
+		return "P010"
+	default:
+		return format.String()
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestPlanarMirrorsHAL(t *testing.T) {
+	if TextureFormatNV12 != hal.TextureFormatNV12 || TextureFormatP010 != hal.TextureFormatP010 {
+		t.Error("root multi-planar formats differ from hal")
+	}
+	if TextureAspectPlane0 != hal.TextureAspectPlane0 || TextureAspectPlane1 != hal.TextureAspectPlane1 {
+		t.Error("root plane aspects differ from hal")
+	}
+	if FeatureTextureFormatNV12 != hal.FeatureTextureFormatNV12 || FeatureTextureFormatP010 != hal.FeatureTextureFormatP010 {
+		t.Error("root multi-planar features differ from hal")
+	}
+}
+
+func TestValidatePlanarFormat(t *testing.T) {
+	if err := validatePlanarFormat(gputypes.TextureFormatRGBA8Unorm, 0); err != nil {
+		t.Errorf("RGBA8Unorm without features: %v", err)
+	}
+	err := validatePlanarFormat(TextureFormatNV12, Features(FeatureTextureFormatP010))
+	if !errors.Is(err, ErrFeatureNotSupported) || !strings.Contains(err.Error(), "TextureFormatNV12") {
+		t.Errorf("NV12 without FeatureTextureFormatNV12 = %v, want ErrFeatureNotSupported naming the feature", err)
+	}
+	if err := validatePlanarFormat(TextureFormatP010, Features(FeatureTextureFormatP010)); err != nil {
+		t.Errorf("P010 with the feature: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// PolygonMode selects how a render pipeline rasterizes triangles, set with
+// RenderPipelineDescriptor.PolygonMode. Line and point topologies are not
+// affected. It is a native extension: WebGPU itself only fills triangles.
+type PolygonMode uint8
+
+const (
+	// PolygonModeFill fills triangles (the default).
+	PolygonModeFill PolygonMode = iota
+	// PolygonModeLine draws triangle edges as lines, for wireframe views.
+	// Requires FeaturePolygonModeLine on the device.
+	PolygonModeLine
+	// PolygonModePoint draws triangle vertices as points. Requires
+	// FeaturePolygonModePoint on the device.
+	PolygonModePoint
+)
+
+// String returns the name of the polygon mode.
+func (m PolygonMode) String() string {
+	switch m {
+	case PolygonModeFill:
+		return "fill"
+	case PolygonModeLine:
+		return "line"
+	case PolygonModePoint:
+		return "point"
+	default:
+		return "unknown"
+	}
+}
+
+// Native-only features, requested through DeviceDescriptor like the
+// gputypes features. They occupy the high bits of gputypes.Features and are
+// never reported by the browser backend.
+const (
+	// FeaturePolygonModeLine allows PolygonModeLine. Supported by Vulkan
+	// (fillModeNonSolid), DX12, Metal and GLES.
+	FeaturePolygonModeLine = hal.FeaturePolygonModeLine
+	// FeaturePolygonModePoint allows PolygonModePoint. Supported by Vulkan
+	// (fillModeNonSolid) and GLES.
+	FeaturePolygonModePoint = hal.FeaturePolygonModePoint
+	// FeatureConditionalRendering lets RenderPassEncoder.BeginConditionalRendering
+	// skip draws on the GPU. Supported by Vulkan (VK_EXT_conditional_rendering)
+	// and DX12 (SetPredication); without it the draws are always issued.
+	FeatureConditionalRendering = hal.FeatureConditionalRendering
+	// FeatureMultiview allows render pipelines and passes with a ViewCount
+	// above 1, up to Adapter.MaxMultiviewViewCount. Supported by Vulkan
+	// (VK_KHR_multiview), DX12 (view instancing) and Metal (vertex
+	// amplification).
+	FeatureMultiview = hal.FeatureMultiview
+	// FeatureProtectedContent allows protected textures, command encoders
+	// and surfaces, for compositing DRM-protected video. Protected memory
+	// cannot be read back by the host. Supported by Vulkan
+	// (VK_KHR_protected_memory, protected swapchains where the surface
+	// reports SurfaceCapabilities.Protected).
+	FeatureProtectedContent = hal.FeatureProtectedContent
+	// FeatureRobustBufferAccess bounds-checks shader buffer accesses, for
+	// devices that run untrusted shaders: out-of-bounds loads return zero
+	// (or a value from inside the binding) and out-of-bounds stores are
+	// discarded, instead of reading or corrupting other memory. Supported
+	// by Vulkan (robustBufferAccess, plus robustBufferAccess2 from
+	// VK_EXT_robustness2 when available), DX12 (descriptor bounds checks),
+	// Metal (naga's generated checks) and GLES contexts created with robust
+	// access (plus naga image checks).
+	//
+	// The checks cost performance in shaders that access buffers heavily,
+	// typically a few percent and more on tiled mobile GPUs; request the
+	// feature only when shader content is not trusted.
+	FeatureRobustBufferAccess = hal.FeatureRobustBufferAccess
+	// FeatureTextureFormatNV12 allows TextureFormatNV12 textures, typically
+	// imported from a video decoder, and their plane views. Supported by
+	// Vulkan (samplerYcbcrConversion) and DX12.
+	FeatureTextureFormatNV12 = hal.FeatureTextureFormatNV12
+	// FeatureTextureFormatP010 allows TextureFormatP010 textures and their
+	// plane views. Supported by Vulkan (samplerYcbcrConversion) and DX12.
+	FeatureTextureFormatP010 = hal.FeatureTextureFormatP010
+)
+
+// validatePolygonMode checks that the device enables the feature a
+// non-fill polygon mode needs.
+func validatePolygonMode(mode PolygonMode, features Features) error {
+	var feature gputypes.Feature
+	switch mode {
+	case PolygonModeFill:
+		return nil
+	case PolygonModeLine:
+		feature = FeaturePolygonModeLine
+	case PolygonModePoint:
+		feature = FeaturePolygonModePoint
+	default:
+		return fmt.Errorf("wgpu: unknown polygon mode %d", mode)
+	}
+	if !features.Contains(feature) {
+		return fmt.Errorf("wgpu: polygon mode %s requires %s: %w", mode, hal.FeatureName(feature), ErrFeatureNotSupported)
+	}
+	return nil
+}
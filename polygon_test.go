@@ -0,0 +1,47 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestPolygonModeMatchesHAL(t *testing.T) {
+	if FeaturePolygonModeLine != hal.FeaturePolygonModeLine || FeaturePolygonModePoint != hal.FeaturePolygonModePoint {
+		t.Error("root polygon mode features differ from hal")
+	}
+	if FeatureRobustBufferAccess != hal.FeatureRobustBufferAccess || hal.FeatureName(FeatureRobustBufferAccess) != "RobustBufferAccess" {
+		t.Error("root FeatureRobustBufferAccess differs from hal")
+	}
+	if hal.PolygonMode(PolygonModeLine) != hal.PolygonModeLine || hal.PolygonMode(PolygonModePoint) != hal.PolygonModePoint {
+		t.Error("root PolygonMode values differ from hal")
+	}
+}
+
+func TestValidatePolygonMode(t *testing.T) {
+	lineOnly := Features(FeaturePolygonModeLine)
+	if err := validatePolygonMode(PolygonModeFill, 0); err != nil {
+		t.Errorf("fill without features: %v", err)
+	}
+	if err := validatePolygonMode(PolygonModeLine, lineOnly); err != nil {
+		t.Errorf("line with FeaturePolygonModeLine: %v", err)
+	}
+	err := validatePolygonMode(PolygonModePoint, lineOnly)
+	if !errors.Is(err, ErrFeatureNotSupported) || !strings.Contains(err.Error(), "PolygonModePoint") {
+		t.Errorf("point without FeaturePolygonModePoint = %v, want ErrFeatureNotSupported naming the feature", err)
+	}
+	if err := validatePolygonMode(PolygonMode(9), ^Features(0)); err == nil {
+		t.Error("unknown polygon mode accepted")
+	}
+}
+
+func TestRequestDeviceNamesMissingPolygonModeFeature(t *testing.T) {
+	err := checkRequiredFeatures(0, &DeviceDescriptor{RequiredFeatures: Features(FeaturePolygonModeLine)})
+	if !errors.Is(err, ErrFeatureNotSupported) || !strings.Contains(err.Error(), "PolygonModeLine") {
+		t.Errorf("checkRequiredFeatures = %v, want ErrFeatureNotSupported naming PolygonModeLine", err)
+	}
+}
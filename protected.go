@@ -0,0 +1,16 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// validateProtected checks that the device enables FeatureProtectedContent
+// when what, a texture, command encoder or surface, is to be protected.
+func validateProtected(what string, protected bool, features Features) error {
+	if protected && !features.Contains(FeatureProtectedContent) {
+		return fmt.Errorf("wgpu: protected %s requires %s: %w", what, hal.FeatureName(FeatureProtectedContent), ErrFeatureNotSupported)
+	}
+	return nil
+}
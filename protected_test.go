@@ -0,0 +1,39 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+func TestValidateProtected(t *testing.T) {
+	if FeatureProtectedContent != hal.FeatureProtectedContent {
+		t.Error("root FeatureProtectedContent differs from hal")
+	}
+	if err := validateProtected("texture", false, 0); err != nil {
+		t.Errorf("unprotected texture without features: %v", err)
+	}
+	err := validateProtected("texture", true, 0)
+	if !errors.Is(err, ErrFeatureNotSupported) || !strings.Contains(err.Error(), "ProtectedContent") {
+		t.Errorf("protected texture without FeatureProtectedContent = %v, want ErrFeatureNotSupported naming the feature", err)
+	}
+	if err := validateProtected("surface", true, Features(FeatureProtectedContent)); err != nil {
+		t.Errorf("protected surface with the feature: %v", err)
+	}
+}
+
+func TestDescriptorsCarryProtected(t *testing.T) {
+	if !(&TextureDescriptor{Protected: true}).toHAL().Protected {
+		t.Error("TextureDescriptor.toHAL dropped Protected")
+	}
+	if !(&CommandEncoderDescriptor{Protected: true}).toHAL().Protected {
+		t.Error("CommandEncoderDescriptor.toHAL dropped Protected")
+	}
+	if !(&SurfaceConfiguration{Protected: true}).toHAL().Protected {
+		t.Error("SurfaceConfiguration.toHAL dropped Protected")
+	}
+}
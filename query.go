@@ -0,0 +1,242 @@
+package wgpu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/gogpu/gputypes"
+)
+
+// QueryType specifies the kind of queries held by a QuerySet.
+type QueryType uint32
+
+const (
+	// QueryTypeOcclusion counts samples that pass the depth/stencil tests.
+	QueryTypeOcclusion QueryType = iota
+	// QueryTypeTimestamp records GPU timestamps. Requires
+	// gputypes.FeatureTimestampQuery on the device.
+	QueryTypeTimestamp
+	// QueryTypePipelineStatistics counts shader invocations and primitives
+	// between BeginPipelineStatisticsQuery and EndPipelineStatisticsQuery
+	// on a render or compute pass. Requires
+	// gputypes.FeaturePipelineStatisticsQuery on the device.
+	QueryTypePipelineStatistics
+)
+
+// String returns the WebGPU name of the query type.
+func (t QueryType) String() string {
+	switch t {
+	case QueryTypeOcclusion:
+		return "occlusion"
+	case QueryTypeTimestamp:
+		return "timestamp"
+	case QueryTypePipelineStatistics:
+		return "pipeline-statistics"
+	default:
+		return "unknown"
+	}
+}
+
+// MaxQueriesPerSet is the largest Count accepted by Device.CreateQuerySet,
+// matching the WebGPU limit of 4096 queries per set.
+const MaxQueriesPerSet = 4096
+
+// QuerySetDescriptor describes a query set.
+type QuerySetDescriptor struct {
+	Label string
+	Type  QueryType
+	// Count is the number of queries, between 1 and MaxQueriesPerSet.
+	Count uint32
+	// PipelineStatistics selects the counters of a
+	// QueryTypePipelineStatistics set; at least one must be set. Ignored
+	// for other query types.
+	PipelineStatistics PipelineStatisticsTypes
+}
+
+// PipelineStatisticsTypes selects the counters a pipeline statistics query
+// records. ResolveQuerySet writes one uint64 per selected counter for each
+// query, in the order of the flags below regardless of backend; use
+// DecodePipelineStatistics to read them.
+type PipelineStatisticsTypes uint32
+
+const (
+	// PipelineStatisticsVertexShaderInvocations counts vertex shader
+	// invocations.
+	PipelineStatisticsVertexShaderInvocations PipelineStatisticsTypes = 1 << iota
+	// PipelineStatisticsClipperInvocations counts primitives that reach the
+	// clipper.
+	PipelineStatisticsClipperInvocations
+	// PipelineStatisticsClipperPrimitivesOut counts primitives the clipper
+	// outputs.
+	PipelineStatisticsClipperPrimitivesOut
+	// PipelineStatisticsFragmentShaderInvocations counts fragment shader
+	// invocations.
+	PipelineStatisticsFragmentShaderInvocations
+	// PipelineStatisticsComputeShaderInvocations counts compute shader
+	// invocations.
+	PipelineStatisticsComputeShaderInvocations
+
+	pipelineStatisticsAll = PipelineStatisticsComputeShaderInvocations<<1 - 1
+)
+
+// count returns the number of selected counters.
+func (t PipelineStatisticsTypes) count() int { return bits.OnesCount32(uint32(t)) }
+
+// PipelineStatistics holds the resolved counters of one pipeline statistics
+// query. Counters the query set did not select are zero. Exact values are
+// implementation-defined: drivers may count helper invocations or skip
+// work culled early, so compare them between runs on one device rather
+// than against computed expectations.
+type PipelineStatistics struct {
+	VertexShaderInvocations   uint64
+	ClipperInvocations        uint64
+	ClipperPrimitivesOut      uint64
+	FragmentShaderInvocations uint64
+	ComputeShaderInvocations  uint64
+}
+
+// DecodePipelineStatistics decodes the results ResolveQuerySet wrote for a
+// pipeline statistics query set with the given counters, one
+// PipelineStatistics per query. len(data) must be a whole number of
+// queries.
+func DecodePipelineStatistics(types PipelineStatisticsTypes, data []byte) ([]PipelineStatistics, error) {
+	types &= pipelineStatisticsAll
+	if types == 0 {
+		return nil, fmt.Errorf("wgpu: DecodePipelineStatistics: no counters selected")
+	}
+	stride := 8 * types.count()
+	if len(data)%stride != 0 {
+		return nil, fmt.Errorf("wgpu: DecodePipelineStatistics: %d bytes is not a multiple of the %d-byte query result", len(data), stride)
+	}
+	out := make([]PipelineStatistics, len(data)/stride)
+	for i := range out {
+		fields := [...]*uint64{
+			&out[i].VertexShaderInvocations,
+			&out[i].ClipperInvocations,
+			&out[i].ClipperPrimitivesOut,
+			&out[i].FragmentShaderInvocations,
+			&out[i].ComputeShaderInvocations,
+		}
+		off := i * stride
+		for bit, field := range fields {
+			if types&(1<<bit) != 0 {
+				*field = binary.LittleEndian.Uint64(data[off:])
+				off += 8
+			}
+		}
+	}
+	return out, nil
+}
+
+// queryResultSize returns the bytes ResolveQuerySet writes per query.
+func queryResultSize(queryType QueryType, statistics PipelineStatisticsTypes) uint64 {
+	if queryType == QueryTypePipelineStatistics {
+		return 8 * uint64(statistics.count())
+	}
+	return 8
+}
+
+// PassTimestampWrites asks a compute or render pass to write GPU timestamps
+// into a timestamp QuerySet when the pass begins and ends. Resolve the
+// queries with CommandEncoder.ResolveQuerySet and multiply the tick
+// difference by Queue.GetTimestampPeriod to get nanoseconds.
+type PassTimestampWrites struct {
+	QuerySet *QuerySet
+	// BeginningOfPassWriteIndex is the query written at pass start, or nil.
+	BeginningOfPassWriteIndex *uint32
+	// EndOfPassWriteIndex is the query written at pass end, or nil.
+	EndOfPassWriteIndex *uint32
+}
+
+// validate checks the indices against the query set. At least one index
+// must be set, both must be in range, and they must differ.
+func (tw *PassTimestampWrites) validate(count uint32, queryType QueryType) error {
+	if queryType != QueryTypeTimestamp {
+		return fmt.Errorf("wgpu: timestamp writes: query set type is %s, want timestamp", queryType)
+	}
+	begin, end := tw.BeginningOfPassWriteIndex, tw.EndOfPassWriteIndex
+	if begin == nil && end == nil {
+		return fmt.Errorf("wgpu: timestamp writes: neither beginning nor end index is set")
+	}
+	if begin != nil && *begin >= count {
+		return fmt.Errorf("wgpu: timestamp writes: beginning index %d out of range (count %d)", *begin, count)
+	}
+	if end != nil && *end >= count {
+		return fmt.Errorf("wgpu: timestamp writes: end index %d out of range (count %d)", *end, count)
+	}
+	if begin != nil && end != nil && *begin == *end {
+		return fmt.Errorf("wgpu: timestamp writes: beginning and end index are both %d", *begin)
+	}
+	return nil
+}
+
+// validatePipelineStatisticsQuery checks the arguments of a pass's
+// BeginPipelineStatisticsQuery.
+func validatePipelineStatisticsQuery(method string, querySet *QuerySet, index uint32) error {
+	switch {
+	case querySet == nil:
+		return fmt.Errorf("wgpu: %s: query set is nil", method)
+	case querySet.released:
+		return fmt.Errorf("wgpu: %s: query set %q: %w", method, querySet.Label(), ErrReleased)
+	case querySet.Type() != QueryTypePipelineStatistics:
+		return fmt.Errorf("wgpu: %s: query set type is %s, want pipeline-statistics", method, querySet.Type())
+	case index >= querySet.Count():
+		return fmt.Errorf("wgpu: %s: query index %d out of range (count %d)", method, index, querySet.Count())
+	}
+	return nil
+}
+
+// validateQuerySetDescriptor applies the WebGPU createQuerySet rules.
+func validateQuerySetDescriptor(desc *QuerySetDescriptor, features Features) error {
+	if desc == nil {
+		return fmt.Errorf("wgpu: query set descriptor is nil")
+	}
+	if desc.Count == 0 || desc.Count > MaxQueriesPerSet {
+		return fmt.Errorf("wgpu: query set count %d out of range [1, %d]", desc.Count, MaxQueriesPerSet)
+	}
+	switch desc.Type {
+	case QueryTypeOcclusion:
+	case QueryTypeTimestamp:
+		if !features.Contains(gputypes.FeatureTimestampQuery) {
+			return fmt.Errorf("wgpu: timestamp query set requires gputypes.FeatureTimestampQuery: %w", ErrFeatureNotSupported)
+		}
+	case QueryTypePipelineStatistics:
+		if !features.Contains(gputypes.FeaturePipelineStatisticsQuery) {
+			return fmt.Errorf("wgpu: pipeline statistics query set requires gputypes.FeaturePipelineStatisticsQuery: %w", ErrFeatureNotSupported)
+		}
+		if desc.PipelineStatistics == 0 || desc.PipelineStatistics&^pipelineStatisticsAll != 0 {
+			return fmt.Errorf("wgpu: pipeline statistics query set selects invalid counters %#x", uint32(desc.PipelineStatistics))
+		}
+	default:
+		return fmt.Errorf("wgpu: unknown query type %d", desc.Type)
+	}
+	return nil
+}
+
+// validateResolveQuerySet applies the WebGPU resolveQuerySet rules.
+func validateResolveQuerySet(querySet *QuerySet, firstQuery, queryCount uint32, destination *Buffer, destinationOffset uint64) error {
+	const method = "wgpu: CommandEncoder.ResolveQuerySet"
+	if querySet == nil {
+		return fmt.Errorf("%s: query set is nil", method)
+	}
+	if destination == nil {
+		return fmt.Errorf("%s: destination buffer is nil", method)
+	}
+	if uint64(firstQuery)+uint64(queryCount) > uint64(querySet.Count()) {
+		return fmt.Errorf("%s: queries [%d, %d) exceed query set count %d",
+			method, firstQuery, uint64(firstQuery)+uint64(queryCount), querySet.Count())
+	}
+	if destinationOffset%256 != 0 {
+		return fmt.Errorf("%s: destination offset %d is not a multiple of 256", method, destinationOffset)
+	}
+	if destination.Usage()&BufferUsageQueryResolve == 0 {
+		return fmt.Errorf("%s: destination buffer lacks BufferUsageQueryResolve", method)
+	}
+	size := queryResultSize(querySet.Type(), querySet.statistics) * uint64(queryCount)
+	if destinationOffset+size > destination.Size() {
+		return fmt.Errorf("%s: %d results (%d bytes) at offset %d overflow destination of %d bytes",
+			method, queryCount, size, destinationOffset, destination.Size())
+	}
+	return nil
+}
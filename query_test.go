@@ -0,0 +1,205 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/core"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/noop"
+)
+
+func TestValidateQuerySetDescriptor(t *testing.T) {
+	timestamps := Features(0)
+	timestamps.Insert(gputypes.FeatureTimestampQuery)
+	statistics := Features(0)
+	statistics.Insert(gputypes.FeaturePipelineStatisticsQuery)
+	vertexInvocations := PipelineStatisticsVertexShaderInvocations
+
+	tests := []struct {
+		name     string
+		desc     *QuerySetDescriptor
+		features Features
+		wantErr  bool
+	}{
+		{"nil", nil, 0, true},
+		{"occlusion", &QuerySetDescriptor{Type: QueryTypeOcclusion, Count: 8}, 0, false},
+		{"zero count", &QuerySetDescriptor{Type: QueryTypeOcclusion}, 0, true},
+		{"count too large", &QuerySetDescriptor{Type: QueryTypeOcclusion, Count: MaxQueriesPerSet + 1}, 0, true},
+		{"timestamp without feature", &QuerySetDescriptor{Type: QueryTypeTimestamp, Count: 2}, 0, true},
+		{"timestamp with feature", &QuerySetDescriptor{Type: QueryTypeTimestamp, Count: 2}, timestamps, false},
+		{"unknown type", &QuerySetDescriptor{Type: QueryType(7), Count: 2}, timestamps, true},
+		{"statistics without feature", &QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 2, PipelineStatistics: vertexInvocations}, 0, true},
+		{"statistics with feature", &QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 2, PipelineStatistics: vertexInvocations}, statistics, false},
+		{"statistics without counters", &QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 2}, statistics, true},
+		{"statistics unknown counter", &QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 2, PipelineStatistics: 1 << 9}, statistics, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQuerySetDescriptor(tt.desc, tt.features)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateQuerySetDescriptor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := validateQuerySetDescriptor(&QuerySetDescriptor{Type: QueryTypeTimestamp, Count: 2}, 0)
+	if !errors.Is(err, ErrFeatureNotSupported) {
+		t.Errorf("missing feature error = %v, want ErrFeatureNotSupported", err)
+	}
+}
+
+func TestPassTimestampWritesValidate(t *testing.T) {
+	idx := func(v uint32) *uint32 { return &v }
+
+	tests := []struct {
+		name      string
+		tw        PassTimestampWrites
+		queryType QueryType
+		wantErr   bool
+	}{
+		{"both", PassTimestampWrites{BeginningOfPassWriteIndex: idx(0), EndOfPassWriteIndex: idx(1)}, QueryTypeTimestamp, false},
+		{"begin only", PassTimestampWrites{BeginningOfPassWriteIndex: idx(3)}, QueryTypeTimestamp, false},
+		{"end only", PassTimestampWrites{EndOfPassWriteIndex: idx(3)}, QueryTypeTimestamp, false},
+		{"neither", PassTimestampWrites{}, QueryTypeTimestamp, true},
+		{"begin out of range", PassTimestampWrites{BeginningOfPassWriteIndex: idx(4)}, QueryTypeTimestamp, true},
+		{"end out of range", PassTimestampWrites{EndOfPassWriteIndex: idx(4)}, QueryTypeTimestamp, true},
+		{"same index", PassTimestampWrites{BeginningOfPassWriteIndex: idx(1), EndOfPassWriteIndex: idx(1)}, QueryTypeTimestamp, true},
+		{"occlusion set", PassTimestampWrites{BeginningOfPassWriteIndex: idx(0)}, QueryTypeOcclusion, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tw.validate(4, tt.queryType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodePipelineStatistics(t *testing.T) {
+	types := PipelineStatisticsVertexShaderInvocations |
+		PipelineStatisticsFragmentShaderInvocations |
+		PipelineStatisticsComputeShaderInvocations
+	data := make([]byte, 2*3*8)
+	for i, v := range []uint64{3, 100, 0, 6, 200, 64} {
+		binary.LittleEndian.PutUint64(data[8*i:], v)
+	}
+	got, err := DecodePipelineStatistics(types, data)
+	if err != nil {
+		t.Fatalf("DecodePipelineStatistics: %v", err)
+	}
+	want := []PipelineStatistics{
+		{VertexShaderInvocations: 3, FragmentShaderInvocations: 100},
+		{VertexShaderInvocations: 6, FragmentShaderInvocations: 200, ComputeShaderInvocations: 64},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("DecodePipelineStatistics = %+v, want %+v", got, want)
+	}
+
+	if _, err := DecodePipelineStatistics(types, data[:16]); err == nil {
+		t.Error("partial query result decoded without error")
+	}
+	if _, err := DecodePipelineStatistics(0, data); err == nil {
+		t.Error("empty counter selection decoded without error")
+	}
+}
+
+func TestValidatePipelineStatisticsQuery(t *testing.T) {
+	stats := &QuerySet{queryType: QueryTypePipelineStatistics, count: 2, statistics: PipelineStatisticsVertexShaderInvocations}
+	released := &QuerySet{queryType: QueryTypePipelineStatistics, count: 2, released: true}
+	occlusion := &QuerySet{queryType: QueryTypeOcclusion, count: 2}
+
+	tests := []struct {
+		name    string
+		set     *QuerySet
+		index   uint32
+		wantErr bool
+	}{
+		{"valid", stats, 1, false},
+		{"nil set", nil, 0, true},
+		{"released set", released, 0, true},
+		{"occlusion set", occlusion, 0, true},
+		{"index out of range", stats, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePipelineStatisticsQuery("Pass.BeginPipelineStatisticsQuery", tt.set, tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePipelineStatisticsQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryResultSize(t *testing.T) {
+	if got := queryResultSize(QueryTypeTimestamp, 0); got != 8 {
+		t.Errorf("timestamp result size = %d, want 8", got)
+	}
+	types := PipelineStatisticsClipperInvocations | PipelineStatisticsClipperPrimitivesOut
+	if got := queryResultSize(QueryTypePipelineStatistics, types); got != 16 {
+		t.Errorf("two-counter statistics result size = %d, want 16", got)
+	}
+}
+
+func TestTimestampWritesNilQuerySet(t *testing.T) {
+	_, err := timestampWritesHAL(&PassTimestampWrites{})
+	if err == nil || errors.Is(err, ErrReleased) {
+		t.Fatalf("timestampWritesHAL(nil QuerySet) = %v, want a validation error", err)
+	}
+}
+
+// querySetDevice is a noop device that creates query sets.
+type querySetDevice struct{ noop.Device }
+
+func (*querySetDevice) CreateQuerySet(*hal.QuerySetDescriptor) (hal.QuerySet, error) {
+	return &noop.Resource{}, nil
+}
+
+func TestEncoderTracksQuerySet(t *testing.T) {
+	device := &Device{core: core.NewDevice(&querySetDevice{}, nil,
+		Features(gputypes.FeatureTimestampQuery), gputypes.DefaultLimits(), "query-ref-test")}
+	t.Cleanup(device.Release)
+	querySet, err := device.CreateQuerySet(&QuerySetDescriptor{Type: QueryTypeTimestamp, Count: 2})
+	if err != nil {
+		t.Fatalf("CreateQuerySet: %v", err)
+	}
+	defer querySet.Release()
+	resolve, err := device.CreateBuffer(&BufferDescriptor{Size: 256, Usage: BufferUsageQueryResolve})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer resolve.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	begin, end := uint32(0), uint32(1)
+	pass, err := encoder.BeginComputePass(&ComputePassDescriptor{TimestampWrites: &PassTimestampWrites{
+		QuerySet: querySet, BeginningOfPassWriteIndex: &begin, EndOfPassWriteIndex: &end,
+	}})
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	encoder.ResolveQuerySet(querySet, 0, 2, resolve, 0)
+	if got := querySet.ref.RefCount(); got != 3 {
+		t.Errorf("query set ref count = %d, want 3 (owner, pass, resolve)", got)
+	}
+
+	commands, err := encoder.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	commands.Release()
+	if got := querySet.ref.RefCount(); got != 1 {
+		t.Errorf("query set ref count after release = %d, want 1", got)
+	}
+}
@@ -0,0 +1,60 @@
+//go:build js && wasm
+
+package wgpu
+
+import (
+	"syscall/js"
+
+	"github.com/gogpu/wgpu/internal/browser"
+)
+
+// QuerySet holds occlusion or timestamp query results written by the GPU.
+// On browser, this wraps a GPUQuerySet via internal/browser.QuerySet.
+type QuerySet struct {
+	browser   *browser.QuerySet
+	label     string
+	queryType QueryType
+	count     uint32
+	released  bool
+	// statistics is the counter selection of a pipeline statistics set.
+	statistics PipelineStatisticsTypes
+}
+
+// Type returns the kind of queries in the set.
+func (q *QuerySet) Type() QueryType { return q.queryType }
+
+// PipelineStatistics returns the counters a pipeline statistics query set
+// records, or 0 for other query types.
+func (q *QuerySet) PipelineStatistics() PipelineStatisticsTypes { return q.statistics }
+
+// Count returns the number of queries in the set.
+func (q *QuerySet) Count() uint32 { return q.count }
+
+// Label returns the debug label.
+func (q *QuerySet) Label() string { return q.label }
+
+// Release destroys the query set.
+func (q *QuerySet) Release() {
+	if q.released {
+		return
+	}
+	q.released = true
+	if q.browser != nil {
+		q.browser.Destroy()
+	}
+}
+
+// buildTimestampWritesJS validates tw and converts it to a JS dictionary.
+func buildTimestampWritesJS(tw *PassTimestampWrites) (js.Value, error) {
+	if tw.QuerySet == nil || tw.QuerySet.released {
+		return js.Undefined(), ErrReleased
+	}
+	if err := tw.validate(tw.QuerySet.count, tw.QuerySet.queryType); err != nil {
+		return js.Undefined(), err
+	}
+	return browser.BuildPassTimestampWrites(
+		tw.QuerySet.browser.Ref(),
+		tw.BeginningOfPassWriteIndex,
+		tw.EndOfPassWriteIndex,
+	), nil
+}
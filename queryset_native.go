@@ -0,0 +1,78 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/core"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// QuerySet holds occlusion, timestamp or pipeline statistics query results
+// written by the GPU.
+type QuerySet struct {
+	hal       hal.QuerySet
+	device    *Device
+	label     string
+	queryType QueryType
+	count     uint32
+	released  bool
+	// statistics is the counter selection of a pipeline statistics set.
+	statistics PipelineStatisticsTypes
+	// ref counts the command buffers that record queries into the set.
+	ref *core.ResourceRef
+}
+
+// Type returns the kind of queries in the set.
+func (q *QuerySet) Type() QueryType { return q.queryType }
+
+// PipelineStatistics returns the counters a pipeline statistics query set
+// records, or 0 for other query types.
+func (q *QuerySet) PipelineStatistics() PipelineStatisticsTypes { return q.statistics }
+
+// Count returns the number of queries in the set.
+func (q *QuerySet) Count() uint32 { return q.count }
+
+// Label returns the debug label.
+func (q *QuerySet) Label() string { return q.label }
+
+// Release destroys the query set. Destruction is deferred until the GPU
+// completes any submission that may reference this query set.
+func (q *QuerySet) Release() {
+	if q.released {
+		return
+	}
+	q.released = true
+
+	halDevice := q.device.halDevice()
+	if halDevice == nil {
+		return
+	}
+
+	dq := q.device.destroyQueue()
+	if dq == nil {
+		halDevice.DestroyQuerySet(q.hal)
+		return
+	}
+
+	subIdx := q.device.lastSubmissionIndex()
+	halQuerySet := q.hal
+	dq.Defer(subIdx, "QuerySet", func() {
+		halDevice.DestroyQuerySet(halQuerySet)
+	})
+}
+
+// timestampWritesHAL validates tw and returns the HAL query set and indices.
+func timestampWritesHAL(tw *PassTimestampWrites) (hal.QuerySet, error) {
+	if tw.QuerySet == nil {
+		return nil, fmt.Errorf("wgpu: timestamp writes: query set is nil")
+	}
+	if tw.QuerySet.released {
+		return nil, ErrReleased
+	}
+	if err := tw.validate(tw.QuerySet.count, tw.QuerySet.queryType); err != nil {
+		return nil, err
+	}
+	return tw.QuerySet.hal, nil
+}
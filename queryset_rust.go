@@ -0,0 +1,74 @@
+//go:build rust
+
+package wgpu
+
+import rwgpu "github.com/go-webgpu/webgpu/wgpu"
+
+// QuerySet holds occlusion or timestamp query results written by the GPU.
+// On Rust backend, this wraps go-webgpu/webgpu QuerySet.
+type QuerySet struct {
+	r         *rwgpu.QuerySet
+	label     string
+	queryType QueryType
+	count     uint32
+	released  bool
+	// statistics is the counter selection of a pipeline statistics set.
+	statistics PipelineStatisticsTypes
+}
+
+// Type returns the kind of queries in the set.
+func (q *QuerySet) Type() QueryType { return q.queryType }
+
+// PipelineStatistics returns the counters a pipeline statistics query set
+// records, or 0 for other query types.
+func (q *QuerySet) PipelineStatistics() PipelineStatisticsTypes { return q.statistics }
+
+// Count returns the number of queries in the set.
+func (q *QuerySet) Count() uint32 { return q.count }
+
+// Label returns the debug label.
+func (q *QuerySet) Label() string { return q.label }
+
+// Release destroys the query set.
+func (q *QuerySet) Release() {
+	if q.released {
+		return
+	}
+	q.released = true
+	if q.r != nil {
+		q.r.Release()
+	}
+}
+
+// convertQueryTypeRust maps QueryType to the webgpu.h enum, which starts at 1.
+func convertQueryTypeRust(t QueryType) rwgpu.QueryType {
+	if t == QueryTypeTimestamp {
+		return rwgpu.QueryTypeTimestamp
+	}
+	return rwgpu.QueryTypeOcclusion
+}
+
+// convertTimestampWritesRust validates tw and converts it to go-webgpu form.
+func convertTimestampWritesRust(tw *PassTimestampWrites) (*rwgpu.PassTimestampWrites, error) {
+	if tw == nil {
+		return nil, nil
+	}
+	if tw.QuerySet == nil || tw.QuerySet.released {
+		return nil, ErrReleased
+	}
+	if err := tw.validate(tw.QuerySet.count, tw.QuerySet.queryType); err != nil {
+		return nil, err
+	}
+	rtw := &rwgpu.PassTimestampWrites{
+		QuerySet:                  tw.QuerySet.r,
+		BeginningOfPassWriteIndex: rwgpu.TimestampLocationUndefined,
+		EndOfPassWriteIndex:       rwgpu.TimestampLocationUndefined,
+	}
+	if tw.BeginningOfPassWriteIndex != nil {
+		rtw.BeginningOfPassWriteIndex = *tw.BeginningOfPassWriteIndex
+	}
+	if tw.EndOfPassWriteIndex != nil {
+		rtw.EndOfPassWriteIndex = *tw.EndOfPassWriteIndex
+	}
+	return rtw, nil
+}
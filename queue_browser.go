@@ -3,6 +3,8 @@
 package wgpu
 
 import (
+	"context"
+	"fmt"
 	"syscall/js"
 
 	"github.com/gogpu/wgpu/internal/browser"
@@ -12,6 +14,7 @@ import (
 // On browser, this wraps a GPUQueue via internal/browser.Queue.
 type Queue struct {
 	browser  *browser.Queue
+	device   *Device
 	released bool
 }
 
@@ -33,6 +36,48 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 	return 0, nil
 }
 
+// SubmitBatch submits desc.CommandBuffers like Submit. On browser, submission
+// indices are not tracked, so desc.WaitFor is ignored; the browser orders
+// submissions on its queue. Fences cannot be shared, so desc.WaitFences and
+// desc.SignalFences must be empty.
+func (q *Queue) SubmitBatch(_ context.Context, desc *SubmitDescriptor) (uint64, error) {
+	if desc == nil {
+		return 0, fmt.Errorf("wgpu: SubmitBatch: descriptor is nil")
+	}
+	if len(desc.WaitFences) > 0 || len(desc.SignalFences) > 0 {
+		return 0, fmt.Errorf("wgpu: SubmitBatch: fences: %w", ErrExternalNotSupported)
+	}
+	return q.Submit(desc.CommandBuffers...)
+}
+
+// OnSubmittedWorkDone returns a channel that receives exactly one value once
+// the GPU has finished the work submitted so far (GPUQueue.onSubmittedWorkDone):
+// nil, or ctx.Err() when ctx ends first. submission is ignored because the
+// browser does not track submission indices.
+func (q *Queue) OnSubmittedWorkDone(ctx context.Context, _ uint64) <-chan error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	done := make(chan error, 1)
+	if q.released {
+		done <- ErrReleased
+		return done
+	}
+	work := make(chan error, 1)
+	go func() {
+		work <- q.browser.OnSubmittedWorkDone()
+	}()
+	go func() {
+		select {
+		case err := <-work:
+			done <- err
+		case <-ctx.Done():
+			done <- ctx.Err()
+		}
+	}()
+	return done
+}
+
 // Poll returns the last completed submission index.
 // On browser, the GPU is polled automatically. Returns 0.
 func (q *Queue) Poll() uint64 {
@@ -49,6 +94,9 @@ func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
 	if buffer == nil || buffer.browser == nil {
 		return ErrReleased
 	}
+	if q.device != nil {
+		q.device.frame.addUploadBytes(len(data))
+	}
 	q.browser.WriteBuffer(buffer.browser.Ref(), offset, data)
 	return nil
 }
@@ -96,6 +144,10 @@ func (q *Queue) WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDa
 // WebGPU browser API handles swapchain synchronization internally.
 func (q *Queue) SetSwapchainSuppressed(_ bool) {}
 
+// GetTimestampPeriod returns the number of nanoseconds per timestamp query
+// tick. WebGPU timestamps are already in nanoseconds, so this returns 1.
+func (q *Queue) GetTimestampPeriod() float32 { return 1 }
+
 // LastSubmissionIndex returns the most recent submission index.
 // On browser, submission indices are not tracked. Returns 0.
 func (q *Queue) LastSubmissionIndex() uint64 {
@@ -0,0 +1,153 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// writeCountingQueue counts the buffer writes that reach the HAL queue.
+type writeCountingQueue struct {
+	hal.Queue
+	writes int
+}
+
+func (q *writeCountingQueue) WriteBuffer(buffer hal.Buffer, offset uint64, data []byte) error {
+	q.writes++
+	return q.Queue.WriteBuffer(buffer, offset, data)
+}
+
+// newDirectWriteQueue returns a device whose queue takes the unified-memory
+// direct write path, and the HAL queue counting staged writes.
+func newDirectWriteQueue(t *testing.T) (*Device, *writeCountingQueue) {
+	t.Helper()
+	inst, err := CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Release)
+	adapter, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	t.Cleanup(device.Release)
+	q := device.Queue()
+	if q == nil || q.pending == nil {
+		t.Skip("skipping: device has no HAL integration")
+	}
+
+	counting := &writeCountingQueue{Queue: q.hal}
+	q.pending.destroy()
+	q.hal = counting
+	q.pending = newPendingWrites(q.halDevice, counting, nil)
+	q.directWrites = true
+	return device, counting
+}
+
+func readHALBuffer(t *testing.T, q *Queue, buf *Buffer, size int) []byte {
+	t.Helper()
+	mapping, err := q.halDevice.MapBuffer(buf.halBuffer(), 0, uint64(size))
+	if err != nil {
+		t.Fatalf("MapBuffer: %v", err)
+	}
+	defer func() { _ = q.halDevice.UnmapBuffer(buf.halBuffer()) }()
+	return bytes.Clone(unsafe.Slice((*byte)(mapping.Ptr), size))
+}
+
+func TestWriteBufferDirectOnUnifiedMemory(t *testing.T) {
+	device, counting := newDirectWriteQueue(t)
+	q := device.Queue()
+
+	buf, err := device.CreateBuffer(&BufferDescriptor{Label: "vertices", Size: 16, Usage: BufferUsageVertex | BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	if err := WriteBufferSlice(q, buf, 4, []uint32{0x04030201, 0x08070605}); err != nil {
+		t.Fatalf("WriteBufferSlice: %v", err)
+	}
+	if counting.writes != 0 {
+		t.Errorf("idle buffer: %d staged writes, want 0", counting.writes)
+	}
+	want := []byte{0, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 0, 0, 0, 0}
+	if got := readHALBuffer(t, q, buf, 16); !bytes.Equal(got, want) {
+		t.Errorf("buffer = %v, want %v", got, want)
+	}
+}
+
+func TestWriteBufferStagesWhileGPUUsesBuffer(t *testing.T) {
+	device, counting := newDirectWriteQueue(t)
+	q := device.Queue()
+
+	buf, err := device.CreateBuffer(&BufferDescriptor{Label: "uniforms", Size: 16, Usage: BufferUsageUniform | BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	// Pretend a submission that has not completed yet reads the buffer.
+	buf.gpuUse.Store(q.hal.PollCompleted() + 1)
+	if err := q.WriteBuffer(buf, 0, make([]byte, 16)); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+	if counting.writes != 1 {
+		t.Fatalf("in-flight buffer: %d staged writes, want 1", counting.writes)
+	}
+	if buf.gpuUse.Load() != pendingGPUUse || len(q.stagedBuffers) != 1 {
+		t.Fatalf("staged write not tracked: gpuUse=%d staged=%d", buf.gpuUse.Load(), len(q.stagedBuffers))
+	}
+
+	// Until the staged write is submitted, later writes must stage too so
+	// the flushed copy cannot overwrite them.
+	if err := q.WriteBuffer(buf, 0, make([]byte, 4)); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+	if counting.writes != 2 || len(q.stagedBuffers) != 1 {
+		t.Fatalf("pending buffer: writes=%d staged=%d, want 2 and 1", counting.writes, len(q.stagedBuffers))
+	}
+
+	subIdx, err := q.Submit()
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if got := buf.gpuUse.Load(); got != subIdx {
+		t.Errorf("gpuUse after Submit = %d, want %d", got, subIdx)
+	}
+	if len(q.stagedBuffers) != 0 {
+		t.Errorf("stagedBuffers after Submit = %d, want 0", len(q.stagedBuffers))
+	}
+
+	// The noop backend completes submissions immediately.
+	if err := q.WriteBuffer(buf, 0, make([]byte, 4)); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+	if counting.writes != 2 {
+		t.Errorf("completed buffer: %d staged writes, want 2", counting.writes)
+	}
+}
+
+func TestWriteBufferSliceUnalignedSize(t *testing.T) {
+	device, _ := newDirectWriteQueue(t)
+
+	buf, err := device.CreateBuffer(&BufferDescriptor{Label: "indices", Size: 16, Usage: BufferUsageIndex | BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	if err := WriteBufferSlice(device.Queue(), buf, 0, []uint16{0, 1, 2}); err == nil {
+		t.Error("WriteBufferSlice with 6 bytes succeeded, want alignment error")
+	}
+	if err := WriteBufferSlice(device.Queue(), buf, 0, []uint16{0, 1, 2, 3}); err != nil {
+		t.Errorf("WriteBufferSlice with 8 bytes: %v", err)
+	}
+}
@@ -3,8 +3,12 @@
 package wgpu
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"sync"
+	"time"
+	"unsafe"
 
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
@@ -29,21 +33,127 @@ type Queue struct {
 	// resource destruction until after the latest known submission completes.
 	// Protected by mu.
 	lastSubmissionIndex uint64
+
+	// directWrites enables WriteBuffer's direct path on unified-memory
+	// adapters. Set once at creation.
+	directWrites bool
+	// stagedBuffers are buffers with staged writes not yet submitted.
+	// Only maintained when directWrites is set. Protected by mu.
+	stagedBuffers []*Buffer
+
+	// submissions logs the submissions in flight for the instance
+	// watchdog, when there is one.
+	submissions submissionLog
 }
 
+// pendingGPUUse marks a buffer whose staged write has not been submitted
+// yet. It compares greater than any completed submission index.
+const pendingGPUUse = math.MaxUint64
+
 // Submit submits command buffers for execution. Non-blocking.
-// Returns a submission index that can be used with Poll() to track completion.
+// Returns a submission index that can be used with Poll() or
+// OnSubmittedWorkDone to track completion.
 // Command buffers are owned by the caller — free them after Poll confirms completion.
 //
-// If there are pending WriteBuffer/WriteTexture operations, they are flushed
-// and prepended before the user command buffers in a single HAL submit.
-func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
+// All command buffers go to the backend in one submission. If there are
+// pending WriteBuffer/WriteTexture operations, they are flushed and prepended
+// before the user command buffers in the same HAL submit.
+func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (_ uint64, err error) {
+	defer startSpan("wgpu.Queue.Submit").End()
+	defer q.device.reportUncaptured("Queue.Submit", &err)
+
+	return q.submit(commandBuffers, nil, nil)
+}
+
+// SubmitBatch waits for the submissions in desc.WaitFor to complete and then
+// submits desc.CommandBuffers like Submit, returning the new submission index.
+// ctx bounds the wait; when it ends first, nothing is submitted and ctx.Err()
+// is returned. desc.WaitFences and desc.SignalFences are waited for and
+// signaled on the GPU; backends that cannot do so return an error wrapping
+// ErrExternalNotSupported.
+func (q *Queue) SubmitBatch(ctx context.Context, desc *SubmitDescriptor) (_ uint64, err error) {
+	defer startSpan("wgpu.Queue.SubmitBatch").End()
+	defer q.device.reportUncaptured("Queue.SubmitBatch", &err)
+
+	if desc == nil {
+		return 0, fmt.Errorf("wgpu: SubmitBatch: descriptor is nil")
+	}
+	for _, idx := range desc.WaitFor {
+		if err := q.waitSubmission(ctx, idx); err != nil {
+			return 0, fmt.Errorf("wgpu: SubmitBatch: %w", err)
+		}
+	}
+	return q.submit(desc.CommandBuffers, desc.WaitFences, desc.SignalFences)
+}
+
+// OnSubmittedWorkDone returns a channel that receives exactly one value once
+// the GPU has finished the work of submission, an index returned by Submit
+// or SubmitBatch: nil, or the error that stopped the wait. A submission of 0
+// stands for everything submitted so far. When ctx ends first, the channel
+// receives ctx.Err().
+func (q *Queue) OnSubmittedWorkDone(ctx context.Context, submission uint64) <-chan error {
+	if submission == 0 {
+		submission = q.LastSubmissionIndex()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- q.waitSubmission(ctx, submission)
+	}()
+	return done
+}
+
+// waitSubmission blocks until the submission with index idx has completed.
+// There is no per-submission wait in the HAL, so an incomplete submission is
+// waited for with WaitIdle on a worker goroutine, as Buffer.Map does.
+func (q *Queue) waitSubmission(ctx context.Context, idx uint64) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if q.hal == nil || q.halDevice == nil {
+		return ErrReleased
+	}
+	if idx == 0 || q.hal.PollCompleted() >= idx {
+		return nil
+	}
+	if last := q.LastSubmissionIndex(); idx > last {
+		return fmt.Errorf("wgpu: submission %d has not been submitted (last is %d)", idx, last)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	idle := make(chan error, 1)
+	go func() {
+		idle <- q.halDevice.WaitIdle()
+	}()
+	select {
+	case err := <-idle:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// submit validates commandBuffers and submits them, with any pending writes
+// in front, as one HAL submission that waits for waits and sets signals.
+func (q *Queue) submit(commandBuffers []*CommandBuffer, waits, signals []FenceValue) (uint64, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
 	if q.hal == nil {
 		return 0, fmt.Errorf("wgpu: queue not available")
 	}
+	if q.device != nil && q.device.lost.Load() {
+		return 0, fmt.Errorf("wgpu: submit: %w", *q.device.lostErr.Load())
+	}
+
+	halWaits, err := q.halFenceValues("wait", waits)
+	if err != nil {
+		return 0, err
+	}
+	halSignals, err := q.halFenceValues("signal", signals)
+	if err != nil {
+		return 0, err
+	}
 
 	// Validate user command buffers before closing the pending-write encoder.
 	// A validation error leaves buffered writes intact for a later valid Submit
@@ -55,8 +165,48 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 		if err := validateCommandBufferForSubmit(cb, i); err != nil {
 			return 0, err
 		}
+		if cb.protected != commandBuffers[0].protected {
+			return 0, fmt.Errorf("wgpu: command buffer at index %d: protected and unprotected command buffers cannot be submitted together", i)
+		}
+	}
+
+	// Pending writes are unprotected work, which a protected submission
+	// cannot carry, so they go first in a submission of their own.
+	if len(commandBuffers) > 0 && commandBuffers[0].protected {
+		if _, err := q.submitLocked(nil, true, nil, nil); err != nil {
+			return 0, err
+		}
+	}
+	return q.submitLocked(commandBuffers, false, halWaits, halSignals)
+}
+
+// halFenceValues converts the fence values of a submission, checking that
+// the queue can wait for and signal fences. kind names them in errors.
+func (q *Queue) halFenceValues(kind string, values []FenceValue) ([]hal.FenceValue, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if _, ok := q.hal.(hal.FenceSubmitter); !ok {
+		return nil, fmt.Errorf("wgpu: fence %ss: %w", kind, ErrExternalNotSupported)
+	}
+	out := make([]hal.FenceValue, len(values))
+	for i, v := range values {
+		if v.Fence == nil || v.Fence.released {
+			return nil, fmt.Errorf("wgpu: %s fence at index %d is nil or released", kind, i)
+		}
+		if v.Fence.device != q.device {
+			return nil, fmt.Errorf("wgpu: %s fence at index %d belongs to another device", kind, i)
+		}
+		out[i] = hal.FenceValue{Fence: v.Fence.hal, Value: v.Value}
 	}
+	return out, nil
+}
 
+// submitLocked submits validated commandBuffers behind any pending writes,
+// with the GPU waiting for waits first and setting signals when done.
+// With pendingOnly it submits just the pending writes, if there are any.
+// q.mu must be held.
+func (q *Queue) submitLocked(commandBuffers []*CommandBuffer, pendingOnly bool, waits, signals []hal.FenceValue) (uint64, error) {
 	// Flush pending writes under lock, then release lock before HAL submit.
 	var pendingCmdBuf hal.CommandBuffer
 	var flushedEncoder hal.CommandEncoder
@@ -85,8 +235,17 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 	for _, cb := range commandBuffers {
 		allBuffers = append(allBuffers, cb.halBuffer())
 	}
+	if pendingOnly && pendingCmdBuf == nil {
+		return q.lastSubmissionIndex, nil
+	}
 
-	subIdx, err := q.hal.Submit(allBuffers)
+	var subIdx uint64
+	var err error
+	if len(waits) > 0 || len(signals) > 0 {
+		subIdx, err = q.hal.(hal.FenceSubmitter).SubmitWithFences(allBuffers, waits, signals)
+	} else {
+		subIdx, err = q.hal.Submit(allBuffers)
+	}
 	if err != nil {
 		if q.pending != nil && pendingCmdBuf != nil {
 			q.pending.mu.Lock()
@@ -98,6 +257,13 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 
 	// Track the latest submission index for deferred resource destruction.
 	q.lastSubmissionIndex = subIdx
+	if q.device.watchdog() != nil {
+		q.submissions.record(subIdx, time.Now(), commandBuffers)
+	}
+
+	if q.directWrites {
+		q.trackBufferUse(subIdx, commandBuffers)
+	}
 
 	// Record inflight resources and clean up completed ones.
 	// dstTextures/dstBuffers prevent premature Release (BUG-DX12-006: use-after-free).
@@ -139,6 +305,28 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 	return subIdx, nil
 }
 
+// trackBufferUse records subIdx as the last submission accessing the buffers
+// the command buffers reference, directly or through bind groups, and the
+// buffers whose staged writes were flushed into it.
+func (q *Queue) trackBufferUse(subIdx uint64, commandBuffers []*CommandBuffer) {
+	for _, buf := range q.stagedBuffers {
+		buf.gpuUse.Store(subIdx)
+	}
+	clear(q.stagedBuffers)
+	q.stagedBuffers = q.stagedBuffers[:0]
+
+	for _, cb := range commandBuffers {
+		for buf := range cb.usedBuffers {
+			buf.gpuUse.Store(subIdx)
+		}
+		for bg := range cb.usedBindGroups {
+			for _, buf := range bg.boundBuffers {
+				buf.gpuUse.Store(subIdx)
+			}
+		}
+	}
+}
+
 // postSubmit handles bookkeeping after a successful HAL submit:
 // 1. Tracks Clone'd ResourceRefs for Drop on GPU completion (Phase 2)
 // 2. Schedules HAL encoder recycling via DestroyQueue (BUG-DX12-004)
@@ -192,6 +380,19 @@ func (q *Queue) postSubmit(subIdx uint64, commandBuffers []*CommandBuffer) {
 		})
 	}
 
+	// Protected command buffers own their command pool; free it once the
+	// GPU is done.
+	for _, cb := range commandBuffers {
+		if cb == nil || !cb.protected || q.halDevice == nil {
+			continue
+		}
+		halDevice := q.halDevice
+		halCmdBuf := cb.halBuffer()
+		dq.Defer(subIdx, "ProtectedCmdBuffer", func() {
+			halDevice.FreeCommandBuffer(halCmdBuf)
+		})
+	}
+
 	// Triage deferred resource destructions from the DestroyQueue.
 	// Resources whose GPU submissions have completed are now safe to destroy.
 	dq.Triage(q.hal.PollCompleted())
@@ -216,6 +417,11 @@ func (q *Queue) Poll() uint64 {
 // behavior on DX12 (upload heap is GENERIC_READ, read-only to GPU).
 // See BUG-DX12-003.
 //
+// On unified-memory adapters (see Adapter.HasUnifiedMemory), a write to a
+// buffer with coherent host-visible memory that no pending or in-flight
+// submission accesses is copied straight into the buffer's mapping, skipping
+// the staging copy. Other writes are staged as usual.
+//
 // Validation (VAL-A1, WebGPU spec §21.1):
 //   - Buffer must not be currently mapped
 //   - Buffer must have CopyDst usage
@@ -224,7 +430,10 @@ func (q *Queue) Poll() uint64 {
 //   - offset + data size must not exceed buffer size
 //
 // Matches Rust wgpu-core queue.rs:647-672 (validate_write_buffer_impl).
-func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
+func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) (err error) {
+	defer startSpan("wgpu.Queue.WriteBuffer").End()
+	defer q.device.reportUncaptured("Queue.WriteBuffer", &err)
+
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -267,10 +476,34 @@ func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
 
 	// --- end VAL-A1 ---
 
+	return q.writeBufferLocked(buffer, offset, data)
+}
+
+// writeMappedAtCreation uploads the contents of a staged MappedAtCreation
+// mapping, returned by core.Buffer.UnmapBuffer, into buffer. Core created
+// the HAL buffer with CopyDst for this, whatever the buffer's own usage.
+func (q *Queue) writeMappedAtCreation(buffer *Buffer, data []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.hal == nil {
+		return fmt.Errorf("wgpu: Buffer.Unmap: queue is nil")
+	}
+	if err := q.writeBufferLocked(buffer, 0, data); err != nil {
+		return fmt.Errorf("wgpu: Buffer.Unmap: %w", err)
+	}
+	return nil
+}
+
+// writeBufferLocked writes data to a validated buffer range.
+// Caller must hold q.mu.
+func (q *Queue) writeBufferLocked(buffer *Buffer, offset uint64, data []byte) error {
 	halBuffer := buffer.halBuffer()
 	if halBuffer == nil {
 		return fmt.Errorf("wgpu: WriteBuffer: no HAL buffer")
 	}
+	if q.device != nil {
+		q.device.frame.addUploadBytes(len(data))
+	}
 
 	// Always route through PendingWrites staging belt when available.
 	// Rust wgpu-core write_buffer() (queue.rs:549) ALWAYS creates a StagingBuffer,
@@ -279,19 +512,48 @@ func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
 	//
 	// DX12: MapWrite buffers now use HEAP_TYPE_CUSTOM with WRITE_COMBINE + COMMON
 	// state (matching Rust suballocation.rs:437), allowing CopyBufferRegion as dst.
+	if q.directWrites && q.writeBufferDirect(buffer, halBuffer, offset, data) {
+		return nil
+	}
+
 	if q.pending != nil {
+		if q.directWrites && buffer.gpuUse.Swap(pendingGPUUse) != pendingGPUUse {
+			q.stagedBuffers = append(q.stagedBuffers, buffer)
+		}
 		return q.pending.writeBuffer(halBuffer, buffer.Usage(), offset, data)
 	}
 
 	return q.hal.WriteBuffer(halBuffer, offset, data)
 }
 
+// writeBufferDirect copies data into the buffer's persistent mapping. It
+// returns false without writing when a submission may still access the
+// buffer or its memory is not host-visible and coherent.
+// Caller must hold q.mu.
+func (q *Queue) writeBufferDirect(buffer *Buffer, halBuffer hal.Buffer, offset uint64, data []byte) bool {
+	if len(data) == 0 || buffer.gpuUse.Load() > q.hal.PollCompleted() {
+		return false
+	}
+	mapping, err := q.halDevice.MapBuffer(halBuffer, offset, uint64(len(data)))
+	if err != nil {
+		return false
+	}
+	if mapping.IsCoherent {
+		copy(unsafe.Slice((*byte)(mapping.Ptr), len(data)), data)
+	}
+	_ = q.halDevice.UnmapBuffer(halBuffer)
+	return mapping.IsCoherent
+}
+
 // WriteTexture writes data to a texture.
 // If PendingWrites batching is enabled (DX12/Vulkan/Metal), the write is
 // recorded into a shared command encoder and flushed on the next Submit.
 // Resource barriers are computed from the texture's tracked CurrentUsage().
 // For GLES/Software backends, the write is performed immediately via HAL.
-func (q *Queue) WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDataLayout, size *Extent3D) error {
+func (q *Queue) WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDataLayout, size *Extent3D) (err error) {
+	defer startSpan("wgpu.Queue.WriteTexture").End()
+	defer q.device.reportUncaptured("Queue.WriteTexture", &err)
+
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -304,6 +566,9 @@ func (q *Queue) WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDa
 	if dst.Texture == nil {
 		return fmt.Errorf("wgpu: WriteTexture: destination texture is invalid")
 	}
+	if dst.Texture.protected {
+		return fmt.Errorf("wgpu: WriteTexture: destination texture is protected")
+	}
 	if layout == nil {
 		return fmt.Errorf("wgpu: WriteTexture: layout is nil")
 	}
@@ -345,6 +610,15 @@ func (q *Queue) SetSwapchainSuppressed(suppressed bool) {
 	}
 }
 
+// GetTimestampPeriod returns the number of nanoseconds per timestamp query
+// tick. Multiply resolved timestamp differences by it to get nanoseconds.
+func (q *Queue) GetTimestampPeriod() float32 {
+	if q == nil || q.hal == nil {
+		return 1
+	}
+	return q.hal.GetTimestampPeriod()
+}
+
 // LastSubmissionIndex returns the most recent submission index.
 // Used by resource Release() methods to schedule deferred destruction.
 // Safe for concurrent use — reads under the queue mutex.
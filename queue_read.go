@@ -0,0 +1,108 @@
+package wgpu
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReadBufferAsync copies len(dst) bytes starting at offset in buffer into dst
+// without blocking the caller. It records and submits a copy into a MapRead
+// staging buffer and returns a channel that receives exactly one value once
+// dst is filled (nil), or the error that stopped it.
+//
+// ctx bounds the wait for the GPU: when it is canceled or its deadline
+// passes, the channel receives ctx.Err() and dst is left untouched. dst must
+// not be accessed until the channel delivers.
+//
+// Staging buffers for reads of up to 1 MiB come from a per-device pool of
+// power-of-two size classes and are reused by later reads, so reading small
+// results every frame does not allocate GPU memory each time.
+//
+// buffer needs BufferUsageCopySrc; offset and len(dst) must be multiples of 4.
+// Validation and submission errors are returned directly.
+func (q *Queue) ReadBufferAsync(ctx context.Context, buffer *Buffer, offset uint64, dst []byte) (<-chan error, error) {
+	if q == nil || q.device == nil || buffer == nil {
+		return nil, ErrReleased
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	size := uint64(len(dst))
+	if buffer.Usage()&BufferUsageCopySrc == 0 {
+		return nil, fmt.Errorf("wgpu: Queue.ReadBufferAsync: buffer %q missing BufferUsageCopySrc: %w",
+			buffer.Label(), ErrBufferUsage)
+	}
+	if offset%4 != 0 || size%4 != 0 || offset > buffer.Size() || size > buffer.Size()-offset {
+		return nil, fmt.Errorf("wgpu: Queue.ReadBufferAsync: offset %d + %d bytes in buffer of size %d: %w",
+			offset, size, buffer.Size(), ErrBufferReadRange)
+	}
+	done := make(chan error, 1)
+	if size == 0 {
+		done <- nil
+		return done, nil
+	}
+
+	pool := &q.device.readbacks
+	staging, err := pool.acquire(q.device, size)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: Queue.ReadBufferAsync: %w", err)
+	}
+	if err := q.submitReadCopy(buffer, offset, staging, size); err != nil {
+		pool.release(staging, true)
+		return nil, err
+	}
+	go func() {
+		err := copyStagingBuffer(ctx, staging, dst)
+		pool.release(staging, err == nil)
+		done <- err
+	}()
+	return done, nil
+}
+
+// submitReadCopy records and submits the copy of size bytes from buffer at
+// offset into the start of staging.
+func (q *Queue) submitReadCopy(buffer *Buffer, offset uint64, staging *Buffer, size uint64) error {
+	encoder, err := q.device.CreateCommandEncoder(&CommandEncoderDescriptor{Label: "ReadBufferAsync"})
+	if err != nil {
+		return fmt.Errorf("wgpu: Queue.ReadBufferAsync: %w", err)
+	}
+	encoder.CopyBufferToBuffer(buffer, offset, staging, 0, size)
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("wgpu: Queue.ReadBufferAsync: %w", err)
+	}
+	if _, err := q.Submit(commands); err != nil {
+		commands.Release()
+		return fmt.Errorf("wgpu: Queue.ReadBufferAsync: %w", err)
+	}
+	return nil
+}
+
+// readStagingBuffer copies staging into dst like copyStagingBuffer and
+// releases it.
+func readStagingBuffer(ctx context.Context, staging *Buffer, dst []byte) error {
+	defer staging.Release()
+	return copyStagingBuffer(ctx, staging, dst)
+}
+
+// copyStagingBuffer waits for staging to map, copies its first len(dst) bytes
+// into dst and unmaps it. A map still pending when ctx ends is canceled by
+// the Unmap.
+func copyStagingBuffer(ctx context.Context, staging *Buffer, dst []byte) error {
+	size := uint64(len(dst))
+	if err := staging.Map(ctx, MapModeRead, 0, size); err != nil {
+		_ = staging.Unmap()
+		return err
+	}
+	rng, err := staging.MappedRange(0, size)
+	if err != nil {
+		_ = staging.Unmap()
+		return err
+	}
+	copy(dst, rng.Bytes())
+	rng.Release()
+	return staging.Unmap()
+}
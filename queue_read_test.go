@@ -0,0 +1,178 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+// newSoftwareDevice requests a device from the software fallback adapter,
+// which executes copies so read-backs see real data.
+func newSoftwareDevice(t *testing.T) *wgpu.Device {
+	t.Helper()
+	hal.RegisterBackend(software.API{})
+	inst, err := wgpu.CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Release)
+	adapter, err := inst.RequestAdapter(&wgpu.RequestAdapterOptions{ForceFallbackAdapter: true})
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	t.Cleanup(adapter.Release)
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	t.Cleanup(device.Release)
+	requireHAL(t, device)
+	return device
+}
+
+func newReadSource(t *testing.T, device *wgpu.Device, usage wgpu.BufferUsage, data []byte) *wgpu.Buffer {
+	t.Helper()
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{Label: "read-source", Size: uint64(len(data)), Usage: usage})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	t.Cleanup(buf.Release)
+	if err := device.Queue().WriteBuffer(buf, 0, data); err != nil {
+		t.Fatalf("WriteBuffer: %v", err)
+	}
+	return buf
+}
+
+func TestReadBufferAsync(t *testing.T) {
+	device := newSoftwareDevice(t)
+	src := newReadSource(t, device, wgpu.BufferUsageStorage|wgpu.BufferUsageCopySrc|wgpu.BufferUsageCopyDst,
+		[]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dst := make([]byte, 8)
+	done, err := device.Queue().ReadBufferAsync(ctx, src, 4, dst)
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("ReadBufferAsync completion: %v", err)
+	}
+	if want := []byte{4, 5, 6, 7, 8, 9, 10, 11}; !bytes.Equal(dst, want) {
+		t.Errorf("dst = %v, want %v", dst, want)
+	}
+}
+
+func TestReadBufferAsyncReusesStaging(t *testing.T) {
+	device := newSoftwareDevice(t)
+	src := newReadSource(t, device, wgpu.BufferUsageCopySrc|wgpu.BufferUsageCopyDst,
+		[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Reads of 4, 8 and 12 bytes share the smallest size class.
+	for frame, size := range []int{4, 8, 12} {
+		dst := make([]byte, size)
+		done, err := device.Queue().ReadBufferAsync(ctx, src, 0, dst)
+		if err != nil {
+			t.Fatalf("frame %d: ReadBufferAsync: %v", frame, err)
+		}
+		if err := <-done; err != nil {
+			t.Fatalf("frame %d: completion: %v", frame, err)
+		}
+		if dst[size-1] != byte(size) {
+			t.Fatalf("frame %d: dst = %v", frame, dst)
+		}
+		if got := device.TestReadbackPoolSize(); got != 1 {
+			t.Fatalf("frame %d: %d idle staging buffers, want 1", frame, got)
+		}
+	}
+}
+
+func TestReadBufferAsyncValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+	readable := newReadSource(t, device, wgpu.BufferUsageCopySrc|wgpu.BufferUsageCopyDst, make([]byte, 16))
+	writeOnly := newReadSource(t, device, wgpu.BufferUsageCopyDst, make([]byte, 16))
+	queue := device.Queue()
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		buffer *wgpu.Buffer
+		offset uint64
+		dst    []byte
+		want   error
+	}{
+		{"missing copy src", writeOnly, 0, make([]byte, 4), wgpu.ErrBufferUsage},
+		{"unaligned offset", readable, 2, make([]byte, 4), wgpu.ErrBufferReadRange},
+		{"unaligned length", readable, 0, make([]byte, 6), wgpu.ErrBufferReadRange},
+		{"past end", readable, 12, make([]byte, 8), wgpu.ErrBufferReadRange},
+		{"nil buffer", nil, 0, make([]byte, 4), wgpu.ErrReleased},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := queue.ReadBufferAsync(ctx, tt.buffer, tt.offset, tt.dst); !errors.Is(err, tt.want) {
+				t.Errorf("ReadBufferAsync = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := queue.ReadBufferAsync(canceled, readable, 0, make([]byte, 4)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadBufferAsync with canceled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestBufferStructuredRoundTrip(t *testing.T) {
+	type sample struct {
+		Value float32
+		Count uint32
+	}
+	device := newSoftwareDevice(t)
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "structured", Size: 32,
+		Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc | wgpu.BufferUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	in := []sample{{1.5, 2}, {-3, 4}}
+	if err := buf.WriteStructured(0, in); err != nil {
+		t.Fatalf("WriteStructured(slice): %v", err)
+	}
+	if err := buf.WriteStructured(16, sample{Value: 8, Count: 9}); err != nil {
+		t.Fatalf("WriteStructured(value): %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := make([]sample, 2)
+	if err := buf.ReadStructured(ctx, 0, out); err != nil {
+		t.Fatalf("ReadStructured(slice): %v", err)
+	}
+	if out[0] != in[0] || out[1] != in[1] {
+		t.Errorf("ReadStructured = %+v, want %+v", out, in)
+	}
+	var one sample
+	if err := buf.ReadStructured(ctx, 16, &one); err != nil || one != (sample{8, 9}) {
+		t.Errorf("ReadStructured(pointer) = %+v, %v", one, err)
+	}
+
+	if err := buf.ReadStructured(ctx, 0, one); !errors.Is(err, wgpu.ErrElementType) {
+		t.Errorf("ReadStructured(value) err = %v, want ErrElementType", err)
+	}
+	if err := buf.ReadStructured(ctx, 24, make([]sample, 2)); !errors.Is(err, wgpu.ErrBufferReadRange) {
+		t.Errorf("ReadStructured past the end err = %v, want ErrBufferReadRange", err)
+	}
+}
@@ -3,6 +3,7 @@
 package wgpu
 
 import (
+	"context"
 	"fmt"
 
 	rwgpu "github.com/go-webgpu/webgpu/wgpu"
@@ -12,6 +13,7 @@ import (
 // On Rust backend, this wraps go-webgpu/webgpu Queue.
 type Queue struct {
 	r        *rwgpu.Queue
+	device   *Device
 	released bool
 }
 
@@ -43,6 +45,60 @@ func (q *Queue) Submit(commandBuffers ...*CommandBuffer) (uint64, error) {
 	return idx, nil
 }
 
+// SubmitBatch waits for the submissions in desc.WaitFor to complete and then
+// submits desc.CommandBuffers like Submit. wgpu-native has no per-submission
+// wait, so any WaitFor entry waits for all submitted work. Fences cannot be
+// shared, so desc.WaitFences and desc.SignalFences must be empty.
+func (q *Queue) SubmitBatch(ctx context.Context, desc *SubmitDescriptor) (uint64, error) {
+	if desc == nil {
+		return 0, fmt.Errorf("wgpu: SubmitBatch: descriptor is nil")
+	}
+	if len(desc.WaitFences) > 0 || len(desc.SignalFences) > 0 {
+		return 0, fmt.Errorf("wgpu: SubmitBatch: fences: %w", ErrExternalNotSupported)
+	}
+	for _, idx := range desc.WaitFor {
+		if idx == 0 {
+			continue
+		}
+		if err := q.waitIdle(ctx); err != nil {
+			return 0, fmt.Errorf("wgpu: SubmitBatch: %w", err)
+		}
+		break
+	}
+	return q.Submit(desc.CommandBuffers...)
+}
+
+// OnSubmittedWorkDone returns a channel that receives exactly one value once
+// the GPU has finished the work of submission: nil, or ctx.Err() when ctx
+// ends first. On Rust backend it waits for all submitted work.
+func (q *Queue) OnSubmittedWorkDone(ctx context.Context, _ uint64) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- q.waitIdle(ctx)
+	}()
+	return done
+}
+
+// waitIdle blocks until all submitted work completes or ctx ends.
+func (q *Queue) waitIdle(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if q.released || q.device == nil {
+		return ErrReleased
+	}
+	idle := make(chan error, 1)
+	go func() {
+		idle <- q.device.WaitIdle()
+	}()
+	select {
+	case err := <-idle:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Poll returns the last completed submission index. Non-blocking.
 // On Rust backend, returns 0 (wgpu-native does not expose poll on queue).
 func (q *Queue) Poll() uint64 {
@@ -57,6 +113,9 @@ func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
 	if buffer == nil || buffer.r == nil {
 		return fmt.Errorf("wgpu: WriteBuffer: buffer is nil")
 	}
+	if q.device != nil {
+		q.device.frame.addUploadBytes(len(data))
+	}
 	return q.r.WriteBuffer(buffer.r, offset, data)
 }
 
@@ -100,6 +159,11 @@ func (q *Queue) WriteTexture(dst *ImageCopyTexture, data []byte, layout *ImageDa
 // SetSwapchainSuppressed is a no-op on the Rust backend.
 func (q *Queue) SetSwapchainSuppressed(_ bool) {}
 
+// GetTimestampPeriod returns the number of nanoseconds per timestamp query
+// tick. go-webgpu does not expose wgpu-native's period, so this assumes
+// nanosecond ticks and returns 1.
+func (q *Queue) GetTimestampPeriod() float32 { return 1 }
+
 // LastSubmissionIndex returns the most recent submission index.
 // On Rust backend, submission indices are not tracked. Returns 0.
 func (q *Queue) LastSubmissionIndex() uint64 {
@@ -0,0 +1,21 @@
+package wgpu
+
+import "unsafe"
+
+// WriteBufferSlice writes the elements of data to buffer at offset, like
+// Queue.WriteBuffer, reinterpreting the slice's backing array as bytes
+// instead of first encoding it into a []byte.
+//
+// T must be a fixed-size type without pointers whose memory layout matches
+// the shader's, such as float32, uint32 or a struct of them; uint16 index
+// slices need an even length to satisfy the 4-byte size alignment. The data
+// is copied before WriteBufferSlice returns, so the slice does not need to
+// stay alive or pinned afterwards.
+func WriteBufferSlice[T any](q *Queue, buffer *Buffer, offset uint64, data []T) error {
+	var bytes []byte
+	if len(data) > 0 {
+		size := len(data) * int(unsafe.Sizeof(data[0]))
+		bytes = unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(data))), size)
+	}
+	return q.WriteBuffer(buffer, offset, bytes)
+}
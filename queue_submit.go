@@ -0,0 +1,36 @@
+package wgpu
+
+// SubmitDescriptor describes a batched submission for Queue.SubmitBatch.
+type SubmitDescriptor struct {
+	// CommandBuffers are executed in order as one backend submission, so a
+	// frame recorded on several encoders costs a single vkQueueSubmit,
+	// ExecuteCommandLists or commit batch.
+	CommandBuffers []*CommandBuffer
+
+	// WaitFor lists submission indices, returned by earlier submits on the
+	// same queue, whose work must finish on the GPU before CommandBuffers
+	// are submitted. The wait happens on the calling goroutine, which makes
+	// it a frames-in-flight throttle: pass the index of the frame submitted
+	// N frames ago. Zero entries are ignored.
+	WaitFor []uint64
+
+	// WaitFences are fence values, typically signaled by another API such
+	// as a video decoder or CUDA, that the GPU waits for before it executes
+	// CommandBuffers. Unlike WaitFor, the wait does not block the caller.
+	WaitFences []FenceValue
+
+	// SignalFences are fence values the GPU sets once CommandBuffers have
+	// completed, for another API to wait on.
+	//
+	// Fences in WaitFences and SignalFences must come from
+	// Device.CreateSharedFence or Device.ImportFence. With no
+	// CommandBuffers, the submission carries only the waits and signals.
+	SignalFences []FenceValue
+}
+
+// FenceValue is a point on the timeline of a shared fence. Shared fence
+// values start at 0 and must only grow.
+type FenceValue struct {
+	Fence *Fence
+	Value uint64
+}
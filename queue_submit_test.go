@@ -0,0 +1,97 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+)
+
+// copyCommands records a copy of size bytes from src to dst on a new encoder.
+func copyCommands(t *testing.T, device *wgpu.Device, src, dst *wgpu.Buffer, size uint64) *wgpu.CommandBuffer {
+	t.Helper()
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	encoder.CopyBufferToBuffer(src, 0, dst, 0, size)
+	commands, err := encoder.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	return commands
+}
+
+// TestSubmitBatch submits two dependent copies recorded on separate encoders
+// in one batch that waits for an earlier submission.
+func TestSubmitBatch(t *testing.T) {
+	device := newSoftwareDevice(t)
+	usage := wgpu.BufferUsageCopySrc | wgpu.BufferUsageCopyDst
+	data := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	a := newReadSource(t, device, usage, data)
+	b := newReadSource(t, device, usage, make([]byte, len(data)))
+	c := newReadSource(t, device, usage, make([]byte, len(data)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := device.Queue()
+	first, err := queue.Submit(copyCommands(t, device, a, b, 4))
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	idx, err := queue.SubmitBatch(ctx, &wgpu.SubmitDescriptor{
+		CommandBuffers: []*wgpu.CommandBuffer{
+			copyCommands(t, device, a, b, uint64(len(data))),
+			copyCommands(t, device, b, c, uint64(len(data))),
+		},
+		WaitFor: []uint64{0, first},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBatch: %v", err)
+	}
+	if idx <= first || queue.LastSubmissionIndex() != idx {
+		t.Errorf("submission index = %d after %d, LastSubmissionIndex = %d", idx, first, queue.LastSubmissionIndex())
+	}
+	if err := <-queue.OnSubmittedWorkDone(ctx, idx); err != nil {
+		t.Fatalf("OnSubmittedWorkDone: %v", err)
+	}
+	if done := queue.Poll(); done < idx {
+		t.Errorf("Poll = %d after OnSubmittedWorkDone(%d)", done, idx)
+	}
+
+	got := make([]byte, len(data))
+	read, err := queue.ReadBufferAsync(ctx, c, 0, got)
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	if err := <-read; err != nil {
+		t.Fatalf("ReadBufferAsync completion: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("c = %v, want %v", got, data)
+	}
+}
+
+func TestSubmitBatchErrors(t *testing.T) {
+	device := newSoftwareDevice(t)
+	queue := device.Queue()
+	ctx := context.Background()
+
+	if _, err := queue.SubmitBatch(ctx, nil); err == nil {
+		t.Error("SubmitBatch(nil): no error")
+	}
+	future := queue.LastSubmissionIndex() + 100
+	if _, err := queue.SubmitBatch(ctx, &wgpu.SubmitDescriptor{WaitFor: []uint64{future}}); err == nil {
+		t.Errorf("SubmitBatch waiting for unsubmitted %d: no error", future)
+	}
+	if err := <-queue.OnSubmittedWorkDone(ctx, future); err == nil {
+		t.Errorf("OnSubmittedWorkDone(%d): no error", future)
+	}
+	if err := <-queue.OnSubmittedWorkDone(ctx, 0); err != nil {
+		t.Errorf("OnSubmittedWorkDone(0): %v", err)
+	}
+}
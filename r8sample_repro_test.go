@@ -364,8 +364,8 @@ func TestDrawIndexedRenders(t *testing.T) {
 		}},
 	})
 	rp.SetPipeline(pipeline)
-	rp.SetVertexBuffer(0, vbuf, 0)
-	rp.SetIndexBuffer(ibuf, gputypes.IndexFormatUint16, 0)
+	rp.SetVertexBuffer(0, vbuf, 0, wgpu.WholeSize)
+	rp.SetIndexBuffer(ibuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 	rp.DrawIndexed(6, 1, 0, 0, 0)
 	_ = rp.End()
 	enc.TransitionTextures([]wgpu.TextureBarrier{{Texture: outTex, Usage: wgpu.TextureUsageTransition{OldUsage: gputypes.TextureUsageRenderAttachment, NewUsage: gputypes.TextureUsageCopySrc}}})
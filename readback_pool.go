@@ -0,0 +1,127 @@
+package wgpu
+
+import (
+	"math/bits"
+	"sync"
+)
+
+const (
+	// minReadbackSize is the smallest pooled staging buffer; smaller reads
+	// share its size class.
+	minReadbackSize = 256
+	// maxPooledReadbackSize is the largest pooled staging buffer. Larger
+	// reads get a staging buffer of their exact size that is released after
+	// use.
+	maxPooledReadbackSize = 1 << 20
+	// readbackClassDepth is the number of idle buffers kept per size class.
+	readbackClassDepth = 4
+
+	readbackClasses = 13 // 256 B .. 1 MiB
+)
+
+// readbackPool recycles the MapRead staging buffers of Queue.ReadBufferAsync
+// so that reading a few bytes every frame does not create and destroy a
+// buffer each time. Buffers are grouped in power-of-two size classes; a read
+// takes an idle buffer of the smallest class that fits it. The zero value is
+// an empty pool.
+type readbackPool struct {
+	mu     sync.Mutex
+	free   [readbackClasses][]*Buffer
+	closed bool
+}
+
+// readbackClass returns the size class for a read of size bytes and the
+// size of that class's buffers. ok is false when size is too large to pool.
+func readbackClass(size uint64) (class int, classSize uint64, ok bool) {
+	if size > maxPooledReadbackSize {
+		return 0, 0, false
+	}
+	classSize = max(uint64(1)<<bits.Len64(size-1), minReadbackSize)
+	class = bits.Len64(classSize) - bits.Len64(minReadbackSize)
+	return class, classSize, true
+}
+
+// acquire returns an idle staging buffer of at least size bytes, creating
+// one on d when its class has none.
+func (p *readbackPool) acquire(d *Device, size uint64) (*Buffer, error) {
+	class, classSize, ok := readbackClass(size)
+	if !ok {
+		classSize = size
+	} else {
+		p.mu.Lock()
+		if n := len(p.free[class]); n > 0 {
+			buf := p.free[class][n-1]
+			p.free[class][n-1] = nil
+			p.free[class] = p.free[class][:n-1]
+			p.mu.Unlock()
+			return buf, nil
+		}
+		p.mu.Unlock()
+	}
+	return d.CreateBuffer(&BufferDescriptor{
+		Label: "ReadBufferAsync staging",
+		Size:  classSize,
+		Usage: BufferUsageMapRead | BufferUsageCopyDst,
+	})
+}
+
+// release returns buf to the pool once it is unmapped. Buffers that failed
+// to map, that belong to no size class, or that would exceed the class
+// depth are released instead.
+func (p *readbackPool) release(buf *Buffer, reusable bool) {
+	class, classSize, ok := readbackClass(buf.Size())
+	if reusable && ok && classSize == buf.Size() {
+		p.mu.Lock()
+		if !p.closed && len(p.free[class]) < readbackClassDepth {
+			p.free[class] = append(p.free[class], buf)
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+	}
+	buf.Release()
+}
+
+// idle returns the number of pooled buffers.
+func (p *readbackPool) idle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, class := range p.free {
+		n += len(class)
+	}
+	return n
+}
+
+// trim releases every idle buffer and returns their total size. Unlike
+// destroy, the pool keeps accepting buffers afterwards.
+func (p *readbackPool) trim() uint64 {
+	p.mu.Lock()
+	free := p.free
+	p.free = [readbackClasses][]*Buffer{}
+	p.mu.Unlock()
+
+	var size uint64
+	for _, class := range free {
+		for _, buf := range class {
+			size += buf.Size()
+			buf.Release()
+		}
+	}
+	return size
+}
+
+// destroy releases every idle buffer. Buffers returned afterwards are
+// released immediately.
+func (p *readbackPool) destroy() {
+	p.mu.Lock()
+	free := p.free
+	p.free = [readbackClasses][]*Buffer{}
+	p.closed = true
+	p.mu.Unlock()
+	for _, class := range free {
+		for _, buf := range class {
+			buf.Release()
+		}
+	}
+}
@@ -0,0 +1,28 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import "testing"
+
+func TestReadbackClass(t *testing.T) {
+	tests := []struct {
+		size      uint64
+		class     int
+		classSize uint64
+		ok        bool
+	}{
+		{4, 0, 256, true},
+		{256, 0, 256, true},
+		{260, 1, 512, true},
+		{4096, 4, 4096, true},
+		{maxPooledReadbackSize, readbackClasses - 1, maxPooledReadbackSize, true},
+		{maxPooledReadbackSize + 4, 0, 0, false},
+	}
+	for _, tt := range tests {
+		class, classSize, ok := readbackClass(tt.size)
+		if class != tt.class || classSize != tt.classSize || ok != tt.ok {
+			t.Errorf("readbackClass(%d) = (%d, %d, %v), want (%d, %d, %v)",
+				tt.size, class, classSize, ok, tt.class, tt.classSize, tt.ok)
+		}
+	}
+}
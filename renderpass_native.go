@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/gogpu/wgpu/core"
+	"github.com/gogpu/wgpu/hal"
 )
 
 // RenderPassEncoder records draw commands within a render pass.
@@ -52,6 +53,17 @@ type RenderPassEncoder struct {
 	// blendConstantSet tracks whether SetBlendConstant has been called.
 	// Matches Rust wgpu-core OptionalState for blend_constant.
 	blendConstantSet bool
+	// conditionalActive is true between BeginConditionalRendering and
+	// EndConditionalRendering.
+	conditionalActive bool
+	// conditionalRaw is the HAL encoder predicating draws, or nil when the
+	// device lacks FeatureConditionalRendering and draws are always issued.
+	conditionalRaw hal.ConditionalRenderPassEncoder
+	// statisticsActive is true between BeginPipelineStatisticsQuery and
+	// EndPipelineStatisticsQuery.
+	statisticsActive bool
+	// statisticsRaw is the HAL encoder counting into the active query.
+	statisticsRaw hal.PipelineStatisticsPassEncoder
 }
 
 // trackRef Clone()'s a ResourceRef and appends directly to the parent
@@ -69,6 +81,10 @@ func (p *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.SetPipeline: pipeline is nil"))
 		return
 	}
+	if err := p.core.CheckPipeline(pipeline.label, &pipeline.passContext); err != nil {
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.SetPipeline: %w", err))
+		return
+	}
 	p.currentPipelineBindGroupCount = pipeline.bindGroupCount
 	p.pipelineSet = true
 	p.requiredVertexBuffers = pipeline.requiredVertexBuffers
@@ -79,6 +95,7 @@ func (p *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 	p.binder.updateExpectations(pipeline.bindGroupLayouts)
 	p.binder.updateLateBufferBindingsFromPipeline(pipeline.lateSizedBufferGroups)
 	p.trackRef(pipeline.ref)
+	p.encoder.counters().addPipelineBind()
 	raw := p.core.RawPass()
 	if raw != nil && pipeline.hal != nil {
 		raw.SetPipeline(pipeline.hal)
@@ -87,7 +104,7 @@ func (p *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 
 // SetBindGroup sets a bind group for the given index.
 func (p *RenderPassEncoder) SetBindGroup(index uint32, group *BindGroup, offsets []uint32) {
-	if err := validateSetBindGroup("RenderPass", index, group, offsets, p.currentPipelineBindGroupCount); err != nil {
+	if err := validateSetBindGroup("RenderPass", p.encoder.device, index, group, offsets, p.currentPipelineBindGroupCount); err != nil {
 		p.encoder.setError(err)
 		return
 	}
@@ -118,8 +135,11 @@ func (p *RenderPassEncoder) SetBindGroup(index uint32, group *BindGroup, offsets
 	}
 }
 
-// SetVertexBuffer sets a vertex buffer for the given slot.
-func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset uint64) {
+// SetVertexBuffer binds size bytes of buffer, starting at offset, to the
+// given slot. Pass WholeSize for the rest of the buffer. The offset must be
+// a multiple of 4 and the range must fit in the buffer, or the encoder
+// records ErrBufferRange.
+func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset, size uint64) {
 	if buffer == nil {
 		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.SetVertexBuffer: buffer is nil"))
 		return
@@ -129,11 +149,13 @@ func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset
 	}
 	p.trackRef(buffer.core.Ref)
 	p.encoder.trackBuffer(buffer)
-	p.core.SetVertexBuffer(slot, buffer.coreBuffer(), offset)
+	p.core.SetVertexBuffer(slot, buffer.coreBuffer(), offset, size)
 }
 
-// SetIndexBuffer sets the index buffer.
-func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset uint64) {
+// SetIndexBuffer binds size bytes of buffer, starting at offset, as the
+// index buffer. Pass WholeSize for the rest of the buffer. The offset must
+// be a multiple of the index format size.
+func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset, size uint64) {
 	if buffer == nil {
 		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.SetIndexBuffer: buffer is nil"))
 		return
@@ -142,7 +164,7 @@ func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, o
 	p.indexBufferFormat = format
 	p.trackRef(buffer.core.Ref)
 	p.encoder.trackBuffer(buffer)
-	p.core.SetIndexBuffer(buffer.coreBuffer(), format, offset)
+	p.core.SetIndexBuffer(buffer.coreBuffer(), format, offset, size)
 }
 
 // SetViewport sets the viewport transformation.
@@ -220,6 +242,7 @@ func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstI
 	if !p.validateDrawState("Draw") {
 		return
 	}
+	p.encoder.counters().addDraws(1)
 	p.core.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
 }
 
@@ -241,6 +264,7 @@ func (p *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex ui
 			p.indexBufferFormat, *p.currentStripIndexFormat, ErrDrawIndexFormatMismatch))
 		return
 	}
+	p.encoder.counters().addDraws(1)
 	p.core.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
 }
 
@@ -262,32 +286,16 @@ func (p *RenderPassEncoder) MultiDrawIndirect(buffer *Buffer, offset uint64, dra
 		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.DrawIndirect: buffer is nil"))
 		return
 	}
-	// VAL-B3: Validate indirect buffer has INDIRECT usage.
-	// Matches Rust wgpu-core render.rs:2763 (check_usage(BufferUsages::INDIRECT)).
-	if buffer.Usage()&BufferUsageIndirect == 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.DrawIndirect: buffer %q missing BufferUsageIndirect usage: %w",
-			buffer.Label(), ErrDrawIndirectBufferUsage))
-		return
-	}
-	// VAL-B3: Validate indirect buffer offset is 4-byte aligned.
-	// Matches Rust wgpu-core render.rs:2766 (offset % 4 != 0).
-	if offset%4 != 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.DrawIndirect: offset %d is not 4-byte aligned: %w",
-			offset, ErrDrawIndirectOffsetAlignment))
-		return
-	}
-	// VAL-B3: Validate indirect args fit within buffer.
-	// DrawIndirect args: 4 × uint32 = 16 bytes. Matches Rust render.rs:2772-2779.
-	if !drawIndirectRangeFits(buffer.Size(), offset, drawCount) {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.MultiDrawIndirect: offset %d + %d draw(s) exceeds buffer size %d: %w",
-			offset, drawCount, buffer.Size(), ErrDrawIndirectBufferOverrun))
+	// VAL-B3: Validate INDIRECT usage, 4-byte offset alignment, and that
+	// every 16-byte record fits. Matches Rust render.rs:2763-2779.
+	if err := validateIndirectArgs("RenderPass.DrawIndirect", buffer, offset,
+		core.DrawIndirectArgsSize, drawCount, drawIndirectErrors); err != nil {
+		p.encoder.setError(err)
 		return
 	}
 	p.trackRef(buffer.core.Ref)
 	p.encoder.trackBuffer(buffer)
+	p.encoder.counters().addDraws(drawCount)
 	p.core.MultiDrawIndirect(buffer.coreBuffer(), offset, drawCount)
 }
 
@@ -322,37 +330,131 @@ func (p *RenderPassEncoder) MultiDrawIndexedIndirect(buffer *Buffer, offset uint
 		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.DrawIndexedIndirect: buffer is nil"))
 		return
 	}
-	// VAL-B3: Validate indirect buffer has INDIRECT usage.
-	// Matches Rust wgpu-core render.rs:2763 (check_usage(BufferUsages::INDIRECT)).
-	if buffer.Usage()&BufferUsageIndirect == 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.DrawIndexedIndirect: buffer %q missing BufferUsageIndirect usage: %w",
-			buffer.Label(), ErrDrawIndirectBufferUsage))
+	// VAL-B3: Validate INDIRECT usage, 4-byte offset alignment, and that
+	// every 20-byte record fits without offset+drawCount*20 wrapping.
+	if err := validateIndirectArgs("RenderPass.DrawIndexedIndirect", buffer, offset,
+		core.DrawIndexedIndirectArgsSize, drawCount, drawIndirectErrors); err != nil {
+		p.encoder.setError(err)
 		return
 	}
-	// VAL-B3: Validate indirect buffer offset is 4-byte aligned.
-	// Matches Rust wgpu-core render.rs:2766 (offset % 4 != 0).
-	if offset%4 != 0 {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.DrawIndexedIndirect: offset %d is not 4-byte aligned: %w",
-			offset, ErrDrawIndirectOffsetAlignment))
+	p.trackRef(buffer.core.Ref)
+	p.encoder.trackBuffer(buffer)
+	p.encoder.counters().addDraws(drawCount)
+	p.core.MultiDrawIndexedIndirect(buffer.coreBuffer(), offset, drawCount)
+}
+
+// BeginConditionalRendering makes the following draws conditional on the
+// 8-byte value at offset in buffer: they are skipped on the GPU when the value
+// is zero, or non-zero when inverted is true. Resolving an occlusion query
+// into the buffer gives GPU-side occlusion culling.
+//
+// The buffer needs BufferUsageIndirect and offset must be 8-byte aligned.
+// Devices without FeatureConditionalRendering issue the draws
+// unconditionally, so the same code runs everywhere. Must be paired with
+// EndConditionalRendering before End; blocks do not nest.
+func (p *RenderPassEncoder) BeginConditionalRendering(buffer *Buffer, offset uint64, inverted bool) {
+	if p.conditionalActive {
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: already active: %w",
+			ErrConditionalRenderingState))
 		return
 	}
-	// VAL-B3: Validate all indirect args fit within the buffer without allowing
-	// offset+drawCount*20 to wrap around uint64.
-	if !indexedIndirectRangeFits(buffer.Size(), offset, drawCount) {
-		p.encoder.setError(fmt.Errorf(
-			"wgpu: RenderPass.MultiDrawIndexedIndirect: offset %d + %d draw(s) exceeds buffer size %d: %w",
-			offset, drawCount, buffer.Size(), ErrDrawIndirectBufferOverrun))
+	if buffer == nil {
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: buffer is nil"))
 		return
 	}
+	if err := validateConditionalRenderingPredicate(buffer.Label(), buffer.Usage(), buffer.Size(), offset); err != nil {
+		p.encoder.setError(err)
+		return
+	}
+	p.conditionalActive = true
 	p.trackRef(buffer.core.Ref)
 	p.encoder.trackBuffer(buffer)
-	p.core.MultiDrawIndexedIndirect(buffer.coreBuffer(), offset, drawCount)
+	if !p.encoder.device.core.Features.Contains(FeatureConditionalRendering) {
+		return
+	}
+	raw, ok := p.core.RawPass().(hal.ConditionalRenderPassEncoder)
+	halBuffer := buffer.halBuffer()
+	if !ok || halBuffer == nil {
+		return
+	}
+	p.conditionalRaw = raw
+	raw.BeginConditionalRendering(halBuffer, offset, inverted)
+}
+
+// EndConditionalRendering ends the block started by BeginConditionalRendering.
+func (p *RenderPassEncoder) EndConditionalRendering() {
+	if !p.conditionalActive {
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.EndConditionalRendering: not active: %w",
+			ErrConditionalRenderingState))
+		return
+	}
+	p.endConditionalRendering()
+}
+
+func (p *RenderPassEncoder) endConditionalRendering() {
+	if p.conditionalRaw != nil {
+		p.conditionalRaw.EndConditionalRendering()
+		p.conditionalRaw = nil
+	}
+	p.conditionalActive = false
+}
+
+// BeginPipelineStatisticsQuery starts counting the pipeline statistics the
+// query set selects into query queryIndex; EndPipelineStatisticsQuery stops.
+// One query may be active at a time and must end before the pass does.
+// Requires a QueryTypePipelineStatistics set. Resolve each query before
+// beginning it again in a later render pass.
+func (p *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	const method = "RenderPass.BeginPipelineStatisticsQuery"
+	if p.statisticsActive {
+		p.encoder.setError(fmt.Errorf("wgpu: %s: a query is already active", method))
+		return
+	}
+	if err := validatePipelineStatisticsQuery(method, querySet, queryIndex); err != nil {
+		p.encoder.setError(err)
+		return
+	}
+	p.statisticsActive = true
+	p.trackRef(querySet.ref)
+	raw, ok := p.core.RawPass().(hal.PipelineStatisticsPassEncoder)
+	if !ok {
+		return
+	}
+	p.statisticsRaw = raw
+	raw.BeginPipelineStatisticsQuery(querySet.hal, queryIndex)
+}
+
+// EndPipelineStatisticsQuery ends the query started by
+// BeginPipelineStatisticsQuery.
+func (p *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	if !p.statisticsActive {
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.EndPipelineStatisticsQuery: no query is active"))
+		return
+	}
+	p.endPipelineStatisticsQuery()
+}
+
+func (p *RenderPassEncoder) endPipelineStatisticsQuery() {
+	if p.statisticsRaw != nil {
+		p.statisticsRaw.EndPipelineStatisticsQuery()
+		p.statisticsRaw = nil
+	}
+	p.statisticsActive = false
 }
 
 // End ends the render pass.
 // After this call, the encoder cannot be used again.
 func (p *RenderPassEncoder) End() error {
+	if p.statisticsActive {
+		p.endPipelineStatisticsQuery()
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.End: pipeline statistics query still active"))
+	}
+	if p.conditionalActive {
+		// Close the block so the backend pass ends cleanly; the encoder still
+		// fails at Finish.
+		p.endConditionalRendering()
+		p.encoder.setError(fmt.Errorf("wgpu: RenderPass.End: conditional rendering still active: %w",
+			ErrConditionalRenderingState))
+	}
 	return p.core.End()
 }
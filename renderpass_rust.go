@@ -3,7 +3,7 @@
 package wgpu
 
 import (
-	"math"
+	"fmt"
 
 	rwgpu "github.com/go-webgpu/webgpu/wgpu"
 	"github.com/gogpu/wgpu/internal/indirect"
@@ -14,6 +14,13 @@ import (
 type RenderPassEncoder struct {
 	r        *rwgpu.RenderPassEncoder
 	released bool
+	// counters receives FrameStatistics counts; nil discards them.
+	counters *frameCounters
+	// conditionalActive is set between BeginConditionalRendering and
+	// EndConditionalRendering.
+	conditionalActive bool
+	// err is the first validation error recorded in the pass; End returns it.
+	err error
 }
 
 // SetPipeline sets the active render pipeline.
@@ -21,6 +28,7 @@ func (p *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 	if pipeline == nil || pipeline.r == nil {
 		return
 	}
+	p.counters.addPipelineBind()
 	p.r.SetPipeline(pipeline.r)
 }
 
@@ -32,23 +40,24 @@ func (p *RenderPassEncoder) SetBindGroup(index uint32, group *BindGroup, offsets
 	p.r.SetBindGroup(index, group.r, offsets)
 }
 
-// SetVertexBuffer sets a vertex buffer for the given slot.
-// Offset is in bytes.
-func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset uint64) {
+// SetVertexBuffer binds size bytes of buffer, starting at offset, to the
+// given slot. Pass WholeSize for the rest of the buffer.
+func (p *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset, size uint64) {
 	if buffer == nil || buffer.r == nil {
 		return
 	}
-	// go-webgpu takes (slot, buffer, offset, size). Pass MaxUint64 for "rest of buffer".
-	p.r.SetVertexBuffer(slot, buffer.r, offset, math.MaxUint64)
+	// go-webgpu takes (slot, buffer, offset, size) and reads MaxUint64,
+	// which WholeSize equals, as "rest of buffer".
+	p.r.SetVertexBuffer(slot, buffer.r, offset, size)
 }
 
-// SetIndexBuffer sets the index buffer.
-func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset uint64) {
+// SetIndexBuffer binds size bytes of buffer, starting at offset, as the
+// index buffer. Pass WholeSize for the rest of the buffer.
+func (p *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format IndexFormat, offset, size uint64) {
 	if buffer == nil || buffer.r == nil {
 		return
 	}
-	// go-webgpu takes (buffer, format, offset, size). Pass MaxUint64 for "rest of buffer".
-	p.r.SetIndexBuffer(buffer.r, format, offset, math.MaxUint64)
+	p.r.SetIndexBuffer(buffer.r, format, offset, size)
 }
 
 // SetViewport sets the viewport transformation.
@@ -81,11 +90,13 @@ func (p *RenderPassEncoder) SetStencilReference(reference uint32) {
 
 // Draw draws primitives.
 func (p *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firstInstance uint32) {
+	p.counters.addDraws(1)
 	p.r.Draw(vertexCount, instanceCount, firstVertex, firstInstance)
 }
 
 // DrawIndexed draws indexed primitives.
 func (p *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex uint32, baseVertex int32, firstInstance uint32) {
+	p.counters.addDraws(1)
 	p.r.DrawIndexed(indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
 }
 
@@ -102,6 +113,7 @@ func (p *RenderPassEncoder) MultiDrawIndirect(buffer *Buffer, offset uint64, dra
 	if buffer == nil || buffer.r == nil {
 		return
 	}
+	p.counters.addDraws(drawCount)
 	if !drawIndirectRangeFits(buffer.Size(), offset, drawCount) {
 		p.r.DrawIndirect(buffer.r, indirect.DelegatedValidationOffset(buffer.Size(), offset, drawIndirectRecordSize, drawCount))
 		return
@@ -126,6 +138,7 @@ func (p *RenderPassEncoder) MultiDrawIndexedIndirect(buffer *Buffer, offset uint
 	if buffer == nil || buffer.r == nil {
 		return
 	}
+	p.counters.addDraws(drawCount)
 	lowerRustIndexedIndirect(buffer.Size(), offset, drawCount, func(recordOffset uint64) {
 		p.r.DrawIndexedIndirect(buffer.r, recordOffset)
 	})
@@ -148,12 +161,64 @@ func lowerRustIndexedIndirect(bufferSize, offset uint64, drawCount uint32, draw
 	}
 }
 
+// BeginConditionalRendering starts a conditional rendering block. This
+// backend never reports FeatureConditionalRendering, so the draws are always
+// issued; the buffer and the pass state are still validated, and End returns
+// the first error.
+func (p *RenderPassEncoder) BeginConditionalRendering(buffer *Buffer, offset uint64, _ bool) {
+	if p.conditionalActive {
+		p.setError(fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: already active: %w",
+			ErrConditionalRenderingState))
+		return
+	}
+	if buffer == nil {
+		p.setError(fmt.Errorf("wgpu: RenderPass.BeginConditionalRendering: buffer is nil"))
+		return
+	}
+	if err := validateConditionalRenderingPredicate(buffer.Label(), buffer.Usage(), buffer.Size(), offset); err != nil {
+		p.setError(err)
+		return
+	}
+	p.conditionalActive = true
+}
+
+// EndConditionalRendering ends the block started by BeginConditionalRendering.
+func (p *RenderPassEncoder) EndConditionalRendering() {
+	if !p.conditionalActive {
+		p.setError(fmt.Errorf("wgpu: RenderPass.EndConditionalRendering: not active: %w",
+			ErrConditionalRenderingState))
+		return
+	}
+	p.conditionalActive = false
+}
+
+// setError records err unless an earlier error was recorded.
+func (p *RenderPassEncoder) setError(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// BeginPipelineStatisticsQuery starts a pipeline statistics query. This
+// backend cannot create pipeline statistics query sets, so there is never a
+// query to begin.
+func (p *RenderPassEncoder) BeginPipelineStatisticsQuery(_ *QuerySet, _ uint32) {}
+
+// EndPipelineStatisticsQuery ends the query started by
+// BeginPipelineStatisticsQuery.
+func (p *RenderPassEncoder) EndPipelineStatisticsQuery() {}
+
 // End ends the render pass.
 func (p *RenderPassEncoder) End() error {
 	if p.released {
 		return ErrReleased
 	}
 	p.released = true
+	if p.conditionalActive {
+		p.conditionalActive = false
+		p.setError(fmt.Errorf("wgpu: RenderPass.End: conditional rendering still active: %w",
+			ErrConditionalRenderingState))
+	}
 	p.r.End()
-	return nil
+	return p.err
 }
@@ -203,6 +203,54 @@ func TestTextureReleaseIdempotent(t *testing.T) {
 	tex.Release() // idempotent — should not panic
 }
 
+func TestCreateTextures(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	descs := []*wgpu.TextureDescriptor{
+		{
+			Label:         "batch-color",
+			Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+			MipLevelCount: 1,
+			SampleCount:   1,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        wgpu.TextureFormatRGBA8Unorm,
+			Usage:         wgpu.TextureUsageTextureBinding,
+		},
+		{
+			Label:         "batch-mips",
+			Size:          wgpu.Extent3D{Width: 8, Height: 8, DepthOrArrayLayers: 1},
+			MipLevelCount: 4,
+			SampleCount:   1,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        gputypes.TextureFormatR8Unorm,
+			Usage:         wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst,
+		},
+	}
+	textures, err := device.CreateTextures(descs)
+	if err != nil {
+		t.Fatalf("CreateTextures: %v", err)
+	}
+	if len(textures) != len(descs) {
+		t.Fatalf("CreateTextures returned %d textures, want %d", len(textures), len(descs))
+	}
+	for i, tex := range textures {
+		if got := tex.Format(); got != descs[i].Format {
+			t.Errorf("texture %d format = %v, want %v", i, got, descs[i].Format)
+		}
+		tex.Release()
+	}
+
+	// An invalid descriptor fails the batch before anything is created.
+	requireValidation(t)
+	invalid := *descs[0]
+	invalid.Size.Width = 0
+	if _, err := device.CreateTextures([]*wgpu.TextureDescriptor{descs[0], &invalid}); err == nil {
+		t.Error("CreateTextures with a zero-width texture succeeded")
+	}
+}
+
 // =============================================================================
 // TextureView accessor tests
 // Covers texture_native.go TextureView missed lines
@@ -306,6 +354,7 @@ func TestShaderModuleReleaseIdempotent(t *testing.T) {
 }
 
 func TestShaderModuleEmptyWGSL(t *testing.T) {
+	requireValidation(t)
 	_, _, device := newDevice(t)
 	defer device.Release()
 
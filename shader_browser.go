@@ -10,6 +10,10 @@ type ShaderModule struct {
 	released bool
 }
 
+// Warnings returns nil: the browser reports compiler messages through
+// GPUShaderModule.getCompilationInfo, which is not read.
+func (m *ShaderModule) Warnings() []ShaderDiagnostic { return nil }
+
 // Release destroys the shader module.
 func (m *ShaderModule) Release() {
 	if m.released {
@@ -0,0 +1,119 @@
+package wgpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShaderSeverity is the severity of a ShaderDiagnostic.
+type ShaderSeverity uint8
+
+const (
+	// ShaderSeverityError marks a diagnostic that stops compilation.
+	ShaderSeverityError ShaderSeverity = iota
+	// ShaderSeverityWarning marks a diagnostic about a shader that still
+	// compiles, such as an unused variable.
+	ShaderSeverityWarning
+)
+
+// String returns "error" or "warning".
+func (s ShaderSeverity) String() string {
+	if s == ShaderSeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// ShaderSpan locates a diagnostic in the shader source. Lines and columns
+// start at 1 and columns count bytes; a zero Line means the location is
+// unknown. The span ends before EndColumn, and EndLine is zero when only
+// the start is known.
+type ShaderSpan struct {
+	Line, Column       int
+	EndLine, EndColumn int
+}
+
+// ShaderDiagnostic is a message from the shader compiler.
+type ShaderDiagnostic struct {
+	Severity ShaderSeverity
+	Message  string
+	Span     ShaderSpan
+	// Notes add context that does not belong to a source location.
+	Notes []string
+}
+
+// ShaderCompileError is returned by CreateShaderModule when WGSL source does
+// not compile. Diagnostics lists the errors the compiler reported, and the
+// message names the shader by label and quotes the source line of each one.
+type ShaderCompileError struct {
+	Label       string
+	Source      string
+	Diagnostics []ShaderDiagnostic
+
+	err error // the compiler's error
+}
+
+// Error formats the diagnostics with source excerpts.
+func (e *ShaderCompileError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("wgpu: shader module")
+	if e.Label != "" {
+		fmt.Fprintf(&sb, " %q", e.Label)
+	}
+	sb.WriteString(" failed to compile")
+	for _, d := range e.Diagnostics {
+		sb.WriteByte('\n')
+		writeShaderDiagnostic(&sb, e.Label, e.Source, &d)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Unwrap returns the compiler's error.
+func (e *ShaderCompileError) Unwrap() error { return e.err }
+
+// writeShaderDiagnostic formats d in the style of rustc and naga:
+//
+//	error: unresolved identifier: c
+//	  --> shader:2:10
+//	   |
+//	 2 |  let b = c;
+//	   |          ^
+//	   = note: ...
+func writeShaderDiagnostic(sb *strings.Builder, label, source string, d *ShaderDiagnostic) {
+	fmt.Fprintf(sb, "%s: %s\n", d.Severity, d.Message)
+	if label == "" {
+		label = "wgsl"
+	}
+	span := d.Span
+	lines := strings.Split(source, "\n")
+	if span.Line < 1 || span.Line > len(lines) {
+		for _, note := range d.Notes {
+			fmt.Fprintf(sb, "  = note: %s\n", note)
+		}
+		return
+	}
+
+	line := strings.TrimSuffix(lines[span.Line-1], "\r")
+	number := fmt.Sprint(span.Line)
+	gutter := strings.Repeat(" ", len(number))
+	fmt.Fprintf(sb, "%s--> %s:%d:%d\n", gutter, label, span.Line, span.Column)
+	fmt.Fprintf(sb, "%s |\n", gutter)
+	fmt.Fprintf(sb, "%s | %s\n", number, line)
+
+	start := min(max(span.Column, 1), len(line)+1)
+	end := start + 1
+	if span.EndLine == span.Line && span.EndColumn > start {
+		end = min(span.EndColumn, len(line)+1)
+	}
+	// Keep tabs in the padding so the caret lines up under the source.
+	pad := []byte(line[:start-1])
+	for i, c := range pad {
+		if c != '\t' {
+			pad[i] = ' '
+		}
+	}
+	fmt.Fprintf(sb, "%s | %s%s\n", gutter, pad, strings.Repeat("^", max(end-start, 1)))
+	for _, note := range d.Notes {
+		fmt.Fprintf(sb, "%s = note: %s\n", gutter, note)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/gogpu/naga/wgsl"
+)
+
+// compileWGSLDiagnostics turns a failure of naga's WGSL front end into a
+// ShaderCompileError.
+func compileWGSLDiagnostics(label, source string, err error) *ShaderCompileError {
+	return &ShaderCompileError{
+		Label:       label,
+		Source:      source,
+		Diagnostics: nagaDiagnostics(err),
+		err:         err,
+	}
+}
+
+// nagaDiagnostics converts an error of naga's WGSL front end into
+// diagnostics. A wgsl.ParseError carries its location; any other error
+// becomes one diagnostic without a location, whose message still names the
+// line and column when naga's text includes them.
+func nagaDiagnostics(err error) []ShaderDiagnostic {
+	var parseErr wgsl.ParseError
+	if ptr := (*wgsl.ParseError)(nil); errors.As(err, &ptr) && ptr != nil {
+		parseErr = *ptr
+	} else if !errors.As(err, &parseErr) {
+		return []ShaderDiagnostic{{Severity: ShaderSeverityError, Message: err.Error()}}
+	}
+	return []ShaderDiagnostic{{
+		Severity: ShaderSeverityError,
+		Message:  parseErr.Message,
+		Span:     ShaderSpan{Line: parseErr.Line, Column: parseErr.Column},
+	}}
+}
+
+// wgslWarnings converts naga's lowering warnings into diagnostics, sorted by
+// position since naga collects some of them from maps.
+func wgslWarnings(warnings []wgsl.Warning) []ShaderDiagnostic {
+	if len(warnings) == 0 {
+		return nil
+	}
+	diags := make([]ShaderDiagnostic, len(warnings))
+	for i, w := range warnings {
+		diags[i] = ShaderDiagnostic{
+			Severity: ShaderSeverityWarning,
+			Message:  w.Message,
+			Span: ShaderSpan{
+				Line:      w.Span.Start.Line,
+				Column:    w.Span.Start.Column,
+				EndLine:   w.Span.End.Line,
+				EndColumn: w.Span.End.Column,
+			},
+		}
+	}
+	slices.SortStableFunc(diags, func(a, b ShaderDiagnostic) int {
+		return cmp.Or(cmp.Compare(a.Span.Line, b.Span.Line), cmp.Compare(a.Span.Column, b.Span.Column))
+	})
+	return diags
+}
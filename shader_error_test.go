@@ -0,0 +1,144 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/naga/wgsl"
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/core"
+)
+
+func TestCreateShaderModuleCompileError(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	for _, tc := range []struct {
+		name, source string
+		message      string
+	}{
+		{
+			name:    "parse",
+			source:  "@compute @workgroup_size(1)\nfn main( {\n}\n",
+			message: "line 2, column 10: expected parameter name",
+		},
+		{
+			name:    "lower",
+			source:  "const a = 1u;\n\n\tconst b: u32 = missing;\n",
+			message: "unknown constant 'missing'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "broken.wgsl", WGSL: tc.source})
+			var compileErr *wgpu.ShaderCompileError
+			if !errors.As(err, &compileErr) {
+				t.Fatalf("CreateShaderModule = %v, want *ShaderCompileError", err)
+			}
+			if compileErr.Label != "broken.wgsl" || len(compileErr.Diagnostics) == 0 {
+				t.Fatalf("error = %+v", compileErr)
+			}
+			if d := compileErr.Diagnostics[0]; d.Severity != wgpu.ShaderSeverityError {
+				t.Errorf("diagnostic = %+v, want an error", d)
+			}
+			msg := err.Error()
+			for _, want := range []string{`"broken.wgsl"`, tc.message} {
+				if !strings.Contains(msg, want) {
+					t.Errorf("message lacks %q:\n%s", want, msg)
+				}
+			}
+		})
+	}
+}
+
+func TestShaderCompileErrorParseErrorSpan(t *testing.T) {
+	source := "@compute @workgroup_size(1)\nfn main( {\n}\n"
+	parseErr := wgsl.ParseError{Message: "expected parameter name", Line: 2, Column: 10}
+
+	for _, err := range []error{parseErr, &parseErr, fmt.Errorf("parse error: %w", parseErr)} {
+		compileErr := wgpu.CompileWGSLDiagnostics("broken.wgsl", source, err)
+		if len(compileErr.Diagnostics) != 1 {
+			t.Fatalf("diagnostics = %+v, want one", compileErr.Diagnostics)
+		}
+		if span := compileErr.Diagnostics[0].Span; span.Line != 2 || span.Column != 10 {
+			t.Errorf("span = %+v, want 2:10", span)
+		}
+		msg := compileErr.Error()
+		for _, want := range []string{"error: expected parameter name", "--> broken.wgsl:2:10", "2 | fn main( {\n  |          ^"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("message lacks %q:\n%s", want, msg)
+			}
+		}
+	}
+}
+
+func TestCreateShaderModuleSource(t *testing.T) {
+	device := newSoftwareDevice(t)
+	spirv := []uint32{0x07230203, 0x00010000, 0, 1, 0}
+
+	for _, tc := range []struct {
+		name string
+		desc wgpu.ShaderModuleDescriptor
+		kind core.CreateShaderModuleErrorKind
+		ok   bool
+		// validated marks rejections that come from the descriptor checks
+		// rather than the source dispatch.
+		validated bool
+	}{
+		{name: "spirv", desc: wgpu.ShaderModuleDescriptor{SPIRV: spirv}, ok: true},
+		{name: "byte-swapped spirv", desc: wgpu.ShaderModuleDescriptor{SPIRV: []uint32{0x03022307, 0x00000100}},
+			kind: core.CreateShaderModuleErrorInvalidSPIRV, validated: true},
+		{name: "glsl", desc: wgpu.ShaderModuleDescriptor{GLSL: "void main() {}", GLSLStage: wgpu.ShaderStageCompute},
+			kind: core.CreateShaderModuleErrorUnsupportedSource},
+		{name: "glsl and spirv", desc: wgpu.ShaderModuleDescriptor{GLSL: "void main() {}", SPIRV: spirv},
+			kind: core.CreateShaderModuleErrorDualSource},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.validated {
+				requireValidation(t)
+			}
+			tc.desc.Label = tc.name
+			module, err := device.CreateShaderModule(&tc.desc)
+			if tc.ok {
+				if err != nil {
+					t.Fatalf("CreateShaderModule: %v", err)
+				}
+				module.Release()
+				return
+			}
+			var csme *core.CreateShaderModuleError
+			if !errors.As(err, &csme) || csme.Kind != tc.kind {
+				t.Fatalf("CreateShaderModule = %v, want *CreateShaderModuleError of kind %d", err, tc.kind)
+			}
+			if tc.desc.GLSL != "" && !strings.Contains(err.Error(), "GLSL") {
+				t.Errorf("message does not name GLSL: %v", err)
+			}
+		})
+	}
+}
+
+func TestShaderModuleWarnings(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	module, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{WGSL: `
+@compute @workgroup_size(1)
+fn main() {
+	var unused: u32 = 1u;
+	var _ignored: u32 = 2u;
+}
+`})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	defer module.Release()
+
+	warnings := module.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %+v, want one", warnings)
+	}
+	if w := warnings[0]; w.Severity != wgpu.ShaderSeverityWarning || !strings.Contains(w.Message, "unused") || w.Span.Line != 4 {
+		t.Errorf("warning = %+v", w)
+	}
+}
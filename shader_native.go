@@ -3,7 +3,10 @@
 package wgpu
 
 import (
+	"sync"
+
 	"github.com/gogpu/naga/ir"
+	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
 )
 
@@ -11,13 +14,38 @@ import (
 type ShaderModule struct {
 	hal      hal.ShaderModule
 	device   *Device
+	label    string
 	released bool
+	// epoch counts Device.ReloadShaderModule swaps, like the epoch half of
+	// a registry ID: the module keeps its identity while its contents are
+	// replaced.
+	epoch core.Epoch
+	// mu guards the pipeline sets below.
+	mu sync.Mutex
+	// computePipelines and renderPipelines are the live pipelines created
+	// from this module, which ReloadShaderModule rebuilds.
+	computePipelines map[*ComputePipeline]struct{}
+	renderPipelines  map[*RenderPipeline]struct{}
 	// irModule stores the parsed naga IR for this shader module.
 	// Used for late buffer binding size validation at draw/dispatch time.
 	// Matches Rust wgpu-core's ShaderModule.interface which stores the
 	// naga Module for shader introspection.
 	// nil when the shader was provided as SPIR-V (no WGSL source to parse).
 	irModule *ir.Module
+	// warnings holds the compiler's warnings about the WGSL source.
+	warnings []ShaderDiagnostic
+}
+
+// wgslIR returns the naga IR parsed from the module's WGSL source when it
+// was created.
+func (m *ShaderModule) wgslIR(string) (*ir.Module, error) {
+	return m.irModule, nil
+}
+
+// Warnings returns the warnings the shader compiler reported for the WGSL
+// source, such as unused variables. Modules created from SPIR-V have none.
+func (m *ShaderModule) Warnings() []ShaderDiagnostic {
+	return m.warnings
 }
 
 // extractShaderBindingSizes extracts the minimum buffer binding sizes
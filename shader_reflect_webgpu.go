@@ -0,0 +1,18 @@
+//go:build rust || (js && wasm)
+
+package wgpu
+
+import (
+	naga "github.com/gogpu/naga"
+	"github.com/gogpu/naga/ir"
+)
+
+// wgslIR parses source, the WGSL the module was created from, to naga IR.
+// The module itself keeps no reflection: the shader is compiled outside Go.
+func (m *ShaderModule) wgslIR(source string) (*ir.Module, error) {
+	ast, err := naga.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return naga.Lower(ast)
+}
@@ -0,0 +1,226 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/wgpu/hal"
+)
+
+// ReloadShaderModule recompiles module from new WGSL source and rebuilds
+// every live compute and render pipeline created from it, for live shader
+// editing. Pipelines, bind groups and layouts keep their identity, so code
+// holding them picks up the new shader on its next SetPipeline.
+//
+// The reload is all or nothing: if the source fails to compile or any
+// pipeline fails to rebuild against it, the error is returned and the
+// module and its pipelines are unchanged. A rebuild fails when the
+// pipeline's layout, or another module it uses, has been released, so keep
+// those alive for pipelines you want to reload.
+//
+// Replaced backend objects are destroyed once the GPU has finished the
+// submissions made so far, as Release does. Do not call ReloadShaderModule
+// while another goroutine creates pipelines from module or records passes
+// with its pipelines; reload between frames.
+func (d *Device) ReloadShaderModule(module *ShaderModule, wgsl string) error {
+	defer startSpan("wgpu.Device.ReloadShaderModule").End()
+
+	if d.released.Load() {
+		return ErrReleased
+	}
+	if module == nil {
+		return fmt.Errorf("wgpu: shader module is nil")
+	}
+	if module.released {
+		return fmt.Errorf("wgpu: ReloadShaderModule %q: %w", module.label, ErrReleased)
+	}
+	if module.device != d {
+		return fmt.Errorf("wgpu: ReloadShaderModule %q: module belongs to another device", module.label)
+	}
+
+	next, err := d.CreateShaderModule(&ShaderModuleDescriptor{Label: module.label, WGSL: wgsl})
+	if err != nil {
+		return err
+	}
+
+	module.mu.Lock()
+	defer module.mu.Unlock()
+
+	computes, renders, err := d.rebuildPipelines(module, next)
+	if err != nil {
+		next.Release()
+		return err
+	}
+
+	// Everything built: swap the new contents in and retire the old. The
+	// rebuilt pipelines were only carriers for their backend objects, so
+	// take them off the other modules they registered with.
+	for p, rebuilt := range computes {
+		oldPipeline := p.hal
+		d.retire("ComputePipeline", func(halDevice hal.Device) {
+			halDevice.DestroyComputePipeline(oldPipeline)
+		})
+		p.hal = rebuilt.hal
+		p.lateSizedBufferGroups = rebuilt.lateSizedBufferGroups
+	}
+	for p, rebuilt := range renders {
+		oldPipeline := p.hal
+		d.retire("RenderPipeline", func(halDevice hal.Device) {
+			halDevice.DestroyRenderPipeline(oldPipeline)
+		})
+		p.hal = rebuilt.hal
+		p.lateSizedBufferGroups = rebuilt.lateSizedBufferGroups
+		if rebuilt.desc.Vertex.Module != next {
+			rebuilt.desc.Vertex.Module.untrackRender(rebuilt)
+		}
+		if frag := fragmentShaderModule(rebuilt.desc.Fragment); frag != next {
+			frag.untrackRender(rebuilt)
+		}
+	}
+	oldModule := module.hal
+	d.retire("ShaderModule", func(halDevice hal.Device) {
+		halDevice.DestroyShaderModule(oldModule)
+	})
+	module.hal = next.hal
+	module.irModule = next.irModule
+	module.warnings = next.warnings
+	module.epoch++
+	// next's backend module now belongs to module.
+	next.released = true
+
+	hal.Logger().Debug("wgpu: shader module reloaded",
+		"label", module.label, "epoch", module.epoch,
+		"computePipelines", len(computes), "renderPipelines", len(renders))
+	return nil
+}
+
+// rebuildPipelines creates a replacement for every pipeline using module,
+// with next standing in for it. On error it releases what it built.
+func (d *Device) rebuildPipelines(module, next *ShaderModule) (map[*ComputePipeline]*ComputePipeline, map[*RenderPipeline]*RenderPipeline, error) {
+	computes := make(map[*ComputePipeline]*ComputePipeline, len(module.computePipelines))
+	renders := make(map[*RenderPipeline]*RenderPipeline, len(module.renderPipelines))
+	fail := func(label string, err error) (map[*ComputePipeline]*ComputePipeline, map[*RenderPipeline]*RenderPipeline, error) {
+		for _, rebuilt := range computes {
+			rebuilt.Release()
+		}
+		for _, rebuilt := range renders {
+			rebuilt.Release()
+		}
+		return nil, nil, fmt.Errorf("wgpu: ReloadShaderModule %q: rebuilding pipeline %q: %w", module.label, label, err)
+	}
+
+	for p := range module.computePipelines {
+		desc := p.desc
+		if err := checkReloadLayout(desc.Layout); err != nil {
+			return fail(desc.Label, err)
+		}
+		desc.Module = next
+		rebuilt, err := d.CreateComputePipeline(&desc)
+		if err != nil {
+			return fail(desc.Label, err)
+		}
+		computes[p] = rebuilt
+	}
+
+	for p := range module.renderPipelines {
+		desc := p.desc
+		if err := checkReloadLayout(desc.Layout); err != nil {
+			return fail(desc.Label, err)
+		}
+		if desc.Vertex.Module == module {
+			desc.Vertex.Module = next
+		} else if desc.Vertex.Module.released {
+			return fail(desc.Label, fmt.Errorf("vertex shader module %q: %w", desc.Vertex.Module.label, ErrReleased))
+		}
+		if desc.Fragment != nil {
+			fragment := *desc.Fragment
+			if fragment.Module == module {
+				fragment.Module = next
+			} else if fragment.Module != nil && fragment.Module.released {
+				return fail(desc.Label, fmt.Errorf("fragment shader module %q: %w", fragment.Module.label, ErrReleased))
+			}
+			desc.Fragment = &fragment
+		}
+		rebuilt, err := d.CreateRenderPipeline(&desc)
+		if err != nil {
+			return fail(desc.Label, err)
+		}
+		renders[p] = rebuilt
+	}
+
+	return computes, renders, nil
+}
+
+// checkReloadLayout reports a released explicit layout, whose backend
+// object a rebuilt pipeline could not use.
+func checkReloadLayout(layout *PipelineLayout) error {
+	if layout != nil && layout.released {
+		return fmt.Errorf("pipeline layout: %w", ErrReleased)
+	}
+	return nil
+}
+
+// retire runs destroy on a backend object that ReloadShaderModule replaced
+// once the submissions made so far complete, like the resources' Release
+// methods.
+func (d *Device) retire(kind string, destroy func(hal.Device)) {
+	halDevice := d.halDevice()
+	if halDevice == nil {
+		return
+	}
+	dq := d.destroyQueue()
+	if dq == nil {
+		destroy(halDevice)
+		return
+	}
+	dq.Defer(d.lastSubmissionIndex(), kind, func() {
+		destroy(halDevice)
+	})
+}
+
+// trackCompute records p as built from m. A nil m is ignored.
+func (m *ShaderModule) trackCompute(p *ComputePipeline) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	if m.computePipelines == nil {
+		m.computePipelines = make(map[*ComputePipeline]struct{})
+	}
+	m.computePipelines[p] = struct{}{}
+	m.mu.Unlock()
+}
+
+// untrackCompute forgets p when it is released.
+func (m *ShaderModule) untrackCompute(p *ComputePipeline) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	delete(m.computePipelines, p)
+	m.mu.Unlock()
+}
+
+// trackRender records p as built from m. A nil m is ignored.
+func (m *ShaderModule) trackRender(p *RenderPipeline) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	if m.renderPipelines == nil {
+		m.renderPipelines = make(map[*RenderPipeline]struct{})
+	}
+	m.renderPipelines[p] = struct{}{}
+	m.mu.Unlock()
+}
+
+// untrackRender forgets p when it is released.
+func (m *ShaderModule) untrackRender(p *RenderPipeline) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	delete(m.renderPipelines, p)
+	m.mu.Unlock()
+}
@@ -0,0 +1,179 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+func fillShader(value uint32) string {
+	return fmt.Sprintf(`
+@group(0) @binding(0) var<storage, read_write> output: array<u32>;
+
+@compute @workgroup_size(4)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    output[id.x] = %du;
+}
+`, value)
+}
+
+// reloadFixture is a compute pipeline with an explicit layout writing four
+// u32s into a storage buffer.
+type reloadFixture struct {
+	device   *wgpu.Device
+	module   *wgpu.ShaderModule
+	layout   *wgpu.PipelineLayout
+	pipeline *wgpu.ComputePipeline
+	group    *wgpu.BindGroup
+	output   *wgpu.Buffer
+}
+
+func newReloadFixture(t *testing.T) *reloadFixture {
+	t.Helper()
+	device := newSoftwareDevice(t)
+	f := &reloadFixture{device: device}
+	var err error
+	f.module, err = device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{Label: "fill", WGSL: fillShader(1)})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	t.Cleanup(f.module.Release)
+	bgl, err := device.CreateBindGroupLayout(&wgpu.BindGroupLayoutDescriptor{
+		Label: "fill",
+		Entries: []wgpu.BindGroupLayoutEntry{{
+			Binding:    0,
+			Visibility: wgpu.ShaderStageCompute,
+			Buffer:     &gputypes.BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroupLayout: %v", err)
+	}
+	t.Cleanup(bgl.Release)
+	f.layout, err = device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{Label: "fill", BindGroupLayouts: []*wgpu.BindGroupLayout{bgl}})
+	if err != nil {
+		t.Fatalf("CreatePipelineLayout: %v", err)
+	}
+	t.Cleanup(f.layout.Release)
+	f.pipeline, err = device.CreateComputePipeline(&wgpu.ComputePipelineDescriptor{
+		Label: "fill", Layout: f.layout, Module: f.module, EntryPoint: "main",
+	})
+	if err != nil {
+		t.Fatalf("CreateComputePipeline: %v", err)
+	}
+	t.Cleanup(f.pipeline.Release)
+	f.output, err = device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "fill output", Size: 16, Usage: wgpu.BufferUsageStorage | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	t.Cleanup(f.output.Release)
+	f.group, err = device.CreateBindGroup(&wgpu.BindGroupDescriptor{
+		Label: "fill", Layout: bgl, Entries: []wgpu.BindGroupEntry{{Binding: 0, Buffer: f.output}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroup: %v", err)
+	}
+	t.Cleanup(f.group.Release)
+	return f
+}
+
+// dispatch runs the pipeline once and checks every output word is want.
+func (f *reloadFixture) dispatch(t *testing.T, want uint32) {
+	t.Helper()
+	enc, err := f.device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := enc.BeginComputePass(nil)
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	pass.SetPipeline(f.pipeline)
+	pass.SetBindGroup(0, f.group, nil)
+	pass.Dispatch(1, 1, 1)
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	commands, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := f.device.Queue().Submit(commands); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	dst := make([]byte, 16)
+	done, err := f.device.Queue().ReadBufferAsync(context.Background(), f.output, 0, dst)
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("ReadBufferAsync completion: %v", err)
+	}
+	for i := range 4 {
+		if got := binary.LittleEndian.Uint32(dst[i*4:]); got != want {
+			t.Errorf("output[%d] = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestReloadShaderModule(t *testing.T) {
+	f := newReloadFixture(t)
+	f.dispatch(t, 1)
+
+	if err := f.device.ReloadShaderModule(f.module, fillShader(7)); err != nil {
+		t.Fatalf("ReloadShaderModule: %v", err)
+	}
+	f.dispatch(t, 7)
+
+	if err := f.device.ReloadShaderModule(f.module, "fn main( {"); err == nil {
+		t.Error("ReloadShaderModule with invalid WGSL should fail")
+	}
+	f.dispatch(t, 7)
+}
+
+func TestReloadShaderModuleReleasedLayout(t *testing.T) {
+	f := newReloadFixture(t)
+	f.layout.Release()
+
+	err := f.device.ReloadShaderModule(f.module, fillShader(2))
+	if !errors.Is(err, wgpu.ErrReleased) {
+		t.Fatalf("ReloadShaderModule = %v, want ErrReleased", err)
+	}
+	f.dispatch(t, 1)
+}
+
+func TestReloadShaderModuleReleasedPipeline(t *testing.T) {
+	f := newReloadFixture(t)
+	f.pipeline.Release()
+
+	// The released pipeline is no longer rebuilt, so its released layout
+	// does not matter.
+	f.layout.Release()
+	if err := f.device.ReloadShaderModule(f.module, fillShader(3)); err != nil {
+		t.Fatalf("ReloadShaderModule: %v", err)
+	}
+}
+
+func TestReloadShaderModuleInvalid(t *testing.T) {
+	device := newSoftwareDevice(t)
+	if err := device.ReloadShaderModule(nil, fillShader(1)); err == nil {
+		t.Error("ReloadShaderModule(nil) should fail")
+	}
+	module, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{WGSL: fillShader(1)})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	module.Release()
+	if err := device.ReloadShaderModule(module, fillShader(2)); !errors.Is(err, wgpu.ErrReleased) {
+		t.Errorf("ReloadShaderModule on released module = %v, want ErrReleased", err)
+	}
+}
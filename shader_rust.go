@@ -12,6 +12,9 @@ type ShaderModule struct {
 	released bool
 }
 
+// Warnings returns nil: wgpu-native does not report compiler warnings.
+func (m *ShaderModule) Warnings() []ShaderDiagnostic { return nil }
+
 // Release destroys the shader module.
 func (m *ShaderModule) Release() {
 	if m.released {
@@ -0,0 +1,60 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/core"
+)
+
+// newSkipValidationDevice requests a device from an instance created with
+// SkipValidation set to skip.
+func newSkipValidationDevice(t *testing.T, skip bool) *wgpu.Device {
+	t.Helper()
+	inst, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{SkipValidation: skip})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	t.Cleanup(inst.Release)
+	adapter, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	t.Cleanup(device.Release)
+	requireHAL(t, device)
+	return device
+}
+
+// invalidSampler clamps the LOD range backwards, which validation rejects
+// but backends accept.
+var invalidSampler = &wgpu.SamplerDescriptor{Label: "inverted-lod", LodMinClamp: 4, LodMaxClamp: 1}
+
+func TestSkipValidationDefaultValidates(t *testing.T) {
+	if !core.ValidationCompiled {
+		t.Skip("validation compiled out by wgpu_novalidate")
+	}
+	device := newSkipValidationDevice(t, false)
+
+	_, err := device.CreateSampler(invalidSampler)
+	var samplerErr *core.CreateSamplerError
+	if !errors.As(err, &samplerErr) {
+		t.Fatalf("CreateSampler = %v, want *core.CreateSamplerError", err)
+	}
+}
+
+func TestSkipValidationSkipsDescriptorChecks(t *testing.T) {
+	device := newSkipValidationDevice(t, true)
+
+	sampler, err := device.CreateSampler(invalidSampler)
+	if err != nil {
+		t.Fatalf("CreateSampler with SkipValidation: %v", err)
+	}
+	sampler.Release()
+}
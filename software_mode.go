@@ -0,0 +1,39 @@
+package wgpu
+
+// SoftwareModeEnv names the environment variable that selects the software
+// backend mode when InstanceDescriptor.Software is SoftwareAuto: "only" uses
+// the software backend alone, "off" never uses it, and "auto" (or unset)
+// keeps it as the last fallback. It lets prebuilt binaries switch to CPU
+// rendering without a rebuild.
+const SoftwareModeEnv = "GOGPU_SOFTWARE"
+
+// SoftwareMode controls when the Pure-Go software backend is used. The
+// backend is compiled into every native build that imports
+// hal/allbackends; the mode only decides whether CreateInstance tries it.
+type SoftwareMode uint8
+
+const (
+	// SoftwareAuto tries the software backend after every GPU backend, so
+	// machines without a usable GPU driver still get an adapter. This is
+	// the default; SoftwareModeEnv can override it.
+	SoftwareAuto SoftwareMode = iota
+	// SoftwareOnly skips GPU backends and uses the software backend alone.
+	SoftwareOnly
+	// SoftwareDisabled never uses the software backend, so RequestAdapter
+	// fails instead of falling back to CPU rendering.
+	SoftwareDisabled
+)
+
+// String returns the SoftwareModeEnv spelling of the mode.
+func (m SoftwareMode) String() string {
+	switch m {
+	case SoftwareAuto:
+		return "auto"
+	case SoftwareOnly:
+		return "only"
+	case SoftwareDisabled:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,176 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// stencilShadowShader draws a front-facing (CCW) quad over the left half of
+// the target, a back-facing (CW) quad over the right half, and a full-screen
+// triangle starting at vertex 12. Only the shading draw writes green.
+const stencilShadowShader = `
+@vertex
+fn vs_main(@builtin(vertex_index) vid: u32) -> @builtin(position) vec4<f32> {
+    var p = array<vec2<f32>, 15>(
+        vec2<f32>(-1.0, -1.0), vec2<f32>(0.0, -1.0), vec2<f32>(0.0, 1.0),
+        vec2<f32>(-1.0, -1.0), vec2<f32>(0.0, 1.0), vec2<f32>(-1.0, 1.0),
+        vec2<f32>(0.0, -1.0), vec2<f32>(1.0, 1.0), vec2<f32>(1.0, -1.0),
+        vec2<f32>(0.0, -1.0), vec2<f32>(0.0, 1.0), vec2<f32>(1.0, 1.0),
+        vec2<f32>(-1.0, -1.0), vec2<f32>(3.0, -1.0), vec2<f32>(-1.0, 3.0),
+    );
+    return vec4<f32>(p[vid], 0.5, 1.0);
+}
+
+@fragment
+fn fs_volume() -> @location(0) vec4<f32> {
+    return vec4<f32>(1.0, 0.0, 0.0, 1.0);
+}
+
+@fragment
+fn fs_shade() -> @location(0) vec4<f32> {
+    return vec4<f32>(0.0, 1.0, 0.0, 1.0);
+}
+`
+
+// TestStencilShadowTwoSided renders the counting pass of a shadow volume:
+// front faces increment the stencil and back faces decrement it, then a
+// full-screen draw colors only the pixels whose count equals the dynamic
+// reference. Sharing one face state, or swapping the faces, colors the wrong
+// half.
+func TestStencilShadowTwoSided(t *testing.T) {
+	device := newSoftwareDevice(t)
+	const w, h = 16, 16
+
+	shader, err := device.CreateShaderModule(&wgpu.ShaderModuleDescriptor{WGSL: stencilShadowShader})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	defer shader.Release()
+	layout, err := device.CreatePipelineLayout(&wgpu.PipelineLayoutDescriptor{})
+	if err != nil {
+		t.Fatalf("CreatePipelineLayout: %v", err)
+	}
+	defer layout.Release()
+
+	newPipeline := func(fs string, writeMask gputypes.ColorWriteMask, front, back wgpu.StencilFaceState) *wgpu.RenderPipeline {
+		t.Helper()
+		p, err := device.CreateRenderPipeline(&wgpu.RenderPipelineDescriptor{
+			Layout: layout,
+			Vertex: wgpu.VertexState{Module: shader, EntryPoint: "vs_main"},
+			Fragment: &wgpu.FragmentState{
+				Module: shader, EntryPoint: fs,
+				Targets: []gputypes.ColorTargetState{{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: writeMask}},
+			},
+			Primitive: gputypes.PrimitiveState{Topology: gputypes.PrimitiveTopologyTriangleList},
+			DepthStencil: &wgpu.DepthStencilState{
+				Format:           gputypes.TextureFormatDepth24PlusStencil8,
+				DepthCompare:     gputypes.CompareFunctionAlways,
+				StencilFront:     front,
+				StencilBack:      back,
+				StencilReadMask:  0xFF,
+				StencilWriteMask: 0xFF,
+			},
+			Multisample: gputypes.MultisampleState{Count: 1, Mask: 0xFFFFFFFF},
+		})
+		if err != nil {
+			t.Fatalf("CreateRenderPipeline: %v", err)
+		}
+		t.Cleanup(p.Release)
+		return p
+	}
+	keep := wgpu.StencilOperationKeep
+	volume := newPipeline("fs_volume", gputypes.ColorWriteMaskNone,
+		wgpu.StencilFaceState{Compare: gputypes.CompareFunctionAlways, FailOp: keep, DepthFailOp: keep, PassOp: wgpu.StencilOperationIncrementWrap},
+		wgpu.StencilFaceState{Compare: gputypes.CompareFunctionAlways, FailOp: keep, DepthFailOp: keep, PassOp: wgpu.StencilOperationDecrementWrap},
+	)
+	equalRef := wgpu.StencilFaceState{Compare: gputypes.CompareFunctionEqual, FailOp: keep, DepthFailOp: keep, PassOp: keep}
+	shade := newPipeline("fs_shade", gputypes.ColorWriteMaskAll, equalRef, equalRef)
+
+	color, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Size: wgpu.Extent3D{Width: w, Height: h, DepthOrArrayLayers: 1}, MipLevelCount: 1, SampleCount: 1,
+		Dimension: gputypes.TextureDimension2D, Format: gputypes.TextureFormatRGBA8Unorm,
+		Usage: gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture(color): %v", err)
+	}
+	defer color.Release()
+	colorView, _ := device.CreateTextureView(color, nil)
+	defer colorView.Release()
+	depthStencil, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Size: wgpu.Extent3D{Width: w, Height: h, DepthOrArrayLayers: 1}, MipLevelCount: 1, SampleCount: 1,
+		Dimension: gputypes.TextureDimension2D, Format: gputypes.TextureFormatDepth24PlusStencil8,
+		Usage: gputypes.TextureUsageRenderAttachment,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture(depth-stencil): %v", err)
+	}
+	defer depthStencil.Release()
+	dsView, _ := device.CreateTextureView(depthStencil, nil)
+	defer dsView.Release()
+
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := enc.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View: colorView, LoadOp: gputypes.LoadOpClear, StoreOp: gputypes.StoreOpStore,
+			ClearValue: gputypes.Color{A: 1},
+		}},
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:        dsView,
+			DepthLoadOp: gputypes.LoadOpClear, DepthStoreOp: gputypes.StoreOpStore, DepthClearValue: 1,
+			StencilLoadOp: gputypes.LoadOpClear, StencilStoreOp: gputypes.StoreOpStore,
+		},
+	})
+	if err != nil {
+		t.Fatalf("BeginRenderPass: %v", err)
+	}
+	pass.SetPipeline(volume)
+	pass.Draw(12, 1, 0, 0)
+	pass.SetPipeline(shade)
+	pass.SetStencilReference(1)
+	pass.Draw(3, 1, 12, 0)
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+
+	staging, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: w * h * 4, Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer staging.Release()
+	enc.CopyTextureToBuffer(color, staging, []wgpu.BufferTextureCopy{{
+		BufferLayout: wgpu.ImageDataLayout{BytesPerRow: w * 4, RowsPerImage: h},
+		TextureBase:  wgpu.ImageCopyTexture{Texture: color},
+		Size:         wgpu.Extent3D{Width: w, Height: h, DepthOrArrayLayers: 1},
+	}})
+	cb, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(cb); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := staging.Map(context.Background(), wgpu.MapModeRead, 0, w*h*4); err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	defer staging.Unmap()
+	rng, _ := staging.MappedRange(0, w*h*4)
+	px := rng.Bytes()
+
+	// Sample away from the seam between the two quads.
+	for _, y := range []int{2, h / 2, h - 3} {
+		left := px[(y*w+2)*4+1]
+		right := px[(y*w+w-3)*4+1]
+		if left < 128 || right > 0 {
+			t.Fatalf("row %d: green left=%d right=%d, want only the front-facing half lit", y, left, right)
+		}
+	}
+}
@@ -236,6 +236,11 @@ func TestSurfaceTargetFromLegacyHandlesForPlatform(t *testing.T) {
 			goos: platformDarwin, display: 1, window: 2,
 			want: SurfaceTargetFromMetalLayer(2),
 		},
+		{
+			name: "iOS",
+			goos: platformIOS, display: 1, window: 2,
+			want: SurfaceTargetFromMetalLayer(2),
+		},
 		{
 			name: "Linux Xlib",
 			goos: platformLinux, display: 1, window: 2,
@@ -25,6 +25,8 @@ type Surface struct {
 
 	// Cached configuration for GetCurrentTexture texture creation.
 	configFormat TextureFormat
+
+	stats SurfaceStats
 }
 
 // CreateSurface creates a rendering surface from a legacy numeric canvas
@@ -154,6 +156,15 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 	case PresentModeMailbox, PresentModeImmediate:
 		return fmt.Errorf("wgpu: present mode %v not supported on browser; only Fifo is supported", config.PresentMode)
 	}
+	if t := config.PreTransform; t != 0 && t != SurfaceTransformIdentity {
+		return fmt.Errorf("wgpu: pre-transform %v not supported on browser", t)
+	}
+	if err := validateProtected("surface", config.Protected, device.features); err != nil {
+		return err
+	}
+	if err := validateScaleFactor(config.ScaleFactor); err != nil {
+		return err
+	}
 
 	// Build the JS GPUCanvasConfiguration object.
 	jsConfig := browser.BuildSurfaceConfiguration(
@@ -166,6 +177,9 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 
 	formatStr := browser.TextureFormatToJS(config.Format)
 	s.browser.Configure(jsConfig, config.Width, config.Height, formatStr)
+	if s.device != nil {
+		s.stats.Reconfigurations++
+	}
 	s.device = device
 	s.configFormat = config.Format
 	return nil
@@ -198,8 +212,10 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 
 	bt, err := s.browser.GetCurrentTexture()
 	if err != nil {
+		s.stats.AcquireFailures++
 		return nil, false, fmt.Errorf("wgpu: %w", err)
 	}
+	s.stats.Acquired++
 
 	return &SurfaceTexture{
 		texture: &Texture{
@@ -226,6 +242,13 @@ func (s *Surface) PresentWithDamage(st *SurfaceTexture, _ []image.Rectangle) err
 	return s.Present(st)
 }
 
+// Stats returns the surface's acquire and reconfiguration counters. Browser
+// presentation is implicit and never suboptimal or outdated, so only Acquired,
+// AcquireFailures and Reconfigurations change.
+func (s *Surface) Stats() SurfaceStats {
+	return s.stats
+}
+
 // ReadPixels is not supported by browser WebGPU surfaces.
 // Headless surface readback is a Pure-Go software-backend extension.
 func (s *Surface) ReadPixels() ([]byte, error) {
@@ -275,17 +298,44 @@ func (s *Surface) Release() {
 // using it is submitted and control returns to the browser event loop.
 type SurfaceTexture struct {
 	texture  *Texture
+	view     *TextureView
 	released bool
 }
 
+// Expired reports whether the surface texture can no longer be used. The
+// browser ends frames when control returns to the event loop, which is not
+// observable here, so Expired only reports textures known to be stale.
+func (st *SurfaceTexture) Expired() bool { return st.released }
+
+// View returns the default view of the surface texture, creating it on first
+// use. The view belongs to the frame; callers need not Release it. View
+// panics once the frame has ended.
+func (st *SurfaceTexture) View() (*TextureView, error) {
+	if st.released {
+		panicExpiredFrame("SurfaceTexture.View")
+	}
+	if st.view == nil {
+		view, err := st.CreateView(nil)
+		if err != nil {
+			return nil, err
+		}
+		st.view = view
+	}
+	return st.view, nil
+}
+
 // AsTexture returns the underlying Texture for direct WriteTexture access.
 func (st *SurfaceTexture) AsTexture() *Texture { return st.texture }
 
 // CreateView creates a texture view of this surface texture.
 //
 // Pass nil for desc to create a default view (all mips, all layers, same format).
+// It panics once the frame has ended.
 func (st *SurfaceTexture) CreateView(desc *TextureViewDescriptor) (*TextureView, error) {
-	if st.released || st.texture == nil || st.texture.browser == nil {
+	if st.released {
+		panicExpiredFrame("SurfaceTexture.CreateView")
+	}
+	if st.texture == nil || st.texture.browser == nil {
 		return nil, ErrReleased
 	}
 
@@ -0,0 +1,10 @@
+package wgpu
+
+// panicExpiredFrame reports a SurfaceTexture used after its frame ended.
+// Holding on to a frame past Present is a programming error rather than a
+// runtime condition, so it panics instead of returning
+// ErrSurfaceTextureExpired like the views and textures derived from it.
+func panicExpiredFrame(method string) {
+	panic("wgpu: " + method + " on a SurfaceTexture whose frame was presented or discarded; " +
+		"call Surface.GetCurrentTexture for the next frame")
+}
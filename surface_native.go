@@ -8,6 +8,7 @@ import (
 	"image"
 	"os"
 	"runtime"
+	"time"
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/core"
@@ -17,6 +18,7 @@ import (
 const (
 	platformWindows = "windows"
 	platformDarwin  = "darwin"
+	platformIOS     = "ios"
 	platformLinux   = "linux"
 	platformAndroid = "android"
 )
@@ -45,6 +47,17 @@ type Surface struct {
 	// matching Rust wgpu's surface_per_backend representation. core points at
 	// exactly one of these at a time to keep its lifecycle state machine small.
 	halSurfaces map[gputypes.Backend]hal.Surface
+
+	// frame is the most recently acquired SurfaceTexture. Its default view
+	// (SurfaceTexture.View) is released when the frame ends.
+	frame *SurfaceTexture
+
+	// storage is the intermediate texture frames are written through when
+	// the configuration asks for StorageBinding but the surface textures
+	// cannot be storage-bound. storageUsage is its usage, zero when no
+	// intermediate is needed.
+	storage      *Texture
+	storageUsage TextureUsage
 }
 
 // CreateSurface creates a rendering surface from legacy platform-specific
@@ -181,7 +194,7 @@ func surfaceTargetFromLegacyHandlesForPlatform(goos, waylandDisplay string, disp
 	switch goos {
 	case platformWindows:
 		return SurfaceTargetFromWindowsHWND(displayHandle, windowHandle)
-	case platformDarwin:
+	case platformDarwin, platformIOS:
 		return SurfaceTargetFromMetalLayer(windowHandle)
 	case platformLinux:
 		if waylandDisplay != "" {
@@ -214,6 +227,8 @@ func (t SurfaceTargetUnsafe) halTarget() (hal.SurfaceTarget, error) {
 		kind = hal.SurfaceTargetAndroidNativeWindow
 	case surfaceTargetMetalLayer:
 		kind = hal.SurfaceTargetMetalLayer
+	case surfaceTargetUIView:
+		kind = hal.SurfaceTargetUIView
 	case surfaceTargetWebCanvasID:
 		return hal.SurfaceTarget{}, fmt.Errorf("%w: Web canvas target on native backend", ErrUnsupportedSurfaceTarget)
 	default:
@@ -228,6 +243,15 @@ func (t SurfaceTargetUnsafe) halTarget() (hal.SurfaceTarget, error) {
 
 // Configure configures the surface for presentation.
 // Must be called before GetCurrentTexture().
+//
+// A configuration with TextureUsageStorageBinding lets compute shaders write
+// the surface texture directly, for example from a path tracer with no
+// render pipeline. The format must support storage on the adapter and must
+// not be sRGB. Where SurfaceCapabilities.Usages lacks StorageBinding, frames
+// are backed by an intermediate texture of the surface format instead: the
+// SurfaceTexture's views and AsTexture refer to it, and Present copies it
+// into the surface texture first. The intermediate costs one copy per frame
+// and is recreated when the surface is resized.
 func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error {
 	if s.released {
 		return ErrReleased
@@ -258,13 +282,29 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 		return core.ErrSurfaceConfigureWhileAcquired
 	}
 
+	// Only Vulkan swapchains can take a pre-rotated image; elsewhere the
+	// compositor always rotates.
+	if t := config.PreTransform; t != 0 && t != SurfaceTransformIdentity &&
+		device.core.Backend() != gputypes.BackendVulkan {
+		return fmt.Errorf("wgpu: pre-transform %v requires the Vulkan backend", t)
+	}
+	if err := validateProtected("surface", config.Protected, device.core.Features); err != nil {
+		return err
+	}
+	if err := validateScaleFactor(config.ScaleFactor); err != nil {
+		return err
+	}
+
 	halConfig := &hal.SurfaceConfiguration{
-		Width:       config.Width,
-		Height:      config.Height,
-		Format:      config.Format,
-		Usage:       config.Usage,
-		PresentMode: config.PresentMode,
-		AlphaMode:   config.AlphaMode,
+		Width:        config.Width,
+		Height:       config.Height,
+		Format:       config.Format,
+		Usage:        config.Usage,
+		PresentMode:  config.PresentMode,
+		AlphaMode:    config.AlphaMode,
+		PreTransform: hal.SurfaceTransform(config.PreTransform),
+		Protected:    config.Protected,
+		ScaleFactor:  config.ScaleFactor,
 	}
 
 	// Create or re-create the HAL surface on the correct backend's HAL instance.
@@ -274,9 +314,23 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 	if err := s.ensureHALSurface(device.core.Backend()); err != nil {
 		return err
 	}
+	usage, storageFallback, err := s.surfaceStorageUsage(device, config)
+	if err != nil {
+		return err
+	}
+	halConfig.Usage = usage
 
 	s.device = device
-	return s.core.Configure(device.core, halConfig)
+	s.releaseStorageTarget()
+	s.storageUsage = 0
+	if err := s.core.Configure(device.core, halConfig); err != nil {
+		return err
+	}
+	if storageFallback {
+		s.storageUsage = config.Usage | TextureUsageCopySrc
+		return s.syncStorageTarget()
+	}
+	return nil
 }
 
 // Unconfigure removes the surface configuration.
@@ -284,6 +338,9 @@ func (s *Surface) Unconfigure() {
 	if s.released {
 		return
 	}
+	s.endFrame()
+	s.releaseStorageTarget()
+	s.storageUsage = 0
 	s.core.Unconfigure()
 }
 
@@ -293,6 +350,8 @@ func (s *Surface) Unconfigure() {
 // If a PrepareFrame hook is registered and reports changed dimensions,
 // the surface is automatically reconfigured before acquiring.
 func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
+	defer startSpan("wgpu.Surface.GetCurrentTexture").End()
+
 	if s.released {
 		return nil, false, ErrReleased
 	}
@@ -300,20 +359,31 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 		return nil, false, fmt.Errorf("wgpu: surface not configured")
 	}
 
+	s.endFrame()
 	acquired, lease, err := s.core.AcquireTextureWithLease(nil)
 	if err != nil {
 		return nil, false, err
 	}
+	if err := s.syncStorageTarget(); err != nil {
+		s.core.DiscardTexture()
+		return nil, false, err
+	}
 
-	return &SurfaceTexture{
+	s.frame = &SurfaceTexture{
 		hal:     acquired.Texture,
 		surface: s,
 		device:  s.device,
 		lease:   lease,
-	}, acquired.Suboptimal, nil
+		storage: s.storage,
+	}
+	return s.frame, acquired.Suboptimal, nil
 }
 
-// Present presents a surface texture to the screen.
+// Present presents a surface texture to the screen. Presenting a texture
+// whose frame ended because its device was released or the surface was
+// unconfigured, reconfigured or discarded returns a *core.SurfacePresentError
+// of kind SurfacePresentErrorExpired. Presenting the same texture twice
+// panics.
 func (s *Surface) Present(texture *SurfaceTexture) error {
 	return s.PresentWithDamage(texture, nil)
 }
@@ -328,24 +398,48 @@ func (s *Surface) Present(texture *SurfaceTexture) error {
 // VK_KHR_incremental_present, GLES eglSwapBuffersWithDamageKHR) use them
 // as compositor hints; others accept and ignore them.
 func (s *Surface) PresentWithDamage(texture *SurfaceTexture, damageRects []image.Rectangle) error {
+	defer startSpan("wgpu.Surface.PresentWithDamage").End()
+
 	if s.released {
 		return ErrReleased
 	}
+	if texture == nil {
+		return fmt.Errorf("wgpu: surface texture is nil")
+	}
+	if texture.surface != s {
+		return fmt.Errorf("wgpu: surface texture belongs to another surface: %w", ErrReleased)
+	}
+	if !texture.isUsable() {
+		if texture.presented {
+			panicExpiredFrame("Surface.Present")
+		}
+		return fmt.Errorf("wgpu: surface texture expired: %w",
+			&core.SurfacePresentError{Kind: core.SurfacePresentErrorExpired, Surface: s.core.Label()})
+	}
 	if s.device == nil {
 		return fmt.Errorf("wgpu: surface not configured")
 	}
 	if s.device.queue == nil || s.device.queue.hal == nil {
 		return fmt.Errorf("wgpu: queue not available")
 	}
-
-	if texture == nil {
-		return fmt.Errorf("wgpu: surface texture is nil")
+	if err := s.resolveStorageTarget(texture); err != nil {
+		return err
 	}
-	if texture.surface != s || !texture.isUsable() {
-		return ErrReleased
+
+	if err := s.core.PresentTexture(s.device.queue.hal, texture.lease, texture.hal, damageRects); err != nil {
+		return err
 	}
+	texture.presented = true
+	s.endFrame()
+	return nil
+}
 
-	return s.core.PresentWithDamage(s.device.queue.hal, damageRects)
+// Stats returns the surface's acquire, present and reconfiguration counters.
+func (s *Surface) Stats() SurfaceStats {
+	if s.core == nil {
+		return SurfaceStats{}
+	}
+	return SurfaceStats(s.core.Stats())
 }
 
 // SetPrepareFrame registers a platform hook called before each GetCurrentTexture.
@@ -377,6 +471,46 @@ func (s *Surface) SetPresentsWithTransaction(enabled bool) {
 	}
 }
 
+// SetMaximumDrawableCount bounds the drawable pool of a Metal surface to 2
+// or 3 drawables (default 3). Fewer drawables lower latency at the cost of
+// throughput. No-op on non-Metal backends.
+func (s *Surface) SetMaximumDrawableCount(count uint32) {
+	if s.released || s.core == nil {
+		return
+	}
+	raw := s.core.RawSurface()
+	if raw == nil {
+		return
+	}
+	if dc, ok := raw.(interface{ SetMaximumDrawableCount(uint32) }); ok {
+		dc.SetMaximumDrawableCount(count)
+	}
+}
+
+// SetDisplayLink paces frames on macOS with a CVDisplayLink. onFrame is
+// called on a display link thread once per refresh with the time the next
+// frame will be shown; ticks arriving while the previous frame's texture is
+// still acquired are dropped, so rendering from onFrame never blocks waiting
+// for a drawable. Pass nil to stop. onFrame must not call SetDisplayLink or
+// Release.
+//
+// Returns an error on backends and platforms without display link pacing.
+func (s *Surface) SetDisplayLink(onFrame func(outputTime time.Duration)) error {
+	if s.released || s.core == nil {
+		return fmt.Errorf("wgpu: surface released")
+	}
+	raw := s.core.RawSurface()
+	if dl, ok := raw.(interface {
+		SetDisplayLink(func(time.Duration)) error
+	}); ok {
+		return dl.SetDisplayLink(onFrame)
+	}
+	if onFrame == nil {
+		return nil
+	}
+	return fmt.Errorf("wgpu: display link pacing requires a Metal surface")
+}
+
 // PresentPixels writes RGBA pixel data directly to the surface and presents it
 // in a single operation, bypassing the WebGPU render pass pipeline entirely.
 //
@@ -425,7 +559,8 @@ func (s *Surface) WritePixels(data []byte, width, height uint32) error {
 // ReadPixels captures the current surface framebuffer as an owned, tightly
 // packed RGBA8 snapshot in top-left, row-major order.
 //
-// This is a non-WebGPU extension implemented by the Pure-Go software backend.
+// This is a non-WebGPU extension implemented by the Pure-Go software and
+// GLES backends.
 // The surface must be configured, and any acquired texture must be presented
 // or discarded before capture.
 func (s *Surface) ReadPixels() ([]byte, error) {
@@ -483,13 +618,24 @@ func (s *Surface) DiscardTexture() {
 	if s.released {
 		return
 	}
+	s.endFrame()
 	s.core.DiscardTexture()
 }
 
+// endFrame releases the default view of the current frame, if any.
+func (s *Surface) endFrame() {
+	if s.frame != nil && s.frame.view != nil {
+		s.frame.view.Release()
+		s.frame.view = nil
+	}
+	s.frame = nil
+}
+
 func (s *Surface) discardForDevice(device *Device) {
 	if s == nil || s.core == nil || s.device != device {
 		return
 	}
+	s.endFrame()
 	s.core.DiscardTexture()
 }
 
@@ -497,6 +643,9 @@ func (s *Surface) retireDevice(device *Device) {
 	if s == nil || s.core == nil || s.device != device {
 		return
 	}
+	s.endFrame()
+	s.releaseStorageTarget()
+	s.storageUsage = 0
 	s.core.RetireDevice(device.core)
 	s.device = nil
 }
@@ -597,6 +746,8 @@ func (s *Surface) Release() {
 		return
 	}
 	s.released = true
+	s.endFrame()
+	s.releaseStorageTarget()
 	if s.core != nil {
 		destroyHALSurfaces(s.core, s.halSurfaces, s.currentBackend, s.surfaceCreated)
 	}
@@ -623,12 +774,24 @@ func destroyHALSurfaces(coreSurface *core.Surface, surfaces map[gputypes.Backend
 	}
 }
 
-// SurfaceTexture is a texture acquired from a surface for rendering.
+// SurfaceTexture is a texture acquired from a surface for rendering. It
+// represents one frame: it and every view or texture derived from it expire
+// once the frame is presented or discarded. Calling View or CreateView on an
+// expired SurfaceTexture, or presenting it twice, panics; Present reports a
+// frame that expired for any other reason as an error, and derived views and
+// textures report ErrSurfaceTextureExpired.
 type SurfaceTexture struct {
 	hal     hal.SurfaceTexture
 	surface *Surface
 	device  *Device
 	lease   uint64
+	// presented is set once Present succeeds for this frame.
+	presented bool
+	// view is the default view returned by View, owned by the frame.
+	view *TextureView
+	// storage is the surface's intermediate texture when storage writes
+	// go through one; see Surface.Configure.
+	storage *Texture
 }
 
 func (st *SurfaceTexture) isUsable() bool {
@@ -640,28 +803,86 @@ func (st *SurfaceTexture) isUsable() bool {
 	return st.surface.core.AcquisitionValid(st.lease)
 }
 
+// Expired reports whether the frame has ended: the texture was presented or
+// discarded, or the surface was unconfigured or released.
+func (st *SurfaceTexture) Expired() bool {
+	return !st.isUsable()
+}
+
+// View returns the default view of the surface texture, creating it on first
+// use. The view belongs to the frame and is released automatically when the
+// frame ends; callers need not Release it. View panics once the frame has
+// ended.
+func (st *SurfaceTexture) View() (*TextureView, error) {
+	if !st.isUsable() {
+		panicExpiredFrame("SurfaceTexture.View")
+	}
+	if st.view == nil {
+		view, err := st.CreateView(nil)
+		if err != nil {
+			return nil, err
+		}
+		st.view = view
+	}
+	return st.view, nil
+}
+
+// Texture returns the surface texture as a Texture, or nil once the frame
+// has ended. It is equivalent to AsTexture.
+func (st *SurfaceTexture) Texture() *Texture {
+	return st.AsTexture()
+}
+
 // AsTexture returns a lightweight Texture wrapper around this surface texture,
 // enabling use with Queue.WriteTexture() for direct CPU pixel upload without a
 // render pass. The surface must be configured with TextureUsageCopyDst.
 //
 // The returned Texture shares the underlying HAL resource — do not Release() it
-// independently. Its lifetime is tied to this SurfaceTexture.
+// independently. Its lifetime is tied to this SurfaceTexture. When the
+// surface writes storage through an intermediate texture, the wrapper refers
+// to the intermediate.
 func (st *SurfaceTexture) AsTexture() *Texture {
 	if !st.isUsable() {
 		return nil
 	}
+	if st.storage != nil {
+		texture := *st.storage
+		texture.surface = st.surface.core
+		texture.surfaceLease = st.lease
+		return &texture
+	}
+	return st.surfaceTexture()
+}
+
+// surfaceTexture returns a wrapper around the acquired surface texture
+// itself, bypassing any intermediate.
+func (st *SurfaceTexture) surfaceTexture() *Texture {
 	return &Texture{
 		hal:          st.hal,
 		device:       st.device,
+		format:       st.format(),
+		sampleCount:  1,
 		surface:      st.surface.core,
 		surfaceLease: st.lease,
 	}
 }
 
-// CreateView creates a texture view of this surface texture.
+// format returns the configured surface format.
+func (st *SurfaceTexture) format() TextureFormat {
+	if config := st.surface.core.Config(); config != nil {
+		return config.Format
+	}
+	return gputypes.TextureFormatUndefined
+}
+
+// CreateView creates a texture view of this surface texture. It panics once
+// the frame has ended.
 func (st *SurfaceTexture) CreateView(desc *TextureViewDescriptor) (*TextureView, error) {
 	if !st.isUsable() {
-		return nil, ErrReleased
+		panicExpiredFrame("SurfaceTexture.CreateView")
+	}
+	if st.storage != nil {
+		return st.device.CreateTextureView(st.AsTexture(), desc)
 	}
 	halDevice := st.device.halDevice()
 	if halDevice == nil {
@@ -687,6 +908,18 @@ func (st *SurfaceTexture) CreateView(desc *TextureViewDescriptor) (*TextureView,
 		return nil, fmt.Errorf("wgpu: failed to create surface texture view: %w", err)
 	}
 
-	texture := &Texture{hal: st.hal, device: st.device, surface: st.surface.core, surfaceLease: st.lease}
-	return &TextureView{hal: halView, device: st.device, texture: texture, surface: st.surface.core, surfaceLease: st.lease}, nil
+	texture := st.surfaceTexture()
+	format := texture.format
+	if desc != nil && desc.Format != gputypes.TextureFormatUndefined {
+		format = desc.Format
+	}
+	return &TextureView{
+		hal:          halView,
+		device:       st.device,
+		texture:      texture,
+		format:       format,
+		sampleCount:  1,
+		surface:      st.surface.core,
+		surfaceLease: st.lease,
+	}, nil
 }
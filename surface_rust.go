@@ -23,6 +23,8 @@ type Surface struct {
 	configFormat TextureFormat
 	configWidth  uint32
 	configHeight uint32
+
+	stats SurfaceStats
 }
 
 // CreateSurface creates a rendering surface from legacy platform-specific
@@ -88,6 +90,15 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 	if device == nil {
 		return fmt.Errorf("wgpu: device is nil")
 	}
+	if t := config.PreTransform; t != 0 && t != SurfaceTransformIdentity {
+		return fmt.Errorf("wgpu: pre-transform %v not supported by the wgpu-native backend", t)
+	}
+	if err := validateProtected("surface", config.Protected, device.features); err != nil {
+		return err
+	}
+	if err := validateScaleFactor(config.ScaleFactor); err != nil {
+		return err
+	}
 
 	rConfig := &rwgpu.SurfaceConfiguration{
 		Format:      config.Format,
@@ -101,6 +112,9 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 	if err := s.r.Configure(device.r, rConfig); err != nil {
 		return fmt.Errorf("wgpu: failed to configure surface: %w", err)
 	}
+	if s.device != nil {
+		s.stats.Reconfigurations++
+	}
 
 	s.device = device
 	s.configFormat = config.Format
@@ -130,8 +144,13 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 
 	rst, suboptimal, err := s.r.GetCurrentTexture()
 	if err != nil {
+		s.stats.AcquireFailures++
 		return nil, false, fmt.Errorf("wgpu: %w", err)
 	}
+	s.stats.Acquired++
+	if suboptimal {
+		s.stats.Suboptimal++
+	}
 
 	return &SurfaceTexture{
 		r: rst,
@@ -144,7 +163,8 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 	}, suboptimal, nil
 }
 
-// Present presents a surface texture to the screen.
+// Present presents a surface texture to the screen. Presenting a texture
+// twice panics.
 func (s *Surface) Present(texture *SurfaceTexture) error {
 	if s.released {
 		return ErrReleased
@@ -152,8 +172,20 @@ func (s *Surface) Present(texture *SurfaceTexture) error {
 	if texture == nil {
 		return fmt.Errorf("wgpu: surface texture is nil")
 	}
+	if texture.presented {
+		panicExpiredFrame("Surface.Present")
+	}
 	// go-webgpu Present takes variadic *SurfaceTexture.
-	return s.r.Present(texture.r)
+	if err := s.r.Present(texture.r); err != nil {
+		s.stats.PresentFailures++
+		return err
+	}
+	texture.presented = true
+	if texture.view != nil {
+		texture.view.Release()
+		texture.view = nil
+	}
+	return nil
 }
 
 // PresentWithDamage presents a surface texture, optionally with damage rects.
@@ -182,6 +214,13 @@ func (s *Surface) ActualExtent() (width, height uint32) {
 	return s.configWidth, s.configHeight
 }
 
+// Stats returns the surface's acquire, present and reconfiguration counters.
+// wgpu-native does not report outdated surfaces distinctly, so Outdated stays
+// zero on this backend.
+func (s *Surface) Stats() SurfaceStats {
+	return s.stats
+}
+
 // SetPrepareFrame registers a platform hook called before each GetCurrentTexture.
 // On Rust backend, this is a no-op — wgpu-native handles HiDPI internally.
 // The function signature uses any to avoid importing core in the rust build path.
@@ -207,16 +246,42 @@ func (s *Surface) Release() {
 
 // SurfaceTexture is a texture acquired from a surface for rendering.
 type SurfaceTexture struct {
-	r       *rwgpu.SurfaceTexture
-	texture *Texture
-	surface *Surface
+	r         *rwgpu.SurfaceTexture
+	texture   *Texture
+	surface   *Surface
+	view      *TextureView
+	presented bool
+}
+
+// Expired reports whether the surface texture has been presented.
+func (st *SurfaceTexture) Expired() bool { return st.presented }
+
+// View returns the default view of the surface texture, creating it on first
+// use. The view is released automatically when the texture is presented.
+// View panics once the texture has been presented.
+func (st *SurfaceTexture) View() (*TextureView, error) {
+	if st.presented {
+		panicExpiredFrame("SurfaceTexture.View")
+	}
+	if st.view == nil {
+		view, err := st.CreateView(nil)
+		if err != nil {
+			return nil, err
+		}
+		st.view = view
+	}
+	return st.view, nil
 }
 
 // AsTexture returns the underlying Texture for direct WriteTexture access.
 func (st *SurfaceTexture) AsTexture() *Texture { return st.texture }
 
-// CreateView creates a texture view of this surface texture.
+// CreateView creates a texture view of this surface texture. It panics once
+// the texture has been presented.
 func (st *SurfaceTexture) CreateView(desc *TextureViewDescriptor) (*TextureView, error) {
+	if st.presented {
+		panicExpiredFrame("SurfaceTexture.CreateView")
+	}
 	if st.texture == nil || st.texture.r == nil {
 		return nil, ErrReleased
 	}
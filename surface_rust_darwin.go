@@ -12,10 +12,12 @@ func surfaceTargetFromLegacyHandles(_, windowHandle uintptr) SurfaceTargetUnsafe
 	return SurfaceTargetFromMetalLayer(windowHandle)
 }
 
-// createPlatformSurfaceTarget creates a rendering surface on macOS via CAMetalLayer.
+// createPlatformSurfaceTarget creates a rendering surface on macOS and iOS via
+// CAMetalLayer. UIView targets are not supported by go-webgpu; pass the view's
+// CAMetalLayer instead.
 func createPlatformSurfaceTarget(instance *rwgpu.Instance, target SurfaceTargetUnsafe) (*rwgpu.Surface, error) {
 	if target.kind != surfaceTargetMetalLayer {
-		return nil, fmt.Errorf("%w: macOS backend requires a Metal layer", ErrUnsupportedSurfaceTarget)
+		return nil, fmt.Errorf("%w: Apple backend requires a Metal layer", ErrUnsupportedSurfaceTarget)
 	}
 	return instance.CreateSurfaceFromMetalLayer(target.windowHandle)
 }
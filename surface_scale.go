@@ -0,0 +1,48 @@
+package wgpu
+
+import (
+	"fmt"
+	"math"
+)
+
+// PhysicalSize converts a size in logical units (points on macOS, DIPs on
+// Windows, surface-local units on Wayland) to pixels at the given scale
+// factor, the value to pass as SurfaceConfiguration Width and Height.
+//
+// Each dimension is rounded to the nearest pixel and a nonzero dimension is
+// at least one pixel, so the result agrees with the backing size the
+// platform gives the window. A zero dimension stays zero: a minimized window
+// has no area. A scale that is not a positive finite number counts as 1.
+func PhysicalSize(width, height uint32, scale float64) (uint32, uint32) {
+	if !(scale > 0) || math.IsInf(scale, 0) {
+		scale = 1
+	}
+	return scaleDimension(width, scale), scaleDimension(height, scale)
+}
+
+// scaleDimension scales one dimension for PhysicalSize.
+func scaleDimension(v uint32, scale float64) uint32 {
+	if v == 0 {
+		return 0
+	}
+	p := math.Round(float64(v) * scale)
+	switch {
+	case p < 1:
+		return 1
+	case p > math.MaxUint32:
+		return math.MaxUint32
+	}
+	return uint32(p)
+}
+
+// validateScaleFactor checks a SurfaceConfiguration ScaleFactor: zero for
+// unknown, or a positive finite number.
+func validateScaleFactor(scale float64) error {
+	if scale == 0 {
+		return nil
+	}
+	if !(scale > 0) || math.IsInf(scale, 0) {
+		return fmt.Errorf("wgpu: surface scale factor %v must be positive and finite", scale)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPhysicalSize(t *testing.T) {
+	tests := []struct {
+		width, height uint32
+		scale         float64
+		wantW, wantH  uint32
+	}{
+		{800, 600, 1, 800, 600},
+		{800, 600, 2, 1600, 1200},
+		{801, 601, 1.5, 1202, 902},
+		{1280, 720, 1.25, 1600, 900},
+		{1, 1, 0.25, 1, 1},
+		{0, 600, 2, 0, 1200},
+		{800, 600, 0, 800, 600},
+		{800, 600, -2, 800, 600},
+		{800, 600, math.NaN(), 800, 600},
+		{800, 600, math.Inf(1), 800, 600},
+		{math.MaxUint32, 1, 2, math.MaxUint32, 2},
+	}
+	for _, tt := range tests {
+		w, h := PhysicalSize(tt.width, tt.height, tt.scale)
+		if w != tt.wantW || h != tt.wantH {
+			t.Errorf("PhysicalSize(%d, %d, %v) = %d, %d; want %d, %d",
+				tt.width, tt.height, tt.scale, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestSurfaceConfigurationCarriesScaleFactor(t *testing.T) {
+	if got := (&SurfaceConfiguration{ScaleFactor: 2}).toHAL().ScaleFactor; got != 2 {
+		t.Errorf("SurfaceConfiguration.toHAL ScaleFactor = %v, want 2", got)
+	}
+}
+
+func TestValidateScaleFactor(t *testing.T) {
+	for _, scale := range []float64{0, 1, 1.5, 2, 3} {
+		if err := validateScaleFactor(scale); err != nil {
+			t.Errorf("validateScaleFactor(%v) = %v", scale, err)
+		}
+	}
+	for _, scale := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := validateScaleFactor(scale); err == nil {
+			t.Errorf("validateScaleFactor(%v) = nil, want an error", scale)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package wgpu
+
+// SurfaceStats counts a surface's acquire and present outcomes since it was
+// created, for diagnosing resize and swapchain churn. A suboptimal frame is
+// still rendered and presented and only asks for a reconfigure when
+// convenient; an outdated surface fails until Configure is called again.
+type SurfaceStats struct {
+	// Acquired is the number of textures returned by GetCurrentTexture.
+	Acquired uint64
+	// Suboptimal is the number of acquired textures reported as suboptimal.
+	Suboptimal uint64
+	// AcquireFailures is the number of GetCurrentTexture calls that failed,
+	// including timeouts.
+	AcquireFailures uint64
+	// PresentFailures is the number of Present calls that failed.
+	PresentFailures uint64
+	// Outdated is the number of acquires and presents that failed with
+	// ErrSurfaceOutdated.
+	Outdated uint64
+	// Reconfigurations is the number of swapchain recreations: Configure on
+	// an already configured surface, plus PrepareFrame resizes.
+	Reconfigurations uint64
+}
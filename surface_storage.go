@@ -0,0 +1,109 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu/hal"
+)
+
+// surfaceStorageUsage returns the usage to configure the backend surface with
+// for config, and whether storage writes go through an intermediate texture.
+// Surfaces whose textures cannot be storage-bound themselves get CopyDst in
+// place of StorageBinding, so the intermediate can be copied in at present.
+func (s *Surface) surfaceStorageUsage(device *Device, config *SurfaceConfiguration) (TextureUsage, bool, error) {
+	usage := config.Usage
+	if usage&TextureUsageStorageBinding == 0 {
+		return usage, false, nil
+	}
+	if config.Format.IsSrgb() {
+		return 0, false, fmt.Errorf("wgpu: surface format %v cannot be used for storage; configure its linear counterpart", config.Format)
+	}
+	adapter := device.core.ParentAdapter()
+	if adapter.TextureFormatCapabilities(config.Format).Flags&hal.TextureFormatCapabilityStorage == 0 {
+		return 0, false, fmt.Errorf("wgpu: surface format %v does not support storage writes on this adapter", config.Format)
+	}
+	if adapter != nil && adapter.HasHAL() {
+		caps := adapter.HALAdapter().SurfaceCapabilities(s.core.RawSurface())
+		if caps != nil && caps.Usages&TextureUsageStorageBinding != 0 {
+			return usage, false, nil
+		}
+	}
+	if config.Protected {
+		return 0, false, fmt.Errorf("wgpu: protected surface does not support storage writes")
+	}
+	return usage&^TextureUsageStorageBinding | TextureUsageCopyDst, true, nil
+}
+
+// syncStorageTarget makes sure the intermediate texture matches the surface
+// extent, recreating it after a resize. It does nothing when the surface
+// textures take storage writes directly.
+func (s *Surface) syncStorageTarget() error {
+	if s.storageUsage == 0 {
+		return nil
+	}
+	width, height := s.ActualExtent()
+	if width == 0 || height == 0 {
+		config := s.core.Config()
+		if config == nil {
+			return fmt.Errorf("wgpu: surface not configured")
+		}
+		width, height = config.Width, config.Height
+	}
+	if s.storage != nil && s.storage.size.Width == width && s.storage.size.Height == height {
+		return nil
+	}
+	s.releaseStorageTarget()
+
+	texture, err := s.device.CreateTexture(&TextureDescriptor{
+		Label:         "surface storage target",
+		Size:          Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     gputypes.TextureDimension2D,
+		Format:        s.core.Config().Format,
+		Usage:         s.storageUsage,
+	})
+	if err != nil {
+		return fmt.Errorf("wgpu: failed to create surface storage target: %w", err)
+	}
+	s.storage = texture
+	return nil
+}
+
+// releaseStorageTarget releases the intermediate texture, if any. Its
+// destruction waits for submissions that may still read it.
+func (s *Surface) releaseStorageTarget() {
+	if s.storage != nil {
+		s.storage.Release()
+		s.storage = nil
+	}
+}
+
+// resolveStorageTarget copies the frame's intermediate texture into the
+// surface texture ahead of presentation. Frames without one are left alone.
+func (s *Surface) resolveStorageTarget(st *SurfaceTexture) error {
+	if st.storage == nil {
+		return nil
+	}
+	encoder, err := s.device.CreateCommandEncoder(&CommandEncoderDescriptor{Label: "surface storage resolve"})
+	if err != nil {
+		return err
+	}
+	src, dst := st.AsTexture(), st.surfaceTexture()
+	encoder.CopyTextureToTexture(src, dst, []TextureCopy{{
+		Source:      ImageCopyTexture{Texture: src},
+		Destination: ImageCopyTexture{Texture: dst},
+		Size:        st.storage.size,
+	}})
+	commands, err := encoder.Finish()
+	if err != nil {
+		return fmt.Errorf("wgpu: surface storage resolve: %w", err)
+	}
+	if _, err := s.device.queue.Submit(commands); err != nil {
+		return fmt.Errorf("wgpu: surface storage resolve: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+//go:build !rust && !(js && wasm) && !android
+
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+const surfaceStorageWGSL = `
+@group(0) @binding(0) var target: texture_storage_2d<rgba8unorm, write>;
+
+@compute @workgroup_size(1)
+fn main(@builtin(global_invocation_id) id: vec3<u32>) {
+    textureStore(target, vec2<u32>(id.x, id.y), vec4<f32>(1.0, 0.0, 0.5, 1.0));
+}
+`
+
+func (f *headlessSoftwareFixture) configureStorage(t *testing.T) {
+	t.Helper()
+	if err := f.surface.Configure(f.device, &SurfaceConfiguration{
+		Width:       f.width,
+		Height:      f.height,
+		Format:      f.format,
+		Usage:       gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageStorageBinding,
+		PresentMode: gputypes.PresentModeFifo,
+		AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+	}); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+}
+
+// dispatchStorage fills the acquired frame from a compute shader and
+// presents it.
+func (f *headlessSoftwareFixture) dispatchStorage(t *testing.T) {
+	t.Helper()
+	device := f.device
+	shader, err := device.CreateShaderModule(&ShaderModuleDescriptor{Label: "surface-storage", WGSL: surfaceStorageWGSL})
+	if err != nil {
+		t.Fatalf("CreateShaderModule: %v", err)
+	}
+	defer shader.Release()
+	layout, err := device.CreateBindGroupLayout(&BindGroupLayoutDescriptor{
+		Label: "surface-storage",
+		Entries: []BindGroupLayoutEntry{{
+			Binding:    0,
+			Visibility: ShaderStageCompute,
+			StorageTexture: &gputypes.StorageTextureBindingLayout{
+				Access:        gputypes.StorageTextureAccessWriteOnly,
+				Format:        f.format,
+				ViewDimension: gputypes.TextureViewDimension2D,
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroupLayout: %v", err)
+	}
+	defer layout.Release()
+	pipelineLayout, err := device.CreatePipelineLayout(&PipelineLayoutDescriptor{BindGroupLayouts: []*BindGroupLayout{layout}})
+	if err != nil {
+		t.Fatalf("CreatePipelineLayout: %v", err)
+	}
+	defer pipelineLayout.Release()
+	pipeline, err := device.CreateComputePipeline(&ComputePipelineDescriptor{
+		Label: "surface-storage", Layout: pipelineLayout, Module: shader, EntryPoint: "main",
+	})
+	if err != nil {
+		t.Fatalf("CreateComputePipeline: %v", err)
+	}
+	defer pipeline.Release()
+
+	texture, _, err := f.surface.GetCurrentTexture()
+	if err != nil {
+		t.Fatalf("GetCurrentTexture: %v", err)
+	}
+	view, err := texture.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	group, err := device.CreateBindGroup(&BindGroupDescriptor{
+		Layout: layout, Entries: []BindGroupEntry{{Binding: 0, TextureView: view}},
+	})
+	if err != nil {
+		t.Fatalf("CreateBindGroup: %v", err)
+	}
+	defer group.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := encoder.BeginComputePass(nil)
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	pass.SetPipeline(pipeline)
+	pass.SetBindGroup(0, group, nil)
+	pass.Dispatch(f.width, f.height, 1)
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	commands, err := encoder.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(commands); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := f.surface.Present(texture); err != nil {
+		t.Fatalf("Present: %v", err)
+	}
+}
+
+func TestSurfaceStorageFallback(t *testing.T) {
+	const width, height = uint32(4), uint32(3)
+	fixture := newHeadlessSoftwareFixture(t, width, height, TextureFormatRGBA8Unorm, false)
+	fixture.configureStorage(t)
+
+	// The software surface does not report StorageBinding, so frames go
+	// through the intermediate texture.
+	if fixture.surface.storage == nil {
+		t.Fatal("storage configuration without surface support created no intermediate texture")
+	}
+	fixture.dispatchStorage(t)
+
+	pixels, err := fixture.surface.ReadPixels()
+	if err != nil {
+		t.Fatalf("ReadPixels: %v", err)
+	}
+	if want := int(width * height * 4); len(pixels) != want {
+		t.Fatalf("ReadPixels length = %d, want %d", len(pixels), want)
+	}
+	wantPixel := []byte{0xff, 0x00, 0x80, 0xff}
+	for offset := 0; offset < len(pixels); offset += 4 {
+		if !bytes.Equal(pixels[offset:offset+4], wantPixel) {
+			t.Fatalf("pixel %d = %v, want RGBA %v", offset/4, pixels[offset:offset+4], wantPixel)
+		}
+	}
+
+	fixture.surface.Unconfigure()
+	if fixture.surface.storage != nil {
+		t.Error("Unconfigure kept the intermediate texture")
+	}
+}
+
+func TestSurfaceStorageFallbackResize(t *testing.T) {
+	fixture := newHeadlessSoftwareFixture(t, 4, 4, TextureFormatRGBA8Unorm, false)
+	fixture.configureStorage(t)
+	first := fixture.surface.storage
+
+	fixture.width, fixture.height = 8, 2
+	fixture.configureStorage(t)
+	storage := fixture.surface.storage
+	if storage == nil || storage == first {
+		t.Fatal("reconfigure did not replace the intermediate texture")
+	}
+	if storage.size.Width != 8 || storage.size.Height != 2 {
+		t.Errorf("intermediate size = %dx%d, want 8x2", storage.size.Width, storage.size.Height)
+	}
+	fixture.configure(t)
+	if fixture.surface.storage != nil {
+		t.Error("configuration without StorageBinding kept the intermediate texture")
+	}
+}
+
+func TestSurfaceStorageRejectsSrgb(t *testing.T) {
+	fixture := newHeadlessSoftwareFixture(t, 4, 4, gputypes.TextureFormatRGBA8UnormSrgb, false)
+	err := fixture.surface.Configure(fixture.device, &SurfaceConfiguration{
+		Width:       4,
+		Height:      4,
+		Format:      gputypes.TextureFormatRGBA8UnormSrgb,
+		Usage:       gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageStorageBinding,
+		PresentMode: gputypes.PresentModeFifo,
+		AlphaMode:   gputypes.CompositeAlphaModeOpaque,
+	})
+	if err == nil {
+		t.Fatal("Configure accepted StorageBinding on an sRGB surface format")
+	}
+}
@@ -37,6 +37,7 @@ const (
 	surfaceTargetAndroidNativeWindow
 	surfaceTargetMetalLayer
 	surfaceTargetWebCanvasID
+	surfaceTargetUIView
 )
 
 // SurfaceTargetUnsafe identifies raw platform handles for surface creation.
@@ -53,13 +54,14 @@ type SurfaceTargetUnsafe struct {
 
 // HeadlessSurfaceTarget requests a surface without a native window.
 //
-// The Pure-Go software backend implements this Go-specific extension. It owns
-// no native handles, so the zero value is ready for use with
-// Instance.CreateSurfaceFromTarget. Rust and browser implementations reject
-// this target with ErrUnsupportedSurfaceTarget.
+// The Pure-Go software backend and the GLES backend (on an offscreen
+// framebuffer over a pbuffer or surfaceless context) implement this
+// Go-specific extension. It owns no native handles, so the zero value is
+// ready for use with Instance.CreateSurfaceFromTarget. Rust and browser
+// implementations reject this target with ErrUnsupportedSurfaceTarget.
 type HeadlessSurfaceTarget struct{}
 
-// SurfaceTarget returns the raw target used by the Pure-Go software backend.
+// SurfaceTarget returns the raw target used by the software and GLES backends.
 func (HeadlessSurfaceTarget) SurfaceTarget() (SurfaceTargetUnsafe, error) {
 	return SurfaceTargetUnsafe{kind: surfaceTargetHeadless}, nil
 }
@@ -109,6 +111,16 @@ func SurfaceTargetFromMetalLayer(layer uintptr) SurfaceTargetUnsafe {
 	}
 }
 
+// SurfaceTargetFromUIView returns a raw UIView* target for iOS and tvOS.
+// When the view's layer is not a CAMetalLayer, the Metal backend attaches a
+// CAMetalLayer sublayer sized to the view's bounds.
+func SurfaceTargetFromUIView(view uintptr) SurfaceTargetUnsafe {
+	return SurfaceTargetUnsafe{
+		kind:         surfaceTargetUIView,
+		windowHandle: view,
+	}
+}
+
 // SurfaceTargetFromWebCanvasID returns a browser canvas target identified by
 // its data-raw-handle attribute. ID zero retains the legacy behavior of using
 // the first canvas element in the document.
@@ -119,6 +131,43 @@ func SurfaceTargetFromWebCanvasID(id uintptr) SurfaceTargetUnsafe {
 	}
 }
 
+// CreateSurfaceFromWindowsHWND creates a surface for a Win32 window.
+// It is shorthand for CreateSurfaceUnsafe(SurfaceTargetFromWindowsHWND(...));
+// the window must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromWindowsHWND(hinstance, hwnd uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromWindowsHWND(hinstance, hwnd))
+}
+
+// CreateSurfaceFromXlibWindow creates a surface for an Xlib window.
+// The display and window must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromXlibWindow(display, window uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromXlibWindow(display, window))
+}
+
+// CreateSurfaceFromWaylandSurface creates a surface for a Wayland wl_surface.
+// The display and surface must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromWaylandSurface(display, surface uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromWaylandSurface(display, surface))
+}
+
+// CreateSurfaceFromAndroidNativeWindow creates a surface for an
+// ANativeWindow. The window must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromAndroidNativeWindow(window uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromAndroidNativeWindow(window))
+}
+
+// CreateSurfaceFromMetalLayer creates a surface for a CAMetalLayer.
+// The layer must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromMetalLayer(layer uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromMetalLayer(layer))
+}
+
+// CreateSurfaceFromUIView creates a surface for an iOS or tvOS UIView.
+// The view must outlive the returned Surface.
+func (i *Instance) CreateSurfaceFromUIView(view uintptr) (*Surface, error) {
+	return i.CreateSurfaceUnsafe(SurfaceTargetFromUIView(view))
+}
+
 func (t SurfaceTargetUnsafe) validate() error {
 	switch t.kind {
 	case surfaceTargetHeadless:
@@ -143,6 +192,10 @@ func (t SurfaceTargetUnsafe) validate() error {
 		if t.windowHandle == 0 {
 			return invalidSurfaceTarget("Metal layer is zero")
 		}
+	case surfaceTargetUIView:
+		if t.windowHandle == 0 {
+			return invalidSurfaceTarget("UIView is zero")
+		}
 	case surfaceTargetWebCanvasID:
 		// Zero intentionally selects the first canvas for compatibility.
 	case surfaceTargetInvalid:
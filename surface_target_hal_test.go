@@ -53,6 +53,11 @@ func TestSurfaceTargetUnsafeMapsToTypedHALTarget(t *testing.T) {
 			target: SurfaceTargetFromMetalLayer(8),
 			want:   hal.SurfaceTarget{Kind: hal.SurfaceTargetMetalLayer, WindowHandle: 8},
 		},
+		{
+			name:   "UIView",
+			target: SurfaceTargetFromUIView(9),
+			want:   hal.SurfaceTarget{Kind: hal.SurfaceTargetUIView, WindowHandle: 9},
+		},
 	}
 
 	for _, test := range tests {
@@ -88,6 +93,7 @@ func TestSurfaceTargetUnsafeValidationAcceptsValidTargets(t *testing.T) {
 		SurfaceTargetFromWaylandSurface(3, 4),
 		SurfaceTargetFromAndroidNativeWindow(5),
 		SurfaceTargetFromMetalLayer(6),
+		SurfaceTargetFromUIView(7),
 		SurfaceTargetFromWebCanvasID(0),
 	}
 
@@ -25,6 +25,7 @@ func TestCreateSurfaceUnsafeRejectsInvalidTargets(t *testing.T) {
 		{name: "Wayland surface", target: wgpu.SurfaceTargetFromWaylandSurface(1, 0)},
 		{name: "Android native window", target: wgpu.SurfaceTargetFromAndroidNativeWindow(0)},
 		{name: "Metal layer", target: wgpu.SurfaceTargetFromMetalLayer(0)},
+		{name: "UIView", target: wgpu.SurfaceTargetFromUIView(0)},
 	}
 
 	for _, test := range tests {
@@ -41,6 +42,36 @@ func TestCreateSurfaceUnsafeRejectsInvalidTargets(t *testing.T) {
 	}
 }
 
+func TestCreateSurfaceHelpersRejectZeroHandles(t *testing.T) {
+	instance := newInstance(t)
+	defer instance.Release()
+
+	tests := []struct {
+		name   string
+		create func() (*wgpu.Surface, error)
+	}{
+		{"Win32 HWND", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromWindowsHWND(0, 0) }},
+		{"Xlib window", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromXlibWindow(1, 0) }},
+		{"Wayland surface", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromWaylandSurface(1, 0) }},
+		{"Android native window", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromAndroidNativeWindow(0) }},
+		{"Metal layer", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromMetalLayer(0) }},
+		{"UIView", func() (*wgpu.Surface, error) { return instance.CreateSurfaceFromUIView(0) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			surface, err := test.create()
+			if surface != nil {
+				surface.Release()
+				t.Fatal("helper returned a surface for a zero handle")
+			}
+			if !errors.Is(err, wgpu.ErrInvalidSurfaceTarget) {
+				t.Fatalf("error = %v, want ErrInvalidSurfaceTarget", err)
+			}
+		})
+	}
+}
+
 type testSurfaceTargetProvider struct {
 	target wgpu.SurfaceTargetUnsafe
 	err    error
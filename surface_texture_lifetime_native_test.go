@@ -4,6 +4,7 @@ package wgpu
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gogpu/gputypes"
@@ -65,6 +66,29 @@ func newAcquiredSurfaceForLifetimeTest(t *testing.T) (*Surface, *SurfaceTexture,
 	return surface, texture, device, rawDevice
 }
 
+// expectExpiredFramePanic fails the test unless f panics because it used a
+// SurfaceTexture after its frame ended.
+func expectExpiredFramePanic(t *testing.T, what string, f func()) {
+	t.Helper()
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "frame was presented or discarded") {
+			t.Errorf("%s: recovered %q, want the expired frame panic", what, msg)
+		}
+	}()
+	f()
+}
+
+// expectExpiredPresentError fails the test unless err reports a present of a
+// SurfaceTexture whose frame expired.
+func expectExpiredPresentError(t *testing.T, what string, err error) {
+	t.Helper()
+	var presentErr *core.SurfacePresentError
+	if !errors.As(err, &presentErr) || presentErr.Kind != core.SurfacePresentErrorExpired {
+		t.Errorf("%s = %v, want a SurfacePresentErrorExpired", what, err)
+	}
+}
+
 func TestSurfaceTextureDerivedWrappersInvalidateAfterDiscard(t *testing.T) {
 	surface, surfaceTexture, device, rawDevice := newAcquiredSurfaceForLifetimeTest(t)
 	defer device.Release()
@@ -90,9 +114,7 @@ func TestSurfaceTextureDerivedWrappersInvalidateAfterDiscard(t *testing.T) {
 	if got := surfaceTexture.AsTexture(); got != nil {
 		t.Fatal("AsTexture returned a wrapper after DiscardTexture")
 	}
-	if _, err := surfaceTexture.CreateView(nil); !errors.Is(err, ErrReleased) {
-		t.Fatalf("CreateView after DiscardTexture = %v, want ErrReleased", err)
-	}
+	expectExpiredFramePanic(t, "CreateView after DiscardTexture", func() { _, _ = surfaceTexture.CreateView(nil) })
 	if _, err := device.CreateTextureView(texture, nil); !errors.Is(err, ErrReleased) {
 		t.Fatalf("Device.CreateTextureView after DiscardTexture = %v, want ErrReleased", err)
 	}
@@ -551,14 +573,64 @@ func TestSurfaceTextureDerivedWrappersInvalidateAfterPresentAndRelease(t *testin
 	}
 }
 
+func TestSurfaceTextureViewEndsWithFrame(t *testing.T) {
+	surface, surfaceTexture, device, rawDevice := newAcquiredSurfaceForLifetimeTest(t)
+	defer device.Release()
+
+	view, err := surfaceTexture.View()
+	if err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if again, err := surfaceTexture.View(); err != nil || again != view {
+		t.Fatalf("second View = %p, %v; want cached %p", again, err, view)
+	}
+	if surfaceTexture.Expired() {
+		t.Fatal("Expired before Present")
+	}
+	if err := surface.Present(surfaceTexture); err != nil {
+		t.Fatalf("surface.Present: %v", err)
+	}
+	if !surfaceTexture.Expired() {
+		t.Fatal("Expired = false after Present")
+	}
+	if err := device.WaitIdle(); err != nil {
+		t.Fatalf("WaitIdle: %v", err)
+	}
+	if rawDevice.destroyedViews != 1 {
+		t.Fatalf("frame view destruction count = %d, want 1", rawDevice.destroyedViews)
+	}
+
+	expectExpiredFramePanic(t, "View after Present", func() { _, _ = surfaceTexture.View() })
+	expectExpiredFramePanic(t, "CreateView after Present", func() { _, _ = surfaceTexture.CreateView(nil) })
+	expectExpiredFramePanic(t, "second Present", func() { _ = surface.Present(surfaceTexture) })
+	if surfaceTexture.Texture() != nil {
+		t.Fatal("Texture returned a wrapper after Present")
+	}
+
+	// Discarding ends the frame just like Present.
+	next, _, err := surface.GetCurrentTexture()
+	if err != nil {
+		t.Fatalf("second GetCurrentTexture: %v", err)
+	}
+	if _, err := next.View(); err != nil {
+		t.Fatalf("View of second frame: %v", err)
+	}
+	surface.DiscardTexture()
+	if err := device.WaitIdle(); err != nil {
+		t.Fatalf("WaitIdle: %v", err)
+	}
+	if rawDevice.destroyedViews != 2 {
+		t.Fatalf("discarded frame view destruction count = %d, want 2", rawDevice.destroyedViews)
+	}
+	surface.Release()
+}
+
 func TestSurfacePresentRejectsTextureFromAnotherAcquisition(t *testing.T) {
 	surface, surfaceTexture, device, _ := newAcquiredSurfaceForLifetimeTest(t)
 	defer device.Release()
 
 	other := &SurfaceTexture{hal: surfaceTexture.hal, surface: surface, device: device, lease: surfaceTexture.lease + 1}
-	if err := surface.Present(other); !errors.Is(err, ErrReleased) {
-		t.Fatalf("Present with foreign token = %v, want ErrReleased", err)
-	}
+	expectExpiredPresentError(t, "Present with foreign token", surface.Present(other))
 	if surface.core.State() != core.SurfaceStateAcquired {
 		t.Fatalf("surface state after rejected Present = %v, want acquired", surface.core.State())
 	}
@@ -601,6 +673,7 @@ func TestSurfaceTextureLeaseAcrossReconfigureAndUnconfigure(t *testing.T) {
 	if surfaceTexture.AsTexture() != nil {
 		t.Fatal("AsTexture returned a wrapper after Unconfigure")
 	}
+	expectExpiredPresentError(t, "Present after Unconfigure", surface.Present(surfaceTexture))
 	if _, err := device.CreateTextureView(texture, nil); !errors.Is(err, ErrReleased) {
 		t.Fatalf("Device.CreateTextureView after Unconfigure = %v, want ErrReleased", err)
 	}
@@ -640,6 +713,7 @@ func TestSurfaceTextureDerivedWrappersInvalidateAfterDeviceRelease(t *testing.T)
 	if surfaceTexture.AsTexture() != nil {
 		t.Fatal("AsTexture returned a wrapper after Device.Release")
 	}
+	expectExpiredPresentError(t, "Present after Device.Release", surface.Present(surfaceTexture))
 	view.Release()
 	if rawDevice.destroyedViews != 0 {
 		t.Fatalf("post-device-release view destruction count = %d, want 0", rawDevice.destroyedViews)
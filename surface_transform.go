@@ -0,0 +1,91 @@
+package wgpu
+
+import "fmt"
+
+// SurfaceTransform is a set of surface transforms: clockwise rotations and
+// horizontal mirroring, as reported in SurfaceCapabilities and requested
+// with SurfaceConfiguration.PreTransform.
+//
+// On mobile the display's native orientation often differs from the one the
+// user holds the device in. By default the compositor rotates every frame
+// to match, which costs an extra full-screen pass. An application can
+// instead pre-rotate: configure PreTransform with the CurrentTransform of
+// the surface, multiply its projection by PreRotationMatrix, and reconfigure
+// when an acquire reports Suboptimal after an orientation change.
+type SurfaceTransform uint32
+
+// Surface transform flags.
+const (
+	SurfaceTransformIdentity SurfaceTransform = 1 << iota
+	SurfaceTransformRotate90
+	SurfaceTransformRotate180
+	SurfaceTransformRotate270
+	SurfaceTransformHorizontalMirror
+	SurfaceTransformHorizontalMirrorRotate90
+	SurfaceTransformHorizontalMirrorRotate180
+	SurfaceTransformHorizontalMirrorRotate270
+)
+
+var surfaceTransformNames = [...]string{
+	"identity",
+	"rotate-90",
+	"rotate-180",
+	"rotate-270",
+	"horizontal-mirror",
+	"horizontal-mirror-rotate-90",
+	"horizontal-mirror-rotate-180",
+	"horizontal-mirror-rotate-270",
+}
+
+// String returns the name of a single transform.
+func (t SurfaceTransform) String() string {
+	for i, name := range surfaceTransformNames {
+		if t == 1<<i {
+			return name
+		}
+	}
+	return fmt.Sprintf("SurfaceTransform(%#x)", uint32(t))
+}
+
+// SwapsDimensions reports whether the transform turns the image a quarter
+// turn, so that a surface texture's width is the window's height.
+func (t SurfaceTransform) SwapsDimensions() bool {
+	return t&(SurfaceTransformRotate90|SurfaceTransformRotate270|
+		SurfaceTransformHorizontalMirrorRotate90|SurfaceTransformHorizontalMirrorRotate270) != 0
+}
+
+// PreRotationMatrix returns the column-major 4x4 clip-space matrix that
+// applies transform to rendering, for use with SurfaceConfiguration.PreTransform.
+// Multiply it on the left of the projection: clip = PreRotationMatrix(t) *
+// projection * view * model. Rotations are clockwise as seen on screen and
+// the mirror is applied first. Depth and w are unchanged. Zero, identity
+// and unknown values return the identity matrix.
+func PreRotationMatrix(transform SurfaceTransform) [16]float32 {
+	var mirror float32 = 1
+	turns := 0
+	switch transform {
+	case SurfaceTransformRotate90:
+		turns = 1
+	case SurfaceTransformRotate180:
+		turns = 2
+	case SurfaceTransformRotate270:
+		turns = 3
+	case SurfaceTransformHorizontalMirror:
+		mirror = -1
+	case SurfaceTransformHorizontalMirrorRotate90:
+		mirror, turns = -1, 1
+	case SurfaceTransformHorizontalMirrorRotate180:
+		mirror, turns = -1, 2
+	case SurfaceTransformHorizontalMirrorRotate270:
+		mirror, turns = -1, 3
+	}
+	// A clockwise quarter turn in y-up clip space maps (x, y) to (y, -x).
+	sin := [4]float32{0, -1, 0, 1}[turns]
+	cos := [4]float32{1, 0, -1, 0}[turns]
+	return [16]float32{
+		mirror * cos, mirror * sin, 0, 0,
+		-sin, cos, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
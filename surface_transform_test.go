@@ -0,0 +1,60 @@
+package wgpu
+
+import "testing"
+
+// applyClip multiplies the x, y of a clip-space point by a column-major matrix.
+func applyClip(m [16]float32, x, y float32) (float32, float32) {
+	return m[0]*x + m[4]*y + m[12], m[1]*x + m[5]*y + m[13]
+}
+
+func TestPreRotationMatrix(t *testing.T) {
+	tests := []struct {
+		transform SurfaceTransform
+		// Where the right (1, 0) and top (0, 1) edges of clip space land.
+		right, top [2]float32
+	}{
+		{0, [2]float32{1, 0}, [2]float32{0, 1}},
+		{SurfaceTransformIdentity, [2]float32{1, 0}, [2]float32{0, 1}},
+		{SurfaceTransformRotate90, [2]float32{0, -1}, [2]float32{1, 0}},
+		{SurfaceTransformRotate180, [2]float32{-1, 0}, [2]float32{0, -1}},
+		{SurfaceTransformRotate270, [2]float32{0, 1}, [2]float32{-1, 0}},
+		{SurfaceTransformHorizontalMirror, [2]float32{-1, 0}, [2]float32{0, 1}},
+		{SurfaceTransformHorizontalMirrorRotate90, [2]float32{0, 1}, [2]float32{1, 0}},
+		{SurfaceTransformRotate90 | SurfaceTransformRotate180, [2]float32{1, 0}, [2]float32{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.transform.String(), func(t *testing.T) {
+			m := PreRotationMatrix(tt.transform)
+			if x, y := applyClip(m, 1, 0); x != tt.right[0] || y != tt.right[1] {
+				t.Errorf("(1, 0) -> (%v, %v), want %v", x, y, tt.right)
+			}
+			if x, y := applyClip(m, 0, 1); x != tt.top[0] || y != tt.top[1] {
+				t.Errorf("(0, 1) -> (%v, %v), want %v", x, y, tt.top)
+			}
+			if m[10] != 1 || m[15] != 1 {
+				t.Errorf("depth or w changed: %v", m)
+			}
+		})
+	}
+}
+
+func TestSurfaceTransformSwapsDimensions(t *testing.T) {
+	swaps := map[SurfaceTransform]bool{
+		SurfaceTransformIdentity:                  false,
+		SurfaceTransformRotate90:                  true,
+		SurfaceTransformRotate180:                 false,
+		SurfaceTransformRotate270:                 true,
+		SurfaceTransformHorizontalMirror:          false,
+		SurfaceTransformHorizontalMirrorRotate90:  true,
+		SurfaceTransformHorizontalMirrorRotate180: false,
+		SurfaceTransformHorizontalMirrorRotate270: true,
+	}
+	for transform, want := range swaps {
+		if got := transform.SwapsDimensions(); got != want {
+			t.Errorf("%v.SwapsDimensions() = %v, want %v", transform, got, want)
+		}
+	}
+	if got := SurfaceTransformRotate270.String(); got != "rotate-270" {
+		t.Errorf("String() = %q, want rotate-270", got)
+	}
+}
@@ -7,6 +7,7 @@ import "github.com/gogpu/wgpu/internal/browser"
 // Texture represents a GPU texture.
 type Texture struct {
 	browser  *browser.Texture
+	device   *Device
 	format   TextureFormat
 	released bool
 }
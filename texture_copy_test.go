@@ -0,0 +1,84 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/core"
+)
+
+func newCopyTexture(t *testing.T, device *wgpu.Device, format wgpu.TextureFormat, width, height uint32) *wgpu.Texture {
+	t.Helper()
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         format.String(),
+		Size:          wgpu.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        format,
+		Usage:         wgpu.TextureUsageCopySrc | wgpu.TextureUsageCopyDst,
+	})
+	if err != nil {
+		t.Fatalf("CreateTexture %v: %v", format, err)
+	}
+	t.Cleanup(tex.Release)
+	return tex
+}
+
+func TestCopyTextureToTextureValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+	rgba8 := newCopyTexture(t, device, wgpu.TextureFormatRGBA8Unorm, 4, 4)
+	srgb := newCopyTexture(t, device, wgpu.TextureFormatRGBA8UnormSrgb, 4, 4)
+	r32 := newCopyTexture(t, device, gputypes.TextureFormatR32Uint, 4, 4)
+	rgba32 := newCopyTexture(t, device, gputypes.TextureFormatRGBA32Uint, 2, 2)
+	bc7 := newCopyTexture(t, device, gputypes.TextureFormatBC7RGBAUnorm, 8, 8)
+
+	tests := []struct {
+		name     string
+		src, dst *wgpu.Texture
+		size     wgpu.Extent3D
+		kind     core.CopyTextureErrorKind
+		valid    bool
+	}{
+		{"sRGB variant", rgba8, srgb, wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1}, 0, true},
+		{"incompatible formats", rgba8, r32, wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+			core.CopyTextureErrorFormatsIncompatible, false},
+		{"out of bounds", rgba8, srgb, wgpu.Extent3D{Width: 8, Height: 4, DepthOrArrayLayers: 1},
+			core.CopyTextureErrorOutOfBounds, false},
+		// The software adapter copies bytes without block layouts.
+		{"reinterpret unsupported", rgba32, bc7, wgpu.Extent3D{Width: 2, Height: 2, DepthOrArrayLayers: 1},
+			core.CopyTextureErrorReinterpretUnsupported, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.valid {
+				requireValidation(t)
+			}
+			enc, err := device.CreateCommandEncoder(nil)
+			if err != nil {
+				t.Fatalf("CreateCommandEncoder: %v", err)
+			}
+			enc.CopyTextureToTexture(tt.src, tt.dst, []wgpu.TextureCopy{{
+				Source:      wgpu.ImageCopyTexture{Texture: tt.src},
+				Destination: wgpu.ImageCopyTexture{Texture: tt.dst},
+				Size:        tt.size,
+			}})
+			cmdBuf, err := enc.Finish()
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Finish: %v", err)
+				}
+				cmdBuf.Release()
+				return
+			}
+			var cte *core.CopyTextureError
+			if !errors.As(err, &cte) || cte.Kind != tt.kind {
+				t.Fatalf("Finish = %v, want CopyTextureError kind %d", err, tt.kind)
+			}
+		})
+	}
+}
@@ -3,18 +3,31 @@
 package wgpu
 
 import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu/core"
 	"github.com/gogpu/wgpu/hal"
 )
 
 // Texture represents a GPU texture.
 type Texture struct {
-	hal          hal.Texture
-	device       *Device
-	format       TextureFormat
-	released     bool
-	surface      *core.Surface
-	surfaceLease uint64
+	hal    hal.Texture
+	device *Device
+	format TextureFormat
+	// dimension, size, mipLevelCount and usage are zero for wrapped and
+	// surface textures, whose shape the wrapper does not know.
+	dimension     TextureDimension
+	size          Extent3D
+	mipLevelCount uint32
+	usage         TextureUsage
+	sampleCount   uint32
+	viewFormats   []TextureFormat
+	transient     bool
+	protected     bool
+	released      bool
+	surface       *core.Surface
+	surfaceLease  uint64
 }
 
 // resolveHAL is the single boundary from a public texture wrapper to HAL.
@@ -32,9 +45,27 @@ func (t *Texture) resolveHAL() hal.Texture {
 	return t.hal
 }
 
-// Format returns the texture format.
+// Format returns the texture format. For a depth texture this may differ
+// from the requested format when the adapter lacked it and CreateTexture
+// substituted a fallback; create pipelines and views with this format.
 func (t *Texture) Format() TextureFormat { return t.format }
 
+// viewSource returns the descriptor view validation checks against, or nil
+// when the texture's shape is unknown.
+func (t *Texture) viewSource() *hal.TextureDescriptor {
+	if t.dimension == gputypes.TextureDimensionUndefined {
+		return nil
+	}
+	return &hal.TextureDescriptor{
+		Size:          t.size.toHAL(),
+		MipLevelCount: t.mipLevelCount,
+		SampleCount:   t.sampleCount,
+		Dimension:     t.dimension,
+		Format:        t.format,
+		ViewFormats:   t.viewFormats,
+	}
+}
+
 // Release destroys the texture. The underlying HAL texture is not freed
 // immediately — destruction is deferred until the GPU completes any submission
 // that may reference it. This prevents use-after-free on DX12/Vulkan.
@@ -70,12 +101,19 @@ func (t *Texture) Release() {
 
 // TextureView represents a view into a texture.
 type TextureView struct {
-	hal          hal.TextureView
-	device       *Device
-	texture      *Texture
-	released     bool
-	surface      *core.Surface
-	surfaceLease uint64
+	hal       hal.TextureView
+	device    *Device
+	texture   *Texture
+	format    TextureFormat
+	dimension TextureViewDimension // undefined when the texture's shape is unknown
+	// mipLevelCount is the number of mips the view spans, 0 when unknown.
+	mipLevelCount uint32
+	// arrayLayerCount is the number of layers the view spans, 0 when unknown.
+	arrayLayerCount uint32
+	sampleCount     uint32
+	released        bool
+	surface         *core.Surface
+	surfaceLease    uint64
 }
 
 // resolveHAL is the single boundary from a public texture-view wrapper to HAL.
@@ -94,6 +132,40 @@ func (v *TextureView) resolveHAL() hal.TextureView {
 	return v.hal
 }
 
+// releasedError returns the error reported when resolveHAL fails:
+// ErrSurfaceTextureExpired for a live view of an ended surface frame,
+// ErrReleased otherwise.
+func (v *TextureView) releasedError() error {
+	if v != nil && !v.released && v.surface != nil && !v.surface.AcquisitionValid(v.surfaceLease) {
+		return ErrSurfaceTextureExpired
+	}
+	return ErrReleased
+}
+
+// toCore returns the core view used in render pass descriptors.
+func (v *TextureView) toCore() *core.TextureView {
+	return &core.TextureView{HAL: v.resolveHAL(), Format: v.format, SampleCount: v.sampleCount}
+}
+
+// checkViewCount checks that a multiview pass attachment is a 2D array view
+// with one layer per view. Views of wrapped textures, whose shape is
+// unknown, are not checked.
+func (v *TextureView) checkViewCount(viewCount uint32) error {
+	if v == nil || viewCount <= 1 || v.dimension == gputypes.TextureViewDimensionUndefined {
+		return nil
+	}
+	if v.dimension != gputypes.TextureViewDimension2DArray || v.arrayLayerCount != viewCount {
+		return fmt.Errorf("view must be a 2D array view with %d layers for view count %d, got %s with %d layers",
+			viewCount, viewCount, v.dimension, v.arrayLayerCount)
+	}
+	return nil
+}
+
+// isTransient reports whether the view's texture is a transient attachment.
+func (v *TextureView) isTransient() bool {
+	return v != nil && v.texture != nil && v.texture.transient
+}
+
 // Texture returns the parent Texture that this view was created from.
 // Returns nil if the view has been released.
 func (v *TextureView) Texture() *Texture {
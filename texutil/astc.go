@@ -0,0 +1,685 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import "math/bits"
+
+// ASTC LDR block decoder, following the Khronos Data Format Specification
+// (chapter "ASTC Compressed Texture Image Formats"). HDR endpoint modes,
+// HDR void-extent blocks, and malformed blocks decode to the error color
+// (opaque magenta), as an LDR-only decoder must.
+
+var astcErrorColor = [4]byte{255, 0, 255, 255}
+
+// iseRange describes an integer-sequence-encoded alphabet of
+// (1<<bits) * (3 if trits, 5 if quints) values.
+type iseRange struct {
+	bits          uint
+	trits, quints bool
+}
+
+// iseRanges lists the quantization ranges in increasing order. Weight ranges
+// use indices 0-11; color endpoints use 4-20.
+var iseRanges = [...]iseRange{
+	{bits: 1}, {trits: true}, {bits: 2}, {quints: true}, {bits: 1, trits: true},
+	{bits: 3}, {bits: 1, quints: true}, {bits: 2, trits: true}, {bits: 4},
+	{bits: 2, quints: true}, {bits: 3, trits: true}, {bits: 5}, {bits: 3, quints: true},
+	{bits: 4, trits: true}, {bits: 6}, {bits: 4, quints: true}, {bits: 5, trits: true},
+	{bits: 7}, {bits: 5, quints: true}, {bits: 6, trits: true}, {bits: 8},
+}
+
+const (
+	astcColorRangeMin = 4
+	astcMaxWeights    = 64
+)
+
+func (q iseRange) bitCount(count int) int {
+	n := count * int(q.bits)
+	switch {
+	case q.trits:
+		n += (8*count + 4) / 5
+	case q.quints:
+		n += (7*count + 2) / 3
+	}
+	return n
+}
+
+// astcBits is a 128-bit block with bit reads that return zero past the end.
+type astcBits [2]uint64
+
+func (b *astcBits) read(pos, n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		p := pos + i
+		if p >= 0 && p < 128 && b[p>>6]>>(p&63)&1 != 0 {
+			v |= 1 << i
+		}
+	}
+	return v
+}
+
+func (b *astcBits) reversed() astcBits {
+	return astcBits{bits.Reverse64(b[1]), bits.Reverse64(b[0])}
+}
+
+// decodeISE decodes count values of range q starting at bit pos.
+func decodeISE(b *astcBits, pos int, count int, q iseRange, out []uint32) {
+	end := pos + q.bitCount(count)
+	read := func(n uint) uint32 {
+		v := uint32(0)
+		for i := 0; i < int(n); i++ {
+			if pos+i < end && b.read(pos+i, 1) != 0 {
+				v |= 1 << i
+			}
+		}
+		pos += int(n)
+		return v
+	}
+	switch {
+	case q.trits:
+		for i := 0; i < count; i += 5 {
+			var m [5]uint32
+			var t uint32
+			m[0] = read(q.bits)
+			t = read(2)
+			m[1] = read(q.bits)
+			t |= read(2) << 2
+			m[2] = read(q.bits)
+			t |= read(1) << 4
+			m[3] = read(q.bits)
+			t |= read(2) << 5
+			m[4] = read(q.bits)
+			t |= read(1) << 7
+			trits := decodeTrits(t)
+			for j := 0; j < 5 && i+j < count; j++ {
+				out[i+j] = trits[j]<<q.bits | m[j]
+			}
+		}
+	case q.quints:
+		for i := 0; i < count; i += 3 {
+			var m [3]uint32
+			var v uint32
+			m[0] = read(q.bits)
+			v = read(3)
+			m[1] = read(q.bits)
+			v |= read(2) << 3
+			m[2] = read(q.bits)
+			v |= read(2) << 5
+			quints := decodeQuints(v)
+			for j := 0; j < 3 && i+j < count; j++ {
+				out[i+j] = quints[j]<<q.bits | m[j]
+			}
+		}
+	default:
+		for i := 0; i < count; i++ {
+			out[i] = read(q.bits)
+		}
+	}
+}
+
+func bit(v uint32, n uint) uint32 { return v >> n & 1 }
+
+func decodeTrits(t uint32) [5]uint32 {
+	var c, t0, t1, t2, t3, t4 uint32
+	if t>>2&7 == 7 {
+		c = t>>5&7<<2 | t&3
+		t4, t3 = 2, 2
+	} else {
+		c = t & 0x1F
+		if t>>5&3 == 3 {
+			t4, t3 = 2, bit(t, 7)
+		} else {
+			t4, t3 = bit(t, 7), t>>5&3
+		}
+	}
+	switch {
+	case c&3 == 3:
+		t2, t1 = 2, bit(c, 4)
+		t0 = bit(c, 3)<<1 | bit(c, 2)&^bit(c, 3)
+	case c>>2&3 == 3:
+		t2, t1, t0 = 2, 2, c&3
+	default:
+		t2, t1 = bit(c, 4), c>>2&3
+		t0 = bit(c, 1)<<1 | bit(c, 0)&^bit(c, 1)
+	}
+	return [5]uint32{t0, t1, t2, t3, t4}
+}
+
+func decodeQuints(q uint32) [3]uint32 {
+	var c, q0, q1, q2 uint32
+	if q>>1&3 == 3 && q>>5&3 == 0 {
+		q2 = bit(q, 0)<<2 | (bit(q, 4)&^bit(q, 0))<<1 | bit(q, 3)&^bit(q, 0)
+		return [3]uint32{4, 4, q2}
+	}
+	if q>>1&3 == 3 {
+		q2 = 4
+		c = q>>3&3<<3 | (^q>>5&3)<<1 | bit(q, 0)
+	} else {
+		q2 = q >> 5 & 3
+		c = q & 0x1F
+	}
+	if c&7 == 5 {
+		q1, q0 = 4, c>>3&3
+	} else {
+		q1, q0 = c>>3&3, c&7
+	}
+	return [3]uint32{q0, q1, q2}
+}
+
+// unquantizeColor maps an ISE color value to 0-255.
+func unquantizeColor(v uint32, q iseRange) uint32 {
+	if !q.trits && !q.quints {
+		return expandBits(v, q.bits)
+	}
+	a := uint32(0)
+	if v&1 != 0 {
+		a = 0x1FF
+	}
+	d := v >> q.bits
+	m := v & (1<<q.bits - 1)
+	var b, c uint32
+	if q.trits {
+		switch q.bits {
+		case 1:
+			c = 204
+		case 2:
+			x := bit(m, 1)
+			b = x<<8 | x<<4 | x<<2 | x<<1
+			c = 93
+		case 3:
+			cb := m >> 1 & 3
+			b = cb<<7 | cb<<2 | cb
+			c = 44
+		case 4:
+			dcb := m >> 1 & 7
+			b = dcb<<6 | dcb
+			c = 22
+		case 5:
+			edcb := m >> 1 & 0xF
+			b = edcb<<5 | edcb>>2
+			c = 11
+		case 6:
+			fedcb := m >> 1 & 0x1F
+			b = fedcb<<4 | fedcb>>4
+			c = 5
+		}
+	} else {
+		switch q.bits {
+		case 1:
+			c = 113
+		case 2:
+			x := bit(m, 1)
+			b = x<<8 | x<<3 | x<<2
+			c = 54
+		case 3:
+			cb := m >> 1 & 3
+			b = cb<<7 | cb<<1 | cb>>1
+			c = 26
+		case 4:
+			dcb := m >> 1 & 7
+			b = dcb<<6 | dcb>>1
+			c = 13
+		case 5:
+			edcb := m >> 1 & 0xF
+			b = edcb<<5 | edcb>>3
+			c = 6
+		}
+	}
+	t := d*c + b
+	t ^= a
+	return a&0x80 | t>>2
+}
+
+// unquantizeWeight maps an ISE weight value to 0-64.
+func unquantizeWeight(v uint32, q iseRange) uint32 {
+	var w uint32
+	switch {
+	case !q.trits && !q.quints:
+		w = v << (6 - q.bits)
+		for shift := q.bits; shift < 6; shift += q.bits {
+			w |= w >> q.bits
+		}
+		w &= 0x3F
+	case q.bits == 0 && q.trits:
+		w = [3]uint32{0, 32, 63}[v]
+	case q.bits == 0 && q.quints:
+		w = [5]uint32{0, 16, 32, 47, 63}[v]
+	default:
+		a := uint32(0)
+		if v&1 != 0 {
+			a = 0x7F
+		}
+		d := v >> q.bits
+		m := v & (1<<q.bits - 1)
+		var b, c uint32
+		switch {
+		case q.trits && q.bits == 1:
+			c = 50
+		case q.quints && q.bits == 1:
+			c = 28
+		case q.trits && q.bits == 2:
+			x := bit(m, 1)
+			b = x<<6 | x<<2 | x
+			c = 23
+		case q.quints && q.bits == 2:
+			x := bit(m, 1)
+			b = x<<6 | x<<1
+			c = 13
+		case q.trits && q.bits == 3:
+			cb := m >> 1 & 3
+			b = cb<<5 | cb
+			c = 11
+		}
+		t := d*c + b
+		t ^= a
+		w = a&0x20 | t>>2
+	}
+	if w > 32 {
+		w++
+	}
+	return w
+}
+
+// astcBlockMode is the decoded 11-bit block mode of a 2D block.
+type astcBlockMode struct {
+	width, height int
+	dualPlane     bool
+	weights       iseRange
+}
+
+func decodeBlockMode(mode uint32) (astcBlockMode, bool) {
+	var bm astcBlockMode
+	r := bit(mode, 4)
+	h := bit(mode, 9)
+	d := bit(mode, 10)
+	a := int(mode >> 5 & 3)
+	if mode&3 != 0 {
+		r |= (mode & 3) << 1
+		b := int(mode >> 7 & 3)
+		switch mode >> 2 & 3 {
+		case 0:
+			bm.width, bm.height = b+4, a+2
+		case 1:
+			bm.width, bm.height = b+8, a+2
+		case 2:
+			bm.width, bm.height = a+2, b+8
+		case 3:
+			b &= 1
+			if mode&0x100 != 0 {
+				bm.width, bm.height = b+2, a+2
+			} else {
+				bm.width, bm.height = a+2, b+6
+			}
+		}
+	} else {
+		r |= (mode >> 2 & 3) << 1
+		if mode>>2&3 == 0 {
+			return bm, false
+		}
+		b := int(mode >> 9 & 3)
+		switch mode >> 7 & 3 {
+		case 0:
+			bm.width, bm.height = 12, a+2
+		case 1:
+			bm.width, bm.height = a+2, 12
+		case 2:
+			bm.width, bm.height = a+6, b+6
+			d, h = 0, 0
+		case 3:
+			switch mode >> 5 & 3 {
+			case 0:
+				bm.width, bm.height = 6, 10
+			case 1:
+				bm.width, bm.height = 10, 6
+			default:
+				return bm, false
+			}
+		}
+	}
+	bm.dualPlane = d != 0
+	bm.weights = iseRanges[int(r)-2+6*int(h)]
+	return bm, true
+}
+
+// astcHash52 is the partition-selection hash from the ASTC specification.
+func astcHash52(p uint32) uint32 {
+	p ^= p >> 15
+	p -= p << 17
+	p += p << 7
+	p += p << 4
+	p ^= p >> 5
+	p += p << 16
+	p ^= p >> 7
+	p ^= p >> 3
+	p ^= p << 6
+	p ^= p >> 17
+	return p
+}
+
+func astcPartition(seed uint32, x, y, partitions int, small bool) int {
+	if small {
+		x, y = x<<1, y<<1
+	}
+	seed += uint32(partitions-1) * 1024
+	rnum := astcHash52(seed)
+	var s [12]uint32
+	for i := 0; i < 8; i++ {
+		s[i] = rnum >> (4 * i) & 0xF
+	}
+	s[8] = rnum >> 18 & 0xF
+	s[9] = rnum >> 22 & 0xF
+	s[10] = rnum >> 26 & 0xF
+	s[11] = (rnum>>30 | rnum<<2) & 0xF
+	for i := range s {
+		s[i] *= s[i]
+	}
+	var sh1, sh2 uint32
+	if seed&1 != 0 {
+		sh1 = 4
+		if seed&2 == 0 {
+			sh1 = 5
+		}
+		sh2 = 5
+		if partitions == 3 {
+			sh2 = 6
+		}
+	} else {
+		sh1 = 5
+		if partitions == 3 {
+			sh1 = 6
+		}
+		sh2 = 4
+		if seed&2 == 0 {
+			sh2 = 5
+		}
+	}
+	sh3 := sh2
+	if seed&0x10 != 0 {
+		sh3 = sh1
+	}
+	for i := 0; i < 8; i += 2 {
+		s[i] >>= sh1
+		s[i+1] >>= sh2
+	}
+	for i := 8; i < 12; i++ {
+		s[i] >>= sh3
+	}
+	ux, uy := uint32(x), uint32(y)
+	// z is always zero for 2D blocks, so seeds 9-12 never contribute.
+	a := (s[0]*ux + s[1]*uy + rnum>>14) & 0x3F
+	b := (s[2]*ux + s[3]*uy + rnum>>10) & 0x3F
+	c := (s[4]*ux + s[5]*uy + rnum>>6) & 0x3F
+	d := (s[6]*ux + s[7]*uy + rnum>>2) & 0x3F
+	if partitions < 4 {
+		d = 0
+	}
+	if partitions < 3 {
+		c = 0
+	}
+	switch {
+	case a >= b && a >= c && a >= d:
+		return 0
+	case b >= c && b >= d:
+		return 1
+	case c >= d:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func clamp255(v int32) uint32 {
+	return uint32(min(max(v, 0), 255))
+}
+
+func blueContract(r, g, b, a int32) [4]uint32 {
+	return [4]uint32{clamp255((r + b) >> 1), clamp255((g + b) >> 1), clamp255(b), clamp255(a)}
+}
+
+// bitTransferSigned moves the top bit of b into a and turns b into a signed
+// six-bit offset.
+func bitTransferSigned(a, b int32) (int32, int32) {
+	a = a>>1 | b&0x80
+	b = b >> 1 & 0x3F
+	if b&0x20 != 0 {
+		b -= 0x40
+	}
+	return a, b
+}
+
+// astcEndpoints decodes the LDR endpoint pair of color endpoint mode cem.
+func astcEndpoints(cem uint32, v []int32) (e0, e1 [4]uint32, ok bool) {
+	rgba := func(r, g, b, a int32) [4]uint32 {
+		return [4]uint32{clamp255(r), clamp255(g), clamp255(b), clamp255(a)}
+	}
+	switch cem {
+	case 0:
+		return rgba(v[0], v[0], v[0], 255), rgba(v[1], v[1], v[1], 255), true
+	case 1:
+		l0 := v[0]>>2 | v[1]&0xC0
+		l1 := min(l0+v[1]&0x3F, 255)
+		return rgba(l0, l0, l0, 255), rgba(l1, l1, l1, 255), true
+	case 4:
+		return rgba(v[0], v[0], v[0], v[2]), rgba(v[1], v[1], v[1], v[3]), true
+	case 5:
+		l, dl := bitTransferSigned(v[0], v[1])
+		a, da := bitTransferSigned(v[2], v[3])
+		return rgba(l, l, l, a), rgba(l+dl, l+dl, l+dl, a+da), true
+	case 6:
+		return rgba(v[0]*v[3]>>8, v[1]*v[3]>>8, v[2]*v[3]>>8, 255), rgba(v[0], v[1], v[2], 255), true
+	case 8, 12:
+		a0, a1 := int32(255), int32(255)
+		if cem == 12 {
+			a0, a1 = v[6], v[7]
+		}
+		if v[1]+v[3]+v[5] >= v[0]+v[2]+v[4] {
+			return rgba(v[0], v[2], v[4], a0), rgba(v[1], v[3], v[5], a1), true
+		}
+		return blueContract(v[1], v[3], v[5], a1), blueContract(v[0], v[2], v[4], a0), true
+	case 9, 13:
+		r, dr := bitTransferSigned(v[0], v[1])
+		g, dg := bitTransferSigned(v[2], v[3])
+		b, db := bitTransferSigned(v[4], v[5])
+		a, da := int32(255), int32(0)
+		if cem == 13 {
+			a, da = bitTransferSigned(v[6], v[7])
+		}
+		if dr+dg+db >= 0 {
+			return rgba(r, g, b, a), rgba(r+dr, g+dg, b+db, a+da), true
+		}
+		return blueContract(r+dr, g+dg, b+db, a+da), blueContract(r, g, b, a), true
+	case 10:
+		return rgba(v[0]*v[3]>>8, v[1]*v[3]>>8, v[2]*v[3]>>8, v[4]), rgba(v[0], v[1], v[2], v[5]), true
+	}
+	return e0, e1, false
+}
+
+func fillASTCError(dst []byte, texels int) {
+	for i := 0; i < texels; i++ {
+		copy(dst[4*i:], astcErrorColor[:])
+	}
+}
+
+// decodeASTC decodes one 16-byte block of a bw x bh footprint into dst.
+// srgb selects the sRGB decode-mode rounding of the specification.
+func decodeASTC(dst, src []byte, bw, bh int, srgb bool) {
+	texels := bw * bh
+	var blk astcBits
+	for i := 0; i < 8; i++ {
+		blk[0] |= uint64(src[i]) << (8 * i)
+		blk[1] |= uint64(src[8+i]) << (8 * i)
+	}
+	mode := blk.read(0, 11)
+
+	if mode&0x1FF == 0x1FC {
+		// Void-extent block: one constant color.
+		if bit(mode, 9) != 0 || blk.read(10, 2) != 3 {
+			fillASTCError(dst, texels)
+			return
+		}
+		var c [4]byte
+		for ch := range c {
+			c[ch] = byte(blk.read(64+16*ch, 16) >> 8)
+		}
+		for i := 0; i < texels; i++ {
+			copy(dst[4*i:], c[:])
+		}
+		return
+	}
+
+	bm, ok := decodeBlockMode(mode)
+	planes := 1
+	if bm.dualPlane {
+		planes = 2
+	}
+	weightCount := bm.width * bm.height * planes
+	partitions := int(blk.read(11, 2)) + 1
+	if !ok || bm.width > bw || bm.height > bh || weightCount > astcMaxWeights ||
+		(bm.dualPlane && partitions == 4) {
+		fillASTCError(dst, texels)
+		return
+	}
+	weightBits := bm.weights.bitCount(weightCount)
+	if weightBits < 24 || weightBits > 96 {
+		fillASTCError(dst, texels)
+		return
+	}
+
+	// Color endpoint modes.
+	var cems [4]uint32
+	colorStart := 17
+	extraCEMBits := 0
+	seed := uint32(0)
+	if partitions == 1 {
+		cems[0] = blk.read(13, 4)
+	} else {
+		seed = blk.read(13, 10)
+		colorStart = 29
+		cemBits := blk.read(23, 6)
+		if cemBits&3 == 0 {
+			for i := 0; i < partitions; i++ {
+				cems[i] = cemBits >> 2
+			}
+		} else {
+			extraCEMBits = 3*partitions - 4
+			cemBits |= blk.read(128-weightBits-extraCEMBits, extraCEMBits) << 6
+			base := cemBits&3 - 1
+			cemBits >>= 2
+			for i := 0; i < partitions; i++ {
+				cems[i] = (base + bit(cemBits, uint(i))) << 2
+				cems[i] |= cemBits >> uint(partitions+2*i) & 3
+			}
+		}
+	}
+	colorValues := 0
+	for i := 0; i < partitions; i++ {
+		colorValues += 2 * int(cems[i]>>2+1)
+	}
+	ccsBits := 0
+	if bm.dualPlane {
+		ccsBits = 2
+	}
+	colorBits := 128 - weightBits - colorStart - extraCEMBits - ccsBits
+	if colorValues > 18 || colorBits < 0 {
+		fillASTCError(dst, texels)
+		return
+	}
+	colorRange := -1
+	for i := len(iseRanges) - 1; i >= astcColorRangeMin; i-- {
+		if iseRanges[i].bitCount(colorValues) <= colorBits {
+			colorRange = i
+			break
+		}
+	}
+	if colorRange < 0 {
+		fillASTCError(dst, texels)
+		return
+	}
+	var raw [18]uint32
+	decodeISE(&blk, colorStart, colorValues, iseRanges[colorRange], raw[:])
+	var endpoints [4][2][4]uint32
+	next := 0
+	for i := 0; i < partitions; i++ {
+		n := 2 * int(cems[i]>>2+1)
+		var v [8]int32
+		for j := 0; j < n; j++ {
+			v[j] = int32(unquantizeColor(raw[next+j], iseRanges[colorRange]))
+		}
+		next += n
+		e0, e1, ok := astcEndpoints(cems[i], v[:])
+		if !ok {
+			fillASTCError(dst, texels)
+			return
+		}
+		endpoints[i] = [2][4]uint32{e0, e1}
+	}
+	ccs := -1
+	if bm.dualPlane {
+		ccs = int(blk.read(128-weightBits-extraCEMBits-2, 2))
+	}
+
+	// Weights are stored bit-reversed from the top of the block.
+	rev := blk.reversed()
+	var weights [astcMaxWeights]uint32
+	decodeISE(&rev, 0, weightCount, bm.weights, weights[:])
+	for i := 0; i < weightCount; i++ {
+		weights[i] = unquantizeWeight(weights[i], bm.weights)
+	}
+
+	ds := (1024 + bw/2) / (bw - 1)
+	dt := (1024 + bh/2) / (bh - 1)
+	small := texels < 31
+	for y := 0; y < bh; y++ {
+		for x := 0; x < bw; x++ {
+			gs := (ds*x*(bm.width-1) + 32) >> 6
+			gt := (dt*y*(bm.height-1) + 32) >> 6
+			js, fs := gs>>4, gs&0xF
+			jt, ft := gt>>4, gt&0xF
+			w11 := (fs*ft + 8) >> 4
+			w10 := ft - w11
+			w01 := fs - w11
+			w00 := 16 - fs - ft + w11
+			v0 := js + jt*bm.width
+
+			var plane [2]uint32
+			for p := 0; p < planes; p++ {
+				at := func(i int) int {
+					if i >= bm.width*bm.height {
+						return 0
+					}
+					return int(weights[i*planes+p])
+				}
+				sum := at(v0) * w00
+				if w01 > 0 {
+					sum += at(v0+1) * w01
+				}
+				if w10 > 0 {
+					sum += at(v0+bm.width) * w10
+				}
+				if w11 > 0 {
+					sum += at(v0+bm.width+1) * w11
+				}
+				plane[p] = uint32((sum + 8) >> 4)
+			}
+
+			part := 0
+			if partitions > 1 {
+				part = astcPartition(seed, x, y, partitions, small)
+			}
+			e := &endpoints[part]
+			px := dst[4*(y*bw+x):]
+			for ch := 0; ch < 4; ch++ {
+				w := plane[0]
+				if ch == ccs {
+					w = plane[1]
+				}
+				c0, c1 := e[0][ch]<<8|e[0][ch], e[1][ch]<<8|e[1][ch]
+				if srgb {
+					c0, c1 = e[0][ch]<<8|0x80, e[1][ch]<<8|0x80
+				}
+				px[ch] = byte((c0*(64-w) + c1*w + 32) >> 6 >> 8)
+			}
+		}
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import "encoding/binary"
+
+// BC1-BC5 and BC7 block decoders. Each writes a 4x4 block of RGBA texels,
+// row-major, into dst[:64].
+
+func decodeBC1(dst, src []byte) {
+	decodeBCColor(dst, src, true)
+}
+
+func decodeBC2(dst, src []byte) {
+	decodeBCColor(dst, src[8:], false)
+	alpha := binary.LittleEndian.Uint64(src)
+	for i := 0; i < 16; i++ {
+		dst[4*i+3] = uint8(alpha>>(4*i)&0xF) * 17
+	}
+}
+
+func decodeBC3(dst, src []byte) {
+	decodeBCColor(dst, src[8:], false)
+	var alpha [16]byte
+	decodeBCChannel(alpha[:], src)
+	for i, a := range alpha {
+		dst[4*i+3] = a
+	}
+}
+
+func decodeBC4(dst, src []byte, signed bool) {
+	var r [16]byte
+	one := byte(255)
+	if signed {
+		decodeBCChannelSigned(r[:], src)
+		one = 127
+	} else {
+		decodeBCChannel(r[:], src)
+	}
+	for i := range r {
+		dst[4*i], dst[4*i+1], dst[4*i+2], dst[4*i+3] = r[i], 0, 0, one
+	}
+}
+
+func decodeBC5(dst, src []byte, signed bool) {
+	var r, g [16]byte
+	one := byte(255)
+	if signed {
+		decodeBCChannelSigned(r[:], src)
+		decodeBCChannelSigned(g[:], src[8:])
+		one = 127
+	} else {
+		decodeBCChannel(r[:], src)
+		decodeBCChannel(g[:], src[8:])
+	}
+	for i := range r {
+		dst[4*i], dst[4*i+1], dst[4*i+2], dst[4*i+3] = r[i], g[i], 0, one
+	}
+}
+
+// decodeBCColor decodes the 8-byte BC1 color block. BC2 and BC3 always use
+// four-color mode; only BC1 switches to three colors plus transparent black
+// when c0 <= c1.
+func decodeBCColor(dst, src []byte, bc1 bool) {
+	c0 := binary.LittleEndian.Uint16(src)
+	c1 := binary.LittleEndian.Uint16(src[2:])
+	var palette [4][4]byte
+	palette[0] = expand565(c0)
+	palette[1] = expand565(c1)
+	for ch := 0; ch < 3; ch++ {
+		a, b := uint32(palette[0][ch]), uint32(palette[1][ch])
+		if c0 > c1 || !bc1 {
+			palette[2][ch] = uint8((2*a + b + 1) / 3)
+			palette[3][ch] = uint8((a + 2*b + 1) / 3)
+		} else {
+			palette[2][ch] = uint8((a + b) / 2)
+		}
+	}
+	palette[2][3] = 255
+	if c0 > c1 || !bc1 {
+		palette[3][3] = 255
+	}
+	indices := binary.LittleEndian.Uint32(src[4:])
+	for i := 0; i < 16; i++ {
+		copy(dst[4*i:4*i+4], palette[indices>>(2*i)&3][:])
+	}
+}
+
+func expand565(c uint16) [4]byte {
+	r, g, b := byte(c>>11), byte(c>>5&0x3F), byte(c&0x1F)
+	return [4]byte{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+}
+
+// decodeBCChannel decodes an 8-byte BC4-style unsigned channel block.
+func decodeBCChannel(dst, src []byte) {
+	var palette [8]byte
+	a, b := uint32(src[0]), uint32(src[1])
+	palette[0], palette[1] = src[0], src[1]
+	if a > b {
+		for i := uint32(1); i < 7; i++ {
+			palette[i+1] = uint8(((7-i)*a + i*b + 3) / 7)
+		}
+	} else {
+		for i := uint32(1); i < 5; i++ {
+			palette[i+1] = uint8(((5-i)*a + i*b + 2) / 5)
+		}
+		palette[6], palette[7] = 0, 255
+	}
+	bits := binary.LittleEndian.Uint64(src) >> 16
+	for i := 0; i < 16; i++ {
+		dst[i] = palette[bits>>(3*i)&7]
+	}
+}
+
+// decodeBCChannelSigned decodes a signed BC4 channel block into two's
+// complement bytes, as stored by the Snorm formats.
+func decodeBCChannelSigned(dst, src []byte) {
+	var palette [8]int32
+	a, b := int32(int8(src[0])), int32(int8(src[1]))
+	a, b = max(a, -127), max(b, -127)
+	palette[0], palette[1] = a, b
+	if a > b {
+		for i := int32(1); i < 7; i++ {
+			palette[i+1] = ((7-i)*a + i*b) / 7
+		}
+	} else {
+		for i := int32(1); i < 5; i++ {
+			palette[i+1] = ((5-i)*a + i*b) / 5
+		}
+		palette[6], palette[7] = -127, 127
+	}
+	bits := binary.LittleEndian.Uint64(src) >> 16
+	for i := 0; i < 16; i++ {
+		dst[i] = byte(int8(palette[bits>>(3*i)&7]))
+	}
+}
+
+// bc7Mode describes one of the eight BC7 block encodings.
+type bc7Mode struct {
+	subsets        int
+	partitionBits  uint
+	rotationBits   uint
+	indexSelection uint
+	colorBits      uint
+	alphaBits      uint
+	endpointPBits  bool
+	sharedPBits    bool
+	indexBits      uint
+	index2Bits     uint
+}
+
+var bc7Modes = [8]bc7Mode{
+	{subsets: 3, partitionBits: 4, colorBits: 4, endpointPBits: true, indexBits: 3},
+	{subsets: 2, partitionBits: 6, colorBits: 6, sharedPBits: true, indexBits: 3},
+	{subsets: 3, partitionBits: 6, colorBits: 5, indexBits: 2},
+	{subsets: 2, partitionBits: 6, colorBits: 7, endpointPBits: true, indexBits: 2},
+	{subsets: 1, rotationBits: 2, indexSelection: 1, colorBits: 5, alphaBits: 6, indexBits: 2, index2Bits: 3},
+	{subsets: 1, rotationBits: 2, colorBits: 7, alphaBits: 8, indexBits: 2, index2Bits: 2},
+	{subsets: 1, colorBits: 7, alphaBits: 7, endpointPBits: true, indexBits: 4},
+	{subsets: 2, partitionBits: 6, colorBits: 5, alphaBits: 5, endpointPBits: true, indexBits: 2},
+}
+
+var bc7Weights = [5][]uint32{
+	2: {0, 21, 43, 64},
+	3: {0, 9, 18, 27, 37, 46, 55, 64},
+	4: {0, 4, 9, 13, 17, 21, 26, 30, 34, 38, 43, 47, 51, 55, 60, 64},
+}
+
+// bc7Partitions2 holds the two-subset shapes; bit i selects texel i's subset.
+var bc7Partitions2 = [64]uint16{
+	0xCCCC, 0x8888, 0xEEEE, 0xECC8, 0xC880, 0xFEEC, 0xFEC8, 0xEC80,
+	0xC800, 0xFFEC, 0xFE80, 0xE800, 0xFFE8, 0xFF00, 0xFFF0, 0xF000,
+	0xF710, 0x008E, 0x7100, 0x08CE, 0x008C, 0x7310, 0x3100, 0x8CCE,
+	0x088C, 0x3110, 0x6666, 0x366C, 0x17E8, 0x0FF0, 0x718E, 0x399C,
+	0xAAAA, 0xF0F0, 0x5A5A, 0x33CC, 0x3C3C, 0x55AA, 0x9696, 0xA55A,
+	0x73CE, 0x13C8, 0x324C, 0x3BDC, 0x6996, 0xC33C, 0x9966, 0x0660,
+	0x0272, 0x04E4, 0x4E40, 0x2720, 0xC936, 0x936C, 0x39C6, 0x639C,
+	0x9336, 0x9CC6, 0x817E, 0xE718, 0xCCF0, 0x0FCC, 0x7744, 0xEE22,
+}
+
+// bc7Partitions3 holds the three-subset shapes, one subset per texel.
+var bc7Partitions3 = [64][16]uint8{
+	{0, 0, 1, 1, 0, 0, 1, 1, 0, 2, 2, 1, 2, 2, 2, 2},
+	{0, 0, 0, 1, 0, 0, 1, 1, 2, 2, 1, 1, 2, 2, 2, 1},
+	{0, 0, 0, 0, 2, 0, 0, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+	{0, 2, 2, 2, 0, 0, 2, 2, 0, 0, 1, 1, 0, 1, 1, 1},
+	{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 2, 1, 1, 2, 2},
+	{0, 0, 1, 1, 0, 0, 1, 1, 0, 0, 2, 2, 0, 0, 2, 2},
+	{0, 0, 2, 2, 0, 0, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1},
+	{0, 0, 1, 1, 0, 0, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+	{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2},
+	{0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2},
+	{0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+	{0, 0, 1, 2, 0, 0, 1, 2, 0, 0, 1, 2, 0, 0, 1, 2},
+	{0, 1, 1, 2, 0, 1, 1, 2, 0, 1, 1, 2, 0, 1, 1, 2},
+	{0, 1, 2, 2, 0, 1, 2, 2, 0, 1, 2, 2, 0, 1, 2, 2},
+	{0, 0, 1, 1, 0, 1, 1, 2, 1, 1, 2, 2, 1, 2, 2, 2},
+	{0, 0, 1, 1, 2, 0, 0, 1, 2, 2, 0, 0, 2, 2, 2, 0},
+	{0, 0, 0, 1, 0, 0, 1, 1, 0, 1, 1, 2, 1, 1, 2, 2},
+	{0, 1, 1, 1, 0, 0, 1, 1, 2, 0, 0, 1, 2, 2, 0, 0},
+	{0, 0, 0, 0, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2},
+	{0, 0, 2, 2, 0, 0, 2, 2, 0, 0, 2, 2, 1, 1, 1, 1},
+	{0, 1, 1, 1, 0, 1, 1, 1, 0, 2, 2, 2, 0, 2, 2, 2},
+	{0, 0, 0, 1, 0, 0, 0, 1, 2, 2, 2, 1, 2, 2, 2, 1},
+	{0, 0, 0, 0, 0, 0, 1, 1, 0, 1, 2, 2, 0, 1, 2, 2},
+	{0, 0, 0, 0, 1, 1, 0, 0, 2, 2, 1, 0, 2, 2, 1, 0},
+	{0, 1, 2, 2, 0, 1, 2, 2, 0, 0, 1, 1, 0, 0, 0, 0},
+	{0, 0, 1, 2, 0, 0, 1, 2, 1, 1, 2, 2, 2, 2, 2, 2},
+	{0, 1, 1, 0, 1, 2, 2, 1, 1, 2, 2, 1, 0, 1, 1, 0},
+	{0, 0, 0, 0, 0, 1, 1, 0, 1, 2, 2, 1, 1, 2, 2, 1},
+	{0, 0, 2, 2, 1, 1, 0, 2, 1, 1, 0, 2, 0, 0, 2, 2},
+	{0, 1, 1, 0, 0, 1, 1, 0, 2, 0, 0, 2, 2, 2, 2, 2},
+	{0, 0, 1, 1, 0, 1, 2, 2, 0, 1, 2, 2, 0, 0, 1, 1},
+	{0, 0, 0, 0, 2, 0, 0, 0, 2, 2, 1, 1, 2, 2, 2, 1},
+	{0, 0, 0, 0, 0, 0, 0, 2, 1, 1, 2, 2, 1, 2, 2, 2},
+	{0, 2, 2, 2, 0, 0, 2, 2, 0, 0, 1, 2, 0, 0, 1, 1},
+	{0, 0, 1, 1, 0, 0, 1, 2, 0, 0, 2, 2, 0, 2, 2, 2},
+	{0, 1, 2, 0, 0, 1, 2, 0, 0, 1, 2, 0, 0, 1, 2, 0},
+	{0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 0, 0, 0, 0},
+	{0, 1, 2, 0, 1, 2, 0, 1, 2, 0, 1, 2, 0, 1, 2, 0},
+	{0, 1, 2, 0, 2, 0, 1, 2, 1, 2, 0, 1, 0, 1, 2, 0},
+	{0, 0, 1, 1, 2, 2, 0, 0, 1, 1, 2, 2, 0, 0, 1, 1},
+	{0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 0, 0, 0, 0, 1, 1},
+	{0, 1, 0, 1, 0, 1, 0, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+	{0, 0, 0, 0, 0, 0, 0, 0, 2, 1, 2, 1, 2, 1, 2, 1},
+	{0, 0, 2, 2, 1, 1, 2, 2, 0, 0, 2, 2, 1, 1, 2, 2},
+	{0, 0, 2, 2, 0, 0, 1, 1, 0, 0, 2, 2, 0, 0, 1, 1},
+	{0, 2, 2, 0, 1, 2, 2, 1, 0, 2, 2, 0, 1, 2, 2, 1},
+	{0, 1, 0, 1, 2, 2, 2, 2, 2, 2, 2, 2, 0, 1, 0, 1},
+	{0, 0, 0, 0, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+	{0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 2, 2, 2, 2},
+	{0, 2, 2, 2, 0, 1, 1, 1, 0, 2, 2, 2, 0, 1, 1, 1},
+	{0, 0, 0, 2, 1, 1, 1, 2, 0, 0, 0, 2, 1, 1, 1, 2},
+	{0, 0, 0, 0, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2},
+	{0, 2, 2, 2, 0, 1, 1, 1, 0, 1, 1, 1, 0, 2, 2, 2},
+	{0, 0, 0, 2, 1, 1, 1, 2, 1, 1, 1, 2, 0, 0, 0, 2},
+	{0, 1, 1, 0, 0, 1, 1, 0, 0, 1, 1, 0, 2, 2, 2, 2},
+	{0, 0, 0, 0, 0, 0, 0, 0, 2, 1, 1, 2, 2, 1, 1, 2},
+	{0, 1, 1, 0, 0, 1, 1, 0, 2, 2, 2, 2, 2, 2, 2, 2},
+	{0, 0, 2, 2, 0, 0, 1, 1, 0, 0, 1, 1, 0, 0, 2, 2},
+	{0, 0, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 0, 0, 2, 2},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 1, 1, 2},
+	{0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 1},
+	{0, 2, 2, 2, 1, 2, 2, 2, 0, 2, 2, 2, 1, 2, 2, 2},
+	{0, 1, 0, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+	{0, 1, 1, 1, 2, 0, 1, 1, 2, 2, 0, 1, 2, 2, 2, 0},
+}
+
+// Anchor texels whose index omits its top bit: subset 1 of the two-subset
+// shapes, and subsets 1 and 2 of the three-subset shapes.
+var (
+	bc7Anchors2 = [64]uint8{
+		15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15,
+		15, 2, 8, 2, 2, 8, 8, 15, 2, 8, 2, 2, 8, 8, 2, 2,
+		15, 15, 6, 8, 2, 8, 15, 15, 2, 8, 2, 2, 2, 15, 15, 6,
+		6, 2, 6, 8, 15, 15, 2, 2, 15, 15, 15, 15, 15, 2, 2, 15,
+	}
+	bc7Anchors3a = [64]uint8{
+		3, 3, 15, 15, 8, 3, 15, 15, 8, 8, 6, 6, 6, 5, 3, 3,
+		3, 3, 8, 15, 3, 3, 6, 10, 5, 8, 8, 6, 8, 5, 15, 15,
+		8, 15, 3, 5, 6, 10, 8, 15, 15, 3, 15, 5, 15, 15, 15, 15,
+		3, 15, 5, 5, 5, 8, 5, 10, 5, 10, 8, 13, 15, 12, 3, 3,
+	}
+	bc7Anchors3b = [64]uint8{
+		15, 8, 8, 3, 15, 15, 3, 8, 15, 15, 15, 15, 15, 15, 15, 8,
+		15, 8, 15, 3, 15, 8, 15, 8, 3, 15, 6, 10, 15, 15, 10, 8,
+		15, 3, 15, 10, 10, 8, 9, 10, 6, 15, 8, 15, 3, 6, 6, 8,
+		15, 3, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 3, 15, 15, 8,
+	}
+)
+
+// bitReader reads little-endian bit fields from a 128-bit block.
+type bitReader struct {
+	lo, hi uint64
+	pos    uint
+}
+
+func newBitReader(src []byte) bitReader {
+	return bitReader{lo: binary.LittleEndian.Uint64(src), hi: binary.LittleEndian.Uint64(src[8:])}
+}
+
+// bits returns the next n (at most 32) bits.
+func (r *bitReader) bits(n uint) uint32 {
+	if n == 0 {
+		return 0
+	}
+	var v uint64
+	switch {
+	case r.pos >= 64:
+		v = r.hi >> (r.pos - 64)
+	case r.pos+n <= 64:
+		v = r.lo >> r.pos
+	default:
+		v = r.lo>>r.pos | r.hi<<(64-r.pos)
+	}
+	r.pos += n
+	return uint32(v & (1<<n - 1))
+}
+
+func bc7Subset(mode *bc7Mode, partition uint32, texel int) int {
+	switch mode.subsets {
+	case 2:
+		return int(bc7Partitions2[partition] >> texel & 1)
+	case 3:
+		return int(bc7Partitions3[partition][texel])
+	}
+	return 0
+}
+
+func bc7IsAnchor(mode *bc7Mode, partition uint32, texel int) bool {
+	if texel == 0 {
+		return true
+	}
+	switch mode.subsets {
+	case 2:
+		return texel == int(bc7Anchors2[partition])
+	case 3:
+		return texel == int(bc7Anchors3a[partition]) || texel == int(bc7Anchors3b[partition])
+	}
+	return false
+}
+
+// decodeBC7 decodes a BC7 block. Reserved mode 8 decodes to transparent
+// black, as the format requires.
+func decodeBC7(dst, src []byte) {
+	modeIndex := 0
+	for modeIndex < 8 && src[0]&(1<<modeIndex) == 0 {
+		modeIndex++
+	}
+	if modeIndex == 8 {
+		clear(dst[:64])
+		return
+	}
+	mode := &bc7Modes[modeIndex]
+	r := newBitReader(src)
+	r.pos = uint(modeIndex + 1)
+	partition := r.bits(mode.partitionBits)
+	rotation := r.bits(mode.rotationBits)
+	indexSelection := r.bits(mode.indexSelection)
+
+	// Endpoints are stored channel by channel, then alpha, then P-bits.
+	var endpoints [6][4]uint32
+	n := 2 * mode.subsets
+	for ch := 0; ch < 3; ch++ {
+		for e := 0; e < n; e++ {
+			endpoints[e][ch] = r.bits(mode.colorBits)
+		}
+	}
+	for e := 0; e < n; e++ {
+		endpoints[e][3] = r.bits(mode.alphaBits)
+	}
+	colorBits, alphaBits := mode.colorBits, mode.alphaBits
+	switch {
+	case mode.endpointPBits:
+		for e := 0; e < n; e++ {
+			p := r.bits(1)
+			for ch := 0; ch < 4; ch++ {
+				endpoints[e][ch] = endpoints[e][ch]<<1 | p
+			}
+		}
+		colorBits++
+		if alphaBits > 0 {
+			alphaBits++
+		}
+	case mode.sharedPBits:
+		for s := 0; s < mode.subsets; s++ {
+			p := r.bits(1)
+			for ch := 0; ch < 4; ch++ {
+				endpoints[2*s][ch] = endpoints[2*s][ch]<<1 | p
+				endpoints[2*s+1][ch] = endpoints[2*s+1][ch]<<1 | p
+			}
+		}
+		colorBits++
+	}
+	for e := 0; e < n; e++ {
+		for ch := 0; ch < 3; ch++ {
+			endpoints[e][ch] = expandBits(endpoints[e][ch], colorBits)
+		}
+		if alphaBits > 0 {
+			endpoints[e][3] = expandBits(endpoints[e][3], alphaBits)
+		} else {
+			endpoints[e][3] = 255
+		}
+	}
+
+	var indices, indices2 [16]uint32
+	for i := 0; i < 16; i++ {
+		bits := mode.indexBits
+		if bc7IsAnchor(mode, partition, i) {
+			bits--
+		}
+		indices[i] = r.bits(bits)
+	}
+	if mode.index2Bits > 0 {
+		for i := 0; i < 16; i++ {
+			bits := mode.index2Bits
+			if i == 0 {
+				bits--
+			}
+			indices2[i] = r.bits(bits)
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		s := bc7Subset(mode, partition, i)
+		e0, e1 := &endpoints[2*s], &endpoints[2*s+1]
+		colorWeight := bc7Weights[mode.indexBits][indices[i]]
+		alphaWeight := colorWeight
+		if mode.index2Bits > 0 {
+			alphaWeight = bc7Weights[mode.index2Bits][indices2[i]]
+			if indexSelection == 1 {
+				colorWeight, alphaWeight = alphaWeight, colorWeight
+			}
+		}
+		px := dst[4*i : 4*i+4]
+		for ch := 0; ch < 3; ch++ {
+			px[ch] = uint8(((64-colorWeight)*e0[ch] + colorWeight*e1[ch] + 32) >> 6)
+		}
+		px[3] = uint8(((64-alphaWeight)*e0[3] + alphaWeight*e1[3] + 32) >> 6)
+		if rotation > 0 {
+			px[3], px[rotation-1] = px[rotation-1], px[3]
+		}
+	}
+}
+
+// expandBits widens an n-bit value to 8 bits by replicating its high bits.
+func expandBits(v uint32, n uint) uint32 {
+	v <<= 8 - n
+	for shift := n; shift < 8; shift += n {
+		v |= v >> n
+	}
+	return v & 0xFF
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// DDS header constants (Microsoft DDS programming guide).
+const (
+	ddsMagic          = "DDS "
+	ddsHeaderSize     = 124
+	ddsDX10HeaderSize = 20
+
+	ddsPixelFormatAlphaPixels = 0x1
+	ddsPixelFormatFourCC      = 0x4
+	ddsPixelFormatRGB         = 0x40
+	ddsPixelFormatLuminance   = 0x20000
+
+	ddsCaps2Cubemap = 0x200
+	ddsCaps2Volume  = 0x200000
+
+	dx10ResourceDimensionTexture3D = 4
+	dx10MiscTextureCube            = 0x4
+)
+
+var errInvalidDDS = errors.New("texutil: invalid DDS file")
+
+// DecodeDDS decodes a DirectDraw Surface file, including the DX10 extended
+// header. 2D textures, texture arrays, cube maps, and volume textures are
+// supported, as are the legacy DXT1-5/ATI1/ATI2 FourCCs and 32-bit
+// uncompressed RGBA/BGRA masks.
+func DecodeDDS(r io.Reader) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("texutil: read dds: %w", err)
+	}
+	if len(data) < 4+ddsHeaderSize || string(data[:4]) != ddsMagic {
+		return nil, fmt.Errorf("%w: missing DDS header", errInvalidDDS)
+	}
+	header := data[4 : 4+ddsHeaderSize]
+	u32 := func(b []byte, off int) uint32 { return binary.LittleEndian.Uint32(b[off:]) }
+	if u32(header, 0) != ddsHeaderSize {
+		return nil, fmt.Errorf("%w: header size %d", errInvalidDDS, u32(header, 0))
+	}
+
+	img := &Image{
+		Dimension:          wgpu.TextureDimension2D,
+		Height:             u32(header, 8),
+		Width:              u32(header, 12),
+		DepthOrArrayLayers: 1,
+	}
+	depth := max(1, u32(header, 20))
+	mipCount := max(1, u32(header, 24))
+	caps2 := u32(header, 108)
+	pfFlags := u32(header, 76)
+	fourCC := string(header[80:84])
+	payload := data[4+ddsHeaderSize:]
+
+	layers := uint32(1)
+	switch {
+	case pfFlags&ddsPixelFormatFourCC != 0 && fourCC == "DX10":
+		if len(payload) < ddsDX10HeaderSize {
+			return nil, fmt.Errorf("%w: truncated DX10 header", errInvalidDDS)
+		}
+		dxgiFormat := u32(payload, 0)
+		format, ok := dxgiFormats[dxgiFormat]
+		if !ok {
+			return nil, fmt.Errorf("%w: DXGI format %d", ErrUnsupportedFormat, dxgiFormat)
+		}
+		img.Format = format
+		layers = max(1, u32(payload, 12))
+		if u32(payload, 8)&dx10MiscTextureCube != 0 {
+			if layers > math.MaxUint32/6 {
+				return nil, fmt.Errorf("%w: %d cube layers", errInvalidDDS, layers)
+			}
+			img.Cube = true
+			layers *= 6
+		}
+		if u32(payload, 4) == dx10ResourceDimensionTexture3D {
+			img.Dimension = wgpu.TextureDimension3D
+		}
+		payload = payload[ddsDX10HeaderSize:]
+	case pfFlags&ddsPixelFormatFourCC != 0:
+		format, ok := ddsFourCCFormat(fourCC)
+		if !ok {
+			return nil, fmt.Errorf("%w: DDS FourCC %q", ErrUnsupportedFormat, fourCC)
+		}
+		img.Format = format
+	case pfFlags&(ddsPixelFormatRGB|ddsPixelFormatLuminance) != 0:
+		format, ok := ddsMaskFormat(pfFlags, u32(header, 84), u32(header, 88), u32(header, 92), u32(header, 96), u32(header, 100))
+		if !ok {
+			return nil, fmt.Errorf("%w: DDS bit masks", ErrUnsupportedFormat)
+		}
+		img.Format = format
+	default:
+		return nil, fmt.Errorf("%w: DDS pixel format flags %#x", ErrUnsupportedFormat, pfFlags)
+	}
+
+	if img.Dimension != wgpu.TextureDimension3D {
+		if caps2&ddsCaps2Volume != 0 {
+			img.Dimension = wgpu.TextureDimension3D
+		} else if caps2&ddsCaps2Cubemap != 0 && !img.Cube {
+			// Legacy cube maps always store all six faces.
+			img.Cube = true
+			layers *= 6
+		}
+	}
+	if img.Dimension == wgpu.TextureDimension3D {
+		img.DepthOrArrayLayers = depth
+	} else {
+		img.DepthOrArrayLayers = layers
+	}
+	if img.Width == 0 || img.Height == 0 {
+		return nil, fmt.Errorf("%w: image size %dx%d", errInvalidDDS, img.Width, img.Height)
+	}
+	if maxLevels := mipLevelCount(img.Width, img.Height); mipCount > maxLevels {
+		return nil, fmt.Errorf("%w: %d mip levels exceed the %d allowed for %dx%d",
+			errInvalidDDS, mipCount, maxLevels, img.Width, img.Height)
+	}
+	// Check the header's claimed size against the payload before allocating
+	// anything: a forged header can describe terabytes of pixel data.
+	if total, ok := ddsDataSize(img, mipCount); !ok || total > uint64(len(payload)) {
+		return nil, fmt.Errorf("%w: truncated pixel data", errInvalidDDS)
+	}
+
+	// DDS stores each array layer's full mip chain before the next layer;
+	// Image wants each mip level's layers together. 3D images are a single
+	// "layer" whose mip levels each hold all of their slices.
+	img.Levels = make([][]byte, mipCount)
+	chainLayers := layers
+	if img.Dimension == wgpu.TextureDimension3D {
+		chainLayers = 1
+	}
+	for level := range img.Levels {
+		_, _, size, err := img.levelLayout(level)
+		if err != nil {
+			return nil, err
+		}
+		img.Levels[level] = make([]byte, 0, size)
+	}
+	offset := uint64(0)
+	for range chainLayers {
+		for level := range img.Levels {
+			_, _, size, _ := img.levelLayout(level)
+			layerSize := size / uint64(chainLayers)
+			img.Levels[level] = append(img.Levels[level], payload[offset:offset+layerSize]...)
+			offset += layerSize
+		}
+	}
+	return img, img.Validate()
+}
+
+// ddsDataSize returns the bytes of pixel data mipCount levels of img hold, or
+// false when the size does not fit in a uint64.
+func ddsDataSize(img *Image, mipCount uint32) (uint64, bool) {
+	info, ok := blockInfoFor(img.Format)
+	if !ok {
+		return 0, false
+	}
+	var total uint64
+	for level := range int(mipCount) {
+		size := img.LevelSize(level)
+		blocksWide := (uint64(size.Width) + uint64(info.width) - 1) / uint64(info.width)
+		blocksHigh := (uint64(size.Height) + uint64(info.height) - 1) / uint64(info.height)
+		hi, layerBytes := bits.Mul64(blocksWide*uint64(info.bytes), blocksHigh)
+		if hi != 0 {
+			return 0, false
+		}
+		hi, levelBytes := bits.Mul64(layerBytes, uint64(size.DepthOrArrayLayers))
+		if hi != 0 {
+			return 0, false
+		}
+		var carry uint64
+		if total, carry = bits.Add64(total, levelBytes, 0); carry != 0 {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+func ddsFourCCFormat(fourCC string) (gputypes.TextureFormat, bool) {
+	switch fourCC {
+	case "DXT1":
+		return gputypes.TextureFormatBC1RGBAUnorm, true
+	case "DXT2", "DXT3":
+		return gputypes.TextureFormatBC2RGBAUnorm, true
+	case "DXT4", "DXT5":
+		return gputypes.TextureFormatBC3RGBAUnorm, true
+	case "ATI1", "BC4U":
+		return gputypes.TextureFormatBC4RUnorm, true
+	case "BC4S":
+		return gputypes.TextureFormatBC4RSnorm, true
+	case "ATI2", "BC5U":
+		return gputypes.TextureFormatBC5RGUnorm, true
+	case "BC5S":
+		return gputypes.TextureFormatBC5RGSnorm, true
+	}
+	return gputypes.TextureFormatUndefined, false
+}
+
+func ddsMaskFormat(flags, bitCount, r, g, b, a uint32) (gputypes.TextureFormat, bool) {
+	if flags&ddsPixelFormatAlphaPixels == 0 {
+		a = 0
+	}
+	switch {
+	case bitCount == 32 && r == 0x000000ff && g == 0x0000ff00 && b == 0x00ff0000:
+		return gputypes.TextureFormatRGBA8Unorm, true
+	case bitCount == 32 && r == 0x00ff0000 && g == 0x0000ff00 && b == 0x000000ff:
+		return gputypes.TextureFormatBGRA8Unorm, true
+	case bitCount == 8 && r == 0xff && a == 0:
+		return gputypes.TextureFormatR8Unorm, true
+	case bitCount == 16 && r == 0xffff && a == 0:
+		return gputypes.TextureFormatR16Unorm, true
+	}
+	return gputypes.TextureFormatUndefined, false
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// blockDecoder decodes one compressed block into width*height RGBA texels.
+type blockDecoder func(dst, src []byte)
+
+// decompressionFor returns the block decoder for a compressed format and the
+// uncompressed format it produces.
+func decompressionFor(format gputypes.TextureFormat) (blockDecoder, gputypes.TextureFormat, bool) {
+	rgba := func(srgb bool) gputypes.TextureFormat {
+		if srgb {
+			return gputypes.TextureFormatRGBA8UnormSrgb
+		}
+		return gputypes.TextureFormatRGBA8Unorm
+	}
+	switch format {
+	case gputypes.TextureFormatBC1RGBAUnorm, gputypes.TextureFormatBC1RGBAUnormSrgb:
+		return decodeBC1, rgba(format == gputypes.TextureFormatBC1RGBAUnormSrgb), true
+	case gputypes.TextureFormatBC2RGBAUnorm, gputypes.TextureFormatBC2RGBAUnormSrgb:
+		return decodeBC2, rgba(format == gputypes.TextureFormatBC2RGBAUnormSrgb), true
+	case gputypes.TextureFormatBC3RGBAUnorm, gputypes.TextureFormatBC3RGBAUnormSrgb:
+		return decodeBC3, rgba(format == gputypes.TextureFormatBC3RGBAUnormSrgb), true
+	case gputypes.TextureFormatBC4RUnorm:
+		return func(dst, src []byte) { decodeBC4(dst, src, false) }, gputypes.TextureFormatRGBA8Unorm, true
+	case gputypes.TextureFormatBC4RSnorm:
+		return func(dst, src []byte) { decodeBC4(dst, src, true) }, gputypes.TextureFormatRGBA8Snorm, true
+	case gputypes.TextureFormatBC5RGUnorm:
+		return func(dst, src []byte) { decodeBC5(dst, src, false) }, gputypes.TextureFormatRGBA8Unorm, true
+	case gputypes.TextureFormatBC5RGSnorm:
+		return func(dst, src []byte) { decodeBC5(dst, src, true) }, gputypes.TextureFormatRGBA8Snorm, true
+	case gputypes.TextureFormatBC7RGBAUnorm, gputypes.TextureFormatBC7RGBAUnormSrgb:
+		return decodeBC7, rgba(format == gputypes.TextureFormatBC7RGBAUnormSrgb), true
+	}
+	if format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb {
+		info, _ := blockInfoFor(format)
+		srgb := (format-gputypes.TextureFormatASTC4x4Unorm)%2 == 1
+		w, h := int(info.width), int(info.height)
+		return func(dst, src []byte) { decodeASTC(dst, src, w, h, srgb) }, rgba(srgb), true
+	}
+	return nil, 0, false
+}
+
+// compressionFeature returns the device feature a block-compressed format
+// requires, or 0 for formats every device supports.
+func compressionFeature(format gputypes.TextureFormat) gputypes.Feature {
+	switch {
+	case format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatBC7RGBAUnormSrgb:
+		return gputypes.FeatureTextureCompressionBC
+	case format >= gputypes.TextureFormatETC2RGB8Unorm && format <= gputypes.TextureFormatEACRG11Snorm:
+		return gputypes.FeatureTextureCompressionETC2
+	case format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb:
+		return gputypes.FeatureTextureCompressionASTC
+	}
+	return 0
+}
+
+// Decompress returns a copy of img with every mip level expanded to 8-bit
+// RGBA on the CPU. BC1-BC3, BC7, and ASTC produce RGBA8Unorm (RGBA8UnormSrgb
+// for sRGB formats); BC4 and BC5 fill the red and green channels of
+// RGBA8Unorm or RGBA8Snorm. ASTC blocks using HDR endpoints decode to
+// magenta. BC6H, ETC2, and EAC are not supported and fail with
+// [ErrUnsupportedFormat].
+func Decompress(img *Image) (*Image, error) {
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	decode, format, ok := decompressionFor(img.Format)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot decompress %v", ErrUnsupportedFormat, img.Format)
+	}
+	info, _ := blockInfoFor(img.Format)
+	bw, bh := int(info.width), int(info.height)
+	block := make([]byte, 4*bw*bh)
+
+	out := *img
+	out.Format = format
+	out.Levels = make([][]byte, len(img.Levels))
+	for level, data := range img.Levels {
+		size := img.LevelSize(level)
+		width, height := int(size.Width), int(size.Height)
+		blocksX, blocksY := int(ceilDiv(size.Width, info.width)), int(ceilDiv(size.Height, info.height))
+		dst := make([]byte, 4*width*height*int(size.DepthOrArrayLayers))
+		src := data
+		for layer := 0; layer < int(size.DepthOrArrayLayers); layer++ {
+			plane := dst[4*width*height*layer:]
+			for by := 0; by < blocksY; by++ {
+				for bx := 0; bx < blocksX; bx++ {
+					decode(block, src[:info.bytes])
+					src = src[info.bytes:]
+					// Crop blocks that overhang the level's edge.
+					for y := 0; y < bh && by*bh+y < height; y++ {
+						x0 := bx * bw
+						n := min(bw, width-x0)
+						row := 4 * ((by*bh+y)*width + x0)
+						copy(plane[row:row+4*n], block[4*y*bw:])
+					}
+				}
+			}
+		}
+		out.Levels[level] = dst
+	}
+	return &out, nil
+}
+
+// decompressIfUnsupported decompresses img when device lacks the feature its
+// format requires and the format can be decompressed.
+func decompressIfUnsupported(device *wgpu.Device, img *Image) (*Image, error) {
+	feature := compressionFeature(img.Format)
+	if feature == 0 || device.Features().Contains(feature) {
+		return img, nil
+	}
+	return Decompress(img)
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+// Package texutil loads image files into wgpu textures.
+//
+// It decodes PNG (via the standard library), DDS, and KTX2 containers into a
+// backend-neutral [Image] holding every mip level, then uploads that image
+// with [CreateTexture]. Uploads go through [wgpu.Queue.WriteTexture], so the
+// native backends batch them into the queue's pending-writes staging belt and
+// flush them with the next Submit.
+//
+//	f, _ := os.Open("albedo.ktx2")
+//	defer f.Close()
+//	tex, err := texutil.Load(device, f, &texutil.Options{Label: "albedo"})
+//
+// Block-compressed formats (BC, ETC2/EAC, ASTC) are uploaded verbatim; the
+// caller is responsible for requesting the matching device feature. With
+// [Options.DecompressUnsupported], BC and ASTC images the device cannot
+// sample are expanded to RGBA8 on the CPU instead (see [Decompress]).
+// Basis Universal and Zstandard supercompressed KTX2 files need a
+// [Transcoder]; texutil does not ship one.
+package texutil
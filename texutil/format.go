@@ -0,0 +1,157 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import "github.com/gogpu/gputypes"
+
+// blockInfo describes the texel block of a format. Uncompressed formats use
+// 1x1 blocks.
+type blockInfo struct {
+	width, height uint32
+	bytes         uint32
+}
+
+// astcBlocks lists ASTC footprints in gputypes (and VkFormat) order.
+var astcBlocks = [...][2]uint32{
+	{4, 4}, {5, 4}, {5, 5}, {6, 5}, {6, 6}, {8, 5}, {8, 6}, {8, 8},
+	{10, 5}, {10, 6}, {10, 8}, {10, 10}, {12, 10}, {12, 12},
+}
+
+func blockInfoFor(format gputypes.TextureFormat) (blockInfo, bool) {
+	if format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb {
+		dims := astcBlocks[(format-gputypes.TextureFormatASTC4x4Unorm)/2]
+		return blockInfo{width: dims[0], height: dims[1], bytes: 16}, true
+	}
+	bytes := format.BlockCopySize()
+	if bytes == 0 {
+		return blockInfo{}, false
+	}
+	if format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatEACRG11Snorm {
+		return blockInfo{width: 4, height: 4, bytes: bytes}, true
+	}
+	return blockInfo{width: 1, height: 1, bytes: bytes}, true
+}
+
+// VkFormat values used by KTX2 for the block-compressed range. BC1 through
+// ASTC 12x12 are contiguous and in the same order as gputypes.
+const (
+	vkFormatBC1RGBAUnormBlock  = 133
+	vkFormatASTC12x12SrgbBlock = 184
+)
+
+// vkFormats maps uncompressed VkFormat values to gputypes formats.
+var vkFormats = map[uint32]gputypes.TextureFormat{
+	9:   gputypes.TextureFormatR8Unorm,
+	10:  gputypes.TextureFormatR8Snorm,
+	13:  gputypes.TextureFormatR8Uint,
+	14:  gputypes.TextureFormatR8Sint,
+	16:  gputypes.TextureFormatRG8Unorm,
+	17:  gputypes.TextureFormatRG8Snorm,
+	20:  gputypes.TextureFormatRG8Uint,
+	21:  gputypes.TextureFormatRG8Sint,
+	37:  gputypes.TextureFormatRGBA8Unorm,
+	38:  gputypes.TextureFormatRGBA8Snorm,
+	41:  gputypes.TextureFormatRGBA8Uint,
+	42:  gputypes.TextureFormatRGBA8Sint,
+	43:  gputypes.TextureFormatRGBA8UnormSrgb,
+	44:  gputypes.TextureFormatBGRA8Unorm,
+	50:  gputypes.TextureFormatBGRA8UnormSrgb,
+	64:  gputypes.TextureFormatRGB10A2Unorm,
+	68:  gputypes.TextureFormatRGB10A2Uint,
+	70:  gputypes.TextureFormatR16Unorm,
+	71:  gputypes.TextureFormatR16Snorm,
+	74:  gputypes.TextureFormatR16Uint,
+	75:  gputypes.TextureFormatR16Sint,
+	76:  gputypes.TextureFormatR16Float,
+	77:  gputypes.TextureFormatRG16Unorm,
+	78:  gputypes.TextureFormatRG16Snorm,
+	81:  gputypes.TextureFormatRG16Uint,
+	82:  gputypes.TextureFormatRG16Sint,
+	83:  gputypes.TextureFormatRG16Float,
+	91:  gputypes.TextureFormatRGBA16Unorm,
+	92:  gputypes.TextureFormatRGBA16Snorm,
+	95:  gputypes.TextureFormatRGBA16Uint,
+	96:  gputypes.TextureFormatRGBA16Sint,
+	97:  gputypes.TextureFormatRGBA16Float,
+	98:  gputypes.TextureFormatR32Uint,
+	99:  gputypes.TextureFormatR32Sint,
+	100: gputypes.TextureFormatR32Float,
+	101: gputypes.TextureFormatRG32Uint,
+	102: gputypes.TextureFormatRG32Sint,
+	103: gputypes.TextureFormatRG32Float,
+	107: gputypes.TextureFormatRGBA32Uint,
+	108: gputypes.TextureFormatRGBA32Sint,
+	109: gputypes.TextureFormatRGBA32Float,
+	122: gputypes.TextureFormatRG11B10Ufloat,
+	123: gputypes.TextureFormatRGB9E5Ufloat,
+}
+
+func formatFromVk(vkFormat uint32) (gputypes.TextureFormat, bool) {
+	if vkFormat >= vkFormatBC1RGBAUnormBlock && vkFormat <= vkFormatASTC12x12SrgbBlock {
+		return gputypes.TextureFormatBC1RGBAUnorm + gputypes.TextureFormat(vkFormat-vkFormatBC1RGBAUnormBlock), true
+	}
+	format, ok := vkFormats[vkFormat]
+	return format, ok
+}
+
+// dxgiFormats maps DXGI_FORMAT values found in DDS DX10 headers.
+var dxgiFormats = map[uint32]gputypes.TextureFormat{
+	2:  gputypes.TextureFormatRGBA32Float,
+	3:  gputypes.TextureFormatRGBA32Uint,
+	4:  gputypes.TextureFormatRGBA32Sint,
+	10: gputypes.TextureFormatRGBA16Float,
+	11: gputypes.TextureFormatRGBA16Unorm,
+	12: gputypes.TextureFormatRGBA16Uint,
+	13: gputypes.TextureFormatRGBA16Snorm,
+	14: gputypes.TextureFormatRGBA16Sint,
+	16: gputypes.TextureFormatRG32Float,
+	17: gputypes.TextureFormatRG32Uint,
+	18: gputypes.TextureFormatRG32Sint,
+	24: gputypes.TextureFormatRGB10A2Unorm,
+	25: gputypes.TextureFormatRGB10A2Uint,
+	26: gputypes.TextureFormatRG11B10Ufloat,
+	28: gputypes.TextureFormatRGBA8Unorm,
+	29: gputypes.TextureFormatRGBA8UnormSrgb,
+	30: gputypes.TextureFormatRGBA8Uint,
+	31: gputypes.TextureFormatRGBA8Snorm,
+	32: gputypes.TextureFormatRGBA8Sint,
+	34: gputypes.TextureFormatRG16Float,
+	35: gputypes.TextureFormatRG16Unorm,
+	36: gputypes.TextureFormatRG16Uint,
+	37: gputypes.TextureFormatRG16Snorm,
+	38: gputypes.TextureFormatRG16Sint,
+	41: gputypes.TextureFormatR32Float,
+	42: gputypes.TextureFormatR32Uint,
+	43: gputypes.TextureFormatR32Sint,
+	49: gputypes.TextureFormatRG8Unorm,
+	50: gputypes.TextureFormatRG8Uint,
+	51: gputypes.TextureFormatRG8Snorm,
+	52: gputypes.TextureFormatRG8Sint,
+	54: gputypes.TextureFormatR16Float,
+	56: gputypes.TextureFormatR16Unorm,
+	57: gputypes.TextureFormatR16Uint,
+	58: gputypes.TextureFormatR16Snorm,
+	59: gputypes.TextureFormatR16Sint,
+	61: gputypes.TextureFormatR8Unorm,
+	62: gputypes.TextureFormatR8Uint,
+	63: gputypes.TextureFormatR8Snorm,
+	64: gputypes.TextureFormatR8Sint,
+	67: gputypes.TextureFormatRGB9E5Ufloat,
+	71: gputypes.TextureFormatBC1RGBAUnorm,
+	72: gputypes.TextureFormatBC1RGBAUnormSrgb,
+	74: gputypes.TextureFormatBC2RGBAUnorm,
+	75: gputypes.TextureFormatBC2RGBAUnormSrgb,
+	77: gputypes.TextureFormatBC3RGBAUnorm,
+	78: gputypes.TextureFormatBC3RGBAUnormSrgb,
+	80: gputypes.TextureFormatBC4RUnorm,
+	81: gputypes.TextureFormatBC4RSnorm,
+	83: gputypes.TextureFormatBC5RGUnorm,
+	84: gputypes.TextureFormatBC5RGSnorm,
+	87: gputypes.TextureFormatBGRA8Unorm,
+	91: gputypes.TextureFormatBGRA8UnormSrgb,
+	95: gputypes.TextureFormatBC6HRGBUfloat,
+	96: gputypes.TextureFormatBC6HRGBFloat,
+	98: gputypes.TextureFormatBC7RGBAUnorm,
+	99: gputypes.TextureFormatBC7RGBAUnormSrgb,
+}
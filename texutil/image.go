@@ -0,0 +1,211 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// ErrUnsupportedFormat is returned when a container stores pixel data in a
+// format that has no WebGPU equivalent.
+var ErrUnsupportedFormat = errors.New("texutil: unsupported pixel format")
+
+// Image is a decoded texture with its complete mip chain.
+//
+// Levels[0] is the full-resolution level. Each level holds every array layer
+// (or, for 3D images, every depth slice) back to back. Within a layer, rows
+// of texel blocks are tightly packed with no padding.
+type Image struct {
+	Format    wgpu.TextureFormat
+	Dimension wgpu.TextureDimension
+	Width     uint32
+	Height    uint32
+	// DepthOrArrayLayers is the depth of a 3D image or the layer count of a
+	// 2D image. Cube maps store six layers per cube in +X,-X,+Y,-Y,+Z,-Z order.
+	DepthOrArrayLayers uint32
+	Cube               bool
+	Levels             [][]byte
+}
+
+// LevelSize returns the size of mip level in texels. Width and height never
+// drop below one; depth shrinks only for 3D images.
+func (img *Image) LevelSize(level int) wgpu.Extent3D {
+	size := wgpu.Extent3D{
+		Width:              max(1, img.Width>>level),
+		Height:             max(1, img.Height>>level),
+		DepthOrArrayLayers: max(1, img.DepthOrArrayLayers),
+	}
+	if img.Dimension == wgpu.TextureDimension3D {
+		size.DepthOrArrayLayers = max(1, img.DepthOrArrayLayers>>level)
+	}
+	return size
+}
+
+// levelLayout returns the row pitch, rows per layer, and total byte size of a
+// tightly packed mip level.
+func (img *Image) levelLayout(level int) (bytesPerRow, rowsPerImage uint32, total uint64, err error) {
+	info, ok := blockInfoFor(img.Format)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("%w: %v", ErrUnsupportedFormat, img.Format)
+	}
+	size := img.LevelSize(level)
+	bytesPerRow = ceilDiv(size.Width, info.width) * info.bytes
+	rowsPerImage = ceilDiv(size.Height, info.height)
+	total = uint64(bytesPerRow) * uint64(rowsPerImage) * uint64(size.DepthOrArrayLayers)
+	return bytesPerRow, rowsPerImage, total, nil
+}
+
+// Validate checks that the image description is consistent with the data.
+func (img *Image) Validate() error {
+	if img == nil {
+		return errors.New("texutil: image is nil")
+	}
+	if img.Width == 0 || img.Height == 0 || img.DepthOrArrayLayers == 0 {
+		return fmt.Errorf("texutil: invalid image size %dx%dx%d", img.Width, img.Height, img.DepthOrArrayLayers)
+	}
+	if len(img.Levels) == 0 {
+		return errors.New("texutil: image has no mip levels")
+	}
+	if maxLevels := mipLevelCount(img.Width, img.Height); len(img.Levels) > int(maxLevels) {
+		return fmt.Errorf("texutil: %d mip levels exceed the %d allowed for %dx%d", len(img.Levels), maxLevels, img.Width, img.Height)
+	}
+	if img.Cube && img.DepthOrArrayLayers%6 != 0 {
+		return fmt.Errorf("texutil: cube image has %d layers, want a multiple of 6", img.DepthOrArrayLayers)
+	}
+	for level, data := range img.Levels {
+		_, _, want, err := img.levelLayout(level)
+		if err != nil {
+			return err
+		}
+		if uint64(len(data)) != want {
+			return fmt.Errorf("texutil: mip level %d has %d bytes, want %d", level, len(data), want)
+		}
+	}
+	return nil
+}
+
+// Options controls how an image is turned into a texture.
+type Options struct {
+	// Label is the debug label of the created texture.
+	Label string
+	// Usage is added to TextureBinding|CopyDst, which are always set.
+	Usage wgpu.TextureUsage
+	// SRGB marks 8-bit RGBA PNG data as sRGB-encoded, selecting
+	// RGBA8UnormSrgb and gamma-correct mip generation. DDS and KTX2 carry
+	// their own color space and ignore this flag.
+	SRGB bool
+	// GenerateMipmaps builds a full mip chain on the CPU for images decoded
+	// with a single level. Only 8-bit RGBA images are supported.
+	GenerateMipmaps bool
+	// Transcoder converts supercompressed KTX2 payloads. See [Transcoder].
+	Transcoder Transcoder
+	// DecompressUnsupported expands BC and ASTC images to 8-bit RGBA with
+	// [Decompress] when the device lacks the matching texture-compression
+	// feature, so content can ship in a single compressed format. The
+	// uploaded texture is four to eight times larger than the compressed data.
+	DecompressUnsupported bool
+}
+
+// CreateTexture creates a texture sized for img and uploads every mip level.
+func CreateTexture(device *wgpu.Device, img *Image, opts *Options) (*wgpu.Texture, error) {
+	if device == nil {
+		return nil, errors.New("texutil: device is nil")
+	}
+	if img == nil {
+		return nil, errors.New("texutil: image is nil")
+	}
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.DecompressUnsupported {
+		var err error
+		if img, err = decompressIfUnsupported(device, img); err != nil {
+			return nil, err
+		}
+	}
+	if opts.GenerateMipmaps && len(img.Levels) == 1 {
+		if err := GenerateMipmaps(img, opts.SRGB); err != nil {
+			return nil, err
+		}
+	}
+
+	dimension := img.Dimension
+	if dimension == gputypes.TextureDimensionUndefined {
+		dimension = wgpu.TextureDimension2D
+	}
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: opts.Label,
+		Size: wgpu.Extent3D{
+			Width:              img.Width,
+			Height:             img.Height,
+			DepthOrArrayLayers: img.DepthOrArrayLayers,
+		},
+		MipLevelCount: uint32(len(img.Levels)),
+		SampleCount:   1,
+		Dimension:     dimension,
+		Format:        img.Format,
+		Usage:         wgpu.TextureUsageTextureBinding | wgpu.TextureUsageCopyDst | opts.Usage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("texutil: create texture: %w", err)
+	}
+	if err := Upload(device.Queue(), tex, img); err != nil {
+		tex.Release()
+		return nil, err
+	}
+	return tex, nil
+}
+
+// Upload writes every mip level of img into tex, which must have been created
+// with at least len(img.Levels) mip levels and CopyDst usage.
+func Upload(queue *wgpu.Queue, tex *wgpu.Texture, img *Image) error {
+	if queue == nil || tex == nil {
+		return errors.New("texutil: queue or texture is nil")
+	}
+	info, ok := blockInfoFor(img.Format)
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFormat, img.Format)
+	}
+	for level, data := range img.Levels {
+		bytesPerRow, rowsPerImage, _, err := img.levelLayout(level)
+		if err != nil {
+			return err
+		}
+		// Copy extents of compressed formats must cover whole blocks, even
+		// where the mip level itself is smaller than one block.
+		size := img.LevelSize(level)
+		size.Width = ceilDiv(size.Width, info.width) * info.width
+		size.Height = ceilDiv(size.Height, info.height) * info.height
+		err = queue.WriteTexture(
+			&wgpu.ImageCopyTexture{Texture: tex, MipLevel: uint32(level)},
+			data,
+			&wgpu.ImageDataLayout{BytesPerRow: bytesPerRow, RowsPerImage: rowsPerImage},
+			&size,
+		)
+		if err != nil {
+			return fmt.Errorf("texutil: write mip level %d: %w", level, err)
+		}
+	}
+	return nil
+}
+
+func ceilDiv(v, d uint32) uint32 {
+	return (v + d - 1) / d
+}
+
+// mipLevelCount returns the length of a full mip chain for a 2D size.
+func mipLevelCount(width, height uint32) uint32 {
+	count := uint32(1)
+	for size := max(width, height); size > 1; size >>= 1 {
+		count++
+	}
+	return count
+}
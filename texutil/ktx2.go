@@ -0,0 +1,217 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogpu/wgpu"
+)
+
+// KTX2 supercompression schemes (KTX 2.0 specification, section 3.12.1).
+const (
+	SupercompressionNone    = 0
+	SupercompressionBasisLZ = 1
+	SupercompressionZstd    = 2
+	SupercompressionZlib    = 3
+)
+
+const (
+	ktx2HeaderSize          = 80
+	ktx2LevelIndexEntrySize = 24
+	ktx2VkFormatUndefined   = 0
+	// ktx2MaxLevelBytes bounds the allocation for an inflated level so a
+	// corrupt header cannot request an arbitrarily large buffer.
+	ktx2MaxLevelBytes = 1 << 31
+)
+
+var ktx2Identifier = []byte{0xAB, 0x4B, 0x54, 0x58, 0x20, 0x32, 0x30, 0xBB, 0x0D, 0x0A, 0x1A, 0x0A}
+
+var errInvalidKTX2 = errors.New("texutil: invalid KTX2 file")
+
+// ErrTranscoderRequired is returned for Basis Universal or Zstandard KTX2
+// files when [Options.Transcoder] is nil.
+var ErrTranscoderRequired = errors.New("texutil: KTX2 payload requires a transcoder")
+
+// KTX2 is a parsed KTX2 container whose payload texutil cannot decode on its
+// own. It is passed to a [Transcoder].
+type KTX2 struct {
+	VkFormat               uint32
+	TypeSize               uint32
+	PixelWidth             uint32
+	PixelHeight            uint32
+	PixelDepth             uint32
+	LayerCount             uint32
+	FaceCount              uint32
+	SupercompressionScheme uint32
+
+	// DataFormatDescriptor is the raw DFD block. For Basis Universal files it
+	// distinguishes ETC1S from UASTC payloads.
+	DataFormatDescriptor []byte
+	// SupercompressionGlobalData holds the BasisLZ codebooks, if any.
+	SupercompressionGlobalData []byte
+	// Levels holds each mip level's payload as stored in the file (still
+	// supercompressed), largest level first.
+	Levels []KTX2Level
+}
+
+// KTX2Level is one entry of the KTX2 level index.
+type KTX2Level struct {
+	Data                   []byte
+	UncompressedByteLength uint64
+}
+
+// Transcoder converts a supercompressed or Basis Universal KTX2 payload into
+// a GPU-native [Image]. Implementations typically wrap the Basis Universal
+// transcoder and pick a target format the device supports (BC7, ASTC, ETC2,
+// or RGBA8 as a last resort).
+type Transcoder interface {
+	Transcode(src *KTX2) (*Image, error)
+}
+
+// TranscoderFunc adapts an ordinary function to [Transcoder].
+type TranscoderFunc func(src *KTX2) (*Image, error)
+
+// Transcode calls f(src).
+func (f TranscoderFunc) Transcode(src *KTX2) (*Image, error) { return f(src) }
+
+// DecodeKTX2 decodes a KTX2 file. Uncompressed and zlib-supercompressed
+// payloads are decoded directly; BasisLZ, UASTC (VK_FORMAT_UNDEFINED), and
+// Zstandard payloads are handed to transcoder, or fail with
+// [ErrTranscoderRequired] when transcoder is nil.
+func DecodeKTX2(r io.Reader, transcoder Transcoder) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("texutil: read ktx2: %w", err)
+	}
+	src, err := parseKTX2(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if src.VkFormat == ktx2VkFormatUndefined ||
+		src.SupercompressionScheme == SupercompressionBasisLZ ||
+		src.SupercompressionScheme == SupercompressionZstd {
+		if transcoder == nil {
+			return nil, ErrTranscoderRequired
+		}
+		img, err := transcoder.Transcode(src)
+		if err != nil {
+			return nil, fmt.Errorf("texutil: transcode ktx2: %w", err)
+		}
+		return img, img.Validate()
+	}
+	if src.SupercompressionScheme != SupercompressionNone && src.SupercompressionScheme != SupercompressionZlib {
+		return nil, fmt.Errorf("%w: unknown supercompression scheme %d", errInvalidKTX2, src.SupercompressionScheme)
+	}
+
+	format, ok := formatFromVk(src.VkFormat)
+	if !ok {
+		return nil, fmt.Errorf("%w: VkFormat %d", ErrUnsupportedFormat, src.VkFormat)
+	}
+	img := &Image{
+		Format:             format,
+		Dimension:          wgpu.TextureDimension2D,
+		Width:              src.PixelWidth,
+		Height:             max(1, src.PixelHeight),
+		DepthOrArrayLayers: max(1, src.LayerCount) * src.FaceCount,
+		Cube:               src.FaceCount == 6,
+		Levels:             make([][]byte, len(src.Levels)),
+	}
+	switch {
+	case src.PixelDepth > 0:
+		img.Dimension = wgpu.TextureDimension3D
+		img.DepthOrArrayLayers = src.PixelDepth
+	case src.PixelHeight == 0:
+		img.Dimension = wgpu.TextureDimension1D
+	}
+
+	for i, level := range src.Levels {
+		if src.SupercompressionScheme == SupercompressionZlib {
+			img.Levels[i], err = inflateLevel(level)
+			if err != nil {
+				return nil, fmt.Errorf("texutil: inflate ktx2 level %d: %w", i, err)
+			}
+			continue
+		}
+		img.Levels[i] = level.Data
+	}
+	return img, img.Validate()
+}
+
+func parseKTX2(data []byte) (*KTX2, error) {
+	if len(data) < ktx2HeaderSize || !bytes.Equal(data[:len(ktx2Identifier)], ktx2Identifier) {
+		return nil, fmt.Errorf("%w: missing KTX2 identifier", errInvalidKTX2)
+	}
+	le := binary.LittleEndian
+	src := &KTX2{
+		VkFormat:               le.Uint32(data[12:]),
+		TypeSize:               le.Uint32(data[16:]),
+		PixelWidth:             le.Uint32(data[20:]),
+		PixelHeight:            le.Uint32(data[24:]),
+		PixelDepth:             le.Uint32(data[28:]),
+		LayerCount:             le.Uint32(data[32:]),
+		FaceCount:              le.Uint32(data[36:]),
+		SupercompressionScheme: le.Uint32(data[44:]),
+	}
+	if src.PixelWidth == 0 || (src.FaceCount != 1 && src.FaceCount != 6) {
+		return nil, fmt.Errorf("%w: width %d, face count %d", errInvalidKTX2, src.PixelWidth, src.FaceCount)
+	}
+	// A level count of zero asks the loader to generate mips; only the base
+	// level is stored in that case.
+	levelCount := max(1, le.Uint32(data[40:]))
+
+	section := func(offset, length uint64) ([]byte, error) {
+		if length == 0 {
+			return nil, nil
+		}
+		if offset > uint64(len(data)) || length > uint64(len(data))-offset {
+			return nil, fmt.Errorf("%w: section [%d, +%d) out of bounds", errInvalidKTX2, offset, length)
+		}
+		return data[offset : offset+length], nil
+	}
+	var err error
+	if src.DataFormatDescriptor, err = section(uint64(le.Uint32(data[48:])), uint64(le.Uint32(data[52:]))); err != nil {
+		return nil, err
+	}
+	if src.SupercompressionGlobalData, err = section(le.Uint64(data[64:]), le.Uint64(data[72:])); err != nil {
+		return nil, err
+	}
+
+	index, err := section(ktx2HeaderSize, uint64(levelCount)*ktx2LevelIndexEntrySize)
+	if err != nil {
+		return nil, err
+	}
+	src.Levels = make([]KTX2Level, levelCount)
+	for i := range src.Levels {
+		entry := index[i*ktx2LevelIndexEntrySize:]
+		levelData, err := section(le.Uint64(entry[0:]), le.Uint64(entry[8:]))
+		if err != nil {
+			return nil, err
+		}
+		src.Levels[i] = KTX2Level{Data: levelData, UncompressedByteLength: le.Uint64(entry[16:])}
+	}
+	return src, nil
+}
+
+func inflateLevel(level KTX2Level) ([]byte, error) {
+	if level.UncompressedByteLength > ktx2MaxLevelBytes {
+		return nil, fmt.Errorf("level of %d bytes is too large", level.UncompressedByteLength)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(level.Data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	out := make([]byte, level.UncompressedByteLength)
+	if _, err := io.ReadFull(zr, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
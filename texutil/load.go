@@ -0,0 +1,63 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gogpu/wgpu"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Decode sniffs the container format (PNG, DDS, or KTX2) and decodes r.
+func Decode(r io.Reader, opts *Options) (*Image, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(ktx2Identifier))
+	if err != nil && len(magic) < len(ddsMagic) {
+		return nil, fmt.Errorf("texutil: read header: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(magic, pngSignature):
+		return DecodePNG(br, opts.SRGB)
+	case bytes.HasPrefix(magic, []byte(ddsMagic)):
+		return DecodeDDS(br)
+	case bytes.Equal(magic, ktx2Identifier):
+		return DecodeKTX2(br, opts.Transcoder)
+	}
+	return nil, fmt.Errorf("texutil: unrecognized image container")
+}
+
+// Load decodes r with [Decode] and uploads it with [CreateTexture].
+func Load(device *wgpu.Device, r io.Reader, opts *Options) (*wgpu.Texture, error) {
+	img, err := Decode(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return CreateTexture(device, img, opts)
+}
+
+// LoadFile opens path and calls [Load].
+func LoadFile(device *wgpu.Device, path string, opts *Options) (*wgpu.Texture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("texutil: %w", err)
+	}
+	defer f.Close()
+	if opts == nil {
+		opts = &Options{Label: path}
+	} else if opts.Label == "" {
+		withLabel := *opts
+		withLabel.Label = path
+		opts = &withLabel
+	}
+	return Load(device, f, opts)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// DecodePNG decodes a PNG into a single-level RGBA8 image with straight
+// (non-premultiplied) alpha. When srgb is true the result is tagged
+// RGBA8UnormSrgb, which is correct for color textures authored in sRGB.
+func DecodePNG(r io.Reader, srgb bool) (*Image, error) {
+	decoded, err := png.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("texutil: decode png: %w", err)
+	}
+	return FromImage(decoded, srgb), nil
+}
+
+// FromImage converts any [image.Image] into a single-level RGBA8 image.
+func FromImage(src image.Image, srgb bool) *Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	pixels := make([]byte, width*height*4)
+
+	if nrgba, ok := src.(*image.NRGBA); ok {
+		for y := range height {
+			start := nrgba.PixOffset(bounds.Min.X, bounds.Min.Y+y)
+			copy(pixels[y*width*4:(y+1)*width*4], nrgba.Pix[start:start+width*4])
+		}
+	} else {
+		i := 0
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+				pixels[i], pixels[i+1], pixels[i+2], pixels[i+3] = c.R, c.G, c.B, c.A
+				i += 4
+			}
+		}
+	}
+
+	format := gputypes.TextureFormatRGBA8Unorm
+	if srgb {
+		format = gputypes.TextureFormatRGBA8UnormSrgb
+	}
+	return &Image{
+		Format:             format,
+		Dimension:          wgpu.TextureDimension2D,
+		Width:              uint32(width),
+		Height:             uint32(height),
+		DepthOrArrayLayers: 1,
+		Levels:             [][]byte{pixels},
+	}
+}
+
+// GenerateMipmaps replaces img's mip chain with a full chain box-filtered
+// from level 0. Only RGBA8 and BGRA8 2D images are supported. When srgb is
+// true (or the format is an sRGB format) color channels are filtered in
+// linear space so that mips do not darken.
+func GenerateMipmaps(img *Image, srgb bool) error {
+	switch img.Format {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatBGRA8Unorm:
+	case gputypes.TextureFormatRGBA8UnormSrgb, gputypes.TextureFormatBGRA8UnormSrgb:
+		srgb = true
+	default:
+		return fmt.Errorf("%w: cannot generate mipmaps for %v", ErrUnsupportedFormat, img.Format)
+	}
+	if img.Dimension == wgpu.TextureDimension3D {
+		return fmt.Errorf("texutil: cannot generate mipmaps for 3D images")
+	}
+	if len(img.Levels) == 0 {
+		return fmt.Errorf("texutil: image has no base level")
+	}
+
+	layers := int(img.DepthOrArrayLayers)
+	count := int(mipLevelCount(img.Width, img.Height))
+	levels := make([][]byte, 1, count)
+	levels[0] = img.Levels[0]
+	srcW, srcH := int(img.Width), int(img.Height)
+	for range count - 1 {
+		dstW, dstH := max(1, srcW/2), max(1, srcH/2)
+		src := levels[len(levels)-1]
+		dst := make([]byte, dstW*dstH*4*layers)
+		for layer := range layers {
+			downsample(
+				dst[layer*dstW*dstH*4:(layer+1)*dstW*dstH*4], dstW, dstH,
+				src[layer*srcW*srcH*4:(layer+1)*srcW*srcH*4], srcW, srcH,
+				srgb,
+			)
+		}
+		levels = append(levels, dst)
+		srcW, srcH = dstW, dstH
+	}
+	img.Levels = levels
+	return nil
+}
+
+// downsample averages 2x2 texel footprints, clamping at odd edges. The alpha
+// channel (index 3) is always linear.
+func downsample(dst []byte, dstW, dstH int, src []byte, srcW, srcH int, srgb bool) {
+	for y := range dstH {
+		y0, y1 := min(2*y, srcH-1), min(2*y+1, srcH-1)
+		for x := range dstW {
+			x0, x1 := min(2*x, srcW-1), min(2*x+1, srcW-1)
+			taps := [4]int{
+				(y0*srcW + x0) * 4, (y0*srcW + x1) * 4,
+				(y1*srcW + x0) * 4, (y1*srcW + x1) * 4,
+			}
+			out := (y*dstW + x) * 4
+			for c := range 4 {
+				if srgb && c < 3 {
+					var sum float32
+					for _, t := range taps {
+						sum += srgbToLinear[src[t+c]]
+					}
+					dst[out+c] = linearToSRGB(sum / 4)
+					continue
+				}
+				var sum int
+				for _, t := range taps {
+					sum += int(src[t+c])
+				}
+				dst[out+c] = byte((sum + 2) / 4)
+			}
+		}
+	}
+}
+
+var srgbToLinear = func() (table [256]float32) {
+	for i := range table {
+		v := float64(i) / 255
+		if v <= 0.04045 {
+			table[i] = float32(v / 12.92)
+		} else {
+			table[i] = float32(math.Pow((v+0.055)/1.055, 2.4))
+		}
+	}
+	return table
+}()
+
+func linearToSRGB(v float32) byte {
+	var s float64
+	if v <= 0.0031308 {
+		s = float64(v) * 12.92
+	} else {
+		s = 1.055*math.Pow(float64(v), 1/2.4) - 0.055
+	}
+	return byte(math.Round(math.Min(math.Max(s, 0), 1) * 255))
+}
@@ -0,0 +1,492 @@
+// Copyright 2026 The GoGPU Authors
+// SPDX-License-Identifier: MIT
+
+package texutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			src.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 40), G: uint8(y * 40), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodePNG(t *testing.T) {
+	img, err := Decode(bytes.NewReader(encodePNG(t, 5, 3)), &Options{SRGB: true})
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatRGBA8UnormSrgb {
+		t.Fatalf("Format = %v, want RGBA8UnormSrgb", img.Format)
+	}
+	if img.Width != 5 || img.Height != 3 || len(img.Levels) != 1 {
+		t.Fatalf("got %dx%d with %d levels, want 5x3 with 1", img.Width, img.Height, len(img.Levels))
+	}
+	if got := img.Levels[0][4*(1*5+2):][:4]; !bytes.Equal(got, []byte{80, 40, 200, 255}) {
+		t.Fatalf("texel (2,1) = %v, want [80 40 200 255]", got)
+	}
+}
+
+func TestGenerateMipmaps(t *testing.T) {
+	img, err := DecodePNG(bytes.NewReader(encodePNG(t, 5, 3)), false)
+	if err != nil {
+		t.Fatalf("DecodePNG: %v", err)
+	}
+	if err := GenerateMipmaps(img, false); err != nil {
+		t.Fatalf("GenerateMipmaps: %v", err)
+	}
+	if len(img.Levels) != 3 {
+		t.Fatalf("levels = %d, want 3 (5x3, 2x1, 1x1)", len(img.Levels))
+	}
+	if err := img.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	// Level 1 texel 0 averages x in {0,1}, y in {0,1}: R = (0+40+0+40)/4.
+	if r := img.Levels[1][0]; r != 20 {
+		t.Fatalf("level 1 red = %d, want 20", r)
+	}
+}
+
+func TestGenerateMipmapsSRGBIsGammaCorrect(t *testing.T) {
+	img := &Image{
+		Format:             gputypes.TextureFormatRGBA8UnormSrgb,
+		Width:              2,
+		Height:             1,
+		DepthOrArrayLayers: 1,
+		Levels:             [][]byte{{0, 0, 0, 255, 255, 255, 255, 255}},
+	}
+	if err := GenerateMipmaps(img, false); err != nil {
+		t.Fatalf("GenerateMipmaps: %v", err)
+	}
+	// 50% linear gray is ~188 in sRGB, not the naive 128.
+	if got := img.Levels[1][0]; got < 185 || got > 190 {
+		t.Fatalf("sRGB mip of black+white = %d, want ~188", got)
+	}
+	if got := img.Levels[1][3]; got != 255 {
+		t.Fatalf("alpha = %d, want 255", got)
+	}
+}
+
+func buildDDS(fourCC string, width, height, mips, caps2 uint32, dx10 []uint32, payload []byte) []byte {
+	header := make([]byte, ddsHeaderSize)
+	le := binary.LittleEndian
+	le.PutUint32(header[0:], ddsHeaderSize)
+	le.PutUint32(header[8:], height)
+	le.PutUint32(header[12:], width)
+	le.PutUint32(header[24:], mips)
+	le.PutUint32(header[72:], 32)
+	le.PutUint32(header[76:], ddsPixelFormatFourCC)
+	copy(header[80:84], fourCC)
+	le.PutUint32(header[108:], caps2)
+	out := append([]byte(ddsMagic), header...)
+	for _, v := range dx10 {
+		out = le.AppendUint32(out, v)
+	}
+	return append(out, payload...)
+}
+
+func TestDecodeDDSBC1MipChain(t *testing.T) {
+	// 8x8 BC1: level 0 = 2x2 blocks, level 1 = 1 block, level 2 (2x2) = 1 block.
+	payload := bytes.Repeat([]byte{0xAA}, (4+1+1)*8)
+	img, err := Decode(bytes.NewReader(buildDDS("DXT1", 8, 8, 3, 0, nil, payload)), nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatBC1RGBAUnorm {
+		t.Fatalf("Format = %v, want BC1RGBAUnorm", img.Format)
+	}
+	want := []int{32, 8, 8}
+	for i, level := range img.Levels {
+		if len(level) != want[i] {
+			t.Fatalf("level %d = %d bytes, want %d", i, len(level), want[i])
+		}
+	}
+}
+
+func TestDecodeDDSDX10CubeReordersLayers(t *testing.T) {
+	// 2x2 RGBA8 cube with two mips: per face 16 bytes (level 0) + 4 bytes (level 1).
+	var payload []byte
+	for face := range 6 {
+		payload = append(payload, bytes.Repeat([]byte{byte(face)}, 16)...)
+		payload = append(payload, bytes.Repeat([]byte{byte(0x10 + face)}, 4)...)
+	}
+	dx10 := []uint32{28, 3, dx10MiscTextureCube, 1, 0}
+	img, err := DecodeDDS(bytes.NewReader(buildDDS("DX10", 2, 2, 2, 0, dx10, payload)))
+	if err != nil {
+		t.Fatalf("DecodeDDS: %v", err)
+	}
+	if !img.Cube || img.DepthOrArrayLayers != 6 {
+		t.Fatalf("Cube = %v, layers = %d, want cube with 6 layers", img.Cube, img.DepthOrArrayLayers)
+	}
+	if img.Levels[1][4*3] != 0x13 {
+		t.Fatalf("level 1 face 3 = %#x, want 0x13", img.Levels[1][4*3])
+	}
+}
+
+func TestDecodeDDSRejectsTruncatedData(t *testing.T) {
+	_, err := DecodeDDS(bytes.NewReader(buildDDS("DXT5", 8, 8, 1, 0, nil, make([]byte, 10))))
+	if !errors.Is(err, errInvalidDDS) {
+		t.Fatalf("err = %v, want errInvalidDDS", err)
+	}
+}
+
+func TestDecodeDDSRejectsForgedHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		dds  []byte
+	}{
+		{"too many mips", buildDDS("DXT1", 8, 8, 5, 0, nil, make([]byte, 1024))},
+		{"huge size", buildDDS("DXT1", 0xFFFFFFFF, 0xFFFFFFFF, 1, 0, nil, make([]byte, 64))},
+		{"huge cube array", buildDDS("DX10", 4, 4, 1, 0, []uint32{28, 3, dx10MiscTextureCube, 0xFFFFFFFF, 0}, nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeDDS(bytes.NewReader(tt.dds))
+			if !errors.Is(err, errInvalidDDS) {
+				t.Fatalf("err = %v, want errInvalidDDS", err)
+			}
+		})
+	}
+}
+
+func buildKTX2(vkFormat, width, height, scheme uint32, levels []KTX2Level) []byte {
+	le := binary.LittleEndian
+	header := make([]byte, ktx2HeaderSize)
+	copy(header, ktx2Identifier)
+	le.PutUint32(header[12:], vkFormat)
+	le.PutUint32(header[16:], 1)
+	le.PutUint32(header[20:], width)
+	le.PutUint32(header[24:], height)
+	le.PutUint32(header[36:], 1)
+	le.PutUint32(header[40:], uint32(len(levels)))
+	le.PutUint32(header[44:], scheme)
+
+	offset := uint64(ktx2HeaderSize + len(levels)*ktx2LevelIndexEntrySize)
+	index := make([]byte, 0, len(levels)*ktx2LevelIndexEntrySize)
+	var data []byte
+	for _, level := range levels {
+		index = le.AppendUint64(index, offset)
+		index = le.AppendUint64(index, uint64(len(level.Data)))
+		index = le.AppendUint64(index, level.UncompressedByteLength)
+		data = append(data, level.Data...)
+		offset += uint64(len(level.Data))
+	}
+	return append(append(header, index...), data...)
+}
+
+func TestDecodeKTX2Uncompressed(t *testing.T) {
+	levels := []KTX2Level{
+		{Data: bytes.Repeat([]byte{1}, 4*2*2), UncompressedByteLength: 16},
+		{Data: []byte{2, 2, 2, 2}, UncompressedByteLength: 4},
+	}
+	img, err := Decode(bytes.NewReader(buildKTX2(43, 2, 2, SupercompressionNone, levels)), nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatRGBA8UnormSrgb || len(img.Levels) != 2 {
+		t.Fatalf("got %v with %d levels, want RGBA8UnormSrgb with 2", img.Format, len(img.Levels))
+	}
+}
+
+func TestDecodeKTX2Zlib(t *testing.T) {
+	raw := bytes.Repeat([]byte{7}, 16) // one BC7 block
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, _ = zw.Write(raw)
+	_ = zw.Close()
+
+	levels := []KTX2Level{{Data: compressed.Bytes(), UncompressedByteLength: uint64(len(raw))}}
+	img, err := DecodeKTX2(bytes.NewReader(buildKTX2(145, 4, 4, SupercompressionZlib, levels)), nil)
+	if err != nil {
+		t.Fatalf("DecodeKTX2: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatBC7RGBAUnorm || !bytes.Equal(img.Levels[0], raw) {
+		t.Fatalf("got %v / %v, want BC7 with inflated payload", img.Format, img.Levels[0])
+	}
+}
+
+func TestDecodeKTX2BasisNeedsTranscoder(t *testing.T) {
+	file := buildKTX2(0, 4, 4, SupercompressionBasisLZ, []KTX2Level{{Data: []byte{1, 2, 3}}})
+	if _, err := DecodeKTX2(bytes.NewReader(file), nil); !errors.Is(err, ErrTranscoderRequired) {
+		t.Fatalf("err = %v, want ErrTranscoderRequired", err)
+	}
+
+	var seen *KTX2
+	transcoder := TranscoderFunc(func(src *KTX2) (*Image, error) {
+		seen = src
+		return &Image{
+			Format:             gputypes.TextureFormatRGBA8Unorm,
+			Width:              4,
+			Height:             4,
+			DepthOrArrayLayers: 1,
+			Levels:             [][]byte{make([]byte, 64)},
+		}, nil
+	})
+	img, err := DecodeKTX2(bytes.NewReader(file), transcoder)
+	if err != nil {
+		t.Fatalf("DecodeKTX2: %v", err)
+	}
+	if seen == nil || !bytes.Equal(seen.Levels[0].Data, []byte{1, 2, 3}) {
+		t.Fatal("transcoder did not receive the raw level payload")
+	}
+	if img.Width != 4 {
+		t.Fatalf("Width = %d, want 4", img.Width)
+	}
+}
+
+func TestBlockInfo(t *testing.T) {
+	tests := []struct {
+		format gputypes.TextureFormat
+		want   blockInfo
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, blockInfo{1, 1, 4}},
+		{gputypes.TextureFormatBC1RGBAUnorm, blockInfo{4, 4, 8}},
+		{gputypes.TextureFormatEACRG11Snorm, blockInfo{4, 4, 16}},
+		{gputypes.TextureFormatASTC10x6UnormSrgb, blockInfo{10, 6, 16}},
+	}
+	for _, tt := range tests {
+		if got, ok := blockInfoFor(tt.format); !ok || got != tt.want {
+			t.Errorf("blockInfoFor(%v) = %+v, %v; want %+v", tt.format, got, ok, tt.want)
+		}
+	}
+	if got, _ := formatFromVk(184); got != gputypes.TextureFormatASTC12x12UnormSrgb {
+		t.Errorf("formatFromVk(184) = %v, want ASTC12x12UnormSrgb", got)
+	}
+}
+
+func TestCreateTextureRejectsNilDevice(t *testing.T) {
+	img, _ := DecodePNG(bytes.NewReader(encodePNG(t, 2, 2)), false)
+	if _, err := CreateTexture((*wgpu.Device)(nil), img, nil); err == nil {
+		t.Fatal("CreateTexture(nil device) succeeded")
+	}
+}
+
+func TestDecompressBC1CropsPartialBlock(t *testing.T) {
+	// c0 = red, c1 = blue; texels use indices 0, 1, 2, 3 in turn.
+	block := []byte{0x00, 0xF8, 0x1F, 0x00, 0xE4, 0xE4, 0xE4, 0xE4}
+	img := &Image{Format: gputypes.TextureFormatBC1RGBAUnorm, Width: 2, Height: 2, DepthOrArrayLayers: 1, Levels: [][]byte{block}}
+	out, err := Decompress(img)
+	if err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+	if out.Format != gputypes.TextureFormatRGBA8Unorm {
+		t.Errorf("Format = %v, want RGBA8Unorm", out.Format)
+	}
+	want := []byte{
+		255, 0, 0, 255, 0, 0, 255, 255,
+		255, 0, 0, 255, 0, 0, 255, 255,
+	}
+	if !bytes.Equal(out.Levels[0], want) {
+		t.Errorf("texels = %v, want %v", out.Levels[0], want)
+	}
+	if img.Format != gputypes.TextureFormatBC1RGBAUnorm {
+		t.Error("Decompress modified its input")
+	}
+}
+
+func TestDecompressRejectsUnsupportedFormats(t *testing.T) {
+	img := &Image{Format: gputypes.TextureFormatBC6HRGBUfloat, Width: 4, Height: 4, DepthOrArrayLayers: 1, Levels: [][]byte{make([]byte, 16)}}
+	if _, err := Decompress(img); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Decompress(BC6H) = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+// blockBits builds a 128-bit block field by field, least significant first.
+type blockBits struct {
+	data [16]byte
+	pos  int
+}
+
+func (b *blockBits) put(v uint32, n int) {
+	b.set(b.pos, v, n)
+	b.pos += n
+}
+
+func (b *blockBits) set(pos int, v uint32, n int) {
+	for i := 0; i < n; i++ {
+		if v>>i&1 != 0 {
+			b.data[(pos+i)/8] |= 1 << ((pos + i) % 8)
+		}
+	}
+}
+
+func TestDecodeBC7Mode6(t *testing.T) {
+	var b blockBits
+	b.put(1<<6, 7) // mode 6
+	for ch := 0; ch < 4; ch++ {
+		b.put(0x7F, 7) // endpoint 0
+		b.put(0x00, 7) // endpoint 1
+	}
+	b.put(1, 1) // P-bit of endpoint 0
+	b.put(0, 1) // P-bit of endpoint 1
+	b.put(0, 3) // anchor texel: endpoint 0
+	for i := 1; i < 16; i++ {
+		b.put(15, 4) // endpoint 1
+	}
+	var dst [64]byte
+	decodeBC7(dst[:], b.data[:])
+	if got := dst[:4]; !bytes.Equal(got, []byte{255, 255, 255, 255}) {
+		t.Errorf("texel 0 = %v, want opaque white", got)
+	}
+	if got := dst[60:]; !bytes.Equal(got, []byte{0, 0, 0, 0}) {
+		t.Errorf("texel 15 = %v, want transparent black", got)
+	}
+}
+
+func TestBC7AnchorsBelongToTheirSubsets(t *testing.T) {
+	for p := 0; p < 64; p++ {
+		if bc7Partitions2[p]&1 != 0 || bc7Partitions3[p][0] != 0 {
+			t.Errorf("partition %d: texel 0 is not in subset 0", p)
+		}
+		if bc7Partitions2[p]>>bc7Anchors2[p]&1 != 1 {
+			t.Errorf("partition %d: two-subset anchor %d is not in subset 1", p, bc7Anchors2[p])
+		}
+		if s := bc7Partitions3[p]; s[bc7Anchors3a[p]] != 1 || s[bc7Anchors3b[p]] != 2 {
+			t.Errorf("partition %d: three-subset anchors %d, %d are not in subsets 1, 2", p, bc7Anchors3a[p], bc7Anchors3b[p])
+		}
+	}
+}
+
+func TestASTCIntegerSequenceDecodingIsComplete(t *testing.T) {
+	trits := make(map[[5]uint32]bool)
+	for v := uint32(0); v < 256; v++ {
+		trits[decodeTrits(v)] = true
+	}
+	if len(trits) != 243 {
+		t.Errorf("trit blocks decode to %d distinct tuples, want 243", len(trits))
+	}
+	quints := make(map[[3]uint32]bool)
+	for v := uint32(0); v < 128; v++ {
+		quints[decodeQuints(v)] = true
+	}
+	if len(quints) != 125 {
+		t.Errorf("quint blocks decode to %d distinct tuples, want 125", len(quints))
+	}
+}
+
+func TestASTCUnquantizationSpansFullRange(t *testing.T) {
+	levels := func(q iseRange) uint32 {
+		n := uint32(1) << q.bits
+		switch {
+		case q.trits:
+			n *= 3
+		case q.quints:
+			n *= 5
+		}
+		return n
+	}
+	check := func(kind string, q iseRange, top uint32, unquantize func(uint32, iseRange) uint32) {
+		seen := make(map[uint32]bool)
+		lo, hi := top, uint32(0)
+		for v := uint32(0); v < levels(q); v++ {
+			u := unquantize(v, q)
+			seen[u] = true
+			lo, hi = min(lo, u), max(hi, u)
+		}
+		if lo != 0 || hi != top || uint32(len(seen)) != levels(q) {
+			t.Errorf("%s range %+v: values span %d-%d with %d distinct, want 0-%d with %d",
+				kind, q, lo, hi, len(seen), top, levels(q))
+		}
+	}
+	for i, q := range iseRanges {
+		if i <= 11 {
+			check("weight", q, 64, unquantizeWeight)
+		}
+		if i >= astcColorRangeMin {
+			check("color", q, 255, unquantizeColor)
+		}
+	}
+}
+
+func TestDecodeASTCVoidExtent(t *testing.T) {
+	var b blockBits
+	b.put(0x1FC, 9)
+	b.put(0, 1)               // LDR
+	b.put(3, 2)               // reserved
+	b.set(12, 0xFFFFFFFF, 32) // no extent coordinates
+	b.set(44, 0xFFFFF, 20)    //
+	b.set(64, 0x1234, 16)     // R
+	b.set(80, 0xABCD, 16)     // G
+	b.set(96, 0x0000, 16)     // B
+	b.set(112, 0xFFFF, 16)    // A
+	dst := make([]byte, 4*6*6)
+	decodeASTC(dst, b.data[:], 6, 6, false)
+	for i := 0; i < 36; i++ {
+		if got := dst[4*i : 4*i+4]; !bytes.Equal(got, []byte{0x12, 0xAB, 0x00, 0xFF}) {
+			t.Fatalf("texel %d = %v, want [18 171 0 255]", i, got)
+		}
+	}
+}
+
+func TestDecodeASTCSinglePartition(t *testing.T) {
+	var b blockBits
+	// Block mode 0x42: 4x4 weight grid of 2-bit weights, one plane.
+	b.put(0x42, 11)
+	b.put(0, 2) // one partition
+	b.put(8, 4) // CEM 8: LDR RGB direct
+	for _, v := range []uint32{0, 255, 0, 255, 0, 255} {
+		b.put(v, 8) // R0 R1 G0 G1 B0 B1: black to white
+	}
+	// Weights are stored bit-reversed from the end of the block.
+	for i := 0; i < 16; i++ {
+		w := uint32(i % 4)
+		for k := 0; k < 2; k++ {
+			b.set(127-(2*i+k), w>>k&1, 1)
+		}
+	}
+	dst := make([]byte, 4*16)
+	decodeASTC(dst, b.data[:], 4, 4, false)
+	want := []byte{0, 84, 171, 255}
+	for i := 0; i < 16; i++ {
+		px := dst[4*i : 4*i+4]
+		if px[0] != want[i%4] || px[1] != want[i%4] || px[2] != want[i%4] || px[3] != 255 {
+			t.Errorf("texel %d = %v, want gray %d", i, px, want[i%4])
+		}
+	}
+}
+
+func TestDecodeASTCErrorBlock(t *testing.T) {
+	dst := make([]byte, 4*16)
+	decodeASTC(dst, make([]byte, 16), 4, 4, false) // block mode 0 is reserved
+	if !bytes.Equal(dst[:4], astcErrorColor[:]) {
+		t.Errorf("reserved block mode = %v, want the error color", dst[:4])
+	}
+}
+
+func TestCompressionFeature(t *testing.T) {
+	tests := []struct {
+		format gputypes.TextureFormat
+		want   gputypes.Feature
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, 0},
+		{gputypes.TextureFormatBC7RGBAUnormSrgb, gputypes.FeatureTextureCompressionBC},
+		{gputypes.TextureFormatEACRG11Snorm, gputypes.FeatureTextureCompressionETC2},
+		{gputypes.TextureFormatASTC4x4Unorm, gputypes.FeatureTextureCompressionASTC},
+	}
+	for _, tt := range tests {
+		if got := compressionFeature(tt.format); got != tt.want {
+			t.Errorf("compressionFeature(%v) = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+}
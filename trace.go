@@ -0,0 +1,152 @@
+package wgpu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"runtime/trace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Chrome trace thread IDs. CPU spans and GPU pass timings are shown as two
+// tracks of one process.
+const (
+	traceTIDCPU = 1
+	traceTIDGPU = 2
+)
+
+// traceRecorder is the recorder installed by SetTraceRecorder, or nil.
+var traceRecorder atomic.Pointer[TraceRecorder]
+
+// SetTraceRecorder installs r as the destination for wgpu trace spans.
+// Pass nil to stop recording.
+//
+// Independently of the recorder, instance, device, queue and encoder
+// operations always open a runtime/trace region named after the method
+// (e.g. "wgpu.Queue.Submit"), so they show up in `go tool trace` whenever
+// runtime tracing is on.
+//
+// SetTraceRecorder is safe for concurrent use.
+func SetTraceRecorder(r *TraceRecorder) {
+	traceRecorder.Store(r)
+}
+
+// TraceRecorder collects CPU spans of wgpu operations and GPU pass timings
+// from GPUTimer, and writes them in the Chrome trace event format understood
+// by chrome://tracing and Perfetto.
+//
+// GPU and CPU clocks are not synchronized. Each GPUTimer.Read places its
+// passes on the GPU track starting at the time GPUTimer.Resolve was called,
+// keeping their measured durations and relative offsets.
+//
+// A TraceRecorder is safe for concurrent use.
+type TraceRecorder struct {
+	mu     sync.Mutex
+	start  time.Time
+	events []traceEvent
+}
+
+// traceEvent is one Chrome trace event. Timestamps are in microseconds.
+type traceEvent struct {
+	Name  string            `json:"name"`
+	Cat   string            `json:"cat,omitempty"`
+	Phase string            `json:"ph"`
+	TS    float64           `json:"ts"`
+	Dur   float64           `json:"dur,omitempty"`
+	PID   int               `json:"pid"`
+	TID   int               `json:"tid"`
+	Args  map[string]string `json:"args,omitempty"`
+}
+
+// NewTraceRecorder creates an empty recorder. Timestamps in the exported
+// trace are relative to its creation.
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{start: time.Now()}
+}
+
+// Len returns the number of recorded events.
+func (r *TraceRecorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// Reset discards all recorded events.
+func (r *TraceRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = r.events[:0]
+}
+
+// WriteChromeTrace writes the recorded events as a Chrome trace JSON object.
+func (r *TraceRecorder) WriteChromeTrace(w io.Writer) error {
+	r.mu.Lock()
+	events := make([]traceEvent, 0, len(r.events)+2)
+	events = append(events,
+		traceEvent{Name: "thread_name", Phase: "M", PID: 1, TID: traceTIDCPU, Args: map[string]string{"name": "CPU"}},
+		traceEvent{Name: "thread_name", Phase: "M", PID: 1, TID: traceTIDGPU, Args: map[string]string{"name": "GPU"}},
+	)
+	events = append(events, r.events...)
+	r.mu.Unlock()
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// add appends a complete ("X") event.
+func (r *TraceRecorder) add(name, cat string, tid int, start time.Time, dur time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, traceEvent{
+		Name:  name,
+		Cat:   cat,
+		Phase: "X",
+		TS:    float64(start.Sub(r.start)) / float64(time.Microsecond),
+		Dur:   float64(dur) / float64(time.Microsecond),
+		PID:   1,
+		TID:   tid,
+	})
+}
+
+// addGPUTimings records GPU passes on the GPU track, starting at anchor.
+func (r *TraceRecorder) addGPUTimings(anchor time.Time, timings []PassTiming) {
+	for _, t := range timings {
+		r.add(t.Label, "gpu", traceTIDGPU, anchor.Add(t.Offset), t.Duration)
+	}
+}
+
+// traceSpan is an open wgpu trace span. The zero value is a no-op.
+type traceSpan struct {
+	region *trace.Region
+	rec    *TraceRecorder
+	name   string
+	start  time.Time
+}
+
+// startSpan opens a runtime/trace region and, when a recorder is installed,
+// a CPU span. Callers defer End.
+func startSpan(name string) traceSpan {
+	s := traceSpan{name: name}
+	if trace.IsEnabled() {
+		s.region = trace.StartRegion(context.Background(), name)
+	}
+	if rec := traceRecorder.Load(); rec != nil {
+		s.rec = rec
+		s.start = time.Now()
+	}
+	return s
+}
+
+// End closes the span.
+func (s traceSpan) End() {
+	if s.region != nil {
+		s.region.End()
+	}
+	if s.rec != nil {
+		s.rec.add(s.name, "cpu", traceTIDCPU, s.start, time.Since(s.start))
+	}
+}
@@ -0,0 +1,93 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type chromeTrace struct {
+	TraceEvents []traceEvent `json:"traceEvents"`
+}
+
+func decodeChromeTrace(t *testing.T, rec *TraceRecorder) chromeTrace {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := rec.WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("WriteChromeTrace: %v", err)
+	}
+	var out chromeTrace
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid trace JSON: %v", err)
+	}
+	return out
+}
+
+func TestTraceRecorderCPUSpans(t *testing.T) {
+	rec := NewTraceRecorder()
+	SetTraceRecorder(rec)
+	defer SetTraceRecorder(nil)
+
+	inst, err := CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	defer inst.Release()
+
+	span := startSpan("wgpu.Test")
+	time.Sleep(time.Millisecond)
+	span.End()
+	SetTraceRecorder(nil)
+	startSpan("wgpu.Untraced").End()
+
+	found := map[string]traceEvent{}
+	for _, ev := range decodeChromeTrace(t, rec).TraceEvents {
+		found[ev.Name] = ev
+	}
+	if _, ok := found["wgpu.CreateInstance"]; !ok {
+		t.Error("missing wgpu.CreateInstance span")
+	}
+	ev, ok := found["wgpu.Test"]
+	if !ok {
+		t.Fatal("missing wgpu.Test span")
+	}
+	if ev.Phase != "X" || ev.TID != traceTIDCPU || ev.Dur < 1000 {
+		t.Errorf("wgpu.Test = %+v, want complete CPU event of at least 1000us", ev)
+	}
+	if _, ok := found["wgpu.Untraced"]; ok {
+		t.Error("span recorded after SetTraceRecorder(nil)")
+	}
+
+	rec.Reset()
+	if rec.Len() != 0 {
+		t.Errorf("Len after Reset = %d, want 0", rec.Len())
+	}
+}
+
+func TestTraceRecorderGPUTimings(t *testing.T) {
+	rec := NewTraceRecorder()
+	anchor := rec.start.Add(10 * time.Millisecond)
+	rec.addGPUTimings(anchor, []PassTiming{
+		{Label: "shadow", Duration: 2 * time.Millisecond},
+		{Label: "main", Duration: 3 * time.Millisecond, Offset: 2500 * time.Microsecond},
+	})
+
+	var gpu []traceEvent
+	for _, ev := range decodeChromeTrace(t, rec).TraceEvents {
+		if ev.TID == traceTIDGPU && ev.Phase == "X" {
+			gpu = append(gpu, ev)
+		}
+	}
+	if len(gpu) != 2 {
+		t.Fatalf("got %d GPU events, want 2", len(gpu))
+	}
+	if gpu[0].Name != "shadow" || gpu[0].TS != 10000 || gpu[0].Dur != 2000 {
+		t.Errorf("shadow = %+v", gpu[0])
+	}
+	if gpu[1].Name != "main" || gpu[1].TS != 12500 || gpu[1].Dur != 3000 {
+		t.Errorf("main = %+v", gpu[1])
+	}
+}
@@ -0,0 +1,27 @@
+package wgpu
+
+// TrimReport describes the memory Device.Trim returned. Backends that do
+// not pool memory, and the Rust and browser backends, report zeros.
+type TrimReport struct {
+	// DeviceMemory is the number of bytes of empty device memory blocks the
+	// backend allocator freed. Only Vulkan suballocates from pooled blocks;
+	// DX12, Metal and GLES free memory with each resource.
+	DeviceMemory uint64
+	// DeviceMemoryBlocks is the number of device memory blocks freed.
+	DeviceMemoryBlocks int
+	// StagingMemory is the number of bytes of idle staging buffers kept for
+	// Queue.ReadBufferAsync that were released.
+	StagingMemory uint64
+	// CommandEncoders is the number of idle pooled command encoders that
+	// were destroyed, with their DX12 command allocator or Vulkan command
+	// pool.
+	CommandEncoders int
+	// CommandPools is the number of idle Vulkan command pools destroyed.
+	CommandPools int
+}
+
+// Reclaimed returns the bytes of memory Trim released: DeviceMemory plus
+// StagingMemory. Command encoders and pools are not sized.
+func (r TrimReport) Reclaimed() uint64 {
+	return r.DeviceMemory + r.StagingMemory
+}
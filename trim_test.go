@@ -0,0 +1,56 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"testing"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestDeviceTrimReleasesIdleEncoders(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+	defer device.Release()
+	requireHAL(t, device)
+
+	enc, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	cmd, err := enc.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := device.Queue().Submit(cmd); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if err := device.WaitIdle(); err != nil {
+		t.Fatalf("WaitIdle: %v", err)
+	}
+
+	report := device.Trim()
+	if report.Reclaimed() != report.DeviceMemory+report.StagingMemory {
+		t.Errorf("Reclaimed() = %d, want %d", report.Reclaimed(), report.DeviceMemory+report.StagingMemory)
+	}
+	if again := device.Trim(); again.CommandEncoders != 0 || again.StagingMemory != 0 {
+		t.Errorf("second Trim() = %+v, want no encoders or staging memory", again)
+	}
+
+	// The device keeps working after a trim.
+	enc, err = device.CreateCommandEncoder(nil)
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder after Trim: %v", err)
+	}
+	enc.DiscardEncoding()
+}
+
+func TestDeviceTrimAfterRelease(t *testing.T) {
+	inst, _, device := newDevice(t)
+	defer inst.Release()
+	device.Release()
+
+	if got := device.Trim(); got != (wgpu.TrimReport{}) {
+		t.Errorf("Trim() on released device = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,241 @@
+package wgpu
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// ErrElementType is returned by the typed data helpers for element types
+	// that have no fixed GPU representation: types containing pointers,
+	// slices, maps, strings, interfaces, bools, or the platform-sized int,
+	// uint and uintptr.
+	ErrElementType = errors.New("wgpu: element type has no fixed GPU representation")
+
+	// ErrElementSize is returned by BytesToSlice and Buffer.ReadStructured
+	// when the byte length is not a whole number of elements.
+	ErrElementSize = errors.New("wgpu: byte length is not a multiple of the element size")
+)
+
+// hostLittleEndian reports whether the host stores scalars in the byte order
+// shaders read them in. Only then can Go memory be copied to a buffer as is.
+var hostLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// SliceToBytes encodes data as the little-endian bytes a shader reads, laid
+// out as in Go memory: elements are unsafe.Sizeof(T) apart and struct
+// padding is zero-filled only as far as Go zeroes it. The result is a copy;
+// on little-endian hosts it is a plain memory copy, elsewhere every scalar
+// is byte-swapped.
+//
+// T must be a fixed-size scalar (int8 to int64, uint8 to uint64, float32,
+// float64) or an array or struct of them; other types return
+// ErrElementType. Go alignment rules are not GPU layout rules: a struct the
+// shader declares with vec3 members or uniform padding needs explicit pad
+// fields, or use package layout.
+func SliceToBytes[T any](data []T) ([]byte, error) {
+	el, err := elementLayoutOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data)*int(el.size))
+	if len(data) > 0 {
+		el.encode(out, unsafe.Pointer(unsafe.SliceData(data)))
+	}
+	return out, nil
+}
+
+// BytesToSlice decodes little-endian bytes, such as a mapped readback range,
+// into a new []T. len(b) must be a multiple of unsafe.Sizeof(T), or it
+// returns ErrElementSize. T follows the rules of SliceToBytes. The result
+// does not alias b, so it stays valid after the buffer is unmapped, and is
+// correctly aligned for T wherever b starts.
+func BytesToSlice[T any](b []byte) ([]T, error) {
+	el, err := elementLayoutOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(b))%el.size != 0 {
+		return nil, fmt.Errorf("wgpu: BytesToSlice: %d bytes for %v of size %d: %w",
+			len(b), el.typ, el.size, ErrElementSize)
+	}
+	out := make([]T, uint64(len(b))/el.size)
+	if len(out) > 0 {
+		el.decode(unsafe.Pointer(unsafe.SliceData(out)), b)
+	}
+	return out, nil
+}
+
+// WriteStructured writes v to the buffer at offset through the device queue,
+// encoded as by SliceToBytes. v is a value of a supported element type, a
+// non-nil pointer to one, or a slice of them. The buffer needs
+// BufferUsageCopyDst and the encoded size must be a multiple of 4, as for
+// Queue.WriteBuffer.
+func (b *Buffer) WriteStructured(offset uint64, v any) error {
+	if b == nil || b.device == nil {
+		return ErrReleased
+	}
+	ptr, el, n, err := structuredTarget(v, false)
+	if err != nil {
+		return fmt.Errorf("wgpu: Buffer.WriteStructured: %w", err)
+	}
+	data := make([]byte, uint64(n)*el.size)
+	if n > 0 {
+		el.encode(data, ptr)
+	}
+	return b.device.Queue().WriteBuffer(b, offset, data)
+}
+
+// ReadStructured copies buffer contents starting at offset into v and
+// blocks until the copy completes or ctx is done. v is a non-nil pointer to
+// a value of a supported element type, or a slice whose length sets how
+// many elements are read. It uses Queue.ReadBufferAsync, so the buffer needs
+// BufferUsageCopySrc and offset and the read size must be multiples of 4.
+// On error v is left unchanged.
+func (b *Buffer) ReadStructured(ctx context.Context, offset uint64, v any) error {
+	if b == nil || b.device == nil {
+		return ErrReleased
+	}
+	ptr, el, n, err := structuredTarget(v, true)
+	if err != nil {
+		return fmt.Errorf("wgpu: Buffer.ReadStructured: %w", err)
+	}
+	data := make([]byte, uint64(n)*el.size)
+	done, err := b.device.Queue().ReadBufferAsync(ctx, b, offset, data)
+	if err != nil {
+		return err
+	}
+	if err := <-done; err != nil {
+		return err
+	}
+	if n > 0 {
+		el.decode(ptr, data)
+	}
+	return nil
+}
+
+// structuredTarget resolves the argument of WriteStructured or
+// ReadStructured to the address of its first element, the element layout
+// and the element count. Reads need memory they can fill, so they take only
+// pointers and slices.
+func structuredTarget(v any, forRead bool) (unsafe.Pointer, *elementLayout, int, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return nil, nil, 0, fmt.Errorf("nil value: %w", ErrElementType)
+	case rv.Kind() == reflect.Slice:
+		el, err := elementLayoutOf(rv.Type().Elem())
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		return rv.UnsafePointer(), el, rv.Len(), nil
+	case rv.Kind() == reflect.Pointer:
+		if rv.IsNil() {
+			return nil, nil, 0, fmt.Errorf("nil %v: %w", rv.Type(), ErrElementType)
+		}
+		el, err := elementLayoutOf(rv.Type().Elem())
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		return rv.UnsafePointer(), el, 1, nil
+	case forRead:
+		return nil, nil, 0, fmt.Errorf("%v is not a pointer or slice: %w", rv.Type(), ErrElementType)
+	}
+	el, err := elementLayoutOf(rv.Type())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	cp := reflect.New(rv.Type())
+	cp.Elem().Set(rv)
+	return cp.UnsafePointer(), el, 1, nil
+}
+
+// elementLayout records where the multi-byte scalars of an element type sit,
+// which is all big-endian hosts need to swap.
+type elementLayout struct {
+	typ     reflect.Type
+	size    uint64
+	scalars []scalarSpan // multi-byte scalars, by offset
+}
+
+// scalarSpan is a scalar of size bytes at offset within an element.
+type scalarSpan struct {
+	offset, size uint64
+}
+
+var elementLayouts sync.Map // map[reflect.Type]*elementLayout
+
+// elementLayoutOf returns the cached layout of t, or ErrElementType.
+func elementLayoutOf(t reflect.Type) (*elementLayout, error) {
+	if cached, ok := elementLayouts.Load(t); ok {
+		return cached.(*elementLayout), nil
+	}
+	el := &elementLayout{typ: t, size: uint64(t.Size())}
+	if el.size == 0 {
+		return nil, fmt.Errorf("%v has size 0: %w", t, ErrElementType)
+	}
+	if err := el.collect(t, 0); err != nil {
+		return nil, err
+	}
+	actual, _ := elementLayouts.LoadOrStore(t, el)
+	return actual.(*elementLayout), nil
+}
+
+func (el *elementLayout) collect(t reflect.Type, offset uint64) error {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Uint8:
+	case reflect.Int16, reflect.Uint16, reflect.Int32, reflect.Uint32,
+		reflect.Int64, reflect.Uint64, reflect.Float32, reflect.Float64:
+		el.scalars = append(el.scalars, scalarSpan{offset: offset, size: uint64(t.Size())})
+	case reflect.Array:
+		elem := t.Elem()
+		for i := 0; i < t.Len(); i++ {
+			if err := el.collect(elem, offset+uint64(i)*uint64(elem.Size())); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if err := el.collect(f.Type, offset+uint64(f.Offset)); err != nil {
+				return fmt.Errorf("%v.%s: %w", t, f.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%v: %w", t, ErrElementType)
+	}
+	return nil
+}
+
+// encode writes len(dst)/el.size elements starting at src into dst.
+func (el *elementLayout) encode(dst []byte, src unsafe.Pointer) {
+	copy(dst, unsafe.Slice((*byte)(src), len(dst)))
+	el.swap(dst)
+}
+
+// decode writes the elements in src to memory starting at dst.
+func (el *elementLayout) decode(dst unsafe.Pointer, src []byte) {
+	out := unsafe.Slice((*byte)(dst), len(src))
+	copy(out, src)
+	el.swap(out)
+}
+
+// swap converts every scalar in buf between host and little-endian order.
+// It is a no-op on little-endian hosts.
+func (el *elementLayout) swap(buf []byte) {
+	if hostLittleEndian || len(el.scalars) == 0 {
+		return
+	}
+	for base := uint64(0); base < uint64(len(buf)); base += el.size {
+		for _, s := range el.scalars {
+			v := buf[base+s.offset : base+s.offset+s.size]
+			for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+				v[i], v[j] = v[j], v[i]
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package wgpu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+type typedParticle struct {
+	Pos   [2]float32
+	Mass  float32
+	Index uint32
+	Flags uint16
+	_     uint16
+}
+
+func TestSliceToBytes(t *testing.T) {
+	got, err := SliceToBytes([]uint32{1, 0x01020304})
+	if err != nil {
+		t.Fatalf("SliceToBytes: %v", err)
+	}
+	if want := []byte{1, 0, 0, 0, 4, 3, 2, 1}; !bytes.Equal(got, want) {
+		t.Errorf("SliceToBytes = %v, want %v", got, want)
+	}
+
+	got, err = SliceToBytes([]float32{1.5})
+	if err != nil || math.Float32frombits(binary.LittleEndian.Uint32(got)) != 1.5 {
+		t.Errorf("SliceToBytes(1.5) = %v, %v", got, err)
+	}
+
+	if got, err := SliceToBytes[uint32](nil); err != nil || len(got) != 0 {
+		t.Errorf("SliceToBytes(nil) = %v, %v", got, err)
+	}
+}
+
+func TestBytesToSliceRoundTrip(t *testing.T) {
+	in := []typedParticle{
+		{Pos: [2]float32{1, -2}, Mass: 0.5, Index: 7, Flags: 3},
+		{Pos: [2]float32{3, 4}, Mass: 2, Index: 1 << 20, Flags: 0xffff},
+	}
+	data, err := SliceToBytes(in)
+	if err != nil {
+		t.Fatalf("SliceToBytes: %v", err)
+	}
+	if len(data) != 2*20 {
+		t.Fatalf("len = %d, want 40", len(data))
+	}
+	// Offset 1 misaligns the source for typedParticle; the copy fixes that.
+	out, err := BytesToSlice[typedParticle](append([]byte{0xaa}, data...)[1:])
+	if err != nil {
+		t.Fatalf("BytesToSlice: %v", err)
+	}
+	if !slices.Equal(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+
+	if _, err := BytesToSlice[uint32](data[:6]); !errors.Is(err, ErrElementSize) {
+		t.Errorf("BytesToSlice(6 bytes) err = %v, want ErrElementSize", err)
+	}
+}
+
+func TestTypedDataRejectsUnsupportedTypes(t *testing.T) {
+	if _, err := SliceToBytes([]int{1}); !errors.Is(err, ErrElementType) {
+		t.Errorf("int: err = %v, want ErrElementType", err)
+	}
+	if _, err := SliceToBytes([]bool{true}); !errors.Is(err, ErrElementType) {
+		t.Errorf("bool: err = %v, want ErrElementType", err)
+	}
+	type withPointer struct {
+		A float32
+		P *float32
+	}
+	if _, err := SliceToBytes([]withPointer{{}}); !errors.Is(err, ErrElementType) {
+		t.Errorf("pointer field: err = %v, want ErrElementType", err)
+	}
+	if _, err := BytesToSlice[struct{}](nil); !errors.Is(err, ErrElementType) {
+		t.Errorf("empty struct: err = %v, want ErrElementType", err)
+	}
+	if _, _, _, err := structuredTarget(typedParticle{}, true); !errors.Is(err, ErrElementType) {
+		t.Errorf("read into a value: err = %v, want ErrElementType", err)
+	}
+}
+
+func TestTypedDataBigEndianSwap(t *testing.T) {
+	saved := hostLittleEndian
+	hostLittleEndian = false
+	t.Cleanup(func() { hostLittleEndian = saved })
+
+	// Pretend Go memory is big-endian: every multi-byte scalar is reversed
+	// and bytes stay in place.
+	type mixed struct {
+		A uint16
+		B uint8
+		_ uint8
+		C uint32
+	}
+	got, err := SliceToBytes([]mixed{{A: 0x0102, B: 9, C: 0x03040506}})
+	if err != nil {
+		t.Fatalf("SliceToBytes: %v", err)
+	}
+	native, _ := sliceBytesNative([]mixed{{A: 0x0102, B: 9, C: 0x03040506}})
+	want := []byte{native[1], native[0], native[2], native[3], native[7], native[6], native[5], native[4]}
+	if !bytes.Equal(got, want) {
+		t.Errorf("swapped = %v, want %v", got, want)
+	}
+	back, err := BytesToSlice[mixed](got)
+	if err != nil || back[0].A != 0x0102 || back[0].B != 9 || back[0].C != 0x03040506 {
+		t.Errorf("swap round trip = %+v, %v", back, err)
+	}
+}
+
+// sliceBytesNative returns data's memory without any byte swapping.
+func sliceBytesNative[T any](data []T) ([]byte, error) {
+	saved := hostLittleEndian
+	hostLittleEndian = true
+	defer func() { hostLittleEndian = saved }()
+	return SliceToBytes(data)
+}
@@ -11,6 +11,10 @@ const MinBindGroups = 4
 // Devices may support up to 8, but only MinBindGroups (4) is guaranteed.
 const MaxBindGroups = 8
 
+// WholeSize passed as the size of a vertex or index buffer binding selects
+// the rest of the buffer after the offset.
+const WholeSize = ^uint64(0)
+
 // Backend types
 type Backend = gputypes.Backend
 type Backends = gputypes.Backends
@@ -78,12 +82,16 @@ const (
 
 // Commonly used texture format constants
 const (
-	TextureFormatRGBA8Unorm     = gputypes.TextureFormatRGBA8Unorm
-	TextureFormatRGBA8UnormSrgb = gputypes.TextureFormatRGBA8UnormSrgb
-	TextureFormatBGRA8Unorm     = gputypes.TextureFormatBGRA8Unorm
-	TextureFormatBGRA8UnormSrgb = gputypes.TextureFormatBGRA8UnormSrgb
-	TextureFormatDepth24Plus    = gputypes.TextureFormatDepth24Plus
-	TextureFormatDepth32Float   = gputypes.TextureFormatDepth32Float
+	TextureFormatUndefined            = gputypes.TextureFormatUndefined
+	TextureFormatRGBA8Unorm           = gputypes.TextureFormatRGBA8Unorm
+	TextureFormatRGBA8UnormSrgb       = gputypes.TextureFormatRGBA8UnormSrgb
+	TextureFormatBGRA8Unorm           = gputypes.TextureFormatBGRA8Unorm
+	TextureFormatBGRA8UnormSrgb       = gputypes.TextureFormatBGRA8UnormSrgb
+	TextureFormatDepth16Unorm         = gputypes.TextureFormatDepth16Unorm
+	TextureFormatDepth24Plus          = gputypes.TextureFormatDepth24Plus
+	TextureFormatDepth24PlusStencil8  = gputypes.TextureFormatDepth24PlusStencil8
+	TextureFormatDepth32Float         = gputypes.TextureFormatDepth32Float
+	TextureFormatDepth32FloatStencil8 = gputypes.TextureFormatDepth32FloatStencil8
 )
 
 // Shader types
@@ -161,5 +169,6 @@ const (
 // Default functions (re-exported for convenience)
 var (
 	DefaultLimits             = gputypes.DefaultLimits
+	DownlevelLimits           = gputypes.DownlevelLimits
 	DefaultInstanceDescriptor = gputypes.DefaultInstanceDescriptor
 )
@@ -0,0 +1,111 @@
+package wgpu
+
+import (
+	"context"
+	"fmt"
+)
+
+// Default chunking of Queue.UploadStream.
+const (
+	// uploadStreamDefaultChunkSize keeps each chunk within one staging
+	// buffer well below the 64 MiB cap on staging allocations.
+	uploadStreamDefaultChunkSize = 4 << 20
+
+	// uploadStreamDefaultMaxInFlight bounds staging memory to 16 MiB with
+	// the default chunk size.
+	uploadStreamDefaultMaxInFlight = 4
+)
+
+// UploadStreamDescriptor describes a chunked upload for Queue.UploadStream.
+type UploadStreamDescriptor struct {
+	// Buffer is the destination. It needs BufferUsageCopyDst.
+	Buffer *Buffer
+
+	// Offset is where the upload starts in Buffer, a multiple of 4.
+	Offset uint64
+
+	// Data is the source, typically FileRegion.Bytes of a mapped asset
+	// file. Its length must be a multiple of 4. UploadStream reads it one
+	// chunk at a time, so pages of a mapped file are faulted in as they
+	// are needed instead of all at once.
+	Data []byte
+
+	// ChunkSize is the number of bytes copied and submitted at a time,
+	// rounded down to a multiple of 4. Zero means 4 MiB.
+	ChunkSize uint64
+
+	// MaxInFlight is the number of submitted chunks the GPU may not have
+	// copied yet. When it is reached, UploadStream waits for the oldest
+	// chunk before reading the next, which bounds the staging memory to
+	// MaxInFlight * ChunkSize. Zero means 4.
+	MaxInFlight int
+
+	// Progress, if set, is called on the calling goroutine after each
+	// chunk is submitted, with the bytes submitted so far and len(Data).
+	Progress func(uploaded, total uint64)
+}
+
+// UploadStream copies desc.Data into desc.Buffer in chunks, each written
+// like Queue.WriteBuffer and submitted on its own, so multi-gigabyte assets
+// can be streamed from a mapped file without holding them in Go heap memory
+// or in staging memory all at once. It returns the submission index of the
+// last chunk; wait for it with OnSubmittedWorkDone before reading the
+// buffer on the CPU. desc.Data is not used after UploadStream returns.
+//
+// ctx bounds the waits for chunks in flight. When it ends, UploadStream
+// returns an error matching ctx.Err() along with the index of the last
+// chunk it submitted; the chunks submitted so far still complete.
+func (q *Queue) UploadStream(ctx context.Context, desc *UploadStreamDescriptor) (uint64, error) {
+	if q == nil || desc == nil || desc.Buffer == nil {
+		return 0, fmt.Errorf("wgpu: Queue.UploadStream: queue, descriptor or buffer is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	total := uint64(len(desc.Data))
+	if desc.Offset%4 != 0 || total%4 != 0 || desc.Offset > desc.Buffer.Size() || total > desc.Buffer.Size()-desc.Offset {
+		return 0, fmt.Errorf("wgpu: Queue.UploadStream: offset %d + %d bytes in buffer of size %d: "+
+			"offset and size must be multiples of 4 and fit the buffer", desc.Offset, total, desc.Buffer.Size())
+	}
+	chunkSize := desc.ChunkSize &^ 3
+	if desc.ChunkSize == 0 {
+		chunkSize = uploadStreamDefaultChunkSize
+	}
+	if chunkSize == 0 {
+		return 0, fmt.Errorf("wgpu: Queue.UploadStream: chunk size %d is smaller than 4 bytes", desc.ChunkSize)
+	}
+	maxInFlight := desc.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = uploadStreamDefaultMaxInFlight
+	}
+
+	var last uint64
+	inFlight := make([]uint64, 0, maxInFlight)
+	for uploaded := uint64(0); uploaded < total; {
+		if err := ctx.Err(); err != nil {
+			return last, err
+		}
+		if len(inFlight) == maxInFlight {
+			if err := <-q.OnSubmittedWorkDone(ctx, inFlight[0]); err != nil {
+				return last, fmt.Errorf("wgpu: Queue.UploadStream: %w", err)
+			}
+			inFlight = append(inFlight[:0], inFlight[1:]...)
+		}
+
+		n := min(chunkSize, total-uploaded)
+		if err := q.WriteBuffer(desc.Buffer, desc.Offset+uploaded, desc.Data[uploaded:uploaded+n]); err != nil {
+			return last, fmt.Errorf("wgpu: Queue.UploadStream: chunk at %d: %w", uploaded, err)
+		}
+		idx, err := q.Submit()
+		if err != nil {
+			return last, fmt.Errorf("wgpu: Queue.UploadStream: chunk at %d: %w", uploaded, err)
+		}
+		last = idx
+		inFlight = append(inFlight, idx)
+		uploaded += n
+		if desc.Progress != nil {
+			desc.Progress(uploaded, total)
+		}
+	}
+	return last, nil
+}
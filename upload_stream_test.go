@@ -0,0 +1,170 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu"
+)
+
+func TestUploadStreamFromFileRegion(t *testing.T) {
+	device := newSoftwareDevice(t)
+
+	content := make([]byte, 3*4096)
+	for i := range content {
+		content[i] = byte(i * 7)
+	}
+	path := filepath.Join(t.TempDir(), "asset.bin")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The region starts off a page boundary and outlives the file.
+	region, err := wgpu.MapFileRegion(f, 4100, 1000)
+	f.Close()
+	if err != nil {
+		t.Fatalf("MapFileRegion: %v", err)
+	}
+	defer region.Close()
+	if !bytes.Equal(region.Bytes(), content[4100:5100]) {
+		t.Fatal("mapped bytes differ from the file")
+	}
+
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "stream-dst",
+		Size:  1024,
+		Usage: wgpu.BufferUsageCopyDst | wgpu.BufferUsageCopySrc,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var progress []uint64
+	_, err = device.Queue().UploadStream(ctx, &wgpu.UploadStreamDescriptor{
+		Buffer:      buf,
+		Offset:      8,
+		Data:        region.Bytes(),
+		ChunkSize:   150, // rounded down to 148
+		MaxInFlight: 2,
+		Progress: func(uploaded, total uint64) {
+			if total != 1000 {
+				t.Errorf("Progress total = %d, want 1000", total)
+			}
+			progress = append(progress, uploaded)
+		},
+	})
+	if err != nil {
+		t.Fatalf("UploadStream: %v", err)
+	}
+	if want := []uint64{148, 296, 444, 592, 740, 888, 1000}; !slices.Equal(progress, want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+
+	got := make([]byte, 1000)
+	done, err := device.Queue().ReadBufferAsync(ctx, buf, 8, got)
+	if err != nil {
+		t.Fatalf("ReadBufferAsync: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("ReadBufferAsync completion: %v", err)
+	}
+	if !bytes.Equal(got, content[4100:5100]) {
+		t.Error("buffer contents differ from the file region")
+	}
+
+	if err := region.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if err := region.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestMapFileRegionBounds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "short.bin")
+	if err := os.WriteFile(path, make([]byte, 5000), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		name         string
+		offset, size int64
+	}{
+		{"past EOF", 4100, 1000},
+		{"offset past EOF", 6000, 1},
+		{"negative offset", -1, 10},
+		{"zero size", 0, 0},
+		{"negative size", 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := wgpu.MapFileRegion(f, tt.offset, tt.size)
+			if err == nil {
+				region.Close()
+				t.Fatalf("MapFileRegion(%d, %d) succeeded, want an error", tt.offset, tt.size)
+			}
+		})
+	}
+
+	region, err := wgpu.MapFileRegion(f, 4000, 1000)
+	if err != nil {
+		t.Fatalf("MapFileRegion up to EOF: %v", err)
+	}
+	if len(region.Bytes()) != 1000 {
+		t.Errorf("len = %d, want 1000", len(region.Bytes()))
+	}
+	region.Close()
+}
+
+func TestUploadStreamValidation(t *testing.T) {
+	device := newSoftwareDevice(t)
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{Size: 64, Usage: wgpu.BufferUsageCopyDst})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+	queue := device.Queue()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		desc *wgpu.UploadStreamDescriptor
+	}{
+		{"nil descriptor", nil},
+		{"nil buffer", &wgpu.UploadStreamDescriptor{Data: make([]byte, 4)}},
+		{"unaligned size", &wgpu.UploadStreamDescriptor{Buffer: buf, Data: make([]byte, 6)}},
+		{"unaligned offset", &wgpu.UploadStreamDescriptor{Buffer: buf, Offset: 2, Data: make([]byte, 4)}},
+		{"out of range", &wgpu.UploadStreamDescriptor{Buffer: buf, Offset: 32, Data: make([]byte, 64)}},
+		{"tiny chunks", &wgpu.UploadStreamDescriptor{Buffer: buf, Data: make([]byte, 8), ChunkSize: 3}},
+	}
+	for _, tt := range tests {
+		if _, err := queue.UploadStream(ctx, tt.desc); err == nil {
+			t.Errorf("%s: UploadStream succeeded", tt.name)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := queue.UploadStream(canceled, &wgpu.UploadStreamDescriptor{Buffer: buf, Data: make([]byte, 8)}); !errors.Is(err, context.Canceled) {
+		t.Errorf("UploadStream with canceled context error = %v, want context.Canceled", err)
+	}
+}
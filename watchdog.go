@@ -0,0 +1,76 @@
+package wgpu
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultWatchdogDeadline is the deadline of a WatchdogDescriptor that
+// leaves Deadline zero.
+const DefaultWatchdogDeadline = 10 * time.Second
+
+// WatchdogDescriptor enables the GPU hang watchdog of an instance, set with
+// InstanceDescriptor.Watchdog. The watchdog tracks the submissions of every
+// device of the instance and flags a hang when one has not completed within
+// Deadline, as happens when a WGSL shader loops forever. The device is then
+// lost: Queue.Submit returns ErrDeviceLost, and the next Device.Poll or
+// Tick passes it to pending callbacks and reports the loss once as an
+// ErrorFilterInternal error through the uncaptured error callback, unless
+// a failed Submit reported it first. Callbacks therefore still run only on
+// the goroutine driving the device. The GPU work itself cannot be canceled;
+// release the device to recover.
+type WatchdogDescriptor struct {
+	// Deadline is how long a submission may run before it counts as hung.
+	// It should exceed the longest legitimate submission, such as a large
+	// compute job. Zero means DefaultWatchdogDeadline.
+	Deadline time.Duration
+
+	// OnHang, if set, is called with the report of each hang before the
+	// device is lost, on the watchdog's goroutine. The report is also part
+	// of the ErrDeviceLost error, which the default uncaptured error policy
+	// logs at error level.
+	OnHang func(*HangReport)
+}
+
+// HangReport describes a submission the watchdog flagged as hung.
+type HangReport struct {
+	// Device is the device that hung.
+	Device *Device
+
+	// Submission is the index of the oldest submission that has not
+	// completed, and Elapsed how long ago it was submitted.
+	Submission uint64
+	Elapsed    time.Duration
+
+	// CommandBuffers are the labels of the command buffers in flight, from
+	// the oldest incomplete submission on, in submission order. Unlabeled
+	// command buffers are listed as "".
+	CommandBuffers []string
+
+	// DebugMarkers are the labels of the last render and compute passes
+	// recorded in those command buffers, oldest first, which narrow down
+	// the shader that hung. At most 16 are kept.
+	DebugMarkers []string
+}
+
+// String formats the report for logs.
+func (r *HangReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "submission %d has not completed after %v", r.Submission, r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(&sb, "; command buffers in flight: %s", quoteLabels(r.CommandBuffers))
+	fmt.Fprintf(&sb, "; last passes: %s", quoteLabels(r.DebugMarkers))
+	return sb.String()
+}
+
+// quoteLabels lists labels for HangReport.String.
+func quoteLabels(labels []string) string {
+	if len(labels) == 0 {
+		return "none"
+	}
+	quoted := make([]string, len(labels))
+	for i, label := range labels {
+		quoted[i] = fmt.Sprintf("%q", label)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,202 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxHangMarkers bounds the pass labels a command buffer keeps for hang
+// reports, and the labels a HangReport carries.
+const maxHangMarkers = 16
+
+// watchdog is the GPU hang watchdog of an instance. Its goroutine checks
+// the submissions of the instance's devices every quarter deadline.
+type watchdog struct {
+	deadline time.Duration
+	onHang   func(*HangReport)
+	stop     chan struct{}
+
+	// mu is held while devices are checked. Device.Release takes it once
+	// after marking the device released, so no check is still polling the
+	// HAL queue when the device is destroyed.
+	mu sync.Mutex
+}
+
+// newWatchdog returns the watchdog desc describes. Start it with run.
+func newWatchdog(desc *WatchdogDescriptor) *watchdog {
+	deadline := desc.Deadline
+	if deadline <= 0 {
+		deadline = DefaultWatchdogDeadline
+	}
+	return &watchdog{deadline: deadline, onHang: desc.OnHang, stop: make(chan struct{})}
+}
+
+// run checks the devices of instance until close is called.
+func (w *watchdog) run(instance *Instance) {
+	ticker := time.NewTicker(max(w.deadline/4, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			w.checkDevices(instance.watchedDevices(), now)
+		}
+	}
+}
+
+// close stops the watchdog goroutine. It does not wait for a check in
+// progress, which may be running OnHang.
+func (w *watchdog) close() {
+	close(w.stop)
+}
+
+// checkDevices loses every device whose oldest incomplete submission is
+// older than the deadline. OnHang and the device-lost path run after mu is
+// released, so they may release the device.
+func (w *watchdog) checkDevices(devices []*Device, now time.Time) {
+	var hangs []*HangReport
+	w.mu.Lock()
+	for _, d := range devices {
+		if d.released.Load() || d.lost.Load() || d.queue == nil || d.queue.hal == nil {
+			continue
+		}
+		if report := d.queue.submissions.overdue(d.queue.hal.PollCompleted(), now, w.deadline); report != nil {
+			report.Device = d
+			hangs = append(hangs, report)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, report := range hangs {
+		if w.onHang != nil {
+			w.onHang(report)
+		}
+		report.Device.lose(fmt.Errorf("wgpu: GPU hang detected by the watchdog: %s: %w", report, ErrDeviceLost))
+	}
+}
+
+// sync waits for a check in progress to finish polling.
+func (w *watchdog) sync() {
+	w.mu.Lock()
+	w.mu.Unlock() //nolint:staticcheck // empty critical section is the barrier
+}
+
+// watchedDevices returns the devices of the instance.
+func (i *Instance) watchedDevices() []*Device {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	devices := make([]*Device, 0, len(i.devices))
+	for d := range i.devices {
+		devices = append(devices, d)
+	}
+	return devices
+}
+
+// watchdog returns the hang watchdog of the device's instance, or nil when
+// the instance has none.
+func (d *Device) watchdog() *watchdog {
+	if d == nil || d.instance == nil {
+		return nil
+	}
+	return d.instance.watchdog
+}
+
+// lose takes the device-lost path: later submissions fail with err, and the
+// next Poll or Tick calls the waiting callbacks with err and reports it once
+// as an uncaptured ErrorFilterInternal error. It runs on the watchdog's
+// goroutine, so it only marks the device; callbacks and error reports wait
+// for the goroutine driving the device.
+func (d *Device) lose(err error) {
+	if !d.lostErr.CompareAndSwap(nil, &err) {
+		return
+	}
+	d.lost.Store(true)
+	d.callbacks.cancelLater(err)
+}
+
+// reportLoss reports the error the device was lost with, if any, as an
+// uncaptured error. Poll calls it; the uncaptured error policy reports a
+// loss only once, so a Submit that already failed with it wins.
+func (d *Device) reportLoss() {
+	if p := d.lostErr.Load(); p != nil {
+		err := *p
+		d.reportUncaptured("Watchdog", &err)
+	}
+}
+
+// submissionLog records the submissions of a queue that may not have
+// completed, for the watchdog. Only queues of devices with a watchdog
+// record them.
+type submissionLog struct {
+	mu      sync.Mutex
+	entries []loggedSubmission
+}
+
+// loggedSubmission is a submission in a submissionLog.
+type loggedSubmission struct {
+	index          uint64
+	submitted      time.Time
+	commandBuffers []string
+	markers        []string
+}
+
+// record logs submission index, made at submitted from commandBuffers.
+func (l *submissionLog) record(index uint64, submitted time.Time, commandBuffers []*CommandBuffer) {
+	entry := loggedSubmission{index: index, submitted: submitted}
+	for _, cb := range commandBuffers {
+		label := ""
+		if cb.core != nil {
+			label = cb.core.Label()
+		}
+		entry.commandBuffers = append(entry.commandBuffers, label)
+		entry.markers = append(entry.markers, cb.passLabels...)
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.mu.Unlock()
+}
+
+// overdue forgets the submissions up to completed and, when the oldest
+// remaining one was submitted deadline or longer before now, returns the
+// report of the hang.
+func (l *submissionLog) overdue(completed uint64, now time.Time, deadline time.Duration) *HangReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for n < len(l.entries) && l.entries[n].index <= completed {
+		n++
+	}
+	l.entries = append(l.entries[:0], l.entries[n:]...)
+	if len(l.entries) == 0 || now.Sub(l.entries[0].submitted) < deadline {
+		return nil
+	}
+
+	report := &HangReport{
+		Submission: l.entries[0].index,
+		Elapsed:    now.Sub(l.entries[0].submitted),
+	}
+	for _, entry := range l.entries {
+		report.CommandBuffers = append(report.CommandBuffers, entry.commandBuffers...)
+		report.DebugMarkers = append(report.DebugMarkers, entry.markers...)
+	}
+	if extra := len(report.DebugMarkers) - maxHangMarkers; extra > 0 {
+		report.DebugMarkers = report.DebugMarkers[extra:]
+	}
+	return report
+}
+
+// recordPass keeps the label of a pass begun on the encoder for hang
+// reports, when the device has a watchdog.
+func (e *CommandEncoder) recordPass(label string) {
+	if e.device.watchdog() == nil {
+		return
+	}
+	if len(e.passLabels) == maxHangMarkers {
+		e.passLabels = append(e.passLabels[:0], e.passLabels[1:]...)
+	}
+	e.passLabels = append(e.passLabels, label)
+}
@@ -0,0 +1,164 @@
+//go:build !rust && !(js && wasm)
+
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogpu/wgpu/hal"
+	"github.com/gogpu/wgpu/hal/software"
+)
+
+func TestSubmissionLogOverdue(t *testing.T) {
+	var log submissionLog
+	start := time.Now()
+	log.entries = []loggedSubmission{
+		{index: 1, submitted: start, commandBuffers: []string{"upload"}},
+		{index: 2, submitted: start.Add(time.Second), commandBuffers: []string{"frame"}, markers: []string{"shadows", "lighting"}},
+		{index: 3, submitted: start.Add(2 * time.Second), commandBuffers: []string{"post"}, markers: []string{"bloom"}},
+	}
+
+	if r := log.overdue(1, start.Add(3*time.Second), 5*time.Second); r != nil {
+		t.Fatalf("overdue before the deadline = %v, want nil", r)
+	}
+	if len(log.entries) != 2 {
+		t.Fatalf("log holds %d submissions after submission 1 completed, want 2", len(log.entries))
+	}
+
+	r := log.overdue(1, start.Add(7*time.Second), 5*time.Second)
+	if r == nil {
+		t.Fatal("overdue past the deadline = nil, want a report")
+	}
+	if r.Submission != 2 || r.Elapsed != 6*time.Second {
+		t.Errorf("report = submission %d after %v, want submission 2 after 6s", r.Submission, r.Elapsed)
+	}
+	if got := strings.Join(r.CommandBuffers, ","); got != "frame,post" {
+		t.Errorf("CommandBuffers = %q, want frame,post", got)
+	}
+	if got := strings.Join(r.DebugMarkers, ","); got != "shadows,lighting,bloom" {
+		t.Errorf("DebugMarkers = %q, want shadows,lighting,bloom", got)
+	}
+	if s := r.String(); !strings.Contains(s, "submission 2") || !strings.Contains(s, `"lighting"`) {
+		t.Errorf("String() = %q, want the submission and pass labels", s)
+	}
+
+	if r := log.overdue(3, start.Add(time.Hour), 5*time.Second); r != nil || len(log.entries) != 0 {
+		t.Errorf("overdue after all completed = %v with %d logged, want nil and none", r, len(log.entries))
+	}
+}
+
+func TestWatchdogLosesHungDevice(t *testing.T) {
+	hal.RegisterBackend(software.API{})
+	hangs := make(chan *HangReport, 1)
+	instance, err := CreateInstance(&InstanceDescriptor{
+		Software: SoftwareOnly,
+		Watchdog: &WatchdogDescriptor{
+			Deadline: 20 * time.Millisecond,
+			OnHang:   func(r *HangReport) { hangs <- r },
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	defer instance.Release()
+	adapter, err := instance.RequestAdapter(&RequestAdapterOptions{ForceFallbackAdapter: true})
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	defer adapter.Release()
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice: %v", err)
+	}
+	defer device.Release()
+	uncaptured := make(chan *GPUError, 4)
+	device.SetUncapturedErrorCallback(func(e *GPUError) { uncaptured <- e })
+
+	// Completed work is not a hang.
+	encoder, err := device.CreateCommandEncoder(&CommandEncoderDescriptor{Label: "frame"})
+	if err != nil {
+		t.Fatalf("CreateCommandEncoder: %v", err)
+	}
+	pass, err := encoder.BeginComputePass(&ComputePassDescriptor{Label: "simulate"})
+	if err != nil {
+		t.Fatalf("BeginComputePass: %v", err)
+	}
+	if err := pass.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	cb, err := encoder.Finish()
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if got := strings.Join(cb.passLabels, ","); got != "simulate" {
+		t.Errorf("command buffer pass labels = %q, want simulate", got)
+	}
+	idx, err := device.Queue().Submit(cb)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	select {
+	case r := <-hangs:
+		t.Fatalf("completed submission reported as hung: %v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The software backend completes everything synchronously, so log a
+	// submission that never completes, with a callback waiting on it.
+	device.queue.submissions.record(idx+1000, time.Now(), []*CommandBuffer{{passLabels: []string{"infinite-loop"}}})
+	var callbackErr error
+	device.callbacks.add(func() (bool, error) { return false, nil }, func(err error) { callbackErr = err })
+	var report *HangReport
+	select {
+	case report = <-hangs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchdog did not report the hung submission")
+	}
+	if report.Device != device || report.Submission != idx+1000 || report.Elapsed < 20*time.Millisecond {
+		t.Errorf("report = %+v, want submission %d of the device past the deadline", report, idx+1000)
+	}
+	if len(report.DebugMarkers) != 1 || report.DebugMarkers[0] != "infinite-loop" {
+		t.Errorf("DebugMarkers = %q, want the hung pass", report.DebugMarkers)
+	}
+
+	// OnHang runs before the device is lost. The watchdog only marks the
+	// device; the loss reaches callbacks and the uncaptured error callback
+	// from the next Poll, on this goroutine.
+	for deadline := time.Now().Add(5 * time.Second); !device.lost.Load(); {
+		if time.Now().After(deadline) {
+			t.Fatal("watchdog did not lose the device")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case e := <-uncaptured:
+		t.Fatalf("device loss reported on the watchdog goroutine: %v", e)
+	default:
+	}
+	if callbackErr != nil {
+		t.Fatalf("callback ran on the watchdog goroutine with %v", callbackErr)
+	}
+	device.Poll(PollPoll)
+	if !errors.Is(callbackErr, ErrDeviceLost) {
+		t.Errorf("callback error after Poll = %v, want ErrDeviceLost", callbackErr)
+	}
+	select {
+	case e := <-uncaptured:
+		if e.Type != ErrorFilterInternal || !strings.Contains(e.Message, "infinite-loop") {
+			t.Errorf("uncaptured error = %v, want an internal error naming the hung pass", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("device loss was not reported")
+	}
+	if _, err := device.Queue().Submit(); !errors.Is(err, ErrDeviceLost) {
+		t.Errorf("Submit after the hang = %v, want ErrDeviceLost", err)
+	}
+	select {
+	case e := <-uncaptured:
+		t.Errorf("device loss reported again: %v", e)
+	default:
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu"
+	"github.com/gogpu/wgpu/core"
 
 	// Import noop backend. The noop backend is intentionally ignored during real
 	// adapter enumeration; tests that require HAL integration use an explicitly
@@ -66,6 +67,15 @@ func requireHAL(t *testing.T, device *wgpu.Device) {
 	}
 }
 
+// requireValidation skips the test when the wgpu_novalidate build tag
+// compiled out the descriptor checks it expects to reject something.
+func requireValidation(t *testing.T) {
+	t.Helper()
+	if !core.ValidationCompiled {
+		t.Skip("skipping: validation compiled out by wgpu_novalidate")
+	}
+}
+
 // --- Instance tests ---
 
 func TestCreateInstance(t *testing.T) {
@@ -130,6 +140,37 @@ func TestInstanceRequestAdapter(t *testing.T) {
 	adapter.Release()
 }
 
+func TestInstanceEnumerateAdapters(t *testing.T) {
+	inst := newInstance(t)
+	defer inst.Release()
+
+	adapters, err := inst.EnumerateAdapters()
+	if err != nil {
+		t.Fatalf("EnumerateAdapters: %v", err)
+	}
+	if len(adapters) == 0 {
+		t.Fatal("EnumerateAdapters returned no adapters")
+	}
+	selected, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter: %v", err)
+	}
+	defer selected.Release()
+	found := false
+	for _, adapter := range adapters {
+		found = found || adapter.Info() == selected.Info()
+		adapter.Release()
+	}
+	if !found {
+		t.Errorf("RequestAdapter chose %q, which EnumerateAdapters did not list", selected.Info().Name)
+	}
+
+	inst.Release()
+	if _, err := inst.EnumerateAdapters(); !errors.Is(err, wgpu.ErrReleased) {
+		t.Errorf("EnumerateAdapters after Release: err = %v, want ErrReleased", err)
+	}
+}
+
 func TestAdapterInfo(t *testing.T) {
 	_, adapter := newAdapter(t)
 	defer adapter.Release()
@@ -210,6 +251,50 @@ func TestRequestDeviceWithDescriptor(t *testing.T) {
 	device.Release()
 }
 
+func TestRequestDeviceFeatureNegotiation(t *testing.T) {
+	_, adapter := newAdapter(t)
+	defer adapter.Release()
+
+	var unsupported wgpu.Features
+	for _, f := range []gputypes.Feature{gputypes.FeatureShaderFloat64, gputypes.FeatureSubgroupBarrier, gputypes.FeatureVertexAttribute64bit} {
+		if !adapter.Features().Contains(f) {
+			unsupported = wgpu.Features(f)
+			break
+		}
+	}
+	if unsupported == 0 {
+		t.Skip("adapter supports every probe feature")
+	}
+
+	_, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{RequiredFeatures: unsupported})
+	if !errors.Is(err, wgpu.ErrFeatureNotSupported) {
+		t.Fatalf("RequestDevice(required unsupported) err = %v, want ErrFeatureNotSupported", err)
+	}
+
+	device, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{OptionalFeatures: unsupported})
+	if err != nil {
+		t.Fatalf("RequestDevice(optional unsupported): %v", err)
+	}
+	defer device.Release()
+	if device.Features()&unsupported != 0 {
+		t.Errorf("unsupported optional feature was enabled: %v", device.Features())
+	}
+}
+
+func TestRequestDeviceLimitExceedsAdapter(t *testing.T) {
+	_, adapter := newAdapter(t)
+	defer adapter.Release()
+
+	limits := wgpu.Limits{MaxBufferSize: adapter.Limits().MaxBufferSize + 1}
+	if limits.MaxBufferSize == 0 {
+		t.Skip("adapter reports maximal MaxBufferSize")
+	}
+	_, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{RequiredLimits: limits})
+	if !errors.Is(err, wgpu.ErrLimitNotSupported) {
+		t.Fatalf("err = %v, want ErrLimitNotSupported", err)
+	}
+}
+
 func TestDeviceQueue(t *testing.T) {
 	_, _, device := newDevice(t)
 	defer device.Release()
@@ -352,6 +437,145 @@ func TestDeviceCreateTexture(t *testing.T) {
 	}
 }
 
+func TestAdapterPreferredDepthStencilFormat(t *testing.T) {
+	_, adapter, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	for _, req := range []wgpu.DepthStencilRequirements{
+		{},
+		{Stencil: true},
+		{MinDepthBits: 32, Usage: wgpu.TextureUsageTextureBinding},
+	} {
+		format := adapter.GetPreferredDepthStencilFormat(req)
+		if format == wgpu.TextureFormatUndefined {
+			t.Errorf("%+v: no depth format", req)
+			continue
+		}
+		if req.Stencil && !format.HasStencil() {
+			t.Errorf("%+v: %v has no stencil aspect", req, format)
+		}
+
+		tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+			Label:         "depth",
+			Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+			MipLevelCount: 1,
+			SampleCount:   1,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        format,
+			Usage:         wgpu.TextureUsageRenderAttachment | req.Usage,
+		})
+		if err != nil {
+			t.Errorf("%+v: CreateTexture(%v): %v", req, format, err)
+			continue
+		}
+		if tex.Format() != format {
+			t.Errorf("%+v: preferred %v fell back to %v", req, format, tex.Format())
+		}
+		tex.Release()
+	}
+}
+
+func TestAdapterSupportedSampleCounts(t *testing.T) {
+	_, adapter, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	format := wgpu.TextureFormatRGBA8Unorm
+	counts := adapter.SupportedSampleCounts(format)
+	if len(counts) == 0 || counts[0] != 1 {
+		t.Fatalf("SupportedSampleCounts = %v, want leading 1", counts)
+	}
+	supported := map[uint32]bool{}
+	for i, n := range counts {
+		if i > 0 && n <= counts[i-1] {
+			t.Fatalf("SupportedSampleCounts = %v, want ascending", counts)
+		}
+		supported[n] = true
+	}
+
+	for _, n := range []uint32{2, 4, 8, 16} {
+		tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+			Label:         "msaa",
+			Size:          wgpu.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1},
+			MipLevelCount: 1,
+			SampleCount:   n,
+			Dimension:     wgpu.TextureDimension2D,
+			Format:        format,
+			Usage:         wgpu.TextureUsageRenderAttachment,
+		})
+		if supported[n] {
+			if err != nil {
+				t.Errorf("%dx: CreateTexture: %v", n, err)
+				continue
+			}
+			tex.Release()
+			continue
+		}
+		if err == nil {
+			tex.Release()
+			t.Errorf("%dx: CreateTexture succeeded for unsupported sample count", n)
+		}
+	}
+}
+
+func TestDeviceCreateTransientTexture(t *testing.T) {
+	_, _, device := newDevice(t)
+	defer device.Release()
+	requireHAL(t, device)
+
+	desc := wgpu.TextureDescriptor{
+		Label:         "transient-depth",
+		Size:          wgpu.Extent3D{Width: 64, Height: 64, DepthOrArrayLayers: 1},
+		MipLevelCount: 1,
+		SampleCount:   1,
+		Dimension:     wgpu.TextureDimension2D,
+		Format:        wgpu.TextureFormatDepth32Float,
+		Usage:         wgpu.TextureUsageRenderAttachment,
+		Transient:     true,
+	}
+	tex, err := device.CreateTexture(&desc)
+	if err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	defer tex.Release()
+	view, err := device.CreateTextureView(tex, nil)
+	if err != nil {
+		t.Fatalf("CreateTextureView: %v", err)
+	}
+	defer view.Release()
+
+	for _, ops := range []struct {
+		load  gputypes.LoadOp
+		store gputypes.StoreOp
+	}{
+		{gputypes.LoadOpLoad, gputypes.StoreOpDiscard},
+		{gputypes.LoadOpClear, gputypes.StoreOpStore},
+	} {
+		encoder, err := device.CreateCommandEncoder(nil)
+		if err != nil {
+			t.Fatalf("CreateCommandEncoder: %v", err)
+		}
+		_, err = encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+			DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+				View:         view,
+				DepthLoadOp:  ops.load,
+				DepthStoreOp: ops.store,
+			},
+		})
+		if err == nil {
+			t.Errorf("BeginRenderPass(load=%v, store=%v) on transient attachment should fail", ops.load, ops.store)
+		}
+		encoder.DiscardEncoding()
+	}
+
+	requireValidation(t)
+	desc.Usage |= wgpu.TextureUsageTextureBinding
+	if _, err := device.CreateTexture(&desc); err == nil {
+		t.Error("CreateTexture with transient sampled texture should fail")
+	}
+}
+
 func TestDeviceCreateTextureNilDesc(t *testing.T) {
 	_, _, device := newDevice(t)
 	defer device.Release()
@@ -1612,7 +1836,7 @@ func TestRenderPassSetVertexBufferNilDeferredError(t *testing.T) {
 	device, encoder, pass := newEncoderWithRenderPass(t)
 	defer device.Release()
 
-	pass.SetVertexBuffer(0, nil, 0) // should record deferred error
+	pass.SetVertexBuffer(0, nil, 0, wgpu.WholeSize) // should record deferred error
 	_ = pass.End()
 
 	_, err := encoder.Finish()
@@ -1625,7 +1849,7 @@ func TestRenderPassSetIndexBufferNilDeferredError(t *testing.T) {
 	device, encoder, pass := newEncoderWithRenderPass(t)
 	defer device.Release()
 
-	pass.SetIndexBuffer(nil, 0, 0) // should record deferred error (format doesn't matter for nil buffer)
+	pass.SetIndexBuffer(nil, 0, 0, wgpu.WholeSize) // should record deferred error (format doesn't matter for nil buffer)
 	_ = pass.End()
 
 	_, err := encoder.Finish()
@@ -1634,6 +1858,69 @@ func TestRenderPassSetIndexBufferNilDeferredError(t *testing.T) {
 	}
 }
 
+func TestRenderPassBufferRangeDeferredError(t *testing.T) {
+	tests := []struct {
+		name string
+		bind func(pass *wgpu.RenderPassEncoder, buf *wgpu.Buffer)
+	}{
+		{"vertex past end", func(pass *wgpu.RenderPassEncoder, buf *wgpu.Buffer) {
+			pass.SetVertexBuffer(0, buf, 32, 64)
+		}},
+		{"vertex offset past end", func(pass *wgpu.RenderPassEncoder, buf *wgpu.Buffer) {
+			pass.SetVertexBuffer(0, buf, 68, wgpu.WholeSize)
+		}},
+		{"vertex misaligned", func(pass *wgpu.RenderPassEncoder, buf *wgpu.Buffer) {
+			pass.SetVertexBuffer(0, buf, 2, 16)
+		}},
+		{"index misaligned", func(pass *wgpu.RenderPassEncoder, buf *wgpu.Buffer) {
+			pass.SetIndexBuffer(buf, gputypes.IndexFormatUint32, 2, wgpu.WholeSize)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, encoder, pass := newEncoderWithRenderPass(t)
+			defer device.Release()
+			buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+				Size:  64,
+				Usage: wgpu.BufferUsageVertex | wgpu.BufferUsageIndex,
+			})
+			if err != nil {
+				t.Fatalf("CreateBuffer: %v", err)
+			}
+			defer buf.Release()
+
+			tt.bind(pass, buf)
+			_ = pass.End()
+
+			if _, err := encoder.Finish(); !errors.Is(err, wgpu.ErrBufferRange) {
+				t.Fatalf("Finish err = %v, want ErrBufferRange", err)
+			}
+		})
+	}
+}
+
+func TestRenderPassBufferRangeInBounds(t *testing.T) {
+	device, encoder, pass := newEncoderWithRenderPass(t)
+	defer device.Release()
+	buf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
+		Size:  64,
+		Usage: wgpu.BufferUsageVertex | wgpu.BufferUsageIndex,
+	})
+	if err != nil {
+		t.Fatalf("CreateBuffer: %v", err)
+	}
+	defer buf.Release()
+
+	pass.SetVertexBuffer(0, buf, 16, 48)
+	pass.SetVertexBuffer(1, buf, 64, wgpu.WholeSize)
+	pass.SetIndexBuffer(buf, gputypes.IndexFormatUint16, 2, 30)
+	_ = pass.End()
+
+	if _, err := encoder.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+}
+
 func TestRenderPassDrawIndirectNilDeferredError(t *testing.T) {
 	device, encoder, pass := newEncoderWithRenderPass(t)
 	defer device.Release()
@@ -1740,7 +2027,7 @@ func TestRenderPassDrawIndexedIndirectCountRangeValidation(t *testing.T) {
 				t.Fatalf("CreateBuffer(index): %v", err)
 			}
 			defer idxBuf.Release()
-			pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+			pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 
 			indirectBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 				Label: "count-indirect-buffer",
@@ -1782,7 +2069,7 @@ func TestRenderPassDrawIndexedIndirectCountMany(t *testing.T) {
 		t.Fatalf("CreateBuffer(index): %v", err)
 	}
 	defer idxBuf.Release()
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 
 	indirectBuf, err := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "count-indirect-buffer",
@@ -2183,7 +2470,7 @@ func TestRenderPassDrawWithInsufficientVertexBuffers(t *testing.T) {
 	}
 	defer buf.Release()
 
-	pass.SetVertexBuffer(0, buf, 0)
+	pass.SetVertexBuffer(0, buf, 0, wgpu.WholeSize)
 	pass.Draw(3, 1, 0, 0) // should fail: need 2, have 1
 	_ = pass.End()
 
@@ -2212,7 +2499,7 @@ func TestRenderPassDrawWithSufficientVertexBuffers(t *testing.T) {
 	}
 	defer buf.Release()
 
-	pass.SetVertexBuffer(0, buf, 0)
+	pass.SetVertexBuffer(0, buf, 0, wgpu.WholeSize)
 	pass.Draw(3, 1, 0, 0) // should pass vertex buffer check
 	_ = pass.End()
 
@@ -2302,7 +2589,7 @@ func TestRenderPassDrawIndexedWithIndexBuffer(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, 0, 0)
+	pass.SetIndexBuffer(idxBuf, 0, 0, wgpu.WholeSize)
 	pass.DrawIndexed(3, 1, 0, 0, 0) // index buffer is set
 	_ = pass.End()
 
@@ -2389,6 +2676,7 @@ func TestComputePassDispatchZeroWorkgroupsAllowed(t *testing.T) {
 // =============================================================================
 
 func TestCreateComputePipelineWorkgroupSizeTooLarge(t *testing.T) {
+	requireValidation(t)
 	_, _, device := newDevice(t)
 	defer device.Release()
 	requireHAL(t, device)
@@ -2415,6 +2703,7 @@ func TestCreateComputePipelineWorkgroupSizeTooLarge(t *testing.T) {
 }
 
 func TestCreateComputePipelineTotalInvocationsExceeded(t *testing.T) {
+	requireValidation(t)
 	_, _, device := newDevice(t)
 	defer device.Release()
 	requireHAL(t, device)
@@ -2564,7 +2853,7 @@ func TestDrawMissingVertexBufferSentinel(t *testing.T) {
 		t.Fatalf("CreateBuffer: %v", bufErr)
 	}
 	defer buf.Release()
-	pass.SetVertexBuffer(0, buf, 0)
+	pass.SetVertexBuffer(0, buf, 0, wgpu.WholeSize)
 
 	pass.Draw(3, 1, 0, 0)
 	_ = pass.End()
@@ -2803,7 +3092,7 @@ func TestDrawIndexedFormatMismatchSentinel(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 	pass.DrawIndexed(3, 1, 0, 0, 0) // format mismatch: buffer=Uint16, pipeline=Uint32
 	_ = pass.End()
 
@@ -2837,7 +3126,7 @@ func TestDrawIndexedFormatMatchesStripFormat(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0) // matches pipeline
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize) // matches pipeline
 	pass.DrawIndexed(3, 1, 0, 0, 0)
 	_ = pass.End()
 
@@ -2868,7 +3157,7 @@ func TestDrawIndexedNoStripFormatSkipsCheck(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 	pass.DrawIndexed(3, 1, 0, 0, 0) // no strip format → no format check
 	_ = pass.End()
 
@@ -2900,7 +3189,7 @@ func TestDrawIndexedIndirectFormatMismatchSentinel(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint32, 0) // mismatch: buffer=Uint32, pipeline=Uint16
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint32, 0, wgpu.WholeSize) // mismatch: buffer=Uint32, pipeline=Uint16
 
 	indirectBuf, bufErr := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "indirect-buf",
@@ -3038,7 +3327,7 @@ func TestDrawIndexedIndirectBufferMissingUsageSentinel(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 
 	// Buffer WITHOUT Indirect usage.
 	indBuf, bufErr := device.CreateBuffer(&wgpu.BufferDescriptor{
@@ -3081,7 +3370,7 @@ func TestDrawIndexedIndirectOffsetUnalignedSentinel(t *testing.T) {
 	}
 	defer idxBuf.Release()
 
-	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0)
+	pass.SetIndexBuffer(idxBuf, gputypes.IndexFormatUint16, 0, wgpu.WholeSize)
 
 	indBuf, bufErr := device.CreateBuffer(&wgpu.BufferDescriptor{
 		Label: "indirect-buf",
@@ -57,6 +57,9 @@ func NewDeviceFromHAL(
 		cmdEncoderPool: pool,
 	}
 	queue.device = device
+	if queue.pending != nil {
+		queue.pending.counters = &device.frame
+	}
 
 	return device, nil
 }